@@ -54,6 +54,15 @@ const (
 	NotRegisteredID = int64(-1)
 )
 
+// keys of the extra info in component states of QueryNode
+const (
+	// ReadQueueLengthKey is the number of search and query requests being served by a QueryNode
+	ReadQueueLengthKey = "read_queue_length"
+
+	// ReadQueueWaitMsKey is the estimated wait time in milliseconds of a new search or query request
+	ReadQueueWaitMsKey = "read_queue_wait_ms"
+)
+
 // Endian is type alias of binary.LittleEndian.
 // Milvus uses little endian by default.
 var Endian = binary.LittleEndian
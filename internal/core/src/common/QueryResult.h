@@ -78,6 +78,8 @@ struct SearchResult {
     aligned_vector<char> ids_data_;
     std::vector<aligned_vector<char>> output_fields_data_;
     std::vector<FieldMeta> output_fields_meta_;
+    // insert timestamps of hits, only filled if requested by plan
+    std::vector<Timestamp> timestamps_;
 };
 
 using SearchResultPtr = std::shared_ptr<SearchResult>;
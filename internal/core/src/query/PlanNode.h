@@ -40,12 +40,18 @@ struct SearchInfo {
     FieldOffset field_offset_;
     MetricType metric_type_;
     nlohmann::json search_params_;
+    // output the insert timestamps of hits
+    bool output_timestamps_ = false;
+    // search by brute force instead of the vector index if the raw data is in memory
+    bool brute_force_ = false;
 };
 
 struct VectorPlanNode : PlanNode {
     std::optional<ExprPtr> predicate_;
     SearchInfo search_info_;
     std::string placeholder_tag_;
+    // rows inserted before expire_ts_ are invisible, 0 means rows never expire
+    Timestamp expire_ts_ = 0;
 };
 
 struct FloatVectorANNS : VectorPlanNode {
@@ -66,6 +72,8 @@ struct RetrievePlanNode : PlanNode {
     accept(PlanNodeVisitor&) override;
 
     ExprPtr predicate_;
+    // rows inserted before expire_ts_ are invisible, 0 means rows never expire
+    Timestamp expire_ts_ = 0;
 };
 
 }  // namespace milvus::query
@@ -48,7 +48,8 @@ FloatSearch(const segcore::SegmentGrowingImpl& segment,
 
     int current_chunk_id = 0;
 
-    if (indexing_record.is_in(vecfield_offset)) {
+    // small indexing is built with the metric type of field, brute force all chunks if search with another one
+    if (indexing_record.is_in(vecfield_offset) && field.get_metric_type() == metric_type) {
         auto max_indexed_id = indexing_record.get_finished_ack();
         const auto& field_indexing = indexing_record.get_vec_field_indexing(vecfield_offset);
         auto search_conf = field_indexing.get_search_params(topk);
@@ -39,7 +39,11 @@ SearchOnSealed(const Schema& schema,
 
     AssertInfo(record.is_ready(field_offset), "[SearchOnSealed]Record isn't ready");
     auto field_indexing = record.get_field_indexing(field_offset);
-    AssertInfo(field_indexing->metric_type_ == search_info.metric_type_,
+    auto index_type = field_indexing->indexing_->index_type();
+    // flat indexes could be searched with any metric type, others only with the built one
+    auto is_flat = index_type == knowhere::IndexEnum::INDEX_FAISS_IDMAP ||
+                   index_type == knowhere::IndexEnum::INDEX_FAISS_BIN_IDMAP;
+    AssertInfo(is_flat || field_indexing->metric_type_ == search_info.metric_type_,
                "Metric type of field index isn't the same with search info");
 
     auto final = [&] {
@@ -47,8 +51,7 @@ SearchOnSealed(const Schema& schema,
 
         auto conf = search_info.search_params_;
         conf[knowhere::meta::TOPK] = search_info.topk_;
-        conf[knowhere::Metric::TYPE] = MetricTypeToName(field_indexing->metric_type_);
-        auto index_type = field_indexing->indexing_->index_type();
+        conf[knowhere::Metric::TYPE] = MetricTypeToName(search_info.metric_type_);
         auto adapter = knowhere::AdapterMgr::GetInstance().GetAdapter(index_type);
         AssertInfo(adapter->CheckSearch(conf, index_type, field_indexing->indexing_->index_mode()),
                    "[SearchOnSealed]Search params check failed");
@@ -37,6 +37,19 @@ class ExecPlanNodeVisitor : public PlanNodeVisitor {
         : segment_(segment), timestamp_(timestamp), placeholder_group_(placeholder_group) {
     }
 
+    // only the rows set in candidates are searched, the predicate of the plan is not evaluated
+    ExecPlanNodeVisitor(const segcore::SegmentInterface& segment,
+                        Timestamp timestamp,
+                        const PlaceholderGroup& placeholder_group,
+                        const BitsetType* candidates,
+                        bool brute_force)
+        : segment_(segment),
+          timestamp_(timestamp),
+          placeholder_group_(placeholder_group),
+          candidates_(candidates),
+          brute_force_(brute_force) {
+    }
+
     ExecPlanNodeVisitor(const segcore::SegmentInterface& segment, Timestamp timestamp)
         : segment_(segment), timestamp_(timestamp) {
     }
@@ -73,6 +86,8 @@ class ExecPlanNodeVisitor : public PlanNodeVisitor {
     const segcore::SegmentInterface& segment_;
     Timestamp timestamp_;
     PlaceholderGroup placeholder_group_;
+    const BitsetType* candidates_ = nullptr;
+    bool brute_force_ = false;
 
     SearchResultOpt search_result_opt_;
     RetrieveResultOpt retrieve_result_opt_;
@@ -31,6 +31,19 @@ class ExecPlanNodeVisitor : PlanNodeVisitor {
         : segment_(segment), timestamp_(timestamp), placeholder_group_(placeholder_group) {
     }
 
+    // only the rows set in candidates are searched, the predicate of the plan is not evaluated
+    ExecPlanNodeVisitor(const segcore::SegmentInterface& segment,
+                        Timestamp timestamp,
+                        const PlaceholderGroup& placeholder_group,
+                        const BitsetType* candidates,
+                        bool brute_force)
+        : segment_(segment),
+          timestamp_(timestamp),
+          placeholder_group_(placeholder_group),
+          candidates_(candidates),
+          brute_force_(brute_force) {
+    }
+
     SearchResult
     get_moved_result(PlanNode& node) {
         assert(!search_result_opt_.has_value());
@@ -50,6 +63,8 @@ class ExecPlanNodeVisitor : PlanNodeVisitor {
     const segcore::SegmentInterface& segment_;
     Timestamp timestamp_;
     const PlaceholderGroup& placeholder_group_;
+    const BitsetType* candidates_ = nullptr;
+    bool brute_force_ = false;
 
     SearchResultOpt search_result_opt_;
 };
@@ -90,7 +105,11 @@ ExecPlanNodeVisitor::VectorVisitorImpl(VectorPlanNode& node) {
     }
 
     BitsetType bitset_holder;
-    if (node.predicate_.has_value()) {
+    if (candidates_ != nullptr) {
+        // the predicate is evaluated by the caller, the rows beyond candidates are inserted later
+        bitset_holder = *candidates_;
+        bitset_holder.resize(active_count, false);
+    } else if (node.predicate_.has_value()) {
         bitset_holder = ExecExprVisitor(*segment, active_count, timestamp_).call_child(*node.predicate_.value());
     } else {
         bitset_holder.resize(active_count, true);
@@ -100,9 +119,13 @@ ExecPlanNodeVisitor::VectorVisitorImpl(VectorPlanNode& node) {
     bitset_holder.flip();
 
     segment->mask_with_delete(bitset_holder, active_count, timestamp_);
+    if (node.expire_ts_ > 0) {
+        segment->mask_with_expiration(bitset_holder, active_count, node.expire_ts_);
+    }
     BitsetView final_view = bitset_holder;
-    segment->vector_search(active_count, node.search_info_, src_data, num_queries, timestamp_, final_view,
-                           search_result);
+    auto search_info = node.search_info_;
+    search_info.brute_force_ = brute_force_;
+    segment->vector_search(active_count, search_info, src_data, num_queries, timestamp_, final_view, search_result);
 
     search_result_opt_ = std::move(search_result);
 }
@@ -130,6 +153,9 @@ ExecPlanNodeVisitor::visit(RetrievePlanNode& node) {
     bitset_holder.flip();
 
     segment->mask_with_delete(bitset_holder, active_count, timestamp_);
+    if (node.expire_ts_ > 0) {
+        segment->mask_with_expiration(bitset_holder, active_count, node.expire_ts_);
+    }
     BitsetView final_view = bitset_holder;
     auto seg_offsets = segment->search_ids(final_view, timestamp_);
     retrieve_result.result_offsets_.assign((int64_t*)seg_offsets.data(),
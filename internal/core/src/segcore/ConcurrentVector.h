@@ -87,6 +87,9 @@ class VectorBase {
     virtual SpanBase
     get_span_base(int64_t chunk_id) const = 0;
 
+    virtual ssize_t
+    num_chunk() const = 0;
+
     int64_t
     get_size_per_chunk() const {
         return size_per_chunk_;
@@ -207,7 +210,7 @@ class ConcurrentVectorImpl : public VectorBase {
     }
 
     ssize_t
-    num_chunk() const {
+    num_chunk() const override {
         return chunks_.size();
     }
 
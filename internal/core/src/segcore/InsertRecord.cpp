@@ -9,6 +9,8 @@
 // is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
 // or implied. See the License for the specific language governing permissions and limitations under the License
 
+#include <algorithm>
+
 #include "InsertRecord.h"
 
 namespace milvus::segcore {
@@ -63,4 +65,24 @@ InsertRecord::InsertRecord(const Schema& schema, int64_t size_per_chunk)
     }
 }
 
+void
+InsertRecord::reserve_vector_chunks(const Schema& schema, int64_t row_count) {
+    int64_t field_index = 0;
+    for (auto& field : schema) {
+        if (field.is_vector()) {
+            fields_data_[field_index]->grow_to_at_least(row_count);
+        }
+        ++field_index;
+    }
+}
+
+int64_t
+InsertRecord::num_allocated_chunk() const {
+    int64_t num_chunk = std::max(uids_.num_chunk(), timestamps_.num_chunk());
+    for (auto& field_data : fields_data_) {
+        num_chunk = std::max(num_chunk, (int64_t)field_data->num_chunk());
+    }
+    return num_chunk;
+}
+
 }  // namespace milvus::segcore
@@ -56,6 +56,14 @@ struct InsertRecord {
         return ptr;
     }
 
+    // pre-allocate the chunks of vector fields to hold row_count rows
+    void
+    reserve_vector_chunks(const Schema& schema, int64_t row_count);
+
+    // the max number of chunks allocated by any field
+    int64_t
+    num_allocated_chunk() const;
+
     // append a column of scalar type
     template <typename Type>
     void
@@ -43,6 +43,17 @@ class SegmentGrowing : public SegmentInternalInterface {
     virtual int64_t
     PreInsert(int64_t size) = 0;
 
+    // pre-allocate the vector chunks for the expected row count of this segment
+    virtual void
+    Reserve(int64_t row_count) = 0;
+
+    virtual int64_t
+    get_allocated_chunk_num() const = 0;
+
+    // rebuild the segment with the rows not deleted before the horizon, the deletes after the horizon are kept
+    virtual std::unique_ptr<SegmentGrowing>
+    Compact(Timestamp horizon, int64_t* dead_rows) const = 0;
+
     virtual Status
     Insert(int64_t reserved_offset,
            int64_t size,
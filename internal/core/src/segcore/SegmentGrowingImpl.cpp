@@ -32,6 +32,69 @@ SegmentGrowingImpl::PreInsert(int64_t size) {
     return reserved_begin;
 }
 
+void
+SegmentGrowingImpl::Reserve(int64_t row_count) {
+    if (row_count <= 0) {
+        return;
+    }
+    record_.reserve_vector_chunks(*schema_, row_count);
+}
+
+int64_t
+SegmentGrowingImpl::get_allocated_chunk_num() const {
+    return record_.num_allocated_chunk();
+}
+
+std::unique_ptr<SegmentGrowing>
+SegmentGrowingImpl::Compact(Timestamp horizon, int64_t* dead_rows) const {
+    auto ins_barrier = get_row_count();
+    BitsetType deleted(ins_barrier);
+    mask_with_delete(deleted, ins_barrier, horizon);
+
+    std::vector<int64_t> live_offsets;
+    live_offsets.reserve(ins_barrier - deleted.count());
+    for (int64_t i = 0; i < ins_barrier; ++i) {
+        if (!deleted[i]) {
+            live_offsets.push_back(i);
+        }
+    }
+    auto live_rows = static_cast<int64_t>(live_offsets.size());
+
+    auto compacted = std::make_unique<SegmentGrowingImpl>(schema_, segcore_config_, id_);
+    compacted->enable_small_index_ = enable_small_index_;
+    if (live_rows > 0) {
+        std::vector<idx_t> row_ids(live_rows);
+        std::vector<Timestamp> timestamps(live_rows);
+        bulk_subscript(SystemFieldType::RowId, live_offsets.data(), live_rows, row_ids.data());
+        bulk_subscript(SystemFieldType::Timestamp, live_offsets.data(), live_rows, timestamps.data());
+        std::vector<aligned_vector<uint8_t>> columns_data(schema_->size());
+        for (int fid = 0; fid < schema_->size(); ++fid) {
+            auto field_offset = FieldOffset(fid);
+            columns_data[fid].resize(schema_->operator[](field_offset).get_sizeof() * live_rows);
+            bulk_subscript(field_offset, live_offsets.data(), live_rows, columns_data[fid].data());
+        }
+        auto reserved_begin = compacted->PreInsert(live_rows);
+        compacted->do_insert(reserved_begin, live_rows, row_ids.data(), timestamps.data(), columns_data);
+    }
+
+    // the deletes at or after the horizon may still hit the rows for the reads after it, migrate them as they are
+    auto del_barrier = get_barrier(get_deleted_record(), horizon);
+    auto del_tail = deleted_record_.ack_responder_.GetAck() - del_barrier;
+    if (del_tail > 0) {
+        std::vector<idx_t> uids(del_tail);
+        std::vector<Timestamp> timestamps(del_tail);
+        for (int64_t i = 0; i < del_tail; ++i) {
+            uids[i] = deleted_record_.uids_[del_barrier + i];
+            timestamps[i] = deleted_record_.timestamps_[del_barrier + i];
+        }
+        auto reserved_begin = compacted->PreDelete(del_tail);
+        compacted->Delete(reserved_begin, del_tail, uids.data(), timestamps.data());
+    }
+
+    *dead_rows = ins_barrier - live_rows;
+    return compacted;
+}
+
 int64_t
 SegmentGrowingImpl::PreDelete(int64_t size) {
     auto reserved_begin = deleted_record_.reserved.fetch_add(size);
@@ -105,6 +168,17 @@ SegmentGrowingImpl::mask_with_delete(BitsetType& bitset, int64_t ins_barrier, Ti
     bitset |= delete_bitset;
 }
 
+void
+SegmentGrowingImpl::mask_with_expiration(BitsetType& bitset, int64_t ins_barrier, Timestamp expire_ts) const {
+    AssertInfo(ins_barrier <= bitset.size(), "Insert barrier exceeds filtered bitmap size");
+    auto& ts_vec = get_insert_record().timestamps_;
+    for (int64_t i = 0; i < ins_barrier; ++i) {
+        if (ts_vec[i] < expire_ts) {
+            bitset[i] = true;
+        }
+    }
+}
+
 Status
 SegmentGrowingImpl::Insert(int64_t reserved_begin,
                            int64_t size,
@@ -362,7 +436,8 @@ SegmentGrowingImpl::bulk_subscript(SystemFieldType system_type,
                                    void* output) const {
     switch (system_type) {
         case SystemFieldType::Timestamp:
-            PanicInfo("timestamp unsupported");
+            bulk_subscript_impl<Timestamp>(this->record_.timestamps_, seg_offsets, count, 0, output);
+            break;
         case SystemFieldType::RowId:
             bulk_subscript_impl<int64_t>(this->record_.uids_, seg_offsets, count, INVALID_ID, output);
             break;
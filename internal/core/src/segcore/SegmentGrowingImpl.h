@@ -42,6 +42,15 @@ class SegmentGrowingImpl : public SegmentGrowing {
     int64_t
     PreInsert(int64_t size) override;
 
+    void
+    Reserve(int64_t row_count) override;
+
+    int64_t
+    get_allocated_chunk_num() const override;
+
+    std::unique_ptr<SegmentGrowing>
+    Compact(Timestamp horizon, int64_t* dead_rows) const override;
+
     Status
     Insert(int64_t reserved_offset,
            int64_t size,
@@ -180,6 +189,9 @@ class SegmentGrowingImpl : public SegmentGrowing {
     void
     mask_with_delete(BitsetType& bitset, int64_t ins_barrier, Timestamp timestamp) const override;
 
+    void
+    mask_with_expiration(BitsetType& bitset, int64_t ins_barrier, Timestamp expire_ts) const override;
+
     std::pair<std::unique_ptr<IdArray>, std::vector<SegOffset>>
     search_ids(const IdArray& id_array, Timestamp timestamp) const override;
 
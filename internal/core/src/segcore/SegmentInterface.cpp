@@ -9,6 +9,8 @@
 // is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
 // or implied. See the License for the specific language governing permissions and limitations under the License
 
+#include <algorithm>
+
 #include "SegmentInterface.h"
 #include "query/generated/ExecPlanNodeVisitor.h"
 
@@ -63,6 +65,12 @@ SegmentInternalInterface::FillTargetEntry(const query::Plan* plan, SearchResult&
         }
     }
 
+    // fill insert timestamps, 0 for invalid hits
+    if (plan->plan_node_->search_info_.output_timestamps_) {
+        results.timestamps_.resize(size);
+        bulk_subscript(SystemFieldType::Timestamp, results.ids_.data(), size, results.timestamps_.data());
+    }
+
     // fill other entries except primary key by result_offset
     for (auto field_offset : plan->target_entries_) {
         auto& field_meta = get_schema()[field_offset];
@@ -92,6 +100,21 @@ SegmentInternalInterface::Search(const query::Plan* plan,
     return results;
 }
 
+std::unique_ptr<SearchResult>
+SegmentInternalInterface::SearchWithCandidates(const query::Plan* plan,
+                                               const query::PlaceholderGroup& placeholder_group,
+                                               Timestamp timestamp,
+                                               const BitsetType& candidates,
+                                               bool brute_force) const {
+    std::shared_lock lck(mutex_);
+    check_search(plan);
+    query::ExecPlanNodeVisitor visitor(*this, timestamp, placeholder_group, &candidates, brute_force);
+    auto results = std::make_unique<SearchResult>();
+    *results = visitor.get_moved_result(*plan->plan_node_);
+    results->segment_ = (void*)this;
+    return results;
+}
+
 // Note: this is temporary solution.
 // modify bulk script implement to make process more clear
 static std::unique_ptr<ScalarArray>
@@ -201,7 +224,9 @@ SegmentInternalInterface::BulkSubScript(FieldOffset field_offset, const SegOffse
 }
 
 std::unique_ptr<proto::segcore::RetrieveResults>
-SegmentInternalInterface::Retrieve(const query::RetrievePlan* plan, Timestamp timestamp) const {
+SegmentInternalInterface::Retrieve(const query::RetrievePlan* plan,
+                                   Timestamp timestamp,
+                                   const std::vector<FieldId>& offsets_only_fields) const {
     std::shared_lock lck(mutex_);
     auto results = std::make_unique<proto::segcore::RetrieveResults>();
     query::ExecPlanNodeVisitor visitor(*this, timestamp);
@@ -214,8 +239,16 @@ SegmentInternalInterface::Retrieve(const query::RetrievePlan* plan, Timestamp ti
     auto ids = results->mutable_ids();
     auto pk_offset = plan->schema_.get_primary_key_offset();
     for (auto field_offset : plan->field_offsets_) {
-        auto col = BulkSubScript(field_offset, (SegOffset*)retrieve_results.result_offsets_.data(),
-                                 retrieve_results.result_offsets_.size());
+        std::unique_ptr<DataArray> col;
+        if (field_offset.get() >= 0 &&
+            std::find(offsets_only_fields.begin(), offsets_only_fields.end(),
+                      get_schema()[field_offset].get_id()) != offsets_only_fields.end()) {
+            // skip materializing the field, only its type and dim are returned
+            col = CreateDataArrayFrom(nullptr, 0, get_schema()[field_offset]);
+        } else {
+            col = BulkSubScript(field_offset, (SegOffset*)retrieve_results.result_offsets_.data(),
+                                retrieve_results.result_offsets_.size());
+        }
         auto col_data = col.release();
         fields_data->AddAllocated(col_data);
         if (pk_offset.has_value() && pk_offset.value() == field_offset) {
@@ -226,4 +259,21 @@ SegmentInternalInterface::Retrieve(const query::RetrievePlan* plan, Timestamp ti
     }
     return results;
 }
+
+std::unique_ptr<proto::segcore::RetrieveResults>
+SegmentInternalInterface::RetrieveByOffsets(FieldId field_id, const int64_t* seg_offsets, int64_t count) const {
+    // the row count of sealed segment takes the lock itself
+    auto row_count = get_row_count();
+    std::shared_lock lck(mutex_);
+    for (int64_t i = 0; i < count; ++i) {
+        AssertInfo(seg_offsets[i] >= 0 && seg_offsets[i] < row_count,
+                   "offset " + std::to_string(seg_offsets[i]) + " out of range " + std::to_string(row_count));
+    }
+    auto field_offset = get_schema().get_offset(field_id);
+    auto results = std::make_unique<proto::segcore::RetrieveResults>();
+    results->mutable_offset()->Add(seg_offsets, seg_offsets + count);
+    auto col = BulkSubScript(field_offset, (const SegOffset*)seg_offsets, count);
+    results->mutable_fields_data()->AddAllocated(col.release());
+    return results;
+}
 }  // namespace milvus::segcore
@@ -48,8 +48,24 @@ class SegmentInterface {
     virtual std::unique_ptr<SearchResult>
     Search(const query::Plan* Plan, const query::PlaceholderGroup& placeholder_group, Timestamp timestamp) const = 0;
 
+    // only the rows set in candidates are searched, the predicate of plan is not evaluated,
+    // brute_force searches the candidates by brute force instead of the vector index if the raw data is in memory
+    virtual std::unique_ptr<SearchResult>
+    SearchWithCandidates(const query::Plan* Plan,
+                         const query::PlaceholderGroup& placeholder_group,
+                         Timestamp timestamp,
+                         const BitsetType& candidates,
+                         bool brute_force) const = 0;
+
+    // fields in offsets_only_fields are returned without data, the caller fills them by result offsets
     virtual std::unique_ptr<proto::segcore::RetrieveResults>
-    Retrieve(const query::RetrievePlan* Plan, Timestamp timestamp) const = 0;
+    Retrieve(const query::RetrievePlan* Plan,
+             Timestamp timestamp,
+             const std::vector<FieldId>& offsets_only_fields = {}) const = 0;
+
+    // returns the field_id column of the rows at seg_offsets, along with the offsets
+    virtual std::unique_ptr<proto::segcore::RetrieveResults>
+    RetrieveByOffsets(FieldId field_id, const int64_t* seg_offsets, int64_t count) const = 0;
 
     virtual int64_t
     GetMemoryUsageInBytes() const = 0;
@@ -93,6 +109,13 @@ class SegmentInternalInterface : public SegmentInterface {
            const query::PlaceholderGroup& placeholder_group,
            Timestamp timestamp) const override;
 
+    std::unique_ptr<SearchResult>
+    SearchWithCandidates(const query::Plan* Plan,
+                         const query::PlaceholderGroup& placeholder_group,
+                         Timestamp timestamp,
+                         const BitsetType& candidates,
+                         bool brute_force) const override;
+
     void
     FillPrimaryKeys(const query::Plan* plan, SearchResult& results) const override;
 
@@ -100,7 +123,12 @@ class SegmentInternalInterface : public SegmentInterface {
     FillTargetEntry(const query::Plan* plan, SearchResult& results) const override;
 
     std::unique_ptr<proto::segcore::RetrieveResults>
-    Retrieve(const query::RetrievePlan* plan, Timestamp timestamp) const override;
+    Retrieve(const query::RetrievePlan* plan,
+             Timestamp timestamp,
+             const std::vector<FieldId>& offsets_only_fields = {}) const override;
+
+    std::unique_ptr<proto::segcore::RetrieveResults>
+    RetrieveByOffsets(FieldId field_id, const int64_t* seg_offsets, int64_t count) const override;
 
     virtual std::string
     debug() const = 0;
@@ -118,6 +146,10 @@ class SegmentInternalInterface : public SegmentInterface {
     virtual void
     mask_with_delete(BitsetType& bitset, int64_t ins_barrier, Timestamp timestamp) const = 0;
 
+    // mask the rows inserted before expire_ts
+    virtual void
+    mask_with_expiration(BitsetType& bitset, int64_t ins_barrier, Timestamp expire_ts) const = 0;
+
     // count of chunk that has index available
     virtual int64_t
     num_chunk_index(FieldOffset field_offset) const = 0;
@@ -309,7 +309,9 @@ SegmentSealedImpl::vector_search(int64_t vec_count,
     auto& field_meta = schema_->operator[](field_offset);
 
     AssertInfo(field_meta.is_vector(), "The meta type of vector field is not vector type");
-    if (get_bit(vecindex_ready_bitset_, field_offset)) {
+    // searching a few candidates by brute force beats the vector index
+    auto brute_force = search_info.brute_force_ && get_bit(field_data_ready_bitset_, field_offset);
+    if (get_bit(vecindex_ready_bitset_, field_offset) && !brute_force) {
         AssertInfo(vecindexs_.is_ready(field_offset),
                    "vector indexes isn't ready for field " + std::to_string(field_offset.get()));
         query::SearchOnSealed(*schema_, vecindexs_, search_info, query_data, query_count, bitset, output, id_);
@@ -427,8 +429,21 @@ SegmentSealedImpl::bulk_subscript(SystemFieldType system_type,
                                   int64_t count,
                                   void* output) const {
     AssertInfo(is_system_field_ready(), "System field isn't ready when do bulk_insert");
-    AssertInfo(system_type == SystemFieldType::RowId, "System field type of id column is not RowId");
-    bulk_subscript_impl<int64_t>(row_ids_.data(), seg_offsets, count, output);
+    switch (system_type) {
+        case SystemFieldType::Timestamp: {
+            auto dst = reinterpret_cast<Timestamp*>(output);
+            for (int64_t i = 0; i < count; ++i) {
+                auto offset = seg_offsets[i];
+                dst[i] = (offset == INVALID_SEG_OFFSET ? 0 : timestamps_[offset]);
+            }
+            break;
+        }
+        case SystemFieldType::RowId:
+            bulk_subscript_impl<int64_t>(row_ids_.data(), seg_offsets, count, output);
+            break;
+        default:
+            PanicInfo("unknown subscript fields");
+    }
 }
 
 template <typename T>
@@ -605,6 +620,17 @@ SegmentSealedImpl::LoadSegmentMeta(const proto::segcore::LoadSegmentMeta& segmen
     PanicInfo("unimplemented");
 }
 
+void
+SegmentSealedImpl::mask_with_expiration(BitsetType& bitset, int64_t ins_barrier, Timestamp expire_ts) const {
+    AssertInfo(ins_barrier <= bitset.size(), "Insert barrier exceeds filtered bitmap size");
+    AssertInfo(ins_barrier <= this->timestamps_.size(), "Insert barrier exceeds timestamp size");
+    for (int64_t i = 0; i < ins_barrier; ++i) {
+        if (timestamps_[i] < expire_ts) {
+            bitset[i] = true;
+        }
+    }
+}
+
 int64_t
 SegmentSealedImpl::get_active_count(Timestamp ts) const {
     // TODO optimize here to reduce expr search range
@@ -143,6 +143,9 @@ class SegmentSealedImpl : public SegmentSealed {
     void
     mask_with_delete(BitsetType& bitset, int64_t ins_barrier, Timestamp timestamp) const override;
 
+    void
+    mask_with_expiration(BitsetType& bitset, int64_t ins_barrier, Timestamp expire_ts) const override;
+
     bool
     is_system_field_ready() const {
         return system_ready_count_ == 2;
@@ -116,6 +116,19 @@ GetMetricType(CSearchPlan plan) {
     return strdup(metric_str.c_str());
 }
 
+int64_t
+GetFieldID(CSearchPlan plan) {
+    auto search_plan = static_cast<milvus::query::Plan*>(plan);
+    auto field_offset = search_plan->plan_node_->search_info_.field_offset_;
+    return search_plan->schema_[field_offset].get_id().get();
+}
+
+void
+SetSearchPlanExpireTs(CSearchPlan c_plan, uint64_t expire_ts) {
+    auto plan = (milvus::query::Plan*)c_plan;
+    plan->plan_node_->expire_ts_ = expire_ts;
+}
+
 void
 DeleteSearchPlan(CSearchPlan cPlan) {
     auto plan = (milvus::query::Plan*)cPlan;
@@ -164,3 +177,9 @@ DeleteRetrievePlan(CRetrievePlan c_plan) {
     auto plan = (milvus::query::RetrievePlan*)c_plan;
     delete plan;
 }
+
+void
+SetRetrievePlanExpireTs(CRetrievePlan c_plan, uint64_t expire_ts) {
+    auto plan = (milvus::query::RetrievePlan*)c_plan;
+    plan->plan_node_->expire_ts_ = expire_ts;
+}
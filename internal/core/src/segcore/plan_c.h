@@ -45,6 +45,13 @@ GetTopK(CSearchPlan plan);
 const char*
 GetMetricType(CSearchPlan plan);
 
+int64_t
+GetFieldID(CSearchPlan plan);
+
+// rows inserted before expire_ts are invisible to the plan, 0 means rows never expire
+void
+SetSearchPlanExpireTs(CSearchPlan plan, uint64_t expire_ts);
+
 void
 DeleteSearchPlan(CSearchPlan plan);
 
@@ -60,6 +67,10 @@ CreateRetrievePlanByExpr(CCollection c_col,
 void
 DeleteRetrievePlan(CRetrievePlan plan);
 
+// rows inserted before expire_ts are invisible to the plan, 0 means rows never expire
+void
+SetRetrievePlanExpireTs(CRetrievePlan plan, uint64_t expire_ts);
+
 #ifdef __cplusplus
 }
 #endif
@@ -9,6 +9,7 @@
 // is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
 // or implied. See the License for the specific language governing permissions and limitations under the License
 
+#include <algorithm>
 #include <limits>
 #include <unordered_set>
 #include <vector>
@@ -132,6 +133,7 @@ ReorganizeSearchResults(std::vector<SearchResult*>& search_results,
                         int32_t topK,
                         milvus::aligned_vector<int64_t>& result_ids,
                         std::vector<float>& result_distances,
+                        std::vector<milvus::Timestamp>& result_timestamps,
                         std::vector<milvus::aligned_vector<char>>& result_output_fields_data) {
     auto num_segments = search_results.size();
     auto results_count = 0;
@@ -157,6 +159,10 @@ ReorganizeSearchResults(std::vector<SearchResult*>& search_results,
             memcpy(&result_ids[loc], &search_result->ids_data_[j * sizeof(int64_t)], sizeof(int64_t));
             // set result distances
             result_distances[loc] = search_result->distances_[j];
+            // set result timestamps
+            if (!result_timestamps.empty()) {
+                result_timestamps[loc] = search_result->timestamps_[j];
+            }
             // set result output fields data
             for (int k = 0; k < search_result->output_fields_meta_.size(); k++) {
                 auto ele_size = search_result->output_fields_meta_[k].get_sizeof();
@@ -176,6 +182,7 @@ ReorganizeSearchResults(std::vector<SearchResult*>& search_results,
 std::vector<char>
 GetSearchResultDataSlice(milvus::aligned_vector<int64_t>& result_ids,
                          std::vector<float>& result_distances,
+                         std::vector<milvus::Timestamp>& result_timestamps,
                          std::vector<milvus::aligned_vector<char>>& result_output_fields_data,
                          int32_t nq,
                          int32_t topK,
@@ -219,6 +226,12 @@ GetSearchResultDataSlice(milvus::aligned_vector<int64_t>& result_ids,
                    std::to_string(search_result_data->scores_size()) +
                    ", expected size = " + std::to_string(offset_end - offset_begin));
 
+    // set timestamps
+    if (!result_timestamps.empty()) {
+        *search_result_data->mutable_timestamps() = {result_timestamps.begin() + offset_begin,
+                                                     result_timestamps.begin() + offset_end};
+    }
+
     // set output fields
     for (int i = 0; i < result_output_fields_data.size(); i++) {
         auto& field_meta = output_fields_meta[i];
@@ -256,6 +269,11 @@ Marshal(CSearchResultDataBlobs* cSearchResultDataBlobs,
         auto result_ids = milvus::aligned_vector<int64_t>(nq * topK);
         auto result_distances = std::vector<float>(nq * topK);
 
+        // init result timestamps if requested, the segments without hits have no timestamps filled
+        auto output_timestamps = std::any_of(search_results.begin(), search_results.end(),
+                                             [](SearchResult* result) { return !result->timestamps_.empty(); });
+        auto result_timestamps = std::vector<milvus::Timestamp>(output_timestamps ? nq * topK : 0);
+
         // init result output fields data
         auto& output_fields_meta = search_results[0]->output_fields_meta_;
         auto num_output_fields = output_fields_meta.size();
@@ -266,7 +284,8 @@ Marshal(CSearchResultDataBlobs* cSearchResultDataBlobs,
         }
 
         // Reorganize search results, get result ids, distances and output fields data
-        ReorganizeSearchResults(search_results, nq, topK, result_ids, result_distances, result_output_fields_data);
+        ReorganizeSearchResults(search_results, nq, topK, result_ids, result_distances, result_timestamps,
+                                result_output_fields_data);
 
         // prefix sum, get slices offsets
         AssertInfo(num_slices > 0, "empty nq_slice_sizes is not allowed");
@@ -287,8 +306,9 @@ Marshal(CSearchResultDataBlobs* cSearchResultDataBlobs,
         search_result_data_blobs->blobs.resize(num_slices);
 #pragma omp parallel for
         for (int i = 0; i < num_slices; i++) {
-            auto proto = GetSearchResultDataSlice(result_ids, result_distances, result_output_fields_data, nq, topK,
-                                                  slice_offsets[i], slice_offsets[i + 1], output_fields_meta);
+            auto proto = GetSearchResultDataSlice(result_ids, result_distances, result_timestamps,
+                                                  result_output_fields_data, nq, topK, slice_offsets[i],
+                                                  slice_offsets[i + 1], output_fields_meta);
             search_result_data_blobs->blobs[i] = proto;
         }
 
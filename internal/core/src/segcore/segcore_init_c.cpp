@@ -9,12 +9,74 @@
 // is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
 // or implied. See the License for the specific language governing permissions and limitations under the License
 
+#include <string>
+#include <vector>
+
 #include "config/ConfigKnowhere.h"
 #include "log/Log.h"
 #include "segcore/SegcoreConfig.h"
 #include "segcore/segcore_init_c.h"
 
+#ifndef SEGCORE_BUILD_VERSION
+#define SEGCORE_BUILD_VERSION "unknown"
+#endif
+
+#ifndef SEGCORE_BUILD_COMMIT
+#define SEGCORE_BUILD_COMMIT "unknown"
+#endif
+
 namespace milvus::segcore {
+
+namespace {
+char*
+CopyToCString(const std::string& value) {
+    char* ret = reinterpret_cast<char*>(malloc(value.length() + 1));
+    memcpy(ret, value.c_str(), value.length());
+    ret[value.length()] = 0;
+    return ret;
+}
+}  // namespace
+
+extern "C" int64_t
+SegcoreABIVersion() {
+    return SEGCORE_ABI_VERSION;
+}
+
+// return value must be freed by the caller
+extern "C" char*
+SegcoreVersion() {
+    return CopyToCString(std::string(SEGCORE_BUILD_VERSION) + "-" + SEGCORE_BUILD_COMMIT);
+}
+
+// return value must be freed by the caller
+extern "C" char*
+SegcoreSimdCapabilities() {
+    std::vector<std::string> capabilities;
+#if defined(__x86_64__) || defined(__i386__)
+    __builtin_cpu_init();
+    if (__builtin_cpu_supports("sse4.2")) {
+        capabilities.emplace_back("sse4_2");
+    }
+    if (__builtin_cpu_supports("avx")) {
+        capabilities.emplace_back("avx");
+    }
+    if (__builtin_cpu_supports("avx2")) {
+        capabilities.emplace_back("avx2");
+    }
+    if (__builtin_cpu_supports("avx512f")) {
+        capabilities.emplace_back("avx512");
+    }
+#endif
+    std::string joined;
+    for (const auto& capability : capabilities) {
+        if (!joined.empty()) {
+            joined += ",";
+        }
+        joined += capability;
+    }
+    return CopyToCString(joined);
+}
+
 extern "C" void
 SegcoreInit() {
     milvus::config::KnowhereInitImpl();
@@ -44,10 +106,7 @@ extern "C" char*
 SegcoreSetSimdType(const char* value) {
     LOG_SEGCORE_DEBUG_ << "set config simd_type: " << value;
     auto real_type = milvus::config::KnowhereSetSimdType(value);
-    char* ret = reinterpret_cast<char*>(malloc(real_type.length() + 1));
-    memcpy(ret, real_type.c_str(), real_type.length());
-    ret[real_type.length()] = 0;
-    return ret;
+    return CopyToCString(real_type);
 }
 
 extern "C" void
@@ -11,10 +11,27 @@
 
 #pragma once
 
+#include <stdint.h>
+
 #ifdef __cplusplus
 extern "C" {
 #endif
 
+// version of the segcore C API, bump it on any incompatible change of the API,
+// callers must check it against the version they are built with before any other call
+#define SEGCORE_ABI_VERSION 1
+
+int64_t
+SegcoreABIVersion();
+
+// return value must be freed by the caller
+char*
+SegcoreVersion();
+
+// return value must be freed by the caller, the SIMD instruction sets supported by the CPU separated by comma
+char*
+SegcoreSimdCapabilities();
+
 void
 SegcoreInit();
 
@@ -22,25 +22,88 @@
 #include "segcore/segment_c.h"
 
 //////////////////////////////    common interfaces    //////////////////////////////
+static void
+CheckSegmentSchema(const milvus::Schema& schema) {
+    for (auto& field_meta : schema.get_fields()) {
+        if (field_meta.is_vector() && field_meta.get_dim() <= 0) {
+            throw milvus::SegcoreError(milvus::ErrorCodeEnum::IllegalArgument,
+                                       "invalid dim " + std::to_string(field_meta.get_dim()) + " of vector field " +
+                                           field_meta.get_name().get());
+        }
+    }
+}
+
 CSegmentInterface
 NewSegment(CCollection collection, SegmentType seg_type, int64_t segment_id) {
-    auto col = (milvus::segcore::Collection*)collection;
-
-    std::unique_ptr<milvus::segcore::SegmentInterface> segment;
-    switch (seg_type) {
-        case Growing:
-            segment = milvus::segcore::CreateGrowingSegment(col->get_schema(), segment_id);
-            break;
-        case Sealed:
-        case Indexing:
-            segment = milvus::segcore::CreateSealedSegment(col->get_schema(), segment_id);
-            break;
-        default:
-            LOG_SEGCORE_ERROR_ << "invalid segment type " << (int32_t)seg_type;
-            break;
+    CSegmentInterface segment = nullptr;
+    auto status = NewSegmentWithStatus(collection, seg_type, segment_id, &segment);
+    if (status.error_code != Success) {
+        LOG_SEGCORE_ERROR_ << "failed to create segment " << segment_id << ": " << status.error_msg;
+        free((char*)status.error_msg);
     }
+    return segment;
+}
 
-    return (void*)segment.release();
+CStatus
+NewSegmentWithStatus(CCollection collection, SegmentType seg_type, int64_t segment_id, CSegmentInterface* newSegment) {
+    try {
+        auto col = (milvus::segcore::Collection*)collection;
+        auto schema = col->get_schema();
+        CheckSegmentSchema(*schema);
+
+        std::unique_ptr<milvus::segcore::SegmentInterface> segment;
+        switch (seg_type) {
+            case Growing:
+                segment = milvus::segcore::CreateGrowingSegment(schema, segment_id);
+                break;
+            case Sealed:
+            case Indexing:
+                segment = milvus::segcore::CreateSealedSegment(schema, segment_id);
+                break;
+            default:
+                throw milvus::SegcoreError(milvus::ErrorCodeEnum::IllegalArgument,
+                                           "invalid segment type " + std::to_string((int32_t)seg_type));
+        }
+
+        *newSegment = segment.release();
+        return milvus::SuccessCStatus();
+    } catch (milvus::SegcoreError& e) {
+        *newSegment = nullptr;
+        return milvus::FailureCStatus((ErrorCode)e.get_error_code(), e.what());
+    } catch (std::exception& e) {
+        *newSegment = nullptr;
+        return milvus::FailureCStatus(UnexpectedError, e.what());
+    }
+}
+
+CStatus
+NewGrowingSegmentWithChunkRows(CCollection collection,
+                               int64_t segment_id,
+                               int64_t chunk_rows,
+                               CSegmentInterface* newSegment) {
+    try {
+        if (chunk_rows <= 0) {
+            throw milvus::SegcoreError(milvus::ErrorCodeEnum::IllegalArgument,
+                                       "invalid chunk rows " + std::to_string(chunk_rows));
+        }
+        auto col = (milvus::segcore::Collection*)collection;
+        auto schema = col->get_schema();
+        CheckSegmentSchema(*schema);
+
+        // the growing segment keeps its own copy of the config
+        auto config = milvus::segcore::SegcoreConfig::default_config();
+        config.set_chunk_rows(chunk_rows);
+        auto segment = milvus::segcore::CreateGrowingSegment(schema, segment_id, config);
+
+        *newSegment = segment.release();
+        return milvus::SuccessCStatus();
+    } catch (milvus::SegcoreError& e) {
+        *newSegment = nullptr;
+        return milvus::FailureCStatus((ErrorCode)e.get_error_code(), e.what());
+    } catch (std::exception& e) {
+        *newSegment = nullptr;
+        return milvus::FailureCStatus(UnexpectedError, e.what());
+    }
 }
 
 void
@@ -80,6 +143,39 @@ Search(CSegmentInterface c_segment,
     }
 }
 
+CStatus
+SearchWithCandidates(CSegmentInterface c_segment,
+                     CSearchPlan c_plan,
+                     CPlaceholderGroup c_placeholder_group,
+                     uint64_t timestamp,
+                     const uint8_t* candidates,
+                     int64_t num_rows,
+                     bool brute_force,
+                     CSearchResult* result,
+                     int64_t segment_id) {
+    try {
+        auto segment = (milvus::segcore::SegmentInterface*)c_segment;
+        auto plan = (milvus::query::Plan*)c_plan;
+        auto phg_ptr = reinterpret_cast<const milvus::query::PlaceholderGroup*>(c_placeholder_group);
+        milvus::BitsetType bitset(num_rows);
+        for (int64_t i = 0; i < num_rows; ++i) {
+            if (candidates[i >> 3] & (1 << (i & 0x7))) {
+                bitset.set(i);
+            }
+        }
+        auto search_result = segment->SearchWithCandidates(plan, *phg_ptr, timestamp, bitset, brute_force);
+        if (!milvus::segcore::PositivelyRelated(plan->plan_node_->search_info_.metric_type_)) {
+            for (auto& dis : search_result->distances_) {
+                dis *= -1;
+            }
+        }
+        *result = search_result.release();
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(UnexpectedError, e.what());
+    }
+}
+
 void
 DeleteRetrieveResult(CRetrieveResult* retrieve_result) {
     std::free((void*)(retrieve_result->proto_blob));
@@ -87,10 +183,46 @@ DeleteRetrieveResult(CRetrieveResult* retrieve_result) {
 
 CStatus
 Retrieve(CSegmentInterface c_segment, CRetrievePlan c_plan, uint64_t timestamp, CRetrieveResult* result) {
+    return RetrieveWithOffsetsOnlyFields(c_segment, c_plan, timestamp, nullptr, 0, result);
+}
+
+CStatus
+RetrieveWithOffsetsOnlyFields(CSegmentInterface c_segment,
+                              CRetrievePlan c_plan,
+                              uint64_t timestamp,
+                              const int64_t* offsets_only_field_ids,
+                              int64_t num_offsets_only_fields,
+                              CRetrieveResult* result) {
     try {
         auto segment = (const milvus::segcore::SegmentInterface*)c_segment;
         auto plan = (const milvus::query::RetrievePlan*)c_plan;
-        auto retrieve_result = segment->Retrieve(plan, timestamp);
+        std::vector<milvus::FieldId> offsets_only_fields;
+        for (int64_t i = 0; i < num_offsets_only_fields; ++i) {
+            offsets_only_fields.emplace_back(offsets_only_field_ids[i]);
+        }
+        auto retrieve_result = segment->Retrieve(plan, timestamp, offsets_only_fields);
+
+        auto size = retrieve_result->ByteSize();
+        void* buffer = malloc(size);
+        retrieve_result->SerializePartialToArray(buffer, size);
+
+        result->proto_blob = buffer;
+        result->proto_size = size;
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(UnexpectedError, e.what());
+    }
+}
+
+CStatus
+RetrieveByOffsets(CSegmentInterface c_segment,
+                  int64_t field_id,
+                  const int64_t* offsets,
+                  int64_t count,
+                  CRetrieveResult* result) {
+    try {
+        auto segment = (const milvus::segcore::SegmentInterface*)c_segment;
+        auto retrieve_result = segment->RetrieveByOffsets(milvus::FieldId(field_id), offsets, count);
 
         auto size = retrieve_result->ByteSize();
         void* buffer = malloc(size);
@@ -198,6 +330,38 @@ PreInsert(CSegmentInterface c_segment, int64_t size, int64_t* offset) {
     }
 }
 
+CStatus
+ReserveGrowingSegment(CSegmentInterface c_segment, int64_t row_count) {
+    try {
+        auto segment = (milvus::segcore::SegmentGrowing*)c_segment;
+        segment->Reserve(row_count);
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(UnexpectedError, e.what());
+    }
+}
+
+int64_t
+GetAllocatedChunkNum(CSegmentInterface c_segment) {
+    auto segment = (milvus::segcore::SegmentGrowing*)c_segment;
+    return segment->get_allocated_chunk_num();
+}
+
+CStatus
+CompactGrowingSegment(CSegmentInterface c_segment,
+                      uint64_t horizon,
+                      CSegmentInterface* new_segment,
+                      int64_t* dead_rows) {
+    try {
+        auto segment = (milvus::segcore::SegmentGrowing*)c_segment;
+        auto compacted = segment->Compact(horizon, dead_rows);
+        *new_segment = compacted.release();
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(UnexpectedError, e.what());
+    }
+}
+
 CStatus
 Delete(CSegmentInterface c_segment,
        int64_t reserved_offset,
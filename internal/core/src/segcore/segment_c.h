@@ -31,6 +31,17 @@ typedef CProto CRetrieveResult;
 CSegmentInterface
 NewSegment(CCollection collection, SegmentType seg_type, int64_t segment_id);
 
+// same as NewSegment, but reports why the segment could not be created, e.g. an invalid schema
+CStatus
+NewSegmentWithStatus(CCollection collection, SegmentType seg_type, int64_t segment_id, CSegmentInterface* newSegment);
+
+// creates a growing segment whose chunks hold chunk_rows rows, instead of the globally configured chunk rows
+CStatus
+NewGrowingSegmentWithChunkRows(CCollection collection,
+                               int64_t segment_id,
+                               int64_t chunk_rows,
+                               CSegmentInterface* newSegment);
+
 void
 DeleteSegment(CSegmentInterface c_segment);
 
@@ -45,12 +56,43 @@ Search(CSegmentInterface c_segment,
        CSearchResult* result,
        int64_t segment_id);
 
+// only the rows whose bits are set in candidates are searched, bit i of candidates[i / 8] is row i,
+// the predicate of c_plan is not evaluated. brute_force searches the candidates by brute force
+// instead of the vector index if the raw data of the vector field is in memory
+CStatus
+SearchWithCandidates(CSegmentInterface c_segment,
+                     CSearchPlan c_plan,
+                     CPlaceholderGroup c_placeholder_group,
+                     uint64_t timestamp,
+                     const uint8_t* candidates,
+                     int64_t num_rows,
+                     bool brute_force,
+                     CSearchResult* result,
+                     int64_t segment_id);
+
 void
 DeleteRetrieveResult(CRetrieveResult* retrieve_result);
 
 CStatus
 Retrieve(CSegmentInterface c_segment, CRetrievePlan c_plan, uint64_t timestamp, CRetrieveResult* result);
 
+// fields in offsets_only_field_ids are returned without data, the caller fills them by result offsets
+CStatus
+RetrieveWithOffsetsOnlyFields(CSegmentInterface c_segment,
+                              CRetrievePlan c_plan,
+                              uint64_t timestamp,
+                              const int64_t* offsets_only_field_ids,
+                              int64_t num_offsets_only_fields,
+                              CRetrieveResult* result);
+
+// returns the field_id column of the rows at offsets, every offset must be less than the row count
+CStatus
+RetrieveByOffsets(CSegmentInterface c_segment,
+                  int64_t field_id,
+                  const int64_t* offsets,
+                  int64_t count,
+                  CRetrieveResult* result);
+
 int64_t
 GetMemoryUsageInBytes(CSegmentInterface c_segment);
 
@@ -83,6 +125,18 @@ InsertColumnData(CSegmentInterface c_segment,
 CStatus
 PreInsert(CSegmentInterface c_segment, int64_t size, int64_t* offset);
 
+CStatus
+ReserveGrowingSegment(CSegmentInterface c_segment, int64_t row_count);
+
+int64_t
+GetAllocatedChunkNum(CSegmentInterface c_segment);
+
+CStatus
+CompactGrowingSegment(CSegmentInterface c_segment,
+                      uint64_t horizon,
+                      CSegmentInterface* new_segment,
+                      int64_t* dead_rows);
+
 CStatus
 Delete(CSegmentInterface c_segment,
        int64_t reserved_offset,
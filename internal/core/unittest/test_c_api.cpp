@@ -14,6 +14,7 @@
 #include <google/protobuf/text_format.h>
 #include <iostream>
 #include <random>
+#include <set>
 #include <string>
 #include <unordered_set>
 #include <knowhere/index/vector_index/helpers/IndexParameter.h>
@@ -28,8 +29,10 @@
 #include "segcore/Collection.h"
 #include "segcore/reduce_c.h"
 #include "segcore/Reduce.h"
+#include "segcore/SegcoreConfig.h"
 #include "test_utils/DataGen.h"
 #include "utils/Types.h"
+#include "utils/Utils.h"
 
 namespace chrono = std::chrono;
 
@@ -221,6 +224,39 @@ TEST(CApiTest, SegmentTest) {
     DeleteSegment(segment);
 }
 
+TEST(CApiTest, NewSegmentWithStatusTest) {
+    auto collection = NewCollection(get_default_schema_config());
+    CSegmentInterface segment;
+    auto status = NewSegmentWithStatus(collection, Growing, -1, &segment);
+    ASSERT_EQ(status.error_code, Success);
+    ASSERT_NE(segment, nullptr);
+    DeleteSegment(segment);
+
+    status = NewSegmentWithStatus(collection, Invalid, -1, &segment);
+    ASSERT_EQ(status.error_code, IllegalArgument);
+    ASSERT_EQ(segment, nullptr);
+    free((char*)status.error_msg);
+    DeleteCollection(collection);
+
+    auto schema_config = R"(name: "invalid-dim-collection"
+                            fields: <
+                              fieldID: 100
+                              name: "fakevec"
+                              data_type: FloatVector
+                              type_params: <
+                                key: "dim"
+                                value: "0"
+                              >
+                            >)";
+    collection = NewCollection(schema_config);
+    status = NewSegmentWithStatus(collection, Sealed, -1, &segment);
+    ASSERT_EQ(status.error_code, IllegalArgument);
+    ASSERT_EQ(segment, nullptr);
+    ASSERT_NE(std::string(status.error_msg).find("invalid dim 0"), std::string::npos);
+    free((char*)status.error_msg);
+    DeleteCollection(collection);
+}
+
 TEST(CApiTest, InsertTest) {
     auto collection = NewCollection(get_default_schema_config());
     auto segment = NewSegment(collection, Growing, -1);
@@ -256,6 +292,127 @@ TEST(CApiTest, InsertColumnDataTest) {
     DeleteSegment(segment);
 }
 
+TEST(CApiTest, ReserveGrowingSegmentTest) {
+    auto collection = NewCollection(get_default_schema_config());
+    auto segment = NewSegment(collection, Growing, -1);
+    ASSERT_EQ(GetAllocatedChunkNum(segment), 0);
+
+    // no hint, keep growing reactively
+    auto status = ReserveGrowingSegment(segment, 0);
+    ASSERT_EQ(status.error_code, Success);
+    ASSERT_EQ(GetAllocatedChunkNum(segment), 0);
+
+    int64_t expected_rows = 100000;
+    auto chunk_rows = SegcoreConfig::default_config().get_chunk_rows();
+    status = ReserveGrowingSegment(segment, expected_rows);
+    ASSERT_EQ(status.error_code, Success);
+    ASSERT_EQ(GetAllocatedChunkNum(segment), upper_div(expected_rows, chunk_rows));
+
+    int N = 10000;
+    auto [raw_data, timestamps, uids] = generate_data(N);
+    auto line_sizeof = (sizeof(int) + sizeof(float) * DIM);
+
+    int64_t offset;
+    PreInsert(segment, N, &offset);
+    auto res = Insert(segment, offset, N, uids.data(), timestamps.data(), raw_data.data(), (int)line_sizeof, N);
+    ASSERT_EQ(res.error_code, Success);
+    ASSERT_EQ(GetRowCount(segment), N);
+    ASSERT_EQ(GetAllocatedChunkNum(segment), upper_div(expected_rows, chunk_rows));
+
+    DeleteCollection(collection);
+    DeleteSegment(segment);
+}
+
+TEST(CApiTest, NewGrowingSegmentWithChunkRowsTest) {
+    auto collection = NewCollection(get_default_schema_config());
+    CSegmentInterface segment;
+    auto status = NewGrowingSegmentWithChunkRows(collection, -1, 0, &segment);
+    ASSERT_EQ(status.error_code, IllegalArgument);
+    ASSERT_EQ(segment, nullptr);
+    free((char*)status.error_msg);
+
+    int64_t chunk_rows = 1024;
+    status = NewGrowingSegmentWithChunkRows(collection, -1, chunk_rows, &segment);
+    ASSERT_EQ(status.error_code, Success);
+    ASSERT_NE(segment, nullptr);
+
+    int64_t expected_rows = 100000;
+    status = ReserveGrowingSegment(segment, expected_rows);
+    ASSERT_EQ(status.error_code, Success);
+    ASSERT_EQ(GetAllocatedChunkNum(segment), upper_div(expected_rows, chunk_rows));
+
+    int N = 10000;
+    auto [raw_data, timestamps, uids] = generate_data(N);
+    auto line_sizeof = (sizeof(int) + sizeof(float) * DIM);
+
+    int64_t offset;
+    PreInsert(segment, N, &offset);
+    auto res = Insert(segment, offset, N, uids.data(), timestamps.data(), raw_data.data(), (int)line_sizeof, N);
+    ASSERT_EQ(res.error_code, Success);
+    ASSERT_EQ(GetRowCount(segment), N);
+
+    // the default config is left as is
+    ASSERT_NE(SegcoreConfig::default_config().get_chunk_rows(), chunk_rows);
+
+    DeleteCollection(collection);
+    DeleteSegment(segment);
+}
+
+TEST(CApiTest, CompactGrowingSegmentTest) {
+    auto collection = NewCollection(get_default_schema_config());
+    CSegmentInterface segment;
+    auto status = NewGrowingSegmentWithChunkRows(collection, 1, 1024, &segment);
+    ASSERT_EQ(status.error_code, Success);
+
+    int N = 10000;
+    auto [raw_data, timestamps, uids] = generate_data(N);
+    auto line_sizeof = (sizeof(int) + sizeof(float) * DIM);
+
+    int64_t offset;
+    PreInsert(segment, N, &offset);
+    auto res = Insert(segment, offset, N, uids.data(), timestamps.data(), raw_data.data(), (int)line_sizeof, N);
+    ASSERT_EQ(res.error_code, Success);
+
+    // the first half of rows are deleted before the horizon, the last row after it
+    std::vector<int64_t> delete_row_ids(uids.begin(), uids.begin() + N / 2);
+    std::vector<uint64_t> delete_timestamps(N / 2, 10);
+    delete_row_ids.push_back(uids.back());
+    delete_timestamps.push_back(30);
+    auto del_offset = PreDelete(segment, delete_row_ids.size());
+    auto del_res =
+        Delete(segment, del_offset, delete_row_ids.size(), delete_row_ids.data(), delete_timestamps.data());
+    ASSERT_EQ(del_res.error_code, Success);
+
+    CSegmentInterface compacted = nullptr;
+    int64_t dead_rows = 0;
+    status = CompactGrowingSegment(segment, 20, &compacted, &dead_rows);
+    ASSERT_EQ(status.error_code, Success);
+    ASSERT_NE(compacted, nullptr);
+    ASSERT_EQ(dead_rows, N / 2);
+    ASSERT_EQ(GetRowCount(compacted), N / 2);
+    ASSERT_LT(GetMemoryUsageInBytes(compacted), GetMemoryUsageInBytes(segment));
+
+    // nothing to drop after compacted
+    CSegmentInterface recompacted = nullptr;
+    status = CompactGrowingSegment(compacted, 20, &recompacted, &dead_rows);
+    ASSERT_EQ(status.error_code, Success);
+    ASSERT_EQ(dead_rows, 0);
+    ASSERT_EQ(GetRowCount(recompacted), N / 2);
+
+    // the delete after the horizon is kept
+    CSegmentInterface tail_compacted = nullptr;
+    status = CompactGrowingSegment(recompacted, 40, &tail_compacted, &dead_rows);
+    ASSERT_EQ(status.error_code, Success);
+    ASSERT_EQ(dead_rows, 1);
+    ASSERT_EQ(GetRowCount(tail_compacted), N / 2 - 1);
+
+    DeleteCollection(collection);
+    DeleteSegment(segment);
+    DeleteSegment(compacted);
+    DeleteSegment(recompacted);
+    DeleteSegment(tail_compacted);
+}
+
 TEST(CApiTest, DeleteTest) {
     auto collection = NewCollection(get_default_schema_config());
     auto segment = NewSegment(collection, Growing, -1);
@@ -314,6 +471,7 @@ TEST(CApiTest, SearchTest) {
     void* plan = nullptr;
     auto status = CreateSearchPlan(collection, dsl_string, &plan);
     ASSERT_EQ(status.error_code, Success);
+    ASSERT_EQ(GetFieldID(plan), 100);
 
     void* placeholderGroup = nullptr;
     status = ParsePlaceholderGroup(plan, blob.data(), blob.length(), &placeholderGroup);
@@ -340,6 +498,76 @@ TEST(CApiTest, SearchTest) {
     DeleteSegment(segment);
 }
 
+TEST(CApiTest, SearchWithCandidatesTest) {
+    auto collection = NewCollection(get_default_schema_config());
+    auto segment = NewSegment(collection, Growing, -1);
+
+    int N = 10000;
+    auto [raw_data, timestamps, uids] = generate_data(N);
+    auto line_sizeof = (sizeof(int) + sizeof(float) * DIM);
+
+    int64_t offset;
+    PreInsert(segment, N, &offset);
+    auto ins_res = Insert(segment, offset, N, uids.data(), timestamps.data(), raw_data.data(), (int)line_sizeof, N);
+    ASSERT_EQ(ins_res.error_code, Success);
+
+    const char* dsl_string = R"(
+    {
+        "bool": {
+            "vector": {
+                "fakevec": {
+                    "metric_type": "L2",
+                    "params": {
+                        "nprobe": 10
+                    },
+                    "query": "$0",
+                    "topk": 10,
+                    "round_decimal": 3
+                }
+            }
+        }
+    })";
+
+    int num_queries = 10;
+    auto blob = generate_query_data(num_queries);
+
+    void* plan = nullptr;
+    auto status = CreateSearchPlan(collection, dsl_string, &plan);
+    ASSERT_EQ(status.error_code, Success);
+    void* placeholderGroup = nullptr;
+    status = ParsePlaceholderGroup(plan, blob.data(), blob.length(), &placeholderGroup);
+    ASSERT_EQ(status.error_code, Success);
+
+    // only the rows 3, 500 and 9999 are candidates, the bitset is shorter than the segment
+    std::vector<uint8_t> candidates(N / 8, 0);
+    for (auto row : {3, 500, 9999}) {
+        candidates[row >> 3] |= 1 << (row & 0x7);
+    }
+    for (auto brute_force : {false, true}) {
+        CSearchResult search_result;
+        auto res = SearchWithCandidates(segment, plan, placeholderGroup, N, candidates.data(), N - 1, brute_force,
+                                        &search_result, -1);
+        ASSERT_EQ(res.error_code, Success);
+        auto result = (SearchResult*)search_result;
+        for (int i = 0; i < num_queries; ++i) {
+            std::set<int64_t> hits;
+            for (int j = 0; j < 10; ++j) {
+                auto id = result->ids_[i * 10 + j];
+                if (id != -1) {
+                    hits.insert(id);
+                }
+            }
+            ASSERT_EQ(hits, std::set<int64_t>({3, 500}));
+        }
+        DeleteSearchResult(search_result);
+    }
+
+    DeleteSearchPlan(plan);
+    DeletePlaceholderGroup(placeholderGroup);
+    DeleteCollection(collection);
+    DeleteSegment(segment);
+}
+
 TEST(CApiTest, SearchTest2) {
     auto collection = NewCollection(get_default_schema_config());
     auto segment = NewSegment(collection, Growing, -1);
@@ -581,6 +809,48 @@ TEST(CApiTest, RetrieveTestWithExpr2) {
     DeleteSegment(segment);
 }
 
+TEST(CApiTest, RetrieveByOffsetsTest) {
+    auto collection = NewCollection(get_default_schema_config());
+    auto segment = NewSegment(collection, Growing, -1);
+
+    int N = 10000;
+    auto [raw_data, timestamps, uids] = generate_data(N);
+    auto line_sizeof = (sizeof(int) + sizeof(float) * DIM);
+
+    int64_t offset;
+    PreInsert(segment, N, &offset);
+    auto ins_res = Insert(segment, offset, N, uids.data(), timestamps.data(), raw_data.data(), (int)line_sizeof, N);
+    ASSERT_EQ(ins_res.error_code, Success);
+
+    std::vector<int64_t> offsets{N - 1, 0, 42};
+    CRetrieveResult retrieve_result;
+    auto res = RetrieveByOffsets(segment, 101, offsets.data(), offsets.size(), &retrieve_result);
+    ASSERT_EQ(res.error_code, Success);
+
+    proto::segcore::RetrieveResults results;
+    ASSERT_TRUE(results.ParseFromArray(retrieve_result.proto_blob, retrieve_result.proto_size));
+    ASSERT_EQ(results.offset_size(), offsets.size());
+    ASSERT_EQ(results.fields_data_size(), 1);
+    auto& ages = results.fields_data(0).scalars().int_data().data();
+    ASSERT_EQ(ages.size(), offsets.size());
+    for (int i = 0; i < offsets.size(); ++i) {
+        ASSERT_EQ(results.offset(i), offsets[i]);
+        int age;
+        memcpy(&age, raw_data.data() + offsets[i] * line_sizeof + sizeof(float) * DIM, sizeof(age));
+        ASSERT_EQ(ages[i], age);
+    }
+    DeleteRetrieveResult(&retrieve_result);
+
+    // the offsets out of the inserted rows are rejected
+    std::vector<int64_t> out_of_range{N};
+    res = RetrieveByOffsets(segment, 101, out_of_range.data(), out_of_range.size(), &retrieve_result);
+    ASSERT_NE(res.error_code, Success);
+    free((char*)res.error_msg);
+
+    DeleteCollection(collection);
+    DeleteSegment(segment);
+}
+
 TEST(CApiTest, GetMemoryUsageInBytesTest) {
     auto collection = NewCollection(get_default_schema_config());
     auto segment = NewSegment(collection, Growing, -1);
@@ -21,3 +21,16 @@ TEST(Init, Naive) {
     SegcoreSetChunkRows(32768);
     SegcoreSetSimdType("auto");
 }
+
+TEST(Init, Version) {
+    using namespace milvus::segcore;
+    ASSERT_EQ(SegcoreABIVersion(), SEGCORE_ABI_VERSION);
+
+    auto version = SegcoreVersion();
+    ASSERT_GT(strlen(version), 0);
+    free(version);
+
+    auto capabilities = SegcoreSimdCapabilities();
+    ASSERT_NE(capabilities, nullptr);
+    free(capabilities);
+}
@@ -686,6 +686,7 @@ TEST(Query, FillSegment) {
         }
     })";
     auto plan = CreatePlan(*schema, dsl);
+    plan->plan_node_->search_info_.output_timestamps_ = true;
     auto ph_proto = CreatePlaceholderGroup(10, 16, 443);
     auto ph = ParsePlaceholderGroup(plan.get(), ph_proto.SerializeAsString());
     Timestamp ts = N * 2UL;
@@ -709,6 +710,7 @@ TEST(Query, FillSegment) {
         ASSERT_EQ(fields_meta[1].get_sizeof(), sizeof(int32_t));
         ASSERT_EQ(fields_data[0].size(), fields_meta[0].get_sizeof() * topk * num_queries);
         ASSERT_EQ(fields_data[1].size(), fields_meta[1].get_sizeof() * topk * num_queries);
+        ASSERT_EQ(result->timestamps_.size(), topk * num_queries);
 
         for (int i = 0; i < topk * num_queries; i++) {
             int64_t val;
@@ -722,6 +724,9 @@ TEST(Query, FillSegment) {
 
             ASSERT_EQ(val, std_val) << "io:" << internal_offset;
             if (val != -1) {
+                // check insert timestamp
+                ASSERT_EQ(result->timestamps_[i], dataset.timestamps_[internal_offset]);
+
                 // check vector field
                 std::vector<float> vfloat(dim);
                 memcpy(vfloat.data(), &fields_data[0][i * sizeof(float) * dim], dim * sizeof(float));
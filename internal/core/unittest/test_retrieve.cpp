@@ -141,6 +141,107 @@ TEST(Retrieve, AutoID2) {
     ASSERT_EQ(field1_data.data_size(), DIM * req_size);
 }
 
+TEST(Retrieve, OffsetsOnlyFields) {
+    auto schema = std::make_shared<Schema>();
+    auto fid_64 = schema->AddDebugField("i64", DataType::INT64);
+    auto DIM = 16;
+    auto fid_vec = schema->AddDebugField("vector_64", DataType::VECTOR_FLOAT, DIM, MetricType::METRIC_L2);
+    schema->set_primary_key(FieldOffset(0));
+
+    int64_t N = 100;
+    int64_t req_size = 10;
+    auto choose = [=](int i) { return i * 3 % N; };
+
+    auto dataset = DataGen(schema, N);
+    auto segment = CreateSealedSegment(schema);
+    SealedLoader(dataset, *segment);
+    auto i64_col = dataset.get_col<int64_t>(0);
+
+    auto plan = std::make_unique<query::RetrievePlan>(*schema);
+    std::vector<int64_t> values;
+    for (int i = 0; i < req_size; ++i) {
+        values.emplace_back(i64_col[choose(i)]);
+    }
+    auto term_expr = std::make_unique<query::TermExprImpl<int64_t>>(FieldOffset(0), DataType::INT64, values);
+    plan->plan_node_ = std::make_unique<query::RetrievePlanNode>();
+    plan->plan_node_->predicate_ = std::move(term_expr);
+    std::vector<FieldOffset> target_offsets{FieldOffset(0), FieldOffset(1)};
+    plan->field_offsets_ = target_offsets;
+
+    auto full_results = segment->Retrieve(plan.get(), 100);
+    auto retrieve_results = segment->Retrieve(plan.get(), 100, {fid_vec});
+    ASSERT_EQ(retrieve_results->fields_data_size(), target_offsets.size());
+    ASSERT_EQ(retrieve_results->offset_size(), req_size);
+    for (int i = 0; i < req_size; ++i) {
+        ASSERT_EQ(retrieve_results->offset(i), full_results->offset(i));
+    }
+
+    auto field0 = retrieve_results->fields_data(0);
+    ASSERT_EQ(field0.scalars().long_data().data_size(), req_size);
+
+    // the vector field keeps its type and dim, but no data
+    auto field1 = retrieve_results->fields_data(1);
+    ASSERT_TRUE(field1.has_vectors());
+    ASSERT_EQ(field1.field_id(), fid_vec.get());
+    ASSERT_EQ(field1.vectors().dim(), DIM);
+    ASSERT_EQ(field1.vectors().float_vector().data_size(), 0);
+    ASSERT_LT(retrieve_results->ByteSize(), full_results->ByteSize());
+}
+
+TEST(Retrieve, Expiration) {
+    auto schema = std::make_shared<Schema>();
+    auto fid_64 = schema->AddDebugField("i64", DataType::INT64);
+    auto DIM = 16;
+    auto fid_vec = schema->AddDebugField("vector_64", DataType::VECTOR_FLOAT, DIM, MetricType::METRIC_L2);
+    schema->set_primary_key(FieldOffset(0));
+
+    int64_t N = 100;
+    int64_t req_size = 10;
+    auto choose = [=](int i) { return i * 3 % N; };
+
+    // the timestamp of row i is i
+    auto dataset = DataGen(schema, N);
+    auto sealed = CreateSealedSegment(schema);
+    SealedLoader(dataset, *sealed);
+    auto growing = CreateGrowingSegment(schema);
+    growing->PreInsert(N);
+    growing->Insert(0, N, dataset.row_ids_.data(), dataset.timestamps_.data(), dataset.raw_);
+    auto i64_col = dataset.get_col<int64_t>(0);
+
+    auto plan = std::make_unique<query::RetrievePlan>(*schema);
+    std::vector<int64_t> values;
+    for (int i = 0; i < req_size; ++i) {
+        values.emplace_back(i64_col[choose(i)]);
+    }
+    auto term_expr = std::make_unique<query::TermExprImpl<int64_t>>(FieldOffset(0), DataType::INT64, values);
+    plan->plan_node_ = std::make_unique<query::RetrievePlanNode>();
+    plan->plan_node_->predicate_ = std::move(term_expr);
+    plan->field_offsets_ = std::vector<FieldOffset>{FieldOffset(0)};
+
+    std::vector<SegmentInternalInterface*> segments{sealed.get(), growing.get()};
+    for (auto segment : segments) {
+        plan->plan_node_->expire_ts_ = 0;
+        auto retrieve_results = segment->Retrieve(plan.get(), N);
+        ASSERT_EQ(retrieve_results->offset_size(), req_size);
+
+        // rows 0, 3, 6 and 9 are inserted before the expire ts
+        plan->plan_node_->expire_ts_ = 10;
+        retrieve_results = segment->Retrieve(plan.get(), N);
+        ASSERT_EQ(retrieve_results->offset_size(), req_size - 4);
+        auto field0_data = retrieve_results->fields_data(0).scalars().long_data();
+        for (int i = 0; i < field0_data.data_size(); ++i) {
+            auto offset = retrieve_results->offset(i);
+            ASSERT_GE(dataset.timestamps_[offset], 10);
+            ASSERT_EQ(field0_data.data(i), i64_col[offset]);
+        }
+
+        // all rows are expired
+        plan->plan_node_->expire_ts_ = N;
+        retrieve_results = segment->Retrieve(plan.get(), N);
+        ASSERT_EQ(retrieve_results->offset_size(), 0);
+    }
+}
+
 TEST(Retrieve, NotExist) {
     auto schema = std::make_shared<Schema>();
     auto fid_64 = schema->AddDebugField("i64", DataType::INT64);
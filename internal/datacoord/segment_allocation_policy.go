@@ -33,7 +33,7 @@ func calBySchemaPolicy(schema *schemapb.CollectionSchema) (int, error) {
 	if schema == nil {
 		return -1, errors.New("nil schema")
 	}
-	sizePerRecord, err := typeutil.EstimateSizePerRecord(schema)
+	sizePerRecord, err := typeutil.EstimateRowSize(schema, nil)
 	if err != nil {
 		return -1, err
 	}
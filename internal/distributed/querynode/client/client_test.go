@@ -104,6 +104,33 @@ func Test_NewClient(t *testing.T) {
 
 		r15, err := client.Query(ctx, nil)
 		retCheck(retNotNil, r15, err)
+
+		r16, err := client.SyncDistribution(ctx, nil)
+		retCheck(retNotNil, r16, err)
+
+		r17, err := client.PauseChannel(ctx, nil)
+		retCheck(retNotNil, r17, err)
+
+		r18, err := client.ResumeChannel(ctx, nil)
+		retCheck(retNotNil, r18, err)
+
+		r19, err := client.ExportSegmentDeletes(ctx, nil)
+		retCheck(retNotNil, r19, err)
+
+		r20, err := client.UpdateLoadConfig(ctx, nil)
+		retCheck(retNotNil, r20, err)
+
+		r21, err := client.GetDataDistribution(ctx, nil)
+		retCheck(retNotNil, r21, err)
+
+		r22, err := client.PromoteSegments(ctx, nil)
+		retCheck(retNotNil, r22, err)
+
+		r23, err := client.RefreshIndex(ctx, nil)
+		retCheck(retNotNil, r23, err)
+
+		r24, err := client.WatchDmChannelsWithStatus(ctx, nil)
+		retCheck(retNotNil, r24, err)
 	}
 
 	client.grpcClient = &mock.ClientBase{
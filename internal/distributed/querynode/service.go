@@ -270,6 +270,11 @@ func (s *Server) WatchDmChannels(ctx context.Context, req *querypb.WatchDmChanne
 	return s.querynode.WatchDmChannels(ctx, req)
 }
 
+// WatchDmChannelsWithStatus watches the channels about data manipulation, and returns the status of each channel.
+func (s *Server) WatchDmChannelsWithStatus(ctx context.Context, req *querypb.WatchDmChannelsRequest) (*querypb.WatchDmChannelsResponse, error) {
+	return s.querynode.WatchDmChannelsWithStatus(ctx, req)
+}
+
 // WatchDeltaChannels watches the channels about data manipulation.
 func (s *Server) WatchDeltaChannels(ctx context.Context, req *querypb.WatchDeltaChannelsRequest) (*commonpb.Status, error) {
 	// ignore ctx
@@ -305,6 +310,46 @@ func (s *Server) GetSegmentInfo(ctx context.Context, req *querypb.GetSegmentInfo
 	return s.querynode.GetSegmentInfo(ctx, req)
 }
 
+// SyncDistribution flips the serving state of the specified segments in QueryNode.
+func (s *Server) SyncDistribution(ctx context.Context, req *querypb.SyncDistributionRequest) (*commonpb.Status, error) {
+	return s.querynode.SyncDistribution(ctx, req)
+}
+
+// PauseChannel stops QueryNode from consuming the specified channel.
+func (s *Server) PauseChannel(ctx context.Context, req *querypb.PauseChannelRequest) (*commonpb.Status, error) {
+	return s.querynode.PauseChannel(ctx, req)
+}
+
+// ResumeChannel lets QueryNode continue consuming the specified channel.
+func (s *Server) ResumeChannel(ctx context.Context, req *querypb.ResumeChannelRequest) (*commonpb.Status, error) {
+	return s.querynode.ResumeChannel(ctx, req)
+}
+
+// ExportSegmentDeletes exports the applied deletes of a sealed segment on QueryNode.
+func (s *Server) ExportSegmentDeletes(ctx context.Context, req *querypb.ExportSegmentDeletesRequest) (*querypb.ExportSegmentDeletesResponse, error) {
+	return s.querynode.ExportSegmentDeletes(ctx, req)
+}
+
+// UpdateLoadConfig updates the load configs of a collection loaded on QueryNode.
+func (s *Server) UpdateLoadConfig(ctx context.Context, req *querypb.UpdateLoadConfigRequest) (*querypb.UpdateLoadConfigResponse, error) {
+	return s.querynode.UpdateLoadConfig(ctx, req)
+}
+
+// GetDataDistribution gets the dm channels watched by QueryNode.
+func (s *Server) GetDataDistribution(ctx context.Context, req *querypb.GetDataDistributionRequest) (*querypb.GetDataDistributionResponse, error) {
+	return s.querynode.GetDataDistribution(ctx, req)
+}
+
+// PromoteSegments promotes the warm standby segments in QueryNode to serving.
+func (s *Server) PromoteSegments(ctx context.Context, req *querypb.PromoteSegmentsRequest) (*commonpb.Status, error) {
+	return s.querynode.PromoteSegments(ctx, req)
+}
+
+// RefreshIndex re-attaches the stale indexes of a sealed segment in QueryNode.
+func (s *Server) RefreshIndex(ctx context.Context, req *querypb.RefreshIndexRequest) (*commonpb.Status, error) {
+	return s.querynode.RefreshIndex(ctx, req)
+}
+
 // Search performs search of streaming/historical replica on QueryNode.
 func (s *Server) Search(ctx context.Context, req *querypb.SearchRequest) (*internalpb.SearchResults, error) {
 	return s.querynode.Search(ctx, req)
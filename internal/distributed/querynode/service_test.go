@@ -46,6 +46,9 @@ type MockQueryNode struct {
 	metricResp *milvuspb.GetMetricsResponse
 	searchResp *internalpb.SearchResults
 	queryResp  *internalpb.RetrieveResults
+	exportResp *querypb.ExportSegmentDeletesResponse
+	configResp *querypb.UpdateLoadConfigResponse
+	distResp   *querypb.GetDataDistributionResponse
 }
 
 func (m *MockQueryNode) Init() error {
@@ -88,6 +91,10 @@ func (m *MockQueryNode) WatchDmChannels(ctx context.Context, req *querypb.WatchD
 	return m.status, m.err
 }
 
+func (m *MockQueryNode) WatchDmChannelsWithStatus(ctx context.Context, req *querypb.WatchDmChannelsRequest) (*querypb.WatchDmChannelsResponse, error) {
+	return &querypb.WatchDmChannelsResponse{Status: m.status}, m.err
+}
+
 func (m *MockQueryNode) WatchDeltaChannels(ctx context.Context, req *querypb.WatchDeltaChannelsRequest) (*commonpb.Status, error) {
 	return m.status, m.err
 }
@@ -112,6 +119,38 @@ func (m *MockQueryNode) GetSegmentInfo(ctx context.Context, req *querypb.GetSegm
 	return m.infoResp, m.err
 }
 
+func (m *MockQueryNode) SyncDistribution(ctx context.Context, req *querypb.SyncDistributionRequest) (*commonpb.Status, error) {
+	return m.status, m.err
+}
+
+func (m *MockQueryNode) PauseChannel(ctx context.Context, req *querypb.PauseChannelRequest) (*commonpb.Status, error) {
+	return m.status, m.err
+}
+
+func (m *MockQueryNode) ResumeChannel(ctx context.Context, req *querypb.ResumeChannelRequest) (*commonpb.Status, error) {
+	return m.status, m.err
+}
+
+func (m *MockQueryNode) ExportSegmentDeletes(ctx context.Context, req *querypb.ExportSegmentDeletesRequest) (*querypb.ExportSegmentDeletesResponse, error) {
+	return m.exportResp, m.err
+}
+
+func (m *MockQueryNode) UpdateLoadConfig(ctx context.Context, req *querypb.UpdateLoadConfigRequest) (*querypb.UpdateLoadConfigResponse, error) {
+	return m.configResp, m.err
+}
+
+func (m *MockQueryNode) GetDataDistribution(ctx context.Context, req *querypb.GetDataDistributionRequest) (*querypb.GetDataDistributionResponse, error) {
+	return m.distResp, m.err
+}
+
+func (m *MockQueryNode) PromoteSegments(ctx context.Context, req *querypb.PromoteSegmentsRequest) (*commonpb.Status, error) {
+	return m.status, m.err
+}
+
+func (m *MockQueryNode) RefreshIndex(ctx context.Context, req *querypb.RefreshIndexRequest) (*commonpb.Status, error) {
+	return m.status, m.err
+}
+
 func (m *MockQueryNode) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return m.metricResp, m.err
 }
@@ -224,6 +263,9 @@ func Test_NewServer(t *testing.T) {
 		strResp:    &milvuspb.StringResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}},
 		infoResp:   &querypb.GetSegmentInfoResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}},
 		metricResp: &milvuspb.GetMetricsResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}},
+		exportResp: &querypb.ExportSegmentDeletesResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}},
+		configResp: &querypb.UpdateLoadConfigResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}},
+		distResp:   &querypb.GetDataDistributionResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}},
 	}
 	server.querynode = mqn
 
@@ -309,6 +351,62 @@ func Test_NewServer(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
 	})
 
+	t.Run("SyncDistribution", func(t *testing.T) {
+		req := &querypb.SyncDistributionRequest{}
+		resp, err := server.SyncDistribution(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
+	t.Run("PauseChannel", func(t *testing.T) {
+		req := &querypb.PauseChannelRequest{}
+		resp, err := server.PauseChannel(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
+	t.Run("ResumeChannel", func(t *testing.T) {
+		req := &querypb.ResumeChannelRequest{}
+		resp, err := server.ResumeChannel(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
+	t.Run("ExportSegmentDeletes", func(t *testing.T) {
+		req := &querypb.ExportSegmentDeletesRequest{}
+		resp, err := server.ExportSegmentDeletes(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	})
+
+	t.Run("UpdateLoadConfig", func(t *testing.T) {
+		req := &querypb.UpdateLoadConfigRequest{}
+		resp, err := server.UpdateLoadConfig(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	})
+
+	t.Run("GetDataDistribution", func(t *testing.T) {
+		req := &querypb.GetDataDistributionRequest{}
+		resp, err := server.GetDataDistribution(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	})
+
+	t.Run("PromoteSegments", func(t *testing.T) {
+		req := &querypb.PromoteSegmentsRequest{}
+		resp, err := server.PromoteSegments(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
+	t.Run("RefreshIndex", func(t *testing.T) {
+		req := &querypb.RefreshIndexRequest{}
+		resp, err := server.RefreshIndex(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
 	t.Run("GetMetrics", func(t *testing.T) {
 		req := &milvuspb.GetMetricsRequest{
 			Request: "",
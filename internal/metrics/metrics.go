@@ -41,6 +41,13 @@ const (
 	SuccessLabel = "success"
 	FailLabel    = "fail"
 	TotalLabel   = "total"
+	ClampLabel   = "clamp"
+	RejectLabel  = "reject"
+
+	AppliedLabel   = "applied"
+	CoalescedLabel = "coalesced"
+	ExpiredLabel   = "expired"
+	DroppedLabel   = "dropped"
 
 	InsertLabel = "insert"
 	DeleteLabel = "delete"
@@ -50,6 +57,9 @@ const (
 	CacheHitLabel  = "hit"
 	CacheMissLabel = "miss"
 
+	CgoReadPoolLabel  = "read"
+	CgoWritePoolLabel = "write"
+
 	UnissuedIndexTaskLabel   = "unissued"
 	InProgressIndexTaskLabel = "in-progress"
 	FinishedIndexTaskLabel   = "finished"
@@ -72,6 +82,9 @@ const (
 	queryTypeLabelName       = "query_type"
 	segmentTypeLabelName     = "segment_type"
 	usernameLabelName        = "username"
+	cgoPoolLabelName         = "cgo_pool"
+
+	replicaSelectionPolicyLabelName = "policy"
 )
 
 var (
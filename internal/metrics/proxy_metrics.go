@@ -280,6 +280,43 @@ var (
 			Help:      "The latency that for credential request",
 			Buckets:   buckets, // unit: ms
 		}, []string{nodeIDLabelName, functionLabelName, usernameLabelName})
+
+	// ProxySearchResultViolations record the number of reduced search results which failed the layout validation.
+	ProxySearchResultViolations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "search_result_violations",
+			Help:      "The number of reduced search results which failed the layout validation",
+		}, []string{nodeIDLabelName})
+
+	// ProxyRequeryMissingHits record the number of search hits dropped because requery didn't return their rows.
+	ProxyRequeryMissingHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "requery_missing_hits",
+			Help:      "The number of search hits dropped because requery didn't return their rows",
+		}, []string{nodeIDLabelName})
+
+	// ProxyResultDecompressLatency record the latency of decompressing the results received from query nodes.
+	ProxyResultDecompressLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "result_decompress_latency",
+			Help:      "The latency of decompressing the results received from query nodes",
+			Buckets:   buckets, // unit: ms
+		}, []string{nodeIDLabelName, queryTypeLabelName})
+
+	// ProxyReplicaSelections record the number of requests routed to the replicas selected by the replica selection policy.
+	ProxyReplicaSelections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "replica_selections",
+			Help:      "The number of requests routed to the replicas selected by the replica selection policy",
+		}, []string{nodeIDLabelName, replicaSelectionPolicyLabelName, statusLabelName})
 )
 
 //RegisterProxy registers Proxy metrics
@@ -318,4 +355,9 @@ func RegisterProxy(registry *prometheus.Registry) {
 
 	// for credential
 	registry.MustRegister(ProxyCredentialReqLatency)
+
+	registry.MustRegister(ProxySearchResultViolations)
+	registry.MustRegister(ProxyRequeryMissingHits)
+	registry.MustRegister(ProxyResultDecompressLatency)
+	registry.MustRegister(ProxyReplicaSelections)
 }
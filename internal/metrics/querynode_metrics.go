@@ -197,6 +197,393 @@ var (
 		}, []string{
 			nodeIDLabelName,
 		})
+
+	QueryNodeFlowGraphPaused = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "flow_graph_paused",
+			Help:      "Whether the flow graph of a channel is paused for maintenance in QueryNode, 1 means paused.",
+		}, []string{
+			nodeIDLabelName,
+			channelNameLabelName,
+		})
+
+	QueryNodeSearchResultViolations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "search_result_violations",
+			Help:      "The number of reduced search results which failed the layout validation in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeRetrieveBinlogFiles = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "retrieve_binlog_files",
+			Help:      "The number of distinct binlog files read by a retrieve request in QueryNode.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12), // 1 ~ 2048
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeRetrieveMaterializedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "retrieve_materialized_bytes",
+			Help:      "The bytes of retrieve results materialized by segcore in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeSkewedGuaranteeTs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "skewed_guarantee_ts",
+			Help:      "The number of requests whose guarantee timestamp is too far ahead of tSafe in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+			statusLabelName,
+		})
+
+	QueryNodeUnorderedDeleteBatches = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "unordered_delete_batches",
+			Help:      "The number of delete batches out of timestamp order applied to sealed segments in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeStaleDeleteRecords = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "stale_delete_records",
+			Help:      "The number of delete records older than the last applied delete dropped in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeTimeTicks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "time_ticks",
+			Help:      "The number of time ticks applied to tSafe or coalesced into a later one in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+			statusLabelName,
+		})
+
+	QueryNodeAutoIDViolations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "autoid_violations",
+			Help:      "The number of insert messages of AutoID collections dropped for primary keys not allocated by the coordinator.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeInvalidInsertPayloads = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "invalid_insert_payloads",
+			Help:      "The number of insert messages dropped for payload sizes mismatching the schema.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeBloomFilterPrunedPKs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "bloom_filter_pruned_pks",
+			Help:      "The number of delete primary keys pruned by the bloom filters of segments.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeResultCompressRatio = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "result_compress_ratio",
+			Help:      "The ratio of compressed size to original size of search and query results in QueryNode.",
+			Buckets:   prometheus.LinearBuckets(0.05, 0.05, 20),
+		}, []string{
+			nodeIDLabelName,
+			queryTypeLabelName,
+		})
+
+	QueryNodeSearchDedupRatio = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "search_dedup_ratio",
+			Help:      "The ratio of distinct query vectors to all query vectors of search requests in QueryNode.",
+			Buckets:   prometheus.LinearBuckets(0.05, 0.05, 20),
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeResultCompressLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "result_compress_latency",
+			Help:      "The latency of compressing search and query results in QueryNode.",
+			Buckets:   buckets,
+		}, []string{
+			nodeIDLabelName,
+			queryTypeLabelName,
+		})
+
+	QueryNodeNumReapedGrowingSegments = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "num_reaped_growing_segments",
+			Help:      "The number of idle empty growing segments removed in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeCompactedGrowingRows = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "compacted_growing_rows",
+			Help:      "The number of deleted rows dropped by compacting growing segments in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeStorageBreakerOpen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "storage_breaker_open",
+			Help:      "Whether the object storage accesses fail fast after consecutive failures in QueryNode, 1 means open.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeFullyDeletedSegmentsSkipped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "fully_deleted_segments_skipped",
+			Help:      "The number of sealed segments skipped by search and query for all their rows are deleted in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+			queryTypeLabelName,
+		})
+
+	QueryNodePartitionKeyPrunedSegments = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "partition_key_pruned_segments",
+			Help:      "The number of segments skipped by search and query for their partitions are not routed to by the partition keys in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+			queryTypeLabelName,
+		})
+
+	QueryNodeQuarantinedSegments = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "quarantined_segments",
+			Help:      "The number of segments quarantined for the operations on them repeatedly failed or panicked in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeUnsupportedMsgs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "unsupported_msgs",
+			Help:      "The number of messages dropped by the flow graphs for their types are not supported in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+			msgTypeLabelName,
+		})
+
+	QueryNodeStaleIndexes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "stale_indexes",
+			Help:      "The number of segment indexes found stale for their index files were garbage collected in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeSearchConcurrencyLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "search_concurrency_limit",
+			Help:      "The current limit of the concurrent segment searches in segcore of QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeSearchConcurrencyWaitLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "search_concurrency_wait_latency",
+			Help:      "The latency of the segment searches waiting for the search concurrency limit in QueryNode.",
+			Buckets:   buckets,
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeCgoPoolQueueLength = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "cgo_pool_queue_length",
+			Help:      "The number of cgo calls waiting for the threads of the read or write cgo pool in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+			cgoPoolLabelName,
+		})
+
+	QueryNodeCgoPoolWaitLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "cgo_pool_wait_latency",
+			Help:      "The latency of the cgo calls waiting for the threads of the read or write cgo pool in QueryNode.",
+			Buckets:   buckets,
+		}, []string{
+			nodeIDLabelName,
+			cgoPoolLabelName,
+		})
+
+	QueryNodeCollectionReadInflight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "collection_read_inflight",
+			Help:      "The number of search and query requests of a collection being served by QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+			collectionIDLabelName,
+		})
+
+	QueryNodeCollectionReadRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "collection_read_rejected",
+			Help:      "The number of search and query requests of a collection rejected for its cap of concurrent reads in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+			collectionIDLabelName,
+		})
+
+	QueryNodePendingDeletes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "pending_deletes",
+			Help:      "The number of delete primary keys matching no growing segment kept for the inserts arriving later in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodePendingDeletesResolved = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "pending_deletes_resolved",
+			Help:      "The number of pending delete primary keys applied to the inserts arriving later, expired or dropped for the limit in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+			statusLabelName,
+		})
+
+	QueryNodeBufferedSealedDeletes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "buffered_sealed_deletes",
+			Help:      "The number of delete primary keys from delta channels matching no sealed segment buffered for the segments loaded later in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeBufferedSealedDeletesResolved = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "buffered_sealed_deletes_resolved",
+			Help:      "The number of buffered delete primary keys replayed against the sealed segments loaded later, expired before replayed or dropped for the limit in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+			statusLabelName,
+		})
+
+	QueryNodeSegmentDiskUsage = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "segment_disk_usage",
+			Help:      "The bytes of the local files of the segments, e.g. the raw vectors cached on disk, in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeConsumedRows = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "consumed_rows",
+			Help:      "The number of rows applied from the dml channels to the segments in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+			channelNameLabelName,
+			msgTypeLabelName,
+		})
+
+	QueryNodeConsumedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "consumed_bytes",
+			Help:      "The bytes of the messages applied from the dml channels to the segments in QueryNode.",
+		}, []string{
+			nodeIDLabelName,
+			channelNameLabelName,
+			msgTypeLabelName,
+		})
+
+	QueryNodeApplyLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "apply_latency",
+			Help:      "The latency from the timestamp of the dml messages to being applied to the segments in QueryNode.",
+			Buckets:   buckets,
+		}, []string{
+			nodeIDLabelName,
+			channelNameLabelName,
+			msgTypeLabelName,
+		})
 )
 
 //RegisterQueryNode registers QueryNode metrics
@@ -217,4 +604,40 @@ func RegisterQueryNode(registry *prometheus.Registry) {
 	registry.MustRegister(QueryNodeLoadSegmentLatency)
 	registry.MustRegister(QueryNodeServiceTime)
 	registry.MustRegister(QueryNodeNumFlowGraphs)
+	registry.MustRegister(QueryNodeFlowGraphPaused)
+	registry.MustRegister(QueryNodeSearchResultViolations)
+	registry.MustRegister(QueryNodeNumReapedGrowingSegments)
+	registry.MustRegister(QueryNodeCompactedGrowingRows)
+	registry.MustRegister(QueryNodeRetrieveBinlogFiles)
+	registry.MustRegister(QueryNodeRetrieveMaterializedBytes)
+	registry.MustRegister(QueryNodeSkewedGuaranteeTs)
+	registry.MustRegister(QueryNodeUnorderedDeleteBatches)
+	registry.MustRegister(QueryNodeStaleDeleteRecords)
+	registry.MustRegister(QueryNodeTimeTicks)
+	registry.MustRegister(QueryNodeAutoIDViolations)
+	registry.MustRegister(QueryNodeInvalidInsertPayloads)
+	registry.MustRegister(QueryNodeBloomFilterPrunedPKs)
+	registry.MustRegister(QueryNodeResultCompressRatio)
+	registry.MustRegister(QueryNodeResultCompressLatency)
+	registry.MustRegister(QueryNodeSearchDedupRatio)
+	registry.MustRegister(QueryNodeStorageBreakerOpen)
+	registry.MustRegister(QueryNodeFullyDeletedSegmentsSkipped)
+	registry.MustRegister(QueryNodePartitionKeyPrunedSegments)
+	registry.MustRegister(QueryNodeQuarantinedSegments)
+	registry.MustRegister(QueryNodeUnsupportedMsgs)
+	registry.MustRegister(QueryNodeStaleIndexes)
+	registry.MustRegister(QueryNodeSearchConcurrencyLimit)
+	registry.MustRegister(QueryNodeSearchConcurrencyWaitLatency)
+	registry.MustRegister(QueryNodeCgoPoolQueueLength)
+	registry.MustRegister(QueryNodeCgoPoolWaitLatency)
+	registry.MustRegister(QueryNodeCollectionReadInflight)
+	registry.MustRegister(QueryNodeCollectionReadRejected)
+	registry.MustRegister(QueryNodePendingDeletes)
+	registry.MustRegister(QueryNodePendingDeletesResolved)
+	registry.MustRegister(QueryNodeBufferedSealedDeletes)
+	registry.MustRegister(QueryNodeBufferedSealedDeletesResolved)
+	registry.MustRegister(QueryNodeSegmentDiskUsage)
+	registry.MustRegister(QueryNodeConsumedRows)
+	registry.MustRegister(QueryNodeConsumedBytes)
+	registry.MustRegister(QueryNodeApplyLatency)
 }
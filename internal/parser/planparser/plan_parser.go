@@ -14,7 +14,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package proxy
+package planparser
 
 import (
 	"fmt"
@@ -169,7 +169,8 @@ func (optimizer *optimizer) Exit(node *ant_ast.Node) {
 	}
 }
 
-func parseExpr(schema *typeutil.SchemaHelper, exprStr string) (*planpb.Expr, error) {
+// ParseExpr parses the boolean expression exprStr into planpb.Expr, nil if exprStr is empty
+func ParseExpr(schema *typeutil.SchemaHelper, exprStr string) (*planpb.Expr, error) {
 	if exprStr == "" {
 		return nil, nil
 	}
@@ -637,16 +638,31 @@ func (pc *parserContext) handleExpr(nodeRaw *ant_ast.Node) (*planpb.Expr, error)
 	}
 }
 
-func createQueryPlan(schemaPb *schemapb.CollectionSchema, exprStr string, vectorFieldName string, queryInfo *planpb.QueryInfo) (*planpb.PlanNode, error) {
+// CreateQueryPlan creates the plan of vector search on vectorFieldName filtered by exprStr
+func CreateQueryPlan(schemaPb *schemapb.CollectionSchema, exprStr string, vectorFieldName string, queryInfo *planpb.QueryInfo) (*planpb.PlanNode, error) {
 	schema, err := typeutil.CreateSchemaHelper(schemaPb)
 	if err != nil {
 		return nil, err
 	}
 
-	expr, err := parseExpr(schema, exprStr)
+	expr, err := ParseExpr(schema, exprStr)
 	if err != nil {
 		return nil, err
 	}
+	return createQueryPlan(schema, expr, vectorFieldName, queryInfo)
+}
+
+// CreateQueryPlanWithPredicates creates the plan of vector search on vectorFieldName filtered by the parsed expr,
+// nil expr means no filter
+func CreateQueryPlanWithPredicates(schemaPb *schemapb.CollectionSchema, expr *planpb.Expr, vectorFieldName string, queryInfo *planpb.QueryInfo) (*planpb.PlanNode, error) {
+	schema, err := typeutil.CreateSchemaHelper(schemaPb)
+	if err != nil {
+		return nil, err
+	}
+	return createQueryPlan(schema, expr, vectorFieldName, queryInfo)
+}
+
+func createQueryPlan(schema *typeutil.SchemaHelper, expr *planpb.Expr, vectorFieldName string, queryInfo *planpb.QueryInfo) (*planpb.PlanNode, error) {
 	vectorField, err := schema.GetFieldFromName(vectorFieldName)
 	if err != nil {
 		return nil, err
@@ -672,13 +688,14 @@ func createQueryPlan(schemaPb *schemapb.CollectionSchema, exprStr string, vector
 	return planNode, nil
 }
 
-func createExprPlan(schemaPb *schemapb.CollectionSchema, exprStr string) (*planpb.PlanNode, error) {
+// CreateExprPlan creates the plan of retrieving the entities matching exprStr
+func CreateExprPlan(schemaPb *schemapb.CollectionSchema, exprStr string) (*planpb.PlanNode, error) {
 	schema, err := typeutil.CreateSchemaHelper(schemaPb)
 	if err != nil {
 		return nil, err
 	}
 
-	expr, err := parseExpr(schema, exprStr)
+	expr, err := ParseExpr(schema, exprStr)
 	if err != nil {
 		return nil, err
 	}
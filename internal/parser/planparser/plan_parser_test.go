@@ -14,7 +14,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package proxy
+package planparser
 
 import (
 	"fmt"
@@ -68,7 +68,7 @@ func TestParseExpr_Naive(t *testing.T) {
 			"FloatField > -1.0",
 		}
 		for _, exprStr := range exprStrs {
-			exprProto, err := parseExpr(schema, exprStr)
+			exprProto, err := ParseExpr(schema, exprStr)
 			assert.Nil(t, err)
 			str := proto.MarshalTextString(exprProto)
 			println(str)
@@ -81,7 +81,7 @@ func TestParseExpr_Naive(t *testing.T) {
 			"FloatField > -aa",
 		}
 		for _, exprStr := range exprStrs {
-			exprProto, err := parseExpr(schema, exprStr)
+			exprProto, err := ParseExpr(schema, exprStr)
 			assert.Error(t, err)
 			assert.Nil(t, exprProto)
 		}
@@ -118,7 +118,7 @@ func TestParseExpr_Naive(t *testing.T) {
 			"FloatField > 1.0 ** 2.0",
 		}
 		for _, exprStr := range exprStrs {
-			exprProto, err := parseExpr(schema, exprStr)
+			exprProto, err := ParseExpr(schema, exprStr)
 			assert.Nil(t, err)
 			str := proto.MarshalTextString(exprProto)
 			println(str)
@@ -152,7 +152,7 @@ func TestParseExpr_Naive(t *testing.T) {
 			"FloatField > aa ** 2.0",
 		}
 		for _, exprStr := range exprStrs {
-			exprProto, err := parseExpr(schema, exprStr)
+			exprProto, err := ParseExpr(schema, exprStr)
 			assert.Error(t, err)
 			assert.Nil(t, exprProto)
 		}
@@ -180,7 +180,7 @@ func TestParsePlanNode_Naive(t *testing.T) {
 	// TODO: change it to better solution
 	for offset, exprStr := range exprStrs {
 		fmt.Printf("case %d: %s\n", offset, exprStr)
-		planProto, err := createQueryPlan(schema, exprStr, "FloatVectorField", queryInfo)
+		planProto, err := CreateQueryPlan(schema, exprStr, "FloatVectorField", queryInfo)
 		assert.Nil(t, err)
 		dbgStr := proto.MarshalTextString(planProto)
 		println(dbgStr)
@@ -188,6 +188,33 @@ func TestParsePlanNode_Naive(t *testing.T) {
 
 }
 
+func TestCreateQueryPlanWithPredicates(t *testing.T) {
+	schema := newTestSchema()
+	schemaHelper, err := typeutil.CreateSchemaHelper(schema)
+	assert.NoError(t, err)
+	queryInfo := &planpb.QueryInfo{
+		Topk:         10,
+		MetricType:   "L2",
+		SearchParams: "{\"nprobe\": 10}",
+	}
+
+	exprStr := "Int64Field < 3 and (Int64Field > 2 || Int64Field == 1)"
+	expr, err := ParseExpr(schemaHelper, exprStr)
+	assert.NoError(t, err)
+	plan, err := CreateQueryPlanWithPredicates(schema, expr, "FloatVectorField", queryInfo)
+	assert.NoError(t, err)
+	expected, err := CreateQueryPlan(schema, exprStr, "FloatVectorField", queryInfo)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(expected, plan))
+
+	plan, err = CreateQueryPlanWithPredicates(schema, nil, "FloatVectorField", queryInfo)
+	assert.NoError(t, err)
+	assert.Nil(t, plan.GetVectorAnns().GetPredicates())
+
+	_, err = CreateQueryPlanWithPredicates(schema, expr, "Int64Field", queryInfo)
+	assert.Error(t, err)
+}
+
 func TestExternalParser(t *testing.T) {
 	ast, err := ant_parser.Parse("!(1 < a < 2 or b in [1, 2, 3]) or (c < 3 and b > 5)")
 	// NOTE: probe ast here via IDE
@@ -216,7 +243,7 @@ func TestExprPlan_Str(t *testing.T) {
 	}
 
 	// without filter
-	planProto, err := createQueryPlan(schema, "", "fakevec", queryInfo)
+	planProto, err := CreateQueryPlan(schema, "", "fakevec", queryInfo)
 	assert.Nil(t, err)
 	dbgStr := proto.MarshalTextString(planProto)
 	println(dbgStr)
@@ -229,7 +256,7 @@ func TestExprPlan_Str(t *testing.T) {
 
 	for offset, exprStr := range exprStrs {
 		fmt.Printf("case %d: %s\n", offset, exprStr)
-		planProto, err := createQueryPlan(schema, exprStr, "fakevec", queryInfo)
+		planProto, err := CreateQueryPlan(schema, exprStr, "fakevec", queryInfo)
 		assert.Nil(t, err)
 		dbgStr := proto.MarshalTextString(planProto)
 		println(dbgStr)
@@ -281,14 +308,14 @@ func TestExprMultiRange_Str(t *testing.T) {
 
 	for offset, exprStr := range exprStrs {
 		fmt.Printf("case %d: %s\n", offset, exprStr)
-		planProto, err := createQueryPlan(schema, exprStr, "fakevec", queryInfo)
+		planProto, err := CreateQueryPlan(schema, exprStr, "fakevec", queryInfo)
 		assert.Nil(t, err)
 		dbgStr := proto.MarshalTextString(planProto)
 		println(dbgStr)
 	}
 	for offset, exprStr := range invalidExprs {
 		fmt.Printf("invalid case %d: %s\n", offset, exprStr)
-		planProto, err := createQueryPlan(schema, exprStr, "fakevec", queryInfo)
+		planProto, err := CreateQueryPlan(schema, exprStr, "fakevec", queryInfo)
 		assert.Error(t, err)
 		dbgStr := proto.MarshalTextString(planProto)
 		println(dbgStr)
@@ -323,7 +350,7 @@ func TestExprFieldCompare_Str(t *testing.T) {
 
 	for offset, exprStr := range exprStrs {
 		fmt.Printf("case %d: %s\n", offset, exprStr)
-		planProto, err := createQueryPlan(schema, exprStr, "fakevec", queryInfo)
+		planProto, err := CreateQueryPlan(schema, exprStr, "fakevec", queryInfo)
 		assert.Nil(t, err)
 		dbgStr := proto.MarshalTextString(planProto)
 		println(dbgStr)
@@ -56,6 +56,19 @@ const (
 	ErrorCode_DeleteCredentialFailure ErrorCode = 31
 	ErrorCode_GetCredentialFailure    ErrorCode = 32
 	ErrorCode_ListCredUsersFailure    ErrorCode = 33
+	// the request is rejected by the concurrency limits of the server, retry later
+	ErrorCode_RateLimit ErrorCode = 34
+	// the segment is released or not loaded by the node, retry with another replica
+	ErrorCode_SegmentReleased ErrorCode = 35
+	// the tSafe of the node lags behind the guarantee ts of the request too far, retry with another replica
+	ErrorCode_TSafeLagged ErrorCode = 36
+	// the object storage is unavailable to the node, retry later
+	ErrorCode_StorageUnavailable ErrorCode = 37
+	// the node is not ready to serve, retry with another replica
+	ErrorCode_NotReadyServe ErrorCode = 38
+	// the cursor of the paginated query is out of retention or the distribution of the shards has changed,
+	// restart the pagination without cursor
+	ErrorCode_CursorExpired ErrorCode = 39
 	// internal error code.
 	ErrorCode_DDRequestRace ErrorCode = 1000
 )
@@ -94,6 +107,12 @@ var ErrorCode_name = map[int32]string{
 	31:   "DeleteCredentialFailure",
 	32:   "GetCredentialFailure",
 	33:   "ListCredUsersFailure",
+	34:   "RateLimit",
+	35:   "SegmentReleased",
+	36:   "TSafeLagged",
+	37:   "StorageUnavailable",
+	38:   "NotReadyServe",
+	39:   "CursorExpired",
 	1000: "DDRequestRace",
 }
 
@@ -131,6 +150,12 @@ var ErrorCode_value = map[string]int32{
 	"DeleteCredentialFailure": 31,
 	"GetCredentialFailure":    32,
 	"ListCredUsersFailure":    33,
+	"RateLimit":               34,
+	"SegmentReleased":         35,
+	"TSafeLagged":             36,
+	"StorageUnavailable":      37,
+	"NotReadyServe":           38,
+	"CursorExpired":           39,
 	"DDRequestRace":           1000,
 }
 
@@ -972,115 +997,119 @@ func init() {
 func init() { proto.RegisterFile("common.proto", fileDescriptor_555bd8c177793206) }
 
 var fileDescriptor_555bd8c177793206 = []byte{
-	// 1747 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4b, 0x73, 0x1c, 0xb7,
-	0x11, 0xe6, 0xec, 0x2e, 0xb9, 0x5a, 0x2c, 0x1f, 0x10, 0xf8, 0x10, 0x2d, 0xd1, 0x8e, 0xc2, 0x93,
-	0x8a, 0x55, 0x96, 0x92, 0xa8, 0x2a, 0x39, 0xf9, 0x40, 0xee, 0x90, 0xd4, 0x96, 0x48, 0x8a, 0xd9,
-	0x25, 0x65, 0x57, 0x0e, 0x51, 0x81, 0x33, 0xcd, 0x5d, 0x44, 0x18, 0x60, 0x02, 0x60, 0x28, 0x6e,
-	0x4e, 0x8e, 0xf3, 0x07, 0x12, 0x5f, 0x72, 0xcd, 0x0f, 0x48, 0x52, 0x79, 0x27, 0x3f, 0x21, 0xef,
-	0x73, 0x9c, 0xf7, 0x31, 0x3f, 0x20, 0x4f, 0x3f, 0xe4, 0x54, 0x63, 0x66, 0x67, 0x46, 0x92, 0x7d,
-	0xf2, 0x0d, 0xfd, 0x75, 0xe3, 0x43, 0xa3, 0xbb, 0xd1, 0x0d, 0x32, 0x1f, 0xe9, 0x24, 0xd1, 0xea,
-	0x76, 0x6a, 0xb4, 0xd3, 0x6c, 0x39, 0x11, 0xf2, 0x22, 0xb3, 0xb9, 0x74, 0x3b, 0x57, 0x6d, 0x3e,
-	0x22, 0x73, 0x43, 0xc7, 0x5d, 0x66, 0xd9, 0x6b, 0x84, 0x80, 0x31, 0xda, 0x3c, 0x8a, 0x74, 0x0c,
-	0xeb, 0xc1, 0xcd, 0xe0, 0xd6, 0xe2, 0xe7, 0x5e, 0xb9, 0xfd, 0x11, 0x7b, 0x6e, 0xef, 0xa2, 0x59,
-	0x4f, 0xc7, 0x30, 0xe8, 0xc0, 0x74, 0xc9, 0xd6, 0xc8, 0x9c, 0x01, 0x6e, 0xb5, 0x5a, 0x6f, 0xdc,
-	0x0c, 0x6e, 0x75, 0x06, 0x85, 0xb4, 0xf9, 0x79, 0x32, 0x7f, 0x1f, 0x26, 0x0f, 0xb9, 0xcc, 0xe0,
-	0x98, 0x0b, 0xc3, 0x28, 0x69, 0x3e, 0x86, 0x89, 0xe7, 0xef, 0x0c, 0x70, 0xc9, 0x56, 0xc8, 0xec,
-	0x05, 0xaa, 0x8b, 0x8d, 0xb9, 0xb0, 0x79, 0x97, 0x74, 0xef, 0xc3, 0x24, 0xe4, 0x8e, 0x7f, 0xcc,
-	0x36, 0x46, 0x5a, 0x31, 0x77, 0xdc, 0xef, 0x9a, 0x1f, 0xf8, 0xf5, 0xe6, 0x06, 0x69, 0xed, 0x48,
-	0x7d, 0x56, 0x51, 0x06, 0x5e, 0x59, 0x50, 0xbe, 0x4a, 0xda, 0xdb, 0x71, 0x6c, 0xc0, 0x5a, 0xb6,
-	0x48, 0x1a, 0x22, 0x2d, 0xd8, 0x1a, 0x22, 0x45, 0xb2, 0x54, 0x1b, 0xe7, 0xc9, 0x9a, 0x03, 0xbf,
-	0xde, 0x7c, 0x3b, 0x20, 0xed, 0x43, 0x3b, 0xda, 0xe1, 0x16, 0xd8, 0x17, 0xc8, 0x95, 0xc4, 0x8e,
-	0x1e, 0xb9, 0x49, 0x3a, 0x0d, 0xcd, 0xc6, 0x47, 0x86, 0xe6, 0xd0, 0x8e, 0x4e, 0x26, 0x29, 0x0c,
-	0xda, 0x49, 0xbe, 0x40, 0x4f, 0x12, 0x3b, 0xea, 0x87, 0x05, 0x73, 0x2e, 0xb0, 0x0d, 0xd2, 0x71,
-	0x22, 0x01, 0xeb, 0x78, 0x92, 0xae, 0x37, 0x6f, 0x06, 0xb7, 0x5a, 0x83, 0x0a, 0x60, 0xd7, 0xc9,
-	0x15, 0xab, 0x33, 0x13, 0x41, 0x3f, 0x5c, 0x6f, 0xf9, 0x6d, 0xa5, 0xbc, 0xf9, 0x1a, 0xe9, 0x1c,
-	0xda, 0xd1, 0x3d, 0xe0, 0x31, 0x18, 0xf6, 0x19, 0xd2, 0x3a, 0xe3, 0x36, 0xf7, 0xa8, 0xfb, 0xf1,
-	0x1e, 0xe1, 0x0d, 0x06, 0xde, 0x72, 0xf3, 0xcb, 0x64, 0x3e, 0x3c, 0x3c, 0xf8, 0x04, 0x0c, 0xe8,
-	0xba, 0x1d, 0x73, 0x13, 0x1f, 0xf1, 0x64, 0x9a, 0xb1, 0x0a, 0xd8, 0x7a, 0x3a, 0x4b, 0x3a, 0x65,
-	0x79, 0xb0, 0x2e, 0x69, 0x0f, 0xb3, 0x28, 0x02, 0x6b, 0xe9, 0x0c, 0x5b, 0x26, 0x4b, 0xa7, 0x0a,
-	0x2e, 0x53, 0x88, 0x1c, 0xc4, 0xde, 0x86, 0x06, 0xec, 0x2a, 0x59, 0xe8, 0x69, 0xa5, 0x20, 0x72,
-	0x7b, 0x5c, 0x48, 0x88, 0x69, 0x83, 0xad, 0x10, 0x7a, 0x0c, 0x26, 0x11, 0xd6, 0x0a, 0xad, 0x42,
-	0x50, 0x02, 0x62, 0xda, 0x64, 0xd7, 0xc8, 0x72, 0x4f, 0x4b, 0x09, 0x91, 0x13, 0x5a, 0x1d, 0x69,
-	0xb7, 0x7b, 0x29, 0xac, 0xb3, 0xb4, 0x85, 0xb4, 0x7d, 0x29, 0x61, 0xc4, 0xe5, 0xb6, 0x19, 0x65,
-	0x09, 0x28, 0x47, 0x67, 0x91, 0xa3, 0x00, 0x43, 0x91, 0x80, 0x42, 0x26, 0xda, 0xae, 0xa1, 0x7d,
-	0x15, 0xc3, 0x25, 0xe6, 0x87, 0x5e, 0x61, 0x2f, 0x91, 0xd5, 0x02, 0xad, 0x1d, 0xc0, 0x13, 0xa0,
-	0x1d, 0xb6, 0x44, 0xba, 0x85, 0xea, 0xe4, 0xc1, 0xf1, 0x7d, 0x4a, 0x6a, 0x0c, 0x03, 0xfd, 0x64,
-	0x00, 0x91, 0x36, 0x31, 0xed, 0xd6, 0x5c, 0x78, 0x08, 0x91, 0xd3, 0xa6, 0x1f, 0xd2, 0x79, 0x74,
-	0xb8, 0x00, 0x87, 0xc0, 0x4d, 0x34, 0x1e, 0x80, 0xcd, 0xa4, 0xa3, 0x0b, 0x8c, 0x92, 0xf9, 0x3d,
-	0x21, 0xe1, 0x48, 0xbb, 0x3d, 0x9d, 0xa9, 0x98, 0x2e, 0xb2, 0x45, 0x42, 0x0e, 0xc1, 0xf1, 0x22,
-	0x02, 0x4b, 0x78, 0x6c, 0x8f, 0x47, 0x63, 0x28, 0x00, 0xca, 0xd6, 0x08, 0xeb, 0x71, 0xa5, 0xb4,
-	0xeb, 0x19, 0xe0, 0x0e, 0xf6, 0xb4, 0x8c, 0xc1, 0xd0, 0xab, 0xe8, 0xce, 0x33, 0xb8, 0x90, 0x40,
-	0x59, 0x65, 0x1d, 0x82, 0x84, 0xd2, 0x7a, 0xb9, 0xb2, 0x2e, 0x70, 0xb4, 0x5e, 0x41, 0xe7, 0x77,
-	0x32, 0x21, 0x63, 0x1f, 0x92, 0x3c, 0x2d, 0xab, 0xe8, 0x63, 0xe1, 0xfc, 0xd1, 0x41, 0x7f, 0x78,
-	0x42, 0xd7, 0xd8, 0x2a, 0xb9, 0x5a, 0x20, 0x87, 0xe0, 0x8c, 0x88, 0x7c, 0xf0, 0xae, 0xa1, 0xab,
-	0x0f, 0x32, 0xf7, 0xe0, 0xfc, 0x10, 0x12, 0x6d, 0x26, 0x74, 0x1d, 0x13, 0xea, 0x99, 0xa6, 0x29,
-	0xa2, 0x2f, 0xe1, 0x09, 0xbb, 0x49, 0xea, 0x26, 0x55, 0x78, 0xe9, 0x75, 0x76, 0x83, 0x5c, 0x3b,
-	0x4d, 0x63, 0xee, 0xa0, 0x9f, 0xe0, 0x63, 0x3b, 0xe1, 0xf6, 0x31, 0x5e, 0x37, 0x33, 0x40, 0x6f,
-	0xb0, 0xeb, 0x64, 0xed, 0xd9, 0x5c, 0x94, 0xc1, 0xda, 0xc0, 0x8d, 0xf9, 0x6d, 0x7b, 0x06, 0x62,
-	0x50, 0x4e, 0x70, 0x39, 0xdd, 0xf8, 0x72, 0xc5, 0xfa, 0xa2, 0xf2, 0x15, 0x54, 0xe6, 0x37, 0x7f,
-	0x51, 0xf9, 0x29, 0xb6, 0x4e, 0x56, 0xf6, 0xc1, 0xbd, 0xa8, 0xb9, 0x89, 0x9a, 0x03, 0x61, 0xbd,
-	0xea, 0xd4, 0x82, 0xb1, 0x53, 0xcd, 0xa7, 0x19, 0x23, 0x0b, 0x61, 0x38, 0x80, 0xaf, 0x66, 0x60,
-	0xdd, 0x80, 0x47, 0x40, 0xff, 0xd1, 0xde, 0x7a, 0x83, 0x10, 0x7f, 0x7f, 0x6c, 0xaa, 0xc0, 0x18,
-	0x59, 0xac, 0xa4, 0x23, 0xad, 0x80, 0xce, 0xb0, 0x79, 0x72, 0xe5, 0x54, 0x09, 0x6b, 0x33, 0x88,
-	0x69, 0x80, 0xb9, 0xef, 0xab, 0x63, 0xa3, 0x47, 0xd8, 0x96, 0x68, 0x03, 0xb5, 0x7b, 0x42, 0x09,
-	0x3b, 0xf6, 0x55, 0x4f, 0xc8, 0x5c, 0x51, 0x04, 0xad, 0xad, 0xb7, 0x02, 0x32, 0x3f, 0x84, 0x11,
-	0x56, 0x78, 0x4e, 0xbe, 0x42, 0x68, 0x5d, 0xae, 0xe8, 0xcb, 0xd8, 0x07, 0xf8, 0x02, 0xf7, 0x8d,
-	0x7e, 0x22, 0xd4, 0x88, 0x36, 0x90, 0x6d, 0x08, 0x5c, 0x7a, 0xe6, 0x2e, 0x69, 0xef, 0xc9, 0xcc,
-	0x1f, 0xd3, 0xf2, 0x87, 0xa2, 0x80, 0x66, 0xb3, 0xa8, 0x0a, 0x8d, 0x4e, 0x53, 0x88, 0xe9, 0x1c,
-	0x5b, 0x20, 0x9d, 0x3c, 0x43, 0xa8, 0x6b, 0x6f, 0xbd, 0x43, 0x7c, 0x4f, 0xf4, 0xad, 0x6d, 0x81,
-	0x74, 0x4e, 0x55, 0x0c, 0xe7, 0x42, 0x41, 0x4c, 0x67, 0x7c, 0x79, 0xe5, 0x89, 0xa9, 0xf2, 0x1c,
-	0x63, 0x04, 0x90, 0xac, 0x86, 0x01, 0xd6, 0xc8, 0x3d, 0x6e, 0x6b, 0xd0, 0x39, 0xd6, 0x6c, 0x08,
-	0x36, 0x32, 0xe2, 0xac, 0xbe, 0x7d, 0x84, 0xb5, 0x33, 0x1c, 0xeb, 0x27, 0x15, 0x66, 0xe9, 0x18,
-	0x4f, 0xda, 0x07, 0x37, 0x9c, 0x58, 0x07, 0x49, 0x4f, 0xab, 0x73, 0x31, 0xb2, 0x54, 0xe0, 0x49,
-	0x07, 0x9a, 0xc7, 0xb5, 0xed, 0x5f, 0xc1, 0xaa, 0x1d, 0x80, 0x04, 0x6e, 0xeb, 0xac, 0x8f, 0xfd,
-	0x03, 0xf3, 0xae, 0x6e, 0x4b, 0xc1, 0x2d, 0x95, 0x78, 0x15, 0xf4, 0x32, 0x17, 0x13, 0x4c, 0xca,
-	0xb6, 0x74, 0x60, 0x72, 0x59, 0xb1, 0x15, 0xb2, 0x94, 0xdb, 0x1f, 0x73, 0xe3, 0x84, 0x27, 0xf9,
-	0x65, 0xe0, 0xd3, 0x6f, 0x74, 0x5a, 0x61, 0xbf, 0xc2, 0x7e, 0x36, 0x7f, 0x8f, 0xdb, 0x0a, 0xfa,
-	0x75, 0xc0, 0xd6, 0xc8, 0xd5, 0xe9, 0xd5, 0x2a, 0xfc, 0x37, 0x01, 0x5b, 0x26, 0x8b, 0x78, 0xb5,
-	0x12, 0xb3, 0xf4, 0xb7, 0x1e, 0xc4, 0x4b, 0xd4, 0xc0, 0xdf, 0x79, 0x86, 0xe2, 0x16, 0x35, 0xfc,
-	0xf7, 0xfe, 0x30, 0x64, 0x28, 0x8a, 0xc0, 0xd2, 0x77, 0x03, 0xf4, 0x74, 0x7a, 0x58, 0x01, 0xd3,
-	0xf7, 0xbc, 0x21, 0xb2, 0x96, 0x86, 0xef, 0x7b, 0xc3, 0x82, 0xb3, 0x44, 0x3f, 0xf0, 0xe8, 0x3d,
-	0xae, 0x62, 0x7d, 0x7e, 0x5e, 0xa2, 0x4f, 0x03, 0xb6, 0x4e, 0x96, 0x71, 0xfb, 0x0e, 0x97, 0x5c,
-	0x45, 0x95, 0xfd, 0x87, 0x01, 0x5b, 0x25, 0xf4, 0xb9, 0xe3, 0x2c, 0x7d, 0xb3, 0xc1, 0xe8, 0x34,
-	0xbe, 0xbe, 0xf8, 0xe9, 0x77, 0x1b, 0x3e, 0x56, 0x85, 0x61, 0x8e, 0x7d, 0xaf, 0xc1, 0x16, 0xf3,
-	0xa0, 0xe7, 0xf2, 0xf7, 0x1b, 0xac, 0x4b, 0xe6, 0xfa, 0xca, 0x82, 0x71, 0xf4, 0x9b, 0x58, 0x9f,
-	0x73, 0xf9, 0x63, 0xa5, 0xdf, 0xc2, 0x67, 0x30, 0xeb, 0xeb, 0x93, 0xbe, 0xed, 0x15, 0x79, 0x43,
-	0xa5, 0xff, 0x6c, 0xfa, 0x08, 0xd4, 0xbb, 0xeb, 0xbf, 0x9a, 0x78, 0xd2, 0x3e, 0xb8, 0xea, 0xd5,
-	0xd1, 0x7f, 0x37, 0xd9, 0x75, 0xb2, 0x3a, 0xc5, 0x7c, 0xaf, 0x2b, 0xdf, 0xdb, 0x7f, 0x9a, 0x6c,
-	0x83, 0x5c, 0xc3, 0x87, 0x5f, 0x96, 0x07, 0x6e, 0x12, 0xd6, 0x89, 0xc8, 0xd2, 0xff, 0x36, 0xd9,
-	0x0d, 0xb2, 0xb6, 0x0f, 0xae, 0x0c, 0x7b, 0x4d, 0xf9, 0xbf, 0x26, 0x5b, 0x20, 0x57, 0x06, 0xd8,
-	0x0c, 0xe1, 0x02, 0xe8, 0xbb, 0x4d, 0xcc, 0xdd, 0x54, 0x2c, 0xdc, 0x79, 0xaf, 0x89, 0x11, 0x7d,
-	0x9d, 0xbb, 0x68, 0x1c, 0x26, 0xbd, 0x31, 0x57, 0x0a, 0xa4, 0xa5, 0xef, 0x37, 0x31, 0x6e, 0x03,
-	0x48, 0xf4, 0x05, 0xd4, 0xe0, 0x0f, 0x70, 0xc8, 0x31, 0x6f, 0xfc, 0xc5, 0x0c, 0xcc, 0xa4, 0x54,
-	0x3c, 0x6d, 0x62, 0x06, 0x72, 0xfb, 0x67, 0x35, 0x1f, 0x36, 0xd9, 0xcb, 0x64, 0x3d, 0x7f, 0xd3,
-	0xd3, 0xf8, 0xa3, 0x72, 0x04, 0x7d, 0x75, 0xae, 0xe9, 0x9b, 0xad, 0x92, 0x31, 0x04, 0xe9, 0x78,
-	0xb9, 0xef, 0xeb, 0x2d, 0xf4, 0x0b, 0xdf, 0x10, 0x0e, 0xee, 0x03, 0xff, 0x15, 0xb0, 0xf4, 0xad,
-	0x16, 0x26, 0x6e, 0x1f, 0xdc, 0x00, 0x52, 0x29, 0x22, 0x6e, 0xe9, 0x37, 0x3c, 0x52, 0x30, 0x7b,
-	0xca, 0x3f, 0xb4, 0xd8, 0x12, 0x21, 0xf9, 0xd3, 0xf3, 0xc0, 0x3b, 0x53, 0x2a, 0x9c, 0x86, 0x17,
-	0x60, 0x26, 0x1e, 0xfd, 0x63, 0x79, 0x40, 0xad, 0x41, 0xd1, 0x3f, 0xb5, 0x30, 0x64, 0x27, 0x22,
-	0x81, 0x13, 0x11, 0x3d, 0xa6, 0x3f, 0xe8, 0x60, 0xc8, 0xfc, 0x8d, 0x8e, 0x74, 0x0c, 0x68, 0x63,
-	0xe9, 0x0f, 0x3b, 0x58, 0x17, 0x58, 0x6e, 0x79, 0x5d, 0xfc, 0xc8, 0xcb, 0x45, 0x93, 0xed, 0x87,
-	0xf4, 0xc7, 0x38, 0x95, 0x49, 0x21, 0x9f, 0x0c, 0x1f, 0xd0, 0x9f, 0x74, 0xf0, 0xa8, 0x6d, 0x29,
-	0x75, 0xc4, 0x5d, 0x59, 0xf4, 0x3f, 0xed, 0xe0, 0xab, 0xa9, 0x9d, 0x5e, 0x64, 0xed, 0x67, 0x1d,
-	0x8c, 0x7d, 0x81, 0xfb, 0x9a, 0x0a, 0xb1, 0x6d, 0xfe, 0xdc, 0xb3, 0xe2, 0x67, 0x13, 0x3d, 0x39,
-	0x71, 0xf4, 0x17, 0xde, 0xee, 0xf9, 0x41, 0x43, 0xff, 0xdc, 0x2d, 0xea, 0xab, 0x86, 0xfd, 0xa5,
-	0x9b, 0x3f, 0x83, 0x67, 0x27, 0x0b, 0xfd, 0xab, 0x87, 0x9f, 0x9f, 0x46, 0xf4, 0x6f, 0x5d, 0x74,
-	0xac, 0x3e, 0x50, 0x14, 0x4f, 0xc0, 0xd2, 0xbf, 0x77, 0xb7, 0x36, 0x49, 0x3b, 0xb4, 0xd2, 0xb7,
-	0xd6, 0x36, 0x69, 0x86, 0x56, 0xd2, 0x19, 0xec, 0x44, 0x3b, 0x5a, 0xcb, 0xdd, 0xcb, 0xd4, 0x3c,
-	0xfc, 0x2c, 0x0d, 0xb6, 0x76, 0xc8, 0x52, 0x4f, 0x27, 0x29, 0x2f, 0x4b, 0xd5, 0x77, 0xd3, 0xbc,
-	0x0d, 0x43, 0x9c, 0x87, 0x79, 0x06, 0xdb, 0xd9, 0xee, 0x25, 0x44, 0x99, 0x6f, 0xda, 0x01, 0x8a,
-	0xb8, 0x09, 0x1d, 0x8c, 0x69, 0x63, 0xeb, 0x0d, 0x42, 0x7b, 0x5a, 0x59, 0x61, 0x1d, 0xa8, 0x68,
-	0x72, 0x00, 0x17, 0x20, 0xfd, 0x68, 0x70, 0x46, 0xab, 0x11, 0x9d, 0xf1, 0xbf, 0x36, 0xf0, 0xbf,
-	0xaf, 0x7c, 0x80, 0xec, 0xe0, 0xe4, 0xf5, 0x5f, 0xb3, 0x45, 0x42, 0x76, 0x2f, 0x40, 0xb9, 0x8c,
-	0x4b, 0x39, 0xa1, 0x4d, 0x94, 0x7b, 0x99, 0x75, 0x3a, 0x11, 0x5f, 0xf3, 0x23, 0xea, 0xdb, 0x01,
-	0xe9, 0xe6, 0xd3, 0xa2, 0x74, 0x2d, 0x17, 0x8f, 0x41, 0xc5, 0xc2, 0x93, 0xe3, 0xcf, 0xc2, 0x43,
-	0xc5, 0x5c, 0x0b, 0x2a, 0xa3, 0xa1, 0xe3, 0xc6, 0x4d, 0xbf, 0x80, 0x39, 0x14, 0xea, 0x27, 0x4a,
-	0x6a, 0x1e, 0xfb, 0x91, 0x55, 0x6e, 0x3d, 0xe6, 0xc6, 0xfa, 0xb9, 0x85, 0x1f, 0xaf, 0x82, 0xdf,
-	0xf8, 0xfb, 0xc4, 0x74, 0xb6, 0x02, 0xab, 0x3b, 0xcf, 0xed, 0xbc, 0x4e, 0x16, 0x85, 0x9e, 0xfe,
-	0x6e, 0x47, 0x26, 0x8d, 0x76, 0xba, 0x3d, 0xff, 0xbb, 0x3d, 0xc6, 0x9f, 0xee, 0x71, 0xf0, 0xa5,
-	0xbb, 0x23, 0xe1, 0xc6, 0xd9, 0x19, 0xfe, 0x79, 0xef, 0xe4, 0x66, 0xaf, 0x0a, 0x5d, 0xac, 0xee,
-	0x08, 0xe5, 0x30, 0x4f, 0xf2, 0x8e, 0xff, 0x17, 0xdf, 0xc9, 0xff, 0xc5, 0xe9, 0xd9, 0x77, 0x82,
-	0xe0, 0x6c, 0xce, 0x43, 0x77, 0xff, 0x1f, 0x00, 0x00, 0xff, 0xff, 0x17, 0xcc, 0xd7, 0x5c, 0x6b,
-	0x0d, 0x00, 0x00,
+	// 1817 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x49, 0x73, 0x5b, 0xb9,
+	0x11, 0xd6, 0x23, 0x29, 0x51, 0x04, 0xb5, 0xb4, 0xa1, 0xc5, 0x1a, 0x5b, 0x33, 0x71, 0x94, 0xcd,
+	0xa5, 0xaa, 0xb1, 0x93, 0xb8, 0x2a, 0x39, 0xcd, 0x41, 0x22, 0x25, 0x99, 0x65, 0x49, 0x56, 0x48,
+	0xc9, 0x33, 0x95, 0x43, 0x5c, 0xd0, 0x7b, 0x2d, 0x0a, 0x31, 0x1e, 0xc0, 0x00, 0x78, 0xb2, 0x98,
+	0xd3, 0x64, 0xf2, 0x07, 0x92, 0xb9, 0xe4, 0x9a, 0x1f, 0x90, 0xa4, 0xb2, 0x27, 0x3f, 0x21, 0xfb,
+	0x39, 0x93, 0xfd, 0x98, 0x6b, 0xaa, 0xb2, 0xce, 0x9a, 0x6a, 0xbc, 0xc7, 0x47, 0xda, 0x9e, 0x39,
+	0xcd, 0x0d, 0xfd, 0x75, 0xe3, 0x43, 0xa3, 0xbb, 0xd1, 0x68, 0x36, 0x17, 0x9b, 0x34, 0x35, 0xfa,
+	0xd6, 0xc0, 0x1a, 0x6f, 0xf8, 0x52, 0x2a, 0xd5, 0x45, 0xe6, 0x72, 0xe9, 0x56, 0xae, 0xda, 0x78,
+	0xc8, 0x66, 0x7a, 0x5e, 0xf8, 0xcc, 0xf1, 0x97, 0x18, 0x43, 0x6b, 0x8d, 0x7d, 0x18, 0x9b, 0x04,
+	0xd7, 0xa2, 0x1b, 0xd1, 0xcd, 0x85, 0xcf, 0xbe, 0x70, 0xeb, 0x7d, 0xf6, 0xdc, 0xda, 0x21, 0xb3,
+	0x96, 0x49, 0xb0, 0xdb, 0xc0, 0xd1, 0x92, 0xaf, 0xb2, 0x19, 0x8b, 0xc2, 0x19, 0xbd, 0x56, 0xb9,
+	0x11, 0xdd, 0x6c, 0x74, 0x0b, 0x69, 0xe3, 0x73, 0x6c, 0xee, 0x1e, 0x0e, 0x1f, 0x08, 0x95, 0xe1,
+	0x91, 0x90, 0x96, 0x03, 0xab, 0x3e, 0xc2, 0x61, 0xe0, 0x6f, 0x74, 0x69, 0xc9, 0x97, 0xd9, 0xf4,
+	0x05, 0xa9, 0x8b, 0x8d, 0xb9, 0xb0, 0x71, 0x87, 0x35, 0xef, 0xe1, 0xb0, 0x2d, 0xbc, 0xf8, 0x80,
+	0x6d, 0x9c, 0xd5, 0x12, 0xe1, 0x45, 0xd8, 0x35, 0xd7, 0x0d, 0xeb, 0x8d, 0x75, 0x56, 0xdb, 0x56,
+	0xe6, 0x74, 0x4c, 0x19, 0x05, 0x65, 0x41, 0xf9, 0x22, 0xab, 0x6f, 0x25, 0x89, 0x45, 0xe7, 0xf8,
+	0x02, 0xab, 0xc8, 0x41, 0xc1, 0x56, 0x91, 0x03, 0x22, 0x1b, 0x18, 0xeb, 0x03, 0x59, 0xb5, 0x1b,
+	0xd6, 0x1b, 0xaf, 0x47, 0xac, 0x7e, 0xe0, 0xfa, 0xdb, 0xc2, 0x21, 0xff, 0x3c, 0x9b, 0x4d, 0x5d,
+	0xff, 0xa1, 0x1f, 0x0e, 0x46, 0xa1, 0x59, 0x7f, 0xdf, 0xd0, 0x1c, 0xb8, 0xfe, 0xf1, 0x70, 0x80,
+	0xdd, 0x7a, 0x9a, 0x2f, 0xc8, 0x93, 0xd4, 0xf5, 0x3b, 0xed, 0x82, 0x39, 0x17, 0xf8, 0x3a, 0x6b,
+	0x78, 0x99, 0xa2, 0xf3, 0x22, 0x1d, 0xac, 0x55, 0x6f, 0x44, 0x37, 0x6b, 0xdd, 0x31, 0xc0, 0xaf,
+	0xb1, 0x59, 0x67, 0x32, 0x1b, 0x63, 0xa7, 0xbd, 0x56, 0x0b, 0xdb, 0x4a, 0x79, 0xe3, 0x25, 0xd6,
+	0x38, 0x70, 0xfd, 0xbb, 0x28, 0x12, 0xb4, 0xfc, 0xd3, 0xac, 0x76, 0x2a, 0x5c, 0xee, 0x51, 0xf3,
+	0x83, 0x3d, 0xa2, 0x1b, 0x74, 0x83, 0xe5, 0xc6, 0x97, 0xd8, 0x5c, 0xfb, 0x60, 0xff, 0x43, 0x30,
+	0x90, 0xeb, 0xee, 0x5c, 0xd8, 0xe4, 0x50, 0xa4, 0xa3, 0x8c, 0x8d, 0x81, 0xcd, 0x7f, 0xcc, 0xb0,
+	0x46, 0x59, 0x1e, 0xbc, 0xc9, 0xea, 0xbd, 0x2c, 0x8e, 0xd1, 0x39, 0x98, 0xe2, 0x4b, 0x6c, 0xf1,
+	0x44, 0xe3, 0xe5, 0x00, 0x63, 0x8f, 0x49, 0xb0, 0x81, 0x88, 0x5f, 0x61, 0xf3, 0x2d, 0xa3, 0x35,
+	0xc6, 0x7e, 0x57, 0x48, 0x85, 0x09, 0x54, 0xf8, 0x32, 0x83, 0x23, 0xb4, 0xa9, 0x74, 0x4e, 0x1a,
+	0xdd, 0x46, 0x2d, 0x31, 0x81, 0x2a, 0xbf, 0xca, 0x96, 0x5a, 0x46, 0x29, 0x8c, 0xbd, 0x34, 0xfa,
+	0xd0, 0xf8, 0x9d, 0x4b, 0xe9, 0xbc, 0x83, 0x1a, 0xd1, 0x76, 0x94, 0xc2, 0xbe, 0x50, 0x5b, 0xb6,
+	0x9f, 0xa5, 0xa8, 0x3d, 0x4c, 0x13, 0x47, 0x01, 0xb6, 0x65, 0x8a, 0x9a, 0x98, 0xa0, 0x3e, 0x81,
+	0x76, 0x74, 0x82, 0x97, 0x94, 0x1f, 0x98, 0xe5, 0xcf, 0xb1, 0x95, 0x02, 0x9d, 0x38, 0x40, 0xa4,
+	0x08, 0x0d, 0xbe, 0xc8, 0x9a, 0x85, 0xea, 0xf8, 0xfe, 0xd1, 0x3d, 0x60, 0x13, 0x0c, 0x5d, 0xf3,
+	0xb8, 0x8b, 0xb1, 0xb1, 0x09, 0x34, 0x27, 0x5c, 0x78, 0x80, 0xb1, 0x37, 0xb6, 0xd3, 0x86, 0x39,
+	0x72, 0xb8, 0x00, 0x7b, 0x28, 0x6c, 0x7c, 0xde, 0x45, 0x97, 0x29, 0x0f, 0xf3, 0x1c, 0xd8, 0xdc,
+	0xae, 0x54, 0x78, 0x68, 0xfc, 0xae, 0xc9, 0x74, 0x02, 0x0b, 0x7c, 0x81, 0xb1, 0x03, 0xf4, 0xa2,
+	0x88, 0xc0, 0x22, 0x1d, 0xdb, 0x12, 0xf1, 0x39, 0x16, 0x00, 0xf0, 0x55, 0xc6, 0x5b, 0x42, 0x6b,
+	0xe3, 0x5b, 0x16, 0x85, 0xc7, 0x5d, 0xa3, 0x12, 0xb4, 0x70, 0x85, 0xdc, 0x79, 0x02, 0x97, 0x0a,
+	0x81, 0x8f, 0xad, 0xdb, 0xa8, 0xb0, 0xb4, 0x5e, 0x1a, 0x5b, 0x17, 0x38, 0x59, 0x2f, 0x93, 0xf3,
+	0xdb, 0x99, 0x54, 0x49, 0x08, 0x49, 0x9e, 0x96, 0x15, 0xf2, 0xb1, 0x70, 0xfe, 0x70, 0xbf, 0xd3,
+	0x3b, 0x86, 0x55, 0xbe, 0xc2, 0xae, 0x14, 0xc8, 0x01, 0x7a, 0x2b, 0xe3, 0x10, 0xbc, 0xab, 0xe4,
+	0xea, 0xfd, 0xcc, 0xdf, 0x3f, 0x3b, 0xc0, 0xd4, 0xd8, 0x21, 0xac, 0x51, 0x42, 0x03, 0xd3, 0x28,
+	0x45, 0xf0, 0x1c, 0x9d, 0xb0, 0x93, 0x0e, 0xfc, 0x70, 0x1c, 0x5e, 0xb8, 0xc6, 0xaf, 0xb3, 0xab,
+	0x27, 0x83, 0x44, 0x78, 0xec, 0xa4, 0xf4, 0xd8, 0x8e, 0x85, 0x7b, 0x44, 0xd7, 0xcd, 0x2c, 0xc2,
+	0x75, 0x7e, 0x8d, 0xad, 0x3e, 0x99, 0x8b, 0x32, 0x58, 0xeb, 0xb4, 0x31, 0xbf, 0x6d, 0xcb, 0x62,
+	0x82, 0xda, 0x4b, 0xa1, 0x46, 0x1b, 0x9f, 0x1f, 0xb3, 0x3e, 0xab, 0x7c, 0x81, 0x94, 0xf9, 0xcd,
+	0x9f, 0x55, 0x7e, 0x84, 0xaf, 0xb1, 0xe5, 0x3d, 0xf4, 0xcf, 0x6a, 0x6e, 0x90, 0x66, 0x5f, 0xba,
+	0xa0, 0x3a, 0x71, 0x68, 0xdd, 0x48, 0xf3, 0x51, 0xce, 0xd9, 0x7c, 0xbb, 0xdd, 0xc5, 0xaf, 0x64,
+	0xe8, 0x7c, 0x57, 0xc4, 0x08, 0x7f, 0xaf, 0xf3, 0x79, 0xd6, 0xe8, 0x0a, 0x8f, 0xfb, 0x32, 0x95,
+	0x1e, 0x36, 0xe8, 0xee, 0x3d, 0xec, 0x53, 0x55, 0x76, 0x51, 0xa1, 0x70, 0x98, 0xc0, 0xc7, 0x28,
+	0x68, 0xc7, 0x3d, 0x71, 0x86, 0xfb, 0xa2, 0xdf, 0xc7, 0x04, 0x3e, 0x4e, 0x19, 0xeb, 0x79, 0x63,
+	0x45, 0x1f, 0x4f, 0xb4, 0xb8, 0x10, 0x52, 0x89, 0x53, 0x85, 0xf0, 0x09, 0x0a, 0xe6, 0xa1, 0xf1,
+	0x5d, 0x14, 0xc9, 0xb0, 0x87, 0xf6, 0x02, 0xe1, 0x93, 0xe1, 0xc1, 0x64, 0xd6, 0x19, 0xbb, 0x73,
+	0x39, 0x90, 0x16, 0x13, 0xf8, 0xd4, 0xe6, 0x2b, 0x8c, 0x85, 0x90, 0x53, 0x1f, 0x47, 0xce, 0xd9,
+	0xc2, 0x58, 0x3a, 0x34, 0x1a, 0x61, 0x8a, 0xcf, 0xb1, 0xd9, 0x13, 0x2d, 0x9d, 0xcb, 0x30, 0x81,
+	0x88, 0xca, 0xad, 0xa3, 0x8f, 0xac, 0xe9, 0x53, 0x27, 0x84, 0x0a, 0x69, 0x77, 0xa5, 0x96, 0xee,
+	0x3c, 0x3c, 0x34, 0xc6, 0x66, 0x8a, 0xba, 0xab, 0x6d, 0xbe, 0x16, 0xb1, 0xb9, 0xc2, 0xfd, 0x9c,
+	0x7c, 0x99, 0xc1, 0xa4, 0x3c, 0xa6, 0x2f, 0xd3, 0x1d, 0xd1, 0xa3, 0xdf, 0xb3, 0xe6, 0xb1, 0xd4,
+	0x7d, 0xa8, 0x10, 0x5b, 0x0f, 0x85, 0x0a, 0xcc, 0x4d, 0x56, 0xdf, 0x55, 0x59, 0x38, 0xa6, 0x16,
+	0x0e, 0x25, 0x81, 0xcc, 0xa6, 0x49, 0xd5, 0xb6, 0x66, 0x30, 0xc0, 0x04, 0x66, 0x28, 0x84, 0x79,
+	0x51, 0x90, 0xae, 0xbe, 0xf9, 0x06, 0x0b, 0x6d, 0x38, 0x74, 0xd3, 0x79, 0xd6, 0x38, 0xd1, 0x09,
+	0x9e, 0x49, 0x8d, 0x09, 0x4c, 0x85, 0x8a, 0xce, 0x6b, 0x61, 0x5c, 0x5a, 0x09, 0x45, 0x80, 0xc8,
+	0x26, 0x30, 0xa4, 0xb0, 0xdd, 0x15, 0x6e, 0x02, 0x3a, 0xa3, 0xa0, 0xb7, 0xd1, 0xc5, 0x56, 0x9e,
+	0x4e, 0x6e, 0xef, 0x87, 0x94, 0x9d, 0x9b, 0xc7, 0x63, 0xcc, 0xc1, 0x39, 0x9d, 0xb4, 0x87, 0xbe,
+	0x37, 0x74, 0x1e, 0xd3, 0x96, 0xd1, 0x67, 0xb2, 0xef, 0x40, 0xd2, 0x49, 0xfb, 0x46, 0x24, 0x13,
+	0xdb, 0xbf, 0x4c, 0x0f, 0xa5, 0x48, 0xf5, 0x04, 0xfc, 0x28, 0xbc, 0xe9, 0xe0, 0xea, 0x96, 0x92,
+	0xc2, 0x81, 0xa2, 0xab, 0x90, 0x97, 0xb9, 0x98, 0x52, 0x52, 0xb6, 0x94, 0x47, 0x9b, 0xcb, 0x9a,
+	0x2f, 0xb3, 0xc5, 0xdc, 0xfe, 0x48, 0x58, 0x2f, 0x03, 0xc9, 0x2f, 0xa2, 0x50, 0x71, 0xd6, 0x0c,
+	0xc6, 0xd8, 0x2f, 0xa9, 0x85, 0xce, 0xdd, 0x15, 0x6e, 0x0c, 0xfd, 0x2a, 0xe2, 0xab, 0xec, 0xca,
+	0xe8, 0x6a, 0x63, 0xfc, 0xd7, 0x11, 0x5f, 0x62, 0x0b, 0x74, 0xb5, 0x12, 0x73, 0xf0, 0x9b, 0x00,
+	0xd2, 0x25, 0x26, 0xc0, 0xdf, 0x06, 0x86, 0xe2, 0x16, 0x13, 0xf8, 0xef, 0xc2, 0x61, 0xc4, 0x50,
+	0x14, 0x81, 0x83, 0x37, 0x23, 0xf2, 0x74, 0x74, 0x58, 0x01, 0xc3, 0x5b, 0xc1, 0x90, 0x58, 0x4b,
+	0xc3, 0xb7, 0x83, 0x61, 0xc1, 0x59, 0xa2, 0xef, 0x04, 0xf4, 0xae, 0xd0, 0x89, 0x39, 0x3b, 0x2b,
+	0xd1, 0x77, 0x23, 0xbe, 0xc6, 0x96, 0x68, 0xfb, 0xb6, 0x50, 0x42, 0xc7, 0x63, 0xfb, 0xf7, 0x22,
+	0xbe, 0xc2, 0xe0, 0xa9, 0xe3, 0x1c, 0xbc, 0x5a, 0xe1, 0x30, 0x8a, 0x6f, 0x28, 0x7e, 0xf8, 0x4e,
+	0x25, 0xc4, 0xaa, 0x30, 0xcc, 0xb1, 0xef, 0x56, 0xf8, 0x42, 0x1e, 0xf4, 0x5c, 0xfe, 0x5e, 0x85,
+	0x37, 0xd9, 0x4c, 0x47, 0x3b, 0xb4, 0x1e, 0xbe, 0x41, 0xf5, 0x39, 0x93, 0xf7, 0x07, 0xf8, 0x26,
+	0x3d, 0x83, 0xe9, 0x50, 0x9f, 0xf0, 0x7a, 0x50, 0xe4, 0x3d, 0x1c, 0xfe, 0x59, 0x0d, 0x11, 0x98,
+	0x6c, 0xe8, 0xff, 0xaa, 0xd2, 0x49, 0x7b, 0xe8, 0xc7, 0xaf, 0x0e, 0xfe, 0x5d, 0xe5, 0xd7, 0xd8,
+	0xca, 0x08, 0x0b, 0xed, 0xb5, 0x7c, 0x6f, 0xff, 0xa9, 0xf2, 0x75, 0x76, 0x95, 0x7a, 0x4d, 0x59,
+	0x1e, 0xb4, 0x49, 0x3a, 0x2f, 0x63, 0x07, 0xff, 0xad, 0xf2, 0xeb, 0x6c, 0x75, 0x0f, 0x7d, 0x19,
+	0xf6, 0x09, 0xe5, 0xff, 0xaa, 0x7c, 0x9e, 0xcd, 0x76, 0xa9, 0xff, 0xe2, 0x05, 0xc2, 0x9b, 0x55,
+	0xca, 0xdd, 0x48, 0x2c, 0xdc, 0x79, 0xab, 0x4a, 0x11, 0x7d, 0x59, 0xf8, 0xf8, 0xbc, 0x9d, 0xb6,
+	0xce, 0x85, 0xd6, 0xa8, 0x1c, 0xbc, 0x5d, 0xa5, 0xb8, 0x75, 0x31, 0x35, 0x17, 0x38, 0x01, 0xbf,
+	0x43, 0xff, 0x2a, 0x0f, 0xc6, 0x5f, 0xc8, 0xd0, 0x0e, 0x4b, 0xc5, 0xbb, 0x55, 0xca, 0x40, 0x6e,
+	0xff, 0xa4, 0xe6, 0xbd, 0x2a, 0x7f, 0x9e, 0xad, 0xe5, 0x6f, 0x7a, 0x14, 0x7f, 0x52, 0xf6, 0xb1,
+	0xa3, 0xcf, 0x0c, 0xbc, 0x5a, 0x2b, 0x19, 0xdb, 0xa8, 0xbc, 0x28, 0xf7, 0x7d, 0xad, 0x46, 0x7e,
+	0xd1, 0x1b, 0xa2, 0x59, 0x61, 0x3f, 0x4c, 0x1f, 0x0e, 0x5e, 0xab, 0x51, 0xe2, 0xf6, 0xd0, 0x77,
+	0x71, 0xa0, 0x64, 0x2c, 0x1c, 0x7c, 0x3d, 0x20, 0x05, 0x73, 0xa0, 0xfc, 0x7d, 0x8d, 0x2f, 0x32,
+	0x96, 0x3f, 0xbd, 0x00, 0xbc, 0x31, 0xa2, 0xa2, 0x0f, 0xf8, 0x02, 0xed, 0x30, 0xa0, 0x7f, 0x28,
+	0x0f, 0x98, 0x68, 0x50, 0xf0, 0xc7, 0x1a, 0x85, 0xec, 0x58, 0xa6, 0x78, 0x2c, 0xe3, 0x47, 0xf0,
+	0xfd, 0x06, 0x85, 0x2c, 0xdc, 0xe8, 0xd0, 0x24, 0x48, 0x36, 0x0e, 0x7e, 0xd0, 0xa0, 0xba, 0xa0,
+	0x72, 0xcb, 0xeb, 0xe2, 0x87, 0x41, 0x2e, 0xfa, 0x7a, 0xa7, 0x0d, 0x3f, 0xa2, 0x41, 0x80, 0x15,
+	0xf2, 0x71, 0xef, 0x3e, 0xfc, 0xb8, 0x41, 0x47, 0x6d, 0x29, 0x65, 0x62, 0xe1, 0xcb, 0xa2, 0xff,
+	0x49, 0x83, 0x5e, 0xcd, 0xc4, 0xe9, 0x45, 0xd6, 0x7e, 0xda, 0xa0, 0xd8, 0x17, 0x78, 0xa8, 0xa9,
+	0x36, 0xb5, 0xcd, 0x9f, 0x05, 0x56, 0x9a, 0x6f, 0xc9, 0x93, 0x63, 0x0f, 0x3f, 0x0f, 0x76, 0x4f,
+	0xff, 0x6d, 0xf0, 0xa7, 0x66, 0x51, 0x5f, 0x13, 0xd8, 0x9f, 0x9b, 0xf9, 0x33, 0x78, 0xf2, 0x33,
+	0x83, 0xbf, 0x04, 0xf8, 0xe9, 0x0f, 0x10, 0xfe, 0xda, 0x24, 0xc7, 0x26, 0xff, 0x30, 0x2d, 0x52,
+	0x74, 0xf0, 0xb7, 0xe6, 0xe6, 0x06, 0xab, 0xb7, 0x9d, 0x0a, 0xad, 0xb5, 0xce, 0xaa, 0x6d, 0xa7,
+	0x60, 0x8a, 0x3a, 0xd1, 0xb6, 0x31, 0x6a, 0xe7, 0x72, 0x60, 0x1f, 0x7c, 0x06, 0xa2, 0xcd, 0x6d,
+	0xb6, 0xd8, 0x32, 0xe9, 0x40, 0x94, 0xa5, 0x1a, 0xba, 0x69, 0xde, 0x86, 0x31, 0xc9, 0xc3, 0x3c,
+	0x45, 0xed, 0x6c, 0xe7, 0x12, 0xe3, 0x2c, 0x34, 0xed, 0x88, 0x44, 0xda, 0x44, 0x0e, 0x26, 0x50,
+	0xd9, 0x7c, 0x85, 0x41, 0xcb, 0x68, 0x27, 0x9d, 0x47, 0x1d, 0x0f, 0xf7, 0xf1, 0x02, 0x55, 0xf8,
+	0x1a, 0xbc, 0x35, 0xba, 0x0f, 0x53, 0x61, 0x50, 0xc4, 0x30, 0xf0, 0xe5, 0x1f, 0xc8, 0x36, 0x7d,
+	0xf6, 0xb4, 0x93, 0xbc, 0xd9, 0xb9, 0x40, 0xed, 0x33, 0xa1, 0xd4, 0x10, 0xaa, 0x24, 0xb7, 0x32,
+	0xe7, 0x4d, 0x2a, 0xbf, 0x1a, 0xbe, 0xa8, 0x6f, 0x45, 0xac, 0x99, 0xff, 0x16, 0xa5, 0x6b, 0xb9,
+	0x78, 0x84, 0x3a, 0x91, 0x81, 0x9c, 0x86, 0x99, 0x00, 0x15, 0xff, 0x5a, 0x34, 0x36, 0xea, 0x79,
+	0x61, 0xfd, 0x68, 0xea, 0xcc, 0xa1, 0xb6, 0x79, 0xac, 0x95, 0x11, 0x49, 0xf8, 0xb2, 0xca, 0xad,
+	0x47, 0xc2, 0xd2, 0xdf, 0x9d, 0x8f, 0x9b, 0x05, 0xbf, 0x0d, 0xf7, 0x49, 0x60, 0x7a, 0x0c, 0x8e,
+	0xef, 0x3c, 0xb3, 0xfd, 0x32, 0x5b, 0x90, 0x66, 0x34, 0x50, 0xf7, 0xed, 0x20, 0xde, 0x6e, 0xb6,
+	0xc2, 0x40, 0x7d, 0x44, 0xc3, 0xf5, 0x51, 0xf4, 0xc5, 0x3b, 0x7d, 0xe9, 0xcf, 0xb3, 0x53, 0x1a,
+	0xb3, 0x6f, 0xe7, 0x66, 0x2f, 0x4a, 0x53, 0xac, 0x6e, 0x4b, 0xed, 0x29, 0x4f, 0xea, 0x76, 0x18,
+	0xc5, 0x6f, 0xe7, 0xa3, 0xf8, 0xe0, 0xf4, 0xdb, 0x51, 0x74, 0x3a, 0x13, 0xa0, 0x3b, 0xff, 0x1f,
+	0x00, 0x34, 0x22, 0x04, 0x1b, 0xde, 0x0d, 0x00, 0x00,
 }
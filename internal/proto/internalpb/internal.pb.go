@@ -1298,6 +1298,14 @@ type SearchRequest struct {
 	TravelTimestamp      uint64           `protobuf:"varint,11,opt,name=travel_timestamp,json=travelTimestamp,proto3" json:"travel_timestamp,omitempty"`
 	GuaranteeTimestamp   uint64           `protobuf:"varint,12,opt,name=guarantee_timestamp,json=guaranteeTimestamp,proto3" json:"guarantee_timestamp,omitempty"`
 	TimeoutTimestamp     uint64           `protobuf:"varint,13,opt,name=timeout_timestamp,json=timeoutTimestamp,proto3" json:"timeout_timestamp,omitempty"`
+	SnapshotTimestamp    uint64           `protobuf:"varint,14,opt,name=snapshot_timestamp,json=snapshotTimestamp,proto3" json:"snapshot_timestamp,omitempty"`
+	NormalizeScores      bool             `protobuf:"varint,15,opt,name=normalize_scores,json=normalizeScores,proto3" json:"normalize_scores,omitempty"`
+	MandatoryFilter      string           `protobuf:"bytes,16,opt,name=mandatory_filter,json=mandatoryFilter,proto3" json:"mandatory_filter,omitempty"`
+	MandatoryFilterPlan  []byte           `protobuf:"bytes,17,opt,name=mandatory_filter_plan,json=mandatoryFilterPlan,proto3" json:"mandatory_filter_plan,omitempty"`
+	WeightedPartitionIDs []int64          `protobuf:"varint,18,rep,packed,name=weighted_partitionIDs,json=weightedPartitionIDs,proto3" json:"weighted_partitionIDs,omitempty"`
+	PartitionWeights     []float32        `protobuf:"fixed32,19,rep,packed,name=partition_weights,json=partitionWeights,proto3" json:"partition_weights,omitempty"`
+	MaxScannedSegments   int64            `protobuf:"varint,20,opt,name=max_scanned_segments,json=maxScannedSegments,proto3" json:"max_scanned_segments,omitempty"`
+	RequestId            string           `protobuf:"bytes,21,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -1419,6 +1427,62 @@ func (m *SearchRequest) GetTimeoutTimestamp() uint64 {
 	return 0
 }
 
+func (m *SearchRequest) GetSnapshotTimestamp() uint64 {
+	if m != nil {
+		return m.SnapshotTimestamp
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetNormalizeScores() bool {
+	if m != nil {
+		return m.NormalizeScores
+	}
+	return false
+}
+
+func (m *SearchRequest) GetMandatoryFilter() string {
+	if m != nil {
+		return m.MandatoryFilter
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetMandatoryFilterPlan() []byte {
+	if m != nil {
+		return m.MandatoryFilterPlan
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetWeightedPartitionIDs() []int64 {
+	if m != nil {
+		return m.WeightedPartitionIDs
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetPartitionWeights() []float32 {
+	if m != nil {
+		return m.PartitionWeights
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetMaxScannedSegments() int64 {
+	if m != nil {
+		return m.MaxScannedSegments
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
 type SearchResults struct {
 	Base                     *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	Status                   *commonpb.Status  `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
@@ -1430,12 +1494,16 @@ type SearchResults struct {
 	ChannelIDsSearched       []string          `protobuf:"bytes,8,rep,name=channelIDs_searched,json=channelIDsSearched,proto3" json:"channelIDs_searched,omitempty"`
 	GlobalSealedSegmentIDs   []int64           `protobuf:"varint,9,rep,packed,name=global_sealed_segmentIDs,json=globalSealedSegmentIDs,proto3" json:"global_sealed_segmentIDs,omitempty"`
 	// schema.SearchResultsData inside
-	SlicedBlob           []byte   `protobuf:"bytes,10,opt,name=sliced_blob,json=slicedBlob,proto3" json:"sliced_blob,omitempty"`
-	SlicedNumCount       int64    `protobuf:"varint,11,opt,name=sliced_num_count,json=slicedNumCount,proto3" json:"sliced_num_count,omitempty"`
-	SlicedOffset         int64    `protobuf:"varint,12,opt,name=sliced_offset,json=slicedOffset,proto3" json:"sliced_offset,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	SlicedBlob             []byte   `protobuf:"bytes,10,opt,name=sliced_blob,json=slicedBlob,proto3" json:"sliced_blob,omitempty"`
+	SlicedNumCount         int64    `protobuf:"varint,11,opt,name=sliced_num_count,json=slicedNumCount,proto3" json:"sliced_num_count,omitempty"`
+	SlicedOffset           int64    `protobuf:"varint,12,opt,name=sliced_offset,json=slicedOffset,proto3" json:"sliced_offset,omitempty"`
+	SlicedBlobCompressType string   `protobuf:"bytes,13,opt,name=sliced_blob_compress_type,json=slicedBlobCompressType,proto3" json:"sliced_blob_compress_type,omitempty"`
+	SkippedSegments        int64    `protobuf:"varint,14,opt,name=skipped_segments,json=skippedSegments,proto3" json:"skipped_segments,omitempty"`
+	ReadTimestamp          uint64   `protobuf:"varint,15,opt,name=read_timestamp,json=readTimestamp,proto3" json:"read_timestamp,omitempty"`
+	RequestId              string   `protobuf:"bytes,16,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
 }
 
 func (m *SearchResults) Reset()         { *m = SearchResults{} }
@@ -1547,6 +1615,34 @@ func (m *SearchResults) GetSlicedOffset() int64 {
 	return 0
 }
 
+func (m *SearchResults) GetSlicedBlobCompressType() string {
+	if m != nil {
+		return m.SlicedBlobCompressType
+	}
+	return ""
+}
+
+func (m *SearchResults) GetSkippedSegments() int64 {
+	if m != nil {
+		return m.SkippedSegments
+	}
+	return 0
+}
+
+func (m *SearchResults) GetReadTimestamp() uint64 {
+	if m != nil {
+		return m.ReadTimestamp
+	}
+	return 0
+}
+
+func (m *SearchResults) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
 type RetrieveRequest struct {
 	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	ResultChannelID      string            `protobuf:"bytes,2,opt,name=result_channelID,json=resultChannelID,proto3" json:"result_channelID,omitempty"`
@@ -1558,6 +1654,12 @@ type RetrieveRequest struct {
 	TravelTimestamp      uint64            `protobuf:"varint,8,opt,name=travel_timestamp,json=travelTimestamp,proto3" json:"travel_timestamp,omitempty"`
 	GuaranteeTimestamp   uint64            `protobuf:"varint,9,opt,name=guarantee_timestamp,json=guaranteeTimestamp,proto3" json:"guarantee_timestamp,omitempty"`
 	TimeoutTimestamp     uint64            `protobuf:"varint,10,opt,name=timeout_timestamp,json=timeoutTimestamp,proto3" json:"timeout_timestamp,omitempty"`
+	SnapshotTimestamp    uint64            `protobuf:"varint,11,opt,name=snapshot_timestamp,json=snapshotTimestamp,proto3" json:"snapshot_timestamp,omitempty"`
+	MandatoryFilter      string            `protobuf:"bytes,12,opt,name=mandatory_filter,json=mandatoryFilter,proto3" json:"mandatory_filter,omitempty"`
+	MandatoryFilterPlan  []byte            `protobuf:"bytes,13,opt,name=mandatory_filter_plan,json=mandatoryFilterPlan,proto3" json:"mandatory_filter_plan,omitempty"`
+	MaxScannedSegments   int64             `protobuf:"varint,14,opt,name=max_scanned_segments,json=maxScannedSegments,proto3" json:"max_scanned_segments,omitempty"`
+	RequestId            string            `protobuf:"bytes,15,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Cursor               *QueryCursor      `protobuf:"bytes,16,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -1658,6 +1760,48 @@ func (m *RetrieveRequest) GetTimeoutTimestamp() uint64 {
 	return 0
 }
 
+func (m *RetrieveRequest) GetSnapshotTimestamp() uint64 {
+	if m != nil {
+		return m.SnapshotTimestamp
+	}
+	return 0
+}
+
+func (m *RetrieveRequest) GetMandatoryFilter() string {
+	if m != nil {
+		return m.MandatoryFilter
+	}
+	return ""
+}
+
+func (m *RetrieveRequest) GetMandatoryFilterPlan() []byte {
+	if m != nil {
+		return m.MandatoryFilterPlan
+	}
+	return nil
+}
+
+func (m *RetrieveRequest) GetMaxScannedSegments() int64 {
+	if m != nil {
+		return m.MaxScannedSegments
+	}
+	return 0
+}
+
+func (m *RetrieveRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *RetrieveRequest) GetCursor() *QueryCursor {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
 type RetrieveResults struct {
 	Base                      *commonpb.MsgBase     `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	Status                    *commonpb.Status      `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
@@ -1667,6 +1811,13 @@ type RetrieveResults struct {
 	SealedSegmentIDsRetrieved []int64               `protobuf:"varint,6,rep,packed,name=sealed_segmentIDs_retrieved,json=sealedSegmentIDsRetrieved,proto3" json:"sealed_segmentIDs_retrieved,omitempty"`
 	ChannelIDsRetrieved       []string              `protobuf:"bytes,7,rep,name=channelIDs_retrieved,json=channelIDsRetrieved,proto3" json:"channelIDs_retrieved,omitempty"`
 	GlobalSealedSegmentIDs    []int64               `protobuf:"varint,8,rep,packed,name=global_sealed_segmentIDs,json=globalSealedSegmentIDs,proto3" json:"global_sealed_segmentIDs,omitempty"`
+	CompressedBlob            []byte                `protobuf:"bytes,9,opt,name=compressed_blob,json=compressedBlob,proto3" json:"compressed_blob,omitempty"`
+	CompressType              string                `protobuf:"bytes,10,opt,name=compress_type,json=compressType,proto3" json:"compress_type,omitempty"`
+	MatchedCount              int64                 `protobuf:"varint,11,opt,name=matched_count,json=matchedCount,proto3" json:"matched_count,omitempty"`
+	SkippedSegments           int64                 `protobuf:"varint,12,opt,name=skipped_segments,json=skippedSegments,proto3" json:"skipped_segments,omitempty"`
+	ReadTimestamp             uint64                `protobuf:"varint,13,opt,name=read_timestamp,json=readTimestamp,proto3" json:"read_timestamp,omitempty"`
+	RequestId                 string                `protobuf:"bytes,14,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	DistributionDigest        uint64                `protobuf:"varint,15,opt,name=distribution_digest,json=distributionDigest,proto3" json:"distribution_digest,omitempty"`
 	XXX_NoUnkeyedLiteral      struct{}              `json:"-"`
 	XXX_unrecognized          []byte                `json:"-"`
 	XXX_sizecache             int32                 `json:"-"`
@@ -1753,6 +1904,55 @@ func (m *RetrieveResults) GetGlobalSealedSegmentIDs() []int64 {
 	return nil
 }
 
+func (m *RetrieveResults) GetCompressedBlob() []byte {
+	if m != nil {
+		return m.CompressedBlob
+	}
+	return nil
+}
+
+func (m *RetrieveResults) GetCompressType() string {
+	if m != nil {
+		return m.CompressType
+	}
+	return ""
+}
+
+func (m *RetrieveResults) GetMatchedCount() int64 {
+	if m != nil {
+		return m.MatchedCount
+	}
+	return 0
+}
+
+func (m *RetrieveResults) GetSkippedSegments() int64 {
+	if m != nil {
+		return m.SkippedSegments
+	}
+	return 0
+}
+
+func (m *RetrieveResults) GetReadTimestamp() uint64 {
+	if m != nil {
+		return m.ReadTimestamp
+	}
+	return 0
+}
+
+func (m *RetrieveResults) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *RetrieveResults) GetDistributionDigest() uint64 {
+	if m != nil {
+		return m.DistributionDigest
+	}
+	return 0
+}
+
 type DeleteRequest struct {
 	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	ShardName            string            `protobuf:"bytes,2,opt,name=shardName,proto3" json:"shardName,omitempty"`
@@ -2066,6 +2266,10 @@ type SegmentStats struct {
 	MemorySize           int64    `protobuf:"varint,2,opt,name=memory_size,json=memorySize,proto3" json:"memory_size,omitempty"`
 	NumRows              int64    `protobuf:"varint,3,opt,name=num_rows,json=numRows,proto3" json:"num_rows,omitempty"`
 	RecentlyModified     bool     `protobuf:"varint,4,opt,name=recently_modified,json=recentlyModified,proto3" json:"recently_modified,omitempty"`
+	RowBudget            int64    `protobuf:"varint,5,opt,name=row_budget,json=rowBudget,proto3" json:"row_budget,omitempty"`
+	AllocatedChunks      int64    `protobuf:"varint,6,opt,name=allocated_chunks,json=allocatedChunks,proto3" json:"allocated_chunks,omitempty"`
+	ChunkRows            int64    `protobuf:"varint,7,opt,name=chunk_rows,json=chunkRows,proto3" json:"chunk_rows,omitempty"`
+	DiskUsage            int64    `protobuf:"varint,8,opt,name=disk_usage,json=diskUsage,proto3" json:"disk_usage,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2124,13 +2328,44 @@ func (m *SegmentStats) GetRecentlyModified() bool {
 	return false
 }
 
+func (m *SegmentStats) GetRowBudget() int64 {
+	if m != nil {
+		return m.RowBudget
+	}
+	return 0
+}
+
+func (m *SegmentStats) GetAllocatedChunks() int64 {
+	if m != nil {
+		return m.AllocatedChunks
+	}
+	return 0
+}
+
+func (m *SegmentStats) GetChunkRows() int64 {
+	if m != nil {
+		return m.ChunkRows
+	}
+	return 0
+}
+
+func (m *SegmentStats) GetDiskUsage() int64 {
+	if m != nil {
+		return m.DiskUsage
+	}
+	return 0
+}
+
 type QueryNodeStats struct {
-	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	SegStats             []*SegmentStats   `protobuf:"bytes,2,rep,name=seg_stats,json=segStats,proto3" json:"seg_stats,omitempty"`
-	FieldStats           []*FieldStats     `protobuf:"bytes,3,rep,name=field_stats,json=fieldStats,proto3" json:"field_stats,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Base                  *commonpb.MsgBase  `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	SegStats              []*SegmentStats    `protobuf:"bytes,2,rep,name=seg_stats,json=segStats,proto3" json:"seg_stats,omitempty"`
+	FieldStats            []*FieldStats      `protobuf:"bytes,3,rep,name=field_stats,json=fieldStats,proto3" json:"field_stats,omitempty"`
+	CollectionStats       []*CollectionStats `protobuf:"bytes,4,rep,name=collection_stats,json=collectionStats,proto3" json:"collection_stats,omitempty"`
+	ReleasedCollectionIDs []int64            `protobuf:"varint,5,rep,packed,name=released_collectionIDs,json=releasedCollectionIDs,proto3" json:"released_collectionIDs,omitempty"`
+	FullSnapshot          bool               `protobuf:"varint,6,opt,name=full_snapshot,json=fullSnapshot,proto3" json:"full_snapshot,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}           `json:"-"`
+	XXX_unrecognized      []byte             `json:"-"`
+	XXX_sizecache         int32              `json:"-"`
 }
 
 func (m *QueryNodeStats) Reset()         { *m = QueryNodeStats{} }
@@ -2179,6 +2414,27 @@ func (m *QueryNodeStats) GetFieldStats() []*FieldStats {
 	return nil
 }
 
+func (m *QueryNodeStats) GetCollectionStats() []*CollectionStats {
+	if m != nil {
+		return m.CollectionStats
+	}
+	return nil
+}
+
+func (m *QueryNodeStats) GetReleasedCollectionIDs() []int64 {
+	if m != nil {
+		return m.ReleasedCollectionIDs
+	}
+	return nil
+}
+
+func (m *QueryNodeStats) GetFullSnapshot() bool {
+	if m != nil {
+		return m.FullSnapshot
+	}
+	return false
+}
+
 type MsgPosition struct {
 	ChannelName          string   `protobuf:"bytes,1,opt,name=channel_name,json=channelName,proto3" json:"channel_name,omitempty"`
 	MsgID                []byte   `protobuf:"bytes,2,opt,name=msgID,proto3" json:"msgID,omitempty"`
@@ -2383,6 +2639,198 @@ func (m *CredentialInfo) GetEncryptedPassword() string {
 	return ""
 }
 
+// in-memory stats of a collection on a query node
+type CollectionStats struct {
+	CollectionID         int64    `protobuf:"varint,1,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	NumRows              int64    `protobuf:"varint,2,opt,name=num_rows,json=numRows,proto3" json:"num_rows,omitempty"`
+	MemorySize           int64    `protobuf:"varint,3,opt,name=memory_size,json=memorySize,proto3" json:"memory_size,omitempty"`
+	Qps                  float64  `protobuf:"fixed64,4,opt,name=qps,proto3" json:"qps,omitempty"`
+	TsafeLagMs           int64    `protobuf:"varint,5,opt,name=tsafe_lag_ms,json=tsafeLagMs,proto3" json:"tsafe_lag_ms,omitempty"`
+	DiskUsage            int64    `protobuf:"varint,6,opt,name=disk_usage,json=diskUsage,proto3" json:"disk_usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CollectionStats) Reset()         { *m = CollectionStats{} }
+func (m *CollectionStats) String() string { return proto.CompactTextString(m) }
+func (*CollectionStats) ProtoMessage()    {}
+func (*CollectionStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41f4a519b878ee3b, []int{33}
+}
+
+func (m *CollectionStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectionStats.Unmarshal(m, b)
+}
+func (m *CollectionStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectionStats.Marshal(b, m, deterministic)
+}
+func (m *CollectionStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectionStats.Merge(m, src)
+}
+func (m *CollectionStats) XXX_Size() int {
+	return xxx_messageInfo_CollectionStats.Size(m)
+}
+func (m *CollectionStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectionStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectionStats proto.InternalMessageInfo
+
+func (m *CollectionStats) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *CollectionStats) GetNumRows() int64 {
+	if m != nil {
+		return m.NumRows
+	}
+	return 0
+}
+
+func (m *CollectionStats) GetMemorySize() int64 {
+	if m != nil {
+		return m.MemorySize
+	}
+	return 0
+}
+
+func (m *CollectionStats) GetQps() float64 {
+	if m != nil {
+		return m.Qps
+	}
+	return 0
+}
+
+func (m *CollectionStats) GetTsafeLagMs() int64 {
+	if m != nil {
+		return m.TsafeLagMs
+	}
+	return 0
+}
+
+func (m *CollectionStats) GetDiskUsage() int64 {
+	if m != nil {
+		return m.DiskUsage
+	}
+	return 0
+}
+
+// the position of a query ordered by primary key, encoded into the opaque cursor of the query results
+type QueryCursor struct {
+	SnapshotTimestamp    uint64               `protobuf:"varint,1,opt,name=snapshot_timestamp,json=snapshotTimestamp,proto3" json:"snapshot_timestamp,omitempty"`
+	PlanHash             uint64               `protobuf:"varint,2,opt,name=plan_hash,json=planHash,proto3" json:"plan_hash,omitempty"`
+	LastPk               *schemapb.IDs        `protobuf:"bytes,3,opt,name=last_pk,json=lastPk,proto3" json:"last_pk,omitempty"`
+	Distributions        []*ShardDistribution `protobuf:"bytes,4,rep,name=distributions,proto3" json:"distributions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *QueryCursor) Reset()         { *m = QueryCursor{} }
+func (m *QueryCursor) String() string { return proto.CompactTextString(m) }
+func (*QueryCursor) ProtoMessage()    {}
+func (*QueryCursor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41f4a519b878ee3b, []int{34}
+}
+
+func (m *QueryCursor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryCursor.Unmarshal(m, b)
+}
+func (m *QueryCursor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryCursor.Marshal(b, m, deterministic)
+}
+func (m *QueryCursor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryCursor.Merge(m, src)
+}
+func (m *QueryCursor) XXX_Size() int {
+	return xxx_messageInfo_QueryCursor.Size(m)
+}
+func (m *QueryCursor) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryCursor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryCursor proto.InternalMessageInfo
+
+func (m *QueryCursor) GetSnapshotTimestamp() uint64 {
+	if m != nil {
+		return m.SnapshotTimestamp
+	}
+	return 0
+}
+
+func (m *QueryCursor) GetPlanHash() uint64 {
+	if m != nil {
+		return m.PlanHash
+	}
+	return 0
+}
+
+func (m *QueryCursor) GetLastPk() *schemapb.IDs {
+	if m != nil {
+		return m.LastPk
+	}
+	return nil
+}
+
+func (m *QueryCursor) GetDistributions() []*ShardDistribution {
+	if m != nil {
+		return m.Distributions
+	}
+	return nil
+}
+
+// the digest of the sealed segments of a shard
+type ShardDistribution struct {
+	DmlChannel           string   `protobuf:"bytes,1,opt,name=dml_channel,json=dmlChannel,proto3" json:"dml_channel,omitempty"`
+	Digest               uint64   `protobuf:"varint,2,opt,name=digest,proto3" json:"digest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ShardDistribution) Reset()         { *m = ShardDistribution{} }
+func (m *ShardDistribution) String() string { return proto.CompactTextString(m) }
+func (*ShardDistribution) ProtoMessage()    {}
+func (*ShardDistribution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41f4a519b878ee3b, []int{35}
+}
+
+func (m *ShardDistribution) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShardDistribution.Unmarshal(m, b)
+}
+func (m *ShardDistribution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShardDistribution.Marshal(b, m, deterministic)
+}
+func (m *ShardDistribution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardDistribution.Merge(m, src)
+}
+func (m *ShardDistribution) XXX_Size() int {
+	return xxx_messageInfo_ShardDistribution.Size(m)
+}
+func (m *ShardDistribution) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardDistribution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShardDistribution proto.InternalMessageInfo
+
+func (m *ShardDistribution) GetDmlChannel() string {
+	if m != nil {
+		return m.DmlChannel
+	}
+	return ""
+}
+
+func (m *ShardDistribution) GetDigest() uint64 {
+	if m != nil {
+		return m.Digest
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("milvus.proto.internal.StateCode", StateCode_name, StateCode_value)
 	proto.RegisterEnum("milvus.proto.internal.InsertDataVersion", InsertDataVersion_name, InsertDataVersion_value)
@@ -2419,138 +2867,180 @@ func init() {
 	proto.RegisterType((*ChannelTimeTickMsg)(nil), "milvus.proto.internal.ChannelTimeTickMsg")
 	proto.RegisterType((*ClearCredUsersCacheRequest)(nil), "milvus.proto.internal.ClearCredUsersCacheRequest")
 	proto.RegisterType((*CredentialInfo)(nil), "milvus.proto.internal.CredentialInfo")
+	proto.RegisterType((*CollectionStats)(nil), "milvus.proto.internal.CollectionStats")
+	proto.RegisterType((*QueryCursor)(nil), "milvus.proto.internal.QueryCursor")
+	proto.RegisterType((*ShardDistribution)(nil), "milvus.proto.internal.ShardDistribution")
 }
 
 func init() { proto.RegisterFile("internal.proto", fileDescriptor_41f4a519b878ee3b) }
 
 var fileDescriptor_41f4a519b878ee3b = []byte{
-	// 2043 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0xcf, 0x73, 0x1c, 0x47,
-	0x15, 0xce, 0xec, 0xac, 0xb4, 0xbb, 0x6f, 0x57, 0xab, 0x55, 0x4b, 0x76, 0xc6, 0xb2, 0x13, 0x2b,
-	0x93, 0x00, 0xc2, 0x26, 0xb6, 0x51, 0x42, 0x92, 0x02, 0x0a, 0xc7, 0xda, 0x05, 0xb3, 0xe5, 0xd8,
-	0x88, 0x91, 0xe3, 0x2a, 0xe0, 0x30, 0xd5, 0xbb, 0xd3, 0xda, 0x1d, 0x3c, 0x33, 0x3d, 0xe9, 0xee,
-	0x91, 0xbc, 0x3e, 0x71, 0xe0, 0x04, 0x05, 0x55, 0x1c, 0x38, 0xc2, 0x8d, 0x33, 0x47, 0x4e, 0x40,
-	0x15, 0xa7, 0x9c, 0xb8, 0xf3, 0xaf, 0x70, 0xa2, 0xfa, 0xc7, 0xcc, 0xce, 0xae, 0x56, 0xb2, 0xa4,
-	0x54, 0x88, 0x53, 0x95, 0xdb, 0xf4, 0x7b, 0xaf, 0xbb, 0x5f, 0x7f, 0xef, 0x7b, 0xaf, 0x5f, 0xef,
-	0x42, 0x3b, 0x4c, 0x04, 0x61, 0x09, 0x8e, 0x6e, 0xa5, 0x8c, 0x0a, 0x8a, 0x2e, 0xc5, 0x61, 0x74,
-	0x98, 0x71, 0x3d, 0xba, 0x95, 0x2b, 0x37, 0x5b, 0x43, 0x1a, 0xc7, 0x34, 0xd1, 0xe2, 0xcd, 0x16,
-	0x1f, 0x8e, 0x49, 0x8c, 0xf5, 0xc8, 0xfd, 0x87, 0x05, 0x2b, 0x5d, 0x1a, 0xa7, 0x34, 0x21, 0x89,
-	0xe8, 0x27, 0x07, 0x14, 0x5d, 0x86, 0xe5, 0x84, 0x06, 0xa4, 0xdf, 0x73, 0xac, 0x2d, 0x6b, 0xdb,
-	0xf6, 0xcc, 0x08, 0x21, 0xa8, 0x32, 0x1a, 0x11, 0xa7, 0xb2, 0x65, 0x6d, 0x37, 0x3c, 0xf5, 0x8d,
-	0xee, 0x02, 0x70, 0x81, 0x05, 0xf1, 0x87, 0x34, 0x20, 0x8e, 0xbd, 0x65, 0x6d, 0xb7, 0x77, 0xb6,
-	0x6e, 0x2d, 0xf4, 0xe2, 0xd6, 0xbe, 0x34, 0xec, 0xd2, 0x80, 0x78, 0x0d, 0x9e, 0x7f, 0xa2, 0x0f,
-	0x01, 0xc8, 0x33, 0xc1, 0xb0, 0x1f, 0x26, 0x07, 0xd4, 0xa9, 0x6e, 0xd9, 0xdb, 0xcd, 0x9d, 0x37,
-	0x66, 0x17, 0x30, 0xce, 0x3f, 0x20, 0x93, 0x27, 0x38, 0xca, 0xc8, 0x1e, 0x0e, 0x99, 0xd7, 0x50,
-	0x93, 0xa4, 0xbb, 0xee, 0x7f, 0x2c, 0x58, 0x2d, 0x0e, 0xa0, 0xf6, 0xe0, 0xe8, 0xbb, 0xb0, 0xa4,
-	0xb6, 0x50, 0x27, 0x68, 0xee, 0xbc, 0x75, 0x82, 0x47, 0x33, 0xe7, 0xf6, 0xf4, 0x14, 0xf4, 0x31,
-	0xac, 0xf3, 0x6c, 0x30, 0xcc, 0x55, 0xbe, 0x92, 0x72, 0xa7, 0xa2, 0x5c, 0x3b, 0xdb, 0x4a, 0xa8,
-	0xbc, 0x80, 0x71, 0xe9, 0x1d, 0x58, 0x96, 0x2b, 0x65, 0x5c, 0xa1, 0xd4, 0xdc, 0xb9, 0xba, 0xf0,
-	0x90, 0xfb, 0xca, 0xc4, 0x33, 0xa6, 0xee, 0x55, 0xb8, 0x72, 0x9f, 0x88, 0xb9, 0xd3, 0x79, 0xe4,
-	0x93, 0x8c, 0x70, 0x61, 0x94, 0x8f, 0xc3, 0x98, 0x3c, 0x0e, 0x87, 0x4f, 0xbb, 0x63, 0x9c, 0x24,
-	0x24, 0xca, 0x95, 0xaf, 0xc1, 0xd5, 0xfb, 0x44, 0x4d, 0x08, 0xb9, 0x08, 0x87, 0x7c, 0x4e, 0x7d,
-	0x09, 0xd6, 0xef, 0x13, 0xd1, 0x0b, 0xe6, 0xc4, 0x4f, 0xa0, 0xfe, 0x48, 0x06, 0x5b, 0xd2, 0xe0,
-	0x3d, 0xa8, 0xe1, 0x20, 0x60, 0x84, 0x73, 0x83, 0xe2, 0xb5, 0x85, 0x1e, 0xdf, 0xd3, 0x36, 0x5e,
-	0x6e, 0xbc, 0x88, 0x26, 0xee, 0x2f, 0x01, 0xfa, 0x49, 0x28, 0xf6, 0x30, 0xc3, 0x31, 0x3f, 0x91,
-	0x60, 0x3d, 0x68, 0x71, 0x81, 0x99, 0xf0, 0x53, 0x65, 0x67, 0x20, 0x3f, 0x03, 0x1b, 0x9a, 0x6a,
-	0x9a, 0x5e, 0xdd, 0xfd, 0x19, 0xc0, 0xbe, 0x60, 0x61, 0x32, 0xfa, 0x28, 0xe4, 0x42, 0xee, 0x75,
-	0x28, 0xed, 0xe4, 0x21, 0xec, 0xed, 0x86, 0x67, 0x46, 0xa5, 0x70, 0x54, 0xce, 0x1e, 0x8e, 0xbb,
-	0xd0, 0xcc, 0xe1, 0x7e, 0xc8, 0x47, 0xe8, 0x0e, 0x54, 0x07, 0x98, 0x93, 0x53, 0xe1, 0x79, 0xc8,
-	0x47, 0xbb, 0x98, 0x13, 0x4f, 0x59, 0xba, 0xbf, 0xb1, 0xe1, 0xd5, 0x2e, 0x23, 0x8a, 0xfc, 0x51,
-	0x44, 0x86, 0x22, 0xa4, 0x89, 0xc1, 0xfe, 0xfc, 0xab, 0xa1, 0x57, 0xa1, 0x16, 0x0c, 0xfc, 0x04,
-	0xc7, 0x39, 0xd8, 0xcb, 0xc1, 0xe0, 0x11, 0x8e, 0x09, 0xfa, 0x3a, 0xb4, 0x87, 0xc5, 0xfa, 0x52,
-	0xa2, 0x38, 0xd7, 0xf0, 0xe6, 0xa4, 0xe8, 0x2d, 0x58, 0x49, 0x31, 0x13, 0x61, 0x61, 0x56, 0x55,
-	0x66, 0xb3, 0x42, 0x19, 0xd0, 0x60, 0xd0, 0xef, 0x39, 0x4b, 0x2a, 0x58, 0xea, 0x1b, 0xb9, 0xd0,
-	0x9a, 0xae, 0xd5, 0xef, 0x39, 0xcb, 0x4a, 0x37, 0x23, 0x43, 0x5b, 0xd0, 0x2c, 0x16, 0xea, 0xf7,
-	0x9c, 0x9a, 0x32, 0x29, 0x8b, 0x64, 0x70, 0x74, 0x2d, 0x72, 0xea, 0x5b, 0xd6, 0x76, 0xcb, 0x33,
-	0x23, 0x74, 0x07, 0xd6, 0x0f, 0x43, 0x26, 0x32, 0x1c, 0x19, 0x7e, 0x4a, 0x3f, 0xb8, 0xd3, 0x50,
-	0x11, 0x5c, 0xa4, 0x42, 0x3b, 0xb0, 0x91, 0x8e, 0x27, 0x3c, 0x1c, 0xce, 0x4d, 0x01, 0x35, 0x65,
-	0xa1, 0xce, 0xfd, 0x97, 0x05, 0x97, 0x7a, 0x8c, 0xa6, 0x2f, 0x45, 0x28, 0x72, 0x90, 0xab, 0xa7,
-	0x80, 0xbc, 0x74, 0x1c, 0x64, 0xf7, 0x77, 0x15, 0xb8, 0xac, 0x19, 0xb5, 0x97, 0x03, 0xfb, 0x39,
-	0x9c, 0xe2, 0x1b, 0xb0, 0x3a, 0xdd, 0x55, 0x1b, 0x2c, 0x3e, 0xc6, 0xd7, 0xa0, 0x5d, 0x04, 0x58,
-	0xdb, 0xfd, 0x7f, 0x29, 0xe5, 0xfe, 0xb6, 0x02, 0x1b, 0x32, 0xa8, 0x5f, 0xa1, 0x21, 0xd1, 0xf8,
-	0xb3, 0x05, 0x48, 0xb3, 0xe3, 0x5e, 0x14, 0x62, 0xfe, 0x45, 0x62, 0xb1, 0x01, 0x4b, 0x58, 0xfa,
-	0x60, 0x20, 0xd0, 0x03, 0x97, 0x43, 0x47, 0x46, 0xeb, 0xf3, 0xf2, 0xae, 0xd8, 0xd4, 0x2e, 0x6f,
-	0xfa, 0x27, 0x0b, 0xd6, 0xee, 0x45, 0x82, 0xb0, 0x97, 0x14, 0x94, 0x7f, 0x56, 0xf2, 0xa8, 0xf5,
-	0x93, 0x80, 0x3c, 0xfb, 0x22, 0x1d, 0x7c, 0x0d, 0xe0, 0x20, 0x24, 0x51, 0x50, 0x66, 0x6f, 0x43,
-	0x49, 0x3e, 0x13, 0x73, 0x1d, 0xa8, 0xa9, 0x45, 0x0a, 0xd6, 0xe6, 0x43, 0xd9, 0x03, 0xe8, 0x7e,
-	0xd0, 0xf4, 0x00, 0xf5, 0x33, 0xf7, 0x00, 0x6a, 0x9a, 0xe9, 0x01, 0xfe, 0x5d, 0x85, 0x95, 0x7e,
-	0xc2, 0x09, 0x13, 0x17, 0x07, 0xef, 0x1a, 0x34, 0xf8, 0x18, 0x33, 0x75, 0x50, 0x03, 0xdf, 0x54,
-	0x50, 0x86, 0xd6, 0x7e, 0x11, 0xb4, 0xd5, 0x33, 0x16, 0x87, 0xa5, 0xd3, 0x8a, 0xc3, 0xf2, 0x29,
-	0x10, 0xd7, 0x5e, 0x5c, 0x1c, 0xea, 0xc7, 0x6f, 0x5f, 0x79, 0x40, 0x32, 0x8a, 0x65, 0xd3, 0xda,
-	0x73, 0x1a, 0x4a, 0x3f, 0x15, 0xa0, 0xd7, 0x01, 0x44, 0x18, 0x13, 0x2e, 0x70, 0x9c, 0xea, 0x7b,
-	0xb4, 0xea, 0x95, 0x24, 0xf2, 0xee, 0x66, 0xf4, 0xa8, 0xdf, 0xe3, 0x4e, 0x73, 0xcb, 0x96, 0x4d,
-	0x9c, 0x1e, 0xa1, 0x77, 0xa1, 0xce, 0xe8, 0x91, 0x1f, 0x60, 0x81, 0x9d, 0x96, 0x0a, 0xde, 0x95,
-	0x85, 0x60, 0xef, 0x46, 0x74, 0xe0, 0xd5, 0x18, 0x3d, 0xea, 0x61, 0x81, 0xd1, 0x5d, 0x68, 0x2a,
-	0x06, 0x70, 0x3d, 0x71, 0x45, 0x4d, 0x7c, 0x7d, 0x76, 0xa2, 0x79, 0xb6, 0xfc, 0x48, 0xda, 0xc9,
-	0x49, 0x9e, 0xa6, 0x26, 0x57, 0x0b, 0x5c, 0x81, 0x7a, 0x92, 0xc5, 0x3e, 0xa3, 0x47, 0xdc, 0x69,
-	0x6f, 0x59, 0xdb, 0x55, 0xaf, 0x96, 0x64, 0xb1, 0x47, 0x8f, 0x38, 0xda, 0x85, 0xda, 0x21, 0x61,
-	0x3c, 0xa4, 0x89, 0xb3, 0xaa, 0x1e, 0x28, 0xdb, 0x27, 0x34, 0xf1, 0x9a, 0x31, 0x72, 0xb9, 0x27,
-	0xda, 0xde, 0xcb, 0x27, 0xba, 0x7f, 0xa9, 0xc2, 0xca, 0x3e, 0xc1, 0x6c, 0x38, 0xbe, 0x38, 0xa1,
-	0xbe, 0x09, 0x1d, 0x46, 0x78, 0x16, 0x09, 0x7f, 0xa8, 0xdb, 0x90, 0x7e, 0xcf, 0xf0, 0x6a, 0x55,
-	0xcb, 0xbb, 0xb9, 0xb8, 0x08, 0xba, 0x7d, 0x4a, 0xd0, 0xab, 0x0b, 0x82, 0xee, 0x42, 0xab, 0x14,
-	0x61, 0xee, 0x2c, 0xa9, 0xd0, 0xcc, 0xc8, 0x50, 0x07, 0xec, 0x80, 0x47, 0x8a, 0x4f, 0x0d, 0x4f,
-	0x7e, 0xa2, 0x9b, 0xb0, 0x96, 0x46, 0x78, 0x48, 0xc6, 0x34, 0x0a, 0x08, 0xf3, 0x47, 0x8c, 0x66,
-	0xa9, 0xe2, 0x54, 0xcb, 0xeb, 0x94, 0x14, 0xf7, 0xa5, 0x1c, 0xbd, 0x0f, 0xf5, 0x80, 0x47, 0xbe,
-	0x98, 0xa4, 0x44, 0x91, 0xaa, 0x7d, 0xc2, 0xd9, 0x7b, 0x3c, 0x7a, 0x3c, 0x49, 0x89, 0x57, 0x0b,
-	0xf4, 0x07, 0xba, 0x03, 0x1b, 0x9c, 0xb0, 0x10, 0x47, 0xe1, 0x73, 0x12, 0xf8, 0xe4, 0x59, 0xca,
-	0xfc, 0x34, 0xc2, 0x89, 0x62, 0x5e, 0xcb, 0x43, 0x53, 0xdd, 0x0f, 0x9f, 0xa5, 0x6c, 0x2f, 0xc2,
-	0x09, 0xda, 0x86, 0x0e, 0xcd, 0x44, 0x9a, 0x09, 0xdf, 0x70, 0x23, 0x0c, 0x14, 0x11, 0x6d, 0xaf,
-	0xad, 0xe5, 0x8a, 0x0a, 0xbc, 0x1f, 0x48, 0x68, 0x05, 0xc3, 0x87, 0x24, 0xf2, 0x0b, 0x86, 0x3a,
-	0x4d, 0xc5, 0x82, 0x55, 0x2d, 0x7f, 0x9c, 0x8b, 0xd1, 0x6d, 0x58, 0x1f, 0x65, 0x98, 0xe1, 0x44,
-	0x10, 0x52, 0xb2, 0x6e, 0x29, 0x6b, 0x54, 0xa8, 0xa6, 0x13, 0x6e, 0xc2, 0x9a, 0x34, 0xa3, 0x99,
-	0x28, 0x99, 0xaf, 0x28, 0xf3, 0x8e, 0x51, 0x14, 0xc6, 0xee, 0x1f, 0x4a, 0x3c, 0x91, 0x21, 0xe5,
-	0x17, 0xe0, 0xc9, 0x45, 0x9e, 0x26, 0x0b, 0xc9, 0x65, 0x2f, 0x26, 0xd7, 0x75, 0x68, 0xc6, 0x44,
-	0xb0, 0x70, 0xa8, 0x83, 0xa8, 0xab, 0x13, 0x68, 0x91, 0x8a, 0xd4, 0x75, 0x68, 0xca, 0x5c, 0xfa,
-	0x24, 0x23, 0x2c, 0x24, 0xdc, 0x14, 0x77, 0x48, 0xb2, 0xf8, 0xa7, 0x5a, 0x82, 0xd6, 0x61, 0x49,
-	0xd0, 0xd4, 0x7f, 0x9a, 0x17, 0x25, 0x41, 0xd3, 0x07, 0xe8, 0xfb, 0xb0, 0xc9, 0x09, 0x8e, 0x48,
-	0xe0, 0x17, 0x45, 0x84, 0xfb, 0x5c, 0x61, 0x41, 0x02, 0xa7, 0xa6, 0xe2, 0xe6, 0x68, 0x8b, 0xfd,
-	0xc2, 0x60, 0xdf, 0xe8, 0x65, 0x58, 0x0a, 0xc7, 0x4b, 0xd3, 0xea, 0xaa, 0x7f, 0x47, 0x53, 0x55,
-	0x31, 0xe1, 0x03, 0x70, 0x46, 0x11, 0x1d, 0xe0, 0xc8, 0x3f, 0xb6, 0xab, 0x7a, 0x28, 0xd8, 0xde,
-	0x65, 0xad, 0xdf, 0x9f, 0xdb, 0x52, 0x1e, 0x8f, 0x47, 0xe1, 0x90, 0x04, 0xfe, 0x20, 0xa2, 0x03,
-	0x07, 0x14, 0xff, 0x40, 0x8b, 0x64, 0x55, 0x92, 0xbc, 0x33, 0x06, 0x12, 0x86, 0x21, 0xcd, 0x12,
-	0xa1, 0xd8, 0x64, 0x7b, 0x6d, 0x2d, 0x7f, 0x94, 0xc5, 0x5d, 0x29, 0x45, 0x6f, 0xc2, 0x8a, 0xb1,
-	0xa4, 0x07, 0x07, 0x9c, 0x08, 0x45, 0x23, 0xdb, 0x6b, 0x69, 0xe1, 0x4f, 0x94, 0xcc, 0xfd, 0xab,
-	0x0d, 0xab, 0x9e, 0x44, 0x97, 0x1c, 0x92, 0x2f, 0x7d, 0xf5, 0x38, 0x29, 0x8b, 0x97, 0xcf, 0x95,
-	0xc5, 0xb5, 0x33, 0x67, 0x71, 0xfd, 0x5c, 0x59, 0xdc, 0x38, 0x5f, 0x16, 0xc3, 0x09, 0x59, 0xfc,
-	0xf7, 0x99, 0x88, 0xbd, 0xac, 0x79, 0x7c, 0x03, 0xec, 0x30, 0xd0, 0xad, 0x63, 0x73, 0xc7, 0x59,
-	0x78, 0x57, 0xf6, 0x7b, 0xdc, 0x93, 0x46, 0xf3, 0xf7, 0xeb, 0xd2, 0xb9, 0xef, 0xd7, 0x1f, 0xc0,
-	0xd5, 0xe3, 0xd9, 0xcd, 0x0c, 0x46, 0x81, 0xb3, 0xac, 0x02, 0x7a, 0x65, 0x3e, 0xbd, 0x73, 0x10,
-	0x03, 0xf4, 0x6d, 0xd8, 0x28, 0xe5, 0xf7, 0x74, 0x62, 0x4d, 0xbf, 0xe9, 0xa7, 0xba, 0xe9, 0x94,
-	0xd3, 0x32, 0xbc, 0x7e, 0x5a, 0x86, 0xbb, 0x9f, 0xda, 0xb0, 0xd2, 0x23, 0x11, 0x11, 0xe4, 0xab,
-	0xf6, 0xef, 0xc4, 0xf6, 0xef, 0x5b, 0x80, 0xc2, 0x44, 0xbc, 0xf7, 0xae, 0x9f, 0xb2, 0x30, 0xc6,
-	0x6c, 0xe2, 0x3f, 0x25, 0x93, 0xbc, 0x74, 0x76, 0x94, 0x66, 0x4f, 0x2b, 0x1e, 0x90, 0x09, 0x7f,
-	0x61, 0x3b, 0x58, 0xee, 0xbf, 0x74, 0xad, 0x2c, 0xfa, 0xaf, 0xef, 0x41, 0x6b, 0x66, 0x8b, 0xd6,
-	0x0b, 0x08, 0xdb, 0x4c, 0xa7, 0xfb, 0xba, 0xff, 0xb5, 0xa0, 0xf1, 0x11, 0xc5, 0x81, 0x7a, 0x09,
-	0x5d, 0x30, 0x8c, 0x45, 0x93, 0x5b, 0x99, 0x6f, 0x72, 0xaf, 0xc1, 0xf4, 0x31, 0x63, 0x02, 0x59,
-	0x7a, 0xdd, 0x94, 0x5e, 0x29, 0xd5, 0xd9, 0x57, 0xca, 0x75, 0x68, 0x86, 0xd2, 0x21, 0x3f, 0xc5,
-	0x62, 0xac, 0x0b, 0x65, 0xc3, 0x03, 0x25, 0xda, 0x93, 0x12, 0xf9, 0x8c, 0xc9, 0x0d, 0xd4, 0x33,
-	0x66, 0xf9, 0xcc, 0xcf, 0x18, 0xb3, 0x88, 0x7a, 0xc6, 0xfc, 0xda, 0x02, 0x50, 0x07, 0x97, 0x45,
-	0xe2, 0xf8, 0xa2, 0xd6, 0x45, 0x16, 0x95, 0x15, 0x5c, 0x45, 0x8a, 0x44, 0x58, 0x4c, 0x93, 0x8a,
-	0x1b, 0x70, 0x90, 0x8c, 0x9a, 0x56, 0x99, 0x84, 0xe2, 0xee, 0xef, 0x2d, 0x00, 0x55, 0x15, 0xb4,
-	0x1b, 0xf3, 0xf4, 0xb3, 0x4e, 0x7f, 0xe0, 0x55, 0x66, 0xa1, 0xdb, 0xcd, 0xa1, 0x93, 0x05, 0x4f,
-	0x3e, 0xcc, 0x17, 0x9c, 0xa1, 0xd4, 0x91, 0xe7, 0x87, 0x37, 0xe8, 0xaa, 0x6f, 0xf7, 0x8f, 0x16,
-	0xb4, 0x8c, 0x77, 0xda, 0xa5, 0x99, 0x28, 0x5b, 0xf3, 0x51, 0x56, 0x0d, 0x4f, 0x4c, 0xd9, 0xc4,
-	0xe7, 0xe1, 0x73, 0x62, 0x1c, 0x02, 0x2d, 0xda, 0x0f, 0x9f, 0x93, 0x19, 0xf2, 0xda, 0xb3, 0xe4,
-	0xbd, 0x09, 0x6b, 0x8c, 0x0c, 0x49, 0x22, 0xa2, 0x89, 0x1f, 0xd3, 0x20, 0x3c, 0x08, 0x49, 0xa0,
-	0xd8, 0x50, 0xf7, 0x3a, 0xb9, 0xe2, 0xa1, 0x91, 0xbb, 0x9f, 0x5a, 0xd0, 0x96, 0x3d, 0xd2, 0xe4,
-	0x11, 0x0d, 0x88, 0xf6, 0xec, 0xfc, 0x8c, 0xfd, 0x50, 0x9d, 0xc5, 0xc0, 0xa3, 0x7f, 0x02, 0x7f,
-	0xf3, 0xa4, 0x7f, 0x54, 0x4a, 0x18, 0x78, 0x75, 0x4e, 0x46, 0x7a, 0xcf, 0x5d, 0x53, 0xec, 0xcf,
-	0x04, 0xf1, 0x34, 0xb0, 0xa6, 0xde, 0x6b, 0x88, 0x7f, 0x65, 0x41, 0xf3, 0x21, 0x1f, 0xed, 0x51,
-	0xae, 0xea, 0x05, 0x7a, 0x03, 0x5a, 0xa6, 0x46, 0xeb, 0x62, 0x65, 0xa9, 0x64, 0x69, 0x0e, 0xa7,
-	0x3f, 0xa8, 0xa2, 0x0d, 0x58, 0x8a, 0xf9, 0xc8, 0x44, 0xbc, 0xe5, 0xe9, 0x01, 0xda, 0x84, 0x7a,
-	0xcc, 0x47, 0xea, 0xed, 0x60, 0x32, 0xac, 0x18, 0xcb, 0xb0, 0x4d, 0x2f, 0xe3, 0xaa, 0xba, 0x8c,
-	0xa7, 0x02, 0xf7, 0x6f, 0x16, 0x20, 0x73, 0xdb, 0x7d, 0xa6, 0x5f, 0xdd, 0x15, 0x61, 0xcb, 0x3f,
-	0x0a, 0x57, 0x54, 0xba, 0xce, 0xc8, 0xe6, 0xea, 0x9b, 0x7d, 0xac, 0xbe, 0xdd, 0x84, 0xb5, 0x80,
-	0x1c, 0x60, 0x79, 0x31, 0xcf, 0xbb, 0xdc, 0x31, 0x8a, 0x69, 0xff, 0x70, 0x0d, 0x36, 0xbb, 0x11,
-	0xc1, 0xac, 0xcb, 0x48, 0xf0, 0x31, 0x27, 0x8c, 0x77, 0xf1, 0x70, 0x9c, 0xdf, 0x45, 0xee, 0x2f,
-	0xa0, 0x2d, 0x15, 0x24, 0x11, 0x21, 0x8e, 0xd4, 0x5f, 0x2d, 0x9b, 0x50, 0xcf, 0xb8, 0x0c, 0x47,
-	0x01, 0x6c, 0x31, 0x46, 0x6f, 0x03, 0x22, 0xc9, 0x90, 0x4d, 0x52, 0x99, 0xac, 0x29, 0xe6, 0xfc,
-	0x88, 0xb2, 0xc0, 0x5c, 0x48, 0x6b, 0x85, 0x66, 0xcf, 0x28, 0x6e, 0x7c, 0x00, 0x8d, 0xe2, 0x7f,
-	0x36, 0xd4, 0x81, 0x56, 0x3f, 0x09, 0x85, 0xea, 0xc8, 0xc2, 0x64, 0xd4, 0x79, 0x05, 0x35, 0xa1,
-	0xf6, 0x63, 0x82, 0x23, 0x31, 0x9e, 0x74, 0x2c, 0xd4, 0x82, 0xfa, 0xbd, 0x41, 0x42, 0x59, 0x8c,
-	0xa3, 0x4e, 0xe5, 0xc6, 0x0e, 0xac, 0x1d, 0x7b, 0x00, 0x4b, 0x13, 0x8f, 0x1e, 0x49, 0x2c, 0x83,
-	0xce, 0x2b, 0x68, 0x15, 0x9a, 0x5d, 0x1a, 0x65, 0x71, 0xa2, 0x05, 0xd6, 0xee, 0xfb, 0x3f, 0xff,
-	0xce, 0x28, 0x14, 0xe3, 0x6c, 0x20, 0x81, 0xbf, 0xad, 0x23, 0xf1, 0x76, 0x48, 0xcd, 0xd7, 0xed,
-	0x9c, 0x64, 0xb7, 0x55, 0x70, 0x8a, 0x61, 0x3a, 0x18, 0x2c, 0x2b, 0xc9, 0x3b, 0xff, 0x0b, 0x00,
-	0x00, 0xff, 0xff, 0x48, 0x13, 0x23, 0xf5, 0xc1, 0x1c, 0x00, 0x00,
+	// 2658 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x5b, 0x73, 0x23, 0x47,
+	0x15, 0x8e, 0x34, 0xb6, 0x25, 0x1d, 0x5d, 0xdd, 0xb6, 0x37, 0xb3, 0x97, 0x24, 0xce, 0x6c, 0x12,
+	0x9c, 0x2c, 0xd9, 0x4d, 0x9c, 0x2b, 0x81, 0x22, 0x59, 0x4b, 0x64, 0xa3, 0xca, 0xee, 0xe2, 0x8c,
+	0x36, 0xa1, 0x80, 0x87, 0xa9, 0xd6, 0x4c, 0x5b, 0x1a, 0x3c, 0xb7, 0x74, 0xf7, 0xac, 0xed, 0x3c,
+	0xf1, 0xc0, 0x13, 0x14, 0x54, 0xf1, 0x03, 0xc2, 0xdf, 0xe0, 0x09, 0xa8, 0xa2, 0x78, 0xa0, 0x78,
+	0xe0, 0x81, 0x37, 0xaa, 0xf8, 0x1f, 0x54, 0xf1, 0x44, 0xf5, 0x65, 0x2e, 0x92, 0x25, 0xaf, 0xbd,
+	0xa9, 0x90, 0x50, 0x95, 0x37, 0xf5, 0x77, 0x4e, 0xdf, 0xce, 0xf9, 0xce, 0x39, 0xdd, 0x3d, 0x82,
+	0x8e, 0x1f, 0x71, 0x42, 0x23, 0x1c, 0xdc, 0x4c, 0x68, 0xcc, 0x63, 0xb4, 0x15, 0xfa, 0xc1, 0xc3,
+	0x94, 0xa9, 0xd6, 0xcd, 0x4c, 0x78, 0xa5, 0xe5, 0xc6, 0x61, 0x18, 0x47, 0x0a, 0xbe, 0xd2, 0x62,
+	0xee, 0x94, 0x84, 0x58, 0xb5, 0xac, 0x3f, 0x56, 0xa0, 0xdd, 0x8f, 0xc3, 0x24, 0x8e, 0x48, 0xc4,
+	0x87, 0xd1, 0x41, 0x8c, 0x2e, 0xc1, 0x5a, 0x14, 0x7b, 0x64, 0x38, 0x30, 0x2b, 0xdb, 0x95, 0x1d,
+	0xc3, 0xd6, 0x2d, 0x84, 0x60, 0x85, 0xc6, 0x01, 0x31, 0xab, 0xdb, 0x95, 0x9d, 0x86, 0x2d, 0x7f,
+	0xa3, 0x77, 0x01, 0x18, 0xc7, 0x9c, 0x38, 0x6e, 0xec, 0x11, 0xd3, 0xd8, 0xae, 0xec, 0x74, 0x76,
+	0xb7, 0x6f, 0x2e, 0x5c, 0xc5, 0xcd, 0x91, 0x50, 0xec, 0xc7, 0x1e, 0xb1, 0x1b, 0x2c, 0xfb, 0x89,
+	0xde, 0x03, 0x20, 0xc7, 0x9c, 0x62, 0xc7, 0x8f, 0x0e, 0x62, 0x73, 0x65, 0xdb, 0xd8, 0x69, 0xee,
+	0x3e, 0x3b, 0x3b, 0x80, 0x5e, 0xfc, 0x87, 0xe4, 0xe4, 0x13, 0x1c, 0xa4, 0x64, 0x1f, 0xfb, 0xd4,
+	0x6e, 0xc8, 0x4e, 0x62, 0xb9, 0xd6, 0x3f, 0x2b, 0xd0, 0xcd, 0x37, 0x20, 0xe7, 0x60, 0xe8, 0x1d,
+	0x58, 0x95, 0x53, 0xc8, 0x1d, 0x34, 0x77, 0x9f, 0x5b, 0xb2, 0xa2, 0x99, 0x7d, 0xdb, 0xaa, 0x0b,
+	0xfa, 0x18, 0x36, 0x58, 0x3a, 0x76, 0x33, 0x91, 0x23, 0x51, 0x66, 0x56, 0xb7, 0x8d, 0x73, 0x8f,
+	0x84, 0xca, 0x03, 0xe8, 0x25, 0xbd, 0x06, 0x6b, 0x62, 0xa4, 0x94, 0x49, 0x2b, 0x35, 0x77, 0xaf,
+	0x2e, 0xdc, 0xe4, 0x48, 0xaa, 0xd8, 0x5a, 0xd5, 0xba, 0x0a, 0x97, 0xef, 0x10, 0x3e, 0xb7, 0x3b,
+	0x9b, 0x7c, 0x9a, 0x12, 0xc6, 0xb5, 0xf0, 0x81, 0x1f, 0x92, 0x07, 0xbe, 0x7b, 0xd8, 0x9f, 0xe2,
+	0x28, 0x22, 0x41, 0x26, 0x7c, 0x0a, 0xae, 0xde, 0x21, 0xb2, 0x83, 0xcf, 0xb8, 0xef, 0xb2, 0x39,
+	0xf1, 0x16, 0x6c, 0xdc, 0x21, 0x7c, 0xe0, 0xcd, 0xc1, 0x9f, 0x40, 0xfd, 0xbe, 0x70, 0xb6, 0xa0,
+	0xc1, 0x9b, 0x50, 0xc3, 0x9e, 0x47, 0x09, 0x63, 0xda, 0x8a, 0xd7, 0x16, 0xae, 0xf8, 0xb6, 0xd2,
+	0xb1, 0x33, 0xe5, 0x45, 0x34, 0xb1, 0x7e, 0x06, 0x30, 0x8c, 0x7c, 0xbe, 0x8f, 0x29, 0x0e, 0xd9,
+	0x52, 0x82, 0x0d, 0xa0, 0xc5, 0x38, 0xa6, 0xdc, 0x49, 0xa4, 0x9e, 0x59, 0x3d, 0x2f, 0x1b, 0x9a,
+	0xb2, 0x9b, 0x1a, 0xdd, 0xfa, 0x31, 0xc0, 0x88, 0x53, 0x3f, 0x9a, 0xdc, 0xf5, 0x19, 0x17, 0x73,
+	0x3d, 0x14, 0x7a, 0x62, 0x13, 0xc6, 0x4e, 0xc3, 0xd6, 0xad, 0x92, 0x3b, 0xaa, 0xe7, 0x77, 0xc7,
+	0xbb, 0xd0, 0xcc, 0xcc, 0x7d, 0x8f, 0x4d, 0xd0, 0x2b, 0xb0, 0x32, 0xc6, 0x8c, 0x9c, 0x69, 0x9e,
+	0x7b, 0x6c, 0xb2, 0x87, 0x19, 0xb1, 0xa5, 0xa6, 0xf5, 0x4b, 0x03, 0x9e, 0xec, 0x53, 0x22, 0xc9,
+	0x1f, 0x04, 0xc4, 0xe5, 0x7e, 0x1c, 0x69, 0xdb, 0x5f, 0x7c, 0x34, 0xf4, 0x24, 0xd4, 0xbc, 0xb1,
+	0x13, 0xe1, 0x30, 0x33, 0xf6, 0x9a, 0x37, 0xbe, 0x8f, 0x43, 0x82, 0x5e, 0x80, 0x8e, 0x9b, 0x8f,
+	0x2f, 0x10, 0xc9, 0xb9, 0x86, 0x3d, 0x87, 0xa2, 0xe7, 0xa0, 0x9d, 0x60, 0xca, 0xfd, 0x5c, 0x6d,
+	0x45, 0xaa, 0xcd, 0x82, 0xc2, 0xa1, 0xde, 0x78, 0x38, 0x30, 0x57, 0xa5, 0xb3, 0xe4, 0x6f, 0x64,
+	0x41, 0xab, 0x18, 0x6b, 0x38, 0x30, 0xd7, 0xa4, 0x6c, 0x06, 0x43, 0xdb, 0xd0, 0xcc, 0x07, 0x1a,
+	0x0e, 0xcc, 0x9a, 0x54, 0x29, 0x43, 0xc2, 0x39, 0x2a, 0x17, 0x99, 0xf5, 0xed, 0xca, 0x4e, 0xcb,
+	0xd6, 0x2d, 0xf4, 0x0a, 0x6c, 0x3c, 0xf4, 0x29, 0x4f, 0x71, 0xa0, 0xf9, 0x29, 0xd6, 0xc1, 0xcc,
+	0x86, 0xf4, 0xe0, 0x22, 0x11, 0xda, 0x85, 0xcd, 0x64, 0x7a, 0xc2, 0x7c, 0x77, 0xae, 0x0b, 0xc8,
+	0x2e, 0x0b, 0x65, 0xd6, 0x9f, 0x2b, 0xb0, 0x35, 0xa0, 0x71, 0xf2, 0xb5, 0x70, 0x45, 0x66, 0xe4,
+	0x95, 0x33, 0x8c, 0xbc, 0x7a, 0xda, 0xc8, 0xd6, 0xaf, 0xab, 0x70, 0x49, 0x31, 0x6a, 0x3f, 0x33,
+	0xec, 0x97, 0xb0, 0x8b, 0x6f, 0x41, 0xb7, 0x98, 0xd5, 0x89, 0x96, 0x6f, 0xe3, 0x79, 0xe8, 0xe4,
+	0x0e, 0x56, 0x7a, 0xff, 0x5b, 0x4a, 0x59, 0xbf, 0xaa, 0xc2, 0xa6, 0x70, 0xea, 0x37, 0xd6, 0x10,
+	0xd6, 0xf8, 0x5d, 0x05, 0x90, 0x62, 0xc7, 0xed, 0xc0, 0xc7, 0xec, 0xab, 0xb4, 0xc5, 0x26, 0xac,
+	0x62, 0xb1, 0x06, 0x6d, 0x02, 0xd5, 0xb0, 0x18, 0xf4, 0x84, 0xb7, 0xbe, 0xac, 0xd5, 0xe5, 0x93,
+	0x1a, 0xe5, 0x49, 0x3f, 0xaf, 0xc0, 0xfa, 0xed, 0x80, 0x13, 0xfa, 0x35, 0x35, 0xca, 0x9f, 0xaa,
+	0x99, 0xd7, 0x86, 0x91, 0x47, 0x8e, 0xbf, 0xca, 0x05, 0x3e, 0x05, 0x70, 0xe0, 0x93, 0xc0, 0x2b,
+	0xb3, 0xb7, 0x21, 0x91, 0x2f, 0xc4, 0x5c, 0x13, 0x6a, 0x72, 0x90, 0x9c, 0xb5, 0x59, 0x53, 0x9c,
+	0x01, 0xd4, 0x79, 0x50, 0x9f, 0x01, 0xea, 0xe7, 0x3e, 0x03, 0xc8, 0x6e, 0xfa, 0x0c, 0xf0, 0xf7,
+	0x15, 0x68, 0x0f, 0x23, 0x46, 0x28, 0x7f, 0x7c, 0xe3, 0x5d, 0x83, 0x06, 0x9b, 0x62, 0xea, 0xdd,
+	0x2f, 0xcc, 0x57, 0x00, 0x65, 0xd3, 0x1a, 0x8f, 0x32, 0xed, 0xca, 0x39, 0x93, 0xc3, 0xea, 0x59,
+	0xc9, 0x61, 0xed, 0x0c, 0x13, 0xd7, 0x1e, 0x9d, 0x1c, 0xea, 0xa7, 0xab, 0xaf, 0xd8, 0x20, 0x99,
+	0x84, 0xe2, 0xd0, 0x3a, 0x30, 0x1b, 0x52, 0x5e, 0x00, 0xe8, 0x69, 0x00, 0xee, 0x87, 0x84, 0x71,
+	0x1c, 0x26, 0xaa, 0x8e, 0xae, 0xd8, 0x25, 0x44, 0xd4, 0x6e, 0x1a, 0x1f, 0x0d, 0x07, 0xcc, 0x6c,
+	0x6e, 0x1b, 0xe2, 0x10, 0xa7, 0x5a, 0xe8, 0x75, 0xa8, 0xd3, 0xf8, 0xc8, 0xf1, 0x30, 0xc7, 0x66,
+	0x4b, 0x3a, 0xef, 0xf2, 0x42, 0x63, 0xef, 0x05, 0xf1, 0xd8, 0xae, 0xd1, 0xf8, 0x68, 0x80, 0x39,
+	0x46, 0xef, 0x42, 0x53, 0x32, 0x80, 0xa9, 0x8e, 0x6d, 0xd9, 0xf1, 0xe9, 0xd9, 0x8e, 0xfa, 0xda,
+	0xf2, 0xbe, 0xd0, 0x13, 0x9d, 0x6c, 0x45, 0x4d, 0x26, 0x07, 0xb8, 0x0c, 0xf5, 0x28, 0x0d, 0x1d,
+	0x1a, 0x1f, 0x31, 0xb3, 0xb3, 0x5d, 0xd9, 0x59, 0xb1, 0x6b, 0x51, 0x1a, 0xda, 0xf1, 0x11, 0x43,
+	0x7b, 0x50, 0x7b, 0x48, 0x28, 0xf3, 0xe3, 0xc8, 0xec, 0xca, 0x0b, 0xca, 0xce, 0x92, 0x43, 0xbc,
+	0x62, 0x8c, 0x18, 0xee, 0x13, 0xa5, 0x6f, 0x67, 0x1d, 0xad, 0xdf, 0xd6, 0xa0, 0x3d, 0x22, 0x98,
+	0xba, 0xd3, 0xc7, 0x27, 0xd4, 0x8b, 0xd0, 0xa3, 0x84, 0xa5, 0x01, 0x77, 0x5c, 0x75, 0x0c, 0x19,
+	0x0e, 0x34, 0xaf, 0xba, 0x0a, 0xef, 0x67, 0x70, 0xee, 0x74, 0xe3, 0x0c, 0xa7, 0xaf, 0x2c, 0x70,
+	0xba, 0x05, 0xad, 0x92, 0x87, 0x99, 0xb9, 0x2a, 0x5d, 0x33, 0x83, 0xa1, 0x1e, 0x18, 0x1e, 0x0b,
+	0x24, 0x9f, 0x1a, 0xb6, 0xf8, 0x89, 0x6e, 0xc0, 0x7a, 0x12, 0x60, 0x97, 0x4c, 0xe3, 0xc0, 0x23,
+	0xd4, 0x99, 0xd0, 0x38, 0x4d, 0x24, 0xa7, 0x5a, 0x76, 0xaf, 0x24, 0xb8, 0x23, 0x70, 0xf4, 0x16,
+	0xd4, 0x3d, 0x16, 0x38, 0xfc, 0x24, 0x21, 0x92, 0x54, 0x9d, 0x25, 0x7b, 0x1f, 0xb0, 0xe0, 0xc1,
+	0x49, 0x42, 0xec, 0x9a, 0xa7, 0x7e, 0xa0, 0x57, 0x60, 0x93, 0x11, 0xea, 0xe3, 0xc0, 0xff, 0x8c,
+	0x78, 0x0e, 0x39, 0x4e, 0xa8, 0x93, 0x04, 0x38, 0x92, 0xcc, 0x6b, 0xd9, 0xa8, 0x90, 0xfd, 0xe0,
+	0x38, 0xa1, 0xfb, 0x01, 0x8e, 0xd0, 0x0e, 0xf4, 0xe2, 0x94, 0x27, 0x29, 0x77, 0x34, 0x37, 0x7c,
+	0x4f, 0x12, 0xd1, 0xb0, 0x3b, 0x0a, 0x97, 0x54, 0x60, 0x43, 0x4f, 0x98, 0x96, 0x53, 0xfc, 0x90,
+	0x04, 0x4e, 0xce, 0x50, 0xb3, 0x29, 0x59, 0xd0, 0x55, 0xf8, 0x83, 0x0c, 0x46, 0xb7, 0x60, 0x63,
+	0x92, 0x62, 0x8a, 0x23, 0x4e, 0x48, 0x49, 0xbb, 0x25, 0xb5, 0x51, 0x2e, 0x2a, 0x3a, 0xdc, 0x80,
+	0x75, 0xa1, 0x16, 0xa7, 0xbc, 0xa4, 0xde, 0x96, 0xea, 0x3d, 0x2d, 0x28, 0x94, 0x5f, 0x06, 0xc4,
+	0x22, 0x9c, 0xb0, 0x69, 0x5c, 0xd6, 0x56, 0x84, 0x5c, 0xcf, 0x24, 0x85, 0xfa, 0x8b, 0xd0, 0x8b,
+	0x62, 0x1a, 0xca, 0x7d, 0x3b, 0xcc, 0x8d, 0x29, 0x61, 0x92, 0xa3, 0x75, 0xbb, 0x9b, 0xe3, 0x23,
+	0x09, 0x0b, 0xd5, 0x10, 0x47, 0x1e, 0xe6, 0x31, 0x3d, 0x71, 0x0e, 0x7c, 0x51, 0xbe, 0xcc, 0x9e,
+	0x62, 0x4f, 0x8e, 0xbf, 0x2f, 0x61, 0xb4, 0x0b, 0x5b, 0xf3, 0xaa, 0xca, 0xd4, 0xeb, 0xd2, 0xd4,
+	0x1b, 0x73, 0xfa, 0xd2, 0xd6, 0xaf, 0xc1, 0xd6, 0x11, 0xf1, 0x27, 0x53, 0x4e, 0x3c, 0x67, 0x86,
+	0x42, 0x48, 0x1a, 0x7c, 0x33, 0x13, 0xee, 0x97, 0x64, 0x92, 0x38, 0x59, 0xdb, 0x51, 0x1a, 0xcc,
+	0xdc, 0xd8, 0x36, 0x76, 0xaa, 0x76, 0x2f, 0x17, 0xfc, 0x48, 0xe1, 0xc2, 0xff, 0x21, 0x3e, 0x76,
+	0x98, 0x2b, 0x48, 0xee, 0x39, 0x3a, 0xd3, 0x30, 0x73, 0x53, 0xf2, 0x18, 0x85, 0xf8, 0x78, 0xa4,
+	0x44, 0x23, 0x2d, 0x11, 0xc5, 0x87, 0xaa, 0x68, 0x13, 0x9e, 0xdf, 0x52, 0x29, 0x58, 0x23, 0x43,
+	0xcf, 0xfa, 0xc3, 0x6a, 0x11, 0x93, 0x22, 0x7c, 0xd8, 0x63, 0xc4, 0xe4, 0xe3, 0x5c, 0x03, 0x17,
+	0x06, 0xb2, 0xb1, 0x38, 0x90, 0x9f, 0x81, 0x66, 0x48, 0x38, 0xf5, 0x5d, 0x15, 0x30, 0xaa, 0x12,
+	0x80, 0x82, 0x64, 0x54, 0x3c, 0x03, 0x4d, 0x91, 0xb7, 0x3e, 0x4d, 0x09, 0xf5, 0x09, 0xd3, 0x85,
+	0x14, 0xa2, 0x34, 0xfc, 0x48, 0x21, 0x68, 0x03, 0x56, 0x79, 0x9c, 0x38, 0x87, 0x59, 0x01, 0xe0,
+	0x71, 0xf2, 0x21, 0xfa, 0x1e, 0x5c, 0x61, 0x04, 0x07, 0x85, 0x19, 0x87, 0x03, 0xe6, 0x30, 0x69,
+	0x0b, 0xe2, 0x99, 0x35, 0xe9, 0x32, 0x53, 0x69, 0x8c, 0x72, 0x85, 0x91, 0x96, 0x8b, 0x10, 0xc8,
+	0x17, 0x5e, 0xea, 0x56, 0x97, 0x77, 0x25, 0x54, 0x88, 0xf2, 0x0e, 0x6f, 0x83, 0x39, 0x09, 0xe2,
+	0x31, 0x0e, 0x9c, 0x53, 0xb3, 0xca, 0x4b, 0x99, 0x61, 0x5f, 0x52, 0xf2, 0xd1, 0xdc, 0x94, 0x62,
+	0x7b, 0x2c, 0xf0, 0x5d, 0xe2, 0x39, 0xe3, 0x20, 0x1e, 0x9b, 0x20, 0x09, 0x08, 0x0a, 0x12, 0x15,
+	0x40, 0xc4, 0xb8, 0x56, 0x10, 0x66, 0x70, 0xe3, 0x34, 0xe2, 0x32, 0x72, 0x0d, 0xbb, 0xa3, 0xf0,
+	0xfb, 0x69, 0xd8, 0x17, 0x28, 0xba, 0x0e, 0x6d, 0xad, 0x19, 0x1f, 0x1c, 0x30, 0xc2, 0x65, 0xc8,
+	0x1a, 0x76, 0x4b, 0x81, 0x3f, 0x94, 0x18, 0xfa, 0x0e, 0x5c, 0x2e, 0xcd, 0xe7, 0x88, 0x47, 0x18,
+	0x4a, 0x18, 0x53, 0xd6, 0x6f, 0x4b, 0xeb, 0x5f, 0x2a, 0x66, 0xef, 0x6b, 0xb1, 0xf4, 0xc4, 0x8b,
+	0xd0, 0x63, 0x87, 0x7e, 0x92, 0x94, 0xb9, 0xd9, 0x91, 0x53, 0x74, 0x35, 0x9e, 0x13, 0xf3, 0x79,
+	0xe8, 0x50, 0x82, 0xbd, 0x52, 0x84, 0x77, 0x65, 0x84, 0xb7, 0x05, 0x5a, 0x44, 0xf7, 0x2c, 0x7f,
+	0x7b, 0xf3, 0xfc, 0xfd, 0xdb, 0x2a, 0x74, 0x6d, 0xc1, 0x04, 0xf2, 0x90, 0xfc, 0xdf, 0x57, 0x95,
+	0x65, 0xd9, 0x7d, 0xed, 0x42, 0xd9, 0xbd, 0x76, 0xee, 0xec, 0x5e, 0xbf, 0x50, 0x76, 0x6f, 0x5c,
+	0x2c, 0xbb, 0xc3, 0x85, 0xb2, 0x7b, 0xf3, 0x8c, 0xec, 0x7e, 0x2a, 0x65, 0xb7, 0x2e, 0x98, 0xb2,
+	0xdb, 0xcb, 0x53, 0xf6, 0xb2, 0x84, 0xda, 0x39, 0x67, 0x42, 0xed, 0xce, 0x11, 0x12, 0xbd, 0x03,
+	0x6b, 0x6e, 0x4a, 0x59, 0xac, 0x0a, 0x4b, 0x73, 0xd7, 0x5a, 0x72, 0x4e, 0x12, 0xa9, 0xe9, 0xa4,
+	0x2f, 0x35, 0x6d, 0xdd, 0xc3, 0xfa, 0xc7, 0x0c, 0x99, 0xbf, 0xae, 0xe9, 0xf8, 0x25, 0x30, 0x7c,
+	0x4f, 0xdd, 0xb6, 0x9a, 0xbb, 0xe6, 0xc2, 0xe3, 0xe5, 0x70, 0xc0, 0x6c, 0xa1, 0x34, 0x7f, 0x24,
+	0x5d, 0xbd, 0xf0, 0x91, 0xf4, 0xfb, 0x70, 0xf5, 0x74, 0x92, 0xa6, 0xda, 0x46, 0x9e, 0xb9, 0x26,
+	0xb9, 0x7e, 0x79, 0x3e, 0x4b, 0x67, 0x46, 0xf4, 0xd0, 0xab, 0xb0, 0x59, 0x4a, 0xd3, 0x45, 0xc7,
+	0x9a, 0x7a, 0x06, 0x2b, 0x64, 0x45, 0x97, 0xb3, 0x12, 0x75, 0xfd, 0xcc, 0x44, 0x2d, 0xaf, 0x2d,
+	0x2a, 0x1b, 0x66, 0xc9, 0x5a, 0x1d, 0xcc, 0x3a, 0x05, 0x2c, 0x13, 0xf6, 0x75, 0x68, 0xcf, 0x66,
+	0x55, 0x90, 0xa6, 0x6e, 0xb9, 0xe5, 0x5c, 0x7a, 0x1d, 0xda, 0x21, 0xe6, 0xa2, 0x76, 0xcc, 0xa4,
+	0xf4, 0x96, 0x06, 0x55, 0x42, 0x5f, 0x94, 0x70, 0x5b, 0xe7, 0x4d, 0xb8, 0xed, 0x47, 0x27, 0xdc,
+	0xce, 0x3c, 0xbf, 0x6f, 0xc1, 0x86, 0xe7, 0x33, 0x4e, 0xfd, 0x71, 0x2a, 0x4f, 0x2c, 0x9e, 0x3f,
+	0x21, 0x8c, 0xeb, 0xdc, 0x8d, 0xca, 0xa2, 0x81, 0x94, 0x58, 0x7f, 0x35, 0xa0, 0x3d, 0x20, 0x01,
+	0xe1, 0xe4, 0x9b, 0x6b, 0xe4, 0xd2, 0x6b, 0xe4, 0xb7, 0x01, 0xf9, 0x11, 0x7f, 0xf3, 0x75, 0x27,
+	0xa1, 0x7e, 0x88, 0xe9, 0x89, 0x73, 0x48, 0x4e, 0xb2, 0x63, 0x41, 0x4f, 0x4a, 0xf6, 0x95, 0xe0,
+	0x43, 0x72, 0xc2, 0x1e, 0x79, 0xad, 0x2c, 0xdf, 0xe3, 0x14, 0x69, 0xf2, 0x7b, 0xdc, 0x77, 0xa1,
+	0x35, 0x33, 0x45, 0xeb, 0x11, 0x51, 0xdc, 0x4c, 0x8a, 0x79, 0xad, 0xff, 0x54, 0xa0, 0x71, 0x37,
+	0xc6, 0x9e, 0x7c, 0x51, 0x79, 0x4c, 0x37, 0xe6, 0x97, 0xe5, 0xea, 0xfc, 0x65, 0xf9, 0x1a, 0x14,
+	0x8f, 0x22, 0xda, 0x91, 0x05, 0x50, 0x7e, 0xed, 0x58, 0x99, 0x7d, 0xed, 0x78, 0x06, 0x9a, 0xbe,
+	0x58, 0x90, 0x93, 0x60, 0x3e, 0x55, 0x85, 0xb5, 0x61, 0x83, 0x84, 0xf6, 0x05, 0x22, 0x9e, 0x43,
+	0x32, 0x05, 0xf9, 0x1c, 0xb2, 0x76, 0xee, 0xe7, 0x10, 0x3d, 0x88, 0xe8, 0x65, 0xfd, 0xa2, 0x22,
+	0xbe, 0xbf, 0x78, 0xe4, 0x58, 0x64, 0xce, 0xd3, 0x83, 0x56, 0x1e, 0x67, 0x50, 0x51, 0x7e, 0xa4,
+	0xa7, 0x48, 0x80, 0x79, 0x39, 0x84, 0x95, 0x71, 0x90, 0xf0, 0x9a, 0x12, 0x65, 0x51, 0x6c, 0xfd,
+	0xa6, 0x02, 0x20, 0x53, 0xa5, 0x5a, 0xc6, 0x3c, 0xfd, 0x2a, 0x67, 0x3f, 0x14, 0x55, 0x67, 0x4d,
+	0xb7, 0x97, 0x99, 0x8e, 0x89, 0xc1, 0x4c, 0x63, 0xd1, 0x1e, 0x4a, 0x37, 0xfb, 0x6c, 0xf3, 0xda,
+	0xba, 0xf2, 0xb7, 0xf5, 0x79, 0x15, 0x5a, 0x7a, 0x75, 0x6a, 0x49, 0x33, 0x5e, 0xae, 0xcc, 0x7b,
+	0x59, 0x1e, 0xe6, 0x43, 0x51, 0xa1, 0x99, 0xff, 0x19, 0xd1, 0x0b, 0x02, 0x05, 0x8d, 0xfc, 0xcf,
+	0xc8, 0x0c, 0x79, 0x8d, 0x59, 0xf2, 0xde, 0x80, 0x75, 0x4a, 0x5c, 0x12, 0xf1, 0xe0, 0xc4, 0x09,
+	0x63, 0xcf, 0x3f, 0xf0, 0x89, 0x27, 0xd9, 0x50, 0xb7, 0x7b, 0x99, 0xe0, 0x9e, 0xc6, 0x65, 0x1e,
+	0x8b, 0x8f, 0x9c, 0x71, 0xea, 0x4d, 0x08, 0xd7, 0x77, 0x82, 0x06, 0x8d, 0x8f, 0xf6, 0x24, 0x20,
+	0x12, 0x27, 0x0e, 0x82, 0xd8, 0x95, 0x76, 0x77, 0xa7, 0x69, 0x74, 0xc8, 0x74, 0x5c, 0x77, 0x73,
+	0xbc, 0x2f, 0x61, 0x31, 0x92, 0x54, 0x50, 0x6b, 0x52, 0x01, 0xde, 0x90, 0x88, 0x5c, 0xd5, 0x53,
+	0x00, 0x9e, 0xcf, 0x0e, 0x9d, 0x94, 0xe1, 0x09, 0xd1, 0xc1, 0xdd, 0x10, 0xc8, 0xc7, 0x02, 0xb0,
+	0xfe, 0x5d, 0x85, 0x8e, 0x2c, 0xf6, 0xe2, 0xa3, 0xa0, 0xb2, 0xd0, 0xc5, 0x23, 0xe7, 0x3d, 0x69,
+	0x53, 0xed, 0x26, 0xf5, 0x49, 0xef, 0xfa, 0xb2, 0x2f, 0xc4, 0x25, 0x5f, 0xd8, 0x75, 0x46, 0x26,
+	0x6a, 0xce, 0x3d, 0x5d, 0x89, 0xcf, 0xe5, 0xea, 0x82, 0x60, 0xba, 0x18, 0xab, 0x31, 0x3e, 0x82,
+	0x5e, 0x29, 0x9f, 0xaa, 0x81, 0xd4, 0xd7, 0xe6, 0x17, 0x96, 0x7e, 0xd2, 0xcd, 0xd4, 0xd5, 0x68,
+	0x5d, 0x77, 0x16, 0x40, 0x6f, 0xc0, 0x25, 0x4a, 0x02, 0x82, 0x99, 0xac, 0x72, 0x05, 0x69, 0xb3,
+	0x03, 0xf2, 0x56, 0x26, 0xed, 0x97, 0x85, 0xa2, 0x36, 0x1e, 0xa4, 0x41, 0xe0, 0x64, 0xe7, 0x45,
+	0xe9, 0xba, 0xba, 0xdd, 0x12, 0xe0, 0x48, 0x63, 0xd6, 0xcf, 0x2b, 0xd0, 0xbc, 0xc7, 0x26, 0xfb,
+	0x31, 0x93, 0x69, 0x16, 0x3d, 0x0b, 0x2d, 0x5d, 0xef, 0x55, 0x8e, 0xaf, 0xc8, 0x1c, 0xd3, 0x74,
+	0x8b, 0xef, 0x59, 0xe2, 0x2d, 0x39, 0x64, 0x13, 0x1d, 0x28, 0x2d, 0x5b, 0x35, 0xd0, 0x15, 0xa8,
+	0x87, 0x6c, 0x22, 0x9f, 0x6e, 0x74, 0x62, 0xca, 0xdb, 0x82, 0xed, 0x45, 0x41, 0x5d, 0x91, 0x55,
+	0xb0, 0x00, 0xac, 0xdf, 0x8b, 0x6f, 0x07, 0x6a, 0xfc, 0x2f, 0xf4, 0xd1, 0x53, 0xc6, 0x79, 0xf9,
+	0x9b, 0x5c, 0x55, 0x66, 0xb9, 0x19, 0x6c, 0xae, 0x2c, 0x18, 0xa7, 0xca, 0xc2, 0x0d, 0x58, 0xf7,
+	0xc8, 0x01, 0x16, 0x87, 0xbc, 0xf9, 0x25, 0xf7, 0xb4, 0x20, 0x3f, 0x06, 0x58, 0xd7, 0xe0, 0x4a,
+	0x3f, 0x20, 0x98, 0xf6, 0x29, 0xf1, 0x3e, 0x66, 0x84, 0xb2, 0x3e, 0x76, 0xa7, 0x59, 0x09, 0xb7,
+	0x7e, 0x0a, 0x1d, 0x21, 0x20, 0x11, 0xf7, 0x71, 0x20, 0xbf, 0x74, 0x5f, 0x81, 0x7a, 0xca, 0x08,
+	0x2d, 0x19, 0x36, 0x6f, 0x8b, 0x23, 0x3f, 0x89, 0x5c, 0x7a, 0x92, 0xa8, 0x87, 0x11, 0xc6, 0x8e,
+	0x62, 0xea, 0xe9, 0x3a, 0xbe, 0x9e, 0x4b, 0xf6, 0xb5, 0xc0, 0xfa, 0x8b, 0xfc, 0x33, 0xc2, 0x2c,
+	0x4f, 0xce, 0x93, 0xe7, 0xca, 0x99, 0xa3, 0x3a, 0x9b, 0x39, 0xe6, 0xb2, 0x8e, 0x71, 0x2a, 0xeb,
+	0xf4, 0xc0, 0xf8, 0x34, 0x51, 0x87, 0xda, 0x8a, 0x2d, 0x7e, 0xa2, 0x6d, 0x68, 0x71, 0x86, 0x0f,
+	0x88, 0x13, 0xe0, 0x89, 0x13, 0xe6, 0xaf, 0x0a, 0x12, 0xbb, 0x8b, 0x27, 0xf7, 0xe6, 0x03, 0x7f,
+	0x6d, 0x3e, 0xf0, 0xff, 0x55, 0x81, 0x66, 0xe9, 0x94, 0xbf, 0xe4, 0xe2, 0x53, 0x59, 0x76, 0xf1,
+	0xb9, 0x0a, 0x0d, 0x71, 0x79, 0x71, 0xa6, 0x98, 0x4d, 0xe5, 0x76, 0x56, 0xec, 0xba, 0x00, 0x3e,
+	0xc0, 0x6c, 0x8a, 0x5e, 0x85, 0x5a, 0x80, 0x19, 0x77, 0x92, 0x43, 0xd3, 0x78, 0x44, 0x05, 0x5f,
+	0x13, 0x8a, 0xfb, 0x87, 0xe8, 0x3e, 0xb4, 0xcb, 0xa7, 0xb3, 0x2c, 0x72, 0x97, 0xbd, 0xe3, 0x8e,
+	0xc4, 0xf1, 0x6a, 0x50, 0xea, 0x60, 0xcf, 0x76, 0xb7, 0xee, 0xc2, 0xfa, 0x29, 0x1d, 0x61, 0x67,
+	0x2f, 0x0c, 0xb2, 0x3b, 0x84, 0x26, 0x02, 0x78, 0x61, 0xf6, 0xd1, 0x58, 0xbc, 0x78, 0xeb, 0x13,
+	0xa3, 0xda, 0x92, 0x6e, 0xbd, 0xf4, 0x36, 0x34, 0xf2, 0xbf, 0xb6, 0xa0, 0x1e, 0xb4, 0xc4, 0x3f,
+	0x1d, 0xe4, 0x65, 0xd7, 0x8f, 0x26, 0xbd, 0x27, 0x50, 0x13, 0x6a, 0x1f, 0x10, 0x1c, 0xf0, 0xe9,
+	0x49, 0xaf, 0x82, 0x5a, 0x50, 0xbf, 0x3d, 0x56, 0x4f, 0x7b, 0xbd, 0xea, 0x4b, 0xbb, 0xb0, 0x7e,
+	0xea, 0xcd, 0x59, 0xa8, 0xd8, 0xf1, 0x91, 0x88, 0x1f, 0xaf, 0xf7, 0x04, 0xea, 0x42, 0xb3, 0x1f,
+	0x07, 0x69, 0x18, 0x29, 0xa0, 0xb2, 0xf7, 0xd6, 0x4f, 0xde, 0x98, 0xf8, 0x7c, 0x9a, 0x8e, 0x45,
+	0xb0, 0xdd, 0x52, 0x06, 0x78, 0xd9, 0x8f, 0xf5, 0xaf, 0x5b, 0x99, 0x11, 0x6e, 0x49, 0x9b, 0xe4,
+	0xcd, 0x64, 0x3c, 0x5e, 0x93, 0xc8, 0x6b, 0xff, 0x1d, 0x00, 0xb7, 0x88, 0xd9, 0x48, 0x34, 0x24,
+	0x00, 0x00,
 }
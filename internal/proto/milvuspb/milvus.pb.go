@@ -1126,10 +1126,11 @@ type ShowCollectionsResponse struct {
 	// The utc timestamp calculated by created_timestamp
 	CreatedUtcTimestamps []uint64 `protobuf:"varint,5,rep,packed,name=created_utc_timestamps,json=createdUtcTimestamps,proto3" json:"created_utc_timestamps,omitempty"`
 	// Load percentage on querynode when type is InMemory
-	InMemoryPercentages  []int64  `protobuf:"varint,6,rep,packed,name=inMemory_percentages,json=inMemoryPercentages,proto3" json:"inMemory_percentages,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	InMemoryPercentages  []int64                    `protobuf:"varint,6,rep,packed,name=inMemory_percentages,json=inMemoryPercentages,proto3" json:"inMemory_percentages,omitempty"`
+	InMemoryStats        []*CollectionInMemoryStats `protobuf:"bytes,7,rep,name=inMemory_stats,json=inMemoryStats,proto3" json:"inMemory_stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
 }
 
 func (m *ShowCollectionsResponse) Reset()         { *m = ShowCollectionsResponse{} }
@@ -1199,6 +1200,13 @@ func (m *ShowCollectionsResponse) GetInMemoryPercentages() []int64 {
 	return nil
 }
 
+func (m *ShowCollectionsResponse) GetInMemoryStats() []*CollectionInMemoryStats {
+	if m != nil {
+		return m.InMemoryStats
+	}
+	return nil
+}
+
 //
 // Create partition in created collection.
 type CreatePartitionRequest struct {
@@ -2787,6 +2795,7 @@ type MutationResult struct {
 	DeleteCnt            int64            `protobuf:"varint,7,opt,name=delete_cnt,json=deleteCnt,proto3" json:"delete_cnt,omitempty"`
 	UpsertCnt            int64            `protobuf:"varint,8,opt,name=upsert_cnt,json=upsertCnt,proto3" json:"upsert_cnt,omitempty"`
 	Timestamp            uint64           `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Partial              bool             `protobuf:"varint,10,opt,name=partial,proto3" json:"partial,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -2880,6 +2889,13 @@ func (m *MutationResult) GetTimestamp() uint64 {
 	return 0
 }
 
+func (m *MutationResult) GetPartial() bool {
+	if m != nil {
+		return m.Partial
+	}
+	return false
+}
+
 type DeleteRequest struct {
 	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	DbName               string            `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
@@ -2887,6 +2903,7 @@ type DeleteRequest struct {
 	PartitionName        string            `protobuf:"bytes,4,opt,name=partition_name,json=partitionName,proto3" json:"partition_name,omitempty"`
 	Expr                 string            `protobuf:"bytes,5,opt,name=expr,proto3" json:"expr,omitempty"`
 	HashKeys             []uint32          `protobuf:"varint,6,rep,packed,name=hash_keys,json=hashKeys,proto3" json:"hash_keys,omitempty"`
+	Sync                 bool              `protobuf:"varint,7,opt,name=sync,proto3" json:"sync,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -2959,6 +2976,13 @@ func (m *DeleteRequest) GetHashKeys() []uint32 {
 	return nil
 }
 
+func (m *DeleteRequest) GetSync() bool {
+	if m != nil {
+		return m.Sync
+	}
+	return false
+}
+
 type PlaceholderValue struct {
 	Tag  string          `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
 	Type PlaceholderType `protobuf:"varint,2,opt,name=type,proto3,enum=milvus.proto.milvus.PlaceholderType" json:"type,omitempty"`
@@ -3067,6 +3091,8 @@ type SearchRequest struct {
 	SearchParams         []*commonpb.KeyValuePair `protobuf:"bytes,9,rep,name=search_params,json=searchParams,proto3" json:"search_params,omitempty"`
 	TravelTimestamp      uint64                   `protobuf:"varint,10,opt,name=travel_timestamp,json=travelTimestamp,proto3" json:"travel_timestamp,omitempty"`
 	GuaranteeTimestamp   uint64                   `protobuf:"varint,11,opt,name=guarantee_timestamp,json=guaranteeTimestamp,proto3" json:"guarantee_timestamp,omitempty"`
+	SnapshotTimestamp    uint64                   `protobuf:"varint,12,opt,name=snapshot_timestamp,json=snapshotTimestamp,proto3" json:"snapshot_timestamp,omitempty"`
+	ExprTemplateValues   []*TemplateValue         `protobuf:"bytes,13,rep,name=expr_template_values,json=exprTemplateValues,proto3" json:"expr_template_values,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
 	XXX_sizecache        int32                    `json:"-"`
@@ -3174,6 +3200,20 @@ func (m *SearchRequest) GetGuaranteeTimestamp() uint64 {
 	return 0
 }
 
+func (m *SearchRequest) GetSnapshotTimestamp() uint64 {
+	if m != nil {
+		return m.SnapshotTimestamp
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetExprTemplateValues() []*TemplateValue {
+	if m != nil {
+		return m.ExprTemplateValues
+	}
+	return nil
+}
+
 type Hits struct {
 	IDs                  []int64   `protobuf:"varint,1,rep,packed,name=IDs,proto3" json:"IDs,omitempty"`
 	RowData              [][]byte  `protobuf:"bytes,2,rep,name=row_data,json=rowData,proto3" json:"row_data,omitempty"`
@@ -3233,6 +3273,10 @@ type SearchResults struct {
 	Status               *commonpb.Status           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	Results              *schemapb.SearchResultData `protobuf:"bytes,2,opt,name=results,proto3" json:"results,omitempty"`
 	CollectionName       string                     `protobuf:"bytes,3,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	SnapshotTimestamp    uint64                     `protobuf:"varint,4,opt,name=snapshot_timestamp,json=snapshotTimestamp,proto3" json:"snapshot_timestamp,omitempty"`
+	ScoreType            string                     `protobuf:"bytes,5,opt,name=score_type,json=scoreType,proto3" json:"score_type,omitempty"`
+	Partial              bool                       `protobuf:"varint,6,opt,name=partial,proto3" json:"partial,omitempty"`
+	SkippedSegments      int64                      `protobuf:"varint,7,opt,name=skipped_segments,json=skippedSegments,proto3" json:"skipped_segments,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
 	XXX_unrecognized     []byte                     `json:"-"`
 	XXX_sizecache        int32                      `json:"-"`
@@ -3284,6 +3328,34 @@ func (m *SearchResults) GetCollectionName() string {
 	return ""
 }
 
+func (m *SearchResults) GetSnapshotTimestamp() uint64 {
+	if m != nil {
+		return m.SnapshotTimestamp
+	}
+	return 0
+}
+
+func (m *SearchResults) GetScoreType() string {
+	if m != nil {
+		return m.ScoreType
+	}
+	return ""
+}
+
+func (m *SearchResults) GetPartial() bool {
+	if m != nil {
+		return m.Partial
+	}
+	return false
+}
+
+func (m *SearchResults) GetSkippedSegments() int64 {
+	if m != nil {
+		return m.SkippedSegments
+	}
+	return 0
+}
+
 type FlushRequest struct {
 	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	DbName               string            `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
@@ -3403,6 +3475,16 @@ type QueryRequest struct {
 	PartitionNames       []string          `protobuf:"bytes,6,rep,name=partition_names,json=partitionNames,proto3" json:"partition_names,omitempty"`
 	TravelTimestamp      uint64            `protobuf:"varint,7,opt,name=travel_timestamp,json=travelTimestamp,proto3" json:"travel_timestamp,omitempty"`
 	GuaranteeTimestamp   uint64            `protobuf:"varint,8,opt,name=guarantee_timestamp,json=guaranteeTimestamp,proto3" json:"guarantee_timestamp,omitempty"`
+	SnapshotTimestamp    uint64            `protobuf:"varint,9,opt,name=snapshot_timestamp,json=snapshotTimestamp,proto3" json:"snapshot_timestamp,omitempty"`
+	SampleSize           int64             `protobuf:"varint,10,opt,name=sample_size,json=sampleSize,proto3" json:"sample_size,omitempty"`
+	SampleSeed           int64             `protobuf:"varint,11,opt,name=sample_seed,json=sampleSeed,proto3" json:"sample_seed,omitempty"`
+	MaxScannedSegments   int64             `protobuf:"varint,12,opt,name=max_scanned_segments,json=maxScannedSegments,proto3" json:"max_scanned_segments,omitempty"`
+	Limit                int64             `protobuf:"varint,13,opt,name=limit,proto3" json:"limit,omitempty"`
+	OrderByField         string            `protobuf:"bytes,14,opt,name=order_by_field,json=orderByField,proto3" json:"order_by_field,omitempty"`
+	OrderDesc            bool              `protobuf:"varint,15,opt,name=order_desc,json=orderDesc,proto3" json:"order_desc,omitempty"`
+	ArrowFormat          bool              `protobuf:"varint,16,opt,name=arrow_format,json=arrowFormat,proto3" json:"arrow_format,omitempty"`
+	ExprTemplateValues   []*TemplateValue  `protobuf:"bytes,17,rep,name=expr_template_values,json=exprTemplateValues,proto3" json:"expr_template_values,omitempty"`
+	Cursor               []byte            `protobuf:"bytes,18,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -3489,10 +3571,85 @@ func (m *QueryRequest) GetGuaranteeTimestamp() uint64 {
 	return 0
 }
 
+func (m *QueryRequest) GetSnapshotTimestamp() uint64 {
+	if m != nil {
+		return m.SnapshotTimestamp
+	}
+	return 0
+}
+
+func (m *QueryRequest) GetSampleSize() int64 {
+	if m != nil {
+		return m.SampleSize
+	}
+	return 0
+}
+
+func (m *QueryRequest) GetSampleSeed() int64 {
+	if m != nil {
+		return m.SampleSeed
+	}
+	return 0
+}
+
+func (m *QueryRequest) GetMaxScannedSegments() int64 {
+	if m != nil {
+		return m.MaxScannedSegments
+	}
+	return 0
+}
+
+func (m *QueryRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *QueryRequest) GetOrderByField() string {
+	if m != nil {
+		return m.OrderByField
+	}
+	return ""
+}
+
+func (m *QueryRequest) GetOrderDesc() bool {
+	if m != nil {
+		return m.OrderDesc
+	}
+	return false
+}
+
+func (m *QueryRequest) GetArrowFormat() bool {
+	if m != nil {
+		return m.ArrowFormat
+	}
+	return false
+}
+
+func (m *QueryRequest) GetExprTemplateValues() []*TemplateValue {
+	if m != nil {
+		return m.ExprTemplateValues
+	}
+	return nil
+}
+
+func (m *QueryRequest) GetCursor() []byte {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
 type QueryResults struct {
 	Status               *commonpb.Status      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	FieldsData           []*schemapb.FieldData `protobuf:"bytes,2,rep,name=fields_data,json=fieldsData,proto3" json:"fields_data,omitempty"`
 	CollectionName       string                `protobuf:"bytes,3,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	SnapshotTimestamp    uint64                `protobuf:"varint,4,opt,name=snapshot_timestamp,json=snapshotTimestamp,proto3" json:"snapshot_timestamp,omitempty"`
+	Partial              bool                  `protobuf:"varint,5,opt,name=partial,proto3" json:"partial,omitempty"`
+	SkippedSegments      int64                 `protobuf:"varint,6,opt,name=skipped_segments,json=skippedSegments,proto3" json:"skipped_segments,omitempty"`
+	ArrowIpc             []byte                `protobuf:"bytes,7,opt,name=arrow_ipc,json=arrowIpc,proto3" json:"arrow_ipc,omitempty"`
+	Cursor               []byte                `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -3544,6 +3701,41 @@ func (m *QueryResults) GetCollectionName() string {
 	return ""
 }
 
+func (m *QueryResults) GetSnapshotTimestamp() uint64 {
+	if m != nil {
+		return m.SnapshotTimestamp
+	}
+	return 0
+}
+
+func (m *QueryResults) GetPartial() bool {
+	if m != nil {
+		return m.Partial
+	}
+	return false
+}
+
+func (m *QueryResults) GetSkippedSegments() int64 {
+	if m != nil {
+		return m.SkippedSegments
+	}
+	return 0
+}
+
+func (m *QueryResults) GetArrowIpc() []byte {
+	if m != nil {
+		return m.ArrowIpc
+	}
+	return nil
+}
+
+func (m *QueryResults) GetCursor() []byte {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
 type VectorIDs struct {
 	CollectionName       string        `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
 	FieldName            string        `protobuf:"bytes,2,opt,name=field_name,json=fieldName,proto3" json:"field_name,omitempty"`
@@ -5753,6 +5945,125 @@ func (m *ListCredUsersRequest) GetBase() *commonpb.MsgBase {
 	return nil
 }
 
+type CollectionInMemoryStats struct {
+	NumRows              int64    `protobuf:"varint,1,opt,name=num_rows,json=numRows,proto3" json:"num_rows,omitempty"`
+	MemorySize           int64    `protobuf:"varint,2,opt,name=memory_size,json=memorySize,proto3" json:"memory_size,omitempty"`
+	Qps                  float64  `protobuf:"fixed64,3,opt,name=qps,proto3" json:"qps,omitempty"`
+	TsafeLagMs           int64    `protobuf:"varint,4,opt,name=tsafe_lag_ms,json=tsafeLagMs,proto3" json:"tsafe_lag_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CollectionInMemoryStats) Reset()         { *m = CollectionInMemoryStats{} }
+func (m *CollectionInMemoryStats) String() string { return proto.CompactTextString(m) }
+func (*CollectionInMemoryStats) ProtoMessage()    {}
+func (*CollectionInMemoryStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02345ba45cc0e303, []int{89}
+}
+
+func (m *CollectionInMemoryStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectionInMemoryStats.Unmarshal(m, b)
+}
+func (m *CollectionInMemoryStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectionInMemoryStats.Marshal(b, m, deterministic)
+}
+func (m *CollectionInMemoryStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectionInMemoryStats.Merge(m, src)
+}
+func (m *CollectionInMemoryStats) XXX_Size() int {
+	return xxx_messageInfo_CollectionInMemoryStats.Size(m)
+}
+func (m *CollectionInMemoryStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectionInMemoryStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectionInMemoryStats proto.InternalMessageInfo
+
+func (m *CollectionInMemoryStats) GetNumRows() int64 {
+	if m != nil {
+		return m.NumRows
+	}
+	return 0
+}
+
+func (m *CollectionInMemoryStats) GetMemorySize() int64 {
+	if m != nil {
+		return m.MemorySize
+	}
+	return 0
+}
+
+func (m *CollectionInMemoryStats) GetQps() float64 {
+	if m != nil {
+		return m.Qps
+	}
+	return 0
+}
+
+func (m *CollectionInMemoryStats) GetTsafeLagMs() int64 {
+	if m != nil {
+		return m.TsafeLagMs
+	}
+	return 0
+}
+
+// TemplateValue is the typed value of the parameter {name} of an expression template
+type TemplateValue struct {
+	Name                 string                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                *schemapb.ScalarField `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	IsList               bool                  `protobuf:"varint,3,opt,name=is_list,json=isList,proto3" json:"is_list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *TemplateValue) Reset()         { *m = TemplateValue{} }
+func (m *TemplateValue) String() string { return proto.CompactTextString(m) }
+func (*TemplateValue) ProtoMessage()    {}
+func (*TemplateValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02345ba45cc0e303, []int{90}
+}
+
+func (m *TemplateValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TemplateValue.Unmarshal(m, b)
+}
+func (m *TemplateValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TemplateValue.Marshal(b, m, deterministic)
+}
+func (m *TemplateValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TemplateValue.Merge(m, src)
+}
+func (m *TemplateValue) XXX_Size() int {
+	return xxx_messageInfo_TemplateValue.Size(m)
+}
+func (m *TemplateValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_TemplateValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TemplateValue proto.InternalMessageInfo
+
+func (m *TemplateValue) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TemplateValue) GetValue() *schemapb.ScalarField {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *TemplateValue) GetIsList() bool {
+	if m != nil {
+		return m.IsList
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterEnum("milvus.proto.milvus.ShowType", ShowType_name, ShowType_value)
 	proto.RegisterEnum("milvus.proto.milvus.PlaceholderType", PlaceholderType_name, PlaceholderType_value)
@@ -5846,278 +6157,305 @@ func init() {
 	proto.RegisterType((*DeleteCredentialRequest)(nil), "milvus.proto.milvus.DeleteCredentialRequest")
 	proto.RegisterType((*ListCredUsersResponse)(nil), "milvus.proto.milvus.ListCredUsersResponse")
 	proto.RegisterType((*ListCredUsersRequest)(nil), "milvus.proto.milvus.ListCredUsersRequest")
+	proto.RegisterType((*CollectionInMemoryStats)(nil), "milvus.proto.milvus.CollectionInMemoryStats")
+	proto.RegisterType((*TemplateValue)(nil), "milvus.proto.milvus.TemplateValue")
 }
 
 func init() { proto.RegisterFile("milvus.proto", fileDescriptor_02345ba45cc0e303) }
 
 var fileDescriptor_02345ba45cc0e303 = []byte{
-	// 4255 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x3c, 0x4d, 0x6f, 0x1c, 0xc9,
-	0x75, 0xec, 0x19, 0xce, 0xd7, 0x9b, 0x19, 0x72, 0x54, 0xfc, 0x9a, 0x1d, 0x49, 0x2b, 0xaa, 0xf7,
-	0x8b, 0x2b, 0x79, 0x25, 0x2f, 0xb5, 0xde, 0x75, 0x76, 0x9d, 0xac, 0x25, 0x31, 0x2b, 0x11, 0x2b,
-	0x29, 0x74, 0x73, 0xd7, 0x86, 0xb3, 0x10, 0x06, 0xc5, 0xee, 0xe2, 0xb0, 0xa3, 0x9e, 0xee, 0x71,
-	0x57, 0x8d, 0x28, 0xee, 0xc9, 0x80, 0x83, 0x7c, 0xc0, 0x9b, 0x35, 0x82, 0x18, 0x49, 0x7c, 0x48,
-	0x10, 0xe4, 0xe3, 0x90, 0x43, 0x82, 0xd8, 0x01, 0x12, 0x23, 0x97, 0xe4, 0x90, 0x43, 0x0e, 0x01,
-	0xf2, 0x71, 0x09, 0x02, 0x5f, 0xf2, 0x07, 0x72, 0x08, 0x90, 0x63, 0x0e, 0x41, 0x7d, 0x74, 0x4f,
-	0x77, 0x4f, 0xf5, 0x70, 0xa8, 0xb1, 0x4c, 0xea, 0x36, 0xfd, 0xea, 0xbd, 0xaa, 0x57, 0xaf, 0x5e,
-	0xbd, 0x7a, 0xf5, 0xde, 0xab, 0x81, 0x46, 0xdf, 0xf5, 0x1e, 0x0f, 0xe9, 0xb5, 0x41, 0x18, 0xb0,
-	0x00, 0x2d, 0x25, 0xbf, 0xae, 0xc9, 0x8f, 0x4e, 0xc3, 0x0e, 0xfa, 0xfd, 0xc0, 0x97, 0xc0, 0x4e,
-	0x83, 0xda, 0x07, 0xa4, 0x8f, 0xe5, 0x97, 0xf9, 0x87, 0x06, 0xa0, 0xdb, 0x21, 0xc1, 0x8c, 0xdc,
-	0xf4, 0x5c, 0x4c, 0x2d, 0xf2, 0xad, 0x21, 0xa1, 0x0c, 0x7d, 0x11, 0xe6, 0xf7, 0x30, 0x25, 0x6d,
-	0x63, 0xdd, 0xd8, 0xa8, 0x6f, 0x5e, 0xb8, 0x96, 0xea, 0x56, 0x75, 0x77, 0x9f, 0xf6, 0x6e, 0x61,
-	0x4a, 0x2c, 0x81, 0x89, 0xd6, 0xa0, 0xe2, 0xec, 0x75, 0x7d, 0xdc, 0x27, 0xed, 0xc2, 0xba, 0xb1,
-	0x51, 0xb3, 0xca, 0xce, 0xde, 0x03, 0xdc, 0x27, 0xe8, 0x35, 0x58, 0xb4, 0x03, 0xcf, 0x23, 0x36,
-	0x73, 0x03, 0x5f, 0x22, 0x14, 0x05, 0xc2, 0xc2, 0x08, 0x2c, 0x10, 0x97, 0xa1, 0x84, 0x39, 0x0f,
-	0xed, 0x79, 0xd1, 0x2c, 0x3f, 0x4c, 0x0a, 0xad, 0xad, 0x30, 0x18, 0x3c, 0x2b, 0xee, 0xe2, 0x41,
-	0x8b, 0xc9, 0x41, 0xff, 0xc0, 0x80, 0x73, 0x37, 0x3d, 0x46, 0xc2, 0x33, 0x2a, 0x94, 0xdf, 0x2f,
-	0xc0, 0x9a, 0x5c, 0xb5, 0xdb, 0x31, 0xfa, 0x69, 0x72, 0xb9, 0x0a, 0x65, 0xa9, 0x55, 0x82, 0xcd,
-	0x86, 0xa5, 0xbe, 0xd0, 0x45, 0x00, 0x7a, 0x80, 0x43, 0x87, 0x76, 0xfd, 0x61, 0xbf, 0x5d, 0x5a,
-	0x37, 0x36, 0x4a, 0x56, 0x4d, 0x42, 0x1e, 0x0c, 0xfb, 0xc8, 0x82, 0x73, 0x76, 0xe0, 0x53, 0x97,
-	0x32, 0xe2, 0xdb, 0x47, 0x5d, 0x8f, 0x3c, 0x26, 0x5e, 0xbb, 0xbc, 0x6e, 0x6c, 0x2c, 0x6c, 0xbe,
-	0xa2, 0xe5, 0xfb, 0xf6, 0x08, 0xfb, 0x1e, 0x47, 0xb6, 0x5a, 0x76, 0x06, 0x62, 0x7e, 0xd7, 0x80,
-	0x15, 0xae, 0x30, 0x67, 0x42, 0x30, 0xe6, 0x9f, 0x1b, 0xb0, 0x7c, 0x17, 0xd3, 0xb3, 0xb1, 0x4a,
-	0x17, 0x01, 0x98, 0xdb, 0x27, 0x5d, 0xca, 0x70, 0x7f, 0x20, 0x56, 0x6a, 0xde, 0xaa, 0x71, 0xc8,
-	0x2e, 0x07, 0x98, 0xdf, 0x84, 0xc6, 0xad, 0x20, 0xf0, 0x2c, 0x42, 0x07, 0x81, 0x4f, 0x09, 0xba,
-	0x01, 0x65, 0xca, 0x30, 0x1b, 0x52, 0xc5, 0xe4, 0x79, 0x2d, 0x93, 0xbb, 0x02, 0xc5, 0x52, 0xa8,
-	0x5c, 0x5f, 0x1f, 0x63, 0x6f, 0x28, 0x79, 0xac, 0x5a, 0xf2, 0xc3, 0xfc, 0x04, 0x16, 0x76, 0x59,
-	0xe8, 0xfa, 0xbd, 0x9f, 0x62, 0xe7, 0xb5, 0xa8, 0xf3, 0x7f, 0x37, 0xe0, 0x85, 0x2d, 0x42, 0xed,
-	0xd0, 0xdd, 0x3b, 0x23, 0xdb, 0xc1, 0x84, 0xc6, 0x08, 0xb2, 0xbd, 0x25, 0x44, 0x5d, 0xb4, 0x52,
-	0xb0, 0xcc, 0x62, 0x94, 0xb2, 0x8b, 0xf1, 0xed, 0x12, 0x74, 0x74, 0x93, 0x9a, 0x45, 0x7c, 0x3f,
-	0x1f, 0xef, 0xd2, 0x82, 0x20, 0xca, 0xec, 0x31, 0x75, 0x2e, 0x8c, 0x46, 0xdb, 0x15, 0x80, 0x78,
-	0x33, 0x67, 0x67, 0x55, 0xd4, 0xcc, 0x6a, 0x13, 0x56, 0x1e, 0xbb, 0x21, 0x1b, 0x62, 0xaf, 0x6b,
-	0x1f, 0x60, 0xdf, 0x27, 0x9e, 0x90, 0x13, 0x37, 0x5f, 0xc5, 0x8d, 0x9a, 0xb5, 0xa4, 0x1a, 0x6f,
-	0xcb, 0x36, 0x2e, 0x2c, 0x8a, 0xde, 0x82, 0xd5, 0xc1, 0xc1, 0x11, 0x75, 0xed, 0x31, 0xa2, 0x92,
-	0x20, 0x5a, 0x8e, 0x5a, 0x53, 0x54, 0x57, 0xe1, 0x9c, 0x2d, 0x2c, 0xa0, 0xd3, 0xe5, 0x52, 0x93,
-	0x62, 0x2c, 0x0b, 0x31, 0xb6, 0x54, 0xc3, 0x47, 0x11, 0x9c, 0xb3, 0x15, 0x21, 0x0f, 0x99, 0x9d,
-	0x20, 0xa8, 0x08, 0x82, 0x25, 0xd5, 0xf8, 0x31, 0xb3, 0x47, 0x34, 0x69, 0xdb, 0x55, 0xcd, 0xda,
-	0xae, 0x36, 0x54, 0x84, 0x2d, 0x26, 0xb4, 0x5d, 0x13, 0x6c, 0x46, 0x9f, 0x68, 0x1b, 0x16, 0x29,
-	0xc3, 0x21, 0xeb, 0x0e, 0x02, 0xea, 0x72, 0xb9, 0xd0, 0x36, 0xac, 0x17, 0x37, 0xea, 0x9b, 0xeb,
-	0xda, 0x45, 0xfa, 0x90, 0x1c, 0x6d, 0x61, 0x86, 0x77, 0xb0, 0x1b, 0x5a, 0x0b, 0x82, 0x70, 0x27,
-	0xa2, 0xd3, 0x1b, 0xc8, 0xfa, 0x4c, 0x06, 0x52, 0xa7, 0xc5, 0x0d, 0xad, 0xed, 0xfa, 0x91, 0x01,
-	0x2b, 0xf7, 0x02, 0xec, 0x9c, 0x8d, 0x3d, 0xf5, 0x0a, 0x2c, 0x84, 0x64, 0xe0, 0xb9, 0x36, 0xe6,
-	0xeb, 0xb1, 0x47, 0x42, 0xb1, 0xab, 0x4a, 0x56, 0x53, 0x41, 0x1f, 0x08, 0xa0, 0xf9, 0xb9, 0x01,
-	0x6d, 0x8b, 0x78, 0x04, 0xd3, 0xb3, 0x61, 0x0b, 0xcc, 0xef, 0x1b, 0xf0, 0xe2, 0x1d, 0xc2, 0x12,
-	0xbb, 0x8a, 0x61, 0xe6, 0x52, 0xe6, 0xda, 0xa7, 0xe9, 0x57, 0x98, 0xdf, 0x33, 0xe0, 0x52, 0x2e,
-	0x5b, 0xb3, 0x18, 0x99, 0x77, 0xa0, 0xc4, 0x7f, 0xd1, 0x76, 0x41, 0xe8, 0xfc, 0xe5, 0x3c, 0x9d,
-	0xff, 0x3a, 0xb7, 0xdd, 0x42, 0xe9, 0x25, 0xbe, 0xf9, 0x5f, 0x06, 0xac, 0xee, 0x1e, 0x04, 0x87,
-	0x23, 0x96, 0x9e, 0x85, 0x80, 0xd2, 0x66, 0xb7, 0x98, 0x31, 0xbb, 0xe8, 0x4d, 0x98, 0x67, 0x47,
-	0x03, 0x22, 0x74, 0x6b, 0x61, 0xf3, 0xe2, 0x35, 0x8d, 0x3b, 0x7d, 0x8d, 0x33, 0xf9, 0xd1, 0xd1,
-	0x80, 0x58, 0x02, 0x15, 0xbd, 0x0e, 0xad, 0x8c, 0xc8, 0x23, 0xc3, 0xb5, 0x98, 0x96, 0x39, 0x35,
-	0x7f, 0x5c, 0x80, 0xb5, 0xb1, 0x29, 0xce, 0x22, 0x6c, 0xdd, 0xd8, 0x05, 0xed, 0xd8, 0x7c, 0xff,
-	0x24, 0x50, 0x5d, 0x87, 0x7b, 0xbc, 0xc5, 0x8d, 0xa2, 0xd5, 0x4c, 0xd8, 0x6f, 0x87, 0xa2, 0x37,
-	0x00, 0x8d, 0x99, 0x55, 0x69, 0xbd, 0xe7, 0xad, 0x73, 0x59, 0xbb, 0x2a, 0x6c, 0xb7, 0xd6, 0xb0,
-	0x4a, 0x11, 0xcc, 0x5b, 0xcb, 0x1a, 0xcb, 0x4a, 0xd1, 0x9b, 0xb0, 0xec, 0xfa, 0xf7, 0x49, 0x3f,
-	0x08, 0x8f, 0xba, 0x03, 0x12, 0xda, 0xc4, 0x67, 0xb8, 0x47, 0x68, 0xbb, 0x2c, 0x38, 0x5a, 0x8a,
-	0xda, 0x76, 0x46, 0x4d, 0xe6, 0x5f, 0x1b, 0xb0, 0x2a, 0x3d, 0xde, 0x1d, 0x1c, 0x32, 0xf7, 0x0c,
-	0x58, 0xa3, 0x41, 0xc4, 0x87, 0xc4, 0x93, 0xfe, 0x79, 0x33, 0x86, 0x8a, 0x5d, 0xf6, 0x43, 0x03,
-	0x96, 0xb9, 0x33, 0xfa, 0x3c, 0xf1, 0xfc, 0x57, 0x06, 0x2c, 0xdd, 0xc5, 0xf4, 0x79, 0x62, 0xf9,
-	0x27, 0xea, 0xa4, 0x8a, 0x79, 0x3e, 0xd5, 0x2b, 0xdb, 0x6b, 0xb0, 0x98, 0x66, 0x3a, 0xf2, 0x7e,
-	0x16, 0x52, 0x5c, 0x53, 0xcd, 0x91, 0x56, 0xd2, 0x1d, 0x69, 0x7f, 0x3b, 0x3a, 0xd2, 0x9e, 0xaf,
-	0x09, 0x9a, 0x7f, 0x67, 0xc0, 0xc5, 0x3b, 0x84, 0xc5, 0x5c, 0x9f, 0x89, 0xa3, 0x6f, 0x5a, 0xa5,
-	0xfa, 0x5c, 0x1e, 0xdc, 0x5a, 0xe6, 0x4f, 0xe5, 0x80, 0xfc, 0x6e, 0x01, 0x56, 0xf8, 0xe9, 0x71,
-	0x36, 0x94, 0x60, 0x9a, 0x3b, 0x8e, 0x46, 0x51, 0x4a, 0xda, 0x9d, 0x10, 0x1d, 0xbb, 0xe5, 0xa9,
-	0x8f, 0x5d, 0xf3, 0x47, 0x05, 0xe9, 0x2e, 0x24, 0xa5, 0x31, 0xcb, 0xb2, 0x68, 0x78, 0x2d, 0x68,
-	0x79, 0x35, 0xa1, 0x11, 0x43, 0xb6, 0xb7, 0xa2, 0x63, 0x34, 0x05, 0x3b, 0xb3, 0xa7, 0xe8, 0x67,
-	0x06, 0xac, 0x46, 0xb7, 0xca, 0x5d, 0xd2, 0xeb, 0x13, 0x9f, 0x3d, 0xbd, 0x0e, 0x65, 0x35, 0xa0,
-	0xa0, 0xd1, 0x80, 0x0b, 0x50, 0xa3, 0x72, 0x9c, 0xf8, 0xc2, 0x38, 0x02, 0x98, 0x7f, 0x6f, 0xc0,
-	0xda, 0x18, 0x3b, 0xb3, 0x2c, 0x62, 0x1b, 0x2a, 0xae, 0xef, 0x90, 0x27, 0x31, 0x37, 0xd1, 0x27,
-	0x6f, 0xd9, 0x1b, 0xba, 0x9e, 0x13, 0xb3, 0x11, 0x7d, 0xa2, 0xcb, 0xd0, 0x20, 0x3e, 0xde, 0xf3,
-	0x48, 0x57, 0xe0, 0x0a, 0x45, 0xae, 0x5a, 0x75, 0x09, 0xdb, 0xe6, 0x20, 0x4e, 0xbc, 0xef, 0x12,
-	0x41, 0x5c, 0x92, 0xc4, 0xea, 0xd3, 0xfc, 0x2d, 0x03, 0x96, 0xb8, 0x16, 0x2a, 0xee, 0xe9, 0xb3,
-	0x95, 0xe6, 0x3a, 0xd4, 0x13, 0x6a, 0xa6, 0x26, 0x92, 0x04, 0x99, 0x8f, 0x60, 0x39, 0xcd, 0xce,
-	0x2c, 0xd2, 0x7c, 0x11, 0x20, 0x5e, 0x2b, 0xb9, 0x1b, 0x8a, 0x56, 0x02, 0x62, 0x7e, 0x56, 0x88,
-	0x62, 0xc7, 0x42, 0x4c, 0xa7, 0x1c, 0xda, 0x12, 0x4b, 0x92, 0xb4, 0xe7, 0x35, 0x01, 0x11, 0xcd,
-	0x5b, 0xd0, 0x20, 0x4f, 0x58, 0x88, 0xbb, 0x03, 0x1c, 0xe2, 0xbe, 0xdc, 0x56, 0x53, 0x99, 0xde,
-	0xba, 0x20, 0xdb, 0x11, 0x54, 0x7c, 0x10, 0xa1, 0x22, 0x72, 0x90, 0xb2, 0x1c, 0x44, 0x40, 0xc4,
-	0x81, 0xf1, 0x4f, 0xdc, 0xd9, 0x53, 0xda, 0x7c, 0xd6, 0x05, 0x92, 0x9e, 0x4a, 0x29, 0x3b, 0x95,
-	0x3f, 0x33, 0xa0, 0x25, 0xa6, 0x20, 0xe7, 0x33, 0xe0, 0xdd, 0x66, 0x68, 0x8c, 0x0c, 0xcd, 0x84,
-	0xbd, 0xf7, 0x73, 0x50, 0x56, 0x72, 0x2f, 0x4e, 0x2b, 0x77, 0x45, 0x70, 0xcc, 0x34, 0xcc, 0x3f,
-	0x36, 0x60, 0x25, 0x23, 0xf2, 0x59, 0x14, 0xfe, 0x23, 0x40, 0x72, 0x86, 0xce, 0x68, 0xda, 0xd1,
-	0x39, 0xfd, 0x8a, 0xf6, 0x50, 0xca, 0x0a, 0xc9, 0x3a, 0xe7, 0x66, 0x20, 0xd4, 0xfc, 0x57, 0x03,
-	0x2e, 0xdc, 0x21, 0x4c, 0xa0, 0xde, 0xe2, 0x46, 0x67, 0x27, 0x0c, 0x7a, 0x21, 0xa1, 0xf4, 0xf9,
-	0xd5, 0x8f, 0xdf, 0x95, 0x8e, 0x9d, 0x6e, 0x4a, 0xb3, 0xc8, 0xff, 0x32, 0x34, 0xc4, 0x18, 0xc4,
-	0xe9, 0x86, 0xc1, 0x21, 0x55, 0x7a, 0x54, 0x57, 0x30, 0x2b, 0x38, 0x14, 0x0a, 0xc1, 0x02, 0x86,
-	0x3d, 0x89, 0xa0, 0x4e, 0x14, 0x01, 0xe1, 0xcd, 0x62, 0x0f, 0x46, 0x8c, 0xf1, 0xce, 0xc9, 0xf3,
-	0x2b, 0xe3, 0x3f, 0x35, 0x60, 0x25, 0x33, 0x95, 0x59, 0x64, 0xfb, 0x25, 0xe9, 0x76, 0xca, 0xc9,
-	0x2c, 0x6c, 0x5e, 0xd2, 0xd2, 0x24, 0x06, 0x93, 0xd8, 0xe8, 0x12, 0xd4, 0xf7, 0xb1, 0xeb, 0x75,
-	0x43, 0x82, 0x69, 0xe0, 0xab, 0x89, 0x02, 0x07, 0x59, 0x02, 0x62, 0xfe, 0xa3, 0x21, 0x13, 0x74,
-	0xcf, 0xb9, 0xc5, 0xfb, 0x93, 0x02, 0x34, 0xb7, 0x7d, 0x4a, 0x42, 0x76, 0xf6, 0xaf, 0x26, 0xe8,
-	0x7d, 0xa8, 0x8b, 0x89, 0xd1, 0xae, 0x83, 0x19, 0x56, 0xa7, 0xd9, 0x8b, 0xda, 0x68, 0xfe, 0x07,
-	0x1c, 0x6f, 0x0b, 0x33, 0x6c, 0x49, 0xe9, 0x50, 0xfe, 0x1b, 0x9d, 0x87, 0xda, 0x01, 0xa6, 0x07,
-	0xdd, 0x47, 0xe4, 0x48, 0xfa, 0x8b, 0x4d, 0xab, 0xca, 0x01, 0x1f, 0x92, 0x23, 0x8a, 0x5e, 0x80,
-	0xaa, 0x3f, 0xec, 0xcb, 0x0d, 0x56, 0x59, 0x37, 0x36, 0x9a, 0x56, 0xc5, 0x1f, 0xf6, 0xc5, 0xf6,
-	0xfa, 0xe7, 0x02, 0x2c, 0xdc, 0x1f, 0xf2, 0x8b, 0x90, 0xc8, 0x45, 0x0c, 0x3d, 0xf6, 0x74, 0xca,
-	0x78, 0x05, 0x8a, 0xd2, 0xa5, 0xe0, 0x14, 0x6d, 0x2d, 0xe3, 0xdb, 0x5b, 0xd4, 0xe2, 0x48, 0x22,
-	0x0e, 0x3f, 0xb4, 0x6d, 0xe5, 0x9d, 0x15, 0x05, 0xb3, 0x35, 0x0e, 0x91, 0xbe, 0xd9, 0x79, 0xa8,
-	0x91, 0x30, 0x8c, 0x7d, 0x37, 0x31, 0x15, 0x12, 0x86, 0xb2, 0xd1, 0x84, 0x06, 0xb6, 0x1f, 0xf9,
-	0xc1, 0xa1, 0x47, 0x9c, 0x1e, 0x71, 0xc4, 0xb2, 0x57, 0xad, 0x14, 0x4c, 0x2a, 0x06, 0x5f, 0xf8,
-	0xae, 0xed, 0x33, 0x71, 0xaa, 0x17, 0xb9, 0x62, 0x70, 0xc8, 0x6d, 0x9f, 0xf1, 0x66, 0x87, 0x78,
-	0x84, 0x11, 0xd1, 0x5c, 0x91, 0xcd, 0x12, 0xa2, 0x9a, 0x87, 0x83, 0x98, 0xba, 0x2a, 0x9b, 0x25,
-	0x84, 0x37, 0x5f, 0x80, 0xda, 0x28, 0xd9, 0x50, 0x1b, 0x45, 0x1b, 0x05, 0xc0, 0xfc, 0x89, 0x01,
-	0xcd, 0x2d, 0xd1, 0xd5, 0x73, 0xa0, 0x74, 0x08, 0xe6, 0xc9, 0x93, 0x41, 0xa8, 0xb6, 0x8e, 0xf8,
-	0x3d, 0x51, 0x8f, 0xcc, 0xc7, 0xd0, 0xda, 0xf1, 0xb0, 0x4d, 0x0e, 0x02, 0xcf, 0x21, 0xa1, 0x38,
-	0xdb, 0x51, 0x0b, 0x8a, 0x0c, 0xf7, 0x94, 0xf3, 0xc0, 0x7f, 0xa2, 0x2f, 0xab, 0xab, 0x9f, 0x34,
-	0x4b, 0x2f, 0x6b, 0x4f, 0xd9, 0x44, 0x37, 0x89, 0xc0, 0xeb, 0x2a, 0x94, 0x45, 0x02, 0x50, 0xba,
-	0x15, 0x0d, 0x4b, 0x7d, 0x99, 0x0f, 0x53, 0xe3, 0xde, 0x09, 0x83, 0xe1, 0x00, 0x6d, 0x43, 0x63,
-	0x30, 0x82, 0x71, 0x5d, 0xcd, 0x3f, 0xd3, 0xb3, 0x4c, 0x5b, 0x29, 0x52, 0xf3, 0xbf, 0x8b, 0xd0,
-	0xdc, 0x25, 0x38, 0xb4, 0x0f, 0x9e, 0x8b, 0x20, 0x53, 0x0b, 0x8a, 0x0e, 0xf5, 0xd4, 0xaa, 0xf1,
-	0x9f, 0xe8, 0x2a, 0x9c, 0x4b, 0x4c, 0xa8, 0xdb, 0xe3, 0x02, 0x12, 0x7a, 0xdf, 0xb0, 0x5a, 0x83,
-	0xac, 0xe0, 0xde, 0x81, 0xaa, 0x43, 0xbd, 0xae, 0x58, 0xa2, 0x8a, 0x58, 0x22, 0xfd, 0xfc, 0xb6,
-	0xa8, 0x27, 0x96, 0xa6, 0xe2, 0xc8, 0x1f, 0xe8, 0x25, 0x68, 0x06, 0x43, 0x36, 0x18, 0xb2, 0xae,
-	0xb4, 0x3b, 0xed, 0xaa, 0x60, 0xaf, 0x21, 0x81, 0xc2, 0x2c, 0x51, 0xf4, 0x01, 0x34, 0xa9, 0x10,
-	0x65, 0xe4, 0x98, 0xd7, 0xa6, 0x75, 0x10, 0x1b, 0x92, 0x4e, 0x79, 0xe6, 0xaf, 0x43, 0x8b, 0x85,
-	0xf8, 0x31, 0xf1, 0x12, 0xa9, 0x3d, 0x10, 0xbb, 0x6d, 0x51, 0xc2, 0x47, 0x69, 0xbd, 0xeb, 0xb0,
-	0xd4, 0x1b, 0xe2, 0x10, 0xfb, 0x8c, 0x90, 0x04, 0x76, 0x5d, 0x60, 0xa3, 0xb8, 0x29, 0x26, 0x30,
-	0x3f, 0x84, 0xf9, 0xbb, 0x2e, 0x13, 0x82, 0xe4, 0x36, 0xcb, 0x10, 0xd7, 0x20, 0x61, 0x99, 0x5e,
-	0x80, 0x6a, 0x18, 0x1c, 0x4a, 0x1b, 0x5c, 0x10, 0x2a, 0x58, 0x09, 0x83, 0x43, 0x61, 0x60, 0x45,
-	0x41, 0x44, 0x10, 0x2a, 0xdd, 0x2c, 0x58, 0xea, 0xcb, 0xfc, 0x4b, 0x63, 0xa4, 0x3c, 0xdc, 0x7c,
-	0xd2, 0xa7, 0xb3, 0x9f, 0xef, 0x43, 0x25, 0x94, 0xf4, 0x13, 0x53, 0xb9, 0xc9, 0x91, 0xc4, 0x19,
-	0x10, 0x51, 0x4d, 0x9f, 0x27, 0xfa, 0x55, 0x03, 0x1a, 0x1f, 0x78, 0x43, 0xfa, 0x2c, 0x94, 0x5d,
-	0x97, 0xbd, 0x28, 0xea, 0x33, 0x27, 0xbf, 0x5d, 0x80, 0xa6, 0x62, 0x63, 0x16, 0x27, 0x28, 0x97,
-	0x95, 0x5d, 0xa8, 0xf3, 0x21, 0xbb, 0x94, 0xf4, 0xa2, 0x98, 0x4e, 0x7d, 0x73, 0x53, 0x6b, 0x1e,
-	0x52, 0x6c, 0x88, 0x6c, 0xf9, 0xae, 0x20, 0xfa, 0x45, 0x9f, 0x85, 0x47, 0x16, 0xd8, 0x31, 0xa0,
-	0xf3, 0x10, 0x16, 0x33, 0xcd, 0x5c, 0x89, 0x1e, 0x91, 0xa3, 0xc8, 0xfe, 0x3d, 0x22, 0x47, 0xe8,
-	0xad, 0x64, 0x4d, 0x43, 0xde, 0x29, 0x7e, 0x2f, 0xf0, 0x7b, 0x37, 0xc3, 0x10, 0x1f, 0xa9, 0x9a,
-	0x87, 0x77, 0x0b, 0x5f, 0x36, 0xcc, 0x7f, 0x28, 0x40, 0xe3, 0x6b, 0x43, 0x12, 0x1e, 0x9d, 0xa6,
-	0x1d, 0x8a, 0x4e, 0x85, 0xf9, 0xc4, 0xa9, 0x30, 0xb6, 0xf5, 0x4b, 0x9a, 0xad, 0xaf, 0x31, 0x60,
-	0x65, 0xad, 0x01, 0xd3, 0xed, 0xed, 0xca, 0x89, 0xf6, 0x76, 0x35, 0x77, 0x6f, 0xff, 0x85, 0x11,
-	0x8b, 0x70, 0xa6, 0xdd, 0x98, 0x72, 0xc7, 0x0a, 0x27, 0x76, 0xc7, 0xa6, 0xde, 0x8d, 0x3f, 0x34,
-	0xa0, 0xf6, 0x75, 0x62, 0xb3, 0x20, 0xe4, 0xf6, 0x47, 0x43, 0x66, 0x4c, 0xe1, 0x1a, 0x17, 0xb2,
-	0xae, 0xf1, 0x0d, 0xa8, 0xba, 0x4e, 0x17, 0x73, 0xfd, 0x12, 0xe3, 0x4e, 0x72, 0xc9, 0x2a, 0xae,
-	0x23, 0x14, 0x71, 0xfa, 0x24, 0xc0, 0xef, 0x19, 0xd0, 0x90, 0x3c, 0x53, 0x49, 0xf9, 0x5e, 0x62,
-	0x38, 0x43, 0xa7, 0xf4, 0xea, 0x23, 0x9e, 0xe8, 0xdd, 0xb9, 0xd1, 0xb0, 0x37, 0x01, 0xb8, 0x90,
-	0x15, 0xb9, 0xdc, 0x33, 0xeb, 0x5a, 0x6e, 0x25, 0xb9, 0x10, 0xf8, 0xdd, 0x39, 0xab, 0xc6, 0xa9,
-	0x44, 0x17, 0xb7, 0x2a, 0x50, 0x12, 0xd4, 0xe6, 0xff, 0x19, 0xb0, 0x74, 0x1b, 0x7b, 0xf6, 0x96,
-	0x4b, 0x19, 0xf6, 0xed, 0x19, 0x9c, 0xb0, 0x77, 0xa1, 0x12, 0x0c, 0xba, 0x1e, 0xd9, 0x67, 0x8a,
-	0xa5, 0xcb, 0x13, 0x66, 0x24, 0xc5, 0x60, 0x95, 0x83, 0xc1, 0x3d, 0xb2, 0xcf, 0xd0, 0x57, 0xa0,
-	0x1a, 0x0c, 0xba, 0xa1, 0xdb, 0x3b, 0x60, 0x4a, 0xfa, 0x53, 0x10, 0x57, 0x82, 0x81, 0xc5, 0x29,
-	0x12, 0xb1, 0x95, 0xf9, 0x13, 0xc6, 0x56, 0xcc, 0x7f, 0x1b, 0x9b, 0xfe, 0x0c, 0x7b, 0xe0, 0x5d,
-	0xa8, 0xba, 0x3e, 0xeb, 0x3a, 0x2e, 0x8d, 0x44, 0x70, 0x51, 0xaf, 0x43, 0x3e, 0x13, 0x33, 0x10,
-	0x6b, 0xea, 0x33, 0x3e, 0x36, 0xfa, 0x2a, 0xc0, 0xbe, 0x17, 0x60, 0x45, 0x2d, 0x65, 0x70, 0x49,
-	0xbf, 0x7d, 0x38, 0x5a, 0x44, 0x5f, 0x13, 0x44, 0xbc, 0x87, 0xd1, 0x92, 0xfe, 0x8b, 0x01, 0x2b,
-	0x3b, 0x24, 0x94, 0x15, 0x2f, 0x4c, 0x85, 0x41, 0xb7, 0xfd, 0xfd, 0x20, 0x1d, 0x89, 0x36, 0x32,
-	0x91, 0xe8, 0x9f, 0x4e, 0xf4, 0x35, 0x75, 0x73, 0x92, 0xf9, 0x90, 0xe8, 0xe6, 0x14, 0x65, 0x7d,
-	0xe4, 0xcd, 0x73, 0x21, 0x67, 0x99, 0x14, 0xbf, 0xc9, 0x0b, 0xb8, 0xf9, 0x3b, 0xb2, 0x50, 0x43,
-	0x3b, 0xa9, 0xa7, 0x57, 0xd8, 0x55, 0x50, 0x96, 0x3e, 0x63, 0xf7, 0x5f, 0x85, 0x8c, 0xed, 0xc8,
-	0x31, 0x44, 0x3f, 0x30, 0x60, 0x3d, 0x9f, 0xab, 0x59, 0x8e, 0xe8, 0xaf, 0x42, 0xc9, 0xf5, 0xf7,
-	0x83, 0x28, 0xec, 0x76, 0x45, 0xef, 0xa2, 0x6b, 0xc7, 0x95, 0x84, 0xe6, 0xdf, 0x14, 0xa0, 0x25,
-	0x8c, 0xfa, 0x29, 0x2c, 0x7f, 0x9f, 0xf4, 0xbb, 0xd4, 0xfd, 0x94, 0x44, 0xcb, 0xdf, 0x27, 0xfd,
-	0x5d, 0xf7, 0x53, 0x92, 0xd2, 0x8c, 0x52, 0x5a, 0x33, 0x26, 0x47, 0x95, 0x93, 0x61, 0xd5, 0x4a,
-	0x3a, 0xac, 0xba, 0x0a, 0x65, 0x3f, 0x70, 0xc8, 0xf6, 0x96, 0xba, 0x76, 0xaa, 0xaf, 0x91, 0xaa,
-	0xd5, 0x4e, 0xa8, 0x6a, 0x9f, 0x1b, 0xd0, 0xb9, 0x43, 0x58, 0x56, 0x76, 0xa7, 0xa7, 0x65, 0xdf,
-	0x33, 0xe0, 0xbc, 0x96, 0xa1, 0x59, 0x14, 0xec, 0xbd, 0xb4, 0x82, 0xe9, 0xef, 0x80, 0x63, 0x43,
-	0x2a, 0xdd, 0x7a, 0x13, 0x1a, 0x5b, 0xc3, 0x7e, 0x3f, 0x76, 0xb9, 0x2e, 0x43, 0x23, 0x94, 0x3f,
-	0xe5, 0x15, 0x49, 0x9e, 0xbf, 0x75, 0x05, 0xe3, 0x17, 0x21, 0xf3, 0x2a, 0x34, 0x15, 0x89, 0xe2,
-	0xba, 0x03, 0xd5, 0x50, 0xfd, 0x56, 0xf8, 0xf1, 0xb7, 0xb9, 0x02, 0x4b, 0x16, 0xe9, 0x71, 0xd5,
-	0x0e, 0xef, 0xb9, 0xfe, 0x23, 0x35, 0x8c, 0xf9, 0x1d, 0x03, 0x96, 0xd3, 0x70, 0xd5, 0xd7, 0xdb,
-	0x50, 0xc1, 0x8e, 0x13, 0x12, 0x4a, 0x27, 0x2e, 0xcb, 0x4d, 0x89, 0x63, 0x45, 0xc8, 0x09, 0xc9,
-	0x15, 0xa6, 0x96, 0x9c, 0xd9, 0x85, 0x73, 0x77, 0x08, 0xbb, 0x4f, 0x58, 0x38, 0x53, 0x06, 0xbf,
-	0xcd, 0x2f, 0x2f, 0x82, 0x58, 0xa9, 0x45, 0xf4, 0x69, 0x7e, 0x66, 0x00, 0x4a, 0x8e, 0x30, 0xcb,
-	0x32, 0x27, 0xa5, 0x5c, 0x48, 0x4b, 0x59, 0xd6, 0x42, 0xf5, 0x07, 0x81, 0x4f, 0x7c, 0x96, 0x74,
-	0xb7, 0x9a, 0x31, 0x34, 0x2a, 0x2b, 0x41, 0xf7, 0x02, 0xec, 0xdc, 0xc2, 0xde, 0x6c, 0xee, 0xc1,
-	0x45, 0x00, 0x1a, 0xda, 0x5d, 0xb5, 0x5b, 0x0b, 0xca, 0xfa, 0x84, 0xf6, 0x03, 0xb9, 0x61, 0x2f,
-	0x41, 0xdd, 0xa1, 0x4c, 0x35, 0x47, 0x09, 0x65, 0x70, 0x28, 0x93, 0xed, 0xa2, 0xd6, 0x95, 0x12,
-	0xec, 0x11, 0xa7, 0x9b, 0xc8, 0xc7, 0xcd, 0x0b, 0xb4, 0x96, 0x6c, 0xd8, 0x8d, 0xe1, 0x9a, 0xcd,
-	0x55, 0xd2, 0x6e, 0xae, 0x87, 0xb0, 0x76, 0x1f, 0xfb, 0x43, 0xec, 0xdd, 0x0e, 0xfa, 0x03, 0x9c,
-	0xaa, 0x93, 0xcc, 0x9a, 0x43, 0x43, 0x63, 0x0e, 0x5f, 0x94, 0x85, 0x74, 0xd2, 0x05, 0x17, 0x73,
-	0x9a, 0xb7, 0x12, 0x10, 0x93, 0x42, 0x7b, 0xbc, 0xfb, 0x59, 0x16, 0x54, 0x30, 0x15, 0x75, 0x95,
-	0xb4, 0xd1, 0x23, 0x98, 0xf9, 0x3e, 0xbc, 0x20, 0x8a, 0x1a, 0x23, 0x50, 0x2a, 0x05, 0x90, 0xed,
-	0xc0, 0xd0, 0x74, 0xf0, 0xeb, 0x05, 0x61, 0x02, 0xc7, 0x7a, 0x98, 0x85, 0xf1, 0x77, 0xd3, 0x91,
-	0xf7, 0x97, 0x73, 0x0a, 0x77, 0xd3, 0x23, 0xaa, 0xf0, 0xfb, 0x06, 0x2c, 0x92, 0x27, 0xc4, 0x1e,
-	0x32, 0xd7, 0xef, 0xed, 0x78, 0xd8, 0x7f, 0x10, 0xa8, 0x83, 0x27, 0x0b, 0x46, 0x2f, 0x43, 0x93,
-	0x4b, 0x3f, 0x18, 0x32, 0x85, 0x27, 0x4f, 0xa0, 0x34, 0x90, 0xf7, 0xc7, 0xe7, 0xeb, 0x11, 0x46,
-	0x1c, 0x85, 0x27, 0x8f, 0xa3, 0x2c, 0x78, 0x4c, 0x94, 0x1c, 0x4c, 0x4f, 0x22, 0xca, 0xff, 0x30,
-	0x32, 0xa2, 0x54, 0x3d, 0x9c, 0x96, 0x28, 0xef, 0x02, 0xf4, 0x49, 0xd8, 0x23, 0xdb, 0xc2, 0xf8,
-	0xcb, 0x1b, 0xfe, 0x86, 0xd6, 0xf8, 0x8f, 0x3a, 0xb8, 0x1f, 0x11, 0x58, 0x09, 0x5a, 0xf3, 0x0e,
-	0x2c, 0x69, 0x50, 0xb8, 0x5d, 0xa3, 0xc1, 0x30, 0xb4, 0x49, 0x14, 0x24, 0x8a, 0x3e, 0xf9, 0x39,
-	0xc8, 0x70, 0xd8, 0x23, 0x4c, 0x29, 0xad, 0xfa, 0x32, 0xdf, 0x16, 0xc9, 0x2a, 0x11, 0x50, 0x48,
-	0x69, 0x6a, 0x3a, 0xf1, 0x6e, 0x8c, 0x25, 0xde, 0xf7, 0x45, 0x66, 0x28, 0x49, 0x37, 0x63, 0xd1,
-	0xc4, 0x3e, 0xef, 0x8a, 0x38, 0xea, 0xd1, 0x46, 0xf4, 0x69, 0xfe, 0xaf, 0x01, 0xcd, 0xed, 0xfe,
-	0x20, 0x18, 0x25, 0x45, 0xa6, 0xbe, 0x72, 0x8e, 0x07, 0x95, 0x0b, 0xba, 0xa0, 0xf2, 0x4b, 0xd0,
-	0x4c, 0x97, 0xfc, 0xcb, 0xf8, 0x4f, 0xc3, 0x4e, 0x96, 0xfa, 0x9f, 0x87, 0x5a, 0x18, 0x1c, 0x76,
-	0xb9, 0x29, 0x75, 0x54, 0x79, 0x46, 0x35, 0x0c, 0x0e, 0xb9, 0x81, 0x75, 0xd0, 0x32, 0x94, 0xf6,
-	0x5d, 0x2f, 0xae, 0x2c, 0x92, 0x1f, 0xe8, 0x3d, 0x7e, 0x21, 0x93, 0xe9, 0xdb, 0xf2, 0xb4, 0xf7,
-	0xa2, 0x88, 0xc2, 0xfc, 0x04, 0x16, 0xa2, 0x59, 0xcf, 0xf8, 0x5a, 0x85, 0x61, 0xfa, 0x28, 0xaa,
-	0x9c, 0x90, 0x1f, 0xe6, 0x55, 0x99, 0xd5, 0x13, 0xfd, 0xa7, 0x16, 0x1d, 0xc1, 0x3c, 0xc7, 0x50,
-	0x7b, 0x49, 0xfc, 0xe6, 0x0b, 0xb0, 0x9a, 0xc5, 0x9e, 0x85, 0xa5, 0xb7, 0xd3, 0xfb, 0x47, 0xff,
-	0x20, 0x21, 0x39, 0x9a, 0xda, 0x3b, 0x6a, 0x05, 0xec, 0x60, 0xe8, 0x33, 0x65, 0x80, 0xf8, 0x0a,
-	0xdc, 0xe6, 0xdf, 0x68, 0x0d, 0x2a, 0xae, 0xd3, 0xf5, 0xf8, 0xdd, 0x4d, 0x9e, 0x49, 0x65, 0xd7,
-	0xb9, 0xc7, 0xef, 0x75, 0xef, 0x44, 0x9e, 0xd6, 0xd4, 0xe5, 0x16, 0xca, 0xcb, 0xfa, 0xbe, 0xf4,
-	0x03, 0x2c, 0x59, 0x06, 0xf9, 0x8c, 0x8b, 0x6a, 0x36, 0xa0, 0x75, 0xe8, 0xb2, 0x83, 0xae, 0x78,
-	0xda, 0x21, 0x0e, 0x61, 0x99, 0x57, 0xae, 0x5a, 0x0b, 0x1c, 0xbe, 0xcb, 0xc1, 0xfc, 0x20, 0xa6,
-	0xe6, 0x6f, 0x18, 0xb0, 0x94, 0x62, 0x6b, 0x96, 0xa5, 0xf8, 0x0a, 0xf7, 0x4f, 0x64, 0x47, 0xca,
-	0x13, 0x5d, 0xd7, 0x1a, 0x23, 0x35, 0x9a, 0x30, 0x42, 0x31, 0x85, 0xf9, 0x9f, 0x06, 0xd4, 0x13,
-	0x2d, 0xfc, 0x7a, 0xa3, 0xda, 0x46, 0xd7, 0x9b, 0x18, 0x30, 0x95, 0x18, 0x5e, 0x82, 0xd1, 0xd6,
-	0x4c, 0x94, 0x87, 0x27, 0xea, 0xda, 0x1c, 0x8a, 0xee, 0xc2, 0x82, 0x14, 0x53, 0xcc, 0xba, 0x36,
-	0xea, 0x10, 0x57, 0xec, 0xe1, 0xd0, 0x51, 0x5c, 0x5a, 0x4d, 0x9a, 0xf8, 0x92, 0x49, 0xc6, 0xc0,
-	0x21, 0x62, 0xa4, 0x92, 0xb4, 0x96, 0xc2, 0xff, 0x71, 0x28, 0xbf, 0x86, 0x34, 0x92, 0xa4, 0xdc,
-	0x95, 0xf3, 0x08, 0x76, 0x48, 0x18, 0xcf, 0x2d, 0xfe, 0xe6, 0xbe, 0x93, 0xfc, 0xdd, 0xe5, 0xae,
-	0xad, 0x32, 0x32, 0x20, 0x41, 0xdc, 0xeb, 0x45, 0xaf, 0xc2, 0xa2, 0xd3, 0x4f, 0xbd, 0x2b, 0x8a,
-	0x9c, 0x3d, 0xa7, 0x9f, 0x78, 0x50, 0x94, 0x62, 0x68, 0x3e, 0xcd, 0xd0, 0xff, 0x18, 0xf1, 0x6b,
-	0xcb, 0x90, 0x38, 0xc4, 0x67, 0x2e, 0xf6, 0x9e, 0x5e, 0x27, 0x3b, 0x50, 0x1d, 0x52, 0x12, 0x26,
-	0x6c, 0x62, 0xfc, 0xcd, 0xdb, 0x06, 0x98, 0xd2, 0xc3, 0x20, 0x74, 0x14, 0x97, 0xf1, 0xf7, 0x84,
-	0x22, 0x41, 0xf9, 0x92, 0x4f, 0x5f, 0x24, 0xf8, 0x36, 0xac, 0xf5, 0x03, 0xc7, 0xdd, 0x77, 0x75,
-	0xb5, 0x85, 0x9c, 0x6c, 0x25, 0x6a, 0x4e, 0xd1, 0x99, 0x3f, 0x28, 0xc0, 0xda, 0xc7, 0x03, 0xe7,
-	0x67, 0x30, 0xe7, 0x75, 0xa8, 0x07, 0x9e, 0xb3, 0x93, 0x9e, 0x76, 0x12, 0xc4, 0x31, 0x7c, 0x72,
-	0x18, 0x63, 0xc8, 0x50, 0x73, 0x12, 0x34, 0xb1, 0x80, 0xf2, 0xa9, 0x64, 0x53, 0x9e, 0x24, 0x9b,
-	0x1e, 0xac, 0xc9, 0xac, 0xed, 0x33, 0x16, 0x8d, 0xf9, 0x2b, 0xb0, 0xc2, 0x0d, 0x29, 0x1f, 0xe6,
-	0x63, 0x4a, 0xc2, 0x19, 0x2d, 0xce, 0x05, 0xa8, 0x45, 0x3d, 0x47, 0xb5, 0xad, 0x23, 0x80, 0x79,
-	0x17, 0x96, 0x33, 0x63, 0x3d, 0xe5, 0x8c, 0xae, 0x5c, 0x86, 0x6a, 0x54, 0xab, 0x8b, 0x2a, 0x50,
-	0xbc, 0xe9, 0x79, 0xad, 0x39, 0xd4, 0x80, 0xea, 0xb6, 0x2a, 0x48, 0x6d, 0x19, 0x57, 0x7e, 0x01,
-	0x16, 0x33, 0x39, 0x5d, 0x54, 0x85, 0xf9, 0x07, 0x81, 0x4f, 0x5a, 0x73, 0xa8, 0x05, 0x8d, 0x5b,
-	0xae, 0x8f, 0xc3, 0x23, 0x19, 0xf1, 0x6c, 0x39, 0x68, 0x11, 0xea, 0x22, 0xf2, 0xa7, 0x00, 0x64,
-	0xf3, 0xc7, 0x2f, 0x43, 0xf3, 0xbe, 0x60, 0x64, 0x97, 0x84, 0x8f, 0x5d, 0x9b, 0xa0, 0x2e, 0xb4,
-	0xb2, 0x0f, 0xa2, 0xd1, 0x17, 0xf4, 0xde, 0x9d, 0xfe, 0xdd, 0x74, 0x67, 0x92, 0x0c, 0xcd, 0x39,
-	0xf4, 0x09, 0x2c, 0xa4, 0x9f, 0x15, 0x23, 0x7d, 0x68, 0x4a, 0xfb, 0xf6, 0xf8, 0xb8, 0xce, 0xbb,
-	0xd0, 0x4c, 0xbd, 0x12, 0x46, 0xaf, 0x6b, 0xfb, 0xd6, 0xbd, 0x24, 0xee, 0xe8, 0x6d, 0x6f, 0xf2,
-	0x25, 0xaf, 0xe4, 0x3e, 0xfd, 0x94, 0x2f, 0x87, 0x7b, 0xed, 0x7b, 0xbf, 0xe3, 0xb8, 0xc7, 0x70,
-	0x6e, 0xec, 0xc9, 0x1d, 0x7a, 0x23, 0xe7, 0x34, 0xd3, 0x3f, 0xcd, 0x3b, 0x6e, 0x88, 0x43, 0x40,
-	0xe3, 0xaf, 0x61, 0xd1, 0x35, 0xfd, 0x0a, 0xe4, 0xbd, 0x05, 0xee, 0x5c, 0x9f, 0x1a, 0x3f, 0x16,
-	0xdc, 0xaf, 0x19, 0xb0, 0x96, 0xf3, 0x4e, 0x0e, 0xdd, 0xd0, 0x76, 0x37, 0xf9, 0xb1, 0x5f, 0xe7,
-	0xad, 0x93, 0x11, 0xc5, 0x8c, 0xf8, 0xb0, 0x98, 0x79, 0x3a, 0x86, 0xae, 0xe6, 0xd6, 0xc9, 0x8f,
-	0xbf, 0xa1, 0xeb, 0x7c, 0x61, 0x3a, 0xe4, 0x78, 0xbc, 0x87, 0xb0, 0x98, 0x79, 0x6f, 0x95, 0x33,
-	0x9e, 0xfe, 0x55, 0xd6, 0x71, 0x0b, 0xfa, 0x4d, 0x68, 0xa6, 0x1e, 0x46, 0xe5, 0x68, 0xbc, 0xee,
-	0xf1, 0xd4, 0x71, 0x5d, 0x3f, 0x84, 0x46, 0xf2, 0xfd, 0x12, 0xda, 0xc8, 0xdb, 0x4b, 0x63, 0x1d,
-	0x9f, 0x64, 0x2b, 0x8d, 0xde, 0x1d, 0x4c, 0xd8, 0x4a, 0x63, 0x4f, 0x35, 0xa6, 0xdf, 0x4a, 0x89,
-	0xfe, 0x27, 0x6e, 0xa5, 0x13, 0x0f, 0xf1, 0x1d, 0x79, 0xa7, 0xd0, 0xbc, 0x6b, 0x41, 0x9b, 0x79,
-	0xba, 0x99, 0xff, 0x82, 0xa7, 0x73, 0xe3, 0x44, 0x34, 0xb1, 0x14, 0x1f, 0xc1, 0x42, 0xfa, 0xf5,
-	0x46, 0x8e, 0x14, 0xb5, 0x0f, 0x5e, 0x3a, 0x57, 0xa7, 0xc2, 0x8d, 0x07, 0xfb, 0x18, 0xea, 0x89,
-	0xff, 0x38, 0x41, 0xaf, 0x4d, 0xd0, 0xe3, 0xe4, 0x1f, 0x7e, 0x1c, 0x27, 0xc9, 0xaf, 0x41, 0x2d,
-	0xfe, 0x6b, 0x12, 0xf4, 0x4a, 0xae, 0xfe, 0x9e, 0xa4, 0xcb, 0x5d, 0x80, 0xd1, 0xff, 0x8e, 0xa0,
-	0x57, 0xb5, 0x7d, 0x8e, 0xfd, 0x31, 0xc9, 0x71, 0x9d, 0xc6, 0xd3, 0x97, 0x45, 0x71, 0x93, 0xa6,
-	0x9f, 0xac, 0xe2, 0x3c, 0xae, 0xdb, 0x03, 0x68, 0xa6, 0x6a, 0xaf, 0xf3, 0xb6, 0xb0, 0xa6, 0x24,
-	0xbe, 0x73, 0x65, 0x1a, 0xd4, 0x78, 0xfd, 0x0e, 0xa0, 0x99, 0xaa, 0x84, 0xcd, 0x19, 0x49, 0x57,
-	0xf8, 0x9b, 0x33, 0x92, 0xb6, 0xb0, 0xd6, 0x9c, 0x43, 0xdf, 0x4e, 0x14, 0xdd, 0xa6, 0x0a, 0x9b,
-	0xd1, 0x9b, 0x13, 0xfb, 0xd1, 0xd5, 0x75, 0x77, 0x36, 0x4f, 0x42, 0x12, 0xb3, 0xa0, 0xb4, 0x4a,
-	0x8a, 0x34, 0x5f, 0xab, 0x4e, 0xb2, 0x52, 0xbb, 0x50, 0x96, 0xb5, 0xad, 0xc8, 0xcc, 0xa9, 0x62,
-	0x4f, 0x14, 0xbe, 0x76, 0x5e, 0xd2, 0xe2, 0xa4, 0xcb, 0x3e, 0x65, 0xa7, 0xd2, 0x0b, 0xce, 0xe9,
-	0x34, 0x55, 0xd8, 0x38, 0x6d, 0xa7, 0x16, 0x94, 0x65, 0xd1, 0x52, 0x4e, 0xa7, 0xa9, 0xc2, 0xbb,
-	0xce, 0x64, 0x1c, 0x91, 0xcd, 0x36, 0xe7, 0xd0, 0x0e, 0x94, 0x44, 0xa8, 0x0c, 0x5d, 0x9e, 0x54,
-	0xcf, 0x33, 0xa9, 0xc7, 0x54, 0xc9, 0x8f, 0x39, 0x87, 0x7e, 0x09, 0x4a, 0x22, 0x41, 0x94, 0xd3,
-	0x63, 0xb2, 0x28, 0xa7, 0x33, 0x11, 0x25, 0x62, 0xd1, 0x81, 0x46, 0x32, 0x13, 0x9f, 0x73, 0x64,
-	0x69, 0x6a, 0x15, 0x3a, 0xd3, 0x60, 0x46, 0xa3, 0xc8, 0x6d, 0x34, 0x0a, 0x1b, 0xe6, 0x6f, 0xa3,
-	0xb1, 0x90, 0x64, 0xfe, 0x36, 0x1a, 0x8f, 0x42, 0x9a, 0x73, 0xe8, 0x37, 0x0d, 0x68, 0xe7, 0xa5,
-	0x87, 0x51, 0xae, 0x07, 0x34, 0x29, 0xc7, 0xdd, 0xf9, 0xd2, 0x09, 0xa9, 0x62, 0x5e, 0x3e, 0x15,
-	0x41, 0x9b, 0xb1, 0x84, 0xf0, 0xf5, 0xbc, 0xfe, 0x72, 0xd2, 0x9f, 0x9d, 0x2f, 0x4e, 0x4f, 0x10,
-	0x8f, 0xbd, 0x07, 0xf5, 0x44, 0xc0, 0x28, 0xc7, 0xf2, 0x8e, 0x47, 0xba, 0x72, 0x56, 0x55, 0x13,
-	0x7b, 0x92, 0xea, 0x2d, 0xf2, 0x8b, 0x39, 0xca, 0x98, 0x4c, 0x57, 0xe6, 0xa8, 0x77, 0x2a, 0x3d,
-	0x69, 0xce, 0x21, 0x02, 0x8d, 0x64, 0xb2, 0x31, 0x47, 0x1b, 0x35, 0x79, 0xca, 0xce, 0xeb, 0x53,
-	0x60, 0xc6, 0xc3, 0x74, 0x01, 0x46, 0xc9, 0xbe, 0x9c, 0xb3, 0x6e, 0x2c, 0xdf, 0xd8, 0x79, 0xed,
-	0x58, 0xbc, 0xe4, 0xb1, 0x9f, 0x48, 0xdf, 0xe5, 0x48, 0x7f, 0x3c, 0xc1, 0x37, 0xc5, 0x5d, 0x64,
-	0x3c, 0x45, 0x94, 0x73, 0x17, 0xc9, 0xcd, 0x46, 0x75, 0xae, 0x4f, 0x8d, 0x1f, 0xcf, 0xe7, 0x5b,
-	0xd0, 0xca, 0xa6, 0xd4, 0x72, 0xee, 0xb8, 0x39, 0x89, 0xbd, 0xce, 0x1b, 0x53, 0x62, 0x27, 0xcf,
-	0xc3, 0xf3, 0xe3, 0x3c, 0x7d, 0xc3, 0x65, 0x07, 0x22, 0x9b, 0x33, 0xcd, 0xac, 0x93, 0x89, 0xa3,
-	0x69, 0x66, 0x9d, 0x4a, 0x13, 0xa9, 0xc3, 0x4b, 0x44, 0xa4, 0xf3, 0x0e, 0xaf, 0x64, 0x82, 0x22,
-	0xe7, 0x9c, 0x49, 0x87, 0xf3, 0xa5, 0xfb, 0x99, 0x8e, 0xab, 0xa3, 0x7c, 0x3f, 0x61, 0x2c, 0x54,
-	0x9f, 0xe3, 0x7e, 0xea, 0x03, 0xf5, 0x42, 0xd1, 0x5b, 0xd9, 0xf0, 0xe1, 0xe4, 0xd8, 0x44, 0x36,
-	0xac, 0x74, 0x7c, 0xf8, 0xa0, 0x95, 0x8d, 0xd5, 0xe5, 0x0c, 0x90, 0x13, 0xd2, 0x9b, 0x62, 0x80,
-	0x6c, 0xc4, 0x2b, 0x67, 0x80, 0x9c, 0xc0, 0xd8, 0x14, 0xbe, 0x64, 0x2a, 0xfa, 0x94, 0x73, 0x34,
-	0xe9, 0x22, 0x54, 0x39, 0x47, 0x93, 0x36, 0x70, 0x66, 0xce, 0x6d, 0x0e, 0xa1, 0xb1, 0x13, 0x06,
-	0x4f, 0x8e, 0xa2, 0xc0, 0xd1, 0xcf, 0xc6, 0xd8, 0xdd, 0xfa, 0x06, 0x2c, 0xb8, 0x31, 0x4e, 0x2f,
-	0x1c, 0xd8, 0xb7, 0xea, 0x32, 0x80, 0xb5, 0xc3, 0x89, 0x77, 0x8c, 0x5f, 0xbe, 0xd1, 0x73, 0xd9,
-	0xc1, 0x70, 0x8f, 0x4b, 0xe6, 0xba, 0x44, 0x7b, 0xc3, 0x0d, 0xd4, 0xaf, 0xeb, 0xae, 0xcf, 0x48,
-	0xe8, 0x63, 0xef, 0xba, 0x18, 0x4a, 0x41, 0x07, 0x7b, 0x7f, 0x64, 0x18, 0x7b, 0x65, 0x01, 0xba,
-	0xf1, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0x77, 0xf8, 0xe0, 0x92, 0x08, 0x52, 0x00, 0x00,
+	// 4652 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x7c, 0x4d, 0x8c, 0xdc, 0xc8,
+	0x75, 0xb0, 0xd8, 0x3d, 0xfd, 0xf7, 0xba, 0x7b, 0xa6, 0xc5, 0xf9, 0x51, 0x6f, 0x4b, 0x5a, 0x8d,
+	0x28, 0xed, 0xee, 0x48, 0xb2, 0xa4, 0xdd, 0xd1, 0x7a, 0xd7, 0xdf, 0xae, 0xbf, 0xac, 0x25, 0x4d,
+	0x56, 0x1a, 0xac, 0xa4, 0x8c, 0x39, 0x5a, 0x1b, 0x8e, 0xb1, 0x20, 0x6a, 0xc8, 0x9a, 0x1e, 0x46,
+	0x6c, 0x92, 0xcb, 0xaa, 0xd6, 0x68, 0xf6, 0x64, 0xc0, 0x41, 0x12, 0xc3, 0xf6, 0x1a, 0x41, 0x8c,
+	0x24, 0x3e, 0x24, 0x08, 0xf2, 0x73, 0xc8, 0x2d, 0x76, 0x80, 0x24, 0xc8, 0x25, 0x08, 0x90, 0x43,
+	0x6e, 0x4e, 0x72, 0x48, 0x10, 0xe4, 0x92, 0x53, 0xae, 0x41, 0x82, 0x1c, 0x73, 0x08, 0xea, 0x87,
+	0x6c, 0x92, 0x5d, 0xec, 0xe9, 0x51, 0x5b, 0x9e, 0xd1, 0x8d, 0xf5, 0xea, 0xbd, 0xaa, 0x57, 0xaf,
+	0x5e, 0xbd, 0xaa, 0x7a, 0xef, 0x15, 0xa1, 0x35, 0x70, 0xbd, 0xa7, 0x43, 0x72, 0x23, 0x8c, 0x02,
+	0x1a, 0xe8, 0x8b, 0xe9, 0xd2, 0x0d, 0x51, 0xe8, 0xb5, 0xec, 0x60, 0x30, 0x08, 0x7c, 0x01, 0xec,
+	0xb5, 0x88, 0xbd, 0x87, 0x07, 0x48, 0x94, 0x8c, 0xdf, 0xd7, 0x40, 0xbf, 0x1b, 0x61, 0x44, 0xf1,
+	0x6d, 0xcf, 0x45, 0xc4, 0xc4, 0x9f, 0x0e, 0x31, 0xa1, 0xfa, 0x9b, 0x30, 0xb7, 0x83, 0x08, 0xee,
+	0x6a, 0xab, 0xda, 0x5a, 0x73, 0xfd, 0xdc, 0x8d, 0x4c, 0xb3, 0xb2, 0xb9, 0x87, 0xa4, 0x7f, 0x07,
+	0x11, 0x6c, 0x72, 0x4c, 0xfd, 0x0c, 0xd4, 0x9c, 0x1d, 0xcb, 0x47, 0x03, 0xdc, 0x2d, 0xad, 0x6a,
+	0x6b, 0x0d, 0xb3, 0xea, 0xec, 0x3c, 0x42, 0x03, 0xac, 0xbf, 0x01, 0x0b, 0x76, 0xe0, 0x79, 0xd8,
+	0xa6, 0x6e, 0xe0, 0x0b, 0x84, 0x32, 0x47, 0x98, 0x1f, 0x81, 0x39, 0xe2, 0x12, 0x54, 0x10, 0xe3,
+	0xa1, 0x3b, 0xc7, 0xab, 0x45, 0xc1, 0x20, 0xd0, 0xd9, 0x88, 0x82, 0xf0, 0x45, 0x71, 0x97, 0x74,
+	0x5a, 0x4e, 0x77, 0xfa, 0x7b, 0x1a, 0x9c, 0xbe, 0xed, 0x51, 0x1c, 0x9d, 0x50, 0xa1, 0xfc, 0x6e,
+	0x09, 0xce, 0x88, 0x59, 0xbb, 0x9b, 0xa0, 0x1f, 0x27, 0x97, 0x2b, 0x50, 0x15, 0x5a, 0xc5, 0xd9,
+	0x6c, 0x99, 0xb2, 0xa4, 0x9f, 0x07, 0x20, 0x7b, 0x28, 0x72, 0x88, 0xe5, 0x0f, 0x07, 0xdd, 0xca,
+	0xaa, 0xb6, 0x56, 0x31, 0x1b, 0x02, 0xf2, 0x68, 0x38, 0xd0, 0x4d, 0x38, 0x6d, 0x07, 0x3e, 0x71,
+	0x09, 0xc5, 0xbe, 0x7d, 0x60, 0x79, 0xf8, 0x29, 0xf6, 0xba, 0xd5, 0x55, 0x6d, 0x6d, 0x7e, 0xfd,
+	0x35, 0x25, 0xdf, 0x77, 0x47, 0xd8, 0x0f, 0x18, 0xb2, 0xd9, 0xb1, 0x73, 0x10, 0xe3, 0xbb, 0x1a,
+	0x2c, 0x33, 0x85, 0x39, 0x11, 0x82, 0x31, 0xfe, 0x54, 0x83, 0xa5, 0xfb, 0x88, 0x9c, 0x8c, 0x59,
+	0x3a, 0x0f, 0x40, 0xdd, 0x01, 0xb6, 0x08, 0x45, 0x83, 0x90, 0xcf, 0xd4, 0x9c, 0xd9, 0x60, 0x90,
+	0x6d, 0x06, 0x30, 0xbe, 0x01, 0xad, 0x3b, 0x41, 0xe0, 0x99, 0x98, 0x84, 0x81, 0x4f, 0xb0, 0x7e,
+	0x0b, 0xaa, 0x84, 0x22, 0x3a, 0x24, 0x92, 0xc9, 0xb3, 0x4a, 0x26, 0xb7, 0x39, 0x8a, 0x29, 0x51,
+	0x99, 0xbe, 0x3e, 0x45, 0xde, 0x50, 0xf0, 0x58, 0x37, 0x45, 0xc1, 0xf8, 0x26, 0xcc, 0x6f, 0xd3,
+	0xc8, 0xf5, 0xfb, 0x3f, 0xc3, 0xc6, 0x1b, 0x71, 0xe3, 0xff, 0xa4, 0xc1, 0x2b, 0x1b, 0x98, 0xd8,
+	0x91, 0xbb, 0x73, 0x42, 0x96, 0x83, 0x01, 0xad, 0x11, 0x64, 0x73, 0x83, 0x8b, 0xba, 0x6c, 0x66,
+	0x60, 0xb9, 0xc9, 0xa8, 0xe4, 0x27, 0xe3, 0x5b, 0x15, 0xe8, 0xa9, 0x06, 0x35, 0x8b, 0xf8, 0xfe,
+	0x7f, 0xb2, 0x4a, 0x4b, 0x9c, 0x28, 0xb7, 0xc6, 0x44, 0xdd, 0x8d, 0x51, 0x6f, 0xdb, 0x1c, 0x90,
+	0x2c, 0xe6, 0xfc, 0xa8, 0xca, 0x8a, 0x51, 0xad, 0xc3, 0xf2, 0x53, 0x37, 0xa2, 0x43, 0xe4, 0x59,
+	0xf6, 0x1e, 0xf2, 0x7d, 0xec, 0x71, 0x39, 0x31, 0xf3, 0x55, 0x5e, 0x6b, 0x98, 0x8b, 0xb2, 0xf2,
+	0xae, 0xa8, 0x63, 0xc2, 0x22, 0xfa, 0xdb, 0xb0, 0x12, 0xee, 0x1d, 0x10, 0xd7, 0x1e, 0x23, 0xaa,
+	0x70, 0xa2, 0xa5, 0xb8, 0x36, 0x43, 0x75, 0x0d, 0x4e, 0xdb, 0xdc, 0x02, 0x3a, 0x16, 0x93, 0x9a,
+	0x10, 0x63, 0x95, 0x8b, 0xb1, 0x23, 0x2b, 0x1e, 0xc7, 0x70, 0xc6, 0x56, 0x8c, 0x3c, 0xa4, 0x76,
+	0x8a, 0xa0, 0xc6, 0x09, 0x16, 0x65, 0xe5, 0xc7, 0xd4, 0x1e, 0xd1, 0x64, 0x6d, 0x57, 0x3d, 0x6f,
+	0xbb, 0xba, 0x50, 0xe3, 0xb6, 0x18, 0x93, 0x6e, 0x83, 0xb3, 0x19, 0x17, 0xf5, 0x4d, 0x58, 0x20,
+	0x14, 0x45, 0xd4, 0x0a, 0x03, 0xe2, 0x32, 0xb9, 0x90, 0x2e, 0xac, 0x96, 0xd7, 0x9a, 0xeb, 0xab,
+	0xca, 0x49, 0xfa, 0x08, 0x1f, 0x6c, 0x20, 0x8a, 0xb6, 0x90, 0x1b, 0x99, 0xf3, 0x9c, 0x70, 0x2b,
+	0xa6, 0x53, 0x1b, 0xc8, 0xe6, 0x4c, 0x06, 0x52, 0xa5, 0xc5, 0x2d, 0xa5, 0xed, 0xfa, 0x89, 0x06,
+	0xcb, 0x0f, 0x02, 0xe4, 0x9c, 0x8c, 0x35, 0xf5, 0x1a, 0xcc, 0x47, 0x38, 0xf4, 0x5c, 0x1b, 0xb1,
+	0xf9, 0xd8, 0xc1, 0x11, 0x5f, 0x55, 0x15, 0xb3, 0x2d, 0xa1, 0x8f, 0x38, 0xd0, 0xf8, 0x5c, 0x83,
+	0xae, 0x89, 0x3d, 0x8c, 0xc8, 0xc9, 0xb0, 0x05, 0xc6, 0x0f, 0x35, 0x78, 0xf5, 0x1e, 0xa6, 0xa9,
+	0x55, 0x45, 0x11, 0x75, 0x09, 0x75, 0xed, 0xe3, 0x3c, 0x57, 0x18, 0x3f, 0xd0, 0xe0, 0x42, 0x21,
+	0x5b, 0xb3, 0x18, 0x99, 0x77, 0xa1, 0xc2, 0xbe, 0x48, 0xb7, 0xc4, 0x75, 0xfe, 0x62, 0x91, 0xce,
+	0x7f, 0x8d, 0xd9, 0x6e, 0xae, 0xf4, 0x02, 0xdf, 0xf8, 0x77, 0x0d, 0x56, 0xb6, 0xf7, 0x82, 0xfd,
+	0x11, 0x4b, 0x2f, 0x42, 0x40, 0x59, 0xb3, 0x5b, 0xce, 0x99, 0x5d, 0xfd, 0x2d, 0x98, 0xa3, 0x07,
+	0x21, 0xe6, 0xba, 0x35, 0xbf, 0x7e, 0xfe, 0x86, 0xe2, 0x38, 0x7d, 0x83, 0x31, 0xf9, 0xf8, 0x20,
+	0xc4, 0x26, 0x47, 0xd5, 0xaf, 0x40, 0x27, 0x27, 0xf2, 0xd8, 0x70, 0x2d, 0x64, 0x65, 0x4e, 0x8c,
+	0xef, 0x97, 0xe1, 0xcc, 0xd8, 0x10, 0x67, 0x11, 0xb6, 0xaa, 0xef, 0x92, 0xb2, 0x6f, 0xb6, 0x7e,
+	0x52, 0xa8, 0xae, 0xc3, 0x4e, 0xbc, 0xe5, 0xb5, 0xb2, 0xd9, 0x1e, 0x41, 0x37, 0x1d, 0xa2, 0x5f,
+	0x07, 0x7d, 0xcc, 0xac, 0x0a, 0xeb, 0x3d, 0x67, 0x9e, 0xce, 0xdb, 0x55, 0x6e, 0xbb, 0x95, 0x86,
+	0x55, 0x88, 0x60, 0xce, 0x5c, 0x52, 0x58, 0x56, 0xa2, 0xbf, 0x05, 0x4b, 0xae, 0xff, 0x10, 0x0f,
+	0x82, 0xe8, 0xc0, 0x0a, 0x71, 0x64, 0x63, 0x9f, 0xa2, 0x3e, 0x26, 0xdd, 0x2a, 0xe7, 0x68, 0x31,
+	0xae, 0xdb, 0x1a, 0x55, 0xe9, 0xdb, 0x30, 0x9f, 0x90, 0x08, 0xfd, 0xaa, 0x71, 0xfd, 0xfa, 0x82,
+	0x72, 0x8a, 0x46, 0x02, 0xde, 0x94, 0x44, 0x4c, 0x70, 0xc4, 0x6c, 0xbb, 0xe9, 0xa2, 0xf1, 0xe7,
+	0x1a, 0xac, 0x88, 0x63, 0xf4, 0x16, 0x8a, 0xa8, 0x7b, 0x02, 0x4c, 0x5c, 0x18, 0xf3, 0x21, 0xf0,
+	0xc4, 0xa1, 0xbf, 0x9d, 0x40, 0xf9, 0xd2, 0xfd, 0xb1, 0x06, 0x4b, 0xec, 0x84, 0xfb, 0x32, 0xf1,
+	0xfc, 0x67, 0x1a, 0x2c, 0xde, 0x47, 0xe4, 0x65, 0x62, 0xf9, 0xdf, 0xe4, 0xf6, 0x97, 0xf0, 0x7c,
+	0xac, 0xf7, 0xc0, 0x37, 0x60, 0x21, 0xcb, 0x74, 0x7c, 0xa4, 0x9a, 0xcf, 0x70, 0x4d, 0x14, 0xfb,
+	0x64, 0x45, 0xb5, 0x4f, 0xfe, 0xe5, 0x68, 0x9f, 0x7c, 0xb9, 0x06, 0x68, 0xfc, 0xb5, 0x06, 0xe7,
+	0xef, 0x61, 0x9a, 0x70, 0x7d, 0x22, 0xf6, 0xd3, 0x69, 0x95, 0xea, 0x73, 0x71, 0x1a, 0x50, 0x32,
+	0x7f, 0x2c, 0xbb, 0xee, 0x77, 0x4b, 0xb0, 0xcc, 0xb6, 0xa4, 0x93, 0xa1, 0x04, 0xd3, 0x5c, 0x9c,
+	0x14, 0x8a, 0x52, 0x51, 0xae, 0x84, 0x78, 0x2f, 0xaf, 0x4e, 0xbd, 0x97, 0x1b, 0x3f, 0x29, 0xc1,
+	0x4a, 0x5e, 0x1a, 0xb3, 0x4c, 0x8b, 0x82, 0xd7, 0x92, 0x92, 0x57, 0x03, 0x5a, 0x09, 0x64, 0x73,
+	0x23, 0xde, 0x9b, 0x33, 0xb0, 0x93, 0xba, 0x35, 0x1b, 0xdf, 0xd3, 0x60, 0x25, 0xbe, 0xaa, 0x6e,
+	0xe3, 0xfe, 0x00, 0xfb, 0xf4, 0xf9, 0x75, 0x28, 0xaf, 0x01, 0x25, 0x85, 0x06, 0x9c, 0x83, 0x06,
+	0x11, 0xfd, 0x24, 0xb7, 0xd0, 0x11, 0xc0, 0xf8, 0x1b, 0x0d, 0xce, 0x8c, 0xb1, 0x33, 0xcb, 0x24,
+	0x76, 0xa1, 0xe6, 0xfa, 0x0e, 0x7e, 0x96, 0x70, 0x13, 0x17, 0x59, 0xcd, 0xce, 0xd0, 0xf5, 0x9c,
+	0x84, 0x8d, 0xb8, 0xa8, 0x5f, 0x84, 0x16, 0xf6, 0xd1, 0x8e, 0x87, 0x2d, 0x8e, 0xcb, 0x15, 0xb9,
+	0x6e, 0x36, 0x05, 0x6c, 0x93, 0x81, 0x18, 0xf1, 0xae, 0x8b, 0x39, 0x71, 0x45, 0x10, 0xcb, 0xa2,
+	0xf1, 0x7d, 0x0d, 0x16, 0x99, 0x16, 0x4a, 0xee, 0xc9, 0x8b, 0x95, 0xe6, 0x2a, 0x34, 0x53, 0x6a,
+	0x26, 0x07, 0x92, 0x06, 0x19, 0x4f, 0x60, 0x29, 0xcb, 0xce, 0x2c, 0xd2, 0x7c, 0x15, 0x20, 0x99,
+	0x2b, 0xb1, 0x1a, 0xca, 0x66, 0x0a, 0x62, 0x7c, 0xaf, 0x14, 0x3b, 0xa4, 0xb9, 0x98, 0x8e, 0xd9,
+	0x5f, 0xc6, 0xa7, 0x24, 0x6d, 0xcf, 0x1b, 0x1c, 0xc2, 0xab, 0x37, 0xa0, 0x85, 0x9f, 0xd1, 0x08,
+	0x59, 0x21, 0x8a, 0xd0, 0x40, 0x2c, 0xab, 0xa9, 0x4c, 0x6f, 0x93, 0x93, 0x6d, 0x71, 0x2a, 0xd6,
+	0x09, 0x57, 0x11, 0xd1, 0x49, 0x55, 0x74, 0xc2, 0x21, 0x7c, 0xc3, 0xf8, 0x7b, 0x76, 0xd8, 0x93,
+	0xda, 0x7c, 0xd2, 0x05, 0x92, 0x1d, 0x4a, 0x25, 0x3f, 0x94, 0x3f, 0xd1, 0xa0, 0xc3, 0x87, 0x20,
+	0xc6, 0x13, 0xb2, 0x66, 0x73, 0x34, 0x5a, 0x8e, 0x66, 0xc2, 0xda, 0xfb, 0x7f, 0x50, 0x95, 0x72,
+	0x2f, 0x4f, 0x2b, 0x77, 0x49, 0x70, 0xc8, 0x30, 0x8c, 0x3f, 0x64, 0x1e, 0xe4, 0xac, 0xc8, 0x67,
+	0x51, 0xf8, 0xc7, 0xa0, 0x8b, 0x11, 0x3a, 0xa3, 0x61, 0xc7, 0xfb, 0xf4, 0x6b, 0xca, 0x4d, 0x29,
+	0x2f, 0x24, 0xf3, 0xb4, 0x9b, 0x83, 0x10, 0xe3, 0x1f, 0x34, 0x38, 0x77, 0x0f, 0x53, 0x8e, 0x7a,
+	0x87, 0x19, 0x9d, 0xad, 0x28, 0xe8, 0x47, 0x98, 0x90, 0x97, 0x57, 0x3f, 0x7e, 0x5b, 0x1c, 0xec,
+	0x54, 0x43, 0x9a, 0x45, 0xfe, 0x17, 0xa1, 0xc5, 0xfb, 0xc0, 0x8e, 0x15, 0x05, 0xfb, 0x44, 0xea,
+	0x51, 0x53, 0xc2, 0xcc, 0x60, 0x9f, 0x2b, 0x04, 0x0d, 0x28, 0xf2, 0x04, 0x82, 0xdc, 0x51, 0x38,
+	0x84, 0x55, 0xf3, 0x35, 0x18, 0x33, 0xc6, 0x1a, 0xc7, 0x2f, 0xaf, 0x8c, 0xff, 0x58, 0x83, 0xe5,
+	0xdc, 0x50, 0x66, 0x91, 0xed, 0x17, 0xc5, 0xb1, 0x53, 0x0c, 0x66, 0x7e, 0xfd, 0x82, 0x92, 0x26,
+	0xd5, 0x99, 0xc0, 0xd6, 0x2f, 0x40, 0x73, 0x17, 0xb9, 0x9e, 0x15, 0x61, 0x44, 0x02, 0x5f, 0x0e,
+	0x14, 0x18, 0xc8, 0xe4, 0x10, 0xe3, 0xef, 0x34, 0x11, 0xf5, 0x7b, 0xc9, 0x2d, 0xde, 0x1f, 0x95,
+	0xa0, 0xbd, 0xe9, 0x13, 0x1c, 0xd1, 0x93, 0x7f, 0x35, 0xd1, 0x3f, 0x80, 0x26, 0x1f, 0x18, 0xb1,
+	0x1c, 0x44, 0x91, 0xdc, 0xcd, 0x5e, 0x55, 0x86, 0x08, 0x3e, 0x64, 0x78, 0xcc, 0x69, 0x6d, 0x0a,
+	0xe9, 0x10, 0xf6, 0xad, 0x9f, 0x85, 0xc6, 0x1e, 0x22, 0x7b, 0xd6, 0x13, 0x7c, 0x20, 0xce, 0x8b,
+	0x6d, 0xb3, 0xce, 0x00, 0x1f, 0xe1, 0x03, 0xa2, 0xbf, 0x02, 0x75, 0x7f, 0x38, 0x10, 0x0b, 0x8c,
+	0x39, 0xdd, 0xdb, 0x66, 0xcd, 0x1f, 0x0e, 0xf8, 0xf2, 0xfa, 0x8f, 0x12, 0xcc, 0x3f, 0x1c, 0x52,
+	0x24, 0x03, 0x1c, 0x43, 0x8f, 0x3e, 0x9f, 0x32, 0x5e, 0x85, 0xb2, 0x38, 0x52, 0x30, 0x8a, 0xae,
+	0x92, 0xf1, 0xcd, 0x0d, 0x62, 0x32, 0x24, 0x36, 0x71, 0x64, 0x68, 0xdb, 0xf2, 0x74, 0x56, 0xe6,
+	0xcc, 0x36, 0x18, 0x44, 0x9c, 0xcd, 0xce, 0x42, 0x03, 0x47, 0x51, 0x72, 0x76, 0xe3, 0x43, 0xc1,
+	0x51, 0x24, 0x2a, 0x0d, 0x68, 0x21, 0xfb, 0x89, 0x1f, 0xec, 0x7b, 0xd8, 0xe9, 0x63, 0x87, 0x4f,
+	0x7b, 0xdd, 0xcc, 0xc0, 0x84, 0x62, 0xb0, 0x89, 0xb7, 0x6c, 0x9f, 0xf2, 0x5d, 0xbd, 0x6c, 0x36,
+	0x04, 0xe4, 0xae, 0x4f, 0x59, 0xb5, 0x83, 0x3d, 0x4c, 0x31, 0xaf, 0xae, 0x89, 0x6a, 0x01, 0x91,
+	0xd5, 0xc3, 0x30, 0xa1, 0xae, 0x8b, 0x6a, 0x01, 0x61, 0xd5, 0xe7, 0xa0, 0x31, 0x8a, 0x60, 0x34,
+	0x46, 0x2e, 0x4c, 0x0e, 0x60, 0x5b, 0x26, 0x9f, 0x58, 0xe4, 0x75, 0x81, 0x73, 0x16, 0x17, 0x8d,
+	0xff, 0xd4, 0xa0, 0xbd, 0xc1, 0x3b, 0x79, 0x09, 0xd4, 0x51, 0x87, 0x39, 0xfc, 0x2c, 0x8c, 0xe4,
+	0xa2, 0xe2, 0xdf, 0x93, 0x35, 0x4c, 0x87, 0x39, 0x72, 0xe0, 0xdb, 0x5c, 0x9a, 0x75, 0x93, 0x7f,
+	0x1b, 0x4f, 0xa1, 0xb3, 0xe5, 0x21, 0x1b, 0xef, 0x05, 0x9e, 0x83, 0x23, 0x7e, 0x12, 0xd0, 0x3b,
+	0x50, 0xa6, 0xa8, 0x2f, 0x8f, 0x1a, 0xec, 0x53, 0xff, 0x92, 0xbc, 0x28, 0x0a, 0x23, 0x76, 0x59,
+	0xb9, 0x27, 0xa7, 0x9a, 0x49, 0xf9, 0x7e, 0x57, 0xa0, 0xca, 0x63, 0x90, 0xe2, 0x10, 0xd2, 0x32,
+	0x65, 0xc9, 0xf8, 0x24, 0xd3, 0xef, 0xbd, 0x28, 0x18, 0x86, 0xfa, 0x26, 0xb4, 0xc2, 0x11, 0x8c,
+	0x69, 0x76, 0xf1, 0x09, 0x20, 0xcf, 0xb4, 0x99, 0x21, 0x35, 0xfe, 0x7b, 0x0e, 0xda, 0xdb, 0x18,
+	0x45, 0xf6, 0xde, 0x4b, 0xe1, 0x92, 0xea, 0x40, 0xd9, 0x21, 0x9e, 0x9c, 0x49, 0xf6, 0xc9, 0x82,
+	0x77, 0xa9, 0x01, 0x59, 0x7d, 0x26, 0x20, 0xbe, 0x4a, 0x5a, 0x66, 0x27, 0xcc, 0x0b, 0xee, 0x5d,
+	0xa8, 0x3b, 0xc4, 0xb3, 0xf8, 0x14, 0xd5, 0xf8, 0x14, 0xa9, 0xc7, 0xb7, 0x41, 0x3c, 0x3e, 0x35,
+	0x35, 0x47, 0x7c, 0xe8, 0x97, 0xa0, 0x1d, 0x0c, 0x69, 0x38, 0xa4, 0x96, 0xb0, 0x52, 0xdd, 0x3a,
+	0x67, 0xaf, 0x25, 0x80, 0xdc, 0x88, 0x11, 0xfd, 0x43, 0x68, 0x13, 0x2e, 0xca, 0xf8, 0x18, 0xdf,
+	0x98, 0xf6, 0x38, 0xd9, 0x12, 0x74, 0xf2, 0x1c, 0x7f, 0x05, 0x3a, 0x34, 0x42, 0x4f, 0xb1, 0x97,
+	0x8a, 0x2e, 0x02, 0x5f, 0x9b, 0x0b, 0x02, 0x3e, 0x8a, 0x2c, 0xde, 0x84, 0xc5, 0xfe, 0x10, 0x45,
+	0xc8, 0xa7, 0x18, 0xa7, 0xb0, 0x9b, 0x1c, 0x5b, 0x4f, 0xaa, 0x46, 0x04, 0xd7, 0x41, 0x27, 0x3e,
+	0x0a, 0xc9, 0x5e, 0x40, 0x53, 0xf8, 0x2d, 0x8e, 0x7f, 0x3a, 0xae, 0x19, 0xa1, 0x3f, 0x86, 0x25,
+	0xb6, 0x5c, 0x2c, 0x8a, 0x07, 0xa1, 0x87, 0x28, 0xb6, 0xa4, 0x8e, 0xb6, 0xf9, 0xc8, 0x0c, 0xa5,
+	0xc6, 0x3d, 0x96, 0xb8, 0x42, 0xdd, 0x74, 0x46, 0x9f, 0x01, 0x11, 0xe3, 0x23, 0x98, 0xbb, 0xef,
+	0x52, 0x3e, 0x9b, 0x9b, 0x1b, 0x42, 0x7d, 0xcb, 0xc2, 0x98, 0xbe, 0x02, 0xf5, 0x28, 0xd8, 0x17,
+	0xdb, 0x46, 0x89, 0xaf, 0x83, 0x5a, 0x14, 0xec, 0xf3, 0x3d, 0x81, 0x27, 0x86, 0x04, 0x91, 0x5c,
+	0x20, 0x25, 0x53, 0x96, 0x8c, 0xbf, 0x2d, 0x8d, 0x34, 0x98, 0x59, 0x7c, 0xf2, 0x7c, 0x26, 0xff,
+	0x03, 0xa8, 0x45, 0x82, 0x7e, 0x62, 0x48, 0x3b, 0xdd, 0x13, 0xdf, 0xb6, 0x62, 0xaa, 0xe9, 0x95,
+	0x5d, 0x3d, 0x05, 0x73, 0x45, 0x53, 0xc0, 0xf6, 0x17, 0x36, 0x52, 0xa1, 0xb5, 0xf2, 0x60, 0xc0,
+	0x21, 0x5c, 0x33, 0x53, 0x36, 0xba, 0x9a, 0xb1, 0xd1, 0x4c, 0x8d, 0xc8, 0x13, 0x37, 0x0c, 0xb1,
+	0x63, 0xc9, 0x4b, 0x31, 0x91, 0xfb, 0xc3, 0x82, 0x84, 0xc7, 0xd7, 0x70, 0xe3, 0x57, 0x35, 0x68,
+	0x7d, 0xe8, 0x0d, 0xc9, 0x8b, 0x30, 0x02, 0xaa, 0xc0, 0x52, 0x59, 0x1d, 0xd4, 0xfa, 0xcd, 0x12,
+	0xb4, 0x25, 0x1b, 0xb3, 0x1c, 0x25, 0x0b, 0x59, 0xd9, 0x86, 0x26, 0xeb, 0x92, 0x89, 0x23, 0xf6,
+	0x8c, 0x35, 0xd7, 0xd7, 0x95, 0x4a, 0x9c, 0x61, 0x83, 0x07, 0x81, 0xb6, 0x39, 0xd1, 0x2f, 0xfa,
+	0x34, 0x3a, 0x30, 0xc1, 0x4e, 0x00, 0xbd, 0x4f, 0x60, 0x21, 0x57, 0xcd, 0xf4, 0xfa, 0x09, 0x3e,
+	0x88, 0xf7, 0x85, 0x27, 0xf8, 0x40, 0x7f, 0x3b, 0x9d, 0x6e, 0x52, 0x74, 0x16, 0x7a, 0x10, 0xf8,
+	0xfd, 0xdb, 0x51, 0x84, 0x0e, 0x64, 0x3a, 0xca, 0x7b, 0xa5, 0x2f, 0x69, 0xc6, 0x4f, 0x2b, 0xd0,
+	0xfa, 0xea, 0x10, 0x47, 0x07, 0xc7, 0x69, 0x9f, 0xe3, 0x1d, 0x74, 0x2e, 0xb5, 0x83, 0x8e, 0x99,
+	0xc4, 0x8a, 0xc2, 0x24, 0x2a, 0x0c, 0x7b, 0x55, 0x69, 0xd8, 0x55, 0x36, 0xaf, 0x76, 0x24, 0x9b,
+	0x57, 0x3f, 0xa2, 0xcd, 0x6b, 0x14, 0x2d, 0xb8, 0x0b, 0xd0, 0x24, 0x68, 0x10, 0x7a, 0xd8, 0x22,
+	0xee, 0x67, 0x98, 0x5b, 0x5e, 0xe6, 0x57, 0xe2, 0xa0, 0x6d, 0xf7, 0x33, 0x9c, 0x46, 0xc0, 0xd8,
+	0xe9, 0x36, 0x33, 0x08, 0x18, 0x3b, 0xfa, 0x9b, 0xb0, 0x34, 0x40, 0xcf, 0x2c, 0x62, 0x23, 0xdf,
+	0x4f, 0xaf, 0xbe, 0x16, 0xc7, 0xd4, 0x07, 0xe8, 0xd9, 0xb6, 0xa8, 0x8a, 0x17, 0x20, 0x4b, 0x47,
+	0xf2, 0xdc, 0x81, 0x4b, 0xbb, 0x6d, 0x8e, 0x22, 0x0a, 0xfa, 0x65, 0x98, 0x0f, 0x22, 0xb6, 0xab,
+	0xed, 0x1c, 0x08, 0x21, 0x77, 0xe7, 0xf9, 0x04, 0xb4, 0x38, 0xf4, 0xce, 0x01, 0x17, 0x32, 0x33,
+	0x10, 0x02, 0x8b, 0x79, 0x05, 0xba, 0x0b, 0xdc, 0x08, 0x34, 0x38, 0x84, 0x5d, 0xf3, 0xd9, 0xa5,
+	0x15, 0x45, 0xcc, 0xa8, 0xee, 0x06, 0xd1, 0x00, 0xd1, 0x6e, 0x87, 0x23, 0x34, 0x39, 0xec, 0x43,
+	0x0e, 0x2a, 0xb4, 0xf2, 0xa7, 0x67, 0xb1, 0xf2, 0xcc, 0x60, 0xdb, 0xc3, 0x88, 0x04, 0x51, 0x57,
+	0x17, 0x99, 0x7c, 0xa2, 0x64, 0xfc, 0x73, 0x29, 0xd1, 0xe8, 0x99, 0xec, 0x75, 0xe6, 0x8e, 0x51,
+	0x3a, 0xf2, 0x1d, 0xe3, 0x45, 0xd9, 0xeb, 0x94, 0x41, 0xae, 0x1c, 0x6e, 0x90, 0xab, 0x4a, 0x83,
+	0xcc, 0x8e, 0xa7, 0x62, 0xd2, 0xdc, 0x50, 0x1c, 0x43, 0x5b, 0x66, 0x9d, 0x03, 0x36, 0x43, 0x3b,
+	0x25, 0xd8, 0x7a, 0x46, 0xb0, 0x3f, 0xd6, 0xa0, 0xf1, 0x35, 0x6c, 0xd3, 0x20, 0x62, 0x5b, 0xa9,
+	0x62, 0x7c, 0xda, 0x14, 0x17, 0xd3, 0x52, 0xfe, 0x62, 0x7a, 0x0b, 0xea, 0xae, 0x63, 0x21, 0x66,
+	0x97, 0xba, 0xe5, 0x43, 0x2e, 0x44, 0x35, 0xd7, 0xe1, 0x06, 0x6c, 0xfa, 0x10, 0xdc, 0xef, 0x68,
+	0xd0, 0x12, 0x3c, 0x13, 0x41, 0xf9, 0x7e, 0xaa, 0x3b, 0x4d, 0x65, 0x2c, 0x65, 0x21, 0x19, 0xe8,
+	0xfd, 0x53, 0xa3, 0x6e, 0x6f, 0x03, 0x30, 0x6d, 0x90, 0xe4, 0xc2, 0xd6, 0xae, 0x2a, 0xb9, 0x15,
+	0xe4, 0x5c, 0x33, 0xee, 0x9f, 0x32, 0x1b, 0x8c, 0x8a, 0x37, 0x71, 0xa7, 0x06, 0x15, 0x4e, 0x6d,
+	0xfc, 0xaf, 0x06, 0x8b, 0x77, 0x91, 0x67, 0x6f, 0xb8, 0x84, 0x22, 0xdf, 0x9e, 0xe1, 0xa2, 0xf3,
+	0x1e, 0xd4, 0x82, 0xd0, 0xf2, 0xf0, 0x2e, 0x95, 0x2c, 0x5d, 0x9c, 0x30, 0x22, 0x21, 0x06, 0xb3,
+	0x1a, 0x84, 0x0f, 0xf0, 0x2e, 0xd5, 0xbf, 0x0c, 0xf5, 0x20, 0xb4, 0x22, 0xb7, 0xbf, 0x47, 0xbb,
+	0xe5, 0x69, 0x89, 0x6b, 0x41, 0x68, 0x32, 0x8a, 0x94, 0x67, 0x73, 0xee, 0x88, 0x9e, 0x4d, 0xe3,
+	0x1f, 0xc7, 0x86, 0x3f, 0xc3, 0x62, 0x7d, 0x0f, 0xea, 0xae, 0x4f, 0x2d, 0xc7, 0x25, 0xb1, 0x08,
+	0xce, 0xab, 0x75, 0xc8, 0xa7, 0x7c, 0x04, 0x7c, 0x4e, 0x7d, 0xca, 0xfa, 0xd6, 0xbf, 0x02, 0xb0,
+	0xeb, 0x05, 0x48, 0x52, 0x0b, 0x19, 0x5c, 0x50, 0xaf, 0x73, 0x86, 0x16, 0xd3, 0x37, 0x38, 0x11,
+	0x6b, 0x61, 0x34, 0xa5, 0x3f, 0xd5, 0x60, 0x79, 0x0b, 0x47, 0x22, 0x89, 0x8d, 0xca, 0xc5, 0xb6,
+	0xe9, 0xef, 0x06, 0xd9, 0x38, 0x90, 0x96, 0x8b, 0x03, 0xfd, 0x6c, 0x62, 0x1f, 0x19, 0xbf, 0x85,
+	0x88, 0x46, 0xc6, 0x7e, 0x8b, 0x38, 0xe6, 0x2a, 0x8e, 0x77, 0xf3, 0x05, 0xd3, 0x24, 0xf9, 0x4d,
+	0xbb, 0xbf, 0x8c, 0xdf, 0x12, 0xb9, 0x57, 0xca, 0x41, 0x3d, 0xbf, 0xc2, 0xae, 0x80, 0x3c, 0x21,
+	0xe4, 0xce, 0x0b, 0xaf, 0x43, 0xce, 0x76, 0x14, 0x64, 0x84, 0xfd, 0x48, 0x83, 0xd5, 0x62, 0xae,
+	0x66, 0x39, 0xda, 0x7d, 0x05, 0x2a, 0xae, 0xbf, 0x1b, 0xc4, 0x4e, 0xef, 0xab, 0xea, 0x2b, 0xaf,
+	0xb2, 0x5f, 0x41, 0x68, 0xfc, 0x45, 0x09, 0x3a, 0x7c, 0xf7, 0x39, 0x86, 0xe9, 0x1f, 0xe0, 0x81,
+	0x38, 0x53, 0xc8, 0xe9, 0x1f, 0xe0, 0x01, 0x3f, 0x50, 0xa4, 0x35, 0xa3, 0x92, 0xd5, 0x8c, 0xc9,
+	0x31, 0x9d, 0x74, 0x50, 0xa3, 0x96, 0x0d, 0x6a, 0xac, 0x40, 0xd5, 0x0f, 0x1c, 0xbc, 0xb9, 0x21,
+	0x9d, 0x3e, 0xb2, 0x34, 0x52, 0xb5, 0xc6, 0x11, 0x55, 0xed, 0x73, 0x0d, 0x7a, 0xf7, 0x30, 0xcd,
+	0xcb, 0xee, 0xf8, 0xb4, 0xec, 0x07, 0x1a, 0x9c, 0x55, 0x32, 0x34, 0x8b, 0x82, 0xbd, 0x9f, 0x55,
+	0x30, 0xb5, 0x4f, 0x65, 0xac, 0x4b, 0xa9, 0x5b, 0x6f, 0x41, 0x6b, 0x63, 0x38, 0x18, 0x24, 0x47,
+	0xf5, 0x8b, 0xd0, 0x8a, 0xc4, 0xa7, 0xb8, 0xbc, 0x89, 0xfd, 0xb7, 0x29, 0x61, 0xec, 0xfa, 0x66,
+	0x5c, 0x83, 0xb6, 0x24, 0x91, 0x5c, 0xf7, 0xa0, 0x1e, 0xc9, 0x6f, 0x89, 0x9f, 0x94, 0x8d, 0x65,
+	0x58, 0x34, 0x71, 0x9f, 0xa9, 0x76, 0xf4, 0xc0, 0xf5, 0x9f, 0xc8, 0x6e, 0x8c, 0x6f, 0x6b, 0xb0,
+	0x94, 0x85, 0xcb, 0xb6, 0xde, 0x81, 0x1a, 0x72, 0x9c, 0x08, 0x13, 0x32, 0x71, 0x5a, 0x6e, 0x0b,
+	0x1c, 0x33, 0x46, 0x4e, 0x49, 0xae, 0x34, 0xb5, 0xe4, 0x0c, 0x0b, 0x4e, 0xdf, 0xc3, 0xf4, 0x21,
+	0xa6, 0xd1, 0x4c, 0xf9, 0x33, 0x5d, 0x76, 0x0f, 0xe7, 0xc4, 0x52, 0x2d, 0xe2, 0x22, 0x4b, 0x0e,
+	0xd0, 0xd3, 0x3d, 0xcc, 0x32, 0xcd, 0x69, 0x29, 0x97, 0xb2, 0x52, 0x16, 0xe9, 0x8d, 0x83, 0x30,
+	0xf0, 0xb1, 0x4f, 0xd3, 0xe7, 0xc2, 0x76, 0x02, 0x8d, 0x93, 0xba, 0x74, 0x96, 0xd4, 0x75, 0x07,
+	0x79, 0xb3, 0x1d, 0x0f, 0xd8, 0x05, 0x3f, 0xb2, 0x2d, 0xb9, 0x5a, 0x4b, 0xd2, 0xfa, 0x44, 0xf6,
+	0x23, 0xb1, 0x60, 0x2f, 0x40, 0xd3, 0x21, 0x54, 0x56, 0xc7, 0xe9, 0x1c, 0xe0, 0x10, 0x2a, 0xea,
+	0x79, 0xfa, 0x3a, 0xc1, 0xc8, 0x1b, 0x9d, 0x2a, 0x37, 0x37, 0xc4, 0x7e, 0x5f, 0x36, 0x3b, 0xa2,
+	0x62, 0x3b, 0x81, 0x2b, 0x16, 0x57, 0x45, 0xb9, 0xb8, 0x3e, 0x81, 0x33, 0x0f, 0x91, 0xcf, 0xf2,
+	0xeb, 0x83, 0x41, 0x88, 0x32, 0xa9, 0xcf, 0x79, 0x73, 0xa8, 0x29, 0xcc, 0xe1, 0xab, 0x22, 0x37,
+	0x56, 0x5c, 0xdd, 0xf8, 0x98, 0xe6, 0xcc, 0x14, 0xc4, 0x20, 0xd0, 0x1d, 0x6f, 0x7e, 0x96, 0x09,
+	0xe5, 0x4c, 0xc5, 0x4d, 0xa5, 0x6d, 0xf4, 0x08, 0x66, 0x7c, 0x00, 0xaf, 0xf0, 0x3c, 0xe5, 0x18,
+	0x94, 0x09, 0xc0, 0xe5, 0x1b, 0xd0, 0x14, 0x0d, 0xfc, 0x7a, 0x09, 0x7a, 0xaa, 0x16, 0x66, 0x61,
+	0xfc, 0xbd, 0x6c, 0xdc, 0xeb, 0x72, 0x41, 0x2e, 0x7e, 0xb6, 0x47, 0x41, 0xa2, 0xaf, 0xc1, 0x02,
+	0x7e, 0x86, 0xed, 0x21, 0x75, 0xfd, 0xfe, 0x96, 0x87, 0xfc, 0x47, 0x81, 0xdc, 0x78, 0xf2, 0x60,
+	0xfd, 0x32, 0xb4, 0x99, 0xf4, 0x83, 0x21, 0x95, 0x78, 0x62, 0x07, 0xca, 0x02, 0x59, 0x7b, 0x6c,
+	0xbc, 0x1e, 0xa6, 0xd8, 0x91, 0x78, 0x62, 0x3b, 0xca, 0x83, 0xc7, 0x44, 0xc9, 0xc0, 0xe4, 0x28,
+	0xa2, 0xfc, 0x17, 0x0d, 0x7a, 0xaa, 0x16, 0x8e, 0x4b, 0x94, 0xf7, 0x01, 0x06, 0x38, 0xea, 0xe3,
+	0x4d, 0x6e, 0xfc, 0x85, 0x67, 0x68, 0xad, 0x20, 0x21, 0x38, 0x6e, 0xe0, 0x61, 0x4c, 0x60, 0xa6,
+	0x68, 0x8d, 0x7b, 0xb0, 0xa8, 0x40, 0x61, 0x76, 0x8d, 0x04, 0xc3, 0xc8, 0xc6, 0xb1, 0xbf, 0x33,
+	0x2e, 0xb2, 0x7d, 0x90, 0xa2, 0xa8, 0x8f, 0xa9, 0x54, 0x5a, 0x59, 0x32, 0xde, 0xe1, 0xa1, 0x62,
+	0xee, 0x88, 0xca, 0x68, 0x6a, 0x36, 0xed, 0x45, 0x1b, 0x4b, 0x7b, 0xd9, 0x85, 0xe5, 0x1c, 0xdd,
+	0x8c, 0x29, 0x4b, 0xbb, 0xac, 0x29, 0xec, 0xc8, 0x77, 0x58, 0x71, 0xd1, 0xf8, 0x1f, 0x0d, 0xda,
+	0x9b, 0x83, 0x30, 0x18, 0x85, 0x24, 0xa7, 0xbe, 0x72, 0x8e, 0x07, 0x6e, 0x4a, 0xaa, 0xc0, 0xcd,
+	0x25, 0x68, 0x67, 0x5f, 0xf1, 0x08, 0xbf, 0x61, 0xcb, 0x4e, 0xbf, 0xde, 0x39, 0x0b, 0x0d, 0x76,
+	0x51, 0x66, 0xa6, 0xd4, 0x91, 0xc9, 0x51, 0xcc, 0x87, 0xcc, 0x0c, 0xac, 0xc3, 0xfc, 0x2a, 0xbb,
+	0xae, 0x97, 0xe4, 0xf5, 0x89, 0x82, 0xfe, 0x3e, 0xbb, 0x90, 0x89, 0xe4, 0x89, 0xea, 0xb4, 0xf7,
+	0xa2, 0x98, 0x82, 0x3d, 0x40, 0x8b, 0x47, 0x3d, 0xe3, 0x03, 0x34, 0x8a, 0xc8, 0x93, 0x38, 0x6f,
+	0x49, 0x14, 0x8c, 0x6b, 0x22, 0xa6, 0xce, 0xdb, 0xcf, 0x4c, 0xba, 0x0e, 0x73, 0x0c, 0x43, 0xae,
+	0x25, 0xfe, 0xcd, 0x26, 0x60, 0x25, 0x8f, 0x3d, 0x0b, 0x4b, 0xef, 0x64, 0xd7, 0x8f, 0xfa, 0x8d,
+	0x51, 0xba, 0x37, 0xb9, 0x76, 0xe4, 0x0c, 0xd8, 0xc1, 0xd0, 0xa7, 0xd2, 0x00, 0xb1, 0x19, 0xb8,
+	0xcb, 0xca, 0xcc, 0xf9, 0xe8, 0x3a, 0x96, 0xc7, 0xee, 0x6e, 0x62, 0x4f, 0xaa, 0xba, 0xce, 0x03,
+	0x76, 0xaf, 0x7b, 0x37, 0x3e, 0x69, 0x4d, 0x9d, 0xec, 0x24, 0x4f, 0x59, 0x3f, 0x14, 0xe7, 0x00,
+	0x53, 0x24, 0x21, 0xbf, 0xe0, 0x94, 0xb6, 0x35, 0xe8, 0xec, 0xbb, 0x74, 0xcf, 0xe2, 0xaf, 0xb5,
+	0xf8, 0x26, 0x2c, 0xb2, 0x3a, 0xea, 0xe6, 0x3c, 0x83, 0x6f, 0x33, 0x30, 0xdb, 0x88, 0x89, 0xf1,
+	0x1b, 0x1a, 0x2c, 0x66, 0xd8, 0x9a, 0x65, 0x2a, 0xbe, 0xcc, 0xce, 0x27, 0xa2, 0x21, 0x79, 0x12,
+	0x5d, 0x55, 0x1a, 0x23, 0xd9, 0x1b, 0x37, 0x42, 0x09, 0x85, 0xf1, 0xaf, 0x1a, 0x34, 0x53, 0x35,
+	0xec, 0x7a, 0x23, 0xeb, 0x46, 0xd7, 0x9b, 0x04, 0x30, 0x95, 0x18, 0x2e, 0xc1, 0x68, 0x69, 0xa6,
+	0x5e, 0x7c, 0xa4, 0xb2, 0x4a, 0x1d, 0xa2, 0xdf, 0x87, 0x79, 0x21, 0xa6, 0x84, 0x75, 0xa5, 0xd7,
+	0x21, 0xc9, 0x97, 0x45, 0x91, 0x23, 0xb9, 0x34, 0xdb, 0x24, 0x55, 0x12, 0x21, 0xfe, 0xc0, 0xc1,
+	0xbc, 0xa7, 0x8a, 0xb0, 0x96, 0xac, 0xbc, 0xe9, 0x10, 0x76, 0x0d, 0x69, 0xa5, 0x49, 0xd9, 0x51,
+	0xce, 0xc3, 0xc8, 0xc1, 0x51, 0x32, 0xb6, 0xa4, 0xcc, 0xce, 0x4e, 0xe2, 0xdb, 0x62, 0x47, 0x5b,
+	0x69, 0x64, 0x40, 0x80, 0xd8, 0xa9, 0x57, 0x7f, 0x1d, 0x16, 0x9c, 0x41, 0xe6, 0xa9, 0x60, 0x7c,
+	0xd8, 0x73, 0x06, 0xa9, 0x37, 0x82, 0x19, 0x86, 0xe6, 0xb2, 0x0c, 0xfd, 0x97, 0x96, 0x3c, 0xa0,
+	0x8e, 0xb0, 0x83, 0x7d, 0xea, 0x22, 0xef, 0xf9, 0x75, 0xb2, 0x07, 0xf5, 0x21, 0xc1, 0x51, 0xca,
+	0x26, 0x26, 0x65, 0x56, 0x17, 0x22, 0x42, 0xf6, 0x83, 0xc8, 0x91, 0x5c, 0x26, 0xe5, 0x09, 0x29,
+	0xba, 0xc2, 0x51, 0xa9, 0x4e, 0xd1, 0x7d, 0x07, 0xce, 0x0c, 0x02, 0xc7, 0xdd, 0x75, 0x55, 0x99,
+	0xbd, 0x8c, 0x6c, 0x39, 0xae, 0xce, 0xd0, 0x19, 0x3f, 0x2a, 0xc1, 0x99, 0x8f, 0x43, 0xe7, 0xe7,
+	0x30, 0xe6, 0x55, 0x68, 0x06, 0x9e, 0xb3, 0x95, 0x1d, 0x76, 0x1a, 0xc4, 0x30, 0x7c, 0xbc, 0x9f,
+	0x60, 0x88, 0x10, 0x45, 0x1a, 0x34, 0x31, 0x7d, 0xf9, 0xb9, 0x64, 0x53, 0x9d, 0x24, 0x9b, 0x3e,
+	0xcb, 0x19, 0xf6, 0xf0, 0x0b, 0x17, 0x8d, 0xf1, 0x2b, 0xb0, 0xcc, 0x0c, 0x29, 0xeb, 0xe6, 0x63,
+	0x82, 0xa3, 0x19, 0x2d, 0xce, 0x39, 0x68, 0xc4, 0x2d, 0xc7, 0x99, 0xe5, 0x23, 0x80, 0x71, 0x1f,
+	0x96, 0x72, 0x7d, 0x3d, 0xe7, 0x88, 0x8c, 0xef, 0xb0, 0xe5, 0xa2, 0x7e, 0x53, 0x95, 0xf1, 0x83,
+	0x68, 0x59, 0x3f, 0xc8, 0x05, 0x68, 0x0e, 0xe4, 0x93, 0x2d, 0xf7, 0x33, 0x21, 0x8b, 0xb2, 0x09,
+	0x02, 0xc4, 0x7d, 0x28, 0x1d, 0x28, 0x7f, 0x1a, 0x0a, 0xdb, 0xac, 0x99, 0xec, 0x53, 0x5f, 0x85,
+	0x16, 0x25, 0x68, 0x17, 0x5b, 0x1e, 0xea, 0x5b, 0x83, 0xd8, 0xe7, 0x06, 0x1c, 0xf6, 0x00, 0xf5,
+	0x1f, 0x12, 0x83, 0x42, 0x3b, 0x13, 0xb3, 0x60, 0xbb, 0x6c, 0xea, 0xd4, 0xc2, 0xbf, 0xd9, 0xae,
+	0x98, 0x0e, 0xdd, 0xa9, 0xdd, 0xc9, 0xdb, 0x36, 0xf2, 0x90, 0x70, 0x27, 0xcb, 0xe0, 0x1d, 0xdf,
+	0xf8, 0x88, 0xd8, 0xf8, 0xc4, 0x86, 0x51, 0x75, 0x09, 0x93, 0xe1, 0xd5, 0x8b, 0x50, 0x8f, 0xdf,
+	0x0a, 0xe8, 0x35, 0x28, 0xdf, 0xf6, 0xbc, 0xce, 0x29, 0xbd, 0x05, 0xf5, 0x58, 0x16, 0x1d, 0xed,
+	0xea, 0x2f, 0xc0, 0x42, 0x2e, 0x4b, 0x44, 0xaf, 0xc3, 0xdc, 0xa3, 0xc0, 0xc7, 0x9d, 0x53, 0x7a,
+	0x07, 0x5a, 0x77, 0x5c, 0x1f, 0x45, 0x07, 0xc2, 0xe7, 0xdb, 0x71, 0xf4, 0x05, 0x68, 0x72, 0xdf,
+	0xa7, 0x04, 0xe0, 0xf5, 0xbf, 0xba, 0x0c, 0xed, 0x87, 0x9c, 0xcd, 0x6d, 0x1c, 0x3d, 0x75, 0x6d,
+	0xac, 0x5b, 0xd0, 0xc9, 0xff, 0xe5, 0x41, 0x2f, 0x78, 0xf0, 0xa6, 0xfe, 0x19, 0x44, 0x6f, 0x92,
+	0x16, 0x19, 0xa7, 0xf4, 0x6f, 0xc2, 0x7c, 0xf6, 0x5f, 0x09, 0xba, 0xda, 0x39, 0xa7, 0xfc, 0xa1,
+	0xc2, 0x61, 0x8d, 0x5b, 0xd0, 0xce, 0xfc, 0xfa, 0x40, 0xbf, 0xa2, 0x6c, 0x5b, 0xf5, 0x7b, 0x84,
+	0x9e, 0x7a, 0xf7, 0x49, 0xff, 0x9e, 0x40, 0x70, 0x9f, 0x7d, 0x9f, 0x5c, 0xc0, 0xbd, 0xf2, 0x11,
+	0xf3, 0x61, 0xdc, 0x23, 0x38, 0x3d, 0xf6, 0x8e, 0x58, 0xbf, 0x5e, 0xb0, 0x9f, 0xab, 0xdf, 0x1b,
+	0x1f, 0xd6, 0xc5, 0x3e, 0xe8, 0xe3, 0x4f, 0xfc, 0xf5, 0x1b, 0xea, 0x19, 0x28, 0xfa, 0xc1, 0x41,
+	0xef, 0xe6, 0xd4, 0xf8, 0x89, 0xe0, 0x7e, 0x4d, 0x83, 0x33, 0x05, 0x8f, 0x7f, 0xf5, 0x5b, 0xca,
+	0xe6, 0x26, 0xbf, 0x60, 0xee, 0xbd, 0x7d, 0x34, 0xa2, 0x84, 0x11, 0x1f, 0x16, 0x72, 0xef, 0x61,
+	0xf5, 0x6b, 0x85, 0xef, 0x74, 0xc6, 0x1f, 0x06, 0xf7, 0xbe, 0x30, 0x1d, 0x72, 0xd2, 0x1f, 0x0b,
+	0xfb, 0x67, 0xdf, 0x7b, 0x16, 0xf4, 0xa7, 0x7e, 0x15, 0x7a, 0xd8, 0x84, 0x7e, 0x03, 0xda, 0x99,
+	0x87, 0x99, 0x05, 0x1a, 0xaf, 0x7a, 0xbc, 0x79, 0x58, 0xd3, 0x9f, 0x40, 0x2b, 0xfd, 0x7e, 0x52,
+	0x5f, 0x2b, 0x5a, 0x4b, 0x63, 0x0d, 0x1f, 0x65, 0x29, 0x25, 0xc4, 0x64, 0xc2, 0x52, 0x1a, 0x7b,
+	0x2a, 0x36, 0xfd, 0x52, 0x4a, 0xb5, 0x3f, 0x71, 0x29, 0x1d, 0xb9, 0x8b, 0x6f, 0x8b, 0x5b, 0x95,
+	0xe2, 0x5d, 0x9d, 0xbe, 0x5e, 0xa4, 0x9b, 0xc5, 0x2f, 0x08, 0x7b, 0xb7, 0x8e, 0x44, 0x93, 0x48,
+	0xf1, 0x09, 0xcc, 0x67, 0x5f, 0x8f, 0x15, 0x48, 0x51, 0xf9, 0xe0, 0xae, 0x77, 0x6d, 0x2a, 0xdc,
+	0xa4, 0xb3, 0x8f, 0xa1, 0x99, 0xfa, 0x71, 0x93, 0xfe, 0xc6, 0x04, 0x3d, 0x4e, 0xff, 0xc5, 0xe8,
+	0x30, 0x49, 0x7e, 0x15, 0x1a, 0xc9, 0xff, 0x96, 0xf4, 0xd7, 0x0a, 0xf5, 0xf7, 0x28, 0x4d, 0x6e,
+	0x03, 0x8c, 0x7e, 0xa6, 0xa4, 0xbf, 0xae, 0x6c, 0x73, 0xec, 0x6f, 0x4b, 0x87, 0x35, 0x9a, 0x0c,
+	0x5f, 0x24, 0xe5, 0x4e, 0x1a, 0x7e, 0x3a, 0x8b, 0xfc, 0xb0, 0x66, 0xf7, 0xa0, 0x1d, 0x9b, 0x4e,
+	0xd1, 0xf0, 0x95, 0x89, 0xe6, 0x35, 0xd3, 0xf4, 0xd5, 0x69, 0x50, 0x93, 0xf9, 0xdb, 0x83, 0x76,
+	0x26, 0x13, 0xbf, 0xa0, 0x27, 0xd5, 0xc3, 0x83, 0xde, 0xd5, 0x69, 0x50, 0x93, 0x9e, 0xbe, 0x95,
+	0x4a, 0xfa, 0xcf, 0x3c, 0xac, 0xd0, 0xdf, 0x9a, 0xd8, 0x8e, 0xea, 0x5d, 0x49, 0x6f, 0xfd, 0x28,
+	0x24, 0x09, 0x0b, 0x52, 0xab, 0x84, 0x48, 0x8b, 0xb5, 0xea, 0x28, 0x33, 0xb5, 0x0d, 0x55, 0x91,
+	0x5b, 0xaf, 0x1b, 0x05, 0xaf, 0x68, 0x52, 0x89, 0xf7, 0xbd, 0x4b, 0x4a, 0x9c, 0x6c, 0xda, 0xb9,
+	0x68, 0x54, 0xdc, 0x03, 0x0a, 0x1a, 0xcd, 0xa4, 0x4f, 0x4f, 0xdb, 0xa8, 0x09, 0x55, 0x91, 0x81,
+	0x58, 0xd0, 0x68, 0x26, 0x95, 0xb7, 0x37, 0x19, 0x87, 0x35, 0xc9, 0x46, 0xbf, 0x05, 0x15, 0xee,
+	0x2c, 0xd4, 0x2f, 0x4e, 0xca, 0x84, 0x9b, 0xd4, 0x62, 0x26, 0x59, 0xce, 0x38, 0xa5, 0xff, 0x12,
+	0x54, 0x78, 0x88, 0xac, 0xa0, 0xc5, 0x74, 0x3a, 0x5b, 0x6f, 0x22, 0x4a, 0xcc, 0xa2, 0x03, 0xad,
+	0x74, 0x2e, 0x42, 0xc1, 0x96, 0xa5, 0xc8, 0xd6, 0xe8, 0x4d, 0x83, 0x19, 0xf7, 0x22, 0x96, 0xd1,
+	0xc8, 0x71, 0x5a, 0xbc, 0x8c, 0xc6, 0x9c, 0xb2, 0xbd, 0xab, 0xd3, 0xa0, 0x26, 0x02, 0xfa, 0x8e,
+	0x06, 0xdd, 0xa2, 0x00, 0xb9, 0x5e, 0x78, 0x02, 0x9a, 0x14, 0xe5, 0xef, 0x7d, 0xf1, 0x88, 0x54,
+	0x09, 0x2f, 0x9f, 0x71, 0xb7, 0xd5, 0x58, 0x48, 0xfc, 0x66, 0x51, 0x7b, 0x05, 0x01, 0xe0, 0xde,
+	0x9b, 0xd3, 0x13, 0x24, 0x7d, 0xef, 0x40, 0x33, 0xe5, 0x32, 0x2b, 0xb0, 0xbc, 0xe3, 0xbe, 0xbe,
+	0xde, 0xda, 0xe1, 0x88, 0x49, 0x1f, 0x5b, 0x50, 0xe1, 0x11, 0xd6, 0x02, 0x65, 0x4c, 0x07, 0x6c,
+	0x7b, 0xc6, 0x24, 0x94, 0xa4, 0x45, 0x0c, 0xad, 0x74, 0xb8, 0xb5, 0x40, 0x1b, 0x15, 0x91, 0xda,
+	0xde, 0x95, 0x29, 0x30, 0x93, 0x6e, 0x2c, 0x80, 0x51, 0xb8, 0xb3, 0x60, 0xaf, 0x1b, 0x8b, 0xb8,
+	0xf6, 0xde, 0x38, 0x14, 0x2f, 0xbd, 0xed, 0xa7, 0x02, 0x98, 0x05, 0xd2, 0x1f, 0x0f, 0x71, 0x4e,
+	0x71, 0x17, 0x19, 0x0f, 0x92, 0x15, 0xdc, 0x45, 0x0a, 0xe3, 0x71, 0xbd, 0x9b, 0x53, 0xe3, 0x27,
+	0xe3, 0xf9, 0x14, 0x3a, 0xf9, 0xa0, 0x62, 0xc1, 0x1d, 0xb7, 0x20, 0xb4, 0xd9, 0xbb, 0x3e, 0x25,
+	0x76, 0x7a, 0x3f, 0x3c, 0x3b, 0xce, 0xd3, 0xd7, 0x5d, 0xba, 0xc7, 0xe3, 0x59, 0xd3, 0x8c, 0x3a,
+	0x1d, 0x3a, 0xeb, 0xdd, 0x9c, 0x1a, 0x3f, 0x61, 0x81, 0x6d, 0x5e, 0xdc, 0x27, 0x5f, 0xb4, 0x79,
+	0xa5, 0x43, 0x34, 0xbd, 0x4b, 0x13, 0x71, 0xd2, 0xc7, 0xcf, 0x6c, 0x64, 0x41, 0x2f, 0x3e, 0x27,
+	0x8c, 0x05, 0x2b, 0x7a, 0xd7, 0xa6, 0xc2, 0x4d, 0x29, 0x7a, 0x27, 0xef, 0x40, 0x9d, 0xec, 0x9b,
+	0xc8, 0x3b, 0xd6, 0x0e, 0x77, 0x1f, 0x74, 0xf2, 0xde, 0xca, 0x82, 0x0e, 0x0a, 0x9c, 0x9a, 0x53,
+	0x74, 0x90, 0xf7, 0xf9, 0x15, 0x74, 0x50, 0xe0, 0x1a, 0x9c, 0xe2, 0x2c, 0x99, 0xf1, 0xbf, 0x15,
+	0x6c, 0x4d, 0x2a, 0x1f, 0x5d, 0xef, 0xea, 0x34, 0xa8, 0xf1, 0x64, 0xac, 0x0f, 0xa1, 0xb5, 0x15,
+	0x05, 0xcf, 0x0e, 0x62, 0xc7, 0xd1, 0xcf, 0xc7, 0xd8, 0xdd, 0xf9, 0x3a, 0xcc, 0xbb, 0x09, 0x4e,
+	0x3f, 0x0a, 0xed, 0x3b, 0x4d, 0xe1, 0xc0, 0xda, 0x62, 0xc4, 0x5b, 0xda, 0x2f, 0xdf, 0xea, 0xbb,
+	0x74, 0x6f, 0xb8, 0xc3, 0x24, 0x73, 0x53, 0xa0, 0x5d, 0x77, 0x03, 0xf9, 0x75, 0xd3, 0xf5, 0x29,
+	0x8e, 0x7c, 0xe4, 0xdd, 0xe4, 0x5d, 0x49, 0x68, 0xb8, 0xf3, 0x07, 0x9a, 0xb6, 0x53, 0xe5, 0xa0,
+	0x5b, 0xff, 0x37, 0x00, 0x46, 0xac, 0xd6, 0xe7, 0xdd, 0x56, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
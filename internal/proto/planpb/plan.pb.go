@@ -229,6 +229,7 @@ type QueryInfo struct {
 	MetricType           string   `protobuf:"bytes,3,opt,name=metric_type,json=metricType,proto3" json:"metric_type,omitempty"`
 	SearchParams         string   `protobuf:"bytes,4,opt,name=search_params,json=searchParams,proto3" json:"search_params,omitempty"`
 	RoundDecimal         int64    `protobuf:"varint,5,opt,name=round_decimal,json=roundDecimal,proto3" json:"round_decimal,omitempty"`
+	OutputTimestamps     bool     `protobuf:"varint,6,opt,name=output_timestamps,json=outputTimestamps,proto3" json:"output_timestamps,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -287,6 +288,13 @@ func (m *QueryInfo) GetRoundDecimal() int64 {
 	return 0
 }
 
+func (m *QueryInfo) GetOutputTimestamps() bool {
+	if m != nil {
+		return m.OutputTimestamps
+	}
+	return false
+}
+
 type ColumnInfo struct {
 	FieldId              int64             `protobuf:"varint,1,opt,name=field_id,json=fieldId,proto3" json:"field_id,omitempty"`
 	DataType             schemapb.DataType `protobuf:"varint,2,opt,name=data_type,json=dataType,proto3,enum=milvus.proto.schema.DataType" json:"data_type,omitempty"`
@@ -897,6 +905,11 @@ type PlanNode struct {
 	//	*PlanNode_Predicates
 	Node                 isPlanNode_Node `protobuf_oneof:"node"`
 	OutputFieldIds       []int64         `protobuf:"varint,3,rep,packed,name=output_field_ids,json=outputFieldIds,proto3" json:"output_field_ids,omitempty"`
+	SampleSize           int64           `protobuf:"varint,4,opt,name=sample_size,json=sampleSize,proto3" json:"sample_size,omitempty"`
+	SampleSeed           int64           `protobuf:"varint,5,opt,name=sample_seed,json=sampleSeed,proto3" json:"sample_seed,omitempty"`
+	Limit                int64           `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	OrderByFieldId       int64           `protobuf:"varint,7,opt,name=order_by_field_id,json=orderByFieldId,proto3" json:"order_by_field_id,omitempty"`
+	OrderDesc            bool            `protobuf:"varint,8,opt,name=order_desc,json=orderDesc,proto3" json:"order_desc,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -971,6 +984,41 @@ func (m *PlanNode) GetOutputFieldIds() []int64 {
 	return nil
 }
 
+func (m *PlanNode) GetSampleSize() int64 {
+	if m != nil {
+		return m.SampleSize
+	}
+	return 0
+}
+
+func (m *PlanNode) GetSampleSeed() int64 {
+	if m != nil {
+		return m.SampleSeed
+	}
+	return 0
+}
+
+func (m *PlanNode) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *PlanNode) GetOrderByFieldId() int64 {
+	if m != nil {
+		return m.OrderByFieldId
+	}
+	return 0
+}
+
+func (m *PlanNode) GetOrderDesc() bool {
+	if m != nil {
+		return m.OrderDesc
+	}
+	return false
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*PlanNode) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1000,75 +1048,81 @@ func init() {
 func init() { proto.RegisterFile("plan.proto", fileDescriptor_2d655ab2f7683c23) }
 
 var fileDescriptor_2d655ab2f7683c23 = []byte{
-	// 1108 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcd, 0x72, 0x1b, 0xc5,
-	0x13, 0xd7, 0x6a, 0x25, 0x79, 0xb7, 0xa5, 0x48, 0xf2, 0x5e, 0xfe, 0xce, 0xdf, 0x04, 0x9b, 0x25,
-	0x45, 0x04, 0x54, 0xec, 0x22, 0x09, 0x4e, 0x11, 0x0a, 0xca, 0x5f, 0xc1, 0x52, 0x11, 0x6c, 0xb3,
-	0x18, 0x1f, 0xb8, 0x6c, 0x8d, 0x76, 0xc7, 0xd2, 0x54, 0x56, 0x33, 0xeb, 0xd9, 0x59, 0x11, 0x9d,
-	0xb9, 0x71, 0xe3, 0x25, 0xe0, 0x0c, 0x37, 0xde, 0x81, 0x07, 0xe0, 0xce, 0x8b, 0x50, 0xd3, 0xb3,
-	0xd6, 0x47, 0x4a, 0x76, 0x4c, 0x55, 0x6e, 0x33, 0xbf, 0xe9, 0xee, 0xe9, 0x5f, 0x7f, 0xcd, 0x00,
-	0xa4, 0x09, 0xe1, 0x5b, 0xa9, 0x14, 0x4a, 0x78, 0xab, 0x23, 0x96, 0x8c, 0xf3, 0xcc, 0xec, 0xb6,
-	0xf4, 0xc1, 0xff, 0x1b, 0x59, 0x34, 0xa4, 0x23, 0x62, 0x20, 0xff, 0x17, 0x0b, 0x1a, 0x47, 0x94,
-	0x53, 0xc9, 0xa2, 0x73, 0x92, 0xe4, 0xd4, 0x5b, 0x07, 0xa7, 0x2f, 0x44, 0x12, 0x8e, 0x49, 0xb2,
-	0x66, 0x6d, 0x5a, 0x1d, 0xa7, 0x5b, 0x0a, 0x56, 0x34, 0x72, 0x4e, 0x12, 0xef, 0x1e, 0xb8, 0x8c,
-	0xab, 0x9d, 0x27, 0x78, 0x5a, 0xde, 0xb4, 0x3a, 0x76, 0xb7, 0x14, 0x38, 0x08, 0x15, 0xc7, 0x17,
-	0x89, 0x20, 0x0a, 0x8f, 0xed, 0x4d, 0xab, 0x63, 0xe9, 0x63, 0x84, 0xf4, 0xf1, 0x06, 0x40, 0xa6,
-	0x24, 0xe3, 0x03, 0x3c, 0xaf, 0x6c, 0x5a, 0x1d, 0xb7, 0x5b, 0x0a, 0x5c, 0x83, 0x9d, 0x93, 0x64,
-	0xbf, 0x0a, 0xf6, 0x98, 0x24, 0xfe, 0xcf, 0x16, 0xb8, 0xdf, 0xe6, 0x54, 0x4e, 0x7a, 0xfc, 0x42,
-	0x78, 0x1e, 0x54, 0x94, 0x48, 0x5f, 0xa2, 0x33, 0x76, 0x80, 0x6b, 0x6f, 0x03, 0xea, 0x23, 0xaa,
-	0x24, 0x8b, 0x42, 0x35, 0x49, 0x29, 0x5e, 0xe5, 0x06, 0x60, 0xa0, 0xb3, 0x49, 0x4a, 0xbd, 0xf7,
-	0xe1, 0x4e, 0x46, 0x89, 0x8c, 0x86, 0x61, 0x4a, 0x24, 0x19, 0x65, 0xe6, 0xb6, 0xa0, 0x61, 0xc0,
-	0x53, 0xc4, 0xb4, 0x90, 0x14, 0x39, 0x8f, 0xc3, 0x98, 0x46, 0x6c, 0x44, 0x92, 0xb5, 0x2a, 0x5e,
-	0xd1, 0x40, 0xf0, 0xd0, 0x60, 0xfe, 0xaf, 0x16, 0xc0, 0x81, 0x48, 0xf2, 0x11, 0x47, 0x6f, 0xee,
-	0x82, 0x73, 0xc1, 0x68, 0x12, 0x87, 0x2c, 0x2e, 0x3c, 0x5a, 0xc1, 0x7d, 0x2f, 0xf6, 0x9e, 0x81,
-	0x1b, 0x13, 0x45, 0x8c, 0x4b, 0x3a, 0x38, 0xcd, 0x47, 0xf7, 0xb6, 0x16, 0xe2, 0x5f, 0x44, 0xfe,
-	0x90, 0x28, 0xa2, 0xbd, 0x0c, 0x9c, 0xb8, 0x58, 0x79, 0xf7, 0xa1, 0xc9, 0xb2, 0x30, 0x95, 0x6c,
-	0x44, 0xe4, 0x24, 0x7c, 0x49, 0x27, 0xc8, 0xc9, 0x09, 0x1a, 0x2c, 0x3b, 0x35, 0xe0, 0xd7, 0x74,
-	0xe2, 0xad, 0x83, 0xcb, 0xb2, 0x90, 0xe4, 0x4a, 0xf4, 0x0e, 0x91, 0x91, 0x13, 0x38, 0x2c, 0xdb,
-	0xc3, 0xbd, 0xff, 0x87, 0x05, 0xcd, 0xef, 0x39, 0x91, 0x93, 0x80, 0xf0, 0x01, 0x7d, 0xfe, 0x2a,
-	0x95, 0xde, 0x97, 0x50, 0x8f, 0xd0, 0xf5, 0x90, 0xf1, 0x0b, 0x81, 0xfe, 0xd6, 0x5f, 0xf7, 0x09,
-	0x8b, 0x65, 0x46, 0x30, 0x80, 0x68, 0x46, 0xf6, 0x43, 0x28, 0x8b, 0xb4, 0xa0, 0x72, 0x77, 0x89,
-	0xda, 0x49, 0x8a, 0x34, 0xca, 0x22, 0xf5, 0x3e, 0x85, 0xea, 0x58, 0xd7, 0x0f, 0xfa, 0x5d, 0x7f,
-	0xb4, 0xb1, 0x44, 0x7a, 0xbe, 0xcc, 0x02, 0x23, 0xed, 0xff, 0x56, 0x86, 0xd6, 0x3e, 0x7b, 0xbb,
-	0x5e, 0x3f, 0x80, 0x56, 0x22, 0x7e, 0xa4, 0x32, 0x64, 0x3c, 0x4a, 0xf2, 0x8c, 0x8d, 0x4d, 0x36,
-	0x9c, 0xa0, 0x89, 0x70, 0xef, 0x0a, 0xd5, 0x82, 0x79, 0x9a, 0x2e, 0x08, 0x9a, 0xa8, 0x37, 0x11,
-	0x9e, 0x09, 0xee, 0x42, 0xdd, 0x58, 0x34, 0x14, 0x2b, 0xb7, 0xa3, 0x08, 0xa8, 0x63, 0xba, 0x6a,
-	0x17, 0xea, 0xe6, 0x2a, 0x63, 0xa1, 0x7a, 0x4b, 0x0b, 0xa8, 0x83, 0x6b, 0xff, 0x2f, 0x0b, 0xea,
-	0x07, 0x62, 0x94, 0x12, 0x69, 0xa2, 0x74, 0x04, 0xed, 0x84, 0x5e, 0xa8, 0xf0, 0x3f, 0x87, 0xaa,
-	0xa9, 0xd5, 0xe6, 0x2a, 0xba, 0x07, 0xab, 0x92, 0x0d, 0x86, 0x8b, 0x96, 0xca, 0xb7, 0xb1, 0xd4,
-	0x42, 0xbd, 0x83, 0xd7, 0xeb, 0xc5, 0xbe, 0x45, 0xbd, 0xf8, 0x3f, 0x59, 0xe0, 0x9c, 0x51, 0x39,
-	0x7a, 0x2b, 0x19, 0x7f, 0x0a, 0x35, 0x8c, 0x6b, 0xb6, 0x56, 0xde, 0xb4, 0x6f, 0x13, 0xd8, 0x42,
-	0x5c, 0x4f, 0x3f, 0x17, 0x7b, 0x06, 0xdd, 0x78, 0x82, 0xee, 0x5b, 0xe8, 0xfe, 0xfd, 0x25, 0x26,
-	0xa6, 0x92, 0x66, 0x75, 0x92, 0x62, 0xe5, 0x3f, 0x84, 0x6a, 0x34, 0x64, 0x49, 0x5c, 0xc4, 0xec,
-	0x7f, 0x4b, 0x14, 0xb5, 0x4e, 0x60, 0xa4, 0xfc, 0x0d, 0x58, 0x29, 0xb4, 0xbd, 0x3a, 0xac, 0xf4,
-	0xf8, 0x98, 0x24, 0x2c, 0x6e, 0x97, 0xbc, 0x15, 0xb0, 0x8f, 0x85, 0x6a, 0x5b, 0xfe, 0xdf, 0x16,
-	0x80, 0x69, 0x09, 0x74, 0x6a, 0x67, 0xce, 0xa9, 0x0f, 0x96, 0xd8, 0x9e, 0x89, 0x16, 0xcb, 0xc2,
-	0xad, 0x8f, 0xa1, 0xa2, 0x13, 0xfd, 0x26, 0xaf, 0x50, 0x48, 0x73, 0xc0, 0x5c, 0x16, 0xdd, 0x7b,
-	0x3d, 0x07, 0x94, 0xf2, 0x77, 0xc0, 0xb9, 0xba, 0x6b, 0x91, 0x44, 0x13, 0xe0, 0x85, 0x18, 0xb0,
-	0x88, 0x24, 0x7b, 0x3c, 0x6e, 0x5b, 0xde, 0x1d, 0x70, 0x8b, 0xfd, 0x89, 0x6c, 0x97, 0xfd, 0xdf,
-	0x6d, 0xa8, 0x20, 0xa9, 0x67, 0xe0, 0x2a, 0x2a, 0x47, 0x21, 0x7d, 0x95, 0xca, 0x22, 0xdd, 0xeb,
-	0x4b, 0xee, 0xbc, 0x2a, 0x10, 0xfd, 0x8a, 0xa8, 0xab, 0x62, 0xf9, 0x02, 0x20, 0xd7, 0x77, 0x1b,
-	0x65, 0x43, 0xef, 0x9d, 0x9b, 0xb2, 0xa5, 0xdf, 0x98, 0x7c, 0x1a, 0xcf, 0x5d, 0xa8, 0xf7, 0xd9,
-	0x4c, 0xdf, 0xbe, 0xb6, 0xd6, 0x66, 0x81, 0xed, 0x96, 0x02, 0xe8, 0xcf, 0x32, 0x72, 0x00, 0x8d,
-	0xc8, 0x34, 0xa2, 0x31, 0x61, 0xc6, 0xc1, 0xbb, 0x4b, 0xcb, 0x75, 0xda, 0xaf, 0xdd, 0x52, 0x50,
-	0x8f, 0xe6, 0xda, 0xf7, 0x1b, 0x68, 0x1b, 0x16, 0x52, 0xcf, 0x3d, 0x63, 0xc8, 0x4c, 0x85, 0xf7,
-	0xae, 0xe3, 0x32, 0x9d, 0x90, 0xdd, 0x52, 0xd0, 0xcc, 0x17, 0x67, 0xe6, 0x29, 0xac, 0x16, 0xac,
-	0xe6, 0xec, 0xd5, 0xd0, 0x9e, 0x7f, 0x2d, 0xb7, 0x79, 0x83, 0xad, 0xfe, 0x22, 0xb4, 0x5f, 0x83,
-	0x8a, 0x36, 0xe2, 0xff, 0x63, 0x01, 0x9c, 0xd3, 0x48, 0x09, 0xb9, 0x77, 0x7c, 0xfc, 0x5d, 0xf1,
-	0x04, 0x19, 0x61, 0xf3, 0x3f, 0xd0, 0x4f, 0x90, 0xb1, 0xb7, 0xf0, 0x38, 0x96, 0x17, 0x1f, 0xc7,
-	0xa7, 0x00, 0xa9, 0xa4, 0x31, 0x8b, 0x88, 0xa2, 0xd9, 0x9b, 0xca, 0x6c, 0x4e, 0xd4, 0xfb, 0x1c,
-	0xe0, 0x52, 0xff, 0x05, 0xcc, 0x68, 0xa8, 0x5c, 0x9b, 0xee, 0xe9, 0x87, 0x21, 0x70, 0x2f, 0xa7,
-	0x7f, 0x87, 0x07, 0xd0, 0x4a, 0x13, 0x12, 0xd1, 0xa1, 0x48, 0x62, 0x2a, 0x43, 0x45, 0x06, 0x18,
-	0x64, 0x37, 0x68, 0xce, 0xc1, 0x67, 0x64, 0xe0, 0xff, 0x69, 0x81, 0x73, 0x9a, 0x10, 0x7e, 0x2c,
-	0x62, 0x1c, 0xd6, 0x63, 0x64, 0x1c, 0x12, 0xce, 0xb3, 0x1b, 0xc6, 0xd1, 0x2c, 0x2e, 0xba, 0x44,
-	0x8c, 0xce, 0x1e, 0xe7, 0x99, 0xf7, 0xd9, 0x02, 0xdb, 0x9b, 0x5b, 0x50, 0xab, 0xce, 0xf1, 0xed,
-	0x40, 0x5b, 0xe4, 0x2a, 0xcd, 0x55, 0x78, 0x15, 0x4a, 0x1d, 0x2e, 0xbb, 0x63, 0x07, 0x4d, 0x83,
-	0x7f, 0x65, 0x22, 0x9a, 0xe9, 0x0c, 0x71, 0x11, 0xd3, 0x8f, 0x38, 0xd4, 0xcc, 0x60, 0x5d, 0xec,
-	0xc5, 0x16, 0xd4, 0x8f, 0x24, 0x25, 0x8a, 0xca, 0xb3, 0x21, 0xe1, 0x6d, 0xcb, 0x6b, 0x43, 0xa3,
-	0x00, 0x9e, 0x5f, 0xe6, 0x24, 0x69, 0x97, 0xbd, 0x06, 0x38, 0x2f, 0x68, 0x96, 0xe1, 0xb9, 0x8d,
-	0xcd, 0x4a, 0xb3, 0xcc, 0x1c, 0x56, 0x3c, 0x17, 0xaa, 0x66, 0x59, 0xd5, 0x72, 0xc7, 0x42, 0x99,
-	0x5d, 0x6d, 0xff, 0xf1, 0x0f, 0x9f, 0x0c, 0x98, 0x1a, 0xe6, 0xfd, 0xad, 0x48, 0x8c, 0xb6, 0x0d,
-	0xa9, 0x87, 0x4c, 0x14, 0xab, 0x6d, 0xc6, 0x15, 0x95, 0x9c, 0x24, 0xdb, 0xc8, 0x73, 0x5b, 0xf3,
-	0x4c, 0xfb, 0xfd, 0x1a, 0xee, 0x1e, 0xff, 0x1b, 0x00, 0x00, 0xff, 0xff, 0xeb, 0xf1, 0x90, 0xdc,
-	0x9d, 0x0a, 0x00, 0x00,
+	// 1201 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x72, 0xdb, 0x44,
+	0x14, 0xb6, 0x2c, 0xdb, 0x91, 0x8e, 0x5d, 0xc7, 0xd9, 0x61, 0x06, 0x97, 0x52, 0x12, 0x44, 0x07,
+	0x52, 0x3a, 0x4d, 0x86, 0xb6, 0xb4, 0x43, 0x19, 0x98, 0xe6, 0xa7, 0xd4, 0x1e, 0x4a, 0x1a, 0xd4,
+	0x90, 0x0b, 0x6e, 0x34, 0x6b, 0x69, 0x63, 0xef, 0x54, 0xd2, 0xaa, 0xab, 0x95, 0xa9, 0x7b, 0xcb,
+	0x13, 0xf0, 0x12, 0x70, 0x0d, 0x37, 0xbc, 0x04, 0x0f, 0xc0, 0x0c, 0x97, 0xbc, 0x08, 0xb3, 0x67,
+	0x15, 0xff, 0x64, 0x9c, 0x34, 0xcc, 0xf4, 0xee, 0xec, 0x77, 0xbe, 0x73, 0xf6, 0xfc, 0xe9, 0xac,
+	0x00, 0xb2, 0x98, 0xa6, 0x5b, 0x99, 0x14, 0x4a, 0x90, 0xb5, 0x84, 0xc7, 0xe3, 0x22, 0x37, 0xa7,
+	0x2d, 0xad, 0x78, 0xaf, 0x95, 0x87, 0x23, 0x96, 0x50, 0x03, 0x79, 0xbf, 0x58, 0xd0, 0x7a, 0xc2,
+	0x52, 0x26, 0x79, 0x78, 0x4c, 0xe3, 0x82, 0x91, 0x6b, 0xe0, 0x0c, 0x84, 0x88, 0x83, 0x31, 0x8d,
+	0xbb, 0xd6, 0x86, 0xb5, 0xe9, 0xf4, 0x2a, 0xfe, 0x8a, 0x46, 0x8e, 0x69, 0x4c, 0xae, 0x83, 0xcb,
+	0x53, 0x75, 0xff, 0x1e, 0x6a, 0xab, 0x1b, 0xd6, 0xa6, 0xdd, 0xab, 0xf8, 0x0e, 0x42, 0xa5, 0xfa,
+	0x24, 0x16, 0x54, 0xa1, 0xda, 0xde, 0xb0, 0x36, 0x2d, 0xad, 0x46, 0x48, 0xab, 0xd7, 0x01, 0x72,
+	0x25, 0x79, 0x3a, 0x44, 0x7d, 0x6d, 0xc3, 0xda, 0x74, 0x7b, 0x15, 0xdf, 0x35, 0xd8, 0x31, 0x8d,
+	0x77, 0xeb, 0x60, 0x8f, 0x69, 0xec, 0xfd, 0x69, 0x81, 0xfb, 0x7d, 0xc1, 0xe4, 0xa4, 0x9f, 0x9e,
+	0x08, 0x42, 0xa0, 0xa6, 0x44, 0xf6, 0x02, 0x83, 0xb1, 0x7d, 0x94, 0xc9, 0x3a, 0x34, 0x13, 0xa6,
+	0x24, 0x0f, 0x03, 0x35, 0xc9, 0x18, 0x5e, 0xe5, 0xfa, 0x60, 0xa0, 0xa3, 0x49, 0xc6, 0xc8, 0x47,
+	0x70, 0x25, 0x67, 0x54, 0x86, 0xa3, 0x20, 0xa3, 0x92, 0x26, 0xb9, 0xb9, 0xcd, 0x6f, 0x19, 0xf0,
+	0x10, 0x31, 0x4d, 0x92, 0xa2, 0x48, 0xa3, 0x20, 0x62, 0x21, 0x4f, 0x68, 0xdc, 0xad, 0xe3, 0x15,
+	0x2d, 0x04, 0xf7, 0x0d, 0x46, 0x6e, 0xc1, 0x9a, 0x28, 0x54, 0x56, 0xa8, 0x40, 0xf1, 0x84, 0xe5,
+	0x8a, 0x26, 0x59, 0xde, 0x6d, 0xe8, 0xc2, 0xf8, 0x1d, 0xa3, 0x38, 0x9a, 0xe2, 0xde, 0xaf, 0x16,
+	0xc0, 0x9e, 0x88, 0x8b, 0x24, 0xc5, 0xd0, 0xaf, 0x82, 0x73, 0xc2, 0x59, 0x1c, 0x05, 0x3c, 0x2a,
+	0xc3, 0x5f, 0xc1, 0x73, 0x3f, 0x22, 0x0f, 0xc1, 0x8d, 0xa8, 0xa2, 0x26, 0x7e, 0x5d, 0xc9, 0xf6,
+	0x9d, 0xeb, 0x5b, 0x0b, 0xcd, 0x2a, 0xdb, 0xb4, 0x4f, 0x15, 0xd5, 0x29, 0xf9, 0x4e, 0x54, 0x4a,
+	0xe4, 0x06, 0xb4, 0x79, 0x1e, 0x64, 0x92, 0x27, 0x54, 0x4e, 0x82, 0x17, 0x6c, 0x82, 0x05, 0x70,
+	0xfc, 0x16, 0xcf, 0x0f, 0x0d, 0xf8, 0x2d, 0x9b, 0x90, 0x6b, 0xe0, 0xf2, 0x3c, 0xa0, 0x85, 0x12,
+	0xfd, 0x7d, 0x4c, 0xdf, 0xf1, 0x1d, 0x9e, 0xef, 0xe0, 0xd9, 0xfb, 0xc3, 0x82, 0xf6, 0x0f, 0x29,
+	0x95, 0x13, 0x9f, 0xa6, 0x43, 0xf6, 0xf8, 0x55, 0x26, 0xc9, 0xd7, 0xd0, 0x0c, 0x31, 0xf4, 0x80,
+	0xa7, 0x27, 0x02, 0xe3, 0x6d, 0x9e, 0x8d, 0x09, 0x27, 0x6b, 0x96, 0xa0, 0x0f, 0xe1, 0x2c, 0xd9,
+	0x9b, 0x50, 0x15, 0x59, 0x99, 0xca, 0xd5, 0x25, 0x66, 0xcf, 0x32, 0x4c, 0xa3, 0x2a, 0x32, 0xf2,
+	0x39, 0xd4, 0xc7, 0x7a, 0xd8, 0x30, 0xee, 0xe6, 0x9d, 0xf5, 0x25, 0xec, 0xf9, 0x99, 0xf4, 0x0d,
+	0xdb, 0xfb, 0xad, 0x0a, 0xab, 0xbb, 0xfc, 0xed, 0x46, 0xfd, 0x09, 0xac, 0xc6, 0xe2, 0x27, 0x26,
+	0x03, 0x9e, 0x86, 0x71, 0x91, 0xf3, 0xb1, 0xe9, 0x86, 0xe3, 0xb7, 0x11, 0xee, 0x9f, 0xa2, 0x9a,
+	0x58, 0x64, 0xd9, 0x02, 0xd1, 0x54, 0xbd, 0x8d, 0xf0, 0x8c, 0xf8, 0x08, 0x9a, 0xc6, 0xa3, 0x49,
+	0xb1, 0x76, 0xb9, 0x14, 0x01, 0x6d, 0x50, 0xd6, 0x1e, 0xcc, 0x55, 0xc6, 0x43, 0xfd, 0x92, 0x1e,
+	0xd0, 0x06, 0x65, 0xef, 0x2f, 0x0b, 0x9a, 0x7b, 0x22, 0xc9, 0xa8, 0x34, 0x55, 0x7a, 0x02, 0x9d,
+	0x98, 0x9d, 0xa8, 0xe0, 0x7f, 0x97, 0xaa, 0xad, 0xcd, 0x66, 0x67, 0xd2, 0x87, 0x35, 0xc9, 0x87,
+	0xa3, 0x45, 0x4f, 0xd5, 0xcb, 0x78, 0x5a, 0x45, 0xbb, 0xbd, 0xb3, 0xf3, 0x62, 0x5f, 0x62, 0x5e,
+	0xbc, 0x9f, 0x2d, 0x70, 0x8e, 0x98, 0x4c, 0xde, 0x4a, 0xc7, 0x1f, 0x40, 0x03, 0xeb, 0x9a, 0x77,
+	0xab, 0x1b, 0xf6, 0x65, 0x0a, 0x5b, 0xd2, 0xf5, 0xaa, 0x74, 0xf1, 0x9b, 0xc1, 0x30, 0xee, 0x61,
+	0xf8, 0x16, 0x86, 0x7f, 0x63, 0x89, 0x8b, 0x29, 0xd3, 0x48, 0xcf, 0x32, 0x9c, 0xfc, 0xdb, 0x50,
+	0x0f, 0x47, 0x3c, 0x8e, 0xca, 0x9a, 0xbd, 0xbb, 0xc4, 0x50, 0xdb, 0xf8, 0x86, 0xe5, 0xad, 0xc3,
+	0x4a, 0x69, 0x4d, 0x9a, 0xb0, 0xd2, 0x4f, 0xc7, 0x34, 0xe6, 0x51, 0xa7, 0x42, 0x56, 0xc0, 0x3e,
+	0x10, 0xaa, 0x63, 0x79, 0x7f, 0x5b, 0x00, 0xe6, 0x93, 0xc0, 0xa0, 0xee, 0xcf, 0x05, 0xf5, 0xf1,
+	0x12, 0xdf, 0x33, 0x6a, 0x29, 0x96, 0x61, 0xdd, 0x82, 0x9a, 0x6e, 0xf4, 0x9b, 0xa2, 0x42, 0x92,
+	0xce, 0x01, 0x7b, 0xd9, 0xb5, 0x2f, 0x66, 0x1b, 0x96, 0x77, 0x1f, 0x9c, 0x5d, 0xbe, 0x2c, 0x89,
+	0x36, 0xc0, 0x53, 0x31, 0xe4, 0x21, 0x8d, 0x77, 0xd2, 0xa8, 0x63, 0x91, 0x2b, 0xe0, 0x96, 0xe7,
+	0x67, 0xb2, 0x53, 0xf5, 0x7e, 0xb7, 0xa1, 0x86, 0x49, 0x3d, 0x04, 0x57, 0x31, 0x99, 0x04, 0xec,
+	0x55, 0x26, 0xcb, 0x76, 0x5f, 0x5b, 0x72, 0xe7, 0xe9, 0x80, 0xe8, 0x27, 0x47, 0x95, 0x32, 0xf9,
+	0x0a, 0xa0, 0xd0, 0x77, 0x1b, 0x63, 0x93, 0xde, 0xfb, 0x17, 0x75, 0x4b, 0x3f, 0x48, 0xc5, 0xb4,
+	0x9e, 0x8f, 0xa0, 0x39, 0xe0, 0x33, 0x7b, 0xfb, 0xdc, 0x59, 0x9b, 0x15, 0xb6, 0x57, 0xf1, 0x61,
+	0x30, 0xeb, 0xc8, 0x1e, 0xb4, 0x42, 0xf3, 0x21, 0x1a, 0x17, 0x66, 0x1d, 0x7c, 0xb0, 0x74, 0x5c,
+	0xa7, 0xdf, 0x6b, 0xaf, 0xe2, 0x37, 0xc3, 0xd9, 0x91, 0x7c, 0x07, 0x1d, 0x93, 0x85, 0xd4, 0x7b,
+	0xcf, 0x38, 0x32, 0x5b, 0xe1, 0xc3, 0xf3, 0x72, 0x99, 0x6e, 0xc8, 0x5e, 0xc5, 0x6f, 0x17, 0x0b,
+	0x08, 0x39, 0x84, 0xb5, 0x01, 0x3f, 0xeb, 0xaf, 0x81, 0xfe, 0xbc, 0x73, 0x73, 0x9b, 0x77, 0xb8,
+	0x3a, 0x58, 0x84, 0x76, 0x1b, 0x50, 0xd3, 0x4e, 0xbc, 0x7f, 0x2d, 0x80, 0x63, 0x16, 0x2a, 0x21,
+	0x77, 0x0e, 0x0e, 0x9e, 0x97, 0x4f, 0x90, 0x21, 0x77, 0xad, 0xd3, 0x27, 0xc8, 0xf8, 0x5b, 0x78,
+	0x1c, 0xab, 0x8b, 0x8f, 0xe3, 0x03, 0x80, 0x4c, 0xb2, 0x88, 0x87, 0x54, 0xb1, 0xfc, 0x4d, 0x63,
+	0x36, 0x47, 0x25, 0x5f, 0x02, 0xbc, 0xd4, 0x3f, 0x0e, 0x66, 0x35, 0xd4, 0xce, 0x6d, 0xf7, 0xf4,
+	0xef, 0xc2, 0x77, 0x5f, 0x9e, 0x8a, 0x7a, 0xc3, 0x67, 0x31, 0x0d, 0xd9, 0x48, 0xc4, 0x11, 0x93,
+	0x81, 0xa2, 0x43, 0x2c, 0xb2, 0xeb, 0xb7, 0xe7, 0xe0, 0x23, 0x3a, 0xf4, 0xfe, 0xa9, 0x82, 0x73,
+	0x18, 0xd3, 0xf4, 0x40, 0x44, 0xb8, 0xac, 0xc7, 0x98, 0x71, 0x40, 0xd3, 0x34, 0xbf, 0x60, 0x1d,
+	0xcd, 0xea, 0xa2, 0x47, 0xc4, 0xd8, 0xec, 0xa4, 0x69, 0x4e, 0xbe, 0x58, 0xc8, 0xf6, 0xe2, 0x4f,
+	0x50, 0x9b, 0xce, 0xe5, 0xbb, 0x09, 0xe5, 0x3f, 0x48, 0x70, 0x5a, 0x4a, 0x5d, 0x2e, 0x7b, 0xd3,
+	0xf6, 0xdb, 0x06, 0xff, 0xc6, 0x54, 0x34, 0xd7, 0x7f, 0x4c, 0x39, 0x4d, 0xb2, 0x98, 0x05, 0x39,
+	0x7f, 0x6d, 0x5e, 0x25, 0xdb, 0x07, 0x03, 0x3d, 0xe7, 0xaf, 0xd9, 0x3c, 0x81, 0xb1, 0xa8, 0x5b,
+	0x5f, 0x20, 0x30, 0x16, 0x91, 0x77, 0xa0, 0x1e, 0xf3, 0x84, 0x2b, 0x9c, 0x14, 0xdb, 0x37, 0x07,
+	0x72, 0x13, 0xd6, 0x84, 0xd4, 0xe5, 0x1a, 0x4c, 0xa6, 0x31, 0x74, 0x57, 0x90, 0xd1, 0x46, 0xc5,
+	0xee, 0xa4, 0x8c, 0x81, 0x5c, 0x07, 0x30, 0xd4, 0x88, 0xe5, 0x61, 0xd7, 0xc1, 0x71, 0x70, 0x11,
+	0xd9, 0x67, 0x79, 0xa8, 0x67, 0x28, 0x15, 0x11, 0xfb, 0x34, 0x85, 0x86, 0x59, 0xfd, 0x8b, 0xdb,
+	0x62, 0x15, 0x9a, 0x4f, 0x24, 0xa3, 0x8a, 0xc9, 0xa3, 0x11, 0x4d, 0x3b, 0x16, 0xe9, 0x40, 0xab,
+	0x04, 0x1e, 0xbf, 0x2c, 0x68, 0xdc, 0xa9, 0x92, 0x16, 0x38, 0x4f, 0x59, 0x9e, 0xa3, 0xde, 0xc6,
+	0x75, 0xc2, 0xf2, 0xdc, 0x28, 0x6b, 0xc4, 0x85, 0xba, 0x11, 0xeb, 0x9a, 0x77, 0x20, 0x94, 0x39,
+	0x35, 0x76, 0xef, 0xfe, 0xf8, 0xd9, 0x90, 0xab, 0x51, 0x31, 0xd8, 0x0a, 0x45, 0xb2, 0x6d, 0xca,
+	0x7e, 0x9b, 0x8b, 0x52, 0xda, 0xe6, 0xa9, 0x62, 0x32, 0xa5, 0xf1, 0x36, 0x76, 0x62, 0x5b, 0x77,
+	0x22, 0x1b, 0x0c, 0x1a, 0x78, 0xba, 0xfb, 0xdf, 0x00, 0x22, 0x99, 0x84, 0xde, 0x6c, 0x0b, 0x00,
+	0x00,
 }
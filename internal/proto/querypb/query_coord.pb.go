@@ -132,6 +132,64 @@ func (LoadType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_aab7cc9a69ed26e8, []int{2}
 }
 
+// the built-in transformations of the field data of sealed segments applied on load
+type FieldTransformType int32
+
+const (
+	FieldTransformType_NoTransform       FieldTransformType = 0
+	FieldTransformType_Lowercase         FieldTransformType = 1
+	FieldTransformType_Trim              FieldTransformType = 2
+	FieldTransformType_TruncatePrecision FieldTransformType = 3
+)
+
+var FieldTransformType_name = map[int32]string{
+	0: "NoTransform",
+	1: "Lowercase",
+	2: "Trim",
+	3: "TruncatePrecision",
+}
+
+var FieldTransformType_value = map[string]int32{
+	"NoTransform":       0,
+	"Lowercase":         1,
+	"Trim":              2,
+	"TruncatePrecision": 3,
+}
+
+func (x FieldTransformType) String() string {
+	return proto.EnumName(FieldTransformType_name, int32(x))
+}
+
+func (FieldTransformType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{3}
+}
+
+// the detail level of the segment infos returned by GetSegmentInfo
+type SegmentInfoDetail int32
+
+const (
+	SegmentInfoDetail_FullDetail    SegmentInfoDetail = 0
+	SegmentInfoDetail_SummaryDetail SegmentInfoDetail = 1
+)
+
+var SegmentInfoDetail_name = map[int32]string{
+	0: "FullDetail",
+	1: "SummaryDetail",
+}
+
+var SegmentInfoDetail_value = map[string]int32{
+	"FullDetail":    0,
+	"SummaryDetail": 1,
+}
+
+func (x SegmentInfoDetail) String() string {
+	return proto.EnumName(SegmentInfoDetail_name, int32(x))
+}
+
+func (SegmentInfoDetail) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{4}
+}
+
 //--------------------QueryCoord grpc request and response proto------------------
 type ShowCollectionsRequest struct {
 	Base *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
@@ -190,12 +248,13 @@ func (m *ShowCollectionsRequest) GetCollectionIDs() []int64 {
 }
 
 type ShowCollectionsResponse struct {
-	Status               *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	CollectionIDs        []int64          `protobuf:"varint,2,rep,packed,name=collectionIDs,proto3" json:"collectionIDs,omitempty"`
-	InMemoryPercentages  []int64          `protobuf:"varint,3,rep,packed,name=inMemory_percentages,json=inMemoryPercentages,proto3" json:"inMemory_percentages,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Status               *commonpb.Status      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	CollectionIDs        []int64               `protobuf:"varint,2,rep,packed,name=collectionIDs,proto3" json:"collectionIDs,omitempty"`
+	InMemoryPercentages  []int64               `protobuf:"varint,3,rep,packed,name=inMemory_percentages,json=inMemoryPercentages,proto3" json:"inMemory_percentages,omitempty"`
+	LoadedFields         []*schemapb.LongArray `protobuf:"bytes,4,rep,name=loaded_fields,json=loadedFields,proto3" json:"loaded_fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *ShowCollectionsResponse) Reset()         { *m = ShowCollectionsResponse{} }
@@ -244,6 +303,13 @@ func (m *ShowCollectionsResponse) GetInMemoryPercentages() []int64 {
 	return nil
 }
 
+func (m *ShowCollectionsResponse) GetLoadedFields() []*schemapb.LongArray {
+	if m != nil {
+		return m.LoadedFields
+	}
+	return nil
+}
+
 type ShowPartitionsRequest struct {
 	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	DbID                 int64             `protobuf:"varint,2,opt,name=dbID,proto3" json:"dbID,omitempty"`
@@ -862,6 +928,8 @@ type GetSegmentInfoRequest struct {
 	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	SegmentIDs           []int64           `protobuf:"varint,2,rep,packed,name=segmentIDs,proto3" json:"segmentIDs,omitempty"`
 	CollectionID         int64             `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	Detail               SegmentInfoDetail `protobuf:"varint,4,opt,name=detail,proto3,enum=milvus.proto.query.SegmentInfoDetail" json:"detail,omitempty"`
+	CollectionIDs        []int64           `protobuf:"varint,5,rep,packed,name=collectionIDs,proto3" json:"collectionIDs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -913,6 +981,20 @@ func (m *GetSegmentInfoRequest) GetCollectionID() int64 {
 	return 0
 }
 
+func (m *GetSegmentInfoRequest) GetDetail() SegmentInfoDetail {
+	if m != nil {
+		return m.Detail
+	}
+	return SegmentInfoDetail_FullDetail
+}
+
+func (m *GetSegmentInfoRequest) GetCollectionIDs() []int64 {
+	if m != nil {
+		return m.CollectionIDs
+	}
+	return nil
+}
+
 type GetSegmentInfoResponse struct {
 	Status               *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	Infos                []*SegmentInfo   `protobuf:"bytes,2,rep,name=infos,proto3" json:"infos,omitempty"`
@@ -1333,6 +1415,12 @@ type WatchDmChannelsRequest struct {
 	ExcludeInfos         []*datapb.SegmentInfo      `protobuf:"bytes,7,rep,name=exclude_infos,json=excludeInfos,proto3" json:"exclude_infos,omitempty"`
 	LoadMeta             *LoadMetaInfo              `protobuf:"bytes,8,opt,name=load_meta,json=loadMeta,proto3" json:"load_meta,omitempty"`
 	ReplicaID            int64                      `protobuf:"varint,9,opt,name=replicaID,proto3" json:"replicaID,omitempty"`
+	SegmentRowBudget     int64                      `protobuf:"varint,10,opt,name=segment_row_budget,json=segmentRowBudget,proto3" json:"segment_row_budget,omitempty"`
+	CollectionTtlSeconds int64                      `protobuf:"varint,11,opt,name=collection_ttl_seconds,json=collectionTtlSeconds,proto3" json:"collection_ttl_seconds,omitempty"`
+	GrowingChunkRows     int64                      `protobuf:"varint,12,opt,name=growing_chunk_rows,json=growingChunkRows,proto3" json:"growing_chunk_rows,omitempty"`
+	Version              int64                      `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
+	AllOrNothing         bool                       `protobuf:"varint,14,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	MaxConcurrentReads   int64                      `protobuf:"varint,15,opt,name=max_concurrent_reads,json=maxConcurrentReads,proto3" json:"max_concurrent_reads,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
 	XXX_unrecognized     []byte                     `json:"-"`
 	XXX_sizecache        int32                      `json:"-"`
@@ -1426,6 +1514,48 @@ func (m *WatchDmChannelsRequest) GetReplicaID() int64 {
 	return 0
 }
 
+func (m *WatchDmChannelsRequest) GetSegmentRowBudget() int64 {
+	if m != nil {
+		return m.SegmentRowBudget
+	}
+	return 0
+}
+
+func (m *WatchDmChannelsRequest) GetCollectionTtlSeconds() int64 {
+	if m != nil {
+		return m.CollectionTtlSeconds
+	}
+	return 0
+}
+
+func (m *WatchDmChannelsRequest) GetGrowingChunkRows() int64 {
+	if m != nil {
+		return m.GrowingChunkRows
+	}
+	return 0
+}
+
+func (m *WatchDmChannelsRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *WatchDmChannelsRequest) GetAllOrNothing() bool {
+	if m != nil {
+		return m.AllOrNothing
+	}
+	return false
+}
+
+func (m *WatchDmChannelsRequest) GetMaxConcurrentReads() int64 {
+	if m != nil {
+		return m.MaxConcurrentReads
+	}
+	return 0
+}
+
 type WatchDeltaChannelsRequest struct {
 	Base                 *commonpb.MsgBase      `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	NodeID               int64                  `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
@@ -1511,6 +1641,9 @@ type SegmentLoadInfo struct {
 	IndexInfos           []*FieldIndexInfo     `protobuf:"bytes,11,rep,name=index_infos,json=indexInfos,proto3" json:"index_infos,omitempty"`
 	SegmentSize          int64                 `protobuf:"varint,12,opt,name=segment_size,json=segmentSize,proto3" json:"segment_size,omitempty"`
 	InsertChannel        string                `protobuf:"bytes,13,opt,name=insert_channel,json=insertChannel,proto3" json:"insert_channel,omitempty"`
+	Version              int64                 `protobuf:"varint,14,opt,name=version,proto3" json:"version,omitempty"`
+	DeleteSnapshot       []byte                `protobuf:"bytes,15,opt,name=delete_snapshot,json=deleteSnapshot,proto3" json:"delete_snapshot,omitempty"`
+	BloomFilterPath      string                `protobuf:"bytes,16,opt,name=bloom_filter_path,json=bloomFilterPath,proto3" json:"bloom_filter_path,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -1632,6 +1765,27 @@ func (m *SegmentLoadInfo) GetInsertChannel() string {
 	return ""
 }
 
+func (m *SegmentLoadInfo) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *SegmentLoadInfo) GetDeleteSnapshot() []byte {
+	if m != nil {
+		return m.DeleteSnapshot
+	}
+	return nil
+}
+
+func (m *SegmentLoadInfo) GetBloomFilterPath() string {
+	if m != nil {
+		return m.BloomFilterPath
+	}
+	return ""
+}
+
 type FieldIndexInfo struct {
 	FieldID              int64                    `protobuf:"varint,1,opt,name=fieldID,proto3" json:"fieldID,omitempty"`
 	EnableIndex          bool                     `protobuf:"varint,2,opt,name=enable_index,json=enableIndex,proto3" json:"enable_index,omitempty"`
@@ -1736,6 +1890,11 @@ type LoadSegmentsRequest struct {
 	CollectionID         int64                      `protobuf:"varint,6,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
 	LoadMeta             *LoadMetaInfo              `protobuf:"bytes,7,opt,name=load_meta,json=loadMeta,proto3" json:"load_meta,omitempty"`
 	ReplicaID            int64                      `protobuf:"varint,8,opt,name=replicaID,proto3" json:"replicaID,omitempty"`
+	SyncIndexLoading     bool                       `protobuf:"varint,9,opt,name=sync_index_loading,json=syncIndexLoading,proto3" json:"sync_index_loading,omitempty"`
+	DeferServing         bool                       `protobuf:"varint,10,opt,name=defer_serving,json=deferServing,proto3" json:"defer_serving,omitempty"`
+	Standby              bool                       `protobuf:"varint,11,opt,name=standby,proto3" json:"standby,omitempty"`
+	CollectionTtlSeconds int64                      `protobuf:"varint,12,opt,name=collection_ttl_seconds,json=collectionTtlSeconds,proto3" json:"collection_ttl_seconds,omitempty"`
+	FieldTransforms      []*FieldTransform          `protobuf:"bytes,13,rep,name=field_transforms,json=fieldTransforms,proto3" json:"field_transforms,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
 	XXX_unrecognized     []byte                     `json:"-"`
 	XXX_sizecache        int32                      `json:"-"`
@@ -1822,6 +1981,41 @@ func (m *LoadSegmentsRequest) GetReplicaID() int64 {
 	return 0
 }
 
+func (m *LoadSegmentsRequest) GetSyncIndexLoading() bool {
+	if m != nil {
+		return m.SyncIndexLoading
+	}
+	return false
+}
+
+func (m *LoadSegmentsRequest) GetDeferServing() bool {
+	if m != nil {
+		return m.DeferServing
+	}
+	return false
+}
+
+func (m *LoadSegmentsRequest) GetStandby() bool {
+	if m != nil {
+		return m.Standby
+	}
+	return false
+}
+
+func (m *LoadSegmentsRequest) GetCollectionTtlSeconds() int64 {
+	if m != nil {
+		return m.CollectionTtlSeconds
+	}
+	return 0
+}
+
+func (m *LoadSegmentsRequest) GetFieldTransforms() []*FieldTransform {
+	if m != nil {
+		return m.FieldTransforms
+	}
+	return nil
+}
+
 type ReleaseSegmentsRequest struct {
 	Base   *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	NodeID int64             `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
@@ -2329,24 +2523,32 @@ func (m *PartitionStates) GetInMemoryPercentage() int64 {
 }
 
 type SegmentInfo struct {
-	SegmentID            int64                 `protobuf:"varint,1,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
-	CollectionID         int64                 `protobuf:"varint,2,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
-	PartitionID          int64                 `protobuf:"varint,3,opt,name=partitionID,proto3" json:"partitionID,omitempty"`
-	NodeID               int64                 `protobuf:"varint,4,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
-	MemSize              int64                 `protobuf:"varint,5,opt,name=mem_size,json=memSize,proto3" json:"mem_size,omitempty"`
-	NumRows              int64                 `protobuf:"varint,6,opt,name=num_rows,json=numRows,proto3" json:"num_rows,omitempty"`
-	IndexName            string                `protobuf:"bytes,7,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
-	IndexID              int64                 `protobuf:"varint,8,opt,name=indexID,proto3" json:"indexID,omitempty"`
-	DmChannel            string                `protobuf:"bytes,9,opt,name=dmChannel,proto3" json:"dmChannel,omitempty"`
-	CompactionFrom       []int64               `protobuf:"varint,10,rep,packed,name=compactionFrom,proto3" json:"compactionFrom,omitempty"`
-	CreatedByCompaction  bool                  `protobuf:"varint,11,opt,name=createdByCompaction,proto3" json:"createdByCompaction,omitempty"`
-	SegmentState         commonpb.SegmentState `protobuf:"varint,12,opt,name=segment_state,json=segmentState,proto3,enum=milvus.proto.common.SegmentState" json:"segment_state,omitempty"`
-	IndexInfos           []*FieldIndexInfo     `protobuf:"bytes,13,rep,name=index_infos,json=indexInfos,proto3" json:"index_infos,omitempty"`
-	ReplicaIds           []int64               `protobuf:"varint,14,rep,packed,name=replica_ids,json=replicaIds,proto3" json:"replica_ids,omitempty"`
-	NodeIds              []int64               `protobuf:"varint,15,rep,packed,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	SegmentID            int64                    `protobuf:"varint,1,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
+	CollectionID         int64                    `protobuf:"varint,2,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	PartitionID          int64                    `protobuf:"varint,3,opt,name=partitionID,proto3" json:"partitionID,omitempty"`
+	NodeID               int64                    `protobuf:"varint,4,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	MemSize              int64                    `protobuf:"varint,5,opt,name=mem_size,json=memSize,proto3" json:"mem_size,omitempty"`
+	NumRows              int64                    `protobuf:"varint,6,opt,name=num_rows,json=numRows,proto3" json:"num_rows,omitempty"`
+	IndexName            string                   `protobuf:"bytes,7,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	IndexID              int64                    `protobuf:"varint,8,opt,name=indexID,proto3" json:"indexID,omitempty"`
+	DmChannel            string                   `protobuf:"bytes,9,opt,name=dmChannel,proto3" json:"dmChannel,omitempty"`
+	CompactionFrom       []int64                  `protobuf:"varint,10,rep,packed,name=compactionFrom,proto3" json:"compactionFrom,omitempty"`
+	CreatedByCompaction  bool                     `protobuf:"varint,11,opt,name=createdByCompaction,proto3" json:"createdByCompaction,omitempty"`
+	SegmentState         commonpb.SegmentState    `protobuf:"varint,12,opt,name=segment_state,json=segmentState,proto3,enum=milvus.proto.common.SegmentState" json:"segment_state,omitempty"`
+	IndexInfos           []*FieldIndexInfo        `protobuf:"bytes,13,rep,name=index_infos,json=indexInfos,proto3" json:"index_infos,omitempty"`
+	ReplicaIds           []int64                  `protobuf:"varint,14,rep,packed,name=replica_ids,json=replicaIds,proto3" json:"replica_ids,omitempty"`
+	NodeIds              []int64                  `protobuf:"varint,15,rep,packed,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"`
+	Version              int64                    `protobuf:"varint,16,opt,name=version,proto3" json:"version,omitempty"`
+	IndexPending         bool                     `protobuf:"varint,17,opt,name=index_pending,json=indexPending,proto3" json:"index_pending,omitempty"`
+	BloomFilterStats     *SegmentBloomFilterStats `protobuf:"bytes,18,opt,name=bloom_filter_stats,json=bloomFilterStats,proto3" json:"bloom_filter_stats,omitempty"`
+	LoadStats            *SegmentLoadStats        `protobuf:"bytes,19,opt,name=load_stats,json=loadStats,proto3" json:"load_stats,omitempty"`
+	Quarantined          bool                     `protobuf:"varint,20,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+	FieldTransforms      []*FieldTransform        `protobuf:"bytes,21,rep,name=field_transforms,json=fieldTransforms,proto3" json:"field_transforms,omitempty"`
+	DiskUsage            int64                    `protobuf:"varint,22,opt,name=disk_usage,json=diskUsage,proto3" json:"disk_usage,omitempty"`
+	LastDeleteTimestamp  uint64                   `protobuf:"varint,23,opt,name=last_delete_timestamp,json=lastDeleteTimestamp,proto3" json:"last_delete_timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
 func (m *SegmentInfo) Reset()         { *m = SegmentInfo{} }
@@ -2479,6 +2681,62 @@ func (m *SegmentInfo) GetNodeIds() []int64 {
 	return nil
 }
 
+func (m *SegmentInfo) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *SegmentInfo) GetIndexPending() bool {
+	if m != nil {
+		return m.IndexPending
+	}
+	return false
+}
+
+func (m *SegmentInfo) GetBloomFilterStats() *SegmentBloomFilterStats {
+	if m != nil {
+		return m.BloomFilterStats
+	}
+	return nil
+}
+
+func (m *SegmentInfo) GetLoadStats() *SegmentLoadStats {
+	if m != nil {
+		return m.LoadStats
+	}
+	return nil
+}
+
+func (m *SegmentInfo) GetQuarantined() bool {
+	if m != nil {
+		return m.Quarantined
+	}
+	return false
+}
+
+func (m *SegmentInfo) GetFieldTransforms() []*FieldTransform {
+	if m != nil {
+		return m.FieldTransforms
+	}
+	return nil
+}
+
+func (m *SegmentInfo) GetDiskUsage() int64 {
+	if m != nil {
+		return m.DiskUsage
+	}
+	return 0
+}
+
+func (m *SegmentInfo) GetLastDeleteTimestamp() uint64 {
+	if m != nil {
+		return m.LastDeleteTimestamp
+	}
+	return 0
+}
+
 type CollectionInfo struct {
 	CollectionID         int64                      `protobuf:"varint,1,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
 	PartitionIDs         []int64                    `protobuf:"varint,2,rep,packed,name=partitionIDs,proto3" json:"partitionIDs,omitempty"`
@@ -2787,272 +3045,1825 @@ func (m *SealedSegmentsChangeInfo) GetInfos() []*SegmentChangeInfo {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("milvus.proto.query.PartitionState", PartitionState_name, PartitionState_value)
-	proto.RegisterEnum("milvus.proto.query.TriggerCondition", TriggerCondition_name, TriggerCondition_value)
-	proto.RegisterEnum("milvus.proto.query.LoadType", LoadType_name, LoadType_value)
-	proto.RegisterType((*ShowCollectionsRequest)(nil), "milvus.proto.query.ShowCollectionsRequest")
-	proto.RegisterType((*ShowCollectionsResponse)(nil), "milvus.proto.query.ShowCollectionsResponse")
-	proto.RegisterType((*ShowPartitionsRequest)(nil), "milvus.proto.query.ShowPartitionsRequest")
-	proto.RegisterType((*ShowPartitionsResponse)(nil), "milvus.proto.query.ShowPartitionsResponse")
-	proto.RegisterType((*LoadCollectionRequest)(nil), "milvus.proto.query.LoadCollectionRequest")
-	proto.RegisterType((*ReleaseCollectionRequest)(nil), "milvus.proto.query.ReleaseCollectionRequest")
-	proto.RegisterType((*LoadPartitionsRequest)(nil), "milvus.proto.query.LoadPartitionsRequest")
-	proto.RegisterType((*ReleasePartitionsRequest)(nil), "milvus.proto.query.ReleasePartitionsRequest")
-	proto.RegisterType((*CreateQueryChannelRequest)(nil), "milvus.proto.query.CreateQueryChannelRequest")
-	proto.RegisterType((*CreateQueryChannelResponse)(nil), "milvus.proto.query.CreateQueryChannelResponse")
-	proto.RegisterType((*GetPartitionStatesRequest)(nil), "milvus.proto.query.GetPartitionStatesRequest")
-	proto.RegisterType((*GetPartitionStatesResponse)(nil), "milvus.proto.query.GetPartitionStatesResponse")
-	proto.RegisterType((*GetSegmentInfoRequest)(nil), "milvus.proto.query.GetSegmentInfoRequest")
-	proto.RegisterType((*GetSegmentInfoResponse)(nil), "milvus.proto.query.GetSegmentInfoResponse")
-	proto.RegisterType((*GetShardLeadersRequest)(nil), "milvus.proto.query.GetShardLeadersRequest")
-	proto.RegisterType((*GetShardLeadersResponse)(nil), "milvus.proto.query.GetShardLeadersResponse")
-	proto.RegisterType((*ShardLeadersList)(nil), "milvus.proto.query.ShardLeadersList")
-	proto.RegisterType((*AddQueryChannelRequest)(nil), "milvus.proto.query.AddQueryChannelRequest")
-	proto.RegisterType((*RemoveQueryChannelRequest)(nil), "milvus.proto.query.RemoveQueryChannelRequest")
-	proto.RegisterType((*LoadMetaInfo)(nil), "milvus.proto.query.LoadMetaInfo")
-	proto.RegisterType((*WatchDmChannelsRequest)(nil), "milvus.proto.query.WatchDmChannelsRequest")
-	proto.RegisterType((*WatchDeltaChannelsRequest)(nil), "milvus.proto.query.WatchDeltaChannelsRequest")
-	proto.RegisterType((*SegmentLoadInfo)(nil), "milvus.proto.query.SegmentLoadInfo")
-	proto.RegisterType((*FieldIndexInfo)(nil), "milvus.proto.query.FieldIndexInfo")
-	proto.RegisterType((*LoadSegmentsRequest)(nil), "milvus.proto.query.LoadSegmentsRequest")
-	proto.RegisterType((*ReleaseSegmentsRequest)(nil), "milvus.proto.query.ReleaseSegmentsRequest")
-	proto.RegisterType((*SearchRequest)(nil), "milvus.proto.query.SearchRequest")
-	proto.RegisterType((*QueryRequest)(nil), "milvus.proto.query.QueryRequest")
-	proto.RegisterType((*HandoffSegmentsRequest)(nil), "milvus.proto.query.HandoffSegmentsRequest")
-	proto.RegisterType((*LoadBalanceRequest)(nil), "milvus.proto.query.LoadBalanceRequest")
-	proto.RegisterType((*DmChannelWatchInfo)(nil), "milvus.proto.query.DmChannelWatchInfo")
-	proto.RegisterType((*QueryChannelInfo)(nil), "milvus.proto.query.QueryChannelInfo")
-	proto.RegisterType((*PartitionStates)(nil), "milvus.proto.query.PartitionStates")
-	proto.RegisterType((*SegmentInfo)(nil), "milvus.proto.query.SegmentInfo")
-	proto.RegisterType((*CollectionInfo)(nil), "milvus.proto.query.CollectionInfo")
-	proto.RegisterType((*UnsubscribeChannels)(nil), "milvus.proto.query.UnsubscribeChannels")
-	proto.RegisterType((*UnsubscribeChannelInfo)(nil), "milvus.proto.query.UnsubscribeChannelInfo")
-	proto.RegisterType((*SegmentChangeInfo)(nil), "milvus.proto.query.SegmentChangeInfo")
-	proto.RegisterType((*SealedSegmentsChangeInfo)(nil), "milvus.proto.query.SealedSegmentsChangeInfo")
+//---- segment serving distribution proto between QueryCoord and QueryNode -----
+type SyncDistributionRequest struct {
+	Base         *commonpb.MsgBase       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	NodeID       int64                   `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	CollectionID int64                   `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	Actions      []*SegmentServingAction `protobuf:"bytes,4,rep,name=actions,proto3" json:"actions,omitempty"`
+	// actions older than the latest applied version of a segment are ignored
+	Version              int64    `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("query_coord.proto", fileDescriptor_aab7cc9a69ed26e8) }
-
-var fileDescriptor_aab7cc9a69ed26e8 = []byte{
-	// 2755 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x5b, 0x6f, 0x1c, 0x49,
-	0x15, 0x76, 0xcf, 0xcd, 0x33, 0x67, 0x2e, 0x9e, 0x94, 0x13, 0xef, 0x78, 0xc8, 0xc5, 0xe9, 0xdc,
-	0x4c, 0x96, 0x75, 0x82, 0x03, 0x68, 0x57, 0xc0, 0x43, 0x6c, 0x13, 0xaf, 0x49, 0xe2, 0xf5, 0xb6,
-	0x9d, 0x00, 0x51, 0xa4, 0xa1, 0x67, 0xba, 0x3c, 0x6e, 0xa5, 0x2f, 0x93, 0xae, 0x9e, 0x24, 0xce,
-	0x33, 0x42, 0x2c, 0x17, 0x21, 0x9e, 0x10, 0x12, 0xe2, 0x09, 0x04, 0x48, 0xac, 0x78, 0xe6, 0x05,
-	0x21, 0x7e, 0x01, 0x12, 0xef, 0x88, 0x37, 0x7e, 0x01, 0x8f, 0x08, 0x54, 0x97, 0xee, 0xe9, 0x4b,
-	0xb5, 0x67, 0xec, 0xd9, 0x6c, 0x22, 0xb4, 0x6f, 0x5d, 0xa7, 0x4f, 0xd5, 0x39, 0xa7, 0xce, 0xa9,
-	0x53, 0x5f, 0xd5, 0x29, 0x38, 0xf5, 0x74, 0x88, 0xbd, 0xc3, 0x4e, 0xcf, 0x75, 0x3d, 0x63, 0x65,
-	0xe0, 0xb9, 0xbe, 0x8b, 0x90, 0x6d, 0x5a, 0xcf, 0x86, 0x84, 0xb7, 0x56, 0xd8, 0xff, 0x76, 0xad,
-	0xe7, 0xda, 0xb6, 0xeb, 0x70, 0x5a, 0xbb, 0x16, 0xe5, 0x68, 0x37, 0x4c, 0xc7, 0xc7, 0x9e, 0xa3,
-	0x5b, 0xc1, 0x5f, 0xd2, 0x3b, 0xc0, 0xb6, 0x2e, 0x5a, 0x4d, 0x43, 0xf7, 0xf5, 0xe8, 0xf8, 0xea,
-	0xf7, 0x14, 0x58, 0xd8, 0x3d, 0x70, 0x9f, 0xaf, 0xbb, 0x96, 0x85, 0x7b, 0xbe, 0xe9, 0x3a, 0x44,
-	0xc3, 0x4f, 0x87, 0x98, 0xf8, 0xe8, 0x26, 0x14, 0xba, 0x3a, 0xc1, 0x2d, 0x65, 0x49, 0x59, 0xae,
-	0xae, 0x9e, 0x5d, 0x89, 0x69, 0x22, 0x54, 0xb8, 0x4f, 0xfa, 0x6b, 0x3a, 0xc1, 0x1a, 0xe3, 0x44,
-	0x08, 0x0a, 0x46, 0x77, 0x6b, 0xa3, 0x95, 0x5b, 0x52, 0x96, 0xf3, 0x1a, 0xfb, 0x46, 0x97, 0xa1,
-	0xde, 0x0b, 0xc7, 0xde, 0xda, 0x20, 0xad, 0xfc, 0x52, 0x7e, 0x39, 0xaf, 0xc5, 0x89, 0xea, 0x6f,
-	0x15, 0x78, 0x2b, 0xa5, 0x06, 0x19, 0xb8, 0x0e, 0xc1, 0xe8, 0x16, 0x94, 0x88, 0xaf, 0xfb, 0x43,
-	0x22, 0x34, 0xf9, 0x9c, 0x54, 0x93, 0x5d, 0xc6, 0xa2, 0x09, 0xd6, 0xb4, 0xd8, 0x9c, 0x44, 0x2c,
-	0xfa, 0x22, 0x9c, 0x36, 0x9d, 0xfb, 0xd8, 0x76, 0xbd, 0xc3, 0xce, 0x00, 0x7b, 0x3d, 0xec, 0xf8,
-	0x7a, 0x1f, 0x07, 0x3a, 0xce, 0x07, 0xff, 0x76, 0x46, 0xbf, 0xd4, 0xdf, 0x28, 0x70, 0x86, 0x6a,
-	0xba, 0xa3, 0x7b, 0xbe, 0xf9, 0x0a, 0xe6, 0x4b, 0x85, 0x5a, 0x54, 0xc7, 0x56, 0x9e, 0xfd, 0x8b,
-	0xd1, 0x28, 0xcf, 0x20, 0x10, 0x4f, 0x6d, 0x2b, 0x30, 0x75, 0x63, 0x34, 0xf5, 0xd7, 0xc2, 0xb1,
-	0x51, 0x3d, 0xa7, 0x99, 0xd0, 0xa4, 0xcc, 0x5c, 0x5a, 0xe6, 0x49, 0xa6, 0xf3, 0x5f, 0x0a, 0x9c,
-	0xb9, 0xe7, 0xea, 0xc6, 0xc8, 0xf1, 0x9f, 0xfe, 0x74, 0x7e, 0x1d, 0x4a, 0x7c, 0x95, 0xb4, 0x0a,
-	0x4c, 0xd6, 0x95, 0xb8, 0x2c, 0xb1, 0x82, 0x46, 0x1a, 0xee, 0x32, 0x82, 0x26, 0x3a, 0xa1, 0x2b,
-	0xd0, 0xf0, 0xf0, 0xc0, 0x32, 0x7b, 0x7a, 0xc7, 0x19, 0xda, 0x5d, 0xec, 0xb5, 0x8a, 0x4b, 0xca,
-	0x72, 0x51, 0xab, 0x0b, 0xea, 0x36, 0x23, 0xaa, 0xbf, 0x54, 0xa0, 0xa5, 0x61, 0x0b, 0xeb, 0x04,
-	0xbf, 0x4e, 0x63, 0x17, 0xa0, 0xe4, 0xb8, 0x06, 0xde, 0xda, 0x60, 0xc6, 0xe6, 0x35, 0xd1, 0x52,
-	0x7f, 0x94, 0xe3, 0x8e, 0x78, 0xc3, 0xe3, 0x3a, 0xe2, 0xac, 0xe2, 0x27, 0xe3, 0xac, 0x92, 0xcc,
-	0x59, 0x7f, 0x19, 0x39, 0xeb, 0x4d, 0x9f, 0x90, 0x91, 0x43, 0x8b, 0x31, 0x87, 0x7e, 0x07, 0x16,
-	0xd7, 0x3d, 0xac, 0xfb, 0xf8, 0x43, 0xba, 0x69, 0xac, 0x1f, 0xe8, 0x8e, 0x83, 0xad, 0xc0, 0x84,
-	0xa4, 0x70, 0x45, 0x22, 0xbc, 0x05, 0xb3, 0x03, 0xcf, 0x7d, 0x71, 0x18, 0xea, 0x1d, 0x34, 0xd5,
-	0xdf, 0x29, 0xd0, 0x96, 0x8d, 0x3d, 0x4d, 0x7e, 0xb9, 0x04, 0x75, 0xb1, 0xfb, 0xf1, 0xd1, 0x98,
-	0xcc, 0x8a, 0x56, 0x7b, 0x1a, 0x91, 0x80, 0x6e, 0xc2, 0x69, 0xce, 0xe4, 0x61, 0x32, 0xb4, 0xfc,
-	0x90, 0x37, 0xcf, 0x78, 0x11, 0xfb, 0xa7, 0xb1, 0x5f, 0xa2, 0x87, 0xfa, 0x7b, 0x05, 0x16, 0x37,
-	0xb1, 0x1f, 0x3a, 0x91, 0x4a, 0xc5, 0x6f, 0x68, 0xca, 0xfe, 0x58, 0x81, 0xb6, 0x4c, 0xd7, 0x69,
-	0xa6, 0xf5, 0x11, 0x2c, 0x84, 0x32, 0x3a, 0x06, 0x26, 0x3d, 0xcf, 0x1c, 0xb0, 0x60, 0x66, 0x09,
-	0xbc, 0xba, 0x7a, 0x69, 0x25, 0x0d, 0x30, 0x56, 0x92, 0x1a, 0x9c, 0x09, 0x87, 0xd8, 0x88, 0x8c,
-	0xa0, 0xfe, 0x44, 0x81, 0x33, 0x9b, 0xd8, 0xdf, 0xc5, 0x7d, 0x1b, 0x3b, 0xfe, 0x96, 0xb3, 0xef,
-	0x9e, 0x7c, 0x5e, 0xcf, 0x03, 0x10, 0x31, 0x4e, 0xb8, 0xb9, 0x44, 0x28, 0x93, 0xcc, 0x31, 0xc3,
-	0x32, 0x49, 0x7d, 0xa6, 0x99, 0xbb, 0x2f, 0x43, 0xd1, 0x74, 0xf6, 0xdd, 0x60, 0xaa, 0x2e, 0xc8,
-	0xa6, 0x2a, 0x2a, 0x8c, 0x73, 0xab, 0x0e, 0xd7, 0xe2, 0x40, 0xf7, 0x8c, 0x7b, 0x58, 0x37, 0xb0,
-	0x37, 0x45, 0xb8, 0x25, 0xcd, 0xce, 0x49, 0xcc, 0xfe, 0xb1, 0x02, 0x6f, 0xa5, 0x04, 0x4e, 0x63,
-	0xf7, 0xd7, 0xa0, 0x44, 0xe8, 0x60, 0x81, 0xe1, 0x97, 0xa5, 0x86, 0x47, 0xc4, 0xdd, 0x33, 0x89,
-	0xaf, 0x89, 0x3e, 0xaa, 0x0b, 0xcd, 0xe4, 0x3f, 0x74, 0x11, 0x6a, 0x62, 0xa9, 0x76, 0x1c, 0xdd,
-	0xe6, 0x13, 0x50, 0xd1, 0xaa, 0x82, 0xb6, 0xad, 0xdb, 0x18, 0x2d, 0x42, 0x99, 0x26, 0xae, 0x8e,
-	0x69, 0x04, 0xee, 0x9f, 0x65, 0x89, 0xcc, 0x20, 0xe8, 0x1c, 0x00, 0xfb, 0xa5, 0x1b, 0x86, 0xc7,
-	0xc1, 0x44, 0x45, 0xab, 0x50, 0xca, 0x6d, 0x4a, 0x50, 0xff, 0x93, 0x83, 0x85, 0xdb, 0x86, 0x21,
-	0x4b, 0x73, 0xc7, 0x9f, 0xf0, 0x51, 0x36, 0xcd, 0x45, 0xb3, 0xe9, 0x44, 0x6b, 0x3c, 0x95, 0xc2,
-	0x0a, 0xc7, 0x48, 0x61, 0xc5, 0xac, 0x14, 0x86, 0x36, 0xa1, 0x4e, 0x30, 0x7e, 0xd2, 0x19, 0xb8,
-	0x84, 0xad, 0x41, 0xb6, 0x63, 0x55, 0x57, 0xd5, 0xb8, 0x35, 0x21, 0xee, 0xbf, 0x4f, 0xfa, 0x3b,
-	0x82, 0x53, 0xab, 0xd1, 0x8e, 0x41, 0x0b, 0x3d, 0x80, 0x85, 0xbe, 0xe5, 0x76, 0x75, 0xab, 0x43,
-	0xb0, 0x6e, 0x61, 0xa3, 0x23, 0xd6, 0x17, 0x69, 0xcd, 0x4e, 0x16, 0xe0, 0xa7, 0x79, 0xf7, 0x5d,
-	0xd6, 0x5b, 0xfc, 0x20, 0xea, 0x3f, 0x15, 0x58, 0xd4, 0xb0, 0xed, 0x3e, 0xc3, 0xff, 0xaf, 0x2e,
-	0x50, 0x7f, 0xa6, 0x40, 0x8d, 0x82, 0xa3, 0xfb, 0xd8, 0xd7, 0xe9, 0x4c, 0xa0, 0xf7, 0xa0, 0x62,
-	0xb9, 0xba, 0xd1, 0xf1, 0x0f, 0x07, 0xdc, 0xb4, 0x46, 0xd2, 0x34, 0x3e, 0x7b, 0xb4, 0xd3, 0xde,
-	0xe1, 0x00, 0x6b, 0x65, 0x4b, 0x7c, 0x4d, 0xb2, 0xa4, 0x53, 0xbb, 0x45, 0x5e, 0xb2, 0x5b, 0xfc,
-	0x35, 0x0f, 0x0b, 0xdf, 0xd2, 0xfd, 0xde, 0xc1, 0x86, 0x2d, 0xd4, 0x24, 0xaf, 0x67, 0xce, 0x27,
-	0x01, 0x29, 0x61, 0x2a, 0x2d, 0xca, 0x22, 0x8d, 0x9e, 0x4a, 0x57, 0x1e, 0x0a, 0x37, 0x44, 0x52,
-	0x69, 0x04, 0xec, 0x95, 0x4e, 0x02, 0xf6, 0xd6, 0xa1, 0x8e, 0x5f, 0xf4, 0xac, 0x21, 0x4d, 0x2b,
-	0x4c, 0x3a, 0x8f, 0xf3, 0xf3, 0x12, 0xe9, 0xd1, 0x30, 0xaf, 0x89, 0x4e, 0x5b, 0x42, 0x07, 0xee,
-	0x6a, 0x1b, 0xfb, 0x7a, 0xab, 0xcc, 0xd4, 0x58, 0xca, 0x72, 0x75, 0x10, 0x1f, 0xdc, 0xdd, 0xb4,
-	0x85, 0xce, 0x42, 0x45, 0x40, 0xcb, 0xad, 0x8d, 0x56, 0x85, 0x4d, 0xdf, 0x88, 0xa0, 0xfe, 0x57,
-	0x81, 0x45, 0xee, 0x44, 0x6c, 0xf9, 0xfa, 0xeb, 0xf5, 0x63, 0xe8, 0xa3, 0xc2, 0x31, 0x7d, 0x14,
-	0x99, 0x9f, 0xca, 0x71, 0xe7, 0x47, 0xfd, 0x73, 0x01, 0xe6, 0xc4, 0xe4, 0x53, 0x0e, 0xb6, 0xba,
-	0xce, 0x42, 0x25, 0xdc, 0xfa, 0x05, 0x34, 0x1d, 0x11, 0xd0, 0x12, 0x54, 0x23, 0xb1, 0x25, 0x0c,
-	0x8d, 0x92, 0x26, 0xb2, 0x36, 0x00, 0x72, 0x85, 0x08, 0x90, 0x3b, 0x07, 0xb0, 0x6f, 0x0d, 0xc9,
-	0x41, 0xc7, 0x37, 0x6d, 0x2c, 0xe0, 0x74, 0x85, 0x51, 0xf6, 0x4c, 0x1b, 0xa3, 0xdb, 0x50, 0xeb,
-	0x9a, 0x8e, 0xe5, 0xf6, 0x3b, 0x03, 0xdd, 0x3f, 0x20, 0xad, 0x52, 0x66, 0x34, 0xdd, 0x31, 0xb1,
-	0x65, 0xac, 0x31, 0x5e, 0xad, 0xca, 0xfb, 0xec, 0xd0, 0x2e, 0xe8, 0x3c, 0x54, 0x9d, 0xa1, 0xdd,
-	0x71, 0xf7, 0x3b, 0x9e, 0xfb, 0x9c, 0xc6, 0x23, 0x13, 0xe1, 0x0c, 0xed, 0x0f, 0xf6, 0x35, 0xf7,
-	0x39, 0xdd, 0x7a, 0x2b, 0x74, 0x13, 0x26, 0x96, 0xdb, 0x27, 0xad, 0xf2, 0x44, 0xe3, 0x8f, 0x3a,
-	0xd0, 0xde, 0x06, 0x8d, 0x23, 0xd6, 0xbb, 0x32, 0x59, 0xef, 0xb0, 0x03, 0xba, 0x0a, 0x8d, 0x9e,
-	0x6b, 0x0f, 0x74, 0x36, 0x43, 0x77, 0x3c, 0xd7, 0x6e, 0x01, 0x5b, 0xc9, 0x09, 0x2a, 0x5a, 0x87,
-	0xaa, 0xe9, 0x18, 0xf8, 0x85, 0x58, 0x53, 0x55, 0x26, 0x47, 0x95, 0xb9, 0x9c, 0x09, 0xda, 0xa2,
-	0xbc, 0xcc, 0xe9, 0x60, 0x06, 0x9f, 0x84, 0x22, 0x02, 0xe1, 0xd1, 0x0e, 0x31, 0x5f, 0xe2, 0x56,
-	0x8d, 0x7b, 0x51, 0xd0, 0x76, 0xcd, 0x97, 0x98, 0x1e, 0xd5, 0x4c, 0x87, 0x60, 0x6f, 0x94, 0xa0,
-	0xeb, 0x2c, 0x41, 0xd7, 0x39, 0x35, 0xc8, 0xcd, 0x7f, 0xcc, 0x41, 0x23, 0x2e, 0x88, 0x9e, 0x5c,
-	0xf6, 0x19, 0x25, 0x88, 0x9e, 0xa0, 0x49, 0xc5, 0x62, 0x47, 0xef, 0x5a, 0x34, 0x21, 0x18, 0xf8,
-	0x05, 0x0b, 0x9e, 0xb2, 0x56, 0xe5, 0x34, 0x36, 0x00, 0x0d, 0x02, 0x6e, 0x1e, 0x43, 0x2a, 0xfc,
-	0x64, 0x51, 0x61, 0x14, 0x86, 0x53, 0x5a, 0x30, 0xcb, 0xcd, 0x08, 0x42, 0x27, 0x68, 0xd2, 0x3f,
-	0xdd, 0xa1, 0xc9, 0xa4, 0xf2, 0xd0, 0x09, 0x9a, 0x68, 0x03, 0x6a, 0x7c, 0xc8, 0x81, 0xee, 0xe9,
-	0x76, 0x10, 0x38, 0x17, 0xa5, 0xeb, 0xf9, 0x2e, 0x3e, 0x7c, 0xa8, 0x5b, 0x43, 0xbc, 0xa3, 0x9b,
-	0x9e, 0xc6, 0x27, 0x7a, 0x87, 0xf5, 0x42, 0xcb, 0xd0, 0xe4, 0xa3, 0xec, 0x9b, 0x16, 0x16, 0x21,
-	0x38, 0xcb, 0xc0, 0x50, 0x83, 0xd1, 0xef, 0x98, 0x16, 0xe6, 0x51, 0x16, 0x9a, 0xc0, 0xa6, 0xb6,
-	0xcc, 0x83, 0x8c, 0x51, 0xe8, 0xc4, 0xaa, 0xdf, 0xcf, 0xc3, 0x3c, 0x5d, 0x6b, 0xc1, 0x0e, 0x7e,
-	0xf2, 0x74, 0x73, 0x0e, 0xc0, 0x20, 0x7e, 0x27, 0x96, 0x72, 0x2a, 0x06, 0xf1, 0xb7, 0x79, 0xd6,
-	0x79, 0x2f, 0xc8, 0x28, 0xf9, 0xec, 0xb3, 0x46, 0x62, 0xed, 0xa7, 0x33, 0xff, 0x89, 0xee, 0x64,
-	0x2e, 0x41, 0x9d, 0xb8, 0x43, 0xaf, 0x87, 0x3b, 0xb1, 0xb3, 0x71, 0x8d, 0x13, 0xb7, 0xe5, 0x49,
-	0xb1, 0x24, 0xbd, 0x1b, 0x8a, 0x64, 0xb7, 0xd9, 0xe9, 0xb2, 0x7f, 0x39, 0x99, 0xfd, 0xff, 0xa1,
-	0xc0, 0x82, 0xb8, 0x65, 0x98, 0xde, 0x17, 0x59, 0xa9, 0x3f, 0x48, 0x74, 0xf9, 0x23, 0x4e, 0xac,
-	0x85, 0x09, 0xb6, 0xf5, 0xa2, 0x64, 0x5b, 0x8f, 0x9f, 0xda, 0x4a, 0xc9, 0x53, 0x9b, 0xfa, 0x03,
-	0x05, 0xea, 0xbb, 0x58, 0xf7, 0x7a, 0x07, 0x81, 0x5d, 0x5f, 0x81, 0xbc, 0x87, 0x9f, 0x0a, 0xb3,
-	0x2e, 0x67, 0x40, 0xd8, 0x58, 0x17, 0x8d, 0x76, 0x40, 0x17, 0xa0, 0x6a, 0xd8, 0x56, 0xe2, 0x72,
-	0x00, 0x0c, 0xdb, 0x0a, 0x40, 0x5d, 0x5c, 0x95, 0x7c, 0x4a, 0x95, 0x8f, 0x14, 0xa8, 0x7d, 0xc8,
-	0x91, 0x1d, 0xd7, 0xe4, 0xdd, 0xa8, 0x26, 0x57, 0x33, 0x34, 0xd1, 0xb0, 0xef, 0x99, 0xf8, 0x19,
-	0xfe, 0x64, 0x75, 0xf9, 0xa9, 0x02, 0x0b, 0xef, 0xeb, 0x8e, 0xe1, 0xee, 0xef, 0x4f, 0xef, 0xf7,
-	0xf5, 0x30, 0x93, 0x6e, 0x1d, 0xe7, 0xb0, 0x1a, 0xeb, 0xa4, 0xfe, 0x21, 0x07, 0x88, 0x86, 0xf0,
-	0x9a, 0x6e, 0xe9, 0x4e, 0x0f, 0x9f, 0x5c, 0x9b, 0x2b, 0xd0, 0x88, 0x2d, 0xbc, 0xf0, 0xe2, 0x3d,
-	0xba, 0xf2, 0x08, 0xba, 0x0b, 0x8d, 0x2e, 0x17, 0xd5, 0xf1, 0xb0, 0x4e, 0x5c, 0x87, 0x85, 0x67,
-	0x43, 0x7e, 0xd4, 0xdc, 0xf3, 0xcc, 0x7e, 0x1f, 0x7b, 0xeb, 0xae, 0x63, 0xf0, 0x63, 0x4d, 0xbd,
-	0x1b, 0xa8, 0x49, 0xbb, 0x32, 0x7f, 0x84, 0x59, 0x28, 0xc0, 0x9f, 0x10, 0xa6, 0x21, 0x82, 0xde,
-	0x86, 0x53, 0xf1, 0x13, 0xcf, 0x28, 0x9e, 0x9b, 0x24, 0x7a, 0x98, 0x91, 0xdd, 0x34, 0x48, 0xb2,
-	0x82, 0xfa, 0x0b, 0x05, 0x50, 0x08, 0xbb, 0x19, 0x7e, 0x63, 0xfb, 0xce, 0x24, 0xb7, 0x6a, 0x67,
-	0xa1, 0x62, 0x04, 0x3d, 0x45, 0xe8, 0x8c, 0x08, 0x34, 0x6f, 0x71, 0x33, 0x3a, 0x34, 0x85, 0x60,
-	0x23, 0x80, 0x2e, 0x9c, 0x78, 0x8f, 0xd1, 0xe2, 0x49, 0xa5, 0x90, 0x4c, 0x2a, 0x1f, 0xe7, 0xa0,
-	0x19, 0x3d, 0x88, 0x4d, 0xac, 0xd9, 0xab, 0xb9, 0x81, 0x3b, 0xe2, 0xd4, 0x59, 0x98, 0xe2, 0xd4,
-	0x99, 0x3e, 0x15, 0x17, 0x4f, 0x76, 0x2a, 0x56, 0x7f, 0xa5, 0xc0, 0x5c, 0xe2, 0xc2, 0x2b, 0x09,
-	0x31, 0x95, 0x34, 0xc4, 0x7c, 0x17, 0x8a, 0x14, 0x77, 0x61, 0x36, 0x49, 0x0d, 0x39, 0xfc, 0x89,
-	0x8f, 0xaa, 0xf1, 0x0e, 0xe8, 0x06, 0xcc, 0x4b, 0x8a, 0x24, 0xc2, 0xd1, 0x28, 0x5d, 0x23, 0x51,
-	0xff, 0x54, 0x80, 0x6a, 0x64, 0x3e, 0xc6, 0xa0, 0xe3, 0x49, 0x8e, 0x97, 0x09, 0xf3, 0xf2, 0x69,
-	0xf3, 0x32, 0xaa, 0x04, 0x68, 0x11, 0xca, 0x36, 0xb6, 0x39, 0xae, 0x10, 0x20, 0xc7, 0xc6, 0x36,
-	0x83, 0x6b, 0x8b, 0x50, 0xa6, 0xd0, 0x96, 0xe1, 0x5a, 0xbe, 0x66, 0x66, 0x9d, 0xa1, 0xcd, 0x50,
-	0x6d, 0x1c, 0x52, 0xcd, 0x1e, 0x01, 0xa9, 0xca, 0x71, 0x48, 0x15, 0x5b, 0x2c, 0x95, 0xe4, 0x62,
-	0x99, 0x14, 0xb0, 0xde, 0x84, 0xf9, 0x1e, 0xbb, 0xad, 0x36, 0xd6, 0x0e, 0xd7, 0xc3, 0x5f, 0xad,
-	0x2a, 0xc3, 0x7e, 0xb2, 0x5f, 0xe8, 0x0e, 0x0d, 0x2e, 0x81, 0x4e, 0x99, 0x97, 0x6b, 0xcc, 0xcb,
-	0x72, 0xc4, 0x26, 0x7c, 0xc3, 0x9d, 0x1c, 0xa4, 0x55, 0xd6, 0x4a, 0x42, 0xe5, 0xfa, 0x89, 0xa0,
-	0xf2, 0x05, 0xa8, 0x06, 0x25, 0x0b, 0xd3, 0x20, 0xad, 0x06, 0x4f, 0x6f, 0xc1, 0x82, 0x37, 0x48,
-	0xec, 0xea, 0x6c, 0x2e, 0x76, 0x75, 0xa6, 0xfe, 0x2d, 0x0f, 0x8d, 0x11, 0x48, 0x9a, 0x38, 0x15,
-	0x4c, 0x52, 0xec, 0xdb, 0x86, 0xe6, 0xe8, 0x66, 0x99, 0xcd, 0xd2, 0x91, 0x38, 0x2f, 0x79, 0xa7,
-	0x3c, 0x37, 0x48, 0xac, 0xb9, 0xd8, 0x95, 0x4a, 0xe1, 0x58, 0x57, 0x2a, 0x53, 0xd6, 0x84, 0x6e,
-	0xc1, 0x19, 0x8f, 0xa3, 0x30, 0xa3, 0x13, 0x33, 0x9b, 0x03, 0x9a, 0xd3, 0xc1, 0xcf, 0x9d, 0xa8,
-	0xf9, 0x19, 0xcb, 0x78, 0x36, 0x6b, 0x19, 0x27, 0xdd, 0x58, 0x4e, 0xb9, 0x31, 0x5d, 0x9a, 0xaa,
-	0xc8, 0x4a, 0x53, 0x0f, 0x60, 0xfe, 0x81, 0x43, 0x86, 0x5d, 0xd2, 0xf3, 0xcc, 0x2e, 0x0e, 0xae,
-	0x0c, 0x26, 0x72, 0x6b, 0x1b, 0xca, 0x22, 0x5f, 0x73, 0x97, 0x56, 0xb4, 0xb0, 0xad, 0xfe, 0x50,
-	0x81, 0x85, 0xf4, 0xb8, 0x2c, 0x62, 0x46, 0xc9, 0x40, 0x89, 0x25, 0x83, 0x6f, 0xc3, 0xfc, 0x68,
-	0xf8, 0x4e, 0x6c, 0xe4, 0xea, 0xea, 0x35, 0x99, 0xef, 0x24, 0x8a, 0x6b, 0x68, 0x34, 0x46, 0x40,
-	0x53, 0xff, 0xad, 0xc0, 0x29, 0xb1, 0xac, 0x28, 0xad, 0xcf, 0xae, 0x62, 0xe8, 0x06, 0xe5, 0x3a,
-	0x96, 0xe9, 0x84, 0xa0, 0x5e, 0xd8, 0xc8, 0x89, 0x02, 0xd4, 0xbf, 0x0f, 0x73, 0x82, 0x29, 0xdc,
-	0x67, 0x26, 0x44, 0x44, 0x0d, 0xde, 0x2f, 0xdc, 0x61, 0xae, 0x40, 0xc3, 0xdd, 0xdf, 0x8f, 0xca,
-	0xe3, 0x89, 0xb2, 0x2e, 0xa8, 0x42, 0xe0, 0x37, 0xa1, 0x19, 0xb0, 0x1d, 0x77, 0x67, 0x9b, 0x13,
-	0x1d, 0xc3, 0xab, 0xd4, 0x8f, 0x14, 0x68, 0xc5, 0xf7, 0xb9, 0x88, 0xf9, 0xc7, 0x07, 0x63, 0x5f,
-	0x8d, 0x17, 0x30, 0xae, 0x1c, 0xa1, 0xcf, 0x48, 0x8e, 0x38, 0x81, 0x5d, 0x7f, 0x09, 0x8d, 0xf8,
-	0x9a, 0x45, 0x35, 0x28, 0x6f, 0xbb, 0xfe, 0x37, 0x5e, 0x98, 0xc4, 0x6f, 0xce, 0xa0, 0x06, 0xc0,
-	0xb6, 0xeb, 0xef, 0x78, 0x98, 0x60, 0xc7, 0x6f, 0x2a, 0x08, 0xa0, 0xf4, 0x81, 0xb3, 0x61, 0x92,
-	0x27, 0xcd, 0x1c, 0x9a, 0x17, 0x5b, 0xaa, 0x6e, 0x6d, 0x89, 0x85, 0xd0, 0xcc, 0xd3, 0xee, 0x61,
-	0xab, 0x80, 0x9a, 0x50, 0x0b, 0x59, 0x36, 0x77, 0x1e, 0x34, 0x8b, 0xa8, 0x02, 0x45, 0xfe, 0x59,
-	0xba, 0x6e, 0x40, 0x33, 0x09, 0xfa, 0xe8, 0x98, 0x0f, 0x9c, 0xbb, 0x8e, 0xfb, 0x3c, 0x24, 0x35,
-	0x67, 0x50, 0x15, 0x66, 0x05, 0x90, 0x6e, 0x2a, 0x68, 0x0e, 0xaa, 0x11, 0x0c, 0xdb, 0xcc, 0x51,
-	0xc2, 0xa6, 0x37, 0xe8, 0x09, 0x34, 0xcb, 0x55, 0xa0, 0x5e, 0xdb, 0x70, 0x9f, 0x3b, 0xcd, 0xc2,
-	0xf5, 0x35, 0x28, 0x07, 0xc9, 0x84, 0xb2, 0xf2, 0xd1, 0x1d, 0xda, 0x6c, 0xce, 0xa0, 0x53, 0x50,
-	0x8f, 0x95, 0xc3, 0x9b, 0x0a, 0x42, 0xd0, 0x88, 0x3f, 0x55, 0x68, 0xe6, 0x56, 0x7f, 0x5e, 0x07,
-	0xe0, 0x68, 0xcb, 0x75, 0x3d, 0x03, 0x0d, 0x00, 0x6d, 0x62, 0x9f, 0xee, 0x24, 0xae, 0x13, 0xec,
-	0x02, 0x04, 0xdd, 0xcc, 0x00, 0x25, 0x69, 0x56, 0xa1, 0x6a, 0x3b, 0xeb, 0x3c, 0x92, 0x60, 0x57,
-	0x67, 0x90, 0xcd, 0x24, 0xee, 0x99, 0x36, 0xde, 0x33, 0x7b, 0x4f, 0x42, 0x98, 0x96, 0x2d, 0x31,
-	0xc1, 0x1a, 0x48, 0x4c, 0x24, 0x6d, 0xd1, 0xd8, 0xf5, 0x3d, 0xd3, 0xe9, 0x07, 0xe5, 0x24, 0x75,
-	0x06, 0x3d, 0x85, 0xd3, 0x9b, 0x98, 0x49, 0x37, 0x89, 0x6f, 0xf6, 0x48, 0x20, 0x70, 0x35, 0x5b,
-	0x60, 0x8a, 0xf9, 0x98, 0x22, 0x2d, 0x98, 0x4b, 0x3c, 0x0d, 0x42, 0xd7, 0xe5, 0x15, 0x29, 0xd9,
-	0x33, 0xa6, 0xf6, 0xdb, 0x13, 0xf1, 0x86, 0xd2, 0x4c, 0x68, 0xc4, 0x9f, 0xcd, 0xa0, 0xcf, 0x67,
-	0x0d, 0x90, 0x7a, 0x19, 0xd0, 0xbe, 0x3e, 0x09, 0x6b, 0x28, 0xea, 0x11, 0x8f, 0xa7, 0x71, 0xa2,
-	0xa4, 0xaf, 0x32, 0xda, 0x47, 0x55, 0xf2, 0xd4, 0x19, 0xf4, 0x5d, 0x38, 0x95, 0x7a, 0xbf, 0x80,
-	0xbe, 0x20, 0x1b, 0x3e, 0xeb, 0x99, 0xc3, 0x38, 0x09, 0x8f, 0x92, 0xab, 0x21, 0x5b, 0xfb, 0xd4,
-	0x7b, 0x97, 0xc9, 0xb5, 0x8f, 0x0c, 0x7f, 0x94, 0xf6, 0xc7, 0x96, 0x30, 0x04, 0x94, 0x7e, 0xc1,
-	0x80, 0xde, 0x91, 0x89, 0xc8, 0x7c, 0x45, 0xd1, 0x5e, 0x99, 0x94, 0x3d, 0x74, 0xf9, 0x90, 0xad,
-	0xd6, 0xe4, 0x71, 0x43, 0x2a, 0x36, 0xf3, 0xd5, 0x82, 0x5c, 0x6c, 0xf6, 0xc3, 0x01, 0x1e, 0xd4,
-	0xf1, 0xc2, 0xb8, 0xdc, 0x57, 0xd2, 0x62, 0xbe, 0x3c, 0xa8, 0xe5, 0x75, 0x76, 0x75, 0x06, 0xed,
-	0xc5, 0x92, 0x30, 0xba, 0x9a, 0x15, 0x13, 0xf1, 0x9b, 0x86, 0x71, 0xee, 0xea, 0x00, 0x6c, 0x62,
-	0xff, 0x3e, 0xf6, 0x3d, 0xb3, 0x47, 0x92, 0x83, 0x8a, 0xc6, 0x88, 0x21, 0x18, 0xf4, 0xda, 0x58,
-	0xbe, 0x50, 0xed, 0x2e, 0x54, 0x37, 0xb1, 0xaf, 0x71, 0xa4, 0x45, 0x50, 0x66, 0xcf, 0x80, 0x23,
-	0x10, 0xb1, 0x3c, 0x9e, 0x31, 0x9a, 0xc8, 0x12, 0x75, 0x7a, 0x94, 0x39, 0xb7, 0xe9, 0xd7, 0x03,
-	0xf2, 0x44, 0x96, 0x51, 0xf8, 0x57, 0x67, 0x56, 0xff, 0x5e, 0x85, 0x0a, 0x8b, 0x42, 0xba, 0xe3,
-	0x7d, 0xb6, 0x31, 0xbd, 0x82, 0x8d, 0xe9, 0x31, 0xcc, 0x25, 0xde, 0x1d, 0xc8, 0xfd, 0x29, 0x7f,
-	0x9c, 0x30, 0x2e, 0xe4, 0xbb, 0x80, 0xd2, 0x55, 0x75, 0x79, 0xaa, 0xc8, 0xac, 0xbe, 0x8f, 0x93,
-	0xf1, 0x18, 0xe6, 0x12, 0x25, 0x64, 0xb9, 0x05, 0xf2, 0x3a, 0xf3, 0x04, 0x16, 0xa4, 0x6b, 0x9b,
-	0x72, 0x0b, 0x32, 0x6b, 0xa0, 0xe3, 0x64, 0x3c, 0xe4, 0x85, 0xf9, 0x10, 0xb4, 0x5f, 0xcb, 0xca,
-	0x37, 0x89, 0x8b, 0xd6, 0xd7, 0xbf, 0x03, 0xbd, 0xfa, 0x1d, 0xfa, 0x31, 0xcc, 0x25, 0xaa, 0x0b,
-	0x72, 0xef, 0xca, 0x4b, 0x10, 0xe3, 0x46, 0xff, 0x14, 0xf7, 0x94, 0x5d, 0x28, 0xf1, 0x92, 0x00,
-	0xba, 0x28, 0x3f, 0xc2, 0x44, 0xca, 0x05, 0xed, 0x71, 0x45, 0x05, 0x32, 0xb4, 0x7c, 0xc2, 0x06,
-	0x2d, 0xb2, 0x15, 0x83, 0xa4, 0xf5, 0x9c, 0x68, 0xa9, 0xa0, 0x3d, 0xbe, 0x3a, 0x10, 0x0c, 0xfa,
-	0xaa, 0xf7, 0xa9, 0xb5, 0x2f, 0x3d, 0x5a, 0xed, 0x9b, 0xfe, 0xc1, 0xb0, 0x4b, 0xfd, 0x71, 0x83,
-	0x73, 0xbe, 0x63, 0xba, 0xe2, 0xeb, 0x46, 0xa0, 0xda, 0x0d, 0x36, 0xd2, 0x0d, 0x66, 0xcb, 0xa0,
-	0xdb, 0x2d, 0xb1, 0xe6, 0xad, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0xaa, 0xf8, 0xae, 0x2f, 0x61,
-	0x30, 0x00, 0x00,
+func (m *SyncDistributionRequest) Reset()         { *m = SyncDistributionRequest{} }
+func (m *SyncDistributionRequest) String() string { return proto.CompactTextString(m) }
+func (*SyncDistributionRequest) ProtoMessage()    {}
+func (*SyncDistributionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{39}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// QueryCoordClient is the client API for QueryCoord service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type QueryCoordClient interface {
-	GetComponentStates(ctx context.Context, in *internalpb.GetComponentStatesRequest, opts ...grpc.CallOption) (*internalpb.ComponentStates, error)
-	GetTimeTickChannel(ctx context.Context, in *internalpb.GetTimeTickChannelRequest, opts ...grpc.CallOption) (*milvuspb.StringResponse, error)
-	GetStatisticsChannel(ctx context.Context, in *internalpb.GetStatisticsChannelRequest, opts ...grpc.CallOption) (*milvuspb.StringResponse, error)
-	ShowCollections(ctx context.Context, in *ShowCollectionsRequest, opts ...grpc.CallOption) (*ShowCollectionsResponse, error)
-	ShowPartitions(ctx context.Context, in *ShowPartitionsRequest, opts ...grpc.CallOption) (*ShowPartitionsResponse, error)
-	LoadPartitions(ctx context.Context, in *LoadPartitionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
-	ReleasePartitions(ctx context.Context, in *ReleasePartitionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
-	LoadCollection(ctx context.Context, in *LoadCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
-	ReleaseCollection(ctx context.Context, in *ReleaseCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
-	CreateQueryChannel(ctx context.Context, in *CreateQueryChannelRequest, opts ...grpc.CallOption) (*CreateQueryChannelResponse, error)
-	GetPartitionStates(ctx context.Context, in *GetPartitionStatesRequest, opts ...grpc.CallOption) (*GetPartitionStatesResponse, error)
-	GetSegmentInfo(ctx context.Context, in *GetSegmentInfoRequest, opts ...grpc.CallOption) (*GetSegmentInfoResponse, error)
-	LoadBalance(ctx context.Context, in *LoadBalanceRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
-	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
-	GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error)
-	// https://wiki.lfaidata.foundation/display/MIL/MEP+23+--+Multiple+memory+replication+design
-	GetReplicas(ctx context.Context, in *milvuspb.GetReplicasRequest, opts ...grpc.CallOption) (*milvuspb.GetReplicasResponse, error)
-	GetShardLeaders(ctx context.Context, in *GetShardLeadersRequest, opts ...grpc.CallOption) (*GetShardLeadersResponse, error)
+func (m *SyncDistributionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncDistributionRequest.Unmarshal(m, b)
 }
-
-type queryCoordClient struct {
-	cc *grpc.ClientConn
+func (m *SyncDistributionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncDistributionRequest.Marshal(b, m, deterministic)
 }
-
-func NewQueryCoordClient(cc *grpc.ClientConn) QueryCoordClient {
-	return &queryCoordClient{cc}
+func (m *SyncDistributionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncDistributionRequest.Merge(m, src)
+}
+func (m *SyncDistributionRequest) XXX_Size() int {
+	return xxx_messageInfo_SyncDistributionRequest.Size(m)
+}
+func (m *SyncDistributionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncDistributionRequest.DiscardUnknown(m)
 }
 
-func (c *queryCoordClient) GetComponentStates(ctx context.Context, in *internalpb.GetComponentStatesRequest, opts ...grpc.CallOption) (*internalpb.ComponentStates, error) {
-	out := new(internalpb.ComponentStates)
+var xxx_messageInfo_SyncDistributionRequest proto.InternalMessageInfo
+
+func (m *SyncDistributionRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *SyncDistributionRequest) GetNodeID() int64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *SyncDistributionRequest) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *SyncDistributionRequest) GetActions() []*SegmentServingAction {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+func (m *SyncDistributionRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type SegmentServingAction struct {
+	SegmentID            int64    `protobuf:"varint,1,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
+	Serving              bool     `protobuf:"varint,2,opt,name=serving,proto3" json:"serving,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SegmentServingAction) Reset()         { *m = SegmentServingAction{} }
+func (m *SegmentServingAction) String() string { return proto.CompactTextString(m) }
+func (*SegmentServingAction) ProtoMessage()    {}
+func (*SegmentServingAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{40}
+}
+
+func (m *SegmentServingAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentServingAction.Unmarshal(m, b)
+}
+func (m *SegmentServingAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentServingAction.Marshal(b, m, deterministic)
+}
+func (m *SegmentServingAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentServingAction.Merge(m, src)
+}
+func (m *SegmentServingAction) XXX_Size() int {
+	return xxx_messageInfo_SegmentServingAction.Size(m)
+}
+func (m *SegmentServingAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentServingAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentServingAction proto.InternalMessageInfo
+
+func (m *SegmentServingAction) GetSegmentID() int64 {
+	if m != nil {
+		return m.SegmentID
+	}
+	return 0
+}
+
+func (m *SegmentServingAction) GetServing() bool {
+	if m != nil {
+		return m.Serving
+	}
+	return false
+}
+
+// stop applying the inserts and deletes of a channel without releasing loaded data, tSafe of the channel freezes until resumed
+type PauseChannelRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	NodeID               int64             `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	CollectionID         int64             `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	ChannelName          string            `protobuf:"bytes,4,opt,name=channel_name,json=channelName,proto3" json:"channel_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PauseChannelRequest) Reset()         { *m = PauseChannelRequest{} }
+func (m *PauseChannelRequest) String() string { return proto.CompactTextString(m) }
+func (*PauseChannelRequest) ProtoMessage()    {}
+func (*PauseChannelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{41}
+}
+
+func (m *PauseChannelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseChannelRequest.Unmarshal(m, b)
+}
+func (m *PauseChannelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseChannelRequest.Marshal(b, m, deterministic)
+}
+func (m *PauseChannelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseChannelRequest.Merge(m, src)
+}
+func (m *PauseChannelRequest) XXX_Size() int {
+	return xxx_messageInfo_PauseChannelRequest.Size(m)
+}
+func (m *PauseChannelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseChannelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseChannelRequest proto.InternalMessageInfo
+
+func (m *PauseChannelRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *PauseChannelRequest) GetNodeID() int64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *PauseChannelRequest) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *PauseChannelRequest) GetChannelName() string {
+	if m != nil {
+		return m.ChannelName
+	}
+	return ""
+}
+
+type ResumeChannelRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	NodeID               int64             `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	CollectionID         int64             `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	ChannelName          string            `protobuf:"bytes,4,opt,name=channel_name,json=channelName,proto3" json:"channel_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ResumeChannelRequest) Reset()         { *m = ResumeChannelRequest{} }
+func (m *ResumeChannelRequest) String() string { return proto.CompactTextString(m) }
+func (*ResumeChannelRequest) ProtoMessage()    {}
+func (*ResumeChannelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{42}
+}
+
+func (m *ResumeChannelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResumeChannelRequest.Unmarshal(m, b)
+}
+func (m *ResumeChannelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResumeChannelRequest.Marshal(b, m, deterministic)
+}
+func (m *ResumeChannelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumeChannelRequest.Merge(m, src)
+}
+func (m *ResumeChannelRequest) XXX_Size() int {
+	return xxx_messageInfo_ResumeChannelRequest.Size(m)
+}
+func (m *ResumeChannelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumeChannelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResumeChannelRequest proto.InternalMessageInfo
+
+func (m *ResumeChannelRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *ResumeChannelRequest) GetNodeID() int64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *ResumeChannelRequest) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *ResumeChannelRequest) GetChannelName() string {
+	if m != nil {
+		return m.ChannelName
+	}
+	return ""
+}
+
+// export the applied deletes of a sealed segment, the snapshot could be attached to SegmentLoadInfo of another replica
+type ExportSegmentDeletesRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	NodeID               int64             `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	CollectionID         int64             `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	SegmentID            int64             `protobuf:"varint,4,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ExportSegmentDeletesRequest) Reset()         { *m = ExportSegmentDeletesRequest{} }
+func (m *ExportSegmentDeletesRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportSegmentDeletesRequest) ProtoMessage()    {}
+func (*ExportSegmentDeletesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{43}
+}
+
+func (m *ExportSegmentDeletesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportSegmentDeletesRequest.Unmarshal(m, b)
+}
+func (m *ExportSegmentDeletesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportSegmentDeletesRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportSegmentDeletesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportSegmentDeletesRequest.Merge(m, src)
+}
+func (m *ExportSegmentDeletesRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportSegmentDeletesRequest.Size(m)
+}
+func (m *ExportSegmentDeletesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportSegmentDeletesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportSegmentDeletesRequest proto.InternalMessageInfo
+
+func (m *ExportSegmentDeletesRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *ExportSegmentDeletesRequest) GetNodeID() int64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *ExportSegmentDeletesRequest) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *ExportSegmentDeletesRequest) GetSegmentID() int64 {
+	if m != nil {
+		return m.SegmentID
+	}
+	return 0
+}
+
+type ExportSegmentDeletesResponse struct {
+	Status               *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	SegmentID            int64            `protobuf:"varint,2,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
+	Snapshot             []byte           `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ExportSegmentDeletesResponse) Reset()         { *m = ExportSegmentDeletesResponse{} }
+func (m *ExportSegmentDeletesResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportSegmentDeletesResponse) ProtoMessage()    {}
+func (*ExportSegmentDeletesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{44}
+}
+
+func (m *ExportSegmentDeletesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportSegmentDeletesResponse.Unmarshal(m, b)
+}
+func (m *ExportSegmentDeletesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportSegmentDeletesResponse.Marshal(b, m, deterministic)
+}
+func (m *ExportSegmentDeletesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportSegmentDeletesResponse.Merge(m, src)
+}
+func (m *ExportSegmentDeletesResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportSegmentDeletesResponse.Size(m)
+}
+func (m *ExportSegmentDeletesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportSegmentDeletesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportSegmentDeletesResponse proto.InternalMessageInfo
+
+func (m *ExportSegmentDeletesResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ExportSegmentDeletesResponse) GetSegmentID() int64 {
+	if m != nil {
+		return m.SegmentID
+	}
+	return 0
+}
+
+func (m *ExportSegmentDeletesResponse) GetSnapshot() []byte {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+// statistics of the pk bloom filter of a segment, fill ratio is the fraction of set bits
+type SegmentBloomFilterStats struct {
+	BitSize              uint64   `protobuf:"varint,1,opt,name=bit_size,json=bitSize,proto3" json:"bit_size,omitempty"`
+	HashFunctions        uint32   `protobuf:"varint,2,opt,name=hash_functions,json=hashFunctions,proto3" json:"hash_functions,omitempty"`
+	FillRatio            float64  `protobuf:"fixed64,3,opt,name=fill_ratio,json=fillRatio,proto3" json:"fill_ratio,omitempty"`
+	ApproxElementCount   uint64   `protobuf:"varint,4,opt,name=approx_element_count,json=approxElementCount,proto3" json:"approx_element_count,omitempty"`
+	EstimatedFpr         float64  `protobuf:"fixed64,5,opt,name=estimated_fpr,json=estimatedFpr,proto3" json:"estimated_fpr,omitempty"`
+	Lookups              int64    `protobuf:"varint,6,opt,name=lookups,proto3" json:"lookups,omitempty"`
+	Pruned               int64    `protobuf:"varint,7,opt,name=pruned,proto3" json:"pruned,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SegmentBloomFilterStats) Reset()         { *m = SegmentBloomFilterStats{} }
+func (m *SegmentBloomFilterStats) String() string { return proto.CompactTextString(m) }
+func (*SegmentBloomFilterStats) ProtoMessage()    {}
+func (*SegmentBloomFilterStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{45}
+}
+
+func (m *SegmentBloomFilterStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentBloomFilterStats.Unmarshal(m, b)
+}
+func (m *SegmentBloomFilterStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentBloomFilterStats.Marshal(b, m, deterministic)
+}
+func (m *SegmentBloomFilterStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentBloomFilterStats.Merge(m, src)
+}
+func (m *SegmentBloomFilterStats) XXX_Size() int {
+	return xxx_messageInfo_SegmentBloomFilterStats.Size(m)
+}
+func (m *SegmentBloomFilterStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentBloomFilterStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentBloomFilterStats proto.InternalMessageInfo
+
+func (m *SegmentBloomFilterStats) GetBitSize() uint64 {
+	if m != nil {
+		return m.BitSize
+	}
+	return 0
+}
+
+func (m *SegmentBloomFilterStats) GetHashFunctions() uint32 {
+	if m != nil {
+		return m.HashFunctions
+	}
+	return 0
+}
+
+func (m *SegmentBloomFilterStats) GetFillRatio() float64 {
+	if m != nil {
+		return m.FillRatio
+	}
+	return 0
+}
+
+func (m *SegmentBloomFilterStats) GetApproxElementCount() uint64 {
+	if m != nil {
+		return m.ApproxElementCount
+	}
+	return 0
+}
+
+func (m *SegmentBloomFilterStats) GetEstimatedFpr() float64 {
+	if m != nil {
+		return m.EstimatedFpr
+	}
+	return 0
+}
+
+func (m *SegmentBloomFilterStats) GetLookups() int64 {
+	if m != nil {
+		return m.Lookups
+	}
+	return 0
+}
+
+func (m *SegmentBloomFilterStats) GetPruned() int64 {
+	if m != nil {
+		return m.Pruned
+	}
+	return 0
+}
+
+// bytes downloaded and wall-clock durations of the phases of loading a field of a segment
+type FieldLoadStats struct {
+	FieldID              int64    `protobuf:"varint,1,opt,name=fieldID,proto3" json:"fieldID,omitempty"`
+	BinlogSize           int64    `protobuf:"varint,2,opt,name=binlog_size,json=binlogSize,proto3" json:"binlog_size,omitempty"`
+	IndexSize            int64    `protobuf:"varint,3,opt,name=index_size,json=indexSize,proto3" json:"index_size,omitempty"`
+	DownloadMs           int64    `protobuf:"varint,4,opt,name=download_ms,json=downloadMs,proto3" json:"download_ms,omitempty"`
+	DeserializeMs        int64    `protobuf:"varint,5,opt,name=deserialize_ms,json=deserializeMs,proto3" json:"deserialize_ms,omitempty"`
+	SegcoreLoadMs        int64    `protobuf:"varint,6,opt,name=segcore_load_ms,json=segcoreLoadMs,proto3" json:"segcore_load_ms,omitempty"`
+	IndexLoadMs          int64    `protobuf:"varint,7,opt,name=index_load_ms,json=indexLoadMs,proto3" json:"index_load_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FieldLoadStats) Reset()         { *m = FieldLoadStats{} }
+func (m *FieldLoadStats) String() string { return proto.CompactTextString(m) }
+func (*FieldLoadStats) ProtoMessage()    {}
+func (*FieldLoadStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{46}
+}
+
+func (m *FieldLoadStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldLoadStats.Unmarshal(m, b)
+}
+func (m *FieldLoadStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldLoadStats.Marshal(b, m, deterministic)
+}
+func (m *FieldLoadStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldLoadStats.Merge(m, src)
+}
+func (m *FieldLoadStats) XXX_Size() int {
+	return xxx_messageInfo_FieldLoadStats.Size(m)
+}
+func (m *FieldLoadStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldLoadStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldLoadStats proto.InternalMessageInfo
+
+func (m *FieldLoadStats) GetFieldID() int64 {
+	if m != nil {
+		return m.FieldID
+	}
+	return 0
+}
+
+func (m *FieldLoadStats) GetBinlogSize() int64 {
+	if m != nil {
+		return m.BinlogSize
+	}
+	return 0
+}
+
+func (m *FieldLoadStats) GetIndexSize() int64 {
+	if m != nil {
+		return m.IndexSize
+	}
+	return 0
+}
+
+func (m *FieldLoadStats) GetDownloadMs() int64 {
+	if m != nil {
+		return m.DownloadMs
+	}
+	return 0
+}
+
+func (m *FieldLoadStats) GetDeserializeMs() int64 {
+	if m != nil {
+		return m.DeserializeMs
+	}
+	return 0
+}
+
+func (m *FieldLoadStats) GetSegcoreLoadMs() int64 {
+	if m != nil {
+		return m.SegcoreLoadMs
+	}
+	return 0
+}
+
+func (m *FieldLoadStats) GetIndexLoadMs() int64 {
+	if m != nil {
+		return m.IndexLoadMs
+	}
+	return 0
+}
+
+// bytes downloaded and wall-clock durations of the phases of the last load of a segment,
+// phases not attributed to a single field such as the segcore insert of growing segments are only in the totals
+type SegmentLoadStats struct {
+	BinlogSize           int64             `protobuf:"varint,1,opt,name=binlog_size,json=binlogSize,proto3" json:"binlog_size,omitempty"`
+	IndexSize            int64             `protobuf:"varint,2,opt,name=index_size,json=indexSize,proto3" json:"index_size,omitempty"`
+	DownloadMs           int64             `protobuf:"varint,3,opt,name=download_ms,json=downloadMs,proto3" json:"download_ms,omitempty"`
+	DeserializeMs        int64             `protobuf:"varint,4,opt,name=deserialize_ms,json=deserializeMs,proto3" json:"deserialize_ms,omitempty"`
+	SegcoreLoadMs        int64             `protobuf:"varint,5,opt,name=segcore_load_ms,json=segcoreLoadMs,proto3" json:"segcore_load_ms,omitempty"`
+	IndexLoadMs          int64             `protobuf:"varint,6,opt,name=index_load_ms,json=indexLoadMs,proto3" json:"index_load_ms,omitempty"`
+	LoadMs               int64             `protobuf:"varint,7,opt,name=load_ms,json=loadMs,proto3" json:"load_ms,omitempty"`
+	FieldStats           []*FieldLoadStats `protobuf:"bytes,8,rep,name=field_stats,json=fieldStats,proto3" json:"field_stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SegmentLoadStats) Reset()         { *m = SegmentLoadStats{} }
+func (m *SegmentLoadStats) String() string { return proto.CompactTextString(m) }
+func (*SegmentLoadStats) ProtoMessage()    {}
+func (*SegmentLoadStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{47}
+}
+
+func (m *SegmentLoadStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentLoadStats.Unmarshal(m, b)
+}
+func (m *SegmentLoadStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentLoadStats.Marshal(b, m, deterministic)
+}
+func (m *SegmentLoadStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentLoadStats.Merge(m, src)
+}
+func (m *SegmentLoadStats) XXX_Size() int {
+	return xxx_messageInfo_SegmentLoadStats.Size(m)
+}
+func (m *SegmentLoadStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentLoadStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentLoadStats proto.InternalMessageInfo
+
+func (m *SegmentLoadStats) GetBinlogSize() int64 {
+	if m != nil {
+		return m.BinlogSize
+	}
+	return 0
+}
+
+func (m *SegmentLoadStats) GetIndexSize() int64 {
+	if m != nil {
+		return m.IndexSize
+	}
+	return 0
+}
+
+func (m *SegmentLoadStats) GetDownloadMs() int64 {
+	if m != nil {
+		return m.DownloadMs
+	}
+	return 0
+}
+
+func (m *SegmentLoadStats) GetDeserializeMs() int64 {
+	if m != nil {
+		return m.DeserializeMs
+	}
+	return 0
+}
+
+func (m *SegmentLoadStats) GetSegcoreLoadMs() int64 {
+	if m != nil {
+		return m.SegcoreLoadMs
+	}
+	return 0
+}
+
+func (m *SegmentLoadStats) GetIndexLoadMs() int64 {
+	if m != nil {
+		return m.IndexLoadMs
+	}
+	return 0
+}
+
+func (m *SegmentLoadStats) GetLoadMs() int64 {
+	if m != nil {
+		return m.LoadMs
+	}
+	return 0
+}
+
+func (m *SegmentLoadStats) GetFieldStats() []*FieldLoadStats {
+	if m != nil {
+		return m.FieldStats
+	}
+	return nil
+}
+
+// update the load configs of a collection loaded on query node without reloading it,
+// the configs not in the request are kept as is, the known configs are collection_ttl_seconds, segment_row_budget,
+// pk_index_enabled, max_concurrent_reads, masked_fields, masked_fields_policy and chunk_stats_fields
+type UpdateLoadConfigRequest struct {
+	Base                 *commonpb.MsgBase        `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	NodeID               int64                    `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	CollectionID         int64                    `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	Configs              []*commonpb.KeyValuePair `protobuf:"bytes,4,rep,name=configs,proto3" json:"configs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *UpdateLoadConfigRequest) Reset()         { *m = UpdateLoadConfigRequest{} }
+func (m *UpdateLoadConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateLoadConfigRequest) ProtoMessage()    {}
+func (*UpdateLoadConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{48}
+}
+
+func (m *UpdateLoadConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateLoadConfigRequest.Unmarshal(m, b)
+}
+func (m *UpdateLoadConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateLoadConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateLoadConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateLoadConfigRequest.Merge(m, src)
+}
+func (m *UpdateLoadConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateLoadConfigRequest.Size(m)
+}
+func (m *UpdateLoadConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateLoadConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateLoadConfigRequest proto.InternalMessageInfo
+
+func (m *UpdateLoadConfigRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *UpdateLoadConfigRequest) GetNodeID() int64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *UpdateLoadConfigRequest) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *UpdateLoadConfigRequest) GetConfigs() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Configs
+	}
+	return nil
+}
+
+// how an updated load config takes effect on query node
+type LoadConfigUpdateResult struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	InPlace              bool     `protobuf:"varint,2,opt,name=in_place,json=inPlace,proto3" json:"in_place,omitempty"`
+	ReloadSegmentIDs     []int64  `protobuf:"varint,3,rep,name=reload_segmentIDs,json=reloadSegmentIDs,packed,proto3" json:"reload_segmentIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LoadConfigUpdateResult) Reset()         { *m = LoadConfigUpdateResult{} }
+func (m *LoadConfigUpdateResult) String() string { return proto.CompactTextString(m) }
+func (*LoadConfigUpdateResult) ProtoMessage()    {}
+func (*LoadConfigUpdateResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{49}
+}
+
+func (m *LoadConfigUpdateResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadConfigUpdateResult.Unmarshal(m, b)
+}
+func (m *LoadConfigUpdateResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadConfigUpdateResult.Marshal(b, m, deterministic)
+}
+func (m *LoadConfigUpdateResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadConfigUpdateResult.Merge(m, src)
+}
+func (m *LoadConfigUpdateResult) XXX_Size() int {
+	return xxx_messageInfo_LoadConfigUpdateResult.Size(m)
+}
+func (m *LoadConfigUpdateResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadConfigUpdateResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadConfigUpdateResult proto.InternalMessageInfo
+
+func (m *LoadConfigUpdateResult) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *LoadConfigUpdateResult) GetInPlace() bool {
+	if m != nil {
+		return m.InPlace
+	}
+	return false
+}
+
+func (m *LoadConfigUpdateResult) GetReloadSegmentIDs() []int64 {
+	if m != nil {
+		return m.ReloadSegmentIDs
+	}
+	return nil
+}
+
+type UpdateLoadConfigResponse struct {
+	Status               *commonpb.Status          `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Results              []*LoadConfigUpdateResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *UpdateLoadConfigResponse) Reset()         { *m = UpdateLoadConfigResponse{} }
+func (m *UpdateLoadConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateLoadConfigResponse) ProtoMessage()    {}
+func (*UpdateLoadConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{50}
+}
+
+func (m *UpdateLoadConfigResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateLoadConfigResponse.Unmarshal(m, b)
+}
+func (m *UpdateLoadConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateLoadConfigResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateLoadConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateLoadConfigResponse.Merge(m, src)
+}
+func (m *UpdateLoadConfigResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateLoadConfigResponse.Size(m)
+}
+func (m *UpdateLoadConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateLoadConfigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateLoadConfigResponse proto.InternalMessageInfo
+
+func (m *UpdateLoadConfigResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *UpdateLoadConfigResponse) GetResults() []*LoadConfigUpdateResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type GetDataDistributionRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetDataDistributionRequest) Reset()         { *m = GetDataDistributionRequest{} }
+func (m *GetDataDistributionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDataDistributionRequest) ProtoMessage()    {}
+func (*GetDataDistributionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{51}
+}
+
+func (m *GetDataDistributionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataDistributionRequest.Unmarshal(m, b)
+}
+func (m *GetDataDistributionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataDistributionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDataDistributionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataDistributionRequest.Merge(m, src)
+}
+func (m *GetDataDistributionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDataDistributionRequest.Size(m)
+}
+func (m *GetDataDistributionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataDistributionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataDistributionRequest proto.InternalMessageInfo
+
+func (m *GetDataDistributionRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+// a dm channel watched by query node with the version of the WatchDmChannels request owning it
+type DmChannelOwnership struct {
+	Channel              string                  `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	CollectionID         int64                   `protobuf:"varint,2,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	ReplicaID            int64                   `protobuf:"varint,3,opt,name=replicaID,proto3" json:"replicaID,omitempty"`
+	Version              int64                   `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	SeekPosition         *internalpb.MsgPosition `protobuf:"bytes,5,opt,name=seek_position,json=seekPosition,proto3" json:"seek_position,omitempty"`
+	ServiceableTs        uint64                  `protobuf:"varint,6,opt,name=serviceable_ts,json=serviceableTs,proto3" json:"serviceable_ts,omitempty"`
+	Paused               bool                    `protobuf:"varint,7,opt,name=paused,proto3" json:"paused,omitempty"`
+	InsertRowsPerSec     float64                 `protobuf:"fixed64,8,opt,name=insert_rows_per_sec,json=insertRowsPerSec,proto3" json:"insert_rows_per_sec,omitempty"`
+	InsertBytesPerSec    float64                 `protobuf:"fixed64,9,opt,name=insert_bytes_per_sec,json=insertBytesPerSec,proto3" json:"insert_bytes_per_sec,omitempty"`
+	DeleteRowsPerSec     float64                 `protobuf:"fixed64,10,opt,name=delete_rows_per_sec,json=deleteRowsPerSec,proto3" json:"delete_rows_per_sec,omitempty"`
+	DeleteBytesPerSec    float64                 `protobuf:"fixed64,11,opt,name=delete_bytes_per_sec,json=deleteBytesPerSec,proto3" json:"delete_bytes_per_sec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *DmChannelOwnership) Reset()         { *m = DmChannelOwnership{} }
+func (m *DmChannelOwnership) String() string { return proto.CompactTextString(m) }
+func (*DmChannelOwnership) ProtoMessage()    {}
+func (*DmChannelOwnership) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{52}
+}
+
+func (m *DmChannelOwnership) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DmChannelOwnership.Unmarshal(m, b)
+}
+func (m *DmChannelOwnership) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DmChannelOwnership.Marshal(b, m, deterministic)
+}
+func (m *DmChannelOwnership) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DmChannelOwnership.Merge(m, src)
+}
+func (m *DmChannelOwnership) XXX_Size() int {
+	return xxx_messageInfo_DmChannelOwnership.Size(m)
+}
+func (m *DmChannelOwnership) XXX_DiscardUnknown() {
+	xxx_messageInfo_DmChannelOwnership.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DmChannelOwnership proto.InternalMessageInfo
+
+func (m *DmChannelOwnership) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *DmChannelOwnership) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *DmChannelOwnership) GetReplicaID() int64 {
+	if m != nil {
+		return m.ReplicaID
+	}
+	return 0
+}
+
+func (m *DmChannelOwnership) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *DmChannelOwnership) GetSeekPosition() *internalpb.MsgPosition {
+	if m != nil {
+		return m.SeekPosition
+	}
+	return nil
+}
+
+func (m *DmChannelOwnership) GetServiceableTs() uint64 {
+	if m != nil {
+		return m.ServiceableTs
+	}
+	return 0
+}
+
+func (m *DmChannelOwnership) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+func (m *DmChannelOwnership) GetInsertRowsPerSec() float64 {
+	if m != nil {
+		return m.InsertRowsPerSec
+	}
+	return 0
+}
+
+func (m *DmChannelOwnership) GetInsertBytesPerSec() float64 {
+	if m != nil {
+		return m.InsertBytesPerSec
+	}
+	return 0
+}
+
+func (m *DmChannelOwnership) GetDeleteRowsPerSec() float64 {
+	if m != nil {
+		return m.DeleteRowsPerSec
+	}
+	return 0
+}
+
+func (m *DmChannelOwnership) GetDeleteBytesPerSec() float64 {
+	if m != nil {
+		return m.DeleteBytesPerSec
+	}
+	return 0
+}
+
+type GetDataDistributionResponse struct {
+	Status               *commonpb.Status      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	NodeID               int64                 `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	Channels             []*DmChannelOwnership `protobuf:"bytes,3,rep,name=channels,proto3" json:"channels,omitempty"`
+	Indexes              []*SegmentFieldIndex  `protobuf:"bytes,4,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetDataDistributionResponse) Reset()         { *m = GetDataDistributionResponse{} }
+func (m *GetDataDistributionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDataDistributionResponse) ProtoMessage()    {}
+func (*GetDataDistributionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{53}
+}
+
+func (m *GetDataDistributionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataDistributionResponse.Unmarshal(m, b)
+}
+func (m *GetDataDistributionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataDistributionResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDataDistributionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataDistributionResponse.Merge(m, src)
+}
+func (m *GetDataDistributionResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDataDistributionResponse.Size(m)
+}
+func (m *GetDataDistributionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataDistributionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataDistributionResponse proto.InternalMessageInfo
+
+func (m *GetDataDistributionResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetDataDistributionResponse) GetNodeID() int64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *GetDataDistributionResponse) GetChannels() []*DmChannelOwnership {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *GetDataDistributionResponse) GetIndexes() []*SegmentFieldIndex {
+	if m != nil {
+		return m.Indexes
+	}
+	return nil
+}
+
+// the index of a field served by a sealed segment on query node
+type SegmentFieldIndex struct {
+	CollectionID         int64    `protobuf:"varint,1,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	SegmentID            int64    `protobuf:"varint,2,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
+	FieldID              int64    `protobuf:"varint,3,opt,name=fieldID,proto3" json:"fieldID,omitempty"`
+	IndexID              int64    `protobuf:"varint,4,opt,name=indexID,proto3" json:"indexID,omitempty"`
+	BuildID              int64    `protobuf:"varint,5,opt,name=buildID,proto3" json:"buildID,omitempty"`
+	Version              int64    `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	Stale                bool     `protobuf:"varint,7,opt,name=stale,proto3" json:"stale,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SegmentFieldIndex) Reset()         { *m = SegmentFieldIndex{} }
+func (m *SegmentFieldIndex) String() string { return proto.CompactTextString(m) }
+func (*SegmentFieldIndex) ProtoMessage()    {}
+func (*SegmentFieldIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{54}
+}
+
+func (m *SegmentFieldIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentFieldIndex.Unmarshal(m, b)
+}
+func (m *SegmentFieldIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentFieldIndex.Marshal(b, m, deterministic)
+}
+func (m *SegmentFieldIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentFieldIndex.Merge(m, src)
+}
+func (m *SegmentFieldIndex) XXX_Size() int {
+	return xxx_messageInfo_SegmentFieldIndex.Size(m)
+}
+func (m *SegmentFieldIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentFieldIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentFieldIndex proto.InternalMessageInfo
+
+func (m *SegmentFieldIndex) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *SegmentFieldIndex) GetSegmentID() int64 {
+	if m != nil {
+		return m.SegmentID
+	}
+	return 0
+}
+
+func (m *SegmentFieldIndex) GetFieldID() int64 {
+	if m != nil {
+		return m.FieldID
+	}
+	return 0
+}
+
+func (m *SegmentFieldIndex) GetIndexID() int64 {
+	if m != nil {
+		return m.IndexID
+	}
+	return 0
+}
+
+func (m *SegmentFieldIndex) GetBuildID() int64 {
+	if m != nil {
+		return m.BuildID
+	}
+	return 0
+}
+
+func (m *SegmentFieldIndex) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *SegmentFieldIndex) GetStale() bool {
+	if m != nil {
+		return m.Stale
+	}
+	return false
+}
+
+// promote the warm standby segments on query node to serving in place, e.g. on failure of the node serving them,
+// the deletes since the checkpoints are replayed before the segments are served
+type PromoteSegmentsRequest struct {
+	Base                 *commonpb.MsgBase         `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	NodeID               int64                     `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	CollectionID         int64                     `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	SegmentIDs           []int64                   `protobuf:"varint,4,rep,name=segmentIDs,packed,proto3" json:"segmentIDs,omitempty"`
+	Checkpoints          []*internalpb.MsgPosition `protobuf:"bytes,5,rep,name=checkpoints,proto3" json:"checkpoints,omitempty"`
+	Version              int64                     `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *PromoteSegmentsRequest) Reset()         { *m = PromoteSegmentsRequest{} }
+func (m *PromoteSegmentsRequest) String() string { return proto.CompactTextString(m) }
+func (*PromoteSegmentsRequest) ProtoMessage()    {}
+func (*PromoteSegmentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{55}
+}
+
+func (m *PromoteSegmentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PromoteSegmentsRequest.Unmarshal(m, b)
+}
+func (m *PromoteSegmentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PromoteSegmentsRequest.Marshal(b, m, deterministic)
+}
+func (m *PromoteSegmentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PromoteSegmentsRequest.Merge(m, src)
+}
+func (m *PromoteSegmentsRequest) XXX_Size() int {
+	return xxx_messageInfo_PromoteSegmentsRequest.Size(m)
+}
+func (m *PromoteSegmentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PromoteSegmentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PromoteSegmentsRequest proto.InternalMessageInfo
+
+func (m *PromoteSegmentsRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *PromoteSegmentsRequest) GetNodeID() int64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *PromoteSegmentsRequest) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *PromoteSegmentsRequest) GetSegmentIDs() []int64 {
+	if m != nil {
+		return m.SegmentIDs
+	}
+	return nil
+}
+
+func (m *PromoteSegmentsRequest) GetCheckpoints() []*internalpb.MsgPosition {
+	if m != nil {
+		return m.Checkpoints
+	}
+	return nil
+}
+
+func (m *PromoteSegmentsRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+// re-attach the indexes of a sealed segment on query node with the current index metadata,
+// e.g. after the index files it was loaded with are garbage collected upstream
+type RefreshIndexRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	NodeID               int64             `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	CollectionID         int64             `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	SegmentID            int64             `protobuf:"varint,4,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
+	IndexInfos           []*FieldIndexInfo `protobuf:"bytes,5,rep,name=index_infos,json=indexInfos,proto3" json:"index_infos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *RefreshIndexRequest) Reset()         { *m = RefreshIndexRequest{} }
+func (m *RefreshIndexRequest) String() string { return proto.CompactTextString(m) }
+func (*RefreshIndexRequest) ProtoMessage()    {}
+func (*RefreshIndexRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{56}
+}
+
+func (m *RefreshIndexRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefreshIndexRequest.Unmarshal(m, b)
+}
+func (m *RefreshIndexRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefreshIndexRequest.Marshal(b, m, deterministic)
+}
+func (m *RefreshIndexRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefreshIndexRequest.Merge(m, src)
+}
+func (m *RefreshIndexRequest) XXX_Size() int {
+	return xxx_messageInfo_RefreshIndexRequest.Size(m)
+}
+func (m *RefreshIndexRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefreshIndexRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefreshIndexRequest proto.InternalMessageInfo
+
+func (m *RefreshIndexRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *RefreshIndexRequest) GetNodeID() int64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *RefreshIndexRequest) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *RefreshIndexRequest) GetSegmentID() int64 {
+	if m != nil {
+		return m.SegmentID
+	}
+	return 0
+}
+
+func (m *RefreshIndexRequest) GetIndexInfos() []*FieldIndexInfo {
+	if m != nil {
+		return m.IndexInfos
+	}
+	return nil
+}
+
+type WatchDmChannelsResponse struct {
+	Status               *commonpb.Status        `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	ChannelStatus        []*DmChannelWatchStatus `protobuf:"bytes,2,rep,name=channel_status,json=channelStatus,proto3" json:"channel_status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *WatchDmChannelsResponse) Reset()         { *m = WatchDmChannelsResponse{} }
+func (m *WatchDmChannelsResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchDmChannelsResponse) ProtoMessage()    {}
+func (*WatchDmChannelsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{57}
+}
+
+func (m *WatchDmChannelsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchDmChannelsResponse.Unmarshal(m, b)
+}
+func (m *WatchDmChannelsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchDmChannelsResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchDmChannelsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchDmChannelsResponse.Merge(m, src)
+}
+func (m *WatchDmChannelsResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchDmChannelsResponse.Size(m)
+}
+func (m *WatchDmChannelsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchDmChannelsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchDmChannelsResponse proto.InternalMessageInfo
+
+func (m *WatchDmChannelsResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *WatchDmChannelsResponse) GetChannelStatus() []*DmChannelWatchStatus {
+	if m != nil {
+		return m.ChannelStatus
+	}
+	return nil
+}
+
+// the outcome of watching a dm channel of WatchDmChannelsRequest
+type DmChannelWatchStatus struct {
+	Channel              string                  `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Status               *commonpb.Status        `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	SeekPosition         *internalpb.MsgPosition `protobuf:"bytes,3,opt,name=seek_position,json=seekPosition,proto3" json:"seek_position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *DmChannelWatchStatus) Reset()         { *m = DmChannelWatchStatus{} }
+func (m *DmChannelWatchStatus) String() string { return proto.CompactTextString(m) }
+func (*DmChannelWatchStatus) ProtoMessage()    {}
+func (*DmChannelWatchStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{58}
+}
+
+func (m *DmChannelWatchStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DmChannelWatchStatus.Unmarshal(m, b)
+}
+func (m *DmChannelWatchStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DmChannelWatchStatus.Marshal(b, m, deterministic)
+}
+func (m *DmChannelWatchStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DmChannelWatchStatus.Merge(m, src)
+}
+func (m *DmChannelWatchStatus) XXX_Size() int {
+	return xxx_messageInfo_DmChannelWatchStatus.Size(m)
+}
+func (m *DmChannelWatchStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_DmChannelWatchStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DmChannelWatchStatus proto.InternalMessageInfo
+
+func (m *DmChannelWatchStatus) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *DmChannelWatchStatus) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *DmChannelWatchStatus) GetSeekPosition() *internalpb.MsgPosition {
+	if m != nil {
+		return m.SeekPosition
+	}
+	return nil
+}
+
+type FieldTransform struct {
+	FieldID              int64              `protobuf:"varint,1,opt,name=fieldID,proto3" json:"fieldID,omitempty"`
+	Type                 FieldTransformType `protobuf:"varint,2,opt,name=type,proto3,enum=milvus.proto.query.FieldTransformType" json:"type,omitempty"`
+	Precision            int32              `protobuf:"varint,3,opt,name=precision,proto3" json:"precision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *FieldTransform) Reset()         { *m = FieldTransform{} }
+func (m *FieldTransform) String() string { return proto.CompactTextString(m) }
+func (*FieldTransform) ProtoMessage()    {}
+func (*FieldTransform) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{59}
+}
+
+func (m *FieldTransform) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldTransform.Unmarshal(m, b)
+}
+func (m *FieldTransform) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldTransform.Marshal(b, m, deterministic)
+}
+func (m *FieldTransform) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldTransform.Merge(m, src)
+}
+func (m *FieldTransform) XXX_Size() int {
+	return xxx_messageInfo_FieldTransform.Size(m)
+}
+func (m *FieldTransform) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldTransform.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldTransform proto.InternalMessageInfo
+
+func (m *FieldTransform) GetFieldID() int64 {
+	if m != nil {
+		return m.FieldID
+	}
+	return 0
+}
+
+func (m *FieldTransform) GetType() FieldTransformType {
+	if m != nil {
+		return m.Type
+	}
+	return FieldTransformType_NoTransform
+}
+
+func (m *FieldTransform) GetPrecision() int32 {
+	if m != nil {
+		return m.Precision
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("milvus.proto.query.PartitionState", PartitionState_name, PartitionState_value)
+	proto.RegisterEnum("milvus.proto.query.TriggerCondition", TriggerCondition_name, TriggerCondition_value)
+	proto.RegisterEnum("milvus.proto.query.LoadType", LoadType_name, LoadType_value)
+	proto.RegisterEnum("milvus.proto.query.FieldTransformType", FieldTransformType_name, FieldTransformType_value)
+	proto.RegisterEnum("milvus.proto.query.SegmentInfoDetail", SegmentInfoDetail_name, SegmentInfoDetail_value)
+	proto.RegisterType((*ShowCollectionsRequest)(nil), "milvus.proto.query.ShowCollectionsRequest")
+	proto.RegisterType((*ShowCollectionsResponse)(nil), "milvus.proto.query.ShowCollectionsResponse")
+	proto.RegisterType((*ShowPartitionsRequest)(nil), "milvus.proto.query.ShowPartitionsRequest")
+	proto.RegisterType((*ShowPartitionsResponse)(nil), "milvus.proto.query.ShowPartitionsResponse")
+	proto.RegisterType((*LoadCollectionRequest)(nil), "milvus.proto.query.LoadCollectionRequest")
+	proto.RegisterType((*ReleaseCollectionRequest)(nil), "milvus.proto.query.ReleaseCollectionRequest")
+	proto.RegisterType((*LoadPartitionsRequest)(nil), "milvus.proto.query.LoadPartitionsRequest")
+	proto.RegisterType((*ReleasePartitionsRequest)(nil), "milvus.proto.query.ReleasePartitionsRequest")
+	proto.RegisterType((*CreateQueryChannelRequest)(nil), "milvus.proto.query.CreateQueryChannelRequest")
+	proto.RegisterType((*CreateQueryChannelResponse)(nil), "milvus.proto.query.CreateQueryChannelResponse")
+	proto.RegisterType((*GetPartitionStatesRequest)(nil), "milvus.proto.query.GetPartitionStatesRequest")
+	proto.RegisterType((*GetPartitionStatesResponse)(nil), "milvus.proto.query.GetPartitionStatesResponse")
+	proto.RegisterType((*GetSegmentInfoRequest)(nil), "milvus.proto.query.GetSegmentInfoRequest")
+	proto.RegisterType((*GetSegmentInfoResponse)(nil), "milvus.proto.query.GetSegmentInfoResponse")
+	proto.RegisterType((*GetShardLeadersRequest)(nil), "milvus.proto.query.GetShardLeadersRequest")
+	proto.RegisterType((*GetShardLeadersResponse)(nil), "milvus.proto.query.GetShardLeadersResponse")
+	proto.RegisterType((*ShardLeadersList)(nil), "milvus.proto.query.ShardLeadersList")
+	proto.RegisterType((*AddQueryChannelRequest)(nil), "milvus.proto.query.AddQueryChannelRequest")
+	proto.RegisterType((*RemoveQueryChannelRequest)(nil), "milvus.proto.query.RemoveQueryChannelRequest")
+	proto.RegisterType((*LoadMetaInfo)(nil), "milvus.proto.query.LoadMetaInfo")
+	proto.RegisterType((*WatchDmChannelsRequest)(nil), "milvus.proto.query.WatchDmChannelsRequest")
+	proto.RegisterType((*WatchDeltaChannelsRequest)(nil), "milvus.proto.query.WatchDeltaChannelsRequest")
+	proto.RegisterType((*SegmentLoadInfo)(nil), "milvus.proto.query.SegmentLoadInfo")
+	proto.RegisterType((*FieldIndexInfo)(nil), "milvus.proto.query.FieldIndexInfo")
+	proto.RegisterType((*LoadSegmentsRequest)(nil), "milvus.proto.query.LoadSegmentsRequest")
+	proto.RegisterType((*ReleaseSegmentsRequest)(nil), "milvus.proto.query.ReleaseSegmentsRequest")
+	proto.RegisterType((*SearchRequest)(nil), "milvus.proto.query.SearchRequest")
+	proto.RegisterType((*QueryRequest)(nil), "milvus.proto.query.QueryRequest")
+	proto.RegisterType((*HandoffSegmentsRequest)(nil), "milvus.proto.query.HandoffSegmentsRequest")
+	proto.RegisterType((*LoadBalanceRequest)(nil), "milvus.proto.query.LoadBalanceRequest")
+	proto.RegisterType((*DmChannelWatchInfo)(nil), "milvus.proto.query.DmChannelWatchInfo")
+	proto.RegisterType((*QueryChannelInfo)(nil), "milvus.proto.query.QueryChannelInfo")
+	proto.RegisterType((*PartitionStates)(nil), "milvus.proto.query.PartitionStates")
+	proto.RegisterType((*SegmentInfo)(nil), "milvus.proto.query.SegmentInfo")
+	proto.RegisterType((*CollectionInfo)(nil), "milvus.proto.query.CollectionInfo")
+	proto.RegisterType((*UnsubscribeChannels)(nil), "milvus.proto.query.UnsubscribeChannels")
+	proto.RegisterType((*UnsubscribeChannelInfo)(nil), "milvus.proto.query.UnsubscribeChannelInfo")
+	proto.RegisterType((*SegmentChangeInfo)(nil), "milvus.proto.query.SegmentChangeInfo")
+	proto.RegisterType((*SealedSegmentsChangeInfo)(nil), "milvus.proto.query.SealedSegmentsChangeInfo")
+	proto.RegisterType((*SyncDistributionRequest)(nil), "milvus.proto.query.SyncDistributionRequest")
+	proto.RegisterType((*SegmentServingAction)(nil), "milvus.proto.query.SegmentServingAction")
+	proto.RegisterType((*PauseChannelRequest)(nil), "milvus.proto.query.PauseChannelRequest")
+	proto.RegisterType((*ResumeChannelRequest)(nil), "milvus.proto.query.ResumeChannelRequest")
+	proto.RegisterType((*ExportSegmentDeletesRequest)(nil), "milvus.proto.query.ExportSegmentDeletesRequest")
+	proto.RegisterType((*ExportSegmentDeletesResponse)(nil), "milvus.proto.query.ExportSegmentDeletesResponse")
+	proto.RegisterType((*SegmentBloomFilterStats)(nil), "milvus.proto.query.SegmentBloomFilterStats")
+	proto.RegisterType((*FieldLoadStats)(nil), "milvus.proto.query.FieldLoadStats")
+	proto.RegisterType((*SegmentLoadStats)(nil), "milvus.proto.query.SegmentLoadStats")
+	proto.RegisterType((*UpdateLoadConfigRequest)(nil), "milvus.proto.query.UpdateLoadConfigRequest")
+	proto.RegisterType((*LoadConfigUpdateResult)(nil), "milvus.proto.query.LoadConfigUpdateResult")
+	proto.RegisterType((*UpdateLoadConfigResponse)(nil), "milvus.proto.query.UpdateLoadConfigResponse")
+	proto.RegisterType((*GetDataDistributionRequest)(nil), "milvus.proto.query.GetDataDistributionRequest")
+	proto.RegisterType((*DmChannelOwnership)(nil), "milvus.proto.query.DmChannelOwnership")
+	proto.RegisterType((*GetDataDistributionResponse)(nil), "milvus.proto.query.GetDataDistributionResponse")
+	proto.RegisterType((*SegmentFieldIndex)(nil), "milvus.proto.query.SegmentFieldIndex")
+	proto.RegisterType((*PromoteSegmentsRequest)(nil), "milvus.proto.query.PromoteSegmentsRequest")
+	proto.RegisterType((*RefreshIndexRequest)(nil), "milvus.proto.query.RefreshIndexRequest")
+	proto.RegisterType((*WatchDmChannelsResponse)(nil), "milvus.proto.query.WatchDmChannelsResponse")
+	proto.RegisterType((*DmChannelWatchStatus)(nil), "milvus.proto.query.DmChannelWatchStatus")
+	proto.RegisterType((*FieldTransform)(nil), "milvus.proto.query.FieldTransform")
+}
+
+func init() { proto.RegisterFile("query_coord.proto", fileDescriptor_aab7cc9a69ed26e8) }
+
+var fileDescriptor_aab7cc9a69ed26e8 = []byte{
+	// 4353 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3b, 0x5b, 0x6f, 0x1c, 0x59,
+	0x5a, 0xa9, 0xbe, 0xd8, 0xdd, 0x5f, 0x5f, 0x5c, 0x39, 0x76, 0x9c, 0x4e, 0xcf, 0xcd, 0x53, 0x33,
+	0xc9, 0x18, 0x67, 0x26, 0x09, 0x9e, 0x65, 0xb5, 0xcb, 0x2e, 0x42, 0xb1, 0x3d, 0xc9, 0x9a, 0x49,
+	0x1c, 0x6f, 0xd9, 0x99, 0xdd, 0x1d, 0x8d, 0x28, 0xaa, 0xab, 0x4e, 0xb7, 0x4b, 0xa9, 0x4b, 0xa7,
+	0x4e, 0x75, 0x1c, 0x0f, 0x8f, 0xac, 0x10, 0xcb, 0x45, 0x88, 0x07, 0x84, 0x90, 0x10, 0xbc, 0x70,
+	0x5b, 0x89, 0x81, 0xbf, 0xc0, 0xc3, 0x8a, 0x67, 0x04, 0xaf, 0x08, 0xf1, 0x02, 0xbc, 0x20, 0x21,
+	0x21, 0x21, 0x21, 0x21, 0x2e, 0x3a, 0xb7, 0xea, 0xba, 0xb5, 0xbb, 0x6c, 0x4f, 0x36, 0x11, 0xe2,
+	0xad, 0xce, 0x77, 0xbe, 0xef, 0x7c, 0xe7, 0xf2, 0x9d, 0xef, 0x5a, 0x07, 0x2e, 0x3f, 0x9d, 0xe0,
+	0xf0, 0xc4, 0xb0, 0x82, 0x20, 0xb4, 0x6f, 0x8d, 0xc3, 0x20, 0x0a, 0x10, 0xf2, 0x1c, 0xf7, 0xd9,
+	0x84, 0xf0, 0xd6, 0x2d, 0xd6, 0xdf, 0x6f, 0x5b, 0x81, 0xe7, 0x05, 0x3e, 0x87, 0xf5, 0xdb, 0x49,
+	0x8c, 0x7e, 0xd7, 0xf1, 0x23, 0x1c, 0xfa, 0xa6, 0x2b, 0x7b, 0x89, 0x75, 0x84, 0x3d, 0x53, 0xb4,
+	0x54, 0xdb, 0x8c, 0xcc, 0xe4, 0xf8, 0xda, 0xf7, 0x15, 0x58, 0x3d, 0x38, 0x0a, 0x8e, 0xb7, 0x03,
+	0xd7, 0xc5, 0x56, 0xe4, 0x04, 0x3e, 0xd1, 0xf1, 0xd3, 0x09, 0x26, 0x11, 0xba, 0x03, 0xb5, 0x81,
+	0x49, 0x70, 0x4f, 0x59, 0x53, 0xd6, 0x5b, 0x9b, 0xaf, 0xdf, 0x4a, 0xcd, 0x44, 0x4c, 0xe1, 0x21,
+	0x19, 0x6d, 0x99, 0x04, 0xeb, 0x0c, 0x13, 0x21, 0xa8, 0xd9, 0x83, 0xdd, 0x9d, 0x5e, 0x65, 0x4d,
+	0x59, 0xaf, 0xea, 0xec, 0x1b, 0xbd, 0x0b, 0x1d, 0x2b, 0x1e, 0x7b, 0x77, 0x87, 0xf4, 0xaa, 0x6b,
+	0xd5, 0xf5, 0xaa, 0x9e, 0x06, 0x6a, 0xff, 0xa2, 0xc0, 0xd5, 0xdc, 0x34, 0xc8, 0x38, 0xf0, 0x09,
+	0x46, 0x1f, 0xc2, 0x02, 0x89, 0xcc, 0x68, 0x42, 0xc4, 0x4c, 0x5e, 0x2b, 0x9c, 0xc9, 0x01, 0x43,
+	0xd1, 0x05, 0x6a, 0x9e, 0x6d, 0xa5, 0x80, 0x2d, 0xfa, 0x49, 0x58, 0x71, 0xfc, 0x87, 0xd8, 0x0b,
+	0xc2, 0x13, 0x63, 0x8c, 0x43, 0x0b, 0xfb, 0x91, 0x39, 0xc2, 0x72, 0x8e, 0xcb, 0xb2, 0x6f, 0x7f,
+	0xda, 0x85, 0xb6, 0xa1, 0xe3, 0x06, 0xa6, 0x8d, 0x6d, 0x63, 0xe8, 0x60, 0xd7, 0x26, 0xbd, 0xda,
+	0x5a, 0x75, 0xbd, 0xb5, 0xf9, 0x66, 0x7a, 0x52, 0x62, 0xd7, 0x1f, 0x04, 0xfe, 0xe8, 0x6e, 0x18,
+	0x9a, 0x27, 0x7a, 0x9b, 0x13, 0xdd, 0x63, 0x34, 0xda, 0x1f, 0x29, 0x70, 0x85, 0x2e, 0x77, 0xdf,
+	0x0c, 0x23, 0xe7, 0x05, 0x6c, 0xba, 0x06, 0xed, 0xe4, 0x42, 0x7b, 0x55, 0xd6, 0x97, 0x82, 0x51,
+	0x9c, 0xb1, 0x64, 0xbf, 0xbb, 0xc3, 0xd7, 0x51, 0xd5, 0x53, 0x30, 0xed, 0x0f, 0x85, 0x74, 0x24,
+	0xe7, 0x79, 0x91, 0x53, 0xc9, 0xf2, 0xac, 0xe4, 0x79, 0x9e, 0xe3, 0x4c, 0xb4, 0x7f, 0x56, 0xe0,
+	0xca, 0x83, 0xc0, 0xb4, 0xa7, 0xd2, 0xf3, 0xe3, 0xdf, 0xce, 0x9f, 0x81, 0x05, 0x7e, 0xe8, 0xbd,
+	0x1a, 0xe3, 0x75, 0xbd, 0x50, 0x20, 0xa6, 0x33, 0x3c, 0x60, 0x00, 0x5d, 0x10, 0xa1, 0xeb, 0xd0,
+	0x0d, 0xf1, 0xd8, 0x75, 0x2c, 0xd3, 0xf0, 0x27, 0xde, 0x00, 0x87, 0xbd, 0xfa, 0x9a, 0xb2, 0x5e,
+	0xd7, 0x3b, 0x02, 0xba, 0xc7, 0x80, 0xda, 0xef, 0x29, 0xd0, 0xd3, 0xb1, 0x8b, 0x4d, 0x82, 0x5f,
+	0xe6, 0x62, 0x57, 0x61, 0xc1, 0x0f, 0x6c, 0xbc, 0xbb, 0xc3, 0x16, 0x5b, 0xd5, 0x45, 0x4b, 0xfb,
+	0xb5, 0x0a, 0x3f, 0x88, 0x57, 0x5c, 0xae, 0x13, 0x87, 0x55, 0xff, 0x72, 0x0e, 0x6b, 0xa1, 0xe8,
+	0xb0, 0xfe, 0x72, 0x7a, 0x58, 0xaf, 0xfa, 0x86, 0x4c, 0x0f, 0xb4, 0x9e, 0x3a, 0xd0, 0xef, 0xc1,
+	0xb5, 0xed, 0x10, 0x9b, 0x11, 0xfe, 0x36, 0xb5, 0x3c, 0xdb, 0x47, 0xa6, 0xef, 0x63, 0x57, 0x2e,
+	0x21, 0xcb, 0x5c, 0x29, 0x60, 0xde, 0x83, 0xc5, 0x71, 0x18, 0x3c, 0x3f, 0x89, 0xe7, 0x2d, 0x9b,
+	0xda, 0x9f, 0x2a, 0xd0, 0x2f, 0x1a, 0xfb, 0x22, 0xfa, 0xe5, 0x1d, 0xe8, 0x08, 0x13, 0xca, 0x47,
+	0x63, 0x3c, 0x9b, 0x7a, 0xfb, 0x69, 0x82, 0x03, 0xba, 0x03, 0x2b, 0x1c, 0x29, 0xc4, 0x64, 0xe2,
+	0x46, 0x31, 0x6e, 0x95, 0xe1, 0x22, 0xd6, 0xa7, 0xb3, 0x2e, 0x41, 0xa1, 0xfd, 0x50, 0x81, 0x6b,
+	0xf7, 0x71, 0x14, 0x1f, 0x22, 0xe5, 0x8a, 0x5f, 0x51, 0x95, 0xfd, 0x85, 0x02, 0xfd, 0xa2, 0xb9,
+	0x5e, 0x64, 0x5b, 0x3f, 0x85, 0xd5, 0x98, 0x87, 0x61, 0x63, 0x62, 0x85, 0xce, 0x98, 0x7e, 0x73,
+	0x05, 0xde, 0xda, 0x7c, 0xe7, 0x56, 0xde, 0x4b, 0xb9, 0x95, 0x9d, 0xc1, 0x95, 0x78, 0x88, 0x9d,
+	0xc4, 0x08, 0xda, 0xbf, 0x29, 0x70, 0xe5, 0x3e, 0x8e, 0x0e, 0xf0, 0xc8, 0xc3, 0x7e, 0xb4, 0xeb,
+	0x0f, 0x83, 0xf3, 0xef, 0xeb, 0x9b, 0x00, 0x44, 0x8c, 0x13, 0x1b, 0x97, 0x04, 0xa4, 0xac, 0x1e,
+	0xb7, 0x71, 0x64, 0x3a, 0x2e, 0x53, 0x6d, 0xdd, 0xcd, 0xeb, 0x45, 0x6b, 0x4b, 0xcc, 0x76, 0x87,
+	0x21, 0xeb, 0x82, 0x28, 0xef, 0x77, 0xd4, 0x8b, 0xdc, 0x1d, 0xea, 0x75, 0x65, 0x17, 0x7d, 0x91,
+	0x03, 0xfa, 0x29, 0xa8, 0x3b, 0xfe, 0x30, 0x90, 0xe7, 0xf1, 0xd6, 0x9c, 0x39, 0xeb, 0x1c, 0x5b,
+	0xf3, 0xf9, 0x2c, 0x8e, 0xcc, 0xd0, 0x7e, 0x80, 0x4d, 0x1b, 0x87, 0x17, 0x90, 0xe9, 0xec, 0xde,
+	0x56, 0xf2, 0x7b, 0xab, 0xfd, 0xba, 0x02, 0x57, 0x73, 0x0c, 0x2f, 0xb2, 0xee, 0x6f, 0xc2, 0x02,
+	0xa1, 0x83, 0xc9, 0x85, 0xbf, 0x5b, 0xb8, 0xf0, 0x04, 0xbb, 0x07, 0x0e, 0x89, 0x74, 0x41, 0xa3,
+	0x05, 0xa0, 0x66, 0xfb, 0xd0, 0xdb, 0xd0, 0x16, 0xfa, 0xc0, 0xf0, 0x4d, 0x8f, 0x6f, 0x40, 0x53,
+	0x6f, 0x09, 0xd8, 0x9e, 0xe9, 0x61, 0x74, 0x0d, 0x1a, 0x54, 0x3b, 0x1a, 0x8e, 0x2d, 0x65, 0x6c,
+	0x91, 0xb6, 0x77, 0x6d, 0x82, 0xde, 0x00, 0x60, 0x5d, 0xa6, 0x6d, 0x87, 0xdc, 0x63, 0x69, 0xea,
+	0x4d, 0x0a, 0xb9, 0x4b, 0x01, 0xda, 0x7f, 0x55, 0x60, 0xf5, 0xae, 0x6d, 0x17, 0xe9, 0xd2, 0xb3,
+	0x6f, 0xf8, 0x54, 0x65, 0x57, 0x92, 0x2a, 0xbb, 0x94, 0x90, 0xe7, 0xf4, 0x64, 0xed, 0x0c, 0x7a,
+	0xb2, 0x3e, 0x4b, 0x4f, 0xa2, 0xfb, 0xd0, 0x21, 0x18, 0x3f, 0x31, 0xc6, 0x01, 0x61, 0x17, 0x9d,
+	0x99, 0xc5, 0xd6, 0xa6, 0x96, 0x5e, 0x4d, 0x1c, 0xa1, 0x3c, 0x24, 0xa3, 0x7d, 0x81, 0xa9, 0xb7,
+	0x29, 0xa1, 0x6c, 0xa1, 0xc7, 0xb0, 0x3a, 0x72, 0x83, 0x81, 0xe9, 0x1a, 0x04, 0x9b, 0x2e, 0xb6,
+	0x0d, 0x71, 0x89, 0x49, 0x6f, 0xb1, 0x9c, 0x80, 0xaf, 0x70, 0xf2, 0x03, 0x46, 0x2d, 0x3a, 0x88,
+	0xf6, 0x0f, 0x0a, 0x5c, 0xd3, 0xb1, 0x17, 0x3c, 0xc3, 0xff, 0x57, 0x8f, 0x40, 0xfb, 0x2d, 0x05,
+	0xda, 0xd4, 0x03, 0x7b, 0x88, 0x23, 0x93, 0xee, 0x04, 0xfa, 0x3a, 0x34, 0x69, 0xe8, 0x61, 0x44,
+	0x27, 0x63, 0xbe, 0xb4, 0x6e, 0x76, 0x69, 0x7c, 0xf7, 0x28, 0xd1, 0xe1, 0xc9, 0x18, 0xeb, 0x0d,
+	0x57, 0x7c, 0x95, 0xb9, 0xd2, 0x39, 0x93, 0x54, 0x2d, 0x30, 0x49, 0x7f, 0x55, 0x87, 0xd5, 0xef,
+	0x98, 0x91, 0x75, 0xb4, 0xe3, 0x89, 0x69, 0x92, 0x97, 0xb3, 0xe7, 0x65, 0x3c, 0xa1, 0x58, 0x95,
+	0xd6, 0x8b, 0x24, 0x8d, 0xc6, 0xcf, 0xb7, 0x3e, 0x11, 0xc7, 0x90, 0x50, 0xa5, 0x09, 0x8f, 0x72,
+	0xe1, 0x3c, 0x1e, 0xe5, 0x36, 0x74, 0xf0, 0x73, 0xcb, 0x9d, 0x50, 0xb5, 0xc2, 0xb8, 0x2f, 0x16,
+	0x45, 0x95, 0x8c, 0x7b, 0x52, 0xcc, 0xdb, 0x82, 0x68, 0x57, 0xcc, 0x81, 0x1f, 0xb5, 0x87, 0x23,
+	0xb3, 0xd7, 0x60, 0xd3, 0x58, 0x9b, 0x75, 0xd4, 0x52, 0x3e, 0xf8, 0x71, 0xd3, 0x16, 0x7a, 0x1d,
+	0x9a, 0xc2, 0x7f, 0xdd, 0xdd, 0xe9, 0x35, 0xd9, 0xf6, 0x4d, 0x01, 0xe8, 0x7d, 0x40, 0xe2, 0x12,
+	0x1a, 0x61, 0x70, 0x6c, 0x0c, 0x26, 0xf6, 0x08, 0x47, 0x3d, 0x60, 0x68, 0xaa, 0xe8, 0xd1, 0x83,
+	0xe3, 0x2d, 0x06, 0x47, 0x5f, 0x81, 0xd5, 0xe9, 0xce, 0x1b, 0x51, 0x44, 0x2f, 0xb2, 0x15, 0xf8,
+	0x36, 0xe9, 0xb5, 0x18, 0xc5, 0xca, 0xb4, 0xf7, 0x30, 0x72, 0x0f, 0x78, 0x1f, 0xe5, 0x31, 0x0a,
+	0x83, 0x63, 0xc7, 0x1f, 0x19, 0xd6, 0xd1, 0xc4, 0x7f, 0x42, 0x39, 0x91, 0x5e, 0x9b, 0xf3, 0x10,
+	0x3d, 0xdb, 0xb4, 0x43, 0x0f, 0x8e, 0x09, 0x75, 0x2d, 0x9f, 0xe1, 0x90, 0x50, 0x3d, 0xd3, 0xe1,
+	0xae, 0xa5, 0x68, 0xa2, 0x77, 0xa1, 0x6b, 0xba, 0xae, 0x11, 0x84, 0x86, 0x1f, 0x44, 0x47, 0x8e,
+	0x3f, 0xea, 0x75, 0xd7, 0x94, 0xf5, 0x86, 0xde, 0x36, 0x5d, 0xf7, 0x51, 0xb8, 0xc7, 0x61, 0xf4,
+	0x72, 0x79, 0xe6, 0x73, 0xc3, 0x0a, 0x7c, 0x6b, 0x12, 0x86, 0x6c, 0x61, 0xd8, 0xb4, 0x49, 0x6f,
+	0x89, 0x0d, 0x86, 0x3c, 0xf3, 0xf9, 0x76, 0xdc, 0xa5, 0xd3, 0x1e, 0xed, 0x7f, 0x14, 0xb8, 0xc6,
+	0x05, 0x19, 0xbb, 0x91, 0xf9, 0x72, 0x65, 0x39, 0x96, 0xd3, 0xda, 0x19, 0xe5, 0x34, 0x21, 0x23,
+	0xcd, 0xb3, 0xca, 0x88, 0xf6, 0x45, 0x1d, 0x96, 0x84, 0x00, 0x52, 0x0c, 0xda, 0x4b, 0xe5, 0x26,
+	0xf6, 0xb1, 0x44, 0x0c, 0x30, 0x05, 0xa0, 0x35, 0x68, 0x25, 0xee, 0x97, 0x58, 0x68, 0x12, 0x54,
+	0x6a, 0xb5, 0xd2, 0x63, 0xae, 0x25, 0x3c, 0xe6, 0x37, 0x00, 0x86, 0xee, 0x84, 0x1c, 0x19, 0x91,
+	0xe3, 0x61, 0x11, 0xb7, 0x34, 0x19, 0xe4, 0xd0, 0xf1, 0x30, 0xba, 0x0b, 0xed, 0x81, 0xe3, 0xbb,
+	0xc1, 0xc8, 0x18, 0x9b, 0xd1, 0x11, 0xe9, 0x2d, 0xcc, 0xbc, 0x51, 0x2c, 0x29, 0xb3, 0xc5, 0x70,
+	0xf5, 0x16, 0xa7, 0xd9, 0xa7, 0x24, 0xe8, 0x4d, 0x68, 0xf9, 0x13, 0xcf, 0x08, 0x86, 0x5c, 0x10,
+	0x17, 0x39, 0x0b, 0x7f, 0xe2, 0x3d, 0x1a, 0x32, 0x09, 0xfc, 0x26, 0x34, 0x49, 0x64, 0x46, 0xc4,
+	0x0d, 0x46, 0xa4, 0xd7, 0x28, 0x35, 0xfe, 0x94, 0x80, 0x52, 0xdb, 0x54, 0x8e, 0x18, 0x75, 0xb3,
+	0x1c, 0x75, 0x4c, 0x80, 0x6e, 0x40, 0xd7, 0x0a, 0xbc, 0xb1, 0xc9, 0x76, 0xe8, 0x5e, 0x18, 0x78,
+	0x3d, 0x60, 0xda, 0x2c, 0x03, 0x45, 0xdb, 0xd0, 0x72, 0x7c, 0x1b, 0x3f, 0x17, 0x7a, 0xa5, 0xb5,
+	0x56, 0xcd, 0x5b, 0x64, 0x7e, 0xe4, 0x8c, 0xd1, 0x2e, 0xc5, 0x65, 0x87, 0x0e, 0x8e, 0xfc, 0x24,
+	0xd4, 0x2b, 0x92, 0x97, 0x9f, 0x38, 0x9f, 0x63, 0x71, 0x25, 0x5b, 0x02, 0x76, 0xe0, 0x7c, 0x8e,
+	0x69, 0x4c, 0xec, 0xf8, 0x04, 0x87, 0x53, 0x23, 0xd5, 0x61, 0x46, 0xaa, 0xc3, 0xa1, 0xd2, 0xa2,
+	0x25, 0x2e, 0x6d, 0x37, 0x7d, 0x69, 0xdf, 0x83, 0x25, 0x1b, 0xbb, 0x38, 0xc2, 0x06, 0xf1, 0xcd,
+	0x31, 0x39, 0x0a, 0x22, 0x76, 0x13, 0xdb, 0x7a, 0x97, 0x83, 0x0f, 0x04, 0x14, 0x6d, 0xc0, 0xe5,
+	0x81, 0x1b, 0x04, 0x9e, 0x31, 0x74, 0xdc, 0x08, 0x87, 0xec, 0x78, 0x7b, 0x2a, 0x63, 0xb6, 0xc4,
+	0x3a, 0xee, 0x31, 0x38, 0x3d, 0x42, 0xed, 0x2f, 0x2a, 0xd0, 0x4d, 0xaf, 0x8b, 0xce, 0x80, 0x65,
+	0xee, 0x62, 0x61, 0x95, 0x4d, 0xba, 0x4a, 0xec, 0x9b, 0x03, 0x97, 0xea, 0x60, 0x1b, 0x3f, 0x67,
+	0xb2, 0xda, 0xd0, 0x5b, 0x1c, 0xc6, 0x06, 0xa0, 0x32, 0xc7, 0x77, 0x93, 0x39, 0x87, 0x3c, 0x62,
+	0x6c, 0x32, 0x08, 0x73, 0x0d, 0x7b, 0xb0, 0xc8, 0x77, 0x4d, 0x4a, 0xaa, 0x6c, 0xd2, 0x9e, 0xc1,
+	0xc4, 0x61, 0x5c, 0xb9, 0xa4, 0xca, 0x26, 0xda, 0x81, 0x36, 0x1f, 0x72, 0x6c, 0x86, 0xa6, 0x27,
+	0xe5, 0xf4, 0xed, 0x42, 0xf5, 0xf1, 0x31, 0x3e, 0xf9, 0xc4, 0x74, 0x27, 0x78, 0xdf, 0x74, 0x42,
+	0x9d, 0x9f, 0xeb, 0x3e, 0xa3, 0x42, 0xeb, 0xa0, 0xf2, 0x51, 0x86, 0x8e, 0x8b, 0x85, 0xc4, 0x2f,
+	0x32, 0xff, 0xb3, 0xcb, 0xe0, 0xf7, 0x1c, 0x17, 0x73, 0xa1, 0x8e, 0x97, 0xc0, 0x4e, 0xb2, 0xc1,
+	0x65, 0x9a, 0x41, 0xe8, 0x39, 0x6a, 0xff, 0x51, 0x83, 0x65, 0x7a, 0xb5, 0xa5, 0xd3, 0x74, 0x7e,
+	0xed, 0xf6, 0x06, 0x80, 0x4d, 0x22, 0x23, 0xa5, 0xe1, 0x9a, 0x36, 0x89, 0xf6, 0x18, 0x00, 0x7d,
+	0x5d, 0x2a, 0xb0, 0xea, 0xec, 0x18, 0x32, 0xa3, 0x6a, 0xf2, 0xc6, 0xf6, 0x5c, 0xb9, 0xb6, 0x77,
+	0xa0, 0x43, 0x82, 0x49, 0x68, 0x61, 0x23, 0x95, 0xf3, 0x68, 0x73, 0xe0, 0x5e, 0xb1, 0x0e, 0x5e,
+	0x28, 0x8c, 0x15, 0x13, 0xca, 0x74, 0xf1, 0x62, 0x06, 0xb7, 0x51, 0x64, 0x70, 0x4f, 0x7c, 0x8b,
+	0xcb, 0xa2, 0x41, 0x89, 0xa8, 0x21, 0x6b, 0x32, 0x99, 0x54, 0x69, 0x0f, 0x93, 0xc8, 0x07, 0x1c,
+	0x4e, 0xd7, 0x64, 0xe3, 0x21, 0x0e, 0x0d, 0x82, 0xc3, 0x67, 0x14, 0x11, 0xb8, 0xc5, 0x63, 0xc0,
+	0x03, 0x0e, 0xa3, 0x42, 0x48, 0x22, 0xd3, 0xb7, 0x07, 0x27, 0xcc, 0x0c, 0x37, 0x74, 0xd9, 0x3c,
+	0xc5, 0x5e, 0xb7, 0x4f, 0xb1, 0xd7, 0x0f, 0x41, 0x65, 0x77, 0xc7, 0x88, 0x42, 0xd3, 0x27, 0xc3,
+	0x20, 0xf4, 0x48, 0xaf, 0x33, 0x47, 0xc1, 0x1c, 0x4a, 0x54, 0x7d, 0x69, 0x98, 0x6a, 0x13, 0xed,
+	0xef, 0x15, 0x58, 0x15, 0xf9, 0xb2, 0x8b, 0x4b, 0xdf, 0x2c, 0xdb, 0x2a, 0x2d, 0x49, 0xf5, 0x94,
+	0xdc, 0x4b, 0xad, 0x84, 0xef, 0x58, 0x2f, 0xf0, 0x1d, 0xd3, 0xf9, 0x87, 0x85, 0x6c, 0xfe, 0x41,
+	0xfb, 0x15, 0x05, 0x3a, 0x07, 0xd8, 0x0c, 0xad, 0x23, 0xb9, 0xae, 0xaf, 0x42, 0x35, 0xc4, 0x4f,
+	0xc5, 0xb2, 0xde, 0x9d, 0x11, 0x27, 0xa5, 0x48, 0x74, 0x4a, 0x80, 0xde, 0x82, 0x96, 0xed, 0xb9,
+	0x99, 0x34, 0x17, 0xd8, 0x9e, 0x2b, 0xf5, 0x6c, 0x7a, 0x2a, 0xd5, 0xdc, 0x54, 0x7e, 0xa0, 0x40,
+	0xfb, 0xdb, 0x3c, 0x7c, 0xe0, 0x33, 0xf9, 0x5a, 0x72, 0x26, 0x37, 0x66, 0xcc, 0x44, 0xc7, 0x51,
+	0xe8, 0xe0, 0x67, 0xf8, 0xcb, 0x9d, 0xcb, 0x6f, 0x2a, 0xb0, 0xfa, 0x2d, 0xd3, 0xb7, 0x83, 0xe1,
+	0xf0, 0xe2, 0xe7, 0xbe, 0x1d, 0x9b, 0xaa, 0xdd, 0xb3, 0x64, 0x44, 0x52, 0x44, 0xda, 0x9f, 0x55,
+	0x00, 0xd1, 0x9b, 0xb5, 0x65, 0xba, 0xa6, 0x6f, 0xe1, 0xf3, 0xcf, 0xe6, 0x3a, 0x74, 0x53, 0xaa,
+	0x26, 0xae, 0x43, 0x25, 0x75, 0x0d, 0x41, 0x1f, 0x43, 0x77, 0xc0, 0x59, 0x51, 0x1f, 0x94, 0x04,
+	0x3e, 0x13, 0xcf, 0x6e, 0x71, 0x3e, 0xe3, 0x30, 0x74, 0x46, 0x23, 0x1c, 0x6e, 0x07, 0xbe, 0xcd,
+	0x63, 0xe7, 0xce, 0x40, 0x4e, 0x93, 0x92, 0xb2, 0xf3, 0x88, 0xf5, 0xae, 0x0c, 0x72, 0x20, 0x56,
+	0xbc, 0x04, 0xdd, 0x84, 0xcb, 0xe9, 0xb0, 0x7a, 0x2a, 0xcf, 0x2a, 0x49, 0x46, 0xcc, 0x45, 0x39,
+	0xb3, 0x02, 0x3d, 0xa8, 0xfd, 0xae, 0x02, 0x28, 0x8e, 0xed, 0x98, 0x83, 0xcc, 0x2c, 0x6d, 0x99,
+	0xfc, 0xf0, 0xeb, 0xd0, 0xb4, 0xbd, 0xed, 0x94, 0xe8, 0x4c, 0x01, 0x54, 0xab, 0xf1, 0x65, 0x18,
+	0xbc, 0x7c, 0x26, 0x7d, 0x43, 0x0e, 0x7c, 0xc0, 0x60, 0x69, 0x35, 0x5a, 0xcb, 0xa8, 0x51, 0xed,
+	0x8b, 0x0a, 0xa8, 0xc9, 0x68, 0xbf, 0xf4, 0xcc, 0x5e, 0x4c, 0x2e, 0xf9, 0x94, 0xd4, 0x46, 0xed,
+	0x02, 0xa9, 0x8d, 0x7c, 0xea, 0xa5, 0x7e, 0xbe, 0xd4, 0x8b, 0xf6, 0xfb, 0x0a, 0x2c, 0x65, 0x52,
+	0xb7, 0x59, 0x1f, 0x5e, 0xc9, 0xfb, 0xf0, 0x5f, 0x83, 0x3a, 0xa1, 0xb8, 0x6c, 0x93, 0xba, 0xc5,
+	0xea, 0x3f, 0x3d, 0xaa, 0xce, 0x09, 0xd0, 0x6d, 0x58, 0x2e, 0x28, 0xf7, 0x89, 0x83, 0x46, 0xf9,
+	0x6a, 0x9f, 0xf6, 0x77, 0x8b, 0xd0, 0x4a, 0xec, 0xc7, 0x9c, 0xf0, 0xa3, 0x4c, 0x0e, 0x23, 0xb3,
+	0xbc, 0x6a, 0x7e, 0x79, 0x33, 0xea, 0x5d, 0x34, 0x15, 0xe8, 0x61, 0x8f, 0x7b, 0x52, 0xc2, 0xad,
+	0xf3, 0xb0, 0xc7, 0xfc, 0x61, 0x9a, 0x25, 0x9c, 0x78, 0x3c, 0x70, 0xe0, 0x77, 0x66, 0xd1, 0x9f,
+	0x78, 0x2c, 0x6c, 0x48, 0x3b, 0x91, 0x8b, 0xa7, 0x38, 0x91, 0x8d, 0xb4, 0x13, 0x99, 0xba, 0x2c,
+	0xcd, 0xec, 0x65, 0x29, 0x1b, 0x11, 0xdc, 0x81, 0x65, 0x8b, 0xd5, 0x5d, 0xec, 0xad, 0x93, 0xed,
+	0xb8, 0x4b, 0x78, 0x04, 0x45, 0x5d, 0xe8, 0x1e, 0x74, 0xc4, 0x8e, 0x1a, 0xfc, 0x94, 0xdb, 0xec,
+	0x94, 0x8b, 0x7d, 0x54, 0x71, 0x36, 0xfc, 0x90, 0xdb, 0x24, 0xd1, 0xca, 0xc6, 0x22, 0x9d, 0x73,
+	0xc5, 0x22, 0x6f, 0x41, 0x4b, 0x16, 0xdf, 0x68, 0x06, 0xb6, 0xcb, 0xd5, 0x9b, 0xbc, 0xf0, 0x36,
+	0x49, 0xe5, 0x67, 0x97, 0xd2, 0xf9, 0xd9, 0x44, 0xf4, 0xa1, 0xa6, 0xa3, 0x8f, 0x77, 0xa0, 0x23,
+	0xbc, 0x70, 0xec, 0x33, 0x47, 0xeb, 0x32, 0xf7, 0x9f, 0xb8, 0x8f, 0xcd, 0x61, 0xe8, 0x7b, 0x80,
+	0x52, 0x91, 0x07, 0x8b, 0xe5, 0x7a, 0x88, 0xdd, 0xb4, 0x9b, 0xa7, 0xdc, 0xdb, 0xad, 0x69, 0x54,
+	0x42, 0x37, 0x82, 0xe8, 0xea, 0x20, 0x03, 0x41, 0xdb, 0x00, 0xcc, 0x95, 0xe4, 0x43, 0x2e, 0x17,
+	0xf9, 0x03, 0x39, 0x97, 0x98, 0x8f, 0xd5, 0x74, 0xe5, 0x27, 0x15, 0xe4, 0xa7, 0x13, 0x33, 0x34,
+	0xfd, 0xc8, 0xf1, 0xb1, 0xdd, 0x5b, 0xe1, 0xf1, 0x4b, 0x02, 0x54, 0xe8, 0xb1, 0x5d, 0x39, 0xb7,
+	0xc7, 0xc6, 0x5c, 0x7c, 0x87, 0x3c, 0x31, 0x26, 0x84, 0xde, 0xd9, 0x55, 0xe1, 0xe2, 0x3b, 0xe4,
+	0xc9, 0x63, 0x0a, 0x40, 0x9b, 0x70, 0xc5, 0x35, 0x49, 0x64, 0x88, 0xb8, 0x8e, 0xc6, 0xe9, 0x24,
+	0x32, 0xbd, 0x71, 0xef, 0xea, 0x9a, 0xb2, 0x5e, 0xd3, 0x97, 0x69, 0xe7, 0x0e, 0xeb, 0x3b, 0x94,
+	0x5d, 0xda, 0x5f, 0x57, 0xa1, 0x3b, 0xf5, 0xdc, 0x4b, 0x6b, 0xeb, 0x32, 0x7f, 0x16, 0xec, 0x81,
+	0x1a, 0xb7, 0xb9, 0x20, 0x9f, 0x1a, 0x7c, 0x64, 0x0b, 0x58, 0x4b, 0xe3, 0x34, 0x20, 0x9d, 0x5a,
+	0xad, 0x9d, 0x29, 0xb5, 0x7a, 0xc1, 0x02, 0xf4, 0x87, 0x70, 0x25, 0xe4, 0x8e, 0xb2, 0x6d, 0xa4,
+	0x96, 0xcd, 0x7d, 0xce, 0x15, 0xd9, 0xb9, 0x9f, 0x5c, 0xfe, 0x0c, 0x4d, 0xbb, 0x38, 0x4b, 0xd3,
+	0x66, 0x6f, 0x5a, 0x23, 0x77, 0xd3, 0xf2, 0x75, 0xf0, 0x66, 0x51, 0x1d, 0xfc, 0x31, 0x2c, 0x3f,
+	0xf6, 0xc9, 0x64, 0x40, 0xab, 0x7e, 0x03, 0x2c, 0xd3, 0x66, 0xa5, 0x8e, 0xb5, 0x0f, 0x0d, 0x61,
+	0x52, 0xf9, 0x91, 0x36, 0xf5, 0xb8, 0xad, 0xfd, 0xaa, 0x02, 0xab, 0xf9, 0x71, 0x99, 0xc4, 0x4c,
+	0xf5, 0xb5, 0x92, 0xd2, 0xd7, 0xdf, 0x85, 0xe5, 0x44, 0x98, 0x93, 0x1a, 0xb9, 0xb5, 0xf9, 0x5e,
+	0xd1, 0xd9, 0x15, 0x4c, 0x5c, 0x47, 0xd3, 0x31, 0x24, 0x4c, 0xfb, 0x77, 0x05, 0x2e, 0x8b, 0xab,
+	0x49, 0x61, 0x23, 0x96, 0x92, 0xa5, 0x5a, 0x25, 0xf0, 0x5d, 0xc7, 0xc7, 0x46, 0x6a, 0x3a, 0x6d,
+	0x0e, 0x14, 0x91, 0xe6, 0xb7, 0x60, 0x49, 0x20, 0xc5, 0xae, 0x40, 0x49, 0xa7, 0xb5, 0xcb, 0xe9,
+	0x62, 0x27, 0xe0, 0x3a, 0x74, 0x83, 0xe1, 0x30, 0xc9, 0x8f, 0xdb, 0xb2, 0x8e, 0x80, 0x0a, 0x86,
+	0x3f, 0x07, 0xaa, 0x44, 0x3b, 0xab, 0xf3, 0xb1, 0x24, 0x08, 0xe3, 0x92, 0xca, 0x0f, 0x14, 0xe8,
+	0xa5, 0x5d, 0x91, 0xc4, 0xf2, 0xcf, 0xee, 0x2f, 0x7f, 0x23, 0x5d, 0xc8, 0x3c, 0xad, 0xf8, 0x3a,
+	0xe5, 0x23, 0xcb, 0x99, 0xff, 0x48, 0x7f, 0x22, 0x3b, 0xf1, 0xad, 0x1d, 0x87, 0x44, 0xa1, 0x33,
+	0x98, 0x5c, 0xec, 0xdf, 0x98, 0x8b, 0x24, 0x67, 0xb7, 0x60, 0x91, 0x9b, 0x4e, 0xb9, 0xb1, 0xeb,
+	0xa7, 0x2c, 0x44, 0x44, 0xe7, 0x77, 0x19, 0x81, 0x2e, 0x09, 0x93, 0xb6, 0xaa, 0x9e, 0xb2, 0x55,
+	0xda, 0x1e, 0xac, 0x14, 0x91, 0xce, 0xf1, 0x84, 0x68, 0xf0, 0xcf, 0xd1, 0x45, 0x62, 0x4b, 0x36,
+	0xb5, 0x3f, 0x56, 0x60, 0x79, 0xdf, 0x9c, 0x10, 0xfc, 0x52, 0x0b, 0x62, 0xd9, 0xca, 0x6b, 0x2d,
+	0x57, 0x79, 0xd5, 0xfe, 0x44, 0x81, 0x15, 0xea, 0x4d, 0x7b, 0xaf, 0xfc, 0x4c, 0x7f, 0xa8, 0xc0,
+	0x6b, 0x1f, 0x3d, 0x1f, 0x07, 0xa1, 0xac, 0xf1, 0x73, 0x33, 0xf7, 0x92, 0x6a, 0x05, 0x29, 0xc1,
+	0xa8, 0x65, 0x04, 0x43, 0xfb, 0x0d, 0x05, 0x5e, 0x2f, 0x9e, 0xeb, 0x45, 0x4a, 0xf3, 0x29, 0x9e,
+	0x95, 0xac, 0x30, 0xf6, 0xa1, 0x11, 0x67, 0x79, 0xab, 0x2c, 0xcb, 0x1b, 0xb7, 0xb5, 0x5f, 0xaa,
+	0xc0, 0xd5, 0x19, 0x8e, 0x13, 0xf5, 0xed, 0x06, 0x8e, 0x48, 0x42, 0x2b, 0xcc, 0x89, 0x58, 0x1c,
+	0x38, 0x71, 0x02, 0xfa, 0xc8, 0x24, 0x47, 0xc6, 0x70, 0xe2, 0x5b, 0xf2, 0xe7, 0x14, 0x65, 0xbd,
+	0xa3, 0x77, 0x28, 0xf4, 0x9e, 0x04, 0xb2, 0xaa, 0x81, 0xe3, 0xba, 0x46, 0x68, 0x46, 0x4e, 0xc0,
+	0x78, 0x2b, 0x7a, 0x93, 0x42, 0x74, 0x0a, 0xa0, 0x01, 0x9d, 0x39, 0xa6, 0xbf, 0x28, 0x19, 0xd8,
+	0xc5, 0xcc, 0xe3, 0xb5, 0x82, 0x89, 0x1f, 0xb1, 0x5d, 0xab, 0xe9, 0x88, 0xf7, 0x7d, 0xc4, 0xbb,
+	0xb6, 0x69, 0x0f, 0xd5, 0xf1, 0x98, 0x44, 0x8e, 0x47, 0xbd, 0x66, 0x63, 0x38, 0xe6, 0x3f, 0xee,
+	0x29, 0x7a, 0x3b, 0x06, 0xde, 0x1b, 0x87, 0xf4, 0xf2, 0xb9, 0x41, 0xf0, 0x64, 0x32, 0x8e, 0x83,
+	0x01, 0xd1, 0xa4, 0xe7, 0x3a, 0x0e, 0x27, 0xd4, 0x5d, 0xe3, 0x86, 0x58, 0xb4, 0xb4, 0xff, 0x56,
+	0x44, 0xe6, 0x3a, 0xf6, 0xf4, 0x4e, 0xc9, 0x5c, 0xbf, 0x05, 0xa2, 0x6e, 0xc1, 0x77, 0x86, 0x6f,
+	0x37, 0x70, 0x10, 0xdb, 0x9c, 0x74, 0xd2, 0xb7, 0x9a, 0x49, 0xfa, 0x52, 0x7a, 0x3b, 0x38, 0xf6,
+	0x79, 0x32, 0x93, 0x08, 0x11, 0x01, 0x09, 0x7a, 0xc8, 0x2c, 0x8b, 0x8d, 0x09, 0x0e, 0x1d, 0xd3,
+	0x75, 0x3e, 0xc7, 0x14, 0x87, 0xeb, 0xa4, 0x4e, 0x02, 0xfa, 0x90, 0x16, 0x25, 0x96, 0x08, 0x1e,
+	0x59, 0x41, 0x88, 0x0d, 0x39, 0x16, 0x5f, 0x6e, 0x47, 0x80, 0x1f, 0xf0, 0xe1, 0x34, 0xe9, 0x6d,
+	0x4b, 0x2c, 0xbe, 0x76, 0x1e, 0x1d, 0x70, 0x1c, 0xed, 0x47, 0x15, 0x50, 0xb3, 0xce, 0x6e, 0x76,
+	0xa1, 0xca, 0x9c, 0x85, 0x56, 0xe6, 0x2c, 0xb4, 0x5a, 0x62, 0xa1, 0xb5, 0x92, 0x0b, 0xad, 0x97,
+	0x5a, 0xe8, 0x42, 0x6e, 0xa1, 0xe8, 0x2a, 0x2c, 0xca, 0x5e, 0x21, 0x02, 0x62, 0x2e, 0xdb, 0xd0,
+	0xe2, 0xce, 0x3a, 0x0f, 0x0a, 0x1a, 0x73, 0xfc, 0xf4, 0x69, 0x48, 0x00, 0x8c, 0x8c, 0x7d, 0x6b,
+	0x3f, 0x52, 0xe0, 0xea, 0xe3, 0xb1, 0x6d, 0x46, 0x98, 0xff, 0x21, 0xeb, 0x0f, 0x9d, 0xd1, 0xcb,
+	0xd1, 0x42, 0xdf, 0x80, 0x45, 0x8b, 0xb1, 0x97, 0x46, 0xb1, 0x44, 0x8d, 0x43, 0x52, 0x68, 0x21,
+	0xac, 0x4e, 0xe7, 0xcf, 0xd7, 0xc3, 0xf3, 0x2a, 0x48, 0x85, 0xea, 0x13, 0x7c, 0x22, 0x7e, 0xd4,
+	0xa1, 0x9f, 0x54, 0x49, 0x38, 0xbe, 0x31, 0x76, 0x4d, 0x0b, 0x4b, 0x53, 0xe7, 0xf8, 0xfb, 0xb4,
+	0x49, 0x53, 0x5f, 0x21, 0xe6, 0x81, 0x56, 0x36, 0x23, 0xa9, 0xf2, 0x8e, 0x69, 0xea, 0x4b, 0xfb,
+	0x6d, 0x05, 0x7a, 0xf9, 0xad, 0xbb, 0x88, 0x52, 0xdc, 0x81, 0x45, 0x9e, 0x28, 0x92, 0x0e, 0xce,
+	0xc6, 0xac, 0x78, 0x21, 0xbf, 0x50, 0x5d, 0x92, 0x6a, 0x7b, 0xec, 0x0f, 0xbf, 0x1d, 0x33, 0x32,
+	0xbf, 0x14, 0x4f, 0x47, 0xfb, 0x9b, 0x6a, 0x22, 0x7d, 0xf7, 0xe8, 0xd8, 0xc7, 0x21, 0x39, 0x72,
+	0xc6, 0x54, 0xdd, 0xc8, 0x74, 0x16, 0xdf, 0x5c, 0xd9, 0x2c, 0x95, 0x54, 0x49, 0x65, 0xe5, 0xaa,
+	0xd9, 0xe2, 0x46, 0xc2, 0xb9, 0xa9, 0xa5, 0x03, 0xf1, 0x2f, 0x2b, 0x91, 0xc5, 0x52, 0xaf, 0xd4,
+	0xc1, 0xb1, 0x30, 0x2b, 0xe9, 0x45, 0xfc, 0xee, 0xd5, 0xf4, 0x4e, 0x02, 0x7a, 0xc8, 0xf5, 0x2f,
+	0xf5, 0x7d, 0xb8, 0xfe, 0x6d, 0xe8, 0xa2, 0x85, 0x3e, 0x80, 0x65, 0x5e, 0xb9, 0x64, 0x29, 0x1c,
+	0x1a, 0x30, 0xd1, 0x92, 0x08, 0xcb, 0xc8, 0x28, 0xba, 0xca, 0xbb, 0x68, 0x36, 0x67, 0x9f, 0x96,
+	0x57, 0x2c, 0x74, 0x1b, 0x56, 0x38, 0xcc, 0x18, 0x9c, 0x44, 0x78, 0x8a, 0xdf, 0x64, 0xf8, 0x97,
+	0x79, 0xdf, 0x16, 0xed, 0x12, 0x04, 0x1f, 0xc0, 0xb2, 0x08, 0x8b, 0x53, 0xe3, 0x03, 0x1f, 0x9f,
+	0x77, 0xa5, 0xc7, 0x17, 0xe8, 0xe9, 0xf1, 0x5b, 0x7c, 0x7c, 0xde, 0x97, 0x18, 0x5f, 0xfb, 0x57,
+	0x05, 0x5e, 0x2b, 0x94, 0x92, 0x8b, 0xc8, 0xef, 0xac, 0xeb, 0xbf, 0x95, 0x08, 0xd3, 0x78, 0x44,
+	0x7d, 0xa3, 0x48, 0xb0, 0xf3, 0x42, 0x36, 0x0d, 0xe7, 0xd0, 0xcf, 0x8a, 0xb4, 0x17, 0x96, 0xea,
+	0xe1, 0x34, 0xe7, 0x7f, 0x9a, 0x1f, 0xd2, 0x25, 0x95, 0xf6, 0xb7, 0xd3, 0x10, 0x6c, 0xda, 0x5d,
+	0x36, 0x09, 0x7d, 0x8a, 0xaf, 0x92, 0x30, 0xbb, 0xd5, 0xb4, 0xd9, 0x3d, 0x4f, 0xb9, 0x37, 0x21,
+	0xf9, 0x0b, 0x69, 0xc9, 0x5f, 0x61, 0x39, 0x54, 0x17, 0x0b, 0x41, 0xe4, 0x0d, 0xed, 0x97, 0x2b,
+	0xb0, 0xba, 0x1f, 0x06, 0x5e, 0x10, 0xbd, 0xc0, 0xa2, 0x58, 0x19, 0xf5, 0x9d, 0xae, 0xe2, 0xd4,
+	0x72, 0x3f, 0xd7, 0xee, 0x40, 0xcb, 0x3a, 0xc2, 0xd6, 0x93, 0x71, 0xe0, 0xf8, 0x11, 0xaf, 0x27,
+	0x94, 0xbb, 0xb6, 0x49, 0xb2, 0xd9, 0xdb, 0xa3, 0xfd, 0x93, 0x02, 0xcb, 0x3a, 0x1e, 0x86, 0x98,
+	0x1c, 0xf1, 0x83, 0x7f, 0xf5, 0x5c, 0xe9, 0x6c, 0x82, 0xb3, 0x7e, 0x9e, 0x04, 0xa7, 0xf6, 0x07,
+	0x0a, 0x5c, 0xcd, 0xfd, 0x2e, 0x77, 0x91, 0x5b, 0xfb, 0x08, 0xba, 0x32, 0x5e, 0x11, 0xc4, 0x95,
+	0xd9, 0x41, 0x69, 0xba, 0x8e, 0x23, 0x46, 0xea, 0x08, 0x7a, 0xde, 0xd4, 0xfe, 0x5c, 0x81, 0x95,
+	0x22, 0xbc, 0x53, 0x4c, 0xc6, 0x74, 0xe2, 0x95, 0xf2, 0x13, 0xcf, 0xd9, 0x82, 0xea, 0x39, 0x8b,
+	0x1a, 0xdf, 0x97, 0xce, 0x74, 0x9c, 0xbb, 0x3c, 0xc5, 0x99, 0xfe, 0x69, 0xa8, 0xb1, 0x8c, 0x1e,
+	0x2f, 0x65, 0xdc, 0x98, 0x9f, 0x17, 0x65, 0xb9, 0x3d, 0x46, 0x43, 0xc5, 0x63, 0x1c, 0x62, 0xcb,
+	0x21, 0x72, 0xb6, 0x75, 0x7d, 0x0a, 0xd8, 0xf8, 0x1c, 0xba, 0xe9, 0xa4, 0x22, 0x6a, 0x43, 0x63,
+	0x2f, 0x88, 0x3e, 0x7a, 0xee, 0x90, 0x48, 0xbd, 0x84, 0xba, 0x00, 0x7b, 0x41, 0xb4, 0x1f, 0x62,
+	0x82, 0xfd, 0x48, 0x55, 0x10, 0xc0, 0xc2, 0x23, 0x7f, 0xc7, 0x21, 0x4f, 0xd4, 0x0a, 0x5a, 0x16,
+	0x65, 0x19, 0xd3, 0xdd, 0x15, 0x99, 0x3a, 0xb5, 0x4a, 0xc9, 0xe3, 0x56, 0x0d, 0xa9, 0xd0, 0x8e,
+	0x51, 0xee, 0xef, 0x3f, 0x56, 0xeb, 0xa8, 0x09, 0x75, 0xfe, 0xb9, 0xb0, 0x61, 0x83, 0x9a, 0x2d,
+	0x1c, 0xd2, 0x31, 0x1f, 0xfb, 0x1f, 0xfb, 0xc1, 0x71, 0x0c, 0x52, 0x2f, 0xa1, 0x16, 0x2c, 0x8a,
+	0x62, 0xac, 0xaa, 0xa0, 0x25, 0x68, 0x25, 0xea, 0xa0, 0x6a, 0x85, 0x02, 0xee, 0x87, 0x63, 0x4b,
+	0x5c, 0x3e, 0x3e, 0x05, 0x9a, 0x56, 0xda, 0x09, 0x8e, 0x7d, 0xb5, 0xb6, 0xb1, 0x05, 0x0d, 0x99,
+	0xed, 0xa4, 0xa8, 0x7c, 0x74, 0x9f, 0x36, 0xd5, 0x4b, 0xe8, 0x32, 0x74, 0x52, 0x8f, 0x83, 0x54,
+	0x05, 0x21, 0xe8, 0xa6, 0x1f, 0x6e, 0xa9, 0x95, 0x8d, 0xc7, 0x80, 0xf2, 0xfb, 0x4b, 0x47, 0xdb,
+	0x0b, 0x62, 0x90, 0x7a, 0x09, 0x75, 0xa0, 0xf9, 0x20, 0x38, 0xc6, 0xa1, 0x65, 0x12, 0xac, 0x2a,
+	0xa8, 0x01, 0xb5, 0xc3, 0xd0, 0xf1, 0xd4, 0x0a, 0xba, 0x02, 0x97, 0x0f, 0xc3, 0x89, 0x6f, 0x99,
+	0x11, 0xde, 0x97, 0x5b, 0xaf, 0x56, 0x37, 0xbe, 0x1a, 0x5b, 0x87, 0xe9, 0x6f, 0xfb, 0x74, 0xc7,
+	0xef, 0x4d, 0x5c, 0x97, 0xb7, 0xf8, 0x14, 0x0f, 0x26, 0x9e, 0x67, 0x86, 0x27, 0x02, 0xa4, 0x6c,
+	0xfe, 0x4e, 0x07, 0x80, 0x17, 0x10, 0x83, 0x20, 0xb4, 0xd1, 0x18, 0xd0, 0x7d, 0x1c, 0xd1, 0xe2,
+	0x48, 0xe0, 0xcb, 0xc2, 0x06, 0x41, 0x77, 0x66, 0x88, 0x64, 0x1e, 0x55, 0xec, 0x5c, 0x7f, 0x56,
+	0x89, 0x3d, 0x83, 0xae, 0x5d, 0x42, 0x1e, 0xe3, 0x48, 0x33, 0xe4, 0x87, 0x8e, 0xf5, 0x24, 0xae,
+	0x3c, 0xce, 0xe6, 0x98, 0x41, 0x95, 0x1c, 0x33, 0x49, 0x6e, 0xd1, 0x38, 0x88, 0x42, 0xc7, 0x8f,
+	0xdd, 0x5a, 0xed, 0x12, 0x7a, 0x0a, 0x2b, 0xf4, 0x1f, 0xfd, 0xc8, 0x8c, 0x1c, 0x12, 0x39, 0x16,
+	0x91, 0x0c, 0x37, 0x67, 0x33, 0xcc, 0x21, 0x9f, 0x91, 0xa5, 0x0b, 0x4b, 0x99, 0xc7, 0x9f, 0x68,
+	0xa3, 0xf8, 0x4f, 0xfe, 0xa2, 0x87, 0xaa, 0xfd, 0x9b, 0xa5, 0x70, 0x63, 0x6e, 0x0e, 0x74, 0xd3,
+	0x6f, 0x1a, 0xd1, 0x4f, 0xcc, 0x1a, 0x20, 0xf7, 0x6c, 0xab, 0xbf, 0x51, 0x06, 0x35, 0x66, 0xf5,
+	0x29, 0x17, 0xef, 0x79, 0xac, 0x0a, 0x9f, 0xcc, 0xf5, 0x4f, 0x53, 0x91, 0xda, 0x25, 0xf4, 0x0b,
+	0x70, 0x39, 0xf7, 0xb8, 0x0c, 0xbd, 0x5f, 0x34, 0xfc, 0xac, 0x37, 0x68, 0xf3, 0x38, 0x7c, 0x9a,
+	0xbd, 0x9c, 0xb3, 0x67, 0x9f, 0x7b, 0x8c, 0x58, 0x7e, 0xf6, 0x89, 0xe1, 0x4f, 0x9b, 0xfd, 0x99,
+	0x39, 0x4c, 0x00, 0xe5, 0x9f, 0x97, 0xa1, 0x0f, 0x8a, 0x58, 0xcc, 0x7c, 0xe2, 0xd6, 0xbf, 0x55,
+	0x16, 0x3d, 0x3e, 0xf2, 0x09, 0xbb, 0xad, 0xd9, 0x0a, 0x7a, 0x21, 0xdb, 0x99, 0x4f, 0xca, 0xfa,
+	0xb7, 0xca, 0xa2, 0x27, 0x85, 0x3a, 0xfd, 0xa0, 0xa8, 0xf8, 0xac, 0x0a, 0x5f, 0x5a, 0xf5, 0x37,
+	0xca, 0xa0, 0xc6, 0xac, 0x0e, 0x53, 0x36, 0x01, 0xdd, 0x98, 0x25, 0x13, 0xe9, 0x9f, 0x67, 0xe6,
+	0x1d, 0x97, 0x01, 0x70, 0x1f, 0x47, 0x0f, 0x71, 0x14, 0x3a, 0x16, 0xc9, 0x0e, 0x2a, 0x1a, 0x53,
+	0x04, 0x39, 0xe8, 0x7b, 0x73, 0xf1, 0xe2, 0x69, 0x0f, 0xa0, 0x75, 0x1f, 0x47, 0x3a, 0x0f, 0x41,
+	0x09, 0x9a, 0x49, 0x29, 0x31, 0x24, 0x8b, 0xf5, 0xf9, 0x88, 0x49, 0x45, 0x96, 0x79, 0xdf, 0x84,
+	0x66, 0xee, 0x6d, 0xfe, 0xd5, 0x55, 0xff, 0x66, 0x29, 0x5c, 0xc9, 0x6d, 0xf3, 0x3f, 0x11, 0x34,
+	0x99, 0x14, 0x52, 0x03, 0xfc, 0xff, 0x86, 0xe9, 0x05, 0x18, 0xa6, 0xcf, 0x60, 0x29, 0xf3, 0x5e,
+	0xab, 0xf8, 0x3c, 0x8b, 0x1f, 0x75, 0xcd, 0x13, 0xf9, 0x01, 0xa0, 0xfc, 0x6b, 0xa4, 0x62, 0x55,
+	0x31, 0xf3, 0xd5, 0xd2, 0x3c, 0x1e, 0x9f, 0xc1, 0x52, 0x26, 0x96, 0x28, 0x5e, 0x41, 0xf1, 0xfb,
+	0x9c, 0x12, 0x2b, 0xc8, 0xbf, 0x87, 0x28, 0x5e, 0xc1, 0xcc, 0x77, 0x13, 0xf3, 0x78, 0x7c, 0xc2,
+	0x1f, 0x34, 0xc5, 0x45, 0xce, 0xf7, 0x66, 0xe9, 0x9b, 0x4c, 0x78, 0xfc, 0xf2, 0x2d, 0xd0, 0x8b,
+	0xb7, 0xd0, 0x9f, 0xc1, 0x52, 0xe6, 0x87, 0xd9, 0xe2, 0xd3, 0x2d, 0xfe, 0xab, 0x76, 0xde, 0xe8,
+	0x3f, 0x46, 0x9b, 0x72, 0x00, 0x0b, 0xfc, 0x2f, 0x57, 0xf4, 0x76, 0x71, 0xd6, 0x27, 0xf1, 0x07,
+	0x6c, 0x7f, 0xde, 0x7f, 0xb2, 0x3c, 0x4b, 0x4a, 0x07, 0xad, 0xb3, 0x1b, 0x83, 0x0a, 0x7f, 0xca,
+	0x4e, 0xfe, 0xfd, 0xda, 0x9f, 0xff, 0xc3, 0xab, 0x1c, 0xf4, 0x85, 0xdb, 0xa9, 0x9f, 0x07, 0x35,
+	0x5b, 0xc4, 0x46, 0xc5, 0x1e, 0x6e, 0x71, 0xa9, 0xbb, 0xc4, 0x7d, 0x4a, 0x16, 0x7b, 0x8b, 0xef,
+	0x53, 0x41, 0x39, 0x78, 0xde, 0xb8, 0xdf, 0x85, 0x4e, 0xaa, 0x36, 0x8b, 0xd6, 0x8b, 0x25, 0x31,
+	0x5f, 0xbe, 0x9d, 0x37, 0xf2, 0x2f, 0xc2, 0x4a, 0x51, 0x7d, 0x12, 0xdd, 0x2e, 0x62, 0x70, 0x4a,
+	0xd5, 0xb5, 0x7f, 0xa7, 0x3c, 0x41, 0x7c, 0x1c, 0x01, 0xa8, 0xd9, 0x1a, 0x40, 0xf1, 0x71, 0xcc,
+	0x28, 0xb2, 0xf4, 0xdf, 0x2f, 0x87, 0x1c, 0x33, 0x7c, 0x0e, 0xcb, 0x05, 0x79, 0x5b, 0x34, 0xcb,
+	0x25, 0x9c, 0x51, 0x06, 0xe8, 0xdf, 0x2e, 0x8d, 0x9f, 0xb4, 0x76, 0x99, 0x4c, 0x63, 0xb1, 0x36,
+	0x29, 0x4e, 0x47, 0x96, 0x90, 0xbb, 0x64, 0xfa, 0xae, 0x58, 0xee, 0x0a, 0x12, 0x7c, 0xf3, 0xc6,
+	0x7d, 0x06, 0xd7, 0x32, 0xc6, 0xeb, 0x3b, 0x4e, 0x24, 0x13, 0x52, 0x67, 0xb1, 0x75, 0x37, 0x4b,
+	0xe1, 0xca, 0xdd, 0xda, 0xfa, 0xca, 0xa7, 0x9b, 0x23, 0x27, 0x3a, 0x9a, 0x0c, 0xe8, 0x8c, 0x6e,
+	0x73, 0xd2, 0x0f, 0x9c, 0x40, 0x7c, 0xdd, 0x96, 0x2a, 0xe4, 0x36, 0x1b, 0xed, 0x36, 0x1b, 0x6d,
+	0x3c, 0x18, 0x2c, 0xb0, 0xe6, 0x87, 0xff, 0x3b, 0x00, 0xc5, 0x98, 0x00, 0xa7, 0xeb, 0x49, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// QueryCoordClient is the client API for QueryCoord service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type QueryCoordClient interface {
+	GetComponentStates(ctx context.Context, in *internalpb.GetComponentStatesRequest, opts ...grpc.CallOption) (*internalpb.ComponentStates, error)
+	GetTimeTickChannel(ctx context.Context, in *internalpb.GetTimeTickChannelRequest, opts ...grpc.CallOption) (*milvuspb.StringResponse, error)
+	GetStatisticsChannel(ctx context.Context, in *internalpb.GetStatisticsChannelRequest, opts ...grpc.CallOption) (*milvuspb.StringResponse, error)
+	ShowCollections(ctx context.Context, in *ShowCollectionsRequest, opts ...grpc.CallOption) (*ShowCollectionsResponse, error)
+	ShowPartitions(ctx context.Context, in *ShowPartitionsRequest, opts ...grpc.CallOption) (*ShowPartitionsResponse, error)
+	LoadPartitions(ctx context.Context, in *LoadPartitionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	ReleasePartitions(ctx context.Context, in *ReleasePartitionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	LoadCollection(ctx context.Context, in *LoadCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	ReleaseCollection(ctx context.Context, in *ReleaseCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	CreateQueryChannel(ctx context.Context, in *CreateQueryChannelRequest, opts ...grpc.CallOption) (*CreateQueryChannelResponse, error)
+	GetPartitionStates(ctx context.Context, in *GetPartitionStatesRequest, opts ...grpc.CallOption) (*GetPartitionStatesResponse, error)
+	GetSegmentInfo(ctx context.Context, in *GetSegmentInfoRequest, opts ...grpc.CallOption) (*GetSegmentInfoResponse, error)
+	LoadBalance(ctx context.Context, in *LoadBalanceRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
+	GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error)
+	// https://wiki.lfaidata.foundation/display/MIL/MEP+23+--+Multiple+memory+replication+design
+	GetReplicas(ctx context.Context, in *milvuspb.GetReplicasRequest, opts ...grpc.CallOption) (*milvuspb.GetReplicasResponse, error)
+	GetShardLeaders(ctx context.Context, in *GetShardLeadersRequest, opts ...grpc.CallOption) (*GetShardLeadersResponse, error)
+}
+
+type queryCoordClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewQueryCoordClient(cc *grpc.ClientConn) QueryCoordClient {
+	return &queryCoordClient{cc}
+}
+
+func (c *queryCoordClient) GetComponentStates(ctx context.Context, in *internalpb.GetComponentStatesRequest, opts ...grpc.CallOption) (*internalpb.ComponentStates, error) {
+	out := new(internalpb.ComponentStates)
 	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryCoord/GetComponentStates", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -3645,12 +5456,21 @@ type QueryNodeClient interface {
 	AddQueryChannel(ctx context.Context, in *AddQueryChannelRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	RemoveQueryChannel(ctx context.Context, in *RemoveQueryChannelRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	WatchDmChannels(ctx context.Context, in *WatchDmChannelsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	WatchDmChannelsWithStatus(ctx context.Context, in *WatchDmChannelsRequest, opts ...grpc.CallOption) (*WatchDmChannelsResponse, error)
 	WatchDeltaChannels(ctx context.Context, in *WatchDeltaChannelsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	LoadSegments(ctx context.Context, in *LoadSegmentsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	ReleaseCollection(ctx context.Context, in *ReleaseCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	ReleasePartitions(ctx context.Context, in *ReleasePartitionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	ReleaseSegments(ctx context.Context, in *ReleaseSegmentsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	GetSegmentInfo(ctx context.Context, in *GetSegmentInfoRequest, opts ...grpc.CallOption) (*GetSegmentInfoResponse, error)
+	SyncDistribution(ctx context.Context, in *SyncDistributionRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	PauseChannel(ctx context.Context, in *PauseChannelRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	ResumeChannel(ctx context.Context, in *ResumeChannelRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	ExportSegmentDeletes(ctx context.Context, in *ExportSegmentDeletesRequest, opts ...grpc.CallOption) (*ExportSegmentDeletesResponse, error)
+	UpdateLoadConfig(ctx context.Context, in *UpdateLoadConfigRequest, opts ...grpc.CallOption) (*UpdateLoadConfigResponse, error)
+	GetDataDistribution(ctx context.Context, in *GetDataDistributionRequest, opts ...grpc.CallOption) (*GetDataDistributionResponse, error)
+	PromoteSegments(ctx context.Context, in *PromoteSegmentsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	RefreshIndex(ctx context.Context, in *RefreshIndexRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*internalpb.SearchResults, error)
 	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*internalpb.RetrieveResults, error)
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
@@ -3719,6 +5539,15 @@ func (c *queryNodeClient) WatchDmChannels(ctx context.Context, in *WatchDmChanne
 	return out, nil
 }
 
+func (c *queryNodeClient) WatchDmChannelsWithStatus(ctx context.Context, in *WatchDmChannelsRequest, opts ...grpc.CallOption) (*WatchDmChannelsResponse, error) {
+	out := new(WatchDmChannelsResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryNode/WatchDmChannelsWithStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *queryNodeClient) WatchDeltaChannels(ctx context.Context, in *WatchDeltaChannelsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	out := new(commonpb.Status)
 	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryNode/WatchDeltaChannels", in, out, opts...)
@@ -3773,6 +5602,78 @@ func (c *queryNodeClient) GetSegmentInfo(ctx context.Context, in *GetSegmentInfo
 	return out, nil
 }
 
+func (c *queryNodeClient) SyncDistribution(ctx context.Context, in *SyncDistributionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryNode/SyncDistribution", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryNodeClient) PauseChannel(ctx context.Context, in *PauseChannelRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryNode/PauseChannel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryNodeClient) ResumeChannel(ctx context.Context, in *ResumeChannelRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryNode/ResumeChannel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryNodeClient) ExportSegmentDeletes(ctx context.Context, in *ExportSegmentDeletesRequest, opts ...grpc.CallOption) (*ExportSegmentDeletesResponse, error) {
+	out := new(ExportSegmentDeletesResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryNode/ExportSegmentDeletes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryNodeClient) UpdateLoadConfig(ctx context.Context, in *UpdateLoadConfigRequest, opts ...grpc.CallOption) (*UpdateLoadConfigResponse, error) {
+	out := new(UpdateLoadConfigResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryNode/UpdateLoadConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryNodeClient) GetDataDistribution(ctx context.Context, in *GetDataDistributionRequest, opts ...grpc.CallOption) (*GetDataDistributionResponse, error) {
+	out := new(GetDataDistributionResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryNode/GetDataDistribution", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryNodeClient) PromoteSegments(ctx context.Context, in *PromoteSegmentsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryNode/PromoteSegments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryNodeClient) RefreshIndex(ctx context.Context, in *RefreshIndexRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryNode/RefreshIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *queryNodeClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*internalpb.SearchResults, error) {
 	out := new(internalpb.SearchResults)
 	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryNode/Search", in, out, opts...)
@@ -3808,12 +5709,21 @@ type QueryNodeServer interface {
 	AddQueryChannel(context.Context, *AddQueryChannelRequest) (*commonpb.Status, error)
 	RemoveQueryChannel(context.Context, *RemoveQueryChannelRequest) (*commonpb.Status, error)
 	WatchDmChannels(context.Context, *WatchDmChannelsRequest) (*commonpb.Status, error)
+	WatchDmChannelsWithStatus(context.Context, *WatchDmChannelsRequest) (*WatchDmChannelsResponse, error)
 	WatchDeltaChannels(context.Context, *WatchDeltaChannelsRequest) (*commonpb.Status, error)
 	LoadSegments(context.Context, *LoadSegmentsRequest) (*commonpb.Status, error)
 	ReleaseCollection(context.Context, *ReleaseCollectionRequest) (*commonpb.Status, error)
 	ReleasePartitions(context.Context, *ReleasePartitionsRequest) (*commonpb.Status, error)
 	ReleaseSegments(context.Context, *ReleaseSegmentsRequest) (*commonpb.Status, error)
 	GetSegmentInfo(context.Context, *GetSegmentInfoRequest) (*GetSegmentInfoResponse, error)
+	SyncDistribution(context.Context, *SyncDistributionRequest) (*commonpb.Status, error)
+	PauseChannel(context.Context, *PauseChannelRequest) (*commonpb.Status, error)
+	ResumeChannel(context.Context, *ResumeChannelRequest) (*commonpb.Status, error)
+	ExportSegmentDeletes(context.Context, *ExportSegmentDeletesRequest) (*ExportSegmentDeletesResponse, error)
+	UpdateLoadConfig(context.Context, *UpdateLoadConfigRequest) (*UpdateLoadConfigResponse, error)
+	GetDataDistribution(context.Context, *GetDataDistributionRequest) (*GetDataDistributionResponse, error)
+	PromoteSegments(context.Context, *PromoteSegmentsRequest) (*commonpb.Status, error)
+	RefreshIndex(context.Context, *RefreshIndexRequest) (*commonpb.Status, error)
 	Search(context.Context, *SearchRequest) (*internalpb.SearchResults, error)
 	Query(context.Context, *QueryRequest) (*internalpb.RetrieveResults, error)
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
@@ -3842,6 +5752,9 @@ func (*UnimplementedQueryNodeServer) RemoveQueryChannel(ctx context.Context, req
 func (*UnimplementedQueryNodeServer) WatchDmChannels(ctx context.Context, req *WatchDmChannelsRequest) (*commonpb.Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WatchDmChannels not implemented")
 }
+func (*UnimplementedQueryNodeServer) WatchDmChannelsWithStatus(ctx context.Context, req *WatchDmChannelsRequest) (*WatchDmChannelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WatchDmChannelsWithStatus not implemented")
+}
 func (*UnimplementedQueryNodeServer) WatchDeltaChannels(ctx context.Context, req *WatchDeltaChannelsRequest) (*commonpb.Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WatchDeltaChannels not implemented")
 }
@@ -3860,6 +5773,30 @@ func (*UnimplementedQueryNodeServer) ReleaseSegments(ctx context.Context, req *R
 func (*UnimplementedQueryNodeServer) GetSegmentInfo(ctx context.Context, req *GetSegmentInfoRequest) (*GetSegmentInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSegmentInfo not implemented")
 }
+func (*UnimplementedQueryNodeServer) SyncDistribution(ctx context.Context, req *SyncDistributionRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncDistribution not implemented")
+}
+func (*UnimplementedQueryNodeServer) PauseChannel(ctx context.Context, req *PauseChannelRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseChannel not implemented")
+}
+func (*UnimplementedQueryNodeServer) ResumeChannel(ctx context.Context, req *ResumeChannelRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeChannel not implemented")
+}
+func (*UnimplementedQueryNodeServer) ExportSegmentDeletes(ctx context.Context, req *ExportSegmentDeletesRequest) (*ExportSegmentDeletesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSegmentDeletes not implemented")
+}
+func (*UnimplementedQueryNodeServer) UpdateLoadConfig(ctx context.Context, req *UpdateLoadConfigRequest) (*UpdateLoadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLoadConfig not implemented")
+}
+func (*UnimplementedQueryNodeServer) GetDataDistribution(ctx context.Context, req *GetDataDistributionRequest) (*GetDataDistributionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataDistribution not implemented")
+}
+func (*UnimplementedQueryNodeServer) PromoteSegments(ctx context.Context, req *PromoteSegmentsRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteSegments not implemented")
+}
+func (*UnimplementedQueryNodeServer) RefreshIndex(ctx context.Context, req *RefreshIndexRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshIndex not implemented")
+}
 func (*UnimplementedQueryNodeServer) Search(ctx context.Context, req *SearchRequest) (*internalpb.SearchResults, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
@@ -3982,6 +5919,24 @@ func _QueryNode_WatchDmChannels_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QueryNode_WatchDmChannelsWithStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchDmChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryNodeServer).WatchDmChannelsWithStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.query.QueryNode/WatchDmChannelsWithStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryNodeServer).WatchDmChannelsWithStatus(ctx, req.(*WatchDmChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QueryNode_WatchDeltaChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WatchDeltaChannelsRequest)
 	if err := dec(in); err != nil {
@@ -4090,6 +6045,150 @@ func _QueryNode_GetSegmentInfo_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QueryNode_SyncDistribution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncDistributionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryNodeServer).SyncDistribution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.query.QueryNode/SyncDistribution",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryNodeServer).SyncDistribution(ctx, req.(*SyncDistributionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryNode_PauseChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryNodeServer).PauseChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.query.QueryNode/PauseChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryNodeServer).PauseChannel(ctx, req.(*PauseChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryNode_ResumeChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryNodeServer).ResumeChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.query.QueryNode/ResumeChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryNodeServer).ResumeChannel(ctx, req.(*ResumeChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryNode_ExportSegmentDeletes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportSegmentDeletesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryNodeServer).ExportSegmentDeletes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.query.QueryNode/ExportSegmentDeletes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryNodeServer).ExportSegmentDeletes(ctx, req.(*ExportSegmentDeletesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryNode_UpdateLoadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLoadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryNodeServer).UpdateLoadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.query.QueryNode/UpdateLoadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryNodeServer).UpdateLoadConfig(ctx, req.(*UpdateLoadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryNode_GetDataDistribution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDataDistributionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryNodeServer).GetDataDistribution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.query.QueryNode/GetDataDistribution",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryNodeServer).GetDataDistribution(ctx, req.(*GetDataDistributionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryNode_PromoteSegments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteSegmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryNodeServer).PromoteSegments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.query.QueryNode/PromoteSegments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryNodeServer).PromoteSegments(ctx, req.(*PromoteSegmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryNode_RefreshIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryNodeServer).RefreshIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.query.QueryNode/RefreshIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryNodeServer).RefreshIndex(ctx, req.(*RefreshIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QueryNode_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SearchRequest)
 	if err := dec(in); err != nil {
@@ -4172,6 +6271,10 @@ var _QueryNode_serviceDesc = grpc.ServiceDesc{
 			MethodName: "WatchDmChannels",
 			Handler:    _QueryNode_WatchDmChannels_Handler,
 		},
+		{
+			MethodName: "WatchDmChannelsWithStatus",
+			Handler:    _QueryNode_WatchDmChannelsWithStatus_Handler,
+		},
 		{
 			MethodName: "WatchDeltaChannels",
 			Handler:    _QueryNode_WatchDeltaChannels_Handler,
@@ -4196,6 +6299,38 @@ var _QueryNode_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetSegmentInfo",
 			Handler:    _QueryNode_GetSegmentInfo_Handler,
 		},
+		{
+			MethodName: "SyncDistribution",
+			Handler:    _QueryNode_SyncDistribution_Handler,
+		},
+		{
+			MethodName: "PauseChannel",
+			Handler:    _QueryNode_PauseChannel_Handler,
+		},
+		{
+			MethodName: "ResumeChannel",
+			Handler:    _QueryNode_ResumeChannel_Handler,
+		},
+		{
+			MethodName: "ExportSegmentDeletes",
+			Handler:    _QueryNode_ExportSegmentDeletes_Handler,
+		},
+		{
+			MethodName: "UpdateLoadConfig",
+			Handler:    _QueryNode_UpdateLoadConfig_Handler,
+		},
+		{
+			MethodName: "GetDataDistribution",
+			Handler:    _QueryNode_GetDataDistribution_Handler,
+		},
+		{
+			MethodName: "PromoteSegments",
+			Handler:    _QueryNode_PromoteSegments_Handler,
+		},
+		{
+			MethodName: "RefreshIndex",
+			Handler:    _QueryNode_RefreshIndex_Handler,
+		},
 		{
 			MethodName: "Search",
 			Handler:    _QueryNode_Search_Handler,
@@ -89,6 +89,7 @@ type FieldSchema struct {
 	TypeParams           []*commonpb.KeyValuePair `protobuf:"bytes,6,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
 	IndexParams          []*commonpb.KeyValuePair `protobuf:"bytes,7,rep,name=index_params,json=indexParams,proto3" json:"index_params,omitempty"`
 	AutoID               bool                     `protobuf:"varint,8,opt,name=autoID,proto3" json:"autoID,omitempty"`
+	IsPartitionKey       bool                     `protobuf:"varint,9,opt,name=is_partition_key,json=isPartitionKey,proto3" json:"is_partition_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
 	XXX_sizecache        int32                    `json:"-"`
@@ -175,6 +176,13 @@ func (m *FieldSchema) GetAutoID() bool {
 	return false
 }
 
+func (m *FieldSchema) GetIsPartitionKey() bool {
+	if m != nil {
+		return m.IsPartitionKey
+	}
+	return false
+}
+
 //*
 // @brief Collection schema
 type CollectionSchema struct {
@@ -948,6 +956,8 @@ type SearchResultData struct {
 	Scores               []float32    `protobuf:"fixed32,4,rep,packed,name=scores,proto3" json:"scores,omitempty"`
 	Ids                  *IDs         `protobuf:"bytes,5,opt,name=ids,proto3" json:"ids,omitempty"`
 	Topks                []int64      `protobuf:"varint,6,rep,packed,name=topks,proto3" json:"topks,omitempty"`
+	Timestamps           []uint64     `protobuf:"varint,7,rep,packed,name=timestamps,proto3" json:"timestamps,omitempty"`
+	RawScores            []float32    `protobuf:"fixed32,8,rep,packed,name=raw_scores,json=rawScores,proto3" json:"raw_scores,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -1020,6 +1030,20 @@ func (m *SearchResultData) GetTopks() []int64 {
 	return nil
 }
 
+func (m *SearchResultData) GetTimestamps() []uint64 {
+	if m != nil {
+		return m.Timestamps
+	}
+	return nil
+}
+
+func (m *SearchResultData) GetRawScores() []float32 {
+	if m != nil {
+		return m.RawScores
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("milvus.proto.schema.DataType", DataType_name, DataType_value)
 	proto.RegisterType((*FieldSchema)(nil), "milvus.proto.schema.FieldSchema")
@@ -1041,68 +1065,71 @@ func init() {
 func init() { proto.RegisterFile("schema.proto", fileDescriptor_1c5fb4d8cc22d66a) }
 
 var fileDescriptor_1c5fb4d8cc22d66a = []byte{
-	// 995 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x5d, 0x6f, 0xe3, 0x44,
-	0x17, 0xce, 0xc4, 0xf9, 0xb0, 0x8f, 0xf3, 0xf6, 0xb5, 0x66, 0x17, 0x64, 0x90, 0x76, 0x9b, 0x8d,
-	0x40, 0x8a, 0x56, 0xa2, 0xd5, 0xb6, 0x68, 0x59, 0x56, 0xac, 0x80, 0x34, 0xaa, 0x12, 0x15, 0xad,
-	0x82, 0x8b, 0x8a, 0xc4, 0x4d, 0x34, 0x89, 0x67, 0xdb, 0x51, 0x6d, 0x8f, 0x99, 0x99, 0xac, 0xc8,
-	0x0f, 0xe0, 0x1f, 0x70, 0x85, 0xb8, 0xe0, 0x8f, 0x71, 0x81, 0xf8, 0x1d, 0x48, 0x68, 0x3e, 0x92,
-	0x18, 0xe2, 0xad, 0x7a, 0x77, 0x66, 0x7c, 0x9e, 0x67, 0xce, 0x79, 0xce, 0x87, 0xa1, 0x27, 0x97,
-	0x37, 0x34, 0x27, 0x47, 0xa5, 0xe0, 0x8a, 0xe3, 0x07, 0x39, 0xcb, 0xde, 0xae, 0xa4, 0x3d, 0x1d,
-	0xd9, 0x4f, 0x1f, 0xf6, 0x96, 0x3c, 0xcf, 0x79, 0x61, 0x2f, 0x07, 0x7f, 0x36, 0x21, 0x3c, 0x67,
-	0x34, 0x4b, 0x2f, 0xcd, 0x57, 0x1c, 0x43, 0xf7, 0x8d, 0x3e, 0x4e, 0xc7, 0x31, 0xea, 0xa3, 0xa1,
-	0x97, 0x6c, 0x8e, 0x18, 0x43, 0xab, 0x20, 0x39, 0x8d, 0x9b, 0x7d, 0x34, 0x0c, 0x12, 0x63, 0xe3,
-	0x8f, 0xe0, 0x80, 0xc9, 0x79, 0x29, 0x58, 0x4e, 0xc4, 0x7a, 0x7e, 0x4b, 0xd7, 0xb1, 0xd7, 0x47,
-	0x43, 0x3f, 0xe9, 0x31, 0x39, 0xb3, 0x97, 0x17, 0x74, 0x8d, 0xfb, 0x10, 0xa6, 0x54, 0x2e, 0x05,
-	0x2b, 0x15, 0xe3, 0x45, 0xdc, 0x32, 0x04, 0xd5, 0x2b, 0xfc, 0x12, 0x82, 0x94, 0x28, 0x32, 0x57,
-	0xeb, 0x92, 0xc6, 0xed, 0x3e, 0x1a, 0x1e, 0x9c, 0x3c, 0x3a, 0xaa, 0x09, 0xfe, 0x68, 0x4c, 0x14,
-	0xf9, 0x6e, 0x5d, 0xd2, 0xc4, 0x4f, 0x9d, 0x85, 0x47, 0x10, 0x6a, 0xd8, 0xbc, 0x24, 0x82, 0xe4,
-	0x32, 0xee, 0xf4, 0xbd, 0x61, 0x78, 0xf2, 0xe4, 0xdf, 0x68, 0x97, 0xf2, 0x05, 0x5d, 0x5f, 0x91,
-	0x6c, 0x45, 0x67, 0x84, 0x89, 0x04, 0x34, 0x6a, 0x66, 0x40, 0x78, 0x0c, 0x3d, 0x56, 0xa4, 0xf4,
-	0xa7, 0x0d, 0x49, 0xf7, 0xbe, 0x24, 0xa1, 0x81, 0x39, 0x96, 0xf7, 0xa1, 0x43, 0x56, 0x8a, 0x4f,
-	0xc7, 0xb1, 0x6f, 0x54, 0x70, 0xa7, 0xc1, 0xaf, 0x08, 0xa2, 0x33, 0x9e, 0x65, 0x74, 0xa9, 0x93,
-	0x75, 0x42, 0x6f, 0xe4, 0x44, 0x15, 0x39, 0xff, 0x23, 0x54, 0x73, 0x5f, 0xa8, 0xdd, 0x13, 0x5e,
-	0xf5, 0x09, 0xfc, 0x02, 0x3a, 0xa6, 0x4e, 0x32, 0x6e, 0x99, 0xd0, 0xfb, 0xb5, 0xea, 0x55, 0x0a,
-	0x9d, 0x38, 0xff, 0xc1, 0x21, 0x04, 0x23, 0xce, 0xb3, 0xaf, 0x85, 0x20, 0x6b, 0x1d, 0x94, 0xd6,
-	0x35, 0x46, 0x7d, 0x6f, 0xe8, 0x27, 0xc6, 0x1e, 0x3c, 0x06, 0x7f, 0x5a, 0xa8, 0xfd, 0xef, 0x6d,
-	0xf7, 0xfd, 0x10, 0x82, 0x6f, 0x78, 0x71, 0xbd, 0xef, 0xe0, 0x39, 0x87, 0x3e, 0xc0, 0x79, 0xc6,
-	0x49, 0x0d, 0x45, 0xd3, 0x79, 0x3c, 0x81, 0x70, 0xcc, 0x57, 0x8b, 0x8c, 0xee, 0xbb, 0xa0, 0x1d,
-	0xc9, 0x68, 0xad, 0xa8, 0xdc, 0xf7, 0xe8, 0xed, 0x48, 0x2e, 0x95, 0x60, 0x75, 0x91, 0x04, 0xce,
-	0xe5, 0x0f, 0x0f, 0xc2, 0xcb, 0x25, 0xc9, 0x88, 0x30, 0x4a, 0xe0, 0x57, 0x10, 0x2c, 0x38, 0xcf,
-	0xe6, 0xce, 0x11, 0x0d, 0xc3, 0x93, 0xc7, 0xb5, 0xc2, 0x6d, 0x15, 0x9a, 0x34, 0x12, 0x5f, 0x43,
-	0x74, 0x1f, 0xe2, 0x97, 0xe0, 0xb3, 0x42, 0x59, 0x74, 0xd3, 0xa0, 0xeb, 0x9b, 0x76, 0x23, 0xdf,
-	0xa4, 0x91, 0x74, 0x59, 0xa1, 0x0c, 0xf6, 0x15, 0x04, 0x19, 0x2f, 0xae, 0x2d, 0xd8, 0xbb, 0xe3,
-	0xe9, 0xad, 0xb6, 0xfa, 0x69, 0x0d, 0x31, 0xf0, 0xaf, 0x00, 0xde, 0x68, 0x4d, 0x2d, 0xbe, 0x65,
-	0xf0, 0x87, 0xf5, 0x35, 0xdf, 0x4a, 0x3f, 0x69, 0x24, 0x81, 0x01, 0x19, 0x86, 0x33, 0x08, 0x53,
-	0xa3, 0xb9, 0xa5, 0x68, 0x1b, 0x8a, 0xfa, 0xb6, 0xa9, 0xd4, 0x66, 0xd2, 0x48, 0xc0, 0xc2, 0x36,
-	0x24, 0xd2, 0x68, 0x6e, 0x49, 0x3a, 0x77, 0x90, 0x54, 0x6a, 0xa3, 0x49, 0x2c, 0x6c, 0x93, 0xcb,
-	0x42, 0x97, 0xd6, 0x72, 0x74, 0xef, 0xc8, 0x65, 0xd7, 0x01, 0x3a, 0x17, 0x03, 0xd2, 0x0c, 0xa3,
-	0x8e, 0xad, 0xf5, 0xe0, 0x17, 0x04, 0xe1, 0x15, 0x5d, 0x2a, 0xee, 0xea, 0x1b, 0x81, 0x97, 0xb2,
-	0xdc, 0x2d, 0x32, 0x6d, 0xea, 0x41, 0xb7, 0xba, 0xbd, 0x35, 0x6e, 0xae, 0x6c, 0xf7, 0x50, 0x2e,
-	0x34, 0x30, 0x4b, 0x8e, 0x3f, 0x86, 0xff, 0x2d, 0x58, 0xa1, 0x57, 0x9e, 0xa3, 0xd1, 0x05, 0xec,
-	0x4d, 0x1a, 0x49, 0xcf, 0x5e, 0x5b, 0xb7, 0x6d, 0x58, 0x7f, 0x23, 0x08, 0x4c, 0x40, 0x26, 0xdd,
-	0x67, 0xd0, 0x32, 0x6b, 0x0e, 0xdd, 0x67, 0xcd, 0x19, 0x57, 0xfc, 0x08, 0xc0, 0x4c, 0xeb, 0xbc,
-	0xb2, 0x80, 0x03, 0x73, 0xf3, 0x5a, 0xaf, 0x8d, 0x2f, 0xa0, 0x2b, 0x4d, 0x57, 0x4b, 0xd7, 0x49,
-	0xef, 0xa8, 0xc0, 0xae, 0xf3, 0x75, 0x27, 0x3a, 0x88, 0x46, 0xdb, 0x2c, 0xa4, 0xeb, 0xa3, 0x7a,
-	0x74, 0x45, 0x57, 0x8d, 0x76, 0x10, 0xfc, 0x01, 0xf8, 0x36, 0x34, 0x96, 0x9a, 0x1e, 0xda, 0xfe,
-	0x30, 0xd2, 0x51, 0x17, 0xda, 0xc6, 0x1c, 0xfc, 0x8c, 0xc0, 0x9b, 0x8e, 0x25, 0xfe, 0x0c, 0x3a,
-	0x7a, 0x5e, 0x58, 0x7a, 0xe7, 0xac, 0x55, 0x1b, 0xbe, 0xcd, 0x0a, 0x35, 0x4d, 0xf1, 0xe7, 0xd0,
-	0x91, 0x4a, 0x68, 0x60, 0xf3, 0xde, 0x1d, 0xd6, 0x96, 0x4a, 0x4c, 0xd3, 0x11, 0x80, 0xcf, 0xd2,
-	0xb9, 0x8d, 0xe3, 0x2f, 0x04, 0xd1, 0x25, 0x25, 0x62, 0x79, 0x93, 0x50, 0xb9, 0xca, 0xec, 0x1c,
-	0x1c, 0x42, 0x58, 0xac, 0xf2, 0xf9, 0x8f, 0x2b, 0x2a, 0x18, 0x95, 0xae, 0x57, 0xa0, 0x58, 0xe5,
-	0xdf, 0xda, 0x1b, 0xfc, 0x00, 0xda, 0x8a, 0x97, 0xf3, 0x5b, 0xf3, 0xb6, 0x97, 0xb4, 0x14, 0x2f,
-	0x2f, 0xf0, 0x97, 0x10, 0xda, 0xfd, 0xb9, 0x19, 0x60, 0xef, 0x9d, 0xf9, 0x6c, 0x2b, 0x9f, 0xd8,
-	0x22, 0x9a, 0x96, 0xd5, 0x8b, 0x5c, 0x2e, 0xb9, 0xa0, 0x76, 0x61, 0x37, 0x13, 0x77, 0xc2, 0x4f,
-	0xc1, 0x63, 0xa9, 0x74, 0xe3, 0x18, 0xd7, 0xaf, 0x93, 0xb1, 0x4c, 0xb4, 0x13, 0x7e, 0x68, 0x22,
-	0xbb, 0xb5, 0xff, 0x3c, 0x2f, 0xb1, 0x87, 0xa7, 0xbf, 0x21, 0xf0, 0x37, 0xfd, 0x83, 0x7d, 0x68,
-	0xbd, 0xe6, 0x05, 0x8d, 0x1a, 0xda, 0xd2, 0x5b, 0x2c, 0x42, 0xda, 0x9a, 0x16, 0xea, 0x45, 0xd4,
-	0xc4, 0x01, 0xb4, 0xa7, 0x85, 0x7a, 0xf6, 0x3c, 0xf2, 0x9c, 0x79, 0x7a, 0x12, 0xb5, 0x9c, 0xf9,
-	0xfc, 0xd3, 0xa8, 0xad, 0x4d, 0x33, 0x05, 0x11, 0x60, 0x80, 0x8e, 0xdd, 0x03, 0x51, 0xa8, 0x6d,
-	0x2b, 0x76, 0xf4, 0x10, 0x87, 0xd0, 0xbd, 0x22, 0xe2, 0xec, 0x86, 0x88, 0xe8, 0x3d, 0x1c, 0x41,
-	0x6f, 0x54, 0x99, 0x80, 0x28, 0xc5, 0xff, 0x87, 0xf0, 0x7c, 0x37, 0x39, 0x11, 0x1d, 0x7d, 0x0f,
-	0x07, 0x8c, 0x6f, 0xf2, 0xba, 0x16, 0xe5, 0x72, 0x14, 0xda, 0x3f, 0xd2, 0x4c, 0xe7, 0x38, 0x43,
-	0x3f, 0x9c, 0x5e, 0x33, 0x75, 0xb3, 0x5a, 0xe8, 0xdf, 0xed, 0xb1, 0x75, 0xfb, 0x84, 0x71, 0x67,
-	0x1d, 0xb3, 0x42, 0x51, 0x51, 0x90, 0xec, 0xd8, 0x28, 0x72, 0x6c, 0x15, 0x29, 0x17, 0xbf, 0x23,
-	0xb4, 0xe8, 0x98, 0xab, 0xd3, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xd1, 0xcc, 0x21, 0x94, 0x03,
-	0x09, 0x00, 0x00,
+	// 1041 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x5f, 0x6f, 0xe3, 0x44,
+	0x10, 0xcf, 0xc6, 0xf9, 0x63, 0x8f, 0x43, 0xb1, 0xf6, 0x0e, 0x64, 0x90, 0xae, 0xcd, 0x45, 0x20,
+	0x45, 0x27, 0xd1, 0xea, 0x5a, 0x74, 0x1c, 0x27, 0x4e, 0x40, 0x1a, 0x55, 0x8d, 0x8a, 0x4e, 0xc1,
+	0x45, 0x45, 0xe2, 0x25, 0xda, 0xc4, 0x7b, 0xed, 0xaa, 0xb6, 0xd7, 0xec, 0x6e, 0xee, 0xc8, 0x07,
+	0xe0, 0x89, 0x57, 0x9e, 0x10, 0x0f, 0x88, 0xef, 0xc5, 0x47, 0x41, 0x42, 0xfb, 0xc7, 0x8d, 0x4b,
+	0x73, 0x51, 0xdf, 0x66, 0xc7, 0xf3, 0xfb, 0xed, 0xcc, 0x6f, 0x66, 0xc7, 0xd0, 0x93, 0x8b, 0x2b,
+	0x9a, 0x93, 0xfd, 0x52, 0x70, 0xc5, 0xf1, 0x83, 0x9c, 0x65, 0x6f, 0x96, 0xd2, 0x9e, 0xf6, 0xed,
+	0xa7, 0x8f, 0x7b, 0x0b, 0x9e, 0xe7, 0xbc, 0xb0, 0xce, 0xc1, 0x6f, 0x1e, 0x84, 0x27, 0x8c, 0x66,
+	0xe9, 0xb9, 0xf9, 0x8a, 0x63, 0xe8, 0xbe, 0xd6, 0xc7, 0xc9, 0x38, 0x46, 0x7d, 0x34, 0xf4, 0x92,
+	0xea, 0x88, 0x31, 0xb4, 0x0a, 0x92, 0xd3, 0xb8, 0xd9, 0x47, 0xc3, 0x20, 0x31, 0x36, 0xfe, 0x04,
+	0x76, 0x98, 0x9c, 0x95, 0x82, 0xe5, 0x44, 0xac, 0x66, 0xd7, 0x74, 0x15, 0x7b, 0x7d, 0x34, 0xf4,
+	0x93, 0x1e, 0x93, 0x53, 0xeb, 0x3c, 0xa3, 0x2b, 0xdc, 0x87, 0x30, 0xa5, 0x72, 0x21, 0x58, 0xa9,
+	0x18, 0x2f, 0xe2, 0x96, 0x21, 0xa8, 0xbb, 0xf0, 0x0b, 0x08, 0x52, 0xa2, 0xc8, 0x4c, 0xad, 0x4a,
+	0x1a, 0xb7, 0xfb, 0x68, 0xb8, 0x73, 0xf8, 0x68, 0x7f, 0x43, 0xf2, 0xfb, 0x63, 0xa2, 0xc8, 0x0f,
+	0xab, 0x92, 0x26, 0x7e, 0xea, 0x2c, 0x3c, 0x82, 0x50, 0xc3, 0x66, 0x25, 0x11, 0x24, 0x97, 0x71,
+	0xa7, 0xef, 0x0d, 0xc3, 0xc3, 0xc7, 0xb7, 0xd1, 0xae, 0xe4, 0x33, 0xba, 0xba, 0x20, 0xd9, 0x92,
+	0x4e, 0x09, 0x13, 0x09, 0x68, 0xd4, 0xd4, 0x80, 0xf0, 0x18, 0x7a, 0xac, 0x48, 0xe9, 0x2f, 0x15,
+	0x49, 0xf7, 0xbe, 0x24, 0xa1, 0x81, 0x39, 0x96, 0x0f, 0xa1, 0x43, 0x96, 0x8a, 0x4f, 0xc6, 0xb1,
+	0x6f, 0x54, 0x70, 0x27, 0x3c, 0x84, 0x48, 0xab, 0x44, 0x84, 0x62, 0xba, 0x5a, 0xa3, 0x53, 0x60,
+	0x22, 0x76, 0x98, 0x9c, 0x56, 0xee, 0x33, 0xba, 0x1a, 0xfc, 0x81, 0x20, 0x3a, 0xe6, 0x59, 0x46,
+	0x17, 0xda, 0xe3, 0x5a, 0x52, 0x09, 0x8f, 0x6a, 0xc2, 0xff, 0x4f, 0xd2, 0xe6, 0x5d, 0x49, 0xd7,
+	0xc9, 0x78, 0xb7, 0x92, 0x79, 0x0e, 0x1d, 0xd3, 0x51, 0x19, 0xb7, 0x4c, 0x91, 0xfd, 0x8d, 0x3a,
+	0xd7, 0x46, 0x22, 0x71, 0xf1, 0x83, 0x3d, 0x08, 0x46, 0x9c, 0x67, 0xdf, 0x0a, 0x41, 0x56, 0x3a,
+	0x29, 0xdd, 0x81, 0x18, 0xf5, 0xbd, 0xa1, 0x9f, 0x18, 0x7b, 0xb0, 0x0b, 0xfe, 0xa4, 0x50, 0x77,
+	0xbf, 0xb7, 0xdd, 0xf7, 0x3d, 0x08, 0xbe, 0xe3, 0xc5, 0xe5, 0xdd, 0x00, 0xcf, 0x05, 0xf4, 0x01,
+	0x4e, 0x32, 0x4e, 0x36, 0x50, 0x34, 0x5d, 0xc4, 0x63, 0x08, 0xc7, 0x7c, 0x39, 0xcf, 0xe8, 0xdd,
+	0x10, 0xb4, 0x26, 0x19, 0xad, 0x14, 0x95, 0x77, 0x23, 0x7a, 0x6b, 0x92, 0x73, 0x25, 0xd8, 0xa6,
+	0x4c, 0x02, 0x17, 0xf2, 0x8f, 0x07, 0xe1, 0xf9, 0x82, 0x64, 0x44, 0x18, 0x25, 0xf0, 0x4b, 0x08,
+	0xe6, 0x9c, 0x67, 0x33, 0x17, 0x88, 0x86, 0xe1, 0xe1, 0xee, 0x46, 0xe1, 0x6e, 0x14, 0x3a, 0x6d,
+	0x24, 0xbe, 0x86, 0xe8, 0x89, 0xc5, 0x2f, 0xc0, 0x67, 0x85, 0xb2, 0xe8, 0xa6, 0x41, 0x6f, 0x1e,
+	0xef, 0x4a, 0xbe, 0xd3, 0x46, 0xd2, 0x65, 0x85, 0x32, 0xd8, 0x97, 0x10, 0x64, 0xbc, 0xb8, 0xb4,
+	0x60, 0x6f, 0xcb, 0xd5, 0x37, 0xda, 0xea, 0xab, 0x35, 0xc4, 0xc0, 0xbf, 0x01, 0x78, 0xad, 0x35,
+	0xb5, 0xf8, 0x96, 0xc1, 0xef, 0x6d, 0xee, 0xf9, 0x8d, 0xf4, 0xa7, 0x8d, 0x24, 0x30, 0x20, 0xc3,
+	0x70, 0x0c, 0x61, 0x6a, 0x34, 0xb7, 0x14, 0xed, 0x3e, 0x7a, 0xe7, 0xd8, 0xd4, 0x7a, 0x73, 0xda,
+	0x48, 0xc0, 0xc2, 0x2a, 0x12, 0x69, 0x34, 0xb7, 0x24, 0x9d, 0x2d, 0x24, 0xb5, 0xde, 0x68, 0x12,
+	0x0b, 0xab, 0x6a, 0x99, 0xeb, 0xd6, 0x5a, 0x8e, 0xee, 0x96, 0x5a, 0xd6, 0x13, 0xa0, 0x6b, 0x31,
+	0x20, 0xcd, 0x30, 0xea, 0xd8, 0x5e, 0x0f, 0x7e, 0x47, 0x10, 0x5e, 0xd0, 0x85, 0xe2, 0xae, 0xbf,
+	0x11, 0x78, 0x29, 0xcb, 0xdd, 0xca, 0xd3, 0xa6, 0x5e, 0x09, 0x56, 0xb7, 0x37, 0x26, 0x2c, 0x6e,
+	0x6e, 0xb9, 0xed, 0x96, 0x72, 0xa1, 0x81, 0x59, 0x72, 0xfc, 0x29, 0xbc, 0x37, 0x67, 0x85, 0x5e,
+	0x8e, 0x8e, 0x46, 0x37, 0xb0, 0x77, 0xda, 0x48, 0x7a, 0xd6, 0x6d, 0xc3, 0x6e, 0xd2, 0xfa, 0x17,
+	0x41, 0x60, 0x12, 0x32, 0xe5, 0x3e, 0x85, 0x96, 0x59, 0x88, 0xe8, 0x3e, 0x0b, 0xd1, 0x84, 0xe2,
+	0x47, 0x00, 0xe6, 0xb5, 0xce, 0x6a, 0xab, 0x3a, 0x30, 0x9e, 0x57, 0x7a, 0x6d, 0x7c, 0x05, 0x5d,
+	0x69, 0xa6, 0x5a, 0xc6, 0xde, 0xb6, 0x0e, 0xac, 0x27, 0x5f, 0x4f, 0xa2, 0x83, 0x68, 0xb4, 0xad,
+	0x42, 0xc6, 0xad, 0x2d, 0xe8, 0x9a, 0xae, 0x1a, 0xed, 0x20, 0xf8, 0x23, 0xf0, 0x6d, 0x6a, 0x2c,
+	0x8d, 0xdb, 0xf5, 0x5f, 0x4b, 0x3a, 0xea, 0x42, 0xdb, 0x98, 0x83, 0x5f, 0x11, 0x78, 0x93, 0xb1,
+	0xc4, 0x5f, 0x40, 0x47, 0xbf, 0x17, 0x96, 0xc6, 0xe8, 0x9e, 0x03, 0xdf, 0x66, 0x85, 0x9a, 0xa4,
+	0xf8, 0x4b, 0xe8, 0x48, 0x25, 0x34, 0xb0, 0x79, 0xef, 0x09, 0x6b, 0x4b, 0x25, 0x26, 0xe9, 0x08,
+	0xc0, 0x67, 0xe9, 0xcc, 0xe6, 0xf1, 0x77, 0x13, 0xa2, 0x73, 0x4a, 0xc4, 0xe2, 0x2a, 0xa1, 0x72,
+	0x99, 0xd9, 0x77, 0xb0, 0x07, 0x61, 0xb1, 0xcc, 0x67, 0x3f, 0x2f, 0xa9, 0x60, 0x54, 0xba, 0x59,
+	0x81, 0x62, 0x99, 0x7f, 0x6f, 0x3d, 0xf8, 0x01, 0xb4, 0x15, 0x2f, 0x67, 0xd7, 0xe6, 0x6e, 0x2f,
+	0x69, 0x29, 0x5e, 0x9e, 0xe1, 0xaf, 0x21, 0xb4, 0xfb, 0xb3, 0x7a, 0xc0, 0xde, 0x3b, 0xeb, 0xb9,
+	0xe9, 0x7c, 0x62, 0x9b, 0x68, 0x46, 0x56, 0x2f, 0x72, 0xb9, 0xe0, 0x82, 0xda, 0x85, 0xdd, 0x4c,
+	0xdc, 0x09, 0x3f, 0x01, 0x8f, 0xa5, 0xd2, 0x3d, 0xc7, 0x78, 0xf3, 0x3a, 0x19, 0xcb, 0x44, 0x07,
+	0xe1, 0x87, 0x26, 0xb3, 0x6b, 0xfb, 0x77, 0xf4, 0x12, 0x7b, 0xc0, 0xbb, 0x00, 0x8a, 0xe5, 0x54,
+	0x2a, 0x92, 0x97, 0xf6, 0x9f, 0xd7, 0x4a, 0x6a, 0x1e, 0x3d, 0x4c, 0x82, 0xbc, 0x9d, 0xb9, 0xdb,
+	0x7d, 0x73, 0x7b, 0x20, 0xc8, 0xdb, 0x73, 0xe3, 0x78, 0xf2, 0x27, 0x02, 0xbf, 0x1a, 0x3f, 0xec,
+	0x43, 0xeb, 0x15, 0x2f, 0x68, 0xd4, 0xd0, 0x96, 0x5e, 0x82, 0x11, 0xd2, 0xd6, 0xa4, 0x50, 0xcf,
+	0xa3, 0x26, 0x0e, 0xa0, 0x3d, 0x29, 0xd4, 0xd3, 0x67, 0x91, 0xe7, 0xcc, 0xa3, 0xc3, 0xa8, 0xe5,
+	0xcc, 0x67, 0x9f, 0x47, 0x6d, 0x6d, 0x9a, 0x47, 0x14, 0x01, 0x06, 0xe8, 0xd8, 0x35, 0x12, 0x85,
+	0xda, 0xb6, 0xbd, 0x8a, 0x1e, 0xe2, 0x10, 0xba, 0x17, 0x44, 0x1c, 0x5f, 0x11, 0x11, 0x7d, 0x80,
+	0x23, 0xe8, 0x8d, 0x6a, 0x0f, 0x28, 0x4a, 0xf1, 0xfb, 0x10, 0x9e, 0xac, 0x1f, 0x5e, 0x44, 0x47,
+	0x3f, 0xc2, 0x0e, 0xe3, 0x95, 0x2c, 0x97, 0xa2, 0x5c, 0x8c, 0x42, 0xfb, 0x43, 0x9b, 0x6a, 0x89,
+	0xa6, 0xe8, 0xa7, 0xa3, 0x4b, 0xa6, 0xae, 0x96, 0x73, 0xfd, 0x5f, 0x3f, 0xb0, 0x61, 0x9f, 0x31,
+	0xee, 0xac, 0x03, 0x56, 0x28, 0x2a, 0x0a, 0x92, 0x1d, 0x18, 0x41, 0x0f, 0xac, 0xa0, 0xe5, 0xfc,
+	0x2f, 0x84, 0xe6, 0x1d, 0xe3, 0x3a, 0xfa, 0x6f, 0x00, 0x1c, 0x88, 0x8f, 0xc2, 0x6c, 0x09, 0x00,
+	0x00,
 }
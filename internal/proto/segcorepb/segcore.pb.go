@@ -25,6 +25,7 @@ type RetrieveResults struct {
 	Ids                  *schemapb.IDs         `protobuf:"bytes,1,opt,name=ids,proto3" json:"ids,omitempty"`
 	Offset               []int64               `protobuf:"varint,2,rep,packed,name=offset,proto3" json:"offset,omitempty"`
 	FieldsData           []*schemapb.FieldData `protobuf:"bytes,3,rep,name=fields_data,json=fieldsData,proto3" json:"fields_data,omitempty"`
+	MatchedCount         int64                 `protobuf:"varint,4,opt,name=matched_count,json=matchedCount,proto3" json:"matched_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -76,6 +77,13 @@ func (m *RetrieveResults) GetFieldsData() []*schemapb.FieldData {
 	return nil
 }
 
+func (m *RetrieveResults) GetMatchedCount() int64 {
+	if m != nil {
+		return m.MatchedCount
+	}
+	return 0
+}
+
 type LoadFieldMeta struct {
 	MinTimestamp         int64    `protobuf:"varint,1,opt,name=min_timestamp,json=minTimestamp,proto3" json:"min_timestamp,omitempty"`
 	MaxTimestamp         int64    `protobuf:"varint,2,opt,name=max_timestamp,json=maxTimestamp,proto3" json:"max_timestamp,omitempty"`
@@ -188,26 +196,26 @@ func init() {
 func init() { proto.RegisterFile("segcore.proto", fileDescriptor_1d79fce784797357) }
 
 var fileDescriptor_1d79fce784797357 = []byte{
-	// 326 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0x41, 0x4b, 0xfb, 0x40,
-	0x10, 0xc5, 0x69, 0xc3, 0xbf, 0xfc, 0x3b, 0x6d, 0x29, 0x04, 0x91, 0xa0, 0x28, 0xa5, 0xbd, 0x14,
-	0xc1, 0x04, 0xaa, 0x08, 0x9e, 0x04, 0x2d, 0x82, 0xa0, 0x97, 0xad, 0x27, 0x2f, 0x61, 0x9a, 0x4c,
-	0xdb, 0xc5, 0x6c, 0xb6, 0x64, 0x27, 0x6d, 0xe9, 0x07, 0xf1, 0xf3, 0xca, 0x6e, 0x22, 0x5a, 0xe8,
-	0x6d, 0xe7, 0xcd, 0x6f, 0xe6, 0xbd, 0x1d, 0xe8, 0x19, 0x5a, 0x26, 0xba, 0xa0, 0x70, 0x5d, 0x68,
-	0xd6, 0xfe, 0x89, 0x92, 0xd9, 0xa6, 0x34, 0x55, 0x15, 0xd6, 0xbd, 0xb3, 0xae, 0x49, 0x56, 0xa4,
-	0xb0, 0x52, 0x87, 0x5f, 0x0d, 0xe8, 0x0b, 0xe2, 0x42, 0xd2, 0x86, 0x04, 0x99, 0x32, 0x63, 0xe3,
-	0x5f, 0x81, 0x27, 0x53, 0x13, 0x34, 0x06, 0x8d, 0x71, 0x67, 0x12, 0x84, 0x87, 0x5b, 0xaa, 0xe1,
-	0x97, 0xa9, 0x11, 0x16, 0xf2, 0x4f, 0xa1, 0xa5, 0x17, 0x0b, 0x43, 0x1c, 0x34, 0x07, 0xde, 0xd8,
-	0x13, 0x75, 0xe5, 0x3f, 0x40, 0x67, 0x21, 0x29, 0x4b, 0x4d, 0x9c, 0x22, 0x63, 0xe0, 0x0d, 0xbc,
-	0x71, 0x67, 0x72, 0x79, 0x74, 0xd7, 0xb3, 0xe5, 0xa6, 0xc8, 0x28, 0xa0, 0x1a, 0xb1, 0xef, 0xe1,
-	0x06, 0x7a, 0xaf, 0x1a, 0x53, 0xd7, 0x7c, 0x23, 0x46, 0x7f, 0x04, 0x3d, 0x25, 0xf3, 0x98, 0xa5,
-	0x22, 0xc3, 0xa8, 0xd6, 0x2e, 0x9f, 0x27, 0xba, 0x4a, 0xe6, 0xef, 0x3f, 0x9a, 0x83, 0x70, 0xf7,
-	0x07, 0x6a, 0xd6, 0x10, 0xee, 0x7e, 0xa1, 0x73, 0x68, 0x17, 0x7a, 0x1b, 0x27, 0xba, 0xcc, 0x39,
-	0xf0, 0x1c, 0xf0, 0xbf, 0xd0, 0xdb, 0x27, 0x5b, 0x0f, 0x3f, 0xa1, 0x6f, 0x7d, 0x67, 0xb4, 0x54,
-	0x94, 0xb3, 0x73, 0xbe, 0x87, 0x7f, 0x8a, 0x18, 0xed, 0x45, 0xec, 0x2f, 0x46, 0xe1, 0xb1, 0xbb,
-	0x86, 0x07, 0x69, 0x45, 0x35, 0xe1, 0x5f, 0x00, 0xb0, 0x66, 0xcc, 0x62, 0x23, 0xf7, 0x54, 0x87,
-	0x69, 0x3b, 0x65, 0x26, 0xf7, 0xf4, 0x78, 0xf7, 0x71, 0xbb, 0x94, 0xbc, 0x2a, 0xe7, 0x61, 0xa2,
-	0x55, 0x54, 0xad, 0xbd, 0x96, 0xba, 0x7e, 0x45, 0x32, 0x67, 0x2a, 0x72, 0xcc, 0x22, 0xe7, 0x14,
-	0xd5, 0x4e, 0xeb, 0xf9, 0xbc, 0xe5, 0x84, 0x9b, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x5a, 0xd6,
-	0xac, 0xcc, 0xf1, 0x01, 0x00, 0x00,
+	// 334 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0x51, 0x4b, 0xfb, 0x30,
+	0x14, 0xc5, 0xe9, 0xf2, 0xff, 0x0f, 0x97, 0x6d, 0x0c, 0x8a, 0x48, 0x51, 0x94, 0xb2, 0xbd, 0x14,
+	0xc1, 0x16, 0xa6, 0x08, 0x3e, 0x09, 0x3a, 0x04, 0x41, 0x5f, 0x32, 0x9f, 0x7c, 0x29, 0x59, 0x7b,
+	0xb7, 0x05, 0x9b, 0x66, 0x34, 0xb7, 0xdb, 0xd8, 0x57, 0xf3, 0xcb, 0x49, 0x9a, 0x0c, 0x1d, 0xec,
+	0x2d, 0xf7, 0xf4, 0x9c, 0xf3, 0xbb, 0xbd, 0xb4, 0xaf, 0x61, 0x91, 0xa9, 0x0a, 0xe2, 0x55, 0xa5,
+	0x50, 0xf9, 0xa7, 0x52, 0x14, 0xeb, 0x5a, 0xdb, 0x29, 0x76, 0xdf, 0xce, 0x7b, 0x3a, 0x5b, 0x82,
+	0xe4, 0x56, 0x1d, 0x7e, 0x7b, 0x74, 0xc0, 0x00, 0x2b, 0x01, 0x6b, 0x60, 0xa0, 0xeb, 0x02, 0xb5,
+	0x7f, 0x4d, 0x89, 0xc8, 0x75, 0xe0, 0x85, 0x5e, 0xd4, 0x1d, 0x07, 0xf1, 0x61, 0x8b, 0x0d, 0xbf,
+	0x4e, 0x34, 0x33, 0x26, 0xff, 0x8c, 0xb6, 0xd5, 0x7c, 0xae, 0x01, 0x83, 0x56, 0x48, 0x22, 0xc2,
+	0xdc, 0xe4, 0x3f, 0xd2, 0xee, 0x5c, 0x40, 0x91, 0xeb, 0x34, 0xe7, 0xc8, 0x03, 0x12, 0x92, 0xa8,
+	0x3b, 0xbe, 0x3a, 0xda, 0xf5, 0x62, 0x7c, 0x13, 0x8e, 0x9c, 0x51, 0x1b, 0x31, 0x6f, 0x7f, 0x44,
+	0xfb, 0x92, 0x63, 0xb6, 0x84, 0x3c, 0xcd, 0x54, 0x5d, 0x62, 0xf0, 0x2f, 0xf4, 0x22, 0xc2, 0x7a,
+	0x4e, 0x7c, 0x36, 0xda, 0x70, 0x4d, 0xfb, 0x6f, 0x8a, 0xe7, 0x4d, 0xc3, 0x3b, 0xb8, 0x94, 0x28,
+	0x53, 0x14, 0x12, 0x34, 0x72, 0xb9, 0x0a, 0x3c, 0x97, 0x12, 0xe5, 0xc7, 0x5e, 0xb3, 0xd5, 0xdb,
+	0x3f, 0xa6, 0xd6, 0xbe, 0x7a, 0xfb, 0x6b, 0xba, 0xa0, 0x9d, 0x4a, 0x6d, 0x1c, 0x9b, 0x34, 0x86,
+	0x93, 0x4a, 0x6d, 0x2c, 0xf7, 0x8b, 0x0e, 0x0c, 0x77, 0x0a, 0x0b, 0x09, 0x25, 0x36, 0xe4, 0x07,
+	0xfa, 0x5f, 0x02, 0x72, 0x73, 0x36, 0xf3, 0xab, 0xa3, 0xf8, 0xd8, 0xf1, 0xe3, 0x83, 0x6d, 0x99,
+	0x4d, 0xf8, 0x97, 0x94, 0xa2, 0x42, 0x5e, 0xa4, 0x5a, 0xec, 0xc0, 0x2d, 0xd3, 0x69, 0x94, 0xa9,
+	0xd8, 0xc1, 0xd3, 0xfd, 0xe7, 0xdd, 0x42, 0xe0, 0xb2, 0x9e, 0xc5, 0x99, 0x92, 0x89, 0xad, 0xbd,
+	0x11, 0xca, 0xbd, 0x12, 0x51, 0x22, 0x54, 0x25, 0x2f, 0x92, 0x86, 0x94, 0x38, 0xd2, 0x6a, 0x36,
+	0x6b, 0x37, 0xc2, 0xed, 0xcf, 0x00, 0x2b, 0x5e, 0x34, 0xc4, 0x16, 0x02, 0x00, 0x00,
 }
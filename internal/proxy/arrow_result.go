@@ -0,0 +1,255 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/ipc"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// arrowFieldIDKey is the key of the field ID in the metadata of the Arrow columns
+const arrowFieldIDKey = "milvus.field_id"
+
+// arrowResultTooLargeError is the error of the query results whose Arrow IPC stream exceeds the size limit
+type arrowResultTooLargeError struct {
+	limit int64
+}
+
+func (e *arrowResultTooLargeError) Error() string {
+	return fmt.Sprintf("the Arrow IPC stream of query results exceeds the limit of %d bytes, "+
+		"narrow down the query with a filter, limit or fewer output fields", e.limit)
+}
+
+// limitedBuffer is a buffer refusing the writes beyond limit bytes
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int64
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if int64(b.Len()+len(p)) > b.limit {
+		return 0, &arrowResultTooLargeError{limit: b.limit}
+	}
+	return b.Buffer.Write(p)
+}
+
+// arrowStreamWriter converts the columns of query results into the record batches of an Arrow IPC stream,
+// a record batch per write, so that the results may be converted batch by batch as they are merged. The columns of
+// every write must be of the same fields, a column without data is written as nulls.
+type arrowStreamWriter struct {
+	schema *arrow.Schema
+	dims   []int
+	buf    *limitedBuffer
+	writer *ipc.Writer
+}
+
+// newArrowStreamWriter returns an arrowStreamWriter of the columns like fieldsData, the types and dimensions of the
+// columns are taken from the fields of collSchema, or from fieldsData for the columns not of the collection fields,
+// e.g. computed by output expressions. The stream is refused once it exceeds maxSize bytes.
+func newArrowStreamWriter(collSchema *schemapb.CollectionSchema, fieldsData []*schemapb.FieldData, maxSize int64) (*arrowStreamWriter, error) {
+	fields := make([]arrow.Field, 0, len(fieldsData))
+	dims := make([]int, 0, len(fieldsData))
+	for _, fieldData := range fieldsData {
+		dataType := fieldData.GetType()
+		dim := int(fieldData.GetVectors().GetDim())
+		for _, field := range collSchema.GetFields() {
+			if field.GetFieldID() == fieldData.GetFieldId() && field.GetName() == fieldData.GetFieldName() {
+				dataType = field.GetDataType()
+				if typeutil.IsVectorType(dataType) {
+					fieldDim, err := getDimOfFieldSchema(field)
+					if err != nil {
+						return nil, err
+					}
+					dim = int(fieldDim)
+				}
+			}
+		}
+		arrowType, err := arrowTypeOf(dataType, dim)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", fieldData.GetFieldName(), err)
+		}
+		fields = append(fields, arrow.Field{
+			Name:     fieldData.GetFieldName(),
+			Type:     arrowType,
+			Nullable: true,
+			Metadata: arrow.NewMetadata([]string{arrowFieldIDKey}, []string{strconv.FormatInt(fieldData.GetFieldId(), 10)}),
+		})
+		dims = append(dims, dim)
+	}
+
+	schema := arrow.NewSchema(fields, nil)
+	buf := &limitedBuffer{limit: maxSize}
+	return &arrowStreamWriter{
+		schema: schema,
+		dims:   dims,
+		buf:    buf,
+		writer: ipc.NewWriter(buf, ipc.WithSchema(schema), ipc.WithAllocator(memory.DefaultAllocator)),
+	}, nil
+}
+
+// arrowTypeOf returns the Arrow type of the columns of dataType, the vectors are the lists of dim elements
+func arrowTypeOf(dataType schemapb.DataType, dim int) (arrow.DataType, error) {
+	switch dataType {
+	case schemapb.DataType_Bool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case schemapb.DataType_Int8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case schemapb.DataType_Int16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case schemapb.DataType_Int32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case schemapb.DataType_Int64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case schemapb.DataType_Float:
+		return arrow.PrimitiveTypes.Float32, nil
+	case schemapb.DataType_Double:
+		return arrow.PrimitiveTypes.Float64, nil
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		return arrow.BinaryTypes.String, nil
+	case schemapb.DataType_FloatVector:
+		if dim <= 0 {
+			return nil, errDimLessThanOrEqualToZero(dim)
+		}
+		return arrow.FixedSizeListOf(int32(dim), arrow.PrimitiveTypes.Float32), nil
+	case schemapb.DataType_BinaryVector:
+		if dim <= 0 || dim%8 != 0 {
+			return nil, errDimShouldDivide8(dim)
+		}
+		return &arrow.FixedSizeBinaryType{ByteWidth: dim / 8}, nil
+	default:
+		return nil, errUnsupportedDataType(dataType)
+	}
+}
+
+// write converts the columns into a record batch of the stream
+func (w *arrowStreamWriter) write(fieldsData []*schemapb.FieldData) error {
+	if len(fieldsData) != len(w.schema.Fields()) {
+		return fmt.Errorf("%d columns are written to the Arrow stream of %d columns", len(fieldsData), len(w.schema.Fields()))
+	}
+	numRows := 0
+	for _, fieldData := range fieldsData {
+		rows, err := typeutil.GetRowCountOfFieldData(fieldData)
+		if err != nil {
+			return err
+		}
+		if rows > numRows {
+			numRows = rows
+		}
+	}
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, w.schema)
+	defer builder.Release()
+	for i, fieldData := range fieldsData {
+		rows, err := typeutil.GetRowCountOfFieldData(fieldData)
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			// the column without data, e.g. an output field filled by none of the segments
+			for j := 0; j < numRows; j++ {
+				builder.Field(i).AppendNull()
+			}
+			continue
+		}
+		if rows != numRows {
+			return fmt.Errorf("field %s has %d rows, expected %d rows", fieldData.GetFieldName(), rows, numRows)
+		}
+		if err := appendArrowColumn(builder.Field(i), fieldData, w.dims[i]); err != nil {
+			return fmt.Errorf("field %s: %w", fieldData.GetFieldName(), err)
+		}
+	}
+
+	record := builder.NewRecord()
+	defer record.Release()
+	return w.writer.Write(record)
+}
+
+// appendArrowColumn appends the values of fieldData to the builder of its Arrow type
+func appendArrowColumn(builder array.Builder, fieldData *schemapb.FieldData, dim int) error {
+	switch b := builder.(type) {
+	case *array.BooleanBuilder:
+		b.AppendValues(fieldData.GetScalars().GetBoolData().GetData(), nil)
+	case *array.Int8Builder:
+		for _, v := range fieldData.GetScalars().GetIntData().GetData() {
+			b.Append(int8(v))
+		}
+	case *array.Int16Builder:
+		for _, v := range fieldData.GetScalars().GetIntData().GetData() {
+			b.Append(int16(v))
+		}
+	case *array.Int32Builder:
+		b.AppendValues(fieldData.GetScalars().GetIntData().GetData(), nil)
+	case *array.Int64Builder:
+		b.AppendValues(fieldData.GetScalars().GetLongData().GetData(), nil)
+	case *array.Float32Builder:
+		b.AppendValues(fieldData.GetScalars().GetFloatData().GetData(), nil)
+	case *array.Float64Builder:
+		b.AppendValues(fieldData.GetScalars().GetDoubleData().GetData(), nil)
+	case *array.StringBuilder:
+		b.AppendValues(fieldData.GetScalars().GetStringData().GetData(), nil)
+	case *array.FixedSizeListBuilder:
+		vectors := fieldData.GetVectors().GetFloatVector().GetData()
+		if int(fieldData.GetVectors().GetDim()) != dim || len(vectors)%dim != 0 {
+			return errInvalidDim(int(fieldData.GetVectors().GetDim()))
+		}
+		values := b.ValueBuilder().(*array.Float32Builder)
+		for offset := 0; offset < len(vectors); offset += dim {
+			b.Append(true)
+			values.AppendValues(vectors[offset:offset+dim], nil)
+		}
+	case *array.FixedSizeBinaryBuilder:
+		vectors := fieldData.GetVectors().GetBinaryVector()
+		if int(fieldData.GetVectors().GetDim()) != dim || len(vectors)%(dim/8) != 0 {
+			return errInvalidDim(int(fieldData.GetVectors().GetDim()))
+		}
+		for offset := 0; offset < len(vectors); offset += dim / 8 {
+			b.Append(vectors[offset : offset+dim/8])
+		}
+	default:
+		return fmt.Errorf("unsupported Arrow builder %T", builder)
+	}
+	return nil
+}
+
+// finish ends the stream and returns its bytes, the schema is written even if no batch is written
+func (w *arrowStreamWriter) finish() ([]byte, error) {
+	if err := w.writer.Close(); err != nil {
+		return nil, err
+	}
+	return w.buf.Bytes(), nil
+}
+
+// encodeArrowStream returns the Arrow IPC stream of the columns of query results in a single record batch
+func encodeArrowStream(collSchema *schemapb.CollectionSchema, fieldsData []*schemapb.FieldData, maxSize int64) ([]byte, error) {
+	w, err := newArrowStreamWriter(collSchema, fieldsData, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.write(fieldsData); err != nil {
+		return nil, err
+	}
+	return w.finish()
+}
@@ -0,0 +1,181 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/ipc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// readArrowStream reads the schema and the records of an Arrow IPC stream
+func readArrowStream(t *testing.T, stream []byte) (*arrow.Schema, []arrow.Record) {
+	reader, err := ipc.NewReader(bytes.NewReader(stream))
+	require.NoError(t, err)
+	defer reader.Release()
+	var records []arrow.Record
+	for reader.Next() {
+		record := reader.Record()
+		record.Retain()
+		records = append(records, record)
+	}
+	require.NoError(t, reader.Err())
+	return reader.Schema(), records
+}
+
+func TestEncodeArrowStream(t *testing.T) {
+	const numRows = 10
+	dataTypes := []schemapb.DataType{
+		schemapb.DataType_Bool,
+		schemapb.DataType_Int32,
+		schemapb.DataType_Int64,
+		schemapb.DataType_Float,
+		schemapb.DataType_Double,
+		schemapb.DataType_VarChar,
+		schemapb.DataType_FloatVector,
+		schemapb.DataType_BinaryVector,
+	}
+	collSchema := &schemapb.CollectionSchema{}
+	var fieldsData []*schemapb.FieldData
+	for i, dataType := range dataTypes {
+		fieldID := int64(100 + i)
+		fieldData := generateFieldData(dataType, "", fieldID, numRows)
+		field := &schemapb.FieldSchema{FieldID: fieldID, Name: fieldData.GetFieldName(), DataType: dataType}
+		if dataType == schemapb.DataType_FloatVector || dataType == schemapb.DataType_BinaryVector {
+			field.TypeParams = []*commonpb.KeyValuePair{{Key: "dim", Value: strconv.Itoa(testVecDim)}}
+		}
+		collSchema.Fields = append(collSchema.Fields, field)
+		fieldsData = append(fieldsData, fieldData)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		stream, err := encodeArrowStream(collSchema, fieldsData, 1<<20)
+		require.NoError(t, err)
+		schema, records := readArrowStream(t, stream)
+		require.Len(t, records, 1)
+		record := records[0]
+		defer record.Release()
+
+		require.Equal(t, len(dataTypes), len(schema.Fields()))
+		assert.Equal(t, int64(numRows), record.NumRows())
+		for i, fieldData := range fieldsData {
+			field := schema.Field(i)
+			assert.Equal(t, fieldData.GetFieldName(), field.Name)
+			index := field.Metadata.FindKey(arrowFieldIDKey)
+			require.GreaterOrEqual(t, index, 0)
+			assert.Equal(t, strconv.FormatInt(fieldData.GetFieldId(), 10), field.Metadata.Values()[index])
+		}
+
+		bools := record.Column(0).(*array.Boolean)
+		for j, b := range fieldsData[0].GetScalars().GetBoolData().GetData() {
+			assert.Equal(t, b, bools.Value(j))
+		}
+		assert.Equal(t, fieldsData[1].GetScalars().GetIntData().GetData(), record.Column(1).(*array.Int32).Int32Values())
+		assert.Equal(t, fieldsData[2].GetScalars().GetLongData().GetData(), record.Column(2).(*array.Int64).Int64Values())
+		assert.Equal(t, fieldsData[3].GetScalars().GetFloatData().GetData(), record.Column(3).(*array.Float32).Float32Values())
+		assert.Equal(t, fieldsData[4].GetScalars().GetDoubleData().GetData(), record.Column(4).(*array.Float64).Float64Values())
+		strs := record.Column(5).(*array.String)
+		for j, s := range fieldsData[5].GetScalars().GetStringData().GetData() {
+			assert.Equal(t, s, strs.Value(j))
+		}
+
+		assert.Equal(t, arrow.FixedSizeListOf(testVecDim, arrow.PrimitiveTypes.Float32), schema.Field(6).Type)
+		vectors := record.Column(6).(*array.FixedSizeList)
+		assert.Equal(t, numRows, vectors.Len())
+		assert.Equal(t, fieldsData[6].GetVectors().GetFloatVector().GetData(), vectors.ListValues().(*array.Float32).Float32Values())
+
+		binaryVectors := record.Column(7).(*array.FixedSizeBinary)
+		expected := fieldsData[7].GetVectors().GetBinaryVector()
+		for j := 0; j < numRows; j++ {
+			assert.Equal(t, expected[j*testVecDim/8:(j+1)*testVecDim/8], binaryVectors.Value(j))
+		}
+	})
+
+	t.Run("batches", func(t *testing.T) {
+		fields := fieldsData[1:3]
+		w, err := newArrowStreamWriter(collSchema, fields, 1<<20)
+		require.NoError(t, err)
+		require.NoError(t, w.write(fields))
+		require.NoError(t, w.write(fields))
+		stream, err := w.finish()
+		require.NoError(t, err)
+
+		_, records := readArrowStream(t, stream)
+		require.Len(t, records, 2)
+		for _, record := range records {
+			assert.Equal(t, int64(numRows), record.NumRows())
+			assert.Equal(t, fields[1].GetScalars().GetLongData().GetData(), record.Column(1).(*array.Int64).Int64Values())
+			record.Release()
+		}
+	})
+
+	t.Run("schema only", func(t *testing.T) {
+		w, err := newArrowStreamWriter(collSchema, fieldsData, 1<<20)
+		require.NoError(t, err)
+		stream, err := w.finish()
+		require.NoError(t, err)
+		schema, records := readArrowStream(t, stream)
+		assert.Empty(t, records)
+		assert.Equal(t, len(dataTypes), len(schema.Fields()))
+	})
+
+	t.Run("nulls", func(t *testing.T) {
+		empty := &schemapb.FieldData{
+			Type:      schemapb.DataType_Int64,
+			FieldName: "computed",
+			FieldId:   0,
+			Field:     &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{}},
+		}
+		fields := []*schemapb.FieldData{fieldsData[1], empty}
+		stream, err := encodeArrowStream(collSchema, fields, 1<<20)
+		require.NoError(t, err)
+		schema, records := readArrowStream(t, stream)
+		require.Len(t, records, 1)
+		defer records[0].Release()
+		assert.Equal(t, arrow.PrimitiveTypes.Int64, schema.Field(1).Type)
+		assert.True(t, schema.Field(1).Nullable)
+		assert.Equal(t, numRows, records[0].Column(1).NullN())
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := encodeArrowStream(collSchema, fieldsData, 1024)
+		var tooLarge *arrowResultTooLargeError
+		assert.True(t, errors.As(err, &tooLarge))
+	})
+
+	t.Run("mismatched rows", func(t *testing.T) {
+		fields := []*schemapb.FieldData{fieldsData[1], generateFieldData(schemapb.DataType_Int64, "", 102, numRows-1)}
+		_, err := encodeArrowStream(collSchema, fields, 1<<20)
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		fields := []*schemapb.FieldData{{Type: schemapb.DataType_None, FieldName: "none"}}
+		_, err := encodeArrowStream(collSchema, fields, 1<<20)
+		assert.Error(t, err)
+	})
+}
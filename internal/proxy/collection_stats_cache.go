@@ -0,0 +1,169 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/mq/msgstream/mqwrapper"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+)
+
+// queryNodeCollectionStats is the latest snapshot of the collection stats pushed by a QueryNode
+type queryNodeCollectionStats struct {
+	collections map[UniqueID]*internalpb.CollectionStats
+	updateTime  time.Time
+}
+
+// collectionStatsCache consumes the collection stats pushed by QueryNodes to the query node stats channel, and keeps
+// the recent snapshot of every QueryNode to serve the in-memory stats of ShowCollections.
+// The stats of a QueryNode not pushing for expire are dropped.
+type collectionStatsCache struct {
+	expire time.Duration
+
+	mu    sync.RWMutex
+	nodes map[UniqueID]*queryNodeCollectionStats
+
+	factory msgstream.Factory
+	stream  msgstream.MsgStream
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newCollectionStatsCache(ctx context.Context, factory msgstream.Factory, expire time.Duration) *collectionStatsCache {
+	ctx1, cancel := context.WithCancel(ctx)
+	return &collectionStatsCache{
+		expire:  expire,
+		nodes:   make(map[UniqueID]*queryNodeCollectionStats),
+		factory: factory,
+		ctx:     ctx1,
+		cancel:  cancel,
+	}
+}
+
+// start consumes the query node stats channel from the latest message
+func (c *collectionStatsCache) start() error {
+	stream, err := c.factory.NewMsgStream(c.ctx)
+	if err != nil {
+		return err
+	}
+	subName := fmt.Sprintf("%s-%d-collection-stats", Params.CommonCfg.ProxySubName, Params.ProxyCfg.ProxyID)
+	stream.AsConsumerWithPosition([]string{Params.CommonCfg.QueryNodeStats}, subName, mqwrapper.SubscriptionPositionLatest)
+	stream.Start()
+	c.stream = stream
+	log.Info("collection stats cache started", zap.String("channel", Params.CommonCfg.QueryNodeStats), zap.String("subName", subName))
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		for {
+			select {
+			case <-c.ctx.Done():
+				log.Info("collection stats cache loop exit")
+				return
+			case msgPack, ok := <-stream.Chan():
+				if !ok {
+					log.Warn("query node stats channel closed")
+					return
+				}
+				if msgPack == nil {
+					continue
+				}
+				now := time.Now()
+				for _, msg := range msgPack.Msgs {
+					if statsMsg, ok := msg.(*msgstream.QueryNodeStatsMsg); ok {
+						c.update(&statsMsg.QueryNodeStats, now)
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (c *collectionStatsCache) close() {
+	c.cancel()
+	c.wg.Wait()
+	if c.stream != nil {
+		c.stream.Close()
+	}
+}
+
+// update applies the stats pushed by a QueryNode, a full snapshot replaces the previous stats of the node,
+// otherwise the collections carried are updated and the released ones are removed
+func (c *collectionStatsCache) update(stats *internalpb.QueryNodeStats, now time.Time) {
+	// the stats of segments pushed by earlier QueryNodes carry no collection stats
+	if !stats.GetFullSnapshot() && len(stats.GetCollectionStats()) == 0 && len(stats.GetReleasedCollectionIDs()) == 0 {
+		return
+	}
+	nodeID := stats.GetBase().GetSourceID()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	node, ok := c.nodes[nodeID]
+	// the deltas are not applied to the expired stats, which may miss some releases
+	if !ok || stats.GetFullSnapshot() || now.Sub(node.updateTime) > c.expire {
+		node = &queryNodeCollectionStats{collections: make(map[UniqueID]*internalpb.CollectionStats)}
+		c.nodes[nodeID] = node
+	}
+	for _, collectionStats := range stats.GetCollectionStats() {
+		node.collections[collectionStats.GetCollectionID()] = collectionStats
+	}
+	for _, collectionID := range stats.GetReleasedCollectionIDs() {
+		delete(node.collections, collectionID)
+	}
+	node.updateTime = now
+
+	for id, node := range c.nodes {
+		if now.Sub(node.updateTime) > c.expire {
+			delete(c.nodes, id)
+		}
+	}
+}
+
+// get returns the stats of collection on all the QueryNodes pushed within expire before now,
+// the stats are all zero if no QueryNode pushed the stats of collection
+func (c *collectionStatsCache) get(collectionID UniqueID, now time.Time) *milvuspb.CollectionInMemoryStats {
+	ret := &milvuspb.CollectionInMemoryStats{}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, node := range c.nodes {
+		if now.Sub(node.updateTime) > c.expire {
+			continue
+		}
+		collectionStats, ok := node.collections[collectionID]
+		if !ok {
+			continue
+		}
+		ret.NumRows += collectionStats.GetNumRows()
+		ret.MemorySize += collectionStats.GetMemorySize()
+		ret.Qps += collectionStats.GetQps()
+		if collectionStats.GetTsafeLagMs() > ret.TsafeLagMs {
+			ret.TsafeLagMs = collectionStats.GetTsafeLagMs()
+		}
+	}
+	return ret
+}
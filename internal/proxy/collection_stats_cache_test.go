@@ -0,0 +1,173 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+func genQueryNodeStats(nodeID UniqueID, full bool, released []UniqueID, stats ...*internalpb.CollectionStats) *internalpb.QueryNodeStats {
+	return &internalpb.QueryNodeStats{
+		Base:                  &commonpb.MsgBase{MsgType: commonpb.MsgType_QueryNodeStats, SourceID: nodeID},
+		CollectionStats:       stats,
+		ReleasedCollectionIDs: released,
+		FullSnapshot:          full,
+	}
+}
+
+func TestCollectionStatsCache_update(t *testing.T) {
+	c := newCollectionStatsCache(context.Background(), newSimpleMockMsgStreamFactory(), time.Minute)
+	now := time.Now()
+
+	assert.Equal(t, &milvuspb.CollectionInMemoryStats{}, c.get(1, now))
+
+	c.update(genQueryNodeStats(1, true, nil,
+		&internalpb.CollectionStats{CollectionID: 1, NumRows: 100, MemorySize: 1000, Qps: 1.5, TsafeLagMs: 20},
+		&internalpb.CollectionStats{CollectionID: 2, NumRows: 10, MemorySize: 100}), now)
+	c.update(genQueryNodeStats(2, true, nil,
+		&internalpb.CollectionStats{CollectionID: 1, NumRows: 50, MemorySize: 500, Qps: 2.5, TsafeLagMs: 10}), now)
+
+	// aggregated on all the query nodes
+	assert.Equal(t, &milvuspb.CollectionInMemoryStats{NumRows: 150, MemorySize: 1500, Qps: 4, TsafeLagMs: 20}, c.get(1, now))
+	assert.Equal(t, &milvuspb.CollectionInMemoryStats{NumRows: 10, MemorySize: 100}, c.get(2, now))
+
+	t.Run("delta", func(t *testing.T) {
+		c.update(genQueryNodeStats(1, false, []UniqueID{2},
+			&internalpb.CollectionStats{CollectionID: 1, NumRows: 200, MemorySize: 2000}), now)
+		assert.Equal(t, &milvuspb.CollectionInMemoryStats{NumRows: 250, MemorySize: 2500, Qps: 2.5, TsafeLagMs: 10}, c.get(1, now))
+		assert.Equal(t, &milvuspb.CollectionInMemoryStats{}, c.get(2, now))
+
+		// the stats of segments carry no collection stats
+		c.update(&internalpb.QueryNodeStats{
+			Base:     &commonpb.MsgBase{MsgType: commonpb.MsgType_QueryNodeStats, SourceID: 1},
+			SegStats: []*internalpb.SegmentStats{{SegmentID: 1, NumRows: 1}},
+		}, now)
+		assert.Equal(t, int64(250), c.get(1, now).GetNumRows())
+	})
+
+	t.Run("full snapshot", func(t *testing.T) {
+		c.update(genQueryNodeStats(1, true, nil,
+			&internalpb.CollectionStats{CollectionID: 2, NumRows: 20}), now)
+		assert.Equal(t, int64(50), c.get(1, now).GetNumRows())
+		assert.Equal(t, int64(20), c.get(2, now).GetNumRows())
+	})
+
+	t.Run("expire", func(t *testing.T) {
+		later := now.Add(2 * time.Minute)
+		assert.Equal(t, &milvuspb.CollectionInMemoryStats{}, c.get(1, later))
+
+		c.update(genQueryNodeStats(2, false, nil,
+			&internalpb.CollectionStats{CollectionID: 1, NumRows: 60}), later)
+		assert.Equal(t, int64(60), c.get(1, later).GetNumRows())
+		// the expired query node is dropped
+		assert.Len(t, c.nodes, 1)
+	})
+}
+
+func TestCollectionStatsCache_consume(t *testing.T) {
+	c := newCollectionStatsCache(context.Background(), newSimpleMockMsgStreamFactory(), time.Minute)
+	require.NoError(t, c.start())
+	defer c.close()
+
+	// the mock stream consumes the messages produced by itself
+	err := c.stream.Produce(&msgstream.MsgPack{
+		Msgs: []msgstream.TsMsg{
+			&msgstream.QueryNodeStatsMsg{
+				BaseMsg: msgstream.BaseMsg{HashValues: []uint32{0}},
+				QueryNodeStats: *genQueryNodeStats(1, true, nil,
+					&internalpb.CollectionStats{CollectionID: 1, NumRows: 100, MemorySize: 1000}),
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return c.get(1, time.Now()).GetNumRows() == 100
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+// showCollectionsRootCoord returns collection1 on ShowCollections
+type showCollectionsRootCoord struct {
+	MockRootCoordClientInterface
+}
+
+func (m *showCollectionsRootCoord) ShowCollections(ctx context.Context, req *milvuspb.ShowCollectionsRequest) (*milvuspb.ShowCollectionsResponse, error) {
+	return &milvuspb.ShowCollectionsResponse{
+		Status:          &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		CollectionNames: []string{"collection1"},
+		CollectionIds:   []int64{1},
+	}, nil
+}
+
+func TestShowCollectionsTask_inMemoryStats(t *testing.T) {
+	ctx := context.Background()
+	err := InitMetaCache(&MockRootCoordClientInterface{})
+	require.NoError(t, err)
+
+	qc := NewQueryCoordMock()
+	qc.Init()
+	qc.Start()
+	defer qc.Stop()
+	qc.SetShowCollectionsFunc(func(ctx context.Context, req *querypb.ShowCollectionsRequest) (*querypb.ShowCollectionsResponse, error) {
+		return &querypb.ShowCollectionsResponse{
+			Status:              &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			CollectionIDs:       []int64{1},
+			InMemoryPercentages: []int64{100},
+		}, nil
+	})
+
+	cache := newCollectionStatsCache(ctx, newSimpleMockMsgStreamFactory(), time.Minute)
+	cache.update(genQueryNodeStats(1, true, nil,
+		&internalpb.CollectionStats{CollectionID: 1, NumRows: 100, MemorySize: 1000, Qps: 3, TsafeLagMs: 5}), time.Now())
+
+	execute := func(collectionStats *collectionStatsCache) *milvuspb.ShowCollectionsResponse {
+		task := &showCollectionsTask{
+			ctx:       ctx,
+			Condition: NewTaskCondition(ctx),
+			ShowCollectionsRequest: &milvuspb.ShowCollectionsRequest{
+				Base:            &commonpb.MsgBase{},
+				Type:            milvuspb.ShowType_InMemory,
+				CollectionNames: []string{"collection1"},
+			},
+			rootCoord:       &showCollectionsRootCoord{},
+			queryCoord:      qc,
+			collectionStats: collectionStats,
+		}
+		require.NoError(t, task.PreExecute(ctx))
+		require.NoError(t, task.Execute(ctx))
+		return task.result
+	}
+
+	result := execute(cache)
+	assert.Equal(t, []int64{100}, result.GetInMemoryPercentages())
+	assert.Equal(t, []*milvuspb.CollectionInMemoryStats{{NumRows: 100, MemorySize: 1000, Qps: 3, TsafeLagMs: 5}}, result.GetInMemoryStats())
+
+	// not filled if the stats are not consumed
+	result = execute(nil)
+	assert.Equal(t, []int64{100}, result.GetInMemoryPercentages())
+	assert.Empty(t, result.GetInMemoryStats())
+}
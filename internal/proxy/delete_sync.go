@@ -0,0 +1,138 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+// deleteSyncer waits until the shard leaders of a collection apply a delete, so that the queries with Strong
+// consistency issued after a synced delete returns never see the deleted entities.
+// The timestamps the shard leaders applied their channels up to are polled from their data distribution,
+// by the clients cached by the replica load balancer polling the same shard leaders.
+type deleteSyncer struct {
+	qc       types.QueryCoord
+	clients  *replicaLoadBalancer
+	timeout  time.Duration
+	interval time.Duration
+}
+
+func newDeleteSyncer(qc types.QueryCoord, clients *replicaLoadBalancer, timeout, interval time.Duration) *deleteSyncer {
+	return &deleteSyncer{
+		qc:       qc,
+		clients:  clients,
+		timeout:  timeout,
+		interval: interval,
+	}
+}
+
+// wait polls the shard leaders of collectionName until all of them applied their channels up to ts, or the timeout
+// expires. It returns the channels not synced when the timeout expires, the delete is still applied eventually.
+func (s *deleteSyncer) wait(ctx context.Context, collectionName string, ts Timestamp) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	shards, err := globalMetaCache.GetShards(ctx, true, collectionName, s.qc)
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[string]*querypb.ShardLeadersList, len(shards))
+	for _, shard := range shards {
+		pending[shard.GetChannelName()] = shard
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		for channel, leaders := range pending {
+			if s.isSynced(ctx, leaders, ts) {
+				delete(pending, channel)
+			}
+		}
+		if len(pending) == 0 {
+			return nil, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			channels := make([]string, 0, len(pending))
+			for channel := range pending {
+				channels = append(channels, channel)
+			}
+			sort.Strings(channels)
+			return channels, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// isSynced returns whether the leaders of all the replicas of a shard applied the channel up to ts
+func (s *deleteSyncer) isSynced(ctx context.Context, leaders *querypb.ShardLeadersList, ts Timestamp) bool {
+	s.clients.watch(leaders)
+	for i, nodeID := range leaders.GetNodeIds() {
+		if i >= len(leaders.GetNodeAddrs()) {
+			return false
+		}
+		appliedTs, err := s.getAppliedTs(ctx, nodeID, leaders.GetNodeAddrs()[i], leaders.GetChannelName())
+		if err != nil {
+			log.Debug("failed to get the applied ts of shard leader", zap.Int64("nodeID", nodeID),
+				zap.String("channel", leaders.GetChannelName()), zap.Error(err))
+			return false
+		}
+		if appliedTs < ts {
+			return false
+		}
+	}
+	return true
+}
+
+// getAppliedTs returns the timestamp the QueryNode nodeID at address applied the channel up to
+func (s *deleteSyncer) getAppliedTs(ctx context.Context, nodeID UniqueID, address string, channel string) (Timestamp, error) {
+	qn, err := s.clients.pollClient(ctx, nodeID, address)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := qn.GetDataDistribution(ctx, &querypb.GetDataDistributionRequest{
+		Base: &commonpb.MsgBase{
+			MsgType:  commonpb.MsgType_SystemInfo,
+			SourceID: Params.ProxyCfg.ProxyID,
+		},
+	})
+	if err != nil {
+		s.clients.dropPollClient(nodeID, qn)
+		return 0, err
+	}
+	if resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return 0, fmt.Errorf("failed to get data distribution of QueryNode %d, reason = %s", resp.GetNodeID(), resp.GetStatus().GetReason())
+	}
+	for _, ownership := range resp.GetChannels() {
+		if ownership.GetChannel() == channel {
+			return ownership.GetServiceableTs(), nil
+		}
+	}
+	return 0, fmt.Errorf("channel %s is not watched by QueryNode %d", channel, resp.GetNodeID())
+}
@@ -0,0 +1,133 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+// appliedTsQueryNodeMock reports channel-1 applied up to a timestamp advancing by step on every poll
+type appliedTsQueryNodeMock struct {
+	QueryNodeMock
+	appliedTs atomic.Uint64
+	step      uint64
+	polls     atomic.Int64
+}
+
+func (m *appliedTsQueryNodeMock) GetDataDistribution(ctx context.Context, req *querypb.GetDataDistributionRequest) (*querypb.GetDataDistributionResponse, error) {
+	m.polls.Inc()
+	return &querypb.GetDataDistributionResponse{
+		Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Channels: []*querypb.DmChannelOwnership{
+			{Channel: "channel-1", ServiceableTs: m.appliedTs.Add(m.step)},
+		},
+	}, nil
+}
+
+func TestDeleteSyncer_wait(t *testing.T) {
+	err := InitMetaCache(&MockRootCoordClientInterface{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	collectionName := "collection1"
+	qc := NewQueryCoordMock()
+	qc.Init()
+	qc.Start()
+	defer qc.Stop()
+	qc.validShardLeaders = true
+
+	t.Run("synced", func(t *testing.T) {
+		qn := &appliedTsQueryNodeMock{step: 100}
+		connects := atomic.NewInt64(0)
+		syncer := newDeleteSyncer(qc, newReplicaLoadBalancer(ctx, func(ctx context.Context, address string) (types.QueryNode, error) {
+			connects.Inc()
+			return qn, nil
+		}), time.Second, time.Millisecond)
+
+		unsynced, err := syncer.wait(ctx, collectionName, 1000)
+		assert.NoError(t, err)
+		assert.Empty(t, unsynced)
+		// every leader of the 3 replicas reaches 1000 before the wait returns
+		assert.GreaterOrEqual(t, qn.appliedTs.Load(), uint64(1000+2*100))
+		// the clients of the leaders are reused across the polls
+		assert.Greater(t, qn.polls.Load(), int64(3))
+		assert.Equal(t, int64(3), connects.Load())
+	})
+
+	t.Run("already synced", func(t *testing.T) {
+		qn := &appliedTsQueryNodeMock{step: 0}
+		qn.appliedTs.Store(1000)
+		syncer := newDeleteSyncer(qc, newReplicaLoadBalancer(ctx, func(ctx context.Context, address string) (types.QueryNode, error) {
+			return qn, nil
+		}), time.Second, time.Hour)
+
+		unsynced, err := syncer.wait(ctx, collectionName, 1000)
+		assert.NoError(t, err)
+		assert.Empty(t, unsynced)
+		assert.Equal(t, int64(3), qn.polls.Load())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		qn := &appliedTsQueryNodeMock{step: 1}
+		syncer := newDeleteSyncer(qc, newReplicaLoadBalancer(ctx, func(ctx context.Context, address string) (types.QueryNode, error) {
+			return qn, nil
+		}), 50*time.Millisecond, 10*time.Millisecond)
+
+		start := time.Now()
+		unsynced, err := syncer.wait(ctx, collectionName, 1000000)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"channel-1"}, unsynced)
+		assert.GreaterOrEqual(t, int64(time.Since(start)), int64(50*time.Millisecond))
+	})
+
+	t.Run("leader unavailable", func(t *testing.T) {
+		syncer := newDeleteSyncer(qc, newReplicaLoadBalancer(ctx, func(ctx context.Context, address string) (types.QueryNode, error) {
+			return nil, errors.New("mock error")
+		}), 20*time.Millisecond, 5*time.Millisecond)
+
+		unsynced, err := syncer.wait(ctx, collectionName, 1000)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"channel-1"}, unsynced)
+	})
+
+	t.Run("channel not watched", func(t *testing.T) {
+		syncer := newDeleteSyncer(qc, newReplicaLoadBalancer(ctx, func(ctx context.Context, address string) (types.QueryNode, error) {
+			return &appliedTsQueryNodeMock{}, nil
+		}), 20*time.Millisecond, 5*time.Millisecond)
+		_, err := syncer.getAppliedTs(ctx, 1, "localhost:9000", "channel-2")
+		assert.Error(t, err)
+	})
+
+	t.Run("collection not found", func(t *testing.T) {
+		syncer := newDeleteSyncer(qc, newReplicaLoadBalancer(ctx, func(ctx context.Context, address string) (types.QueryNode, error) {
+			return &appliedTsQueryNodeMock{}, nil
+		}), 20*time.Millisecond, 5*time.Millisecond)
+		_, err := syncer.wait(ctx, "non-exists", 1000)
+		assert.Error(t, err)
+	})
+}
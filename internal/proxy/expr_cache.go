@@ -0,0 +1,134 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/parser/planparser"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/cache"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// exprCacheKey identifies a parsed expression, the schema version keeps the expressions parsed against
+// an outdated schema from being hit
+type exprCacheKey struct {
+	collectionID  UniqueID
+	schemaVersion uint64
+	expr          string
+}
+
+// exprCache is the LRU cache of the filter expressions of search and query parsed into planpb.Expr.
+// The cached expressions are never handed out, callers get copies of them to mutate freely.
+type exprCache struct {
+	lru *cache.LRU
+}
+
+// globalExprCache caches the parsed expressions of all the collections, nil if disabled
+var globalExprCache *exprCache
+
+// initExprCache initializes globalExprCache with the capacity Params.ProxyCfg.ExprCacheSize
+func initExprCache() error {
+	if Params.ProxyCfg.ExprCacheSize <= 0 {
+		globalExprCache = nil
+		return nil
+	}
+	c, err := newExprCache(Params.ProxyCfg.ExprCacheSize)
+	if err != nil {
+		return err
+	}
+	globalExprCache = c
+	return nil
+}
+
+func newExprCache(capacity int) (*exprCache, error) {
+	lru, err := cache.NewLRU(capacity, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &exprCache{lru: lru}, nil
+}
+
+// get returns a copy of the cached expression of key
+func (c *exprCache) get(key exprCacheKey) (*planpb.Expr, bool) {
+	value, ok := c.lru.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(value.(*planpb.Expr)).(*planpb.Expr), true
+}
+
+// add caches a copy of expr, so that the mutations of expr by the caller are not seen by others
+func (c *exprCache) add(key exprCacheKey, expr *planpb.Expr) {
+	c.lru.Add(key, proto.Clone(expr).(*planpb.Expr))
+}
+
+// removeCollection drops the cached expressions of collection, on dropping or changing its schema
+func (c *exprCache) removeCollection(collectionID UniqueID) {
+	if c == nil {
+		return
+	}
+	for _, key := range c.lru.Keys() {
+		if key.(exprCacheKey).collectionID == collectionID {
+			c.lru.Remove(key)
+		}
+	}
+}
+
+func (c *exprCache) close() {
+	c.lru.Close()
+}
+
+// parseExpr parses the filter expression exprStr of collection against schema, the parsed expressions are cached
+// in globalExprCache if schema is the latest one cached in globalMetaCache. Returns nil if exprStr is empty.
+func parseExpr(ctx context.Context, collectionName string, schema *schemapb.CollectionSchema, exprStr string) (*planpb.Expr, error) {
+	parse := func() (*planpb.Expr, error) {
+		schemaHelper, err := typeutil.CreateSchemaHelper(schema)
+		if err != nil {
+			return nil, err
+		}
+		return planparser.ParseExpr(schemaHelper, exprStr)
+	}
+	if globalExprCache == nil || exprStr == "" {
+		return parse()
+	}
+	collInfo, err := globalMetaCache.GetCollectionInfo(ctx, collectionName)
+	if err != nil || collInfo.schema != schema {
+		// the schema has changed since it was got
+		return parse()
+	}
+
+	nodeID := strconv.FormatInt(Params.ProxyCfg.ProxyID, 10)
+	key := exprCacheKey{collectionID: collInfo.collID, schemaVersion: collInfo.schemaVersion, expr: exprStr}
+	if expr, ok := globalExprCache.get(key); ok {
+		metrics.ProxyCacheHitCounter.WithLabelValues(nodeID, "ParseExpr", metrics.CacheHitLabel).Inc()
+		return expr, nil
+	}
+	metrics.ProxyCacheHitCounter.WithLabelValues(nodeID, "ParseExpr", metrics.CacheMissLabel).Inc()
+	expr, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	globalExprCache.add(key, expr)
+	return expr, nil
+}
@@ -0,0 +1,163 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+func TestParseExpr_cache(t *testing.T) {
+	ctx := context.Background()
+	defer func(metaCache Cache, exprCache *exprCache) {
+		globalMetaCache, globalExprCache = metaCache, exprCache
+	}(globalMetaCache, globalExprCache)
+
+	const collectionName = "expr_cache"
+	const exprStr = "int64 > 10 and int64 < 100"
+	metaCache, err := NewMetaCache(nil)
+	require.NoError(t, err)
+	globalMetaCache = metaCache
+	updateSchema := func(schema *schemapb.CollectionSchema) {
+		metaCache.mu.Lock()
+		defer metaCache.mu.Unlock()
+		metaCache.updateCollection(&milvuspb.DescribeCollectionResponse{CollectionID: 1, Schema: schema}, collectionName)
+	}
+	updateSchema(constructCollectionSchema(testInt64Field, testFloatVecField, testVecDim, collectionName))
+	globalExprCache, err = newExprCache(16)
+	require.NoError(t, err)
+	defer globalExprCache.close()
+
+	nodeID := strconv.FormatInt(Params.ProxyCfg.ProxyID, 10)
+	hits := metrics.ProxyCacheHitCounter.WithLabelValues(nodeID, "ParseExpr", metrics.CacheHitLabel)
+	misses := metrics.ProxyCacheHitCounter.WithLabelValues(nodeID, "ParseExpr", metrics.CacheMissLabel)
+	parse := func(exprStr string) (*planpb.Expr, error) {
+		schema, err := globalMetaCache.GetCollectionSchema(ctx, collectionName)
+		require.NoError(t, err)
+		return parseExpr(ctx, collectionName, schema, exprStr)
+	}
+
+	hitsBefore, missesBefore := testutil.ToFloat64(hits), testutil.ToFloat64(misses)
+	expected, err := parse(exprStr)
+	require.NoError(t, err)
+	require.NotNil(t, expected)
+	assert.Equal(t, missesBefore+1, testutil.ToFloat64(misses))
+	assert.Equal(t, 1, globalExprCache.lru.Len())
+
+	t.Run("hit", func(t *testing.T) {
+		before := testutil.ToFloat64(hits)
+		expr, err := parse(exprStr)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(expected, expr))
+		assert.Equal(t, before+1, testutil.ToFloat64(hits))
+		assert.Equal(t, hitsBefore+1, testutil.ToFloat64(hits))
+	})
+
+	t.Run("immutable", func(t *testing.T) {
+		expr, err := parse(exprStr)
+		require.NoError(t, err)
+		// mutate the returned expression as the tasks may do
+		expr.GetBinaryExpr().Left = nil
+		expr.Expr = nil
+
+		expr, err = parse(exprStr)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(expected, expr))
+	})
+
+	t.Run("errors not cached", func(t *testing.T) {
+		before := globalExprCache.lru.Len()
+		_, err := parse("not_exist > 1")
+		assert.Error(t, err)
+		assert.Equal(t, before, globalExprCache.lru.Len())
+
+		expr, err := parse("")
+		assert.NoError(t, err)
+		assert.Nil(t, expr)
+		assert.Equal(t, before, globalExprCache.lru.Len())
+	})
+
+	t.Run("invalidated on schema change", func(t *testing.T) {
+		outdated, err := globalMetaCache.GetCollectionSchema(ctx, collectionName)
+		require.NoError(t, err)
+		info, err := globalMetaCache.GetCollectionInfo(ctx, collectionName)
+		require.NoError(t, err)
+		version := info.schemaVersion
+
+		// the same schema keeps the version
+		updateSchema(proto.Clone(outdated).(*schemapb.CollectionSchema))
+		info, err = globalMetaCache.GetCollectionInfo(ctx, collectionName)
+		require.NoError(t, err)
+		assert.Equal(t, version, info.schemaVersion)
+		assert.Equal(t, 1, globalExprCache.lru.Len())
+
+		schema := proto.Clone(outdated).(*schemapb.CollectionSchema)
+		schema.Fields = append(schema.Fields, &schemapb.FieldSchema{FieldID: 200, Name: testInt32Field, DataType: schemapb.DataType_Int32})
+		updateSchema(schema)
+		info, err = globalMetaCache.GetCollectionInfo(ctx, collectionName)
+		require.NoError(t, err)
+		assert.Greater(t, info.schemaVersion, version)
+		assert.Equal(t, 0, globalExprCache.lru.Len())
+
+		before := testutil.ToFloat64(misses)
+		expr, err := parse(exprStr)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(expected, expr))
+		assert.Equal(t, before+1, testutil.ToFloat64(misses))
+		_, err = parse("int32 > 1")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, globalExprCache.lru.Len())
+
+		// the expressions parsed against the outdated schema are not cached
+		_, err = parseExpr(ctx, collectionName, outdated, "int64 > 1")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, globalExprCache.lru.Len())
+	})
+
+	t.Run("invalidated on collection removed", func(t *testing.T) {
+		require.NotZero(t, globalExprCache.lru.Len())
+		globalMetaCache.RemoveCollection(ctx, collectionName)
+		assert.Equal(t, 0, globalExprCache.lru.Len())
+	})
+}
+
+func TestParseExpr_disabled(t *testing.T) {
+	defer func(exprCache *exprCache) { globalExprCache = exprCache }(globalExprCache)
+	defer func(size int) { Params.ProxyCfg.ExprCacheSize = size }(Params.ProxyCfg.ExprCacheSize)
+
+	Params.ProxyCfg.ExprCacheSize = 0
+	require.NoError(t, initExprCache())
+	assert.Nil(t, globalExprCache)
+	// no-op on the disabled cache
+	globalExprCache.removeCollection(1)
+
+	schema := constructCollectionSchema(testInt64Field, testFloatVecField, testVecDim, "expr_cache")
+	expr, err := parseExpr(context.Background(), "expr_cache", schema, "int64 in [1, 2, 3]")
+	assert.NoError(t, err)
+	assert.NotNil(t, expr.GetTermExpr())
+}
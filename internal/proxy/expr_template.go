@@ -0,0 +1,388 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+type exprTemplateTokenKind int
+
+const (
+	exprTemplateIdent exprTemplateTokenKind = iota
+	exprTemplateLiteral
+	exprTemplatePlaceholder
+	exprTemplateOperator
+)
+
+type exprTemplateToken struct {
+	kind exprTemplateTokenKind
+	pos  int // position of the first byte of token in template
+	end  int // position right after the last byte of token in template
+	text string
+}
+
+var exprTemplateComparisons = map[string]bool{
+	"==": true,
+	"!=": true,
+	"<":  true,
+	"<=": true,
+	">":  true,
+	">=": true,
+}
+
+var exprTemplateKeywords = map[string]bool{
+	"in":    true,
+	"not":   true,
+	"and":   true,
+	"or":    true,
+	"true":  true,
+	"false": true,
+}
+
+func isExprTemplateIdentChar(c byte) bool {
+	return c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
+
+// tokenizeExprTemplate splits template into the tokens needed to locate the placeholders and their operands,
+// the string literals are skipped as a whole, so that braces in them are never taken as placeholders
+func tokenizeExprTemplate(template string) ([]exprTemplateToken, error) {
+	tokens := make([]exprTemplateToken, 0)
+	for i := 0; i < len(template); {
+		c := template[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '"' || c == '\'':
+			start := i
+			for i++; i < len(template) && template[i] != c; i++ {
+				if template[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(template) {
+				return nil, fmt.Errorf("invalid expression template `%s`: unterminated string at position %d", template, start)
+			}
+			i++
+			tokens = append(tokens, exprTemplateToken{kind: exprTemplateLiteral, pos: start, end: i, text: template[start:i]})
+		case c == '{':
+			start := i
+			for i++; i < len(template) && isExprTemplateIdentChar(template[i]); i++ {
+			}
+			if i >= len(template) || template[i] != '}' || i == start+1 {
+				return nil, fmt.Errorf("invalid expression template `%s`: invalid placeholder at position %d", template, start)
+			}
+			i++
+			tokens = append(tokens, exprTemplateToken{kind: exprTemplatePlaceholder, pos: start, end: i, text: template[start+1 : i-1]})
+		case c == '}':
+			return nil, fmt.Errorf("invalid expression template `%s`: unexpected '}' at position %d", template, i)
+		case isExprTemplateIdentChar(c) || c == '.':
+			start := i
+			for i < len(template) && (isExprTemplateIdentChar(template[i]) || template[i] == '.') {
+				i++
+			}
+			kind := exprTemplateIdent
+			if unicode.IsDigit(rune(c)) || c == '.' {
+				kind = exprTemplateLiteral
+			}
+			tokens = append(tokens, exprTemplateToken{kind: kind, pos: start, end: i, text: template[start:i]})
+		default:
+			start := i
+			i++
+			if i < len(template) && (strings.ContainsRune("=<>!", rune(c)) && template[i] == '=' || strings.ContainsRune("&|", rune(c)) && template[i] == c) {
+				i++
+			}
+			tokens = append(tokens, exprTemplateToken{kind: exprTemplateOperator, pos: start, end: i, text: template[start:i]})
+		}
+	}
+	return tokens, nil
+}
+
+func isExprTemplateField(token exprTemplateToken) bool {
+	return token.kind == exprTemplateIdent && !exprTemplateKeywords[token.text]
+}
+
+// exprTemplateInOperand returns the field on the left of `in` or `not in` ending right before tokens[i],
+// an empty string if tokens[i-1] is not `in`
+func exprTemplateInOperand(tokens []exprTemplateToken, i int) string {
+	if i < 2 || tokens[i-1].kind != exprTemplateIdent || tokens[i-1].text != "in" {
+		return ""
+	}
+	j := i - 2
+	if tokens[j].kind == exprTemplateIdent && tokens[j].text == "not" {
+		j--
+	}
+	if j < 0 || !isExprTemplateField(tokens[j]) {
+		return ""
+	}
+	return tokens[j].text
+}
+
+// exprTemplateOperand infers the field compared with the placeholder tokens[i], which is one of
+// `field op {p}`, `{p} op field`, `field in {p}` and `field in [..., {p}, ...]`,
+// inList tells whether the placeholder is the whole right operand of `in`
+func exprTemplateOperand(tokens []exprTemplateToken, i int) (field string, inList bool) {
+	if field := exprTemplateInOperand(tokens, i); field != "" {
+		return field, true
+	}
+	if i >= 2 && tokens[i-1].kind == exprTemplateOperator && exprTemplateComparisons[tokens[i-1].text] && isExprTemplateField(tokens[i-2]) {
+		return tokens[i-2].text, false
+	}
+	if i+2 < len(tokens) && tokens[i+1].kind == exprTemplateOperator && exprTemplateComparisons[tokens[i+1].text] && isExprTemplateField(tokens[i+2]) {
+		return tokens[i+2].text, false
+	}
+	// an element of a list, look for the enclosing `[` and the `in` before it
+	depth := 0
+	for j := i - 1; j >= 0; j-- {
+		if tokens[j].kind != exprTemplateOperator {
+			continue
+		}
+		switch tokens[j].text {
+		case "]":
+			depth++
+		case "[":
+			if depth == 0 {
+				return exprTemplateInOperand(tokens, j), false
+			}
+			depth--
+		}
+	}
+	return "", false
+}
+
+// renderExprTemplateString quotes s as a string literal of expression
+func renderExprTemplateString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '"':
+			sb.WriteString(`\"`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+func renderExprTemplateFloat(v float64, bitSize int) (string, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "", fmt.Errorf("%v is not supported", v)
+	}
+	s := strconv.FormatFloat(v, 'f', -1, bitSize)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s, nil
+}
+
+func checkExprTemplateInt(v int64, field *schemapb.FieldSchema) error {
+	if field == nil || typeutil.IsFloatingType(field.DataType) {
+		return nil
+	}
+	if !typeutil.IsIntegerType(field.DataType) {
+		return fmt.Errorf("integer value is not comparable with field %s of type %s", field.Name, field.DataType)
+	}
+	var min, max int64
+	switch field.DataType {
+	case schemapb.DataType_Int8:
+		min, max = math.MinInt8, math.MaxInt8
+	case schemapb.DataType_Int16:
+		min, max = math.MinInt16, math.MaxInt16
+	case schemapb.DataType_Int32:
+		min, max = math.MinInt32, math.MaxInt32
+	default:
+		return nil
+	}
+	if v < min || v > max {
+		return fmt.Errorf("value %d is out of the range of field %s of type %s", v, field.Name, field.DataType)
+	}
+	return nil
+}
+
+func checkExprTemplateKind(kind string, ok bool, field *schemapb.FieldSchema) error {
+	if field == nil || ok {
+		return nil
+	}
+	return fmt.Errorf("%s value is not comparable with field %s of type %s", kind, field.Name, field.DataType)
+}
+
+// renderExprTemplateValues renders the elements of value as literals of expression, checking them against
+// the type of the compared field if any
+func renderExprTemplateValues(value *schemapb.ScalarField, field *schemapb.FieldSchema) ([]string, error) {
+	var elems []string
+	switch data := value.GetData().(type) {
+	case *schemapb.ScalarField_BoolData:
+		if err := checkExprTemplateKind("bool", typeutil.IsBoolType(field.GetDataType()), field); err != nil {
+			return nil, err
+		}
+		for _, v := range data.BoolData.GetData() {
+			elems = append(elems, strconv.FormatBool(v))
+		}
+	case *schemapb.ScalarField_IntData:
+		for _, v := range data.IntData.GetData() {
+			if err := checkExprTemplateInt(int64(v), field); err != nil {
+				return nil, err
+			}
+			elems = append(elems, strconv.FormatInt(int64(v), 10))
+		}
+	case *schemapb.ScalarField_LongData:
+		for _, v := range data.LongData.GetData() {
+			if err := checkExprTemplateInt(v, field); err != nil {
+				return nil, err
+			}
+			elems = append(elems, strconv.FormatInt(v, 10))
+		}
+	case *schemapb.ScalarField_FloatData:
+		if err := checkExprTemplateKind("float", typeutil.IsFloatingType(field.GetDataType()), field); err != nil {
+			return nil, err
+		}
+		for _, v := range data.FloatData.GetData() {
+			s, err := renderExprTemplateFloat(float64(v), 32)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, s)
+		}
+	case *schemapb.ScalarField_DoubleData:
+		if err := checkExprTemplateKind("float", typeutil.IsFloatingType(field.GetDataType()), field); err != nil {
+			return nil, err
+		}
+		for _, v := range data.DoubleData.GetData() {
+			s, err := renderExprTemplateFloat(v, 64)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, s)
+		}
+	case *schemapb.ScalarField_StringData:
+		if err := checkExprTemplateKind("string", typeutil.IsStringType(field.GetDataType()), field); err != nil {
+			return nil, err
+		}
+		for _, v := range data.StringData.GetData() {
+			elems = append(elems, renderExprTemplateString(v))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", data)
+	}
+	return elems, nil
+}
+
+// substituteExprTemplate substitutes the typed values into the placeholders {name} of expression template,
+// the string values are escaped and the values are checked against the type of the field they are compared with,
+// every parameter must be referenced by template and every placeholder must have a value.
+// The values are substituted in one pass, so that placeholders in the values are never expanded
+func substituteExprTemplate(template string, values []*milvuspb.TemplateValue, schema *schemapb.CollectionSchema) (string, error) {
+	params := make(map[string]*milvuspb.TemplateValue, len(values))
+	for _, value := range values {
+		if value.GetName() == "" {
+			return "", fmt.Errorf("expression template parameter without name")
+		}
+		if _, ok := params[value.GetName()]; ok {
+			return "", fmt.Errorf("duplicate expression template parameter {%s}", value.GetName())
+		}
+		if value.GetValue() == nil {
+			return "", fmt.Errorf("expression template parameter {%s} has no value", value.GetName())
+		}
+		params[value.GetName()] = value
+	}
+
+	tokens, err := tokenizeExprTemplate(template)
+	if err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		for _, token := range tokens {
+			if token.kind == exprTemplatePlaceholder {
+				return "", fmt.Errorf("missing value of expression template parameter {%s}", token.text)
+			}
+		}
+		return template, nil
+	}
+
+	schemaHelper, err := typeutil.CreateSchemaHelper(schema)
+	if err != nil {
+		return "", err
+	}
+	referenced := make(map[string]bool, len(params))
+	var sb strings.Builder
+	last := 0
+	for i, token := range tokens {
+		if token.kind != exprTemplatePlaceholder {
+			continue
+		}
+		param, ok := params[token.text]
+		if !ok {
+			return "", fmt.Errorf("missing value of expression template parameter {%s}", token.text)
+		}
+		referenced[token.text] = true
+
+		fieldName, inList := exprTemplateOperand(tokens, i)
+		if param.GetIsList() && !inList {
+			return "", fmt.Errorf("expression template parameter {%s} is a list, which could only be the right operand of in", token.text)
+		}
+		if !param.GetIsList() && inList {
+			return "", fmt.Errorf("expression template parameter {%s} is the right operand of in, which should be a list", token.text)
+		}
+		var field *schemapb.FieldSchema
+		if fieldName != "" {
+			field, err = schemaHelper.GetFieldFromName(fieldName)
+			if err != nil {
+				return "", fmt.Errorf("invalid expression template parameter {%s}: %w", token.text, err)
+			}
+		}
+		elems, err := renderExprTemplateValues(param.GetValue(), field)
+		if err != nil {
+			return "", fmt.Errorf("invalid expression template parameter {%s}: %w", token.text, err)
+		}
+		sb.WriteString(template[last:token.pos])
+		if param.GetIsList() {
+			sb.WriteString("[" + strings.Join(elems, ", ") + "]")
+		} else {
+			if len(elems) != 1 {
+				return "", fmt.Errorf("expression template parameter {%s} should have exactly one value, got %d", token.text, len(elems))
+			}
+			sb.WriteString(elems[0])
+		}
+		last = token.end
+	}
+	sb.WriteString(template[last:])
+
+	for _, value := range values {
+		if !referenced[value.GetName()] {
+			return "", fmt.Errorf("expression template parameter {%s} is not referenced by expression `%s`", value.GetName(), template)
+		}
+	}
+	return sb.String(), nil
+}
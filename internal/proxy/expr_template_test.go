@@ -0,0 +1,206 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/parser/planparser"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+func newExprTemplateTestSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Name: "test",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "id", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "name", DataType: schemapb.DataType_VarChar},
+			{FieldID: 102, Name: "age", DataType: schemapb.DataType_Int8},
+			{FieldID: 103, Name: "score", DataType: schemapb.DataType_Double},
+			{FieldID: 104, Name: "flag", DataType: schemapb.DataType_Bool},
+		},
+	}
+}
+
+func newStringTemplateValue(name string, isList bool, data ...string) *milvuspb.TemplateValue {
+	return &milvuspb.TemplateValue{
+		Name:   name,
+		IsList: isList,
+		Value: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: data}},
+		},
+	}
+}
+
+func newLongTemplateValue(name string, isList bool, data ...int64) *milvuspb.TemplateValue {
+	return &milvuspb.TemplateValue{
+		Name:   name,
+		IsList: isList,
+		Value: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: data}},
+		},
+	}
+}
+
+func newDoubleTemplateValue(name string, isList bool, data ...float64) *milvuspb.TemplateValue {
+	return &milvuspb.TemplateValue{
+		Name:   name,
+		IsList: isList,
+		Value: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: data}},
+		},
+	}
+}
+
+func TestSubstituteExprTemplate(t *testing.T) {
+	schema := newExprTemplateTestSchema()
+
+	t.Run("no template", func(t *testing.T) {
+		expr, err := substituteExprTemplate(`name == "{x}" && age > 1`, nil, schema)
+		assert.NoError(t, err)
+		assert.Equal(t, `name == "{x}" && age > 1`, expr)
+	})
+
+	t.Run("typed values", func(t *testing.T) {
+		expr, err := substituteExprTemplate(`name == {name} && age > {age} && score <= {score} && flag == {flag}`,
+			[]*milvuspb.TemplateValue{
+				newStringTemplateValue("name", false, "alice"),
+				newLongTemplateValue("age", false, -3),
+				newDoubleTemplateValue("score", false, 2),
+				{
+					Name:  "flag",
+					Value: &schemapb.ScalarField{Data: &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: []bool{true}}}},
+				},
+			}, schema)
+		assert.NoError(t, err)
+		assert.Equal(t, `name == "alice" && age > -3 && score <= 2.0 && flag == true`, expr)
+	})
+
+	t.Run("strings with quotes and backslashes", func(t *testing.T) {
+		raw := `it's "quoted" \ and \" escaped` + "\n"
+		expr, err := substituteExprTemplate(`name == {name}`, []*milvuspb.TemplateValue{newStringTemplateValue("name", false, raw)}, schema)
+		require.NoError(t, err)
+		assert.Equal(t, `name == "it's \"quoted\" \\ and \\\" escaped\n"`, expr)
+
+		schemaHelper, err := typeutil.CreateSchemaHelper(schema)
+		require.NoError(t, err)
+		plan, err := planparser.ParseExpr(schemaHelper, expr)
+		require.NoError(t, err)
+		assert.Equal(t, raw, plan.GetUnaryRangeExpr().GetValue().GetStringVal())
+	})
+
+	t.Run("injection is escaped", func(t *testing.T) {
+		expr, err := substituteExprTemplate(`name == {name}`,
+			[]*milvuspb.TemplateValue{newStringTemplateValue("name", false, `" || id > 0 || name == "`)}, schema)
+		assert.NoError(t, err)
+		assert.Equal(t, `name == "\" || id > 0 || name == \""`, expr)
+	})
+
+	t.Run("nested templates", func(t *testing.T) {
+		expr, err := substituteExprTemplate(`(id in {ids} || (name not in [{a}, "{b}", {b}])) && {lo} < age < {hi}`,
+			[]*milvuspb.TemplateValue{
+				newLongTemplateValue("ids", true, 1, 2, 3),
+				newStringTemplateValue("a", false, "{b}"),
+				newStringTemplateValue("b", false, "}{"),
+				newLongTemplateValue("lo", false, 1),
+				newLongTemplateValue("hi", false, 10),
+			}, schema)
+		assert.NoError(t, err)
+		assert.Equal(t, `(id in [1, 2, 3] || (name not in ["{b}", "{b}", "}{"])) && 1 < age < 10`, expr)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		_, err := substituteExprTemplate(`age > {age}`, []*milvuspb.TemplateValue{newStringTemplateValue("age", false, "1")}, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "{age}")
+
+		_, err = substituteExprTemplate(`{n} == name`, []*milvuspb.TemplateValue{newLongTemplateValue("n", false, 1)}, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "{n}")
+
+		_, err = substituteExprTemplate(`age > {age}`, []*milvuspb.TemplateValue{newDoubleTemplateValue("age", false, 1.5)}, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "{age}")
+
+		_, err = substituteExprTemplate(`age in [1, {age}]`, []*milvuspb.TemplateValue{newLongTemplateValue("age", false, 1000)}, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "{age}")
+
+		_, err = substituteExprTemplate(`score > {score}`, []*milvuspb.TemplateValue{newDoubleTemplateValue("score", false, math.NaN())}, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "{score}")
+	})
+
+	t.Run("missing and extra parameters", func(t *testing.T) {
+		_, err := substituteExprTemplate(`age > {age} && name == {name}`, []*milvuspb.TemplateValue{newLongTemplateValue("age", false, 1)}, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "{name}")
+
+		_, err = substituteExprTemplate(`age > {age}`, nil, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "{age}")
+
+		_, err = substituteExprTemplate(`age > {age}`, []*milvuspb.TemplateValue{
+			newLongTemplateValue("age", false, 1),
+			newLongTemplateValue("id", false, 1),
+		}, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "{id}")
+
+		_, err = substituteExprTemplate(`age > {age}`, []*milvuspb.TemplateValue{
+			newLongTemplateValue("age", false, 1),
+			newLongTemplateValue("age", false, 2),
+		}, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "{age}")
+	})
+
+	t.Run("list parameters", func(t *testing.T) {
+		_, err := substituteExprTemplate(`id == {ids}`, []*milvuspb.TemplateValue{newLongTemplateValue("ids", true, 1, 2)}, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "{ids}")
+
+		_, err = substituteExprTemplate(`id in {ids}`, []*milvuspb.TemplateValue{newLongTemplateValue("ids", false, 1)}, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "{ids}")
+
+		_, err = substituteExprTemplate(`id == {id}`, []*milvuspb.TemplateValue{newLongTemplateValue("id", false, 1, 2)}, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "{id}")
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := substituteExprTemplate(`name == "{name}`, nil, schema)
+		assert.Error(t, err)
+
+		_, err = substituteExprTemplate(`age > {a-b}`, nil, schema)
+		assert.Error(t, err)
+
+		_, err = substituteExprTemplate(`age > {}`, nil, schema)
+		assert.Error(t, err)
+
+		_, err = substituteExprTemplate(`unknown > {x}`, []*milvuspb.TemplateValue{newLongTemplateValue("x", false, 1)}, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "{x}")
+	})
+}
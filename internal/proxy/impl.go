@@ -819,6 +819,7 @@ func (node *Proxy) ShowCollections(ctx context.Context, request *milvuspb.ShowCo
 		ShowCollectionsRequest: request,
 		queryCoord:             node.queryCoord,
 		rootCoord:              node.rootCoord,
+		collectionStats:        node.collectionStatsCache,
 	}
 
 	log.Debug("ShowCollections received",
@@ -2284,6 +2285,7 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 }
 
 // Delete delete records from collection, then these records cannot be searched.
+// With the sync flag, it returns after the shard leaders of the collection apply the delete, or the sync timeout expires.
 func (node *Proxy) Delete(ctx context.Context, request *milvuspb.DeleteRequest) (*milvuspb.MutationResult, error) {
 	sp, ctx := trace.StartSpanFromContextWithOperationName(ctx, "Proxy-Delete")
 	defer sp.Finish()
@@ -2368,6 +2370,22 @@ func (node *Proxy) Delete(ctx context.Context, request *milvuspb.DeleteRequest)
 		}, nil
 	}
 
+	// wait out of the task scheduler, the time ticks of the channels don't pass the delete until its task is done
+	if request.GetSync() {
+		syncer := newDeleteSyncer(node.queryCoord, node.replicaLoadBalancer, Params.ProxyCfg.DeleteSyncTimeout, Params.ProxyCfg.DeleteSyncPollInterval)
+		unsynced, err := syncer.wait(ctx, request.CollectionName, dt.BeginTs())
+		// the delete is applied eventually, the caller retries its reads if it's not confirmed
+		dt.result.Partial = err != nil || len(unsynced) > 0
+		if err != nil {
+			log.Warn("failed to wait for the delete to be applied by shard leaders", zap.String("collection", request.CollectionName),
+				zap.Uint64("timestamp", dt.BeginTs()), zap.Error(err), zap.String("traceID", traceID))
+		} else if len(unsynced) > 0 {
+			log.Warn("delete is partially synced, shard leaders didn't apply it before timeout", zap.String("collection", request.CollectionName),
+				zap.Uint64("timestamp", dt.BeginTs()), zap.Strings("channels", unsynced), zap.Duration("timeout", Params.ProxyCfg.DeleteSyncTimeout),
+				zap.String("traceID", traceID))
+		}
+	}
+
 	metrics.ProxyDMLFunctionCall.WithLabelValues(strconv.FormatInt(Params.ProxyCfg.ProxyID, 10), method,
 		metrics.TotalLabel).Inc()
 	metrics.ProxyDMLFunctionCall.WithLabelValues(strconv.FormatInt(Params.ProxyCfg.ProxyID, 10), method,
@@ -2389,6 +2407,9 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 	sp, ctx := trace.StartSpanFromContextWithOperationName(ctx, "Proxy-Search")
 	defer sp.Finish()
 	traceID, _, _ := trace.InfoFromSpan(sp)
+	requestID := requestIDFromContext(ctx)
+	sp.SetTag("request_id", requestID)
+	setRequestIDHeader(ctx, requestID)
 
 	qt := &searchTask{
 		ctx:       ctx,
@@ -2399,11 +2420,14 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 				SourceID: Params.ProxyCfg.ProxyID,
 			},
 			ResultChannelID: strconv.FormatInt(Params.ProxyCfg.ProxyID, 10),
+			RequestId:       requestID,
 		},
-		request:            request,
-		qc:                 node.queryCoord,
-		tr:                 timerecord.NewTimeRecorder("search"),
-		getQueryNodePolicy: defaultGetQueryNodePolicy,
+		request:             request,
+		qc:                  node.queryCoord,
+		tr:                  timerecord.NewTimeRecorder("search"),
+		getQueryNodePolicy:  defaultGetQueryNodePolicy,
+		searchShardPolicy:   node.replicaLoadBalancer.pickShard,
+		mandatoryFilterHook: node.mandatoryFilterHook,
 	}
 
 	travelTs := request.TravelTimestamp
@@ -2412,6 +2436,7 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 	log.Debug(
 		rpcReceived(method),
 		zap.String("traceID", traceID),
+		zap.String("requestID", requestID),
 		zap.String("role", typeutil.ProxyRole),
 		zap.String("db", request.DbName),
 		zap.String("collection", request.CollectionName),
@@ -2629,6 +2654,9 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 	sp, ctx := trace.StartSpanFromContextWithOperationName(ctx, "Proxy-Query")
 	defer sp.Finish()
 	traceID, _, _ := trace.InfoFromSpan(sp)
+	requestID := requestIDFromContext(ctx)
+	sp.SetTag("request_id", requestID)
+	setRequestIDHeader(ctx, requestID)
 	tr := timerecord.NewTimeRecorder("Query")
 
 	qt := &queryTask{
@@ -2640,11 +2668,13 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 				SourceID: Params.ProxyCfg.ProxyID,
 			},
 			ResultChannelID: strconv.FormatInt(Params.ProxyCfg.ProxyID, 10),
+			RequestId:       requestID,
 		},
-		request:            request,
-		qc:                 node.queryCoord,
-		getQueryNodePolicy: defaultGetQueryNodePolicy,
-		queryShardPolicy:   roundRobinPolicy,
+		request:             request,
+		qc:                  node.queryCoord,
+		getQueryNodePolicy:  defaultGetQueryNodePolicy,
+		queryShardPolicy:    node.replicaLoadBalancer.pickShard,
+		mandatoryFilterHook: node.mandatoryFilterHook,
 	}
 
 	method := "Query"
@@ -2652,6 +2682,7 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 	log.Debug(
 		rpcReceived(method),
 		zap.String("traceID", traceID),
+		zap.String("requestID", requestID),
 		zap.String("role", typeutil.ProxyRole),
 		zap.String("db", request.DbName),
 		zap.String("collection", request.CollectionName),
@@ -2706,9 +2737,13 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 		metrics.ProxySearchCount.WithLabelValues(strconv.FormatInt(Params.ProxyCfg.ProxyID, 10),
 			metrics.QueryLabel, metrics.FailLabel).Inc()
 
+		errorCode := commonpb.ErrorCode_UnexpectedError
+		if isCursorExpired(err) {
+			errorCode = commonpb.ErrorCode_CursorExpired
+		}
 		return &milvuspb.QueryResults{
 			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				ErrorCode: errorCode,
 				Reason:    err.Error(),
 			},
 		}, nil
@@ -2734,8 +2769,13 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 	metrics.ProxySendMessageLatency.WithLabelValues(strconv.FormatInt(Params.ProxyCfg.ProxyID, 10),
 		metrics.QueryLabel).Observe(float64(tr.ElapseSpan().Milliseconds()))
 	return &milvuspb.QueryResults{
-		Status:     qt.result.Status,
-		FieldsData: qt.result.FieldsData,
+		Status:            qt.result.Status,
+		FieldsData:        qt.result.FieldsData,
+		SnapshotTimestamp: qt.result.SnapshotTimestamp,
+		Partial:           qt.result.Partial,
+		SkippedSegments:   qt.result.SkippedSegments,
+		ArrowIpc:          qt.result.ArrowIpc,
+		Cursor:            qt.result.Cursor,
 	}, nil
 }
 
@@ -3062,8 +3102,9 @@ func (node *Proxy) CalcDistance(ctx context.Context, request *milvuspb.CalcDista
 			qc:      node.queryCoord,
 			ids:     ids.IdArray,
 
-			getQueryNodePolicy: defaultGetQueryNodePolicy,
-			queryShardPolicy:   roundRobinPolicy,
+			getQueryNodePolicy:  defaultGetQueryNodePolicy,
+			queryShardPolicy:    node.replicaLoadBalancer.pickShard,
+			mandatoryFilterHook: node.mandatoryFilterHook,
 		}
 
 		err := node.sched.dqQueue.Enqueue(qt)
@@ -3956,6 +3997,15 @@ func (node *Proxy) Import(ctx context.Context, req *milvuspb.ImportRequest) (*mi
 		resp.Status.Reason = err.Error()
 		return resp, err
 	}
+	// the imported rows are not routed by partition key, see validatePartitionKey
+	if err := checkNoPartitionKey(ctx, node.rootCoord, "", req.GetCollectionName(), "importing"); err != nil {
+		log.Error("import is rejected",
+			zap.String("collection name", req.GetCollectionName()),
+			zap.Error(err))
+		resp.Status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+		resp.Status.Reason = err.Error()
+		return resp, nil
+	}
 	chNames, err := node.chMgr.getVChannels(collID)
 	if err != nil {
 		err = node.chMgr.createDMLMsgStream(collID)
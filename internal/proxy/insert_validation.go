@@ -0,0 +1,199 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// insertDataError is the error of the malformed columns of an insert request, listing all the violations
+type insertDataError struct {
+	violations []string
+}
+
+func (e *insertDataError) Error() string {
+	return fmt.Sprintf("invalid insert data: %s", strings.Join(e.violations, "; "))
+}
+
+// validateInsertFieldsData checks the columns of an insert request against the collection schema before they are
+// processed, so that malformed requests fail with the reasons instead of panicking in repacking. Every field not
+// auto id must be passed exactly once, no other field is passed, the data of every column matches the type of
+// its field and has numRows rows, where the rows of vectors are derived from the dim of the schema.
+func validateInsertFieldsData(schema *schemapb.CollectionSchema, fieldsData []*schemapb.FieldData, numRows uint64) error {
+	var violations []string
+	addViolation := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+	if numRows == 0 {
+		addViolation("num_rows should be greater than 0")
+	}
+
+	fieldsSchema := make(map[string]*schemapb.FieldSchema, len(schema.GetFields()))
+	for _, field := range schema.GetFields() {
+		fieldsSchema[field.GetName()] = field
+	}
+	passed := make(map[string]bool, len(fieldsData))
+	for i, fieldData := range fieldsData {
+		if fieldData == nil {
+			addViolation("the %dth field data is nil", i)
+			continue
+		}
+		name := fieldData.GetFieldName()
+		fieldSchema, ok := fieldsSchema[name]
+		if !ok {
+			addViolation("field %s does not exist in collection %s", name, schema.GetName())
+			continue
+		}
+		if passed[name] {
+			addViolation("field %s is passed more than once", name)
+			continue
+		}
+		passed[name] = true
+		if fieldSchema.GetAutoID() {
+			addViolation("field %s is auto id, its data should not be passed", name)
+			continue
+		}
+		if err := checkFieldDataRows(fieldSchema, fieldData, numRows); err != nil {
+			addViolation("%s", err.Error())
+		}
+	}
+	for _, field := range schema.GetFields() {
+		if !field.GetAutoID() && !passed[field.GetName()] {
+			addViolation("field %s is missing", field.GetName())
+		}
+	}
+
+	if len(violations) > 0 {
+		return &insertDataError{violations: violations}
+	}
+	return nil
+}
+
+// checkFieldDataRows checks the data of fieldData is of the type of fieldSchema and has numRows rows
+func checkFieldDataRows(fieldSchema *schemapb.FieldSchema, fieldData *schemapb.FieldData, numRows uint64) error {
+	name := fieldSchema.GetName()
+	mismatch := func(rows int) error {
+		return fmt.Errorf("the num_rows(%d) of field %s is not equal to passed NumRows(%d)", rows, name, numRows)
+	}
+	typeMismatch := func() error {
+		return fmt.Errorf("the data of field %s is not of type %s", name, fieldSchema.GetDataType().String())
+	}
+
+	if typeutil.IsVectorType(fieldSchema.GetDataType()) {
+		vectors := fieldData.GetVectors()
+		if vectors == nil {
+			return typeMismatch()
+		}
+		dim, err := getDimOfFieldSchema(fieldSchema)
+		if err != nil {
+			return err
+		}
+		if vectors.GetDim() != dim {
+			return fmt.Errorf("the dim(%d) of field %s is not equal to the dim(%d) of schema", vectors.GetDim(), name, dim)
+		}
+		switch fieldSchema.GetDataType() {
+		case schemapb.DataType_FloatVector:
+			data, ok := vectors.GetData().(*schemapb.VectorField_FloatVector)
+			if !ok {
+				return typeMismatch()
+			}
+			length := uint64(len(data.FloatVector.GetData()))
+			if length%uint64(dim) != 0 || length/uint64(dim) != numRows {
+				return fmt.Errorf("the length(%d) of field %s is not equal to NumRows(%d) * dim(%d)", length, name, numRows, dim)
+			}
+		case schemapb.DataType_BinaryVector:
+			data, ok := vectors.GetData().(*schemapb.VectorField_BinaryVector)
+			if !ok {
+				return typeMismatch()
+			}
+			bits := uint64(len(data.BinaryVector)) * 8
+			if bits%uint64(dim) != 0 || bits/uint64(dim) != numRows {
+				return fmt.Errorf("the bytes(%d) of field %s is not equal to NumRows(%d) * dim(%d) / 8", len(data.BinaryVector), name, numRows, dim)
+			}
+		}
+		return nil
+	}
+
+	scalars := fieldData.GetScalars()
+	if scalars == nil {
+		return typeMismatch()
+	}
+	var rows int
+	switch fieldSchema.GetDataType() {
+	case schemapb.DataType_Bool:
+		data, ok := scalars.GetData().(*schemapb.ScalarField_BoolData)
+		if !ok {
+			return typeMismatch()
+		}
+		rows = len(data.BoolData.GetData())
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		data, ok := scalars.GetData().(*schemapb.ScalarField_IntData)
+		if !ok {
+			return typeMismatch()
+		}
+		rows = len(data.IntData.GetData())
+	case schemapb.DataType_Int64:
+		data, ok := scalars.GetData().(*schemapb.ScalarField_LongData)
+		if !ok {
+			return typeMismatch()
+		}
+		rows = len(data.LongData.GetData())
+	case schemapb.DataType_Float:
+		data, ok := scalars.GetData().(*schemapb.ScalarField_FloatData)
+		if !ok {
+			return typeMismatch()
+		}
+		rows = len(data.FloatData.GetData())
+	case schemapb.DataType_Double:
+		data, ok := scalars.GetData().(*schemapb.ScalarField_DoubleData)
+		if !ok {
+			return typeMismatch()
+		}
+		rows = len(data.DoubleData.GetData())
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		data, ok := scalars.GetData().(*schemapb.ScalarField_StringData)
+		if !ok {
+			return typeMismatch()
+		}
+		rows = len(data.StringData.GetData())
+	default:
+		return errUnsupportedDataType(fieldSchema.GetDataType())
+	}
+	if uint64(rows) != numRows {
+		return mismatch(rows)
+	}
+	return nil
+}
+
+// getDimOfFieldSchema returns the positive dim in the type params of the vector field
+func getDimOfFieldSchema(fieldSchema *schemapb.FieldSchema) (int64, error) {
+	for _, kv := range fieldSchema.GetTypeParams() {
+		if kv.GetKey() == "dim" {
+			dim, err := strconv.ParseInt(kv.GetValue(), 10, 64)
+			if err != nil || dim <= 0 {
+				return 0, fmt.Errorf("invalid dim %s of field %s", kv.GetValue(), fieldSchema.GetName())
+			}
+			return dim, nil
+		}
+	}
+	return 0, fmt.Errorf("dim of field %s is not specified", fieldSchema.GetName())
+}
@@ -0,0 +1,288 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+)
+
+const (
+	validationDim    = 8
+	validationBinDim = 16
+)
+
+func genValidationSchema(autoID bool) *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Name: "validation",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: testInt64Field, IsPrimaryKey: true, DataType: schemapb.DataType_Int64, AutoID: autoID},
+			{FieldID: 101, Name: testInt32Field, DataType: schemapb.DataType_Int32},
+			{FieldID: 102, Name: testVarCharField, DataType: schemapb.DataType_VarChar},
+			{FieldID: 103, Name: testFloatVecField, DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: strconv.Itoa(validationDim)}}},
+			{FieldID: 104, Name: testBinaryVecField, DataType: schemapb.DataType_BinaryVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: strconv.Itoa(validationBinDim)}}},
+		},
+	}
+}
+
+// genValidationFieldsData generates the valid columns of numRows rows of the schema
+func genValidationFieldsData(schema *schemapb.CollectionSchema, numRows int) []*schemapb.FieldData {
+	var fieldsData []*schemapb.FieldData
+	for _, field := range schema.GetFields() {
+		if field.GetAutoID() {
+			continue
+		}
+		switch field.GetDataType() {
+		case schemapb.DataType_FloatVector:
+			fieldsData = append(fieldsData, newFloatVectorFieldData(field.GetName(), numRows, validationDim))
+		case schemapb.DataType_BinaryVector:
+			fieldsData = append(fieldsData, newBinaryVectorFieldData(field.GetName(), numRows, validationBinDim))
+		default:
+			fieldsData = append(fieldsData, newScalarFieldData(field, field.GetName(), numRows))
+		}
+	}
+	return fieldsData
+}
+
+func TestValidateInsertFieldsData(t *testing.T) {
+	numRows := 10
+	schema := genValidationSchema(false)
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, validateInsertFieldsData(schema, genValidationFieldsData(schema, numRows), uint64(numRows)))
+
+		autoIDSchema := genValidationSchema(true)
+		assert.NoError(t, validateInsertFieldsData(autoIDSchema, genValidationFieldsData(autoIDSchema, numRows), uint64(numRows)))
+	})
+
+	cases := []struct {
+		name       string
+		mutate     func([]*schemapb.FieldData) []*schemapb.FieldData
+		violations []string
+	}{
+		{
+			name: "missing field",
+			mutate: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				return fieldsData[1:]
+			},
+			violations: []string{"field int64 is missing"},
+		},
+		{
+			name: "extra field",
+			mutate: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				return append(fieldsData, &schemapb.FieldData{FieldName: "unknown", Field: fieldsData[1].Field})
+			},
+			violations: []string{"field unknown does not exist in collection validation"},
+		},
+		{
+			name: "duplicate field",
+			mutate: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				return append(fieldsData, fieldsData[1])
+			},
+			violations: []string{"field int32 is passed more than once"},
+		},
+		{
+			name: "scalar rows",
+			mutate: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				data := fieldsData[1].GetScalars().GetIntData()
+				data.Data = data.Data[1:]
+				return fieldsData
+			},
+			violations: []string{"the num_rows(9) of field int32 is not equal to passed NumRows(10)"},
+		},
+		{
+			name: "scalar type",
+			mutate: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				fieldsData[2].Field = fieldsData[1].Field
+				return fieldsData
+			},
+			violations: []string{"the data of field varChar is not of type VarChar"},
+		},
+		{
+			name: "nil scalars",
+			mutate: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				fieldsData[0].Field = nil
+				return fieldsData
+			},
+			violations: []string{"the data of field int64 is not of type Int64"},
+		},
+		{
+			name: "float vector length",
+			mutate: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				data := fieldsData[3].GetVectors().GetFloatVector()
+				data.Data = data.Data[:len(data.Data)-validationDim/2]
+				return fieldsData
+			},
+			violations: []string{"the length(76) of field fvec is not equal to NumRows(10) * dim(8)"},
+		},
+		{
+			name: "float vector dim",
+			mutate: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				fieldsData[3].GetVectors().Dim = validationDim * 2
+				return fieldsData
+			},
+			violations: []string{"the dim(16) of field fvec is not equal to the dim(8) of schema"},
+		},
+		{
+			name: "binary vector bytes",
+			mutate: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				vectors := fieldsData[4].GetVectors()
+				vectors.Data = &schemapb.VectorField_BinaryVector{BinaryVector: vectors.GetBinaryVector()[1:]}
+				return fieldsData
+			},
+			violations: []string{"the bytes(19) of field bvec is not equal to NumRows(10) * dim(16) / 8"},
+		},
+		{
+			name: "all violations",
+			mutate: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				data := fieldsData[1].GetScalars().GetIntData()
+				data.Data = data.Data[1:]
+				fieldsData[4].Field = nil
+				return append(fieldsData[1:], nil)
+			},
+			violations: []string{
+				"the num_rows(9) of field int32 is not equal to passed NumRows(10)",
+				"the data of field bvec is not of type BinaryVector",
+				"the 4th field data is nil",
+				"field int64 is missing",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fieldsData := c.mutate(genValidationFieldsData(schema, numRows))
+			err := validateInsertFieldsData(schema, fieldsData, uint64(numRows))
+			assert.Error(t, err)
+			assert.Equal(t, c.violations, err.(*insertDataError).violations)
+		})
+	}
+
+	t.Run("auto id passed", func(t *testing.T) {
+		autoIDSchema := genValidationSchema(true)
+		err := validateInsertFieldsData(autoIDSchema, genValidationFieldsData(schema, numRows), uint64(numRows))
+		assert.Error(t, err)
+		assert.Equal(t, []string{"field int64 is auto id, its data should not be passed"}, err.(*insertDataError).violations)
+	})
+
+	t.Run("zero rows", func(t *testing.T) {
+		err := validateInsertFieldsData(schema, genValidationFieldsData(schema, 0), 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "num_rows should be greater than 0")
+	})
+
+	t.Run("invalid dim in schema", func(t *testing.T) {
+		invalidSchema := genValidationSchema(false)
+		invalidSchema.Fields[3].TypeParams[0].Value = "0"
+		err := validateInsertFieldsData(invalidSchema, genValidationFieldsData(schema, numRows), uint64(numRows))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid dim 0 of field fvec")
+	})
+}
+
+// TestValidateInsertFieldsData_Random validates randomly malformed columns, which must be rejected without panic
+func TestValidateInsertFieldsData_Random(t *testing.T) {
+	schema := genValidationSchema(false)
+	mutations := []func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData{
+		// drop a column
+		func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+			i := rand.Intn(len(fieldsData))
+			return append(fieldsData[:i], fieldsData[i+1:]...)
+		},
+		// duplicate a column
+		func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+			return append(fieldsData, fieldsData[rand.Intn(len(fieldsData))])
+		},
+		// nil column
+		func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+			fieldsData[rand.Intn(len(fieldsData))] = nil
+			return fieldsData
+		},
+		// drop the data of a column
+		func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+			if fieldData := fieldsData[rand.Intn(len(fieldsData))]; fieldData != nil {
+				fieldData.Field = nil
+			}
+			return fieldsData
+		},
+		// swap the data of two columns
+		func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+			i, j := rand.Intn(len(fieldsData)), rand.Intn(len(fieldsData))
+			if fieldsData[i] != nil && fieldsData[j] != nil && i != j {
+				fieldsData[i].Field, fieldsData[j].Field = fieldsData[j].Field, fieldsData[i].Field
+			}
+			return fieldsData
+		},
+		// rename a column
+		func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+			if fieldData := fieldsData[rand.Intn(len(fieldsData))]; fieldData != nil {
+				fieldData.FieldName = strconv.Itoa(rand.Int())
+			}
+			return fieldsData
+		},
+		// nil vectors or scalars
+		func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+			if fieldData := fieldsData[rand.Intn(len(fieldsData))]; fieldData != nil {
+				switch fieldData.Field.(type) {
+				case *schemapb.FieldData_Vectors:
+					fieldData.Field = &schemapb.FieldData_Vectors{}
+				default:
+					fieldData.Field = &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{}}
+				}
+			}
+			return fieldsData
+		},
+		// random dim
+		func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+			if vectors := fieldsData[rand.Intn(len(fieldsData))].GetVectors(); vectors != nil {
+				vectors.Dim = rand.Int63n(64) - 32
+			}
+			return fieldsData
+		},
+	}
+
+	for i := 0; i < 1000; i++ {
+		numRows := rand.Intn(8) + 1
+		fieldsData := genValidationFieldsData(schema, numRows)
+		for n := rand.Intn(3) + 1; n > 0 && len(fieldsData) > 0; n-- {
+			fieldsData = mutations[rand.Intn(len(mutations))](fieldsData)
+		}
+		passedRows := uint64(numRows)
+		if rand.Intn(4) == 0 {
+			passedRows = uint64(rand.Intn(10))
+		}
+		assert.NotPanics(t, func() {
+			err := validateInsertFieldsData(schema, fieldsData, passedRows)
+			if err == nil {
+				// the accepted columns must be aligned
+				for _, fieldData := range fieldsData {
+					rows, err := funcutil.GetNumRowOfFieldData(fieldData)
+					assert.NoError(t, err)
+					assert.Equal(t, passedRows, rows)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+// getExprFieldIDs returns the ids of the fields referenced by expr
+func getExprFieldIDs(expr *planpb.Expr) []int64 {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_TermExpr:
+		return []int64{e.TermExpr.GetColumnInfo().GetFieldId()}
+	case *planpb.Expr_UnaryRangeExpr:
+		return []int64{e.UnaryRangeExpr.GetColumnInfo().GetFieldId()}
+	case *planpb.Expr_BinaryRangeExpr:
+		return []int64{e.BinaryRangeExpr.GetColumnInfo().GetFieldId()}
+	case *planpb.Expr_CompareExpr:
+		return []int64{e.CompareExpr.GetLeftColumnInfo().GetFieldId(), e.CompareExpr.GetRightColumnInfo().GetFieldId()}
+	case *planpb.Expr_UnaryExpr:
+		return getExprFieldIDs(e.UnaryExpr.GetChild())
+	case *planpb.Expr_BinaryExpr:
+		return append(getExprFieldIDs(e.BinaryExpr.GetLeft()), getExprFieldIDs(e.BinaryExpr.GetRight())...)
+	}
+	return nil
+}
+
+// getPlanFieldIDs returns the ids of the fields referenced by plan, including the vector field, the filter and output fields
+func getPlanFieldIDs(plan *planpb.PlanNode) []int64 {
+	var fieldIDs []int64
+	switch node := plan.GetNode().(type) {
+	case *planpb.PlanNode_VectorAnns:
+		fieldIDs = append(fieldIDs, node.VectorAnns.GetFieldId())
+		fieldIDs = append(fieldIDs, getExprFieldIDs(node.VectorAnns.GetPredicates())...)
+	case *planpb.PlanNode_Predicates:
+		fieldIDs = append(fieldIDs, getExprFieldIDs(node.Predicates)...)
+	}
+	return append(fieldIDs, plan.GetOutputFieldIds()...)
+}
+
+// getUnloadedFieldIDs returns the ids in fieldIDs not in loadedFields, without duplicates
+func getUnloadedFieldIDs(loadedFields map[int64]struct{}, fieldIDs []int64) []int64 {
+	var unloaded []int64
+	seen := make(map[int64]struct{})
+	for _, fieldID := range fieldIDs {
+		if _, ok := loadedFields[fieldID]; ok {
+			continue
+		}
+		if _, ok := seen[fieldID]; !ok {
+			seen[fieldID] = struct{}{}
+			unloaded = append(unloaded, fieldID)
+		}
+	}
+	return unloaded
+}
+
+// checkFieldsLoaded returns an error listing the fields of fieldIDs not loaded into memory,
+// the cached loaded fields are refreshed before rejecting, since the collection may be reloaded by other proxies
+func checkFieldsLoaded(ctx context.Context, qc types.QueryCoord, collectionName string, schema *schemapb.CollectionSchema, fieldIDs []int64) error {
+	loadedFields, err := globalMetaCache.GetLoadedFields(ctx, WithCache, collectionName, qc)
+	if err != nil {
+		return err
+	}
+	unloaded := getUnloadedFieldIDs(loadedFields, fieldIDs)
+	if len(unloaded) == 0 {
+		return nil
+	}
+
+	loadedFields, err = globalMetaCache.GetLoadedFields(ctx, WithoutCache, collectionName, qc)
+	if err != nil {
+		return err
+	}
+	unloaded = getUnloadedFieldIDs(loadedFields, fieldIDs)
+	if len(unloaded) == 0 {
+		return nil
+	}
+
+	fieldNames := make(map[int64]string)
+	for _, field := range schema.GetFields() {
+		fieldNames[field.GetFieldID()] = field.GetName()
+	}
+	names := make([]string, 0, len(unloaded))
+	for _, fieldID := range unloaded {
+		name, ok := fieldNames[fieldID]
+		if !ok {
+			name = fmt.Sprint(fieldID)
+		}
+		names = append(names, name)
+	}
+	return fmt.Errorf("fields [%s] of collection %s are not loaded, please release and load the collection again to access them",
+		strings.Join(names, ", "), collectionName)
+}
@@ -0,0 +1,120 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/parser/planparser"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+func TestGetPlanFieldIDs(t *testing.T) {
+	schema := newTestSchema()
+
+	plan, err := planparser.CreateQueryPlan(schema, "Int64Field > 0 && Int32Field < 5", "FloatVectorField", &planpb.QueryInfo{
+		Topk:         10,
+		MetricType:   "L2",
+		SearchParams: `{"nprobe": 10}`,
+	})
+	require.NoError(t, err)
+	plan.OutputFieldIds = []int64{111}
+	assert.ElementsMatch(t, []int64{201, 105, 104, 111}, getPlanFieldIDs(plan))
+
+	plan, err = planparser.CreateExprPlan(schema, "Int8Field == 1 || not (FloatField < 1.0)")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{102, 110}, getPlanFieldIDs(plan))
+}
+
+func TestCheckFieldsLoaded(t *testing.T) {
+	err := InitMetaCache(&MockRootCoordClientInterface{})
+	require.NoError(t, err)
+
+	var (
+		ctx            = context.TODO()
+		collectionName = "collection1"
+		collectionID   = int64(1)
+		schema         = newTestSchema()
+		qc             = NewQueryCoordMock()
+
+		loadedFields []int64
+		showCalls    int
+		showStatus   = commonpb.ErrorCode_Success
+	)
+	qc.Init()
+	qc.Start()
+	defer qc.Stop()
+	qc.SetShowCollectionsFunc(func(ctx context.Context, req *querypb.ShowCollectionsRequest) (*querypb.ShowCollectionsResponse, error) {
+		showCalls++
+		return &querypb.ShowCollectionsResponse{
+			Status:              &commonpb.Status{ErrorCode: showStatus},
+			CollectionIDs:       []int64{collectionID},
+			InMemoryPercentages: []int64{100},
+			LoadedFields:        []*schemapb.LongArray{{Data: loadedFields}},
+		}, nil
+	})
+
+	t.Run("loaded fields", func(t *testing.T) {
+		loadedFields = []int64{105, 201}
+		assert.NoError(t, checkFieldsLoaded(ctx, qc, collectionName, schema, []int64{105, 201}))
+		assert.NoError(t, checkFieldsLoaded(ctx, qc, collectionName, schema, []int64{105}))
+		assert.Equal(t, 1, showCalls)
+	})
+
+	t.Run("unloaded fields", func(t *testing.T) {
+		showCalls = 0
+		err := checkFieldsLoaded(ctx, qc, collectionName, schema, []int64{105, 110, 110, 111})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "[FloatField, DoubleField]")
+		assert.Contains(t, err.Error(), "load the collection again")
+		// refreshed before rejecting
+		assert.Equal(t, 1, showCalls)
+	})
+
+	t.Run("refresh after reload", func(t *testing.T) {
+		showCalls = 0
+		// reloaded by another proxy
+		loadedFields = []int64{105, 110, 201}
+		assert.NoError(t, checkFieldsLoaded(ctx, qc, collectionName, schema, []int64{110}))
+		assert.Equal(t, 1, showCalls)
+		assert.NoError(t, checkFieldsLoaded(ctx, qc, collectionName, schema, []int64{110}))
+		assert.Equal(t, 1, showCalls)
+	})
+
+	t.Run("invalidated on load and release", func(t *testing.T) {
+		showCalls = 0
+		loadedFields = []int64{105}
+		globalMetaCache.RemoveLoadedFields(ctx, collectionName)
+		assert.NoError(t, checkFieldsLoaded(ctx, qc, collectionName, schema, []int64{105}))
+		assert.Equal(t, 1, showCalls)
+		assert.Error(t, checkFieldsLoaded(ctx, qc, collectionName, schema, []int64{201}))
+	})
+
+	t.Run("show collections failed", func(t *testing.T) {
+		showStatus = commonpb.ErrorCode_UnexpectedError
+		defer func() { showStatus = commonpb.ErrorCode_Success }()
+		globalMetaCache.RemoveLoadedFields(ctx, collectionName)
+		assert.Error(t, checkFieldsLoaded(ctx, qc, collectionName, schema, []int64{105}))
+	})
+}
@@ -0,0 +1,61 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/parser/planparser"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// MandatoryFilterHook returns the filter expression enforced on the search and query requests of a collection,
+// e.g. `tenant_id == 1` derived from the identity of caller in ctx by an auth or policy plugin,
+// an empty expression means no filter. Requests are rejected if the hook fails
+type MandatoryFilterHook func(ctx context.Context, collectionName string) (string, error)
+
+// compileMandatoryFilter asks hook for the mandatory filter of collection and compiles it against schema,
+// returns the filter expression and the serialized planpb.Expr, both empty if there is no filter
+func compileMandatoryFilter(ctx context.Context, hook MandatoryFilterHook, collectionName string, schema *schemapb.CollectionSchema) (string, []byte, error) {
+	if hook == nil {
+		return "", nil, nil
+	}
+	filter, err := hook(ctx, collectionName)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get mandatory filter of collection %s: %w", collectionName, err)
+	}
+	if filter == "" {
+		return "", nil, nil
+	}
+	schemaHelper, err := typeutil.CreateSchemaHelper(schema)
+	if err != nil {
+		return "", nil, err
+	}
+	expr, err := planparser.ParseExpr(schemaHelper, filter)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid mandatory filter %s of collection %s: %w", filter, collectionName, err)
+	}
+	filterPlan, err := proto.Marshal(expr)
+	if err != nil {
+		return "", nil, err
+	}
+	return filter, filterPlan, nil
+}
@@ -0,0 +1,118 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/parser/planparser"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+func newTestSchema() *schemapb.CollectionSchema {
+	fields := []*schemapb.FieldSchema{
+		{FieldID: 0, Name: "FieldID", IsPrimaryKey: false, Description: "field no.1", DataType: schemapb.DataType_Int64},
+	}
+
+	for name, value := range schemapb.DataType_value {
+		dataType := schemapb.DataType(value)
+		if !typeutil.IsIntegerType(dataType) && !typeutil.IsFloatingType(dataType) && !typeutil.IsVectorType(dataType) {
+			continue
+		}
+		newField := &schemapb.FieldSchema{
+			FieldID: int64(100 + value), Name: name + "Field", IsPrimaryKey: false, Description: "", DataType: dataType,
+		}
+		fields = append(fields, newField)
+	}
+
+	return &schemapb.CollectionSchema{
+		Name:        "test",
+		Description: "schema for test used",
+		AutoID:      true,
+		Fields:      fields,
+	}
+}
+
+func TestCompileMandatoryFilter(t *testing.T) {
+	ctx := context.Background()
+	schema := newTestSchema()
+	const tenantFilter = "Int64Field == 1"
+
+	t.Run("no hook", func(t *testing.T) {
+		filter, filterPlan, err := compileMandatoryFilter(ctx, nil, "test", schema)
+		assert.NoError(t, err)
+		assert.Empty(t, filter)
+		assert.Nil(t, filterPlan)
+	})
+
+	t.Run("no filter", func(t *testing.T) {
+		hook := func(ctx context.Context, collectionName string) (string, error) { return "", nil }
+		filter, filterPlan, err := compileMandatoryFilter(ctx, hook, "test", schema)
+		assert.NoError(t, err)
+		assert.Empty(t, filter)
+		assert.Nil(t, filterPlan)
+	})
+
+	t.Run("tenant filter", func(t *testing.T) {
+		var requested string
+		hook := func(ctx context.Context, collectionName string) (string, error) {
+			requested = collectionName
+			return tenantFilter, nil
+		}
+		filter, filterPlan, err := compileMandatoryFilter(ctx, hook, "test", schema)
+		require.NoError(t, err)
+		assert.Equal(t, "test", requested)
+		assert.Equal(t, tenantFilter, filter)
+
+		schemaHelper, err := typeutil.CreateSchemaHelper(schema)
+		require.NoError(t, err)
+		expected, err := planparser.ParseExpr(schemaHelper, tenantFilter)
+		require.NoError(t, err)
+		expr := &planpb.Expr{}
+		require.NoError(t, proto.Unmarshal(filterPlan, expr))
+		assert.True(t, proto.Equal(expected, expr))
+	})
+
+	t.Run("hook failed", func(t *testing.T) {
+		hook := func(ctx context.Context, collectionName string) (string, error) { return "", errors.New("mock error") }
+		_, _, err := compileMandatoryFilter(ctx, hook, "test", schema)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		for _, invalid := range []string{"unknown_field == 1", "Int64Field =="} {
+			hook := func(ctx context.Context, collectionName string) (string, error) { return invalid, nil }
+			_, _, err := compileMandatoryFilter(ctx, hook, "test", schema)
+			assert.Error(t, err, invalid)
+		}
+	})
+}
+
+func TestProxy_SetMandatoryFilterHook(t *testing.T) {
+	node := &Proxy{}
+	assert.Nil(t, node.mandatoryFilterHook)
+	node.SetMandatoryFilterHook(func(ctx context.Context, collectionName string) (string, error) { return "", nil })
+	assert.NotNil(t, node.mandatoryFilterHook)
+}
@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"sync"
 
+	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/common"
@@ -54,6 +55,10 @@ type Cache interface {
 	// GetCollectionSchema get collection's schema.
 	GetCollectionSchema(ctx context.Context, collectionName string) (*schemapb.CollectionSchema, error)
 	GetShards(ctx context.Context, withCache bool, collectionName string, qc types.QueryCoord) ([]*querypb.ShardLeadersList, error)
+	// GetLoadedFields get the ids of collection's fields loaded into memory, refreshed from QueryCoord if withCache == false.
+	GetLoadedFields(ctx context.Context, withCache bool, collectionName string, qc types.QueryCoord) (map[int64]struct{}, error)
+	// RemoveLoadedFields invalidates the cached loaded fields of collection, on load and release.
+	RemoveLoadedFields(ctx context.Context, collectionName string)
 	RemoveCollection(ctx context.Context, collectionName string)
 	RemovePartition(ctx context.Context, collectionName string, partitionName string)
 
@@ -70,8 +75,10 @@ type collectionInfo struct {
 	schema              *schemapb.CollectionSchema
 	partInfo            map[string]*partitionInfo
 	shardLeaders        []*querypb.ShardLeadersList
+	loadedFields        map[int64]struct{} // nil if not cached
 	createdTimestamp    uint64
 	createdUtcTimestamp uint64
+	schemaVersion       uint64 // bumped whenever the cached schema changes, keys the caches derived from the schema
 }
 
 type partitionInfo struct {
@@ -90,6 +97,7 @@ type MetaCache struct {
 	collInfo         map[string]*collectionInfo
 	credMap          map[string]*internalpb.CredentialInfo // cache for credential, lazy load
 	credUsernameList []string                              // no need initialize when NewMetaCache
+	schemaVersion    uint64                                // the latest version assigned to a cached schema, guarded by mu
 	mu               sync.RWMutex
 	credMut          sync.RWMutex
 }
@@ -172,6 +180,8 @@ func (m *MetaCache) GetCollectionInfo(ctx context.Context, collectionName string
 		createdTimestamp:    collInfo.createdTimestamp,
 		createdUtcTimestamp: collInfo.createdUtcTimestamp,
 		shardLeaders:        collInfo.shardLeaders,
+		loadedFields:        collInfo.loadedFields,
+		schemaVersion:       collInfo.schemaVersion,
 	}, nil
 }
 
@@ -207,9 +217,18 @@ func (m *MetaCache) GetCollectionSchema(ctx context.Context, collectionName stri
 }
 
 func (m *MetaCache) updateCollection(coll *milvuspb.DescribeCollectionResponse, collectionName string) {
-	_, ok := m.collInfo[collectionName]
+	info, ok := m.collInfo[collectionName]
 	if !ok {
-		m.collInfo[collectionName] = &collectionInfo{}
+		info = &collectionInfo{}
+		m.collInfo[collectionName] = info
+	}
+	if !ok || !proto.Equal(info.schema, coll.Schema) {
+		if ok {
+			// the expressions parsed against the previous schema are outdated
+			globalExprCache.removeCollection(info.collID)
+		}
+		m.schemaVersion++
+		info.schemaVersion = m.schemaVersion
 	}
 	m.collInfo[collectionName].schema = coll.Schema
 	m.collInfo[collectionName].collID = coll.CollectionID
@@ -419,6 +438,9 @@ func (m *MetaCache) updatePartitions(partitions *milvuspb.ShowPartitionsResponse
 func (m *MetaCache) RemoveCollection(ctx context.Context, collectionName string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	if info, ok := m.collInfo[collectionName]; ok {
+		globalExprCache.removeCollection(info.collID)
+	}
 	delete(m.collInfo, collectionName)
 }
 
@@ -563,3 +585,63 @@ func (m *MetaCache) GetShards(ctx context.Context, withCache bool, collectionNam
 	m.collInfo[collectionName].shardLeaders = shards
 	return shards, nil
 }
+
+// GetLoadedFields update cache if withCache == false
+func (m *MetaCache) GetLoadedFields(ctx context.Context, withCache bool, collectionName string, qc types.QueryCoord) (map[int64]struct{}, error) {
+	info, err := m.GetCollectionInfo(ctx, collectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	if withCache && info.loadedFields != nil {
+		return info.loadedFields, nil
+	}
+
+	resp, err := qc.ShowCollections(ctx, &querypb.ShowCollectionsRequest{
+		Base: &commonpb.MsgBase{
+			MsgType:  commonpb.MsgType_ShowCollections,
+			SourceID: Params.ProxyCfg.ProxyID,
+		},
+		CollectionIDs: []int64{info.collID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return nil, fmt.Errorf("fail to show collections from QueryCoord: %s", resp.GetStatus().GetReason())
+	}
+
+	var fieldIDs []int64
+	for index, collID := range resp.GetCollectionIDs() {
+		if collID == info.collID && index < len(resp.GetLoadedFields()) {
+			fieldIDs = resp.GetLoadedFields()[index].GetData()
+			break
+		}
+	}
+	loadedFields := make(map[int64]struct{})
+	if len(fieldIDs) > 0 {
+		for _, fieldID := range fieldIDs {
+			loadedFields[fieldID] = struct{}{}
+		}
+	} else {
+		// loaded fields are not reported, all the fields are loaded
+		for _, field := range info.schema.GetFields() {
+			loadedFields[field.GetFieldID()] = struct{}{}
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if collInfo, ok := m.collInfo[collectionName]; ok {
+		collInfo.loadedFields = loadedFields
+	}
+	return loadedFields, nil
+}
+
+func (m *MetaCache) RemoveLoadedFields(ctx context.Context, collectionName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if collInfo, ok := m.collInfo[collectionName]; ok {
+		collInfo.loadedFields = nil
+	}
+}
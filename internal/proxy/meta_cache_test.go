@@ -25,6 +25,7 @@ import (
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/types"
@@ -358,3 +359,93 @@ func TestMetaCache_GetShards(t *testing.T) {
 	})
 
 }
+
+func TestMetaCache_GetLoadedFields(t *testing.T) {
+	client := &MockRootCoordClientInterface{}
+	err := InitMetaCache(client)
+	require.Nil(t, err)
+
+	var (
+		ctx            = context.TODO()
+		collectionName = "collection1"
+		qc             = NewQueryCoordMock()
+		showCalls      = 0
+		loadedFields   *schemapb.LongArray
+	)
+	qc.Init()
+	qc.Start()
+	defer qc.Stop()
+	qc.SetShowCollectionsFunc(func(ctx context.Context, req *querypb.ShowCollectionsRequest) (*querypb.ShowCollectionsResponse, error) {
+		showCalls++
+		resp := &querypb.ShowCollectionsResponse{
+			Status:              &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			CollectionIDs:       []int64{2, 1},
+			InMemoryPercentages: []int64{100, 100},
+		}
+		if loadedFields != nil {
+			resp.LoadedFields = []*schemapb.LongArray{{Data: []int64{200}}, loadedFields}
+		}
+		return resp, nil
+	})
+
+	t.Run("No collection in meta cache", func(t *testing.T) {
+		fields, err := globalMetaCache.GetLoadedFields(ctx, true, "non-exists", qc)
+		assert.Error(t, err)
+		assert.Nil(t, fields)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		loadedFields = &schemapb.LongArray{Data: []int64{100, 101}}
+		fields, err := globalMetaCache.GetLoadedFields(ctx, true, collectionName, qc)
+		assert.NoError(t, err)
+		assert.Equal(t, map[int64]struct{}{100: {}, 101: {}}, fields)
+		assert.Equal(t, 1, showCalls)
+
+		loadedFields = &schemapb.LongArray{Data: []int64{100}}
+		fields, err = globalMetaCache.GetLoadedFields(ctx, true, collectionName, qc)
+		assert.NoError(t, err)
+		assert.Len(t, fields, 2)
+		assert.Equal(t, 1, showCalls)
+
+		// refresh
+		fields, err = globalMetaCache.GetLoadedFields(ctx, false, collectionName, qc)
+		assert.NoError(t, err)
+		assert.Equal(t, map[int64]struct{}{100: {}}, fields)
+		assert.Equal(t, 2, showCalls)
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		loadedFields = &schemapb.LongArray{Data: []int64{101}}
+		globalMetaCache.RemoveLoadedFields(ctx, collectionName)
+		globalMetaCache.RemoveLoadedFields(ctx, "non-exists")
+		fields, err := globalMetaCache.GetLoadedFields(ctx, true, collectionName, qc)
+		assert.NoError(t, err)
+		assert.Equal(t, map[int64]struct{}{101: {}}, fields)
+		assert.Equal(t, 3, showCalls)
+	})
+
+	t.Run("not reported", func(t *testing.T) {
+		// all the fields of schema are loaded, the schema of collection1 has no field
+		loadedFields = nil
+		fields, err := globalMetaCache.GetLoadedFields(ctx, false, collectionName, qc)
+		assert.NoError(t, err)
+		assert.NotNil(t, fields)
+		assert.Empty(t, fields)
+	})
+
+	t.Run("QueryCoord failed", func(t *testing.T) {
+		qc.SetShowCollectionsFunc(func(ctx context.Context, req *querypb.ShowCollectionsRequest) (*querypb.ShowCollectionsResponse, error) {
+			return &querypb.ShowCollectionsResponse{
+				Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "mock error"},
+			}, nil
+		})
+		_, err := globalMetaCache.GetLoadedFields(ctx, false, collectionName, qc)
+		assert.Error(t, err)
+
+		qc.SetShowCollectionsFunc(func(ctx context.Context, req *querypb.ShowCollectionsRequest) (*querypb.ShowCollectionsResponse, error) {
+			return nil, errors.New("mock error")
+		})
+		_, err = globalMetaCache.GetLoadedFields(ctx, false, collectionName, qc)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,432 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// outputExpr is an output field of query in the form of `<expr> as <alias>`, the expression is either a field,
+// which is renamed as alias, or arithmetic over the numeric fields, which is computed in float64 by proxy
+type outputExpr struct {
+	text   string
+	alias  string
+	root   *outputExprNode
+	fields []string // names of the referenced fields
+}
+
+// outputExprNode is a field, a number, or the binary operation of op over the left and right node
+type outputExprNode struct {
+	pos   int
+	op    byte
+	field string
+	value float64
+	left  *outputExprNode
+	right *outputExprNode
+}
+
+// isOutputExpr returns whether the output field is an expression instead of a field name or wildcard
+func isOutputExpr(outputField string) bool {
+	outputField = strings.TrimSpace(outputField)
+	if outputField == "*" || outputField == "%" {
+		return false
+	}
+	return strings.ContainsAny(outputField, "+-*/() \t")
+}
+
+// parseOutputExprs splits the output fields of query into the plain output fields and the output expressions
+func parseOutputExprs(outputFields []string, schema *schemapb.CollectionSchema) ([]string, []*outputExpr, error) {
+	fields := make(map[string]*schemapb.FieldSchema, len(schema.GetFields()))
+	for _, field := range schema.GetFields() {
+		fields[field.GetName()] = field
+	}
+	plainFields := make([]string, 0, len(outputFields))
+	exprs := make([]*outputExpr, 0)
+	aliases := make(map[string]bool)
+	for _, outputField := range outputFields {
+		if !isOutputExpr(outputField) {
+			plainFields = append(plainFields, outputField)
+			continue
+		}
+		expr, err := parseOutputExpr(outputField, fields)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, ok := fields[expr.alias]; ok || aliases[expr.alias] {
+			return nil, nil, fmt.Errorf("invalid output expression `%s`: duplicate output field name %s", outputField, expr.alias)
+		}
+		aliases[expr.alias] = true
+		exprs = append(exprs, expr)
+	}
+	return plainFields, exprs, nil
+}
+
+type outputExprToken struct {
+	pos  int
+	text string
+}
+
+func tokenizeOutputExpr(text string) ([]outputExprToken, error) {
+	tokens := make([]outputExprToken, 0)
+	for i := 0; i < len(text); {
+		c := rune(text[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case strings.ContainsRune("+-*/()", c):
+			tokens = append(tokens, outputExprToken{pos: i, text: text[i : i+1]})
+			i++
+		case c == '_' || c == '.' || unicode.IsLetter(c) || unicode.IsDigit(c):
+			start := i
+			for i < len(text) && (text[i] == '_' || text[i] == '.' || unicode.IsLetter(rune(text[i])) || unicode.IsDigit(rune(text[i]))) {
+				i++
+			}
+			tokens = append(tokens, outputExprToken{pos: start, text: text[start:i]})
+		default:
+			return nil, fmt.Errorf("invalid output expression `%s`: unexpected character %q at position %d", text, c, i)
+		}
+	}
+	return tokens, nil
+}
+
+type outputExprParser struct {
+	text   string
+	tokens []outputExprToken
+	next   int
+	fields map[string]*schemapb.FieldSchema
+	refs   []string
+}
+
+// parseOutputExpr parses `<expr> as <alias>`, the expression is built of numeric fields, numbers,
+// + - * / and parentheses, or a single field to rename
+func parseOutputExpr(text string, fields map[string]*schemapb.FieldSchema) (*outputExpr, error) {
+	tokens, err := tokenizeOutputExpr(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &outputExprParser{text: text, tokens: tokens, fields: fields}
+
+	expr := &outputExpr{text: text}
+	if len(tokens) >= 2 && strings.EqualFold(tokens[1].text, "as") && fields[tokens[0].text] != nil {
+		// a field of any type is renamed
+		expr.root = &outputExprNode{pos: tokens[0].pos, field: tokens[0].text}
+		p.refs = append(p.refs, tokens[0].text)
+		p.next = 1
+	} else {
+		expr.root, err = p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !p.consume("as") {
+		if token, ok := p.peek(); ok {
+			return nil, p.errorf(token.pos, "unexpected %s", token.text)
+		}
+		return nil, p.errorf(len(text), "missing alias")
+	}
+	token, ok := p.peek()
+	if !ok {
+		return nil, p.errorf(len(text), "missing alias")
+	}
+	if !isOutputExprField(token.text) || strings.EqualFold(token.text, "as") {
+		return nil, p.errorf(token.pos, "invalid alias %s", token.text)
+	}
+	expr.alias = token.text
+	p.next++
+	if token, ok := p.peek(); ok {
+		return nil, p.errorf(token.pos, "unexpected %s", token.text)
+	}
+	expr.fields = p.refs
+	return expr, nil
+}
+
+func isOutputExprField(text string) bool {
+	if text == "" || unicode.IsDigit(rune(text[0])) {
+		return false
+	}
+	return !strings.Contains(text, ".")
+}
+
+func (p *outputExprParser) errorf(pos int, format string, args ...interface{}) error {
+	return fmt.Errorf("invalid output expression `%s`: %s at position %d", p.text, fmt.Sprintf(format, args...), pos)
+}
+
+func (p *outputExprParser) peek() (outputExprToken, bool) {
+	if p.next >= len(p.tokens) {
+		return outputExprToken{}, false
+	}
+	return p.tokens[p.next], true
+}
+
+// consume skips the next token if it's text, keywords are case insensitive
+func (p *outputExprParser) consume(text string) bool {
+	token, ok := p.peek()
+	if !ok || !strings.EqualFold(token.text, text) {
+		return false
+	}
+	p.next++
+	return true
+}
+
+// parseSum parses term {(+|-) term}
+func (p *outputExprParser) parseSum() (*outputExprNode, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		token, ok := p.peek()
+		if !ok || (token.text != "+" && token.text != "-") {
+			return left, nil
+		}
+		p.next++
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &outputExprNode{pos: token.pos, op: token.text[0], left: left, right: right}
+	}
+}
+
+// parseTerm parses factor {(*|/) factor}
+func (p *outputExprParser) parseTerm() (*outputExprNode, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		token, ok := p.peek()
+		if !ok || (token.text != "*" && token.text != "/") {
+			return left, nil
+		}
+		p.next++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &outputExprNode{pos: token.pos, op: token.text[0], left: left, right: right}
+	}
+}
+
+// parseFactor parses a field, a number, -factor or (sum)
+func (p *outputExprParser) parseFactor() (*outputExprNode, error) {
+	token, ok := p.peek()
+	if !ok {
+		return nil, p.errorf(len(p.text), "unexpected end of expression")
+	}
+	p.next++
+	switch {
+	case token.text == "-":
+		operand, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return &outputExprNode{pos: token.pos, op: '-', left: &outputExprNode{pos: token.pos}, right: operand}, nil
+	case token.text == "(":
+		node, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			if next, ok := p.peek(); ok {
+				return nil, p.errorf(next.pos, "unexpected %s, missing ) of ( at position %d", next.text, token.pos)
+			}
+			return nil, p.errorf(len(p.text), "missing ) of ( at position %d", token.pos)
+		}
+		return node, nil
+	case unicode.IsDigit(rune(token.text[0])) || token.text[0] == '.':
+		value, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, p.errorf(token.pos, "invalid number %s", token.text)
+		}
+		return &outputExprNode{pos: token.pos, value: value}, nil
+	case isOutputExprField(token.text) && !strings.EqualFold(token.text, "as"):
+		field, ok := p.fields[token.text]
+		if !ok {
+			return nil, p.errorf(token.pos, "field %s not exist", token.text)
+		}
+		if !typeutil.IsIntegerType(field.GetDataType()) && !typeutil.IsFloatingType(field.GetDataType()) {
+			return nil, p.errorf(token.pos, "field %s of type %s is not numeric", token.text, field.GetDataType().String())
+		}
+		if next, ok := p.peek(); ok && next.text == "(" {
+			return nil, p.errorf(next.pos, "unsupported function call %s", token.text)
+		}
+		p.refs = append(p.refs, token.text)
+		return &outputExprNode{pos: token.pos, field: token.text}, nil
+	}
+	return nil, p.errorf(token.pos, "unexpected %s", token.text)
+}
+
+// maxExactInt64 is the max magnitude of int64 values float64 represents exactly
+const maxExactInt64 = 1 << 53
+
+// numericFieldValue returns the i-th value of the numeric field data as float64, int64 values beyond
+// the precision of float64 are rejected instead of rounded
+func numericFieldValue(fieldData *schemapb.FieldData, i int) (float64, error) {
+	scalars := fieldData.GetScalars()
+	switch fieldData.GetType() {
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		if data := scalars.GetIntData().GetData(); i < len(data) {
+			return float64(data[i]), nil
+		}
+	case schemapb.DataType_Int64:
+		if data := scalars.GetLongData().GetData(); i < len(data) {
+			if data[i] > maxExactInt64 || data[i] < -maxExactInt64 {
+				return 0, fmt.Errorf("value %d of field %s at row %d exceeds the precision of float64", data[i], fieldData.GetFieldName(), i)
+			}
+			return float64(data[i]), nil
+		}
+	case schemapb.DataType_Float:
+		if data := scalars.GetFloatData().GetData(); i < len(data) {
+			return float64(data[i]), nil
+		}
+	case schemapb.DataType_Double:
+		if data := scalars.GetDoubleData().GetData(); i < len(data) {
+			return data[i], nil
+		}
+	}
+	return 0, fmt.Errorf("no value of field %s at row %d", fieldData.GetFieldName(), i)
+}
+
+// eval evaluates the node on the i-th row of the fields
+func (n *outputExprNode) eval(fields map[string]*schemapb.FieldData, i int) (float64, error) {
+	if n.op == 0 {
+		if n.field == "" {
+			return n.value, nil
+		}
+		return numericFieldValue(fields[n.field], i)
+	}
+	left, err := n.left.eval(fields, i)
+	if err != nil {
+		return 0, err
+	}
+	right, err := n.right.eval(fields, i)
+	if err != nil {
+		return 0, err
+	}
+	var value float64
+	switch n.op {
+	case '+':
+		value = left + right
+	case '-':
+		value = left - right
+	case '*':
+		value = left * right
+	case '/':
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero at position %d of row %d", n.pos, i)
+		}
+		value = left / right
+	}
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return 0, fmt.Errorf("float64 overflow of %c at position %d of row %d", n.op, n.pos, i)
+	}
+	return value, nil
+}
+
+// compute returns the field data of the output expression over the merged fields data of numRows rows
+func (e *outputExpr) compute(fields map[string]*schemapb.FieldData, numRows int) (*schemapb.FieldData, error) {
+	for _, name := range e.fields {
+		if _, ok := fields[name]; !ok {
+			return nil, fmt.Errorf("output expression `%s`: field %s not retrieved", e.text, name)
+		}
+	}
+	if e.root.op == 0 && e.root.field != "" {
+		renamed := proto.Clone(fields[e.root.field]).(*schemapb.FieldData)
+		renamed.FieldName = e.alias
+		renamed.FieldId = 0
+		return renamed, nil
+	}
+	values := make([]float64, 0, numRows)
+	for i := 0; i < numRows; i++ {
+		value, err := e.root.eval(fields, i)
+		if err != nil {
+			return nil, fmt.Errorf("output expression `%s`: %w", e.text, err)
+		}
+		values = append(values, value)
+	}
+	return &schemapb.FieldData{
+		Type:      schemapb.DataType_Double,
+		FieldName: e.alias,
+		FieldId:   0,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: values}},
+			},
+		},
+	}, nil
+}
+
+// addOutputExprFields adds the fields referenced by output expressions to the output fields,
+// the added fields are hidden from the query results
+func addOutputExprFields(outputFields []string, exprs []*outputExpr) ([]string, map[string]bool) {
+	requested := make(map[string]bool, len(outputFields))
+	for _, field := range outputFields {
+		requested[field] = true
+	}
+	hiddenFields := make(map[string]bool)
+	for _, expr := range exprs {
+		for _, field := range expr.fields {
+			if !requested[field] {
+				requested[field] = true
+				hiddenFields[field] = true
+				outputFields = append(outputFields, field)
+			}
+		}
+	}
+	return outputFields, hiddenFields
+}
+
+// applyOutputExprs appends the fields of output expressions to the fields data of query results,
+// and removes the hidden fields which are retrieved only for the expressions
+func applyOutputExprs(exprs []*outputExpr, fieldsData []*schemapb.FieldData, hiddenFields map[string]bool) ([]*schemapb.FieldData, error) {
+	if len(exprs) == 0 {
+		return fieldsData, nil
+	}
+	fields := make(map[string]*schemapb.FieldData, len(fieldsData))
+	numRows := 0
+	for _, fieldData := range fieldsData {
+		fields[fieldData.GetFieldName()] = fieldData
+		if rows, err := typeutil.GetRowCountOfFieldData(fieldData); err == nil && rows > numRows {
+			numRows = rows
+		}
+	}
+	ret := make([]*schemapb.FieldData, 0, len(fieldsData)+len(exprs))
+	for _, fieldData := range fieldsData {
+		if !hiddenFields[fieldData.GetFieldName()] {
+			ret = append(ret, fieldData)
+		}
+	}
+	for _, expr := range exprs {
+		fieldData, err := expr.compute(fields, numRows)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, fieldData)
+	}
+	return ret, nil
+}
@@ -0,0 +1,244 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+func genOutputExprSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Name: "output_expr",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "i8", DataType: schemapb.DataType_Int8},
+			{FieldID: 102, Name: "i16", DataType: schemapb.DataType_Int16},
+			{FieldID: 103, Name: "i32", DataType: schemapb.DataType_Int32},
+			{FieldID: 104, Name: "i64", DataType: schemapb.DataType_Int64},
+			{FieldID: 105, Name: "f32", DataType: schemapb.DataType_Float},
+			{FieldID: 106, Name: "f64", DataType: schemapb.DataType_Double},
+			{FieldID: 107, Name: "name", DataType: schemapb.DataType_VarChar},
+			{FieldID: 108, Name: "flag", DataType: schemapb.DataType_Bool},
+			{FieldID: 109, Name: "vec", DataType: schemapb.DataType_FloatVector},
+		},
+	}
+}
+
+func genOutputExprFieldsData() []*schemapb.FieldData {
+	intField := func(name string, dataType schemapb.DataType, data []int32) *schemapb.FieldData {
+		return &schemapb.FieldData{
+			FieldName: name,
+			Type:      dataType,
+			Field:     &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: data}}}},
+		}
+	}
+	return []*schemapb.FieldData{
+		{
+			FieldName: "pk",
+			Type:      schemapb.DataType_Int64,
+			Field:     &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2, 3}}}}},
+		},
+		intField("i8", schemapb.DataType_Int8, []int32{-1, 0, 127}),
+		intField("i16", schemapb.DataType_Int16, []int32{2, 0, 1000}),
+		intField("i32", schemapb.DataType_Int32, []int32{3, 4, 5}),
+		{
+			FieldName: "i64",
+			Type:      schemapb.DataType_Int64,
+			Field:     &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{10, 20, math.MaxInt64}}}}},
+		},
+		{
+			FieldName: "f32",
+			Type:      schemapb.DataType_Float,
+			Field:     &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: []float32{0.5, 1.5, -2}}}}},
+		},
+		{
+			FieldName: "f64",
+			Type:      schemapb.DataType_Double,
+			Field:     &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: []float64{0.25, 1e300, 4}}}}},
+		},
+		{
+			FieldName: "name",
+			Type:      schemapb.DataType_VarChar,
+			Field:     &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"a", "b", "c"}}}}},
+		},
+	}
+}
+
+func TestParseOutputExprs(t *testing.T) {
+	schema := genOutputExprSchema()
+
+	_, _, err := parseOutputExprs([]string{"*", "i8", "price * 2 as total", "name as title"}, schema)
+	assert.Error(t, err)
+
+	plainFields, exprs, err := parseOutputExprs([]string{"*", "i8", "i64 * (f32 + 2) as total", "name AS title", "-i8/2 as half"}, schema)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*", "i8"}, plainFields)
+	require.Equal(t, 3, len(exprs))
+	assert.Equal(t, "total", exprs[0].alias)
+	assert.Equal(t, []string{"i64", "f32"}, exprs[0].fields)
+	assert.Equal(t, "title", exprs[1].alias)
+	assert.Equal(t, []string{"name"}, exprs[1].fields)
+	assert.Equal(t, "half", exprs[2].alias)
+	assert.Equal(t, []string{"i8"}, exprs[2].fields)
+
+	invalids := []struct {
+		expr string
+		pos  string
+	}{
+		{"price * 2 as total", "position 0"},
+		{"i8 + as total", "position 5"},
+		{"i8 + 1", "position 6"},
+		{"i8 + 1 as", "position 9"},
+		{"(i8 + 1 as total", "position 8"},
+		{"(i8 + 1) as 2total", "position 12"},
+		{"i8 + 1 as total as t", "position 16"},
+		{"(i8 as x) + 1 as total", "position 4"},
+		{"i8 + name as total", "position 5"},
+		{"i8 + vec as total", "position 5"},
+		{"abs(i8) as total", "position 0"},
+		{"i8 + 1.2.3 as total", "position 5"},
+		{"i8 % 2 as total", "position 3"},
+		{"i8 + 1 as i16", "duplicate"},
+	}
+	for _, invalid := range invalids {
+		_, _, err := parseOutputExprs([]string{invalid.expr}, schema)
+		require.Error(t, err, invalid.expr)
+		assert.Contains(t, err.Error(), invalid.pos, invalid.expr)
+	}
+
+	_, _, err = parseOutputExprs([]string{"i8 + 1 as total", "i16 as total"}, schema)
+	assert.Error(t, err)
+}
+
+func TestAddOutputExprFields(t *testing.T) {
+	_, exprs, err := parseOutputExprs([]string{"i8 + i16 as total", "i16 * pk as x"}, genOutputExprSchema())
+	require.NoError(t, err)
+	outputFields, hiddenFields := addOutputExprFields([]string{"pk", "i8"}, exprs)
+	assert.Equal(t, []string{"pk", "i8", "i16"}, outputFields)
+	assert.Equal(t, map[string]bool{"i16": true}, hiddenFields)
+}
+
+func TestApplyOutputExprs(t *testing.T) {
+	schema := genOutputExprSchema()
+	apply := func(expr string) (*schemapb.FieldData, error) {
+		_, exprs, err := parseOutputExprs([]string{expr}, schema)
+		require.NoError(t, err)
+		fieldsData, err := applyOutputExprs(exprs, genOutputExprFieldsData(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return fieldsData[len(fieldsData)-1], nil
+	}
+
+	cases := []struct {
+		expr     string
+		expected []float64
+	}{
+		{"i8 + i16 as x", []float64{1, 0, 1127}},
+		{"i8 * i32 as x", []float64{-3, 0, 635}},
+		{"i32 - i8 as x", []float64{4, 4, -122}},
+		{"i32 / 2 as x", []float64{1.5, 2, 2.5}},
+		{"i32 * f32 as x", []float64{1.5, 6, -10}},
+		{"f32 + f64 as x", []float64{0.75, 1e300 + 1.5, 2}},
+		{"i8 + i16 * i32 - 1 as x", []float64{4, -1, 5126}},
+		{"(i8 + i16) * i32 as x", []float64{3, 0, 5635}},
+		{"-i32 + 0.5 as x", []float64{-2.5, -3.5, -4.5}},
+	}
+	for _, c := range cases {
+		fieldData, err := apply(c.expr)
+		require.NoError(t, err, c.expr)
+		assert.Equal(t, "x", fieldData.GetFieldName())
+		assert.Equal(t, int64(0), fieldData.GetFieldId())
+		assert.Equal(t, schemapb.DataType_Double, fieldData.GetType())
+		assert.Equal(t, c.expected, fieldData.GetScalars().GetDoubleData().GetData(), c.expr)
+	}
+
+	t.Run("rename", func(t *testing.T) {
+		fieldData, err := apply("name as title")
+		require.NoError(t, err)
+		assert.Equal(t, "title", fieldData.GetFieldName())
+		assert.Equal(t, int64(0), fieldData.GetFieldId())
+		assert.Equal(t, schemapb.DataType_VarChar, fieldData.GetType())
+		assert.Equal(t, []string{"a", "b", "c"}, fieldData.GetScalars().GetStringData().GetData())
+	})
+
+	t.Run("division by zero", func(t *testing.T) {
+		_, err := apply("i32 / i16 as x")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "division by zero")
+		_, err = apply("f32 / (i8 + 1) as x")
+		assert.Error(t, err)
+	})
+
+	t.Run("int64 beyond float64 precision", func(t *testing.T) {
+		// the last value of i64 is MaxInt64
+		_, err := apply("i64 / 2 as x")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "precision")
+
+		fieldData := &schemapb.FieldData{
+			Type:      schemapb.DataType_Int64,
+			FieldName: "i64",
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{
+				LongData: &schemapb.LongArray{Data: []int64{1 << 53, -1 << 53, 1<<53 + 1, -1<<53 - 1}},
+			}}},
+		}
+		value, err := numericFieldValue(fieldData, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(1<<53), value)
+		value, err = numericFieldValue(fieldData, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, -float64(1<<53), value)
+		_, err = numericFieldValue(fieldData, 2)
+		assert.Error(t, err)
+		_, err = numericFieldValue(fieldData, 3)
+		assert.Error(t, err)
+		_, err = numericFieldValue(fieldData, 4)
+		assert.Error(t, err)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		_, err := apply("f64 * f64 as x")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "overflow")
+	})
+
+	t.Run("hidden fields", func(t *testing.T) {
+		_, exprs, err := parseOutputExprs([]string{"i8 + i16 as x"}, schema)
+		require.NoError(t, err)
+		fieldsData, err := applyOutputExprs(exprs, genOutputExprFieldsData(), map[string]bool{"i16": true, "name": true})
+		require.NoError(t, err)
+		var names []string
+		for _, fieldData := range fieldsData {
+			names = append(names, fieldData.GetFieldName())
+		}
+		assert.Equal(t, []string{"pk", "i8", "i32", "i64", "f32", "f64", "x"}, names)
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		_, exprs, err := parseOutputExprs([]string{"i8 + i16 as x"}, schema)
+		require.NoError(t, err)
+		_, err = applyOutputExprs(exprs, genOutputExprFieldsData()[:2], nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,174 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// The rows of a collection with partition key are routed to its partitions by the hash of their partition keys, the
+// partitions are indexed in the order of partition id. QueryNode prunes the partitions by the same routing, so the
+// partitions must not change once rows are routed to them: they are all created along with the collection, and
+// creating or dropping partitions, inserting into a given partition and importing are rejected for the collection.
+
+// validatePartitionKey checks there is at most one partition key field, of type Int64 or VarChar,
+// and the number of partitions it routes the rows to is valid
+func validatePartitionKey(schema *schemapb.CollectionSchema) error {
+	var keyField *schemapb.FieldSchema
+	for _, field := range schema.GetFields() {
+		if !field.GetIsPartitionKey() {
+			continue
+		}
+		if keyField != nil {
+			return fmt.Errorf("there are more than one partition key, field name = %s, %s", keyField.GetName(), field.GetName())
+		}
+		if field.GetDataType() != schemapb.DataType_Int64 && field.GetDataType() != schemapb.DataType_VarChar {
+			return errors.New("the data type of partition key should be Int64 or VarChar")
+		}
+		numPartitions, err := typeutil.GetNumPartitions(field)
+		if err != nil {
+			return err
+		}
+		if int64(numPartitions) > Params.RootCoordCfg.MaxPartitionNum {
+			return fmt.Errorf("the number of partitions of partition key should be limited to %d", Params.RootCoordCfg.MaxPartitionNum)
+		}
+		keyField = field
+	}
+	return nil
+}
+
+// partitionKeyPartitionName returns the name of the i-th partition created for a collection with partition key,
+// the 0-th is the default partition created with the collection
+func partitionKeyPartitionName(i int) string {
+	if i == 0 {
+		return Params.CommonCfg.DefaultPartitionName
+	}
+	return fmt.Sprintf("%s_%d", Params.CommonCfg.DefaultPartitionName, i)
+}
+
+// createPartitionKeyPartitions creates the partitions the rows of the new collection are routed to by partition key,
+// besides the default partition. The collection is dropped if any of them fails to be created, so that the rows are
+// never routed to an incomplete set of partitions
+func createPartitionKeyPartitions(ctx context.Context, rootCoord types.RootCoord, req *milvuspb.CreateCollectionRequest,
+	schema *schemapb.CollectionSchema) error {
+	keyField := typeutil.GetPartitionKeyFieldSchema(schema)
+	if keyField == nil {
+		return nil
+	}
+	numPartitions, err := typeutil.GetNumPartitions(keyField)
+	if err != nil {
+		return err
+	}
+	for i := 1; i < numPartitions; i++ {
+		status, err := rootCoord.CreatePartition(ctx, &milvuspb.CreatePartitionRequest{
+			Base:           &commonpb.MsgBase{MsgType: commonpb.MsgType_CreatePartition, SourceID: Params.ProxyCfg.ProxyID},
+			DbName:         req.GetDbName(),
+			CollectionName: req.GetCollectionName(),
+			PartitionName:  partitionKeyPartitionName(i),
+		})
+		if err == nil && status.GetErrorCode() != commonpb.ErrorCode_Success {
+			err = errors.New(status.GetReason())
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to create partition %d of %d of partition key, %w", i, numPartitions, err)
+			status, dropErr := rootCoord.DropCollection(ctx, &milvuspb.DropCollectionRequest{
+				Base:           &commonpb.MsgBase{MsgType: commonpb.MsgType_DropCollection, SourceID: Params.ProxyCfg.ProxyID},
+				DbName:         req.GetDbName(),
+				CollectionName: req.GetCollectionName(),
+			})
+			if dropErr == nil && status.GetErrorCode() != commonpb.ErrorCode_Success {
+				dropErr = errors.New(status.GetReason())
+			}
+			if dropErr != nil {
+				return fmt.Errorf("%w, and failed to drop the collection, %s", err, dropErr.Error())
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNoPartitionKey returns error if the collection has partition key, whose partitions must not be changed
+func checkNoPartitionKey(ctx context.Context, rootCoord types.RootCoord, dbName, collectionName string, op string) error {
+	resp, err := rootCoord.DescribeCollection(ctx, &milvuspb.DescribeCollectionRequest{
+		Base:           &commonpb.MsgBase{MsgType: commonpb.MsgType_DescribeCollection, SourceID: Params.ProxyCfg.ProxyID},
+		DbName:         dbName,
+		CollectionName: collectionName,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return errors.New(resp.GetStatus().GetReason())
+	}
+	if keyField := typeutil.GetPartitionKeyFieldSchema(resp.GetSchema()); keyField != nil {
+		return fmt.Errorf("%s is not allowed, the partitions of collection %s are routed to by partition key %s",
+			op, collectionName, keyField.GetName())
+	}
+	return nil
+}
+
+// getPartitionKeyPartitions returns the ids of the partitions of the collection with partition key in order,
+// and the names of the partitions by id
+func getPartitionKeyPartitions(ctx context.Context, collectionName string, numPartitions int) ([]UniqueID, map[UniqueID]string, error) {
+	partitions, err := globalMetaCache.GetPartitions(ctx, collectionName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(partitions) != numPartitions {
+		// the partitions may be cached while the collection was being created
+		globalMetaCache.RemoveCollection(ctx, collectionName)
+		partitions, err = globalMetaCache.GetPartitions(ctx, collectionName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(partitions) != numPartitions {
+			return nil, nil, fmt.Errorf("collection %s has %d partitions, but partition key routes to %d partitions",
+				collectionName, len(partitions), numPartitions)
+		}
+	}
+	partitionIDs := make([]UniqueID, 0, len(partitions))
+	names := make(map[UniqueID]string, len(partitions))
+	for name, partitionID := range partitions {
+		partitionIDs = append(partitionIDs, partitionID)
+		names[partitionID] = name
+	}
+	sort.Slice(partitionIDs, func(i, j int) bool { return partitionIDs[i] < partitionIDs[j] })
+	return partitionIDs, names, nil
+}
+
+// routePartitionKeys returns the partition of every row routed to by the hash of its partition key in keys
+func routePartitionKeys(keys *schemapb.FieldData, partitionIDs []UniqueID) ([]UniqueID, error) {
+	indexes, err := typeutil.HashKey2Partitions(keys, len(partitionIDs))
+	if err != nil {
+		return nil, err
+	}
+	routed := make([]UniqueID, len(indexes))
+	for i, index := range indexes {
+		routed[i] = partitionIDs[index]
+	}
+	return routed, nil
+}
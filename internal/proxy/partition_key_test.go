@@ -0,0 +1,297 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/allocator"
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+const testPartitionKeyField = "partition_key"
+
+func genPartitionKeyField(dataType schemapb.DataType, numPartitions string) *schemapb.FieldSchema {
+	return &schemapb.FieldSchema{
+		Name:           testPartitionKeyField,
+		DataType:       dataType,
+		IsPartitionKey: true,
+		TypeParams:     []*commonpb.KeyValuePair{{Key: typeutil.NumPartitionsKey, Value: numPartitions}},
+	}
+}
+
+func TestValidatePartitionKey(t *testing.T) {
+	Params.Init()
+
+	assert.NoError(t, validatePartitionKey(&schemapb.CollectionSchema{}))
+	assert.NoError(t, validatePartitionKey(&schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{genPartitionKeyField(schemapb.DataType_Int64, "4")},
+	}))
+	assert.NoError(t, validatePartitionKey(&schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{genPartitionKeyField(schemapb.DataType_VarChar, "4")},
+	}))
+
+	// more than one partition key
+	assert.Error(t, validatePartitionKey(&schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			genPartitionKeyField(schemapb.DataType_Int64, "4"),
+			genPartitionKeyField(schemapb.DataType_VarChar, "4"),
+		},
+	}))
+	// invalid data type
+	assert.Error(t, validatePartitionKey(&schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{genPartitionKeyField(schemapb.DataType_Float, "4")},
+	}))
+	// invalid number of partitions
+	for _, numPartitions := range []string{"0", "-1", "abc", strconv.FormatInt(Params.RootCoordCfg.MaxPartitionNum+1, 10)} {
+		assert.Error(t, validatePartitionKey(&schemapb.CollectionSchema{
+			Fields: []*schemapb.FieldSchema{genPartitionKeyField(schemapb.DataType_Int64, numPartitions)},
+		}), numPartitions)
+	}
+}
+
+func TestPartitionKeyPartitionName(t *testing.T) {
+	Params.Init()
+	assert.Equal(t, Params.CommonCfg.DefaultPartitionName, partitionKeyPartitionName(0))
+	assert.Equal(t, Params.CommonCfg.DefaultPartitionName+"_3", partitionKeyPartitionName(3))
+}
+
+func TestRoutePartitionKeys(t *testing.T) {
+	keys := &schemapb.FieldData{
+		Type: schemapb.DataType_Int64,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2, 3, 1}}},
+			},
+		},
+	}
+	partitionIDs := []UniqueID{100, 101, 102}
+	routed, err := routePartitionKeys(keys, partitionIDs)
+	require.NoError(t, err)
+	indexes, err := typeutil.HashKey2Partitions(keys, len(partitionIDs))
+	require.NoError(t, err)
+	require.Equal(t, len(indexes), len(routed))
+	for i, index := range indexes {
+		assert.Equal(t, partitionIDs[index], routed[i])
+	}
+	// the same keys are routed to the same partition
+	assert.Equal(t, routed[0], routed[3])
+
+	_, err = routePartitionKeys(keys, nil)
+	assert.Error(t, err)
+}
+
+func TestTask_PartitionKey(t *testing.T) {
+	Params.Init()
+
+	rc := NewRootCoordMock()
+	rc.Start()
+	defer rc.Stop()
+
+	ctx := context.Background()
+	require.NoError(t, InitMetaCache(rc))
+
+	const numPartitions = 4
+	const nb = 100
+	dbName := ""
+	collectionName := "TestTask_PartitionKey" + funcutil.GenRandomStr()
+
+	schema := constructCollectionSchemaByDataType(collectionName, map[string]schemapb.DataType{
+		testInt64Field:    schemapb.DataType_Int64,
+		testFloatVecField: schemapb.DataType_FloatVector,
+	}, testInt64Field, false)
+	schema.Fields = append(schema.Fields, genPartitionKeyField(schemapb.DataType_Int64, strconv.Itoa(numPartitions)))
+	marshaledSchema, err := proto.Marshal(schema)
+	require.NoError(t, err)
+
+	t.Run("create collection", func(t *testing.T) {
+		createColT := &createCollectionTask{
+			Condition: NewTaskCondition(ctx),
+			CreateCollectionRequest: &milvuspb.CreateCollectionRequest{
+				DbName:         dbName,
+				CollectionName: collectionName,
+				Schema:         marshaledSchema,
+				ShardsNum:      2,
+			},
+			ctx:       ctx,
+			rootCoord: rc,
+		}
+		require.NoError(t, createColT.OnEnqueue())
+		require.NoError(t, createColT.PreExecute(ctx))
+		require.NoError(t, createColT.Execute(ctx))
+		require.NoError(t, createColT.PostExecute(ctx))
+
+		// the default partition is created by RootCoord along with the collection, which the mock doesn't
+		status, err := rc.CreatePartition(ctx, &milvuspb.CreatePartitionRequest{
+			DbName:         dbName,
+			CollectionName: collectionName,
+			PartitionName:  Params.CommonCfg.DefaultPartitionName,
+		})
+		require.NoError(t, err)
+		require.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+
+		partitions, err := globalMetaCache.GetPartitions(ctx, collectionName)
+		require.NoError(t, err)
+		assert.Equal(t, numPartitions, len(partitions))
+		for i := 0; i < numPartitions; i++ {
+			assert.Contains(t, partitions, partitionKeyPartitionName(i))
+		}
+	})
+
+	t.Run("partitions are not changed", func(t *testing.T) {
+		createPartT := &createPartitionTask{
+			Condition: NewTaskCondition(ctx),
+			CreatePartitionRequest: &milvuspb.CreatePartitionRequest{
+				Base:           &commonpb.MsgBase{MsgType: commonpb.MsgType_CreatePartition},
+				DbName:         dbName,
+				CollectionName: collectionName,
+				PartitionName:  "p",
+			},
+			ctx:       ctx,
+			rootCoord: rc,
+		}
+		assert.Error(t, createPartT.Execute(ctx))
+
+		dropPartT := &dropPartitionTask{
+			Condition: NewTaskCondition(ctx),
+			DropPartitionRequest: &milvuspb.DropPartitionRequest{
+				Base:           &commonpb.MsgBase{MsgType: commonpb.MsgType_DropPartition},
+				DbName:         dbName,
+				CollectionName: collectionName,
+				PartitionName:  partitionKeyPartitionName(1),
+			},
+			ctx:       ctx,
+			rootCoord: rc,
+		}
+		assert.Error(t, dropPartT.Execute(ctx))
+
+		partitions, err := globalMetaCache.GetPartitions(ctx, collectionName)
+		require.NoError(t, err)
+		assert.Equal(t, numPartitions, len(partitions))
+	})
+
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, collectionName)
+	require.NoError(t, err)
+	idAllocator, err := allocator.NewIDAllocator(ctx, rc, Params.ProxyCfg.ProxyID)
+	require.NoError(t, err)
+	_ = idAllocator.Start()
+	defer idAllocator.Close()
+	segAllocator, err := newSegIDAssigner(ctx, &mockDataCoord{expireTime: Timestamp(2500)}, getLastTick1)
+	require.NoError(t, err)
+	segAllocator.Init()
+	_ = segAllocator.Start()
+	defer segAllocator.Close()
+
+	newInsertTask := func(partitionName string) *insertTask {
+		task := &insertTask{
+			BaseInsertTask: BaseInsertTask{
+				BaseMsg: msgstream.BaseMsg{
+					HashValues: generateHashKeys(nb),
+				},
+				InsertRequest: internalpb.InsertRequest{
+					Base: &commonpb.MsgBase{
+						MsgType:  commonpb.MsgType_Insert,
+						SourceID: Params.ProxyCfg.ProxyID,
+					},
+					DbName:         dbName,
+					CollectionName: collectionName,
+					PartitionName:  partitionName,
+					NumRows:        uint64(nb),
+					Version:        internalpb.InsertDataVersion_ColumnBased,
+				},
+			},
+			Condition:      NewTaskCondition(ctx),
+			ctx:            ctx,
+			rowIDAllocator: idAllocator,
+			segIDAssigner:  segAllocator,
+		}
+		task.FieldsData = []*schemapb.FieldData{
+			generateFieldData(schemapb.DataType_Int64, testInt64Field, common.StartOfUserFieldID, nb),
+			generateFieldData(schemapb.DataType_FloatVector, testFloatVecField, common.StartOfUserFieldID+1, nb),
+			generateFieldData(schemapb.DataType_Int64, testPartitionKeyField, common.StartOfUserFieldID+2, nb),
+		}
+		task.FieldsData[2].FieldName = testPartitionKeyField
+		return task
+	}
+
+	t.Run("insert into partition", func(t *testing.T) {
+		task := newInsertTask(partitionKeyPartitionName(1))
+		assert.Error(t, task.PreExecute(ctx))
+	})
+
+	t.Run("insert routed by partition key", func(t *testing.T) {
+		task := newInsertTask(Params.CommonCfg.DefaultPartitionName)
+		require.NoError(t, task.PreExecute(ctx))
+		task.CollectionID = collectionID
+		require.NoError(t, task.routePartitionKeys(ctx))
+		require.Equal(t, nb, len(task.rowPartitionIDs))
+
+		partitions, err := globalMetaCache.GetPartitions(ctx, collectionName)
+		require.NoError(t, err)
+		partitionIDs, partitionNames, err := getPartitionKeyPartitions(ctx, collectionName, numPartitions)
+		require.NoError(t, err)
+		for name, partitionID := range partitions {
+			assert.Equal(t, name, partitionNames[partitionID])
+		}
+
+		var keys *schemapb.FieldData
+		for _, fieldData := range task.GetFieldsData() {
+			if fieldData.GetFieldName() == testPartitionKeyField {
+				keys = fieldData
+			}
+		}
+		require.NotNil(t, keys)
+		expected, err := routePartitionKeys(keys, partitionIDs)
+		require.NoError(t, err)
+		assert.Equal(t, expected, task.rowPartitionIDs)
+
+		msgPack, err := task.assignSegmentID([]string{"ch0", "ch1"})
+		require.NoError(t, err)
+		rows := 0
+		for _, msg := range msgPack.Msgs {
+			insertMsg := msg.(*msgstream.InsertMsg)
+			assert.Equal(t, partitionNames[insertMsg.GetPartitionID()], insertMsg.GetPartitionName())
+			for i, key := range insertMsg.GetFieldsData()[2].GetScalars().GetLongData().GetData() {
+				routed, err := routePartitionKeys(&schemapb.FieldData{
+					Type: schemapb.DataType_Int64,
+					Field: &schemapb.FieldData_Scalars{
+						Scalars: &schemapb.ScalarField{
+							Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{key}}},
+						},
+					},
+				}, partitionIDs)
+				require.NoError(t, err)
+				assert.Equal(t, routed[0], insertMsg.GetPartitionID(), "row %d", i)
+			}
+			rows += int(insertMsg.NumRows)
+		}
+		assert.Equal(t, nb, rows)
+	})
+}
@@ -95,6 +95,15 @@ type Proxy struct {
 
 	searchResultCh chan *internalpb.SearchResults
 
+	// routes search and query requests to the replica selected by the replica selection policy
+	replicaLoadBalancer *replicaLoadBalancer
+
+	// the collection stats pushed by query nodes, nil if not consumed
+	collectionStatsCache *collectionStatsCache
+
+	// returns the filter enforced on search and query requests, nil means no filter
+	mandatoryFilterHook MandatoryFilterHook
+
 	// Add callback functions at different stages
 	startCallbacks []func()
 	closeCallbacks []func()
@@ -111,6 +120,7 @@ func NewProxy(ctx context.Context, factory dependency.Factory) (*Proxy, error) {
 		factory:        factory,
 		searchResultCh: make(chan *internalpb.SearchResults, n),
 	}
+	node.replicaLoadBalancer = newReplicaLoadBalancer(node.ctx, defaultGetQueryNodePolicy)
 	node.UpdateStateCode(internalpb.StateCode_Abnormal)
 	logutil.Logger(ctx).Debug("create a new Proxy instance", zap.Any("state", node.stateCode.Load()))
 	return node, nil
@@ -250,6 +260,11 @@ func (node *Proxy) Init() error {
 	}
 	log.Debug("init meta cache done", zap.String("role", typeutil.ProxyRole))
 
+	if err := initExprCache(); err != nil {
+		log.Warn("failed to init expression cache", zap.Error(err), zap.String("role", typeutil.ProxyRole))
+		return err
+	}
+
 	return nil
 }
 
@@ -353,6 +368,21 @@ func (node *Proxy) Start() error {
 
 	node.sendChannelsTimeTickLoop()
 
+	if err := node.replicaLoadBalancer.setPolicy(Params.ProxyCfg.ReplicaSelectionPolicy); err != nil {
+		log.Warn("failed to set replica selection policy", zap.Error(err), zap.String("role", typeutil.ProxyRole))
+		return err
+	}
+	node.replicaLoadBalancer.start(Params.ProxyCfg.ReplicaLoadPollInterval)
+
+	// the collection stats are optional, failing to consume them shall not fail the proxy
+	if Params.ProxyCfg.CollectionStatsEnabled {
+		node.collectionStatsCache = newCollectionStatsCache(node.ctx, node.factory, Params.ProxyCfg.CollectionStatsExpire)
+		if err := node.collectionStatsCache.start(); err != nil {
+			log.Warn("failed to start collection stats cache", zap.Error(err), zap.String("role", typeutil.ProxyRole))
+			node.collectionStatsCache = nil
+		}
+	}
+
 	// Start callbacks
 	for _, cb := range node.startCallbacks {
 		cb()
@@ -395,6 +425,21 @@ func (node *Proxy) Stop() error {
 		log.Info("close channels time ticker", zap.String("role", typeutil.ProxyRole))
 	}
 
+	if node.replicaLoadBalancer != nil {
+		node.replicaLoadBalancer.close()
+		log.Info("close replica load balancer", zap.String("role", typeutil.ProxyRole))
+	}
+
+	if node.collectionStatsCache != nil {
+		node.collectionStatsCache.close()
+		log.Info("close collection stats cache", zap.String("role", typeutil.ProxyRole))
+	}
+
+	if globalExprCache != nil {
+		globalExprCache.close()
+		log.Info("close expression cache", zap.String("role", typeutil.ProxyRole))
+	}
+
 	node.wg.Wait()
 
 	for _, cb := range node.closeCallbacks {
@@ -448,3 +493,8 @@ func (node *Proxy) SetDataCoordClient(cli types.DataCoord) {
 func (node *Proxy) SetQueryCoordClient(cli types.QueryCoord) {
 	node.queryCoord = cli
 }
+
+// SetMandatoryFilterHook sets the hook which returns the filter enforced on search and query requests.
+func (node *Proxy) SetMandatoryFilterHook(hook MandatoryFilterHook) {
+	node.mandatoryFilterHook = hook
+}
@@ -0,0 +1,175 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// errCursorExpired is the error of resuming a query by a cursor whose snapshot is out of retention,
+// the pagination should be restarted without cursor
+var errCursorExpired = errors.New("query cursor expired")
+
+// isCursorExpired returns whether err is caused by an expired cursor, found either by proxy or by the shard leaders
+func isCursorExpired(err error) bool {
+	var leaderErr *shardLeaderError
+	if errors.As(err, &leaderErr) {
+		return leaderErr.status.GetErrorCode() == commonpb.ErrorCode_CursorExpired
+	}
+	return errors.Is(err, errCursorExpired)
+}
+
+// hashQueryPlan returns the hash of the retrieve plan of collection regardless of its limit, together with the
+// mandatory filter, so that a cursor only resumes the query it is returned by, whatever the page size is
+func hashQueryPlan(collectionID UniqueID, plan *planpb.PlanNode, mandatoryFilterPlan []byte) (uint64, error) {
+	unlimited := proto.Clone(plan).(*planpb.PlanNode)
+	unlimited.Limit = 0
+	data, err := proto.Marshal(unlimited)
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, uint64(collectionID))
+	h.Write(buf)
+	h.Write(data)
+	h.Write(mandatoryFilterPlan)
+	return h.Sum64(), nil
+}
+
+// decodeQueryCursor decodes the opaque cursor returned by the former page
+func decodeQueryCursor(data []byte) (*internalpb.QueryCursor, error) {
+	cursor := &internalpb.QueryCursor{}
+	if err := proto.Unmarshal(data, cursor); err != nil {
+		return nil, fmt.Errorf("invalid query cursor: %w", err)
+	}
+	if cursor.GetSnapshotTimestamp() == 0 || cursor.GetLastPk() == nil || typeutil.GetSizeOfIDs(cursor.GetLastPk()) != 1 {
+		return nil, errors.New("invalid query cursor: no snapshot or last primary key")
+	}
+	return cursor, nil
+}
+
+// primaryKeysOf returns the primary keys of the rows of fieldsData, nil if the primary key field is not output
+func primaryKeysOf(fieldsData []*schemapb.FieldData, pkField *schemapb.FieldSchema) *schemapb.IDs {
+	for _, fieldData := range fieldsData {
+		if fieldData.GetFieldId() != pkField.GetFieldID() {
+			continue
+		}
+		switch pkField.GetDataType() {
+		case schemapb.DataType_Int64:
+			return &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: fieldData.GetScalars().GetLongData()}}
+		case schemapb.DataType_VarChar:
+			return &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: fieldData.GetScalars().GetStringData()}}
+		}
+	}
+	return nil
+}
+
+// preparePagination checks the cursor of the request. A query ordered by primary key with limit is paginated,
+// and is resumed after the cursor returned by the former page at the snapshot of the former pages if any
+func (t *queryTask) preparePagination(plan *planpb.PlanNode, schema *schemapb.CollectionSchema) error {
+	pkField, err := typeutil.GetPrimaryFieldSchema(schema)
+	if err != nil {
+		return err
+	}
+	t.pkField = pkField
+	t.paginated = t.request.GetLimit() > 0 && t.orderByFieldID == pkField.GetFieldID()
+	if !t.paginated {
+		if len(t.request.GetCursor()) > 0 {
+			return errors.New("query cursor requires the query ordered by primary key with limit")
+		}
+		return nil
+	}
+	t.planHash, err = hashQueryPlan(t.CollectionID, plan, t.RetrieveRequest.MandatoryFilterPlan)
+	if err != nil {
+		return err
+	}
+	if len(t.request.GetCursor()) == 0 {
+		return nil
+	}
+
+	cursor, err := decodeQueryCursor(t.request.GetCursor())
+	if err != nil {
+		return err
+	}
+	if cursor.GetPlanHash() != t.planHash {
+		return errors.New("query cursor does not match the query, the expression, the output fields and the order should be kept between pages")
+	}
+	snapshotTs := cursor.GetSnapshotTimestamp()
+	if t.request.SnapshotTimestamp != 0 && t.request.SnapshotTimestamp != snapshotTs {
+		return fmt.Errorf("query cursor is resumed at snapshot ts %d, which conflicts with snapshot ts %d of the request",
+			snapshotTs, t.request.SnapshotTimestamp)
+	}
+	if tsoutil.CalculateDuration(t.BeginTs(), snapshotTs)/1000 > Params.CommonCfg.RetentionDuration {
+		return fmt.Errorf("%w, snapshot ts %d is out of retention", errCursorExpired, snapshotTs)
+	}
+	t.request.SnapshotTimestamp = snapshotTs
+	t.RetrieveRequest.Cursor = cursor
+	return nil
+}
+
+// addDistribution records the distribution digest reported by the shard leader of channel
+func (t *queryTask) addDistribution(channel string, digest uint64) {
+	t.distributionMu.Lock()
+	defer t.distributionMu.Unlock()
+	t.distributions = append(t.distributions, &internalpb.ShardDistribution{DmlChannel: channel, Digest: digest})
+}
+
+// nextCursor returns the cursor of the page after the results, nil if the results are the last page,
+// or the results are partial, whose following pages would miss the rows of the skipped segments
+func (t *queryTask) nextCursor() ([]byte, error) {
+	if !t.paginated || t.result.GetPartial() {
+		return nil, nil
+	}
+	pks := primaryKeysOf(t.result.GetFieldsData(), t.pkField)
+	if pks == nil {
+		return nil, nil
+	}
+	rows := typeutil.GetSizeOfIDs(pks)
+	if int64(rows) < t.request.GetLimit() {
+		return nil, nil
+	}
+	lastPK := &schemapb.IDs{}
+	typeutil.AppendIDs(lastPK, pks, rows-1)
+
+	t.distributionMu.Lock()
+	distributions := make([]*internalpb.ShardDistribution, len(t.distributions))
+	copy(distributions, t.distributions)
+	t.distributionMu.Unlock()
+	sort.Slice(distributions, func(i, j int) bool {
+		return distributions[i].GetDmlChannel() < distributions[j].GetDmlChannel()
+	})
+	return proto.Marshal(&internalpb.QueryCursor{
+		SnapshotTimestamp: t.result.GetSnapshotTimestamp(),
+		PlanHash:          t.planHash,
+		LastPk:            lastPK,
+		Distributions:     distributions,
+	})
+}
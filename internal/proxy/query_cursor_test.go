@@ -0,0 +1,365 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+// pagingQueryNode serves the sorted primary keys of a shard like a shard leader, resuming after the cursor
+type pagingQueryNode struct {
+	QueryNodeMock
+	pkFieldID int64
+	pks       []int64
+	digest    uint64
+	readTs    Timestamp
+}
+
+func (m *pagingQueryNode) Query(ctx context.Context, req *querypb.QueryRequest) (*internalpb.RetrieveResults, error) {
+	plan := &planpb.PlanNode{}
+	if err := proto.Unmarshal(req.GetReq().GetSerializedExprPlan(), plan); err != nil {
+		return nil, err
+	}
+	begin := 0
+	if cursor := req.GetReq().GetCursor(); cursor != nil {
+		valid := false
+		for _, distribution := range cursor.GetDistributions() {
+			if distribution.GetDmlChannel() == req.GetDmlChannel() && distribution.GetDigest() == m.digest {
+				valid = true
+			}
+		}
+		if !valid {
+			return &internalpb.RetrieveResults{
+				Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_CursorExpired, Reason: "distribution changed"},
+			}, nil
+		}
+		lastPK := cursor.GetLastPk().GetIntId().GetData()[0]
+		begin = sort.Search(len(m.pks), func(i int) bool { return m.pks[i] > lastPK })
+	}
+	end := begin + int(plan.GetLimit())
+	if end > len(m.pks) {
+		end = len(m.pks)
+	}
+	pks := m.pks[begin:end]
+	return &internalpb.RetrieveResults{
+		Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Ids:    &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}},
+		FieldsData: []*schemapb.FieldData{{
+			Type:    schemapb.DataType_Int64,
+			FieldId: m.pkFieldID,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: pks}},
+			}},
+		}},
+		ReadTimestamp:      m.readTs,
+		DistributionDigest: m.digest,
+	}, nil
+}
+
+func TestHashQueryPlan(t *testing.T) {
+	genPlan := func(value int64, desc bool, limit int64) *planpb.PlanNode {
+		return &planpb.PlanNode{
+			Node: &planpb.PlanNode_Predicates{Predicates: &planpb.Expr{
+				Expr: &planpb.Expr_UnaryRangeExpr{UnaryRangeExpr: &planpb.UnaryRangeExpr{
+					ColumnInfo: &planpb.ColumnInfo{FieldId: 100, DataType: schemapb.DataType_Int64},
+					Op:         planpb.OpType_GreaterThan,
+					Value:      &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: value}},
+				}},
+			}},
+			OutputFieldIds: []int64{100},
+			Limit:          limit,
+			OrderByFieldId: 100,
+			OrderDesc:      desc,
+		}
+	}
+
+	hash, err := hashQueryPlan(1, genPlan(0, false, 100), nil)
+	require.NoError(t, err)
+
+	// the page size could be changed between pages
+	other, err := hashQueryPlan(1, genPlan(0, false, 10), nil)
+	require.NoError(t, err)
+	assert.Equal(t, hash, other)
+
+	other, err = hashQueryPlan(1, genPlan(1, false, 100), nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+
+	other, err = hashQueryPlan(1, genPlan(0, true, 100), nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+
+	other, err = hashQueryPlan(2, genPlan(0, false, 100), nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+
+	other, err = hashQueryPlan(1, genPlan(0, false, 100), []byte("tenant"))
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+}
+
+func TestDecodeQueryCursor(t *testing.T) {
+	_, err := decodeQueryCursor([]byte("not a cursor"))
+	assert.Error(t, err)
+
+	data, err := proto.Marshal(&internalpb.QueryCursor{SnapshotTimestamp: 100, PlanHash: 1})
+	require.NoError(t, err)
+	_, err = decodeQueryCursor(data)
+	assert.Error(t, err)
+
+	data, err = proto.Marshal(&internalpb.QueryCursor{
+		SnapshotTimestamp: 100,
+		PlanHash:          1,
+		LastPk:            &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2}}}},
+	})
+	require.NoError(t, err)
+	_, err = decodeQueryCursor(data)
+	assert.Error(t, err)
+
+	data, err = proto.Marshal(&internalpb.QueryCursor{
+		SnapshotTimestamp: 100,
+		PlanHash:          1,
+		LastPk:            &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: []string{"a"}}}},
+		Distributions:     []*internalpb.ShardDistribution{{DmlChannel: "channel-1", Digest: 2}},
+	})
+	require.NoError(t, err)
+	cursor, err := decodeQueryCursor(data)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), cursor.GetSnapshotTimestamp())
+	assert.Equal(t, []string{"a"}, cursor.GetLastPk().GetStrId().GetData())
+	assert.Equal(t, uint64(2), cursor.GetDistributions()[0].GetDigest())
+}
+
+func TestIsCursorExpired(t *testing.T) {
+	assert.True(t, isCursorExpired(fmt.Errorf("%w, snapshot ts 1 is out of retention", errCursorExpired)))
+	assert.True(t, isCursorExpired(fmt.Errorf("query shard failed: %w", &shardLeaderError{
+		status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_CursorExpired},
+	})))
+	assert.False(t, isCursorExpired(&shardLeaderError{
+		status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError},
+	}))
+	assert.False(t, isCursorExpired(errors.New("query failed")))
+}
+
+func TestQueryTask_pagination(t *testing.T) {
+	Params.Init()
+
+	var (
+		ctx = context.TODO()
+
+		rc = NewRootCoordMock()
+		qc = NewQueryCoordMock(withValidShardLeaders())
+
+		collectionName = t.Name() + funcutil.GenRandomStr()
+		expr           = fmt.Sprintf("%s >= 0", testInt64Field)
+		rowNum         = 10000
+		pageSize       = 100
+	)
+
+	rc.Start()
+	defer rc.Stop()
+	qc.Start()
+	defer qc.Stop()
+
+	require.NoError(t, InitMetaCache(rc))
+
+	fieldName2Types := map[string]schemapb.DataType{
+		testInt64Field:    schemapb.DataType_Int64,
+		testFloatVecField: schemapb.DataType_FloatVector,
+	}
+	schema := constructCollectionSchemaByDataType(collectionName, fieldName2Types, testInt64Field, false)
+	marshaledSchema, err := proto.Marshal(schema)
+	require.NoError(t, err)
+
+	createColT := &createCollectionTask{
+		Condition: NewTaskCondition(ctx),
+		CreateCollectionRequest: &milvuspb.CreateCollectionRequest{
+			CollectionName: collectionName,
+			Schema:         marshaledSchema,
+			ShardsNum:      1,
+		},
+		ctx:       ctx,
+		rootCoord: rc,
+	}
+	require.NoError(t, createColT.OnEnqueue())
+	require.NoError(t, createColT.PreExecute(ctx))
+	require.NoError(t, createColT.Execute(ctx))
+	require.NoError(t, createColT.PostExecute(ctx))
+
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, collectionName)
+	require.NoError(t, err)
+	collSchema, err := globalMetaCache.GetCollectionSchema(ctx, collectionName)
+	require.NoError(t, err)
+	var pkFieldID int64
+	for _, field := range collSchema.GetFields() {
+		if field.GetIsPrimaryKey() {
+			pkFieldID = field.GetFieldID()
+		}
+	}
+
+	status, err := qc.LoadCollection(ctx, &querypb.LoadCollectionRequest{
+		Base: &commonpb.MsgBase{
+			MsgType:  commonpb.MsgType_LoadCollection,
+			SourceID: Params.ProxyCfg.ProxyID,
+		},
+		CollectionID: collectionID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, commonpb.ErrorCode_Success, status.ErrorCode)
+
+	qn := &pagingQueryNode{
+		pkFieldID: pkFieldID,
+		pks:       make([]int64, rowNum),
+		digest:    1,
+		readTs:    tsoutil.ComposeTSByTime(time.Now(), 0),
+	}
+	for i := range qn.pks {
+		qn.pks[i] = int64(i * 2)
+	}
+
+	query := func(expr string, cursor []byte) (*milvuspb.QueryResults, error) {
+		task := &queryTask{
+			Condition: NewTaskCondition(ctx),
+			RetrieveRequest: &internalpb.RetrieveRequest{
+				Base: &commonpb.MsgBase{
+					MsgType:   commonpb.MsgType_Retrieve,
+					SourceID:  Params.ProxyCfg.ProxyID,
+					Timestamp: tsoutil.ComposeTSByTime(time.Now(), 0),
+				},
+				CollectionID: collectionID,
+			},
+			ctx: ctx,
+			result: &milvuspb.QueryResults{
+				Status: &commonpb.Status{
+					ErrorCode: commonpb.ErrorCode_Success,
+				},
+			},
+			request: &milvuspb.QueryRequest{
+				Base: &commonpb.MsgBase{
+					MsgType:  commonpb.MsgType_Retrieve,
+					SourceID: Params.ProxyCfg.ProxyID,
+				},
+				CollectionName: collectionName,
+				Expr:           expr,
+				OutputFields:   []string{testInt64Field},
+				Limit:          int64(pageSize),
+				OrderByField:   testInt64Field,
+				Cursor:         cursor,
+			},
+			qc: qc,
+
+			getQueryNodePolicy: func(ctx context.Context, address string) (types.QueryNode, error) {
+				return qn, nil
+			},
+			queryShardPolicy: roundRobinPolicy,
+		}
+		if err := task.OnEnqueue(); err != nil {
+			return nil, err
+		}
+		if err := task.PreExecute(ctx); err != nil {
+			return nil, err
+		}
+		if err := task.Execute(ctx); err != nil {
+			return nil, err
+		}
+		if err := task.PostExecute(ctx); err != nil {
+			return nil, err
+		}
+		return task.result, nil
+	}
+
+	var (
+		cursor     []byte
+		snapshotTs Timestamp
+		pages      int
+		pks        []int64
+	)
+	for {
+		result, err := query(expr, cursor)
+		require.NoError(t, err)
+		if pages == 0 {
+			snapshotTs = result.GetSnapshotTimestamp()
+		}
+		// all the pages are read at the snapshot of the first page
+		assert.Equal(t, snapshotTs, result.GetSnapshotTimestamp())
+		for _, fieldData := range result.GetFieldsData() {
+			pks = append(pks, fieldData.GetScalars().GetLongData().GetData()...)
+		}
+		pages++
+		if result.GetCursor() == nil {
+			break
+		}
+		cursor = result.GetCursor()
+		require.Less(t, pages, rowNum)
+	}
+	assert.Equal(t, rowNum/pageSize+1, pages)
+	assert.Equal(t, qn.pks, pks)
+
+	t.Run("cursor of another query", func(t *testing.T) {
+		result, err := query(expr, nil)
+		require.NoError(t, err)
+		require.NotNil(t, result.GetCursor())
+
+		_, err = query(fmt.Sprintf("%s >= 10", testInt64Field), result.GetCursor())
+		assert.Error(t, err)
+		assert.False(t, isCursorExpired(err))
+	})
+
+	t.Run("snapshot out of retention", func(t *testing.T) {
+		result, err := query(expr, nil)
+		require.NoError(t, err)
+		cursor, err := decodeQueryCursor(result.GetCursor())
+		require.NoError(t, err)
+		retention := time.Duration(Params.CommonCfg.RetentionDuration) * time.Second
+		cursor.SnapshotTimestamp = tsoutil.ComposeTSByTime(time.Now().Add(-2*retention), 0)
+		data, err := proto.Marshal(cursor)
+		require.NoError(t, err)
+
+		_, err = query(expr, data)
+		assert.True(t, isCursorExpired(err))
+	})
+
+	t.Run("distribution changed", func(t *testing.T) {
+		result, err := query(expr, nil)
+		require.NoError(t, err)
+		require.NotNil(t, result.GetCursor())
+
+		// the segments read by the former page are released from the shard
+		qn.digest++
+		_, err = query(expr, result.GetCursor())
+		assert.True(t, isCursorExpired(err))
+	})
+}
@@ -37,8 +37,10 @@ type QueryNodeMock struct {
 
 	state atomic.Value // internal.StateCode
 
-	withSearchResult *internalpb.SearchResults
-	withQueryResult  *internalpb.RetrieveResults
+	withSearchResult         *internalpb.SearchResults
+	withQueryResult          *internalpb.RetrieveResults
+	withComponentStates      *internalpb.ComponentStates
+	withComponentStatesError error
 }
 
 func (m *QueryNodeMock) Search(ctx context.Context, req *querypb.SearchRequest) (*internalpb.SearchResults, error) {
@@ -64,6 +66,10 @@ func (m *QueryNodeMock) WatchDmChannels(ctx context.Context, req *querypb.WatchD
 	return nil, nil
 }
 
+func (m *QueryNodeMock) WatchDmChannelsWithStatus(ctx context.Context, req *querypb.WatchDmChannelsRequest) (*querypb.WatchDmChannelsResponse, error) {
+	return nil, nil
+}
+
 // TODO
 func (m *QueryNodeMock) WatchDeltaChannels(ctx context.Context, req *querypb.WatchDeltaChannelsRequest) (*commonpb.Status, error) {
 	return nil, nil
@@ -94,6 +100,38 @@ func (m *QueryNodeMock) GetSegmentInfo(ctx context.Context, req *querypb.GetSegm
 	return nil, nil
 }
 
+func (m *QueryNodeMock) SyncDistribution(ctx context.Context, req *querypb.SyncDistributionRequest) (*commonpb.Status, error) {
+	return nil, nil
+}
+
+func (m *QueryNodeMock) PauseChannel(ctx context.Context, req *querypb.PauseChannelRequest) (*commonpb.Status, error) {
+	return nil, nil
+}
+
+func (m *QueryNodeMock) ResumeChannel(ctx context.Context, req *querypb.ResumeChannelRequest) (*commonpb.Status, error) {
+	return nil, nil
+}
+
+func (m *QueryNodeMock) ExportSegmentDeletes(ctx context.Context, req *querypb.ExportSegmentDeletesRequest) (*querypb.ExportSegmentDeletesResponse, error) {
+	return nil, nil
+}
+
+func (m *QueryNodeMock) UpdateLoadConfig(ctx context.Context, req *querypb.UpdateLoadConfigRequest) (*querypb.UpdateLoadConfigResponse, error) {
+	return nil, nil
+}
+
+func (m *QueryNodeMock) GetDataDistribution(ctx context.Context, req *querypb.GetDataDistributionRequest) (*querypb.GetDataDistributionResponse, error) {
+	return nil, nil
+}
+
+func (m *QueryNodeMock) PromoteSegments(ctx context.Context, req *querypb.PromoteSegmentsRequest) (*commonpb.Status, error) {
+	return nil, nil
+}
+
+func (m *QueryNodeMock) RefreshIndex(ctx context.Context, req *querypb.RefreshIndexRequest) (*commonpb.Status, error) {
+	return nil, nil
+}
+
 // TODO
 func (m *QueryNodeMock) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return nil, nil
@@ -104,7 +142,7 @@ func (m *QueryNodeMock) Start() error    { return nil }
 func (m *QueryNodeMock) Stop() error     { return nil }
 func (m *QueryNodeMock) Register() error { return nil }
 func (m *QueryNodeMock) GetComponentStates(ctx context.Context) (*internalpb.ComponentStates, error) {
-	return nil, nil
+	return m.withComponentStates, m.withComponentStatesError
 }
 func (m *QueryNodeMock) GetStatisticsChannel(ctx context.Context) (*milvuspb.StringResponse, error) {
 	return nil, nil
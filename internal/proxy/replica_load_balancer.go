@@ -0,0 +1,401 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+)
+
+const (
+	// reported loads older than replicaLoadFreshRounds poll intervals are not trusted
+	replicaLoadFreshRounds = 3
+	// shard leaders not routed to for replicaLoadExpireRounds poll intervals are no longer polled
+	replicaLoadExpireRounds = 20
+	// shard leaders are taken as unhealthy for replicaFailureBackoff after a request routed to them failed
+	replicaFailureBackoff = 10 * time.Second
+)
+
+// replicaLoad is the read load reported by a QueryNode in its component states
+type replicaLoad struct {
+	queueLength int64
+	waitTime    time.Duration
+	updateTime  time.Time
+}
+
+type replicaLoadNode struct {
+	address  string
+	lastSeen time.Time
+	// client is the client polling the node, reused across polls until a poll fails
+	client types.QueryNode
+}
+
+// replicaLoadBalancer polls the read queue of shard leaders in background, and routes search and query
+// requests of a shard to the replica selected by the replica selection policy, the least loaded one by default.
+type replicaLoadBalancer struct {
+	getQueryNodePolicy getQueryNodePolicy
+	interval           time.Duration
+
+	mu       sync.RWMutex
+	nodes    map[UniqueID]*replicaLoadNode
+	loads    map[UniqueID]*replicaLoad
+	failures map[UniqueID]time.Time // time of the last failed request of unhealthy nodes
+	policy   string
+	selector ReplicaSelector
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newReplicaLoadBalancer(ctx context.Context, getQueryNodePolicy getQueryNodePolicy) *replicaLoadBalancer {
+	ctx1, cancel := context.WithCancel(ctx)
+	return &replicaLoadBalancer{
+		getQueryNodePolicy: getQueryNodePolicy,
+		nodes:              make(map[UniqueID]*replicaLoadNode),
+		loads:              make(map[UniqueID]*replicaLoad),
+		failures:           make(map[UniqueID]time.Time),
+		policy:             LeastQueueReplicaSelection,
+		selector:           &leastQueueSelector{},
+		ctx:                ctx1,
+		cancel:             cancel,
+	}
+}
+
+// start starts polling the shard leaders every interval, it does nothing if the interval is not positive
+func (b *replicaLoadBalancer) start(interval time.Duration) {
+	b.mu.Lock()
+	b.interval = interval
+	b.mu.Unlock()
+	if interval <= 0 {
+		log.Info("replica load polling is disabled, route requests by round robin")
+		return
+	}
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-b.ctx.Done():
+				log.Info("replica load polling loop exit")
+				return
+			case <-ticker.C:
+				b.poll()
+			}
+		}
+	}()
+}
+
+func (b *replicaLoadBalancer) close() {
+	b.cancel()
+	b.wg.Wait()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, node := range b.nodes {
+		stopReplicaLoadClient(node)
+	}
+}
+
+// watch registers the shard leaders to poll
+func (b *replicaLoadBalancer) watch(leaders *querypb.ShardLeadersList) {
+	now := time.Now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, nodeID := range leaders.GetNodeIds() {
+		if i >= len(leaders.GetNodeAddrs()) {
+			break
+		}
+		address := leaders.GetNodeAddrs()[i]
+		node, ok := b.nodes[nodeID]
+		if ok && node.address == address {
+			node.lastSeen = now
+			continue
+		}
+		if ok {
+			stopReplicaLoadClient(node)
+		}
+		b.nodes[nodeID] = &replicaLoadNode{address: address, lastSeen: now}
+	}
+}
+
+// stopReplicaLoadClient stops the cached client of node if any, it's called with mu held
+func stopReplicaLoadClient(node *replicaLoadNode) {
+	if node.client != nil {
+		node.client.Stop()
+		node.client = nil
+	}
+}
+
+// pollClient returns the cached client polling the node, a new client is created and cached if there is none
+func (b *replicaLoadBalancer) pollClient(ctx context.Context, nodeID UniqueID, address string) (types.QueryNode, error) {
+	b.mu.RLock()
+	node, ok := b.nodes[nodeID]
+	if ok && node.address == address && node.client != nil {
+		client := node.client
+		b.mu.RUnlock()
+		return client, nil
+	}
+	b.mu.RUnlock()
+
+	client, err := b.getQueryNodePolicy(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	node, ok = b.nodes[nodeID]
+	if !ok || node.address != address {
+		// the node is expired or moved while connecting
+		client.Stop()
+		return nil, fmt.Errorf("QueryNode %d at %s is no longer watched", nodeID, address)
+	}
+	if node.client != nil {
+		client.Stop()
+		return node.client, nil
+	}
+	node.client = client
+	return client, nil
+}
+
+// dropPollClient stops and uncaches client of the node, so that the next poll reconnects
+func (b *replicaLoadBalancer) dropPollClient(nodeID UniqueID, client types.QueryNode) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if node, ok := b.nodes[nodeID]; ok && node.client == client {
+		stopReplicaLoadClient(node)
+	}
+}
+
+// poll refreshes the loads of all watched shard leaders once
+func (b *replicaLoadBalancer) poll() {
+	now := time.Now()
+	nodes := make(map[UniqueID]string)
+	b.mu.Lock()
+	for nodeID, node := range b.nodes {
+		if now.Sub(node.lastSeen) > replicaLoadExpireRounds*b.interval {
+			stopReplicaLoadClient(node)
+			delete(b.nodes, nodeID)
+			delete(b.loads, nodeID)
+			delete(b.failures, nodeID)
+			continue
+		}
+		nodes[nodeID] = node.address
+	}
+	b.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for nodeID, address := range nodes {
+		wg.Add(1)
+		go func(nodeID UniqueID, address string) {
+			defer wg.Done()
+			load, err := b.getLoad(nodeID, address)
+			if err != nil {
+				log.Debug("failed to get read load of QueryNode", zap.Int64("nodeID", nodeID), zap.String("address", address), zap.Error(err))
+				return
+			}
+			b.setLoad(nodeID, load)
+		}(nodeID, address)
+	}
+	wg.Wait()
+}
+
+func (b *replicaLoadBalancer) getLoad(nodeID UniqueID, address string) (*replicaLoad, error) {
+	ctx, cancel := context.WithTimeout(b.ctx, b.interval)
+	defer cancel()
+	qn, err := b.pollClient(ctx, nodeID, address)
+	if err != nil {
+		return nil, err
+	}
+	states, err := qn.GetComponentStates(ctx)
+	if err != nil {
+		b.dropPollClient(nodeID, qn)
+		return nil, err
+	}
+	if states.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return nil, fmt.Errorf("failed to get component states of QueryNode %d, reason = %s", nodeID, states.GetStatus().GetReason())
+	}
+	return parseReplicaLoad(states.GetState().GetExtraInfo())
+}
+
+// parseReplicaLoad parses the read load from the extra info of QueryNode component states
+func parseReplicaLoad(extraInfo []*commonpb.KeyValuePair) (*replicaLoad, error) {
+	queueLengthStr, err := funcutil.GetAttrByKeyFromRepeatedKV(common.ReadQueueLengthKey, extraInfo)
+	if err != nil {
+		return nil, err
+	}
+	queueLength, err := strconv.ParseInt(queueLengthStr, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	waitMsStr, err := funcutil.GetAttrByKeyFromRepeatedKV(common.ReadQueueWaitMsKey, extraInfo)
+	if err != nil {
+		return nil, err
+	}
+	waitMs, err := strconv.ParseInt(waitMsStr, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &replicaLoad{
+		queueLength: queueLength,
+		waitTime:    time.Duration(waitMs) * time.Millisecond,
+		updateTime:  time.Now(),
+	}, nil
+}
+
+func (b *replicaLoadBalancer) setLoad(nodeID UniqueID, load *replicaLoad) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.loads[nodeID] = load
+}
+
+// freshLoad returns the load of node if it's refreshed recently
+func (b *replicaLoadBalancer) freshLoad(nodeID UniqueID) (*replicaLoad, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	load, ok := b.loads[nodeID]
+	if !ok || time.Since(load.updateTime) > replicaLoadFreshRounds*b.interval {
+		return nil, false
+	}
+	return load, true
+}
+
+// candidates returns the states of the shard leaders in original order
+func (b *replicaLoadBalancer) candidates(leaders *querypb.ShardLeadersList) []NodeInfo {
+	candidates := make([]NodeInfo, 0, len(leaders.GetNodeIds()))
+	for i, nodeID := range leaders.GetNodeIds() {
+		if i >= len(leaders.GetNodeAddrs()) {
+			break
+		}
+		candidate := NodeInfo{NodeID: nodeID, Address: leaders.GetNodeAddrs()[i], Healthy: b.healthy(nodeID)}
+		if load, ok := b.freshLoad(nodeID); ok {
+			candidate.LoadKnown = true
+			candidate.QueueLength = load.queueLength
+			candidate.QueueWaitTime = load.waitTime
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// setPolicy sets the policy to select the replica of a shard, the policy must be registered
+func (b *replicaLoadBalancer) setPolicy(policy string) error {
+	selector, err := getReplicaSelector(policy)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.policy = policy
+	b.selector = selector
+	return nil
+}
+
+// selectReplica selects the replica from candidates by the policy, the first candidate is selected
+// if the policy selects none of them
+func (b *replicaLoadBalancer) selectReplica(ctx context.Context, channel string, candidates []NodeInfo) (NodeInfo, string) {
+	b.mu.RLock()
+	policy, selector := b.policy, b.selector
+	b.mu.RUnlock()
+	selected := selector.Select(ctx, channel, candidates)
+	for _, candidate := range candidates {
+		if candidate.NodeID == selected.NodeID {
+			return candidate, policy
+		}
+	}
+	log.Warn("replica selection policy selected an unknown replica, fall back to the first one",
+		zap.String("policy", policy), zap.String("channel", channel), zap.Int64("nodeID", selected.NodeID))
+	return candidates[0], policy
+}
+
+// markFailure marks the node unhealthy for replicaFailureBackoff if err is not nil, healthy otherwise
+func (b *replicaLoadBalancer) markFailure(nodeID UniqueID, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		delete(b.failures, nodeID)
+		return
+	}
+	b.failures[nodeID] = time.Now()
+}
+
+func (b *replicaLoadBalancer) healthy(nodeID UniqueID) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	failedAt, ok := b.failures[nodeID]
+	return !ok || time.Since(failedAt) > replicaFailureBackoff
+}
+
+// pickShard is a pickShardPolicy which routes the request to the replica selected by the policy, and falls back to
+// the replicas selected from the remaining ones on failure
+func (b *replicaLoadBalancer) pickShard(ctx context.Context, getQueryNodePolicy getQueryNodePolicy, query func(UniqueID, types.QueryNode) error, leaders *querypb.ShardLeadersList) error {
+	b.watch(leaders)
+	candidates := b.candidates(leaders)
+	err := errInvalidShardLeaders
+	for len(candidates) > 0 {
+		selected, policy := b.selectReplica(ctx, leaders.GetChannelName(), candidates)
+		remaining := make([]NodeInfo, 0, len(candidates)-1)
+		for _, candidate := range candidates {
+			if candidate.NodeID != selected.NodeID {
+				remaining = append(remaining, candidate)
+			}
+		}
+		candidates = remaining
+
+		err = b.queryReplica(ctx, getQueryNodePolicy, query, selected)
+		if err == nil {
+			b.markFailure(selected.NodeID, nil)
+			metrics.ProxyReplicaSelections.WithLabelValues(strconv.FormatInt(Params.ProxyCfg.ProxyID, 10), policy, metrics.SuccessLabel).Inc()
+			return nil
+		}
+		metrics.ProxyReplicaSelections.WithLabelValues(strconv.FormatInt(Params.ProxyCfg.ProxyID, 10), policy, metrics.FailLabel).Inc()
+		// the request fails the same way on every replica, the replica answering it is not to blame
+		if !isRetriableShardError(err) {
+			b.markFailure(selected.NodeID, nil)
+			return err
+		}
+		b.markFailure(selected.NodeID, err)
+		log.Warn("fail to query with shard leader, retry with another replica",
+			zap.String("leader", leaders.GetChannelName()),
+			zap.Int64("nodeID", selected.NodeID),
+			zap.Error(err))
+	}
+	return fmt.Errorf("no shard leaders available for channel: %s, leaders: %v, err: %s", leaders.GetChannelName(), leaders.GetNodeIds(), err.Error())
+}
+
+func (b *replicaLoadBalancer) queryReplica(ctx context.Context, getQueryNodePolicy getQueryNodePolicy, query func(UniqueID, types.QueryNode) error, node NodeInfo) error {
+	qn, err := getQueryNodePolicy(ctx, node.Address)
+	if err != nil {
+		return err
+	}
+	defer qn.Stop()
+	return query(node.NodeID, qn)
+}
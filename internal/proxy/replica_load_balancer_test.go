@@ -0,0 +1,254 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+func genReadLoadStates(queueLength int64, waitMs int64) *internalpb.ComponentStates {
+	return &internalpb.ComponentStates{
+		Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		State: &internalpb.ComponentInfo{
+			StateCode: internalpb.StateCode_Healthy,
+			ExtraInfo: []*commonpb.KeyValuePair{
+				{Key: common.ReadQueueLengthKey, Value: strconv.FormatInt(queueLength, 10)},
+				{Key: common.ReadQueueWaitMsKey, Value: strconv.FormatInt(waitMs, 10)},
+			},
+		},
+	}
+}
+
+func TestReplicaLoadBalancer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := map[string]*QueryNodeMock{
+		"busy":    {nodeID: 1, address: "busy", withComponentStates: genReadLoadStates(100, 5000)},
+		"idle":    {nodeID: 2, address: "idle", withComponentStates: genReadLoadStates(1, 10)},
+		"unknown": {nodeID: 3, address: "unknown"},
+	}
+	getQueryNode := func(ctx context.Context, address string) (types.QueryNode, error) {
+		qn, ok := nodes[address]
+		if !ok {
+			return nil, fmt.Errorf("unknown address %s", address)
+		}
+		return qn, nil
+	}
+	leaders := &querypb.ShardLeadersList{
+		ChannelName: "channel-1",
+		NodeIds:     []int64{1, 2, 3},
+		NodeAddrs:   []string{"busy", "idle", "unknown"},
+	}
+
+	b := newReplicaLoadBalancer(ctx, getQueryNode)
+	defer b.close()
+	b.interval = time.Minute
+
+	routed := func() map[UniqueID]int {
+		counts := make(map[UniqueID]int)
+		for i := 0; i < 100; i++ {
+			err := b.pickShard(ctx, getQueryNode, func(nodeID UniqueID, qn types.QueryNode) error {
+				counts[nodeID]++
+				return nil
+			}, leaders)
+			assert.NoError(t, err)
+		}
+		return counts
+	}
+
+	// spread checks all the replicas get requests if they are equally loaded
+	spread := func(counts map[UniqueID]int) {
+		assert.Len(t, counts, len(leaders.GetNodeIds()))
+		for _, nodeID := range leaders.GetNodeIds() {
+			assert.Greater(t, counts[nodeID], 0)
+		}
+	}
+
+	t.Run("without reported loads", func(t *testing.T) {
+		spread(routed())
+	})
+
+	t.Run("prefer the least loaded replica", func(t *testing.T) {
+		b.poll()
+		_, ok := b.freshLoad(3)
+		assert.False(t, ok)
+
+		candidates := b.candidates(leaders)
+		assert.Equal(t, []NodeInfo{
+			{NodeID: 1, Address: "busy", Healthy: true, LoadKnown: true, QueueLength: 100, QueueWaitTime: 5 * time.Second},
+			{NodeID: 2, Address: "idle", Healthy: true, LoadKnown: true, QueueLength: 1, QueueWaitTime: 10 * time.Millisecond},
+			{NodeID: 3, Address: "unknown", Healthy: true},
+		}, candidates)
+		assert.Equal(t, map[UniqueID]int{2: 100}, routed())
+
+		// load changes are picked up by the next poll
+		nodes["busy"].withComponentStates = genReadLoadStates(0, 0)
+		b.poll()
+		assert.Equal(t, map[UniqueID]int{1: 100}, routed())
+		nodes["busy"].withComponentStates = genReadLoadStates(100, 5000)
+		b.poll()
+	})
+
+	t.Run("fall back to the next replica on failure", func(t *testing.T) {
+		var tried []UniqueID
+		err := b.pickShard(ctx, getQueryNode, func(nodeID UniqueID, qn types.QueryNode) error {
+			tried = append(tried, nodeID)
+			if nodeID == 2 {
+				return errors.New("mock error")
+			}
+			return nil
+		}, leaders)
+		assert.NoError(t, err)
+		assert.Equal(t, []UniqueID{2, 1}, tried)
+
+		// the failed replica is avoided until it recovers
+		assert.False(t, b.healthy(2))
+		assert.Equal(t, map[UniqueID]int{1: 100}, routed())
+		b.markFailure(2, nil)
+		assert.True(t, b.healthy(2))
+		assert.Equal(t, map[UniqueID]int{2: 100}, routed())
+	})
+
+	t.Run("invalid requests are not retried", func(t *testing.T) {
+		var tried []UniqueID
+		err := b.pickShard(ctx, getQueryNode, func(nodeID UniqueID, qn types.QueryNode) error {
+			tried = append(tried, nodeID)
+			return &shardLeaderError{op: "Search", nodeID: nodeID, status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_IllegalArgument,
+				Reason:    "invalid expr",
+			}}
+		}, leaders)
+		var leaderErr *shardLeaderError
+		assert.True(t, errors.As(err, &leaderErr))
+		assert.Equal(t, []UniqueID{2}, tried)
+		assert.True(t, b.healthy(2))
+	})
+
+	t.Run("all replicas fail", func(t *testing.T) {
+		err := b.pickShard(ctx, getQueryNode, func(nodeID UniqueID, qn types.QueryNode) error {
+			return errors.New("mock error")
+		}, leaders)
+		assert.Error(t, err)
+		for _, nodeID := range leaders.GetNodeIds() {
+			assert.False(t, b.healthy(nodeID))
+			b.markFailure(nodeID, nil)
+		}
+
+		err = b.pickShard(ctx, getQueryNode, func(nodeID UniqueID, qn types.QueryNode) error {
+			return nil
+		}, &querypb.ShardLeadersList{ChannelName: "channel-1"})
+		assert.Error(t, err)
+	})
+
+	t.Run("stale loads are ignored", func(t *testing.T) {
+		b.mu.Lock()
+		for _, load := range b.loads {
+			load.updateTime = time.Now().Add(-time.Hour)
+		}
+		b.mu.Unlock()
+		spread(routed())
+	})
+
+	t.Run("poll clients are reused", func(t *testing.T) {
+		connects := 0
+		getQueryNode := func(ctx context.Context, address string) (types.QueryNode, error) {
+			connects++
+			qn, ok := nodes[address]
+			if !ok {
+				return nil, fmt.Errorf("unknown address %s", address)
+			}
+			return qn, nil
+		}
+		b := newReplicaLoadBalancer(ctx, getQueryNode)
+		b.interval = time.Minute
+		b.watch(leaders)
+		b.poll()
+		b.poll()
+		assert.Equal(t, len(leaders.GetNodeIds()), connects)
+
+		// the client is dropped once a poll fails, and the next poll reconnects
+		nodes["idle"].withComponentStatesError = errors.New("mock error")
+		b.poll()
+		b.mu.RLock()
+		assert.Nil(t, b.nodes[2].client)
+		assert.NotNil(t, b.nodes[1].client)
+		b.mu.RUnlock()
+		nodes["idle"].withComponentStatesError = nil
+		b.poll()
+		assert.Equal(t, len(leaders.GetNodeIds())+1, connects)
+
+		// the client is dropped if the leader moves
+		b.watch(&querypb.ShardLeadersList{ChannelName: "channel-1", NodeIds: []int64{1}, NodeAddrs: []string{"idle"}})
+		b.mu.RLock()
+		assert.Nil(t, b.nodes[1].client)
+		b.mu.RUnlock()
+
+		b.close()
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+		for _, node := range b.nodes {
+			assert.Nil(t, node.client)
+		}
+	})
+
+	t.Run("expired nodes are no longer polled", func(t *testing.T) {
+		b.mu.Lock()
+		b.nodes[1].lastSeen = time.Now().Add(-time.Hour * 24)
+		b.mu.Unlock()
+		b.poll()
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+		assert.NotContains(t, b.nodes, UniqueID(1))
+		assert.NotContains(t, b.loads, UniqueID(1))
+		assert.Contains(t, b.loads, UniqueID(2))
+	})
+}
+
+func TestParseReplicaLoad(t *testing.T) {
+	load, err := parseReplicaLoad(genReadLoadStates(3, 20).GetState().GetExtraInfo())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), load.queueLength)
+	assert.Equal(t, 20*time.Millisecond, load.waitTime)
+
+	_, err = parseReplicaLoad(nil)
+	assert.Error(t, err)
+
+	_, err = parseReplicaLoad([]*commonpb.KeyValuePair{
+		{Key: common.ReadQueueLengthKey, Value: "x"},
+		{Key: common.ReadQueueWaitMsKey, Value: "1"},
+	})
+	assert.Error(t, err)
+
+	_, err = parseReplicaLoad([]*commonpb.KeyValuePair{
+		{Key: common.ReadQueueLengthKey, Value: "1"},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	// RoundRobinReplicaSelection routes the requests of a shard to its healthy replicas in turn
+	RoundRobinReplicaSelection = "round_robin"
+	// LeastQueueReplicaSelection routes the requests of a shard to the healthy replica with the shortest read queue
+	LeastQueueReplicaSelection = "least_queue"
+)
+
+// NodeInfo is the state of a shard leader replica known by proxy
+type NodeInfo struct {
+	NodeID  int64
+	Address string
+
+	// Healthy is false if the last request routed to the replica failed recently
+	Healthy bool
+
+	// LoadKnown is true if the read queue of the replica is polled recently, QueueLength and QueueWaitTime are valid then
+	LoadKnown     bool
+	QueueLength   int64
+	QueueWaitTime time.Duration
+}
+
+// ReplicaSelector selects the shard leader replica to route a search or query request to. Select is called with
+// the remaining candidates again if the request failed on the selected replica, the candidates are never empty.
+// Selectors must be safe for concurrent use
+type ReplicaSelector interface {
+	Select(ctx context.Context, channel string, candidates []NodeInfo) NodeInfo
+}
+
+var replicaSelectors = struct {
+	sync.RWMutex
+	selectors map[string]ReplicaSelector
+}{
+	selectors: map[string]ReplicaSelector{
+		RoundRobinReplicaSelection: newRoundRobinSelector(),
+		LeastQueueReplicaSelection: &leastQueueSelector{},
+	},
+}
+
+// RegisterReplicaSelector registers a custom replica selection policy by name, which is used if proxy.replicaSelection.policy
+// is configured as the name. It must be called before proxy starts, and the names of registered policies could not be reused
+func RegisterReplicaSelector(name string, selector ReplicaSelector) error {
+	if name == "" {
+		return errors.New("empty replica selection policy name")
+	}
+	if selector == nil {
+		return fmt.Errorf("nil replica selector of policy %s", name)
+	}
+	replicaSelectors.Lock()
+	defer replicaSelectors.Unlock()
+	if _, ok := replicaSelectors.selectors[name]; ok {
+		return fmt.Errorf("replica selection policy %s is already registered", name)
+	}
+	replicaSelectors.selectors[name] = selector
+	return nil
+}
+
+func getReplicaSelector(name string) (ReplicaSelector, error) {
+	replicaSelectors.RLock()
+	defer replicaSelectors.RUnlock()
+	selector, ok := replicaSelectors.selectors[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown replica selection policy %s", name)
+	}
+	return selector, nil
+}
+
+// healthyNodes returns the healthy candidates, or all the candidates if none is healthy
+func healthyNodes(candidates []NodeInfo) []NodeInfo {
+	healthy := make([]NodeInfo, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.Healthy {
+			healthy = append(healthy, candidate)
+		}
+	}
+	if len(healthy) == 0 {
+		return candidates
+	}
+	return healthy
+}
+
+// roundRobinSelector selects the healthy replicas of each channel in turn
+type roundRobinSelector struct {
+	mu    sync.Mutex
+	turns map[string]uint64
+}
+
+func newRoundRobinSelector() *roundRobinSelector {
+	return &roundRobinSelector{turns: make(map[string]uint64)}
+}
+
+func (s *roundRobinSelector) Select(ctx context.Context, channel string, candidates []NodeInfo) NodeInfo {
+	healthy := healthyNodes(candidates)
+	s.mu.Lock()
+	turn := s.turns[channel]
+	s.turns[channel] = turn + 1
+	s.mu.Unlock()
+	return healthy[turn%uint64(len(healthy))]
+}
+
+// leastQueueSelector selects the healthy replica with the shortest read queue, then the shortest wait time.
+// Replicas of unknown loads are selected only if no load is known. The ties are broken randomly, so that
+// the requests are spread over the equally loaded replicas instead of piling up on the first one
+type leastQueueSelector struct{}
+
+func (s *leastQueueSelector) Select(ctx context.Context, channel string, candidates []NodeInfo) NodeInfo {
+	healthy := healthyNodes(candidates)
+	less := func(ni, nj NodeInfo) bool {
+		if !ni.LoadKnown || !nj.LoadKnown {
+			return ni.LoadKnown && !nj.LoadKnown
+		}
+		if ni.QueueLength != nj.QueueLength {
+			return ni.QueueLength < nj.QueueLength
+		}
+		return ni.QueueWaitTime < nj.QueueWaitTime
+	}
+	best := make([]NodeInfo, 0, len(healthy))
+	for _, candidate := range healthy {
+		switch {
+		case len(best) == 0 || less(candidate, best[0]):
+			best = append(best[:0], candidate)
+		case !less(best[0], candidate):
+			best = append(best, candidate)
+		}
+	}
+	return best[rand.Intn(len(best))]
+}
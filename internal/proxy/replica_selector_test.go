@@ -0,0 +1,240 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+func TestRoundRobinSelector(t *testing.T) {
+	ctx := context.Background()
+	s := newRoundRobinSelector()
+	candidates := []NodeInfo{
+		{NodeID: 1, Healthy: true},
+		{NodeID: 2, Healthy: false},
+		{NodeID: 3, Healthy: true, LoadKnown: true, QueueLength: 100},
+	}
+
+	t.Run("healthy replicas in turn", func(t *testing.T) {
+		var selected []int64
+		for i := 0; i < 4; i++ {
+			selected = append(selected, s.Select(ctx, "channel-1", candidates).NodeID)
+		}
+		assert.Equal(t, []int64{1, 3, 1, 3}, selected)
+		// turns are kept per channel
+		assert.Equal(t, int64(1), s.Select(ctx, "channel-2", candidates).NodeID)
+	})
+
+	t.Run("no healthy replicas", func(t *testing.T) {
+		unhealthy := []NodeInfo{{NodeID: 1}, {NodeID: 2}}
+		var selected []int64
+		for i := 0; i < 4; i++ {
+			selected = append(selected, s.Select(ctx, "channel-3", unhealthy).NodeID)
+		}
+		assert.Equal(t, []int64{1, 2, 1, 2}, selected)
+	})
+}
+
+func TestLeastQueueSelector(t *testing.T) {
+	ctx := context.Background()
+	s := &leastQueueSelector{}
+
+	cases := []struct {
+		name       string
+		candidates []NodeInfo
+		expected   int64
+	}{
+		{
+			name: "shortest queue",
+			candidates: []NodeInfo{
+				{NodeID: 1, Healthy: true, LoadKnown: true, QueueLength: 10},
+				{NodeID: 2, Healthy: true, LoadKnown: true, QueueLength: 1},
+				{NodeID: 3, Healthy: true},
+			},
+			expected: 2,
+		},
+		{
+			name: "shortest wait time of the same queue length",
+			candidates: []NodeInfo{
+				{NodeID: 1, Healthy: true, LoadKnown: true, QueueLength: 1, QueueWaitTime: time.Second},
+				{NodeID: 2, Healthy: true, LoadKnown: true, QueueLength: 1, QueueWaitTime: time.Millisecond},
+			},
+			expected: 2,
+		},
+		{
+			name: "skip unhealthy replicas",
+			candidates: []NodeInfo{
+				{NodeID: 1, Healthy: false, LoadKnown: true},
+				{NodeID: 2, Healthy: true, LoadKnown: true, QueueLength: 100},
+			},
+			expected: 2,
+		},
+		{
+			name: "no healthy replicas",
+			candidates: []NodeInfo{
+				{NodeID: 1, LoadKnown: true, QueueLength: 100},
+				{NodeID: 2, LoadKnown: true, QueueLength: 1},
+			},
+			expected: 2,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, s.Select(ctx, "channel-1", c.candidates).NodeID)
+		})
+	}
+
+	t.Run("ties are broken randomly", func(t *testing.T) {
+		tiedCases := [][]NodeInfo{
+			{
+				{NodeID: 1, Healthy: true},
+				{NodeID: 2, Healthy: true},
+				{NodeID: 3, Healthy: true},
+			},
+			{
+				{NodeID: 1, Healthy: true, LoadKnown: true, QueueLength: 1, QueueWaitTime: time.Millisecond},
+				{NodeID: 2, Healthy: true, LoadKnown: true, QueueLength: 1, QueueWaitTime: time.Millisecond},
+				{NodeID: 3, Healthy: true, LoadKnown: true, QueueLength: 1, QueueWaitTime: time.Millisecond},
+				{NodeID: 4, Healthy: true, LoadKnown: true, QueueLength: 2},
+				{NodeID: 5, Healthy: true},
+			},
+		}
+		for _, candidates := range tiedCases {
+			selected := make(map[int64]int)
+			for i := 0; i < 300; i++ {
+				selected[s.Select(ctx, "channel-1", candidates).NodeID]++
+			}
+			assert.Len(t, selected, 3)
+			for _, nodeID := range []int64{1, 2, 3} {
+				assert.Greater(t, selected[nodeID], 0)
+			}
+		}
+	})
+}
+
+type mockReplicaSelector struct {
+	selectFunc func(channel string, candidates []NodeInfo) NodeInfo
+}
+
+func (s *mockReplicaSelector) Select(ctx context.Context, channel string, candidates []NodeInfo) NodeInfo {
+	return s.selectFunc(channel, candidates)
+}
+
+func TestRegisterReplicaSelector(t *testing.T) {
+	selector := &mockReplicaSelector{}
+	assert.NoError(t, RegisterReplicaSelector("mock_register", selector))
+	registered, err := getReplicaSelector("mock_register")
+	assert.NoError(t, err)
+	assert.Equal(t, selector, registered)
+
+	assert.Error(t, RegisterReplicaSelector("mock_register", selector))
+	assert.Error(t, RegisterReplicaSelector(RoundRobinReplicaSelection, selector))
+	assert.Error(t, RegisterReplicaSelector("", selector))
+	assert.Error(t, RegisterReplicaSelector("mock_nil", nil))
+
+	_, err = getReplicaSelector("mock_unknown")
+	assert.Error(t, err)
+}
+
+func TestReplicaLoadBalancer_policy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	getQueryNode := func(ctx context.Context, address string) (types.QueryNode, error) {
+		return &QueryNodeMock{address: address}, nil
+	}
+	leaders := &querypb.ShardLeadersList{
+		ChannelName: "channel-1",
+		NodeIds:     []int64{1, 2, 3},
+		NodeAddrs:   []string{"addr-1", "addr-2", "addr-3"},
+	}
+	b := newReplicaLoadBalancer(ctx, getQueryNode)
+	defer b.close()
+	assert.Error(t, b.setPolicy("mock_unknown"))
+
+	routed := func(query func(UniqueID, types.QueryNode) error) []UniqueID {
+		var nodeIDs []UniqueID
+		for i := 0; i < 4; i++ {
+			err := b.pickShard(ctx, getQueryNode, func(nodeID UniqueID, qn types.QueryNode) error {
+				nodeIDs = append(nodeIDs, nodeID)
+				return query(nodeID, qn)
+			}, leaders)
+			assert.NoError(t, err)
+		}
+		return nodeIDs
+	}
+	succeed := func(UniqueID, types.QueryNode) error { return nil }
+
+	t.Run("round robin", func(t *testing.T) {
+		assert.NoError(t, b.setPolicy(RoundRobinReplicaSelection))
+		assert.Equal(t, []UniqueID{1, 2, 3, 1}, routed(succeed))
+	})
+
+	t.Run("custom policy", func(t *testing.T) {
+		var channels []string
+		assert.NoError(t, RegisterReplicaSelector("mock_last", &mockReplicaSelector{
+			selectFunc: func(channel string, candidates []NodeInfo) NodeInfo {
+				channels = append(channels, channel)
+				healthy := healthyNodes(candidates)
+				return healthy[len(healthy)-1]
+			},
+		}))
+		assert.NoError(t, b.setPolicy("mock_last"))
+		assert.Equal(t, []UniqueID{3, 3, 3, 3}, routed(succeed))
+		assert.Equal(t, []string{"channel-1", "channel-1", "channel-1", "channel-1"}, channels)
+	})
+
+	t.Run("fall back on unknown selection", func(t *testing.T) {
+		assert.NoError(t, RegisterReplicaSelector("mock_invalid", &mockReplicaSelector{
+			selectFunc: func(channel string, candidates []NodeInfo) NodeInfo {
+				return NodeInfo{NodeID: 100}
+			},
+		}))
+		assert.NoError(t, b.setPolicy("mock_invalid"))
+		assert.Equal(t, []UniqueID{1, 1, 1, 1}, routed(succeed))
+	})
+
+	t.Run("observe selection outcomes", func(t *testing.T) {
+		assert.NoError(t, b.setPolicy("mock_last"))
+		nodeID := strconv.FormatInt(Params.ProxyCfg.ProxyID, 10)
+		success := metrics.ProxyReplicaSelections.WithLabelValues(nodeID, "mock_last", metrics.SuccessLabel)
+		fail := metrics.ProxyReplicaSelections.WithLabelValues(nodeID, "mock_last", metrics.FailLabel)
+		successBefore, failBefore := testutil.ToFloat64(success), testutil.ToFloat64(fail)
+
+		// the selected replica 3 fails and is retried on replica 2
+		nodeIDs := routed(func(nodeID UniqueID, qn types.QueryNode) error {
+			if nodeID == 3 {
+				return errors.New("mock error")
+			}
+			return nil
+		})
+		assert.Equal(t, []UniqueID{3, 2, 2, 2, 2}, nodeIDs)
+		assert.Equal(t, float64(4), testutil.ToFloat64(success)-successBefore)
+		assert.Equal(t, float64(1), testutil.ToFloat64(fail)-failBefore)
+	})
+}
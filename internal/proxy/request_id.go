@@ -0,0 +1,55 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus/internal/util"
+)
+
+// requestIDFromContext returns the request id supplied by the client in the metadata of ctx, or generates one
+func requestIDFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(util.HeaderRequestID); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+	return genRequestID()
+}
+
+// genRequestID generates a random request id of 32 hex digits
+func genRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
+// setRequestIDHeader returns the request id to the client in the header of the response,
+// nothing is set if ctx is not of a grpc call
+func setRequestIDHeader(ctx context.Context, requestID string) {
+	_ = grpc.SetHeader(ctx, metadata.Pairs(util.HeaderRequestID, requestID))
+}
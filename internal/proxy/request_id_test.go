@@ -0,0 +1,48 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus/internal/util"
+)
+
+func TestRequestIDFromContext(t *testing.T) {
+	t.Run("supplied by client", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(util.HeaderRequestID, "client-request"))
+		assert.Equal(t, "client-request", requestIDFromContext(ctx))
+	})
+
+	t.Run("generated", func(t *testing.T) {
+		id1 := requestIDFromContext(context.Background())
+		id2 := requestIDFromContext(metadata.NewIncomingContext(context.Background(), metadata.Pairs(util.HeaderRequestID, "")))
+		assert.NotEmpty(t, id1)
+		assert.NotEmpty(t, id2)
+		assert.NotEqual(t, id1, id2)
+	})
+
+	t.Run("not grpc", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			setRequestIDHeader(context.Background(), "request")
+		})
+	})
+}
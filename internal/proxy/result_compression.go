@@ -0,0 +1,54 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"strconv"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/compressor"
+	"github.com/milvus-io/milvus/internal/util/timerecord"
+)
+
+// decompressSearchResults decompresses the search results received from query node in place before reduce
+func decompressSearchResults(result *internalpb.SearchResults) error {
+	if result.GetSlicedBlobCompressType() == "" {
+		return nil
+	}
+	tr := timerecord.NewTimeRecorder("decompressSearchResults")
+	if err := compressor.DecompressSearchResults(result); err != nil {
+		return err
+	}
+	metrics.ProxyResultDecompressLatency.WithLabelValues(strconv.FormatInt(Params.ProxyCfg.ProxyID, 10),
+		metrics.SearchLabel).Observe(float64(tr.ElapseSpan().Milliseconds()))
+	return nil
+}
+
+// decompressRetrieveResults decompresses the retrieve results received from query node in place before merge
+func decompressRetrieveResults(result *internalpb.RetrieveResults) error {
+	if result.GetCompressType() == "" {
+		return nil
+	}
+	tr := timerecord.NewTimeRecorder("decompressRetrieveResults")
+	if err := compressor.DecompressRetrieveResults(result); err != nil {
+		return err
+	}
+	metrics.ProxyResultDecompressLatency.WithLabelValues(strconv.FormatInt(Params.ProxyCfg.ProxyID, 10),
+		metrics.QueryLabel).Observe(float64(tr.ElapseSpan().Milliseconds()))
+	return nil
+}
@@ -0,0 +1,68 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/compressor"
+)
+
+func TestDecompressSearchResults(t *testing.T) {
+	blob := []byte(strings.Repeat("search result", 1000))
+
+	// uncompressed results are left as they are
+	result := &internalpb.SearchResults{SlicedBlob: blob}
+	require.NoError(t, decompressSearchResults(result))
+	assert.Equal(t, blob, result.GetSlicedBlob())
+
+	compressed, err := compressor.CompressSearchResults(result, compressor.CompressTypeZstd, 0)
+	require.NoError(t, err)
+	require.True(t, compressed)
+	require.NoError(t, decompressSearchResults(result))
+	assert.Equal(t, blob, result.GetSlicedBlob())
+	assert.Empty(t, result.GetSlicedBlobCompressType())
+
+	result = &internalpb.SearchResults{SlicedBlob: blob, SlicedBlobCompressType: string(compressor.CompressTypeSnappy)}
+	assert.Error(t, decompressSearchResults(result))
+}
+
+func TestDecompressRetrieveResults(t *testing.T) {
+	ids := make([]int64, 1000)
+	result := &internalpb.RetrieveResults{
+		Ids: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids}}},
+	}
+	require.NoError(t, decompressRetrieveResults(result))
+	assert.Equal(t, ids, result.GetIds().GetIntId().GetData())
+
+	compressed, err := compressor.CompressRetrieveResults(result, compressor.CompressTypeSnappy, 0)
+	require.NoError(t, err)
+	require.True(t, compressed)
+	assert.Nil(t, result.GetIds())
+	require.NoError(t, decompressRetrieveResults(result))
+	assert.Equal(t, ids, result.GetIds().GetIntId().GetData())
+	assert.Empty(t, result.GetCompressType())
+
+	result = &internalpb.RetrieveResults{CompressedBlob: []byte("corrupted"), CompressType: string(compressor.CompressTypeZstd)}
+	assert.Error(t, decompressRetrieveResults(result))
+}
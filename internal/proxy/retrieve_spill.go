@@ -0,0 +1,700 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// retrieveResultCollector collects the retrieve results of query nodes to merge as they arrive. Results are kept
+// in memory until their total size exceeds budget, from then on the collected results and every later one are
+// spilled to local files sorted by primary key, and the merged result is produced by a k-way merge of the files.
+// The collector is safe to add results concurrently.
+type retrieveResultCollector struct {
+	budget int64 // 0 disables spilling
+	dir    string
+
+	mu       sync.Mutex
+	memSize  int64
+	results  []*internalpb.RetrieveResults
+	spills   []*retrieveSpillFile
+	fieldNum int // number of fields of the first non-empty result, -1 if no result is checked yet
+}
+
+func newRetrieveResultCollector(budget int64, dir string) *retrieveResultCollector {
+	return &retrieveResultCollector{
+		budget:   budget,
+		dir:      dir,
+		results:  make([]*internalpb.RetrieveResults, 0),
+		spills:   make([]*retrieveSpillFile, 0),
+		fieldNum: -1,
+	}
+}
+
+// add collects result, spilling the collected results to files if their size exceeds the budget
+func (c *retrieveResultCollector) add(result *internalpb.RetrieveResults) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.budget <= 0 {
+		c.results = append(c.results, result)
+		return nil
+	}
+	// skip empty result like mergeRetrieveResults
+	if isEmptyRetrieveResult(result) {
+		return nil
+	}
+	if c.fieldNum < 0 {
+		c.fieldNum = len(result.FieldsData)
+	}
+	if c.fieldNum != len(result.FieldsData) {
+		return fmt.Errorf("mismatch FieldData in proxy RetrieveResults, expect %d get %d", c.fieldNum, len(result.FieldsData))
+	}
+	if len(c.spills) > 0 {
+		return c.spill(result)
+	}
+
+	c.results = append(c.results, result)
+	c.memSize += int64(proto.Size(result))
+	if c.memSize <= c.budget {
+		return nil
+	}
+
+	log.Info("query results exceed memory budget, spill to disk",
+		zap.Int64("size", c.memSize),
+		zap.Int64("budget", c.budget),
+		zap.Int("resultNum", len(c.results)),
+		zap.String("dir", c.dir))
+	results := c.results
+	c.results = nil
+	c.memSize = 0
+	for i, result := range results {
+		if err := c.spill(result); err != nil {
+			// keep the results not spilled yet, the spilled ones are released
+			c.results = results[i:]
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *retrieveResultCollector) spill(result *internalpb.RetrieveResults) error {
+	spill, err := writeRetrieveSpillFile(c.dir, result)
+	if err != nil {
+		return err
+	}
+	c.spills = append(c.spills, spill)
+	return nil
+}
+
+// spilled returns whether the results are spilled to files
+func (c *retrieveResultCollector) spilled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.spills) > 0
+}
+
+// merge merges the collected results and removes the duplicated primary keys, the first collected one wins.
+// The results in memory are merged by mergeRetrieveResults in the order they are collected, only the spilled
+// results are merged by a k-way merge of the files in the order of primary keys.
+func (c *retrieveResultCollector) merge(ctx context.Context) (*milvuspb.QueryResults, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.spills) == 0 {
+		return mergeRetrieveResults(c.results)
+	}
+	readers := make([]retrieveRowReader, 0, len(c.results)+len(c.spills))
+	for _, result := range c.results {
+		readers = append(readers, newRetrieveMemoryReader(result))
+	}
+	for _, spill := range c.spills {
+		reader, err := openRetrieveSpillReader(spill)
+		if err != nil {
+			for _, reader := range readers {
+				reader.close()
+			}
+			return nil, err
+		}
+		readers = append(readers, reader)
+	}
+	return mergeRetrieveRows(ctx, readers, c.fieldNum)
+}
+
+// close removes the spill files
+func (c *retrieveResultCollector) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, spill := range c.spills {
+		if err := os.Remove(spill.path); err != nil && !os.IsNotExist(err) {
+			log.Warn("failed to remove query result spill file", zap.String("path", spill.path), zap.Error(err))
+		}
+	}
+	c.spills = nil
+	c.results = nil
+}
+
+func isEmptyRetrieveResult(result *internalpb.RetrieveResults) bool {
+	return result == nil || result.Ids == nil || typeutil.GetSizeOfIDs(result.Ids) == 0
+}
+
+// sortRowsByPK returns the row offsets of ids in the order of primary keys, the stable sort keeps the first one
+// of the duplicated primary keys first
+func sortRowsByPK(ids *schemapb.IDs) []int64 {
+	order := make([]int64, typeutil.GetSizeOfIDs(ids))
+	for i := range order {
+		order[i] = int64(i)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return pkLess(typeutil.GetPK(ids, order[i]), typeutil.GetPK(ids, order[j]))
+	})
+	return order
+}
+
+// retrieveSpillFile is a retrieve result spilled to a local file, rows are sorted by primary key, and each row
+// is encoded as the primary key followed by the values of the fields in the compact binary format:
+//
+//	int64 primary key, int32, int64: varint
+//	string primary key, string: uvarint length followed by the bytes
+//	bool: 1 byte
+//	float, double: little endian IEEE 754 bits
+//	float vector: dim little endian float bits, binary vector: dim/8 bytes
+type retrieveSpillFile struct {
+	path     string
+	rowCount int64
+	intPK    bool
+	// fields holds the layout of the fields without data
+	fields []*schemapb.FieldData
+}
+
+func writeRetrieveSpillFile(dir string, result *internalpb.RetrieveResults) (spill *retrieveSpillFile, err error) {
+	spill = &retrieveSpillFile{
+		rowCount: int64(typeutil.GetSizeOfIDs(result.Ids)),
+		fields:   make([]*schemapb.FieldData, 0, len(result.FieldsData)),
+	}
+	switch result.Ids.GetIdField().(type) {
+	case *schemapb.IDs_IntId:
+		spill.intPK = true
+	case *schemapb.IDs_StrId:
+		spill.intPK = false
+	default:
+		return nil, fmt.Errorf("unsupported primary key type of query result")
+	}
+	for _, fieldData := range result.FieldsData {
+		layout, err := newSpillRow(fieldData)
+		if err != nil {
+			return nil, err
+		}
+		spill.fields = append(spill.fields, layout)
+	}
+
+	order := sortRowsByPK(result.Ids)
+
+	file, err := ioutil.TempFile(dir, "query-result-spill-")
+	if err != nil {
+		return nil, err
+	}
+	spill.path = file.Name()
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(spill.path)
+		}
+	}()
+
+	w := newSpillWriter(file)
+	for _, idx := range order {
+		w.writePK(typeutil.GetPK(result.Ids, idx))
+		for _, fieldData := range result.FieldsData {
+			w.writeField(fieldData, idx)
+		}
+	}
+	if err = w.flush(); err != nil {
+		return nil, err
+	}
+	if err = file.Close(); err != nil {
+		return nil, err
+	}
+	return spill, nil
+}
+
+// newSpillRow returns a field data of one row with the layout of fieldData
+func newSpillRow(fieldData *schemapb.FieldData) (*schemapb.FieldData, error) {
+	row := &schemapb.FieldData{
+		Type:      fieldData.Type,
+		FieldName: fieldData.FieldName,
+		FieldId:   fieldData.FieldId,
+	}
+	switch field := fieldData.Field.(type) {
+	case *schemapb.FieldData_Scalars:
+		scalars := &schemapb.ScalarField{}
+		switch field.Scalars.Data.(type) {
+		case *schemapb.ScalarField_BoolData:
+			scalars.Data = &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: make([]bool, 1)}}
+		case *schemapb.ScalarField_IntData:
+			scalars.Data = &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: make([]int32, 1)}}
+		case *schemapb.ScalarField_LongData:
+			scalars.Data = &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: make([]int64, 1)}}
+		case *schemapb.ScalarField_FloatData:
+			scalars.Data = &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: make([]float32, 1)}}
+		case *schemapb.ScalarField_DoubleData:
+			scalars.Data = &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: make([]float64, 1)}}
+		case *schemapb.ScalarField_StringData:
+			scalars.Data = &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: make([]string, 1)}}
+		default:
+			return nil, fmt.Errorf("unsupported type %s of field %d to spill", fieldData.Type.String(), fieldData.FieldId)
+		}
+		row.Field = &schemapb.FieldData_Scalars{Scalars: scalars}
+	case *schemapb.FieldData_Vectors:
+		dim := field.Vectors.Dim
+		vectors := &schemapb.VectorField{Dim: dim}
+		switch field.Vectors.Data.(type) {
+		case *schemapb.VectorField_FloatVector:
+			vectors.Data = &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: make([]float32, dim)}}
+		case *schemapb.VectorField_BinaryVector:
+			vectors.Data = &schemapb.VectorField_BinaryVector{BinaryVector: make([]byte, dim/8)}
+		default:
+			return nil, fmt.Errorf("unsupported type %s of field %d to spill", fieldData.Type.String(), fieldData.FieldId)
+		}
+		row.Field = &schemapb.FieldData_Vectors{Vectors: vectors}
+	default:
+		return nil, fmt.Errorf("unsupported type %s of field %d to spill", fieldData.Type.String(), fieldData.FieldId)
+	}
+	return row, nil
+}
+
+func pkLess(a, b interface{}) bool {
+	switch pk := a.(type) {
+	case int64:
+		return pk < b.(int64)
+	case string:
+		return pk < b.(string)
+	default:
+		return false
+	}
+}
+
+type spillWriter struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+}
+
+func newSpillWriter(w io.Writer) *spillWriter {
+	return &spillWriter{w: bufio.NewWriter(w)}
+}
+
+// the errors of bufio.Writer are sticky, so they are checked once on flush
+func (w *spillWriter) writeVarint(v int64) {
+	n := binary.PutVarint(w.buf[:], v)
+	w.w.Write(w.buf[:n])
+}
+
+func (w *spillWriter) writeString(v string) {
+	n := binary.PutUvarint(w.buf[:], uint64(len(v)))
+	w.w.Write(w.buf[:n])
+	w.w.WriteString(v)
+}
+
+func (w *spillWriter) writeUint32(v uint32) {
+	binary.LittleEndian.PutUint32(w.buf[:4], v)
+	w.w.Write(w.buf[:4])
+}
+
+func (w *spillWriter) writeUint64(v uint64) {
+	binary.LittleEndian.PutUint64(w.buf[:8], v)
+	w.w.Write(w.buf[:8])
+}
+
+func (w *spillWriter) writePK(pk interface{}) {
+	switch v := pk.(type) {
+	case int64:
+		w.writeVarint(v)
+	case string:
+		w.writeString(v)
+	}
+}
+
+func (w *spillWriter) writeField(fieldData *schemapb.FieldData, idx int64) {
+	switch field := fieldData.Field.(type) {
+	case *schemapb.FieldData_Scalars:
+		switch data := field.Scalars.Data.(type) {
+		case *schemapb.ScalarField_BoolData:
+			if data.BoolData.Data[idx] {
+				w.w.WriteByte(1)
+			} else {
+				w.w.WriteByte(0)
+			}
+		case *schemapb.ScalarField_IntData:
+			w.writeVarint(int64(data.IntData.Data[idx]))
+		case *schemapb.ScalarField_LongData:
+			w.writeVarint(data.LongData.Data[idx])
+		case *schemapb.ScalarField_FloatData:
+			w.writeUint32(math.Float32bits(data.FloatData.Data[idx]))
+		case *schemapb.ScalarField_DoubleData:
+			w.writeUint64(math.Float64bits(data.DoubleData.Data[idx]))
+		case *schemapb.ScalarField_StringData:
+			w.writeString(data.StringData.Data[idx])
+		}
+	case *schemapb.FieldData_Vectors:
+		dim := field.Vectors.Dim
+		switch data := field.Vectors.Data.(type) {
+		case *schemapb.VectorField_FloatVector:
+			for _, v := range data.FloatVector.Data[idx*dim : (idx+1)*dim] {
+				w.writeUint32(math.Float32bits(v))
+			}
+		case *schemapb.VectorField_BinaryVector:
+			w.w.Write(data.BinaryVector[idx*dim/8 : (idx+1)*dim/8])
+		}
+	}
+}
+
+func (w *spillWriter) flush() error {
+	return w.w.Flush()
+}
+
+// retrieveRowReader reads the rows of a retrieve result one by one in the order of primary keys
+type retrieveRowReader interface {
+	// next moves to the next row, it returns false if all rows are read
+	next() (bool, error)
+	// pk returns the primary key of the current row
+	pk() interface{}
+	// appendRow appends the current row to fieldsData
+	appendRow(fieldsData []*schemapb.FieldData)
+	close()
+}
+
+// retrieveMemoryReader reads the rows of a retrieve result in memory
+type retrieveMemoryReader struct {
+	result *internalpb.RetrieveResults
+	order  []int64
+	pos    int
+}
+
+func newRetrieveMemoryReader(result *internalpb.RetrieveResults) *retrieveMemoryReader {
+	return &retrieveMemoryReader{
+		result: result,
+		order:  sortRowsByPK(result.Ids),
+		pos:    -1,
+	}
+}
+
+func (r *retrieveMemoryReader) next() (bool, error) {
+	if r.pos+1 >= len(r.order) {
+		return false, nil
+	}
+	r.pos++
+	return true, nil
+}
+
+func (r *retrieveMemoryReader) pk() interface{} {
+	return typeutil.GetPK(r.result.Ids, r.order[r.pos])
+}
+
+func (r *retrieveMemoryReader) appendRow(fieldsData []*schemapb.FieldData) {
+	typeutil.AppendFieldData(fieldsData, r.result.FieldsData, r.order[r.pos])
+}
+
+func (r *retrieveMemoryReader) close() {
+	r.result = nil
+}
+
+// retrieveSpillReader reads the rows of a spill file, the file is removed once closed
+type retrieveSpillReader struct {
+	spill     *retrieveSpillFile
+	file      *os.File
+	r         *bufio.Reader
+	remaining int64
+	buf       [8]byte
+
+	// the current row
+	curPK interface{}
+	row   []*schemapb.FieldData
+}
+
+func openRetrieveSpillReader(spill *retrieveSpillFile) (*retrieveSpillReader, error) {
+	file, err := os.Open(spill.path)
+	if err != nil {
+		return nil, err
+	}
+	reader := &retrieveSpillReader{
+		spill:     spill,
+		file:      file,
+		r:         bufio.NewReader(file),
+		remaining: spill.rowCount,
+		row:       make([]*schemapb.FieldData, 0, len(spill.fields)),
+	}
+	for _, layout := range spill.fields {
+		row, err := newSpillRow(layout)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		reader.row = append(reader.row, row)
+	}
+	return reader, nil
+}
+
+func (r *retrieveSpillReader) next() (bool, error) {
+	if r.remaining <= 0 {
+		return false, nil
+	}
+	r.remaining--
+
+	var err error
+	if r.spill.intPK {
+		r.curPK, err = binary.ReadVarint(r.r)
+	} else {
+		r.curPK, err = r.readString()
+	}
+	if err != nil {
+		return false, r.wrapError(err)
+	}
+	for _, fieldData := range r.row {
+		if err := r.readField(fieldData); err != nil {
+			return false, r.wrapError(err)
+		}
+	}
+	return true, nil
+}
+
+func (r *retrieveSpillReader) pk() interface{} {
+	return r.curPK
+}
+
+func (r *retrieveSpillReader) appendRow(fieldsData []*schemapb.FieldData) {
+	typeutil.AppendFieldData(fieldsData, r.row, 0)
+}
+
+func (r *retrieveSpillReader) wrapError(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("failed to read query result spill file %s: %w", r.spill.path, err)
+}
+
+func (r *retrieveSpillReader) readString() (string, error) {
+	length, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return "", err
+	}
+	bs := make([]byte, length)
+	if _, err := io.ReadFull(r.r, bs); err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+func (r *retrieveSpillReader) readUint32() (uint32, error) {
+	if _, err := io.ReadFull(r.r, r.buf[:4]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(r.buf[:4]), nil
+}
+
+func (r *retrieveSpillReader) readUint64() (uint64, error) {
+	if _, err := io.ReadFull(r.r, r.buf[:8]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(r.buf[:8]), nil
+}
+
+func (r *retrieveSpillReader) readField(fieldData *schemapb.FieldData) error {
+	switch field := fieldData.Field.(type) {
+	case *schemapb.FieldData_Scalars:
+		switch data := field.Scalars.Data.(type) {
+		case *schemapb.ScalarField_BoolData:
+			b, err := r.r.ReadByte()
+			if err != nil {
+				return err
+			}
+			data.BoolData.Data[0] = b != 0
+		case *schemapb.ScalarField_IntData:
+			v, err := binary.ReadVarint(r.r)
+			if err != nil {
+				return err
+			}
+			data.IntData.Data[0] = int32(v)
+		case *schemapb.ScalarField_LongData:
+			v, err := binary.ReadVarint(r.r)
+			if err != nil {
+				return err
+			}
+			data.LongData.Data[0] = v
+		case *schemapb.ScalarField_FloatData:
+			v, err := r.readUint32()
+			if err != nil {
+				return err
+			}
+			data.FloatData.Data[0] = math.Float32frombits(v)
+		case *schemapb.ScalarField_DoubleData:
+			v, err := r.readUint64()
+			if err != nil {
+				return err
+			}
+			data.DoubleData.Data[0] = math.Float64frombits(v)
+		case *schemapb.ScalarField_StringData:
+			v, err := r.readString()
+			if err != nil {
+				return err
+			}
+			data.StringData.Data[0] = v
+		}
+	case *schemapb.FieldData_Vectors:
+		switch data := field.Vectors.Data.(type) {
+		case *schemapb.VectorField_FloatVector:
+			for i := range data.FloatVector.Data {
+				v, err := r.readUint32()
+				if err != nil {
+					return err
+				}
+				data.FloatVector.Data[i] = math.Float32frombits(v)
+			}
+		case *schemapb.VectorField_BinaryVector:
+			if _, err := io.ReadFull(r.r, data.BinaryVector); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *retrieveSpillReader) close() {
+	if r.file == nil {
+		return
+	}
+	r.file.Close()
+	r.file = nil
+	// release the disk space as early as possible
+	if err := os.Remove(r.spill.path); err != nil && !os.IsNotExist(err) {
+		log.Warn("failed to remove query result spill file", zap.String("path", r.spill.path), zap.Error(err))
+	}
+}
+
+// retrieveMergeCursor is a reader in the k-way merge, index is the order the result was collected in
+type retrieveMergeCursor struct {
+	reader retrieveRowReader
+	index  int
+}
+
+// retrieveMergeHeap is a min heap of cursors ordered by the primary keys of their current rows, and then by the
+// order the results were collected, so that the first collected one of the duplicated primary keys pops first
+type retrieveMergeHeap []*retrieveMergeCursor
+
+func (h retrieveMergeHeap) Len() int { return len(h) }
+func (h retrieveMergeHeap) Less(i, j int) bool {
+	pki, pkj := h[i].reader.pk(), h[j].reader.pk()
+	if pkLess(pki, pkj) {
+		return true
+	}
+	if pkLess(pkj, pki) {
+		return false
+	}
+	return h[i].index < h[j].index
+}
+func (h retrieveMergeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *retrieveMergeHeap) Push(x interface{}) { *h = append(*h, x.(*retrieveMergeCursor)) }
+func (h *retrieveMergeHeap) Pop() interface{} {
+	old := *h
+	cursor := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return cursor
+}
+
+// mergeRetrieveRows merges the rows of readers by a k-way merge of primary keys, removing the duplicates. The rows
+// are streamed into the merged result one by one, only the current row of each reader is held besides the result,
+// and every reader is closed once exhausted. fieldNum is the number of fields of the rows.
+func mergeRetrieveRows(ctx context.Context, readers []retrieveRowReader, fieldNum int) (*milvuspb.QueryResults, error) {
+	defer func() {
+		for _, reader := range readers {
+			reader.close()
+		}
+	}()
+	h := make(retrieveMergeHeap, 0, len(readers))
+	for i, reader := range readers {
+		ok, err := reader.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			reader.close()
+			continue
+		}
+		h = append(h, &retrieveMergeCursor{reader: reader, index: i})
+	}
+	heap.Init(&h)
+
+	if h.Len() == 0 {
+		return &milvuspb.QueryResults{
+			FieldsData: []*schemapb.FieldData{},
+		}, nil
+	}
+	ret := &milvuspb.QueryResults{
+		FieldsData: make([]*schemapb.FieldData, fieldNum),
+	}
+	var lastPK interface{}
+	var skipDupCnt int64
+	for rowNum := 0; h.Len() > 0; rowNum++ {
+		// check the cancellation once in a while, reading from disk could take long
+		if rowNum%4096 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		cursor := h[0]
+		pk := cursor.reader.pk()
+		if lastPK != nil && pk == lastPK {
+			// primary keys duplicate
+			skipDupCnt++
+		} else {
+			cursor.reader.appendRow(ret.FieldsData)
+			lastPK = pk
+		}
+
+		ok, err := cursor.reader.next()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+			cursor.reader.close()
+		}
+	}
+	log.Debug("skip duplicated query result", zap.Int64("count", skipDupCnt), zap.Int("resultNum", len(readers)))
+	return ret, nil
+}
@@ -0,0 +1,371 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"io/ioutil"
+	"math/rand"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+const spillTestDim = 16
+
+// genSpillTestResult generates a retrieve result of the given primary keys with fields of all spillable types,
+// the values of the fields are derived from the primary key and the node, so duplicates are distinguishable
+func genSpillTestResult(pks []int64, nodeID int64, strPK bool) *internalpb.RetrieveResults {
+	n := len(pks)
+	ids := &schemapb.IDs{}
+	if strPK {
+		strIDs := make([]string, 0, n)
+		for _, pk := range pks {
+			strIDs = append(strIDs, strconv.FormatInt(pk, 10))
+		}
+		ids.IdField = &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: strIDs}}
+	} else {
+		ids.IdField = &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}
+	}
+
+	bools := make([]bool, 0, n)
+	ints := make([]int32, 0, n)
+	longs := make([]int64, 0, n)
+	floats := make([]float32, 0, n)
+	doubles := make([]float64, 0, n)
+	strs := make([]string, 0, n)
+	floatVectors := make([]float32, 0, n*spillTestDim)
+	binaryVectors := make([]byte, 0, n*spillTestDim/8)
+	for _, pk := range pks {
+		bools = append(bools, pk%2 == 0)
+		ints = append(ints, int32(-pk))
+		longs = append(longs, pk*1000+nodeID)
+		floats = append(floats, float32(pk)+0.5)
+		doubles = append(doubles, float64(pk)/3)
+		strs = append(strs, "row-"+strconv.FormatInt(pk, 10)+"-node-"+strconv.FormatInt(nodeID, 10))
+		for i := 0; i < spillTestDim; i++ {
+			floatVectors = append(floatVectors, float32(pk)*float32(i))
+		}
+		for i := 0; i < spillTestDim/8; i++ {
+			binaryVectors = append(binaryVectors, byte(pk+int64(i)))
+		}
+	}
+	scalar := func(fieldID int64, dataType schemapb.DataType, data *schemapb.ScalarField) *schemapb.FieldData {
+		return &schemapb.FieldData{
+			Type:      dataType,
+			FieldName: "field" + strconv.FormatInt(fieldID, 10),
+			FieldId:   fieldID,
+			Field:     &schemapb.FieldData_Scalars{Scalars: data},
+		}
+	}
+	return &internalpb.RetrieveResults{
+		Ids: ids,
+		FieldsData: []*schemapb.FieldData{
+			scalar(100, schemapb.DataType_Int64, &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: longs}}}),
+			scalar(101, schemapb.DataType_Bool, &schemapb.ScalarField{Data: &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: bools}}}),
+			scalar(102, schemapb.DataType_Int32, &schemapb.ScalarField{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: ints}}}),
+			scalar(103, schemapb.DataType_Float, &schemapb.ScalarField{Data: &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: floats}}}),
+			scalar(104, schemapb.DataType_Double, &schemapb.ScalarField{Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: doubles}}}),
+			scalar(105, schemapb.DataType_VarChar, &schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: strs}}}),
+			{
+				Type:      schemapb.DataType_FloatVector,
+				FieldName: "field106",
+				FieldId:   106,
+				Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+					Dim:  spillTestDim,
+					Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: floatVectors}},
+				}},
+			},
+			{
+				Type:      schemapb.DataType_BinaryVector,
+				FieldName: "field107",
+				FieldId:   107,
+				Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+					Dim:  spillTestDim,
+					Data: &schemapb.VectorField_BinaryVector{BinaryVector: binaryVectors},
+				}},
+			},
+		},
+	}
+}
+
+// genSpillTestResults generates the results of nodeNum nodes, with overlapping and unordered primary keys
+func genSpillTestResults(nodeNum int, rowNum int, strPK bool) []*internalpb.RetrieveResults {
+	results := make([]*internalpb.RetrieveResults, 0, nodeNum+1)
+	for nodeID := 0; nodeID < nodeNum; nodeID++ {
+		pks := make([]int64, 0, rowNum)
+		for i := 0; i < rowNum; i++ {
+			pks = append(pks, rand.Int63n(int64(nodeNum*rowNum/2)))
+		}
+		results = append(results, genSpillTestResult(pks, int64(nodeID), strPK))
+	}
+	// empty results are skipped
+	results = append(results, &internalpb.RetrieveResults{})
+	return results
+}
+
+// sortQueryResultsByPK returns the rows of result sorted by the primary keys, which are derived from field 100
+func sortQueryResultsByPK(result *milvuspb.QueryResults, strPK bool) *milvuspb.QueryResults {
+	longs := result.FieldsData[0].GetScalars().GetLongData().GetData()
+	order := make([]int64, len(longs))
+	for i := range order {
+		order[i] = int64(i)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		pki, pkj := longs[order[i]]/1000, longs[order[j]]/1000
+		if strPK {
+			return strconv.FormatInt(pki, 10) < strconv.FormatInt(pkj, 10)
+		}
+		return pki < pkj
+	})
+	sorted := &milvuspb.QueryResults{FieldsData: make([]*schemapb.FieldData, len(result.FieldsData))}
+	for _, idx := range order {
+		typeutil.AppendFieldData(sorted.FieldsData, result.FieldsData, idx)
+	}
+	return sorted
+}
+
+func collectAndMerge(ctx context.Context, results []*internalpb.RetrieveResults, budget int64, dir string) (*milvuspb.QueryResults, bool, error) {
+	collector := newRetrieveResultCollector(budget, dir)
+	defer collector.close()
+	for _, result := range results {
+		if err := collector.add(result); err != nil {
+			return nil, collector.spilled(), err
+		}
+	}
+	merged, err := collector.merge(ctx)
+	return merged, collector.spilled(), err
+}
+
+func TestRetrieveResultCollector_spillVersusInMemory(t *testing.T) {
+	ctx := context.Background()
+	for _, strPK := range []bool{false, true} {
+		results := genSpillTestResults(20, 500, strPK)
+		expected, err := mergeRetrieveResults(results)
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		for _, budget := range []int64{1, int64(proto.Size(results[0])) * 5} {
+			merged, spilled, err := collectAndMerge(ctx, results, budget, dir)
+			require.NoError(t, err)
+			assert.True(t, spilled)
+			assert.True(t, proto.Equal(sortQueryResultsByPK(expected, strPK), merged), "strPK %v, budget %d", strPK, budget)
+
+			// the spill files are removed
+			files, err := ioutil.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Empty(t, files)
+		}
+
+		// under budget the results are merged in memory as without spilling
+		merged, spilled, err := collectAndMerge(ctx, results, 1<<40, dir)
+		require.NoError(t, err)
+		assert.False(t, spilled)
+		assert.True(t, proto.Equal(expected, merged), "strPK %v", strPK)
+
+		// no spilling without budget
+		collector := newRetrieveResultCollector(0, dir)
+		for _, result := range results {
+			require.NoError(t, collector.add(result))
+		}
+		merged, err = collector.merge(ctx)
+		require.NoError(t, err)
+		assert.False(t, collector.spilled())
+		assert.True(t, proto.Equal(expected, merged))
+		collector.close()
+	}
+}
+
+func TestRetrieveResultCollector_spillOnArrival(t *testing.T) {
+	dir := t.TempDir()
+	results := genSpillTestResults(3, 100, false)
+	collector := newRetrieveResultCollector(int64(proto.Size(results[0]))*3/2, dir)
+	defer collector.close()
+
+	// the results are spilled as soon as they exceed the budget, before all results arrive
+	require.NoError(t, collector.add(results[0]))
+	assert.False(t, collector.spilled())
+	require.NoError(t, collector.add(results[1]))
+	assert.True(t, collector.spilled())
+	assert.Empty(t, collector.results)
+	assert.Equal(t, 2, len(collector.spills))
+	require.NoError(t, collector.add(results[2]))
+	assert.Equal(t, 3, len(collector.spills))
+
+	// the spill files are removed once merged
+	_, err := collector.merge(context.Background())
+	require.NoError(t, err)
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestRetrieveResultCollector_concurrentAdd(t *testing.T) {
+	// the shards return disjoint primary keys, so the merged result doesn't depend on the arrival order
+	results := make([]*internalpb.RetrieveResults, 0, 10)
+	for nodeID := int64(0); nodeID < 10; nodeID++ {
+		pks := make([]int64, 0, 100)
+		for i := int64(0); i < 100; i++ {
+			pks = append(pks, i*10+nodeID)
+		}
+		results = append(results, genSpillTestResult(pks, nodeID, false))
+	}
+	expected, _, err := collectAndMerge(context.Background(), results, 1, t.TempDir())
+	require.NoError(t, err)
+
+	collector := newRetrieveResultCollector(1, t.TempDir())
+	defer collector.close()
+	var wg sync.WaitGroup
+	for _, result := range results {
+		wg.Add(1)
+		go func(result *internalpb.RetrieveResults) {
+			defer wg.Done()
+			assert.NoError(t, collector.add(result))
+		}(result)
+	}
+	wg.Wait()
+	merged, err := collector.merge(context.Background())
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(expected, merged))
+}
+
+func TestRetrieveResultCollector_duplicatedPKs(t *testing.T) {
+	// the first collected row of the duplicated primary keys wins, in and across results
+	results := []*internalpb.RetrieveResults{
+		genSpillTestResult([]int64{3, 1, 3}, 1, false),
+		genSpillTestResult([]int64{2, 1}, 2, false),
+		genSpillTestResult([]int64{2, 4}, 3, false),
+	}
+	merged, spilled, err := collectAndMerge(context.Background(), results, 1, t.TempDir())
+	require.NoError(t, err)
+	assert.True(t, spilled)
+	assert.Equal(t, []int64{1001, 2002, 3001, 4003}, merged.FieldsData[0].GetScalars().GetLongData().GetData())
+}
+
+func TestRetrieveResultCollector_inMemory(t *testing.T) {
+	// under budget the rows are kept in the order they are collected instead of the order of primary keys,
+	// and the first collected row of the duplicated primary keys wins
+	results := []*internalpb.RetrieveResults{
+		genSpillTestResult([]int64{3, 1, 3}, 1, false),
+		genSpillTestResult([]int64{2, 1}, 2, false),
+		genSpillTestResult([]int64{2, 4}, 3, false),
+	}
+	dir := t.TempDir()
+	collector := newRetrieveResultCollector(1<<40, dir)
+	defer collector.close()
+	for _, result := range results {
+		require.NoError(t, collector.add(result))
+	}
+	assert.False(t, collector.spilled())
+	assert.Equal(t, len(results), len(collector.results))
+	merged, err := collector.merge(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3001, 1001, 2002, 4003}, merged.FieldsData[0].GetScalars().GetLongData().GetData())
+
+	// nothing is written to disk
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestRetrieveResultCollector_empty(t *testing.T) {
+	results := []*internalpb.RetrieveResults{
+		genSpillTestResult([]int64{1}, 1, false),
+		nil,
+		{},
+	}
+	collector := newRetrieveResultCollector(1, t.TempDir())
+	defer collector.close()
+	// only empty results are collected after spilling
+	require.NoError(t, collector.add(results[0]))
+	results[0].Ids = nil
+	require.NoError(t, collector.add(results[0]))
+	for _, result := range results[1:] {
+		require.NoError(t, collector.add(result))
+	}
+	assert.Equal(t, 1, len(collector.spills))
+	merged, err := collector.merge(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 8, len(merged.FieldsData))
+}
+
+func TestRetrieveResultCollector_errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("mismatch fields", func(t *testing.T) {
+		results := []*internalpb.RetrieveResults{
+			genSpillTestResult([]int64{1, 2}, 1, false),
+			genSpillTestResult([]int64{3, 4}, 2, false),
+		}
+		results[1].FieldsData = results[1].FieldsData[:2]
+		dir := t.TempDir()
+		_, _, err := collectAndMerge(ctx, results, 1, dir)
+		assert.Error(t, err)
+		files, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+
+	t.Run("invalid dir", func(t *testing.T) {
+		results := []*internalpb.RetrieveResults{genSpillTestResult([]int64{1, 2}, 1, false)}
+		_, _, err := collectAndMerge(ctx, results, 1, path.Join(t.TempDir(), "not-exist"))
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported field", func(t *testing.T) {
+		results := []*internalpb.RetrieveResults{genSpillTestResult([]int64{1, 2}, 1, false)}
+		results[0].FieldsData[1].GetScalars().Data = nil
+		dir := t.TempDir()
+		_, _, err := collectAndMerge(ctx, results, 1, dir)
+		assert.Error(t, err)
+		files, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+
+	t.Run("truncated file", func(t *testing.T) {
+		collector := newRetrieveResultCollector(1, t.TempDir())
+		defer collector.close()
+		require.NoError(t, collector.add(genSpillTestResult([]int64{1, 2}, 1, false)))
+		require.NoError(t, ioutil.WriteFile(collector.spills[0].path, []byte{2}, 0600))
+		_, err := collector.merge(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		results := genSpillTestResults(2, 10, false)
+		dir := t.TempDir()
+		_, _, err := collectAndMerge(ctx, results, 1, dir)
+		assert.ErrorIs(t, err, context.Canceled)
+		files, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
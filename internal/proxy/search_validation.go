@@ -0,0 +1,46 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/searchvalidation"
+)
+
+// validateSearchRequest checks the search request against the schema of the collection with the validation shared
+// with the query nodes, and returns the IDs of the output fields. The meta cache knows nothing about the indexes,
+// the index metric types are checked by the query nodes.
+func validateSearchRequest(collectionID UniqueID, schema *schemapb.CollectionSchema, annsField string,
+	queryInfo *planpb.QueryInfo, placeholderGroup []byte, outputFields []string) ([]int64, error) {
+	spec, err := searchvalidation.NewCollectionSearchSpec(collectionID, schema)
+	if err != nil {
+		return nil, err
+	}
+	field, err := spec.FieldByName(annsField)
+	if err != nil {
+		return nil, err
+	}
+	outputFieldIDs, err := searchvalidation.ResolveOutputFields(spec, outputFields)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := searchvalidation.ValidateSearchRequest(spec, field.FieldID, queryInfo, placeholderGroup, outputFieldIDs); err != nil {
+		return nil, err
+	}
+	return outputFieldIDs, nil
+}
@@ -0,0 +1,41 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/util/searchvalidation"
+)
+
+func TestValidateSearchRequest_Conformance(t *testing.T) {
+	schema := searchvalidation.ConformanceSchema()
+	for _, c := range searchvalidation.ConformanceCases() {
+		t.Run(c.Name, func(t *testing.T) {
+			outputFieldIDs, err := validateSearchRequest(searchvalidation.ConformanceCollectionID, schema, c.AnnsField,
+				c.QueryInfo, c.PlaceholderGroup, c.OutputFields)
+			if c.Valid {
+				assert.NoError(t, err)
+				assert.Len(t, outputFieldIDs, len(c.OutputFields))
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
@@ -32,6 +33,7 @@ import (
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/parser/planparser"
 	"github.com/milvus-io/milvus/internal/types"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -62,6 +64,10 @@ const (
 	MetricTypeKey                   = "metric_type"
 	SearchParamsKey                 = "params"
 	RoundDecimalKey                 = "round_decimal"
+	NormalizeScoresKey              = "normalize_scores"
+	OutputTimestampsKey             = "output_timestamps"
+	PartitionWeightsKey             = "partition_weights"
+	MaxScannedSegmentsKey           = "max_scanned_segments"
 	HasCollectionTaskName           = "HasCollectionTask"
 	DescribeCollectionTaskName      = "DescribeCollectionTask"
 	GetCollectionStatisticsTaskName = "GetCollectionStatisticsTask"
@@ -129,6 +135,10 @@ type insertTask struct {
 	vChannels      []vChan
 	pChannels      []pChan
 	schema         *schemapb.CollectionSchema
+
+	// rowPartitionIDs are the partitions of the rows routed to by partition key, nil if there is no partition key
+	rowPartitionIDs []UniqueID
+	partitionNames  map[UniqueID]string
 }
 
 // TraceCtx returns insertTask context
@@ -223,7 +233,6 @@ func (it *insertTask) checkLengthOfFieldsData() error {
 
 func (it *insertTask) checkPrimaryFieldData() error {
 	rowNums := uint32(it.NRows())
-	// TODO(dragondriver): in fact, NumRows is not trustable, we should check all input fields
 	if it.NRows() <= 0 {
 		return errNumRowsLessThanOrEqualToZero(rowNums)
 	}
@@ -300,6 +309,20 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 	}
 	it.schema = collSchema
 
+	// the rows of the collection with partition key are routed to the partitions by their keys
+	if keyField := typeutil.GetPartitionKeyFieldSchema(collSchema); keyField != nil &&
+		partitionTag != "" && partitionTag != Params.CommonCfg.DefaultPartitionName {
+		err := fmt.Errorf("not support manually specifying the partition names if partition key %s is used", keyField.GetName())
+		log.Error("valid partition name failed", zap.String("partition name", partitionTag), zap.Error(err))
+		return err
+	}
+
+	// check the columns against the schema before any of them is processed
+	if err := validateInsertFieldsData(collSchema, it.GetFieldsData(), it.NRows()); err != nil {
+		log.Error("invalid insert data", zap.Int64("msgID", it.Base.MsgID), zap.String("collection name", collectionName), zap.Error(err))
+		return err
+	}
+
 	rowNums := uint32(it.NRows())
 	// set insertTask.rowIDs
 	var rowIDBegin UniqueID
@@ -353,6 +376,50 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 	return nil
 }
 
+// routePartitionKeys routes the rows to the partitions by the hash of their partition keys, if there is partition key
+func (it *insertTask) routePartitionKeys(ctx context.Context) error {
+	it.rowPartitionIDs, it.partitionNames = nil, nil
+	keyField := typeutil.GetPartitionKeyFieldSchema(it.schema)
+	if keyField == nil {
+		return nil
+	}
+	numPartitions, err := typeutil.GetNumPartitions(keyField)
+	if err != nil {
+		return err
+	}
+	partitionIDs, partitionNames, err := getPartitionKeyPartitions(ctx, it.CollectionName, numPartitions)
+	if err != nil {
+		return err
+	}
+	var keys *schemapb.FieldData
+	for _, fieldData := range it.GetFieldsData() {
+		if fieldData.GetFieldId() == keyField.GetFieldID() {
+			keys = fieldData
+			break
+		}
+	}
+	if keys == nil {
+		return fmt.Errorf("partition key %s is not in the insert data", keyField.GetName())
+	}
+	rowPartitionIDs, err := routePartitionKeys(keys, partitionIDs)
+	if err != nil {
+		return err
+	}
+	if uint64(len(rowPartitionIDs)) != it.NRows() {
+		return fmt.Errorf("the number of partition keys %d mismatches the number of rows %d", len(rowPartitionIDs), it.NRows())
+	}
+	it.rowPartitionIDs, it.partitionNames = rowPartitionIDs, partitionNames
+	return nil
+}
+
+// partitionOf returns the partition the row at offset is inserted into
+func (it *insertTask) partitionOf(offset int) UniqueID {
+	if it.rowPartitionIDs == nil {
+		return it.PartitionID
+	}
+	return it.rowPartitionIDs[offset]
+}
+
 func (it *insertTask) assignSegmentID(channelNames []string) (*msgstream.MsgPack, error) {
 	threshold := Params.PulsarCfg.MaxMessageSize
 
@@ -366,29 +433,31 @@ func (it *insertTask) assignSegmentID(channelNames []string) (*msgstream.MsgPack
 		log.Warn("the hashvalues passed through client is not supported now, and will be overwritten")
 	}
 	it.HashValues = typeutil.HashPK2Channels(it.result.IDs, channelNames)
-	// groupedHashKeys represents the dmChannel index
-	channel2RowOffsets := make(map[string][]int)  //   channelName to count
-	channelMaxTSMap := make(map[string]Timestamp) //  channelName to max Timestamp
+	// the rows are grouped by the dmChannel and the partition they are inserted into
+	type shard struct {
+		channelName string
+		partitionID UniqueID
+	}
+	shard2RowOffsets := make(map[shard][]int)  //   shard to row offsets
+	shardMaxTSMap := make(map[shard]Timestamp) //  shard to max Timestamp
 
 	// assert len(it.hashValues) < maxInt
 	for offset, channelID := range it.HashValues {
-		channelName := channelNames[channelID]
-		if _, ok := channel2RowOffsets[channelName]; !ok {
-			channel2RowOffsets[channelName] = []int{}
-		}
-		channel2RowOffsets[channelName] = append(channel2RowOffsets[channelName], offset)
+		key := shard{channelName: channelNames[channelID], partitionID: it.partitionOf(offset)}
+		shard2RowOffsets[key] = append(shard2RowOffsets[key], offset)
 
-		if _, ok := channelMaxTSMap[channelName]; !ok {
-			channelMaxTSMap[channelName] = typeutil.ZeroTimestamp
-		}
 		ts := it.Timestamps[offset]
-		if channelMaxTSMap[channelName] < ts {
-			channelMaxTSMap[channelName] = ts
+		if shardMaxTSMap[key] < ts {
+			shardMaxTSMap[key] = ts
 		}
 	}
 
 	// create empty insert message
-	createInsertMsg := func(segmentID UniqueID, channelName string) *msgstream.InsertMsg {
+	createInsertMsg := func(segmentID UniqueID, channelName string, partitionID UniqueID) *msgstream.InsertMsg {
+		partitionName := it.PartitionName
+		if it.partitionNames != nil {
+			partitionName = it.partitionNames[partitionID]
+		}
 		insertReq := internalpb.InsertRequest{
 			Base: &commonpb.MsgBase{
 				MsgType:   commonpb.MsgType_Insert,
@@ -397,9 +466,9 @@ func (it *insertTask) assignSegmentID(channelNames []string) (*msgstream.MsgPack
 				SourceID:  it.Base.SourceID,
 			},
 			CollectionID:   it.CollectionID,
-			PartitionID:    it.PartitionID,
+			PartitionID:    partitionID,
 			CollectionName: it.CollectionName,
-			PartitionName:  it.PartitionName,
+			PartitionName:  partitionName,
 			SegmentID:      segmentID,
 			ShardName:      channelName,
 			Version:        internalpb.InsertDataVersion_ColumnBased,
@@ -417,10 +486,10 @@ func (it *insertTask) assignSegmentID(channelNames []string) (*msgstream.MsgPack
 	}
 
 	// repack the row data corresponding to the offset to insertMsg
-	getInsertMsgsBySegmentID := func(segmentID UniqueID, rowOffsets []int, channelName string, mexMessageSize int) ([]msgstream.TsMsg, error) {
+	getInsertMsgsBySegmentID := func(segmentID UniqueID, rowOffsets []int, channelName string, partitionID UniqueID, mexMessageSize int) ([]msgstream.TsMsg, error) {
 		repackedMsgs := make([]msgstream.TsMsg, 0)
 		requestSize := 0
-		insertMsg := createInsertMsg(segmentID, channelName)
+		insertMsg := createInsertMsg(segmentID, channelName, partitionID)
 		for _, offset := range rowOffsets {
 			curRowMessageSize, err := typeutil.EstimateEntitySize(it.InsertRequest.GetFieldsData(), offset)
 			if err != nil {
@@ -430,7 +499,7 @@ func (it *insertTask) assignSegmentID(channelNames []string) (*msgstream.MsgPack
 			// if insertMsg's size is greater than the threshold, split into multiple insertMsgs
 			if requestSize+curRowMessageSize >= mexMessageSize {
 				repackedMsgs = append(repackedMsgs, insertMsg)
-				insertMsg = createInsertMsg(segmentID, channelName)
+				insertMsg = createInsertMsg(segmentID, channelName, partitionID)
 				requestSize = 0
 			}
 
@@ -446,12 +515,14 @@ func (it *insertTask) assignSegmentID(channelNames []string) (*msgstream.MsgPack
 		return repackedMsgs, nil
 	}
 
-	// get allocated segmentID info for every dmChannel and repack insertMsgs for every segmentID
-	for channelName, rowOffsets := range channel2RowOffsets {
-		assignedSegmentInfos, err := it.segIDAssigner.GetSegmentID(it.CollectionID, it.PartitionID, channelName, uint32(len(rowOffsets)), channelMaxTSMap[channelName])
+	// get allocated segmentID info for every dmChannel and partition and repack insertMsgs for every segmentID
+	for key, rowOffsets := range shard2RowOffsets {
+		channelName := key.channelName
+		assignedSegmentInfos, err := it.segIDAssigner.GetSegmentID(it.CollectionID, key.partitionID, channelName, uint32(len(rowOffsets)), shardMaxTSMap[key])
 		if err != nil {
 			log.Error("allocate segmentID for insert data failed",
 				zap.Int64("collectionID", it.CollectionID),
+				zap.Int64("partitionID", key.partitionID),
 				zap.String("channel name", channelName),
 				zap.Int("allocate count", len(rowOffsets)),
 				zap.Error(err))
@@ -461,7 +532,7 @@ func (it *insertTask) assignSegmentID(channelNames []string) (*msgstream.MsgPack
 		startPos := 0
 		for segmentID, count := range assignedSegmentInfos {
 			subRowOffsets := rowOffsets[startPos : startPos+int(count)]
-			insertMsgs, err := getInsertMsgsBySegmentID(segmentID, subRowOffsets, channelName, threshold)
+			insertMsgs, err := getInsertMsgsBySegmentID(segmentID, subRowOffsets, channelName, key.partitionID, threshold)
 			if err != nil {
 				log.Error("repack insert data to insert msgs failed",
 					zap.Int64("collectionID", it.CollectionID),
@@ -502,6 +573,10 @@ func (it *insertTask) Execute(ctx context.Context) error {
 		}
 	}
 	it.PartitionID = partitionID
+	if err := it.routePartitionKeys(ctx); err != nil {
+		log.Error("route rows by partition key failed", zap.Int64("msgID", it.Base.MsgID), zap.Int64("collectionID", collID), zap.Error(err))
+		return err
+	}
 	tr.Record("get collection id & partition id from cache")
 
 	stream, err := it.chMgr.getDMLStream(collID)
@@ -694,13 +769,20 @@ func (cct *createCollectionTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	if err := validatePartitionKey(cct.schema); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (cct *createCollectionTask) Execute(ctx context.Context) error {
 	var err error
 	cct.result, err = cct.rootCoord.CreateCollection(ctx, cct.CreateCollectionRequest)
-	return err
+	if err != nil || cct.result.GetErrorCode() != commonpb.ErrorCode_Success {
+		return err
+	}
+	return createPartitionKeyPartitions(ctx, cct.rootCoord, cct.CreateCollectionRequest, cct.schema)
 }
 
 func (cct *createCollectionTask) PostExecute(ctx context.Context) error {
@@ -1218,6 +1300,9 @@ type showCollectionsTask struct {
 	rootCoord  types.RootCoord
 	queryCoord types.QueryCoord
 	result     *milvuspb.ShowCollectionsResponse
+
+	// fills the in-memory stats if not nil
+	collectionStats *collectionStatsCache
 }
 
 func (sct *showCollectionsTask) TraceCtx() context.Context {
@@ -1336,6 +1421,7 @@ func (sct *showCollectionsTask) Execute(ctx context.Context) error {
 			InMemoryPercentages:  make([]int64, 0, len(resp.CollectionIDs)),
 		}
 
+		now := time.Now()
 		for offset, id := range resp.CollectionIDs {
 			collectionName, ok := IDs2Names[id]
 			if !ok {
@@ -1354,6 +1440,9 @@ func (sct *showCollectionsTask) Execute(ctx context.Context) error {
 			sct.result.CreatedTimestamps = append(sct.result.CreatedTimestamps, collectionInfo.createdTimestamp)
 			sct.result.CreatedUtcTimestamps = append(sct.result.CreatedUtcTimestamps, collectionInfo.createdUtcTimestamp)
 			sct.result.InMemoryPercentages = append(sct.result.InMemoryPercentages, resp.InMemoryPercentages[offset])
+			if sct.collectionStats != nil {
+				sct.result.InMemoryStats = append(sct.result.InMemoryStats, sct.collectionStats.get(id, now))
+			}
 		}
 	} else {
 		sct.result = respFromRootCoord
@@ -1429,6 +1518,9 @@ func (cpt *createPartitionTask) PreExecute(ctx context.Context) error {
 }
 
 func (cpt *createPartitionTask) Execute(ctx context.Context) (err error) {
+	if err := checkNoPartitionKey(ctx, cpt.rootCoord, cpt.GetDbName(), cpt.GetCollectionName(), "creating partition"); err != nil {
+		return err
+	}
 	cpt.result, err = cpt.rootCoord.CreatePartition(ctx, cpt.CreatePartitionRequest)
 	if cpt.result == nil {
 		return errors.New("get collection statistics resp is nil")
@@ -1506,6 +1598,9 @@ func (dpt *dropPartitionTask) PreExecute(ctx context.Context) error {
 }
 
 func (dpt *dropPartitionTask) Execute(ctx context.Context) (err error) {
+	if err := checkNoPartitionKey(ctx, dpt.rootCoord, dpt.GetDbName(), dpt.GetCollectionName(), "dropping partition"); err != nil {
+		return err
+	}
 	dpt.result, err = dpt.rootCoord.DropPartition(ctx, dpt.DropPartitionRequest)
 	if dpt.result == nil {
 		return errors.New("get collection statistics resp is nil")
@@ -2684,6 +2779,7 @@ func (lct *loadCollectionTask) Execute(ctx context.Context) (err error) {
 		zap.Int64("msgID", request.Base.MsgID), zap.Int64("collectionID", request.CollectionID),
 		zap.Any("schema", request.Schema))
 	lct.result, err = lct.queryCoord.LoadCollection(ctx, request)
+	globalMetaCache.RemoveLoadedFields(ctx, lct.CollectionName)
 	if err != nil {
 		return fmt.Errorf("call query coordinator LoadCollection: %s", err)
 	}
@@ -2775,6 +2871,7 @@ func (rct *releaseCollectionTask) Execute(ctx context.Context) (err error) {
 	}
 
 	rct.result, err = rct.queryCoord.ReleaseCollection(ctx, request)
+	globalMetaCache.RemoveLoadedFields(ctx, rct.CollectionName)
 
 	_ = rct.chMgr.removeDQLStream(collID)
 
@@ -2877,6 +2974,7 @@ func (lpt *loadPartitionsTask) Execute(ctx context.Context) error {
 		ReplicaNumber: lpt.ReplicaNumber,
 	}
 	lpt.result, err = lpt.queryCoord.LoadPartitions(ctx, request)
+	globalMetaCache.RemoveLoadedFields(ctx, lpt.CollectionName)
 	return err
 }
 
@@ -2970,6 +3068,7 @@ func (rpt *releasePartitionsTask) Execute(ctx context.Context) (err error) {
 		PartitionIDs: partitionIDs,
 	}
 	rpt.result, err = rpt.queryCoord.ReleasePartitions(ctx, request)
+	globalMetaCache.RemoveLoadedFields(ctx, rpt.CollectionName)
 	return err
 }
 
@@ -3075,7 +3174,7 @@ func getPrimaryKeysFromExpr(schema *schemapb.CollectionSchema, expr string) (res
 		return
 	}
 
-	plan, err := createExprPlan(schema, expr)
+	plan, err := planparser.CreateExprPlan(schema, expr)
 	if err != nil {
 		return res, 0, fmt.Errorf("failed to create expr plan, expr = %s", expr)
 	}
@@ -8,6 +8,7 @@ import (
 	qnClient "github.com/milvus-io/milvus/internal/distributed/querynode/client"
 
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
 
@@ -41,6 +42,45 @@ var (
 	errInvalidShardLeaders = errors.New("Invalid shard leader")
 )
 
+// shardLeaderError is the error of a failed status reported by a shard leader
+type shardLeaderError struct {
+	op     string
+	nodeID UniqueID
+	status *commonpb.Status
+}
+
+func (e *shardLeaderError) Error() string {
+	return fmt.Sprintf("fail to %s, QueryNode ID=%d, reason=%s", e.op, e.nodeID, e.status.GetReason())
+}
+
+// isRetriableErrorCode returns whether a request failed with code may succeed with another replica or later,
+// the invalid requests fail with the same code on every replica
+func isRetriableErrorCode(code commonpb.ErrorCode) bool {
+	switch code {
+	case commonpb.ErrorCode_UnexpectedError,
+		commonpb.ErrorCode_RateLimit,
+		commonpb.ErrorCode_OutOfMemory,
+		commonpb.ErrorCode_IndexNotExist,
+		commonpb.ErrorCode_SegmentReleased,
+		commonpb.ErrorCode_TSafeLagged,
+		commonpb.ErrorCode_StorageUnavailable,
+		commonpb.ErrorCode_NotReadyServe:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetriableShardError returns whether a request failed with err may succeed with another replica, the retries are
+// decided by the error codes reported by the shard leaders, the other errors such as rpc failures are retriable
+func isRetriableShardError(err error) bool {
+	var leaderErr *shardLeaderError
+	if errors.As(err, &leaderErr) {
+		return isRetriableErrorCode(leaderErr.status.GetErrorCode())
+	}
+	return true
+}
+
 func roundRobinPolicy(ctx context.Context, getQueryNodePolicy getQueryNodePolicy, query func(UniqueID, types.QueryNode) error, leaders *querypb.ShardLeadersList) error {
 	var (
 		err     = errBegin
@@ -70,6 +110,9 @@ func roundRobinPolicy(ctx context.Context, getQueryNodePolicy getQueryNodePolicy
 				zap.String("leader", leaders.GetChannelName()),
 				zap.Int64("nodeID", currentID),
 				zap.Error(err))
+			if !isRetriableShardError(err) {
+				return err
+			}
 		}
 		current++
 	}
@@ -0,0 +1,113 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+func TestIsRetriableShardError(t *testing.T) {
+	genErr := func(code commonpb.ErrorCode) error {
+		return &shardLeaderError{op: "Search", nodeID: 1, status: &commonpb.Status{ErrorCode: code, Reason: "mock"}}
+	}
+	retriable := []commonpb.ErrorCode{
+		commonpb.ErrorCode_UnexpectedError,
+		commonpb.ErrorCode_RateLimit,
+		commonpb.ErrorCode_OutOfMemory,
+		commonpb.ErrorCode_IndexNotExist,
+		commonpb.ErrorCode_SegmentReleased,
+		commonpb.ErrorCode_TSafeLagged,
+		commonpb.ErrorCode_StorageUnavailable,
+		commonpb.ErrorCode_NotReadyServe,
+	}
+	for _, code := range retriable {
+		assert.True(t, isRetriableShardError(genErr(code)), code.String())
+		assert.True(t, isRetriableShardError(fmt.Errorf("search failed: %w", genErr(code))), code.String())
+	}
+	for _, code := range []commonpb.ErrorCode{
+		commonpb.ErrorCode_IllegalArgument,
+		commonpb.ErrorCode_IllegalMetricType,
+		commonpb.ErrorCode_CollectionNotExists,
+	} {
+		assert.False(t, isRetriableShardError(genErr(code)), code.String())
+	}
+
+	assert.True(t, isRetriableShardError(errInvalidShardLeaders))
+	assert.True(t, isRetriableShardError(errors.New("mock error")))
+	assert.EqualError(t, genErr(commonpb.ErrorCode_IllegalArgument), "fail to Search, QueryNode ID=1, reason=mock")
+}
+
+func TestRoundRobinPolicy(t *testing.T) {
+	ctx := context.Background()
+	getQueryNode := func(ctx context.Context, address string) (types.QueryNode, error) {
+		return &QueryNodeMock{address: address}, nil
+	}
+	leaders := &querypb.ShardLeadersList{
+		ChannelName: "channel-1",
+		NodeIds:     []int64{1, 2, 3},
+		NodeAddrs:   []string{"addr-1", "addr-2", "addr-3"},
+	}
+	query := func(codes map[UniqueID]commonpb.ErrorCode, tried *[]UniqueID) func(UniqueID, types.QueryNode) error {
+		return func(nodeID UniqueID, qn types.QueryNode) error {
+			*tried = append(*tried, nodeID)
+			if code, ok := codes[nodeID]; ok {
+				return &shardLeaderError{op: "Query", nodeID: nodeID, status: &commonpb.Status{ErrorCode: code}}
+			}
+			return nil
+		}
+	}
+
+	t.Run("retry with another replica", func(t *testing.T) {
+		var tried []UniqueID
+		err := roundRobinPolicy(ctx, getQueryNode, query(map[UniqueID]commonpb.ErrorCode{
+			1: commonpb.ErrorCode_SegmentReleased,
+			2: commonpb.ErrorCode_TSafeLagged,
+		}, &tried), leaders)
+		assert.NoError(t, err)
+		assert.Equal(t, []UniqueID{1, 2, 3}, tried)
+	})
+
+	t.Run("invalid requests are not retried", func(t *testing.T) {
+		var tried []UniqueID
+		err := roundRobinPolicy(ctx, getQueryNode, query(map[UniqueID]commonpb.ErrorCode{
+			1: commonpb.ErrorCode_IllegalArgument,
+		}, &tried), leaders)
+		var leaderErr *shardLeaderError
+		assert.True(t, errors.As(err, &leaderErr))
+		assert.Equal(t, []UniqueID{1}, tried)
+	})
+
+	t.Run("all replicas fail", func(t *testing.T) {
+		var tried []UniqueID
+		err := roundRobinPolicy(ctx, getQueryNode, query(map[UniqueID]commonpb.ErrorCode{
+			1: commonpb.ErrorCode_NotReadyServe,
+			2: commonpb.ErrorCode_NotReadyServe,
+			3: commonpb.ErrorCode_NotReadyServe,
+		}, &tried), leaders)
+		assert.Error(t, err)
+		assert.Equal(t, []UniqueID{1, 2, 3}, tried)
+	})
+}
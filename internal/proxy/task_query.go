@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -22,6 +23,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 )
@@ -43,12 +45,31 @@ type queryTask struct {
 	collectionName string
 
 	resultBuf       chan *internalpb.RetrieveResults
-	toReduceResults []*internalpb.RetrieveResults
+	toReduceResults *retrieveResultCollector
+	skippedSegments int64
+	readTs          []Timestamp
 	runningGroup    *errgroup.Group
 	runningGroupCtx context.Context
 
 	getQueryNodePolicy getQueryNodePolicy
 	queryShardPolicy   pickShardPolicy
+
+	// returns the filter enforced on the request, nil means no filter
+	mandatoryFilterHook MandatoryFilterHook
+
+	// output expressions computed after merge, and the fields retrieved only for them
+	outputExprs  []*outputExpr
+	hiddenFields map[string]bool
+
+	// the field to sort the results by if limited
+	orderByFieldID UniqueID
+
+	// the query ordered by primary key with limit is paginated, whose results carry the cursor of the next page
+	pkField        *schemapb.FieldSchema
+	paginated      bool
+	planHash       uint64
+	distributionMu sync.Mutex
+	distributions  []*internalpb.ShardDistribution
 }
 
 func (t *queryTask) PreExecute(ctx context.Context) error {
@@ -146,21 +167,42 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 				pkField = field.Name
 			}
 		}
-		t.request.Expr = IDs2Expr(pkField, t.ids.GetIntId().Data)
+		switch t.ids.GetIdField().(type) {
+		case *schemapb.IDs_StrId:
+			t.request.Expr = StrIDs2Expr(pkField, t.ids.GetStrId().GetData())
+		default:
+			t.request.Expr = IDs2Expr(pkField, t.ids.GetIntId().GetData())
+		}
 	}
 
 	if t.request.Expr == "" {
 		return fmt.Errorf("query expression is empty")
 	}
+	t.request.Expr, err = substituteExprTemplate(t.request.Expr, t.request.GetExprTemplateValues(), schema)
+	if err != nil {
+		return err
+	}
 
-	plan, err := createExprPlan(schema, t.request.Expr)
+	expr, err := parseExpr(ctx, collectionName, schema, t.request.Expr)
+	if err != nil {
+		return err
+	}
+	plan := &planpb.PlanNode{
+		Node: &planpb.PlanNode_Predicates{
+			Predicates: expr,
+		},
+	}
+	// output expressions are computed by proxy over the retrieved fields
+	plainOutputFields, outputExprs, err := parseOutputExprs(t.request.OutputFields, schema)
 	if err != nil {
 		return err
 	}
-	t.request.OutputFields, err = translateOutputFields(t.request.OutputFields, schema, true)
+	t.request.OutputFields, err = translateOutputFields(plainOutputFields, schema, true)
 	if err != nil {
 		return err
 	}
+	t.outputExprs = outputExprs
+	t.request.OutputFields, t.hiddenFields = addOutputExprFields(t.request.OutputFields, outputExprs)
 	log.Debug("translate output fields", zap.Any("OutputFields", t.request.OutputFields),
 		zap.Any("requestID", t.Base.MsgID), zap.Any("requestType", "query"))
 
@@ -198,27 +240,64 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 	log.Debug("translate output fields to field ids", zap.Any("OutputFieldsID", t.OutputFieldsId),
 		zap.Any("requestID", t.Base.MsgID), zap.Any("requestType", "query"))
 
+	if err := checkFieldsLoaded(ctx, t.qc, collectionName, schema, append(getPlanFieldIDs(plan), t.OutputFieldsId...)); err != nil {
+		return err
+	}
+
+	if t.request.SampleSize < 0 {
+		return fmt.Errorf("sample size should not be negative, but got %d", t.request.SampleSize)
+	}
+	plan.SampleSize, plan.SampleSeed = t.request.SampleSize, t.request.SampleSeed
+
+	if err := t.translateOrderBy(plan, schema); err != nil {
+		return err
+	}
+
+	if t.request.MaxScannedSegments < 0 {
+		return fmt.Errorf("max scanned segments should not be negative, but got %d", t.request.MaxScannedSegments)
+	}
+	t.RetrieveRequest.MaxScannedSegments = t.request.MaxScannedSegments
+
 	t.RetrieveRequest.SerializedExprPlan, err = proto.Marshal(plan)
 	if err != nil {
 		return err
 	}
 
-	if t.request.TravelTimestamp == 0 {
+	// the mandatory filter is ANDed into the plan by query nodes, so that it could not be bypassed by the expression
+	t.RetrieveRequest.MandatoryFilter, t.RetrieveRequest.MandatoryFilterPlan, err = compileMandatoryFilter(ctx, t.mandatoryFilterHook, collectionName, schema)
+	if err != nil {
+		return err
+	}
+
+	// the cursor pins the snapshot of the request, so that all the pages are read at the same snapshot
+	if err := t.preparePagination(plan, schema); err != nil {
+		return err
+	}
+
+	travelTimestamp := t.request.TravelTimestamp
+	if t.request.SnapshotTimestamp != 0 {
+		// query exactly at the pinned snapshot
+		travelTimestamp = t.request.SnapshotTimestamp
+	}
+	if travelTimestamp == 0 {
 		t.TravelTimestamp = t.BeginTs()
 	} else {
-		durationSeconds := tsoutil.CalculateDuration(t.BeginTs(), t.request.TravelTimestamp) / 1000
+		durationSeconds := tsoutil.CalculateDuration(t.BeginTs(), travelTimestamp) / 1000
 		if durationSeconds > Params.CommonCfg.RetentionDuration {
 			duration := time.Second * time.Duration(durationSeconds)
 			return fmt.Errorf("only support to travel back to %s so far", duration.String())
 		}
-		t.TravelTimestamp = t.request.TravelTimestamp
+		t.TravelTimestamp = travelTimestamp
 	}
 
-	if t.request.GuaranteeTimestamp == 0 {
+	if t.request.SnapshotTimestamp != 0 {
+		t.GuaranteeTimestamp = t.request.SnapshotTimestamp
+	} else if t.request.GuaranteeTimestamp == 0 {
 		t.GuaranteeTimestamp = t.BeginTs()
 	} else {
 		t.GuaranteeTimestamp = t.request.GuaranteeTimestamp
 	}
+	t.SnapshotTimestamp = t.request.SnapshotTimestamp
 
 	deadline, ok := t.TraceCtx().Deadline()
 	if ok {
@@ -227,7 +306,55 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 
 	t.DbID = 0 // TODO
 	log.Info("Query PreExecute done.",
-		zap.Any("requestID", t.Base.MsgID), zap.Any("requestType", "query"))
+		zap.Any("requestID", t.Base.MsgID), zap.Any("requestType", "query"),
+		zap.String("mandatoryFilter", t.RetrieveRequest.MandatoryFilter))
+	return nil
+}
+
+// translateOrderBy checks the limit and the field to sort by of the request, and sets them to plan
+func (t *queryTask) translateOrderBy(plan *planpb.PlanNode, schema *schemapb.CollectionSchema) error {
+	if t.request.Limit < 0 {
+		return fmt.Errorf("limit should not be negative, but got %d", t.request.Limit)
+	}
+	if t.request.Limit == 0 && t.request.OrderByField == "" {
+		return nil
+	}
+	if t.request.Limit == 0 {
+		return fmt.Errorf("sorting by field %s requires a positive limit", t.request.OrderByField)
+	}
+	if t.request.OrderByField == "" {
+		return errors.New("limit requires a field to sort by")
+	}
+	if t.request.SampleSize > 0 {
+		return errors.New("limit and sample size could not be set at the same time")
+	}
+
+	var orderByField *schemapb.FieldSchema
+	for _, field := range schema.GetFields() {
+		if field.GetName() == t.request.OrderByField {
+			orderByField = field
+			break
+		}
+	}
+	if orderByField == nil {
+		return fmt.Errorf("field %s to sort by not exist", t.request.OrderByField)
+	}
+	if !typeutil.IsOrderableType(orderByField.GetDataType()) {
+		return fmt.Errorf("field %s of type %s could not be sorted by", orderByField.GetName(), orderByField.GetDataType().String())
+	}
+	isOutput := false
+	for _, fieldID := range plan.GetOutputFieldIds() {
+		if fieldID == orderByField.GetFieldID() {
+			isOutput = true
+			break
+		}
+	}
+	if !isOutput {
+		return fmt.Errorf("field %s to sort by should be one of the output fields", orderByField.GetName())
+	}
+
+	t.orderByFieldID = orderByField.GetFieldID()
+	plan.Limit, plan.OrderByFieldId, plan.OrderDesc = t.request.Limit, t.orderByFieldID, t.request.OrderDesc
 	return nil
 }
 
@@ -242,7 +369,33 @@ func (t *queryTask) Execute(ctx context.Context) error {
 		}
 
 		t.resultBuf = make(chan *internalpb.RetrieveResults, len(shards))
-		t.toReduceResults = make([]*internalpb.RetrieveResults, 0, len(shards))
+		spillBudget := Params.ProxyCfg.QueryResultSpillBudget
+		if t.request.GetSampleSize() > 0 || t.request.GetLimit() > 0 {
+			// the sampled and the limited results are bounded by the sample size or the limit, and merged in memory
+			spillBudget = 0
+		}
+		// the results of the former try are dropped
+		t.closeReduceResults()
+		t.toReduceResults = newRetrieveResultCollector(spillBudget, Params.ProxyCfg.QueryResultSpillDir)
+		t.skippedSegments = 0
+		t.readTs = nil
+		t.distributions = nil
+
+		// collect the results as they arrive, so that they are spilled before all shards return
+		var collectErr error
+		collected := make(chan struct{})
+		go func() {
+			defer close(collected)
+			for res := range t.resultBuf {
+				t.skippedSegments += res.GetSkippedSegments()
+				t.readTs = append(t.readTs, res.GetReadTimestamp())
+				if collectErr == nil {
+					collectErr = t.toReduceResults.add(res)
+				}
+				log.Debug("proxy receives one query result", zap.Int64("sourceID", res.GetBase().GetSourceID()), zap.Any("taskID", t.ID()))
+			}
+		}()
+
 		t.runningGroup, t.runningGroupCtx = errgroup.WithContext(ctx)
 		for _, shard := range shards {
 			s := shard
@@ -262,15 +415,25 @@ func (t *queryTask) Execute(ctx context.Context) error {
 		}
 
 		err = t.runningGroup.Wait()
-		return err
+		close(t.resultBuf)
+		<-collected
+		if err != nil {
+			return err
+		}
+		if collectErr != nil {
+			log.Warn("failed to collect query results", zap.Int64("taskID", t.ID()), zap.Error(collectErr))
+		}
+		return collectErr
 	}
 
 	err := executeQuery(WithCache)
 	if err == errInvalidShardLeaders {
 		log.Warn("invalid shard leaders cache, updating shardleader caches and retry search")
-		return executeQuery(WithoutCache)
+		err = executeQuery(WithoutCache)
 	}
 	if err != nil {
+		// PostExecute is skipped on failure, remove the spill files here
+		t.closeReduceResults()
 		return err
 	}
 
@@ -286,33 +449,22 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 	}()
 
 	var err error
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		for {
-			select {
-			case <-t.TraceCtx().Done():
-				log.Warn("proxy", zap.Int64("Query: wait to finish failed, timeout!, taskID:", t.ID()))
-				return
-			case <-t.runningGroupCtx.Done():
-				log.Debug("all queries are finished or canceled", zap.Any("taskID", t.ID()))
-				close(t.resultBuf)
-				for res := range t.resultBuf {
-					t.toReduceResults = append(t.toReduceResults, res)
-					log.Debug("proxy receives one query result", zap.Int64("sourceID", res.GetBase().GetSourceID()), zap.Any("taskID", t.ID()))
-				}
-				wg.Done()
-				return
-			}
-		}
-	}()
-
-	wg.Wait()
-	t.result, err = mergeRetrieveResults(t.toReduceResults)
+	// the spill files are removed once merged, or on error
+	defer t.closeReduceResults()
+	if t.request.GetSampleSize() > 0 {
+		t.result, err = mergeSampledRetrieveResults(t.toReduceResults.results, t.request.GetSampleSize(), t.request.GetSampleSeed())
+	} else if t.request.GetLimit() > 0 {
+		t.result, err = mergeOrderedRetrieveResults(t.toReduceResults.results, t.request.GetLimit(), t.orderByFieldID, t.request.GetOrderDesc())
+	} else {
+		t.result, err = t.toReduceResults.merge(t.TraceCtx())
+	}
 	if err != nil {
 		return err
 	}
 	t.result.CollectionName = t.collectionName
+	t.result.SnapshotTimestamp = getReadTimestamp(t.TravelTimestamp, t.GuaranteeTimestamp, t.readTs)
+	t.result.Partial = t.skippedSegments > 0
+	t.result.SkippedSegments = t.skippedSegments
 
 	if len(t.result.FieldsData) > 0 {
 		t.result.Status = &commonpb.Status{
@@ -340,10 +492,31 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 			}
 		}
 	}
+	t.result.Cursor, err = t.nextCursor()
+	if err != nil {
+		return err
+	}
+	t.result.FieldsData, err = applyOutputExprs(t.outputExprs, t.result.FieldsData, t.hiddenFields)
+	if err != nil {
+		return err
+	}
+	if t.request.GetArrowFormat() {
+		t.result.ArrowIpc, err = encodeArrowStream(schema, t.result.FieldsData, Params.ProxyCfg.MaxArrowResultSize)
+		if err != nil {
+			return err
+		}
+		t.result.FieldsData = nil
+	}
 	log.Info("Query PostExecute done", zap.Any("requestID", t.Base.MsgID), zap.String("requestType", "query"))
 	return nil
 }
 
+func (t *queryTask) closeReduceResults() {
+	if t.toReduceResults != nil {
+		t.toReduceResults.close()
+	}
+}
+
 func (t *queryTask) queryShard(ctx context.Context, leaders *querypb.ShardLeadersList) error {
 	query := func(nodeID UniqueID, qn types.QueryNode) error {
 		req := &querypb.QueryRequest{
@@ -360,10 +533,15 @@ func (t *queryTask) queryShard(ctx context.Context, leaders *querypb.ShardLeader
 		if result.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
 			log.Warn("QueryNode query result error", zap.Int64("nodeID", nodeID),
 				zap.String("reason", result.GetStatus().GetReason()))
-			return fmt.Errorf("fail to Query, QueryNode ID = %d, reason=%s", nodeID, result.GetStatus().GetReason())
+			return &shardLeaderError{op: "Query", nodeID: nodeID, status: result.GetStatus()}
+		}
+		if err := decompressRetrieveResults(result); err != nil {
+			log.Warn("fail to decompress query result", zap.Int64("nodeID", nodeID), zap.Error(err))
+			return err
 		}
 
 		log.Debug("get query result", zap.Int64("nodeID", nodeID), zap.String("channelID", leaders.GetChannelName()))
+		t.addDistribution(leaders.GetChannelName(), result.GetDistributionDigest())
 		t.resultBuf <- result
 		return nil
 	}
@@ -485,31 +663,47 @@ func IDs2Expr(fieldName string, ids []int64) string {
 	return fieldName + " in [ " + idsStr + " ]"
 }
 
+// StrIDs2Expr converts string ids slices to bool expresion with specified field name
+func StrIDs2Expr(fieldName string, ids []string) string {
+	quoted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		quoted = append(quoted, strconv.Quote(id))
+	}
+	return fieldName + " in [ " + strings.Join(quoted, ", ") + " ]"
+}
+
 func mergeRetrieveResults(retrieveResults []*internalpb.RetrieveResults) (*milvuspb.QueryResults, error) {
-	var ret *milvuspb.QueryResults
+	var builders []*typeutil.ColumnBuilder
 	var skipDupCnt int64
-	var idSet = make(map[int64]struct{})
+	var idSet = make(map[interface{}]struct{})
 
 	// merge results and remove duplicates
 	for _, rr := range retrieveResults {
 		// skip empty result, it will break merge result
-		if rr == nil || rr.Ids == nil || rr.Ids.GetIntId() == nil || len(rr.Ids.GetIntId().Data) == 0 {
+		if rr == nil || rr.Ids == nil || typeutil.GetSizeOfIDs(rr.Ids) == 0 {
 			continue
 		}
 
-		if ret == nil {
-			ret = &milvuspb.QueryResults{
-				FieldsData: make([]*schemapb.FieldData, len(rr.FieldsData)),
+		if builders == nil {
+			var err error
+			builders, err = typeutil.NewColumnBuilders(rr.FieldsData)
+			if err != nil {
+				return nil, err
 			}
 		}
 
-		if len(ret.FieldsData) != len(rr.FieldsData) {
-			return nil, fmt.Errorf("mismatch FieldData in proxy RetrieveResults, expect %d get %d", len(ret.FieldsData), len(rr.FieldsData))
+		if len(builders) != len(rr.FieldsData) {
+			return nil, fmt.Errorf("mismatch FieldData in proxy RetrieveResults, expect %d get %d", len(builders), len(rr.FieldsData))
 		}
 
-		for i, id := range rr.Ids.GetIntId().GetData() {
+		for i := 0; i < typeutil.GetSizeOfIDs(rr.Ids); i++ {
+			id := typeutil.GetPK(rr.Ids, int64(i))
 			if _, ok := idSet[id]; !ok {
-				typeutil.AppendFieldData(ret.FieldsData, rr.FieldsData, int64(i))
+				for j, builder := range builders {
+					if err := builder.AppendRow(rr.FieldsData[j], i); err != nil {
+						return nil, err
+					}
+				}
 				idSet[id] = struct{}{}
 			} else {
 				// primary keys duplicate
@@ -519,13 +713,44 @@ func mergeRetrieveResults(retrieveResults []*internalpb.RetrieveResults) (*milvu
 	}
 	log.Debug("skip duplicated query result", zap.Int64("count", skipDupCnt))
 
-	if ret == nil {
-		ret = &milvuspb.QueryResults{
-			FieldsData: []*schemapb.FieldData{},
+	return &milvuspb.QueryResults{
+		FieldsData: typeutil.BuildColumns(builders),
+	}, nil
+}
+
+// mergeSampledRetrieveResults merges the samples of the shards into a uniform sample of at most size rows of
+// all the matched rows, resampling the shards proportionally to their matched rows
+func mergeSampledRetrieveResults(retrieveResults []*internalpb.RetrieveResults, size int64, seed int64) (*milvuspb.QueryResults, error) {
+	samples := make([]typeutil.SampledRows, 0, len(retrieveResults))
+	for _, rr := range retrieveResults {
+		if rr == nil {
+			continue
 		}
+		samples = append(samples, typeutil.SampledRows{IDs: rr.GetIds(), FieldsData: rr.GetFieldsData(), Matched: rr.GetMatchedCount()})
 	}
+	_, fieldsData, matched, err := typeutil.MergeSampledRows(samples, int(size), typeutil.NewSampleRand(seed, 0))
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("sample query results", zap.Int64("size", size), zap.Int64("matched", matched))
+	return &milvuspb.QueryResults{FieldsData: fieldsData}, nil
+}
 
-	return ret, nil
+// mergeOrderedRetrieveResults merges the top rows of the shards into the top limit rows sorted by the field of
+// orderByFieldID, the ties are broken by primary key
+func mergeOrderedRetrieveResults(retrieveResults []*internalpb.RetrieveResults, limit int64, orderByFieldID UniqueID, desc bool) (*milvuspb.QueryResults, error) {
+	rows := make([]typeutil.OrderedRows, 0, len(retrieveResults))
+	for _, rr := range retrieveResults {
+		if rr == nil {
+			continue
+		}
+		rows = append(rows, typeutil.OrderedRows{IDs: rr.GetIds(), FieldsData: rr.GetFieldsData()})
+	}
+	_, fieldsData, err := typeutil.MergeOrderedRows(rows, orderByFieldID, desc, int(limit))
+	if err != nil {
+		return nil, err
+	}
+	return &milvuspb.QueryResults{FieldsData: fieldsData}, nil
 }
 
 func (t *queryTask) TraceCtx() context.Context {
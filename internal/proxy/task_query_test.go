@@ -3,6 +3,7 @@ package proxy
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"testing"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 
@@ -156,6 +158,9 @@ func TestQueryTask_all(t *testing.T) {
 		},
 	}
 
+	// the shard leader lags behind the travel ts
+	result1.ReadTimestamp = task.TravelTimestamp - 1
+
 	fieldID := common.StartOfUserFieldID
 	for fieldName, dataType := range fieldName2Types {
 		result1.FieldsData = append(result1.FieldsData, generateFieldData(dataType, fieldName, int64(fieldID), hitNum))
@@ -168,4 +173,152 @@ func TestQueryTask_all(t *testing.T) {
 	assert.NoError(t, task.Execute(ctx))
 
 	assert.NoError(t, task.PostExecute(ctx))
+	assert.Equal(t, task.TravelTimestamp-1, task.result.GetSnapshotTimestamp())
+}
+
+// genSampledShardResult generates the shard result of n rows of pks [begin, begin+n) in random order, sampled from
+// matched rows
+func genSampledShardResult(begin int64, n int, matched int64) *internalpb.RetrieveResults {
+	pks := make([]int64, 0, n)
+	for _, i := range rand.Perm(n) {
+		pks = append(pks, begin+int64(i))
+	}
+	return &internalpb.RetrieveResults{
+		Ids: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}},
+		FieldsData: []*schemapb.FieldData{{
+			Type:      schemapb.DataType_Int64,
+			FieldName: testInt64Field,
+			FieldId:   100,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: pks}},
+			}},
+		}},
+		MatchedCount: matched,
+	}
+}
+
+func TestMergeSampledRetrieveResults(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		result, err := mergeSampledRetrieveResults([]*internalpb.RetrieveResults{nil, {}}, 10, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, result.GetFieldsData())
+	})
+
+	t.Run("proportional", func(t *testing.T) {
+		// the shards of 1000 and 9000 matched rows both return 100 sampled rows
+		const rounds = 500
+		var fromSmall, total int
+		for round := 0; round < rounds; round++ {
+			results := []*internalpb.RetrieveResults{genSampledShardResult(0, 100, 1000), genSampledShardResult(1000, 100, 9000)}
+			result, err := mergeSampledRetrieveResults(results, 100, 0)
+			require.NoError(t, err)
+			pks := result.GetFieldsData()[0].GetScalars().GetLongData().GetData()
+			assert.Len(t, pks, 100)
+			for _, pk := range pks {
+				if pk < 1000 {
+					fromSmall++
+				}
+				total++
+			}
+		}
+		assert.InDelta(t, 0.1, float64(fromSmall)/float64(total), 0.01)
+	})
+
+	t.Run("seeded", func(t *testing.T) {
+		results := []*internalpb.RetrieveResults{genSampledShardResult(0, 50, 500), genSampledShardResult(1000, 50, 800)}
+		expected, err := mergeSampledRetrieveResults(results, 50, 42)
+		require.NoError(t, err)
+		// the arrival order of the shard results does not matter
+		result, err := mergeSampledRetrieveResults([]*internalpb.RetrieveResults{results[1], results[0]}, 50, 42)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(expected, result))
+	})
+}
+
+func TestQueryTask_translateOrderBy(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: testInt64Field, DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: testVarCharField, DataType: schemapb.DataType_VarChar},
+			{FieldID: 102, Name: testBoolField, DataType: schemapb.DataType_Bool},
+			{FieldID: 103, Name: testFloatVecField, DataType: schemapb.DataType_FloatVector},
+		},
+	}
+	translate := func(request *milvuspb.QueryRequest) (*queryTask, *planpb.PlanNode, error) {
+		task := &queryTask{request: request}
+		plan := &planpb.PlanNode{OutputFieldIds: []int64{100, 101, 102, 103}}
+		return task, plan, task.translateOrderBy(plan, schema)
+	}
+
+	task, plan, err := translate(&milvuspb.QueryRequest{Limit: 10, OrderByField: testVarCharField, OrderDesc: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), plan.GetLimit())
+	assert.Equal(t, int64(101), plan.GetOrderByFieldId())
+	assert.True(t, plan.GetOrderDesc())
+	assert.Equal(t, UniqueID(101), task.orderByFieldID)
+
+	_, plan, err = translate(&milvuspb.QueryRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), plan.GetLimit())
+
+	for _, request := range []*milvuspb.QueryRequest{
+		{Limit: -1, OrderByField: testInt64Field},
+		{OrderByField: testInt64Field},
+		{Limit: 10},
+		{Limit: 10, OrderByField: testInt64Field, SampleSize: 10},
+		{Limit: 10, OrderByField: "not_exist"},
+		{Limit: 10, OrderByField: testBoolField},
+		{Limit: 10, OrderByField: testFloatVecField},
+	} {
+		_, _, err = translate(request)
+		assert.Error(t, err, request.String())
+	}
+
+	// the field to sort by is not output
+	task = &queryTask{request: &milvuspb.QueryRequest{Limit: 10, OrderByField: testVarCharField}}
+	assert.Error(t, task.translateOrderBy(&planpb.PlanNode{OutputFieldIds: []int64{100}}, schema))
+}
+
+// genOrderedShardResult generates the shard result of the pks from begin, whose int64 field takes values
+func genOrderedShardResult(begin int64, values []int64) *internalpb.RetrieveResults {
+	pks := make([]int64, 0, len(values))
+	for i := range values {
+		pks = append(pks, begin+int64(i))
+	}
+	return &internalpb.RetrieveResults{
+		Ids: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}},
+		FieldsData: []*schemapb.FieldData{{
+			Type:      schemapb.DataType_Int64,
+			FieldName: testInt64Field,
+			FieldId:   101,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: values}},
+			}},
+		}},
+	}
+}
+
+func TestMergeOrderedRetrieveResults(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		result, err := mergeOrderedRetrieveResults([]*internalpb.RetrieveResults{nil, {}}, 10, 101, false)
+		assert.NoError(t, err)
+		assert.Empty(t, result.GetFieldsData())
+	})
+
+	results := []*internalpb.RetrieveResults{
+		genOrderedShardResult(0, []int64{1, 3, 5}),
+		genOrderedShardResult(10, []int64{9, 3, 2}),
+		genOrderedShardResult(20, []int64{3, 9}),
+	}
+	result, err := mergeOrderedRetrieveResults(results, 4, 101, false)
+	require.NoError(t, err)
+	// the ties are broken by pk
+	assert.Equal(t, []int64{1, 2, 3, 3}, result.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+
+	result, err = mergeOrderedRetrieveResults(results, 4, 101, true)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{9, 9, 5, 3}, result.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+
+	_, err = mergeOrderedRetrieveResults(results, 4, 102, true)
+	assert.Error(t, err)
 }
@@ -2,10 +2,14 @@ package proxy
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,6 +19,7 @@ import (
 
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/parser/planparser"
 	"github.com/milvus-io/milvus/internal/types"
 
 	"github.com/milvus-io/milvus/internal/util/distance"
@@ -50,6 +55,135 @@ type searchTask struct {
 
 	getQueryNodePolicy getQueryNodePolicy
 	searchShardPolicy  pickShardPolicy
+
+	// requery is set if vector fields are requested as output fields, the output fields are
+	// fetched by a query on the reduced topk ids instead of being returned by every querynode
+	requery bool
+	// scoreType describes the semantics of scores in result, see milvuspb.SearchResults.ScoreType
+	scoreType string
+	// returns the filter enforced on the request, nil means no filter
+	mandatoryFilterHook MandatoryFilterHook
+}
+
+// cosineScoreType is the score type of IP scores normalized to cosine similarity
+const cosineScoreType = "COSINE"
+
+// parseNormalizeScores parses the optional normalize_scores search param, normalizing scores
+// emulates cosine similarity on inner product, so it requires metric type IP
+func parseNormalizeScores(searchParams []*commonpb.KeyValuePair, metricType string) (bool, error) {
+	normalizeStr, err := funcutil.GetAttrByKeyFromRepeatedKV(NormalizeScoresKey, searchParams)
+	if err != nil {
+		return false, nil
+	}
+	normalize, err := strconv.ParseBool(normalizeStr)
+	if err != nil {
+		return false, errors.New(NormalizeScoresKey + " " + normalizeStr + " is invalid")
+	}
+	if normalize && strings.ToUpper(metricType) != distance.IP {
+		return false, fmt.Errorf("%s requires metric type %s, but got %s", NormalizeScoresKey, distance.IP, metricType)
+	}
+	return normalize, nil
+}
+
+// parseOutputTimestamps returns whether to output the insert timestamps of hits, which is disabled by default
+func parseOutputTimestamps(searchParams []*commonpb.KeyValuePair) (bool, error) {
+	outputStr, err := funcutil.GetAttrByKeyFromRepeatedKV(OutputTimestampsKey, searchParams)
+	if err != nil {
+		return false, nil
+	}
+	output, err := strconv.ParseBool(outputStr)
+	if err != nil {
+		return false, errors.New(OutputTimestampsKey + " " + outputStr + " is invalid")
+	}
+	return output, nil
+}
+
+// parseMaxScannedSegments parses the optional max_scanned_segments search param, the shard leaders search at most
+// max_scanned_segments sealed segments of their shards in the order of segment ID if positive
+func parseMaxScannedSegments(searchParams []*commonpb.KeyValuePair) (int64, error) {
+	maxStr, err := funcutil.GetAttrByKeyFromRepeatedKV(MaxScannedSegmentsKey, searchParams)
+	if err != nil {
+		return 0, nil
+	}
+	maxSegments, err := strconv.ParseInt(maxStr, 10, 64)
+	if err != nil || maxSegments < 0 {
+		return 0, errors.New(MaxScannedSegmentsKey + " " + maxStr + " is invalid")
+	}
+	return maxSegments, nil
+}
+
+// parsePartitionWeights parses the optional partition_weights search param, a json object of the partition names to
+// their weights, e.g. {"recent": 2, "archive": 0.5}. The hits of the partitions with larger weights rank higher,
+// the partitions not listed are weighted 1. Query nodes rank a hit of weight w by, for metric type
+//
+//	IP: the inner product subtracted by 1 - w, i.e. ip + w - 1
+//	L2: the distance multiplied by 1 / w, i.e. l2 / w
+//
+// while the unweighted scores are returned. It returns the weights of the searched partitions, or nil if all of them
+// are weighted equally, which is exactly the unweighted search.
+func parsePartitionWeights(searchParams []*commonpb.KeyValuePair, metricType string, partitions map[string]UniqueID,
+	searchPartitionIDs []UniqueID) ([]UniqueID, []float32, error) {
+	weightsStr, err := funcutil.GetAttrByKeyFromRepeatedKV(PartitionWeightsKey, searchParams)
+	if err != nil {
+		return nil, nil, nil
+	}
+	var weightsByName map[string]float64
+	if err := json.Unmarshal([]byte(weightsStr), &weightsByName); err != nil {
+		return nil, nil, errors.New(PartitionWeightsKey + " " + weightsStr + " is invalid")
+	}
+	switch strings.ToUpper(metricType) {
+	case distance.IP, distance.L2:
+	default:
+		return nil, nil, fmt.Errorf("%s requires metric type %s or %s, but got %s", PartitionWeightsKey, distance.IP, distance.L2, metricType)
+	}
+
+	weights := make(map[UniqueID]float32, len(weightsByName))
+	for name, weight := range weightsByName {
+		partitionID, ok := partitions[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("partition %s of %s not found", name, PartitionWeightsKey)
+		}
+		if !(weight > 0) || weight > math.MaxFloat32 {
+			return nil, nil, fmt.Errorf("weight %v of partition %s should be positive", weight, name)
+		}
+		weights[partitionID] = float32(weight)
+	}
+
+	// all the partitions are searched if not specified
+	searched := make([]UniqueID, 0, len(partitions))
+	searched = append(searched, searchPartitionIDs...)
+	if len(searched) == 0 {
+		for _, partitionID := range partitions {
+			searched = append(searched, partitionID)
+		}
+	}
+	weightOf := func(partitionID UniqueID) float32 {
+		if weight, ok := weights[partitionID]; ok {
+			return weight
+		}
+		return 1
+	}
+	weighted := false
+	for _, partitionID := range searched {
+		if weightOf(partitionID) != weightOf(searched[0]) {
+			weighted = true
+			break
+		}
+	}
+	if !weighted {
+		return nil, nil, nil
+	}
+
+	partitionIDs := make([]UniqueID, 0, len(searched))
+	partitionWeights := make([]float32, 0, len(searched))
+	sort.Slice(searched, func(i, j int) bool { return searched[i] < searched[j] })
+	for _, partitionID := range searched {
+		if weight, ok := weights[partitionID]; ok {
+			partitionIDs = append(partitionIDs, partitionID)
+			partitionWeights = append(partitionWeights, weight)
+		}
+	}
+	return partitionIDs, partitionWeights, nil
 }
 
 func (t *searchTask) PreExecute(ctx context.Context) error {
@@ -130,6 +264,23 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 	log.Debug("translate output fields", zap.Any("OutputFields", outputFields))
 	t.request.OutputFields = outputFields
 
+	// the mandatory filter is ANDed into the plan by query nodes, so that it could not be bypassed by the expression
+	t.SearchRequest.MandatoryFilter, t.SearchRequest.MandatoryFilterPlan, err = compileMandatoryFilter(ctx, t.mandatoryFilterHook, collectionName, schema)
+	if err != nil {
+		return err
+	}
+	if t.SearchRequest.MandatoryFilter != "" && t.request.GetDslType() != commonpb.DslType_BoolExprV1 {
+		return errors.New("mandatory filter is not supported by dsl search, please search with boolean expression")
+	}
+	if len(t.request.GetExprTemplateValues()) > 0 && t.request.GetDslType() != commonpb.DslType_BoolExprV1 {
+		return errors.New("expression template is not supported by dsl search, please search with boolean expression")
+	}
+
+	t.SearchRequest.MaxScannedSegments, err = parseMaxScannedSegments(t.request.SearchParams)
+	if err != nil {
+		return err
+	}
+
 	if t.request.GetDslType() == commonpb.DslType_BoolExprV1 {
 		annsField, err := funcutil.GetAttrByKeyFromRepeatedKV(AnnsFieldKey, t.request.SearchParams)
 		if err != nil {
@@ -163,15 +314,42 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 			return errors.New(RoundDecimalKey + " " + roundDecimalStr + " is not invalid")
 		}
 
-		if roundDecimal != -1 && (roundDecimal > 6 || roundDecimal < 0) {
-			return errors.New(RoundDecimalKey + " " + roundDecimalStr + " is not invalid")
+		normalizeScores, err := parseNormalizeScores(t.request.SearchParams, metricType)
+		if err != nil {
+			return err
+		}
+		t.SearchRequest.NormalizeScores = normalizeScores
+		t.scoreType = metricType
+		if normalizeScores {
+			t.scoreType = cosineScoreType
+		}
+
+		outputTimestamps, err := parseOutputTimestamps(t.request.SearchParams)
+		if err != nil {
+			return err
+		}
+
+		t.SearchRequest.WeightedPartitionIDs, t.SearchRequest.PartitionWeights, err = parsePartitionWeights(t.request.SearchParams,
+			metricType, partitionsMap, t.PartitionIDs)
+		if err != nil {
+			return err
+		}
+		if normalizeScores && len(t.SearchRequest.WeightedPartitionIDs) > 0 {
+			return fmt.Errorf("%s could not be used with %s", PartitionWeightsKey, NormalizeScoresKey)
 		}
 
 		queryInfo := &planpb.QueryInfo{
-			Topk:         int64(topK),
-			MetricType:   metricType,
-			SearchParams: searchParams,
-			RoundDecimal: int64(roundDecimal),
+			Topk:             int64(topK),
+			MetricType:       metricType,
+			SearchParams:     searchParams,
+			RoundDecimal:     int64(roundDecimal),
+			OutputTimestamps: outputTimestamps,
+		}
+
+		// fail fast with the checks shared with the query nodes
+		outputFieldIDs, err := validateSearchRequest(collID, schema, annsField, queryInfo, t.request.PlaceholderGroup, t.request.OutputFields)
+		if err != nil {
+			return err
 		}
 
 		log.Debug("create query plan",
@@ -180,7 +358,15 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 			zap.String("anns field", annsField),
 			zap.Any("query info", queryInfo))
 
-		plan, err := createQueryPlan(schema, t.request.Dsl, annsField, queryInfo)
+		t.request.Dsl, err = substituteExprTemplate(t.request.Dsl, t.request.GetExprTemplateValues(), schema)
+		if err != nil {
+			return err
+		}
+		expr, err := parseExpr(ctx, collectionName, schema, t.request.Dsl)
+		if err != nil {
+			return fmt.Errorf("failed to create query plan: %v", err)
+		}
+		plan, err := planparser.CreateQueryPlanWithPredicates(schema, expr, annsField, queryInfo)
 		if err != nil {
 			log.Debug("failed to create query plan",
 				zap.Error(err),
@@ -191,24 +377,22 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 
 			return fmt.Errorf("failed to create query plan: %v", err)
 		}
-		for _, name := range t.request.OutputFields {
-			hitField := false
+		for _, fieldID := range outputFieldIDs {
 			for _, field := range schema.Fields {
-				if field.Name == name {
-					if field.DataType == schemapb.DataType_BinaryVector || field.DataType == schemapb.DataType_FloatVector {
-						return errors.New("search doesn't support vector field as output_fields")
-					}
-
-					t.SearchRequest.OutputFieldsId = append(t.SearchRequest.OutputFieldsId, field.FieldID)
-					plan.OutputFieldIds = append(plan.OutputFieldIds, field.FieldID)
-					hitField = true
-					break
+				if field.FieldID == fieldID && typeutil.IsVectorType(field.DataType) {
+					t.requery = true
 				}
 			}
-			if !hitField {
-				errMsg := "Field " + name + " not exist"
-				return errors.New(errMsg)
-			}
+		}
+		t.SearchRequest.OutputFieldsId = append(t.SearchRequest.OutputFieldsId, outputFieldIDs...)
+		plan.OutputFieldIds = append(plan.OutputFieldIds, outputFieldIDs...)
+		if err := checkFieldsLoaded(ctx, t.qc, collectionName, schema, getPlanFieldIDs(plan)); err != nil {
+			return err
+		}
+		if t.requery {
+			// search ids and distances only, output fields are fetched after reduce
+			t.SearchRequest.OutputFieldsId = nil
+			plan.OutputFieldIds = nil
 		}
 
 		t.SearchRequest.DslType = commonpb.DslType_BoolExprV1
@@ -220,6 +404,10 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 			zap.Any("plan", plan.String()))
 	}
 	travelTimestamp := t.request.TravelTimestamp
+	if t.request.SnapshotTimestamp != 0 {
+		// search exactly at the pinned snapshot
+		travelTimestamp = t.request.SnapshotTimestamp
+	}
 	if travelTimestamp == 0 {
 		travelTimestamp = t.BeginTs()
 	} else {
@@ -230,11 +418,15 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 		}
 	}
 	guaranteeTimestamp := t.request.GuaranteeTimestamp
+	if t.request.SnapshotTimestamp != 0 {
+		guaranteeTimestamp = t.request.SnapshotTimestamp
+	}
 	if guaranteeTimestamp == 0 {
 		guaranteeTimestamp = t.BeginTs()
 	}
 	t.TravelTimestamp = travelTimestamp
 	t.GuaranteeTimestamp = guaranteeTimestamp
+	t.SnapshotTimestamp = t.request.SnapshotTimestamp
 	deadline, ok := t.TraceCtx().Deadline()
 	if ok {
 		t.SearchRequest.TimeoutTimestamp = tsoutil.ComposeTSByTime(deadline, 0)
@@ -245,7 +437,8 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 	t.SearchRequest.PlaceholderGroup = t.request.PlaceholderGroup
 
 	log.Info("search PreExecute done.",
-		zap.Any("requestID", t.Base.MsgID), zap.Any("requestType", "search"))
+		zap.Any("requestID", t.Base.MsgID), zap.Any("requestType", "search"),
+		zap.String("mandatoryFilter", t.SearchRequest.MandatoryFilter))
 	return nil
 }
 
@@ -334,6 +527,13 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 
 	wg.Wait()
 	tr.Record("decodeResultStart")
+	var skippedSegments int64
+	readTs := make([]Timestamp, 0, len(t.toReduceResults))
+	for _, result := range t.toReduceResults {
+		skippedSegments += result.GetSkippedSegments()
+		readTs = append(readTs, result.GetReadTimestamp())
+	}
+	snapshotTs := getReadTimestamp(t.TravelTimestamp, t.GuaranteeTimestamp, readTs)
 	validSearchResults, err := decodeSearchResults(t.toReduceResults)
 	if err != nil {
 		return err
@@ -348,7 +548,11 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 				ErrorCode: commonpb.ErrorCode_Success,
 				Reason:    "search result is empty",
 			},
-			CollectionName: t.collectionName,
+			CollectionName:    t.collectionName,
+			SnapshotTimestamp: snapshotTs,
+			ScoreType:         t.scoreType,
+			Partial:           skippedSegments > 0,
+			SkippedSegments:   skippedSegments,
 		}
 		// add information if any
 		if len(t.toReduceResults) > 0 {
@@ -365,8 +569,26 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if t.requery {
+		tr.Record("requeryStart")
+		if err := t.requeryOutputFields(ctx); err != nil {
+			log.Warn("failed to requery output fields", zap.Int64("msgID", t.ID()), zap.Error(err))
+			return err
+		}
+	}
+	if Params.ProxyCfg.ValidateSearchResult {
+		if err := typeutil.ValidateSearchResultData(t.result.GetResults()); err != nil {
+			metrics.ProxySearchResultViolations.WithLabelValues(strconv.FormatInt(Params.ProxyCfg.ProxyID, 10)).Inc()
+			log.Error("proxy reduced an invalid search result", zap.Int64("msgID", t.ID()), zap.Error(err))
+			return err
+		}
+	}
 	metrics.ProxyReduceSearchResultLatency.WithLabelValues(strconv.FormatInt(Params.ProxyCfg.ProxyID, 10), metrics.SuccessLabel).Observe(float64(tr.RecordSpan().Milliseconds()))
 	t.result.CollectionName = t.collectionName
+	t.result.SnapshotTimestamp = snapshotTs
+	t.result.ScoreType = t.scoreType
+	t.result.Partial = skippedSegments > 0
+	t.result.SkippedSegments = skippedSegments
 
 	schema, err := globalMetaCache.GetCollectionSchema(ctx, t.request.CollectionName)
 	if err != nil {
@@ -404,7 +626,11 @@ func (t *searchTask) searchShard(ctx context.Context, leaders *querypb.ShardLead
 		if result.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
 			log.Warn("QueryNode search result error", zap.Int64("nodeID", nodeID),
 				zap.String("reason", result.GetStatus().GetReason()))
-			return fmt.Errorf("fail to Search, QueryNode ID=%d, reason=%s", nodeID, result.GetStatus().GetReason())
+			return &shardLeaderError{op: "Search", nodeID: nodeID, status: result.GetStatus()}
+		}
+		if err := decompressSearchResults(result); err != nil {
+			log.Warn("fail to decompress search result", zap.Int64("nodeID", nodeID), zap.Error(err))
+			return err
 		}
 
 		t.resultBuf <- result
@@ -420,6 +646,163 @@ func (t *searchTask) searchShard(ctx context.Context, leaders *querypb.ShardLead
 	return nil
 }
 
+// requeryOutputFields queries the output fields of the reduced topk ids and stitches them into the search result
+func (t *searchTask) requeryOutputFields(ctx context.Context) error {
+	ids := t.result.GetResults().GetIds()
+	if typeutil.GetSizeOfIDs(ids) == 0 {
+		return nil
+	}
+
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, t.request.CollectionName)
+	if err != nil {
+		return err
+	}
+	pkField, err := typeutil.GetPrimaryFieldSchema(schema)
+	if err != nil {
+		return err
+	}
+
+	qt := &queryTask{
+		Condition: NewTaskCondition(t.TraceCtx()),
+		RetrieveRequest: &internalpb.RetrieveRequest{
+			Base: &commonpb.MsgBase{
+				MsgType:   commonpb.MsgType_Retrieve,
+				MsgID:     t.ID(),
+				Timestamp: t.BeginTs(),
+				SourceID:  Params.ProxyCfg.ProxyID,
+			},
+			RequestId: t.GetRequestId(),
+		},
+		ctx: t.TraceCtx(),
+		request: &milvuspb.QueryRequest{
+			Base: &commonpb.MsgBase{
+				MsgType:  commonpb.MsgType_Retrieve,
+				MsgID:    t.ID(),
+				SourceID: Params.ProxyCfg.ProxyID,
+			},
+			DbName:             t.request.GetDbName(),
+			CollectionName:     t.request.GetCollectionName(),
+			PartitionNames:     t.request.GetPartitionNames(),
+			OutputFields:       t.request.GetOutputFields(),
+			TravelTimestamp:    t.TravelTimestamp,
+			GuaranteeTimestamp: t.GuaranteeTimestamp,
+			SnapshotTimestamp:  t.SnapshotTimestamp,
+		},
+		qc:                  t.qc,
+		ids:                 ids,
+		getQueryNodePolicy:  t.getQueryNodePolicy,
+		queryShardPolicy:    t.searchShardPolicy,
+		mandatoryFilterHook: t.mandatoryFilterHook,
+	}
+	if err := qt.PreExecute(ctx); err != nil {
+		return err
+	}
+	if err := qt.Execute(ctx); err != nil {
+		return err
+	}
+	if err := qt.PostExecute(ctx); err != nil {
+		return err
+	}
+
+	dropped, err := stitchRequeryResults(t.result.GetResults(), qt.result.GetFieldsData(), pkField, t.request.GetOutputFields())
+	if err != nil {
+		return err
+	}
+	if dropped > 0 {
+		metrics.ProxyRequeryMissingHits.WithLabelValues(strconv.FormatInt(Params.ProxyCfg.ProxyID, 10)).Add(float64(dropped))
+		log.Warn("search hits are dropped since they are missing in requery results",
+			zap.Int64("msgID", t.ID()), zap.Int("count", dropped))
+	}
+	return nil
+}
+
+// stitchRequeryResults fills the output fields of search result with the requeried rows, preserving the hit order.
+// Hits missing in the requeried rows, deleted after search, are dropped. It returns the number of dropped hits.
+func stitchRequeryResults(data *schemapb.SearchResultData, fieldsData []*schemapb.FieldData,
+	pkField *schemapb.FieldSchema, outputFields []string) (int, error) {
+	var pkFieldData *schemapb.FieldData
+	srcFieldsData := make([]*schemapb.FieldData, 0, len(outputFields))
+	for _, fieldData := range fieldsData {
+		if fieldData.GetFieldId() == pkField.GetFieldID() {
+			pkFieldData = fieldData
+		}
+	}
+	// no rows are returned if all hits have been deleted
+	if len(fieldsData) > 0 {
+		for _, name := range outputFields {
+			found := false
+			for _, fieldData := range fieldsData {
+				if fieldData.GetFieldName() == name {
+					srcFieldsData = append(srcFieldsData, fieldData)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return 0, fmt.Errorf("output field %s not found in requery results", name)
+			}
+		}
+	}
+
+	offsets := make(map[interface{}]int64)
+	if pkFieldData != nil {
+		switch pkField.GetDataType() {
+		case schemapb.DataType_Int64:
+			for i, pk := range pkFieldData.GetScalars().GetLongData().GetData() {
+				offsets[pk] = int64(i)
+			}
+		case schemapb.DataType_VarChar:
+			for i, pk := range pkFieldData.GetScalars().GetStringData().GetData() {
+				offsets[pk] = int64(i)
+			}
+		default:
+			return 0, fmt.Errorf("unsupported primary key type %s", pkField.GetDataType().String())
+		}
+	}
+
+	ids := &schemapb.IDs{}
+	scores := make([]float32, 0, len(data.GetScores()))
+	topks := make([]int64, 0, len(data.GetTopks()))
+	var timestamps []uint64
+	dstFieldsData := make([]*schemapb.FieldData, len(srcFieldsData))
+	for i, fieldData := range srcFieldsData {
+		dstFieldsData[i] = &schemapb.FieldData{
+			Type:      fieldData.GetType(),
+			FieldName: fieldData.GetFieldName(),
+			FieldId:   fieldData.GetFieldId(),
+		}
+	}
+
+	dropped := 0
+	var idx int64
+	for _, topk := range data.GetTopks() {
+		var realTopK int64
+		for j := int64(0); j < topk; j++ {
+			offset, ok := offsets[typeutil.GetPK(data.GetIds(), idx)]
+			if ok {
+				typeutil.AppendIDs(ids, data.GetIds(), int(idx))
+				scores = append(scores, data.GetScores()[idx])
+				if len(data.GetTimestamps()) > 0 {
+					timestamps = append(timestamps, data.GetTimestamps()[idx])
+				}
+				typeutil.AppendFieldData(dstFieldsData, srcFieldsData, offset)
+				realTopK++
+			} else {
+				dropped++
+			}
+			idx++
+		}
+		topks = append(topks, realTopK)
+	}
+
+	data.Ids = ids
+	data.Scores = scores
+	data.Topks = topks
+	data.FieldsData = dstFieldsData
+	data.Timestamps = timestamps
+	return dropped, nil
+}
+
 func (t *searchTask) checkIfLoaded(collectionID UniqueID, searchPartitionIDs []UniqueID) bool {
 	// If request to search partitions
 	if len(searchPartitionIDs) > 0 {
@@ -559,6 +942,14 @@ func checkSearchResultData(data *schemapb.SearchResultData, nq int64, topk int64
 		return fmt.Errorf("search result's score length invalid, score length=%d, expectedLength=%d",
 			len(data.Scores), expectedLength)
 	}
+	if len(data.RawScores) != 0 && len(data.RawScores) != expectedLength {
+		return fmt.Errorf("search result's raw score length invalid, raw score length=%d, expectedLength=%d",
+			len(data.RawScores), expectedLength)
+	}
+	if len(data.Timestamps) != 0 && len(data.Timestamps) != expectedLength {
+		return fmt.Errorf("search result's timestamp length invalid, timestamp length=%d, expectedLength=%d",
+			len(data.Timestamps), expectedLength)
+	}
 	return nil
 }
 
@@ -627,6 +1018,10 @@ func reduceSearchResultData(searchResultData []*schemapb.SearchResultData, nq in
 
 	var skipDupCnt int64
 	var realTopK int64 = -1
+	// insert timestamps of hits are carried only if requested by output_timestamps
+	withTimestamps := typeutil.HasSearchTimestamps(searchResultData)
+	// the hits are ranked by the weighted scores if partition_weights is set, but the unweighted ones are returned
+	withRawScores := typeutil.HasSearchRawScores(searchResultData)
 	for i := int64(0); i < nq; i++ {
 		offsets := make([]int64, len(searchResultData))
 
@@ -651,6 +1046,12 @@ func reduceSearchResultData(searchResultData []*schemapb.SearchResultData, nq in
 				typeutil.AppendFieldData(ret.Results.FieldsData, searchResultData[sel].FieldsData, idx)
 				ret.Results.Ids.GetIntId().Data = append(ret.Results.Ids.GetIntId().Data, id)
 				ret.Results.Scores = append(ret.Results.Scores, score)
+				if withTimestamps {
+					typeutil.AppendSearchTimestamp(ret.Results, searchResultData[sel], idx)
+				}
+				if withRawScores {
+					typeutil.AppendSearchRawScore(ret.Results, searchResultData[sel], idx)
+				}
 				idSet[id] = struct{}{}
 				j++
 			} else {
@@ -669,6 +1070,10 @@ func reduceSearchResultData(searchResultData []*schemapb.SearchResultData, nq in
 	log.Debug("skip duplicated search result", zap.Int64("count", skipDupCnt))
 	ret.Results.TopK = realTopK
 
+	if withRawScores {
+		ret.Results.Scores = ret.Results.RawScores
+		ret.Results.RawScores = nil
+	}
 	if !distance.PositivelyRelated(metricType) {
 		for k := range ret.Results.Scores {
 			ret.Results.Scores[k] *= -1
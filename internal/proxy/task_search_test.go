@@ -21,6 +21,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util/distance"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
 	"github.com/milvus-io/milvus/internal/util/timerecord"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
@@ -59,6 +60,40 @@ func TestSearchTask_PostExecute(t *testing.T) {
 		err := qt.PostExecute(context.TODO())
 		assert.NoError(t, err)
 		assert.Equal(t, qt.result.Status.ErrorCode, commonpb.ErrorCode_Success)
+		assert.False(t, qt.result.GetPartial())
+	})
+
+	t.Run("Test partial result", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		qt := &searchTask{
+			ctx:       ctx,
+			Condition: NewTaskCondition(context.TODO()),
+			SearchRequest: &internalpb.SearchRequest{
+				Base: &commonpb.MsgBase{
+					MsgType:  commonpb.MsgType_Search,
+					SourceID: Params.ProxyCfg.ProxyID,
+				},
+			},
+			tr: timerecord.NewTimeRecorder("search"),
+
+			resultBuf:       make(chan *internalpb.SearchResults, 10),
+			toReduceResults: make([]*internalpb.SearchResults, 0),
+		}
+		// the segments skipped by the shard leaders are summed up
+		qt.resultBuf <- &internalpb.SearchResults{SkippedSegments: 3}
+		qt.resultBuf <- &internalpb.SearchResults{SkippedSegments: 4}
+		qt.resultBuf <- &internalpb.SearchResults{}
+
+		mockctx, mockcancel := context.WithCancel(ctx)
+		qt.runningGroupCtx = mockctx
+		mockcancel()
+
+		err := qt.PostExecute(context.TODO())
+		assert.NoError(t, err)
+		assert.True(t, qt.result.GetPartial())
+		assert.Equal(t, int64(7), qt.result.GetSkippedSegments())
 	})
 }
 
@@ -109,6 +144,13 @@ func getValidSearchParams() []*commonpb.KeyValuePair {
 		}}
 }
 
+// getValidPlaceholderGroup returns the serialized placeholder group of nq query vectors of the test float vector field
+func getValidPlaceholderGroup(t *testing.T, nq int) []byte {
+	placeholderGroup, err := proto.Marshal(constructPlaceholderGroup(nq, testVecDim))
+	require.NoError(t, err)
+	return placeholderGroup
+}
+
 func TestSearchTask_PreExecute(t *testing.T) {
 	var err error
 
@@ -136,7 +178,8 @@ func TestSearchTask_PreExecute(t *testing.T) {
 			ctx:           ctx,
 			SearchRequest: &internalpb.SearchRequest{},
 			request: &milvuspb.SearchRequest{
-				CollectionName: collName,
+				CollectionName:   collName,
+				PlaceholderGroup: getValidPlaceholderGroup(t, 2),
 			},
 			qc: qc,
 			tr: timerecord.NewTimeRecorder("test-search"),
@@ -384,12 +427,284 @@ func TestSearchTask_PreExecute(t *testing.T) {
 		task.request.OutputFields = []string{testInt64Field + funcutil.GenRandomStr()}
 		assert.Error(t, task.PreExecute(ctx))
 
-		// contain vector field
+		// contain vector field, requery the output fields after reduce
+		task.SearchRequest.OutputFieldsId = nil
 		task.request.OutputFields = []string{testFloatVecField}
+		assert.NoError(t, task.PreExecute(ctx))
+		assert.True(t, task.requery)
+		assert.Empty(t, task.SearchRequest.OutputFieldsId)
+	})
+
+	t.Run("search at snapshot", func(t *testing.T) {
+		collName := "search_at_snapshot" + funcutil.GenRandomStr()
+		createColl(t, collName, rc)
+		collID, err := globalMetaCache.GetCollectionID(context.TODO(), collName)
+		require.NoError(t, err)
+		status, err := qc.LoadCollection(ctx, &querypb.LoadCollectionRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_LoadCollection,
+			},
+			CollectionID: collID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+
+		now := time.Now()
+		task := getSearchTask(t, collName)
+		task.SetTs(tsoutil.ComposeTSByTime(now, 0))
+		task.request.SearchParams = getValidSearchParams()
+		task.request.DslType = commonpb.DslType_BoolExprV1
+
+		snapshotTs := tsoutil.ComposeTSByTime(now.Add(-time.Minute), 0)
+		task.request.TravelTimestamp = snapshotTs + 1
+		task.request.GuaranteeTimestamp = snapshotTs + 2
+		task.request.SnapshotTimestamp = snapshotTs
+		assert.NoError(t, task.PreExecute(ctx))
+		assert.Equal(t, snapshotTs, task.TravelTimestamp)
+		assert.Equal(t, snapshotTs, task.GuaranteeTimestamp)
+		assert.Equal(t, snapshotTs, task.SnapshotTimestamp)
+
+		// snapshot beyond retention duration
+		retention := time.Duration(Params.CommonCfg.RetentionDuration) * time.Second
+		task.request.SnapshotTimestamp = tsoutil.ComposeTSByTime(now.Add(-retention-time.Hour), 0)
 		assert.Error(t, task.PreExecute(ctx))
 	})
 }
 
+func TestSearchTask_requery(t *testing.T) {
+	Params.Init()
+
+	var (
+		err error
+		ctx = context.TODO()
+
+		rc = NewRootCoordMock()
+		qc = NewQueryCoordMock(withValidShardLeaders())
+		qn = &QueryNodeMock{}
+
+		collectionName = t.Name() + funcutil.GenRandomStr()
+	)
+
+	mockGetQueryNodePolicy := func(ctx context.Context, address string) (types.QueryNode, error) {
+		return qn, nil
+	}
+
+	require.NoError(t, rc.Start())
+	defer rc.Stop()
+	require.NoError(t, qc.Start())
+	defer qc.Stop()
+	require.NoError(t, InitMetaCache(rc))
+
+	createColl(t, collectionName, rc)
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, collectionName)
+	require.NoError(t, err)
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, collectionName)
+	require.NoError(t, err)
+	status, err := qc.LoadCollection(ctx, &querypb.LoadCollectionRequest{
+		Base: &commonpb.MsgBase{
+			MsgType: commonpb.MsgType_LoadCollection,
+		},
+		CollectionID: collectionID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+
+	var pkFieldID, vecFieldID int64
+	for _, field := range schema.GetFields() {
+		switch field.GetName() {
+		case testInt64Field:
+			pkFieldID = field.GetFieldID()
+		case testFloatVecField:
+			vecFieldID = field.GetFieldID()
+		}
+	}
+
+	task := &searchTask{
+		ctx:       ctx,
+		Condition: NewTaskCondition(ctx),
+		SearchRequest: &internalpb.SearchRequest{
+			Base: &commonpb.MsgBase{
+				MsgType:   commonpb.MsgType_Search,
+				Timestamp: uint64(time.Now().UnixNano()),
+			},
+		},
+		request: &milvuspb.SearchRequest{
+			CollectionName:   collectionName,
+			DslType:          commonpb.DslType_BoolExprV1,
+			SearchParams:     getValidSearchParams(),
+			OutputFields:     []string{testFloatVecField},
+			PlaceholderGroup: getValidPlaceholderGroup(t, 2),
+		},
+		qc: qc,
+		tr: timerecord.NewTimeRecorder("search"),
+
+		getQueryNodePolicy: mockGetQueryNodePolicy,
+		searchShardPolicy:  roundRobinPolicy,
+	}
+	require.NoError(t, task.OnEnqueue())
+	require.NoError(t, task.PreExecute(ctx))
+	require.True(t, task.requery)
+
+	// querynodes return ids and distances only
+	searchData := genSearchResultData(2, 2, []int64{1, 2, 3, 4}, []float32{-1, -2, -3, -4})
+	blob, err := proto.Marshal(searchData)
+	require.NoError(t, err)
+	qn.withSearchResult = &internalpb.SearchResults{
+		Status:     &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		MetricType: distance.L2,
+		NumQueries: 2,
+		TopK:       2,
+		SlicedBlob: blob,
+	}
+
+	// id 2 is deleted before requery
+	pks := []int64{4, 1, 3}
+	vectors := make([]float32, 0, len(pks)*testVecDim)
+	for _, pk := range pks {
+		for i := 0; i < testVecDim; i++ {
+			vectors = append(vectors, float32(pk))
+		}
+	}
+	qn.withQueryResult = &internalpb.RetrieveResults{
+		Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Ids: &schemapb.IDs{
+			IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}},
+		},
+		FieldsData: []*schemapb.FieldData{
+			{
+				Type:    schemapb.DataType_Int64,
+				FieldId: pkFieldID,
+				Field: &schemapb.FieldData_Scalars{
+					Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: pks}},
+					},
+				},
+			},
+			{
+				Type:    schemapb.DataType_FloatVector,
+				FieldId: vecFieldID,
+				Field: &schemapb.FieldData_Vectors{
+					Vectors: &schemapb.VectorField{
+						Dim:  int64(testVecDim),
+						Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: vectors}},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, task.Execute(ctx))
+	require.NoError(t, task.PostExecute(ctx))
+
+	result := task.result.GetResults()
+	assert.Equal(t, []int64{1, 2}, result.GetTopks())
+	assert.Equal(t, []int64{1, 3, 4}, result.GetIds().GetIntId().GetData())
+	assert.Equal(t, []float32{1, 3, 4}, result.GetScores())
+	require.Equal(t, 1, len(result.GetFieldsData()))
+	assert.Equal(t, testFloatVecField, result.GetFieldsData()[0].GetFieldName())
+	vecData := result.GetFieldsData()[0].GetVectors().GetFloatVector().GetData()
+	require.Equal(t, 3*testVecDim, len(vecData))
+	for i, pk := range []int64{1, 3, 4} {
+		assert.Equal(t, float32(pk), vecData[i*testVecDim])
+	}
+}
+
+func Test_stitchRequeryResults(t *testing.T) {
+	genVarCharFieldData := func(fieldID int64, name string, data []string) *schemapb.FieldData {
+		return &schemapb.FieldData{
+			Type:      schemapb.DataType_VarChar,
+			FieldName: name,
+			FieldId:   fieldID,
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: data}},
+				},
+			},
+		}
+	}
+	genInt64FieldData := func(fieldID int64, name string, data []int64) *schemapb.FieldData {
+		return &schemapb.FieldData{
+			Type:      schemapb.DataType_Int64,
+			FieldName: name,
+			FieldId:   fieldID,
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: data}},
+				},
+			},
+		}
+	}
+
+	t.Run("varchar pk", func(t *testing.T) {
+		pkField := &schemapb.FieldSchema{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_VarChar}
+		data := &schemapb.SearchResultData{
+			NumQueries: 2,
+			TopK:       2,
+			Topks:      []int64{2, 1},
+			Scores:     []float32{0.3, 0.2, 0.1},
+			Ids: &schemapb.IDs{
+				IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: []string{"c", "a", "b"}}},
+			},
+		}
+		fieldsData := []*schemapb.FieldData{
+			genVarCharFieldData(100, "pk", []string{"a", "b", "c"}),
+			genInt64FieldData(101, "age", []int64{10, 20, 30}),
+		}
+
+		dropped, err := stitchRequeryResults(data, fieldsData, pkField, []string{"age", "pk"})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, dropped)
+		assert.Equal(t, []int64{2, 1}, data.GetTopks())
+		assert.Equal(t, []string{"c", "a", "b"}, data.GetIds().GetStrId().GetData())
+		assert.Equal(t, []int64{30, 10, 20}, data.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+		assert.Equal(t, []string{"c", "a", "b"}, data.GetFieldsData()[1].GetScalars().GetStringData().GetData())
+		assert.NoError(t, typeutil.ValidateSearchResultData(data))
+	})
+
+	t.Run("int64 pk with missing rows", func(t *testing.T) {
+		pkField := &schemapb.FieldSchema{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64}
+		data := genSearchResultData(2, 2, []int64{1, 2, 3, 4}, []float32{0.4, 0.3, 0.2, 0.1})
+		data.Topks = []int64{2, 2}
+		data.Timestamps = []uint64{11, 12, 13, 14}
+		fieldsData := []*schemapb.FieldData{
+			genInt64FieldData(100, "pk", []int64{4, 1}),
+			genVarCharFieldData(101, "name", []string{"d", "a"}),
+		}
+
+		dropped, err := stitchRequeryResults(data, fieldsData, pkField, []string{"name"})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, dropped)
+		assert.Equal(t, []int64{1, 1}, data.GetTopks())
+		assert.Equal(t, []int64{1, 4}, data.GetIds().GetIntId().GetData())
+		assert.Equal(t, []float32{0.4, 0.1}, data.GetScores())
+		assert.Equal(t, []uint64{11, 14}, data.GetTimestamps())
+		assert.Equal(t, []string{"a", "d"}, data.GetFieldsData()[0].GetScalars().GetStringData().GetData())
+		assert.NoError(t, typeutil.ValidateSearchResultData(data))
+	})
+
+	t.Run("all rows missing", func(t *testing.T) {
+		pkField := &schemapb.FieldSchema{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64}
+		data := genSearchResultData(1, 2, []int64{1, 2}, []float32{0.2, 0.1})
+		data.Topks = []int64{2}
+
+		dropped, err := stitchRequeryResults(data, nil, pkField, []string{"name"})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, dropped)
+		assert.Equal(t, []int64{0}, data.GetTopks())
+		assert.Equal(t, 0, typeutil.GetSizeOfIDs(data.GetIds()))
+	})
+
+	t.Run("output field not found", func(t *testing.T) {
+		pkField := &schemapb.FieldSchema{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64}
+		data := genSearchResultData(1, 1, []int64{1}, []float32{0.1})
+		data.Topks = []int64{1}
+		fieldsData := []*schemapb.FieldData{genInt64FieldData(100, "pk", []int64{1})}
+
+		_, err := stitchRequeryResults(data, fieldsData, pkField, []string{"name"})
+		assert.Error(t, err)
+	})
+}
+
 func TestSearchTaskV2_Execute(t *testing.T) {
 	Params.Init()
 
@@ -451,6 +766,55 @@ func genSearchResultData(nq int64, topk int64, ids []int64, scores []float32) *s
 	}
 }
 
+func TestSearchTask_reduceTimestamps(t *testing.T) {
+	const (
+		nq   = 1
+		topk = 4
+	)
+	data1 := genSearchResultData(nq, topk, []int64{1, 2, 3, 4}, []float32{-1.0, -2.0, -3.0, -4.0})
+	data1.Timestamps = []uint64{11, 12, 13, 14}
+	data2 := genSearchResultData(nq, topk, []int64{5, 1, 3, 4}, []float32{-1.0, -1.5, -3.0, -4.0})
+	data2.Timestamps = []uint64{25, 21, 23, 24}
+
+	res, err := reduceSearchResultData([]*schemapb.SearchResultData{data1, data2}, nq, topk, distance.IP)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 5, 2, 3}, res.GetResults().GetIds().GetIntId().GetData())
+	// the timestamp of the duplicate with the higher score is kept
+	assert.Equal(t, []uint64{11, 25, 12, 13}, res.GetResults().GetTimestamps())
+
+	data1.Timestamps, data2.Timestamps = nil, nil
+	res, err = reduceSearchResultData([]*schemapb.SearchResultData{data1, data2}, nq, topk, distance.IP)
+	assert.NoError(t, err)
+	assert.Nil(t, res.GetResults().GetTimestamps())
+
+	data1.Timestamps = []uint64{11}
+	_, err = reduceSearchResultData([]*schemapb.SearchResultData{data1, data2}, nq, topk, distance.IP)
+	assert.Error(t, err)
+}
+
+func TestSearchTask_reduceRawScores(t *testing.T) {
+	const (
+		nq   = 1
+		topk = 3
+	)
+	// L2 distances are negated in query nodes, data1 is weighted 2 and data2 is weighted 1
+	data1 := genSearchResultData(nq, topk, []int64{1, 2, 3}, []float32{-1.0, -2.0, -4.0})
+	data1.RawScores = []float32{-2.0, -4.0, -8.0}
+	data2 := genSearchResultData(nq, topk, []int64{4, 5, 6}, []float32{-1.5, -3.0, -5.0})
+	data2.RawScores = []float32{-1.5, -3.0, -5.0}
+
+	// ranked by the weighted distances, but the unweighted distances are returned
+	res, err := reduceSearchResultData([]*schemapb.SearchResultData{data1, data2}, nq, topk, distance.L2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 4, 2}, res.GetResults().GetIds().GetIntId().GetData())
+	assert.Equal(t, []float32{2.0, 1.5, 4.0}, res.GetResults().GetScores())
+	assert.Nil(t, res.GetResults().GetRawScores())
+
+	data1.RawScores = []float32{-2.0}
+	_, err = reduceSearchResultData([]*schemapb.SearchResultData{data1, data2}, nq, topk, distance.L2)
+	assert.Error(t, err)
+}
+
 func TestSearchTask_Ts(t *testing.T) {
 	Params.Init()
 	task := &searchTask{
@@ -1229,3 +1593,130 @@ func TestSearchTaskV2_7803_reduce(t *testing.T) {
 	// cancel()
 	// wg.Wait()
 }
+
+func TestSearchTask_parseNormalizeScores(t *testing.T) {
+	kvs := func(normalize string) []*commonpb.KeyValuePair {
+		return []*commonpb.KeyValuePair{{Key: NormalizeScoresKey, Value: normalize}}
+	}
+
+	normalize, err := parseNormalizeScores(nil, distance.L2)
+	assert.NoError(t, err)
+	assert.False(t, normalize)
+
+	normalize, err = parseNormalizeScores(kvs("true"), distance.IP)
+	assert.NoError(t, err)
+	assert.True(t, normalize)
+
+	normalize, err = parseNormalizeScores(kvs("false"), distance.L2)
+	assert.NoError(t, err)
+	assert.False(t, normalize)
+
+	_, err = parseNormalizeScores(kvs("true"), distance.L2)
+	assert.Error(t, err)
+
+	_, err = parseNormalizeScores(kvs("yes please"), distance.IP)
+	assert.Error(t, err)
+}
+
+func TestSearchTask_parseOutputTimestamps(t *testing.T) {
+	kvs := func(output string) []*commonpb.KeyValuePair {
+		return []*commonpb.KeyValuePair{{Key: OutputTimestampsKey, Value: output}}
+	}
+
+	output, err := parseOutputTimestamps(nil)
+	assert.NoError(t, err)
+	assert.False(t, output)
+
+	output, err = parseOutputTimestamps(kvs("true"))
+	assert.NoError(t, err)
+	assert.True(t, output)
+
+	output, err = parseOutputTimestamps(kvs("false"))
+	assert.NoError(t, err)
+	assert.False(t, output)
+
+	_, err = parseOutputTimestamps(kvs("yes please"))
+	assert.Error(t, err)
+}
+
+func TestSearchTask_parseMaxScannedSegments(t *testing.T) {
+	kvs := func(maxSegments string) []*commonpb.KeyValuePair {
+		return []*commonpb.KeyValuePair{{Key: MaxScannedSegmentsKey, Value: maxSegments}}
+	}
+
+	maxSegments, err := parseMaxScannedSegments(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), maxSegments)
+
+	maxSegments, err = parseMaxScannedSegments(kvs("50"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50), maxSegments)
+
+	for _, invalid := range []string{"-1", "1.5", "all"} {
+		_, err = parseMaxScannedSegments(kvs(invalid))
+		assert.Error(t, err)
+	}
+}
+
+func TestSearchTask_parsePartitionWeights(t *testing.T) {
+	kvs := func(weights string) []*commonpb.KeyValuePair {
+		return []*commonpb.KeyValuePair{{Key: PartitionWeightsKey, Value: weights}}
+	}
+	partitions := map[string]UniqueID{"_default": 1, "recent": 2, "archive": 3}
+
+	t.Run("not weighted", func(t *testing.T) {
+		ids, weights, err := parsePartitionWeights(nil, distance.IP, partitions, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, ids)
+		assert.Nil(t, weights)
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		for _, metricType := range []string{distance.IP, distance.L2} {
+			ids, weights, err := parsePartitionWeights(kvs(`{"recent": 2, "archive": 0.5}`), metricType, partitions, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, []UniqueID{2, 3}, ids)
+			assert.Equal(t, []float32{2, 0.5}, weights)
+		}
+
+		// the weights of the partitions not searched are dropped
+		searched := []UniqueID{3, 1}
+		ids, weights, err := parsePartitionWeights(kvs(`{"recent": 2, "archive": 0.5}`), distance.L2, partitions, searched)
+		assert.NoError(t, err)
+		assert.Equal(t, []UniqueID{3}, ids)
+		assert.Equal(t, []float32{0.5}, weights)
+		assert.Equal(t, []UniqueID{3, 1}, searched)
+	})
+
+	t.Run("equal weights", func(t *testing.T) {
+		ids, _, err := parsePartitionWeights(kvs(`{"_default": 3, "recent": 3, "archive": 3}`), distance.IP, partitions, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, ids)
+
+		ids, _, err = parsePartitionWeights(kvs(`{"recent": 1}`), distance.L2, partitions, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, ids)
+
+		// equal among the searched partitions
+		ids, _, err = parsePartitionWeights(kvs(`{"recent": 2, "archive": 2}`), distance.L2, partitions, []UniqueID{2, 3})
+		assert.NoError(t, err)
+		assert.Nil(t, ids)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := parsePartitionWeights(kvs(`recent=2`), distance.IP, partitions, nil)
+		assert.Error(t, err)
+
+		_, _, err = parsePartitionWeights(kvs(`{"recent": 2}`), distance.HAMMING, partitions, nil)
+		assert.Error(t, err)
+
+		_, _, err = parsePartitionWeights(kvs(`{"unknown": 2}`), distance.IP, partitions, nil)
+		assert.Error(t, err)
+
+		_, _, err = parsePartitionWeights(kvs(`{"recent": 0}`), distance.IP, partitions, nil)
+		assert.Error(t, err)
+
+		_, _, err = parsePartitionWeights(kvs(`{"recent": -1}`), distance.L2, partitions, nil)
+		assert.Error(t, err)
+	})
+}
@@ -527,3 +527,21 @@ func ValidatePassword(password string) error {
 	}
 	return nil
 }
+
+// getReadTimestamp returns the ts the results of a read at travelTs cover all the data up to, which is the min of the
+// read ts reported by the shard leaders, the guarantee ts is taken instead for the leaders not reporting it
+func getReadTimestamp(travelTs, guaranteeTs Timestamp, reportedTs []Timestamp) Timestamp {
+	readTs := travelTs
+	if len(reportedTs) == 0 && guaranteeTs < readTs {
+		readTs = guaranteeTs
+	}
+	for _, ts := range reportedTs {
+		if ts == 0 {
+			ts = guaranteeTs
+		}
+		if ts < readTs {
+			readTs = ts
+		}
+	}
+	return readTs
+}
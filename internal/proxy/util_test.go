@@ -562,3 +562,13 @@ func TestValidatePassword(t *testing.T) {
 	res = ValidatePassword("aaaaaaaaaabbbbbbbbbbccccccccccddddddddddeeeeeeeeeeffffffffffgggggggggghhhhhhhhhhiiiiiiiiiijjjjjjjjjjkkkkkkkkkkllllllllllmmmmmmmmmnnnnnnnnnnnooooooooooppppppppppqqqqqqqqqqrrrrrrrrrrsssssssssstttttttttttuuuuuuuuuuuvvvvvvvvvvwwwwwwwwwwwxxxxxxxxxxyyyyyyyyyzzzzzzzzzzz")
 	assert.Error(t, res)
 }
+
+func TestGetReadTimestamp(t *testing.T) {
+	assert.Equal(t, Timestamp(100), getReadTimestamp(100, 200, nil))
+	assert.Equal(t, Timestamp(50), getReadTimestamp(100, 50, nil))
+	assert.Equal(t, Timestamp(100), getReadTimestamp(100, 50, []Timestamp{100, 120}))
+	// bounded by the lagging shard
+	assert.Equal(t, Timestamp(80), getReadTimestamp(100, 50, []Timestamp{100, 80}))
+	// unknown read ts of a shard
+	assert.Equal(t, Timestamp(50), getReadTimestamp(100, 50, []Timestamp{100, 0}))
+}
@@ -51,6 +51,7 @@ type Cluster interface {
 
 	loadSegments(ctx context.Context, nodeID int64, in *querypb.LoadSegmentsRequest) error
 	releaseSegments(ctx context.Context, nodeID int64, in *querypb.ReleaseSegmentsRequest) error
+	syncDistribution(ctx context.Context, nodeID int64, in *querypb.SyncDistributionRequest) error
 
 	watchDmChannels(ctx context.Context, nodeID int64, in *querypb.WatchDmChannelsRequest) error
 	watchDeltaChannels(ctx context.Context, nodeID int64, in *querypb.WatchDeltaChannelsRequest) error
@@ -248,6 +249,26 @@ func (c *queryNodeCluster) releaseSegments(ctx context.Context, nodeID int64, in
 	return fmt.Errorf("releaseSegments: can't find QueryNode by nodeID, nodeID = %d", nodeID)
 }
 
+func (c *queryNodeCluster) syncDistribution(ctx context.Context, nodeID int64, in *querypb.SyncDistributionRequest) error {
+	c.RLock()
+	var targetNode Node
+	if node, ok := c.nodes[nodeID]; ok {
+		targetNode = node
+	}
+	c.RUnlock()
+
+	if targetNode != nil {
+		err := targetNode.syncDistribution(ctx, in)
+		if err != nil {
+			log.Debug("syncDistribution: queryNode sync distribution error", zap.Int64("nodeID", nodeID), zap.String("error info", err.Error()))
+			return err
+		}
+		return nil
+	}
+
+	return fmt.Errorf("syncDistribution: can't find QueryNode by nodeID, nodeID = %d", nodeID)
+}
+
 func (c *queryNodeCluster) watchDmChannels(ctx context.Context, nodeID int64, in *querypb.WatchDmChannelsRequest) error {
 	c.RLock()
 	var targetNode Node
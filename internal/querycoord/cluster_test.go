@@ -352,7 +352,7 @@ func generateIndex(indexBuildID UniqueID, cm storage.ChunkManager) ([]string, er
 
 func generateIndexFileInfo(indexBuildIDs []int64, cm storage.ChunkManager) ([]*indexpb.IndexFilePathInfo, error) {
 	schema := genDefaultCollectionSchema(false)
-	sizePerRecord, _ := typeutil.EstimateSizePerRecord(schema)
+	sizePerRecord, _ := typeutil.EstimateRowSize(schema, nil)
 
 	var indexInfos []*indexpb.IndexFilePathInfo
 	for _, buildID := range indexBuildIDs {
@@ -540,6 +540,20 @@ func TestGrpcRequest(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("Test SyncDistribution", func(t *testing.T) {
+		syncDistributionReq := &querypb.SyncDistributionRequest{
+			NodeID:       nodeID,
+			CollectionID: defaultCollectionID,
+			Actions:      []*querypb.SegmentServingAction{{SegmentID: defaultSegmentID, Serving: true}},
+			Version:      1,
+		}
+		err := cluster.syncDistribution(baseCtx, nodeID, syncDistributionReq)
+		assert.Nil(t, err)
+
+		err = cluster.syncDistribution(baseCtx, -1, syncDistributionReq)
+		assert.NotNil(t, err)
+	})
+
 	t.Run("Test AddQueryChannel", func(t *testing.T) {
 		info := cluster.clusterMeta.getQueryChannelInfoByID(defaultCollectionID)
 		addQueryChannelReq := &querypb.AddQueryChannelRequest{
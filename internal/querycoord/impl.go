@@ -30,6 +30,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
@@ -114,9 +115,11 @@ func (qc *QueryCoord) ShowCollections(ctx context.Context, req *querypb.ShowColl
 		inMemoryCollectionIDs = append(inMemoryCollectionIDs, info.CollectionID)
 	}
 	inMemoryPercentages := make([]int64, 0)
+	loadedFields := make([]*schemapb.LongArray, 0)
 	if len(req.CollectionIDs) == 0 {
 		for _, id := range inMemoryCollectionIDs {
 			inMemoryPercentages = append(inMemoryPercentages, ID2collectionInfo[id].InMemoryPercentage)
+			loadedFields = append(loadedFields, getLoadedFields(ID2collectionInfo[id]))
 		}
 		log.Debug("show collection end",
 			zap.String("role", typeutil.QueryCoordRole),
@@ -127,6 +130,7 @@ func (qc *QueryCoord) ShowCollections(ctx context.Context, req *querypb.ShowColl
 			Status:              status,
 			CollectionIDs:       inMemoryCollectionIDs,
 			InMemoryPercentages: inMemoryPercentages,
+			LoadedFields:        loadedFields,
 		}, nil
 	}
 	for _, id := range req.CollectionIDs {
@@ -144,6 +148,7 @@ func (qc *QueryCoord) ShowCollections(ctx context.Context, req *querypb.ShowColl
 			}, nil
 		}
 		inMemoryPercentages = append(inMemoryPercentages, ID2collectionInfo[id].InMemoryPercentage)
+		loadedFields = append(loadedFields, getLoadedFields(ID2collectionInfo[id]))
 	}
 	log.Debug("show collection end",
 		zap.String("role", typeutil.QueryCoordRole),
@@ -154,6 +159,7 @@ func (qc *QueryCoord) ShowCollections(ctx context.Context, req *querypb.ShowColl
 		Status:              status,
 		CollectionIDs:       req.CollectionIDs,
 		InMemoryPercentages: inMemoryPercentages,
+		LoadedFields:        loadedFields,
 	}, nil
 }
 
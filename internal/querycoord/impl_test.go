@@ -261,6 +261,10 @@ func TestGrpcTask(t *testing.T) {
 		})
 		assert.Equal(t, commonpb.ErrorCode_Success, res.Status.ErrorCode)
 		assert.Nil(t, err)
+		info, err := queryCoord.meta.getCollectionInfoByID(defaultCollectionID)
+		assert.NoError(t, err)
+		assert.Len(t, res.GetLoadedFields(), 1)
+		assert.Len(t, res.GetLoadedFields()[0].GetData(), len(info.GetSchema().GetFields()))
 	})
 
 	t.Run("Test ShowNotLoadedCollections", func(t *testing.T) {
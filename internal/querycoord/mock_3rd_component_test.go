@@ -105,7 +105,7 @@ func genDefaultCollectionSchema(isBinary bool) *schemapb.CollectionSchema {
 
 func generateInsertBinLog(segmentID UniqueID) *datapb.SegmentBinlogs {
 	schema := genDefaultCollectionSchema(false)
-	sizePerRecord, _ := typeutil.EstimateSizePerRecord(schema)
+	sizePerRecord, _ := typeutil.EstimateRowSize(schema, nil)
 
 	var fieldBinLogs []*datapb.FieldBinlog
 	for _, field := range schema.Fields {
@@ -130,6 +130,10 @@ func (client *queryNodeClientMock) WatchDmChannels(ctx context.Context, req *que
 	return client.grpcClient.WatchDmChannels(ctx, req)
 }
 
+func (client *queryNodeClientMock) WatchDmChannelsWithStatus(ctx context.Context, req *querypb.WatchDmChannelsRequest) (*querypb.WatchDmChannelsResponse, error) {
+	return client.grpcClient.WatchDmChannelsWithStatus(ctx, req)
+}
+
 func (client *queryNodeClientMock) WatchDeltaChannels(ctx context.Context, req *querypb.WatchDeltaChannelsRequest) (*commonpb.Status, error) {
 	return client.grpcClient.WatchDeltaChannels(ctx, req)
 }
@@ -154,6 +158,38 @@ func (client *queryNodeClientMock) GetSegmentInfo(ctx context.Context, req *quer
 	return client.grpcClient.GetSegmentInfo(ctx, req)
 }
 
+func (client *queryNodeClientMock) SyncDistribution(ctx context.Context, req *querypb.SyncDistributionRequest) (*commonpb.Status, error) {
+	return client.grpcClient.SyncDistribution(ctx, req)
+}
+
+func (client *queryNodeClientMock) PauseChannel(ctx context.Context, req *querypb.PauseChannelRequest) (*commonpb.Status, error) {
+	return client.grpcClient.PauseChannel(ctx, req)
+}
+
+func (client *queryNodeClientMock) ResumeChannel(ctx context.Context, req *querypb.ResumeChannelRequest) (*commonpb.Status, error) {
+	return client.grpcClient.ResumeChannel(ctx, req)
+}
+
+func (client *queryNodeClientMock) ExportSegmentDeletes(ctx context.Context, req *querypb.ExportSegmentDeletesRequest) (*querypb.ExportSegmentDeletesResponse, error) {
+	return client.grpcClient.ExportSegmentDeletes(ctx, req)
+}
+
+func (client *queryNodeClientMock) UpdateLoadConfig(ctx context.Context, req *querypb.UpdateLoadConfigRequest) (*querypb.UpdateLoadConfigResponse, error) {
+	return client.grpcClient.UpdateLoadConfig(ctx, req)
+}
+
+func (client *queryNodeClientMock) GetDataDistribution(ctx context.Context, req *querypb.GetDataDistributionRequest) (*querypb.GetDataDistributionResponse, error) {
+	return client.grpcClient.GetDataDistribution(ctx, req)
+}
+
+func (client *queryNodeClientMock) PromoteSegments(ctx context.Context, req *querypb.PromoteSegmentsRequest) (*commonpb.Status, error) {
+	return client.grpcClient.PromoteSegments(ctx, req)
+}
+
+func (client *queryNodeClientMock) RefreshIndex(ctx context.Context, req *querypb.RefreshIndexRequest) (*commonpb.Status, error) {
+	return client.grpcClient.RefreshIndex(ctx, req)
+}
+
 func (client *queryNodeClientMock) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return client.grpcClient.GetMetrics(ctx, req)
 }
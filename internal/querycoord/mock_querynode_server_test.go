@@ -71,6 +71,7 @@ type queryNodeServerMock struct {
 	releaseCollection   rpcHandler
 	releasePartition    rpcHandler
 	releaseSegments     rpcHandler
+	syncDistribution    rpcHandler
 	getSegmentInfos     func() (*querypb.GetSegmentInfoResponse, error)
 	getMetrics          func() (*milvuspb.GetMetricsResponse, error)
 
@@ -95,6 +96,7 @@ func newQueryNodeServerMock(ctx context.Context) *queryNodeServerMock {
 		releaseCollection:   returnSuccessResult,
 		releasePartition:    returnSuccessResult,
 		releaseSegments:     returnSuccessResult,
+		syncDistribution:    returnSuccessResult,
 		getSegmentInfos:     returnSuccessGetSegmentInfoResult,
 		getMetrics:          returnSuccessGetMetricsResult,
 
@@ -213,12 +215,17 @@ func (qs *queryNodeServerMock) WatchDmChannels(ctx context.Context, req *querypb
 	return qs.watchDmChannels()
 }
 
+func (qs *queryNodeServerMock) WatchDmChannelsWithStatus(ctx context.Context, req *querypb.WatchDmChannelsRequest) (*querypb.WatchDmChannelsResponse, error) {
+	status, err := qs.watchDmChannels()
+	return &querypb.WatchDmChannelsResponse{Status: status}, err
+}
+
 func (qs *queryNodeServerMock) WatchDeltaChannels(ctx context.Context, req *querypb.WatchDeltaChannelsRequest) (*commonpb.Status, error) {
 	return qs.watchDeltaChannels()
 }
 
 func (qs *queryNodeServerMock) LoadSegments(ctx context.Context, req *querypb.LoadSegmentsRequest) (*commonpb.Status, error) {
-	sizePerRecord, err := typeutil.EstimateSizePerRecord(req.Schema)
+	sizePerRecord, err := typeutil.EstimateRowSize(req.Schema, nil)
 	if err != nil {
 		return returnFailedResult()
 	}
@@ -254,6 +261,10 @@ func (qs *queryNodeServerMock) ReleaseSegments(ctx context.Context, req *querypb
 	return qs.releaseSegments()
 }
 
+func (qs *queryNodeServerMock) SyncDistribution(ctx context.Context, req *querypb.SyncDistributionRequest) (*commonpb.Status, error) {
+	return qs.syncDistribution()
+}
+
 func (qs *queryNodeServerMock) GetSegmentInfo(ctx context.Context, req *querypb.GetSegmentInfoRequest) (*querypb.GetSegmentInfoResponse, error) {
 	segmentInfos := make([]*querypb.SegmentInfo, 0)
 	globalSegInfosMutex.RLock()
@@ -637,6 +637,21 @@ func (qc *QueryCoord) loadBalanceSegmentLoop() {
 						continue
 					}
 
+					// the segments quarantined by QueryNodes are loaded on other nodes first
+					for _, req := range genQuarantinedSegmentsBalanceRequests(availableNodeIDs, nodeID2SegmentInfos, nodeID2MemUsageRate) {
+						log.Warn("loadBalanceSegmentLoop: move quarantined segment", zap.Int64s("segmentIDs", req.SealedSegmentIDs),
+							zap.Int64s("sourceNodeIDs", req.SourceNodeIDs), zap.Int64s("dstNodeIDs", req.DstNodeIDs))
+						baseTask := newBaseTask(qc.loopCtx, querypb.TriggerCondition_LoadBalance)
+						loadBalanceTasks = append(loadBalanceTasks, &loadBalanceTask{
+							baseTask:           baseTask,
+							LoadBalanceRequest: req,
+							broker:             qc.broker,
+							cluster:            qc.cluster,
+							meta:               qc.meta,
+						})
+						delete(nodeID2SegmentInfos[req.SourceNodeIDs[0]], req.SealedSegmentIDs[0])
+					}
+
 					// check which nodes need balance and determine which segments on these nodes need to be migrated to other nodes
 					memoryInsufficient := false
 					for {
@@ -717,6 +732,39 @@ func (qc *QueryCoord) loadBalanceSegmentLoop() {
 	}
 }
 
+// genQuarantinedSegmentsBalanceRequests generates the requests moving every quarantined segment to the node of
+// the lowest memory usage rate other than the node quarantining it
+func genQuarantinedSegmentsBalanceRequests(nodeIDs []int64, nodeID2SegmentInfos map[int64]map[UniqueID]*querypb.SegmentInfo,
+	nodeID2MemUsageRate map[int64]float64) []*querypb.LoadBalanceRequest {
+	var reqs []*querypb.LoadBalanceRequest
+	for _, sourceNodeID := range nodeIDs {
+		for segmentID, info := range nodeID2SegmentInfos[sourceNodeID] {
+			if !info.GetQuarantined() || info.GetSegmentState() != commonpb.SegmentState_Sealed {
+				continue
+			}
+			dstNodeID := int64(-1)
+			for _, nodeID := range nodeIDs {
+				if nodeID != sourceNodeID && (dstNodeID == -1 || nodeID2MemUsageRate[nodeID] < nodeID2MemUsageRate[dstNodeID]) {
+					dstNodeID = nodeID
+				}
+			}
+			if dstNodeID == -1 {
+				continue
+			}
+			reqs = append(reqs, &querypb.LoadBalanceRequest{
+				Base: &commonpb.MsgBase{
+					MsgType: commonpb.MsgType_LoadBalanceSegments,
+				},
+				BalanceReason:    querypb.TriggerCondition_LoadBalance,
+				SourceNodeIDs:    []UniqueID{sourceNodeID},
+				DstNodeIDs:       []UniqueID{dstNodeID},
+				SealedSegmentIDs: []UniqueID{segmentID},
+			})
+		}
+	}
+	return reqs
+}
+
 func chooseSegmentToBalance(sourceNodeID int64, dstNodeID int64,
 	segmentInfos map[UniqueID]*querypb.SegmentInfo,
 	nodeID2MemUsage map[int64]uint64,
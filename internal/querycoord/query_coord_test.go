@@ -628,3 +628,30 @@ func TestLoadBalanceSegmentLoop(t *testing.T) {
 	err = removeAllSession()
 	assert.Nil(t, err)
 }
+
+func TestGenQuarantinedSegmentsBalanceRequests(t *testing.T) {
+	sealed := func(segmentID UniqueID, quarantined bool) *querypb.SegmentInfo {
+		return &querypb.SegmentInfo{SegmentID: segmentID, SegmentState: commonpb.SegmentState_Sealed, Quarantined: quarantined}
+	}
+	nodeID2SegmentInfos := map[int64]map[UniqueID]*querypb.SegmentInfo{
+		1: {100: sealed(100, true), 101: sealed(101, false)},
+		2: {200: sealed(200, false)},
+		3: {300: sealed(300, true), 301: {SegmentID: 301, SegmentState: commonpb.SegmentState_Growing, Quarantined: true}},
+	}
+	nodeID2MemUsageRate := map[int64]float64{1: 0.5, 2: 0.6, 3: 0.3}
+
+	reqs := genQuarantinedSegmentsBalanceRequests([]int64{1, 2, 3}, nodeID2SegmentInfos, nodeID2MemUsageRate)
+	assert.Len(t, reqs, 2)
+	moves := make(map[UniqueID][2]int64)
+	for _, req := range reqs {
+		assert.Equal(t, querypb.TriggerCondition_LoadBalance, req.GetBalanceReason())
+		assert.Len(t, req.GetSealedSegmentIDs(), 1)
+		moves[req.GetSealedSegmentIDs()[0]] = [2]int64{req.GetSourceNodeIDs()[0], req.GetDstNodeIDs()[0]}
+	}
+	// moved to the node of the lowest memory usage rate other than the source
+	assert.Equal(t, [2]int64{1, 3}, moves[100])
+	assert.Equal(t, [2]int64{3, 1}, moves[300])
+
+	// no other node to move to
+	assert.Empty(t, genQuarantinedSegmentsBalanceRequests([]int64{1}, nodeID2SegmentInfos, nodeID2MemUsageRate))
+}
@@ -63,6 +63,7 @@ type Node interface {
 	getSegmentInfo(ctx context.Context, in *querypb.GetSegmentInfoRequest) (*querypb.GetSegmentInfoResponse, error)
 	loadSegments(ctx context.Context, in *querypb.LoadSegmentsRequest) error
 	releaseSegments(ctx context.Context, in *querypb.ReleaseSegmentsRequest) error
+	syncDistribution(ctx context.Context, in *querypb.SyncDistributionRequest) error
 	getComponentInfo(ctx context.Context) *internalpb.ComponentInfo
 
 	getMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
@@ -231,6 +232,7 @@ func (qn *queryNode) watchDmChannels(ctx context.Context, in *querypb.WatchDmCha
 	if err != nil {
 		return err
 	}
+	// the channels watched are ignored by the retry of the same version, so a partial success is retried as a whole
 	if status.ErrorCode != commonpb.ErrorCode_Success {
 		return errors.New(status.Reason)
 	}
@@ -406,6 +408,22 @@ func (qn *queryNode) releaseSegments(ctx context.Context, in *querypb.ReleaseSeg
 	return nil
 }
 
+func (qn *queryNode) syncDistribution(ctx context.Context, in *querypb.SyncDistributionRequest) error {
+	if !qn.isOnline() {
+		return errors.New("SyncDistribution: queryNode is offline")
+	}
+
+	status, err := qn.client.SyncDistribution(ctx, in)
+	if err != nil {
+		return err
+	}
+	if status.ErrorCode != commonpb.ErrorCode_Success {
+		return errors.New(status.Reason)
+	}
+
+	return nil
+}
+
 func (qn *queryNode) getNodeInfo() (Node, error) {
 	qn.RLock()
 	defer qn.RUnlock()
@@ -365,6 +365,12 @@ func TestGrpcRequestWithNodeOffline(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 
+	t.Run("Test SyncDistribution", func(t *testing.T) {
+		req := &querypb.SyncDistributionRequest{}
+		err = node.syncDistribution(baseCtx, req)
+		assert.NotNil(t, err)
+	})
+
 	t.Run("Test getNodeInfo", func(t *testing.T) {
 		node, err = node.getNodeInfo()
 		assert.NotNil(t, err)
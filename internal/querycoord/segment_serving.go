@@ -0,0 +1,152 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoord
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+// servingHandover moves the serving of balanced segments of a collection from the source nodes to the target nodes
+type servingHandover struct {
+	collectionID UniqueID
+	sources      map[int64][]UniqueID // nodeID -> segmentIDs to stop serving
+	targets      map[int64][]UniqueID // nodeID -> segmentIDs to start serving
+}
+
+// collectServingHandovers collects the handovers of the segments loaded with deferred serving by the child tasks,
+// the sources of a segment are the nodes serving it in the same replica as the target
+func collectServingHandovers(triggerTask task, meta Meta) []*servingHandover {
+	handovers := make(map[UniqueID]*servingHandover)
+	for _, childTask := range triggerTask.getChildTask() {
+		if childTask.msgType() != commonpb.MsgType_LoadSegments {
+			continue
+		}
+		req := childTask.(*loadSegmentTask).LoadSegmentsRequest
+		if !req.GetDeferServing() {
+			continue
+		}
+		for _, loadInfo := range req.GetInfos() {
+			handover, ok := handovers[loadInfo.GetCollectionID()]
+			if !ok {
+				handover = &servingHandover{
+					collectionID: loadInfo.GetCollectionID(),
+					sources:      make(map[int64][]UniqueID),
+					targets:      make(map[int64][]UniqueID),
+				}
+				handovers[loadInfo.GetCollectionID()] = handover
+			}
+			handover.targets[req.GetDstNodeID()] = append(handover.targets[req.GetDstNodeID()], loadInfo.GetSegmentID())
+
+			segmentInfo, err := meta.getSegmentInfoByID(loadInfo.GetSegmentID())
+			if err != nil {
+				// the segment is not served by any node yet
+				continue
+			}
+			for i, nodeID := range segmentInfo.GetNodeIds() {
+				if nodeID == req.GetDstNodeID() || i >= len(segmentInfo.GetReplicaIds()) || segmentInfo.GetReplicaIds()[i] != req.GetReplicaID() {
+					continue
+				}
+				handover.sources[nodeID] = append(handover.sources[nodeID], loadInfo.GetSegmentID())
+			}
+		}
+	}
+
+	result := make([]*servingHandover, 0, len(handovers))
+	for _, handover := range handovers {
+		result = append(result, handover)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].collectionID < result[j].collectionID })
+	return result
+}
+
+// syncServingDistribution hands over the serving of the balanced segments of the trigger task. For each collection,
+// the source nodes stop serving the segments before the target nodes start, so that a segment is never served by
+// two nodes of a replica at the same time, at the cost of a short window in which it is served by neither.
+// If any target fails to start serving, the sources are restored to serving and the error is returned.
+func syncServingDistribution(ctx context.Context, triggerTask task, meta Meta, cluster Cluster) error {
+	version := triggerTask.getTaskID()
+	for _, handover := range collectServingHandovers(triggerTask, meta) {
+		if err := handover.apply(ctx, cluster, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *servingHandover) apply(ctx context.Context, cluster Cluster, version int64) error {
+	stopped := make([]int64, 0, len(h.sources))
+	restore := func() {
+		for _, nodeID := range stopped {
+			if err := h.sync(ctx, cluster, nodeID, h.sources[nodeID], true, version); err != nil {
+				log.Warn("syncServingDistribution: failed to restore serving of source node",
+					zap.Int64("collectionID", h.collectionID), zap.Int64("nodeID", nodeID), zap.Error(err))
+			}
+		}
+	}
+
+	for _, nodeID := range sortedNodeIDs(h.sources) {
+		if err := h.sync(ctx, cluster, nodeID, h.sources[nodeID], false, version); err != nil {
+			restore()
+			return err
+		}
+		stopped = append(stopped, nodeID)
+	}
+	for _, nodeID := range sortedNodeIDs(h.targets) {
+		if err := h.sync(ctx, cluster, nodeID, h.targets[nodeID], true, version); err != nil {
+			restore()
+			return err
+		}
+	}
+	log.Debug("syncServingDistribution: hand over segments done",
+		zap.Int64("collectionID", h.collectionID),
+		zap.Any("sources", h.sources),
+		zap.Any("targets", h.targets),
+		zap.Int64("version", version))
+	return nil
+}
+
+func (h *servingHandover) sync(ctx context.Context, cluster Cluster, nodeID int64, segmentIDs []UniqueID, serving bool, version int64) error {
+	actions := make([]*querypb.SegmentServingAction, 0, len(segmentIDs))
+	for _, segmentID := range segmentIDs {
+		actions = append(actions, &querypb.SegmentServingAction{SegmentID: segmentID, Serving: serving})
+	}
+	return cluster.syncDistribution(ctx, nodeID, &querypb.SyncDistributionRequest{
+		Base: &commonpb.MsgBase{
+			MsgType: commonpb.MsgType_LoadBalanceSegments,
+		},
+		NodeID:       nodeID,
+		CollectionID: h.collectionID,
+		Actions:      actions,
+		Version:      version,
+	})
+}
+
+func sortedNodeIDs(nodes map[int64][]UniqueID) []int64 {
+	nodeIDs := make([]int64, 0, len(nodes))
+	for nodeID := range nodes {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool { return nodeIDs[i] < nodeIDs[j] })
+	return nodeIDs
+}
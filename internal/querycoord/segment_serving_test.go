@@ -0,0 +1,227 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+type servingMeta struct {
+	Meta
+	segmentInfos map[UniqueID]*querypb.SegmentInfo
+}
+
+func (m *servingMeta) getSegmentInfoByID(segmentID UniqueID) (*querypb.SegmentInfo, error) {
+	info, ok := m.segmentInfos[segmentID]
+	if !ok {
+		return nil, fmt.Errorf("segment %d not found", segmentID)
+	}
+	return info, nil
+}
+
+type servingState struct {
+	serving bool
+	version int64
+}
+
+// servingCluster applies the serving actions like query nodes do, and checks after every request
+// that no segment is served by two nodes of a replica
+type servingCluster struct {
+	Cluster
+	t           *testing.T
+	nodeReplica map[int64]int64
+	states      map[int64]map[UniqueID]*servingState // nodeID -> segmentID -> state
+	failNodes   map[int64]bool
+	requests    []*querypb.SyncDistributionRequest
+}
+
+func (c *servingCluster) syncDistribution(ctx context.Context, nodeID int64, in *querypb.SyncDistributionRequest) error {
+	if c.failNodes[nodeID] {
+		return errors.New("mock sync distribution failed")
+	}
+	c.requests = append(c.requests, in)
+	for _, action := range in.GetActions() {
+		state, ok := c.states[nodeID][action.GetSegmentID()]
+		if !ok {
+			return fmt.Errorf("segment %d not loaded on node %d", action.GetSegmentID(), nodeID)
+		}
+		if in.GetVersion() < state.version {
+			continue
+		}
+		state.serving = action.GetServing()
+		state.version = in.GetVersion()
+	}
+	c.checkExclusive()
+	return nil
+}
+
+func (c *servingCluster) checkExclusive() {
+	servingNodes := make(map[string][]int64)
+	for nodeID, states := range c.states {
+		for segmentID, state := range states {
+			if state.serving {
+				key := fmt.Sprintf("%d-%d", c.nodeReplica[nodeID], segmentID)
+				servingNodes[key] = append(servingNodes[key], nodeID)
+			}
+		}
+	}
+	for key, nodeIDs := range servingNodes {
+		assert.LessOrEqual(c.t, len(nodeIDs), 1, "replica-segment %s is served by nodes %v", key, nodeIDs)
+	}
+}
+
+func (c *servingCluster) serving(nodeID int64, segmentID UniqueID) bool {
+	return c.states[nodeID][segmentID].serving
+}
+
+// genServingHandoverTask balances segments 1 and 2 of replica 1 from node 1 to node 2,
+// node 3 of replica 2 serves the same segments
+func genServingHandoverTask(ctx context.Context, taskID int64, deferServing bool) (*loadBalanceTask, *servingMeta, *servingCluster) {
+	meta := &servingMeta{segmentInfos: make(map[UniqueID]*querypb.SegmentInfo)}
+	cluster := &servingCluster{
+		nodeReplica: map[int64]int64{1: 1, 2: 1, 3: 2},
+		states:      make(map[int64]map[UniqueID]*servingState),
+		failNodes:   make(map[int64]bool),
+	}
+	for nodeID := range cluster.nodeReplica {
+		cluster.states[nodeID] = make(map[UniqueID]*servingState)
+	}
+	infos := make([]*querypb.SegmentLoadInfo, 0)
+	for _, segmentID := range []UniqueID{1, 2} {
+		meta.segmentInfos[segmentID] = &querypb.SegmentInfo{
+			SegmentID:    segmentID,
+			CollectionID: defaultCollectionID,
+			NodeIds:      []int64{1, 3},
+			ReplicaIds:   []int64{1, 2},
+		}
+		cluster.states[1][segmentID] = &servingState{serving: true}
+		cluster.states[3][segmentID] = &servingState{serving: true}
+		// loaded by the balance, but not serving yet
+		cluster.states[2][segmentID] = &servingState{serving: !deferServing}
+		infos = append(infos, &querypb.SegmentLoadInfo{SegmentID: segmentID, CollectionID: defaultCollectionID})
+	}
+
+	balanceTask := &loadBalanceTask{
+		baseTask: newBaseTask(ctx, querypb.TriggerCondition_LoadBalance),
+		LoadBalanceRequest: &querypb.LoadBalanceRequest{
+			Base: &commonpb.MsgBase{MsgType: commonpb.MsgType_LoadBalanceSegments},
+		},
+	}
+	balanceTask.setTaskID(taskID)
+	balanceTask.addChildTask(&loadSegmentTask{
+		baseTask: newBaseTask(ctx, querypb.TriggerCondition_LoadBalance),
+		LoadSegmentsRequest: &querypb.LoadSegmentsRequest{
+			Base:         &commonpb.MsgBase{MsgType: commonpb.MsgType_LoadSegments},
+			DstNodeID:    2,
+			Infos:        infos,
+			CollectionID: defaultCollectionID,
+			ReplicaID:    1,
+			DeferServing: deferServing,
+		},
+	})
+	return balanceTask, meta, cluster
+}
+
+func TestCollectServingHandovers(t *testing.T) {
+	ctx := context.Background()
+	balanceTask, meta, _ := genServingHandoverTask(ctx, 100, true)
+	handovers := collectServingHandovers(balanceTask, meta)
+	assert.Equal(t, 1, len(handovers))
+	assert.Equal(t, defaultCollectionID, handovers[0].collectionID)
+	// node 3 serves another replica, it's not a source
+	assert.Equal(t, map[int64][]UniqueID{1: {1, 2}}, handovers[0].sources)
+	assert.Equal(t, map[int64][]UniqueID{2: {1, 2}}, handovers[0].targets)
+
+	balanceTask, meta, _ = genServingHandoverTask(ctx, 100, false)
+	assert.Empty(t, collectServingHandovers(balanceTask, meta))
+}
+
+func TestSyncServingDistribution(t *testing.T) {
+	ctx := context.Background()
+	balanceTask, meta, cluster := genServingHandoverTask(ctx, 100, true)
+	cluster.t = t
+	cluster.checkExclusive()
+
+	err := syncServingDistribution(ctx, balanceTask, meta, cluster)
+	assert.NoError(t, err)
+
+	// the source stops serving before the target starts
+	assert.Equal(t, 2, len(cluster.requests))
+	assert.Equal(t, int64(1), cluster.requests[0].GetNodeID())
+	assert.Equal(t, int64(2), cluster.requests[1].GetNodeID())
+	for _, req := range cluster.requests {
+		assert.Equal(t, int64(100), req.GetVersion())
+		assert.Equal(t, defaultCollectionID, req.GetCollectionID())
+		assert.Equal(t, 2, len(req.GetActions()))
+	}
+	for _, segmentID := range []UniqueID{1, 2} {
+		assert.False(t, cluster.serving(1, segmentID))
+		assert.True(t, cluster.serving(2, segmentID))
+		assert.True(t, cluster.serving(3, segmentID))
+	}
+
+	// a delayed handover of an earlier balance is excluded by version
+	staleTask, _, _ := genServingHandoverTask(ctx, 99, true)
+	staleTask.getChildTask()[0].(*loadSegmentTask).DstNodeID = 1
+	err = syncServingDistribution(ctx, staleTask, &servingMeta{segmentInfos: map[UniqueID]*querypb.SegmentInfo{
+		1: {SegmentID: 1, CollectionID: defaultCollectionID, NodeIds: []int64{2}, ReplicaIds: []int64{1}},
+		2: {SegmentID: 2, CollectionID: defaultCollectionID, NodeIds: []int64{2}, ReplicaIds: []int64{1}},
+	}}, cluster)
+	assert.NoError(t, err)
+	for _, segmentID := range []UniqueID{1, 2} {
+		assert.False(t, cluster.serving(1, segmentID))
+		assert.True(t, cluster.serving(2, segmentID))
+	}
+}
+
+func TestSyncServingDistribution_failed(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("target failed", func(t *testing.T) {
+		balanceTask, meta, cluster := genServingHandoverTask(ctx, 100, true)
+		cluster.t = t
+		cluster.failNodes[2] = true
+		err := syncServingDistribution(ctx, balanceTask, meta, cluster)
+		assert.Error(t, err)
+		// the source is restored to serving
+		for _, segmentID := range []UniqueID{1, 2} {
+			assert.True(t, cluster.serving(1, segmentID))
+			assert.False(t, cluster.serving(2, segmentID))
+		}
+	})
+
+	t.Run("source failed", func(t *testing.T) {
+		balanceTask, meta, cluster := genServingHandoverTask(ctx, 100, true)
+		cluster.t = t
+		cluster.failNodes[1] = true
+		err := syncServingDistribution(ctx, balanceTask, meta, cluster)
+		assert.Error(t, err)
+		assert.Empty(t, cluster.requests)
+		for _, segmentID := range []UniqueID{1, 2} {
+			assert.True(t, cluster.serving(1, segmentID))
+			assert.False(t, cluster.serving(2, segmentID))
+		}
+	})
+}
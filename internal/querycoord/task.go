@@ -493,7 +493,8 @@ func (lct *loadCollectionTask) execute(ctx context.Context) error {
 					CollectionID: collectionID,
 					PartitionIDs: partitionIds,
 				},
-				ReplicaID: replica.GetReplicaID(),
+				ReplicaID:        replica.GetReplicaID(),
+				SegmentRowBudget: estimateSegmentRowBudget(lct.Schema),
 			}
 
 			watchDmChannelReqs = append(watchDmChannelReqs, watchRequest)
@@ -922,7 +923,8 @@ func (lpt *loadPartitionTask) execute(ctx context.Context) error {
 					CollectionID: collectionID,
 					PartitionIDs: partitionIDs,
 				},
-				ReplicaID: replica.GetReplicaID(),
+				ReplicaID:        replica.GetReplicaID(),
+				SegmentRowBudget: estimateSegmentRowBudget(lpt.Schema),
 			}
 
 			watchDmChannelReqs = append(watchDmChannelReqs, watchRequest)
@@ -1217,6 +1219,7 @@ func (lst *loadSegmentTask) preExecute(context.Context) error {
 func (lst *loadSegmentTask) execute(ctx context.Context) error {
 	defer lst.reduceRetryCount()
 
+	lst.CollectionTtlSeconds = Params.QueryCoordCfg.CollectionTTLSeconds
 	err := lst.cluster.loadSegments(ctx, lst.DstNodeID, lst.LoadSegmentsRequest)
 	if err != nil {
 		log.Warn("loadSegmentTask: loadSegment occur error", zap.Int64("taskID", lst.getTaskID()))
@@ -1395,6 +1398,10 @@ func (wdt *watchDmChannelTask) preExecute(context.Context) error {
 func (wdt *watchDmChannelTask) execute(ctx context.Context) error {
 	defer wdt.reduceRetryCount()
 
+	// the ids of the tasks increase, so the requests of a channel reassigned later fence the earlier ones on query node,
+	// while the retries of the task are recognized as duplicates
+	wdt.Version = wdt.getTaskID()
+	wdt.CollectionTtlSeconds = Params.QueryCoordCfg.CollectionTTLSeconds
 	err := wdt.cluster.watchDmChannels(wdt.ctx, wdt.NodeID, wdt.WatchDmChannelsRequest)
 	if err != nil {
 		log.Warn("watchDmChannelTask: watchDmChannel occur error", zap.Int64("taskID", wdt.getTaskID()))
@@ -1431,7 +1438,9 @@ func (wdt *watchDmChannelTask) reschedule(ctx context.Context) ([]task, error) {
 				CollectionID: collectionID,
 				PartitionIDs: wdt.GetLoadMeta().GetPartitionIDs(),
 			},
-			ReplicaID: wdt.GetReplicaID(),
+			ReplicaID:        wdt.GetReplicaID(),
+			SegmentRowBudget: wdt.GetSegmentRowBudget(),
+			GrowingChunkRows: wdt.GetGrowingChunkRows(),
 		}
 		watchDmChannelReqs = append(watchDmChannelReqs, req)
 	}
@@ -2016,7 +2025,8 @@ func (lbt *loadBalanceTask) execute(ctx context.Context) error {
 								CollectionID: collectionID,
 								PartitionIDs: toRecoverPartitionIDs,
 							},
-							ReplicaID: info.ReplicaID,
+							ReplicaID:        info.ReplicaID,
+							SegmentRowBudget: estimateSegmentRowBudget(schema),
 						}
 
 						if collectionInfo.LoadType == querypb.LoadType_LoadPartition {
@@ -2137,12 +2147,14 @@ func (lbt *loadBalanceTask) execute(ctx context.Context) error {
 						segmentLoadInfo := lbt.broker.generateSegmentLoadInfo(ctx, collectionID, partitionID, segmentBingLog, true, collectionInfo.Schema)
 						msgBase := proto.Clone(lbt.Base).(*commonpb.MsgBase)
 						msgBase.MsgType = commonpb.MsgType_LoadSegments
+						// the source keeps serving the segment until the balance is done, see syncServingDistribution
 						loadSegmentReq := &querypb.LoadSegmentsRequest{
 							Base:         msgBase,
 							Infos:        []*querypb.SegmentLoadInfo{segmentLoadInfo},
 							Schema:       collectionInfo.Schema,
 							CollectionID: collectionID,
 							ReplicaID:    replica,
+							DeferServing: true,
 						}
 						loadSegmentReqs = append(loadSegmentReqs, loadSegmentReq)
 					}
@@ -648,6 +648,15 @@ func (scheduler *TaskScheduler) scheduleLoop() {
 					}
 				}
 
+				// hand over the serving of balanced segments before the sources release them
+				if triggerTask.getResultInfo().ErrorCode == commonpb.ErrorCode_Success && triggerTask.msgType() == commonpb.MsgType_LoadBalanceSegments {
+					err = syncServingDistribution(scheduler.ctx, triggerTask, scheduler.meta, scheduler.cluster)
+					if err != nil {
+						log.Error("scheduleLoop: sync serving distribution of balanced segments failed", zap.Int64("triggerTaskID", triggerTask.getTaskID()), zap.Error(err))
+						triggerTask.setResultInfo(err)
+					}
+				}
+
 				//TODO::xige-16, judging the triggerCondition is ugly, the taskScheduler will be refactored soon
 				// if query node down, the loaded segment and watched dmChannel by the node should be balance to new querynode
 				// if triggerCondition == NodeDown, loadSegment and watchDmchannel request will keep reschedule until the success
@@ -20,6 +20,8 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
 func getCompareMapFromSlice(sliceData []int64) map[int64]struct{} {
@@ -64,6 +66,28 @@ func estimateSegmentSize(segmentLoadInfo *querypb.SegmentLoadInfo) int64 {
 	return segmentSize
 }
 
+// estimateSegmentRowBudget returns the expected row count of a growing segment of the collection,
+// which is the max segment size of datacoord divided by the estimated size per record, 0 if not enabled
+func estimateSegmentRowBudget(schema *schemapb.CollectionSchema) int64 {
+	if !Params.QueryCoordCfg.EnableSegmentRowBudget {
+		return 0
+	}
+	sizePerRecord, err := typeutil.EstimateRowSize(schema, nil)
+	if err != nil || sizePerRecord <= 0 {
+		return 0
+	}
+	return int64(Params.DataCoordCfg.SegmentMaxSize * 1024 * 1024 / float64(sizePerRecord))
+}
+
+// getLoadedFields returns the ids of the fields loaded into memory for the collection, which are the fields of the load schema
+func getLoadedFields(info *querypb.CollectionInfo) *schemapb.LongArray {
+	fieldIDs := make([]int64, 0, len(info.GetSchema().GetFields()))
+	for _, field := range info.GetSchema().GetFields() {
+		fieldIDs = append(fieldIDs, field.GetFieldID())
+	}
+	return &schemapb.LongArray{Data: fieldIDs}
+}
+
 func getFieldSizeFromFieldBinlog(fieldBinlog *datapb.FieldBinlog) int64 {
 	fieldSize := int64(0)
 	for _, binlog := range fieldBinlog.Binlogs {
@@ -0,0 +1,61 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+func TestEstimateSegmentRowBudget(t *testing.T) {
+	schema := genDefaultCollectionSchema(false)
+	sizePerRecord, err := typeutil.EstimateRowSize(schema, nil)
+	assert.NoError(t, err)
+
+	// disabled by default
+	assert.Equal(t, int64(0), estimateSegmentRowBudget(schema))
+
+	Params.QueryCoordCfg.EnableSegmentRowBudget = true
+	defer func() { Params.QueryCoordCfg.EnableSegmentRowBudget = false }()
+	budget := estimateSegmentRowBudget(schema)
+	assert.Equal(t, int64(Params.DataCoordCfg.SegmentMaxSize*1024*1024/float64(sizePerRecord)), budget)
+	assert.Greater(t, budget, int64(0))
+
+	// size per record can't be estimated
+	invalidSchema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "vec", DataType: schemapb.DataType_FloatVector},
+		},
+	}
+	assert.Equal(t, int64(0), estimateSegmentRowBudget(invalidSchema))
+}
+
+func TestGetLoadedFields(t *testing.T) {
+	schema := genDefaultCollectionSchema(false)
+	loadedFields := getLoadedFields(&querypb.CollectionInfo{Schema: schema})
+	assert.Len(t, loadedFields.GetData(), len(schema.GetFields()))
+	for i, field := range schema.GetFields() {
+		assert.Equal(t, field.GetFieldID(), loadedFields.GetData()[i])
+	}
+
+	assert.Empty(t, getLoadedFields(&querypb.CollectionInfo{}).GetData())
+}
@@ -18,17 +18,21 @@ package querynode
 
 import (
 	"context"
+	"math"
 	"os"
 	"runtime/pprof"
+	"sort"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/log"
 	msgstream2 "github.com/milvus-io/milvus/internal/mq/msgstream"
-	"github.com/milvus-io/milvus/internal/util/typeutil"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
 )
 
 const (
@@ -72,10 +76,9 @@ func benchmarkQueryCollectionSearch(nq int, b *testing.B) {
 	seg, err := queryCollection.historical.replica.getSegmentByID(defaultSegmentID)
 	assert.NoError(b, err)
 	assert.Equal(b, int64(nb), seg.getRowCount())
-	sizePerRecord, err := typeutil.EstimateSizePerRecord(genSimpleSegCoreSchema())
+	col, err := queryCollection.historical.replica.getCollectionByID(defaultCollectionID)
 	assert.NoError(b, err)
-	expectSize := sizePerRecord * nb
-	assert.Equal(b, seg.getMemSize(), int64(expectSize))
+	assert.Equal(b, seg.getMemSize(), col.EstimateSegmentSize(int64(nb)))
 
 	// warming up
 
@@ -149,10 +152,9 @@ func benchmarkQueryCollectionSearchIndex(nq int, indexType string, b *testing.B)
 	seg, err := queryCollection.historical.replica.getSegmentByID(defaultSegmentID)
 	assert.NoError(b, err)
 	assert.Equal(b, int64(nb), seg.getRowCount())
-	sizePerRecord, err := typeutil.EstimateSizePerRecord(genSimpleSegCoreSchema())
+	col, err := queryCollection.historical.replica.getCollectionByID(defaultCollectionID)
 	assert.NoError(b, err)
-	expectSize := sizePerRecord * nb
-	assert.Equal(b, seg.getMemSize(), int64(expectSize))
+	assert.Equal(b, seg.getMemSize(), col.EstimateSegmentSize(int64(nb)))
 
 	// warming up
 	msgTmp, err := genSearchMsg(10, indexType)
@@ -217,3 +219,67 @@ func BenchmarkSearch_IVFFLAT_NQ10000(b *testing.B) {
 	benchmarkQueryCollectionSearchIndex(10000, IndexFaissIVFFlat, b)
 }
 */
+
+func benchmarkGrowingSegmentInsert(rowBudget int64, b *testing.B) {
+	log.SetLevel(zapcore.ErrorLevel)
+	defer log.SetLevel(zapcore.DebugLevel)
+
+	const (
+		dim       = 16
+		batchSize = 1000
+		batchNum  = 100
+	)
+
+	collectionMeta := genTestCollectionMeta(defaultCollectionID, false)
+	collection := newCollection(collectionMeta.ID, collectionMeta.Schema)
+	defer deleteCollection(collection)
+	collection.setSegmentRowBudget(rowBudget)
+
+	// row based records of schema (vec dim 16, int32)
+	var rawData []byte
+	for i := 0; i < dim; i++ {
+		buf := make([]byte, 4)
+		common.Endian.PutUint32(buf, math.Float32bits(float32(i)))
+		rawData = append(rawData, buf...)
+	}
+	bs := make([]byte, 4)
+	common.Endian.PutUint32(bs, 1)
+	rawData = append(rawData, bs...)
+	ids := make([]int64, 0, batchSize)
+	timestamps := make([]uint64, 0, batchSize)
+	records := make([]*commonpb.Blob, 0, batchSize)
+	for i := 0; i < batchSize; i++ {
+		ids = append(ids, int64(i))
+		timestamps = append(timestamps, 0)
+		records = append(records, &commonpb.Blob{Value: rawData})
+	}
+
+	latencies := make([]time.Duration, 0, b.N*batchNum)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		segment, err := newSegment(collection, UniqueID(i), defaultPartitionID, defaultCollectionID, "", segmentTypeGrowing, true)
+		assert.NoError(b, err)
+		for j := 0; j < batchNum; j++ {
+			start := time.Now()
+			offset, err := segment.segmentPreInsert(batchSize)
+			assert.NoError(b, err)
+			err = segment.segmentInsert(offset, &ids, &timestamps, &records)
+			assert.NoError(b, err)
+			latencies = append(latencies, time.Since(start))
+		}
+		deleteSegment(segment)
+	}
+	b.StopTimer()
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	p99 := latencies[len(latencies)*99/100]
+	b.ReportMetric(float64(p99.Microseconds()), "p99-us/batch")
+}
+
+func BenchmarkGrowingSegmentInsert_NoRowBudget(b *testing.B) {
+	benchmarkGrowingSegmentInsert(0, b)
+}
+
+func BenchmarkGrowingSegmentInsert_WithRowBudget(b *testing.B) {
+	benchmarkGrowingSegmentInsert(100*1000, b)
+}
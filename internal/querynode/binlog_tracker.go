@@ -0,0 +1,59 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+// binlogTracker records the distinct binlog files read by a retrieve request,
+// and bounds the number of them
+type binlogTracker struct {
+	limit int // max number of distinct binlog files, no limit if not positive
+	paths map[string]struct{}
+}
+
+func newBinlogTracker(limit int) *binlogTracker {
+	return &binlogTracker{
+		limit: limit,
+		paths: make(map[string]struct{}),
+	}
+}
+
+// touch records path as read, it returns an error if the number of distinct files exceeds the limit
+func (t *binlogTracker) touch(path string) error {
+	if _, ok := t.paths[path]; ok {
+		return nil
+	}
+	if t.limit > 0 && len(t.paths) >= t.limit {
+		return &binlogLimitExceededError{limit: t.limit}
+	}
+	t.paths[path] = struct{}{}
+	return nil
+}
+
+// count returns the number of distinct binlog files read
+func (t *binlogTracker) count() int {
+	return len(t.paths)
+}
+
+// observe reports the number of distinct binlog files read by the request
+func (t *binlogTracker) observe() {
+	metrics.QueryNodeRetrieveBinlogFiles.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Observe(float64(t.count()))
+}
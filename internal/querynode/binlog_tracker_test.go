@@ -0,0 +1,52 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinlogTracker(t *testing.T) {
+	t.Run("limited", func(t *testing.T) {
+		tracker := newBinlogTracker(2)
+		assert.NoError(t, tracker.touch("a"))
+		assert.NoError(t, tracker.touch("a"))
+		assert.NoError(t, tracker.touch("b"))
+		assert.Equal(t, 2, tracker.count())
+
+		// touched files don't count again
+		assert.NoError(t, tracker.touch("b"))
+
+		err := tracker.touch("c")
+		var limitErr *binlogLimitExceededError
+		assert.True(t, errors.As(err, &limitErr))
+		assert.Contains(t, err.Error(), "narrow the filter expression")
+		assert.Equal(t, 2, tracker.count())
+		tracker.observe()
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		tracker := newBinlogTracker(0)
+		for _, path := range []string{"a", "b", "c"} {
+			assert.NoError(t, tracker.touch(path))
+		}
+		assert.Equal(t, 3, tracker.count())
+	})
+}
@@ -0,0 +1,183 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/bits-and-blooms/bloom/v3"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+const (
+	// bloomFilterFileMagic marks the serialized pk bloom filter of a sealed segment
+	bloomFilterFileMagic uint32 = 0x51424653 // "QBFS"
+	// bloomFilterFileVersion is bumped whenever the serialization changes
+	bloomFilterFileVersion uint8 = 1
+)
+
+// serializeBloomFilter serializes the pk bloom filter of the segment along with the parameters it's built with,
+// and the pk range if known. The layout is the header of magic, version, n, fp rate, segmentID and pk type,
+// then the pk range, then the filter as written by BloomFilter.WriteTo, all in little endian
+func (s *Segment) serializeBloomFilter() ([]byte, error) {
+	if s.pkFilter == nil {
+		return nil, fmt.Errorf("bloom filter of segment %d is nil", s.ID())
+	}
+	buf := &bytes.Buffer{}
+	header := []interface{}{
+		bloomFilterFileMagic,
+		bloomFilterFileVersion,
+		uint64(bloomFilterSize),
+		maxBloomFalsePositive,
+		s.ID(),
+		int32(s.pkType),
+	}
+	for _, field := range header {
+		// writing to bytes.Buffer never fails
+		_ = binary.Write(buf, binary.LittleEndian, field)
+	}
+
+	hasRange := s.minPK != nil && s.maxPK != nil && s.minPK.Type() == s.pkType && s.maxPK.Type() == s.pkType
+	if !hasRange {
+		buf.WriteByte(0)
+	} else {
+		buf.WriteByte(1)
+		for _, pk := range []primaryKey{s.minPK, s.maxPK} {
+			if err := writeBloomFilterPK(buf, pk); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if _, err := s.pkFilter.WriteTo(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// loadBloomFilter restores the pk bloom filter of the segment serialized by serializeBloomFilter, it must be
+// called before the segment is registered. Nothing is restored if the filter is built with other parameters,
+// whose lookups would be false negative, or mismatches the segment, the filter should be rebuilt then
+func (s *Segment) loadBloomFilter(data []byte) error {
+	reader := bytes.NewReader(data)
+	var (
+		magic     uint32
+		version   uint8
+		n         uint64
+		fpRate    float64
+		segmentID UniqueID
+		pkType    int32
+		hasRange  uint8
+	)
+	for _, field := range []interface{}{&magic, &version} {
+		if err := binary.Read(reader, binary.LittleEndian, field); err != nil {
+			return fmt.Errorf("serialized bloom filter is too short, size = %d", len(data))
+		}
+	}
+	if magic != bloomFilterFileMagic {
+		return fmt.Errorf("invalid serialized bloom filter magic %x", magic)
+	}
+	if version != bloomFilterFileVersion {
+		return fmt.Errorf("unsupported serialized bloom filter version %d, expected %d", version, bloomFilterFileVersion)
+	}
+	for _, field := range []interface{}{&n, &fpRate, &segmentID, &pkType, &hasRange} {
+		if err := binary.Read(reader, binary.LittleEndian, field); err != nil {
+			return fmt.Errorf("serialized bloom filter is too short, size = %d", len(data))
+		}
+	}
+	if segmentID != s.ID() {
+		return fmt.Errorf("serialized bloom filter of segment %d mismatches segment %d", segmentID, s.ID())
+	}
+	if n != uint64(bloomFilterSize) || fpRate != maxBloomFalsePositive {
+		return fmt.Errorf("bloom filter of segment %d is built with n = %d and fp rate = %v, expected n = %d and fp rate = %v",
+			s.ID(), n, fpRate, bloomFilterSize, maxBloomFalsePositive)
+	}
+	if schemapb.DataType(pkType) != s.pkType {
+		return fmt.Errorf("bloom filter of segment %d is built on pk of type %s, expected %s",
+			s.ID(), schemapb.DataType(pkType), s.pkType)
+	}
+
+	var minPK, maxPK primaryKey
+	if hasRange != 0 {
+		var err error
+		if minPK, err = readBloomFilterPK(reader, s.pkType); err != nil {
+			return fmt.Errorf("invalid pk range of serialized bloom filter of segment %d: %w", s.ID(), err)
+		}
+		if maxPK, err = readBloomFilterPK(reader, s.pkType); err != nil {
+			return fmt.Errorf("invalid pk range of serialized bloom filter of segment %d: %w", s.ID(), err)
+		}
+	}
+
+	filter := &bloom.BloomFilter{}
+	if _, err := filter.ReadFrom(reader); err != nil {
+		return fmt.Errorf("invalid serialized bloom filter of segment %d: %w", s.ID(), err)
+	}
+	if reader.Len() > 0 {
+		return fmt.Errorf("serialized bloom filter of segment %d has %d trailing bytes", s.ID(), reader.Len())
+	}
+	if m, k := bloom.EstimateParameters(uint(n), fpRate); filter.Cap() != m || filter.K() != k {
+		return fmt.Errorf("bloom filter of segment %d has m = %d and k = %d, expected m = %d and k = %d",
+			s.ID(), filter.Cap(), filter.K(), m, k)
+	}
+
+	s.pkFilter = filter
+	s.updatePKRange(minPK, maxPK)
+	return nil
+}
+
+func writeBloomFilterPK(buf *bytes.Buffer, pk primaryKey) error {
+	switch pk := pk.(type) {
+	case *int64PrimaryKey:
+		_ = binary.Write(buf, binary.LittleEndian, pk.Value)
+	case *varCharPrimaryKey:
+		_ = binary.Write(buf, binary.LittleEndian, uint32(len(pk.Value)))
+		buf.WriteString(pk.Value)
+	default:
+		return fmt.Errorf("unsupported pk type %s", pk.Type())
+	}
+	return nil
+}
+
+func readBloomFilterPK(reader *bytes.Reader, pkType schemapb.DataType) (primaryKey, error) {
+	switch pkType {
+	case schemapb.DataType_Int64:
+		var value int64
+		if err := binary.Read(reader, binary.LittleEndian, &value); err != nil {
+			return nil, err
+		}
+		return newInt64PrimaryKey(value), nil
+	case schemapb.DataType_VarChar:
+		var size uint32
+		if err := binary.Read(reader, binary.LittleEndian, &size); err != nil {
+			return nil, err
+		}
+		if int64(size) > int64(reader.Len()) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		value := make([]byte, size)
+		if _, err := io.ReadFull(reader, value); err != nil {
+			return nil, err
+		}
+		return newVarCharPrimaryKey(string(value)), nil
+	default:
+		return nil, fmt.Errorf("unsupported pk type %s", pkType)
+	}
+}
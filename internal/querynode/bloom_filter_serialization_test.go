@@ -0,0 +1,198 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/bits-and-blooms/bloom/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+func genBloomFilterSegment(segmentID UniqueID, pkType schemapb.DataType) *Segment {
+	return &Segment{
+		segmentID: segmentID,
+		pkType:    pkType,
+		pkFilter:  bloom.NewWithEstimates(bloomFilterSize, maxBloomFalsePositive),
+	}
+}
+
+func TestSegment_serializeBloomFilter(t *testing.T) {
+	t.Run("int64 pk", func(t *testing.T) {
+		segment := genBloomFilterSegment(defaultSegmentID, schemapb.DataType_Int64)
+		pks := make([]primaryKey, 0, 1000)
+		for i := int64(0); i < 1000; i++ {
+			pks = append(pks, newInt64PrimaryKey(i*3))
+		}
+		segment.updateBloomFilter(pks)
+		segment.updatePKRange(pks[0], pks[len(pks)-1])
+		data, err := segment.serializeBloomFilter()
+		require.NoError(t, err)
+
+		loaded := genBloomFilterSegment(defaultSegmentID, schemapb.DataType_Int64)
+		require.NoError(t, loaded.loadBloomFilter(data))
+		assert.True(t, segment.pkFilter.Equal(loaded.pkFilter))
+		assert.True(t, loaded.minPK.EQ(newInt64PrimaryKey(0)))
+		assert.True(t, loaded.maxPK.EQ(newInt64PrimaryKey(2997)))
+		buf := make([]byte, 8)
+		for _, pk := range pks {
+			binary.LittleEndian.PutUint64(buf, uint64(pk.(*int64PrimaryKey).Value))
+			assert.True(t, loaded.pkFilter.Test(buf))
+		}
+	})
+
+	t.Run("varchar pk without range", func(t *testing.T) {
+		segment := genBloomFilterSegment(defaultSegmentID, schemapb.DataType_VarChar)
+		segment.updateBloomFilter([]primaryKey{newVarCharPrimaryKey("a"), newVarCharPrimaryKey("bc")})
+		data, err := segment.serializeBloomFilter()
+		require.NoError(t, err)
+
+		loaded := genBloomFilterSegment(defaultSegmentID, schemapb.DataType_VarChar)
+		require.NoError(t, loaded.loadBloomFilter(data))
+		assert.True(t, loaded.pkFilter.TestString("a"))
+		assert.True(t, loaded.pkFilter.TestString("bc"))
+		assert.Nil(t, loaded.minPK)
+		assert.Nil(t, loaded.maxPK)
+
+		segment.updatePKRange(newVarCharPrimaryKey("a"), newVarCharPrimaryKey("bc"))
+		data, err = segment.serializeBloomFilter()
+		require.NoError(t, err)
+		loaded = genBloomFilterSegment(defaultSegmentID, schemapb.DataType_VarChar)
+		require.NoError(t, loaded.loadBloomFilter(data))
+		assert.True(t, loaded.minPK.EQ(newVarCharPrimaryKey("a")))
+		assert.True(t, loaded.maxPK.EQ(newVarCharPrimaryKey("bc")))
+	})
+
+	t.Run("mismatched", func(t *testing.T) {
+		segment := genBloomFilterSegment(defaultSegmentID, schemapb.DataType_Int64)
+		segment.updateBloomFilter([]primaryKey{newInt64PrimaryKey(1)})
+		data, err := segment.serializeBloomFilter()
+		require.NoError(t, err)
+
+		modify := func(f func(data []byte) []byte) []byte {
+			return f(append([]byte{}, data...))
+		}
+		cases := map[string][]byte{
+			"empty":     nil,
+			"truncated": data[:len(data)-1],
+			"trailing":  append(append([]byte{}, data...), 0),
+			"magic": modify(func(data []byte) []byte {
+				data[0]++
+				return data
+			}),
+			"version": modify(func(data []byte) []byte {
+				data[4]++
+				return data
+			}),
+			"n": modify(func(data []byte) []byte {
+				binary.LittleEndian.PutUint64(data[5:], uint64(bloomFilterSize*2))
+				return data
+			}),
+			"fp rate": modify(func(data []byte) []byte {
+				binary.LittleEndian.PutUint64(data[13:], math.Float64bits(maxBloomFalsePositive/2))
+				return data
+			}),
+			"segment": modify(func(data []byte) []byte {
+				binary.LittleEndian.PutUint64(data[21:], uint64(defaultSegmentID+1))
+				return data
+			}),
+			"pk type": modify(func(data []byte) []byte {
+				binary.LittleEndian.PutUint32(data[29:], uint32(schemapb.DataType_VarChar))
+				return data
+			}),
+		}
+		for name, data := range cases {
+			loaded := genBloomFilterSegment(defaultSegmentID, schemapb.DataType_Int64)
+			filter := loaded.pkFilter
+			assert.Error(t, loaded.loadBloomFilter(data), name)
+			assert.Same(t, filter, loaded.pkFilter, name)
+		}
+	})
+
+	t.Run("nil filter", func(t *testing.T) {
+		_, err := (&Segment{segmentID: defaultSegmentID}).serializeBloomFilter()
+		assert.Error(t, err)
+	})
+}
+
+func TestSegmentLoader_loadSegmentBloomFilter(t *testing.T) {
+	ctx := context.Background()
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	defer node.Stop()
+	cm := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+	node.loader.cm = cm
+
+	const statsPath, filterPath = "stats_log/1/2/3/100/1", "stats_log/1/2/3/100/bloom_filter"
+	bf := bloom.NewWithEstimates(bloomFilterSize, maxBloomFalsePositive)
+	buf := make([]byte, 8)
+	for i := uint64(10); i < 20; i++ {
+		binary.LittleEndian.PutUint64(buf, i)
+		bf.Add(buf)
+	}
+	stats, err := json.Marshal(&storage.PrimaryKeyStats{
+		FieldID: 100,
+		Min:     10,
+		Max:     19,
+		BF:      bf,
+		PkType:  int64(schemapb.DataType_Int64),
+		MinPk:   newInt64PrimaryKey(10),
+		MaxPk:   newInt64PrimaryKey(19),
+	})
+	require.NoError(t, err)
+	require.NoError(t, cm.Write(statsPath, stats))
+
+	// the filter is rebuilt from the stats logs, then saved for the later loads
+	segment := genBloomFilterSegment(defaultSegmentID, schemapb.DataType_Int64)
+	require.NoError(t, node.loader.loadSegmentBloomFilter(segment, []string{statsPath}, filterPath))
+	assert.True(t, segment.pkFilter.Equal(bf))
+	assert.True(t, segment.maxPK.EQ(newInt64PrimaryKey(19)))
+	saved, err := cm.Read(filterPath)
+	require.NoError(t, err)
+
+	// the stats logs are not read once the serialized filter is loaded
+	require.NoError(t, cm.Remove(statsPath))
+	segment = genBloomFilterSegment(defaultSegmentID, schemapb.DataType_Int64)
+	require.NoError(t, node.loader.loadSegmentBloomFilter(segment, []string{statsPath}, filterPath))
+	assert.True(t, segment.pkFilter.Equal(bf))
+	assert.True(t, segment.minPK.EQ(newInt64PrimaryKey(10)))
+
+	// the filter serialized with other parameters is rebuilt and overwritten
+	require.NoError(t, cm.Write(statsPath, stats))
+	mismatched := append([]byte{}, saved...)
+	binary.LittleEndian.PutUint64(mismatched[5:], uint64(bloomFilterSize*2))
+	require.NoError(t, cm.Write(filterPath, mismatched))
+	segment = genBloomFilterSegment(defaultSegmentID, schemapb.DataType_Int64)
+	require.NoError(t, node.loader.loadSegmentBloomFilter(segment, []string{statsPath}, filterPath))
+	assert.True(t, segment.pkFilter.Equal(bf))
+	rewritten, err := cm.Read(filterPath)
+	require.NoError(t, err)
+	assert.Equal(t, saved, rewritten)
+
+	// without the stats logs, the serialized filter is not required
+	segment = genBloomFilterSegment(defaultSegmentID+1, schemapb.DataType_Int64)
+	require.NoError(t, node.loader.loadSegmentBloomFilter(segment, nil, "stats_log/1/2/3/101/bloom_filter"))
+	assert.False(t, cm.Exist("stats_log/1/2/3/101/bloom_filter"))
+}
@@ -0,0 +1,130 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/bits-and-blooms/bloom/v3"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+// bloomFilterHeaderSize is the size of m, k and the bitset length written ahead of the bitset words by WriteTo
+const bloomFilterHeaderSize = 3 * 8
+
+// bloomFilterStats describes the occupancy of the pk bloom filter of a segment
+type bloomFilterStats struct {
+	bitSize            uint
+	hashFunctions      uint
+	setBits            uint
+	approxElementCount uint64
+	estimatedFPR       float64
+	lookups            int64 // pks tested against the bloom filter
+	pruned             int64 // pks rejected by the bloom filter
+}
+
+func (stats *bloomFilterStats) fillRatio() float64 {
+	if stats.bitSize == 0 {
+		return 0
+	}
+	return float64(stats.setBits) / float64(stats.bitSize)
+}
+
+func (stats *bloomFilterStats) toProto() *querypb.SegmentBloomFilterStats {
+	return &querypb.SegmentBloomFilterStats{
+		BitSize:            uint64(stats.bitSize),
+		HashFunctions:      uint32(stats.hashFunctions),
+		FillRatio:          stats.fillRatio(),
+		ApproxElementCount: stats.approxElementCount,
+		EstimatedFpr:       stats.estimatedFPR,
+		Lookups:            stats.lookups,
+		Pruned:             stats.pruned,
+	}
+}
+
+// countBloomFilterBits returns the number of set bits of the bloom filter,
+// the bitset is not exposed by the filter, so it's counted on the serialized words
+func countBloomFilterBits(filter *bloom.BloomFilter) (uint, error) {
+	var buf bytes.Buffer
+	if _, err := filter.WriteTo(&buf); err != nil {
+		return 0, err
+	}
+	data := buf.Bytes()
+	if len(data) < bloomFilterHeaderSize {
+		return 0, fmt.Errorf("serialized bloom filter is too short, size = %d", len(data))
+	}
+	// the number of set bits doesn't depend on the byte order of the words
+	var setBits int
+	for _, b := range data[bloomFilterHeaderSize:] {
+		setBits += bits.OnesCount8(b)
+	}
+	return uint(setBits), nil
+}
+
+// newBloomFilterStats estimates the element count and false positive rate of the bloom filter by its fill ratio X/m:
+// n ≈ -(m/k)·ln(1 - X/m), and fpr ≈ (X/m)^k
+func newBloomFilterStats(filter *bloom.BloomFilter) (*bloomFilterStats, error) {
+	setBits, err := countBloomFilterBits(filter)
+	if err != nil {
+		return nil, err
+	}
+	stats := &bloomFilterStats{
+		bitSize:       filter.Cap(),
+		hashFunctions: filter.K(),
+		setBits:       setBits,
+	}
+	if stats.bitSize == 0 || stats.hashFunctions == 0 {
+		return stats, nil
+	}
+	fillRatio := stats.fillRatio()
+	m, k := float64(stats.bitSize), float64(stats.hashFunctions)
+	if fillRatio >= 1 {
+		// saturated, every lookup passes the filter
+		stats.approxElementCount = math.MaxUint64
+		stats.estimatedFPR = 1
+		return stats, nil
+	}
+	stats.approxElementCount = uint64(math.Round(-m / k * math.Log1p(-fillRatio)))
+	stats.estimatedFPR = math.Pow(fillRatio, k)
+	return stats, nil
+}
+
+// getBloomFilterStats returns the statistics of the pk bloom filter and the lookups pruned by it
+func (s *Segment) getBloomFilterStats() (*bloomFilterStats, error) {
+	if s.pkFilter == nil {
+		return nil, fmt.Errorf("bloom filter of segment %d is nil", s.ID())
+	}
+	stats, err := newBloomFilterStats(s.pkFilter)
+	if err != nil {
+		return nil, err
+	}
+	stats.lookups = s.bloomFilterLookups.Load()
+	stats.pruned = s.bloomFilterPruned.Load()
+	return stats, nil
+}
+
+// recordBloomFilterLookups counts the pks tested against the bloom filter and the ones pruned by it
+func (s *Segment) recordBloomFilterLookups(lookups, pruned int) {
+	s.bloomFilterLookups.Add(int64(lookups))
+	s.bloomFilterPruned.Add(int64(pruned))
+	metrics.QueryNodeBloomFilterPrunedPKs.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Add(float64(pruned))
+}
@@ -0,0 +1,108 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bits-and-blooms/bloom/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/common"
+)
+
+func TestNewBloomFilterStats(t *testing.T) {
+	buf := make([]byte, 8)
+	var stats *bloomFilterStats
+	for _, n := range []int{1000, 10000, 50000, int(bloomFilterSize)} {
+		filter := bloom.NewWithEstimates(bloomFilterSize, maxBloomFalsePositive)
+		for i := 0; i < n; i++ {
+			common.Endian.PutUint64(buf, uint64(i))
+			filter.Add(buf)
+		}
+		var err error
+		stats, err = newBloomFilterStats(filter)
+		require.NoError(t, err)
+		assert.Equal(t, filter.Cap(), stats.bitSize)
+		assert.Equal(t, filter.K(), stats.hashFunctions)
+
+		// the analytic false positive rate of n elements is (1 - e^(-kn/m))^k
+		m, k := float64(stats.bitSize), float64(stats.hashFunctions)
+		analyticFillRatio := 1 - math.Exp(-k*float64(n)/m)
+		assert.InEpsilon(t, analyticFillRatio, stats.fillRatio(), 0.01, "n = %d", n)
+		assert.InEpsilon(t, math.Pow(analyticFillRatio, k), stats.estimatedFPR, 0.05, "n = %d", n)
+		assert.InEpsilon(t, n, stats.approxElementCount, 0.01, "n = %d", n)
+	}
+	// the filter is sized for bloomFilterSize elements, the estimated rate holds the designed bound when full
+	assert.InEpsilon(t, maxBloomFalsePositive, stats.estimatedFPR, 0.1)
+
+	t.Run("empty", func(t *testing.T) {
+		stats, err := newBloomFilterStats(bloom.NewWithEstimates(bloomFilterSize, maxBloomFalsePositive))
+		require.NoError(t, err)
+		assert.Equal(t, uint(0), stats.setBits)
+		assert.Equal(t, uint64(0), stats.approxElementCount)
+		assert.Equal(t, float64(0), stats.estimatedFPR)
+	})
+
+	t.Run("saturated", func(t *testing.T) {
+		filter := bloom.New(64, 3)
+		for i := 0; i < 10000; i++ {
+			common.Endian.PutUint64(buf, uint64(i))
+			filter.Add(buf)
+		}
+		stats, err := newBloomFilterStats(filter)
+		require.NoError(t, err)
+		assert.Equal(t, float64(1), stats.fillRatio())
+		assert.Equal(t, uint64(math.MaxUint64), stats.approxElementCount)
+		assert.Equal(t, float64(1), stats.estimatedFPR)
+	})
+}
+
+func TestSegment_getBloomFilterStats(t *testing.T) {
+	segment, err := genSimpleSealedSegment()
+	require.NoError(t, err)
+	defer deleteSegment(segment)
+
+	pks := make([]primaryKey, defaultMsgLength)
+	for i := range pks {
+		pks[i] = newInt64PrimaryKey(int64(i))
+	}
+	segment.updateBloomFilter(pks)
+	deletePks := []primaryKey{newInt64PrimaryKey(0), newInt64PrimaryKey(int64(defaultMsgLength) * 1000)}
+	_, _, err = filterSegmentsByPKs(deletePks, []Timestamp{1, 1}, segment)
+	require.NoError(t, err)
+
+	stats, err := segment.getBloomFilterStats()
+	require.NoError(t, err)
+	assert.InDelta(t, defaultMsgLength, stats.approxElementCount, 1)
+	assert.Equal(t, int64(2), stats.lookups)
+	assert.LessOrEqual(t, stats.pruned, int64(1))
+
+	info := stats.toProto()
+	assert.Equal(t, uint64(segment.pkFilter.Cap()), info.GetBitSize())
+	assert.Equal(t, uint32(segment.pkFilter.K()), info.GetHashFunctions())
+	assert.Equal(t, stats.fillRatio(), info.GetFillRatio())
+	assert.Equal(t, stats.estimatedFPR, info.GetEstimatedFpr())
+	assert.Equal(t, int64(2), info.GetLookups())
+
+	t.Run("nil filter", func(t *testing.T) {
+		_, err := (&Segment{segmentID: defaultSegmentID}).getBloomFilterStats()
+		assert.Error(t, err)
+	})
+}
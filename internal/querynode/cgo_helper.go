@@ -28,7 +28,6 @@ package querynode
 import "C"
 
 import (
-	"errors"
 	"fmt"
 	"unsafe"
 
@@ -42,18 +41,16 @@ func HandleCStatus(status *C.CStatus, extraInfo string) error {
 	if status.error_code == 0 {
 		return nil
 	}
-	errorCode := status.error_code
-	errorName, ok := commonpb.ErrorCode_name[int32(errorCode)]
-	if !ok {
-		errorName = "UnknownError"
-	}
 	errorMsg := C.GoString(status.error_msg)
 	defer C.free(unsafe.Pointer(status.error_msg))
 
-	finalMsg := fmt.Sprintf("[%s] %s", errorName, errorMsg)
-	logMsg := fmt.Sprintf("%s, C Runtime Exception: %s\n", extraInfo, finalMsg)
+	err := &SegcoreError{
+		code:    commonpb.ErrorCode(status.error_code),
+		message: errorMsg,
+	}
+	logMsg := fmt.Sprintf("%s, C Runtime Exception: %s\n", extraInfo, err.Error())
 	log.Warn(logMsg)
-	return errors.New(finalMsg)
+	return err
 }
 
 func CopyCProtoBlob(cProto *C.CProto) []byte {
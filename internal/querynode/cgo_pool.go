@@ -0,0 +1,158 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+var (
+	// cgoReadPool runs the search and retrieve calls into segcore, configured at Init
+	cgoReadPool = newCgoPool(metrics.CgoReadPoolLabel)
+	// cgoWritePool runs the insert, delete and load calls into segcore, configured at Init
+	cgoWritePool = newCgoPool(metrics.CgoWritePoolLabel)
+)
+
+// cgoTask is a call run by a worker of cgoPool, the panic of fn is handed back to the caller
+type cgoTask struct {
+	fn       func() error
+	enqueued time.Time
+	err      error
+	panicked interface{}
+	done     chan struct{}
+}
+
+// cgoPool is a bounded executor of cgo calls. The calls run on a fixed number of workers, each locked to an OS thread
+// of its own, and wait in a queue while all the workers are busy. The read and write paths have pools of their own,
+// so that heavy searches occupying all the read threads never delay the inserts and deletes applied by the flow
+// graphs. A pool of non-positive size runs the calls on the threads of the callers. A call must not run another
+// call on the same pool, which would deadlock once all the workers are busy.
+type cgoPool struct {
+	name  string
+	tasks chan *cgoTask // unbuffered, the calls wait until taken by the workers
+
+	mu   sync.RWMutex // guards size and quit
+	size int
+	quit chan struct{} // closed to stop the current workers once resized, nil if the pool has no worker
+
+	queued atomic.Int64 // calls waiting for the workers
+}
+
+func newCgoPool(name string) *cgoPool {
+	return &cgoPool{name: name, tasks: make(chan *cgoTask)}
+}
+
+// setSize replaces the workers of the pool by size workers, the old ones exit once their running calls are done,
+// and the calls waiting are handed to the new ones
+func (p *cgoPool) setSize(size int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.quit != nil {
+		close(p.quit)
+		p.quit = nil
+	}
+	p.size = 0
+	if size <= 0 {
+		return
+	}
+	p.size = size
+	p.quit = make(chan struct{})
+	for i := 0; i < size; i++ {
+		go p.work(p.quit)
+	}
+}
+
+// getSize returns the number of the workers, 0 if the calls run on the threads of the callers
+func (p *cgoPool) getSize() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.size
+}
+
+// run runs fn on a worker of the pool and waits for it, the panic of fn is raised again on the caller, so that
+// it's recovered as the panic of the segment operation by guard
+func (p *cgoPool) run(fn func() error) error {
+	task := &cgoTask{fn: fn, enqueued: time.Now(), done: make(chan struct{})}
+	for {
+		p.mu.RLock()
+		quit := p.quit
+		p.mu.RUnlock()
+		if quit == nil {
+			return fn()
+		}
+		if p.submit(task, quit) {
+			break
+		}
+	}
+
+	<-task.done
+	if task.panicked != nil {
+		panic(task.panicked)
+	}
+	return task.err
+}
+
+// submit waits until task is taken by a worker, it returns false if the workers are replaced meanwhile
+func (p *cgoPool) submit(task *cgoTask, quit <-chan struct{}) bool {
+	p.observeQueue(p.queued.Inc())
+	defer func() {
+		p.observeQueue(p.queued.Dec())
+	}()
+	select {
+	case p.tasks <- task:
+		return true
+	case <-quit:
+		return false
+	}
+}
+
+func (p *cgoPool) work(quit <-chan struct{}) {
+	// the cgo calls of the worker are always on the same thread, which is never shared with other goroutines
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	for {
+		select {
+		case <-quit:
+			return
+		case task := <-p.tasks:
+			metrics.QueryNodeCgoPoolWaitLatency.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), p.name).
+				Observe(float64(time.Since(task.enqueued).Milliseconds()))
+			p.execute(task)
+		}
+	}
+}
+
+func (p *cgoPool) execute(task *cgoTask) {
+	defer close(task.done)
+	defer func() {
+		if r := recover(); r != nil {
+			task.panicked = r
+		}
+	}()
+	task.err = task.fn()
+}
+
+func (p *cgoPool) observeQueue(queued int64) {
+	metrics.QueryNodeCgoPoolQueueLength.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), p.name).Set(float64(queued))
+}
@@ -0,0 +1,167 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestCgoPool_run(t *testing.T) {
+	t.Run("on callers", func(t *testing.T) {
+		pool := newCgoPool("test")
+		assert.Equal(t, 0, pool.getSize())
+		assert.NoError(t, pool.run(func() error { return nil }))
+		assert.Error(t, pool.run(func() error { return errors.New("mock") }))
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		pool := newCgoPool("test")
+		pool.setSize(2)
+		defer pool.setSize(0)
+		assert.Equal(t, 2, pool.getSize())
+
+		var running, maxRunning atomic.Int64
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := pool.run(func() error {
+					n := running.Inc()
+					for {
+						max := maxRunning.Load()
+						if n <= max || maxRunning.CAS(max, n) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+					running.Dec()
+					return nil
+				})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int64(2), maxRunning.Load())
+		assert.Equal(t, int64(0), pool.queued.Load())
+	})
+
+	t.Run("error and panic", func(t *testing.T) {
+		pool := newCgoPool("test")
+		pool.setSize(1)
+		defer pool.setSize(0)
+
+		assert.Error(t, pool.run(func() error { return errors.New("mock") }))
+		assert.PanicsWithValue(t, "mock", func() {
+			_ = pool.run(func() error { panic("mock") })
+		})
+		// the worker survives the panic
+		assert.NoError(t, pool.run(func() error { return nil }))
+	})
+
+	t.Run("resize", func(t *testing.T) {
+		pool := newCgoPool("test")
+		pool.setSize(1)
+		release := make(chan struct{})
+		wg := sync.WaitGroup{}
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, pool.run(func() error {
+					<-release
+					return nil
+				}))
+			}()
+		}
+		require.Eventually(t, func() bool { return pool.queued.Load() == 2 }, time.Second, time.Millisecond)
+
+		// the calls waiting are handed to the new workers
+		pool.setSize(3)
+		assert.Equal(t, 3, pool.getSize())
+		assert.NoError(t, pool.run(func() error { return nil }))
+		close(release)
+		wg.Wait()
+
+		pool.setSize(0)
+		assert.Equal(t, 0, pool.getSize())
+		assert.NoError(t, pool.run(func() error { return nil }))
+	})
+}
+
+func TestCgoPool_insertNotDelayedBySearch(t *testing.T) {
+	cgoReadPool.setSize(2)
+	defer cgoReadPool.setSize(0)
+	cgoWritePool.setSize(2)
+	defer cgoWritePool.setSize(0)
+
+	streaming, err := genSimpleReplica()
+	require.NoError(t, err)
+	insertNode := newInsertNode(streaming)
+	require.NoError(t, streaming.addSegment(defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel,
+		segmentTypeGrowing, true))
+	segment, err := streaming.getSegmentByID(defaultSegmentID)
+	require.NoError(t, err)
+
+	insert := func() time.Duration {
+		insertData, err := genFlowGraphInsertData()
+		require.NoError(t, err)
+		offset, err := segment.segmentPreInsert(len(insertData.insertIDs[defaultSegmentID]))
+		require.NoError(t, err)
+		insertData.insertOffset[defaultSegmentID] = offset
+
+		start := time.Now()
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		insertNode.insert(insertData, defaultSegmentID, wg)
+		wg.Wait()
+		return time.Since(start)
+	}
+	baseline := insert()
+	rows := segment.getRowCount()
+	require.Greater(t, rows, int64(0))
+
+	// saturate the read pool with the searches never done until released
+	release := make(chan struct{})
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = cgoReadPool.run(func() error {
+				<-release
+				return nil
+			})
+		}()
+	}
+	defer func() {
+		close(release)
+		wg.Wait()
+	}()
+	require.Eventually(t, func() bool { return cgoReadPool.queued.Load() == 8 }, time.Second, time.Millisecond)
+
+	latency := insert()
+	assert.Equal(t, 2*rows, segment.getRowCount())
+	assert.Less(t, latency, baseline+100*time.Millisecond)
+}
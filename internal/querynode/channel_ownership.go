@@ -0,0 +1,126 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	queryPb "github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+// watchAction is what a WatchDmChannels request does to a channel, decided by the version of the request against
+// the version the channel is watched with
+type watchAction int32
+
+const (
+	// the channel is not watched, watch it
+	watchActionNew watchAction = iota
+	// the retry of the request the channel is watched with, nothing to do
+	watchActionDuplicate
+	// the channel is reassigned, tear down the flow graph and watch it again from the new positions
+	watchActionRebuild
+	// the request is older than the one the channel is watched with, reject it
+	watchActionStale
+)
+
+// channelOwner is the WatchDmChannels request a dm channel is watched with
+type channelOwner struct {
+	collectionID UniqueID
+	replicaID    UniqueID
+	version      int64
+	position     *internalpb.MsgPosition
+	// the unflushed segments loaded by the request, removed along with the flow graph on rebuild
+	growingSegmentIDs []UniqueID
+}
+
+// channelOwnership fences the WatchDmChannels requests by the versions of the channels, so that the retries of
+// querycoord are idempotent and the requests outdated by a reassignment of the channels don't take effect
+type channelOwnership struct {
+	mu     sync.RWMutex
+	owners map[Channel]*channelOwner
+}
+
+func newChannelOwnership() *channelOwnership {
+	return &channelOwnership{
+		owners: make(map[Channel]*channelOwner),
+	}
+}
+
+// check returns the action of the request of version on channel, together with the current owner if watched.
+// Version 0 is of the unversioned requests, which are never taken as duplicate or stale, and always rebuild
+// the watched channel.
+func (o *channelOwnership) check(channel Channel, version int64) (watchAction, *channelOwner) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	owner, ok := o.owners[channel]
+	switch {
+	case !ok:
+		return watchActionNew, nil
+	case version == 0:
+		return watchActionRebuild, owner
+	case version == owner.version:
+		return watchActionDuplicate, owner
+	case version > owner.version:
+		return watchActionRebuild, owner
+	default:
+		return watchActionStale, owner
+	}
+}
+
+// own records channel is watched with owner
+func (o *channelOwnership) own(channel Channel, owner *channelOwner) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.owners[channel] = owner
+}
+
+// get returns the owner of channel
+func (o *channelOwnership) get(channel Channel) (*channelOwner, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	owner, ok := o.owners[channel]
+	return owner, ok
+}
+
+// release forgets channels, the requests of any version watch them again
+func (o *channelOwnership) release(channels []Channel) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, channel := range channels {
+		delete(o.owners, channel)
+	}
+}
+
+// getDistribution returns the watched channels ordered by name
+func (o *channelOwnership) getDistribution() []*queryPb.DmChannelOwnership {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	channels := make([]*queryPb.DmChannelOwnership, 0, len(o.owners))
+	for channel, owner := range o.owners {
+		channels = append(channels, &queryPb.DmChannelOwnership{
+			Channel:      channel,
+			CollectionID: owner.collectionID,
+			ReplicaID:    owner.replicaID,
+			Version:      owner.version,
+			SeekPosition: owner.position,
+		})
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].Channel < channels[j].Channel })
+	return channels
+}
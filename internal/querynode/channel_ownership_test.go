@@ -0,0 +1,80 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+)
+
+func TestChannelOwnership(t *testing.T) {
+	ownership := newChannelOwnership()
+
+	action, owner := ownership.check(defaultDMLChannel, 2)
+	assert.Equal(t, watchActionNew, action)
+	assert.Nil(t, owner)
+
+	ownership.own(defaultDMLChannel, &channelOwner{
+		collectionID: defaultCollectionID,
+		replicaID:    1,
+		version:      2,
+		position:     &internalpb.MsgPosition{ChannelName: defaultDMLChannel, Timestamp: 100},
+	})
+	cases := []struct {
+		version int64
+		action  watchAction
+	}{
+		{0, watchActionRebuild},
+		{1, watchActionStale},
+		{2, watchActionDuplicate},
+		{3, watchActionRebuild},
+	}
+	for _, c := range cases {
+		action, owner = ownership.check(defaultDMLChannel, c.version)
+		assert.Equal(t, c.action, action)
+		assert.Equal(t, int64(2), owner.version)
+	}
+
+	// the other channels are not affected
+	action, _ = ownership.check(defaultDMLChannel+"_1", 1)
+	assert.Equal(t, watchActionNew, action)
+
+	ownership.own(defaultDMLChannel+"_0", &channelOwner{collectionID: defaultCollectionID, version: 5})
+	channels := ownership.getDistribution()
+	assert.Len(t, channels, 2)
+	assert.Equal(t, defaultDMLChannel, channels[0].GetChannel())
+	assert.Equal(t, int64(1), channels[0].GetReplicaID())
+	assert.Equal(t, int64(2), channels[0].GetVersion())
+	assert.Equal(t, Timestamp(100), channels[0].GetSeekPosition().GetTimestamp())
+	assert.Equal(t, defaultDMLChannel+"_0", channels[1].GetChannel())
+	assert.Equal(t, int64(5), channels[1].GetVersion())
+
+	ownership.release([]Channel{defaultDMLChannel})
+	_, ok := ownership.get(defaultDMLChannel)
+	assert.False(t, ok)
+	action, _ = ownership.check(defaultDMLChannel, 1)
+	assert.Equal(t, watchActionNew, action)
+	assert.Len(t, ownership.getDistribution(), 1)
+
+	// the unversioned requests rebuild the channel watched by the unversioned ones
+	ownership.own(defaultDMLChannel, &channelOwner{collectionID: defaultCollectionID})
+	action, _ = ownership.check(defaultDMLChannel, 0)
+	assert.Equal(t, watchActionRebuild, action)
+}
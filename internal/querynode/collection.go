@@ -32,25 +32,44 @@ import "C"
 import (
 	"fmt"
 	"math"
+	"strconv"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/milvus-io/milvus/internal/metrics"
 
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// the bounds of the chunk rows override of growing segments
+const (
+	minGrowingChunkRows = 256
+	maxGrowingChunkRows = 1 << 20
 )
 
 // Collection is a wrapper of the underlying C-structure C.CCollection
 type Collection struct {
 	collectionPtr C.CCollection
 	id            UniqueID
-	partitionIDs  []UniqueID
-	schema        *schemapb.CollectionSchema
+
+	schemaMu     sync.RWMutex // guards schema and the field lookup caches below
+	schema       *schemapb.CollectionSchema
+	fieldByID    map[FieldID]*collectionField
+	fieldByName  map[string]*collectionField
+	vectorFields []*collectionField
+	pkField      *collectionField
+	// the field routing the rows to the partitions, nil if the collection has no partition key
+	partitionKeyField *collectionField
 
 	channelMu      sync.RWMutex
 	vChannels      []Channel
@@ -60,6 +79,30 @@ type Collection struct {
 
 	loadType loadType
 
+	// expected row count of growing segments, used to pre-allocate segment memory
+	segmentRowBudget atomic.Int64
+	// rows of a chunk of growing segments, 0 means queryNode.segcore.chunkRows
+	growingChunkRows atomic.Int64
+	// time to live of the rows in seconds, 0 means no TTL
+	ttlSeconds atomic.Int64
+
+	// histograms of the scalar fields estimating the selectivity of the predicates
+	histograms fieldHistogramCache
+
+	maskMu sync.RWMutex // guards mask
+	mask   fieldMask
+
+	// deletes from the delta channels matching no sealed segment, replayed against the sealed segments loaded later
+	sealedDeletes *sealedDeleteBuffer
+
+	chunkStatsMu sync.RWMutex // guards chunkStatsFields
+	// the integer fields whose ranges are tracked per chunk by the chunk search of the growing segments created later
+	chunkStatsFields []FieldID
+
+	pkIndexMu sync.RWMutex // guards pkIndexEnabled
+	// overrides queryNode.segcore.pkIndex.enabled for the sealed segments loaded later, nil to follow the config
+	pkIndexEnabled *bool
+
 	releaseMu          sync.RWMutex // guards release
 	releasedPartitions map[UniqueID]struct{}
 	releaseTime        Timestamp
@@ -70,31 +113,183 @@ func (c *Collection) ID() UniqueID {
 	return c.id
 }
 
+// collectionField caches a field schema together with its parsed type params
+type collectionField struct {
+	schema    *schemapb.FieldSchema
+	dim       int64 // only for vector fields
+	maxLength int64 // only for variable-length fields
+}
+
+// ID returns the field id
+func (f *collectionField) ID() FieldID {
+	return f.schema.GetFieldID()
+}
+
 // Schema returns the schema of collection
 func (c *Collection) Schema() *schemapb.CollectionSchema {
+	c.schemaMu.RLock()
+	defer c.schemaMu.RUnlock()
 	return c.schema
 }
 
-// addPartitionID would add a partition id to partition id list of collection
-func (c *Collection) addPartitionID(partitionID UniqueID) {
-	c.releaseMu.Lock()
-	defer c.releaseMu.Unlock()
+// updateSchema replaces the schema of collection and refreshes the field lookup caches
+func (c *Collection) updateSchema(schema *schemapb.CollectionSchema) {
+	fieldByID := make(map[FieldID]*collectionField, len(schema.GetFields()))
+	fieldByName := make(map[string]*collectionField, len(schema.GetFields()))
+	vectorFields := make([]*collectionField, 0)
+	var pkField, partitionKeyField *collectionField
+	for _, fieldSchema := range schema.GetFields() {
+		field := newCollectionField(fieldSchema)
+		fieldByID[fieldSchema.GetFieldID()] = field
+		fieldByName[fieldSchema.GetName()] = field
+		if typeutil.IsVectorType(fieldSchema.GetDataType()) {
+			vectorFields = append(vectorFields, field)
+		}
+		if fieldSchema.GetIsPrimaryKey() {
+			pkField = field
+		}
+		if fieldSchema.GetIsPartitionKey() {
+			partitionKeyField = field
+		}
+	}
+
+	c.schemaMu.Lock()
+	defer c.schemaMu.Unlock()
+	c.schema = schema
+	c.fieldByID = fieldByID
+	c.fieldByName = fieldByName
+	c.vectorFields = vectorFields
+	c.pkField = pkField
+	c.partitionKeyField = partitionKeyField
+}
+
+// getFieldByID returns the cached field of fieldID
+func (c *Collection) getFieldByID(fieldID FieldID) (*collectionField, error) {
+	c.schemaMu.RLock()
+	defer c.schemaMu.RUnlock()
+	field, ok := c.fieldByID[fieldID]
+	if !ok {
+		return nil, errFieldIDNotFound(c.id, fieldID)
+	}
+	return field, nil
+}
+
+// getFieldByName returns the cached field of fieldName
+func (c *Collection) getFieldByName(fieldName string) (*collectionField, error) {
+	c.schemaMu.RLock()
+	defer c.schemaMu.RUnlock()
+	field, ok := c.fieldByName[fieldName]
+	if !ok {
+		return nil, errFieldNameNotFound(c.id, fieldName)
+	}
+	return field, nil
+}
+
+// getVectorFields returns the vector fields of collection, in schema order
+func (c *Collection) getVectorFields() []*collectionField {
+	c.schemaMu.RLock()
+	defer c.schemaMu.RUnlock()
+	fields := make([]*collectionField, len(c.vectorFields))
+	copy(fields, c.vectorFields)
+	return fields
+}
+
+// checkSearchable returns an error if collection has no vector field to search by, the scalar-only collections
+// only serve queries
+func (c *Collection) checkSearchable() error {
+	c.schemaMu.RLock()
+	defer c.schemaMu.RUnlock()
+	if len(c.vectorFields) == 0 {
+		return &searchUnsupportedError{collectionID: c.id}
+	}
+	return nil
+}
+
+// getPKField returns the primary key field of collection
+func (c *Collection) getPKField() (*collectionField, error) {
+	c.schemaMu.RLock()
+	defer c.schemaMu.RUnlock()
+	if c.pkField == nil {
+		return nil, errPKFieldNotFound(c.id)
+	}
+	return c.pkField, nil
+}
+
+// getPartitionKeyField returns the partition key field of collection, nil if there is none
+func (c *Collection) getPartitionKeyField() *collectionField {
+	c.schemaMu.RLock()
+	defer c.schemaMu.RUnlock()
+	return c.partitionKeyField
+}
+
+func newCollectionField(fieldSchema *schemapb.FieldSchema) *collectionField {
+	field := &collectionField{schema: fieldSchema}
+	switch {
+	case typeutil.IsVectorType(fieldSchema.GetDataType()):
+		for _, kv := range fieldSchema.GetTypeParams() {
+			if kv.GetKey() == "dim" {
+				dim, err := strconv.ParseInt(kv.GetValue(), 10, 64)
+				if err != nil {
+					log.Warn("failed to parse dim of vector field",
+						zap.Int64("fieldID", fieldSchema.GetFieldID()), zap.String("dim", kv.GetValue()), zap.Error(err))
+					break
+				}
+				field.dim = dim
+			}
+		}
+	case typeutil.IsStringType(fieldSchema.GetDataType()):
+		maxLength, err := typeutil.GetMaxLengthOfVarLengthField(fieldSchema)
+		if err == nil {
+			field.maxLength = int64(maxLength)
+		}
+	}
+	return field
+}
 
-	c.partitionIDs = append(c.partitionIDs, partitionID)
-	log.Debug("queryNode collection info after add a partition",
-		zap.Int64("partitionID", partitionID), zap.Int64("collectionID", c.id),
-		zap.Int64s("partitions", c.partitionIDs))
+// estimateSize returns the estimated size in bytes of one value of the field by typeutil.EstimateFieldSize,
+// 0 if it can't be estimated
+func (f *collectionField) estimateSize(avgLength int) int64 {
+	size, err := typeutil.EstimateFieldSize(f.schema, avgLength)
+	if err != nil {
+		return 0
+	}
+	return int64(size)
 }
 
-// removePartitionID removes the partition id from partition id list of collection
-func (c *Collection) removePartitionID(partitionID UniqueID) {
-	tmpIDs := make([]UniqueID, 0, len(c.partitionIDs))
-	for _, id := range c.partitionIDs {
-		if id != partitionID {
-			tmpIDs = append(tmpIDs, id)
+// rowSize returns the size in bytes of a row of row based inserts, which is the sum of the sizes of the user fields
+// as laid out by segcore, or 0 if the collection has variable-length fields
+func (c *Collection) rowSize() int64 {
+	c.schemaMu.RLock()
+	defer c.schemaMu.RUnlock()
+	var size int64
+	for fieldID, field := range c.fieldByID {
+		if fieldID < common.StartOfUserFieldID {
+			continue
+		}
+		if typeutil.IsStringType(field.schema.GetDataType()) {
+			return 0
 		}
+		size += field.estimateSize(0)
+	}
+	return size
+}
+
+// EstimateRowSize returns the estimated size in bytes of one row of collection.
+// avgVarCharLen gives the average length of variable-length fields, fields absent
+// from it are counted by their max length.
+func (c *Collection) EstimateRowSize(avgVarCharLen map[FieldID]int) int64 {
+	c.schemaMu.RLock()
+	defer c.schemaMu.RUnlock()
+	var size int64
+	for fieldID, field := range c.fieldByID {
+		size += field.estimateSize(avgVarCharLen[fieldID])
 	}
-	c.partitionIDs = tmpIDs
+	return size
+}
+
+// EstimateSegmentSize returns the estimated size in bytes of a segment with rowCount rows
+func (c *Collection) EstimateSegmentSize(rowCount int64) int64 {
+	return rowCount * c.EstimateRowSize(nil)
 }
 
 // addVChannels adds virtual channels to collection
@@ -289,6 +484,121 @@ func (c *Collection) getLoadType() loadType {
 	return c.loadType
 }
 
+// setSegmentRowBudget sets the expected row count of growing segments, 0 means no hint
+func (c *Collection) setSegmentRowBudget(budget int64) {
+	c.segmentRowBudget.Store(budget)
+}
+
+// getSegmentRowBudget returns the expected row count of growing segments
+func (c *Collection) getSegmentRowBudget() int64 {
+	return c.segmentRowBudget.Load()
+}
+
+// setGrowingChunkRows overrides the rows of a chunk of the growing segments created later, 0 means no override,
+// the rows should be validated by validateGrowingChunkRows
+func (c *Collection) setGrowingChunkRows(rows int64) {
+	c.growingChunkRows.Store(rows)
+}
+
+// getGrowingChunkRows returns the rows of a chunk of growing segments
+func (c *Collection) getGrowingChunkRows() int64 {
+	if rows := c.growingChunkRows.Load(); rows > 0 {
+		return rows
+	}
+	return Params.QueryNodeCfg.ChunkRows
+}
+
+// validateGrowingChunkRows checks the chunk rows override of growing segments, 0 means no override
+func validateGrowingChunkRows(rows int64) error {
+	if rows != 0 && (rows < minGrowingChunkRows || rows > maxGrowingChunkRows) {
+		return fmt.Errorf("invalid growing chunk rows %d, should be 0 or in range [%d, %d]", rows, minGrowingChunkRows, maxGrowingChunkRows)
+	}
+	return nil
+}
+
+// setTTL sets the time to live of the rows of collection in seconds, 0 means no TTL
+func (c *Collection) setTTL(ttlSeconds int64) {
+	c.ttlSeconds.Store(ttlSeconds)
+}
+
+// getTTL returns the time to live of the rows of collection, 0 means no TTL
+func (c *Collection) getTTL() time.Duration {
+	return time.Duration(c.ttlSeconds.Load()) * time.Second
+}
+
+// setMaskedFields sets the fields of collection whose values never leave the query node, nil to mask no field
+func (c *Collection) setMaskedFields(fieldIDs []FieldID) {
+	masked := make(map[FieldID]struct{}, len(fieldIDs))
+	for _, fieldID := range fieldIDs {
+		masked[fieldID] = struct{}{}
+	}
+	c.maskMu.Lock()
+	defer c.maskMu.Unlock()
+	c.mask.fieldIDs = masked
+}
+
+// setMaskReject sets whether the requests outputting the masked fields are rejected instead of masked
+func (c *Collection) setMaskReject(reject bool) {
+	c.maskMu.Lock()
+	defer c.maskMu.Unlock()
+	c.mask.reject = reject
+}
+
+// getFieldMask returns the masked fields of collection
+func (c *Collection) getFieldMask() fieldMask {
+	c.maskMu.RLock()
+	defer c.maskMu.RUnlock()
+	return c.mask
+}
+
+// setChunkStatsFields sets the fields tracked per chunk by the chunk search of the growing segments created later,
+// the fields should be validated by parseChunkStatsFields
+func (c *Collection) setChunkStatsFields(fieldIDs []FieldID) {
+	c.chunkStatsMu.Lock()
+	defer c.chunkStatsMu.Unlock()
+	c.chunkStatsFields = fieldIDs
+}
+
+// getChunkStatsFields returns the fields tracked per chunk by the chunk search of growing segments
+func (c *Collection) getChunkStatsFields() []FieldID {
+	c.chunkStatsMu.RLock()
+	defer c.chunkStatsMu.RUnlock()
+	return c.chunkStatsFields
+}
+
+// setPKIndexEnabled overrides queryNode.segcore.pkIndex.enabled for the sealed segments loaded later
+func (c *Collection) setPKIndexEnabled(enabled bool) {
+	c.pkIndexMu.Lock()
+	defer c.pkIndexMu.Unlock()
+	c.pkIndexEnabled = &enabled
+}
+
+// isPKIndexEnabled returns whether the pk index is built for the sealed segments loaded
+func (c *Collection) isPKIndexEnabled() bool {
+	c.pkIndexMu.RLock()
+	defer c.pkIndexMu.RUnlock()
+	if c.pkIndexEnabled == nil {
+		return Params.QueryNodeCfg.EnableSealedPKIndex
+	}
+	return *c.pkIndexEnabled
+}
+
+// getExpireTs returns the timestamp before which the inserted rows are expired by TTL when read at readTs,
+// 0 if collection has no TTL. The expiration is derived from the read ts instead of the local clock, so that
+// the replicas serving a request agree on the visible rows. No row expires for a read ts of 0 or MaxTimestamp,
+// which are not bound to a physical time.
+func (c *Collection) getExpireTs(readTs Timestamp) Timestamp {
+	ttl := c.getTTL()
+	if ttl <= 0 || readTs == 0 || readTs == typeutil.MaxTimestamp {
+		return 0
+	}
+	physical, _ := tsoutil.ParseHybridTs(readTs)
+	if physical <= ttl.Milliseconds() {
+		return 0
+	}
+	return tsoutil.ComposeTS(physical-ttl.Milliseconds(), 0)
+}
+
 // newCollection returns a new Collection
 func newCollection(collectionID UniqueID, schema *schemapb.CollectionSchema) *Collection {
 	/*
@@ -303,10 +613,12 @@ func newCollection(collectionID UniqueID, schema *schemapb.CollectionSchema) *Co
 	var newCollection = &Collection{
 		collectionPtr:      collection,
 		id:                 collectionID,
-		schema:             schema,
 		releasedPartitions: make(map[UniqueID]struct{}),
+		sealedDeletes: newSealedDeleteBuffer(collectionID,
+			Params.QueryNodeCfg.SealedDeleteBufferWindow, Params.QueryNodeCfg.SealedDeleteBufferMaxSize),
 	}
 	C.free(unsafe.Pointer(cSchemaBlob))
+	newCollection.updateSchema(schema)
 
 	log.Debug("create collection", zap.Int64("collectionID", collectionID))
 
@@ -324,6 +636,7 @@ func deleteCollection(collection *Collection) {
 	C.DeleteCollection(cPtr)
 
 	collection.collectionPtr = nil
+	collection.sealedDeletes.clear()
 
 	log.Debug("delete collection", zap.Int64("collectionID", collection.ID()))
 
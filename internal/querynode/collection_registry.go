@@ -0,0 +1,106 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// collectionRegistry owns the Collection objects of the query node, shared by the historical and streaming replicas,
+// so that both replicas see the same schema and the plans built against a collection match the segments of either
+// replica. Every replica holding a collection references it, the collection is deleted once all of them released it.
+type collectionRegistry struct {
+	mu          sync.Mutex
+	collections map[UniqueID]*registeredCollection
+}
+
+// registeredCollection is a collection with the number of replicas referencing it
+type registeredCollection struct {
+	collection *Collection
+	refCount   int
+}
+
+func newCollectionRegistry() *collectionRegistry {
+	return &collectionRegistry{
+		collections: make(map[UniqueID]*registeredCollection),
+	}
+}
+
+// acquire references the collection, which is created with schema if not registered yet
+func (r *collectionRegistry) acquire(collectionID UniqueID, schema *schemapb.CollectionSchema) *Collection {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registered, ok := r.collections[collectionID]
+	if !ok {
+		registered = &registeredCollection{collection: newCollection(collectionID, schema)}
+		r.collections[collectionID] = registered
+		metrics.QueryNodeNumCollections.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Set(float64(len(r.collections)))
+	}
+	registered.refCount++
+	return registered.collection
+}
+
+// release dereferences the collection, and deletes it once it is not referenced by any replica
+func (r *collectionRegistry) release(collectionID UniqueID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registered, ok := r.collections[collectionID]
+	if !ok {
+		return fmt.Errorf("collection %d is not registered", collectionID)
+	}
+	registered.refCount--
+	if registered.refCount > 0 {
+		return nil
+	}
+	deleteCollection(registered.collection)
+	delete(r.collections, collectionID)
+	log.Debug("collection released by all replicas", zap.Int64("collectionID", collectionID))
+	metrics.QueryNodeNumCollections.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Set(float64(len(r.collections)))
+	return nil
+}
+
+// get returns the registered collection
+func (r *collectionRegistry) get(collectionID UniqueID) (*Collection, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registered, ok := r.collections[collectionID]
+	if !ok {
+		return nil, fmt.Errorf("collection hasn't been loaded or has been released, collection id = %d", collectionID)
+	}
+	return registered.collection, nil
+}
+
+// refCount returns the number of replicas referencing the collection
+func (r *collectionRegistry) refCount(collectionID UniqueID) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if registered, ok := r.collections[collectionID]; ok {
+		return registered.refCount
+	}
+	return 0
+}
@@ -0,0 +1,167 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// genSharedReplicas generates the historical and streaming replicas sharing the collections of registry
+func genSharedReplicas(t *testing.T) (*collectionRegistry, ReplicaInterface, ReplicaInterface) {
+	kv, err := genEtcdKV()
+	require.NoError(t, err)
+	registry := newCollectionRegistry()
+	config := newQueryNodeConfig()
+	return registry, newCollectionReplica(kv, config, registry), newCollectionReplica(kv, config, registry)
+}
+
+func TestCollectionRegistry(t *testing.T) {
+	registry := newCollectionRegistry()
+	schema := genSimpleSegCoreSchema()
+
+	col := registry.acquire(defaultCollectionID, schema)
+	assert.Same(t, col, registry.acquire(defaultCollectionID, schema))
+	assert.Equal(t, 2, registry.refCount(defaultCollectionID))
+
+	require.NoError(t, registry.release(defaultCollectionID))
+	got, err := registry.get(defaultCollectionID)
+	assert.NoError(t, err)
+	assert.Same(t, col, got)
+	assert.NotNil(t, got.collectionPtr)
+
+	require.NoError(t, registry.release(defaultCollectionID))
+	_, err = registry.get(defaultCollectionID)
+	assert.Error(t, err)
+	assert.Nil(t, col.collectionPtr)
+	assert.Equal(t, 0, registry.refCount(defaultCollectionID))
+	assert.Error(t, registry.release(defaultCollectionID))
+
+	// the collection acquired again after released is a new one
+	again := registry.acquire(defaultCollectionID, schema)
+	assert.NotSame(t, col, again)
+	assert.NoError(t, registry.release(defaultCollectionID))
+}
+
+func TestCollectionReplica_sharedCollection(t *testing.T) {
+	_, historical, streaming := genSharedReplicas(t)
+	defer historical.freeAll()
+	defer streaming.freeAll()
+
+	hCol := historical.addCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	sCol := streaming.addCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	assert.Same(t, hCol, sCol)
+
+	t.Run("partitions", func(t *testing.T) {
+		require.NoError(t, historical.addPartition(defaultCollectionID, defaultPartitionID))
+		require.NoError(t, streaming.addPartition(defaultCollectionID, defaultPartitionID+1))
+
+		partitionIDs, err := historical.getPartitionIDs(defaultCollectionID)
+		assert.NoError(t, err)
+		assert.Equal(t, []UniqueID{defaultPartitionID}, partitionIDs)
+		partitionIDs, err = streaming.getPartitionIDs(defaultCollectionID)
+		assert.NoError(t, err)
+		assert.Equal(t, []UniqueID{defaultPartitionID + 1}, partitionIDs)
+
+		require.NoError(t, historical.removePartition(defaultPartitionID))
+		partitionIDs, err = historical.getPartitionIDs(defaultCollectionID)
+		assert.NoError(t, err)
+		assert.Empty(t, partitionIDs)
+		assert.True(t, streaming.hasPartition(defaultPartitionID+1))
+	})
+
+	t.Run("schema update", func(t *testing.T) {
+		schema := genSimpleSegCoreSchema()
+		schema.Description = "updated"
+		hCol.updateSchema(schema)
+
+		col, err := streaming.getCollectionByID(defaultCollectionID)
+		require.NoError(t, err)
+		assert.Equal(t, "updated", col.Schema().GetDescription())
+		_, err = col.getFieldByID(simplePKField.id)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCollectionReplica_releaseOrder(t *testing.T) {
+	for _, historicalFirst := range []bool{true, false} {
+		registry, historical, streaming := genSharedReplicas(t)
+		historical.addCollection(defaultCollectionID, genSimpleSegCoreSchema())
+		streaming.addCollection(defaultCollectionID, genSimpleSegCoreSchema())
+		require.NoError(t, streaming.addPartition(defaultCollectionID, defaultPartitionID))
+
+		first, second := streaming, historical
+		if historicalFirst {
+			first, second = historical, streaming
+		}
+		require.NoError(t, first.removeCollection(defaultCollectionID))
+		assert.False(t, first.hasCollection(defaultCollectionID))
+		assert.Equal(t, 1, registry.refCount(defaultCollectionID))
+
+		// the collection is still usable by the other replica
+		col, err := second.getCollectionByID(defaultCollectionID)
+		require.NoError(t, err)
+		assert.NotNil(t, col.collectionPtr)
+		plan, err := createRetrievePlanByExpr(col, genRetrievePlanExprWithPredicates(t, genPKRangeExpr(0, 10)), defaultMsgLength)
+		require.NoError(t, err)
+		plan.delete()
+
+		require.NoError(t, second.removeCollection(defaultCollectionID))
+		assert.Nil(t, col.collectionPtr)
+		assert.Equal(t, 0, registry.refCount(defaultCollectionID))
+		_, err = registry.get(defaultCollectionID)
+		assert.Error(t, err)
+	}
+}
+
+func TestCollectionReplica_releaseRace(t *testing.T) {
+	registry, historical, streaming := genSharedReplicas(t)
+
+	const rounds = 20
+	for i := 0; i < rounds; i++ {
+		var wg sync.WaitGroup
+		for _, replica := range []ReplicaInterface{historical, streaming} {
+			wg.Add(1)
+			go func(replica ReplicaInterface) {
+				defer wg.Done()
+				replica.addCollection(defaultCollectionID, genSimpleSegCoreSchema())
+			}(replica)
+		}
+		wg.Wait()
+		require.Equal(t, 2, registry.refCount(defaultCollectionID))
+
+		for _, replica := range []ReplicaInterface{historical, streaming} {
+			wg.Add(2)
+			go func(replica ReplicaInterface) {
+				defer wg.Done()
+				assert.NoError(t, replica.removeCollection(defaultCollectionID))
+			}(replica)
+			go func(replica ReplicaInterface) {
+				defer wg.Done()
+				// the collection resolved is either released or alive
+				if col, err := replica.getCollectionByID(defaultCollectionID); err == nil {
+					assert.Equal(t, defaultCollectionID, col.ID())
+				}
+			}(replica)
+		}
+		wg.Wait()
+		assert.Equal(t, 0, registry.refCount(defaultCollectionID))
+	}
+}
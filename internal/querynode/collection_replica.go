@@ -31,8 +31,10 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -71,6 +73,8 @@ type ReplicaInterface interface {
 	getPKFieldIDByCollectionID(collectionID UniqueID) (FieldID, error)
 	// getSegmentInfosByColID return segments info by collectionID
 	getSegmentInfosByColID(collectionID UniqueID) ([]*querypb.SegmentInfo, error)
+	// getSegmentInfos returns the infos of the segments of collectionIDs at the detail level, only of segmentIDs if not empty
+	getSegmentInfos(collectionIDs []UniqueID, segmentIDs []UniqueID, detail querypb.SegmentInfoDetail) ([]*querypb.SegmentInfo, error)
 
 	// partition
 	// addPartition adds a new partition to collection
@@ -93,6 +97,12 @@ type ReplicaInterface interface {
 	addSegment(segmentID UniqueID, partitionID UniqueID, collectionID UniqueID, vChannelID Channel, segType segmentType, onService bool) error
 	// setSegment adds a segment to collectionReplica
 	setSegment(segment *Segment) error
+	// syncSegmentServing applies the serving actions of version to the segments at once
+	syncSegmentServing(actions []*querypb.SegmentServingAction, version int64) error
+	// promoteSegments flips the warm standby segments to serving
+	promoteSegments(segmentIDs []UniqueID, version int64) error
+	// reassignSegmentChannel moves the growing segments from fromChannel to toChannel at once
+	reassignSegmentChannel(segmentIDs []UniqueID, fromChannel, toChannel Channel) error
 	// removeSegment removes a segment from collectionReplica
 	removeSegment(segmentID UniqueID) error
 	// getSegmentByID returns the segment which id is segmentID
@@ -101,6 +111,10 @@ type ReplicaInterface interface {
 	hasSegment(segmentID UniqueID) bool
 	// getSegmentNum returns num of segments in collectionReplica
 	getSegmentNum() int
+	// removeIdleGrowingSegments removes growing segments which stay empty and idle for idleTolerance
+	removeIdleGrowingSegments(idleTolerance time.Duration, exempt func(segment *Segment) bool) []UniqueID
+	// compactGrowingSegments compacts growing segments whose dead row ratio reaches deadRatio
+	compactGrowingSegments(deadRatio float64, minRows int64, horizon func(segment *Segment) (Timestamp, bool)) map[UniqueID]int64
 	//  getSegmentStatistics returns the statistics of segments in collectionReplica
 	getSegmentStatistics() []*internalpb.SegmentStats
 
@@ -121,6 +135,10 @@ type ReplicaInterface interface {
 	queryRLock()
 	// queryRUnlock guards query and delete segment operations
 	queryRUnlock()
+	// insertRLock keeps the growing segments from being reaped while inserts are in flight
+	insertRLock()
+	// insertRUnlock releases the lock acquired by insertRLock
+	insertRUnlock()
 
 	// getSegmentsMemSize get the memory size in bytes of all the Segments
 	getSegmentsMemSize() int64
@@ -133,15 +151,22 @@ type ReplicaInterface interface {
 // collectionReplica is the data replication of memory data in query node.
 // It implements `ReplicaInterface` interface.
 type collectionReplica struct {
-	mu          sync.RWMutex // guards all
-	collections map[UniqueID]*Collection
+	mu sync.RWMutex // guards all
+	// the collections held by the replica to the ids of their partitions in the replica, the Collection objects
+	// are shared with the other replica through registry
+	collections map[UniqueID][]UniqueID
 	partitions  map[UniqueID]*Partition
 	segments    map[UniqueID]*Segment
 
-	queryMu          sync.RWMutex
+	queryMu sync.RWMutex
+	// insertMu is held in read by insert nodes while inserting, and in write by idle growing segment reaping
+	insertMu         sync.RWMutex
 	excludedSegments map[UniqueID][]*datapb.SegmentInfo // map[collectionID]segmentIDs
 
 	etcdKV *etcdkv.EtcdKV
+
+	config   *QueryNodeConfig
+	registry *collectionRegistry
 }
 
 // queryLock guards query and delete operations
@@ -164,6 +189,16 @@ func (colReplica *collectionReplica) queryRUnlock() {
 	colReplica.queryMu.RUnlock()
 }
 
+// insertRLock keeps the growing segments from being reaped while inserts are in flight
+func (colReplica *collectionReplica) insertRLock() {
+	colReplica.insertMu.RLock()
+}
+
+// insertRUnlock releases the lock acquired by insertRLock
+func (colReplica *collectionReplica) insertRUnlock() {
+	colReplica.insertMu.RUnlock()
+}
+
 // getSegmentsMemSize get the memory size in bytes of all the Segments
 func (colReplica *collectionReplica) getSegmentsMemSize() int64 {
 	colReplica.mu.RLock()
@@ -204,15 +239,15 @@ func (colReplica *collectionReplica) addCollection(collectionID UniqueID, schema
 	colReplica.mu.Lock()
 	defer colReplica.mu.Unlock()
 
-	if col, ok := colReplica.collections[collectionID]; ok {
+	if _, ok := colReplica.collections[collectionID]; ok {
+		col, _ := colReplica.registry.get(collectionID)
 		return col
 	}
 
-	var newCollection = newCollection(collectionID, schema)
-	colReplica.collections[collectionID] = newCollection
+	// the collection held by the other replica is shared, the schema is ignored then
+	var newCollection = colReplica.registry.acquire(collectionID, schema)
+	colReplica.collections[collectionID] = make([]UniqueID, 0)
 	log.Debug("Successfully add collection ", zap.Int64("collectionID", collectionID))
-
-	metrics.QueryNodeNumCollections.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Set(float64(len(colReplica.collections)))
 	return newCollection
 }
 
@@ -227,22 +262,24 @@ func (colReplica *collectionReplica) removeCollection(collectionID UniqueID) err
 
 // removeCollectionPrivate is the private function in collectionReplica, to remove collection from collectionReplica
 func (colReplica *collectionReplica) removeCollectionPrivate(collectionID UniqueID) error {
-	collection, err := colReplica.getCollectionByIDPrivate(collectionID)
-	if err != nil {
-		return err
+	partitionIDs, ok := colReplica.collections[collectionID]
+	if !ok {
+		return fmt.Errorf("collection hasn't been loaded or has been released, collection id = %d", collectionID)
 	}
 
 	// delete partitions
-	for _, partitionID := range collection.partitionIDs {
+	for _, partitionID := range partitionIDs {
 		// ignore error, try to delete
 		_ = colReplica.removePartitionPrivate(partitionID)
 	}
 
-	deleteCollection(collection)
+	// the collection is deleted once the other replica released it as well
 	delete(colReplica.collections, collectionID)
+	if err := colReplica.registry.release(collectionID); err != nil {
+		return err
+	}
 
-	metrics.QueryNodeNumCollections.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Set(float64(len(colReplica.collections)))
-	metrics.QueryNodeNumPartitions.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Sub(float64(len(collection.partitionIDs)))
+	metrics.QueryNodeNumPartitions.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Sub(float64(len(partitionIDs)))
 	return nil
 }
 
@@ -255,12 +292,11 @@ func (colReplica *collectionReplica) getCollectionByID(collectionID UniqueID) (*
 
 // getCollectionByIDPrivate is the private function in collectionReplica, to get collection from collectionReplica
 func (colReplica *collectionReplica) getCollectionByIDPrivate(collectionID UniqueID) (*Collection, error) {
-	collection, ok := colReplica.collections[collectionID]
-	if !ok {
+	if _, ok := colReplica.collections[collectionID]; !ok {
 		return nil, fmt.Errorf("collection hasn't been loaded or has been released, collection id = %d", collectionID)
 	}
 
-	return collection, nil
+	return colReplica.registry.get(collectionID)
 }
 
 // hasCollection checks if collectionReplica has the collection which id is collectionID
@@ -288,40 +324,23 @@ func (colReplica *collectionReplica) getPartitionIDs(collectionID UniqueID) ([]U
 	colReplica.mu.RLock()
 	defer colReplica.mu.RUnlock()
 
-	collection, err := colReplica.getCollectionByIDPrivate(collectionID)
-	if err != nil {
-		return nil, err
-	}
-
-	return collection.partitionIDs, nil
-}
-
-func (colReplica *collectionReplica) getIndexedFieldIDByCollectionIDPrivate(collectionID UniqueID, segment *Segment) ([]FieldID, error) {
-	fields, err := colReplica.getFieldsByCollectionIDPrivate(collectionID)
-	if err != nil {
-		return nil, err
+	partitionIDs, ok := colReplica.collections[collectionID]
+	if !ok {
+		return nil, fmt.Errorf("collection hasn't been loaded or has been released, collection id = %d", collectionID)
 	}
 
-	fieldIDS := make([]FieldID, 0)
-	for _, field := range fields {
-		if segment.hasLoadIndexForIndexedField(field.FieldID) {
-			fieldIDS = append(fieldIDS, field.FieldID)
-		}
-	}
-	return fieldIDS, nil
+	return partitionIDs, nil
 }
 
 func (colReplica *collectionReplica) getVecFieldIDsByCollectionIDPrivate(collectionID UniqueID) ([]FieldID, error) {
-	fields, err := colReplica.getFieldsByCollectionIDPrivate(collectionID)
+	collection, err := colReplica.getCollectionByIDPrivate(collectionID)
 	if err != nil {
 		return nil, err
 	}
 
 	vecFields := make([]FieldID, 0)
-	for _, field := range fields {
-		if field.DataType == schemapb.DataType_BinaryVector || field.DataType == schemapb.DataType_FloatVector {
-			vecFields = append(vecFields, field.FieldID)
-		}
+	for _, field := range collection.getVectorFields() {
+		vecFields = append(vecFields, field.ID())
 	}
 	return vecFields, nil
 }
@@ -339,17 +358,16 @@ func (colReplica *collectionReplica) getPKFieldIDByCollectionID(collectionID Uni
 	colReplica.mu.RLock()
 	defer colReplica.mu.RUnlock()
 
-	fields, err := colReplica.getFieldsByCollectionIDPrivate(collectionID)
+	collection, err := colReplica.getCollectionByIDPrivate(collectionID)
 	if err != nil {
 		return common.InvalidFieldID, err
 	}
 
-	for _, field := range fields {
-		if field.IsPrimaryKey {
-			return field.FieldID, nil
-		}
+	pkField, err := collection.getPKField()
+	if err != nil {
+		return common.InvalidFieldID, nil
 	}
-	return common.InvalidFieldID, nil
+	return pkField.ID(), nil
 }
 
 // getFieldsByCollectionIDPrivate is the private function in collectionReplica, to return vector field ids of collection
@@ -368,31 +386,55 @@ func (colReplica *collectionReplica) getFieldsByCollectionIDPrivate(collectionID
 
 // getSegmentInfosByColID return segments info by collectionID
 func (colReplica *collectionReplica) getSegmentInfosByColID(collectionID UniqueID) ([]*querypb.SegmentInfo, error) {
+	return colReplica.getSegmentInfos([]UniqueID{collectionID}, nil, querypb.SegmentInfoDetail_FullDetail)
+}
+
+// getSegmentInfos returns the infos of the segments of collectionIDs at the detail level, only of segmentIDs if not empty
+func (colReplica *collectionReplica) getSegmentInfos(collectionIDs []UniqueID, segmentIDs []UniqueID, detail querypb.SegmentInfoDetail) ([]*querypb.SegmentInfo, error) {
 	colReplica.mu.RLock()
 	defer colReplica.mu.RUnlock()
 
-	segmentInfos := make([]*querypb.SegmentInfo, 0)
-	collection, ok := colReplica.collections[collectionID]
-	if !ok {
-		// collection not exist, so result segmentInfos is empty
-		return segmentInfos, nil
+	getInfo := colReplica.getSegmentInfo
+	if detail == querypb.SegmentInfoDetail_SummaryDetail {
+		getInfo = getSegmentSummary
 	}
 
-	for _, partitionID := range collection.partitionIDs {
-		partition, ok := colReplica.partitions[partitionID]
-		if !ok {
-			return nil, fmt.Errorf("the meta of collection %d and partition %d are inconsistent in QueryNode", collectionID, partitionID)
+	segmentInfos := make([]*querypb.SegmentInfo, 0)
+	if len(segmentIDs) > 0 {
+		// look up the segments directly, instead of enumerating all the segments of the collections
+		collections := make(map[UniqueID]struct{}, len(collectionIDs))
+		for _, collectionID := range collectionIDs {
+			collections[collectionID] = struct{}{}
 		}
-		for _, segmentID := range partition.segmentIDs {
+		for _, segmentID := range segmentIDs {
 			segment, ok := colReplica.segments[segmentID]
 			if !ok {
-				return nil, fmt.Errorf("the meta of partition %d and segment %d are inconsistent in QueryNode", partitionID, segmentID)
+				continue
 			}
-			segmentInfo := colReplica.getSegmentInfo(segment)
-			segmentInfos = append(segmentInfos, segmentInfo)
+			if _, ok := collections[segment.collectionID]; !ok {
+				continue
+			}
+			segmentInfos = append(segmentInfos, getInfo(segment))
 		}
+		return segmentInfos, nil
 	}
 
+	for _, collectionID := range collectionIDs {
+		// collection not exist, so result segmentInfos is empty
+		for _, partitionID := range colReplica.collections[collectionID] {
+			partition, ok := colReplica.partitions[partitionID]
+			if !ok {
+				return nil, fmt.Errorf("the meta of collection %d and partition %d are inconsistent in QueryNode", collectionID, partitionID)
+			}
+			for _, segmentID := range partition.segmentIDs {
+				segment, ok := colReplica.segments[segmentID]
+				if !ok {
+					return nil, fmt.Errorf("the meta of partition %d and segment %d are inconsistent in QueryNode", partitionID, segmentID)
+				}
+				segmentInfos = append(segmentInfos, getInfo(segment))
+			}
+		}
+	}
 	return segmentInfos, nil
 }
 
@@ -406,13 +448,16 @@ func (colReplica *collectionReplica) addPartition(collectionID UniqueID, partiti
 
 // addPartitionPrivate is the private function in collectionReplica, to add a new partition to collection
 func (colReplica *collectionReplica) addPartitionPrivate(collectionID UniqueID, partitionID UniqueID) error {
-	collection, err := colReplica.getCollectionByIDPrivate(collectionID)
-	if err != nil {
-		return err
+	partitionIDs, ok := colReplica.collections[collectionID]
+	if !ok {
+		return fmt.Errorf("collection hasn't been loaded or has been released, collection id = %d", collectionID)
 	}
 
 	if !colReplica.hasPartitionPrivate(partitionID) {
-		collection.addPartitionID(partitionID)
+		colReplica.collections[collectionID] = append(partitionIDs, partitionID)
+		log.Debug("queryNode collection info after add a partition",
+			zap.Int64("partitionID", partitionID), zap.Int64("collectionID", collectionID),
+			zap.Int64s("partitions", colReplica.collections[collectionID]))
 		var newPartition = newPartition(collectionID, partitionID)
 		colReplica.partitions[partitionID] = newPartition
 	}
@@ -437,9 +482,9 @@ func (colReplica *collectionReplica) removePartitionPrivate(partitionID UniqueID
 		return err
 	}
 
-	collection, err := colReplica.getCollectionByIDPrivate(partition.collectionID)
-	if err != nil {
-		return err
+	partitionIDs, ok := colReplica.collections[partition.collectionID]
+	if !ok {
+		return fmt.Errorf("collection hasn't been loaded or has been released, collection id = %d", partition.collectionID)
 	}
 
 	// delete segments
@@ -448,7 +493,13 @@ func (colReplica *collectionReplica) removePartitionPrivate(partitionID UniqueID
 		_ = colReplica.removeSegmentPrivate(segmentID)
 	}
 
-	collection.removePartitionID(partitionID)
+	tmpIDs := make([]UniqueID, 0, len(partitionIDs))
+	for _, id := range partitionIDs {
+		if id != partitionID {
+			tmpIDs = append(tmpIDs, id)
+		}
+	}
+	colReplica.collections[partition.collectionID] = tmpIDs
 	delete(colReplica.partitions, partitionID)
 
 	metrics.QueryNodeNumPartitions.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Set(float64(len(colReplica.partitions)))
@@ -539,6 +590,10 @@ func (colReplica *collectionReplica) addSegment(segmentID UniqueID, partitionID
 	if err != nil {
 		return err
 	}
+	if colReplica.hasSegmentPrivate(segmentID) {
+		// adding an existing segment is a no-op
+		return nil
+	}
 	seg, err := newSegment(collection, segmentID, partitionID, collectionID, vChannelID, segType, onService)
 	if err != nil {
 		return err
@@ -557,21 +612,181 @@ func (colReplica *collectionReplica) addSegmentPrivate(segmentID UniqueID, parti
 		return nil
 	}
 	partition.addSegmentID(segmentID)
+	segment.quarantine.config = colReplica.config
 	colReplica.segments[segmentID] = segment
 
 	metrics.QueryNodeNumSegments.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Inc()
 	return nil
 }
 
-// setSegment adds a segment to collectionReplica
+// setSegment adds a segment to collectionReplica, a segment with newer version replaces the older one,
+// registering an older version over a newer one is refused, and the duplicate of the registered version is released
 func (colReplica *collectionReplica) setSegment(segment *Segment) error {
+	replaced, err := colReplica.setSegmentPrivate(segment)
+	if err != nil {
+		return err
+	}
+	if replaced == segment {
+		// the duplicate has never been registered, no query runs on it
+		deleteSegment(replaced)
+	} else if replaced != nil {
+		// wait for the running queries on the replaced segment before releasing it
+		colReplica.queryLock()
+		deleteSegment(replaced)
+		colReplica.queryUnlock()
+	}
+	return nil
+}
+
+// setSegmentPrivate registers the segment and returns the segment to release, which is
+// either the replaced older version or the duplicate of the registered version
+func (colReplica *collectionReplica) setSegmentPrivate(segment *Segment) (*Segment, error) {
 	colReplica.mu.Lock()
 	defer colReplica.mu.Unlock()
 	_, err := colReplica.getCollectionByIDPrivate(segment.collectionID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	old, ok := colReplica.segments[segment.segmentID]
+	if !ok {
+		return nil, colReplica.addSegmentPrivate(segment.segmentID, segment.partitionID, segment)
+	}
+	if old.getVersion() > segment.getVersion() {
+		return nil, fmt.Errorf("refuse to register segment %d of version %d, a newer version %d has been loaded",
+			segment.segmentID, segment.getVersion(), old.getVersion())
+	}
+	if old == segment {
+		return nil, nil
+	}
+	if old.getVersion() == segment.getVersion() {
+		// registration is idempotent, the loaded one keeps serving and the duplicate is released
+		log.Debug("segment of the same version has been registered, release the duplicate",
+			zap.Int64("collectionID", segment.collectionID),
+			zap.Int64("segmentID", segment.segmentID),
+			zap.Int64("version", segment.getVersion()))
+		return segment, nil
+	}
+	if old.partitionID != segment.partitionID {
+		return nil, fmt.Errorf("segment %d of version %d belongs to partition %d, but the loaded version %d belongs to partition %d",
+			segment.segmentID, segment.getVersion(), segment.partitionID, old.getVersion(), old.partitionID)
+	}
+	colReplica.segments[segment.segmentID] = segment
+	log.Debug("replace segment with newer version",
+		zap.Int64("collectionID", segment.collectionID),
+		zap.Int64("segmentID", segment.segmentID),
+		zap.Int64("oldVersion", old.getVersion()),
+		zap.Int64("newVersion", segment.getVersion()))
+	return old, nil
+}
+
+// syncSegmentServing applies the serving actions of version to the segments, the actions are applied
+// while no search or query is running, so that a search or query sees either all or none of them.
+// It fails without applying any action if any of the segments is not loaded.
+func (colReplica *collectionReplica) syncSegmentServing(actions []*querypb.SegmentServingAction, version int64) error {
+	colReplica.queryLock()
+	defer colReplica.queryUnlock()
+
+	segments := make([]*Segment, 0, len(actions))
+	colReplica.mu.RLock()
+	for _, action := range actions {
+		segment, err := colReplica.getSegmentByIDPrivate(action.GetSegmentID())
+		if err != nil {
+			colReplica.mu.RUnlock()
+			return err
+		}
+		// the deletes missed by standby segments are replayed on promotion only
+		if action.GetServing() && segment.isStandby() {
+			colReplica.mu.RUnlock()
+			return fmt.Errorf("segment %d is warm standby, which is served by promotion", action.GetSegmentID())
+		}
+		segments = append(segments, segment)
 	}
-	return colReplica.addSegmentPrivate(segment.segmentID, segment.partitionID, segment)
+	colReplica.mu.RUnlock()
+
+	for i, action := range actions {
+		if !segments[i].setServing(action.GetServing(), version) {
+			log.Debug("ignore stale serving action",
+				zap.Int64("segmentID", action.GetSegmentID()),
+				zap.Bool("serving", action.GetServing()),
+				zap.Int64("version", version))
+		}
+	}
+	return nil
+}
+
+// promoteSegments flips the warm standby segments to serving as a serving action of version, the segments are
+// promoted while no search or query is running, so that a search or query sees either all or none of them.
+// It fails without promoting any segment if any of them is not loaded or not standby.
+func (colReplica *collectionReplica) promoteSegments(segmentIDs []UniqueID, version int64) error {
+	colReplica.queryLock()
+	defer colReplica.queryUnlock()
+
+	segments := make([]*Segment, 0, len(segmentIDs))
+	colReplica.mu.RLock()
+	for _, segmentID := range segmentIDs {
+		segment, err := colReplica.getSegmentByIDPrivate(segmentID)
+		if err != nil {
+			colReplica.mu.RUnlock()
+			return err
+		}
+		if !segment.isStandby() {
+			colReplica.mu.RUnlock()
+			return fmt.Errorf("segment %d is not warm standby", segmentID)
+		}
+		segments = append(segments, segment)
+	}
+	colReplica.mu.RUnlock()
+
+	for _, segment := range segments {
+		segment.promote(version)
+	}
+	return nil
+}
+
+// reassignSegmentChannel attributes the growing segments of fromChannel to toChannel, after which they are searched
+// by the queries of toChannel and wait for its tSafe. The segments are moved while no search or query is running, so
+// that a search or query pinned to either channel sees each segment exactly once, under the channel before or after.
+// It fails without moving any segment if any of them is not loaded, not growing or of another channel, the segments
+// already of toChannel are skipped so that the reassignment could be retried.
+func (colReplica *collectionReplica) reassignSegmentChannel(segmentIDs []UniqueID, fromChannel, toChannel Channel) error {
+	if fromChannel == toChannel {
+		return fmt.Errorf("segments are reassigned to their own channel %s", fromChannel)
+	}
+	colReplica.queryLock()
+	defer colReplica.queryUnlock()
+	colReplica.mu.Lock()
+	defer colReplica.mu.Unlock()
+
+	segments := make([]*Segment, 0, len(segmentIDs))
+	for _, segmentID := range segmentIDs {
+		segment, err := colReplica.getSegmentByIDPrivate(segmentID)
+		if err != nil {
+			return err
+		}
+		if segment.getType() != segmentTypeGrowing {
+			return fmt.Errorf("segment %d is not growing, only growing segments are reassigned", segmentID)
+		}
+		switch segment.vChannelID {
+		case toChannel:
+			continue
+		case fromChannel:
+			segments = append(segments, segment)
+		default:
+			return fmt.Errorf("segment %d belongs to channel %s rather than %s", segmentID, segment.vChannelID, fromChannel)
+		}
+	}
+
+	for _, segment := range segments {
+		segment.vChannelID = toChannel
+	}
+	if len(segments) > 0 {
+		log.Info("reassign growing segments",
+			zap.Int64s("segmentIDs", segmentIDs),
+			zap.String("fromChannel", fromChannel),
+			zap.String("toChannel", toChannel))
+	}
+	return nil
 }
 
 // removeSegment removes a segment from collectionReplica
@@ -596,11 +811,96 @@ func (colReplica *collectionReplica) removeSegmentPrivate(segmentID UniqueID) er
 	partition.removeSegmentID(segmentID)
 	delete(colReplica.segments, segmentID)
 	deleteSegment(segment)
+	// removed after the segment is deleted, so that no manifest is saved for the segment afterwards
+	segment.indexManifests.remove(segmentID)
 
 	metrics.QueryNodeNumSegments.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Dec()
 	return nil
 }
 
+// removeIdleGrowingSegments removes growing segments which have no rows, no deletes and no
+// inserts for at least idleTolerance, segments for which exempt returns true are kept.
+// The in-flight inserts are waited for, so that no insert node targets a removed segment.
+// It returns the ids of the removed segments.
+func (colReplica *collectionReplica) removeIdleGrowingSegments(idleTolerance time.Duration, exempt func(segment *Segment) bool) []UniqueID {
+	// wait for the in-flight inserts, which may target the segments idle so far
+	colReplica.insertMu.Lock()
+	defer colReplica.insertMu.Unlock()
+	colReplica.queryMu.Lock()
+	colReplica.mu.Lock()
+	defer colReplica.mu.Unlock()
+	defer colReplica.queryMu.Unlock()
+
+	var removed []UniqueID
+	for segmentID, segment := range colReplica.segments {
+		if segment.getType() != segmentTypeGrowing || !segment.isIdle(idleTolerance) {
+			continue
+		}
+		if exempt != nil && exempt(segment) {
+			continue
+		}
+		lastActive := segment.getLastActiveTime()
+		if err := colReplica.removeSegmentPrivate(segmentID); err != nil {
+			log.Warn("failed to remove idle growing segment",
+				zap.Int64("collectionID", segment.collectionID),
+				zap.Int64("segmentID", segmentID),
+				zap.Error(err))
+			continue
+		}
+		log.Info("remove idle growing segment",
+			zap.Int64("collectionID", segment.collectionID),
+			zap.Int64("partitionID", segment.partitionID),
+			zap.Int64("segmentID", segmentID),
+			zap.String("vChannel", segment.vChannelID),
+			zap.Time("lastActiveTime", lastActive))
+		removed = append(removed, segmentID)
+	}
+	return removed
+}
+
+// compactGrowingSegments rebuilds the growing segments which hold at least minRows rows and whose ratio of the
+// deleted rows reaches deadRatio, dropping the rows deleted before the horizon of the segment. Segments without
+// a horizon are skipped. It returns the number of the rows dropped of each compacted segment.
+func (colReplica *collectionReplica) compactGrowingSegments(deadRatio float64, minRows int64, horizon func(segment *Segment) (Timestamp, bool)) map[UniqueID]int64 {
+	colReplica.mu.RLock()
+	var candidates []*Segment
+	for _, segment := range colReplica.segments {
+		if segment.getType() == segmentTypeGrowing && segment.shouldCompact(deadRatio, minRows) {
+			candidates = append(candidates, segment)
+		}
+	}
+	colReplica.mu.RUnlock()
+
+	compacted := make(map[UniqueID]int64)
+	for _, segment := range candidates {
+		ts, ok := horizon(segment)
+		if !ok {
+			continue
+		}
+		// block the inserts and deletes while compacting, one segment at a time
+		colReplica.insertMu.Lock()
+		deadRows, err := segment.compactGrowing(ts)
+		colReplica.insertMu.Unlock()
+		if err != nil {
+			log.Warn("failed to compact growing segment",
+				zap.Int64("collectionID", segment.collectionID),
+				zap.Int64("segmentID", segment.ID()),
+				zap.Error(err))
+			continue
+		}
+		log.Info("compact growing segment",
+			zap.Int64("collectionID", segment.collectionID),
+			zap.Int64("partitionID", segment.partitionID),
+			zap.Int64("segmentID", segment.ID()),
+			zap.String("vChannel", segment.vChannelID),
+			zap.Uint64("horizon", ts),
+			zap.Int64("deadRows", deadRows),
+			zap.Int64("rowCount", segment.getRowCount()))
+		compacted[segment.ID()] = deadRows
+	}
+	return compacted
+}
+
 // getSegmentByID returns the segment which id is segmentID
 func (colReplica *collectionReplica) getSegmentByID(segmentID UniqueID) (*Segment, error) {
 	colReplica.mu.RLock()
@@ -612,7 +912,7 @@ func (colReplica *collectionReplica) getSegmentByID(segmentID UniqueID) (*Segmen
 func (colReplica *collectionReplica) getSegmentByIDPrivate(segmentID UniqueID) (*Segment, error) {
 	segment, ok := colReplica.segments[segmentID]
 	if !ok {
-		return nil, fmt.Errorf("cannot find segment %d in QueryNode", segmentID)
+		return nil, fmt.Errorf("%w, cannot find segment %d in QueryNode", ErrSegmentReleased, segmentID)
 	}
 
 	return segment, nil
@@ -655,6 +955,10 @@ func (colReplica *collectionReplica) getSegmentStatistics() []*internalpb.Segmen
 			MemorySize:       currentMemSize,
 			NumRows:          segmentNumOfRows,
 			RecentlyModified: segment.getRecentlyModified(),
+			RowBudget:        segment.getRowBudget(),
+			AllocatedChunks:  segment.getAllocatedChunkNum(),
+			ChunkRows:        segment.getChunkRows(),
+			DiskUsage:        segment.getDiskUsage(),
 		}
 
 		statisticData = append(statisticData, &stat)
@@ -707,57 +1011,110 @@ func (colReplica *collectionReplica) freeAll() {
 		_ = colReplica.removeCollectionPrivate(id)
 	}
 
-	colReplica.collections = make(map[UniqueID]*Collection)
+	colReplica.collections = make(map[UniqueID][]UniqueID)
 	colReplica.partitions = make(map[UniqueID]*Partition)
 	colReplica.segments = make(map[UniqueID]*Segment)
 }
 
-// newCollectionReplica returns a new ReplicaInterface
-func newCollectionReplica(etcdKv *etcdkv.EtcdKV) ReplicaInterface {
-	collections := make(map[UniqueID]*Collection)
+// newCollectionReplica returns a new ReplicaInterface, holding the collections of registry
+func newCollectionReplica(etcdKv *etcdkv.EtcdKV, config *QueryNodeConfig, registry *collectionRegistry) ReplicaInterface {
+	collections := make(map[UniqueID][]UniqueID)
 	partitions := make(map[UniqueID]*Partition)
 	segments := make(map[UniqueID]*Segment)
 	excludedSegments := make(map[UniqueID][]*datapb.SegmentInfo)
 
-	var replica ReplicaInterface = &collectionReplica{
+	colReplica := &collectionReplica{
 		collections: collections,
 		partitions:  partitions,
 		segments:    segments,
 
 		excludedSegments: excludedSegments,
 		etcdKV:           etcdKv,
+		config:           config,
+		registry:         registry,
+	}
+	if config != nil {
+		config.addReloadHook(func(dynamic *DynamicQueryNodeConfig) {
+			if dynamic.SegmentQuarantineFailures <= 0 {
+				colReplica.releaseQuarantinedSegments()
+			}
+		})
 	}
 
+	var replica ReplicaInterface = colReplica
 	return replica
 }
 
+// releaseQuarantinedSegments releases all the segments of replica from quarantine
+func (colReplica *collectionReplica) releaseQuarantinedSegments() {
+	colReplica.mu.RLock()
+	defer colReplica.mu.RUnlock()
+	for _, segment := range colReplica.segments {
+		segment.releaseQuarantine()
+	}
+}
+
 // trans segment to queryPb.segmentInfo
 func (colReplica *collectionReplica) getSegmentInfo(segment *Segment) *querypb.SegmentInfo {
 	var indexName string
 	var indexID int64
 	var indexInfos []*querypb.FieldIndexInfo
 	// TODO:: segment has multi vec column
-	indexedFieldIDs, _ := colReplica.getIndexedFieldIDByCollectionIDPrivate(segment.collectionID, segment)
-	for _, fieldID := range indexedFieldIDs {
-		fieldInfo, err := segment.getIndexedFieldInfo(fieldID)
-		if err == nil {
-			indexName = fieldInfo.indexInfo.IndexName
-			indexID = fieldInfo.indexInfo.IndexID
-			indexInfos = append(indexInfos, fieldInfo.indexInfo)
+	fieldInfos := segment.getAllIndexedFieldInfos()
+	fieldIDs := make([]FieldID, 0, len(fieldInfos))
+	for fieldID, fieldInfo := range fieldInfos {
+		if fieldInfo.indexInfo != nil && fieldInfo.indexInfo.EnableIndex {
+			fieldIDs = append(fieldIDs, fieldID)
 		}
 	}
+	sort.Slice(fieldIDs, func(i, j int) bool { return fieldIDs[i] < fieldIDs[j] })
+	for _, fieldID := range fieldIDs {
+		// the infos are copies, so the response shares nothing with the segment
+		indexInfo := fieldInfos[fieldID].indexInfo
+		indexName = indexInfo.IndexName
+		indexID = indexInfo.IndexID
+		indexInfos = append(indexInfos, indexInfo)
+	}
 	info := &querypb.SegmentInfo{
+		SegmentID:           segment.ID(),
+		CollectionID:        segment.collectionID,
+		PartitionID:         segment.partitionID,
+		NodeID:              Params.QueryNodeCfg.QueryNodeID,
+		MemSize:             segment.getMemSize(),
+		NumRows:             segment.getRowCount(),
+		IndexName:           indexName,
+		IndexID:             indexID,
+		DmChannel:           segment.vChannelID,
+		SegmentState:        segment.segmentType,
+		IndexInfos:          indexInfos,
+		Version:             segment.getVersion(),
+		IndexPending:        segment.isIndexPending(),
+		LoadStats:           segment.loadStats.toProto(),
+		Quarantined:         segment.isQuarantined(),
+		FieldTransforms:     segment.getFieldTransforms(),
+		DiskUsage:           segment.getDiskUsage(),
+		LastDeleteTimestamp: segment.getLastDeleteTs(),
+	}
+	bfStats, err := segment.getBloomFilterStats()
+	if err != nil {
+		log.Warn("failed to get bloom filter stats of segment", zap.Int64("segmentID", segment.ID()), zap.Error(err))
+	} else {
+		info.BloomFilterStats = bfStats.toProto()
+	}
+	return info
+}
+
+// getSegmentSummary returns the summary info of segment, leaving out the per-field infos and the stats
+func getSegmentSummary(segment *Segment) *querypb.SegmentInfo {
+	return &querypb.SegmentInfo{
 		SegmentID:    segment.ID(),
 		CollectionID: segment.collectionID,
 		PartitionID:  segment.partitionID,
 		NodeID:       Params.QueryNodeCfg.QueryNodeID,
 		MemSize:      segment.getMemSize(),
 		NumRows:      segment.getRowCount(),
-		IndexName:    indexName,
-		IndexID:      indexID,
 		DmChannel:    segment.vChannelID,
 		SegmentState: segment.segmentType,
-		IndexInfos:   indexInfos,
+		Version:      segment.getVersion(),
 	}
-	return info
 }
@@ -17,10 +17,14 @@
 package querynode
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 )
 
@@ -184,7 +188,15 @@ func TestCollectionReplica_addSegment(t *testing.T) {
 		targetSeg, err := node.historical.replica.getSegmentByID(UniqueID(i))
 		assert.NoError(t, err)
 		assert.Equal(t, targetSeg.segmentID, UniqueID(i))
+
+		// adding the segment again is a no-op
+		err = node.historical.replica.addSegment(UniqueID(i), defaultPartitionID, collectionID, "", segmentTypeGrowing, true)
+		assert.NoError(t, err)
+		seg, err := node.historical.replica.getSegmentByID(UniqueID(i))
+		assert.NoError(t, err)
+		assert.Same(t, targetSeg, seg)
 	}
+	assert.Equal(t, segmentNum, node.historical.replica.getSegmentNum())
 
 	err := node.Stop()
 	assert.NoError(t, err)
@@ -267,6 +279,7 @@ func TestCollectionReplica_getSegmentInfosByColID(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(targetSegs))
 	for _, segment := range targetSegs {
+		assert.NotZero(t, segment.GetBloomFilterStats().GetBitSize())
 		if segment.GetSegmentState() == segmentTypeGrowing {
 			assert.Equal(t, UniqueID(0), segment.IndexID)
 		} else {
@@ -278,6 +291,254 @@ func TestCollectionReplica_getSegmentInfosByColID(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCollectionReplica_setSegmentVersion(t *testing.T) {
+	node := newQueryNodeMock()
+	collectionID := UniqueID(0)
+	collectionMeta := genTestCollectionMeta(collectionID, false)
+	collection := node.historical.replica.addCollection(collectionMeta.ID, collectionMeta.Schema)
+	node.historical.replica.addPartition(collectionID, defaultPartitionID)
+
+	segmentV1, err := newSegment(collection, defaultSegmentID, defaultPartitionID, collectionID, "", segmentTypeSealed, true)
+	assert.NoError(t, err)
+	segmentV1.setVersion(1)
+	err = node.historical.replica.setSegment(segmentV1)
+	assert.NoError(t, err)
+
+	segmentV2, err := newSegment(collection, defaultSegmentID, defaultPartitionID, collectionID, "", segmentTypeSealed, true)
+	assert.NoError(t, err)
+	segmentV2.setVersion(2)
+	err = node.historical.replica.setSegment(segmentV2)
+	assert.NoError(t, err)
+
+	seg, err := node.historical.replica.getSegmentByID(defaultSegmentID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), seg.getVersion())
+	assert.Equal(t, 1, node.historical.replica.getSegmentNum())
+
+	t.Run("refuse older version", func(t *testing.T) {
+		staleSegment, err := newSegment(collection, defaultSegmentID, defaultPartitionID, collectionID, "", segmentTypeSealed, true)
+		assert.NoError(t, err)
+		staleSegment.setVersion(1)
+		err = node.historical.replica.setSegment(staleSegment)
+		assert.Error(t, err)
+		deleteSegment(staleSegment)
+
+		seg, err := node.historical.replica.getSegmentByID(defaultSegmentID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), seg.getVersion())
+	})
+
+	t.Run("identical version is idempotent", func(t *testing.T) {
+		duplicate, err := newSegment(collection, defaultSegmentID, defaultPartitionID, collectionID, "", segmentTypeSealed, true)
+		assert.NoError(t, err)
+		duplicate.setVersion(2)
+		// the duplicate is released by replica
+		err = node.historical.replica.setSegment(duplicate)
+		assert.NoError(t, err)
+
+		seg, err := node.historical.replica.getSegmentByID(defaultSegmentID)
+		assert.NoError(t, err)
+		assert.Same(t, segmentV2, seg)
+		assert.Equal(t, 1, node.historical.replica.getSegmentNum())
+
+		err = node.historical.replica.setSegment(segmentV2)
+		assert.NoError(t, err)
+		seg, err = node.historical.replica.getSegmentByID(defaultSegmentID)
+		assert.NoError(t, err)
+		assert.Same(t, segmentV2, seg)
+	})
+
+	t.Run("segment info carries version", func(t *testing.T) {
+		infos, err := node.historical.replica.getSegmentInfosByColID(collectionID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(infos))
+		assert.Equal(t, int64(2), infos[0].GetVersion())
+	})
+
+	err = node.Stop()
+	assert.NoError(t, err)
+}
+
+func TestCollectionReplica_syncSegmentServing(t *testing.T) {
+	// the segments are balanced from source to target, the target loads them with deferred serving
+	genNode := func(onService bool) (*QueryNode, []*Segment) {
+		node := newQueryNodeMock()
+		collectionMeta := genTestCollectionMeta(defaultCollectionID, false)
+		collection := node.historical.replica.addCollection(collectionMeta.ID, collectionMeta.Schema)
+		node.historical.replica.addPartition(defaultCollectionID, defaultPartitionID)
+		segments := make([]*Segment, 0)
+		for _, segmentID := range []UniqueID{1, 2} {
+			segment, err := newSegment(collection, segmentID, defaultPartitionID, defaultCollectionID, "", segmentTypeSealed, onService)
+			assert.NoError(t, err)
+			err = node.historical.replica.setSegment(segment)
+			assert.NoError(t, err)
+			segments = append(segments, segment)
+		}
+		return node, segments
+	}
+	source, sourceSegments := genNode(true)
+	target, targetSegments := genNode(false)
+	defer source.Stop()
+	defer target.Stop()
+
+	checkExclusive := func() {
+		for i := range sourceSegments {
+			assert.False(t, sourceSegments[i].getOnService() && targetSegments[i].getOnService(),
+				"segment %d is served by both nodes", sourceSegments[i].segmentID)
+		}
+	}
+	genActions := func(serving bool) []*querypb.SegmentServingAction {
+		return []*querypb.SegmentServingAction{{SegmentID: 1, Serving: serving}, {SegmentID: 2, Serving: serving}}
+	}
+
+	// a query on the target sees the actions of a request all or none
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			target.historical.replica.queryRLock()
+			assert.Equal(t, targetSegments[0].getOnService(), targetSegments[1].getOnService())
+			target.historical.replica.queryRUnlock()
+		}
+	}()
+
+	checkExclusive()
+	err := source.historical.replica.syncSegmentServing(genActions(false), 10)
+	assert.NoError(t, err)
+	checkExclusive()
+	err = target.historical.replica.syncSegmentServing(genActions(true), 10)
+	assert.NoError(t, err)
+	checkExclusive()
+	close(stop)
+	wg.Wait()
+
+	for i := range sourceSegments {
+		assert.False(t, sourceSegments[i].getOnService())
+		assert.True(t, targetSegments[i].getOnService())
+	}
+
+	t.Run("stale actions are excluded", func(t *testing.T) {
+		err := source.historical.replica.syncSegmentServing(genActions(true), 9)
+		assert.NoError(t, err)
+		checkExclusive()
+		assert.False(t, sourceSegments[0].getOnService())
+	})
+
+	t.Run("segment not loaded", func(t *testing.T) {
+		actions := append(genActions(false), &querypb.SegmentServingAction{SegmentID: 3, Serving: false})
+		err := target.historical.replica.syncSegmentServing(actions, 11)
+		assert.Error(t, err)
+		// none of the actions is applied
+		assert.True(t, targetSegments[0].getOnService())
+		assert.True(t, targetSegments[1].getOnService())
+	})
+}
+
+func TestCollectionReplica_reassignSegmentChannel(t *testing.T) {
+	const fromChannel, toChannel = "from-channel", "to-channel"
+	replica, err := genSimpleReplica()
+	require.NoError(t, err)
+	defer replica.freeAll()
+	for _, segmentID := range []UniqueID{1, 2} {
+		require.NoError(t, replica.addSegment(segmentID, defaultPartitionID, defaultCollectionID, fromChannel, segmentTypeGrowing, true))
+	}
+	require.NoError(t, replica.addSegment(3, defaultPartitionID, defaultCollectionID, "other-channel", segmentTypeGrowing, true))
+	require.NoError(t, replica.addSegment(4, defaultPartitionID, defaultCollectionID, fromChannel, segmentTypeSealed, true))
+	segmentIDsOf := func(channel Channel) []UniqueID {
+		segmentIDs, err := replica.getSegmentIDsByVChannel(defaultPartitionID, channel)
+		require.NoError(t, err)
+		return segmentIDs
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Error(t, replica.reassignSegmentChannel([]UniqueID{1}, fromChannel, fromChannel))
+		// missing, of another channel and sealed
+		for _, segmentID := range []UniqueID{5, 3, 4} {
+			assert.Error(t, replica.reassignSegmentChannel([]UniqueID{1, segmentID}, fromChannel, toChannel))
+		}
+		// none of the segments is moved
+		assert.ElementsMatch(t, []UniqueID{1, 2, 4}, segmentIDsOf(fromChannel))
+		assert.Empty(t, segmentIDsOf(toChannel))
+	})
+
+	t.Run("wait for queries", func(t *testing.T) {
+		replica.queryRLock()
+		done := make(chan error, 1)
+		go func() {
+			done <- replica.reassignSegmentChannel([]UniqueID{1}, fromChannel, toChannel)
+		}()
+		select {
+		case <-done:
+			t.Fatal("segment reassigned while a query is running")
+		case <-time.After(100 * time.Millisecond):
+		}
+		// the running query still sees the segment of the old channel
+		segment, err := replica.getSegmentByID(1)
+		require.NoError(t, err)
+		assert.Equal(t, fromChannel, segment.vChannelID)
+		replica.queryRUnlock()
+		assert.NoError(t, <-done)
+		assert.Equal(t, []UniqueID{1}, segmentIDsOf(toChannel))
+	})
+
+	// the segment moved already is skipped
+	assert.NoError(t, replica.reassignSegmentChannel([]UniqueID{1, 2}, fromChannel, toChannel))
+	assert.ElementsMatch(t, []UniqueID{1, 2}, segmentIDsOf(toChannel))
+	assert.Equal(t, []UniqueID{4}, segmentIDsOf(fromChannel))
+}
+
+func TestCollectionReplica_promoteSegments(t *testing.T) {
+	node := newQueryNodeMock()
+	defer node.Stop()
+	collectionMeta := genTestCollectionMeta(defaultCollectionID, false)
+	collection := node.historical.replica.addCollection(collectionMeta.ID, collectionMeta.Schema)
+	node.historical.replica.addPartition(defaultCollectionID, defaultPartitionID)
+	segments := make([]*Segment, 0)
+	for _, segmentID := range []UniqueID{1, 2, 3} {
+		segment, err := newSegment(collection, segmentID, defaultPartitionID, defaultCollectionID, "", segmentTypeSealed, false)
+		assert.NoError(t, err)
+		segment.setStandby(segmentID != 3)
+		assert.NoError(t, node.historical.replica.setSegment(segment))
+		segments = append(segments, segment)
+	}
+
+	t.Run("standby segments are not served by sync distribution", func(t *testing.T) {
+		err := node.historical.replica.syncSegmentServing([]*querypb.SegmentServingAction{{SegmentID: 1, Serving: true}}, 1)
+		assert.Error(t, err)
+		assert.False(t, segments[0].getOnService())
+	})
+
+	t.Run("segment not standby", func(t *testing.T) {
+		err := node.historical.replica.promoteSegments([]UniqueID{1, 2, 3}, 2)
+		assert.Error(t, err)
+		err = node.historical.replica.promoteSegments([]UniqueID{1, 2, 4}, 2)
+		assert.Error(t, err)
+		// none of the segments is promoted
+		for _, segment := range segments {
+			assert.False(t, segment.getOnService())
+		}
+	})
+
+	err := node.historical.replica.promoteSegments([]UniqueID{1, 2}, 2)
+	assert.NoError(t, err)
+	for _, segment := range segments[:2] {
+		assert.True(t, segment.getOnService())
+		assert.False(t, segment.isStandby())
+	}
+
+	// serving actions older than the promotion are ignored
+	err = node.historical.replica.syncSegmentServing([]*querypb.SegmentServingAction{{SegmentID: 1, Serving: false}}, 1)
+	assert.NoError(t, err)
+	assert.True(t, segments[0].getOnService())
+}
+
 func TestCollectionReplica_hasSegment(t *testing.T) {
 	node := newQueryNodeMock()
 	collectionID := UniqueID(0)
@@ -301,6 +562,83 @@ func TestCollectionReplica_hasSegment(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCollectionReplica_removeIdleGrowingSegments(t *testing.T) {
+	replica, err := genSimpleReplica()
+	assert.NoError(t, err)
+	defer replica.freeAll()
+
+	const (
+		idleSegmentID     = UniqueID(100)
+		activeSegmentID   = UniqueID(101)
+		insertedSegmentID = UniqueID(102)
+		exemptSegmentID   = UniqueID(103)
+		sealedSegmentID   = UniqueID(104)
+	)
+	idleTolerance := time.Minute
+	addSegment := func(segmentID UniqueID, segType segmentType, idle bool) *Segment {
+		err := replica.addSegment(segmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segType, true)
+		assert.NoError(t, err)
+		segment, err := replica.getSegmentByID(segmentID)
+		assert.NoError(t, err)
+		if idle {
+			segment.lastActiveTime.Store(time.Now().Add(-2 * idleTolerance).UnixNano())
+		}
+		return segment
+	}
+
+	addSegment(idleSegmentID, segmentTypeGrowing, true)
+	addSegment(activeSegmentID, segmentTypeGrowing, false)
+	addSegment(exemptSegmentID, segmentTypeGrowing, true)
+	addSegment(sealedSegmentID, segmentTypeSealed, true)
+
+	inserted := addSegment(insertedSegmentID, segmentTypeGrowing, false)
+	records, err := genSimpleCommonBlob()
+	assert.NoError(t, err)
+	ids := []int64{1}
+	timestamps := []Timestamp{Timestamp(1000)}
+	blobs := []*commonpb.Blob{records[0]}
+	offset, err := inserted.segmentPreInsert(len(ids))
+	assert.NoError(t, err)
+	err = inserted.segmentInsert(offset, &ids, &timestamps, &blobs)
+	assert.NoError(t, err)
+	inserted.lastActiveTime.Store(time.Now().Add(-2 * idleTolerance).UnixNano())
+
+	exempt := func(segment *Segment) bool {
+		return segment.segmentID == exemptSegmentID
+	}
+	removed := replica.removeIdleGrowingSegments(idleTolerance, exempt)
+	assert.ElementsMatch(t, []UniqueID{idleSegmentID}, removed)
+	assert.False(t, replica.hasSegment(idleSegmentID))
+	for _, segmentID := range []UniqueID{activeSegmentID, insertedSegmentID, exemptSegmentID, sealedSegmentID} {
+		assert.True(t, replica.hasSegment(segmentID))
+	}
+
+	// without exemption the idle segment that was exempt gets removed too
+	removed = replica.removeIdleGrowingSegments(idleTolerance, nil)
+	assert.ElementsMatch(t, []UniqueID{exemptSegmentID}, removed)
+	assert.True(t, replica.hasSegment(activeSegmentID))
+
+	// the idle segment targeted by an in-flight insert is not reaped
+	targeted := addSegment(idleSegmentID, segmentTypeGrowing, true)
+	replica.insertRLock()
+	done := make(chan []UniqueID)
+	go func() {
+		done <- replica.removeIdleGrowingSegments(idleTolerance, nil)
+	}()
+	select {
+	case <-done:
+		t.Fatal("idle segments reaped while inserting")
+	case <-time.After(50 * time.Millisecond):
+	}
+	offset, err = targeted.segmentPreInsert(len(ids))
+	assert.NoError(t, err)
+	replica.insertRUnlock()
+	assert.Empty(t, <-done)
+	assert.True(t, replica.hasSegment(idleSegmentID))
+	err = targeted.segmentInsert(offset, &ids, &timestamps, &blobs)
+	assert.NoError(t, err)
+}
+
 func TestCollectionReplica_freeAll(t *testing.T) {
 	node := newQueryNodeMock()
 	collectionID := UniqueID(0)
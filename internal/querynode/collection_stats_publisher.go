@@ -0,0 +1,220 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+// collectionStatsFullSnapshotRounds is the number of messages between two full snapshots
+const collectionStatsFullSnapshotRounds = 10
+
+// collectionStatsPublisher pushes the in-memory stats of the loaded collections to the query node stats channel
+// periodically. To keep the messages small, a message only carries the collections whose stats changed since the
+// last message, and a full snapshot is pushed every collectionStatsFullSnapshotRounds messages, so that the
+// consumers missing some messages or subscribing late converge.
+type collectionStatsPublisher struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	historical   ReplicaInterface
+	streaming    ReplicaInterface
+	tSafeReplica TSafeReplicaInterface
+	readStats    *readTaskStats
+
+	factory msgstream.Factory
+	stream  msgstream.MsgStream
+
+	// owned by the publishing loop
+	lastCollect time.Time
+	published   map[UniqueID]*internalpb.CollectionStats // stats carried by the messages pushed so far
+	rounds      int
+}
+
+func newCollectionStatsPublisher(ctx context.Context, historical, streaming ReplicaInterface, tSafeReplica TSafeReplicaInterface,
+	readStats *readTaskStats, factory msgstream.Factory) *collectionStatsPublisher {
+	ctx1, cancel := context.WithCancel(ctx)
+	return &collectionStatsPublisher{
+		ctx:          ctx1,
+		cancel:       cancel,
+		historical:   historical,
+		streaming:    streaming,
+		tSafeReplica: tSafeReplica,
+		readStats:    readStats,
+		factory:      factory,
+		published:    make(map[UniqueID]*internalpb.CollectionStats),
+	}
+}
+
+// start pushes the collection stats every interval
+func (p *collectionStatsPublisher) start(interval time.Duration) error {
+	stream, err := p.factory.NewMsgStream(p.ctx)
+	if err != nil {
+		return err
+	}
+	stream.AsProducer([]string{Params.CommonCfg.QueryNodeStats})
+	stream.Start()
+	p.stream = stream
+	log.Info("collection stats publisher started", zap.String("channel", Params.CommonCfg.QueryNodeStats), zap.Duration("interval", interval))
+
+	// the qps is counted from now on
+	p.readStats.takeRequests()
+	p.lastCollect = time.Now()
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.ctx.Done():
+				log.Info("collection stats publisher exit")
+				return
+			case <-ticker.C:
+				p.publish(time.Now())
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *collectionStatsPublisher) close() {
+	p.cancel()
+	p.wg.Wait()
+	if p.stream != nil {
+		p.stream.Close()
+	}
+}
+
+// publish pushes the stats of the collections changed since the last message
+func (p *collectionStatsPublisher) publish(now time.Time) {
+	stats := p.encode(p.collect(now))
+	if len(stats.GetCollectionStats()) == 0 && len(stats.GetReleasedCollectionIDs()) == 0 && !stats.GetFullSnapshot() {
+		return
+	}
+	msgPack := &msgstream.MsgPack{
+		Msgs: []msgstream.TsMsg{
+			&msgstream.QueryNodeStatsMsg{
+				BaseMsg:        msgstream.BaseMsg{HashValues: []uint32{0}},
+				QueryNodeStats: *stats,
+			},
+		},
+	}
+	if err := p.stream.Produce(msgPack); err != nil {
+		log.Warn("failed to push collection stats", zap.Error(err))
+	}
+}
+
+// collect returns the stats of the collections loaded at now
+func (p *collectionStatsPublisher) collect(now time.Time) map[UniqueID]*internalpb.CollectionStats {
+	requests := p.readStats.takeRequests()
+	elapsed := now.Sub(p.lastCollect).Seconds()
+	p.lastCollect = now
+
+	stats := make(map[UniqueID]*internalpb.CollectionStats)
+	for _, replica := range []ReplicaInterface{p.historical, p.streaming} {
+		for _, collectionID := range replica.getCollectionIDs() {
+			collectionStats, ok := stats[collectionID]
+			if !ok {
+				collectionStats = &internalpb.CollectionStats{CollectionID: collectionID}
+				stats[collectionID] = collectionStats
+			}
+			segmentInfos, err := replica.getSegmentInfosByColID(collectionID)
+			if err != nil {
+				continue
+			}
+			for _, info := range segmentInfos {
+				collectionStats.NumRows += info.GetNumRows()
+				collectionStats.MemorySize += info.GetMemSize()
+				collectionStats.DiskUsage += info.GetDiskUsage()
+			}
+		}
+	}
+	for collectionID, collectionStats := range stats {
+		if elapsed > 0 {
+			collectionStats.Qps = float64(requests[collectionID]) / elapsed
+		}
+		collectionStats.TsafeLagMs = p.tSafeLag(collectionID, now).Milliseconds()
+	}
+	return stats
+}
+
+// tSafeLag returns the max lag of the tSafe of the dml channels of collection behind now
+func (p *collectionStatsPublisher) tSafeLag(collectionID UniqueID, now time.Time) time.Duration {
+	collection, err := p.streaming.getCollectionByID(collectionID)
+	if err != nil {
+		return 0
+	}
+	var lag time.Duration
+	for _, channel := range collection.getVChannels() {
+		ts, err := p.tSafeReplica.getTSafe(channel)
+		if err != nil || ts == 0 {
+			continue
+		}
+		if l := now.Sub(tsoutil.PhysicalTime(ts)); l > lag {
+			lag = l
+		}
+	}
+	return lag
+}
+
+// encode returns the message carrying the stats changed since the last message, or all the stats every
+// collectionStatsFullSnapshotRounds messages
+func (p *collectionStatsPublisher) encode(stats map[UniqueID]*internalpb.CollectionStats) *internalpb.QueryNodeStats {
+	full := p.rounds%collectionStatsFullSnapshotRounds == 0
+	msg := &internalpb.QueryNodeStats{
+		Base: &commonpb.MsgBase{
+			MsgType:   commonpb.MsgType_QueryNodeStats,
+			SourceID:  Params.QueryNodeCfg.QueryNodeID,
+			Timestamp: tsoutil.GetCurrentTime(),
+		},
+		FullSnapshot: full,
+	}
+	for collectionID, collectionStats := range stats {
+		if full || !proto.Equal(collectionStats, p.published[collectionID]) {
+			msg.CollectionStats = append(msg.CollectionStats, collectionStats)
+		}
+	}
+	for collectionID := range p.published {
+		if _, ok := stats[collectionID]; !ok {
+			msg.ReleasedCollectionIDs = append(msg.ReleasedCollectionIDs, collectionID)
+		}
+	}
+	sort.Slice(msg.CollectionStats, func(i, j int) bool {
+		return msg.CollectionStats[i].GetCollectionID() < msg.CollectionStats[j].GetCollectionID()
+	})
+	sort.Slice(msg.ReleasedCollectionIDs, func(i, j int) bool {
+		return msg.ReleasedCollectionIDs[i] < msg.ReleasedCollectionIDs[j]
+	})
+
+	p.published = stats
+	p.rounds++
+	return msg
+}
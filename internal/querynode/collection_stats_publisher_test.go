@@ -0,0 +1,172 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/mq/msgstream/mqwrapper"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+func TestCollectionStatsPublisher_collect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	p := newCollectionStatsPublisher(ctx, node.historical.replica, node.streaming.replica, node.tSafeReplica, &node.readStats, genFactory())
+
+	now := time.Now()
+	err = node.tSafeReplica.setTSafe(defaultDMLChannel, tsoutil.ComposeTSByTime(now.Add(-3*time.Second), 0))
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		node.readStats.countRequest(defaultCollectionID)
+	}
+	p.lastCollect = now.Add(-2 * time.Second)
+
+	stats := p.collect(now)
+	require.Len(t, stats, 1)
+	collectionStats := stats[defaultCollectionID]
+	assert.Equal(t, defaultCollectionID, collectionStats.GetCollectionID())
+	assert.Equal(t, int64(defaultMsgLength), collectionStats.GetNumRows())
+	assert.NotZero(t, collectionStats.GetMemorySize())
+	assert.Equal(t, float64(2), collectionStats.GetQps())
+	assert.Equal(t, int64(3000), collectionStats.GetTsafeLagMs())
+
+	// the requests are counted since the last collect
+	stats = p.collect(now.Add(time.Second))
+	assert.Zero(t, stats[defaultCollectionID].GetQps())
+}
+
+func TestCollectionStatsPublisher_encode(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	p := newCollectionStatsPublisher(ctx, node.historical.replica, node.streaming.replica, node.tSafeReplica, &node.readStats, genFactory())
+
+	stats := func(rows ...int64) map[UniqueID]*internalpb.CollectionStats {
+		ret := make(map[UniqueID]*internalpb.CollectionStats)
+		for i, r := range rows {
+			collectionID := UniqueID(i + 1)
+			ret[collectionID] = &internalpb.CollectionStats{CollectionID: collectionID, NumRows: r, MemorySize: 10 * r}
+		}
+		return ret
+	}
+
+	// the first message is a full snapshot
+	msg := p.encode(stats(100, 200))
+	assert.True(t, msg.GetFullSnapshot())
+	assert.Equal(t, commonpb.MsgType_QueryNodeStats, msg.GetBase().GetMsgType())
+	assert.Equal(t, Params.QueryNodeCfg.QueryNodeID, msg.GetBase().GetSourceID())
+	require.Len(t, msg.GetCollectionStats(), 2)
+	assert.Equal(t, UniqueID(1), msg.GetCollectionStats()[0].GetCollectionID())
+	assert.Equal(t, UniqueID(2), msg.GetCollectionStats()[1].GetCollectionID())
+
+	// nothing changed
+	msg = p.encode(stats(100, 200))
+	assert.False(t, msg.GetFullSnapshot())
+	assert.Empty(t, msg.GetCollectionStats())
+	assert.Empty(t, msg.GetReleasedCollectionIDs())
+
+	// only the changed collection is carried
+	msg = p.encode(stats(100, 300))
+	require.Len(t, msg.GetCollectionStats(), 1)
+	assert.True(t, proto.Equal(&internalpb.CollectionStats{CollectionID: 2, NumRows: 300, MemorySize: 3000}, msg.GetCollectionStats()[0]))
+
+	// released
+	msg = p.encode(stats(100))
+	assert.Empty(t, msg.GetCollectionStats())
+	assert.Equal(t, []UniqueID{2}, msg.GetReleasedCollectionIDs())
+
+	for p.rounds%collectionStatsFullSnapshotRounds != 0 {
+		msg = p.encode(stats(100))
+		assert.False(t, msg.GetFullSnapshot())
+		assert.Empty(t, msg.GetCollectionStats())
+	}
+	msg = p.encode(stats(100))
+	assert.True(t, msg.GetFullSnapshot())
+	assert.Len(t, msg.GetCollectionStats(), 1)
+}
+
+func TestCollectionStatsPublisher_publish(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+
+	channel := Params.CommonCfg.QueryNodeStats
+	Params.CommonCfg.QueryNodeStats = fmt.Sprintf("%s-%d", channel, rand.Int())
+	defer func() { Params.CommonCfg.QueryNodeStats = channel }()
+
+	factory := genFactory()
+	consumer, err := factory.NewMsgStream(ctx)
+	require.NoError(t, err)
+	consumer.AsConsumerWithPosition([]string{Params.CommonCfg.QueryNodeStats}, defaultSubName, mqwrapper.SubscriptionPositionEarliest)
+	consumer.Start()
+	defer consumer.Close()
+
+	p := newCollectionStatsPublisher(ctx, node.historical.replica, node.streaming.replica, node.tSafeReplica, &node.readStats, factory)
+	err = p.start(time.Hour)
+	require.NoError(t, err)
+	defer p.close()
+
+	receive := func() *internalpb.QueryNodeStats {
+		select {
+		case msgPack := <-consumer.Chan():
+			require.Len(t, msgPack.Msgs, 1)
+			msg, ok := msgPack.Msgs[0].(*msgstream.QueryNodeStatsMsg)
+			require.True(t, ok)
+			return &msg.QueryNodeStats
+		case <-time.After(10 * time.Second):
+			require.FailNow(t, "no collection stats received")
+		}
+		return nil
+	}
+
+	p.publish(time.Now())
+	stats := receive()
+	assert.True(t, stats.GetFullSnapshot())
+	require.Len(t, stats.GetCollectionStats(), 1)
+	assert.Equal(t, defaultCollectionID, stats.GetCollectionStats()[0].GetCollectionID())
+	assert.Equal(t, int64(defaultMsgLength), stats.GetCollectionStats()[0].GetNumRows())
+
+	// the release is pushed
+	err = node.historical.replica.removeCollection(defaultCollectionID)
+	require.NoError(t, err)
+	err = node.streaming.replica.removeCollection(defaultCollectionID)
+	require.NoError(t, err)
+	p.publish(time.Now())
+	stats = receive()
+	assert.False(t, stats.GetFullSnapshot())
+	assert.Empty(t, stats.GetCollectionStats())
+	assert.Equal(t, []UniqueID{defaultCollectionID}, stats.GetReleasedCollectionIDs())
+}
@@ -17,9 +17,16 @@
 package querynode
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
 func TestCollection_newCollection(t *testing.T) {
@@ -50,6 +57,150 @@ func TestCollection_schema(t *testing.T) {
 	deleteCollection(collection)
 }
 
+func TestCollection_fieldLookup(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	defer deleteCollection(collection)
+
+	t.Run("get field by id", func(t *testing.T) {
+		field, err := collection.getFieldByID(simpleVecField.id)
+		assert.NoError(t, err)
+		assert.Equal(t, simpleVecField.id, field.ID())
+		assert.Equal(t, int64(defaultDim), field.dim)
+
+		_, err = collection.getFieldByID(999)
+		assert.Error(t, err)
+		var fieldErr *fieldNotFoundError
+		assert.True(t, errors.As(err, &fieldErr))
+	})
+
+	t.Run("get field by name", func(t *testing.T) {
+		field, err := collection.getFieldByName(defaultConstFieldName)
+		assert.NoError(t, err)
+		assert.Equal(t, simpleConstField.id, field.ID())
+
+		_, err = collection.getFieldByName("not-exist")
+		assert.Error(t, err)
+		var fieldErr *fieldNotFoundError
+		assert.True(t, errors.As(err, &fieldErr))
+	})
+
+	t.Run("get vector and pk fields", func(t *testing.T) {
+		vecFields := collection.getVectorFields()
+		assert.Equal(t, 1, len(vecFields))
+		assert.Equal(t, simpleVecField.id, vecFields[0].ID())
+
+		pkField, err := collection.getPKField()
+		assert.NoError(t, err)
+		assert.Equal(t, simplePKField.id, pkField.ID())
+	})
+}
+
+func TestCollection_updateSchema(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	defer deleteCollection(collection)
+
+	schema := genSimpleSegCoreSchema()
+	schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+		FieldID:  103,
+		Name:     "varchar_field",
+		DataType: schemapb.DataType_VarChar,
+		TypeParams: []*commonpb.KeyValuePair{
+			{
+				Key:   "max_length_per_row",
+				Value: "64",
+			},
+		},
+	})
+	for _, field := range schema.Fields {
+		field.IsPrimaryKey = false
+	}
+	collection.updateSchema(schema)
+
+	field, err := collection.getFieldByName("varchar_field")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(64), field.maxLength)
+	assert.Equal(t, len(schema.Fields), len(collection.Schema().Fields))
+
+	_, err = collection.getPKField()
+	assert.Error(t, err)
+}
+
+func TestCollection_estimateSize(t *testing.T) {
+	typeParams := func(key, value string) []*commonpb.KeyValuePair {
+		return []*commonpb.KeyValuePair{{Key: key, Value: value}}
+	}
+	schema := &schemapb.CollectionSchema{
+		Name: "estimate_size",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "bool", DataType: schemapb.DataType_Bool},
+			{FieldID: 101, Name: "int8", DataType: schemapb.DataType_Int8},
+			{FieldID: 102, Name: "int16", DataType: schemapb.DataType_Int16},
+			{FieldID: 103, Name: "int32", DataType: schemapb.DataType_Int32},
+			{FieldID: 104, Name: "int64", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 105, Name: "float", DataType: schemapb.DataType_Float},
+			{FieldID: 106, Name: "double", DataType: schemapb.DataType_Double},
+			{FieldID: 107, Name: "varchar", DataType: schemapb.DataType_VarChar, TypeParams: typeParams("max_length_per_row", "64")},
+			{FieldID: 108, Name: "binary_vec", DataType: schemapb.DataType_BinaryVector, TypeParams: typeParams("dim", "128")},
+			{FieldID: 109, Name: "float_vec", DataType: schemapb.DataType_FloatVector, TypeParams: typeParams("dim", "16")},
+		},
+	}
+	collection := &Collection{id: defaultCollectionID}
+	collection.updateSchema(schema)
+
+	// 1 + 1 + 2 + 4 + 8 + 4 + 8 + 64 + 128/8 + 16*4
+	assert.Equal(t, int64(172), collection.EstimateRowSize(nil))
+	sizePerRecord, err := typeutil.EstimateRowSize(schema, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(sizePerRecord), collection.EstimateRowSize(nil))
+
+	// varchar counted by average length
+	assert.Equal(t, int64(118), collection.EstimateRowSize(map[FieldID]int{107: 10}))
+	// average length of fixed-length fields is ignored
+	assert.Equal(t, int64(172), collection.EstimateRowSize(map[FieldID]int{104: 100}))
+
+	assert.Equal(t, int64(0), collection.EstimateSegmentSize(0))
+	assert.Equal(t, int64(1720), collection.EstimateSegmentSize(10))
+
+	t.Run("string field without max length", func(t *testing.T) {
+		collection := &Collection{id: defaultCollectionID}
+		collection.updateSchema(&schemapb.CollectionSchema{
+			Fields: []*schemapb.FieldSchema{
+				{FieldID: 100, Name: "string", DataType: schemapb.DataType_String},
+			},
+		})
+		assert.Equal(t, int64(0), collection.EstimateRowSize(nil))
+		assert.Equal(t, int64(32), collection.EstimateRowSize(map[FieldID]int{100: 32}))
+	})
+}
+
+func TestCollection_rowSize(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleInsertDataSchema())
+	// row ID and timestamp are not in the rows
+	assert.Equal(t, int64(defaultDim*4+4+8), collection.rowSize())
+
+	collection.updateSchema(&schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_VarChar, IsPrimaryKey: true},
+			{FieldID: 101, Name: "vec", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "16"}}},
+		},
+	})
+	assert.Equal(t, int64(0), collection.rowSize())
+}
+
+func TestCollection_checkSearchable(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleInsertDataSchema())
+	assert.NoError(t, collection.checkSearchable())
+
+	collection = newCollection(defaultCollectionID, genScalarOnlySchema())
+	defer deleteCollection(collection)
+	var unsupportedErr *searchUnsupportedError
+	assert.True(t, errors.As(collection.checkSearchable(), &unsupportedErr))
+	assert.Empty(t, collection.getVectorFields())
+	assert.Equal(t, int64(4+8), collection.rowSize())
+	assert.Equal(t, int64(4+8), collection.EstimateRowSize(nil))
+}
+
 func TestCollection_vChannel(t *testing.T) {
 	collectionID := UniqueID(0)
 	collectionMeta := genTestCollectionMeta(collectionID, false)
@@ -134,3 +285,45 @@ func TestCollection_loadType(t *testing.T) {
 	lt = collection.getLoadType()
 	assert.Equal(t, loadTypePartition, lt)
 }
+
+func TestCollection_ttl(t *testing.T) {
+	collectionID := UniqueID(0)
+	collectionMeta := genTestCollectionMeta(collectionID, false)
+
+	collection := newCollection(collectionMeta.ID, collectionMeta.Schema)
+	assert.Equal(t, time.Duration(0), collection.getTTL())
+	readTs := tsoutil.ComposeTSByTime(time.Now(), 10)
+	assert.Equal(t, Timestamp(0), collection.getExpireTs(readTs))
+
+	collection.setTTL(3600)
+	assert.Equal(t, time.Hour, collection.getTTL())
+	physical, _ := tsoutil.ParseHybridTs(readTs)
+	assert.Equal(t, tsoutil.ComposeTS(physical-time.Hour.Milliseconds(), 0), collection.getExpireTs(readTs))
+	// the read ts not bound to a physical time expires nothing
+	assert.Equal(t, Timestamp(0), collection.getExpireTs(0))
+	assert.Equal(t, Timestamp(0), collection.getExpireTs(typeutil.MaxTimestamp))
+	// nothing is older than the TTL yet
+	assert.Equal(t, Timestamp(0), collection.getExpireTs(tsoutil.ComposeTS(time.Minute.Milliseconds(), 0)))
+
+	collection.setTTL(0)
+	assert.Equal(t, Timestamp(0), collection.getExpireTs(readTs))
+}
+
+func TestCollection_pkIndexEnabled(t *testing.T) {
+	collectionMeta := genTestCollectionMeta(defaultCollectionID, false)
+	collection := newCollection(collectionMeta.ID, collectionMeta.Schema)
+	defer deleteCollection(collection)
+
+	// follows the config unless overridden
+	Params.QueryNodeCfg.EnableSealedPKIndex = true
+	assert.True(t, collection.isPKIndexEnabled())
+	Params.QueryNodeCfg.EnableSealedPKIndex = false
+	assert.False(t, collection.isPKIndexEnabled())
+
+	collection.setPKIndexEnabled(true)
+	assert.True(t, collection.isPKIndexEnabled())
+	collection.setPKIndexEnabled(false)
+	Params.QueryNodeCfg.EnableSealedPKIndex = true
+	defer func() { Params.QueryNodeCfg.EnableSealedPKIndex = false }()
+	assert.False(t, collection.isPKIndexEnabled())
+}
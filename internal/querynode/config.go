@@ -0,0 +1,316 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+// QueryNodeConfig is the validated configuration of the query node, populated from Params.QueryNodeCfg once at
+// Init and handed to the replicas, the segment loader and the query shards instead of being read from the param
+// table, so that the invalid combinations of knobs fail the start of the node rather than the requests.
+// The static fields are fixed since Init, the dynamic ones could be updated at runtime by reload.
+type QueryNodeConfig struct {
+	FlowGraphMaxQueueLength int32
+	FlowGraphMaxParallelism int32
+
+	ChunkRows int64
+
+	// the cache of the query shards is enabled with the size CacheMemoryLimit
+	CacheEnabled     bool
+	CacheMemoryLimit int64
+	// the segments are refused to load if the memory usage would exceed the ratio of the total memory
+	OverloadedMemoryThresholdPercentage float64
+
+	GracefulTime int64
+
+	GrowingSegmentGCInterval    time.Duration
+	GrowingSegmentIdleTolerance time.Duration
+
+	StorageBreakerFailureThreshold int
+	StorageBreakerCoolDown         time.Duration
+
+	SearchConcurrencyMin            int
+	SearchConcurrencyMax            int
+	SearchConcurrencyAdjustInterval time.Duration
+
+	CgoReadPoolSize  int
+	CgoWritePoolSize int
+
+	CollectionReadQueueLimit int
+
+	CatchUpLag       time.Duration
+	CatchUpBatchRows int64
+
+	TimeTickCoalesceWindow time.Duration
+
+	PendingDeleteWindow  time.Duration
+	PendingDeleteMaxSize int64
+
+	SealedDeleteBufferWindow  time.Duration
+	SealedDeleteBufferMaxSize int64
+
+	ResultCompressType string
+
+	dynamic atomic.Value // *DynamicQueryNodeConfig
+
+	hooksMu sync.Mutex
+	hooks   []func(*DynamicQueryNodeConfig)
+}
+
+// DynamicQueryNodeConfig is the part of QueryNodeConfig updatable at runtime, it is replaced as a whole on reload
+// and never mutated, so that the readers see a consistent snapshot
+type DynamicQueryNodeConfig struct {
+	SegmentQuarantineFailures int
+
+	MaxGuaranteeTsLag time.Duration
+	StrictGuaranteeTs bool
+
+	EnableSearchDedup  bool
+	SimplifyPredicates bool
+
+	PrefilterSelectivity    float64
+	PrefilterBruteForceRows int64
+
+	ResultCompressThreshold int
+}
+
+// configError is the error of an invalid configuration, listing all the violated rules
+type configError struct {
+	violations []string
+}
+
+func (e *configError) Error() string {
+	return fmt.Sprintf("invalid query node config: %s", strings.Join(e.violations, "; "))
+}
+
+// newQueryNodeConfig returns the snapshot of the current Params.QueryNodeCfg, not validated
+func newQueryNodeConfig() *QueryNodeConfig {
+	cfg := &Params.QueryNodeCfg
+	config := &QueryNodeConfig{
+		FlowGraphMaxQueueLength:             cfg.FlowGraphMaxQueueLength,
+		FlowGraphMaxParallelism:             cfg.FlowGraphMaxParallelism,
+		ChunkRows:                           cfg.ChunkRows,
+		CacheEnabled:                        cfg.CacheEnabled,
+		CacheMemoryLimit:                    cfg.CacheMemoryLimit,
+		OverloadedMemoryThresholdPercentage: cfg.OverloadedMemoryThresholdPercentage,
+		GracefulTime:                        cfg.GracefulTime,
+		GrowingSegmentGCInterval:            cfg.GrowingSegmentGCInterval,
+		GrowingSegmentIdleTolerance:         cfg.GrowingSegmentIdleTolerance,
+		StorageBreakerFailureThreshold:      cfg.StorageBreakerFailureThreshold,
+		StorageBreakerCoolDown:              cfg.StorageBreakerCoolDown,
+		SearchConcurrencyMin:                cfg.SearchConcurrencyMin,
+		SearchConcurrencyMax:                cfg.SearchConcurrencyMax,
+		SearchConcurrencyAdjustInterval:     cfg.SearchConcurrencyAdjustInterval,
+		CgoReadPoolSize:                     cfg.CgoReadPoolSize,
+		CgoWritePoolSize:                    cfg.CgoWritePoolSize,
+		CollectionReadQueueLimit:            cfg.CollectionReadQueueLimit,
+		CatchUpLag:                          cfg.CatchUpLag,
+		CatchUpBatchRows:                    cfg.CatchUpBatchRows,
+		TimeTickCoalesceWindow:              cfg.TimeTickCoalesceWindow,
+		PendingDeleteWindow:                 cfg.PendingDeleteWindow,
+		PendingDeleteMaxSize:                cfg.PendingDeleteMaxSize,
+		SealedDeleteBufferWindow:            cfg.SealedDeleteBufferWindow,
+		SealedDeleteBufferMaxSize:           cfg.SealedDeleteBufferMaxSize,
+		ResultCompressType:                  cfg.ResultCompressType,
+	}
+	config.dynamic.Store(newDynamicQueryNodeConfig())
+	return config
+}
+
+func newDynamicQueryNodeConfig() *DynamicQueryNodeConfig {
+	cfg := &Params.QueryNodeCfg
+	return &DynamicQueryNodeConfig{
+		SegmentQuarantineFailures: cfg.SegmentQuarantineFailures,
+		MaxGuaranteeTsLag:         cfg.MaxGuaranteeTsLag,
+		StrictGuaranteeTs:         cfg.StrictGuaranteeTs,
+		EnableSearchDedup:         cfg.EnableSearchDedup,
+		SimplifyPredicates:        cfg.SimplifyPredicates,
+		PrefilterSelectivity:      cfg.PrefilterSelectivity,
+		PrefilterBruteForceRows:   cfg.PrefilterBruteForceRows,
+		ResultCompressThreshold:   cfg.ResultCompressThreshold,
+	}
+}
+
+// loadQueryNodeConfig populates the config from Params.QueryNodeCfg and validates it against the total memory
+// of the node in bytes
+func loadQueryNodeConfig(totalMem uint64) (*QueryNodeConfig, error) {
+	config := newQueryNodeConfig()
+	if err := config.validate(config.getDynamic(), totalMem); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// getDynamic returns the current snapshot of the dynamic fields
+func (c *QueryNodeConfig) getDynamic() *DynamicQueryNodeConfig {
+	return c.dynamic.Load().(*DynamicQueryNodeConfig)
+}
+
+// validate checks the static fields together with the dynamic ones, totalMem of 0 skips the memory checks
+func (c *QueryNodeConfig) validate(dynamic *DynamicQueryNodeConfig, totalMem uint64) error {
+	var violations []string
+	addViolation := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	if c.FlowGraphMaxQueueLength <= 0 {
+		addViolation("flow graph max queue length %d should be positive", c.FlowGraphMaxQueueLength)
+	}
+	if c.FlowGraphMaxParallelism <= 0 {
+		addViolation("flow graph max parallelism %d should be positive", c.FlowGraphMaxParallelism)
+	}
+	if c.ChunkRows <= 0 {
+		addViolation("segcore chunk rows %d should be positive", c.ChunkRows)
+	}
+	if c.OverloadedMemoryThresholdPercentage <= 0 || c.OverloadedMemoryThresholdPercentage > 1 {
+		addViolation("overloaded memory threshold %.2f should be in (0, 1]", c.OverloadedMemoryThresholdPercentage)
+	}
+	if c.CacheEnabled {
+		if c.CacheMemoryLimit <= 0 {
+			addViolation("cache memory limit %d should be positive if cache is enabled", c.CacheMemoryLimit)
+		} else if watermark := uint64(float64(totalMem) * c.OverloadedMemoryThresholdPercentage); totalMem > 0 && uint64(c.CacheMemoryLimit) >= watermark {
+			addViolation("cache memory limit %d should be less than the memory watermark %d of total memory %d",
+				c.CacheMemoryLimit, watermark, totalMem)
+		}
+	}
+	if c.GrowingSegmentGCInterval > 0 && c.GrowingSegmentIdleTolerance <= 0 {
+		addViolation("growing segment idle tolerance %s should be positive if growing segment gc is enabled",
+			c.GrowingSegmentIdleTolerance)
+	}
+	if c.StorageBreakerFailureThreshold > 0 && c.StorageBreakerCoolDown <= 0 {
+		addViolation("storage breaker cool down %s should be positive if storage breaker is enabled", c.StorageBreakerCoolDown)
+	}
+	if c.SearchConcurrencyMax > 0 {
+		if c.SearchConcurrencyMin <= 0 || c.SearchConcurrencyMin > c.SearchConcurrencyMax {
+			addViolation("search concurrency min %d should be in [1, %d] if search concurrency is limited",
+				c.SearchConcurrencyMin, c.SearchConcurrencyMax)
+		}
+		if c.SearchConcurrencyAdjustInterval <= 0 {
+			addViolation("search concurrency adjust interval %s should be positive if search concurrency is limited",
+				c.SearchConcurrencyAdjustInterval)
+		}
+	}
+	if c.CgoReadPoolSize < 0 {
+		addViolation("cgo read pool size %d should not be negative", c.CgoReadPoolSize)
+	}
+	if c.CgoWritePoolSize < 0 {
+		addViolation("cgo write pool size %d should not be negative", c.CgoWritePoolSize)
+	}
+	if c.CollectionReadQueueLimit < 0 {
+		addViolation("collection read queue limit %d should not be negative", c.CollectionReadQueueLimit)
+	}
+	if c.CatchUpLag > 0 && c.CatchUpBatchRows <= 0 {
+		addViolation("catch-up batch rows %d should be positive if catch-up mode is enabled", c.CatchUpBatchRows)
+	}
+	if c.TimeTickCoalesceWindow < 0 {
+		addViolation("time tick coalesce window %s should not be negative", c.TimeTickCoalesceWindow)
+	}
+	if c.PendingDeleteWindow > 0 && c.PendingDeleteMaxSize <= 0 {
+		addViolation("pending delete max size %d should be positive if pending deletes are enabled", c.PendingDeleteMaxSize)
+	}
+	if c.SealedDeleteBufferWindow > 0 && c.SealedDeleteBufferMaxSize <= 0 {
+		addViolation("sealed delete buffer max size %d should be positive if sealed deletes are buffered", c.SealedDeleteBufferMaxSize)
+	}
+	switch c.ResultCompressType {
+	case "none", "zstd", "snappy":
+	default:
+		addViolation("result compress type %s should be none, zstd or snappy", c.ResultCompressType)
+	}
+
+	if dynamic.StrictGuaranteeTs && dynamic.MaxGuaranteeTsLag <= 0 {
+		addViolation("max guarantee ts lag %s should be positive if strict guarantee ts is enabled", dynamic.MaxGuaranteeTsLag)
+	}
+	if dynamic.PrefilterSelectivity < 0 || dynamic.PrefilterSelectivity > 1 {
+		addViolation("prefilter selectivity %.2f should be in [0, 1]", dynamic.PrefilterSelectivity)
+	}
+	if dynamic.PrefilterBruteForceRows < 0 {
+		addViolation("prefilter brute force rows %d should not be negative", dynamic.PrefilterBruteForceRows)
+	}
+	if dynamic.ResultCompressThreshold < 0 {
+		addViolation("result compress threshold %d should not be negative", dynamic.ResultCompressThreshold)
+	}
+
+	if len(violations) > 0 {
+		return &configError{violations: violations}
+	}
+	return nil
+}
+
+// addReloadHook registers hook to be called with the new dynamic fields after every successful reload
+func (c *QueryNodeConfig) addReloadHook(hook func(*DynamicQueryNodeConfig)) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.hooks = append(c.hooks, hook)
+}
+
+// reload updates the dynamic fields from Params.QueryNodeCfg if they are valid, the config is left unchanged
+// otherwise. The changes of the static fields take effect only after restart.
+func (c *QueryNodeConfig) reload(totalMem uint64) error {
+	dynamic := newDynamicQueryNodeConfig()
+	if err := c.validate(dynamic, totalMem); err != nil {
+		return err
+	}
+	if static := newQueryNodeConfig(); !c.staticEqual(static) {
+		log.Warn("the static query node config changed, restart the node to take effect")
+	}
+
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.dynamic.Store(dynamic)
+	log.Info("query node config reloaded", zap.Any("config", dynamic))
+	for _, hook := range c.hooks {
+		hook(dynamic)
+	}
+	return nil
+}
+
+// staticEqual returns whether the static fields of c and other are equal
+func (c *QueryNodeConfig) staticEqual(other *QueryNodeConfig) bool {
+	return c.FlowGraphMaxQueueLength == other.FlowGraphMaxQueueLength &&
+		c.FlowGraphMaxParallelism == other.FlowGraphMaxParallelism &&
+		c.ChunkRows == other.ChunkRows &&
+		c.CacheEnabled == other.CacheEnabled &&
+		c.CacheMemoryLimit == other.CacheMemoryLimit &&
+		c.OverloadedMemoryThresholdPercentage == other.OverloadedMemoryThresholdPercentage &&
+		c.GracefulTime == other.GracefulTime &&
+		c.GrowingSegmentGCInterval == other.GrowingSegmentGCInterval &&
+		c.GrowingSegmentIdleTolerance == other.GrowingSegmentIdleTolerance &&
+		c.StorageBreakerFailureThreshold == other.StorageBreakerFailureThreshold &&
+		c.StorageBreakerCoolDown == other.StorageBreakerCoolDown &&
+		c.SearchConcurrencyMin == other.SearchConcurrencyMin &&
+		c.SearchConcurrencyMax == other.SearchConcurrencyMax &&
+		c.SearchConcurrencyAdjustInterval == other.SearchConcurrencyAdjustInterval &&
+		c.CgoReadPoolSize == other.CgoReadPoolSize &&
+		c.CgoWritePoolSize == other.CgoWritePoolSize &&
+		c.CatchUpLag == other.CatchUpLag &&
+		c.CatchUpBatchRows == other.CatchUpBatchRows &&
+		c.TimeTickCoalesceWindow == other.TimeTickCoalesceWindow &&
+		c.PendingDeleteWindow == other.PendingDeleteWindow &&
+		c.PendingDeleteMaxSize == other.PendingDeleteMaxSize &&
+		c.SealedDeleteBufferWindow == other.SealedDeleteBufferWindow &&
+		c.SealedDeleteBufferMaxSize == other.SealedDeleteBufferMaxSize &&
+		c.ResultCompressType == other.ResultCompressType
+}
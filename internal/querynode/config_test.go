@@ -0,0 +1,220 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// updateDynamicConfig replaces the dynamic fields of config with a copy modified by update
+func updateDynamicConfig(config *QueryNodeConfig, update func(dynamic *DynamicQueryNodeConfig)) {
+	dynamic := *config.getDynamic()
+	update(&dynamic)
+	config.dynamic.Store(&dynamic)
+}
+
+// genValidQueryNodeConfig generates a config passing the validation against 1GB of memory
+func genValidQueryNodeConfig() *QueryNodeConfig {
+	config := &QueryNodeConfig{
+		FlowGraphMaxQueueLength:             1024,
+		FlowGraphMaxParallelism:             1024,
+		ChunkRows:                           32768,
+		CacheEnabled:                        true,
+		CacheMemoryLimit:                    1 << 20,
+		OverloadedMemoryThresholdPercentage: 0.9,
+		GracefulTime:                        0,
+		GrowingSegmentGCInterval:            time.Minute,
+		GrowingSegmentIdleTolerance:         time.Hour,
+		StorageBreakerFailureThreshold:      5,
+		StorageBreakerCoolDown:              time.Second,
+		SearchConcurrencyMin:                1,
+		SearchConcurrencyMax:                16,
+		SearchConcurrencyAdjustInterval:     time.Second,
+		CollectionReadQueueLimit:            64,
+		CatchUpLag:                          time.Minute,
+		CatchUpBatchRows:                    1024,
+		TimeTickCoalesceWindow:              10 * time.Millisecond,
+		PendingDeleteWindow:                 10 * time.Second,
+		PendingDeleteMaxSize:                1024,
+		SealedDeleteBufferWindow:            time.Minute,
+		SealedDeleteBufferMaxSize:           1024,
+		ResultCompressType:                  "zstd",
+	}
+	config.dynamic.Store(&DynamicQueryNodeConfig{
+		SegmentQuarantineFailures: 3,
+		MaxGuaranteeTsLag:         time.Minute,
+		StrictGuaranteeTs:         true,
+		EnableSearchDedup:         true,
+		SimplifyPredicates:        true,
+		PrefilterSelectivity:      0.1,
+		PrefilterBruteForceRows:   1000,
+		ResultCompressThreshold:   1024,
+	})
+	return config
+}
+
+func TestQueryNodeConfig_validate(t *testing.T) {
+	const totalMem = 1 << 30
+
+	config := genValidQueryNodeConfig()
+	assert.NoError(t, config.validate(config.getDynamic(), totalMem))
+
+	cases := []struct {
+		name      string
+		update    func(config *QueryNodeConfig, dynamic *DynamicQueryNodeConfig)
+		violation string
+	}{
+		{"queue length", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.FlowGraphMaxQueueLength = 0 }, "flow graph max queue length"},
+		{"parallelism", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.FlowGraphMaxParallelism = -1 }, "flow graph max parallelism"},
+		{"chunk rows", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.ChunkRows = 0 }, "segcore chunk rows"},
+		{"overloaded threshold zero", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.OverloadedMemoryThresholdPercentage = 0 }, "overloaded memory threshold"},
+		{"overloaded threshold above one", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.OverloadedMemoryThresholdPercentage = 1.5 }, "overloaded memory threshold"},
+		{"cache limit", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.CacheMemoryLimit = 0 }, "cache memory limit 0 should be positive"},
+		{"cache limit above watermark", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.CacheMemoryLimit = totalMem }, "memory watermark"},
+		{"growing segment gc", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.GrowingSegmentIdleTolerance = 0 }, "growing segment idle tolerance"},
+		{"storage breaker", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.StorageBreakerCoolDown = 0 }, "storage breaker cool down"},
+		{"search concurrency min", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.SearchConcurrencyMin = 17 }, "search concurrency min"},
+		{"search concurrency interval", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.SearchConcurrencyAdjustInterval = 0 }, "search concurrency adjust interval"},
+		{"collection read queue limit", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.CollectionReadQueueLimit = -1 }, "collection read queue limit"},
+		{"cgo read pool size", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.CgoReadPoolSize = -1 }, "cgo read pool size"},
+		{"cgo write pool size", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.CgoWritePoolSize = -1 }, "cgo write pool size"},
+		{"catch-up", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.CatchUpBatchRows = 0 }, "catch-up batch rows"},
+		{"time tick coalesce window", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.TimeTickCoalesceWindow = -1 }, "time tick coalesce window"},
+		{"pending delete", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.PendingDeleteMaxSize = 0 }, "pending delete max size"},
+		{"sealed delete buffer", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.SealedDeleteBufferMaxSize = 0 }, "sealed delete buffer max size"},
+		{"compress type", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { c.ResultCompressType = "lz4" }, "result compress type"},
+		{"strict guarantee ts", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { d.MaxGuaranteeTsLag = 0 }, "max guarantee ts lag"},
+		{"prefilter selectivity", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { d.PrefilterSelectivity = 1.1 }, "prefilter selectivity"},
+		{"prefilter brute force rows", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { d.PrefilterBruteForceRows = -1 }, "prefilter brute force rows"},
+		{"compress threshold", func(c *QueryNodeConfig, d *DynamicQueryNodeConfig) { d.ResultCompressThreshold = -1 }, "result compress threshold"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config := genValidQueryNodeConfig()
+			dynamic := *config.getDynamic()
+			c.update(config, &dynamic)
+			err := config.validate(&dynamic, totalMem)
+			var configErr *configError
+			require.True(t, errors.As(err, &configErr))
+			require.Len(t, configErr.violations, 1)
+			assert.Contains(t, configErr.violations[0], c.violation)
+		})
+	}
+
+	t.Run("disabled features", func(t *testing.T) {
+		config := genValidQueryNodeConfig()
+		config.CacheEnabled = false
+		config.CacheMemoryLimit = 0
+		config.GrowingSegmentGCInterval = 0
+		config.GrowingSegmentIdleTolerance = 0
+		config.StorageBreakerFailureThreshold = 0
+		config.StorageBreakerCoolDown = 0
+		config.SearchConcurrencyMax = 0
+		config.SearchConcurrencyMin = 0
+		config.SearchConcurrencyAdjustInterval = 0
+		config.CatchUpLag = 0
+		config.CatchUpBatchRows = 0
+		config.PendingDeleteWindow = 0
+		config.PendingDeleteMaxSize = 0
+		config.SealedDeleteBufferWindow = 0
+		config.SealedDeleteBufferMaxSize = 0
+		dynamic := *config.getDynamic()
+		dynamic.StrictGuaranteeTs = false
+		dynamic.MaxGuaranteeTsLag = 0
+		assert.NoError(t, config.validate(&dynamic, totalMem))
+	})
+
+	t.Run("unknown memory", func(t *testing.T) {
+		config := genValidQueryNodeConfig()
+		config.CacheMemoryLimit = totalMem
+		assert.NoError(t, config.validate(config.getDynamic(), 0))
+	})
+
+	t.Run("all violations", func(t *testing.T) {
+		config := genValidQueryNodeConfig()
+		config.ChunkRows = 0
+		config.ResultCompressType = ""
+		dynamic := *config.getDynamic()
+		dynamic.PrefilterSelectivity = -1
+		err := config.validate(&dynamic, totalMem)
+		var configErr *configError
+		require.True(t, errors.As(err, &configErr))
+		assert.Len(t, configErr.violations, 3)
+		assert.Contains(t, err.Error(), "invalid query node config")
+	})
+}
+
+func TestLoadQueryNodeConfig(t *testing.T) {
+	config, err := loadQueryNodeConfig(0)
+	require.NoError(t, err)
+	assert.Equal(t, Params.QueryNodeCfg.ChunkRows, config.ChunkRows)
+	assert.Equal(t, Params.QueryNodeCfg.EnableSearchDedup, config.getDynamic().EnableSearchDedup)
+
+	enabled, limit := Params.QueryNodeCfg.CacheEnabled, Params.QueryNodeCfg.CacheMemoryLimit
+	defer func() { Params.QueryNodeCfg.CacheEnabled, Params.QueryNodeCfg.CacheMemoryLimit = enabled, limit }()
+	Params.QueryNodeCfg.CacheEnabled = true
+	Params.QueryNodeCfg.CacheMemoryLimit = 1 << 30
+	_, err = loadQueryNodeConfig(1 << 30)
+	var configErr *configError
+	assert.True(t, errors.As(err, &configErr))
+}
+
+func TestQueryNodeConfig_reload(t *testing.T) {
+	config, err := loadQueryNodeConfig(0)
+	require.NoError(t, err)
+
+	var reloaded []*DynamicQueryNodeConfig
+	config.addReloadHook(func(dynamic *DynamicQueryNodeConfig) { reloaded = append(reloaded, dynamic) })
+
+	failures, selectivity, chunkRows := Params.QueryNodeCfg.SegmentQuarantineFailures, Params.QueryNodeCfg.PrefilterSelectivity, Params.QueryNodeCfg.ChunkRows
+	defer func() {
+		Params.QueryNodeCfg.SegmentQuarantineFailures = failures
+		Params.QueryNodeCfg.PrefilterSelectivity = selectivity
+		Params.QueryNodeCfg.ChunkRows = chunkRows
+	}()
+
+	t.Run("valid", func(t *testing.T) {
+		Params.QueryNodeCfg.SegmentQuarantineFailures = failures + 1
+		require.NoError(t, config.reload(0))
+		assert.Equal(t, failures+1, config.getDynamic().SegmentQuarantineFailures)
+		require.Len(t, reloaded, 1)
+		assert.Same(t, config.getDynamic(), reloaded[0])
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		before := config.getDynamic()
+		Params.QueryNodeCfg.PrefilterSelectivity = 2
+		err := config.reload(0)
+		var configErr *configError
+		assert.True(t, errors.As(err, &configErr))
+		assert.Same(t, before, config.getDynamic())
+		assert.Len(t, reloaded, 1)
+		Params.QueryNodeCfg.PrefilterSelectivity = selectivity
+	})
+
+	t.Run("static", func(t *testing.T) {
+		Params.QueryNodeCfg.ChunkRows = chunkRows * 2
+		require.NoError(t, config.reload(0))
+		assert.Equal(t, chunkRows, config.ChunkRows)
+		assert.Len(t, reloaded, 2)
+	})
+}
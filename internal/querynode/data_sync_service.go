@@ -26,6 +26,7 @@ import (
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
 )
 
 // dataSyncService manages a lot of flow graphs
@@ -180,6 +181,142 @@ func (dsService *dataSyncService) startFlowGraphForDeltaChannel(collectionID Uni
 	return nil
 }
 
+// pauseFlowGraphsByDMLChannel pauses the DML flow graph of channel, and the delta flow graph of its delta channel
+// if watched, so that neither inserts nor deletes of the channel are applied until resumed
+func (dsService *dataSyncService) pauseFlowGraphsByDMLChannel(collectionID UniqueID, channel Channel) error {
+	dsService.mu.Lock()
+	defer dsService.mu.Unlock()
+
+	fg, ok := dsService.dmlChannel2FlowGraph[channel]
+	if !ok {
+		return fmt.Errorf("DML flow graph doesn't existed, collectionID = %d", collectionID)
+	}
+	if fg.pause() {
+		metrics.QueryNodeFlowGraphPaused.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), channel).Set(1)
+	}
+	if deltaFg, ok := dsService.deltaChannel2FlowGraph[dsService.getDeltaChannel(channel)]; ok {
+		deltaFg.pause()
+	}
+	return nil
+}
+
+// resumeFlowGraphsByDMLChannel resumes the flow graphs paused by pauseFlowGraphsByDMLChannel
+func (dsService *dataSyncService) resumeFlowGraphsByDMLChannel(collectionID UniqueID, channel Channel) error {
+	dsService.mu.Lock()
+	defer dsService.mu.Unlock()
+
+	fg, ok := dsService.dmlChannel2FlowGraph[channel]
+	if !ok {
+		return fmt.Errorf("DML flow graph doesn't existed, collectionID = %d", collectionID)
+	}
+	if deltaFg, ok := dsService.deltaChannel2FlowGraph[dsService.getDeltaChannel(channel)]; ok {
+		deltaFg.resume()
+	}
+	if fg.resume() {
+		metrics.QueryNodeFlowGraphPaused.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), channel).Set(0)
+	}
+	return nil
+}
+
+// getPausedDMLChannels returns the DML channels whose flow graph is paused
+func (dsService *dataSyncService) getPausedDMLChannels() []Channel {
+	dsService.mu.Lock()
+	defer dsService.mu.Unlock()
+
+	channels := make([]Channel, 0)
+	for channel, fg := range dsService.dmlChannel2FlowGraph {
+		if fg.isPaused() {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+// getCatchUpProgress returns the catch-up progress of the DML channels
+func (dsService *dataSyncService) getCatchUpProgress() map[Channel]catchUpProgress {
+	dsService.mu.Lock()
+	defer dsService.mu.Unlock()
+
+	progress := make(map[Channel]catchUpProgress)
+	for channel, fg := range dsService.dmlChannel2FlowGraph {
+		if p, ok := fg.getCatchUpProgress(); ok {
+			progress[channel] = p
+		}
+	}
+	return progress
+}
+
+// channelIngestionRates is the insert and delete rates of a DML channel
+type channelIngestionRates struct {
+	insert ingestionRate
+	delete ingestionRate
+}
+
+// getIngestionRates returns the insert and delete rates of the DML channels
+func (dsService *dataSyncService) getIngestionRates() map[Channel]channelIngestionRates {
+	dsService.mu.Lock()
+	defer dsService.mu.Unlock()
+
+	rates := make(map[Channel]channelIngestionRates)
+	for channel, fg := range dsService.dmlChannel2FlowGraph {
+		insertRate, deleteRate := fg.getIngestionRates()
+		rates[channel] = channelIngestionRates{insert: insertRate, delete: deleteRate}
+	}
+	return rates
+}
+
+// reassignGrowingSegments moves the growing segments of collection from the DML channel fromChannel to toChannel,
+// both watched by the node, e.g. once the shard of fromChannel is merged into the one of toChannel. The flow graph
+// of fromChannel is paused meanwhile, and the pending deletes of the partitions of the segments are handed over to
+// toChannel, whose inserts may match them from then on.
+func (dsService *dataSyncService) reassignGrowingSegments(collectionID UniqueID, segmentIDs []UniqueID, fromChannel, toChannel Channel) error {
+	dsService.mu.Lock()
+	defer dsService.mu.Unlock()
+
+	fromFg, ok := dsService.dmlChannel2FlowGraph[fromChannel]
+	if !ok {
+		return fmt.Errorf("DML flow graph of channel %s doesn't existed, collectionID = %d", fromChannel, collectionID)
+	}
+	toFg, ok := dsService.dmlChannel2FlowGraph[toChannel]
+	if !ok {
+		return fmt.Errorf("DML flow graph of channel %s doesn't existed, collectionID = %d", toChannel, collectionID)
+	}
+	if fromFg.pause() {
+		defer fromFg.resume()
+	}
+
+	partitionIDs := make([]UniqueID, 0)
+	for _, segmentID := range segmentIDs {
+		segment, err := dsService.streamingReplica.getSegmentByID(segmentID)
+		if err != nil {
+			return err
+		}
+		if segment.collectionID != collectionID {
+			return fmt.Errorf("segment %d belongs to collection %d rather than %d", segmentID, segment.collectionID, collectionID)
+		}
+		partitionIDs = append(partitionIDs, segment.partitionID)
+	}
+	if err := dsService.streamingReplica.reassignSegmentChannel(segmentIDs, fromChannel, toChannel); err != nil {
+		return err
+	}
+	transferred := fromFg.insertNode.pendingDeletes.transfer(toFg.insertNode.pendingDeletes, collectionID, partitionIDs)
+	log.Info("reassign growing segments between DML channels",
+		zap.Int64("collectionID", collectionID),
+		zap.Int64s("segmentIDs", segmentIDs),
+		zap.String("fromChannel", fromChannel),
+		zap.String("toChannel", toChannel),
+		zap.Int("pendingDeletes", transferred))
+	return nil
+}
+
+func (dsService *dataSyncService) getDeltaChannel(channel Channel) Channel {
+	deltaChannel, err := funcutil.ConvertChannelName(channel, Params.CommonCfg.RootCoordDml, Params.CommonCfg.RootCoordDelta)
+	if err != nil {
+		log.Warn("failed to convert dm channel to delta", zap.String("channel", channel), zap.Error(err))
+	}
+	return deltaChannel
+}
+
 // removeFlowGraphsByDMLChannels would remove the DML flow graphs by channels
 func (dsService *dataSyncService) removeFlowGraphsByDMLChannels(channels []Channel) {
 	dsService.mu.Lock()
@@ -190,6 +327,7 @@ func (dsService *dataSyncService) removeFlowGraphsByDMLChannels(channels []Chann
 			// close flow graph
 			dsService.dmlChannel2FlowGraph[channel].close()
 			metrics.QueryNodeNumFlowGraphs.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Dec()
+			metrics.QueryNodeFlowGraphPaused.DeleteLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), channel)
 		}
 		delete(dsService.dmlChannel2FlowGraph, channel)
 	}
@@ -18,9 +18,17 @@ package querynode
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
 )
 
 func TestDataSyncService_DMLFlowGraphs(t *testing.T) {
@@ -130,3 +138,149 @@ func TestDataSyncService_DeltaFlowGraphs(t *testing.T) {
 	dataSyncService.close()
 	assert.Len(t, dataSyncService.deltaChannel2FlowGraph, 0)
 }
+
+func TestDataSyncService_PauseFlowGraphs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streamingReplica, err := genSimpleReplica()
+	assert.NoError(t, err)
+
+	historicalReplica, err := genSimpleReplica()
+	assert.NoError(t, err)
+
+	tSafe := newTSafeReplica()
+	dataSyncService := newDataSyncService(ctx, streamingReplica, historicalReplica, tSafe, genFactory())
+	defer dataSyncService.close()
+
+	dmlChannel := fmt.Sprintf("%s_0v0", Params.CommonCfg.RootCoordDml)
+	deltaChannel := fmt.Sprintf("%s_0v0", Params.CommonCfg.RootCoordDelta)
+	_, err = dataSyncService.addFlowGraphsForDMLChannels(defaultCollectionID, []Channel{dmlChannel})
+	assert.NoError(t, err)
+	_, err = dataSyncService.addFlowGraphsForDeltaChannels(defaultCollectionID, []Channel{deltaChannel})
+	assert.NoError(t, err)
+	dmlFg, err := dataSyncService.getFlowGraphByDMLChannel(defaultCollectionID, dmlChannel)
+	assert.NoError(t, err)
+	deltaFg, err := dataSyncService.getFlowGraphByDeltaChannel(defaultCollectionID, deltaChannel)
+	assert.NoError(t, err)
+	paused := metrics.QueryNodeFlowGraphPaused.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), dmlChannel)
+
+	err = dataSyncService.pauseFlowGraphsByDMLChannel(defaultCollectionID, "invalid-vChannel")
+	assert.Error(t, err)
+	err = dataSyncService.resumeFlowGraphsByDMLChannel(defaultCollectionID, "invalid-vChannel")
+	assert.Error(t, err)
+	assert.Empty(t, dataSyncService.getPausedDMLChannels())
+
+	err = dataSyncService.pauseFlowGraphsByDMLChannel(defaultCollectionID, dmlChannel)
+	assert.NoError(t, err)
+	assert.True(t, dmlFg.isPaused())
+	assert.True(t, deltaFg.isPaused())
+	assert.Equal(t, []Channel{dmlChannel}, dataSyncService.getPausedDMLChannels())
+	assert.Equal(t, float64(1), testutil.ToFloat64(paused))
+
+	// pausing again is a no-op
+	err = dataSyncService.pauseFlowGraphsByDMLChannel(defaultCollectionID, dmlChannel)
+	assert.NoError(t, err)
+
+	err = dataSyncService.resumeFlowGraphsByDMLChannel(defaultCollectionID, dmlChannel)
+	assert.NoError(t, err)
+	assert.False(t, dmlFg.isPaused())
+	assert.False(t, deltaFg.isPaused())
+	assert.Empty(t, dataSyncService.getPausedDMLChannels())
+	assert.Equal(t, float64(0), testutil.ToFloat64(paused))
+}
+
+func TestDataSyncService_reassignGrowingSegments(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streamingReplica, err := genSimpleReplica()
+	require.NoError(t, err)
+	historicalReplica, err := genSimpleReplica()
+	require.NoError(t, err)
+	dataSyncService := newDataSyncService(ctx, streamingReplica, historicalReplica, newTSafeReplica(), genFactory())
+	defer dataSyncService.close()
+
+	fromChannel := fmt.Sprintf("%s_0v0", Params.CommonCfg.RootCoordDml)
+	toChannel := fmt.Sprintf("%s_1v0", Params.CommonCfg.RootCoordDml)
+	_, err = dataSyncService.addFlowGraphsForDMLChannels(defaultCollectionID, []Channel{fromChannel, toChannel})
+	require.NoError(t, err)
+	fromFg, err := dataSyncService.getFlowGraphByDMLChannel(defaultCollectionID, fromChannel)
+	require.NoError(t, err)
+	toFg, err := dataSyncService.getFlowGraphByDMLChannel(defaultCollectionID, toChannel)
+	require.NoError(t, err)
+
+	require.NoError(t, streamingReplica.addSegment(defaultSegmentID, defaultPartitionID, defaultCollectionID, fromChannel, segmentTypeGrowing, true))
+	segment, err := streamingReplica.getSegmentByID(defaultSegmentID)
+	require.NoError(t, err)
+	// pk 1 is deleted before inserted into the segment
+	ts := tsoutil.ComposeTSByTime(time.Now(), 0)
+	fromFg.insertNode.pendingDeletes.add(defaultCollectionID, defaultPartitionID, []primaryKey{newInt64PrimaryKey(1)}, []Timestamp{ts})
+
+	err = dataSyncService.reassignGrowingSegments(defaultCollectionID, []UniqueID{defaultSegmentID}, fromChannel, "invalid-vChannel")
+	assert.Error(t, err)
+	err = dataSyncService.reassignGrowingSegments(defaultCollectionID+1, []UniqueID{defaultSegmentID}, fromChannel, toChannel)
+	assert.Error(t, err)
+	assert.Equal(t, fromChannel, segment.vChannelID)
+	assert.Equal(t, int64(1), fromFg.insertNode.pendingDeletes.len())
+
+	err = dataSyncService.reassignGrowingSegments(defaultCollectionID, []UniqueID{defaultSegmentID}, fromChannel, toChannel)
+	require.NoError(t, err)
+	assert.False(t, fromFg.isPaused())
+	// the segment is queried through the new channel
+	segmentIDs, err := streamingReplica.getSegmentIDsByVChannel(defaultPartitionID, toChannel)
+	require.NoError(t, err)
+	assert.Equal(t, []UniqueID{defaultSegmentID}, segmentIDs)
+	segmentIDs, err = streamingReplica.getSegmentIDsByVChannel(defaultPartitionID, fromChannel)
+	require.NoError(t, err)
+	assert.Empty(t, segmentIDs)
+
+	// the pending delete is applied to the row inserted through the new channel
+	assert.Equal(t, int64(0), fromFg.insertNode.pendingDeletes.len())
+	assert.Equal(t, int64(1), toFg.insertNode.pendingDeletes.len())
+	iData := newInsertData()
+	iData.insertRecords[defaultSegmentID] = []*commonpb.Blob{{}}
+	iData.insertPKs[defaultSegmentID] = []primaryKey{newInt64PrimaryKey(1)}
+	iData.insertTimestamps[defaultSegmentID] = []Timestamp{ts - 1}
+	delData := &deleteData{
+		deleteIDs:        make(map[UniqueID][]primaryKey),
+		deleteTimestamps: make(map[UniqueID][]Timestamp),
+	}
+	toFg.insertNode.applyPendingDeletes(iData, delData, ts-1)
+	assert.Equal(t, []primaryKey{newInt64PrimaryKey(1)}, delData.deleteIDs[defaultSegmentID])
+	assert.Equal(t, []Timestamp{ts}, delData.deleteTimestamps[defaultSegmentID])
+}
+
+func TestDataSyncService_getCatchUpProgress(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streamingReplica, err := genSimpleReplica()
+	assert.NoError(t, err)
+
+	historicalReplica, err := genSimpleReplica()
+	assert.NoError(t, err)
+
+	tSafe := newTSafeReplica()
+	dataSyncService := newDataSyncService(ctx, streamingReplica, historicalReplica, tSafe, genFactory())
+	defer dataSyncService.close()
+
+	dmlChannel := fmt.Sprintf("%s_0v0", Params.CommonCfg.RootCoordDml)
+	deltaChannel := fmt.Sprintf("%s_0v0", Params.CommonCfg.RootCoordDelta)
+	_, err = dataSyncService.addFlowGraphsForDMLChannels(defaultCollectionID, []Channel{dmlChannel})
+	assert.NoError(t, err)
+	_, err = dataSyncService.addFlowGraphsForDeltaChannels(defaultCollectionID, []Channel{deltaChannel})
+	assert.NoError(t, err)
+
+	// only DML flow graphs replay inserts
+	progress := dataSyncService.getCatchUpProgress()
+	assert.Len(t, progress, 1)
+	assert.False(t, progress[dmlChannel].catchingUp)
+
+	dmlFg, err := dataSyncService.getFlowGraphByDMLChannel(defaultCollectionID, dmlChannel)
+	assert.NoError(t, err)
+	dmlFg.insertNode.catchUp.start(tsoutil.ComposeTSByTime(time.Now().Add(-time.Hour), 0))
+	progress = dataSyncService.getCatchUpProgress()
+	assert.True(t, progress[dmlChannel].catchingUp)
+	assert.Greater(t, progress[dmlChannel].startLag, Params.QueryNodeCfg.CatchUpLag)
+}
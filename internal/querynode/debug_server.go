@@ -0,0 +1,445 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/parser/planparser"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// debugMaxPrintedIDs is the max number of primary keys printed by the expr command
+const debugMaxPrintedIDs = 100
+
+const debugHelp = `COMMAND                                                           DESCRIPTION
+help                                                              show this help
+collections                                                       list the collections of historical and streaming replicas
+segments [collection=<id>] [partition=<id>] [type=growing|sealed] [channel=<name>]
+                                                                  list the segments matching all the filters
+tsafe                                                             list the tSafe of channels
+expr <segmentID> <expression>                                     list the primary keys of segment matching the boolean expression
+quit                                                              close the session
+`
+
+// debugServer serves a read-only debug shell of the query node on a local unix socket,
+// each line is a command, which is replied with a table terminated by an empty line
+type debugServer struct {
+	node *QueryNode
+	path string
+
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu    sync.Mutex // guards conns
+	conns map[net.Conn]struct{}
+}
+
+func newDebugServer(node *QueryNode, path string) *debugServer {
+	return &debugServer{
+		node:  node,
+		path:  path,
+		conns: make(map[net.Conn]struct{}),
+	}
+}
+
+func (s *debugServer) start() error {
+	// the socket file left by the previous process makes listen fail
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	listener, err := net.Listen("unix", s.path)
+	if err != nil {
+		return err
+	}
+	// only the user running the query node could connect
+	if err := os.Chmod(s.path, 0600); err != nil {
+		listener.Close()
+		return err
+	}
+	s.listener = listener
+
+	s.wg.Add(1)
+	go s.serve()
+	log.Info("debug server started", zap.String("socket", s.path))
+	return nil
+}
+
+func (s *debugServer) stop() {
+	if s.listener == nil {
+		return
+	}
+	s.listener.Close()
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	log.Info("debug server stopped", zap.String("socket", s.path))
+}
+
+func (s *debugServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			// closed by stop
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.handle(conn)
+	}
+}
+
+func (s *debugServer) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	writer := bufio.NewWriter(conn)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == "quit" || line == "exit" {
+			return
+		}
+		output, err := s.execute(line)
+		if err != nil {
+			output = fmt.Sprintf("ERROR: %s\n", err)
+		}
+		writer.WriteString(output)
+		writer.WriteString("\n")
+		if err := writer.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// execute runs the command of line, none of the commands modifies the query node
+func (s *debugServer) execute(line string) (string, error) {
+	command, rest := cutDebugField(line)
+	args := strings.Fields(rest)
+	switch strings.ToLower(command) {
+	case "help":
+		return debugHelp, nil
+	case "collections":
+		return s.listCollections()
+	case "segments":
+		return s.listSegments(args)
+	case "tsafe":
+		return s.listTSafe()
+	case "expr":
+		return s.runExpr(rest)
+	}
+	return "", fmt.Errorf("unknown command %s, run help to list the commands", command)
+}
+
+type debugReplica struct {
+	name    string
+	replica ReplicaInterface
+}
+
+func (s *debugServer) replicas() []debugReplica {
+	var replicas []debugReplica
+	if s.node.historical != nil {
+		replicas = append(replicas, debugReplica{name: "historical", replica: s.node.historical.replica})
+	}
+	if s.node.streaming != nil {
+		replicas = append(replicas, debugReplica{name: "streaming", replica: s.node.streaming.replica})
+	}
+	return replicas
+}
+
+func (s *debugServer) listCollections() (string, error) {
+	var rows [][]string
+	for _, r := range s.replicas() {
+		for _, collectionID := range sortedIDs(r.replica.getCollectionIDs()) {
+			collection, err := r.replica.getCollectionByID(collectionID)
+			if err != nil {
+				continue
+			}
+			partitionIDs, err := r.replica.getPartitionIDs(collectionID)
+			if err != nil {
+				continue
+			}
+			segmentNum := 0
+			for _, partitionID := range partitionIDs {
+				segmentIDs, err := r.replica.getSegmentIDs(partitionID)
+				if err == nil {
+					segmentNum += len(segmentIDs)
+				}
+			}
+			rows = append(rows, []string{
+				r.name,
+				strconv.FormatInt(collectionID, 10),
+				collection.Schema().GetName(),
+				collection.getLoadType().String(),
+				strconv.Itoa(len(partitionIDs)),
+				strconv.Itoa(segmentNum),
+			})
+		}
+	}
+	return formatDebugTable([]string{"REPLICA", "COLLECTION_ID", "NAME", "LOAD_TYPE", "PARTITIONS", "SEGMENTS"}, rows), nil
+}
+
+// debugSegmentFilter is the filters of segments command, zero values match all
+type debugSegmentFilter struct {
+	collectionID *UniqueID
+	partitionID  *UniqueID
+	segmentType  string
+	channel      Channel
+}
+
+func parseDebugSegmentFilter(args []string) (*debugSegmentFilter, error) {
+	filter := &debugSegmentFilter{}
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid filter %s, filters are in the form of key=value", arg)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+		switch key {
+		case "collection", "partition":
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s id %s", key, value)
+			}
+			if key == "collection" {
+				filter.collectionID = &id
+			} else {
+				filter.partitionID = &id
+			}
+		case "type":
+			value = strings.ToLower(value)
+			if value != "growing" && value != "sealed" {
+				return nil, fmt.Errorf("invalid segment type %s, which should be growing or sealed", value)
+			}
+			filter.segmentType = value
+		case "channel":
+			filter.channel = value
+		default:
+			return nil, fmt.Errorf("unknown filter %s", key)
+		}
+	}
+	return filter, nil
+}
+
+func (filter *debugSegmentFilter) match(segment *Segment) bool {
+	return (filter.collectionID == nil || *filter.collectionID == segment.collectionID) &&
+		(filter.partitionID == nil || *filter.partitionID == segment.partitionID) &&
+		(filter.segmentType == "" || filter.segmentType == strings.ToLower(segment.getType().String())) &&
+		(filter.channel == "" || filter.channel == segment.vChannelID)
+}
+
+func (s *debugServer) listSegments(args []string) (string, error) {
+	filter, err := parseDebugSegmentFilter(args)
+	if err != nil {
+		return "", err
+	}
+
+	var rows [][]string
+	for _, r := range s.replicas() {
+		for _, collectionID := range sortedIDs(r.replica.getCollectionIDs()) {
+			partitionIDs, err := r.replica.getPartitionIDs(collectionID)
+			if err != nil {
+				continue
+			}
+			for _, partitionID := range sortedIDs(partitionIDs) {
+				segmentIDs, err := r.replica.getSegmentIDs(partitionID)
+				if err != nil {
+					continue
+				}
+				for _, segmentID := range sortedIDs(segmentIDs) {
+					segment, err := r.replica.getSegmentByID(segmentID)
+					if err != nil || !filter.match(segment) {
+						continue
+					}
+					rows = append(rows, []string{
+						r.name,
+						strconv.FormatInt(segment.segmentID, 10),
+						strconv.FormatInt(segment.collectionID, 10),
+						strconv.FormatInt(segment.partitionID, 10),
+						strings.ToLower(segment.getType().String()),
+						segment.vChannelID,
+						strconv.FormatInt(segment.getRowCount(), 10),
+						strconv.FormatInt(segment.getMemSize(), 10),
+						strconv.FormatInt(segment.getDiskUsage(), 10),
+					})
+				}
+			}
+		}
+	}
+	return formatDebugTable([]string{"REPLICA", "SEGMENT_ID", "COLLECTION_ID", "PARTITION_ID", "TYPE", "CHANNEL", "ROWS", "MEM_SIZE", "DISK_USAGE"}, rows), nil
+}
+
+func (s *debugServer) listTSafe() (string, error) {
+	var rows [][]string
+	if s.node.tSafeReplica != nil {
+		for _, channel := range s.node.tSafeReplica.getTSafeChannels() {
+			ts, err := s.node.tSafeReplica.getTSafe(channel)
+			if err != nil {
+				continue
+			}
+			rows = append(rows, []string{
+				channel,
+				strconv.FormatUint(ts, 10),
+				tsoutil.PhysicalTime(ts).Format("2006-01-02 15:04:05.000"),
+			})
+		}
+	}
+	return formatDebugTable([]string{"CHANNEL", "TSAFE", "TIME"}, rows), nil
+}
+
+// runExpr lists the primary keys of the rows in segment matching the expression, args is `<segmentID> <expression>`
+func (s *debugServer) runExpr(args string) (string, error) {
+	segmentIDStr, exprStr := cutDebugField(args)
+	if exprStr == "" {
+		return "", errors.New("usage: expr <segmentID> <expression>")
+	}
+	segmentID, err := strconv.ParseInt(segmentIDStr, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid segment id %s", segmentIDStr)
+	}
+
+	for _, r := range s.replicas() {
+		if !r.replica.hasSegment(segmentID) {
+			continue
+		}
+		ids, err := debugRetrieve(r.replica, segmentID, exprStr)
+		if err != nil {
+			return "", err
+		}
+		rows := make([][]string, 0, debugMaxPrintedIDs)
+		for i := 0; i < len(ids) && i < debugMaxPrintedIDs; i++ {
+			rows = append(rows, []string{ids[i]})
+		}
+		output := formatDebugTable([]string{"PK"}, rows)
+		if len(ids) > debugMaxPrintedIDs {
+			output += fmt.Sprintf("... %d more\n", len(ids)-debugMaxPrintedIDs)
+		}
+		return output + fmt.Sprintf("(%d rows)\n", len(ids)), nil
+	}
+	return "", fmt.Errorf("segment %d not found", segmentID)
+}
+
+// debugRetrieve retrieves the primary keys of the rows in segment matching exprStr
+func debugRetrieve(replica ReplicaInterface, segmentID UniqueID, exprStr string) ([]string, error) {
+	// keep the segment from being released during retrieve
+	replica.queryRLock()
+	defer replica.queryRUnlock()
+
+	segment, err := replica.getSegmentByID(segmentID)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := replica.getCollectionByID(segment.collectionID)
+	if err != nil {
+		return nil, err
+	}
+	pkFieldID, err := replica.getPKFieldIDByCollectionID(segment.collectionID)
+	if err != nil {
+		return nil, err
+	}
+	planNode, err := planparser.CreateExprPlan(collection.Schema(), exprStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %s: %w", exprStr, err)
+	}
+	planNode.OutputFieldIds = []int64{pkFieldID}
+	expr, err := proto.Marshal(planNode)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := createRetrievePlanByExpr(collection, expr, typeutil.MaxTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	defer plan.delete()
+
+	result, err := segment.retrieve(plan)
+	if err != nil {
+		return nil, err
+	}
+	return formatDebugIDs(result.GetIds()), nil
+}
+
+func formatDebugIDs(ids *schemapb.IDs) []string {
+	var formatted []string
+	switch ids.GetIdField().(type) {
+	case *schemapb.IDs_IntId:
+		for _, id := range ids.GetIntId().GetData() {
+			formatted = append(formatted, strconv.FormatInt(id, 10))
+		}
+	case *schemapb.IDs_StrId:
+		formatted = append(formatted, ids.GetStrId().GetData()...)
+	}
+	return formatted
+}
+
+// cutDebugField splits the first whitespace separated field off s
+func cutDebugField(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i:])
+	}
+	return s, ""
+}
+
+func formatDebugTable(header []string, rows [][]string) string {
+	var buf bytes.Buffer
+	writer := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	writer.Flush()
+	return buf.String()
+}
+
+func sortedIDs(ids []UniqueID) []UniqueID {
+	sorted := make([]UniqueID, len(ids))
+	copy(sorted, ids)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
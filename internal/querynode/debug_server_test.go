@@ -0,0 +1,187 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// debugSession is a scripted client of the debug server
+type debugSession struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// run sends the command and returns the lines of the reply, without the terminating empty line
+func (s *debugSession) run(command string) []string {
+	_, err := s.conn.Write([]byte(command + "\n"))
+	require.NoError(s.t, err)
+	var lines []string
+	for {
+		line, err := s.reader.ReadString('\n')
+		require.NoError(s.t, err)
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestDebugServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "querynode_debug.sock")
+	// stale socket file of the previous process
+	require.NoError(t, ioutil.WriteFile(path, nil, 0600))
+
+	server := newDebugServer(node, path)
+	require.NoError(t, server.start())
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	defer conn.Close()
+	session := &debugSession{t: t, conn: conn, reader: bufio.NewReader(conn)}
+
+	t.Run("help", func(t *testing.T) {
+		output := strings.Join(session.run("help"), "\n")
+		for _, command := range []string{"collections", "segments", "tsafe", "expr", "quit"} {
+			assert.Contains(t, output, command)
+		}
+	})
+
+	t.Run("collections", func(t *testing.T) {
+		lines := session.run("collections")
+		require.Len(t, lines, 3)
+		assert.Equal(t, []string{"REPLICA", "COLLECTION_ID", "NAME", "LOAD_TYPE", "PARTITIONS", "SEGMENTS"}, strings.Fields(lines[0]))
+		assert.Equal(t, []string{"historical", "0", defaultCollectionName}, strings.Fields(lines[1])[:3])
+		assert.Equal(t, []string{"1", "1"}, strings.Fields(lines[1])[4:])
+		assert.Equal(t, []string{"streaming", "0", defaultCollectionName}, strings.Fields(lines[2])[:3])
+	})
+
+	t.Run("segments", func(t *testing.T) {
+		lines := session.run("segments")
+		require.Len(t, lines, 3)
+		assert.Equal(t, []string{"REPLICA", "SEGMENT_ID", "COLLECTION_ID", "PARTITION_ID", "TYPE", "CHANNEL", "ROWS", "MEM_SIZE", "DISK_USAGE"}, strings.Fields(lines[0]))
+		assert.Equal(t, []string{"historical", "2", "0", "1", "sealed", defaultDMLChannel, "100"}, strings.Fields(lines[1])[:7])
+		assert.Equal(t, []string{"streaming", "2", "0", "1", "growing", defaultDMLChannel}, strings.Fields(lines[2])[:6])
+
+		lines = session.run("segments type=growing partition=1")
+		require.Len(t, lines, 2)
+		assert.Equal(t, "streaming", strings.Fields(lines[1])[0])
+
+		lines = session.run("segments collection=10")
+		assert.Len(t, lines, 1)
+
+		lines = session.run("segments channel=" + defaultDMLChannel + " type=sealed")
+		require.Len(t, lines, 2)
+		assert.Equal(t, "historical", strings.Fields(lines[1])[0])
+
+		for _, command := range []string{"segments foo=1", "segments collection=abc", "segments type=flushed", "segments collection"} {
+			lines = session.run(command)
+			require.Len(t, lines, 1, command)
+			assert.True(t, strings.HasPrefix(lines[0], "ERROR: "), command)
+		}
+	})
+
+	t.Run("tsafe", func(t *testing.T) {
+		lines := session.run("tsafe")
+		require.Len(t, lines, 3)
+		assert.Equal(t, []string{"CHANNEL", "TSAFE", "TIME"}, strings.Fields(lines[0]))
+		assert.Equal(t, defaultDMLChannel, strings.Fields(lines[1])[0])
+		assert.Equal(t, defaultDeltaChannel, strings.Fields(lines[2])[0])
+	})
+
+	t.Run("expr", func(t *testing.T) {
+		lines := session.run("expr 2 pk in [1, 2, 3]")
+		assert.Equal(t, []string{"PK", "1", "2", "3", "(3 rows)"}, trimLines(lines))
+
+		lines = session.run("expr 2 pk < 0")
+		assert.Equal(t, []string{"PK", "(0 rows)"}, trimLines(lines))
+
+		lines = session.run("expr 2 pk >= 0")
+		require.Len(t, lines, debugMaxPrintedIDs+2)
+		assert.Equal(t, "(100 rows)", lines[len(lines)-1])
+
+		for _, command := range []string{"expr 2", "expr abc pk > 0", "expr 100 pk > 0", "expr 2 foo > 0", "expr 2 pk >"} {
+			lines = session.run(command)
+			require.Len(t, lines, 1, command)
+			assert.True(t, strings.HasPrefix(lines[0], "ERROR: "), command)
+		}
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		for _, command := range []string{"drop", "release 0", "delete 2 pk in [1]"} {
+			lines := session.run(command)
+			require.Len(t, lines, 1, command)
+			assert.True(t, strings.HasPrefix(lines[0], "ERROR: unknown command"), command)
+		}
+		// nothing changed
+		assert.Equal(t, []string{"PK", "1", "2", "3", "(3 rows)"}, trimLines(session.run("expr 2 pk in [1, 2, 3]")))
+	})
+
+	t.Run("quit", func(t *testing.T) {
+		_, err := conn.Write([]byte("\nquit\n"))
+		require.NoError(t, err)
+		_, err = session.reader.ReadString('\n')
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		// open session is closed by stop
+		conn, err := net.Dial("unix", path)
+		require.NoError(t, err)
+		defer conn.Close()
+		session := &debugSession{t: t, conn: conn, reader: bufio.NewReader(conn)}
+		assert.Len(t, session.run("tsafe"), 3)
+
+		server.stop()
+		_, err = session.reader.ReadString('\n')
+		assert.Equal(t, io.EOF, err)
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+		_, err = net.Dial("unix", path)
+		assert.Error(t, err)
+	})
+}
+
+func trimLines(lines []string) []string {
+	trimmed := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed = append(trimmed, strings.TrimSpace(line))
+	}
+	return trimmed
+}
@@ -0,0 +1,152 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+// deleteRecords sorts pks and timestamps of a delete batch together
+type deleteRecords struct {
+	pks        []primaryKey
+	timestamps []Timestamp
+}
+
+func (r *deleteRecords) Len() int {
+	return len(r.pks)
+}
+
+func (r *deleteRecords) Less(i, j int) bool {
+	if r.timestamps[i] != r.timestamps[j] {
+		return r.timestamps[i] < r.timestamps[j]
+	}
+	return r.pks[i].LT(r.pks[j])
+}
+
+func (r *deleteRecords) Swap(i, j int) {
+	r.pks[i], r.pks[j] = r.pks[j], r.pks[i]
+	r.timestamps[i], r.timestamps[j] = r.timestamps[j], r.timestamps[i]
+}
+
+// sortDeleteRecords sorts the delete records by timestamp, then by pk, and removes the exact duplicates of
+// (pk, timestamp), since segcore assumes the deletes of a batch arrive in timestamp order. The sort is done
+// in place. It also returns whether the records were out of timestamp order, which indicates an ordering
+// problem upstream, e.g. after seeking the channel.
+func sortDeleteRecords(pks []primaryKey, timestamps []Timestamp) ([]primaryKey, []Timestamp, bool) {
+	reordered := !isTimestampOrdered(timestamps)
+	records := &deleteRecords{pks: pks, timestamps: timestamps}
+	sort.Stable(records)
+
+	n := 0
+	for i := range pks {
+		if n > 0 && timestamps[i] == timestamps[n-1] && pks[i].EQ(pks[n-1]) {
+			continue
+		}
+		pks[n], timestamps[n] = pks[i], timestamps[i]
+		n++
+	}
+	return pks[:n], timestamps[:n], reordered
+}
+
+// isTimestampOrdered returns whether timestamps are in non-decreasing order
+func isTimestampOrdered(timestamps []Timestamp) bool {
+	return sort.SliceIsSorted(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+}
+
+// sortByTimestamp sorts the delete records of every segment by timestamp and removes the duplicates,
+// it must be called before preDelete since the number of records may shrink
+func (d *deleteData) sortByTimestamp() {
+	for segmentID, pks := range d.deleteIDs {
+		sortedPks, sortedTss, reordered := sortDeleteRecords(pks, d.deleteTimestamps[segmentID])
+		if reordered {
+			metrics.QueryNodeUnorderedDeleteBatches.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Inc()
+			log.Warn("delete records are out of timestamp order, reordered before applying",
+				zap.Int64("segmentID", segmentID),
+				zap.Int("numRecords", len(pks)),
+				zap.Int("numDeduplicated", len(sortedPks)))
+		}
+		d.deleteIDs[segmentID] = sortedPks
+		d.deleteTimestamps[segmentID] = sortedTss
+	}
+}
+
+// applyDeletes applies a batch of deletes to the segment. The batch is sorted by timestamp before the delete records
+// are reserved, and the batches are applied one at a time, so that the delete records of segcore are in timestamp
+// order. The records older than the latest batch applied are dropped and reported with staleDeleteError after the
+// rest of the batch is applied, since a batch of the delta channel may overlap the deletes replayed on load. The
+// records at the last applied timestamp are kept, applying a delete twice is harmless while dropping a different
+// delete of the same timestamp is not. Nothing is dropped if force is set for the deletes known to overlap the ones
+// applied, e.g. the deletes replayed on load.
+func (s *Segment) applyDeletes(pks []primaryKey, timestamps []Timestamp, force bool) error {
+	if len(pks) != len(timestamps) {
+		return errors.New("length of entityIDs not equal to length of timestamps")
+	}
+	if len(pks) == 0 {
+		return nil
+	}
+	pks, timestamps, reordered := sortDeleteRecords(pks, timestamps)
+	if reordered {
+		metrics.QueryNodeUnorderedDeleteBatches.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Inc()
+	}
+
+	s.deleteMu.Lock()
+	defer s.deleteMu.Unlock()
+	var staleErr *staleDeleteError
+	if !force && timestamps[0] < s.lastDeleteTs {
+		staleErr = &staleDeleteError{
+			segmentID:    s.segmentID,
+			timestamp:    timestamps[0],
+			lastDeleteTs: s.lastDeleteTs,
+			staleRecords: sort.Search(len(timestamps), func(i int) bool { return timestamps[i] >= s.lastDeleteTs }),
+		}
+		metrics.QueryNodeStaleDeleteRecords.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Add(float64(staleErr.staleRecords))
+		pks, timestamps = pks[staleErr.staleRecords:], timestamps[staleErr.staleRecords:]
+		if len(pks) == 0 {
+			return staleErr
+		}
+	}
+	err := s.guard(segmentOpDelete, func() error {
+		return cgoWritePool.run(func() error {
+			offset := s.segmentPreDelete(len(pks))
+			return s.segmentDelete(offset, pks, timestamps)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if last := timestamps[len(timestamps)-1]; last > s.lastDeleteTs {
+		s.lastDeleteTs = last
+	}
+	if staleErr != nil {
+		return staleErr
+	}
+	return nil
+}
+
+// getLastDeleteTs returns the latest timestamp of the delete batches applied to the segment
+func (s *Segment) getLastDeleteTs() Timestamp {
+	s.deleteMu.Lock()
+	defer s.deleteMu.Unlock()
+	return s.lastDeleteTs
+}
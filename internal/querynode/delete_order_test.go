@@ -0,0 +1,344 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+func genDeleteRecords(ids []int64, timestamps []Timestamp) ([]primaryKey, []Timestamp) {
+	pks := make([]primaryKey, 0, len(ids))
+	for _, id := range ids {
+		pks = append(pks, newInt64PrimaryKey(id))
+	}
+	return pks, append([]Timestamp{}, timestamps...)
+}
+
+func TestSortDeleteRecords(t *testing.T) {
+	t.Run("ordered", func(t *testing.T) {
+		pks, tss := genDeleteRecords([]int64{3, 1, 2}, []Timestamp{10, 20, 20})
+		sortedPks, sortedTss, reordered := sortDeleteRecords(pks, tss)
+		assert.False(t, reordered)
+		assert.Equal(t, []primaryKey{newInt64PrimaryKey(3), newInt64PrimaryKey(1), newInt64PrimaryKey(2)}, sortedPks)
+		assert.Equal(t, []Timestamp{10, 20, 20}, sortedTss)
+	})
+
+	t.Run("shuffled with duplicates", func(t *testing.T) {
+		pks, tss := genDeleteRecords([]int64{2, 3, 1, 3, 2}, []Timestamp{150, 60, 50, 60, 60})
+		sortedPks, sortedTss, reordered := sortDeleteRecords(pks, tss)
+		assert.True(t, reordered)
+		// (3, 60) is deduplicated, (2, 60) and (2, 150) are different deletes
+		assert.Equal(t, []primaryKey{newInt64PrimaryKey(1), newInt64PrimaryKey(2), newInt64PrimaryKey(3), newInt64PrimaryKey(2)}, sortedPks)
+		assert.Equal(t, []Timestamp{50, 60, 60, 150}, sortedTss)
+	})
+
+	t.Run("random", func(t *testing.T) {
+		const n = 1000
+		ids := make([]int64, n)
+		tss := make([]Timestamp, n)
+		for i := range ids {
+			ids[i] = rand.Int63n(100)
+			tss[i] = Timestamp(rand.Int63n(100))
+		}
+		pks, timestamps := genDeleteRecords(ids, tss)
+		sortedPks, sortedTss, _ := sortDeleteRecords(pks, timestamps)
+		require.Equal(t, len(sortedPks), len(sortedTss))
+
+		unique := make(map[string]struct{})
+		for i := range ids {
+			unique[fmt.Sprintf("%d-%d", ids[i], tss[i])] = struct{}{}
+		}
+		assert.Equal(t, len(unique), len(sortedPks))
+		for i := 1; i < len(sortedTss); i++ {
+			assert.True(t, sortedTss[i-1] < sortedTss[i] || sortedTss[i-1] == sortedTss[i] && sortedPks[i-1].LT(sortedPks[i]))
+		}
+	})
+}
+
+func TestDeleteData_sortByTimestamp(t *testing.T) {
+	unordered := metrics.QueryNodeUnorderedDeleteBatches.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID))
+	before := testutil.ToFloat64(unordered)
+
+	delData := &deleteData{
+		deleteIDs:        map[UniqueID][]primaryKey{},
+		deleteTimestamps: map[UniqueID][]Timestamp{},
+	}
+	delData.deleteIDs[1], delData.deleteTimestamps[1] = genDeleteRecords([]int64{1, 2}, []Timestamp{10, 20})
+	delData.deleteIDs[2], delData.deleteTimestamps[2] = genDeleteRecords([]int64{1, 2, 2}, []Timestamp{20, 10, 10})
+	delData.sortByTimestamp()
+
+	assert.Equal(t, []Timestamp{10, 20}, delData.deleteTimestamps[1])
+	assert.Equal(t, []Timestamp{10, 20}, delData.deleteTimestamps[2])
+	assert.Equal(t, []primaryKey{newInt64PrimaryKey(2), newInt64PrimaryKey(1)}, delData.deleteIDs[2])
+	assert.Equal(t, before+1, testutil.ToFloat64(unordered))
+}
+
+func TestSegment_deleteShuffledBatch(t *testing.T) {
+	// pk 1 is deleted at 50, pk 2 at 150, pk 3 at 60 twice
+	ids := []int64{2, 3, 1, 3}
+	tss := []Timestamp{150, 60, 50, 60}
+
+	retrieveIDs := func(segment *Segment, timestamp Timestamp) []int64 {
+		expr, err := genSimpleRetrievePlanExpr()
+		require.NoError(t, err)
+		plan, err := createRetrievePlanByExpr(newCollection(defaultCollectionID, genSimpleSegCoreSchema()), expr, timestamp)
+		require.NoError(t, err)
+		defer plan.delete()
+		result, err := segment.retrieve(plan)
+		require.NoError(t, err)
+		return result.GetIds().GetIntId().GetData()
+	}
+
+	// ordered application
+	ordered, err := genSimpleSealedSegment()
+	require.NoError(t, err)
+	defer deleteSegment(ordered)
+	pks, timestamps := genDeleteRecords([]int64{1, 3, 2}, []Timestamp{50, 60, 150})
+	offset := ordered.segmentPreDelete(len(pks))
+	require.NoError(t, ordered.segmentDelete(offset, pks, timestamps))
+
+	// shuffled batch through the delta flow graph path
+	shuffled, err := genSimpleSealedSegment()
+	require.NoError(t, err)
+	defer deleteSegment(shuffled)
+	delData := &deleteData{
+		deleteIDs:        map[UniqueID][]primaryKey{},
+		deleteTimestamps: map[UniqueID][]Timestamp{},
+	}
+	delData.deleteIDs[defaultSegmentID], delData.deleteTimestamps[defaultSegmentID] = genDeleteRecords(ids, tss)
+	delData.sortByTimestamp()
+	pks = delData.deleteIDs[defaultSegmentID]
+	offset = shuffled.segmentPreDelete(len(pks))
+	require.NoError(t, shuffled.segmentDelete(offset, pks, delData.deleteTimestamps[defaultSegmentID]))
+
+	// shuffled batch loaded from delta logs
+	loaded, err := genSimpleSealedSegment()
+	require.NoError(t, err)
+	defer deleteSegment(loaded)
+	pks, timestamps = genDeleteRecords(ids, tss)
+	require.NoError(t, loaded.segmentLoadDeletedRecord(pks, timestamps, int64(len(pks))))
+
+	for _, ts := range []Timestamp{40, 55, 100, 200} {
+		expected := retrieveIDs(ordered, ts)
+		assert.ElementsMatch(t, expected, retrieveIDs(shuffled, ts), "ts %d", ts)
+		assert.ElementsMatch(t, expected, retrieveIDs(loaded, ts), "ts %d", ts)
+	}
+	assert.ElementsMatch(t, []int64{2}, retrieveIDs(ordered, 100))
+}
+
+func TestSegmentLoader_loadUnorderedDeltaLogs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+
+	dCodec := storage.DeleteCodec{}
+	genDeltaLog := func(ids []int64, timestamps []Timestamp) []byte {
+		data := &storage.DeleteData{}
+		for i, id := range ids {
+			data.Append(newInt64PrimaryKey(id), timestamps[i])
+		}
+		blob, err := dCodec.Serialize(defaultCollectionID, defaultPartitionID, defaultSegmentID, data)
+		require.NoError(t, err)
+		return blob.GetValue()
+	}
+	deltaLogs := map[string][]byte{
+		"ordered1":  genDeltaLog([]int64{1, 2}, []Timestamp{100, 200}),
+		"ordered2":  genDeltaLog([]int64{3, 4}, []Timestamp{50, 150}),
+		"unordered": genDeltaLog([]int64{5, 6}, []Timestamp{300, 250}),
+	}
+	node.loader.cm = newMockChunkManager(withRead(func(path string) ([]byte, error) {
+		return deltaLogs[path], nil
+	}))
+	load := func(paths ...string) {
+		segment, err := genSimpleSealedSegment()
+		require.NoError(t, err)
+		defer deleteSegment(segment)
+		binlogs := make([]*datapb.Binlog, 0, len(paths))
+		for _, path := range paths {
+			binlogs = append(binlogs, &datapb.Binlog{LogPath: path})
+		}
+		require.NoError(t, node.loader.loadDeltaLogs(segment, []*datapb.FieldBinlog{{Binlogs: binlogs}}, 0))
+	}
+
+	// the logs are ordered within themselves but not across each other, which is expected
+	unordered := metrics.QueryNodeUnorderedDeleteBatches.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID))
+	before := testutil.ToFloat64(unordered)
+	load("ordered1", "ordered2")
+	assert.Equal(t, before, testutil.ToFloat64(unordered))
+
+	load("ordered1", "unordered", "ordered2")
+	assert.Equal(t, before+1, testutil.ToFloat64(unordered))
+}
+
+func TestSegment_applyDeletes(t *testing.T) {
+	node := newQueryNodeMock()
+	defer node.Stop()
+	collection := node.historical.replica.addCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	require.NoError(t, node.historical.replica.addPartition(defaultCollectionID, defaultPartitionID))
+
+	// the rows of pk i are inserted at i
+	genSegment := func(segmentID UniqueID) *Segment {
+		segment, err := genSealedSegment(genSimpleSegCoreSchema(), genSimpleInsertDataSchema(), defaultCollectionID,
+			defaultPartitionID, segmentID, defaultDMLChannel, defaultMsgLength)
+		require.NoError(t, err)
+		require.NoError(t, node.historical.replica.setSegment(segment))
+		return segment
+	}
+	segment1, segment2 := genSegment(1), genSegment(2)
+	retrieveIDs := func(segment *Segment, timestamp Timestamp) []int64 {
+		plan, err := createRetrievePlanByExpr(collection, genRetrievePlanExprWithPredicates(t, genPKRangeExpr(0, 9)), timestamp)
+		require.NoError(t, err)
+		defer plan.delete()
+		result, err := segment.retrieve(plan)
+		require.NoError(t, err)
+		return result.GetIds().GetIntId().GetData()
+	}
+	genVarCharDeleteRecords := func(ids []string, timestamps []Timestamp) ([]primaryKey, []Timestamp) {
+		pks := make([]primaryKey, 0, len(ids))
+		for _, id := range ids {
+			pks = append(pks, newVarCharPrimaryKey(id))
+		}
+		return pks, append([]Timestamp{}, timestamps...)
+	}
+
+	// the batches of the two segments are interleaved, every segment tracks its own last applied delete
+	pks, tss := genDeleteRecords([]int64{2, 3, 1}, []Timestamp{250, 160, 150})
+	require.NoError(t, segment1.applyDeletes(pks, tss, false))
+	assert.Equal(t, Timestamp(250), segment1.getLastDeleteTs())
+
+	// segcore has no delete records of varchar pks, the batch is neither applied nor taken as applied
+	pks, tss = genVarCharDeleteRecords([]string{"b", "a"}, []Timestamp{300, 140})
+	err := segment2.applyDeletes(pks, tss, false)
+	var pkTypeErr *deletePKTypeError
+	require.True(t, errors.As(err, &pkTypeErr))
+	assert.Equal(t, Timestamp(0), segment2.getLastDeleteTs())
+
+	// so the later int64 batches are not rejected as stale against it
+	pks, tss = genDeleteRecords([]int64{5}, []Timestamp{200})
+	require.NoError(t, segment2.applyDeletes(pks, tss, false))
+	assert.Equal(t, Timestamp(200), segment2.getLastDeleteTs())
+
+	// a delete at the last applied timestamp is not stale
+	pks, tss = genDeleteRecords([]int64{4}, []Timestamp{250})
+	require.NoError(t, segment1.applyDeletes(pks, tss, false))
+
+	stale := metrics.QueryNodeStaleDeleteRecords.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID))
+	before := testutil.ToFloat64(stale)
+
+	// the stale records are dropped, the others are applied
+	pks, tss = genDeleteRecords([]int64{7, 6}, []Timestamp{210, 180})
+	err = segment2.applyDeletes(pks, tss, false)
+	var staleErr *staleDeleteError
+	require.True(t, errors.As(err, &staleErr))
+	assert.Equal(t, Timestamp(180), staleErr.timestamp)
+	assert.Equal(t, Timestamp(200), staleErr.lastDeleteTs)
+	assert.Equal(t, 1, staleErr.staleRecords)
+	assert.Equal(t, Timestamp(210), segment2.getLastDeleteTs())
+	assert.Equal(t, before+1, testutil.ToFloat64(stale))
+
+	pks, tss = genDeleteRecords([]int64{9, 8}, []Timestamp{170, 190})
+	err = segment1.applyDeletes(pks, tss, false)
+	require.True(t, errors.As(err, &staleErr))
+	assert.Equal(t, 2, staleErr.staleRecords)
+	assert.Equal(t, Timestamp(250), segment1.getLastDeleteTs())
+	assert.Equal(t, before+3, testutil.ToFloat64(stale))
+
+	// the stale records and the varchar pks don't count as failures of the segment
+	assert.False(t, segment1.isQuarantined())
+	assert.False(t, segment2.isQuarantined())
+
+	// the forced batches are applied whatever their timestamps are, but the varchar pks are not either
+	pks, tss = genVarCharDeleteRecords([]string{"f"}, []Timestamp{400})
+	require.True(t, errors.As(segment1.applyDeletes(pks, tss, true), &pkTypeErr))
+	assert.Equal(t, Timestamp(250), segment1.getLastDeleteTs())
+	pks, tss = genDeleteRecords([]int64{8}, []Timestamp{205})
+	require.NoError(t, segment2.applyDeletes(pks, tss, true))
+	assert.Equal(t, Timestamp(210), segment2.getLastDeleteTs())
+	assert.Equal(t, before+3, testutil.ToFloat64(stale))
+
+	pks, _ = genDeleteRecords([]int64{7}, nil)
+	assert.Error(t, segment1.applyDeletes(pks, nil, false))
+
+	// the rows are deleted by the applied records only
+	assert.ElementsMatch(t, []int64{0, 2, 3, 4, 5, 6, 7, 8, 9}, retrieveIDs(segment1, 155))
+	assert.ElementsMatch(t, []int64{0, 5, 6, 7, 8, 9}, retrieveIDs(segment1, 300))
+	assert.ElementsMatch(t, []int64{0, 1, 2, 3, 4, 6, 9}, retrieveIDs(segment2, 300))
+
+	infos, err := node.historical.replica.getSegmentInfosByColID(defaultCollectionID)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(infos))
+	for _, info := range infos {
+		switch info.GetSegmentID() {
+		case segment1.ID():
+			assert.Equal(t, uint64(250), info.GetLastDeleteTimestamp())
+		case segment2.ID():
+			assert.Equal(t, uint64(210), info.GetLastDeleteTimestamp())
+		}
+	}
+}
+
+func TestSegment_deleteVarCharPKs(t *testing.T) {
+	segment, err := genSimpleSealedSegment()
+	require.NoError(t, err)
+	defer deleteSegment(segment)
+
+	pks := []primaryKey{newVarCharPrimaryKey("1")}
+	timestamps := []Timestamp{200}
+	var pkTypeErr *deletePKTypeError
+	offset := segment.segmentPreDelete(len(pks))
+	assert.True(t, errors.As(segment.segmentDelete(offset, pks, timestamps), &pkTypeErr))
+	assert.True(t, errors.As(segment.segmentLoadDeletedRecord(pks, timestamps, int64(len(pks))), &pkTypeErr))
+	assert.Equal(t, Timestamp(0), segment.getLastDeleteTs())
+	assert.Equal(t, Timestamp(0), segment.appliedDeletes.maxTs)
+}
+
+func TestSegment_applyStraddlingDeletes(t *testing.T) {
+	segment, err := genSimpleSealedSegment()
+	require.NoError(t, err)
+	defer deleteSegment(segment)
+
+	// the deletes replayed on load
+	pks, tss := genDeleteRecords([]int64{1, 2}, []Timestamp{50, 100})
+	require.NoError(t, segment.applyDeletes(pks, tss, true))
+
+	// the batch of the delta channel overlaps the replayed deletes, the delete of pk 3 is newer
+	pks, tss = genDeleteRecords([]int64{3, 2}, []Timestamp{120, 100})
+	require.NoError(t, segment.applyDeletes(pks, tss, false))
+	pks, tss = genDeleteRecords([]int64{3, 1}, []Timestamp{130, 50})
+	err = segment.applyDeletes(pks, tss, false)
+	var staleErr *staleDeleteError
+	require.True(t, errors.As(err, &staleErr))
+	assert.Equal(t, 1, staleErr.staleRecords)
+	assert.Equal(t, Timestamp(130), segment.getLastDeleteTs())
+
+	assert.ElementsMatch(t, []int64{1, 2, 3}, retrieveSimpleIDs(t, segment, 40))
+	assert.ElementsMatch(t, []int64{3}, retrieveSimpleIDs(t, segment, 110))
+	assert.Empty(t, retrieveSimpleIDs(t, segment, 200))
+}
@@ -0,0 +1,261 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const (
+	// deleteSnapshotMagic marks the serialized delete snapshot of a sealed segment
+	deleteSnapshotMagic uint32 = 0x51444C53 // "QDLS"
+	// deleteSnapshotVersion is bumped whenever the serialization changes
+	deleteSnapshotVersion uint32 = 1
+	// deleteSnapshotHeaderSize is the size of magic, version, segmentID, rowCount, maxTs and numDeleted
+	deleteSnapshotHeaderSize = 4 + 4 + 8 + 8 + 8 + 8
+)
+
+// appliedDeletes records the deletes applied to a sealed segment by row offset,
+// it's the source of the delete snapshot exported to other replicas of the segment
+type appliedDeletes struct {
+	mu      sync.RWMutex
+	offsets map[int64]Timestamp // row offset -> the earliest delete timestamp of the row
+	maxTs   Timestamp           // the latest timestamp of all the applied deletes, including those matching no row
+	// no earlier than the latest of the earliest delete timestamps of the deleted rows,
+	// all the deleted rows are deleted at rowsDeletedTs
+	rowsDeletedTs Timestamp
+}
+
+func (d *appliedDeletes) record(offset int64, ts Timestamp) {
+	if d.offsets == nil {
+		d.offsets = make(map[int64]Timestamp)
+	}
+	old, ok := d.offsets[offset]
+	if !ok || ts < old {
+		d.offsets[offset] = ts
+	}
+	// an earlier delete of a deleted row keeps rowsDeletedTs, which stays valid though not the tightest
+	if !ok && ts > d.rowsDeletedTs {
+		d.rowsDeletedTs = ts
+	}
+}
+
+// recordAppliedDeletes tracks the deletes applied to the sealed segment by the pk index,
+// it's a no-op for the segments without pk index, whose deletes could not be exported.
+// A delete no later than the insert of the row doesn't delete it, e.g. a delete replayed before the pk is reinserted
+func (s *Segment) recordAppliedDeletes(pks []primaryKey, timestamps []Timestamp) {
+	if !s.hasPKIndex() {
+		return
+	}
+	s.appliedDeletes.mu.Lock()
+	defer s.appliedDeletes.mu.Unlock()
+	for i, pk := range pks {
+		if timestamps[i] > s.appliedDeletes.maxTs {
+			s.appliedDeletes.maxTs = timestamps[i]
+		}
+		if offset, ok := s.searchPK(pk); ok && timestamps[i] > s.rowInsertTs(offset) {
+			s.appliedDeletes.record(offset, timestamps[i])
+		}
+	}
+}
+
+// isFullyDeletedAt returns whether all the rows of the segment are deleted at ts, so that searching or retrieving
+// the segment at ts returns nothing. The deleted rows are only tracked for the sealed segments with pk index,
+// the other segments are never taken as fully deleted.
+func (s *Segment) isFullyDeletedAt(ts Timestamp) bool {
+	if s.getType() != segmentTypeSealed || !s.hasPKIndex() {
+		return false
+	}
+	rowCount := s.getRowCount()
+	if rowCount <= 0 {
+		return false
+	}
+	s.appliedDeletes.mu.RLock()
+	defer s.appliedDeletes.mu.RUnlock()
+	return int64(len(s.appliedDeletes.offsets)) >= rowCount && s.appliedDeletes.rowsDeletedTs <= ts
+}
+
+// deleteSnapshot is the decoded delete snapshot of a sealed segment
+type deleteSnapshot struct {
+	segmentID UniqueID
+	rowCount  int64
+	maxTs     Timestamp
+	// offsets and timestamps of the deleted rows, in ascending order of offset
+	offsets    []int64
+	timestamps []Timestamp
+}
+
+// marshal serializes the snapshot as the header, a bitmap of one bit per row, then the delete timestamps
+// of the set bits in ascending order of offset, all in little endian
+func (snapshot *deleteSnapshot) marshal() []byte {
+	bitmap := make([]byte, (snapshot.rowCount+7)/8)
+	for _, offset := range snapshot.offsets {
+		bitmap[offset/8] |= 1 << (offset % 8)
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, deleteSnapshotHeaderSize+len(bitmap)+8*len(snapshot.timestamps)))
+	header := []interface{}{
+		deleteSnapshotMagic,
+		deleteSnapshotVersion,
+		snapshot.segmentID,
+		snapshot.rowCount,
+		snapshot.maxTs,
+		int64(len(snapshot.offsets)),
+	}
+	for _, field := range header {
+		// writing to bytes.Buffer never fails
+		_ = binary.Write(buf, binary.LittleEndian, field)
+	}
+	buf.Write(bitmap)
+	_ = binary.Write(buf, binary.LittleEndian, snapshot.timestamps)
+	return buf.Bytes()
+}
+
+func unmarshalDeleteSnapshot(data []byte) (*deleteSnapshot, error) {
+	if len(data) < deleteSnapshotHeaderSize {
+		return nil, fmt.Errorf("delete snapshot is too short, size = %d", len(data))
+	}
+	if magic := binary.LittleEndian.Uint32(data[0:]); magic != deleteSnapshotMagic {
+		return nil, fmt.Errorf("invalid delete snapshot magic %x", magic)
+	}
+	if version := binary.LittleEndian.Uint32(data[4:]); version != deleteSnapshotVersion {
+		return nil, fmt.Errorf("unsupported delete snapshot version %d, expected %d", version, deleteSnapshotVersion)
+	}
+	snapshot := &deleteSnapshot{
+		segmentID: int64(binary.LittleEndian.Uint64(data[8:])),
+		rowCount:  int64(binary.LittleEndian.Uint64(data[16:])),
+		maxTs:     binary.LittleEndian.Uint64(data[24:]),
+	}
+	numDeleted := int64(binary.LittleEndian.Uint64(data[32:]))
+	if snapshot.rowCount < 0 || numDeleted < 0 || numDeleted > snapshot.rowCount {
+		return nil, fmt.Errorf("invalid delete snapshot of segment %d, rowCount = %d, numDeleted = %d",
+			snapshot.segmentID, snapshot.rowCount, numDeleted)
+	}
+	bitmapSize := (snapshot.rowCount + 7) / 8
+	if expected := deleteSnapshotHeaderSize + bitmapSize + 8*numDeleted; int64(len(data)) != expected {
+		return nil, fmt.Errorf("delete snapshot of segment %d is corrupted, size = %d, expected = %d",
+			snapshot.segmentID, len(data), expected)
+	}
+
+	bitmap := data[deleteSnapshotHeaderSize : deleteSnapshotHeaderSize+bitmapSize]
+	tss := data[deleteSnapshotHeaderSize+bitmapSize:]
+	snapshot.offsets = make([]int64, 0, numDeleted)
+	snapshot.timestamps = make([]Timestamp, 0, numDeleted)
+	for offset := int64(0); offset < snapshot.rowCount; offset++ {
+		if bitmap[offset/8]&(1<<(offset%8)) == 0 {
+			continue
+		}
+		if int64(len(snapshot.offsets)) == numDeleted {
+			return nil, fmt.Errorf("delete snapshot of segment %d has more deleted rows than %d", snapshot.segmentID, numDeleted)
+		}
+		snapshot.offsets = append(snapshot.offsets, offset)
+		snapshot.timestamps = append(snapshot.timestamps, binary.LittleEndian.Uint64(tss[8*len(snapshot.timestamps):]))
+	}
+	if int64(len(snapshot.offsets)) != numDeleted {
+		return nil, fmt.Errorf("delete snapshot of segment %d has %d deleted rows, expected %d",
+			snapshot.segmentID, len(snapshot.offsets), numDeleted)
+	}
+	return snapshot, nil
+}
+
+// exportDeleteSnapshot serializes the deletes applied to the sealed segment, the snapshot covers
+// all the deletes of the segment no later than its max timestamp
+func (s *Segment) exportDeleteSnapshot() ([]byte, error) {
+	if s.getType() != segmentTypeSealed {
+		return nil, fmt.Errorf("could not export deletes of segment %d, segment type = %s", s.ID(), s.getType().String())
+	}
+	if !s.hasPKIndex() {
+		return nil, fmt.Errorf("could not export deletes of segment %d without pk index", s.ID())
+	}
+
+	snapshot := &deleteSnapshot{
+		segmentID: s.ID(),
+		rowCount:  s.getRowCount(),
+	}
+	s.appliedDeletes.mu.RLock()
+	snapshot.maxTs = s.appliedDeletes.maxTs
+	snapshot.offsets = make([]int64, 0, len(s.appliedDeletes.offsets))
+	for offset := range s.appliedDeletes.offsets {
+		snapshot.offsets = append(snapshot.offsets, offset)
+	}
+	sort.Slice(snapshot.offsets, func(i, j int) bool { return snapshot.offsets[i] < snapshot.offsets[j] })
+	snapshot.timestamps = make([]Timestamp, len(snapshot.offsets))
+	for i, offset := range snapshot.offsets {
+		snapshot.timestamps[i] = s.appliedDeletes.offsets[offset]
+	}
+	s.appliedDeletes.mu.RUnlock()
+
+	for _, offset := range snapshot.offsets {
+		if offset >= snapshot.rowCount {
+			return nil, fmt.Errorf("deleted offset %d exceeds row count %d of segment %d", offset, snapshot.rowCount, s.ID())
+		}
+	}
+	return snapshot.marshal(), nil
+}
+
+// importDeleteSnapshot applies the delete snapshot exported from another replica to the sealed segment,
+// and returns the max timestamp covered by the snapshot. Nothing is applied if the snapshot mismatches the segment
+func (s *Segment) importDeleteSnapshot(data []byte) (Timestamp, error) {
+	snapshot, err := unmarshalDeleteSnapshot(data)
+	if err != nil {
+		return 0, err
+	}
+	if snapshot.segmentID != s.ID() {
+		return 0, fmt.Errorf("delete snapshot of segment %d mismatches segment %d", snapshot.segmentID, s.ID())
+	}
+	if rowCount := s.getRowCount(); snapshot.rowCount != rowCount {
+		return 0, fmt.Errorf("delete snapshot of segment %d has %d rows, but %d rows are loaded",
+			s.ID(), snapshot.rowCount, rowCount)
+	}
+	if !s.hasPKIndex() {
+		return 0, fmt.Errorf("could not import deletes of segment %d without pk index", s.ID())
+	}
+	if len(snapshot.offsets) > 0 {
+		deleted := make(map[int64]Timestamp, len(snapshot.offsets))
+		for i, offset := range snapshot.offsets {
+			deleted[offset] = snapshot.timestamps[i]
+		}
+		pks := make([]primaryKey, 0, len(snapshot.offsets))
+		timestamps := make([]Timestamp, 0, len(snapshot.offsets))
+		s.pkIndex.walk(func(pk primaryKey, offset int64) {
+			if ts, ok := deleted[offset]; ok {
+				pks = append(pks, pk)
+				timestamps = append(timestamps, ts)
+			}
+		})
+		if len(pks) != len(snapshot.offsets) {
+			return 0, errors.New("deleted offsets of snapshot mismatch the pk index")
+		}
+		// records are walked in pk order, sort them to be applied in timestamp order
+		pks, timestamps, _ = sortDeleteRecords(pks, timestamps)
+		if err := s.segmentLoadDeletedRecord(pks, timestamps, int64(len(pks))); err != nil {
+			return 0, err
+		}
+	}
+
+	s.appliedDeletes.mu.Lock()
+	if snapshot.maxTs > s.appliedDeletes.maxTs {
+		s.appliedDeletes.maxTs = snapshot.maxTs
+	}
+	s.appliedDeletes.mu.Unlock()
+	return snapshot.maxTs, nil
+}
@@ -0,0 +1,286 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// genPKIndexedSealedSegment generates a sealed segment with pk index, the pk of row i is i
+func genPKIndexedSealedSegment(t *testing.T, msgLength int) *Segment {
+	segment, err := genSealedSegmentWithMsgLength(msgLength)
+	require.NoError(t, err)
+	pks := make([]int64, msgLength)
+	for i := range pks {
+		pks[i] = int64(i)
+	}
+	segment.setPKIndex(newInt64PkIndex(pks))
+	return segment
+}
+
+func retrieveSimpleIDs(t *testing.T, segment *Segment, timestamp Timestamp) []int64 {
+	expr, err := genSimpleRetrievePlanExpr()
+	require.NoError(t, err)
+	plan, err := createRetrievePlanByExpr(newCollection(defaultCollectionID, genSimpleSegCoreSchema()), expr, timestamp)
+	require.NoError(t, err)
+	defer plan.delete()
+	result, err := segment.retrieve(plan)
+	require.NoError(t, err)
+	return result.GetIds().GetIntId().GetData()
+}
+
+func TestDeleteSnapshot_marshal(t *testing.T) {
+	snapshot := &deleteSnapshot{
+		segmentID:  defaultSegmentID,
+		rowCount:   20,
+		maxTs:      300,
+		offsets:    []int64{0, 7, 8, 19},
+		timestamps: []Timestamp{100, 200, 150, 300},
+	}
+	data := snapshot.marshal()
+	// header, 3 bytes bitmap and 4 timestamps
+	assert.Equal(t, deleteSnapshotHeaderSize+3+4*8, len(data))
+
+	decoded, err := unmarshalDeleteSnapshot(data)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot, decoded)
+
+	t.Run("empty", func(t *testing.T) {
+		empty := &deleteSnapshot{segmentID: defaultSegmentID, offsets: []int64{}, timestamps: []Timestamp{}}
+		decoded, err := unmarshalDeleteSnapshot(empty.marshal())
+		require.NoError(t, err)
+		assert.Equal(t, empty, decoded)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		corrupt := func(f func(data []byte) []byte) []byte {
+			return f(append([]byte{}, data...))
+		}
+		invalids := map[string][]byte{
+			"too short": data[:deleteSnapshotHeaderSize-1],
+			"magic": corrupt(func(data []byte) []byte {
+				data[0] ^= 0xFF
+				return data
+			}),
+			"version": corrupt(func(data []byte) []byte {
+				binary.LittleEndian.PutUint32(data[4:], deleteSnapshotVersion+1)
+				return data
+			}),
+			"truncated": data[:len(data)-1],
+			"deleted rows more than row count": corrupt(func(data []byte) []byte {
+				binary.LittleEndian.PutUint64(data[32:], 21)
+				return data
+			}),
+			"bitmap mismatches deleted rows": corrupt(func(data []byte) []byte {
+				data[deleteSnapshotHeaderSize] |= 0x02
+				return data
+			}),
+			"bitmap lacks deleted rows": corrupt(func(data []byte) []byte {
+				data[deleteSnapshotHeaderSize] = 0
+				return data
+			}),
+		}
+		for name, invalid := range invalids {
+			_, err := unmarshalDeleteSnapshot(invalid)
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+func TestSegment_exportImportDeleteSnapshot(t *testing.T) {
+	source := genPKIndexedSealedSegment(t, defaultMsgLength)
+	defer deleteSegment(source)
+
+	// deletes loaded from delta logs, then pk 2 deleted from the delta channel,
+	// the deletes of pk 1 are recorded by the earliest timestamp
+	pks, timestamps := genDeleteRecords([]int64{3, 1, 1}, []Timestamp{60, 50, 80})
+	require.NoError(t, source.segmentLoadDeletedRecord(pks, timestamps, int64(len(pks))))
+	pks, timestamps = genDeleteRecords([]int64{2, int64(defaultMsgLength)}, []Timestamp{150, 160})
+	offset := source.segmentPreDelete(len(pks))
+	require.NoError(t, source.segmentDelete(offset, pks, timestamps))
+
+	data, err := source.exportDeleteSnapshot()
+	require.NoError(t, err)
+	snapshot, err := unmarshalDeleteSnapshot(data)
+	require.NoError(t, err)
+	assert.Equal(t, defaultSegmentID, snapshot.segmentID)
+	assert.Equal(t, int64(defaultMsgLength), snapshot.rowCount)
+	// pk out of the segment is not recorded but covered by max ts
+	assert.Equal(t, Timestamp(160), snapshot.maxTs)
+	assert.Equal(t, []int64{1, 2, 3}, snapshot.offsets)
+	assert.Equal(t, []Timestamp{50, 150, 60}, snapshot.timestamps)
+
+	replica := genPKIndexedSealedSegment(t, defaultMsgLength)
+	defer deleteSegment(replica)
+	maxTs, err := replica.importDeleteSnapshot(data)
+	require.NoError(t, err)
+	assert.Equal(t, Timestamp(160), maxTs)
+
+	for _, ts := range []Timestamp{40, 55, 70, 100, 200} {
+		assert.ElementsMatch(t, retrieveSimpleIDs(t, source, ts), retrieveSimpleIDs(t, replica, ts), "ts %d", ts)
+	}
+	assert.Empty(t, retrieveSimpleIDs(t, replica, 200))
+
+	// the imported deletes could be exported again
+	exported, err := replica.exportDeleteSnapshot()
+	require.NoError(t, err)
+	assert.Equal(t, data, exported)
+}
+
+func TestSegment_importDeleteSnapshotMismatch(t *testing.T) {
+	source := genPKIndexedSealedSegment(t, defaultMsgLength)
+	defer deleteSegment(source)
+	pks, timestamps := genDeleteRecords([]int64{1}, []Timestamp{50})
+	require.NoError(t, source.segmentLoadDeletedRecord(pks, timestamps, int64(len(pks))))
+	data, err := source.exportDeleteSnapshot()
+	require.NoError(t, err)
+
+	t.Run("segment mismatch", func(t *testing.T) {
+		replica := genPKIndexedSealedSegment(t, defaultMsgLength)
+		defer deleteSegment(replica)
+		replica.segmentID = defaultSegmentID + 1
+		_, err := replica.importDeleteSnapshot(data)
+		assert.Error(t, err)
+		assert.Equal(t, []int64{1, 2, 3}, retrieveSimpleIDs(t, replica, 100))
+	})
+
+	t.Run("row count mismatch", func(t *testing.T) {
+		replica := genPKIndexedSealedSegment(t, defaultMsgLength*2)
+		defer deleteSegment(replica)
+		_, err := replica.importDeleteSnapshot(data)
+		assert.Error(t, err)
+		assert.Equal(t, []int64{1, 2, 3}, retrieveSimpleIDs(t, replica, 100))
+	})
+
+	t.Run("no pk index", func(t *testing.T) {
+		replica, err := genSimpleSealedSegment()
+		require.NoError(t, err)
+		defer deleteSegment(replica)
+		_, err = replica.importDeleteSnapshot(data)
+		assert.Error(t, err)
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		replica := genPKIndexedSealedSegment(t, defaultMsgLength)
+		defer deleteSegment(replica)
+		invalid := append([]byte{}, data...)
+		binary.LittleEndian.PutUint32(invalid[4:], deleteSnapshotVersion+1)
+		_, err := replica.importDeleteSnapshot(invalid)
+		assert.Error(t, err)
+	})
+}
+
+func TestSegment_exportDeleteSnapshotInvalid(t *testing.T) {
+	t.Run("no pk index", func(t *testing.T) {
+		segment, err := genSimpleSealedSegment()
+		require.NoError(t, err)
+		defer deleteSegment(segment)
+		_, err = segment.exportDeleteSnapshot()
+		assert.Error(t, err)
+	})
+
+	t.Run("growing segment", func(t *testing.T) {
+		segment := genPKIndexedSealedSegment(t, defaultMsgLength)
+		defer deleteSegment(segment)
+		segment.setType(segmentTypeGrowing)
+		_, err := segment.exportDeleteSnapshot()
+		assert.Error(t, err)
+	})
+}
+
+func TestFilterDeletesAfter(t *testing.T) {
+	pks, timestamps := genDeleteRecords([]int64{1, 2, 3, 4}, []Timestamp{50, 100, 150, 100})
+	filteredPks, filteredTss := filterDeletesAfter(pks, timestamps, 100)
+	assert.Equal(t, []primaryKey{newInt64PrimaryKey(3)}, filteredPks)
+	assert.Equal(t, []Timestamp{150}, filteredTss)
+
+	filteredPks, filteredTss = filterDeletesAfter(pks, timestamps, 150)
+	assert.Empty(t, filteredPks)
+	assert.Empty(t, filteredTss)
+}
+
+func TestSegment_isFullyDeletedAt(t *testing.T) {
+	msgLength := 10
+	segment := genPKIndexedSealedSegment(t, msgLength)
+	defer deleteSegment(segment)
+	assert.False(t, segment.isFullyDeletedAt(typeutil.MaxTimestamp))
+
+	// partially deleted, the duplicate deletes and those matching no row are not counted
+	ids := make([]int64, 0, msgLength)
+	tss := make([]Timestamp, 0, msgLength)
+	for i := 0; i < msgLength-1; i++ {
+		ids = append(ids, int64(i))
+		tss = append(tss, 100)
+	}
+	pks, timestamps := genDeleteRecords(append(ids, 0, int64(msgLength)), append(tss, 50, 300))
+	offset := segment.segmentPreDelete(len(pks))
+	require.NoError(t, segment.segmentDelete(offset, pks, timestamps))
+	assert.False(t, segment.isFullyDeletedAt(typeutil.MaxTimestamp))
+
+	pks, timestamps = genDeleteRecords([]int64{int64(msgLength - 1)}, []Timestamp{200})
+	offset = segment.segmentPreDelete(len(pks))
+	require.NoError(t, segment.segmentDelete(offset, pks, timestamps))
+	assert.True(t, segment.isFullyDeletedAt(200))
+	assert.True(t, segment.isFullyDeletedAt(typeutil.MaxTimestamp))
+	assert.Empty(t, retrieveSimpleIDs(t, segment, 200))
+
+	// time travel before the last delete
+	assert.False(t, segment.isFullyDeletedAt(150))
+	assert.False(t, segment.isFullyDeletedAt(0))
+
+	t.Run("no pk index", func(t *testing.T) {
+		segment, err := genSealedSegmentWithMsgLength(msgLength)
+		require.NoError(t, err)
+		defer deleteSegment(segment)
+		pks, timestamps := genDeleteRecords(ids, tss)
+		offset := segment.segmentPreDelete(len(pks))
+		require.NoError(t, segment.segmentDelete(offset, pks, timestamps))
+		assert.False(t, segment.isFullyDeletedAt(typeutil.MaxTimestamp))
+	})
+
+	t.Run("delete before reinsert", func(t *testing.T) {
+		segment := genPKIndexedSealedSegment(t, msgLength)
+		defer deleteSegment(segment)
+		// the last row is reinserted at 500 after its pk was deleted at 200
+		insertTss := make([]Timestamp, msgLength)
+		insertTss[msgLength-1] = 500
+		segment.setInsertTimestamps(insertTss)
+
+		pks, timestamps := genDeleteRecords(append(ids, int64(msgLength-1)), append(tss, 200))
+		require.NoError(t, segment.segmentLoadDeletedRecord(pks, timestamps, int64(len(pks))))
+		assert.False(t, segment.isFullyDeletedAt(typeutil.MaxTimestamp))
+
+		data, err := segment.exportDeleteSnapshot()
+		require.NoError(t, err)
+		snapshot, err := unmarshalDeleteSnapshot(data)
+		require.NoError(t, err)
+		assert.Equal(t, ids, snapshot.offsets)
+		assert.Equal(t, Timestamp(200), snapshot.maxTs)
+	})
+
+	t.Run("growing segment", func(t *testing.T) {
+		segment.setType(segmentTypeGrowing)
+		defer segment.setType(segmentTypeSealed)
+		assert.False(t, segment.isFullyDeletedAt(typeutil.MaxTimestamp))
+	})
+}
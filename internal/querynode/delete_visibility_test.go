@@ -0,0 +1,252 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/flowgraph"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+// visibilityHarness feeds the interleaved insert, delete and tick messages of a channel to an insertNode, and
+// asserts the visibility of pks in the growing segments. The timestamps are given in milliseconds since the start
+// of harness, the inserts and deletes are buffered until the next tick, like the messages of a time tick range.
+type visibilityHarness struct {
+	t       *testing.T
+	replica ReplicaInterface
+	node    *insertNode
+
+	start    time.Time
+	lastTick Timestamp
+	msg      insertMsg
+}
+
+// newVisibilityHarness returns a visibilityHarness keeping the pending deletes for window
+func newVisibilityHarness(t *testing.T, window time.Duration) *visibilityHarness {
+	replica, err := genSimpleReplica()
+	require.NoError(t, err)
+	node := newInsertNode(replica)
+	node.pendingDeletes = newPendingDeletes(window, 1024)
+	t.Cleanup(node.Close)
+
+	start := time.Now()
+	return &visibilityHarness{
+		t:        t,
+		replica:  replica,
+		node:     node,
+		start:    start,
+		lastTick: tsoutil.ComposeTSByTime(start, 0),
+	}
+}
+
+func (h *visibilityHarness) ts(ms int64) Timestamp {
+	return tsoutil.ComposeTSByTime(h.start.Add(time.Duration(ms)*time.Millisecond), 0)
+}
+
+// insert buffers the insert of pks into segment at ms
+func (h *visibilityHarness) insert(segmentID UniqueID, ms int64, pks ...int64) *visibilityHarness {
+	maxPK := int64(0)
+	for _, pk := range pks {
+		if pk > maxPK {
+			maxPK = pk
+		}
+	}
+	// the pk of the i-th row generated is i
+	rows, err := genCommonBlob(int(maxPK)+1, genSimpleSegCoreSchema())
+	require.NoError(h.t, err)
+
+	msg := &msgstream.InsertMsg{
+		BaseMsg: genMsgStreamBaseMsg(),
+		InsertRequest: internalpb.InsertRequest{
+			Base:           genCommonMsgBase(commonpb.MsgType_Insert),
+			CollectionName: defaultCollectionName,
+			PartitionName:  defaultPartitionName,
+			CollectionID:   defaultCollectionID,
+			PartitionID:    defaultPartitionID,
+			SegmentID:      segmentID,
+			ShardName:      defaultDMLChannel,
+		},
+	}
+	for _, pk := range pks {
+		msg.RowIDs = append(msg.RowIDs, pk)
+		msg.Timestamps = append(msg.Timestamps, h.ts(ms))
+		msg.RowData = append(msg.RowData, rows[pk])
+	}
+	h.msg.insertMessages = append(h.msg.insertMessages, msg)
+	return h
+}
+
+// delete buffers the delete of pks from all the partitions at ms
+func (h *visibilityHarness) delete(ms int64, pks ...int64) *visibilityHarness {
+	msg := &msgstream.DeleteMsg{
+		BaseMsg: genMsgStreamBaseMsg(),
+		DeleteRequest: internalpb.DeleteRequest{
+			Base:           genCommonMsgBase(commonpb.MsgType_Delete),
+			CollectionName: defaultCollectionName,
+			CollectionID:   defaultCollectionID,
+			PartitionID:    -1,
+			PrimaryKeys:    &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}},
+			NumRows:        int64(len(pks)),
+		},
+	}
+	for range pks {
+		msg.Timestamps = append(msg.Timestamps, h.ts(ms))
+	}
+	h.msg.deleteMessages = append(h.msg.deleteMessages, msg)
+	return h
+}
+
+// tick feeds the buffered messages to the insertNode with the time range ending at ms
+func (h *visibilityHarness) tick(ms int64) *visibilityHarness {
+	msg := h.msg
+	msg.timeRange = TimeRange{timestampMin: h.lastTick, timestampMax: h.ts(ms)}
+	h.node.Operate([]flowgraph.Msg{&msg})
+	h.lastTick = msg.timeRange.timestampMax
+	h.msg = insertMsg{}
+	return h
+}
+
+// visible returns the pks of pks visible at ms in the growing segments
+func (h *visibilityHarness) visible(ms int64, pks ...int64) []int64 {
+	collection, err := h.replica.getCollectionByID(defaultCollectionID)
+	require.NoError(h.t, err)
+	values := make([]*planpb.GenericValue, 0, len(pks))
+	for _, pk := range pks {
+		values = append(values, &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: pk}})
+	}
+	planExpr, err := proto.Marshal(&planpb.PlanNode{
+		Node: &planpb.PlanNode_Predicates{
+			Predicates: &planpb.Expr{
+				Expr: &planpb.Expr_TermExpr{
+					TermExpr: &planpb.TermExpr{
+						ColumnInfo: &planpb.ColumnInfo{
+							FieldId:  simplePKField.id,
+							DataType: simplePKField.dataType,
+						},
+						Values: values,
+					},
+				},
+			},
+		},
+		OutputFieldIds: []FieldID{simplePKField.id},
+	})
+	require.NoError(h.t, err)
+	plan, err := createRetrievePlanByExpr(collection, planExpr, h.ts(ms))
+	require.NoError(h.t, err)
+	defer plan.delete()
+
+	segmentIDs, err := h.replica.getSegmentIDs(defaultPartitionID)
+	require.NoError(h.t, err)
+	var visible []int64
+	for _, segmentID := range segmentIDs {
+		segment, err := h.replica.getSegmentByID(segmentID)
+		require.NoError(h.t, err)
+		result, err := segment.retrieve(plan)
+		require.NoError(h.t, err)
+		visible = append(visible, result.GetIds().GetIntId().GetData()...)
+	}
+	return visible
+}
+
+func (h *visibilityHarness) assertVisible(ms int64, pks ...int64) {
+	assert.ElementsMatch(h.t, pks, h.visible(ms, pks...), "pks should be visible at %d ms", ms)
+}
+
+func (h *visibilityHarness) assertDeleted(ms int64, pks ...int64) {
+	assert.Empty(h.t, h.visible(ms, pks...), "pks should be deleted at %d ms", ms)
+}
+
+func TestDeleteVisibility(t *testing.T) {
+	const segmentID = UniqueID(1001)
+
+	t.Run("delete after insert", func(t *testing.T) {
+		h := newVisibilityHarness(t, time.Second)
+		h.insert(segmentID, 10, 1, 2, 3).tick(10)
+		h.delete(20, 1).tick(20)
+		h.assertVisible(15, 1, 2, 3)
+		h.assertDeleted(30, 1)
+		h.assertVisible(30, 2, 3)
+		assert.Equal(t, int64(0), h.node.pendingDeletes.len())
+	})
+
+	t.Run("delete and insert of the same range", func(t *testing.T) {
+		h := newVisibilityHarness(t, time.Second)
+		h.insert(segmentID, 10, 1, 2).delete(15, 2).tick(20)
+		h.assertVisible(30, 1)
+		h.assertDeleted(30, 2)
+	})
+
+	t.Run("delete before the insert arrives", func(t *testing.T) {
+		h := newVisibilityHarness(t, time.Second)
+		h.insert(segmentID, 10, 1).tick(10)
+		// the insert of pk 2 at 12 ms is retried, and arrives after the delete
+		h.delete(20, 2).tick(20)
+		assert.Equal(t, int64(1), h.node.pendingDeletes.len())
+		h.insert(segmentID, 12, 2).tick(30)
+		h.assertVisible(15, 1, 2)
+		h.assertDeleted(40, 2)
+		h.assertVisible(40, 1)
+		assert.Equal(t, int64(0), h.node.pendingDeletes.len())
+	})
+
+	t.Run("delete before the first growing segment", func(t *testing.T) {
+		h := newVisibilityHarness(t, time.Second)
+		h.delete(20, 1, 2).tick(20)
+		h.insert(segmentID+1, 10, 1).tick(30)
+		h.insert(segmentID+2, 11, 2, 3).tick(40)
+		h.assertDeleted(50, 1, 2)
+		h.assertVisible(50, 3)
+	})
+
+	t.Run("delete earlier than the insert", func(t *testing.T) {
+		h := newVisibilityHarness(t, time.Second)
+		h.delete(10, 1).tick(10)
+		h.insert(segmentID, 20, 1).tick(20)
+		h.assertVisible(30, 1)
+		// kept for the earlier insert of pk arriving even later
+		assert.Equal(t, int64(1), h.node.pendingDeletes.len())
+		h.tick(2000)
+		assert.Equal(t, int64(0), h.node.pendingDeletes.len())
+	})
+
+	t.Run("insert arrives after the window", func(t *testing.T) {
+		h := newVisibilityHarness(t, time.Second)
+		h.delete(20, 1).tick(20)
+		h.tick(1100)
+		assert.Equal(t, int64(0), h.node.pendingDeletes.len())
+		h.insert(segmentID, 10, 1).tick(1200)
+		h.assertVisible(1300, 1)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		h := newVisibilityHarness(t, 0)
+		h.delete(20, 1).tick(20)
+		h.insert(segmentID, 10, 1).tick(30)
+		h.assertVisible(40, 1)
+	})
+}
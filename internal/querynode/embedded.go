@@ -0,0 +1,476 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/compressor"
+	"github.com/milvus-io/milvus/internal/util/flowgraph"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+)
+
+// embeddedNodeAddress is the address the embedded query node registers in its shard clusters
+const embeddedNodeAddress = "embedded"
+
+// EmbeddedQueryNodeOptions configures an EmbeddedQueryNode
+type EmbeddedQueryNodeOptions struct {
+	// DataPath is the local directory standing in for the object storage, a temporary directory removed on Close
+	// is used if empty
+	DataPath string
+}
+
+// EmbeddedQueryNode is a single query node running in process without etcd, message queue and object storage,
+// for the integration tests of query behaviors. The collections, the sealed segments and the DML, which are fed
+// by the coordinators and the DML channels in a cluster, are fed by its methods instead, while the search and
+// query requests are served by the same shard leader and follower paths as in a cluster.
+//
+// Every collection has a single shard led by the node, Params must be initialized before creating the node.
+type EmbeddedQueryNode struct {
+	node *QueryNode
+
+	dataPath       string
+	removeDataPath bool
+
+	mu           sync.Mutex
+	channels     map[UniqueID]Channel
+	clusters     map[UniqueID]*ShardCluster
+	insertNode   *insertNode
+	deleteNode   *deleteNode
+	serviceTimes map[UniqueID]Timestamp
+}
+
+// NewEmbeddedQueryNode creates and starts an embedded query node
+func NewEmbeddedQueryNode(ctx context.Context, opts EmbeddedQueryNodeOptions) (*EmbeddedQueryNode, error) {
+	config, err := loadQueryNodeConfig(0)
+	if err != nil {
+		return nil, err
+	}
+
+	dataPath, removeDataPath := opts.DataPath, false
+	if dataPath == "" {
+		dataPath, err = ioutil.TempDir(os.TempDir(), "embedded-querynode")
+		if err != nil {
+			return nil, err
+		}
+		removeDataPath = true
+	}
+
+	node := NewQueryNode(ctx, nil)
+	node.config = config
+	node.InitSegcore()
+	node.tSafeReplica = newTSafeReplica()
+	collections := newCollectionRegistry()
+	node.historical = newHistorical(node.queryNodeLoopCtx, newCollectionReplica(nil, config, collections), node.tSafeReplica)
+	node.streaming = newStreaming(node.queryNodeLoopCtx, newCollectionReplica(nil, config, collections), nil, nil, node.tSafeReplica)
+	node.ShardClusterService = &ShardClusterService{node: node}
+	chunkManager := storage.NewLocalChunkManager(storage.RootPath(dataPath))
+	node.queryShardService = newQueryShardServiceWithChunkManagers(node.queryNodeLoopCtx, node.historical, node.streaming,
+		node.ShardClusterService, nil, config, chunkManager, chunkManager, false)
+	node.UpdateStateCode(internalpb.StateCode_Healthy)
+
+	return &EmbeddedQueryNode{
+		node:           node,
+		dataPath:       dataPath,
+		removeDataPath: removeDataPath,
+		channels:       make(map[UniqueID]Channel),
+		clusters:       make(map[UniqueID]*ShardCluster),
+		serviceTimes:   make(map[UniqueID]Timestamp),
+		insertNode:     newInsertNode(node.streaming.replica),
+		deleteNode:     newDeleteNode(node.historical.replica),
+	}, nil
+}
+
+// Close stops the node and releases all the collections
+func (e *EmbeddedQueryNode) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.node.UpdateStateCode(internalpb.StateCode_Abnormal)
+	e.node.queryNodeLoopCancel()
+	for _, cluster := range e.clusters {
+		cluster.Close()
+	}
+	e.node.queryShardService.close()
+	e.node.historical.close()
+	e.node.streaming.close()
+	if e.removeDataPath {
+		return os.RemoveAll(e.dataPath)
+	}
+	return nil
+}
+
+// CreateCollection loads the empty collection of schema with partitions, and makes the node the leader of its
+// single shard
+func (e *EmbeddedQueryNode) CreateCollection(collectionID UniqueID, schema *schemapb.CollectionSchema, partitionIDs ...UniqueID) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.channels[collectionID]; ok {
+		return fmt.Errorf("collection %d already exists", collectionID)
+	}
+
+	channel := fmt.Sprintf("%s_embedded_%dv0", Params.CommonCfg.RootCoordDml, collectionID)
+	deltaChannel, err := funcutil.ConvertChannelName(channel, Params.CommonCfg.RootCoordDml, Params.CommonCfg.RootCoordDelta)
+	if err != nil {
+		return err
+	}
+
+	hCol := e.node.historical.replica.addCollection(collectionID, schema)
+	sCol := e.node.streaming.replica.addCollection(collectionID, schema)
+	for _, partitionID := range partitionIDs {
+		if err := e.node.historical.replica.addPartition(collectionID, partitionID); err != nil {
+			return err
+		}
+		if err := e.node.streaming.replica.addPartition(collectionID, partitionID); err != nil {
+			return err
+		}
+	}
+	for _, col := range []*Collection{hCol, sCol} {
+		col.addVChannels([]Channel{channel})
+		col.addVDeltaChannels([]Channel{deltaChannel})
+		col.setLoadType(loadTypePartition)
+	}
+	e.node.tSafeReplica.addTSafe(channel)
+	e.node.tSafeReplica.addTSafe(deltaChannel)
+
+	cluster := NewShardCluster(collectionID, 0, channel,
+		&embeddedNodeDetector{nodeID: Params.QueryNodeCfg.QueryNodeID}, &embeddedSegmentDetector{},
+		func(nodeID int64, addr string) shardQueryNode {
+			return &shardQueryNodeWrapper{QueryNode: e.node}
+		})
+	e.node.ShardClusterService.clusters.Store(channel, cluster)
+
+	if err := e.node.queryShardService.addQueryShard(collectionID, channel, 0); err != nil {
+		return err
+	}
+	qs, err := e.node.queryShardService.getQueryShard(channel)
+	if err != nil {
+		return err
+	}
+	if err := qs.watchDMLTSafe(); err != nil {
+		return err
+	}
+	if err := qs.watchDeltaTSafe(); err != nil {
+		return err
+	}
+
+	e.channels[collectionID] = channel
+	e.clusters[collectionID] = cluster
+	return nil
+}
+
+// LoadSealedSegment loads the rows of insertData as the sealed segment, e.g. generated by testutil.GenInsertData.
+// The RowID and Timestamp fields are filled with the row offsets and ts if missing.
+func (e *EmbeddedQueryNode) LoadSealedSegment(collectionID, partitionID, segmentID UniqueID, insertData *storage.InsertData, ts Timestamp) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	channel, ok := e.channels[collectionID]
+	if !ok {
+		return fmt.Errorf("collection %d not found", collectionID)
+	}
+	col, err := e.node.historical.replica.getCollectionByID(collectionID)
+	if err != nil {
+		return err
+	}
+	fillSystemFields(insertData, ts)
+	pks, err := getPKsFromInsertData(col, insertData)
+	if err != nil {
+		return err
+	}
+
+	segment, err := newSegment(col, segmentID, partitionID, collectionID, channel, segmentTypeSealed, true)
+	if err != nil {
+		return err
+	}
+	if err := loadSegmentFromInsertData(segment, insertData); err != nil {
+		deleteSegment(segment)
+		return err
+	}
+	segment.updateBloomFilter(pks)
+	if err := e.node.historical.replica.setSegment(segment); err != nil {
+		deleteSegment(segment)
+		return err
+	}
+
+	e.clusters[collectionID].updateSegment(segmentEvent{
+		eventType:   segmentAdd,
+		segmentID:   segmentID,
+		partitionID: partitionID,
+		nodeID:      Params.QueryNodeCfg.QueryNodeID,
+		state:       segmentStateLoaded,
+	})
+	return nil
+}
+
+// Insert applies the rows of insertData at ts to the growing segment, which is created if not exists. The RowID and
+// Timestamp fields are filled with the row offsets and ts if missing, the other fields are ordered by field ID as
+// the schema.
+func (e *EmbeddedQueryNode) Insert(collectionID, partitionID, segmentID UniqueID, insertData *storage.InsertData, ts Timestamp) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	channel, ok := e.channels[collectionID]
+	if !ok {
+		return fmt.Errorf("collection %d not found", collectionID)
+	}
+
+	fillSystemFields(insertData, ts)
+	timestamps, rowIDs, rows, err := storage.TransferColumnBasedInsertDataToRowBased(insertData)
+	if err != nil {
+		return err
+	}
+	msg := &msgstream.InsertMsg{
+		BaseMsg: msgstream.BaseMsg{BeginTimestamp: ts, EndTimestamp: ts, HashValues: make([]uint32, len(rows))},
+		InsertRequest: internalpb.InsertRequest{
+			Base:         &commonpb.MsgBase{MsgType: commonpb.MsgType_Insert, Timestamp: ts},
+			CollectionID: collectionID,
+			PartitionID:  partitionID,
+			SegmentID:    segmentID,
+			ShardName:    channel,
+			Timestamps:   timestamps,
+			RowIDs:       rowIDs,
+			RowData:      rows,
+			Version:      internalpb.InsertDataVersion_RowBased,
+		},
+	}
+	e.insertNode.Operate([]flowgraph.Msg{&insertMsg{
+		insertMessages: []*msgstream.InsertMsg{msg},
+		timeRange:      TimeRange{timestampMin: ts, timestampMax: ts},
+	}})
+	return e.advanceServiceTime(collectionID, ts)
+}
+
+// Delete deletes the entities of pks at ts from both the sealed and the growing segments
+func (e *EmbeddedQueryNode) Delete(collectionID UniqueID, pks *schemapb.IDs, ts Timestamp) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	channel, ok := e.channels[collectionID]
+	if !ok {
+		return fmt.Errorf("collection %d not found", collectionID)
+	}
+
+	numRows := len(storage.ParseIDs2PrimaryKeys(pks))
+	timestamps := make([]Timestamp, numRows)
+	for i := range timestamps {
+		timestamps[i] = ts
+	}
+	msg := &msgstream.DeleteMsg{
+		BaseMsg: msgstream.BaseMsg{BeginTimestamp: ts, EndTimestamp: ts, HashValues: make([]uint32, numRows)},
+		DeleteRequest: internalpb.DeleteRequest{
+			Base:         &commonpb.MsgBase{MsgType: commonpb.MsgType_Delete, Timestamp: ts},
+			CollectionID: collectionID,
+			PartitionID:  -1,
+			ShardName:    channel,
+			PrimaryKeys:  pks,
+			Timestamps:   timestamps,
+			NumRows:      int64(numRows),
+		},
+	}
+	timeRange := TimeRange{timestampMin: ts, timestampMax: ts}
+	e.insertNode.Operate([]flowgraph.Msg{&insertMsg{deleteMessages: []*msgstream.DeleteMsg{msg}, timeRange: timeRange}})
+	e.deleteNode.Operate([]flowgraph.Msg{&deleteMsg{deleteMessages: []*msgstream.DeleteMsg{msg}, timeRange: timeRange}})
+	return e.advanceServiceTime(collectionID, ts)
+}
+
+// advanceServiceTime moves the tSafe of the shard of collection to ts, never backward
+func (e *EmbeddedQueryNode) advanceServiceTime(collectionID UniqueID, ts Timestamp) error {
+	if ts <= e.serviceTimes[collectionID] {
+		return nil
+	}
+	e.serviceTimes[collectionID] = ts
+	channel := e.channels[collectionID]
+	col, err := e.node.historical.replica.getCollectionByID(collectionID)
+	if err != nil {
+		return err
+	}
+	for _, vChannel := range append([]Channel{channel}, col.getVDeltaChannels()...) {
+		if err := e.node.tSafeReplica.setTSafe(vChannel, ts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Search searches the collection as the shard leader, the results are not compressed
+func (e *EmbeddedQueryNode) Search(ctx context.Context, req *internalpb.SearchRequest) (*internalpb.SearchResults, error) {
+	channel, err := e.getChannel(req.GetCollectionID())
+	if err != nil {
+		return nil, err
+	}
+	results, err := e.node.Search(ctx, &querypb.SearchRequest{Req: req, DmlChannel: channel})
+	if err != nil {
+		return nil, err
+	}
+	if results.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return nil, errorOfStatus(results.GetStatus())
+	}
+	if err := compressor.DecompressSearchResults(results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Query queries the collection as the shard leader, the results are not compressed
+func (e *EmbeddedQueryNode) Query(ctx context.Context, req *internalpb.RetrieveRequest) (*internalpb.RetrieveResults, error) {
+	channel, err := e.getChannel(req.GetCollectionID())
+	if err != nil {
+		return nil, err
+	}
+	results, err := e.node.Query(ctx, &querypb.QueryRequest{Req: req, DmlChannel: channel})
+	if err != nil {
+		return nil, err
+	}
+	if results.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return nil, errorOfStatus(results.GetStatus())
+	}
+	if err := compressor.DecompressRetrieveResults(results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (e *EmbeddedQueryNode) getChannel(collectionID UniqueID) (Channel, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	channel, ok := e.channels[collectionID]
+	if !ok {
+		return "", fmt.Errorf("collection %d not found", collectionID)
+	}
+	return channel, nil
+}
+
+// embeddedNodeDetector reports the embedded query node as the only node of its shard clusters
+type embeddedNodeDetector struct {
+	nodeID int64
+}
+
+func (d *embeddedNodeDetector) watchNodes(collectionID int64, replicaID int64, vchannelName string) ([]nodeEvent, <-chan nodeEvent) {
+	return []nodeEvent{{eventType: nodeAdd, nodeID: d.nodeID, nodeAddr: embeddedNodeAddress}}, nil
+}
+
+// embeddedSegmentDetector detects no segment, the sealed segments are added to the shard clusters once loaded
+type embeddedSegmentDetector struct{}
+
+func (d *embeddedSegmentDetector) watchSegments(collectionID int64, replicaID int64, vchannelName string) ([]segmentEvent, <-chan segmentEvent) {
+	return nil, nil
+}
+
+// fillSystemFields fills the RowID and Timestamp fields of insertData with the row offsets and ts if missing
+func fillSystemFields(insertData *storage.InsertData, ts Timestamp) {
+	numRows := 0
+	for _, fieldData := range insertData.Data {
+		numRows = fieldData.RowNum()
+		break
+	}
+	if _, ok := insertData.Data[common.RowIDField]; !ok {
+		rowIDs := make([]int64, numRows)
+		for i := range rowIDs {
+			rowIDs[i] = int64(i)
+		}
+		insertData.Data[common.RowIDField] = &storage.Int64FieldData{NumRows: []int64{int64(numRows)}, Data: rowIDs}
+	}
+	if _, ok := insertData.Data[common.TimeStampField]; !ok {
+		timestamps := make([]int64, numRows)
+		for i := range timestamps {
+			timestamps[i] = int64(ts)
+		}
+		insertData.Data[common.TimeStampField] = &storage.Int64FieldData{NumRows: []int64{int64(numRows)}, Data: timestamps}
+	}
+}
+
+// getPKsFromInsertData returns the primary keys of the rows of insertData
+func getPKsFromInsertData(col *Collection, insertData *storage.InsertData) ([]primaryKey, error) {
+	pkField, err := col.getPKField()
+	if err != nil {
+		return nil, err
+	}
+	var pks []primaryKey
+	switch fieldData := insertData.Data[pkField.ID()].(type) {
+	case *storage.Int64FieldData:
+		for _, pk := range fieldData.Data {
+			pks = append(pks, storage.NewInt64PrimaryKey(pk))
+		}
+	case *storage.StringFieldData:
+		for _, pk := range fieldData.Data {
+			pks = append(pks, storage.NewVarCharPrimaryKey(pk))
+		}
+	default:
+		return nil, fmt.Errorf("invalid data of primary key field %d", pkField.ID())
+	}
+	return pks, nil
+}
+
+// loadSegmentFromInsertData loads the fields of insertData into the sealed segment
+func loadSegmentFromInsertData(segment *Segment, insertData *storage.InsertData) error {
+	for fieldID, v := range insertData.Data {
+		var numRows []int64
+		var data interface{}
+		switch fieldData := v.(type) {
+		case *storage.BoolFieldData:
+			numRows = fieldData.NumRows
+			data = fieldData.Data
+		case *storage.Int8FieldData:
+			numRows = fieldData.NumRows
+			data = fieldData.Data
+		case *storage.Int16FieldData:
+			numRows = fieldData.NumRows
+			data = fieldData.Data
+		case *storage.Int32FieldData:
+			numRows = fieldData.NumRows
+			data = fieldData.Data
+		case *storage.Int64FieldData:
+			numRows = fieldData.NumRows
+			data = fieldData.Data
+		case *storage.FloatFieldData:
+			numRows = fieldData.NumRows
+			data = fieldData.Data
+		case *storage.DoubleFieldData:
+			numRows = fieldData.NumRows
+			data = fieldData.Data
+		case *storage.StringFieldData:
+			numRows = fieldData.NumRows
+			data = fieldData.Data
+		case *storage.FloatVectorFieldData:
+			numRows = fieldData.NumRows
+			data = fieldData.Data
+		case *storage.BinaryVectorFieldData:
+			numRows = fieldData.NumRows
+			data = fieldData.Data
+		default:
+			return errors.New("unexpected field data type")
+		}
+		totalNumRows := int64(0)
+		for _, numRow := range numRows {
+			totalNumRows += numRow
+		}
+		if err := segment.segmentLoadFieldData(fieldID, int(totalNumRows), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+func genEmbeddedRetrieveRequest(t *testing.T, pks ...int64) *internalpb.RetrieveRequest {
+	expr, err := proto.Marshal(&planpb.PlanNode{
+		Node:           &planpb.PlanNode_Predicates{Predicates: genPKTermExpr(pks...)},
+		OutputFieldIds: []int64{simplePKField.id},
+	})
+	require.NoError(t, err)
+	return &internalpb.RetrieveRequest{
+		Base:               genCommonMsgBase(commonpb.MsgType_Retrieve),
+		CollectionID:       defaultCollectionID,
+		PartitionIDs:       []UniqueID{defaultPartitionID},
+		SerializedExprPlan: expr,
+		TravelTimestamp:    Timestamp(1000),
+	}
+}
+
+func getRetrievedPKs(results *internalpb.RetrieveResults) []int64 {
+	pks := append([]int64{}, results.GetIds().GetIntId().GetData()...)
+	sort.Slice(pks, func(i, j int) bool { return pks[i] < pks[j] })
+	return pks
+}
+
+func TestEmbeddedQueryNode(t *testing.T) {
+	ctx := context.Background()
+	node, err := NewEmbeddedQueryNode(ctx, EmbeddedQueryNodeOptions{})
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, node.Close())
+	}()
+
+	err = node.CreateCollection(defaultCollectionID, genSimpleSegCoreSchema(), defaultPartitionID)
+	require.NoError(t, err)
+	err = node.CreateCollection(defaultCollectionID, genSimpleSegCoreSchema(), defaultPartitionID)
+	assert.Error(t, err)
+
+	// pks [0, defaultMsgLength) of the sealed segment
+	sealedData, err := genInsertData(defaultMsgLength, genSimpleInsertDataSchema())
+	require.NoError(t, err)
+	err = node.LoadSealedSegment(defaultCollectionID, defaultPartitionID, defaultSegmentID, sealedData, 100)
+	require.NoError(t, err)
+
+	// pks [defaultMsgLength, 2 * defaultMsgLength) of the growing segment
+	growingData, err := genInsertData(defaultMsgLength, genSimpleSegCoreSchema())
+	require.NoError(t, err)
+	for i := range growingData.Data[simplePKField.id].(*storage.Int64FieldData).Data {
+		growingData.Data[simplePKField.id].(*storage.Int64FieldData).Data[i] += defaultMsgLength
+	}
+	err = node.Insert(defaultCollectionID, defaultPartitionID, defaultSegmentID+1, growingData, 200)
+	require.NoError(t, err)
+	assert.Contains(t, growingData.Data, common.RowIDField)
+	assert.Contains(t, growingData.Data, common.TimeStampField)
+
+	t.Run("query", func(t *testing.T) {
+		results, err := node.Query(ctx, genEmbeddedRetrieveRequest(t, 1, 2, defaultMsgLength+1, 2*defaultMsgLength))
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2, defaultMsgLength + 1}, getRetrievedPKs(results))
+	})
+
+	t.Run("search", func(t *testing.T) {
+		req, err := genSearchRequest(defaultNQ, IndexFaissIDMap)
+		require.NoError(t, err)
+		req.TravelTimestamp = Timestamp(1000)
+		results, err := node.Search(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, int64(defaultNQ), results.GetNumQueries())
+		assert.NotEmpty(t, results.GetSlicedBlob())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := node.Delete(defaultCollectionID, &schemapb.IDs{
+			IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, defaultMsgLength + 1}}},
+		}, 300)
+		require.NoError(t, err)
+
+		results, err := node.Query(ctx, genEmbeddedRetrieveRequest(t, 1, 2, defaultMsgLength+1))
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2}, getRetrievedPKs(results))
+	})
+
+	t.Run("collection not found", func(t *testing.T) {
+		req := genEmbeddedRetrieveRequest(t, 1)
+		req.CollectionID = defaultCollectionID + 1
+		_, err := node.Query(ctx, req)
+		assert.Error(t, err)
+
+		err = node.Insert(defaultCollectionID+1, defaultPartitionID, defaultSegmentID, growingData, 400)
+		assert.Error(t, err)
+		err = node.LoadSealedSegment(defaultCollectionID+1, defaultPartitionID, defaultSegmentID, sealedData, 400)
+		assert.Error(t, err)
+	})
+}
@@ -19,14 +19,448 @@ package querynode
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+var (
+	// ErrStorageUnavailable is the error of the object storage accesses failed fast by the storage breaker
+	ErrStorageUnavailable = errors.New("object storage is unavailable")
+	// ErrSegmentReleased is the error of reading a segment released or not loaded by the query node
+	ErrSegmentReleased = errors.New("segment has been released")
+	// ErrTSafeLagged is the error of a request whose guarantee ts the tSafe of the query node lags behind too far
+	ErrTSafeLagged = errors.New("tSafe lags behind the guarantee ts")
+	// ErrInsufficientMemory is the error of loading more data than the memory of the query node could hold
+	ErrInsufficientMemory = errors.New("insufficient memory")
+	// ErrIndexNotLoaded is the error of a segment whose index is not loaded, and whose raw data is not available
+	ErrIndexNotLoaded = errors.New("index is not loaded")
+	// ErrNodeNotReady is the error of the requests to the query node which is not healthy
+	ErrNodeNotReady = errors.New("query node is not ready")
+	// ErrCursorExpired is the error of resuming a query by a cursor whose snapshot is out of retention,
+	// or whose distribution of the shard has changed since the former page
+	ErrCursorExpired = errors.New("query cursor expired")
 )
 
+// errorCodes maps the typed errors of the query node to the error codes reported to the callers, which decide
+// whether to retry with another replica by the codes rather than the reasons
+var errorCodes = []struct {
+	err  error
+	code commonpb.ErrorCode
+}{
+	{ErrStorageUnavailable, commonpb.ErrorCode_StorageUnavailable},
+	{ErrSegmentReleased, commonpb.ErrorCode_SegmentReleased},
+	{ErrTSafeLagged, commonpb.ErrorCode_TSafeLagged},
+	{ErrInsufficientMemory, commonpb.ErrorCode_OutOfMemory},
+	{ErrIndexNotLoaded, commonpb.ErrorCode_IndexNotExist},
+	{ErrNodeNotReady, commonpb.ErrorCode_NotReadyServe},
+	{ErrCursorExpired, commonpb.ErrorCode_CursorExpired},
+}
+
 // msgQueryNodeIsUnhealthy is the error msg of unhealthy query node
 func msgQueryNodeIsUnhealthy(nodeID UniqueID) string {
-	return fmt.Sprintf("query node %d is not ready", nodeID)
+	return errQueryNodeIsUnhealthy(nodeID).Error()
 }
 
 // errQueryNodeIsUnhealthy is the error of query node is unhealthy
 func errQueryNodeIsUnhealthy(nodeID UniqueID) error {
-	return errors.New(msgQueryNodeIsUnhealthy(nodeID))
+	return fmt.Errorf("%w, nodeID = %d", ErrNodeNotReady, nodeID)
+}
+
+// fieldNotFoundError is the error of a field missing in collection schema
+type fieldNotFoundError struct {
+	collectionID UniqueID
+	field        string
+}
+
+func (e *fieldNotFoundError) Error() string {
+	return fmt.Sprintf("%s not found in collection %d", e.field, e.collectionID)
+}
+
+// errFieldIDNotFound returns a fieldNotFoundError for fieldID
+func errFieldIDNotFound(collectionID UniqueID, fieldID FieldID) error {
+	return &fieldNotFoundError{collectionID: collectionID, field: fmt.Sprintf("field %d", fieldID)}
+}
+
+// errFieldNameNotFound returns a fieldNotFoundError for fieldName
+func errFieldNameNotFound(collectionID UniqueID, fieldName string) error {
+	return &fieldNotFoundError{collectionID: collectionID, field: fmt.Sprintf("field %s", fieldName)}
+}
+
+// errPKFieldNotFound returns a fieldNotFoundError for the primary key field
+func errPKFieldNotFound(collectionID UniqueID) error {
+	return &fieldNotFoundError{collectionID: collectionID, field: "primary key field"}
+}
+
+// snapshotTsExpiredError is the error of a pinned snapshot ts older than the retention boundary
+type snapshotTsExpiredError struct {
+	snapshotTs  Timestamp
+	retentionTs Timestamp
+}
+
+func (e *snapshotTsExpiredError) Error() string {
+	return fmt.Sprintf("snapshot ts %d is older than retention boundary %d", e.snapshotTs, e.retentionTs)
+}
+
+// snapshotTsNotServiceableError is the error of a pinned snapshot ts newer than the current tSafe
+type snapshotTsNotServiceableError struct {
+	snapshotTs Timestamp
+	tSafe      Timestamp
+}
+
+func (e *snapshotTsNotServiceableError) Error() string {
+	return fmt.Sprintf("snapshot ts %d is newer than current tSafe %d", e.snapshotTs, e.tSafe)
+}
+
+// cursorExpiredError is the error of a query cursor which could no longer be resumed on the shard
+type cursorExpiredError struct {
+	channel Channel
+	reason  string
+}
+
+func (e *cursorExpiredError) Error() string {
+	return fmt.Sprintf("query cursor expired on channel %s, %s", e.channel, e.reason)
+}
+
+func (e *cursorExpiredError) Unwrap() error {
+	return ErrCursorExpired
+}
+
+// guaranteeTsTooFarAheadError is the error of a guarantee ts too far ahead of the current tSafe,
+// usually generated from a skewed clock
+type guaranteeTsTooFarAheadError struct {
+	guaranteeTs Timestamp
+	tSafe       Timestamp
+	maxLag      time.Duration
+}
+
+func (e *guaranteeTsTooFarAheadError) Error() string {
+	return fmt.Sprintf("guarantee ts %d is ahead of current tSafe %d by more than %s",
+		e.guaranteeTs, e.tSafe, e.maxLag)
+}
+
+func (e *guaranteeTsTooFarAheadError) Unwrap() error {
+	return ErrTSafeLagged
+}
+
+// pauseDeadlineBudgetError is the error of pausing a channel while a request waiting for its tSafe
+// would time out within the budget
+type pauseDeadlineBudgetError struct {
+	channel  Channel
+	deadline time.Time
+	budget   time.Duration
+}
+
+func (e *pauseDeadlineBudgetError) Error() string {
+	return fmt.Sprintf("cannot pause channel %s, a request waiting for its tSafe would time out in %s, within the budget %s",
+		e.channel, time.Until(e.deadline), e.budget)
+}
+
+// metricTypeMismatchError is the error of searching a segment index with an incompatible metric type
+type metricTypeMismatchError struct {
+	segmentID       UniqueID
+	fieldID         FieldID
+	indexType       string
+	indexMetricType string
+	metricType      string
+}
+
+func (e *metricTypeMismatchError) Error() string {
+	return fmt.Sprintf("metric type %s of search request is incompatible with index %s built with metric type %s, segmentID = %d, fieldID = %d",
+		e.metricType, e.indexType, e.indexMetricType, e.segmentID, e.fieldID)
+}
+
+// binlogLimitExceededError is the error of a retrieve touching more distinct binlog files than allowed
+type binlogLimitExceededError struct {
+	limit int
+}
+
+func (e *binlogLimitExceededError) Error() string {
+	return fmt.Sprintf("retrieve touches more than %d distinct binlog files, please narrow the filter expression or reduce the output vector fields", e.limit)
+}
+
+// scoreNormalizationError is the error of normalizing search scores to cosine similarity which is impossible
+type scoreNormalizationError struct {
+	reason string
+}
+
+func (e *scoreNormalizationError) Error() string {
+	return fmt.Sprintf("cannot normalize search scores: %s", e.reason)
+}
+
+// maskedFieldError is the error of a request outputting the masked fields of a collection rejected by its mask policy
+type maskedFieldError struct {
+	collectionID UniqueID
+	fieldIDs     []FieldID
+}
+
+func (e *maskedFieldError) Error() string {
+	return fmt.Sprintf("fields %v of collection %d are masked and can't be output", e.fieldIDs, e.collectionID)
+}
+
+// partitionWeightError is the error of the invalid partition weights of search
+type partitionWeightError struct {
+	reason string
+}
+
+func (e *partitionWeightError) Error() string {
+	return fmt.Sprintf("invalid partition weights: %s", e.reason)
+}
+
+// staleWatchError is the error of a WatchDmChannels request older than the one the channel is watched with
+type staleWatchError struct {
+	channel Channel
+	version int64
+	current int64
+}
+
+func (e *staleWatchError) Error() string {
+	return fmt.Sprintf("stale watch request of version %d on channel %s, which is watched with version %d",
+		e.version, e.channel, e.current)
+}
+
+// fieldCoercionError is the error of converting the data of a field of retrieve results to the type of the field
+// in the current schema
+type fieldCoercionError struct {
+	fieldID FieldID
+	from    schemapb.DataType
+	to      schemapb.DataType
+	reason  string
+}
+
+func (e *fieldCoercionError) Error() string {
+	return fmt.Sprintf("cannot coerce field %d from %s to %s, %s", e.fieldID, e.from, e.to, e.reason)
+}
+
+// insertPayloadSizeError is the error of the payload of an insert mismatching the size expected by the schema,
+// field is the field whose payload mismatches, or "row" for the rows of row based payloads
+type insertPayloadSizeError struct {
+	collectionID UniqueID
+	field        string
+	expected     int64
+	actual       int64
+}
+
+func (e *insertPayloadSizeError) Error() string {
+	return fmt.Sprintf("payload size of %s mismatches the schema of collection %d, expected %d bytes, actual %d bytes",
+		e.field, e.collectionID, e.expected, e.actual)
+}
+
+// SegcoreError is the error reported by segcore through CStatus, the segment and collection are set
+// when the error is raised while operating on a specific segment
+type SegcoreError struct {
+	code         commonpb.ErrorCode
+	message      string
+	segmentID    UniqueID
+	collectionID UniqueID
+}
+
+func (e *SegcoreError) Error() string {
+	errorName, ok := commonpb.ErrorCode_name[int32(e.code)]
+	if !ok {
+		errorName = "UnknownError"
+	}
+	if e.segmentID == 0 && e.collectionID == 0 {
+		return fmt.Sprintf("[%s] %s", errorName, e.message)
+	}
+	return fmt.Sprintf("[%s] %s, collectionID = %d, segmentID = %d", errorName, e.message, e.collectionID, e.segmentID)
+}
+
+// Code returns the error code reported by segcore
+func (e *SegcoreError) Code() commonpb.ErrorCode {
+	return e.code
+}
+
+// errorCodeOf returns the error code to report in status for err, segcore errors and the errors of the statuses
+// reported by other nodes keep their own code
+func errorCodeOf(err error) commonpb.ErrorCode {
+	var segcoreErr *SegcoreError
+	if errors.As(err, &segcoreErr) && segcoreErr.code != commonpb.ErrorCode_Success {
+		return segcoreErr.code
+	}
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.code != commonpb.ErrorCode_Success {
+		return statusErr.code
+	}
+	for _, mapping := range errorCodes {
+		if errors.Is(err, mapping.err) {
+			return mapping.code
+		}
+	}
+	var unsupportedErr *searchUnsupportedError
+	if errors.As(err, &unsupportedErr) {
+		return commonpb.ErrorCode_IllegalArgument
+	}
+	var transformErr *fieldTransformError
+	if errors.As(err, &transformErr) {
+		return commonpb.ErrorCode_IllegalArgument
+	}
+	var binlogPathErr *binlogPathError
+	if errors.As(err, &binlogPathErr) {
+		return commonpb.ErrorCode_IllegalArgument
+	}
+	var maskedErr *maskedFieldError
+	if errors.As(err, &maskedErr) {
+		return commonpb.ErrorCode_PermissionDenied
+	}
+	var rateLimitedErr *readRateLimitedError
+	if errors.As(err, &rateLimitedErr) {
+		return commonpb.ErrorCode_RateLimit
+	}
+	return commonpb.ErrorCode_UnexpectedError
+}
+
+// toStatus returns the status reporting err, every handler of the query node reports its errors by it so that the
+// error codes are mapped in one place
+func toStatus(err error) *commonpb.Status {
+	if err == nil {
+		return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}
+	}
+	return &commonpb.Status{ErrorCode: errorCodeOf(err), Reason: err.Error()}
+}
+
+// statusError is the error of a failed status reported by another node, e.g. a shard follower
+type statusError struct {
+	code   commonpb.ErrorCode
+	reason string
+}
+
+func (e *statusError) Error() string {
+	return e.reason
+}
+
+// errorOfStatus returns the error of the failed status, which keeps the error code of the status
+func errorOfStatus(status *commonpb.Status) error {
+	return &statusError{code: status.GetErrorCode(), reason: status.GetReason()}
+}
+
+// searchUnsupportedError is the error of searching a scalar-only collection, which has no vector field to search by
+type searchUnsupportedError struct {
+	collectionID UniqueID
+}
+
+func (e *searchUnsupportedError) Error() string {
+	return fmt.Sprintf("search is not supported by collection %d, which has no vector field", e.collectionID)
+}
+
+// insertOffsetError is the error of reserving or inserting a row range of growing segment which would be
+// negative, overflow, or overlap the rows reserved or written before
+type insertOffsetError struct {
+	segmentID UniqueID
+	offset    int64
+	rows      int64
+	reason    string
+}
+
+func (e *insertOffsetError) Error() string {
+	return fmt.Sprintf("invalid insert of %d rows at offset %d into segment %d, %s", e.rows, e.offset, e.segmentID, e.reason)
+}
+
+// staleDeleteError is the error of the delete records older than the latest batch applied to the segment,
+// which are dropped since their tombstones would be misplaced in the delete records of segcore
+type staleDeleteError struct {
+	segmentID    UniqueID
+	timestamp    Timestamp // the oldest timestamp of the batch
+	lastDeleteTs Timestamp
+	staleRecords int
+}
+
+func (e *staleDeleteError) Error() string {
+	return fmt.Sprintf("stale deletes of segment %d, %d records are older than the last applied delete timestamp %d, the oldest is at %d",
+		e.segmentID, e.staleRecords, e.lastDeleteTs, e.timestamp)
+}
+
+// deletePKTypeError is the error of deleting by the primary keys of a type segcore has no delete records for,
+// the deletes are not applied nor recorded as applied
+type deletePKTypeError struct {
+	segmentID UniqueID
+	pkType    schemapb.DataType
+}
+
+func (e *deletePKTypeError) Error() string {
+	return fmt.Sprintf("delete by %s primary keys is not supported by segment %d", e.pkType.String(), e.segmentID)
+}
+
+// segmentQuarantinedError is the error of an operation on a quarantined segment
+type segmentQuarantinedError struct {
+	segmentID UniqueID
+}
+
+func (e *segmentQuarantinedError) Error() string {
+	return fmt.Sprintf("segment %d is quarantined", e.segmentID)
+}
+
+// recordCorruptedError is the error of a torn or corrupted record of record file, the records before offset are intact
+type recordCorruptedError struct {
+	offset int64
+	reason string
+}
+
+func (e *recordCorruptedError) Error() string {
+	return fmt.Sprintf("record file is corrupted at offset %d, %s", e.offset, e.reason)
+}
+
+// recordVersionError is the error of opening a record file of another version for appending
+type recordVersionError struct {
+	path     string
+	expected uint32
+	actual   uint32
+}
+
+func (e *recordVersionError) Error() string {
+	return fmt.Sprintf("version of record file %s is %d, expected %d", e.path, e.actual, e.expected)
+}
+
+// staleIndexError is the error of loading a segment whose index files were garbage collected upstream,
+// and whose raw data could not be loaded instead
+type staleIndexError struct {
+	segmentID UniqueID
+	fieldID   FieldID
+	err       error
+}
+
+func (e *staleIndexError) Error() string {
+	return fmt.Sprintf("index of field %d of segment %d is stale, and raw data is not available: %s", e.fieldID, e.segmentID, e.err)
+}
+
+func (e *staleIndexError) Unwrap() error {
+	return ErrIndexNotLoaded
+}
+
+// fieldTransformError is the error of a field transform of load request not applicable to the field
+type fieldTransformError struct {
+	fieldID   FieldID
+	transform querypb.FieldTransformType
+	reason    string
+}
+
+func (e *fieldTransformError) Error() string {
+	return fmt.Sprintf("cannot apply transform %s to field %d, %s", e.transform, e.fieldID, e.reason)
+}
+
+// binlogPathError is the error of a load request whose binlog paths don't belong to the segments to load
+type binlogPathError struct {
+	collectionID UniqueID
+	paths        []string // the offending paths along with the reasons
+}
+
+func (e *binlogPathError) Error() string {
+	return fmt.Sprintf("binlog paths not belonging to the segments of collection %d: %s",
+		e.collectionID, strings.Join(e.paths, ", "))
+}
+
+// readRateLimitedError is the error of a read request rejected for the queue of the requests waiting for the cap
+// of the concurrent reads of its collection is full, it's retriable
+type readRateLimitedError struct {
+	collectionID UniqueID
+	maxReads     int64
+	queued       int
+}
+
+func (e *readRateLimitedError) Error() string {
+	return fmt.Sprintf("too many concurrent reads of collection %d, %d reads are allowed and %d are waiting, retry later",
+		e.collectionID, e.maxReads, e.queued)
 }
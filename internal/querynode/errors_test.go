@@ -17,9 +17,14 @@
 package querynode
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 )
 
@@ -36,3 +41,80 @@ func TestErrors_ErrQueryNodeIsUnhealthy(t *testing.T) {
 		log.Info("TestErrQueryNodeIsUnhealthy", zap.Error(errQueryNodeIsUnhealthy(nodeID)))
 	}
 }
+
+func TestErrors_SnapshotTs(t *testing.T) {
+	var err error = &snapshotTsExpiredError{snapshotTs: 100, retentionTs: 200}
+	assert.EqualError(t, err, "snapshot ts 100 is older than retention boundary 200")
+
+	err = &snapshotTsNotServiceableError{snapshotTs: 300, tSafe: 200}
+	assert.EqualError(t, err, "snapshot ts 300 is newer than current tSafe 200")
+}
+
+func TestErrors_GuaranteeTsTooFarAhead(t *testing.T) {
+	var err error = &guaranteeTsTooFarAheadError{guaranteeTs: 300, tSafe: 200, maxLag: time.Minute}
+	assert.EqualError(t, err, "guarantee ts 300 is ahead of current tSafe 200 by more than 1m0s")
+}
+
+func TestErrors_SegcoreError(t *testing.T) {
+	var err error = &SegcoreError{code: commonpb.ErrorCode_IllegalArgument, message: "invalid dim 0 of vector field vec"}
+	assert.EqualError(t, err, "[IllegalArgument] invalid dim 0 of vector field vec")
+
+	err = &SegcoreError{code: commonpb.ErrorCode_IllegalArgument, message: "invalid dim 0 of vector field vec", collectionID: 1, segmentID: 2}
+	assert.EqualError(t, err, "[IllegalArgument] invalid dim 0 of vector field vec, collectionID = 1, segmentID = 2")
+
+	err = &SegcoreError{code: commonpb.ErrorCode(-1), message: "unknown"}
+	assert.EqualError(t, err, "[UnknownError] unknown")
+}
+
+func TestErrors_ErrorCodeOf(t *testing.T) {
+	var err error = &SegcoreError{code: commonpb.ErrorCode_IllegalArgument}
+	assert.Equal(t, commonpb.ErrorCode_IllegalArgument, errorCodeOf(err))
+	assert.Equal(t, commonpb.ErrorCode_IllegalArgument, errorCodeOf(fmt.Errorf("load failed: %w", err)))
+
+	assert.Equal(t, commonpb.ErrorCode_IllegalArgument, errorCodeOf(&searchUnsupportedError{collectionID: 1}))
+	assert.Equal(t, commonpb.ErrorCode_IllegalArgument, errorCodeOf(&fieldTransformError{fieldID: 101}))
+	assert.Equal(t, commonpb.ErrorCode_RateLimit, errorCodeOf(&readRateLimitedError{collectionID: 1, maxReads: 2}))
+	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, errorCodeOf(&SegcoreError{}))
+	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, errorCodeOf(errors.New("mock error")))
+
+	// the typed errors map to the documented codes, wrapped or not
+	cases := []struct {
+		err  error
+		code commonpb.ErrorCode
+	}{
+		{ErrStorageUnavailable, commonpb.ErrorCode_StorageUnavailable},
+		{ErrSegmentReleased, commonpb.ErrorCode_SegmentReleased},
+		{ErrTSafeLagged, commonpb.ErrorCode_TSafeLagged},
+		{ErrInsufficientMemory, commonpb.ErrorCode_OutOfMemory},
+		{ErrIndexNotLoaded, commonpb.ErrorCode_IndexNotExist},
+		{ErrNodeNotReady, commonpb.ErrorCode_NotReadyServe},
+		{&guaranteeTsTooFarAheadError{guaranteeTs: 300, tSafe: 200, maxLag: time.Minute}, commonpb.ErrorCode_TSafeLagged},
+		{&staleIndexError{segmentID: 1, fieldID: 101, err: errors.New("mock error")}, commonpb.ErrorCode_IndexNotExist},
+		{errQueryNodeIsUnhealthy(1), commonpb.ErrorCode_NotReadyServe},
+		{&cursorExpiredError{channel: "dml", reason: "the distribution of the shard has changed"}, commonpb.ErrorCode_CursorExpired},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.code, errorCodeOf(c.err), c.err.Error())
+		assert.Equal(t, c.code, errorCodeOf(fmt.Errorf("search failed: %w", c.err)), c.err.Error())
+	}
+
+	// the codes reported by other nodes are kept
+	err = errorOfStatus(&commonpb.Status{ErrorCode: commonpb.ErrorCode_SegmentReleased, Reason: "segment has been released"})
+	assert.EqualError(t, err, "segment has been released")
+	assert.Equal(t, commonpb.ErrorCode_SegmentReleased, errorCodeOf(fmt.Errorf("Search 1 failed, reason %w", err)))
+}
+
+func TestErrors_ToStatus(t *testing.T) {
+	status := toStatus(nil)
+	assert.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+	assert.Empty(t, status.GetReason())
+
+	err := fmt.Errorf("%w, cannot find segment %d in QueryNode", ErrSegmentReleased, 1)
+	status = toStatus(err)
+	assert.Equal(t, commonpb.ErrorCode_SegmentReleased, status.GetErrorCode())
+	assert.Equal(t, "segment has been released, cannot find segment 1 in QueryNode", status.GetReason())
+
+	status = toStatus(errors.New("mock error"))
+	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
+	assert.Equal(t, "mock error", status.GetReason())
+}
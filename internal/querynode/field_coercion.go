@@ -0,0 +1,215 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// parsePlanOutputFields returns the fields of the current schema of col the serialized plan outputs, nil if any of
+// them is not in the schema
+func parsePlanOutputFields(col *Collection, expr []byte) []*collectionField {
+	planNode := &planpb.PlanNode{}
+	if err := proto.Unmarshal(expr, planNode); err != nil {
+		return nil
+	}
+	fields := make([]*collectionField, 0, len(planNode.GetOutputFieldIds()))
+	for _, fieldID := range planNode.GetOutputFieldIds() {
+		field, err := col.getFieldByID(fieldID)
+		if err != nil {
+			return nil
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// coerceFieldsData normalizes the fields data of a retrieve result of rowCount rows to the types of outputFields,
+// so that the results of the segments written with different schemas could be merged. The output fields missing
+// from the result, i.e. added to the schema after the segment was written, are filled with the zero values of
+// their types, and the fields written with a narrower integer or floating type are widened. The fields are
+// ordered as outputFields, followed by the fields of the result not in outputFields if any.
+func coerceFieldsData(outputFields []*collectionField, rowCount int, fieldsData []*schemapb.FieldData) ([]*schemapb.FieldData, error) {
+	if len(outputFields) == 0 || rowCount == 0 {
+		return fieldsData, nil
+	}
+
+	fieldDataByID := make(map[FieldID]*schemapb.FieldData, len(fieldsData))
+	for _, fieldData := range fieldsData {
+		fieldDataByID[fieldData.GetFieldId()] = fieldData
+	}
+	coerced := make([]*schemapb.FieldData, 0, len(fieldsData))
+	for _, field := range outputFields {
+		fieldData, ok := fieldDataByID[field.ID()]
+		if !ok {
+			zero, err := genZeroFieldData(field, rowCount)
+			if err != nil {
+				return nil, err
+			}
+			coerced = append(coerced, zero)
+			continue
+		}
+		delete(fieldDataByID, field.ID())
+		fieldData, err := coerceFieldData(field, fieldData)
+		if err != nil {
+			return nil, err
+		}
+		coerced = append(coerced, fieldData)
+	}
+	for _, fieldData := range fieldsData {
+		if _, ok := fieldDataByID[fieldData.GetFieldId()]; ok {
+			coerced = append(coerced, fieldData)
+		}
+	}
+	return coerced, nil
+}
+
+// integerWidth returns the width order of the integer types, 0 if dataType is not an integer type
+func integerWidth(dataType schemapb.DataType) int {
+	switch dataType {
+	case schemapb.DataType_Int8:
+		return 1
+	case schemapb.DataType_Int16:
+		return 2
+	case schemapb.DataType_Int32:
+		return 3
+	case schemapb.DataType_Int64:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// coerceFieldData converts fieldData to the type of field if it's a widening
+func coerceFieldData(field *collectionField, fieldData *schemapb.FieldData) (*schemapb.FieldData, error) {
+	from, to := fieldData.GetType(), field.schema.GetDataType()
+	if from == to {
+		return fieldData, nil
+	}
+	coercionErr := func(reason string) error {
+		return &fieldCoercionError{fieldID: field.ID(), from: from, to: to, reason: reason}
+	}
+
+	fromWidth, toWidth := integerWidth(from), integerWidth(to)
+	switch {
+	case fromWidth > 0 && toWidth > 0 && fromWidth > toWidth,
+		from == schemapb.DataType_Double && to == schemapb.DataType_Float:
+		return nil, coercionErr("narrowing is not allowed")
+	case fromWidth > 0 && toWidth > 0 && to != schemapb.DataType_Int64:
+		// the integers narrower than int64 share the int32 array
+		return &schemapb.FieldData{
+			Type:      to,
+			FieldName: fieldData.GetFieldName(),
+			FieldId:   fieldData.GetFieldId(),
+			Field:     fieldData.GetField(),
+		}, nil
+	case fromWidth > 0 && to == schemapb.DataType_Int64:
+		src := fieldData.GetScalars().GetIntData().GetData()
+		data := make([]int64, len(src))
+		for i, v := range src {
+			data[i] = int64(v)
+		}
+		return &schemapb.FieldData{
+			Type:      to,
+			FieldName: fieldData.GetFieldName(),
+			FieldId:   fieldData.GetFieldId(),
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: data}},
+				},
+			},
+		}, nil
+	case from == schemapb.DataType_Float && to == schemapb.DataType_Double:
+		src := fieldData.GetScalars().GetFloatData().GetData()
+		data := make([]float64, len(src))
+		for i, v := range src {
+			data[i] = float64(v)
+		}
+		return &schemapb.FieldData{
+			Type:      to,
+			FieldName: fieldData.GetFieldName(),
+			FieldId:   fieldData.GetFieldId(),
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: data}},
+				},
+			},
+		}, nil
+	default:
+		return nil, coercionErr("incompatible types")
+	}
+}
+
+// genZeroFieldData generates rowCount zero values of field
+func genZeroFieldData(field *collectionField, rowCount int) (*schemapb.FieldData, error) {
+	fieldData := &schemapb.FieldData{
+		Type:      field.schema.GetDataType(),
+		FieldName: field.schema.GetName(),
+		FieldId:   field.ID(),
+	}
+	scalars := func(scalarField *schemapb.ScalarField) *schemapb.FieldData_Scalars {
+		return &schemapb.FieldData_Scalars{Scalars: scalarField}
+	}
+	switch field.schema.GetDataType() {
+	case schemapb.DataType_Bool:
+		fieldData.Field = scalars(&schemapb.ScalarField{
+			Data: &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: make([]bool, rowCount)}},
+		})
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		fieldData.Field = scalars(&schemapb.ScalarField{
+			Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: make([]int32, rowCount)}},
+		})
+	case schemapb.DataType_Int64:
+		fieldData.Field = scalars(&schemapb.ScalarField{
+			Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: make([]int64, rowCount)}},
+		})
+	case schemapb.DataType_Float:
+		fieldData.Field = scalars(&schemapb.ScalarField{
+			Data: &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: make([]float32, rowCount)}},
+		})
+	case schemapb.DataType_Double:
+		fieldData.Field = scalars(&schemapb.ScalarField{
+			Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: make([]float64, rowCount)}},
+		})
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		fieldData.Field = scalars(&schemapb.ScalarField{
+			Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: make([]string, rowCount)}},
+		})
+	case schemapb.DataType_FloatVector:
+		fieldData.Field = &schemapb.FieldData_Vectors{
+			Vectors: &schemapb.VectorField{
+				Dim: field.dim,
+				Data: &schemapb.VectorField_FloatVector{
+					FloatVector: &schemapb.FloatArray{Data: make([]float32, int64(rowCount)*field.dim)},
+				},
+			},
+		}
+	case schemapb.DataType_BinaryVector:
+		fieldData.Field = &schemapb.FieldData_Vectors{
+			Vectors: &schemapb.VectorField{
+				Dim:  field.dim,
+				Data: &schemapb.VectorField_BinaryVector{BinaryVector: make([]byte, int64(rowCount)*field.dim/8)},
+			},
+		}
+	default:
+		return nil, &fieldCoercionError{fieldID: field.ID(), to: field.schema.GetDataType(), reason: "missing field of unsupported type"}
+	}
+	return fieldData, nil
+}
@@ -0,0 +1,283 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+)
+
+const coercionFieldID = FieldID(200)
+
+// genCoercionField generates the field of dataType in the current schema
+func genCoercionField(dataType schemapb.DataType, dim int) *collectionField {
+	fieldSchema := &schemapb.FieldSchema{
+		FieldID:  coercionFieldID,
+		Name:     "coerced",
+		DataType: dataType,
+	}
+	if dim > 0 {
+		fieldSchema.TypeParams = []*commonpb.KeyValuePair{{Key: "dim", Value: strconv.Itoa(dim)}}
+	}
+	return newCollectionField(fieldSchema)
+}
+
+// genCoercionFieldData generates the field data of dataType with the rows 1, 2, 3 written by an older segment
+func genCoercionFieldData(dataType schemapb.DataType) *schemapb.FieldData {
+	var fieldData *schemapb.FieldData
+	switch dataType {
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		fieldData = genFieldData("coerced", coercionFieldID, schemapb.DataType_Int32, []int32{1, 2, 3}, 0)
+	case schemapb.DataType_Int64:
+		fieldData = genFieldData("coerced", coercionFieldID, dataType, []int64{1, 2, 3}, 0)
+	case schemapb.DataType_Float:
+		fieldData = genFieldData("coerced", coercionFieldID, dataType, []float32{1, 2, 3}, 0)
+	case schemapb.DataType_Double:
+		fieldData = genFieldData("coerced", coercionFieldID, dataType, []float64{1, 2, 3}, 0)
+	case schemapb.DataType_Bool:
+		fieldData = genFieldData("coerced", coercionFieldID, dataType, []bool{true, false, true}, 0)
+	case schemapb.DataType_VarChar:
+		fieldData = &schemapb.FieldData{
+			FieldId: coercionFieldID,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"1", "2", "3"}}},
+			}},
+		}
+	case schemapb.DataType_FloatVector:
+		fieldData = genFieldData("coerced", coercionFieldID, dataType, make([]float32, 3*defaultDim), defaultDim)
+	case schemapb.DataType_BinaryVector:
+		fieldData = genFieldData("coerced", coercionFieldID, dataType, make([]byte, 3*defaultDim/8), defaultDim)
+	}
+	fieldData.Type = dataType
+	return fieldData
+}
+
+func TestCoerceFieldData(t *testing.T) {
+	widenings := []struct {
+		from, to schemapb.DataType
+	}{
+		{schemapb.DataType_Int8, schemapb.DataType_Int16},
+		{schemapb.DataType_Int8, schemapb.DataType_Int32},
+		{schemapb.DataType_Int8, schemapb.DataType_Int64},
+		{schemapb.DataType_Int16, schemapb.DataType_Int32},
+		{schemapb.DataType_Int16, schemapb.DataType_Int64},
+		{schemapb.DataType_Int32, schemapb.DataType_Int64},
+		{schemapb.DataType_Float, schemapb.DataType_Double},
+	}
+	for _, c := range widenings {
+		t.Run(c.from.String()+" to "+c.to.String(), func(t *testing.T) {
+			coerced, err := coerceFieldData(genCoercionField(c.to, 0), genCoercionFieldData(c.from))
+			require.NoError(t, err)
+			assert.Equal(t, c.to, coerced.GetType())
+			assert.Equal(t, coercionFieldID, coerced.GetFieldId())
+			switch c.to {
+			case schemapb.DataType_Int64:
+				assert.Equal(t, []int64{1, 2, 3}, coerced.GetScalars().GetLongData().GetData())
+			case schemapb.DataType_Double:
+				assert.Equal(t, []float64{1, 2, 3}, coerced.GetScalars().GetDoubleData().GetData())
+			default:
+				assert.Equal(t, []int32{1, 2, 3}, coerced.GetScalars().GetIntData().GetData())
+			}
+		})
+	}
+
+	rejections := []struct {
+		from, to schemapb.DataType
+		reason   string
+	}{
+		{schemapb.DataType_Int64, schemapb.DataType_Int32, "narrowing"},
+		{schemapb.DataType_Int64, schemapb.DataType_Int8, "narrowing"},
+		{schemapb.DataType_Int32, schemapb.DataType_Int16, "narrowing"},
+		{schemapb.DataType_Int16, schemapb.DataType_Int8, "narrowing"},
+		{schemapb.DataType_Double, schemapb.DataType_Float, "narrowing"},
+		{schemapb.DataType_Int32, schemapb.DataType_Float, "incompatible"},
+		{schemapb.DataType_Int64, schemapb.DataType_Double, "incompatible"},
+		{schemapb.DataType_Float, schemapb.DataType_Int64, "incompatible"},
+		{schemapb.DataType_Bool, schemapb.DataType_Int32, "incompatible"},
+		{schemapb.DataType_VarChar, schemapb.DataType_Int64, "incompatible"},
+		{schemapb.DataType_Int64, schemapb.DataType_VarChar, "incompatible"},
+		{schemapb.DataType_FloatVector, schemapb.DataType_BinaryVector, "incompatible"},
+	}
+	for _, c := range rejections {
+		t.Run(c.from.String()+" to "+c.to.String(), func(t *testing.T) {
+			_, err := coerceFieldData(genCoercionField(c.to, defaultDim), genCoercionFieldData(c.from))
+			var coercionErr *fieldCoercionError
+			require.True(t, errors.As(err, &coercionErr))
+			assert.Contains(t, err.Error(), c.reason)
+		})
+	}
+
+	t.Run("same type", func(t *testing.T) {
+		for _, dataType := range []schemapb.DataType{schemapb.DataType_Bool, schemapb.DataType_Int8, schemapb.DataType_Int64,
+			schemapb.DataType_Double, schemapb.DataType_VarChar, schemapb.DataType_FloatVector} {
+			fieldData := genCoercionFieldData(dataType)
+			coerced, err := coerceFieldData(genCoercionField(dataType, defaultDim), fieldData)
+			assert.NoError(t, err)
+			assert.Same(t, fieldData, coerced)
+		}
+	})
+}
+
+func TestGenZeroFieldData(t *testing.T) {
+	const rows = 3
+	for _, dataType := range []schemapb.DataType{schemapb.DataType_Bool, schemapb.DataType_Int8, schemapb.DataType_Int16,
+		schemapb.DataType_Int32, schemapb.DataType_Int64, schemapb.DataType_Float, schemapb.DataType_Double,
+		schemapb.DataType_String, schemapb.DataType_VarChar, schemapb.DataType_FloatVector, schemapb.DataType_BinaryVector} {
+		fieldData, err := genZeroFieldData(genCoercionField(dataType, defaultDim), rows)
+		require.NoError(t, err)
+		assert.Equal(t, dataType, fieldData.GetType())
+		assert.Equal(t, coercionFieldID, fieldData.GetFieldId())
+		assert.Equal(t, "coerced", fieldData.GetFieldName())
+		switch dataType {
+		case schemapb.DataType_FloatVector:
+			assert.Equal(t, make([]float32, rows*defaultDim), fieldData.GetVectors().GetFloatVector().GetData())
+		case schemapb.DataType_BinaryVector:
+			assert.Equal(t, make([]byte, rows*defaultDim/8), fieldData.GetVectors().GetBinaryVector())
+		case schemapb.DataType_Bool:
+			assert.Equal(t, make([]bool, rows), fieldData.GetScalars().GetBoolData().GetData())
+		case schemapb.DataType_Int64:
+			assert.Equal(t, make([]int64, rows), fieldData.GetScalars().GetLongData().GetData())
+		case schemapb.DataType_Float:
+			assert.Equal(t, make([]float32, rows), fieldData.GetScalars().GetFloatData().GetData())
+		case schemapb.DataType_Double:
+			assert.Equal(t, make([]float64, rows), fieldData.GetScalars().GetDoubleData().GetData())
+		case schemapb.DataType_String, schemapb.DataType_VarChar:
+			assert.Equal(t, make([]string, rows), fieldData.GetScalars().GetStringData().GetData())
+		default:
+			assert.Equal(t, make([]int32, rows), fieldData.GetScalars().GetIntData().GetData())
+		}
+	}
+
+	_, err := genZeroFieldData(genCoercionField(schemapb.DataType_None, 0), rows)
+	assert.Error(t, err)
+}
+
+func TestCoerceFieldsData(t *testing.T) {
+	pkField := genCoercionField(schemapb.DataType_Int64, 0)
+	pkField.schema.FieldID = simplePKField.id
+	int64Field := genCoercionField(schemapb.DataType_Int64, 0)
+	addedField := genCoercionField(schemapb.DataType_Double, 0)
+	addedField.schema.FieldID = coercionFieldID + 1
+	outputFields := []*collectionField{pkField, int64Field, addedField}
+
+	pkData := genFieldData("pk", simplePKField.id, schemapb.DataType_Int64, []int64{1, 2, 3}, 0)
+	t.Run("old segment", func(t *testing.T) {
+		// the segment written before the field is widened and added, in a different order
+		coerced, err := coerceFieldsData(outputFields, 3, []*schemapb.FieldData{genCoercionFieldData(schemapb.DataType_Int32), pkData})
+		require.NoError(t, err)
+		require.Len(t, coerced, 3)
+		assert.Same(t, pkData, coerced[0])
+		assert.Equal(t, []int64{1, 2, 3}, coerced[1].GetScalars().GetLongData().GetData())
+		assert.Equal(t, schemapb.DataType_Double, coerced[2].GetType())
+		assert.Equal(t, []float64{0, 0, 0}, coerced[2].GetScalars().GetDoubleData().GetData())
+	})
+
+	t.Run("extra field", func(t *testing.T) {
+		extra := genFieldData("extra", coercionFieldID+2, schemapb.DataType_Bool, []bool{true, true, true}, 0)
+		coerced, err := coerceFieldsData(outputFields[:1], 3, []*schemapb.FieldData{extra, pkData})
+		require.NoError(t, err)
+		assert.Equal(t, []*schemapb.FieldData{pkData, extra}, coerced)
+	})
+
+	t.Run("narrowing", func(t *testing.T) {
+		fieldsData := []*schemapb.FieldData{pkData, genCoercionFieldData(schemapb.DataType_Int64)}
+		_, err := coerceFieldsData([]*collectionField{pkField, genCoercionField(schemapb.DataType_Int32, 0)}, 3, fieldsData)
+		assert.Error(t, err)
+	})
+
+	t.Run("not coerced", func(t *testing.T) {
+		fieldsData := []*schemapb.FieldData{pkData}
+		coerced, err := coerceFieldsData(nil, 3, fieldsData)
+		assert.NoError(t, err)
+		assert.Equal(t, fieldsData, coerced)
+		coerced, err = coerceFieldsData(outputFields, 0, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, coerced)
+	})
+}
+
+func TestMergeRetrieveResults_coerce(t *testing.T) {
+	schema := genSimpleSegCoreSchema()
+	col := newCollection(defaultCollectionID, schema)
+	defer deleteCollection(col)
+	expr, err := proto.Marshal(&planpb.PlanNode{
+		Node:           &planpb.PlanNode_Predicates{Predicates: genPKRangeExpr(0, 10)},
+		OutputFieldIds: []int64{simplePKField.id, simpleConstField.id},
+	})
+	require.NoError(t, err)
+	plan, err := createRetrievePlanByExpr(col, expr, defaultMsgLength)
+	require.NoError(t, err)
+	defer plan.delete()
+	require.Len(t, plan.outputFields, 2)
+
+	// the int32 field is widened to int64 in the current schema, the old segment still returns int32 while the new
+	// segment returns int64
+	for _, field := range schema.GetFields() {
+		if field.GetFieldID() == simpleConstField.id {
+			field.DataType = schemapb.DataType_Int64
+		}
+	}
+	col.updateSchema(schema)
+	plan.outputFields = parsePlanOutputFields(col, expr)
+
+	genIDs := func(ids ...int64) *schemapb.IDs {
+		return &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids}}}
+	}
+	oldResult := &segcorepb.RetrieveResults{
+		Ids:    genIDs(1, 2),
+		Offset: []int64{0, 1},
+		FieldsData: []*schemapb.FieldData{
+			genFieldData("pk", simplePKField.id, schemapb.DataType_Int64, []int64{1, 2}, 0),
+			genFieldData("int32", simpleConstField.id, schemapb.DataType_Int32, []int32{10, 20}, 0),
+		},
+	}
+	newResult := &segcorepb.RetrieveResults{
+		Ids:    genIDs(3),
+		Offset: []int64{0},
+		FieldsData: []*schemapb.FieldData{
+			genFieldData("pk", simplePKField.id, schemapb.DataType_Int64, []int64{3}, 0),
+			genFieldData("int32", simpleConstField.id, schemapb.DataType_Int64, []int64{30}, 0),
+		},
+	}
+	merged, err := mergeSegmentRetrieveResults(plan, []*segcorepb.RetrieveResults{oldResult, newResult})
+	require.NoError(t, err)
+	require.Len(t, merged.GetFieldsData(), 2)
+	assert.Equal(t, schemapb.DataType_Int64, merged.GetFieldsData()[1].GetType())
+	assert.Equal(t, []int64{10, 20, 30}, merged.GetFieldsData()[1].GetScalars().GetLongData().GetData())
+
+	shardResults := []*internalpb.RetrieveResults{
+		{Ids: oldResult.GetIds(), FieldsData: []*schemapb.FieldData{
+			genFieldData("pk", simplePKField.id, schemapb.DataType_Int64, []int64{1, 2}, 0),
+			genFieldData("int32", simpleConstField.id, schemapb.DataType_Int32, []int32{10, 20}, 0),
+		}},
+		{Ids: newResult.GetIds(), FieldsData: newResult.GetFieldsData()},
+	}
+	shardMerged, err := mergeShardRetrieveResults(plan, shardResults)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10, 20, 30}, shardMerged.GetFieldsData()[1].GetScalars().GetLongData().GetData())
+}
@@ -0,0 +1,351 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// histogramBucket is a bucket of equiDepthHistogram, holding the values in [lower, upper]
+type histogramBucket struct {
+	lower    float64
+	upper    float64
+	fraction float64 // estimated fraction of the rows whose values are in the bucket
+	distinct int     // distinct sampled values in the bucket
+}
+
+// equiDepthHistogram approximates the distribution of the values of a numeric field by the buckets holding about
+// the same fraction of rows, built from the values sampled from the segments of a collection
+type equiDepthHistogram struct {
+	fieldID     FieldID
+	buckets     []histogramBucket
+	sampledRows int
+	rowCount    int64 // rows of the segments sampled
+	partial     bool  // some rows to sample were not read before the deadline
+}
+
+// weightedValue is a sampled value standing for weight rows of its segment
+type weightedValue struct {
+	value  float64
+	weight float64
+}
+
+// newEquiDepthHistogram returns the histogram of at most numBuckets buckets of the weighted values,
+// nil if there is no value
+func newEquiDepthHistogram(fieldID FieldID, values []weightedValue, numBuckets int) *equiDepthHistogram {
+	if len(values) == 0 || numBuckets <= 0 {
+		return nil
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].value < values[j].value
+	})
+	var total float64
+	for _, v := range values {
+		total += v.weight
+	}
+	if total <= 0 {
+		return nil
+	}
+
+	h := &equiDepthHistogram{fieldID: fieldID, sampledRows: len(values)}
+	depth := total / float64(numBuckets)
+	var bucket *histogramBucket
+	var acc float64
+	for i, v := range values {
+		if bucket == nil {
+			h.buckets = append(h.buckets, histogramBucket{lower: v.value, upper: v.value})
+			bucket = &h.buckets[len(h.buckets)-1]
+		}
+		if i == 0 || v.value != values[i-1].value || bucket.distinct == 0 {
+			bucket.distinct++
+		}
+		bucket.upper = v.value
+		bucket.fraction += v.weight / total
+		acc += v.weight
+		// close the bucket once it reaches its share of the total weight
+		if acc >= depth*float64(len(h.buckets)) {
+			bucket = nil
+		}
+	}
+	return h
+}
+
+// estimateRange estimates the fraction of rows whose values are in the range from lower to upper, the values in a
+// bucket are taken as evenly distributed in its range
+func (h *equiDepthHistogram) estimateRange(lower float64, lowerInclusive bool, upper float64, upperInclusive bool) float64 {
+	var selectivity float64
+	for _, b := range h.buckets {
+		if b.upper < lower || (b.upper == lower && !lowerInclusive) || b.lower > upper || (b.lower == upper && !upperInclusive) {
+			continue
+		}
+		if b.lower == b.upper {
+			selectivity += b.fraction
+			continue
+		}
+		overlap := (math.Min(upper, b.upper) - math.Max(lower, b.lower)) / (b.upper - b.lower)
+		if overlap <= 0 {
+			// the range touches the bucket at a single value
+			overlap = 1 / float64(b.distinct)
+		}
+		selectivity += b.fraction * math.Min(1, overlap)
+	}
+	return math.Min(1, selectivity)
+}
+
+// estimateEqual estimates the fraction of rows whose values equal value, the distinct values in a bucket are taken
+// as equally frequent
+func (h *equiDepthHistogram) estimateEqual(value float64) float64 {
+	var selectivity float64
+	for _, b := range h.buckets {
+		if value < b.lower || value > b.upper {
+			continue
+		}
+		selectivity += b.fraction / float64(b.distinct)
+	}
+	return math.Min(1, selectivity)
+}
+
+// isHistogramType returns whether the histograms of the fields of dataType can be built
+func isHistogramType(dataType schemapb.DataType) bool {
+	switch dataType {
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32, schemapb.DataType_Int64,
+		schemapb.DataType_Float, schemapb.DataType_Double:
+		return true
+	}
+	return false
+}
+
+// numericValues returns the values of the numeric column as float64
+func numericValues(column *schemapb.FieldData) ([]float64, error) {
+	var values []float64
+	switch column.GetType() {
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		for _, v := range column.GetScalars().GetIntData().GetData() {
+			values = append(values, float64(v))
+		}
+	case schemapb.DataType_Int64:
+		for _, v := range column.GetScalars().GetLongData().GetData() {
+			values = append(values, float64(v))
+		}
+	case schemapb.DataType_Float:
+		for _, v := range column.GetScalars().GetFloatData().GetData() {
+			values = append(values, float64(v))
+		}
+	case schemapb.DataType_Double:
+		values = append(values, column.GetScalars().GetDoubleData().GetData()...)
+	default:
+		return nil, fmt.Errorf("no histogram of field %d of type %s", column.GetFieldId(), column.GetType().String())
+	}
+	return values, nil
+}
+
+// buildFieldHistogram builds the histogram of fieldID from about sampleSize rows sampled from segments, the rows
+// sampled from a segment follow its share of the rows of segments. The segments not sampled before deadline are
+// left out of the histogram, which is flagged as partial then.
+func buildFieldHistogram(fieldID FieldID, segments []*Segment, sampleSize int, numBuckets int, deadline time.Time) (*equiDepthHistogram, error) {
+	rowCounts := make([]int64, len(segments))
+	var total int64
+	for i, segment := range segments {
+		if rowCount := segment.getRowCount(); rowCount > 0 {
+			rowCounts[i] = rowCount
+			total += rowCount
+		}
+	}
+	if total == 0 {
+		return nil, nil
+	}
+
+	var values []weightedValue
+	var rowCount int64
+	var partial bool
+	for i, segment := range segments {
+		if rowCounts[i] == 0 {
+			continue
+		}
+		size := int(math.Ceil(float64(sampleSize) * float64(rowCounts[i]) / float64(total)))
+		sample, err := segment.sampleFieldValues(fieldID, size, deadline)
+		if err != nil {
+			return nil, err
+		}
+		partial = partial || sample.partial
+		if sample.rows == 0 {
+			continue
+		}
+		sampled, err := numericValues(sample.values)
+		if err != nil {
+			return nil, err
+		}
+		// every sampled value stands for the rows of the segment evenly
+		weight := float64(sample.rowCount) / float64(len(sampled))
+		for _, v := range sampled {
+			values = append(values, weightedValue{value: v, weight: weight})
+		}
+		rowCount += sample.rowCount
+	}
+	h := newEquiDepthHistogram(fieldID, values, numBuckets)
+	if h != nil {
+		h.rowCount = rowCount
+		h.partial = partial
+	}
+	return h, nil
+}
+
+// fieldHistogramEntry is a histogram cached by fieldHistogramCache
+type fieldHistogramEntry struct {
+	histogram *equiDepthHistogram // nil if the field has no row or failed to build
+	builtAt   time.Time
+	building  bool
+}
+
+// fieldHistogramCache caches the histograms of the fields of a collection, the zero value is ready to use
+type fieldHistogramCache struct {
+	mu      sync.Mutex
+	entries map[FieldID]*fieldHistogramEntry
+}
+
+// get returns the histogram of fieldID built within ttl, nil if there is none
+func (c *fieldHistogramCache) get(fieldID FieldID, ttl time.Duration) *equiDepthHistogram {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[fieldID]
+	if !ok || entry.builtAt.IsZero() || time.Since(entry.builtAt) >= ttl {
+		return nil
+	}
+	return entry.histogram
+}
+
+// claim returns true if the histogram of fieldID is to be built by the caller, that is, it is expired or never
+// built and no one else is building it. The caller must set the histogram built then.
+func (c *fieldHistogramCache) claim(fieldID FieldID, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[FieldID]*fieldHistogramEntry)
+	}
+	entry, ok := c.entries[fieldID]
+	if !ok {
+		entry = &fieldHistogramEntry{}
+		c.entries[fieldID] = entry
+	}
+	if entry.building || (!entry.builtAt.IsZero() && time.Since(entry.builtAt) < ttl) {
+		return false
+	}
+	entry.building = true
+	return true
+}
+
+// set caches the histogram of fieldID built after claimed. A failed build is cached as no histogram,
+// so that it's not rebuilt until expired.
+func (c *fieldHistogramCache) set(fieldID FieldID, histogram *equiDepthHistogram) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[FieldID]*fieldHistogramEntry)
+	}
+	c.entries[fieldID] = &fieldHistogramEntry{histogram: histogram, builtAt: time.Now()}
+}
+
+// histogramFieldIDs returns the ids of the non-pk numeric fields of the range and term predicates of expr
+func histogramFieldIDs(expr *planpb.Expr) []FieldID {
+	var fieldIDs []FieldID
+	var walk func(expr *planpb.Expr)
+	walk = func(expr *planpb.Expr) {
+		var column *planpb.ColumnInfo
+		switch e := expr.GetExpr().(type) {
+		case *planpb.Expr_BinaryExpr:
+			walk(e.BinaryExpr.GetLeft())
+			walk(e.BinaryExpr.GetRight())
+		case *planpb.Expr_TermExpr:
+			column = e.TermExpr.GetColumnInfo()
+		case *planpb.Expr_UnaryRangeExpr:
+			column = e.UnaryRangeExpr.GetColumnInfo()
+		case *planpb.Expr_BinaryRangeExpr:
+			column = e.BinaryRangeExpr.GetColumnInfo()
+		}
+		if column != nil && !column.GetIsPrimaryKey() && isHistogramType(column.GetDataType()) {
+			fieldIDs = append(fieldIDs, column.GetFieldId())
+		}
+	}
+	walk(expr)
+	return fieldIDs
+}
+
+// getCollectionSegments returns the segments of collection in replica
+func getCollectionSegments(replica ReplicaInterface, collectionID UniqueID) []*Segment {
+	partitionIDs, err := replica.getPartitionIDs(collectionID)
+	if err != nil {
+		return nil
+	}
+	var segments []*Segment
+	for _, partitionID := range partitionIDs {
+		segmentIDs, err := replica.getSegmentIDs(partitionID)
+		if err != nil {
+			continue
+		}
+		for _, segmentID := range segmentIDs {
+			if segment, err := replica.getSegmentByID(segmentID); err == nil {
+				segments = append(segments, segment)
+			}
+		}
+	}
+	return segments
+}
+
+// getFieldHistograms returns the cached histograms of the fields of the predicates of prefilter, and builds the
+// expired ones from the historical and streaming segments of collection in background for the later searches
+func (q *queryShard) getFieldHistograms(collection *Collection, predicates *planpb.Expr) map[FieldID]*equiDepthHistogram {
+	sampleSize := Params.QueryNodeCfg.HistogramSampleSize
+	if sampleSize <= 0 {
+		return nil
+	}
+	ttl := Params.QueryNodeCfg.HistogramTTL
+	histograms := make(map[FieldID]*equiDepthHistogram)
+	for _, fieldID := range histogramFieldIDs(predicates) {
+		if h := collection.histograms.get(fieldID, ttl); h != nil {
+			histograms[fieldID] = h
+			continue
+		}
+		if !collection.histograms.claim(fieldID, ttl) {
+			continue
+		}
+		go func(fieldID FieldID) {
+			segments := append(getCollectionSegments(q.historical.replica, collection.id),
+				getCollectionSegments(q.streaming.replica, collection.id)...)
+			deadline := time.Now().Add(Params.QueryNodeCfg.HistogramBuildTimeout)
+			h, err := buildFieldHistogram(fieldID, segments, int(sampleSize), int(Params.QueryNodeCfg.HistogramBuckets), deadline)
+			if err != nil {
+				log.Warn("failed to build field histogram", zap.Int64("collectionID", collection.id),
+					zap.Int64("fieldID", fieldID), zap.Error(err))
+			} else if h != nil {
+				log.Debug("field histogram built", zap.Int64("collectionID", collection.id), zap.Int64("fieldID", fieldID),
+					zap.Int("sampledRows", h.sampledRows), zap.Int64("rowCount", h.rowCount), zap.Bool("partial", h.partial))
+			}
+			collection.histograms.set(fieldID, h)
+		}(fieldID)
+	}
+	return histograms
+}
@@ -0,0 +1,179 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+func genWeightedValues(values []float64, weight float64) []weightedValue {
+	weighted := make([]weightedValue, 0, len(values))
+	for _, v := range values {
+		weighted = append(weighted, weightedValue{value: v, weight: weight})
+	}
+	return weighted
+}
+
+func TestEquiDepthHistogram(t *testing.T) {
+	assert.Nil(t, newEquiDepthHistogram(simpleConstField.id, nil, 10))
+
+	values := make([]float64, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		values = append(values, float64(i))
+	}
+	h := newEquiDepthHistogram(simpleConstField.id, genWeightedValues(values, 1), 10)
+	require.Len(t, h.buckets, 10)
+	for _, b := range h.buckets {
+		assert.InDelta(t, 0.1, b.fraction, 1e-9)
+		assert.Equal(t, 100, b.distinct)
+	}
+	assert.InDelta(t, 0.1, h.estimateRange(0, true, 99, true), 0.01)
+	assert.InDelta(t, 0.25, h.estimateRange(250, true, 500, false), 0.01)
+	assert.InDelta(t, 1, h.estimateRange(math.Inf(-1), true, math.Inf(1), true), 1e-9)
+	assert.Equal(t, float64(0), h.estimateRange(1000, false, math.Inf(1), true))
+	assert.Equal(t, float64(0), h.estimateRange(2000, true, 3000, true))
+	assert.InDelta(t, 0.001, h.estimateEqual(5), 1e-9)
+	assert.Equal(t, float64(0), h.estimateEqual(-1))
+
+	// the frequent value takes the buckets of its own
+	skewed := make([]float64, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		if i < 500 {
+			skewed = append(skewed, 0)
+		} else {
+			skewed = append(skewed, float64(i))
+		}
+	}
+	h = newEquiDepthHistogram(simpleConstField.id, genWeightedValues(skewed, 1), 10)
+	assert.InDelta(t, 0.5, h.estimateEqual(0), 0.01)
+	assert.InDelta(t, 0.5, h.estimateRange(0, true, 0, true), 0.01)
+	assert.InDelta(t, 0.5, h.estimateRange(0, false, math.Inf(1), true), 0.01)
+
+	// the values stand for the rows by their weights
+	weighted := append(genWeightedValues([]float64{0, 1}, 9), genWeightedValues([]float64{2, 3}, 1)...)
+	h = newEquiDepthHistogram(simpleConstField.id, weighted, 2)
+	assert.InDelta(t, 0.9, h.estimateRange(0, true, 1, true), 1e-9)
+}
+
+func TestBuildFieldHistogram(t *testing.T) {
+	segment1, err := genSealedSegmentWithMsgLength(1000)
+	require.NoError(t, err)
+	defer deleteSegment(segment1)
+	segment2, err := genSealedSegmentWithMsgLength(3000)
+	require.NoError(t, err)
+	defer deleteSegment(segment2)
+	segments := []*Segment{segment1, segment2}
+
+	// a quarter of the rows are in [0, 1000) of both segments, the rest are in [1000, 3000) of segment2
+	h, err := buildFieldHistogram(simpleConstField.id, segments, 2000, 20, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, h)
+	assert.False(t, h.partial)
+	assert.Equal(t, int64(4000), h.rowCount)
+	assert.Equal(t, 2000, h.sampledRows)
+	assert.InDelta(t, 0.5, h.estimateRange(math.Inf(-1), true, 1000, false), 0.05)
+	assert.InDelta(t, 0.25, h.estimateRange(2000, true, math.Inf(1), true), 0.05)
+
+	h, err = buildFieldHistogram(simpleConstField.id, segments, 2000, 20, time.Now().Add(-time.Second))
+	assert.NoError(t, err)
+	assert.Nil(t, h)
+
+	h, err = buildFieldHistogram(simpleConstField.id, nil, 2000, 20, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Nil(t, h)
+
+	_, err = buildFieldHistogram(simpleVecField.id, segments, 2000, 20, time.Now().Add(time.Minute))
+	assert.Error(t, err)
+}
+
+func TestFieldHistogramCache(t *testing.T) {
+	var cache fieldHistogramCache
+	h := &equiDepthHistogram{fieldID: simpleConstField.id}
+	assert.Nil(t, cache.get(simpleConstField.id, time.Minute))
+
+	assert.True(t, cache.claim(simpleConstField.id, time.Minute))
+	// being built
+	assert.False(t, cache.claim(simpleConstField.id, time.Minute))
+	assert.Nil(t, cache.get(simpleConstField.id, time.Minute))
+
+	cache.set(simpleConstField.id, h)
+	assert.Same(t, h, cache.get(simpleConstField.id, time.Minute))
+	assert.False(t, cache.claim(simpleConstField.id, time.Minute))
+
+	// expired
+	assert.Nil(t, cache.get(simpleConstField.id, 0))
+	assert.True(t, cache.claim(simpleConstField.id, 0))
+
+	// a failed build is not retried until expired
+	cache.set(simpleConstField.id, nil)
+	assert.Nil(t, cache.get(simpleConstField.id, time.Minute))
+	assert.False(t, cache.claim(simpleConstField.id, time.Minute))
+}
+
+func genConstFieldRangeExpr(lower, upper int64) *planpb.Expr {
+	return &planpb.Expr{Expr: &planpb.Expr_BinaryRangeExpr{BinaryRangeExpr: &planpb.BinaryRangeExpr{
+		ColumnInfo:     &planpb.ColumnInfo{FieldId: simpleConstField.id, DataType: schemapb.DataType_Int32},
+		LowerInclusive: true,
+		UpperInclusive: true,
+		LowerValue:     &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: lower}},
+		UpperValue:     &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: upper}},
+	}}}
+}
+
+func TestHistogramFieldIDs(t *testing.T) {
+	expr := &planpb.Expr{Expr: &planpb.Expr_BinaryExpr{BinaryExpr: &planpb.BinaryExpr{
+		Op:    planpb.BinaryExpr_LogicalAnd,
+		Left:  genPKRangeExpr(0, 9),
+		Right: genConstFieldRangeExpr(0, 9),
+	}}}
+	assert.Equal(t, []FieldID{simpleConstField.id}, histogramFieldIDs(expr))
+	assert.Empty(t, histogramFieldIDs(genPKRangeExpr(0, 9)))
+	assert.Empty(t, histogramFieldIDs(nil))
+}
+
+func TestQueryShard_getFieldHistograms(t *testing.T) {
+	sampleSize := Params.QueryNodeCfg.HistogramSampleSize
+	defer func() { Params.QueryNodeCfg.HistogramSampleSize = sampleSize }()
+
+	qs, err := genSimpleQueryShard(context.Background())
+	require.NoError(t, err)
+	collection, err := qs.historical.replica.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+	expr := genConstFieldRangeExpr(0, 9)
+
+	Params.QueryNodeCfg.HistogramSampleSize = 0
+	assert.Empty(t, qs.getFieldHistograms(collection, expr))
+
+	// built in background for the later searches
+	Params.QueryNodeCfg.HistogramSampleSize = 10000
+	qs.getFieldHistograms(collection, expr)
+	assert.Eventually(t, func() bool {
+		return len(qs.getFieldHistograms(collection, expr)) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	h := qs.getFieldHistograms(collection, expr)[simpleConstField.id]
+	assert.Equal(t, int64(defaultMsgLength), h.rowCount)
+	assert.InDelta(t, 0.1, h.estimateRange(0, true, 9, true), 0.01)
+}
@@ -0,0 +1,175 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// the policies of the requests naming the masked fields as their output fields
+const (
+	// maskPolicyMask returns the masked fields with zero values
+	maskPolicyMask = "mask"
+	// maskPolicyReject rejects the requests
+	maskPolicyReject = "reject"
+)
+
+// fieldMask is the masked fields of a collection, whose values never leave the query node in the results of
+// search and query. The masked fields could still be filtered on.
+type fieldMask struct {
+	fieldIDs map[FieldID]struct{} // replaced rather than modified once set
+	reject   bool                 // reject the requests outputting the masked fields instead of masking them
+}
+
+func (m fieldMask) isMasked(fieldID FieldID) bool {
+	_, ok := m.fieldIDs[fieldID]
+	return ok
+}
+
+// checkOutputFields returns a maskedFieldError if the masked fields are rejected and requested as output fields
+func (m fieldMask) checkOutputFields(collectionID UniqueID, outputFieldIDs []FieldID) error {
+	if !m.reject {
+		return nil
+	}
+	var masked []FieldID
+	for _, fieldID := range outputFieldIDs {
+		if m.isMasked(fieldID) {
+			masked = append(masked, fieldID)
+		}
+	}
+	if len(masked) > 0 {
+		return &maskedFieldError{collectionID: collectionID, fieldIDs: masked}
+	}
+	return nil
+}
+
+// maskFieldsData replaces the columns of the masked fields by the columns of the zero values of the same rows
+func (m fieldMask) maskFieldsData(fieldsData []*schemapb.FieldData) error {
+	for i, column := range fieldsData {
+		if !m.isMasked(column.GetFieldId()) {
+			continue
+		}
+		masked, err := maskColumn(column)
+		if err != nil {
+			return err
+		}
+		fieldsData[i] = masked
+	}
+	return nil
+}
+
+// maskSearchResults masks the fields data of the sliced blob of results
+func (m fieldMask) maskSearchResults(results *internalpb.SearchResults) error {
+	if len(m.fieldIDs) == 0 || results.GetSlicedBlob() == nil {
+		return nil
+	}
+	data := &schemapb.SearchResultData{}
+	if err := proto.Unmarshal(results.GetSlicedBlob(), data); err != nil {
+		return err
+	}
+	masked := false
+	for _, column := range data.GetFieldsData() {
+		masked = masked || m.isMasked(column.GetFieldId())
+	}
+	if !masked {
+		return nil
+	}
+	if err := m.maskFieldsData(data.FieldsData); err != nil {
+		return err
+	}
+	blob, err := proto.Marshal(data)
+	if err != nil {
+		return err
+	}
+	results.SlicedBlob = blob
+	return nil
+}
+
+// maskColumn returns the column of the zero values of the rows of column, of the same field, data type and dim
+func maskColumn(column *schemapb.FieldData) (*schemapb.FieldData, error) {
+	rows, err := typeutil.GetRowCountOfFieldData(column)
+	if err != nil {
+		return nil, err
+	}
+	builder, err := typeutil.NewColumnBuilderOf(column)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < rows; i++ {
+		switch builder.DataType() {
+		case schemapb.DataType_Bool:
+			err = builder.AppendBool(false)
+		case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+			err = builder.AppendInt(0)
+		case schemapb.DataType_Int64:
+			err = builder.AppendLong(0)
+		case schemapb.DataType_Float:
+			err = builder.AppendFloat(0)
+		case schemapb.DataType_Double:
+			err = builder.AppendDouble(0)
+		case schemapb.DataType_String, schemapb.DataType_VarChar:
+			err = builder.AppendString("")
+		case schemapb.DataType_FloatVector:
+			err = builder.AppendFloatVector(make([]float32, builder.Dim()))
+		case schemapb.DataType_BinaryVector:
+			err = builder.AppendBinaryVector(make([]byte, builder.Dim()/8))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return builder.Build(), nil
+}
+
+// parseMaskedFields returns the ids of the comma separated field names of collection to mask, the primary key
+// field can't be masked since it identifies the rows of the results
+func parseMaskedFields(collection *Collection, value string) ([]FieldID, error) {
+	var fieldIDs []FieldID
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		field, err := collection.getFieldByName(name)
+		if err != nil {
+			return nil, err
+		}
+		if field.schema.GetIsPrimaryKey() {
+			return nil, fmt.Errorf("primary key field %s can't be masked", name)
+		}
+		fieldIDs = append(fieldIDs, field.schema.GetFieldID())
+	}
+	return fieldIDs, nil
+}
+
+// parseMaskPolicy returns whether the policy rejects the requests outputting the masked fields
+func parseMaskPolicy(policy string) (bool, error) {
+	switch policy {
+	case maskPolicyMask:
+		return false, nil
+	case maskPolicyReject:
+		return true, nil
+	}
+	return false, fmt.Errorf("unknown mask policy %s, should be %s or %s", policy, maskPolicyMask, maskPolicyReject)
+}
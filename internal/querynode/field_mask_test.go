@@ -0,0 +1,200 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+func TestMaskColumn(t *testing.T) {
+	columns := []*schemapb.FieldData{
+		{FieldId: 100, FieldName: "bool", Type: schemapb.DataType_Bool, Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: []bool{true, true}}}}}},
+		{FieldId: 101, FieldName: "int", Type: schemapb.DataType_Int32, Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: []int32{1, 2}}}}}},
+		{FieldId: 102, FieldName: "long", Type: schemapb.DataType_Int64, Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2}}}}}},
+		{FieldId: 103, FieldName: "double", Type: schemapb.DataType_Double, Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: []float64{1, 2}}}}}},
+		{FieldId: 104, FieldName: "varchar", Type: schemapb.DataType_VarChar, Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"secret", "secret"}}}}}},
+		{FieldId: 105, FieldName: "vec", Type: schemapb.DataType_FloatVector, Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+			Dim: 2, Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: []float32{1, 2, 3, 4}}}}}},
+		{FieldId: 106, FieldName: "bin", Type: schemapb.DataType_BinaryVector, Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+			Dim: 8, Data: &schemapb.VectorField_BinaryVector{BinaryVector: []byte{0xff, 0xff}}}}},
+	}
+	expected := []*schemapb.FieldData{
+		{FieldId: 100, FieldName: "bool", Type: schemapb.DataType_Bool, Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: []bool{false, false}}}}}},
+		{FieldId: 101, FieldName: "int", Type: schemapb.DataType_Int32, Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: []int32{0, 0}}}}}},
+		{FieldId: 102, FieldName: "long", Type: schemapb.DataType_Int64, Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{0, 0}}}}}},
+		{FieldId: 103, FieldName: "double", Type: schemapb.DataType_Double, Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: []float64{0, 0}}}}}},
+		{FieldId: 104, FieldName: "varchar", Type: schemapb.DataType_VarChar, Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"", ""}}}}}},
+		{FieldId: 105, FieldName: "vec", Type: schemapb.DataType_FloatVector, Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+			Dim: 2, Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: []float32{0, 0, 0, 0}}}}}},
+		{FieldId: 106, FieldName: "bin", Type: schemapb.DataType_BinaryVector, Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+			Dim: 8, Data: &schemapb.VectorField_BinaryVector{BinaryVector: []byte{0, 0}}}}},
+	}
+	for i, column := range columns {
+		masked, err := maskColumn(column)
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(expected[i], masked), masked.String())
+	}
+}
+
+func TestFieldMask(t *testing.T) {
+	genIntColumn := func(fieldID FieldID, data ...int32) *schemapb.FieldData {
+		return &schemapb.FieldData{FieldId: fieldID, Type: schemapb.DataType_Int32, Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: data}}}}}
+	}
+	mask := fieldMask{fieldIDs: map[FieldID]struct{}{101: {}}}
+
+	assert.NoError(t, mask.checkOutputFields(defaultCollectionID, []FieldID{100, 101}))
+	fieldsData := []*schemapb.FieldData{genIntColumn(100, 1, 2), genIntColumn(101, 1, 2)}
+	require.NoError(t, mask.maskFieldsData(fieldsData))
+	assert.True(t, proto.Equal(genIntColumn(100, 1, 2), fieldsData[0]))
+	assert.True(t, proto.Equal(genIntColumn(101, 0, 0), fieldsData[1]))
+
+	t.Run("search results", func(t *testing.T) {
+		data := &schemapb.SearchResultData{NumQueries: 1, TopK: 2, FieldsData: []*schemapb.FieldData{genIntColumn(100, 1, 2), genIntColumn(101, 1, 2)}}
+		blob, err := proto.Marshal(data)
+		require.NoError(t, err)
+		results := &internalpb.SearchResults{SlicedBlob: blob}
+		require.NoError(t, mask.maskSearchResults(results))
+		masked := &schemapb.SearchResultData{}
+		require.NoError(t, proto.Unmarshal(results.GetSlicedBlob(), masked))
+		data.FieldsData[1] = genIntColumn(101, 0, 0)
+		assert.True(t, proto.Equal(data, masked))
+
+		// the results without masked fields are kept intact
+		unmasked := &internalpb.SearchResults{SlicedBlob: blob}
+		require.NoError(t, fieldMask{}.maskSearchResults(unmasked))
+		assert.Equal(t, blob, unmasked.GetSlicedBlob())
+		require.NoError(t, mask.maskSearchResults(&internalpb.SearchResults{}))
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		mask.reject = true
+		assert.NoError(t, mask.checkOutputFields(defaultCollectionID, []FieldID{100}))
+		err := mask.checkOutputFields(defaultCollectionID, []FieldID{100, 101})
+		var maskedErr *maskedFieldError
+		require.ErrorAs(t, err, &maskedErr)
+		assert.Equal(t, []FieldID{101}, maskedErr.fieldIDs)
+		assert.Equal(t, commonpb.ErrorCode_PermissionDenied, errorCodeOf(err))
+	})
+}
+
+func TestParseMaskedFields(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	defer deleteCollection(collection)
+
+	fieldIDs, err := parseMaskedFields(collection, " "+defaultConstFieldName+" ,")
+	require.NoError(t, err)
+	assert.Equal(t, []FieldID{simpleConstField.id}, fieldIDs)
+	fieldIDs, err = parseMaskedFields(collection, "")
+	require.NoError(t, err)
+	assert.Empty(t, fieldIDs)
+
+	_, err = parseMaskedFields(collection, "unknown")
+	assert.Error(t, err)
+	_, err = parseMaskedFields(collection, defaultPKFieldName)
+	assert.Error(t, err)
+
+	reject, err := parseMaskPolicy(maskPolicyReject)
+	require.NoError(t, err)
+	assert.True(t, reject)
+	reject, err = parseMaskPolicy(maskPolicyMask)
+	require.NoError(t, err)
+	assert.False(t, reject)
+	_, err = parseMaskPolicy("drop")
+	assert.Error(t, err)
+}
+
+func TestQueryShard_queryMaskedFields(t *testing.T) {
+	qs, err := genSimpleQueryShard(context.Background())
+	require.NoError(t, err)
+	for _, replica := range []ReplicaInterface{qs.historical.replica, qs.streaming.replica} {
+		collection, err := replica.getCollectionByID(defaultCollectionID)
+		require.NoError(t, err)
+		collection.setMaskedFields([]FieldID{simpleConstField.id})
+	}
+
+	// filter on the masked field, whose values are the pks of the rows
+	expr, err := proto.Marshal(&planpb.PlanNode{
+		Node: &planpb.PlanNode_Predicates{Predicates: &planpb.Expr{Expr: &planpb.Expr_TermExpr{TermExpr: &planpb.TermExpr{
+			ColumnInfo: &planpb.ColumnInfo{FieldId: simpleConstField.id, DataType: simpleConstField.dataType},
+			Values: []*planpb.GenericValue{
+				{Val: &planpb.GenericValue_Int64Val{Int64Val: 1}},
+				{Val: &planpb.GenericValue_Int64Val{Int64Val: 2}},
+			},
+		}}}},
+		OutputFieldIds: []int64{simplePKField.id, simpleConstField.id},
+	})
+	require.NoError(t, err)
+	req, err := genSimpleRetrieveRequest()
+	require.NoError(t, err)
+	req.SerializedExprPlan = expr
+	req.OutputFieldsId = []int64{simplePKField.id, simpleConstField.id}
+	request := &querypb.QueryRequest{Req: req, SegmentIDs: []int64{defaultSegmentID}}
+
+	resp, err := qs.query(context.Background(), request)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{1, 2}, resp.GetIds().GetIntId().GetData())
+	require.Len(t, resp.GetFieldsData(), 2)
+	for _, column := range resp.GetFieldsData() {
+		rows, err := typeutil.GetRowCountOfFieldData(column)
+		require.NoError(t, err)
+		assert.Equal(t, 2, rows)
+		if column.GetFieldId() == simpleConstField.id {
+			assert.Equal(t, []int32{0, 0}, column.GetScalars().GetIntData().GetData())
+		} else {
+			assert.ElementsMatch(t, []int64{1, 2}, column.GetScalars().GetLongData().GetData())
+		}
+	}
+
+	t.Run("reject", func(t *testing.T) {
+		collection, err := qs.streaming.replica.getCollectionByID(defaultCollectionID)
+		require.NoError(t, err)
+		collection.setMaskReject(true)
+		defer collection.setMaskReject(false)
+
+		_, err = qs.query(context.Background(), request)
+		assert.Equal(t, commonpb.ErrorCode_PermissionDenied, errorCodeOf(err))
+
+		// filtering on the masked field is still allowed
+		req.OutputFieldsId = []int64{simplePKField.id}
+		resp, err := qs.query(context.Background(), request)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int64{1, 2}, resp.GetIds().GetIntId().GetData())
+	})
+}
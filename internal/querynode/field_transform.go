@@ -0,0 +1,131 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"math"
+	"strings"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// checkFieldTransforms checks the transforms of a load request against the schema of collection. The primary key
+// is never transformed for deletes locate rows by it, and neither are the indexed fields of the segments to load,
+// whose indexes are built on the untransformed data
+func checkFieldTransforms(collection *Collection, transforms []*querypb.FieldTransform, infos []*querypb.SegmentLoadInfo) error {
+	if len(transforms) == 0 {
+		return nil
+	}
+	indexedFields := make(map[FieldID]struct{})
+	for _, info := range infos {
+		for _, indexInfo := range info.GetIndexInfos() {
+			indexedFields[indexInfo.GetFieldID()] = struct{}{}
+		}
+	}
+	for _, transform := range transforms {
+		field, err := collection.getFieldByID(transform.GetFieldID())
+		if err != nil {
+			return err
+		}
+		newErr := func(reason string) error {
+			return &fieldTransformError{fieldID: transform.GetFieldID(), transform: transform.GetType(), reason: reason}
+		}
+		if field.schema.GetIsPrimaryKey() {
+			return newErr("primary key cannot be transformed")
+		}
+		if _, ok := indexedFields[transform.GetFieldID()]; ok {
+			return newErr("field with index cannot be transformed")
+		}
+		dataType := field.schema.GetDataType()
+		switch transform.GetType() {
+		case querypb.FieldTransformType_Lowercase, querypb.FieldTransformType_Trim:
+			if dataType != schemapb.DataType_VarChar && dataType != schemapb.DataType_String {
+				return newErr("only applies to varchar fields")
+			}
+		case querypb.FieldTransformType_TruncatePrecision:
+			if dataType != schemapb.DataType_Float && dataType != schemapb.DataType_Double {
+				return newErr("only applies to float and double fields")
+			}
+			if transform.GetPrecision() < 0 {
+				return newErr("precision must not be negative")
+			}
+		default:
+			return newErr("unknown transform")
+		}
+	}
+	return nil
+}
+
+// applyFieldTransforms transforms the deserialized field data of insertData in place, the transforms of a field
+// are applied in order. The transforms must have been checked by checkFieldTransforms
+func applyFieldTransforms(insertData *storage.InsertData, transforms []*querypb.FieldTransform) error {
+	for _, transform := range transforms {
+		fieldData, ok := insertData.Data[transform.GetFieldID()]
+		if !ok {
+			continue
+		}
+		newErr := func(reason string) error {
+			return &fieldTransformError{fieldID: transform.GetFieldID(), transform: transform.GetType(), reason: reason}
+		}
+		switch data := fieldData.(type) {
+		case *storage.StringFieldData:
+			var fn func(string) string
+			switch transform.GetType() {
+			case querypb.FieldTransformType_Lowercase:
+				fn = strings.ToLower
+			case querypb.FieldTransformType_Trim:
+				fn = strings.TrimSpace
+			default:
+				return newErr("not applicable to string data")
+			}
+			for i, v := range data.Data {
+				data.Data[i] = fn(v)
+			}
+		case *storage.FloatFieldData:
+			if transform.GetType() != querypb.FieldTransformType_TruncatePrecision {
+				return newErr("not applicable to float data")
+			}
+			for i, v := range data.Data {
+				data.Data[i] = float32(truncatePrecision(float64(v), transform.GetPrecision()))
+			}
+		case *storage.DoubleFieldData:
+			if transform.GetType() != querypb.FieldTransformType_TruncatePrecision {
+				return newErr("not applicable to double data")
+			}
+			for i, v := range data.Data {
+				data.Data[i] = truncatePrecision(v, transform.GetPrecision())
+			}
+		default:
+			return newErr("not applicable to the field data")
+		}
+	}
+	return nil
+}
+
+// truncatePrecision truncates v toward zero to precision decimal places
+func truncatePrecision(v float64, precision int32) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return v
+	}
+	scale := math.Pow10(int(precision))
+	if math.IsInf(v*scale, 0) {
+		return v
+	}
+	return math.Trunc(v*scale) / scale
+}
@@ -0,0 +1,270 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+const (
+	transformVarCharFieldID = FieldID(110)
+	transformDoubleFieldID  = FieldID(111)
+	transformFloatFieldID   = FieldID(112)
+)
+
+func genFieldTransform(fieldID FieldID, transformType querypb.FieldTransformType, precision int32) *querypb.FieldTransform {
+	return &querypb.FieldTransform{FieldID: fieldID, Type: transformType, Precision: precision}
+}
+
+func TestCheckFieldTransforms(t *testing.T) {
+	schema := genSimpleInsertDataSchema()
+	schema.Fields = append(schema.Fields,
+		&schemapb.FieldSchema{FieldID: transformVarCharFieldID, Name: "varchar", DataType: schemapb.DataType_VarChar},
+		&schemapb.FieldSchema{FieldID: transformDoubleFieldID, Name: "double", DataType: schemapb.DataType_Double},
+		&schemapb.FieldSchema{FieldID: transformFloatFieldID, Name: "float", DataType: schemapb.DataType_Float},
+	)
+	col := &Collection{id: defaultCollectionID}
+	col.updateSchema(schema)
+
+	t.Run("valid", func(t *testing.T) {
+		transforms := []*querypb.FieldTransform{
+			genFieldTransform(transformVarCharFieldID, querypb.FieldTransformType_Trim, 0),
+			genFieldTransform(transformVarCharFieldID, querypb.FieldTransformType_Lowercase, 0),
+			genFieldTransform(transformDoubleFieldID, querypb.FieldTransformType_TruncatePrecision, 2),
+			genFieldTransform(transformFloatFieldID, querypb.FieldTransformType_TruncatePrecision, 0),
+		}
+		assert.NoError(t, checkFieldTransforms(col, transforms, nil))
+		assert.NoError(t, checkFieldTransforms(col, nil, nil))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		infos := []*querypb.SegmentLoadInfo{{
+			IndexInfos: []*querypb.FieldIndexInfo{{FieldID: transformDoubleFieldID}},
+		}}
+		cases := []struct {
+			name      string
+			transform *querypb.FieldTransform
+			infos     []*querypb.SegmentLoadInfo
+		}{
+			{"unknown transform", genFieldTransform(transformVarCharFieldID, querypb.FieldTransformType(100), 0), nil},
+			{"no transform", genFieldTransform(transformVarCharFieldID, querypb.FieldTransformType_NoTransform, 0), nil},
+			{"lowercase on double", genFieldTransform(transformDoubleFieldID, querypb.FieldTransformType_Lowercase, 0), nil},
+			{"truncate on varchar", genFieldTransform(transformVarCharFieldID, querypb.FieldTransformType_TruncatePrecision, 1), nil},
+			{"negative precision", genFieldTransform(transformDoubleFieldID, querypb.FieldTransformType_TruncatePrecision, -1), nil},
+			{"primary key", genFieldTransform(simplePKField.id, querypb.FieldTransformType_TruncatePrecision, 1), nil},
+			{"indexed field", genFieldTransform(transformDoubleFieldID, querypb.FieldTransformType_TruncatePrecision, 1), infos},
+		}
+		for _, c := range cases {
+			err := checkFieldTransforms(col, []*querypb.FieldTransform{c.transform}, c.infos)
+			var transformErr *fieldTransformError
+			assert.True(t, errors.As(err, &transformErr), c.name)
+		}
+
+		err := checkFieldTransforms(col, []*querypb.FieldTransform{
+			genFieldTransform(999, querypb.FieldTransformType_Trim, 0),
+		}, nil)
+		var notFoundErr *fieldNotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+	})
+}
+
+func TestApplyFieldTransforms(t *testing.T) {
+	genInsertData := func() *storage.InsertData {
+		return &storage.InsertData{Data: map[FieldID]storage.FieldData{
+			transformVarCharFieldID: &storage.StringFieldData{NumRows: []int64{3}, Data: []string{" Foo ", "BAR", "baz\t"}},
+			transformDoubleFieldID:  &storage.DoubleFieldData{NumRows: []int64{3}, Data: []float64{1.2345, -1.2345, 3}},
+			transformFloatFieldID:   &storage.FloatFieldData{NumRows: []int64{3}, Data: []float32{1.25, -1.75, 2.5}},
+		}}
+	}
+
+	t.Run("transform", func(t *testing.T) {
+		insertData := genInsertData()
+		err := applyFieldTransforms(insertData, []*querypb.FieldTransform{
+			genFieldTransform(transformVarCharFieldID, querypb.FieldTransformType_Trim, 0),
+			genFieldTransform(transformVarCharFieldID, querypb.FieldTransformType_Lowercase, 0),
+			genFieldTransform(transformDoubleFieldID, querypb.FieldTransformType_TruncatePrecision, 2),
+			genFieldTransform(transformFloatFieldID, querypb.FieldTransformType_TruncatePrecision, 0),
+			// the fields not loaded are skipped
+			genFieldTransform(simpleConstField.id, querypb.FieldTransformType_TruncatePrecision, 0),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"foo", "bar", "baz"}, insertData.Data[transformVarCharFieldID].(*storage.StringFieldData).Data)
+		assert.InDeltaSlice(t, []float64{1.23, -1.23, 3}, insertData.Data[transformDoubleFieldID].(*storage.DoubleFieldData).Data, 1e-9)
+		assert.Equal(t, []float32{1, -1, 2}, insertData.Data[transformFloatFieldID].(*storage.FloatFieldData).Data)
+	})
+
+	t.Run("mismatched data", func(t *testing.T) {
+		for _, transform := range []*querypb.FieldTransform{
+			genFieldTransform(transformVarCharFieldID, querypb.FieldTransformType_TruncatePrecision, 1),
+			genFieldTransform(transformDoubleFieldID, querypb.FieldTransformType_Trim, 0),
+			genFieldTransform(transformFloatFieldID, querypb.FieldTransformType_Lowercase, 0),
+		} {
+			err := applyFieldTransforms(genInsertData(), []*querypb.FieldTransform{transform})
+			assert.Error(t, err)
+		}
+
+		insertData := &storage.InsertData{Data: map[FieldID]storage.FieldData{
+			simpleConstField.id: &storage.Int32FieldData{NumRows: []int64{1}, Data: []int32{1}},
+		}}
+		err := applyFieldTransforms(insertData, []*querypb.FieldTransform{
+			genFieldTransform(simpleConstField.id, querypb.FieldTransformType_TruncatePrecision, 1),
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestTruncatePrecision(t *testing.T) {
+	assert.InDelta(t, 1.2, truncatePrecision(1.29, 1), 1e-9)
+	assert.InDelta(t, -1.2, truncatePrecision(-1.29, 1), 1e-9)
+	assert.Equal(t, float64(1), truncatePrecision(1.99, 0))
+	assert.True(t, math.IsNaN(truncatePrecision(math.NaN(), 2)))
+	assert.True(t, math.IsInf(truncatePrecision(math.Inf(-1), 2), -1))
+	assert.Equal(t, math.MaxFloat64, truncatePrecision(math.MaxFloat64, 10))
+}
+
+func TestSegmentLoader_loadFieldTransforms(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	collectionID := defaultCollectionID + 1
+	segmentID := UniqueID(200)
+	schema := genSimpleInsertDataSchema()
+	doubleField := &schemapb.FieldSchema{FieldID: transformDoubleFieldID, Name: "double", DataType: schemapb.DataType_Double}
+
+	// the binlogs of the double field keep 6 decimal places
+	insertData, err := genInsertData(defaultMsgLength, schema)
+	require.NoError(t, err)
+	insertData.Data[timestampFieldID].(*storage.Int64FieldData).Data[0] = 1
+	doubles := make([]float64, defaultMsgLength)
+	for i := range doubles {
+		doubles[i] = float64(i) + 0.456789
+	}
+	insertData.Data[transformDoubleFieldID] = &storage.DoubleFieldData{NumRows: []int64{defaultMsgLength}, Data: doubles}
+	schema.Fields = append(schema.Fields, doubleField)
+
+	inCodec := storage.NewInsertCodec(genCollectionMeta(collectionID, schema))
+	blobs, _, err := inCodec.Serialize(defaultPartitionID, segmentID, insertData)
+	require.NoError(t, err)
+	kvs := make(map[string][]byte)
+	var fieldBinlogs []*datapb.FieldBinlog
+	var doubleBinlogPath string
+	for _, blob := range blobs {
+		fieldID, err := strconv.ParseInt(blob.GetKey(), 10, 64)
+		require.NoError(t, err)
+		key := JoinIDPath(collectionID, defaultPartitionID, segmentID, fieldID)
+		kvs[key] = blob.Value
+		fieldBinlogs = append(fieldBinlogs, &datapb.FieldBinlog{FieldID: fieldID, Binlogs: []*datapb.Binlog{{LogPath: key}}})
+		if fieldID == transformDoubleFieldID {
+			doubleBinlogPath = key
+		}
+	}
+	cm := storage.NewLocalChunkManager(storage.RootPath(defaultLocalStorage))
+	require.NoError(t, cm.MultiWrite(kvs))
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	node.historical.replica.addCollection(collectionID, schema)
+	require.NoError(t, node.historical.replica.addPartition(collectionID, defaultPartitionID))
+
+	genReq := func(transforms ...*querypb.FieldTransform) *querypb.LoadSegmentsRequest {
+		return &querypb.LoadSegmentsRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_LoadSegments,
+				MsgID:   rand.Int63(),
+			},
+			CollectionID: collectionID,
+			Schema:       schema,
+			Infos: []*querypb.SegmentLoadInfo{{
+				SegmentID:    segmentID,
+				PartitionID:  defaultPartitionID,
+				CollectionID: collectionID,
+				BinlogPaths:  fieldBinlogs,
+			}},
+			FieldTransforms: transforms,
+		}
+	}
+
+	t.Run("unknown transform", func(t *testing.T) {
+		err := node.loader.loadSegment(genReq(genFieldTransform(transformDoubleFieldID, querypb.FieldTransformType(100), 0)), segmentTypeSealed)
+		var transformErr *fieldTransformError
+		assert.True(t, errors.As(err, &transformErr))
+		assert.False(t, node.historical.replica.hasSegment(segmentID))
+	})
+
+	t.Run("truncate precision", func(t *testing.T) {
+		transform := genFieldTransform(transformDoubleFieldID, querypb.FieldTransformType_TruncatePrecision, 1)
+		err := node.loader.loadSegment(genReq(transform), segmentTypeSealed)
+		require.NoError(t, err)
+
+		segment, err := node.historical.replica.getSegmentByID(segmentID)
+		require.NoError(t, err)
+		infos, err := node.historical.replica.getSegmentInfosByColID(collectionID)
+		require.NoError(t, err)
+		require.Len(t, infos, 1)
+		require.Len(t, infos[0].GetFieldTransforms(), 1)
+		assert.True(t, proto.Equal(transform, infos[0].GetFieldTransforms()[0]))
+
+		// queries see the transformed data
+		col, err := node.historical.replica.getCollectionByID(collectionID)
+		require.NoError(t, err)
+		expr, err := proto.Marshal(&planpb.PlanNode{
+			Node:           &planpb.PlanNode_Predicates{Predicates: genPKRangeExpr(0, defaultMsgLength)},
+			OutputFieldIds: []int64{simplePKField.id, transformDoubleFieldID},
+		})
+		require.NoError(t, err)
+		plan, err := createRetrievePlanByExpr(col, expr, defaultMsgLength)
+		require.NoError(t, err)
+		defer plan.delete()
+		result, err := segment.retrieve(plan)
+		require.NoError(t, err)
+		pks := result.GetIds().GetIntId().GetData()
+		require.Len(t, pks, defaultMsgLength)
+		var values []float64
+		for _, fieldData := range result.GetFieldsData() {
+			if fieldData.GetFieldId() == transformDoubleFieldID {
+				values = fieldData.GetScalars().GetDoubleData().GetData()
+			}
+		}
+		require.Len(t, values, defaultMsgLength)
+		for i, pk := range pks {
+			assert.InDelta(t, float64(pk)+0.4, values[i], 1e-9)
+		}
+
+		// the binlog is kept as it is
+		binlog, err := cm.Read(doubleBinlogPath)
+		require.NoError(t, err)
+		_, _, loaded, err := storage.NewInsertCodec(genCollectionMeta(collectionID, schema)).Deserialize([]*storage.Blob{{Key: doubleBinlogPath, Value: binlog}})
+		require.NoError(t, err)
+		assert.Equal(t, doubles, loaded.Data[transformDoubleFieldID].(*storage.DoubleFieldData).Data)
+	})
+}
@@ -0,0 +1,117 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+// catchUpProgress is the progress of a DML flow graph replaying its backlog
+type catchUpProgress struct {
+	catchingUp   bool
+	startLag     time.Duration // lag of the seek position behind real time
+	lag          time.Duration // lag of the latest replayed message behind real time
+	replayedMsgs int64         // flow graph messages replayed in catch-up mode
+	replayedRows int64
+	batches      int64 // batches applied in catch-up mode
+}
+
+// catchUpState accelerates the initial replay of a DML flow graph seeking from an old checkpoint,
+// the inserts of consecutive flow graph messages are applied in batches and tSafe is held until a batch is applied
+type catchUpState struct {
+	mu       sync.RWMutex // guards progress
+	progress catchUpProgress
+
+	// pending inserts of the batch, only accessed by the insert node
+	pending     *insertData
+	pendingRows int
+}
+
+// start enables catch-up mode if the seek position lags real time more than Params.QueryNodeCfg.CatchUpLag
+func (state *catchUpState) start(seekTs Timestamp) {
+	if Params.QueryNodeCfg.CatchUpLag <= 0 {
+		return
+	}
+	lag := time.Since(tsoutil.PhysicalTime(seekTs))
+	if lag <= Params.QueryNodeCfg.CatchUpLag {
+		return
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.progress = catchUpProgress{
+		catchingUp: true,
+		startLag:   lag,
+		lag:        lag,
+	}
+	log.Info("flow graph starts catching up", zap.Duration("lag", lag))
+}
+
+func (state *catchUpState) isCatchingUp() bool {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	return state.progress.catchingUp
+}
+
+func (state *catchUpState) getProgress() catchUpProgress {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	return state.progress
+}
+
+// batch returns the inserts pending to be applied
+func (state *catchUpState) batch() *insertData {
+	if state.pending == nil {
+		state.pending = newInsertData()
+	}
+	return state.pending
+}
+
+// replay records the flow graph message of msgTs whose rows have been added into batch,
+// and returns whether the batch should be applied now. The batch is applied once it's full, when forced, e.g. by
+// the deletes that must see the inserts before them, or when the replay is within the lag and catch-up mode ends
+func (state *catchUpState) replay(msgTs Timestamp, rows int, force bool) bool {
+	state.pendingRows += rows
+	lag := time.Since(tsoutil.PhysicalTime(msgTs))
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.progress.replayedMsgs++
+	state.progress.replayedRows += int64(rows)
+	state.progress.lag = lag
+	if lag <= Params.QueryNodeCfg.CatchUpLag {
+		state.progress.catchingUp = false
+		log.Info("flow graph caught up",
+			zap.Duration("startLag", state.progress.startLag),
+			zap.Int64("replayedMsgs", state.progress.replayedMsgs),
+			zap.Int64("replayedRows", state.progress.replayedRows),
+			zap.Int64("batches", state.progress.batches+1))
+	}
+	if state.progress.catchingUp && !force && int64(state.pendingRows) < Params.QueryNodeCfg.CatchUpBatchRows {
+		return false
+	}
+	state.progress.batches++
+	state.pending = nil
+	state.pendingRows = 0
+	return true
+}
@@ -0,0 +1,211 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/flowgraph"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+func setCatchUpParams(lag time.Duration, batchRows int64) func() {
+	oldLag, oldBatchRows := Params.QueryNodeCfg.CatchUpLag, Params.QueryNodeCfg.CatchUpBatchRows
+	Params.QueryNodeCfg.CatchUpLag = lag
+	Params.QueryNodeCfg.CatchUpBatchRows = batchRows
+	return func() {
+		Params.QueryNodeCfg.CatchUpLag = oldLag
+		Params.QueryNodeCfg.CatchUpBatchRows = oldBatchRows
+	}
+}
+
+func TestCatchUpState(t *testing.T) {
+	defer setCatchUpParams(10*time.Second, 100)()
+
+	oldTs := tsoutil.ComposeTSByTime(time.Now().Add(-time.Hour), 0)
+	recentTs := tsoutil.ComposeTSByTime(time.Now(), 0)
+
+	t.Run("recent seek position", func(t *testing.T) {
+		state := &catchUpState{}
+		state.start(recentTs)
+		assert.False(t, state.isCatchingUp())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		defer setCatchUpParams(0, 100)()
+		state := &catchUpState{}
+		state.start(oldTs)
+		assert.False(t, state.isCatchingUp())
+	})
+
+	t.Run("replay in batches", func(t *testing.T) {
+		state := &catchUpState{}
+		state.start(oldTs)
+		require.True(t, state.isCatchingUp())
+		assert.Greater(t, state.getProgress().startLag, time.Hour-time.Minute)
+
+		assert.NotNil(t, state.batch())
+		assert.Same(t, state.batch(), state.batch())
+
+		// the batch is applied once it's full
+		assert.False(t, state.replay(oldTs, 60, false))
+		assert.True(t, state.replay(oldTs, 40, false))
+		// or forced by deletes
+		assert.False(t, state.replay(oldTs, 10, false))
+		assert.True(t, state.replay(oldTs, 0, true))
+		assert.True(t, state.isCatchingUp())
+
+		// catch-up mode ends within the lag
+		assert.True(t, state.replay(recentTs, 1, false))
+		assert.False(t, state.isCatchingUp())
+		assert.True(t, state.replay(recentTs, 1, false))
+
+		progress := state.getProgress()
+		assert.False(t, progress.catchingUp)
+		assert.Less(t, progress.lag, 10*time.Second)
+		assert.Equal(t, int64(6), progress.replayedMsgs)
+		assert.Equal(t, int64(112), progress.replayedRows)
+		assert.Equal(t, int64(4), progress.batches)
+	})
+}
+
+func TestFlowGraphInsertNode_catchUp(t *testing.T) {
+	const backlog = 50000
+	defer setCatchUpParams(10*time.Second, 10000)()
+
+	schema := genSimpleSegCoreSchema()
+	rowData, err := genCommonBlob(backlog, schema)
+	require.NoError(t, err)
+
+	// one row per message, all the messages but the last lag behind real time for an hour
+	startTime := time.Now().Add(-time.Hour)
+	msgs := make([]*insertMsg, 0, backlog+1)
+	for i := 0; i < backlog; i++ {
+		ts := tsoutil.ComposeTSByTime(startTime, int64(i))
+		msgs = append(msgs, &insertMsg{
+			insertMessages: []*msgstream.InsertMsg{
+				{
+					BaseMsg: genMsgStreamBaseMsg(),
+					InsertRequest: internalpb.InsertRequest{
+						Base:           genCommonMsgBase(commonpb.MsgType_Insert),
+						CollectionName: defaultCollectionName,
+						PartitionName:  defaultPartitionName,
+						CollectionID:   defaultCollectionID,
+						PartitionID:    defaultPartitionID,
+						SegmentID:      defaultSegmentID,
+						ShardName:      defaultDMLChannel,
+						Timestamps:     []Timestamp{ts},
+						RowIDs:         []int64{int64(i)},
+						RowData:        rowData[i : i+1],
+					},
+				},
+			},
+			timeRange: TimeRange{timestampMin: ts, timestampMax: ts},
+		})
+	}
+	nowTs := tsoutil.ComposeTSByTime(time.Now(), 0)
+	msgs = append(msgs, &insertMsg{timeRange: TimeRange{timestampMin: nowTs, timestampMax: nowTs}})
+
+	appliedTicks := metrics.QueryNodeTimeTicks.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), metrics.AppliedLabel)
+	// replay returns the segment replayed into, the insert node, and the number of messages and time ticks it emitted
+	replay := func(catchUp bool) (*Segment, *insertNode, int, int) {
+		streaming, err := genSimpleReplica()
+		require.NoError(t, err)
+		iNode := newInsertNode(streaming)
+		if catchUp {
+			iNode.catchUp.start(tsoutil.ComposeTSByTime(startTime, 0))
+			require.True(t, iNode.catchUp.isCatchingUp())
+		}
+		tSafe := newTSafeReplica()
+		tSafe.addTSafe(defaultDMLChannel)
+		stNode := newServiceTimeNode(tSafe, defaultCollectionID, defaultDMLChannel)
+		stNode.coalesceWindow = 0
+		defer stNode.Close()
+
+		outputs := 0
+		ticksBefore := testutil.ToFloat64(appliedTicks)
+		for _, msg := range msgs {
+			out := iNode.Operate([]flowgraph.Msg{msg})
+			outputs += len(out)
+			if len(out) > 0 {
+				stNode.Operate(out)
+			}
+		}
+		ticks := int(testutil.ToFloat64(appliedTicks) - ticksBefore)
+
+		segment, err := streaming.getSegmentByID(defaultSegmentID)
+		require.NoError(t, err)
+		return segment, iNode, outputs, ticks
+	}
+
+	perMsgSegment, _, perMsgOutputs, perMsgTicks := replay(false)
+	catchUpSegment, catchUpNode, catchUpOutputs, catchUpTicks := replay(true)
+
+	// tSafe is advanced and the time ticks are counted per message normally, but only per applied batch in catch-up mode
+	assert.Equal(t, backlog+1, perMsgOutputs)
+	assert.Equal(t, backlog+1, perMsgTicks)
+	progress := catchUpNode.catchUp.getProgress()
+	assert.False(t, progress.catchingUp)
+	assert.Equal(t, int64(backlog+1), progress.replayedMsgs)
+	assert.Equal(t, int64(backlog), progress.replayedRows)
+	assert.Equal(t, int64(backlog/10000+1), progress.batches)
+	assert.Equal(t, int(progress.batches), catchUpOutputs)
+	assert.Equal(t, int(progress.batches), catchUpTicks)
+
+	// identical final state
+	assert.Equal(t, int64(backlog), perMsgSegment.getRowCount())
+	assert.Equal(t, perMsgSegment.getRowCount(), catchUpSegment.getRowCount())
+	assert.True(t, perMsgSegment.pkFilter.Equal(catchUpSegment.pkFilter))
+	assert.Equal(t, []int64{1, 2, 3}, retrieveSimpleIDs(t, perMsgSegment, math.MaxUint64))
+	assert.Equal(t, retrieveSimpleIDs(t, perMsgSegment, math.MaxUint64), retrieveSimpleIDs(t, catchUpSegment, math.MaxUint64))
+
+	t.Run("deletes flush the batch", func(t *testing.T) {
+		streaming, err := genSimpleReplica()
+		require.NoError(t, err)
+		iNode := newInsertNode(streaming)
+		iNode.catchUp.start(tsoutil.ComposeTSByTime(startTime, 0))
+		require.True(t, iNode.catchUp.isCatchingUp())
+
+		insertMsg1, err := genSimpleInsertMsg()
+		require.NoError(t, err)
+		out := iNode.Operate([]flowgraph.Msg{&insertMsg{insertMessages: []*msgstream.InsertMsg{insertMsg1}}})
+		assert.Empty(t, out)
+		segment, err := streaming.getSegmentByID(defaultSegmentID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), segment.getRowCount())
+
+		deleteMsg, err := genSimpleDeleteMsg(schemapb.DataType_Int64)
+		require.NoError(t, err)
+		out = iNode.Operate([]flowgraph.Msg{&insertMsg{deleteMessages: []*msgstream.DeleteMsg{deleteMsg}}})
+		assert.Len(t, out, 1)
+		assert.Equal(t, int64(defaultMsgLength), segment.getRowCount())
+		assert.Equal(t, int64(defaultDelLength), segment.getDeletedCount())
+		assert.True(t, iNode.catchUp.isCatchingUp())
+	})
+}
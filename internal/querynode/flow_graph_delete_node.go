@@ -17,12 +17,17 @@
 package querynode
 
 import (
+	"errors"
 	"sync"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/opentracing/opentracing-go"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/flowgraph"
 	"github.com/milvus-io/milvus/internal/util/trace"
@@ -39,6 +44,9 @@ var newVarCharPrimaryKey = storage.NewVarCharPrimaryKey
 type deleteNode struct {
 	baseNode
 	replica ReplicaInterface // historical
+
+	// nil if the throughput of the channel is not collected
+	ingestion *channelIngestion
 }
 
 // Name returns the name of deleteNode
@@ -62,7 +70,6 @@ func (dNode *deleteNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 	delData := &deleteData{
 		deleteIDs:        map[UniqueID][]primaryKey{},
 		deleteTimestamps: map[UniqueID][]Timestamp{},
-		deleteOffset:     map[UniqueID]int64{},
 	}
 
 	if dMsg == nil {
@@ -81,40 +88,43 @@ func (dNode *deleteNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 		traceID, _, _ := trace.InfoFromSpan(spans[i])
 		log.Debug("Process delete request in QueryNode", zap.String("traceID", traceID))
 
-		if dNode.replica.getSegmentNum() != 0 {
-			log.Debug("delete in historical replica",
-				zap.Any("collectionID", delMsg.CollectionID),
-				zap.Any("collectionName", delMsg.CollectionName),
-				zap.Int64("numPKs", delMsg.NumRows),
-				zap.Int("numTS", len(delMsg.Timestamps)),
-				zap.Any("timestampBegin", delMsg.BeginTs()),
-				zap.Any("timestampEnd", delMsg.EndTs()),
-			)
-			processDeleteMessages(dNode.replica, delMsg, delData)
-		}
-	}
-
-	// 2. do preDelete
-	for segmentID, pks := range delData.deleteIDs {
-		segment, err := dNode.replica.getSegmentByID(segmentID)
+		collection, err := dNode.replica.getCollectionByID(delMsg.CollectionID)
 		if err != nil {
-			log.Debug(err.Error())
+			log.Warn(err.Error())
 			continue
 		}
-		offset := segment.segmentPreDelete(len(pks))
-		delData.deleteOffset[segmentID] = offset
+		log.Debug("delete in historical replica",
+			zap.Any("collectionID", delMsg.CollectionID),
+			zap.Any("collectionName", delMsg.CollectionName),
+			zap.Int64("numPKs", delMsg.NumRows),
+			zap.Int("numTS", len(delMsg.Timestamps)),
+			zap.Any("timestampBegin", delMsg.BeginTs()),
+			zap.Any("timestampEnd", delMsg.EndTs()),
+		)
+		// the deletes matching no sealed segment are buffered, in case their segments are loaded later
+		collection.sealedDeletes.bufferUnmatched(delMsg.PartitionID, func() ([]primaryKey, []Timestamp) {
+			return processDeleteMessages(dNode.replica, delMsg, delData)
+		})
+	}
+	for _, collectionID := range dNode.replica.getCollectionIDs() {
+		if collection, err := dNode.replica.getCollectionByID(collectionID); err == nil {
+			collection.sealedDeletes.expire(dMsg.timeRange.timestampMax)
+		}
 	}
 
-	// 3. do delete
+	// 2. do delete, the delete records are reserved by every segment once its batch is checked
+	delData.sortByTimestamp()
 	wg := sync.WaitGroup{}
-	for segmentID := range delData.deleteOffset {
+	for segmentID := range delData.deleteIDs {
 		wg.Add(1)
 		go dNode.delete(delData, segmentID, &wg)
 	}
 	wg.Wait()
+	dNode.ingestion.record(metrics.DeleteLabel, ingestedDeletes(dMsg.deleteMessages), time.Now())
 
 	var res Msg = &serviceTimeMsg{
 		timeRange: dMsg.timeRange,
+		hasData:   len(dMsg.deleteMessages) > 0,
 	}
 	for _, sp := range spans {
 		sp.Finish()
@@ -123,13 +133,26 @@ func (dNode *deleteNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 	return []Msg{res}
 }
 
+// ingestedDeletes returns the volume of the delete messages
+func ingestedDeletes(msgs []*msgstream.DeleteMsg) []ingestedMsg {
+	ingested := make([]ingestedMsg, 0, len(msgs))
+	for _, msg := range msgs {
+		ingested = append(ingested, ingestedMsg{
+			rows:  int64(len(msg.Timestamps)),
+			bytes: int64(proto.Size(&msg.DeleteRequest)),
+			ts:    msg.EndTs(),
+		})
+	}
+	return ingested
+}
+
 // delete will do delete operation at segment which id is segmentID
 func (dNode *deleteNode) delete(deleteData *deleteData, segmentID UniqueID, wg *sync.WaitGroup) {
 	defer wg.Done()
 	log.Debug("QueryNode::dNode::delete", zap.Any("SegmentID", segmentID))
 	targetSegment, err := dNode.replica.getSegmentByID(segmentID)
 	if err != nil {
-		log.Error(err.Error())
+		log.Debug(err.Error())
 		return
 	}
 
@@ -139,10 +162,12 @@ func (dNode *deleteNode) delete(deleteData *deleteData, segmentID UniqueID, wg *
 
 	ids := deleteData.deleteIDs[segmentID]
 	timestamps := deleteData.deleteTimestamps[segmentID]
-	offset := deleteData.deleteOffset[segmentID]
 
-	err = targetSegment.segmentDelete(offset, ids, timestamps)
-	if err != nil {
+	err = targetSegment.applyDeletes(ids, timestamps, false)
+	var staleErr *staleDeleteError
+	if errors.As(err, &staleErr) {
+		log.Warn("stale delete records are dropped, the others are applied", zap.Int64("segmentID", segmentID), zap.Error(err))
+	} else if err != nil {
 		log.Warn("delete segment data failed", zap.Int64("segmentID", segmentID), zap.Error(err))
 		return
 	}
@@ -34,6 +34,7 @@ type filterDeleteNode struct {
 	baseNode
 	collectionID UniqueID
 	replica      ReplicaInterface
+	msgTypes     *msgTypeRegistry
 }
 
 // Name returns the name of filterDeleteNode
@@ -74,14 +75,15 @@ func (fddNode *filterDeleteNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 	}
 
 	for _, msg := range msgStreamMsg.TsMessages() {
+		if !fddNode.msgTypes.isHandled(msg.Type()) {
+			continue
+		}
 		switch msg.Type() {
 		case commonpb.MsgType_Delete:
 			resMsg := fddNode.filterInvalidDeleteMessage(msg.(*msgstream.DeleteMsg))
 			if resMsg != nil {
 				dMsg.deleteMessages = append(dMsg.deleteMessages, resMsg)
 			}
-		default:
-			log.Warn("Non supporting", zap.Int32("message type", int32(msg.Type())))
 		}
 	}
 	var res Msg = &dMsg
@@ -117,7 +119,7 @@ func (fddNode *filterDeleteNode) filterInvalidDeleteMessage(msg *msgstream.Delet
 }
 
 // newFilteredDeleteNode returns a new filterDeleteNode
-func newFilteredDeleteNode(replica ReplicaInterface, collectionID UniqueID) *filterDeleteNode {
+func newFilteredDeleteNode(replica ReplicaInterface, collectionID UniqueID, channel Channel) *filterDeleteNode {
 
 	maxQueueLength := Params.QueryNodeCfg.FlowGraphMaxQueueLength
 	maxParallelism := Params.QueryNodeCfg.FlowGraphMaxParallelism
@@ -126,9 +128,11 @@ func newFilteredDeleteNode(replica ReplicaInterface, collectionID UniqueID) *fil
 	baseNode.SetMaxQueueLength(maxQueueLength)
 	baseNode.SetMaxParallelism(maxParallelism)
 
-	return &filterDeleteNode{
+	fddNode := &filterDeleteNode{
 		baseNode:     baseNode,
 		collectionID: collectionID,
 		replica:      replica,
 	}
+	fddNode.msgTypes = newMsgTypeRegistry(fddNode.Name(), channel, commonpb.MsgType_Delete)
+	return fddNode
 }
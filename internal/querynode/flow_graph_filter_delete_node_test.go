@@ -35,7 +35,7 @@ func getFilterDeleteNode(ctx context.Context) (*filterDeleteNode, error) {
 	}
 
 	historical.addExcludedSegments(defaultCollectionID, nil)
-	return newFilteredDeleteNode(historical, defaultCollectionID), nil
+	return newFilteredDeleteNode(historical, defaultCollectionID, defaultDeltaChannel), nil
 }
 
 func TestFlowGraphFilterDeleteNode_filterDeleteNode(t *testing.T) {
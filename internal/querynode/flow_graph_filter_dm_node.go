@@ -34,6 +34,7 @@ type filterDmNode struct {
 	baseNode
 	collectionID UniqueID
 	replica      ReplicaInterface
+	msgTypes     *msgTypeRegistry
 }
 
 // Name returns the name of filterDmNode
@@ -79,6 +80,9 @@ func (fdmNode *filterDmNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 	for i, msg := range msgStreamMsg.TsMessages() {
 		traceID, _, _ := trace.InfoFromSpan(spans[i])
 		log.Info("Filter invalid message in QueryNode", zap.String("traceID", traceID))
+		if !fdmNode.msgTypes.isHandled(msg.Type()) {
+			continue
+		}
 		switch msg.Type() {
 		case commonpb.MsgType_Insert:
 			resMsg := fdmNode.filterInvalidInsertMessage(msg.(*msgstream.InsertMsg))
@@ -90,8 +94,6 @@ func (fdmNode *filterDmNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 			if resMsg != nil {
 				iMsg.deleteMessages = append(iMsg.deleteMessages, resMsg)
 			}
-		default:
-			log.Warn("Non supporting", zap.Int32("message type", int32(msg.Type())))
 		}
 	}
 
@@ -216,7 +218,7 @@ func (fdmNode *filterDmNode) filterInvalidInsertMessage(msg *msgstream.InsertMsg
 }
 
 // newFilteredDmNode returns a new filterDmNode
-func newFilteredDmNode(replica ReplicaInterface, collectionID UniqueID) *filterDmNode {
+func newFilteredDmNode(replica ReplicaInterface, collectionID UniqueID, channel Channel) *filterDmNode {
 
 	maxQueueLength := Params.QueryNodeCfg.FlowGraphMaxQueueLength
 	maxParallelism := Params.QueryNodeCfg.FlowGraphMaxParallelism
@@ -225,9 +227,11 @@ func newFilteredDmNode(replica ReplicaInterface, collectionID UniqueID) *filterD
 	baseNode.SetMaxQueueLength(maxQueueLength)
 	baseNode.SetMaxParallelism(maxParallelism)
 
-	return &filterDmNode{
+	fdmNode := &filterDmNode{
 		baseNode:     baseNode,
 		collectionID: collectionID,
 		replica:      replica,
 	}
+	fdmNode.msgTypes = newMsgTypeRegistry(fdmNode.Name(), channel, commonpb.MsgType_Insert, commonpb.MsgType_Delete)
+	return fdmNode
 }
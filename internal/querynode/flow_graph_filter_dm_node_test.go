@@ -38,7 +38,7 @@ func getFilterDMNode(ctx context.Context) (*filterDmNode, error) {
 	}
 
 	streaming.addExcludedSegments(defaultCollectionID, nil)
-	return newFilteredDmNode(streaming, defaultCollectionID), nil
+	return newFilteredDmNode(streaming, defaultCollectionID, defaultDMLChannel), nil
 }
 
 func TestFlowGraphFilterDmNode_filterDmNode(t *testing.T) {
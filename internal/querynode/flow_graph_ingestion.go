@@ -0,0 +1,129 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+// ingestionRateWindow is the window over which the ingestion rates of a channel are measured
+const ingestionRateWindow = 10 * time.Second
+
+// ingestedMsg is the volume of a dml message applied to the segments
+type ingestedMsg struct {
+	rows  int64
+	bytes int64
+	ts    Timestamp
+}
+
+// ingestionRate is the rows and bytes per second applied from a channel
+type ingestionRate struct {
+	rowsPerSec  float64
+	bytesPerSec float64
+}
+
+// ingestionWindow measures the ingestion rate over the last completed window
+type ingestionWindow struct {
+	start time.Time
+	rows  int64
+	bytes int64
+	last  ingestionRate
+}
+
+// roll completes the window once it spans ingestionRateWindow, the rate of which is kept until the next one completes
+func (w *ingestionWindow) roll(now time.Time) {
+	if w.start.IsZero() {
+		w.start = now
+		return
+	}
+	elapsed := now.Sub(w.start)
+	if elapsed < ingestionRateWindow {
+		return
+	}
+	w.last = ingestionRate{
+		rowsPerSec:  float64(w.rows) / elapsed.Seconds(),
+		bytesPerSec: float64(w.bytes) / elapsed.Seconds(),
+	}
+	w.start = now
+	w.rows, w.bytes = 0, 0
+}
+
+// channelIngestion collects the inserts and deletes applied from a channel, into the metrics labeled by the channel
+// and the rates reported to query coord. The metrics of the channel are removed once it's unwatched
+type channelIngestion struct {
+	channel Channel
+
+	mu     sync.Mutex
+	insert ingestionWindow
+	delete ingestionWindow
+}
+
+func newChannelIngestion(channel Channel) *channelIngestion {
+	return &channelIngestion{channel: channel}
+}
+
+// record records the messages of msgType applied at now, nil channelIngestion records nothing
+func (ci *channelIngestion) record(msgType string, msgs []ingestedMsg, now time.Time) {
+	if ci == nil || len(msgs) == 0 {
+		return
+	}
+
+	nodeID := fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)
+	var rows, bytes int64
+	for _, msg := range msgs {
+		rows += msg.rows
+		bytes += msg.bytes
+		latency := now.Sub(tsoutil.PhysicalTime(msg.ts))
+		metrics.QueryNodeApplyLatency.WithLabelValues(nodeID, ci.channel, msgType).Observe(float64(latency.Milliseconds()))
+	}
+	metrics.QueryNodeConsumedRows.WithLabelValues(nodeID, ci.channel, msgType).Add(float64(rows))
+	metrics.QueryNodeConsumedBytes.WithLabelValues(nodeID, ci.channel, msgType).Add(float64(bytes))
+
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	window := &ci.insert
+	if msgType == metrics.DeleteLabel {
+		window = &ci.delete
+	}
+	window.roll(now)
+	window.rows += rows
+	window.bytes += bytes
+}
+
+// rates returns the insert and delete rates of the channel over the last completed window
+func (ci *channelIngestion) rates(now time.Time) (ingestionRate, ingestionRate) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	ci.insert.roll(now)
+	ci.delete.roll(now)
+	return ci.insert.last, ci.delete.last
+}
+
+// close removes the metrics of the channel
+func (ci *channelIngestion) close() {
+	nodeID := fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)
+	for _, msgType := range []string{metrics.InsertLabel, metrics.DeleteLabel} {
+		metrics.QueryNodeConsumedRows.DeleteLabelValues(nodeID, ci.channel, msgType)
+		metrics.QueryNodeConsumedBytes.DeleteLabelValues(nodeID, ci.channel, msgType)
+		metrics.QueryNodeApplyLatency.DeleteLabelValues(nodeID, ci.channel, msgType)
+	}
+}
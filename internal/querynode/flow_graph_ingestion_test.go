@@ -0,0 +1,141 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/flowgraph"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+func TestChannelIngestion(t *testing.T) {
+	nodeID := fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)
+	channel := "ingestion-test-channel"
+
+	t.Run("test record and rates", func(t *testing.T) {
+		ci := newChannelIngestion(channel)
+		defer ci.close()
+
+		now := time.Now()
+		ts := tsoutil.ComposeTSByTime(now.Add(-time.Second), 0)
+		ci.record(metrics.InsertLabel, []ingestedMsg{{rows: 10, bytes: 100, ts: ts}, {rows: 20, bytes: 200, ts: ts}}, now)
+		ci.record(metrics.DeleteLabel, []ingestedMsg{{rows: 5, bytes: 50, ts: ts}}, now)
+
+		assert.Equal(t, float64(30), testutil.ToFloat64(metrics.QueryNodeConsumedRows.WithLabelValues(nodeID, channel, metrics.InsertLabel)))
+		assert.Equal(t, float64(300), testutil.ToFloat64(metrics.QueryNodeConsumedBytes.WithLabelValues(nodeID, channel, metrics.InsertLabel)))
+		assert.Equal(t, float64(5), testutil.ToFloat64(metrics.QueryNodeConsumedRows.WithLabelValues(nodeID, channel, metrics.DeleteLabel)))
+		assert.Equal(t, float64(50), testutil.ToFloat64(metrics.QueryNodeConsumedBytes.WithLabelValues(nodeID, channel, metrics.DeleteLabel)))
+
+		// no window completes yet
+		insertRate, deleteRate := ci.rates(now.Add(time.Second))
+		assert.Equal(t, ingestionRate{}, insertRate)
+		assert.Equal(t, ingestionRate{}, deleteRate)
+
+		insertRate, deleteRate = ci.rates(now.Add(ingestionRateWindow))
+		assert.Equal(t, ingestionRate{rowsPerSec: 3, bytesPerSec: 30}, insertRate)
+		assert.Equal(t, ingestionRate{rowsPerSec: 0.5, bytesPerSec: 5}, deleteRate)
+
+		// the rates drop once the channel is idle for a window
+		insertRate, deleteRate = ci.rates(now.Add(2 * ingestionRateWindow))
+		assert.Equal(t, ingestionRate{}, insertRate)
+		assert.Equal(t, ingestionRate{}, deleteRate)
+	})
+
+	t.Run("test close", func(t *testing.T) {
+		ci := newChannelIngestion(channel)
+		ts := tsoutil.ComposeTSByTime(time.Now(), 0)
+		ci.record(metrics.InsertLabel, []ingestedMsg{{rows: 1, bytes: 1, ts: ts}}, time.Now())
+		ci.close()
+
+		assert.False(t, metrics.QueryNodeConsumedRows.DeleteLabelValues(nodeID, channel, metrics.InsertLabel))
+		assert.False(t, metrics.QueryNodeConsumedBytes.DeleteLabelValues(nodeID, channel, metrics.InsertLabel))
+		assert.False(t, metrics.QueryNodeApplyLatency.DeleteLabelValues(nodeID, channel, metrics.InsertLabel))
+	})
+
+	t.Run("test nil", func(t *testing.T) {
+		var ci *channelIngestion
+		assert.NotPanics(t, func() {
+			ci.record(metrics.InsertLabel, []ingestedMsg{{rows: 1, bytes: 1}}, time.Now())
+		})
+	})
+}
+
+func TestFlowGraphInsertNode_ingestion(t *testing.T) {
+	nodeID := fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)
+
+	streaming, err := genSimpleReplica()
+	require.NoError(t, err)
+	err = streaming.addSegment(defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeGrowing, true)
+	require.NoError(t, err)
+
+	insertNode := newInsertNode(streaming)
+	channel := "ingestion-test-insert-node"
+	insertNode.ingestion = newChannelIngestion(channel)
+	defer insertNode.ingestion.close()
+
+	msgInsertMsg, err := genSimpleInsertMsg()
+	require.NoError(t, err)
+	msgDeleteMsg, err := genSimpleDeleteMsg(schemapb.DataType_Int64)
+	require.NoError(t, err)
+	iMsg := &insertMsg{
+		insertMessages: []*msgstream.InsertMsg{msgInsertMsg},
+		deleteMessages: []*msgstream.DeleteMsg{msgDeleteMsg},
+	}
+	insertNode.Operate([]flowgraph.Msg{iMsg})
+
+	assert.Equal(t, float64(defaultMsgLength), testutil.ToFloat64(metrics.QueryNodeConsumedRows.WithLabelValues(nodeID, channel, metrics.InsertLabel)))
+	assert.Greater(t, testutil.ToFloat64(metrics.QueryNodeConsumedBytes.WithLabelValues(nodeID, channel, metrics.InsertLabel)), float64(0))
+	assert.Equal(t, float64(len(msgDeleteMsg.Timestamps)), testutil.ToFloat64(metrics.QueryNodeConsumedRows.WithLabelValues(nodeID, channel, metrics.DeleteLabel)))
+	assert.Greater(t, testutil.ToFloat64(metrics.QueryNodeConsumedBytes.WithLabelValues(nodeID, channel, metrics.DeleteLabel)), float64(0))
+}
+
+func TestDataSyncService_ingestionRates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	nodeID := fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)
+
+	streamingReplica, err := genSimpleReplica()
+	require.NoError(t, err)
+	historicalReplica, err := genSimpleReplica()
+	require.NoError(t, err)
+	dataSyncService := newDataSyncService(ctx, streamingReplica, historicalReplica, newTSafeReplica(), genFactory())
+
+	_, err = dataSyncService.addFlowGraphsForDMLChannels(defaultCollectionID, []Channel{defaultDMLChannel})
+	require.NoError(t, err)
+	fg, err := dataSyncService.getFlowGraphByDMLChannel(defaultCollectionID, defaultDMLChannel)
+	require.NoError(t, err)
+
+	fg.ingestion.record(metrics.InsertLabel, []ingestedMsg{{rows: 10, bytes: 100, ts: tsoutil.ComposeTSByTime(time.Now(), 0)}}, time.Now())
+	rates := dataSyncService.getIngestionRates()
+	assert.Contains(t, rates, defaultDMLChannel)
+
+	// the metrics of the channel are removed once it's unwatched
+	dataSyncService.removeFlowGraphsByDMLChannels([]Channel{defaultDMLChannel})
+	assert.NotContains(t, dataSyncService.getIngestionRates(), defaultDMLChannel)
+	assert.False(t, metrics.QueryNodeConsumedRows.DeleteLabelValues(nodeID, defaultDMLChannel, metrics.InsertLabel))
+}
@@ -22,14 +22,16 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"strconv"
 	"sync"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/opentracing/opentracing-go"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/mq/msgstream"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
@@ -43,6 +45,14 @@ import (
 type insertNode struct {
 	baseNode
 	streamingReplica ReplicaInterface
+
+	catchUp catchUpState
+
+	// the deletes matching no growing segment, applied if the inserts of their pks arrive later
+	pendingDeletes *pendingDeletes
+
+	// nil if the throughput of the channel is not collected
+	ingestion *channelIngestion
 }
 
 // insertData stores the valid insert data
@@ -52,13 +62,23 @@ type insertData struct {
 	insertRecords    map[UniqueID][]*commonpb.Blob
 	insertOffset     map[UniqueID]int64
 	insertPKs        map[UniqueID][]primaryKey // pks
+	ingested         []ingestedMsg
+}
+
+func newInsertData() *insertData {
+	return &insertData{
+		insertIDs:        make(map[UniqueID][]int64),
+		insertTimestamps: make(map[UniqueID][]Timestamp),
+		insertRecords:    make(map[UniqueID][]*commonpb.Blob),
+		insertOffset:     make(map[UniqueID]int64),
+		insertPKs:        make(map[UniqueID][]primaryKey),
+	}
 }
 
 // deleteData stores the valid delete data
 type deleteData struct {
 	deleteIDs        map[UniqueID][]primaryKey // pks
 	deleteTimestamps map[UniqueID][]Timestamp
-	deleteOffset     map[UniqueID]int64
 }
 
 // Name returns the name of insertNode
@@ -81,27 +101,38 @@ func (iNode *insertNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 		return []Msg{}
 	}
 
-	iData := insertData{
-		insertIDs:        make(map[UniqueID][]int64),
-		insertTimestamps: make(map[UniqueID][]Timestamp),
-		insertRecords:    make(map[UniqueID][]*commonpb.Blob),
-		insertOffset:     make(map[UniqueID]int64),
-		insertPKs:        make(map[UniqueID][]primaryKey),
-	}
-
 	if iMsg == nil {
 		return []Msg{}
 	}
 
+	// the growing segments are not reaped as idle until the inserts are done
+	iNode.streamingReplica.insertRLock()
+	defer iNode.streamingReplica.insertRUnlock()
+
+	// the inserts of consecutive messages are accumulated into a batch in catch-up mode
+	catchingUp := iNode.catchUp.isCatchingUp()
+	iData := newInsertData()
+	if catchingUp {
+		iData = iNode.catchUp.batch()
+	}
+
+	// the per-message spans, logs and time ticks are skipped in catch-up mode, where only the applied batches
+	// advance tSafe and count as time ticks
 	var spans []opentracing.Span
-	for _, msg := range iMsg.insertMessages {
-		sp, ctx := trace.StartSpanFromContext(msg.TraceCtx())
-		spans = append(spans, sp)
-		msg.SetTraceCtx(ctx)
+	if !catchingUp {
+		for _, msg := range iMsg.insertMessages {
+			sp, ctx := trace.StartSpanFromContext(msg.TraceCtx())
+			spans = append(spans, sp)
+			msg.SetTraceCtx(ctx)
+		}
 	}
 
 	// 1. hash insertMessages to insertData
+	rows := 0
 	for _, insertMsg := range iMsg.insertMessages {
+		// the size before the column-based data is transferred to rows
+		size := proto.Size(&insertMsg.InsertRequest)
+
 		// if loadType is loadCollection, check if partition exists, if not, create partition
 		col, err := iNode.streamingReplica.getCollectionByID(insertMsg.CollectionID)
 		if err != nil {
@@ -116,34 +147,69 @@ func (iNode *insertNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 			}
 		}
 
-		// check if segment exists, if not, create this segment
-		if !iNode.streamingReplica.hasSegment(insertMsg.SegmentID) {
-			err := iNode.streamingReplica.addSegment(insertMsg.SegmentID, insertMsg.PartitionID, insertMsg.CollectionID, insertMsg.ShardName, segmentTypeGrowing, true)
-			if err != nil {
-				log.Warn(err.Error())
-				continue
-			}
+		if err := checkInsertPayload(col, insertMsg); err != nil {
+			metrics.QueryNodeInvalidInsertPayloads.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Inc()
+			log.Error("drop insert message with invalid payload",
+				zap.Int64("collectionID", insertMsg.CollectionID),
+				zap.Int64("segmentID", insertMsg.SegmentID),
+				zap.Int64("msgID", insertMsg.ID()),
+				zap.Error(err))
+			continue
 		}
 
 		// trans column field data to row data
 		if insertMsg.IsColumnBased() {
-			insertMsg.RowData, err = typeutil.TransferColumnBasedDataToRowBasedData(col.schema, insertMsg.FieldsData)
+			insertMsg.RowData, err = typeutil.TransferColumnBasedDataToRowBasedData(col.Schema(), insertMsg.FieldsData)
 			if err != nil {
 				log.Error("failed to transfer column-based data to row-based data", zap.Error(err))
 				return []Msg{}
 			}
 		}
 
-		iData.insertIDs[insertMsg.SegmentID] = append(iData.insertIDs[insertMsg.SegmentID], insertMsg.RowIDs...)
-		iData.insertTimestamps[insertMsg.SegmentID] = append(iData.insertTimestamps[insertMsg.SegmentID], insertMsg.Timestamps...)
-		// using insertMsg.RowData is valid here, since we have already transferred the column-based data.
-		iData.insertRecords[insertMsg.SegmentID] = append(iData.insertRecords[insertMsg.SegmentID], insertMsg.RowData...)
 		pks, err := getPrimaryKeys(insertMsg, iNode.streamingReplica)
 		if err != nil {
 			log.Warn(err.Error())
 			continue
 		}
+		if Params.QueryNodeCfg.ValidateAutoID {
+			if err := checkAutoIDPrimaryKeys(col, insertMsg, pks); err != nil {
+				metrics.QueryNodeAutoIDViolations.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Inc()
+				log.Error("drop insert message violating AutoID contract",
+					zap.Int64("collectionID", insertMsg.CollectionID),
+					zap.Int64("segmentID", insertMsg.SegmentID),
+					zap.Int64("msgID", insertMsg.ID()),
+					zap.Int("numRows", len(pks)),
+					zap.Error(err))
+				continue
+			}
+		}
+
+		// check if segment exists, if not, create this segment
+		if !iNode.streamingReplica.hasSegment(insertMsg.SegmentID) {
+			err := iNode.streamingReplica.addSegment(insertMsg.SegmentID, insertMsg.PartitionID, insertMsg.CollectionID, insertMsg.ShardName, segmentTypeGrowing, true)
+			if err != nil {
+				log.Warn(err.Error())
+				continue
+			}
+		}
+
+		iData.insertIDs[insertMsg.SegmentID] = append(iData.insertIDs[insertMsg.SegmentID], insertMsg.RowIDs...)
+		iData.insertTimestamps[insertMsg.SegmentID] = append(iData.insertTimestamps[insertMsg.SegmentID], insertMsg.Timestamps...)
+		// using insertMsg.RowData is valid here, since we have already transferred the column-based data.
+		iData.insertRecords[insertMsg.SegmentID] = append(iData.insertRecords[insertMsg.SegmentID], insertMsg.RowData...)
 		iData.insertPKs[insertMsg.SegmentID] = append(iData.insertPKs[insertMsg.SegmentID], pks...)
+		iData.ingested = append(iData.ingested, ingestedMsg{rows: int64(len(pks)), bytes: int64(size), ts: insertMsg.EndTs()})
+		rows += len(pks)
+	}
+
+	if catchingUp && !iNode.catchUp.replay(iMsg.timeRange.timestampMax, rows, len(iMsg.deleteMessages) > 0) {
+		// tSafe is held until the batch is applied, keep the segments of batch from being reaped as idle meanwhile
+		for segmentID := range iData.insertRecords {
+			if segment, err := iNode.streamingReplica.getSegmentByID(segmentID); err == nil {
+				segment.touch()
+			}
+		}
+		return []Msg{}
 	}
 
 	// 2. do preInsert
@@ -156,13 +222,21 @@ func (iNode *insertNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 
 		var numOfRecords = len(iData.insertRecords[segmentID])
 		if targetSegment != nil {
+			if err := targetSegment.checkInsertRecords(iData.insertRecords[segmentID]); err != nil {
+				metrics.QueryNodeInvalidInsertPayloads.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Inc()
+				log.Error("drop inserts with invalid payload", zap.Int64("segmentID", segmentID), zap.Error(err))
+				delete(iData.insertRecords, segmentID)
+				continue
+			}
 			offset, err := targetSegment.segmentPreInsert(numOfRecords)
 			if err != nil {
 				log.Warn(err.Error())
 				continue
 			}
 			iData.insertOffset[segmentID] = offset
-			log.Debug("insertNode operator", zap.Int("insert size", numOfRecords), zap.Int64("insert offset", offset), zap.Int64("segment id", segmentID))
+			if !catchingUp {
+				log.Debug("insertNode operator", zap.Int("insert size", numOfRecords), zap.Int64("insert offset", offset), zap.Int64("segment id", segmentID))
+			}
 			targetSegment.updateBloomFilter(iData.insertPKs[segmentID])
 		}
 	}
@@ -171,47 +245,45 @@ func (iNode *insertNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 	wg := sync.WaitGroup{}
 	for segmentID := range iData.insertRecords {
 		wg.Add(1)
-		go iNode.insert(&iData, segmentID, &wg)
+		go iNode.insert(iData, segmentID, &wg)
 	}
 	wg.Wait()
+	iNode.ingestion.record(metrics.InsertLabel, iData.ingested, time.Now())
 
 	delData := &deleteData{
 		deleteIDs:        make(map[UniqueID][]primaryKey),
 		deleteTimestamps: make(map[UniqueID][]Timestamp),
-		deleteOffset:     make(map[UniqueID]int64),
 	}
-	// 1. filter segment by bloom filter
+	// 1. apply the pending deletes to the rows inserted later than them, and filter segment by bloom filter
+	iNode.applyPendingDeletes(iData, delData, iMsg.timeRange.timestampMin)
 	for _, delMsg := range iMsg.deleteMessages {
+		var unmatchedPKs []primaryKey
+		var unmatchedTss []Timestamp
 		if iNode.streamingReplica.getSegmentNum() != 0 {
 			log.Debug("delete in streaming replica",
 				zap.Any("collectionID", delMsg.CollectionID),
 				zap.Any("collectionName", delMsg.CollectionName),
 				zap.Int64("numPKs", delMsg.NumRows),
 				zap.Any("timestamp", delMsg.Timestamps))
-			processDeleteMessages(iNode.streamingReplica, delMsg, delData)
-		}
-	}
-
-	// 2. do preDelete
-	for segmentID, pks := range delData.deleteIDs {
-		segment, err := iNode.streamingReplica.getSegmentByID(segmentID)
-		if err != nil {
-			log.Debug(err.Error())
-			continue
+			unmatchedPKs, unmatchedTss = processDeleteMessages(iNode.streamingReplica, delMsg, delData)
+		} else {
+			unmatchedPKs, unmatchedTss = storage.ParseIDs2PrimaryKeys(delMsg.PrimaryKeys), delMsg.Timestamps
 		}
-		offset := segment.segmentPreDelete(len(pks))
-		delData.deleteOffset[segmentID] = offset
+		iNode.pendingDeletes.add(delMsg.CollectionID, delMsg.PartitionID, unmatchedPKs, unmatchedTss)
 	}
+	iNode.pendingDeletes.expire(iMsg.timeRange.timestampMax)
 
-	// 3. do delete
-	for segmentID := range delData.deleteOffset {
+	// 2. do delete, the delete records are reserved by every segment once its batch is checked
+	for segmentID := range delData.deleteIDs {
 		wg.Add(1)
 		go iNode.delete(delData, segmentID, &wg)
 	}
 	wg.Wait()
+	iNode.ingestion.record(metrics.DeleteLabel, ingestedDeletes(iMsg.deleteMessages), time.Now())
 
 	var res Msg = &serviceTimeMsg{
 		timeRange: iMsg.timeRange,
+		hasData:   len(iData.insertRecords) > 0 || len(iMsg.deleteMessages) > 0,
 	}
 	for _, sp := range spans {
 		sp.Finish()
@@ -220,8 +292,36 @@ func (iNode *insertNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 	return []Msg{res}
 }
 
-// processDeleteMessages would execute delete operations for growing segments
-func processDeleteMessages(replica ReplicaInterface, msg *msgstream.DeleteMsg, delData *deleteData) {
+// applyPendingDeletes adds the pending deletes of the rows inserted by iData to delData. The deletes of a segment
+// must be applied in timestamp order, so the pending ones are applied at ts, the beginning of the time range of
+// iData, which is later than the deletes applied before and not later than the deletes of the time range. The rows
+// are visible at the timestamps between the deletes and ts, as if the deletes arrived along with the rows.
+func (iNode *insertNode) applyPendingDeletes(iData *insertData, delData *deleteData, ts Timestamp) {
+	for segmentID := range iData.insertRecords {
+		segment, err := iNode.streamingReplica.getSegmentByID(segmentID)
+		if err != nil {
+			continue
+		}
+		pks, tss := iNode.pendingDeletes.match(segment.collectionID, segment.partitionID, iData.insertPKs[segmentID], iData.insertTimestamps[segmentID])
+		if len(pks) == 0 {
+			continue
+		}
+		for i := range tss {
+			if tss[i] < ts {
+				tss[i] = ts
+			}
+		}
+		log.Info("apply pending deletes to the rows inserted later",
+			zap.Int64("collectionID", segment.collectionID),
+			zap.Int64("segmentID", segmentID),
+			zap.Int("numPKs", len(pks)))
+		delData.deleteIDs[segmentID] = append(delData.deleteIDs[segmentID], pks...)
+		delData.deleteTimestamps[segmentID] = append(delData.deleteTimestamps[segmentID], tss...)
+	}
+}
+
+// processDeleteMessages would execute delete operations for growing segments, the pks matching no segment are returned
+func processDeleteMessages(replica ReplicaInterface, msg *msgstream.DeleteMsg, delData *deleteData) ([]primaryKey, []Timestamp) {
 	var partitionIDs []UniqueID
 	var err error
 	if msg.PartitionID != -1 {
@@ -230,7 +330,7 @@ func processDeleteMessages(replica ReplicaInterface, msg *msgstream.DeleteMsg, d
 		partitionIDs, err = replica.getPartitionIDs(msg.CollectionID)
 		if err != nil {
 			log.Warn(err.Error())
-			return
+			return nil, nil
 		}
 	}
 	resultSegmentIDs := make([]UniqueID, 0)
@@ -244,36 +344,63 @@ func processDeleteMessages(replica ReplicaInterface, msg *msgstream.DeleteMsg, d
 	}
 
 	primaryKeys := storage.ParseIDs2PrimaryKeys(msg.PrimaryKeys)
+	matched := make([]bool, len(primaryKeys))
 	for _, segmentID := range resultSegmentIDs {
 		segment, err := replica.getSegmentByID(segmentID)
 		if err != nil {
 			log.Warn(err.Error())
 			continue
 		}
-		pks, tss, err := filterSegmentsByPKs(primaryKeys, msg.Timestamps, segment)
+		indexes, err := matchSegmentPKs(primaryKeys, segment)
 		if err != nil {
 			log.Warn(err.Error())
 			continue
 		}
-		if len(pks) > 0 {
-			delData.deleteIDs[segmentID] = append(delData.deleteIDs[segmentID], pks...)
-			delData.deleteTimestamps[segmentID] = append(delData.deleteTimestamps[segmentID], tss...)
+		for _, index := range indexes {
+			matched[index] = true
+			delData.deleteIDs[segmentID] = append(delData.deleteIDs[segmentID], primaryKeys[index])
+			delData.deleteTimestamps[segmentID] = append(delData.deleteTimestamps[segmentID], msg.Timestamps[index])
 		}
 	}
+
+	var unmatchedPKs []primaryKey
+	var unmatchedTss []Timestamp
+	for index, pk := range primaryKeys {
+		if !matched[index] {
+			unmatchedPKs = append(unmatchedPKs, pk)
+			unmatchedTss = append(unmatchedTss, msg.Timestamps[index])
+		}
+	}
+	return unmatchedPKs, unmatchedTss
 }
 
 // filterSegmentsByPKs would filter segments by primary keys
 func filterSegmentsByPKs(pks []primaryKey, timestamps []Timestamp, segment *Segment) ([]primaryKey, []Timestamp, error) {
+	indexes, err := matchSegmentPKs(pks, segment)
+	if err != nil {
+		return nil, nil, err
+	}
+	retPks := make([]primaryKey, 0, len(indexes))
+	retTss := make([]Timestamp, 0, len(indexes))
+	for _, index := range indexes {
+		retPks = append(retPks, pks[index])
+		retTss = append(retTss, timestamps[index])
+	}
+	return retPks, retTss, nil
+}
+
+// matchSegmentPKs returns the indexes of the primary keys which may exist in segment
+func matchSegmentPKs(pks []primaryKey, segment *Segment) ([]int, error) {
 	if pks == nil {
-		return nil, nil, fmt.Errorf("pks is nil when getSegmentsByPKs")
+		return nil, fmt.Errorf("pks is nil when getSegmentsByPKs")
 	}
 	if segment == nil {
-		return nil, nil, fmt.Errorf("segments is nil when getSegmentsByPKs")
+		return nil, fmt.Errorf("segments is nil when getSegmentsByPKs")
 	}
 
-	retPks := make([]primaryKey, 0)
-	retTss := make([]Timestamp, 0)
+	indexes := make([]int, 0)
 	buf := make([]byte, 8)
+	pruned := 0
 	for index, pk := range pks {
 		exist := false
 		switch pk.Type() {
@@ -285,15 +412,22 @@ func filterSegmentsByPKs(pks []primaryKey, timestamps []Timestamp, segment *Segm
 			varCharPk := pk.(*varCharPrimaryKey)
 			exist = segment.pkFilter.TestString(varCharPk.Value)
 		default:
-			return nil, nil, fmt.Errorf("invalid data type of delete primary keys")
+			return nil, fmt.Errorf("invalid data type of delete primary keys")
+		}
+		if !exist {
+			pruned++
+		}
+		if exist && segment.hasPKIndex() {
+			// bloom filter may be false positive, check it by pk index
+			_, exist = segment.searchPK(pk)
 		}
 		if exist {
-			retPks = append(retPks, pk)
-			retTss = append(retTss, timestamps[index])
+			indexes = append(indexes, index)
 		}
 	}
-	log.Debug("In filterSegmentsByPKs", zap.Any("pk len", len(retPks)), zap.Any("segment", segment.segmentID))
-	return retPks, retTss, nil
+	segment.recordBloomFilterLookups(len(pks), pruned)
+	log.Debug("In filterSegmentsByPKs", zap.Any("pk len", len(indexes)), zap.Any("segment", segment.segmentID))
+	return indexes, nil
 }
 
 // insert would execute insert operations for specific growing segment
@@ -317,8 +451,22 @@ func (iNode *insertNode) insert(iData *insertData, segmentID UniqueID, wg *sync.
 	records := iData.insertRecords[segmentID]
 	offsets := iData.insertOffset[segmentID]
 
-	err = targetSegment.segmentInsert(offsets, &ids, &timestamps, &records)
+	err = targetSegment.guard(segmentOpInsert, func() error {
+		return cgoWritePool.run(func() error {
+			return targetSegment.segmentInsert(offsets, &ids, &timestamps, &records)
+		})
+	})
 	if err != nil {
+		if _, ok := iData.insertOffset[segmentID]; ok {
+			targetSegment.abandonReservation(offsets, int64(len(records)))
+		}
+		var sizeErr *insertPayloadSizeError
+		if errors.As(err, &sizeErr) {
+			metrics.QueryNodeInvalidInsertPayloads.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Inc()
+			log.Error("drop inserts with invalid payload", zap.Int64("segmentID", segmentID), zap.Error(err))
+			wg.Done()
+			return
+		}
 		log.Debug("QueryNode: targetSegmentInsert failed", zap.Error(err))
 		// TODO: add error handling
 		wg.Done()
@@ -335,7 +483,7 @@ func (iNode *insertNode) delete(deleteData *deleteData, segmentID UniqueID, wg *
 	log.Debug("QueryNode::iNode::delete", zap.Any("SegmentID", segmentID))
 	targetSegment, err := iNode.streamingReplica.getSegmentByID(segmentID)
 	if err != nil {
-		log.Error(err.Error())
+		log.Debug(err.Error())
 		return
 	}
 
@@ -345,10 +493,12 @@ func (iNode *insertNode) delete(deleteData *deleteData, segmentID UniqueID, wg *
 
 	ids := deleteData.deleteIDs[segmentID]
 	timestamps := deleteData.deleteTimestamps[segmentID]
-	offset := deleteData.deleteOffset[segmentID]
 
-	err = targetSegment.segmentDelete(offset, ids, timestamps)
-	if err != nil {
+	err = targetSegment.applyDeletes(ids, timestamps, false)
+	var staleErr *staleDeleteError
+	if errors.As(err, &staleErr) {
+		log.Warn("QueryNode: stale delete records are dropped, the others are applied", zap.Int64("segmentID", segmentID), zap.Error(err))
+	} else if err != nil {
 		log.Warn("QueryNode: targetSegmentDelete failed", zap.Error(err))
 		return
 	}
@@ -356,6 +506,81 @@ func (iNode *insertNode) delete(deleteData *deleteData, segmentID UniqueID, wg *
 	log.Debug("Do delete done", zap.Int("len", len(deleteData.deleteIDs[segmentID])), zap.Int64("segmentID", segmentID))
 }
 
+// checkInsertPayload checks the sizes of the vector payloads of an insert message against the dims in the schema,
+// so that a corrupted message never makes segcore write past the column boundary.
+// Column based messages are checked per vector field, row based messages by the size of the first row,
+// which is also the size of the other rows as segcore sees them.
+func checkInsertPayload(col *Collection, msg *msgstream.InsertMsg) error {
+	if !msg.IsColumnBased() {
+		if len(msg.RowData) == 0 {
+			return nil
+		}
+		expected := col.rowSize()
+		if actual := int64(len(msg.RowData[0].GetValue())); expected > 0 && actual != expected {
+			return &insertPayloadSizeError{collectionID: col.ID(), field: "row", expected: expected, actual: actual}
+		}
+		return nil
+	}
+
+	numRows := int64(msg.NRows())
+	for _, fieldData := range msg.FieldsData {
+		var actual int64
+		switch fieldData.GetType() {
+		case schemapb.DataType_FloatVector:
+			actual = int64(len(fieldData.GetVectors().GetFloatVector().GetData())) * 4
+		case schemapb.DataType_BinaryVector:
+			actual = int64(len(fieldData.GetVectors().GetBinaryVector()))
+		default:
+			continue
+		}
+		field, err := col.getFieldByID(fieldData.GetFieldId())
+		if err != nil {
+			return err
+		}
+		if expected := numRows * field.estimateSize(0); actual != expected {
+			return &insertPayloadSizeError{
+				collectionID: col.ID(),
+				field:        fmt.Sprintf("field %s(%d)", field.schema.GetName(), fieldData.GetFieldId()),
+				expected:     expected,
+				actual:       actual,
+			}
+		}
+	}
+	return nil
+}
+
+// checkAutoIDPrimaryKeys checks the pks of an insert message into AutoID collection are allocated by the coordinator,
+// i.e. the pks are the row IDs announced in the message, which are positive and increasing within a message
+func checkAutoIDPrimaryKeys(col *Collection, msg *msgstream.InsertMsg, pks []primaryKey) error {
+	pkField, err := col.getPKField()
+	if err != nil {
+		return err
+	}
+	if !pkField.schema.GetAutoID() {
+		return nil
+	}
+	if len(pks) != len(msg.RowIDs) {
+		return fmt.Errorf("%d pks mismatch %d row IDs", len(pks), len(msg.RowIDs))
+	}
+	var prev int64
+	for i, pk := range pks {
+		int64Pk, ok := pk.(*int64PrimaryKey)
+		if !ok {
+			return fmt.Errorf("invalid AutoID pk type %s", pk.Type().String())
+		}
+		switch {
+		case int64Pk.Value <= 0:
+			return fmt.Errorf("non-positive pk %d at row %d", int64Pk.Value, i)
+		case i > 0 && int64Pk.Value <= prev:
+			return fmt.Errorf("pk %d at row %d is not greater than the previous pk %d", int64Pk.Value, i, prev)
+		case int64Pk.Value != msg.RowIDs[i]:
+			return fmt.Errorf("pk %d at row %d mismatches the allocated row ID %d", int64Pk.Value, i, msg.RowIDs[i])
+		}
+		prev = int64Pk.Value
+	}
+	return nil
+}
+
 // TODO: remove this function to proper file
 // getPrimaryKeys would get primary keys by insert messages
 func getPrimaryKeys(msg *msgstream.InsertMsg, streamingReplica ReplicaInterface) ([]primaryKey, error) {
@@ -371,19 +596,19 @@ func getPrimaryKeys(msg *msgstream.InsertMsg, streamingReplica ReplicaInterface)
 		return nil, err
 	}
 
-	return getPKs(msg, collection.schema)
+	return getPKs(msg, collection)
 }
 
-func getPKs(msg *msgstream.InsertMsg, schema *schemapb.CollectionSchema) ([]primaryKey, error) {
+func getPKs(msg *msgstream.InsertMsg, collection *Collection) ([]primaryKey, error) {
 	if msg.IsRowBased() {
-		return getPKsFromRowBasedInsertMsg(msg, schema)
+		return getPKsFromRowBasedInsertMsg(msg, collection)
 	}
-	return getPKsFromColumnBasedInsertMsg(msg, schema)
+	return getPKsFromColumnBasedInsertMsg(msg, collection)
 }
 
-func getPKsFromRowBasedInsertMsg(msg *msgstream.InsertMsg, schema *schemapb.CollectionSchema) ([]primaryKey, error) {
+func getPKsFromRowBasedInsertMsg(msg *msgstream.InsertMsg, collection *Collection) ([]primaryKey, error) {
 	offset := 0
-	for _, field := range schema.Fields {
+	for _, field := range collection.Schema().GetFields() {
 		if field.IsPrimaryKey {
 			break
 		}
@@ -402,29 +627,20 @@ func getPKsFromRowBasedInsertMsg(msg *msgstream.InsertMsg, schema *schemapb.Coll
 			offset += 4
 		case schemapb.DataType_Double:
 			offset += 8
-		case schemapb.DataType_FloatVector:
-			for _, t := range field.TypeParams {
-				if t.Key == "dim" {
-					dim, err := strconv.Atoi(t.Value)
-					if err != nil {
-						log.Error("strconv wrong on get dim", zap.Error(err))
-						break
-					}
-					offset += dim * 4
-					break
-				}
+		case schemapb.DataType_FloatVector, schemapb.DataType_BinaryVector:
+			vecField, err := collection.getFieldByID(field.FieldID)
+			if err != nil {
+				return nil, err
 			}
-		case schemapb.DataType_BinaryVector:
-			for _, t := range field.TypeParams {
-				if t.Key == "dim" {
-					dim, err := strconv.Atoi(t.Value)
-					if err != nil {
-						log.Error("strconv wrong on get dim", zap.Error(err))
-						return nil, err
-					}
-					offset += dim / 8
-					break
-				}
+			if vecField.dim <= 0 {
+				err = fmt.Errorf("invalid dim %d of vector field %d", vecField.dim, field.FieldID)
+				log.Error("failed to get dim", zap.Error(err))
+				return nil, err
+			}
+			if field.DataType == schemapb.DataType_FloatVector {
+				offset += int(vecField.dim) * 4
+			} else {
+				offset += int(vecField.dim) / 8
 			}
 		}
 	}
@@ -448,13 +664,13 @@ func getPKsFromRowBasedInsertMsg(msg *msgstream.InsertMsg, schema *schemapb.Coll
 	return pks, nil
 }
 
-func getPKsFromColumnBasedInsertMsg(msg *msgstream.InsertMsg, schema *schemapb.CollectionSchema) ([]primaryKey, error) {
-	primaryFieldSchema, err := typeutil.GetPrimaryFieldSchema(schema)
+func getPKsFromColumnBasedInsertMsg(msg *msgstream.InsertMsg, collection *Collection) ([]primaryKey, error) {
+	pkField, err := collection.getPKField()
 	if err != nil {
 		return nil, err
 	}
 
-	primaryFieldData, err := typeutil.GetPrimaryFieldData(msg.GetFieldsData(), primaryFieldSchema)
+	primaryFieldData, err := typeutil.GetPrimaryFieldData(msg.GetFieldsData(), pkField.schema)
 	if err != nil {
 		return nil, err
 	}
@@ -479,5 +695,11 @@ func newInsertNode(streamingReplica ReplicaInterface) *insertNode {
 	return &insertNode{
 		baseNode:         baseNode,
 		streamingReplica: streamingReplica,
+		pendingDeletes:   newPendingDeletes(Params.QueryNodeCfg.PendingDeleteWindow, Params.QueryNodeCfg.PendingDeleteMaxSize),
 	}
 }
+
+// Close removes the pending deletes of channel
+func (iNode *insertNode) Close() {
+	iNode.pendingDeletes.clear()
+}
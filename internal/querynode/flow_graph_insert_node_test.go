@@ -17,16 +17,21 @@
 package querynode
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
 
 	"github.com/bits-and-blooms/bloom/v3"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/mq/msgstream"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/flowgraph"
@@ -67,9 +72,6 @@ func genFlowGraphDeleteData() (*deleteData, error) {
 		deleteTimestamps: map[UniqueID][]Timestamp{
 			defaultSegmentID: deleteMsg.Timestamps,
 		},
-		deleteOffset: map[UniqueID]int64{
-			defaultSegmentID: 0,
-		},
 	}
 	return dData, nil
 }
@@ -369,6 +371,172 @@ func TestFlowGraphInsertNode_operate(t *testing.T) {
 	})
 }
 
+func TestFlowGraphInsertNode_autoID(t *testing.T) {
+	// pks of the simple insert message are the same as row IDs, starting from 0
+	genAutoIDInsertMsg := func(t *testing.T) *msgstream.InsertMsg {
+		msg, err := genSimpleInsertMsg()
+		require.NoError(t, err)
+		msg.RowIDs = msg.RowIDs[1:]
+		msg.Timestamps = msg.Timestamps[1:]
+		msg.RowData = msg.RowData[1:]
+		return msg
+	}
+	operate := func(t *testing.T, msg *msgstream.InsertMsg) *Segment {
+		streaming, err := genSimpleReplica()
+		require.NoError(t, err)
+		col, err := streaming.getCollectionByID(defaultCollectionID)
+		require.NoError(t, err)
+		pkField, err := col.getPKField()
+		require.NoError(t, err)
+		pkField.schema.AutoID = true
+
+		insertNode := newInsertNode(streaming)
+		insertNode.Operate([]flowgraph.Msg{&insertMsg{insertMessages: []*msgstream.InsertMsg{msg}}})
+		if !streaming.hasSegment(defaultSegmentID) {
+			return nil
+		}
+		segment, err := streaming.getSegmentByID(defaultSegmentID)
+		require.NoError(t, err)
+		return segment
+	}
+	violations := metrics.QueryNodeAutoIDViolations.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID))
+
+	t.Run("valid pks", func(t *testing.T) {
+		before := testutil.ToFloat64(violations)
+		segment := operate(t, genAutoIDInsertMsg(t))
+		require.NotNil(t, segment)
+		assert.Equal(t, int64(defaultMsgLength-1), segment.getRowCount())
+		assert.Equal(t, before, testutil.ToFloat64(violations))
+	})
+
+	t.Run("violating pks", func(t *testing.T) {
+		zeroPK, err := genSimpleInsertMsg()
+		require.NoError(t, err)
+		mismatched := genAutoIDInsertMsg(t)
+		mismatched.RowIDs[10] += defaultMsgLength
+		unordered := genAutoIDInsertMsg(t)
+		unordered.RowIDs[1], unordered.RowIDs[2] = unordered.RowIDs[2], unordered.RowIDs[1]
+		unordered.RowData[1], unordered.RowData[2] = unordered.RowData[2], unordered.RowData[1]
+
+		for name, msg := range map[string]*msgstream.InsertMsg{
+			"zero pk":       zeroPK,
+			"mismatched pk": mismatched,
+			"unordered pks": unordered,
+		} {
+			before := testutil.ToFloat64(violations)
+			assert.Nil(t, operate(t, msg), name)
+			assert.Equal(t, before+1, testutil.ToFloat64(violations), name)
+		}
+	})
+
+	t.Run("validation disabled", func(t *testing.T) {
+		Params.QueryNodeCfg.ValidateAutoID = false
+		defer func() { Params.QueryNodeCfg.ValidateAutoID = true }()
+		msg, err := genSimpleInsertMsg()
+		require.NoError(t, err)
+		segment := operate(t, msg)
+		require.NotNil(t, segment)
+		assert.Equal(t, int64(defaultMsgLength), segment.getRowCount())
+	})
+
+	t.Run("not AutoID collection", func(t *testing.T) {
+		col := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+		msg, err := genSimpleInsertMsg()
+		require.NoError(t, err)
+		pks, err := getPKs(msg, col)
+		require.NoError(t, err)
+		assert.NoError(t, checkAutoIDPrimaryKeys(col, msg, pks))
+	})
+}
+
+func TestFlowGraphInsertNode_checkInsertPayload(t *testing.T) {
+	const rows = 10
+	col := &Collection{id: defaultCollectionID}
+	col.updateSchema(&schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "float_vec", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: dimKey, Value: "16"}}},
+			{FieldID: 102, Name: "binary_vec", DataType: schemapb.DataType_BinaryVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: dimKey, Value: "64"}}},
+		},
+	})
+	genMsg := func(floats []float32, bytes []byte) *msgstream.InsertMsg {
+		return &msgstream.InsertMsg{
+			InsertRequest: internalpb.InsertRequest{
+				Version: internalpb.InsertDataVersion_ColumnBased,
+				NumRows: rows,
+				FieldsData: []*schemapb.FieldData{
+					{Type: schemapb.DataType_Int64, FieldId: 100},
+					{Type: schemapb.DataType_FloatVector, FieldId: 101, Field: &schemapb.FieldData_Vectors{
+						Vectors: &schemapb.VectorField{Dim: 16, Data: &schemapb.VectorField_FloatVector{
+							FloatVector: &schemapb.FloatArray{Data: floats}}}}},
+					{Type: schemapb.DataType_BinaryVector, FieldId: 102, Field: &schemapb.FieldData_Vectors{
+						Vectors: &schemapb.VectorField{Dim: 64, Data: &schemapb.VectorField_BinaryVector{
+							BinaryVector: bytes}}}},
+				},
+			},
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, checkInsertPayload(col, genMsg(make([]float32, rows*16), make([]byte, rows*8))))
+	})
+
+	t.Run("corrupted float vector", func(t *testing.T) {
+		err := checkInsertPayload(col, genMsg(make([]float32, rows*16-3), make([]byte, rows*8)))
+		var sizeErr *insertPayloadSizeError
+		require.True(t, errors.As(err, &sizeErr))
+		assert.Equal(t, "field float_vec(101)", sizeErr.field)
+		assert.Equal(t, int64(rows*16*4), sizeErr.expected)
+		assert.Equal(t, int64((rows*16-3)*4), sizeErr.actual)
+	})
+
+	t.Run("corrupted binary vector", func(t *testing.T) {
+		err := checkInsertPayload(col, genMsg(make([]float32, rows*16), make([]byte, rows*8+1)))
+		var sizeErr *insertPayloadSizeError
+		require.True(t, errors.As(err, &sizeErr))
+		assert.Equal(t, "field binary_vec(102)", sizeErr.field)
+		assert.Equal(t, int64(rows*8), sizeErr.expected)
+		assert.Equal(t, int64(rows*8+1), sizeErr.actual)
+	})
+
+	t.Run("field not found", func(t *testing.T) {
+		msg := genMsg(make([]float32, rows*16), make([]byte, rows*8))
+		msg.FieldsData[1].FieldId = 103
+		assert.Error(t, checkInsertPayload(col, msg))
+	})
+
+	t.Run("row based", func(t *testing.T) {
+		msg := &msgstream.InsertMsg{
+			InsertRequest: internalpb.InsertRequest{
+				Version: internalpb.InsertDataVersion_RowBased,
+				RowData: []*commonpb.Blob{{Value: make([]byte, 8+16*4+8)}},
+			},
+		}
+		assert.NoError(t, checkInsertPayload(col, msg))
+		msg.RowData[0].Value = msg.RowData[0].Value[:8+16*4]
+		var sizeErr *insertPayloadSizeError
+		require.True(t, errors.As(checkInsertPayload(col, msg), &sizeErr))
+		assert.Equal(t, "row", sizeErr.field)
+	})
+
+	t.Run("dropped by operate", func(t *testing.T) {
+		streaming, err := genSimpleReplica()
+		require.NoError(t, err)
+		msg, err := genSimpleInsertMsg()
+		require.NoError(t, err)
+		msg.RowData[0].Value = msg.RowData[0].Value[4:]
+		invalid := metrics.QueryNodeInvalidInsertPayloads.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID))
+		before := testutil.ToFloat64(invalid)
+
+		insertNode := newInsertNode(streaming)
+		insertNode.Operate([]flowgraph.Msg{&insertMsg{insertMessages: []*msgstream.InsertMsg{msg}}})
+		assert.False(t, streaming.hasSegment(defaultSegmentID))
+		assert.Equal(t, before+1, testutil.ToFloat64(invalid))
+	})
+}
+
 func TestFilterSegmentsByPKs(t *testing.T) {
 	t.Run("filter int64 pks", func(t *testing.T) {
 		buf := make([]byte, 8)
@@ -388,10 +556,15 @@ func TestFilterSegmentsByPKs(t *testing.T) {
 		pk3 := newInt64PrimaryKey(3)
 		pk4 := newInt64PrimaryKey(4)
 
+		pruned := metrics.QueryNodeBloomFilterPrunedPKs.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID))
+		before := testutil.ToFloat64(pruned)
 		timestamps := []uint64{1, 1, 1, 1, 1}
 		pks, _, err := filterSegmentsByPKs([]primaryKey{pk0, pk1, pk2, pk3, pk4}, timestamps, segment)
 		assert.Nil(t, err)
 		assert.Equal(t, len(pks), 3)
+		assert.Equal(t, int64(5), segment.bloomFilterLookups.Load())
+		assert.Equal(t, int64(2), segment.bloomFilterPruned.Load())
+		assert.Equal(t, before+2, testutil.ToFloat64(pruned))
 
 		pks, _, err = filterSegmentsByPKs([]primaryKey{}, timestamps, segment)
 		assert.Nil(t, err)
@@ -402,6 +575,26 @@ func TestFilterSegmentsByPKs(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 
+	t.Run("filter int64 pks with pk index", func(t *testing.T) {
+		buf := make([]byte, 8)
+		filter := bloom.NewWithEstimates(1000000, 0.01)
+		for i := 0; i < 3; i++ {
+			common.Endian.PutUint64(buf, uint64(i))
+			filter.Add(buf)
+		}
+		segment := &Segment{
+			segmentID: 1,
+			pkFilter:  filter,
+		}
+		// pk 2 was a bloom filter hit but doesn't exist in the segment
+		segment.setPKIndex(newInt64PkIndex([]int64{0, 1}))
+
+		timestamps := []uint64{1, 1, 1, 1}
+		pks, _, err := filterSegmentsByPKs([]primaryKey{newInt64PrimaryKey(0), newInt64PrimaryKey(1), newInt64PrimaryKey(2), newInt64PrimaryKey(3)}, timestamps, segment)
+		assert.Nil(t, err)
+		assert.Equal(t, []primaryKey{newInt64PrimaryKey(0), newInt64PrimaryKey(1)}, pks)
+	})
+
 	t.Run("filter varChar pks", func(t *testing.T) {
 		filter := bloom.NewWithEstimates(1000000, 0.01)
 		for i := 0; i < 3; i++ {
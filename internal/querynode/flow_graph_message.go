@@ -43,6 +43,8 @@ type deleteMsg struct {
 // serviceTimeMsg is an implementation of interface Msg
 type serviceTimeMsg struct {
 	timeRange TimeRange
+	// whether any data message is applied since the previous time tick
+	hasData bool
 }
 
 // TimeTick returns timestamp of insertMsg
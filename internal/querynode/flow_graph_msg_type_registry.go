@@ -0,0 +1,116 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+)
+
+// unsupportedMsgWarnInterval is the minimal interval between two warnings of the same unsupported message type
+const unsupportedMsgWarnInterval = time.Minute
+
+// ignoredMsgTypes are the message types expected on the DML channels but irrelevant to QueryNode,
+// they are dropped silently
+var ignoredMsgTypes = map[commonpb.MsgType]struct{}{
+	commonpb.MsgType_CreateCollection: {},
+	commonpb.MsgType_DropCollection:   {},
+	commonpb.MsgType_CreatePartition:  {},
+	commonpb.MsgType_DropPartition:    {},
+	commonpb.MsgType_TimeTick:         {},
+}
+
+// msgTypeRegistry classifies the types of the messages consumed by a filter node:
+//   - handled, the messages are filtered and passed downstream
+//   - ignored, see ignoredMsgTypes
+//   - unsupported, the types defined in commonpb.MsgType but not handled, the messages are counted and
+//     warned at most once per unsupportedMsgWarnInterval per type
+//   - unknown, the type IDs not defined in commonpb.MsgType, the messages are counted and logged at error
+//     level once per type
+//
+// The unsupported and unknown messages are dropped, or fail the flow graph in strict mode.
+type msgTypeRegistry struct {
+	handled      map[commonpb.MsgType]struct{}
+	strict       bool
+	warnInterval time.Duration
+	logger       *zap.Logger
+
+	mu            sync.Mutex
+	lastWarned    map[commonpb.MsgType]time.Time
+	loggedUnknown map[commonpb.MsgType]struct{}
+}
+
+// newMsgTypeRegistry returns a new msgTypeRegistry of the filter node consuming channel
+func newMsgTypeRegistry(nodeName string, channel Channel, handled ...commonpb.MsgType) *msgTypeRegistry {
+	r := &msgTypeRegistry{
+		handled:       make(map[commonpb.MsgType]struct{}, len(handled)),
+		strict:        Params.QueryNodeCfg.StrictMsgType,
+		warnInterval:  unsupportedMsgWarnInterval,
+		logger:        log.L().With(zap.String("node", nodeName), zap.String("channel", channel)),
+		lastWarned:    make(map[commonpb.MsgType]time.Time),
+		loggedUnknown: make(map[commonpb.MsgType]struct{}),
+	}
+	for _, msgType := range handled {
+		r.handled[msgType] = struct{}{}
+	}
+	return r
+}
+
+// isHandled returns whether the messages of msgType are handled by the node, the others are dropped.
+// In strict mode, it panics on the unsupported and unknown types to fail the flow graph.
+func (r *msgTypeRegistry) isHandled(msgType commonpb.MsgType) bool {
+	if _, ok := r.handled[msgType]; ok {
+		return true
+	}
+	if _, ok := ignoredMsgTypes[msgType]; ok {
+		return false
+	}
+
+	metrics.QueryNodeUnsupportedMsgs.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), msgType.String()).Inc()
+	_, known := commonpb.MsgType_name[int32(msgType)]
+	if r.strict {
+		r.logger.Panic("unsupported message type in strict mode",
+			zap.Int32("msgType", int32(msgType)),
+			zap.String("msgTypeName", msgType.String()),
+			zap.Bool("known", known))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !known {
+		if _, ok := r.loggedUnknown[msgType]; !ok {
+			r.loggedUnknown[msgType] = struct{}{}
+			r.logger.Error("drop messages of unknown type", zap.Int32("msgType", int32(msgType)))
+		}
+		return false
+	}
+	now := time.Now()
+	if now.Sub(r.lastWarned[msgType]) >= r.warnInterval {
+		r.lastWarned[msgType] = now
+		r.logger.Warn("drop messages of unsupported type",
+			zap.Int32("msgType", int32(msgType)),
+			zap.String("msgTypeName", msgType.String()))
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/util/flowgraph"
+)
+
+// typedMsg overrides the type of the wrapped message
+type typedMsg struct {
+	msgstream.TsMsg
+	msgType commonpb.MsgType
+}
+
+func (m *typedMsg) Type() commonpb.MsgType {
+	return m.msgType
+}
+
+func observeMsgTypeRegistry(r *msgTypeRegistry) *observer.ObservedLogs {
+	core, logs := observer.New(zapcore.DebugLevel)
+	r.logger = zap.New(core)
+	return logs
+}
+
+func unsupportedMsgsCount(msgType commonpb.MsgType) float64 {
+	return testutil.ToFloat64(metrics.QueryNodeUnsupportedMsgs.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), msgType.String()))
+}
+
+func TestMsgTypeRegistry_isHandled(t *testing.T) {
+	r := newMsgTypeRegistry("test-node", defaultDMLChannel, commonpb.MsgType_Insert)
+	logs := observeMsgTypeRegistry(r)
+
+	t.Run("handled", func(t *testing.T) {
+		assert.True(t, r.isHandled(commonpb.MsgType_Insert))
+		assert.Equal(t, 0, logs.Len())
+	})
+
+	t.Run("ignored", func(t *testing.T) {
+		before := unsupportedMsgsCount(commonpb.MsgType_DropCollection)
+		assert.False(t, r.isHandled(commonpb.MsgType_DropCollection))
+		assert.Equal(t, before, unsupportedMsgsCount(commonpb.MsgType_DropCollection))
+		assert.Equal(t, 0, logs.Len())
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		before := unsupportedMsgsCount(commonpb.MsgType_Delete)
+		for i := 0; i < 3; i++ {
+			assert.False(t, r.isHandled(commonpb.MsgType_Delete))
+		}
+		assert.Equal(t, before+3, unsupportedMsgsCount(commonpb.MsgType_Delete))
+		warnings := logs.FilterMessage("drop messages of unsupported type").TakeAll()
+		require.Len(t, warnings, 1)
+		assert.Equal(t, zapcore.WarnLevel, warnings[0].Level)
+		assert.Equal(t, "Delete", warnings[0].ContextMap()["msgTypeName"])
+
+		// warned again after the interval
+		r.warnInterval = time.Millisecond
+		time.Sleep(2 * time.Millisecond)
+		assert.False(t, r.isHandled(commonpb.MsgType_Delete))
+		assert.Equal(t, 1, logs.FilterMessage("drop messages of unsupported type").Len())
+		logs.TakeAll()
+		r.warnInterval = unsupportedMsgWarnInterval
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		msgType := commonpb.MsgType(4242)
+		before := unsupportedMsgsCount(msgType)
+		for i := 0; i < 3; i++ {
+			assert.False(t, r.isHandled(msgType))
+		}
+		assert.Equal(t, before+3, unsupportedMsgsCount(msgType))
+		errors := logs.FilterMessage("drop messages of unknown type").TakeAll()
+		require.Len(t, errors, 1)
+		assert.Equal(t, zapcore.ErrorLevel, errors[0].Level)
+		assert.Equal(t, int32(4242), errors[0].ContextMap()["msgType"])
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		r.strict = true
+		defer func() { r.strict = false }()
+		assert.True(t, r.isHandled(commonpb.MsgType_Insert))
+		assert.False(t, r.isHandled(commonpb.MsgType_TimeTick))
+		assert.Panics(t, func() { r.isHandled(commonpb.MsgType_Delete) })
+		assert.Panics(t, func() { r.isHandled(commonpb.MsgType(4242)) })
+	})
+}
+
+func TestFlowGraphFilterDmNode_unknownMsgType(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fg, err := getFilterDMNode(ctx)
+	require.NoError(t, err)
+	logs := observeMsgTypeRegistry(fg.msgTypes)
+
+	iMsg, err := genSimpleInsertMsg()
+	require.NoError(t, err)
+	// e.g. upsert messages produced by newer versions
+	msgType := commonpb.MsgType(4243)
+	unknown := &typedMsg{TsMsg: iMsg, msgType: msgType}
+	before := unsupportedMsgsCount(msgType)
+
+	for i := 0; i < 2; i++ {
+		msg := flowgraph.GenerateMsgStreamMsg([]msgstream.TsMsg{unknown, iMsg}, 0, 1000, nil, nil)
+		res := fg.Operate([]flowgraph.Msg{msg})
+		require.Len(t, res, 1)
+		assert.Len(t, res[0].(*insertMsg).insertMessages, 1)
+	}
+	assert.Equal(t, before+2, unsupportedMsgsCount(msgType))
+	assert.Equal(t, 1, logs.FilterMessage("drop messages of unknown type").Len())
+
+	t.Run("strict", func(t *testing.T) {
+		fg.msgTypes.strict = true
+		msg := flowgraph.GenerateMsgStreamMsg([]msgstream.TsMsg{unknown}, 0, 1000, nil, nil)
+		assert.Panics(t, func() { fg.Operate([]flowgraph.Msg{msg}) })
+	})
+}
+
+func TestFlowGraphFilterDeleteNode_unsupportedMsgType(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fg, err := getFilterDeleteNode(ctx)
+	require.NoError(t, err)
+	logs := observeMsgTypeRegistry(fg.msgTypes)
+
+	iMsg, err := genSimpleInsertMsg()
+	require.NoError(t, err)
+	before := unsupportedMsgsCount(commonpb.MsgType_Insert)
+	msg := flowgraph.GenerateMsgStreamMsg([]msgstream.TsMsg{iMsg, iMsg}, 0, 1000, nil, nil)
+	res := fg.Operate([]flowgraph.Msg{msg})
+	require.Len(t, res, 1)
+	assert.Empty(t, res[0].(*deleteMsg).deleteMessages)
+	assert.Equal(t, before+2, unsupportedMsgsCount(commonpb.MsgType_Insert))
+	assert.Equal(t, 1, logs.FilterMessage("drop messages of unsupported type").Len())
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -39,6 +40,9 @@ type queryNodeFlowGraph struct {
 	channel      Channel
 	flowGraph    *flowgraph.TimeTickedFlowGraph
 	dmlStream    msgstream.MsgStream
+	dmInputNode  *flowgraph.InputNode
+	insertNode   *insertNode // nil for delta flow graph
+	ingestion    *channelIngestion
 	consumerCnt  int
 }
 
@@ -58,15 +62,18 @@ func newQueryNodeFlowGraph(ctx context.Context,
 		collectionID: collectionID,
 		channel:      channel,
 		flowGraph:    flowgraph.NewTimeTickedFlowGraph(ctx1),
+		ingestion:    newChannelIngestion(channel),
 	}
 
 	dmStreamNode, err := q.newDmInputNode(ctx1, factory)
 	if err != nil {
 		return nil, err
 	}
-	var filterDmNode node = newFilteredDmNode(streamingReplica, collectionID)
-	var insertNode node = newInsertNode(streamingReplica)
+	var filterDmNode node = newFilteredDmNode(streamingReplica, collectionID, channel)
+	insertNode := newInsertNode(streamingReplica)
+	insertNode.ingestion = q.ingestion
 	var serviceTimeNode node = newServiceTimeNode(tSafeReplica, collectionID, channel)
+	q.insertNode = insertNode
 
 	q.flowGraph.AddNode(dmStreamNode)
 	q.flowGraph.AddNode(filterDmNode)
@@ -128,14 +135,17 @@ func newQueryNodeDeltaFlowGraph(ctx context.Context,
 		collectionID: collectionID,
 		channel:      channel,
 		flowGraph:    flowgraph.NewTimeTickedFlowGraph(ctx1),
+		ingestion:    newChannelIngestion(channel),
 	}
 
 	dmStreamNode, err := q.newDmInputNode(ctx1, factory)
 	if err != nil {
 		return nil, err
 	}
-	var filterDeleteNode node = newFilteredDeleteNode(historicalReplica, collectionID)
-	var deleteNode node = newDeleteNode(historicalReplica)
+	var filterDeleteNode node = newFilteredDeleteNode(historicalReplica, collectionID, channel)
+	dNode := newDeleteNode(historicalReplica)
+	dNode.ingestion = q.ingestion
+	var deleteNode node = dNode
 	var serviceTimeNode node = newServiceTimeNode(tSafeReplica, collectionID, channel)
 
 	q.flowGraph.AddNode(dmStreamNode)
@@ -195,6 +205,7 @@ func (q *queryNodeFlowGraph) newDmInputNode(ctx context.Context, factory msgstre
 	maxParallelism := Params.QueryNodeCfg.FlowGraphMaxParallelism
 
 	node := flowgraph.NewInputNode(insertStream, "dmlInputNode", maxQueueLength, maxParallelism)
+	q.dmInputNode = node
 	return node, nil
 }
 
@@ -240,13 +251,62 @@ func (q *queryNodeFlowGraph) seekQueryNodeFlowGraph(position *internalpb.MsgPosi
 	)
 	q.consumerCnt++
 	metrics.QueryNodeNumConsumers.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Inc()
+	if err == nil && q.insertNode != nil {
+		// replay the backlog since an old checkpoint in catch-up mode
+		q.insertNode.catchUp.start(position.GetTimestamp())
+	}
 	return err
 }
 
+// pause stops the flow graph from consuming new messages, so that the tSafe of the channel freezes,
+// returns false if the flow graph has been paused
+func (q *queryNodeFlowGraph) pause() bool {
+	if q.dmInputNode == nil || !q.dmInputNode.Pause() {
+		return false
+	}
+	log.Info("pause query node flow graph",
+		zap.Any("collectionID", q.collectionID),
+		zap.Any("channel", q.channel),
+	)
+	return true
+}
+
+// resume lets a paused flow graph continue consuming from where it was paused,
+// returns false if the flow graph is not paused
+func (q *queryNodeFlowGraph) resume() bool {
+	if q.dmInputNode == nil || !q.dmInputNode.Resume() {
+		return false
+	}
+	log.Info("resume query node flow graph",
+		zap.Any("collectionID", q.collectionID),
+		zap.Any("channel", q.channel),
+	)
+	return true
+}
+
+// isPaused returns whether the flow graph is paused
+func (q *queryNodeFlowGraph) isPaused() bool {
+	return q.dmInputNode != nil && q.dmInputNode.IsPaused()
+}
+
+// getCatchUpProgress returns the progress of replaying the backlog, false for the flow graph without insert node
+func (q *queryNodeFlowGraph) getCatchUpProgress() (catchUpProgress, bool) {
+	if q.insertNode == nil {
+		return catchUpProgress{}, false
+	}
+	return q.insertNode.catchUp.getProgress(), true
+}
+
+// getIngestionRates returns the insert and delete rates of the channel
+func (q *queryNodeFlowGraph) getIngestionRates() (ingestionRate, ingestionRate) {
+	return q.ingestion.rates(time.Now())
+}
+
 // close would close queryNodeFlowGraph
 func (q *queryNodeFlowGraph) close() {
 	q.cancel()
 	q.flowGraph.Close()
+	q.ingestion.close()
 	if q.dmlStream != nil && q.consumerCnt > 0 {
 		metrics.QueryNodeNumConsumers.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Sub(float64(q.consumerCnt))
 	}
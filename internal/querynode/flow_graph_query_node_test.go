@@ -18,10 +18,16 @@ package querynode
 
 import (
 	"context"
+	"fmt"
+	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 )
 
@@ -80,3 +86,84 @@ func TestQueryNodeFlowGraph_seekQueryNodeFlowGraph(t *testing.T) {
 
 	fg.close()
 }
+
+func TestQueryNodeFlowGraph_pauseResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	channel := fmt.Sprintf("%s-pause-%d", defaultDMLChannel, rand.Int())
+	tSafe := newTSafeReplica()
+	tSafe.addTSafe(channel)
+
+	streamingReplica, err := genSimpleReplica()
+	require.NoError(t, err)
+	streamingReplica.addExcludedSegments(defaultCollectionID, nil)
+
+	fac := genFactory()
+	fg, err := newQueryNodeFlowGraph(ctx, defaultCollectionID, streamingReplica, tSafe, channel, fac)
+	require.NoError(t, err)
+	defer fg.close()
+	err = fg.consumeFlowGraph(channel, defaultSubName)
+	require.NoError(t, err)
+	fg.flowGraph.Start()
+
+	producer, err := fac.NewMsgStream(ctx)
+	require.NoError(t, err)
+	producer.AsProducer([]string{channel})
+	producer.Start()
+	defer producer.Close()
+
+	produceTimeTick := func(ts Timestamp) {
+		err := producer.Broadcast(&msgstream.MsgPack{
+			Msgs: []msgstream.TsMsg{&msgstream.TimeTickMsg{
+				BaseMsg: msgstream.BaseMsg{BeginTimestamp: ts, EndTimestamp: ts, HashValues: []uint32{0}},
+				TimeTickMsg: internalpb.TimeTickMsg{
+					Base: &commonpb.MsgBase{MsgType: commonpb.MsgType_TimeTick, Timestamp: ts},
+				},
+			}},
+		})
+		require.NoError(t, err)
+	}
+	tSafeEquals := func(ts Timestamp) func() bool {
+		return func() bool {
+			t, err := tSafe.getTSafe(channel)
+			return err == nil && t == ts
+		}
+	}
+
+	produceTimeTick(1000)
+	assert.Eventually(t, tSafeEquals(1000), 10*time.Second, 10*time.Millisecond)
+
+	assert.False(t, fg.resume())
+	assert.True(t, fg.pause())
+	assert.False(t, fg.pause())
+	assert.True(t, fg.isPaused())
+
+	// messages produced during the pause are neither applied nor lost
+	insertMsg, err := genSimpleInsertMsg()
+	require.NoError(t, err)
+	insertMsg.Base.MsgType = commonpb.MsgType_Insert
+	insertMsg.BeginTimestamp = 1500
+	insertMsg.EndTimestamp = 1500
+	err = producer.Produce(&msgstream.MsgPack{Msgs: []msgstream.TsMsg{insertMsg}})
+	require.NoError(t, err)
+	produceTimeTick(2000)
+	produceTimeTick(3000)
+
+	time.Sleep(500 * time.Millisecond)
+	ts, err := tSafe.getTSafe(channel)
+	assert.NoError(t, err)
+	assert.Equal(t, Timestamp(1000), ts)
+	assert.False(t, streamingReplica.hasSegment(defaultSegmentID))
+
+	assert.True(t, fg.resume())
+	assert.False(t, fg.isPaused())
+	assert.Eventually(t, tSafeEquals(3000), 10*time.Second, 10*time.Millisecond)
+	segment, err := streamingReplica.getSegmentByID(defaultSegmentID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(defaultMsgLength), segment.getRowCount())
+
+	// tSafe keeps advancing after resumed
+	produceTimeTick(4000)
+	assert.Eventually(t, tSafeEquals(4000), 10*time.Second, 10*time.Millisecond)
+}
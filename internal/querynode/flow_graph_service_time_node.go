@@ -18,10 +18,13 @@ package querynode
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/util/flowgraph"
 )
 
@@ -31,6 +34,17 @@ type serviceTimeNode struct {
 	collectionID UniqueID
 	vChannel     Channel
 	tSafeReplica TSafeReplicaInterface
+
+	// the time ticks without data messages in between are coalesced within coalesceWindow since the last applied
+	// one, only the latest of them is applied when the window elapses, disabled if coalesceWindow is not positive
+	coalesceWindow time.Duration
+
+	mu          sync.Mutex
+	closed      bool
+	lastApplied time.Time
+	hasPending  bool
+	pendingTs   Timestamp
+	flushTimer  *time.Timer
 }
 
 // Name returns the name of serviceTimeNode
@@ -57,24 +71,86 @@ func (stNode *serviceTimeNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 		return []Msg{}
 	}
 
+	stNode.mu.Lock()
+	defer stNode.mu.Unlock()
+	if stNode.closed {
+		return []Msg{}
+	}
+
+	// the ticks following data messages are applied immediately so that the data is visible without delay,
+	// the others are held at most coalesceWindow since the last applied tick
+	timestamp := serviceTimeMsg.timeRange.timestampMax
+	now := time.Now()
+	if serviceTimeMsg.hasData || stNode.coalesceWindow <= 0 || now.Sub(stNode.lastApplied) >= stNode.coalesceWindow {
+		stNode.apply(timestamp, now)
+		return []Msg{}
+	}
+	if stNode.hasPending {
+		stNode.countTimeTicks(metrics.CoalescedLabel)
+	}
+	stNode.hasPending, stNode.pendingTs = true, timestamp
+	if stNode.flushTimer == nil {
+		stNode.flushTimer = time.AfterFunc(stNode.lastApplied.Add(stNode.coalesceWindow).Sub(now), stNode.flush)
+	}
+
+	return []Msg{}
+}
+
+// apply updates the service time to timestamp, the pending tick is superseded by it
+func (stNode *serviceTimeNode) apply(timestamp Timestamp, now time.Time) {
+	if stNode.hasPending {
+		stNode.countTimeTicks(metrics.CoalescedLabel)
+		stNode.hasPending = false
+	}
+	if stNode.flushTimer != nil {
+		stNode.flushTimer.Stop()
+		stNode.flushTimer = nil
+	}
+	stNode.lastApplied = now
+	stNode.countTimeTicks(metrics.AppliedLabel)
+
 	// update service time
-	err := stNode.tSafeReplica.setTSafe(stNode.vChannel, serviceTimeMsg.timeRange.timestampMax)
+	err := stNode.tSafeReplica.setTSafe(stNode.vChannel, timestamp)
 	if err != nil {
 		log.Error("serviceTimeNode setTSafe failed",
 			zap.Any("collectionID", stNode.collectionID),
 			zap.Error(err),
 		)
 	}
-	//p, _ := tsoutil.ParseTS(serviceTimeMsg.timeRange.timestampMax)
+	//p, _ := tsoutil.ParseTS(timestamp)
 	//log.Debug("update tSafe:",
 	//	zap.Any("collectionID", stNode.collectionID),
-	//	zap.Any("tSafe", serviceTimeMsg.timeRange.timestampMax),
+	//	zap.Any("tSafe", timestamp),
 	//	zap.Any("tSafe_p", p),
-	//	zap.Any("id", id),
 	//	zap.Any("channel", stNode.vChannel),
 	//)
+}
 
-	return []Msg{}
+// flush applies the pending tick once the coalesce window elapses
+func (stNode *serviceTimeNode) flush() {
+	stNode.mu.Lock()
+	defer stNode.mu.Unlock()
+	stNode.flushTimer = nil
+	if stNode.closed || !stNode.hasPending {
+		return
+	}
+	stNode.hasPending = false
+	stNode.apply(stNode.pendingTs, time.Now())
+}
+
+func (stNode *serviceTimeNode) countTimeTicks(status string) {
+	metrics.QueryNodeTimeTicks.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), status).Inc()
+}
+
+// Close stops flushing the pending tick, the tSafe of channel is removed along with the flow graph
+func (stNode *serviceTimeNode) Close() {
+	stNode.mu.Lock()
+	defer stNode.mu.Unlock()
+	stNode.closed = true
+	if stNode.flushTimer != nil {
+		stNode.flushTimer.Stop()
+		stNode.flushTimer = nil
+	}
 }
 
 // newServiceTimeNode returns a new serviceTimeNode
@@ -90,9 +166,10 @@ func newServiceTimeNode(tSafeReplica TSafeReplicaInterface,
 	baseNode.SetMaxParallelism(maxParallelism)
 
 	return &serviceTimeNode{
-		baseNode:     baseNode,
-		collectionID: collectionID,
-		vChannel:     channel,
-		tSafeReplica: tSafeReplica,
+		baseNode:       baseNode,
+		collectionID:   collectionID,
+		vChannel:       channel,
+		tSafeReplica:   tSafeReplica,
+		coalesceWindow: Params.QueryNodeCfg.TimeTickCoalesceWindow,
 	}
 }
@@ -17,8 +17,15 @@
 package querynode
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/util/flowgraph"
 )
 
@@ -82,3 +89,89 @@ func TestServiceTimeNode_Operate(t *testing.T) {
 		node.Operate(in)
 	})
 }
+
+func TestServiceTimeNode_coalesce(t *testing.T) {
+	const window = 50 * time.Millisecond
+	genServiceTimeNode := func(window time.Duration) *serviceTimeNode {
+		tSafe := newTSafeReplica()
+		tSafe.addTSafe(defaultDMLChannel)
+		node := newServiceTimeNode(tSafe, defaultCollectionID, defaultDMLChannel)
+		node.coalesceWindow = window
+		return node
+	}
+	operate := func(node *serviceTimeNode, timestamp Timestamp, hasData bool) {
+		node.Operate([]flowgraph.Msg{&serviceTimeMsg{timeRange: TimeRange{timestampMax: timestamp}, hasData: hasData}})
+	}
+	getTSafe := func(node *serviceTimeNode) Timestamp {
+		ts, err := node.tSafeReplica.getTSafe(defaultDMLChannel)
+		require.NoError(t, err)
+		return ts
+	}
+	nodeID := fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)
+	applied := metrics.QueryNodeTimeTicks.WithLabelValues(nodeID, metrics.AppliedLabel)
+	coalesced := metrics.QueryNodeTimeTicks.WithLabelValues(nodeID, metrics.CoalescedLabel)
+
+	t.Run("coalesce idle ticks", func(t *testing.T) {
+		node := genServiceTimeNode(window)
+		defer node.Close()
+		appliedBefore, coalescedBefore := testutil.ToFloat64(applied), testutil.ToFloat64(coalesced)
+
+		start := time.Now()
+		operate(node, 1000, false)
+		assert.Equal(t, Timestamp(1000), getTSafe(node))
+		operate(node, 2000, false)
+		operate(node, 3000, false)
+		// held within the window, only the latest one is applied
+		if time.Since(start) < window {
+			assert.Equal(t, Timestamp(1000), getTSafe(node))
+		}
+		assert.Eventually(t, func() bool { return getTSafe(node) == 3000 }, 10*window, time.Millisecond)
+		// the added latency is bounded by the window
+		assert.Less(t, int64(time.Since(start)), int64(5*window))
+
+		assert.Equal(t, float64(2), testutil.ToFloat64(applied)-appliedBefore)
+		assert.Equal(t, float64(1), testutil.ToFloat64(coalesced)-coalescedBefore)
+	})
+
+	t.Run("tick following data", func(t *testing.T) {
+		node := genServiceTimeNode(window)
+		defer node.Close()
+		operate(node, 1000, false)
+		operate(node, 2000, false)
+		operate(node, 3000, true)
+		assert.Equal(t, Timestamp(3000), getTSafe(node))
+
+		// the pending tick is superseded, tSafe never goes backward
+		time.Sleep(2 * window)
+		assert.Equal(t, Timestamp(3000), getTSafe(node))
+	})
+
+	t.Run("window elapsed", func(t *testing.T) {
+		node := genServiceTimeNode(window)
+		defer node.Close()
+		operate(node, 1000, false)
+		time.Sleep(window)
+		operate(node, 2000, false)
+		assert.Equal(t, Timestamp(2000), getTSafe(node))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		node := genServiceTimeNode(0)
+		defer node.Close()
+		for ts := Timestamp(1000); ts <= 3000; ts += 1000 {
+			operate(node, ts, false)
+			assert.Equal(t, ts, getTSafe(node))
+		}
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		node := genServiceTimeNode(window)
+		operate(node, 1000, false)
+		operate(node, 2000, false)
+		node.Close()
+		time.Sleep(2 * window)
+		assert.Equal(t, Timestamp(1000), getTSafe(node))
+		operate(node, 3000, true)
+		assert.Equal(t, Timestamp(1000), getTSafe(node))
+	})
+}
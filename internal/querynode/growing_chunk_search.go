@@ -0,0 +1,602 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/distance"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// unfilledRowTs is the timestamp of the rows of a chunk not inserted yet, they are never visible
+const unfilledRowTs = typeutil.MaxTimestamp
+
+// vectorChunk holds the float vectors of the rows of a chunk of growing segment by offset, with the range of their norms
+type vectorChunk struct {
+	pks        []int64
+	timestamps []Timestamp
+	vectors    []float32
+
+	minNorm float64
+	maxNorm float64
+	// bounded is false if any vector of the chunk has a NaN or Inf norm, the chunk is always scanned
+	bounded bool
+}
+
+func newVectorChunk() *vectorChunk {
+	return &vectorChunk{
+		minNorm: math.Inf(1),
+		maxNorm: 0,
+		bounded: true,
+	}
+}
+
+func (c *vectorChunk) rowCount() int {
+	return len(c.timestamps)
+}
+
+// set sets the row at offset of the chunk, the rows before it not set yet are left unfilled
+func (c *vectorChunk) set(offset int, pk int64, timestamp Timestamp, vector []float32) {
+	dim := len(vector)
+	for len(c.timestamps) <= offset {
+		c.pks = append(c.pks, 0)
+		c.timestamps = append(c.timestamps, unfilledRowTs)
+		c.vectors = append(c.vectors, make([]float32, dim)...)
+	}
+	c.pks[offset] = pk
+	c.timestamps[offset] = timestamp
+	copy(c.vectors[offset*dim:(offset+1)*dim], vector)
+
+	norm := vectorNorm(vector)
+	if math.IsNaN(norm) || math.IsInf(norm, 0) {
+		c.bounded = false
+		return
+	}
+	c.minNorm = math.Min(c.minNorm, norm)
+	c.maxNorm = math.Max(c.maxNorm, norm)
+}
+
+// upperBound returns the upper bound of the similarity between query and any vector in the chunk,
+// by Cauchy-Schwarz, ip(q, x) <= |q| * |x|, and l2(q, x) >= (|q| - |x|)^2. For L2 the
+// similarity is the negative distance, so that larger is always more similar.
+func (c *vectorChunk) upperBound(queryNorm float64, metricType string) float64 {
+	if !c.bounded {
+		return math.Inf(1)
+	}
+	if c.minNorm > c.maxNorm {
+		// no row set
+		return math.Inf(-1)
+	}
+	switch metricType {
+	case distance.IP:
+		return queryNorm * c.maxNorm
+	case distance.L2:
+		nearest := math.Min(math.Max(queryNorm, c.minNorm), c.maxNorm)
+		return -(queryNorm - nearest) * (queryNorm - nearest)
+	default:
+		return math.Inf(1)
+	}
+}
+
+// growingVectorChunks mirrors the float vectors of a vector field of growing segment in chunks of the segcore
+// chunk rows, located by the offsets of the rows in segment
+type growingVectorChunks struct {
+	fieldID   FieldID
+	dim       int
+	rowOffset int // byte offset of the field in row based records
+	chunks    []*vectorChunk
+}
+
+// parseRecords parses the vectors of the field from row based records, flattened with dim
+func (g *growingVectorChunks) parseRecords(records []*commonpb.Blob) ([]float32, error) {
+	vectors := make([]float32, 0, len(records)*g.dim)
+	for i, record := range records {
+		value := record.GetValue()
+		if len(value) < g.rowOffset+g.dim*4 {
+			return nil, fmt.Errorf("record %d of %d bytes is too short to hold vector field %d", i, len(value), g.fieldID)
+		}
+		for j := 0; j < g.dim; j++ {
+			offset := g.rowOffset + j*4
+			vectors = append(vectors, math.Float32frombits(common.Endian.Uint32(value[offset:offset+4])))
+		}
+	}
+	return vectors, nil
+}
+
+// growingChunkSearch mirrors the float vectors of growing segment in chunks with the range of their norms maintained
+// at insert time, to search the query vectors chunk by chunk and skip the chunks which could not improve the topk.
+// The chunk search only selects the candidate rows, the candidates are then searched by segcore, so the timestamps,
+// deletes and expiration are applied by segcore as a full scan does. It's disabled once the mirror could be out of
+// sync with segcore, e.g. failed to mirror an insert or the segment is compacted, then the segment is fully scanned.
+// The mirror costs as much memory as the float vectors of the segment, plus the pk and timestamp of each row.
+// The ranges of the insert timestamps, the pks and the values of the tracked integer fields are maintained per chunk,
+// so that a search with a predicate on them visits the chunks likely to match first and skips the chunks matching
+// no row, which costs the values of the tracked fields of each row more.
+type growingChunkSearch struct {
+	mu        sync.RWMutex
+	chunkRows int
+	pkFieldID FieldID
+	pkOffset  int // byte offset of the int64 primary key in row based records
+	fields    map[FieldID]*growingVectorChunks
+	// statsFields are the tracked integer fields besides the pk, by the byte offsets in row based records
+	statsFields map[FieldID]chunkStatsField
+	// stats are the ranges of the rows of each chunk, shared by the vector fields
+	stats []*chunkStats
+	// tolerance is the relative slack of the similarities computed in Go against the ones computed by segcore,
+	// see similarityTolerance
+	tolerance float64
+	// deletedPKs are the pks deleted from segment, the rows of them may or may not be deleted depending on the
+	// timestamps, so they are searched by segcore if they are similar enough, but never count in the topk
+	deletedPKs map[int64]struct{}
+	disabled   bool
+}
+
+// chunkStatsField locates a tracked integer field in row based records
+type chunkStatsField struct {
+	rowOffset int
+	size      int
+}
+
+// newGrowingChunkSearch creates the chunk search of all float vector fields of collection. The chunk search needs an
+// int64 primary key and the fixed-size row layout of records to locate the mirrored fields, it returns an error if
+// the collection is not supported.
+func newGrowingChunkSearch(collection *Collection, chunkRows int, tolerance float64) (*growingChunkSearch, error) {
+	if chunkRows <= 0 {
+		return nil, fmt.Errorf("invalid chunk rows %d of chunk search", chunkRows)
+	}
+	if tolerance < 0 || math.IsNaN(tolerance) {
+		return nil, fmt.Errorf("invalid similarity tolerance %f of chunk search", tolerance)
+	}
+	cs := &growingChunkSearch{
+		chunkRows:   chunkRows,
+		pkOffset:    -1,
+		fields:      make(map[FieldID]*growingVectorChunks),
+		statsFields: make(map[FieldID]chunkStatsField),
+		tolerance:   tolerance,
+		deletedPKs:  make(map[int64]struct{}),
+	}
+	statsFieldIDs := make(map[FieldID]struct{})
+	for _, fieldID := range collection.getChunkStatsFields() {
+		statsFieldIDs[fieldID] = struct{}{}
+	}
+	rowOffset := 0
+	for _, fieldSchema := range collection.Schema().GetFields() {
+		field, err := collection.getFieldByID(fieldSchema.GetFieldID())
+		if err != nil {
+			return nil, err
+		}
+		if typeutil.IsStringType(fieldSchema.GetDataType()) {
+			return nil, fmt.Errorf("chunk search is not supported by collection %d with variable-length field %s",
+				collection.ID(), fieldSchema.GetName())
+		}
+		if fieldSchema.GetIsPrimaryKey() {
+			if fieldSchema.GetDataType() != schemapb.DataType_Int64 {
+				return nil, fmt.Errorf("chunk search is not supported by collection %d with primary key of %s",
+					collection.ID(), fieldSchema.GetDataType().String())
+			}
+			cs.pkFieldID = fieldSchema.GetFieldID()
+			cs.pkOffset = rowOffset
+		} else if _, ok := statsFieldIDs[fieldSchema.GetFieldID()]; ok {
+			cs.statsFields[fieldSchema.GetFieldID()] = chunkStatsField{rowOffset: rowOffset, size: int(field.estimateSize(0))}
+		}
+		if fieldSchema.GetDataType() == schemapb.DataType_FloatVector {
+			if field.dim <= 0 {
+				return nil, fmt.Errorf("invalid dim %d of vector field %d", field.dim, fieldSchema.GetFieldID())
+			}
+			cs.fields[fieldSchema.GetFieldID()] = &growingVectorChunks{
+				fieldID:   fieldSchema.GetFieldID(),
+				dim:       int(field.dim),
+				rowOffset: rowOffset,
+			}
+		}
+		rowOffset += int(field.estimateSize(0))
+	}
+	if cs.pkOffset < 0 {
+		return nil, fmt.Errorf("chunk search is not supported by collection %d without primary key", collection.ID())
+	}
+	if len(cs.fields) == 0 {
+		return nil, fmt.Errorf("chunk search is not supported by collection %d without float vector field", collection.ID())
+	}
+	return cs, nil
+}
+
+// estimateChunkSearchSize returns the estimated size in bytes of the mirror of the chunk search of rowCount rows,
+// 0 if the chunk search is not supported by collection
+func estimateChunkSearchSize(collection *Collection, rowCount int64) int64 {
+	cs, err := newGrowingChunkSearch(collection, 1, 0)
+	if err != nil {
+		return 0
+	}
+	// the values of the tracked fields of a row are shared by the vector fields
+	rowSize := int64(len(cs.statsFields)) * 8
+	for _, field := range cs.fields {
+		// the pk, timestamp and vector of a row are mirrored per field
+		rowSize += 8 + 8 + int64(field.dim)*4
+	}
+	return rowCount * rowSize
+}
+
+// statsFieldIDs returns the tracked integer fields besides the pk
+func (cs *growingChunkSearch) statsFieldIDs() []FieldID {
+	fieldIDs := make([]FieldID, 0, len(cs.statsFields))
+	for fieldID := range cs.statsFields {
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+	return fieldIDs
+}
+
+// append mirrors the row based records inserted at offset of segment
+func (cs *growingChunkSearch) append(offset int64, timestamps []Timestamp, records []*commonpb.Blob) error {
+	if len(timestamps) != len(records) {
+		return fmt.Errorf("%d timestamps mis-match with %d records", len(timestamps), len(records))
+	}
+	pks := make([]int64, 0, len(records))
+	for i, record := range records {
+		value := record.GetValue()
+		if len(value) < cs.pkOffset+8 {
+			return fmt.Errorf("record %d of %d bytes is too short to hold the primary key", i, len(value))
+		}
+		pks = append(pks, int64(common.Endian.Uint64(value[cs.pkOffset:cs.pkOffset+8])))
+	}
+	statsValues := make([]map[FieldID]int64, len(records))
+	if len(cs.statsFields) > 0 {
+		for i, record := range records {
+			value := record.GetValue()
+			statsValues[i] = make(map[FieldID]int64, len(cs.statsFields))
+			for fieldID, field := range cs.statsFields {
+				if len(value) < field.rowOffset+field.size {
+					return fmt.Errorf("record %d of %d bytes is too short to hold field %d", i, len(value), fieldID)
+				}
+				statsValues[i][fieldID] = parseChunkStatsValue(value[field.rowOffset:], field.size)
+			}
+		}
+	}
+	vectors := make(map[FieldID][]float32, len(cs.fields))
+	for fieldID, field := range cs.fields {
+		fieldVectors, err := field.parseRecords(records)
+		if err != nil {
+			return err
+		}
+		vectors[fieldID] = fieldVectors
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.disabled {
+		return nil
+	}
+	for i := range records {
+		rowOffset := int(offset) + i
+		chunkIdx := rowOffset / cs.chunkRows
+		for len(cs.stats) <= chunkIdx {
+			cs.stats = append(cs.stats, newChunkStats(cs.statsFieldIDs()))
+		}
+		cs.stats[chunkIdx].set(rowOffset%cs.chunkRows, pks[i], timestamps[i], statsValues[i])
+	}
+	for fieldID, field := range cs.fields {
+		fieldVectors := vectors[fieldID]
+		for i := range records {
+			rowOffset := int(offset) + i
+			chunkIdx := rowOffset / cs.chunkRows
+			for len(field.chunks) <= chunkIdx {
+				field.chunks = append(field.chunks, newVectorChunk())
+			}
+			field.chunks[chunkIdx].set(rowOffset%cs.chunkRows, pks[i], timestamps[i], fieldVectors[i*field.dim:(i+1)*field.dim])
+		}
+	}
+	return nil
+}
+
+// delete records the pks deleted from segment
+func (cs *growingChunkSearch) delete(pks []int64) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.disabled {
+		return
+	}
+	for _, pk := range pks {
+		cs.deletedPKs[pk] = struct{}{}
+	}
+}
+
+// disable disables the chunk search and releases the mirrored vectors
+func (cs *growingChunkSearch) disable() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.disabled = true
+	for _, field := range cs.fields {
+		field.chunks = nil
+	}
+	cs.stats = nil
+	cs.deletedPKs = nil
+}
+
+func (cs *growingChunkSearch) memSize() int64 {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	size := int64(unsafe.Sizeof(*cs)) + int64(len(cs.deletedPKs))*16
+	for _, field := range cs.fields {
+		for _, chunk := range field.chunks {
+			size += int64(unsafe.Sizeof(*chunk)) + int64(cap(chunk.pks))*8 + int64(cap(chunk.timestamps))*8 + int64(cap(chunk.vectors))*4
+		}
+	}
+	for _, stats := range cs.stats {
+		size += int64(unsafe.Sizeof(*stats))
+		for _, column := range stats.columns {
+			size += int64(unsafe.Sizeof(*column)) + int64(cap(column.values))*8
+		}
+	}
+	return size
+}
+
+// chunkSearchResult is the result of a chunk-wise search
+type chunkSearchResult struct {
+	// candidates are the offsets of the rows to search by segcore in ascending order, the topk of every query is
+	// among them
+	candidates []int64
+
+	scannedChunks int
+	skippedChunks int
+}
+
+// search selects the candidates of the topk most similar rows to each of queries among the rows visible at timestamp
+// and not expired by expireTs, 0 means never expire, and matching pred if not nil. Per query, chunks are visited in
+// descending order of their upper bounds, once the bound of a chunk could not beat the current kth row, neither could
+// the rest, and the search terminates early. Unbounded chunks are always scanned, so it falls back to a full scan if
+// no chunk is bounded. With pred, the chunks matching no row are skipped, and the chunks all of whose rows match are
+// visited before the ones partially matching, so that the topk is filled by the matched rows sooner, then the chunks
+// whose bounds could not beat the kth row are skipped one by one. It returns false if the chunk search is disabled
+// or pred is on a field not tracked.
+func (cs *growingChunkSearch) search(fieldID FieldID, queries [][]float32, topk int, metricType string,
+	timestamp Timestamp, expireTs Timestamp, pred *chunkPredicate) (*chunkSearchResult, bool, error) {
+	if topk <= 0 {
+		return nil, false, fmt.Errorf("invalid topk %d", topk)
+	}
+	metricType = strings.ToUpper(metricType)
+	if metricType != distance.IP && metricType != distance.L2 {
+		return nil, false, fmt.Errorf("metric type %s is not supported by chunk search", metricType)
+	}
+
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	if cs.disabled {
+		return nil, false, nil
+	}
+	field, ok := cs.fields[fieldID]
+	if !ok {
+		return nil, false, fmt.Errorf("no vector chunks of field %d", fieldID)
+	}
+	if pred != nil {
+		for _, predFieldID := range pred.fieldIDs() {
+			if _, ok := cs.statsFields[predFieldID]; !ok && predFieldID != cs.pkFieldID {
+				return nil, false, nil
+			}
+		}
+	}
+
+	result := &chunkSearchResult{}
+	selected := make(map[int64]struct{})
+	for _, query := range queries {
+		if len(query) != field.dim {
+			return nil, false, fmt.Errorf("dim %d of query mis-match with dim %d of field %d", len(query), field.dim, fieldID)
+		}
+		scanned, skipped := cs.searchQuery(field, query, topk, metricType, timestamp, expireTs, pred, selected)
+		result.scannedChunks += scanned
+		result.skippedChunks += skipped
+	}
+	result.candidates = make([]int64, 0, len(selected))
+	for offset := range selected {
+		result.candidates = append(result.candidates, offset)
+	}
+	sort.Slice(result.candidates, func(i, j int) bool { return result.candidates[i] < result.candidates[j] })
+	return result, true, nil
+}
+
+// searchQuery adds the candidates of the topk rows of query to selected, returns the numbers of the chunks scanned
+// and skipped
+func (cs *growingChunkSearch) searchQuery(field *growingVectorChunks, query []float32, topk int, metricType string,
+	timestamp Timestamp, expireTs Timestamp, pred *chunkPredicate, selected map[int64]struct{}) (int, int) {
+	queryNorm := vectorNorm(query)
+	type chunkBound struct {
+		index int
+		bound float64
+		match chunkMatch
+	}
+	bounds := make([]chunkBound, 0, len(field.chunks))
+	skipped := 0
+	for i, chunk := range field.chunks {
+		match := chunkMatchAll
+		if pred != nil {
+			match = cs.stats[i].match(pred, cs.pkFieldID, timestamp, expireTs)
+		}
+		if match == chunkMatchNone {
+			skipped++
+			continue
+		}
+		bounds = append(bounds, chunkBound{index: i, bound: chunk.upperBound(queryNorm, metricType), match: match})
+	}
+	sort.SliceStable(bounds, func(i, j int) bool {
+		if bounds[i].match != bounds[j].match {
+			return bounds[i].match > bounds[j].match
+		}
+		return bounds[i].bound > bounds[j].bound
+	})
+
+	h := make(similarityHeap, 0, topk)
+	// the rows of the deleted pks similar enough, which are searched by segcore along with the topk
+	var deleted []similarityItem
+	scanned := 0
+	for i, b := range bounds {
+		if len(h) >= topk && b.bound < h[0].similarity-similarityTolerance(h[0].similarity, cs.tolerance) {
+			if pred == nil {
+				skipped += len(bounds) - i
+				break
+			}
+			// the bounds are in descending order within the chunks of the same match only
+			skipped++
+			continue
+		}
+		scanned++
+		chunk := field.chunks[b.index]
+		for row := 0; row < chunk.rowCount(); row++ {
+			ts := chunk.timestamps[row]
+			if ts == unfilledRowTs || ts > timestamp || (expireTs > 0 && ts < expireTs) {
+				continue
+			}
+			if pred != nil && b.match != chunkMatchAll && !cs.matchRow(pred, b.index, chunk.pks[row], row) {
+				continue
+			}
+			similarity := rowSimilarity(query, chunk.vectors[row*field.dim:(row+1)*field.dim], metricType)
+			if math.IsNaN(similarity) {
+				continue
+			}
+			item := similarityItem{offset: int64(b.index*cs.chunkRows + row), similarity: similarity}
+			if _, ok := cs.deletedPKs[chunk.pks[row]]; ok {
+				deleted = append(deleted, item)
+				continue
+			}
+			if len(h) < topk {
+				heap.Push(&h, item)
+			} else if similarity > h[0].similarity {
+				h[0] = item
+				heap.Fix(&h, 0)
+			}
+		}
+	}
+
+	for _, item := range h {
+		selected[item.offset] = struct{}{}
+	}
+	for _, item := range deleted {
+		if len(h) < topk || item.similarity >= h[0].similarity-similarityTolerance(h[0].similarity, cs.tolerance) {
+			selected[item.offset] = struct{}{}
+		}
+	}
+	return scanned, skipped
+}
+
+// matchRow returns whether the row at offset of the chunk of chunkIdx matches pred
+func (cs *growingChunkSearch) matchRow(pred *chunkPredicate, chunkIdx int, pk int64, offset int) bool {
+	stats := cs.stats[chunkIdx]
+	for fieldID := range pred.ranges {
+		value := pk
+		if fieldID != cs.pkFieldID {
+			value = stats.columns[fieldID].values[offset]
+		}
+		if !pred.matchValue(fieldID, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// similarityTolerance is the slack of comparing similarities computed in Go with the ones computed by segcore in
+// float32, so that the rows on the boundary of the topk are still searched by segcore. It's relative to the
+// similarity by tolerance, and absolute for the similarities less than 1.
+func similarityTolerance(similarity float64, tolerance float64) float64 {
+	return tolerance * math.Max(1, math.Abs(similarity))
+}
+
+// rowSimilarity returns the similarity of query and vector, larger is more similar
+func rowSimilarity(query []float32, vector []float32, metricType string) float64 {
+	var sum float64
+	if metricType == distance.IP {
+		for i := range query {
+			sum += float64(query[i]) * float64(vector[i])
+		}
+		return sum
+	}
+	for i := range query {
+		diff := float64(query[i]) - float64(vector[i])
+		sum += diff * diff
+	}
+	return -sum
+}
+
+func vectorNorm(vector []float32) float64 {
+	var sum float64
+	for _, v := range vector {
+		sum += float64(v) * float64(v)
+	}
+	return math.Sqrt(sum)
+}
+
+type similarityItem struct {
+	offset     int64
+	similarity float64
+}
+
+// similarityHeap is a min heap of similarity, the top is the least similar of the current topk
+type similarityHeap []similarityItem
+
+func (h similarityHeap) Len() int            { return len(h) }
+func (h similarityHeap) Less(i, j int) bool  { return h[i].similarity < h[j].similarity }
+func (h similarityHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *similarityHeap) Push(x interface{}) { *h = append(*h, x.(similarityItem)) }
+func (h *similarityHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// searchByChunks searches the candidates selected by the chunk search in segcore, it returns false if the chunk search
+// is not applicable to the search, then the segment should be searched as usual
+func (s *Segment) searchByChunks(plan *SearchPlan, searchReq *searchRequest, timestamp Timestamp) (*SearchResult, bool, error) {
+	if len(searchReq.floatVectors) == 0 {
+		return nil, false, nil
+	}
+	// compaction swaps the segcore segment and disables the chunk search under segPtrMu,
+	// so the candidates are always the offsets of the segcore segment searched
+	s.segPtrMu.RLock()
+	defer s.segPtrMu.RUnlock()
+	result, ok, err := s.chunkSearch.search(plan.getFieldID(), searchReq.floatVectors, int(plan.getTopK()),
+		plan.getMetricType(), timestamp, plan.expireTs, plan.chunkPredicate)
+	if err != nil {
+		log.Warn("failed to search by chunks, fall back to full scan", zap.Int64("segmentID", s.segmentID), zap.Error(err))
+		return nil, false, nil
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	var numRows int64
+	if len(result.candidates) > 0 {
+		numRows = result.candidates[len(result.candidates)-1] + 1
+	}
+	candidates := make([]byte, (numRows+7)/8)
+	for _, offset := range result.candidates {
+		candidates[offset>>3] |= 1 << (offset & 0x7)
+	}
+	log.Debug("search by chunks", zap.Int64("segmentID", s.segmentID), zap.Int("candidates", len(result.candidates)),
+		zap.Int("scannedChunks", result.scannedChunks), zap.Int("skippedChunks", result.skippedChunks))
+	searchResult, err := s.searchWithCandidatesLocked(plan, searchReq, timestamp, candidates, numRows, true)
+	return searchResult, true, err
+}
@@ -0,0 +1,584 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/distance"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+const chunkSearchTestFieldID = FieldID(100)
+
+// genScaledVectors generates n random vectors, the norms of the vectors grow with their row
+// so that chunks of later rows bound higher inner products
+func genScaledVectors(n, dim int) []float32 {
+	vectors := make([]float32, 0, n*dim)
+	for i := 0; i < n; i++ {
+		vector := make([]float32, dim)
+		for j := range vector {
+			vector[j] = rand.Float32()*2 - 1
+		}
+		norm := float32(vectorNorm(vector))
+		scale := float32(1 + i/100)
+		for j := range vector {
+			vector[j] = vector[j] / norm * scale
+		}
+		vectors = append(vectors, vector...)
+	}
+	return vectors
+}
+
+// genChunkSearchRecords generates the row based records of the vectors followed by the pks
+func genChunkSearchRecords(pks []int64, vectors []float32, dim int) []*commonpb.Blob {
+	records := make([]*commonpb.Blob, 0, len(pks))
+	for i, pk := range pks {
+		value := make([]byte, dim*4+8)
+		for j := 0; j < dim; j++ {
+			common.Endian.PutUint32(value[j*4:], math.Float32bits(vectors[i*dim+j]))
+		}
+		common.Endian.PutUint64(value[dim*4:], uint64(pk))
+		records = append(records, &commonpb.Blob{Value: value})
+	}
+	return records
+}
+
+func newTestChunkSearch(dim, chunkRows int) *growingChunkSearch {
+	return &growingChunkSearch{
+		chunkRows: chunkRows,
+		pkOffset:  dim * 4,
+		fields: map[FieldID]*growingVectorChunks{
+			chunkSearchTestFieldID: {fieldID: chunkSearchTestFieldID, dim: dim},
+		},
+		tolerance:  Params.QueryNodeCfg.ChunkSearchSimilarityTolerance,
+		deletedPKs: make(map[int64]struct{}),
+	}
+}
+
+// genChunkSearchData mirrors n rows, the pk of row i is i and its timestamp is i + 1
+func genChunkSearchData(t testing.TB, n, dim, chunkRows int) (*growingChunkSearch, []float32) {
+	vectors := genScaledVectors(n, dim)
+	pks := make([]int64, n)
+	timestamps := make([]Timestamp, n)
+	for i := range pks {
+		pks[i] = int64(i)
+		timestamps[i] = Timestamp(i + 1)
+	}
+	cs := newTestChunkSearch(dim, chunkRows)
+	require.NoError(t, cs.append(0, timestamps, genChunkSearchRecords(pks, vectors, dim)))
+	return cs, vectors
+}
+
+// fullScan returns the offsets of the topk rows visible at timestamp whose pks are not deleted, the pk of row i is i
+// and its timestamp is i + 1
+func fullScan(vectors []float32, dim int, query []float32, topk int, metricType string, timestamp Timestamp, deleted map[int64]struct{}) []int64 {
+	type hit struct {
+		offset     int64
+		similarity float64
+	}
+	hits := make([]hit, 0)
+	for i := 0; i*dim < len(vectors); i++ {
+		if _, ok := deleted[int64(i)]; ok || Timestamp(i+1) > timestamp {
+			continue
+		}
+		hits = append(hits, hit{offset: int64(i), similarity: rowSimilarity(query, vectors[i*dim:(i+1)*dim], metricType)})
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].similarity > hits[j].similarity })
+	if len(hits) > topk {
+		hits = hits[:topk]
+	}
+	offsets := make([]int64, 0, len(hits))
+	for _, h := range hits {
+		offsets = append(offsets, h.offset)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	return offsets
+}
+
+func TestGrowingChunkSearch_versusFullScan(t *testing.T) {
+	const (
+		n         = 5000
+		dim       = 16
+		chunkRows = 100
+		topk      = 10
+	)
+	cs, vectors := genChunkSearchData(t, n, dim, chunkRows)
+	chunks := cs.fields[chunkSearchTestFieldID].chunks
+	assert.Equal(t, n/chunkRows, len(chunks))
+
+	for _, metricType := range []string{distance.IP, distance.L2} {
+		for i := 0; i < 20; i++ {
+			query := genScaledVectors(1, dim)
+			for _, timestamp := range []Timestamp{Timestamp(n), Timestamp(n / 3), Timestamp(5)} {
+				result, ok, err := cs.search(chunkSearchTestFieldID, [][]float32{query}, topk, metricType, timestamp, 0, nil)
+				require.NoError(t, err)
+				require.True(t, ok)
+				expected := fullScan(vectors, dim, query, topk, metricType, timestamp, nil)
+				assert.Equal(t, expected, result.candidates, "metric %s, timestamp %d", metricType, timestamp)
+				assert.Equal(t, len(chunks), result.scannedChunks+result.skippedChunks)
+			}
+		}
+	}
+
+	t.Run("multiple queries", func(t *testing.T) {
+		queries := [][]float32{genScaledVectors(1, dim), genScaledVectors(1, dim)}
+		result, ok, err := cs.search(chunkSearchTestFieldID, queries, topk, distance.IP, Timestamp(n), 0, nil)
+		require.NoError(t, err)
+		require.True(t, ok)
+		for _, query := range queries {
+			for _, offset := range fullScan(vectors, dim, query, topk, distance.IP, Timestamp(n), nil) {
+				assert.Contains(t, result.candidates, offset)
+			}
+		}
+		assert.Equal(t, 2*len(chunks), result.scannedChunks+result.skippedChunks)
+	})
+}
+
+func TestGrowingChunkSearch_skipChunks(t *testing.T) {
+	const (
+		n         = 5000
+		dim       = 16
+		chunkRows = 100
+		topk      = 10
+	)
+	cs, _ := genChunkSearchData(t, n, dim, chunkRows)
+
+	// the norm of rows grows every 100 rows, so the chunks of small norms could not beat the topk
+	query := genScaledVectors(1, dim)
+	result, ok, err := cs.search(chunkSearchTestFieldID, [][]float32{query}, topk, distance.IP, Timestamp(n), 0, nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Greater(t, result.skippedChunks, 0)
+
+	// chunks whose norms are far from the norm of query are skipped by L2 bound
+	result, ok, err = cs.search(chunkSearchTestFieldID, [][]float32{query}, topk, distance.L2, Timestamp(n), 0, nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Greater(t, result.skippedChunks, 0)
+}
+
+func TestGrowingChunkSearch_deletes(t *testing.T) {
+	const (
+		n         = 1000
+		dim       = 8
+		chunkRows = 100
+		topk      = 10
+	)
+	cs, vectors := genChunkSearchData(t, n, dim, chunkRows)
+	query := genScaledVectors(1, dim)
+	top := fullScan(vectors, dim, query, topk, distance.IP, Timestamp(n), nil)
+
+	// the rows of the deleted pks don't count in the topk, but are still searched by segcore, which decides whether
+	// they are deleted at the timestamp of the search
+	deleted := map[int64]struct{}{top[0]: {}, top[3]: {}}
+	cs.delete([]int64{top[0], top[3]})
+	result, ok, err := cs.search(chunkSearchTestFieldID, [][]float32{query}, topk, distance.IP, Timestamp(n), 0, nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	alive := fullScan(vectors, dim, query, topk, distance.IP, Timestamp(n), deleted)
+	for _, offset := range alive {
+		assert.Contains(t, result.candidates, offset)
+	}
+	assert.Contains(t, result.candidates, top[0])
+	assert.Contains(t, result.candidates, top[3])
+	assert.Equal(t, topk+2, len(result.candidates))
+}
+
+func TestGrowingChunkSearch_expire(t *testing.T) {
+	const (
+		n         = 1000
+		dim       = 8
+		chunkRows = 100
+		topk      = 10
+	)
+	cs, vectors := genChunkSearchData(t, n, dim, chunkRows)
+	query := genScaledVectors(1, dim)
+
+	// the rows of timestamps before 501 are expired, i.e. the first 500 rows
+	expired := make(map[int64]struct{})
+	for i := int64(0); i < 500; i++ {
+		expired[i] = struct{}{}
+	}
+	result, ok, err := cs.search(chunkSearchTestFieldID, [][]float32{query}, topk, distance.L2, Timestamp(n), 501, nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, fullScan(vectors, dim, query, topk, distance.L2, Timestamp(n), expired), result.candidates)
+}
+
+func TestGrowingChunkSearch_outOfOrderInserts(t *testing.T) {
+	const dim = 2
+	cs := newTestChunkSearch(dim, 2)
+	// rows 2 and 3 are inserted before rows 0 and 1
+	require.NoError(t, cs.append(2, []Timestamp{3, 4}, genChunkSearchRecords([]int64{2, 3}, []float32{2, 0, 3, 0}, dim)))
+	chunks := cs.fields[chunkSearchTestFieldID].chunks
+	require.Equal(t, 2, len(chunks))
+	assert.Equal(t, 0, chunks[0].rowCount())
+
+	result, ok, err := cs.search(chunkSearchTestFieldID, [][]float32{{1, 0}}, 4, distance.IP, 10, 0, nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []int64{2, 3}, result.candidates)
+
+	require.NoError(t, cs.append(0, []Timestamp{1, 2}, genChunkSearchRecords([]int64{0, 1}, []float32{0, 0, 1, 0}, dim)))
+	result, ok, err = cs.search(chunkSearchTestFieldID, [][]float32{{1, 0}}, 4, distance.IP, 10, 0, nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []int64{0, 1, 2, 3}, result.candidates)
+
+	// the row of the gap is never a candidate
+	require.NoError(t, cs.append(6, []Timestamp{7}, genChunkSearchRecords([]int64{6}, []float32{6, 0}, dim)))
+	result, ok, err = cs.search(chunkSearchTestFieldID, [][]float32{{1, 0}}, 10, distance.IP, 10, 0, nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []int64{0, 1, 2, 3, 6}, result.candidates)
+}
+
+func TestGrowingChunkSearch_unbounded(t *testing.T) {
+	const dim = 4
+	cs := newTestChunkSearch(dim, 2)
+	nan := float32(math.NaN())
+	vectors := []float32{
+		1, 0, 0, 0,
+		2, 0, 0, 0,
+		nan, 0, 0, 0,
+		0.5, 0, 0, 0,
+		0.1, 0, 0, 0,
+		0.2, 0, 0, 0,
+	}
+	records := genChunkSearchRecords([]int64{1, 2, 3, 4, 5, 6}, vectors, dim)
+	require.NoError(t, cs.append(0, []Timestamp{1, 1, 1, 1, 1, 1}, records))
+	chunks := cs.fields[chunkSearchTestFieldID].chunks
+	assert.True(t, chunks[0].bounded)
+	assert.False(t, chunks[1].bounded)
+
+	// the unbounded chunk is always scanned, and the last chunk is skipped
+	result, ok, err := cs.search(chunkSearchTestFieldID, [][]float32{{1, 0, 0, 0}}, 1, distance.IP, 1, 0, nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []int64{1}, result.candidates)
+	assert.Equal(t, 2, result.scannedChunks)
+	assert.Equal(t, 1, result.skippedChunks)
+}
+
+func TestGrowingChunkSearch_errors(t *testing.T) {
+	cs := newTestChunkSearch(2, 2)
+	assert.Error(t, cs.append(0, []Timestamp{1}, nil))
+	assert.Error(t, cs.append(0, []Timestamp{1}, []*commonpb.Blob{{Value: make([]byte, 4)}}))
+
+	queries := [][]float32{{1, 1}}
+	_, _, err := cs.search(chunkSearchTestFieldID, [][]float32{{1}}, 1, distance.IP, 1, 0, nil)
+	assert.Error(t, err)
+	_, _, err = cs.search(chunkSearchTestFieldID, queries, 0, distance.IP, 1, 0, nil)
+	assert.Error(t, err)
+	_, _, err = cs.search(chunkSearchTestFieldID, queries, 1, distance.HAMMING, 1, 0, nil)
+	assert.Error(t, err)
+	_, _, err = cs.search(999, queries, 1, distance.IP, 1, 0, nil)
+	assert.Error(t, err)
+
+	result, ok, err := cs.search(chunkSearchTestFieldID, queries, 1, "ip", 1, 0, nil)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, result.candidates)
+
+	t.Run("disabled", func(t *testing.T) {
+		require.NoError(t, cs.append(0, []Timestamp{1}, genChunkSearchRecords([]int64{1}, []float32{1, 1}, 2)))
+		assert.Greater(t, cs.memSize(), int64(0))
+		cs.disable()
+		_, ok, err := cs.search(chunkSearchTestFieldID, queries, 1, distance.IP, 1, 0, nil)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		// the rows inserted after disabled are not mirrored
+		require.NoError(t, cs.append(1, []Timestamp{1}, genChunkSearchRecords([]int64{2}, []float32{1, 1}, 2)))
+		cs.delete([]int64{1})
+		assert.Empty(t, cs.fields[chunkSearchTestFieldID].chunks)
+	})
+}
+
+func TestGrowingChunkSearch_collection(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	cs, err := newGrowingChunkSearch(collection, 16, 1e-5)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(cs.fields))
+	chunks := cs.fields[simpleVecField.id]
+	require.NotNil(t, chunks)
+	assert.Equal(t, defaultDim, chunks.dim)
+	// the simple schema is vector, int32 and pk
+	assert.Equal(t, 0, chunks.rowOffset)
+	assert.Equal(t, defaultDim*4+4, cs.pkOffset)
+
+	records, err := genSimpleCommonBlob()
+	require.NoError(t, err)
+	timestamps := genSimpleTimestampFieldData()
+	require.NoError(t, cs.append(0, timestamps, records))
+	assert.Equal(t, int64(3), cs.fields[simpleVecField.id].chunks[0].pks[3])
+	assert.InDelta(t, float32(3*5)*0.1, cs.fields[simpleVecField.id].chunks[0].vectors[3*defaultDim+5], 1e-6)
+
+	records[1].Value = records[1].Value[:10]
+	assert.Error(t, cs.append(0, timestamps, records))
+
+	// the pk, timestamp and vector of each row are mirrored
+	assert.Equal(t, int64(10*(16+defaultDim*4)), estimateChunkSearchSize(collection, 10))
+
+	t.Run("stats fields", func(t *testing.T) {
+		collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+		collection.setChunkStatsFields([]FieldID{simpleConstField.id})
+		cs, err := newGrowingChunkSearch(collection, 16, 1e-5)
+		require.NoError(t, err)
+		assert.Equal(t, simplePKField.id, cs.pkFieldID)
+		assert.Equal(t, map[FieldID]chunkStatsField{simpleConstField.id: {rowOffset: defaultDim * 4, size: 4}}, cs.statsFields)
+
+		records, err := genSimpleCommonBlob()
+		require.NoError(t, err)
+		require.NoError(t, cs.append(0, timestamps, records))
+		column := cs.stats[0].columns[simpleConstField.id]
+		assert.Equal(t, int64(3), column.values[3])
+		assert.Equal(t, int64(0), column.min)
+		assert.Equal(t, int64(15), column.max)
+		// the value of the tracked field of each row is mirrored too
+		assert.Equal(t, int64(10*(16+defaultDim*4+8)), estimateChunkSearchSize(collection, 10))
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		_, err := newGrowingChunkSearch(collection, 0, 1e-5)
+		assert.Error(t, err)
+		_, err = newGrowingChunkSearch(collection, 16, -1)
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported schema", func(t *testing.T) {
+		schema := genSimpleSegCoreSchema()
+		schema.Fields[0].DataType = schemapb.DataType_BinaryVector
+		_, err := newGrowingChunkSearch(newCollection(defaultCollectionID, schema), 16, 1e-5)
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), estimateChunkSearchSize(newCollection(defaultCollectionID, schema), 10))
+
+		schema = genSimpleSegCoreSchema()
+		schema.Fields[2].DataType = schemapb.DataType_VarChar
+		schema.Fields[2].TypeParams = []*commonpb.KeyValuePair{{Key: "max_length_per_row", Value: "64"}}
+		_, err = newGrowingChunkSearch(newCollection(defaultCollectionID, schema), 16, 1e-5)
+		assert.Error(t, err)
+	})
+}
+
+func TestGrowingChunkSearch_tolerance(t *testing.T) {
+	const dim = 2
+	search := func(tolerance float64) []int64 {
+		cs := newTestChunkSearch(dim, 2)
+		cs.tolerance = tolerance
+		// the deleted row 1 is a bit less similar than the top row 0 by float32 rounding
+		records := genChunkSearchRecords([]int64{0, 1, 2}, []float32{1, 0, 1 - 1e-7, 0, 0.5, 0}, dim)
+		require.NoError(t, cs.append(0, []Timestamp{1, 1, 1}, records))
+		cs.delete([]int64{1})
+		result, ok, err := cs.search(chunkSearchTestFieldID, [][]float32{{1, 0}}, 1, distance.IP, 1, 0, nil)
+		require.NoError(t, err)
+		require.True(t, ok)
+		return result.candidates
+	}
+	// the deleted row within the tolerance of the kth row is still searched by segcore
+	assert.Equal(t, []int64{0, 1}, search(1e-5))
+	assert.Equal(t, []int64{0}, search(0))
+}
+
+func genPKColumnInfo() *planpb.ColumnInfo {
+	return &planpb.ColumnInfo{
+		FieldId:      simplePKField.id,
+		DataType:     schemapb.DataType_Int64,
+		IsPrimaryKey: true,
+	}
+}
+
+// genPKRangeExpr generates the predicate `lower <= pk <= upper`
+func genPKRangeExpr(lower, upper int64) *planpb.Expr {
+	return &planpb.Expr{
+		Expr: &planpb.Expr_BinaryRangeExpr{
+			BinaryRangeExpr: &planpb.BinaryRangeExpr{
+				ColumnInfo:     genPKColumnInfo(),
+				LowerInclusive: true,
+				UpperInclusive: true,
+				LowerValue:     &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: lower}},
+				UpperValue:     &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: upper}},
+			},
+		},
+	}
+}
+
+func genSearchPlanWithPredicates(t testing.TB, predicates *planpb.Expr) *SearchPlan {
+	planNode := &planpb.PlanNode{
+		Node: &planpb.PlanNode_VectorAnns{
+			VectorAnns: &planpb.VectorANNS{
+				FieldId: simpleVecField.id,
+				QueryInfo: &planpb.QueryInfo{
+					Topk:         defaultTopK,
+					MetricType:   L2,
+					SearchParams: `{"nprobe": 10}`,
+					RoundDecimal: -1,
+				},
+				Predicates:     predicates,
+				PlaceholderTag: "$0",
+			},
+		},
+	}
+	expr, err := proto.Marshal(planNode)
+	require.NoError(t, err)
+	plan, err := createSearchPlanByExpr(newCollection(defaultCollectionID, genSimpleSegCoreSchema()), expr)
+	require.NoError(t, err)
+	return plan
+}
+
+// searchSegmentResultData searches segment and returns the reduced results
+func searchSegmentResultData(t testing.TB, segment *Segment, plan *SearchPlan, placeholderGroup []byte) *schemapb.SearchResultData {
+	req, err := parseSearchRequest(plan, placeholderGroup)
+	require.NoError(t, err)
+	defer req.delete()
+
+	searchResult, err := segment.search(context.Background(), plan, []*searchRequest{req}, []Timestamp{typeutil.MaxTimestamp})
+	require.NoError(t, err)
+	searchResults := []*SearchResult{searchResult}
+	defer deleteSearchResults(searchResults)
+	require.NoError(t, reduceSearchResultsAndFillData(plan, searchResults, 1))
+	nq := req.getNumOfQuery()
+	reqSlices, err := getReqSlices([]int64{nq}, nq)
+	require.NoError(t, err)
+	blobs, err := marshal(defaultCollectionID, 0, searchResults, 1, reqSlices)
+	require.NoError(t, err)
+	defer deleteSearchResultDataBlobs(blobs)
+	blob, err := getSearchResultDataBlob(blobs, 0)
+	require.NoError(t, err)
+
+	data := &schemapb.SearchResultData{}
+	require.NoError(t, proto.Unmarshal(blob, data))
+	return data
+}
+
+func TestSegment_searchByChunks(t *testing.T) {
+	Params.QueryNodeCfg.EnableGrowingChunkSearch = true
+	defer func() { Params.QueryNodeCfg.EnableGrowingChunkSearch = false }()
+
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	collection.setGrowingChunkRows(16)
+	collection.setChunkStatsFields([]FieldID{simpleConstField.id})
+	segment, err := newSegment(collection, defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeGrowing, true)
+	require.NoError(t, err)
+	defer deleteSegment(segment)
+	require.NotNil(t, segment.chunkSearch)
+
+	ids := genSimpleRowIDField()
+	timestamps := genSimpleTimestampFieldData()
+	records, err := genSimpleCommonBlob()
+	require.NoError(t, err)
+	offset, err := segment.segmentPreInsert(len(records))
+	require.NoError(t, err)
+	require.NoError(t, segment.segmentInsert(offset, &ids, &timestamps, &records))
+	assert.Greater(t, segment.chunkSearch.memSize(), int64(0))
+
+	placeholderGroup, err := genPlaceHolderGroup(defaultNQ)
+	require.NoError(t, err)
+	search := func(byChunks bool) proto.Message {
+		plan := genSearchPlanWithPredicates(t, nil)
+		defer plan.delete()
+		require.True(t, plan.chunkSearchable)
+		plan.chunkSearchable = byChunks
+		return searchSegmentResultData(t, segment, plan, placeholderGroup)
+	}
+
+	// the results of the chunk search are identical to the full scan
+	expected := search(false)
+	assert.True(t, proto.Equal(expected, search(true)))
+
+	// so are they after the top rows are deleted
+	pks := []primaryKey{newInt64PrimaryKey(int64(defaultMsgLength - 1)), newInt64PrimaryKey(int64(defaultMsgLength - 2))}
+	deleteTimestamps := []Timestamp{Timestamp(defaultMsgLength), Timestamp(defaultMsgLength)}
+	deleteOffset := segment.segmentPreDelete(len(pks))
+	require.NoError(t, segment.segmentDelete(deleteOffset, pks, deleteTimestamps))
+	expected = search(false)
+	assert.True(t, proto.Equal(expected, search(true)))
+
+	t.Run("predicate", func(t *testing.T) {
+		searchWithPredicates := func(predicates *planpb.Expr, byChunks bool) proto.Message {
+			plan := genSearchPlanWithPredicates(t, predicates)
+			defer plan.delete()
+			require.True(t, plan.chunkSearchable)
+			plan.chunkSearchable = byChunks
+			return searchSegmentResultData(t, segment, plan, placeholderGroup)
+		}
+		constColumn := &planpb.ColumnInfo{FieldId: simpleConstField.id, DataType: schemapb.DataType_Int32}
+		for _, predicates := range []*planpb.Expr{
+			genPKRangeExpr(0, 9),
+			genAndExpr(genPKRangeExpr(2, 12), genUnaryRangeExpr(constColumn, planpb.OpType_NotEqual, 5)),
+			genUnaryRangeExpr(constColumn, planpb.OpType_GreaterEqual, int64(defaultMsgLength-5)),
+		} {
+			expected := searchWithPredicates(predicates, false)
+			assert.True(t, proto.Equal(expected, searchWithPredicates(predicates, true)), predicates.String())
+		}
+
+		plan := genSearchPlanWithPredicates(t, &planpb.Expr{Expr: &planpb.Expr_TermExpr{TermExpr: &planpb.TermExpr{
+			ColumnInfo: genPKColumnInfo(),
+			Values:     []*planpb.GenericValue{genInt64Value(1)},
+		}}})
+		defer plan.delete()
+		assert.False(t, plan.chunkSearchable)
+	})
+
+	t.Run("compacted", func(t *testing.T) {
+		_, err := segment.compactGrowing(Timestamp(defaultMsgLength))
+		require.NoError(t, err)
+		plan := genSearchPlanWithPredicates(t, nil)
+		defer plan.delete()
+		req, err := parseSearchRequest(plan, placeholderGroup)
+		require.NoError(t, err)
+		defer req.delete()
+		_, ok, err := segment.searchByChunks(plan, req, Timestamp(defaultMsgLength))
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func BenchmarkGrowingChunkSearch_search(b *testing.B) {
+	const (
+		n         = 100000
+		dim       = 128
+		chunkRows = 1000
+		topk      = 10
+	)
+	cs, _ := genChunkSearchData(b, n, dim, chunkRows)
+	queries := [][]float32{genScaledVectors(1, dim)}
+
+	var skipped int
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, _, err := cs.search(chunkSearchTestFieldID, queries, topk, distance.IP, Timestamp(n), 0, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		skipped += result.skippedChunks
+	}
+	b.ReportMetric(float64(skipped)/float64(b.N), "skipped_chunks/op")
+	b.ReportMetric(float64(len(cs.fields[chunkSearchTestFieldID].chunks)), "chunks")
+}
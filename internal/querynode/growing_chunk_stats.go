@@ -0,0 +1,352 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// maxChunkStatsFields is the max number of the fields tracked per chunk besides the primary key
+const maxChunkStatsFields = 2
+
+// parseChunkStatsFields returns the ids of the comma separated field names whose ranges are tracked per chunk,
+// they must be integer fields other than the primary key, which is always tracked
+func parseChunkStatsFields(collection *Collection, value string) ([]FieldID, error) {
+	var fieldIDs []FieldID
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		field, err := collection.getFieldByName(name)
+		if err != nil {
+			return nil, err
+		}
+		if field.schema.GetIsPrimaryKey() {
+			return nil, fmt.Errorf("primary key field %s is always tracked", name)
+		}
+		if _, ok := intRangeOfType(field.schema.GetDataType()); !ok {
+			return nil, fmt.Errorf("field %s of %s is not an integer field", name, field.schema.GetDataType().String())
+		}
+		for _, fieldID := range fieldIDs {
+			if fieldID == field.schema.GetFieldID() {
+				return nil, fmt.Errorf("duplicated field %s", name)
+			}
+		}
+		fieldIDs = append(fieldIDs, field.schema.GetFieldID())
+	}
+	if len(fieldIDs) > maxChunkStatsFields {
+		return nil, fmt.Errorf("%d fields exceed the max %d fields tracked per chunk", len(fieldIDs), maxChunkStatsFields)
+	}
+	return fieldIDs, nil
+}
+
+// intRange is the inclusive range [lower, upper] of integers, empty if lower > upper
+type intRange struct {
+	lower int64
+	upper int64
+}
+
+var fullIntRange = intRange{lower: math.MinInt64, upper: math.MaxInt64}
+
+func (r intRange) empty() bool {
+	return r.lower > r.upper
+}
+
+func (r intRange) contains(v int64) bool {
+	return v >= r.lower && v <= r.upper
+}
+
+func (r intRange) intersect(other intRange) intRange {
+	if other.lower > r.lower {
+		r.lower = other.lower
+	}
+	if other.upper < r.upper {
+		r.upper = other.upper
+	}
+	return r
+}
+
+// intRangeOfType returns the range of the values of the integer type, false if dataType is not an integer type
+func intRangeOfType(dataType schemapb.DataType) (intRange, bool) {
+	switch dataType {
+	case schemapb.DataType_Int8:
+		return intRange{lower: math.MinInt8, upper: math.MaxInt8}, true
+	case schemapb.DataType_Int16:
+		return intRange{lower: math.MinInt16, upper: math.MaxInt16}, true
+	case schemapb.DataType_Int32:
+		return intRange{lower: math.MinInt32, upper: math.MaxInt32}, true
+	case schemapb.DataType_Int64:
+		return fullIntRange, true
+	}
+	return intRange{}, false
+}
+
+// chunkPredicate is a conjunction of the range and inequality conditions on integer fields, which the chunk search
+// evaluates against the ranges tracked per chunk to order the chunks, and against the rows to select the candidates
+type chunkPredicate struct {
+	ranges   map[FieldID]intRange
+	excluded map[FieldID][]int64
+}
+
+// newChunkPredicate parses the predicate of expr, false if expr is not a conjunction of `>`, `>=`, `<`, `<=`, `==`,
+// `!=` and range conditions on integer fields with integer values in the range of the fields
+func newChunkPredicate(expr *planpb.Expr) (*chunkPredicate, bool) {
+	pred := &chunkPredicate{
+		ranges:   make(map[FieldID]intRange),
+		excluded: make(map[FieldID][]int64),
+	}
+	if !pred.parse(expr) {
+		return nil, false
+	}
+	return pred, true
+}
+
+func (p *chunkPredicate) parse(expr *planpb.Expr) bool {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_BinaryExpr:
+		return e.BinaryExpr.GetOp() == planpb.BinaryExpr_LogicalAnd &&
+			p.parse(e.BinaryExpr.GetLeft()) && p.parse(e.BinaryExpr.GetRight())
+	case *planpb.Expr_UnaryRangeExpr:
+		fieldID, value, ok := parseChunkPredicateValue(e.UnaryRangeExpr.GetColumnInfo(), e.UnaryRangeExpr.GetValue())
+		if !ok {
+			return false
+		}
+		r := fullIntRange
+		switch e.UnaryRangeExpr.GetOp() {
+		case planpb.OpType_GreaterThan:
+			if value == math.MaxInt64 {
+				r = intRange{lower: math.MaxInt64, upper: math.MinInt64}
+			} else {
+				r.lower = value + 1
+			}
+		case planpb.OpType_GreaterEqual:
+			r.lower = value
+		case planpb.OpType_LessThan:
+			if value == math.MinInt64 {
+				r = intRange{lower: math.MaxInt64, upper: math.MinInt64}
+			} else {
+				r.upper = value - 1
+			}
+		case planpb.OpType_LessEqual:
+			r.upper = value
+		case planpb.OpType_Equal:
+			r = intRange{lower: value, upper: value}
+		case planpb.OpType_NotEqual:
+			p.excluded[fieldID] = append(p.excluded[fieldID], value)
+		default:
+			return false
+		}
+		p.addRange(fieldID, r)
+		return true
+	case *planpb.Expr_BinaryRangeExpr:
+		column := e.BinaryRangeExpr.GetColumnInfo()
+		fieldID, lower, ok := parseChunkPredicateValue(column, e.BinaryRangeExpr.GetLowerValue())
+		if !ok {
+			return false
+		}
+		_, upper, ok := parseChunkPredicateValue(column, e.BinaryRangeExpr.GetUpperValue())
+		if !ok {
+			return false
+		}
+		r := intRange{lower: lower, upper: upper}
+		if !e.BinaryRangeExpr.GetLowerInclusive() {
+			if lower == math.MaxInt64 {
+				r.upper = math.MinInt64
+			} else {
+				r.lower++
+			}
+		}
+		if !e.BinaryRangeExpr.GetUpperInclusive() {
+			if upper == math.MinInt64 {
+				r.lower = math.MaxInt64
+			} else {
+				r.upper--
+			}
+		}
+		p.addRange(fieldID, r)
+		return true
+	}
+	return false
+}
+
+func (p *chunkPredicate) addRange(fieldID FieldID, r intRange) {
+	if current, ok := p.ranges[fieldID]; ok {
+		r = r.intersect(current)
+	}
+	p.ranges[fieldID] = r
+}
+
+// parseChunkPredicateValue returns the field of column and the integer value, false if either is not an integer or
+// the value is out of the range of the field, which segcore would cast to the type of the field
+func parseChunkPredicateValue(column *planpb.ColumnInfo, value *planpb.GenericValue) (FieldID, int64, bool) {
+	typeRange, ok := intRangeOfType(column.GetDataType())
+	if !ok {
+		return 0, 0, false
+	}
+	v, ok := value.GetVal().(*planpb.GenericValue_Int64Val)
+	if !ok || !typeRange.contains(v.Int64Val) {
+		return 0, 0, false
+	}
+	return column.GetFieldId(), v.Int64Val, true
+}
+
+// fieldIDs returns the fields the predicate is on
+func (p *chunkPredicate) fieldIDs() []FieldID {
+	fieldIDs := make([]FieldID, 0, len(p.ranges))
+	for fieldID := range p.ranges {
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+	return fieldIDs
+}
+
+// matchValue returns whether value of fieldID matches the predicate
+func (p *chunkPredicate) matchValue(fieldID FieldID, value int64) bool {
+	if !p.ranges[fieldID].contains(value) {
+		return false
+	}
+	for _, excluded := range p.excluded[fieldID] {
+		if value == excluded {
+			return false
+		}
+	}
+	return true
+}
+
+// the matches of the rows of a chunk to the predicate and the timestamps of a search
+type chunkMatch int
+
+const (
+	// chunkMatchNone means no row of the chunk matches
+	chunkMatchNone chunkMatch = iota
+	// chunkMatchSome means some rows of the chunk may match
+	chunkMatchSome
+	// chunkMatchAll means all rows of the chunk match
+	chunkMatchAll
+)
+
+// intColumn is the values of an integer field of the rows of a chunk by offset, with their range
+type intColumn struct {
+	values []int64
+	min    int64
+	max    int64
+}
+
+// chunkStats is the ranges of the insert timestamps and the tracked fields of the rows set of a chunk
+type chunkStats struct {
+	minTs   Timestamp
+	maxTs   Timestamp
+	pkRange intRange
+	columns map[FieldID]*intColumn
+}
+
+func newChunkStats(fieldIDs []FieldID) *chunkStats {
+	stats := &chunkStats{
+		minTs:   unfilledRowTs,
+		maxTs:   0,
+		pkRange: intRange{lower: math.MaxInt64, upper: math.MinInt64},
+		columns: make(map[FieldID]*intColumn, len(fieldIDs)),
+	}
+	for _, fieldID := range fieldIDs {
+		stats.columns[fieldID] = &intColumn{min: math.MaxInt64, max: math.MinInt64}
+	}
+	return stats
+}
+
+// set sets the row at offset of the chunk, values are the values of the tracked fields of the row
+func (s *chunkStats) set(offset int, pk int64, timestamp Timestamp, values map[FieldID]int64) {
+	if timestamp < s.minTs {
+		s.minTs = timestamp
+	}
+	if timestamp > s.maxTs {
+		s.maxTs = timestamp
+	}
+	if pk < s.pkRange.lower {
+		s.pkRange.lower = pk
+	}
+	if pk > s.pkRange.upper {
+		s.pkRange.upper = pk
+	}
+	for fieldID, column := range s.columns {
+		value := values[fieldID]
+		for len(column.values) <= offset {
+			column.values = append(column.values, 0)
+		}
+		column.values[offset] = value
+		if value < column.min {
+			column.min = value
+		}
+		if value > column.max {
+			column.max = value
+		}
+	}
+}
+
+// match returns how the rows of the chunk match pred and are visible at timestamp and not expired by expireTs,
+// pkFieldID is the field of the primary key
+func (s *chunkStats) match(pred *chunkPredicate, pkFieldID FieldID, timestamp Timestamp, expireTs Timestamp) chunkMatch {
+	if s.minTs > s.maxTs || s.minTs > timestamp || (expireTs > 0 && s.maxTs < expireTs) {
+		return chunkMatchNone
+	}
+	match := chunkMatchAll
+	if s.maxTs > timestamp || (expireTs > 0 && s.minTs < expireTs) {
+		match = chunkMatchSome
+	}
+	for fieldID, r := range pred.ranges {
+		chunkRange := s.pkRange
+		if fieldID != pkFieldID {
+			column := s.columns[fieldID]
+			chunkRange = intRange{lower: column.min, upper: column.max}
+		}
+		overlap := chunkRange.intersect(r)
+		if overlap.empty() {
+			return chunkMatchNone
+		}
+		if overlap != chunkRange {
+			match = chunkMatchSome
+		}
+		for _, excluded := range pred.excluded[fieldID] {
+			if chunkRange.lower == excluded && chunkRange.upper == excluded {
+				return chunkMatchNone
+			}
+			if chunkRange.contains(excluded) {
+				match = chunkMatchSome
+			}
+		}
+	}
+	return match
+}
+
+// parseChunkStatsValue parses the little endian signed integer of size bytes
+func parseChunkStatsValue(value []byte, size int) int64 {
+	switch size {
+	case 1:
+		return int64(int8(value[0]))
+	case 2:
+		return int64(int16(common.Endian.Uint16(value)))
+	case 4:
+		return int64(int32(common.Endian.Uint32(value)))
+	default:
+		return int64(common.Endian.Uint64(value))
+	}
+}
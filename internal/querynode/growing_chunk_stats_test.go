@@ -0,0 +1,294 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/distance"
+)
+
+const chunkStatsTestFieldID = FieldID(101)
+
+func genInt64Value(v int64) *planpb.GenericValue {
+	return &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: v}}
+}
+
+func genUnaryRangeExpr(column *planpb.ColumnInfo, op planpb.OpType, v int64) *planpb.Expr {
+	return &planpb.Expr{
+		Expr: &planpb.Expr_UnaryRangeExpr{
+			UnaryRangeExpr: &planpb.UnaryRangeExpr{ColumnInfo: column, Op: op, Value: genInt64Value(v)},
+		},
+	}
+}
+
+func genAndExpr(left, right *planpb.Expr) *planpb.Expr {
+	return &planpb.Expr{
+		Expr: &planpb.Expr_BinaryExpr{
+			BinaryExpr: &planpb.BinaryExpr{Op: planpb.BinaryExpr_LogicalAnd, Left: left, Right: right},
+		},
+	}
+}
+
+func genStatsColumnInfo() *planpb.ColumnInfo {
+	return &planpb.ColumnInfo{FieldId: chunkStatsTestFieldID, DataType: schemapb.DataType_Int64}
+}
+
+// genChunkStatsData mirrors n rows with the tracked field, the pk of row i is i, its timestamp is i + 1, and the
+// value of the tracked field is i
+func genChunkStatsData(t testing.TB, n, dim, chunkRows int) (*growingChunkSearch, []float32) {
+	vectors := genScaledVectors(n, dim)
+	pks := make([]int64, n)
+	timestamps := make([]Timestamp, n)
+	for i := range pks {
+		pks[i] = int64(i)
+		timestamps[i] = Timestamp(i + 1)
+	}
+	records := genChunkSearchRecords(pks, vectors, dim)
+	for i, record := range records {
+		value := make([]byte, 8)
+		common.Endian.PutUint64(value, uint64(i))
+		record.Value = append(record.Value, value...)
+	}
+	cs := newTestChunkSearch(dim, chunkRows)
+	cs.pkFieldID = simplePKField.id
+	cs.statsFields = map[FieldID]chunkStatsField{chunkStatsTestFieldID: {rowOffset: dim*4 + 8, size: 8}}
+	require.NoError(t, cs.append(0, timestamps, records))
+	return cs, vectors
+}
+
+// unmatchedRows returns the rows of n rows not matching pred, the pk and the tracked field of row i are both i
+func unmatchedRows(pred *chunkPredicate, n int) map[int64]struct{} {
+	unmatched := make(map[int64]struct{})
+	for i := int64(0); i < int64(n); i++ {
+		for fieldID := range pred.ranges {
+			if !pred.matchValue(fieldID, i) {
+				unmatched[i] = struct{}{}
+			}
+		}
+	}
+	return unmatched
+}
+
+func TestParseChunkStatsFields(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	fieldIDs, err := parseChunkStatsFields(collection, " "+defaultConstFieldName+" ")
+	require.NoError(t, err)
+	assert.Equal(t, []FieldID{simpleConstField.id}, fieldIDs)
+
+	fieldIDs, err = parseChunkStatsFields(collection, "")
+	require.NoError(t, err)
+	assert.Empty(t, fieldIDs)
+
+	invalids := []string{
+		defaultPKFieldName,
+		defaultVecFieldName,
+		"not_exist",
+		defaultConstFieldName + "," + defaultConstFieldName,
+	}
+	for _, value := range invalids {
+		_, err := parseChunkStatsFields(collection, value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestNewChunkPredicate(t *testing.T) {
+	column := genStatsColumnInfo()
+	pred, ok := newChunkPredicate(genAndExpr(
+		genUnaryRangeExpr(column, planpb.OpType_GreaterThan, 10),
+		genAndExpr(genUnaryRangeExpr(column, planpb.OpType_LessEqual, 20), genUnaryRangeExpr(column, planpb.OpType_NotEqual, 15)),
+	))
+	require.True(t, ok)
+	assert.Equal(t, intRange{lower: 11, upper: 20}, pred.ranges[chunkStatsTestFieldID])
+	assert.False(t, pred.matchValue(chunkStatsTestFieldID, 10))
+	assert.True(t, pred.matchValue(chunkStatsTestFieldID, 11))
+	assert.False(t, pred.matchValue(chunkStatsTestFieldID, 15))
+	assert.True(t, pred.matchValue(chunkStatsTestFieldID, 20))
+
+	pred, ok = newChunkPredicate(genPKRangeExpr(5, 9))
+	require.True(t, ok)
+	assert.Equal(t, intRange{lower: 5, upper: 9}, pred.ranges[simplePKField.id])
+
+	// the conditions never matched
+	pred, ok = newChunkPredicate(genUnaryRangeExpr(column, planpb.OpType_GreaterThan, math.MaxInt64))
+	require.True(t, ok)
+	assert.True(t, pred.ranges[chunkStatsTestFieldID].empty())
+	pred, ok = newChunkPredicate(genUnaryRangeExpr(column, planpb.OpType_LessThan, math.MinInt64))
+	require.True(t, ok)
+	assert.True(t, pred.ranges[chunkStatsTestFieldID].empty())
+
+	unsupported := []*planpb.Expr{
+		{Expr: &planpb.Expr_BinaryExpr{BinaryExpr: &planpb.BinaryExpr{
+			Op: planpb.BinaryExpr_LogicalOr, Left: genPKRangeExpr(0, 1), Right: genPKRangeExpr(5, 6),
+		}}},
+		{Expr: &planpb.Expr_TermExpr{TermExpr: &planpb.TermExpr{ColumnInfo: column, Values: []*planpb.GenericValue{genInt64Value(1)}}}},
+		{Expr: &planpb.Expr_UnaryRangeExpr{UnaryRangeExpr: &planpb.UnaryRangeExpr{
+			ColumnInfo: &planpb.ColumnInfo{FieldId: chunkStatsTestFieldID, DataType: schemapb.DataType_Float},
+			Op:         planpb.OpType_GreaterThan,
+			Value:      &planpb.GenericValue{Val: &planpb.GenericValue_FloatVal{FloatVal: 1}},
+		}}},
+		// out of the range of int8, which segcore casts
+		genUnaryRangeExpr(&planpb.ColumnInfo{FieldId: chunkStatsTestFieldID, DataType: schemapb.DataType_Int8},
+			planpb.OpType_LessThan, 300),
+	}
+	for _, expr := range unsupported {
+		_, ok := newChunkPredicate(expr)
+		assert.False(t, ok, expr.String())
+	}
+}
+
+func TestGrowingChunkSearch_predicate(t *testing.T) {
+	const (
+		n         = 5000
+		dim       = 16
+		chunkRows = 100
+		topk      = 10
+	)
+	cs, vectors := genChunkStatsData(t, n, dim, chunkRows)
+	require.Equal(t, n/chunkRows, len(cs.stats))
+	assert.Equal(t, Timestamp(1), cs.stats[0].minTs)
+	assert.Equal(t, Timestamp(chunkRows), cs.stats[0].maxTs)
+	assert.Equal(t, intRange{lower: 0, upper: chunkRows - 1}, cs.stats[0].pkRange)
+	assert.Equal(t, int64(chunkRows), cs.stats[1].columns[chunkStatsTestFieldID].min)
+
+	column := genStatsColumnInfo()
+	exprs := []*planpb.Expr{
+		genUnaryRangeExpr(column, planpb.OpType_GreaterEqual, n-150),
+		genUnaryRangeExpr(column, planpb.OpType_LessThan, 42),
+		genAndExpr(genPKRangeExpr(1000, 3000), genUnaryRangeExpr(column, planpb.OpType_NotEqual, 2000)),
+		genUnaryRangeExpr(genPKColumnInfo(), planpb.OpType_Equal, 777),
+		genUnaryRangeExpr(column, planpb.OpType_GreaterThan, n),
+	}
+	for _, expr := range exprs {
+		pred, ok := newChunkPredicate(expr)
+		require.True(t, ok)
+		unmatched := unmatchedRows(pred, n)
+		for _, metricType := range []string{distance.IP, distance.L2} {
+			for i := 0; i < 10; i++ {
+				query := genScaledVectors(1, dim)
+				for _, timestamp := range []Timestamp{Timestamp(n), Timestamp(n / 3), Timestamp(rand.Intn(n) + 1)} {
+					// the results are identical to the full scan of the rows matched
+					result, ok, err := cs.search(chunkSearchTestFieldID, [][]float32{query}, topk, metricType, timestamp, 0, pred)
+					require.NoError(t, err)
+					require.True(t, ok)
+					expected := fullScan(vectors, dim, query, topk, metricType, timestamp, unmatched)
+					assert.Equal(t, expected, result.candidates, "%s, metric %s, timestamp %d", expr.String(), metricType, timestamp)
+					assert.Equal(t, len(cs.stats), result.scannedChunks+result.skippedChunks)
+				}
+			}
+		}
+	}
+
+	t.Run("deletes and expire", func(t *testing.T) {
+		pred, ok := newChunkPredicate(genPKRangeExpr(400, 2600))
+		require.True(t, ok)
+		query := genScaledVectors(1, dim)
+		// the rows of timestamps before 501 are expired, i.e. the first 500 rows
+		excluded := unmatchedRows(pred, n)
+		for i := int64(0); i < 500; i++ {
+			excluded[i] = struct{}{}
+		}
+		alive := fullScan(vectors, dim, query, topk, distance.IP, Timestamp(n), excluded)
+		cs.delete([]int64{alive[0]})
+		defer delete(cs.deletedPKs, alive[0])
+		excluded[alive[0]] = struct{}{}
+
+		result, ok, err := cs.search(chunkSearchTestFieldID, [][]float32{query}, topk, distance.IP, Timestamp(n), 501, pred)
+		require.NoError(t, err)
+		require.True(t, ok)
+		for _, offset := range fullScan(vectors, dim, query, topk, distance.IP, Timestamp(n), excluded) {
+			assert.Contains(t, result.candidates, offset)
+		}
+		// the deleted row matched is still searched by segcore
+		assert.Contains(t, result.candidates, alive[0])
+		assert.Equal(t, topk+1, len(result.candidates))
+	})
+
+	t.Run("untracked field", func(t *testing.T) {
+		pred, ok := newChunkPredicate(genUnaryRangeExpr(&planpb.ColumnInfo{FieldId: 999, DataType: schemapb.DataType_Int64},
+			planpb.OpType_GreaterThan, 0))
+		require.True(t, ok)
+		_, ok, err := cs.search(chunkSearchTestFieldID, [][]float32{genScaledVectors(1, dim)}, topk, distance.IP, Timestamp(n), 0, pred)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestGrowingChunkSearch_recencyFilter(t *testing.T) {
+	const (
+		n         = 5000
+		dim       = 16
+		chunkRows = 100
+		topk      = 10
+	)
+	cs, vectors := genChunkStatsData(t, n, dim, chunkRows)
+
+	// the recency filter matches the rows of the last two chunks only, the other chunks are never scanned
+	pred, ok := newChunkPredicate(genUnaryRangeExpr(genStatsColumnInfo(), planpb.OpType_GreaterEqual, n-150))
+	require.True(t, ok)
+	unmatched := unmatchedRows(pred, n)
+	for _, metricType := range []string{distance.IP, distance.L2} {
+		query := genScaledVectors(1, dim)
+		result, ok, err := cs.search(chunkSearchTestFieldID, [][]float32{query}, topk, metricType, Timestamp(n), 0, pred)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, fullScan(vectors, dim, query, topk, metricType, Timestamp(n), unmatched), result.candidates)
+		assert.LessOrEqual(t, result.scannedChunks, 2)
+		assert.Equal(t, n/chunkRows, result.scannedChunks+result.skippedChunks)
+	}
+
+	// the chunks of the rows inserted after the timestamp of search are never scanned either
+	pred, ok = newChunkPredicate(genPKRangeExpr(0, n))
+	require.True(t, ok)
+	query := genScaledVectors(1, dim)
+	result, ok, err := cs.search(chunkSearchTestFieldID, [][]float32{query}, topk, distance.L2, Timestamp(250), 0, pred)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, fullScan(vectors, dim, query, topk, distance.L2, Timestamp(250), nil), result.candidates)
+	assert.LessOrEqual(t, result.scannedChunks, 3)
+}
+
+func TestChunkStats_match(t *testing.T) {
+	stats := newChunkStats([]FieldID{chunkStatsTestFieldID})
+	pred, ok := newChunkPredicate(genUnaryRangeExpr(genStatsColumnInfo(), planpb.OpType_NotEqual, 5))
+	require.True(t, ok)
+	// no row set
+	assert.Equal(t, chunkMatchNone, stats.match(pred, simplePKField.id, 10, 0))
+
+	stats.set(0, 1, 5, map[FieldID]int64{chunkStatsTestFieldID: 5})
+	assert.Equal(t, chunkMatchNone, stats.match(pred, simplePKField.id, 10, 0))
+	stats.set(1, 2, 8, map[FieldID]int64{chunkStatsTestFieldID: 6})
+	assert.Equal(t, chunkMatchSome, stats.match(pred, simplePKField.id, 10, 0))
+
+	pred, ok = newChunkPredicate(genUnaryRangeExpr(genStatsColumnInfo(), planpb.OpType_GreaterEqual, 5))
+	require.True(t, ok)
+	assert.Equal(t, chunkMatchAll, stats.match(pred, simplePKField.id, 10, 0))
+	// some rows are invisible or expired
+	assert.Equal(t, chunkMatchSome, stats.match(pred, simplePKField.id, 6, 0))
+	assert.Equal(t, chunkMatchSome, stats.match(pred, simplePKField.id, 10, 6))
+	// all rows are invisible or expired
+	assert.Equal(t, chunkMatchNone, stats.match(pred, simplePKField.id, 4, 0))
+	assert.Equal(t, chunkMatchNone, stats.match(pred, simplePKField.id, 10, 9))
+}
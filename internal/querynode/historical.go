@@ -37,6 +37,8 @@ type historical struct {
 
 	replica      ReplicaInterface
 	tSafeReplica TSafeReplicaInterface
+
+	binlogExists *binlogExistCache // caches the existence of the binlogs to retrieve the vector fields from
 }
 
 // newHistorical returns a new historical
@@ -48,6 +50,7 @@ func newHistorical(ctx context.Context,
 		ctx:          ctx,
 		replica:      replica,
 		tSafeReplica: tSafeReplica,
+		binlogExists: newBinlogExistCache(Params.QueryNodeCfg.RetrieveBinlogExistTTL),
 	}
 }
 
@@ -67,8 +70,18 @@ func (h *historical) retrieve(collID UniqueID, partIDs []UniqueID, vcm storage.C
 		return retrieveResults, retrieveSegmentIDs, retrievePartIDs, err
 	}
 
+	if len(partIDs) == 0 {
+		col, err := h.replica.getCollectionByID(collID)
+		if err != nil {
+			return retrieveResults, retrieveSegmentIDs, retrievePartIDs, err
+		}
+		retrievePartIDs = prunePartitionsByKey(h.replica, col, retrievePartIDs, plan.partitionKeys, metrics.QueryLabel)
+	}
+
 	log.Debug("retrieve target partitions", zap.Int64("collectionID", collID), zap.Int64s("partitionIDs", retrievePartIDs))
 
+	tracker := newBinlogTracker(Params.QueryNodeCfg.MaxRetrieveBinlogFiles)
+	defer tracker.observe()
 	for _, partID := range retrievePartIDs {
 		segIDs, err := h.replica.getSegmentIDs(partID)
 		if err != nil {
@@ -79,12 +92,29 @@ func (h *historical) retrieve(collID UniqueID, partIDs []UniqueID, vcm storage.C
 			if err != nil {
 				return retrieveResults, retrieveSegmentIDs, retrievePartIDs, err
 			}
+			// the segment is served by another node
+			if !seg.getOnService() {
+				continue
+			}
+			if seg.isQuarantined() {
+				return retrieveResults, retrieveSegmentIDs, retrievePartIDs, &segmentQuarantinedError{segmentID: segID}
+			}
+			// skip the segment if none of the looked up pks is in it
+			if !seg.mayContainPKs(plan.pks) {
+				continue
+			}
+			if skipFullyDeletedSegment(seg, plan.Timestamp, metrics.QueryLabel) {
+				continue
+			}
+			if err = seg.checkRetrieveSources(plan.outputFields, vcm, h.binlogExists); err != nil {
+				return retrieveResults, retrieveSegmentIDs, retrievePartIDs, err
+			}
 			result, err := seg.retrieve(plan)
 			if err != nil {
 				return retrieveResults, retrieveSegmentIDs, retrievePartIDs, err
 			}
 
-			if err = seg.fillIndexedFieldsData(collID, vcm, result); err != nil {
+			if err = seg.fillIndexedFieldsData(collID, vcm, result, tracker); err != nil {
 				return retrieveResults, retrieveSegmentIDs, retrievePartIDs, err
 			}
 			retrieveResults = append(retrieveResults, result)
@@ -99,16 +129,27 @@ func (h *historical) retrieve(collID UniqueID, partIDs []UniqueID, vcm storage.C
 func (h *historical) retrieveBySegmentIDs(collID UniqueID, segmentIDs []UniqueID, vcm storage.ChunkManager, plan *RetrievePlan) (
 	retrieveResults []*segcorepb.RetrieveResults, err error) {
 
+	tracker := newBinlogTracker(Params.QueryNodeCfg.MaxRetrieveBinlogFiles)
+	defer tracker.observe()
 	for _, segID := range segmentIDs {
 		seg, err := h.replica.getSegmentByID(segID)
 		if err != nil {
 			return nil, err
 		}
+		if seg.getOnService() && seg.isQuarantined() {
+			return nil, &segmentQuarantinedError{segmentID: segID}
+		}
+		if !seg.getOnService() || !seg.mayContainPKs(plan.pks) || skipFullyDeletedSegment(seg, plan.Timestamp, metrics.QueryLabel) {
+			continue
+		}
+		if err := seg.checkRetrieveSources(plan.outputFields, vcm, h.binlogExists); err != nil {
+			return nil, err
+		}
 		result, err := seg.retrieve(plan)
 		if err != nil {
 			return nil, err
 		}
-		err = seg.fillIndexedFieldsData(collID, vcm, result)
+		err = seg.fillIndexedFieldsData(collID, vcm, result, tracker)
 		if err != nil {
 			return nil, err
 		}
@@ -119,7 +160,7 @@ func (h *historical) retrieveBySegmentIDs(collID UniqueID, segmentIDs []UniqueID
 }
 
 // search will search all the target segments in historical
-func (h *historical) search(searchReqs []*searchRequest, collID UniqueID, partIDs []UniqueID, plan *SearchPlan,
+func (h *historical) search(ctx context.Context, searchReqs []*searchRequest, collID UniqueID, partIDs []UniqueID, plan *SearchPlan,
 	searchTs Timestamp) (searchResults []*SearchResult, searchSegmentIDs []UniqueID, searchPartIDs []UniqueID, err error) {
 
 	searchPartIDs, err = h.getTargetPartIDs(collID, partIDs)
@@ -144,6 +185,10 @@ func (h *historical) search(searchReqs []*searchRequest, collID UniqueID, partID
 		return searchResults, searchSegmentIDs, searchPartIDs, nil
 	}
 
+	if len(partIDs) == 0 {
+		searchPartIDs = prunePartitionsByKey(h.replica, col, searchPartIDs, plan.partitionKeys, metrics.SearchLabel)
+	}
+
 	var segmentIDs []UniqueID
 	for _, partID := range searchPartIDs {
 		segIDs, err := h.replica.getSegmentIDs(partID)
@@ -154,7 +199,7 @@ func (h *historical) search(searchReqs []*searchRequest, collID UniqueID, partID
 		segmentIDs = append(segmentIDs, segIDs...)
 	}
 
-	searchResults, searchSegmentIDs, err = h.searchSegments(segmentIDs, searchReqs, plan, searchTs)
+	searchResults, searchSegmentIDs, err = h.searchSegments(ctx, segmentIDs, searchReqs, plan, searchTs)
 
 	return searchResults, searchSegmentIDs, searchPartIDs, err
 }
@@ -177,17 +222,43 @@ func (h *historical) getTargetPartIDs(collID UniqueID, partIDs []UniqueID) ([]Un
 	return targetPartIDs, nil
 }
 
+// skipFullyDeletedSegment returns whether to skip searching or retrieving segment at ts, for all its rows are deleted
+func skipFullyDeletedSegment(segment *Segment, ts Timestamp, queryType string) bool {
+	if !segment.isFullyDeletedAt(ts) {
+		return false
+	}
+	metrics.QueryNodeFullyDeletedSegmentsSkipped.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), queryType).Inc()
+	log.Debug("skip fully deleted segment", zap.Int64("segmentID", segment.ID()), zap.Uint64("ts", ts))
+	return true
+}
+
 // searchSegments performs search on listed segments
 // all segment ids are validated before calling this function
-func (h *historical) searchSegments(segIDs []UniqueID, searchReqs []*searchRequest, plan *SearchPlan, searchTs Timestamp) ([]*SearchResult, []UniqueID, error) {
+func (h *historical) searchSegments(ctx context.Context, segIDs []UniqueID, searchReqs []*searchRequest, plan *SearchPlan, searchTs Timestamp) ([]*SearchResult, []UniqueID, error) {
 	// pre-fetch all the segment
 	// if error found, return before executing segment search
+	fieldID, metricType := plan.getFieldID(), plan.getMetricType()
 	segments := make([]*Segment, 0, len(segIDs))
 	for _, segID := range segIDs {
 		seg, err := h.replica.getSegmentByID(segID)
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := seg.checkMetricType(fieldID, metricType); err != nil {
+			return nil, nil, err
+		}
+		// the segment is served by another node
+		if !seg.getOnService() {
+			log.Debug("segment not on service", zap.Int64("segmentID", seg.segmentID))
+			continue
+		}
+		// fail rather than return partial results, until the segment is loaded elsewhere
+		if seg.isQuarantined() {
+			return nil, nil, &segmentQuarantinedError{segmentID: seg.segmentID}
+		}
+		if skipFullyDeletedSegment(seg, searchTs, metrics.SearchLabel) {
+			continue
+		}
 		segments = append(segments, seg)
 	}
 
@@ -203,13 +274,9 @@ func (h *historical) searchSegments(segIDs []UniqueID, searchReqs []*searchReque
 		wg.Add(1)
 		go func(seg *Segment) {
 			defer wg.Done()
-			if !seg.getOnService() {
-				log.Warn("segment no on service", zap.Int64("segmentID", seg.segmentID))
-				return
-			}
 			// record search time
 			tr := timerecord.NewTimeRecorder("searchOnSealed")
-			searchResult, err := seg.search(plan, searchReqs, []Timestamp{searchTs})
+			searchResult, err := seg.search(ctx, plan, searchReqs, []Timestamp{searchTs})
 
 			// update metrics
 			metrics.QueryNodeSQSegmentLatency.WithLabelValues(metrics.SearchLabel,
@@ -18,9 +18,19 @@ package querynode
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/distance"
 )
 
 func TestHistorical_Search(t *testing.T) {
@@ -35,7 +45,7 @@ func TestHistorical_Search(t *testing.T) {
 		plan, searchReqs, err := genSimpleSearchPlanAndRequests(IndexFaissIDMap)
 		assert.NoError(t, err)
 
-		_, _, _, err = his.search(searchReqs, defaultCollectionID, []UniqueID{defaultPartitionID}, plan, Timestamp(0))
+		_, _, _, err = his.search(context.Background(), searchReqs, defaultCollectionID, []UniqueID{defaultPartitionID}, plan, Timestamp(0))
 		assert.NoError(t, err)
 	})
 
@@ -50,7 +60,7 @@ func TestHistorical_Search(t *testing.T) {
 		err = his.replica.removeCollection(defaultCollectionID)
 		assert.NoError(t, err)
 
-		_, _, _, err = his.search(searchReqs, defaultCollectionID, []UniqueID{}, plan, Timestamp(0))
+		_, _, _, err = his.search(context.Background(), searchReqs, defaultCollectionID, []UniqueID{}, plan, Timestamp(0))
 		assert.Error(t, err)
 	})
 
@@ -65,7 +75,7 @@ func TestHistorical_Search(t *testing.T) {
 		err = his.replica.removeCollection(defaultCollectionID)
 		assert.NoError(t, err)
 
-		_, _, _, err = his.search(searchReqs, defaultCollectionID, []UniqueID{defaultPartitionID}, plan, Timestamp(0))
+		_, _, _, err = his.search(context.Background(), searchReqs, defaultCollectionID, []UniqueID{defaultPartitionID}, plan, Timestamp(0))
 		assert.Error(t, err)
 	})
 
@@ -84,7 +94,7 @@ func TestHistorical_Search(t *testing.T) {
 		err = his.replica.removePartition(defaultPartitionID)
 		assert.NoError(t, err)
 
-		_, _, _, err = his.search(searchReqs, defaultCollectionID, []UniqueID{}, plan, Timestamp(0))
+		_, _, _, err = his.search(context.Background(), searchReqs, defaultCollectionID, []UniqueID{}, plan, Timestamp(0))
 		assert.Error(t, err)
 	})
 
@@ -99,9 +109,158 @@ func TestHistorical_Search(t *testing.T) {
 		err = his.replica.removePartition(defaultPartitionID)
 		assert.NoError(t, err)
 
-		res, ids, _, err := his.search(searchReqs, defaultCollectionID, []UniqueID{}, plan, Timestamp(0))
+		res, ids, _, err := his.search(context.Background(), searchReqs, defaultCollectionID, []UniqueID{}, plan, Timestamp(0))
 		assert.Equal(t, 0, len(res))
 		assert.Equal(t, 0, len(ids))
 		assert.NoError(t, err)
 	})
+
+	t.Run("test segments not on service", func(t *testing.T) {
+		tSafe := newTSafeReplica()
+		his, err := genSimpleHistorical(ctx, tSafe)
+		assert.NoError(t, err)
+
+		plan, searchReqs, err := genSimpleSearchPlanAndRequests(IndexFaissIDMap)
+		assert.NoError(t, err)
+
+		_, segmentIDs, _, err := his.search(context.Background(), searchReqs, defaultCollectionID, []UniqueID{defaultPartitionID}, plan, Timestamp(0))
+		assert.NoError(t, err)
+		assert.Equal(t, []UniqueID{defaultSegmentID}, segmentIDs)
+
+		// the segment is handed over to another node
+		err = his.replica.syncSegmentServing([]*querypb.SegmentServingAction{{SegmentID: defaultSegmentID, Serving: false}}, 1)
+		assert.NoError(t, err)
+		_, segmentIDs, _, err = his.search(context.Background(), searchReqs, defaultCollectionID, []UniqueID{defaultPartitionID}, plan, Timestamp(0))
+		assert.NoError(t, err)
+		assert.Empty(t, segmentIDs)
+
+		retrievePlan, err := genSimpleRetrievePlan()
+		assert.NoError(t, err)
+		defer retrievePlan.delete()
+		_, retrieveSegmentIDs, _, err := his.retrieve(defaultCollectionID, []UniqueID{defaultPartitionID}, nil, retrievePlan)
+		assert.NoError(t, err)
+		assert.Empty(t, retrieveSegmentIDs)
+		results, err := his.retrieveBySegmentIDs(defaultCollectionID, []UniqueID{defaultSegmentID}, nil, retrievePlan)
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("test search with metric type override", func(t *testing.T) {
+		tSafe := newTSafeReplica()
+		his, err := genSimpleHistorical(ctx, tSafe)
+		assert.NoError(t, err)
+
+		// the collection is built with L2, search with IP
+		schema := genSimpleSegCoreSchema()
+		for _, field := range schema.Fields {
+			if field.FieldID == simpleVecField.id {
+				field.IndexParams = []*commonpb.KeyValuePair{{Key: metricTypeKey, Value: distance.IP}}
+			}
+		}
+		dsl, err := genBruteForceDSL(schema, defaultTopK, defaultRoundDecimal)
+		assert.NoError(t, err)
+		col, err := his.replica.getCollectionByID(defaultCollectionID)
+		assert.NoError(t, err)
+		plan, err := createSearchPlan(col, dsl)
+		assert.NoError(t, err)
+		defer plan.delete()
+		assert.Equal(t, distance.IP, plan.getMetricType())
+		assert.Equal(t, simpleVecField.id, plan.getFieldID())
+		placeholderGroup, err := genSimplePlaceHolderGroup()
+		assert.NoError(t, err)
+		searchReq, err := parseSearchRequest(plan, placeholderGroup)
+		assert.NoError(t, err)
+		defer searchReq.delete()
+
+		// brute force search accepts any metric type
+		_, _, _, err = his.search(context.Background(), []*searchRequest{searchReq}, defaultCollectionID, []UniqueID{defaultPartitionID}, plan, Timestamp(0))
+		assert.NoError(t, err)
+
+		// ivf index only accepts the metric type it's built with
+		seg, err := his.replica.getSegmentByID(defaultSegmentID)
+		assert.NoError(t, err)
+		seg.setIndexedFieldInfo(simpleVecField.id, &IndexedFieldInfo{
+			indexInfo: &querypb.FieldIndexInfo{
+				FieldID:     simpleVecField.id,
+				EnableIndex: true,
+				IndexParams: []*commonpb.KeyValuePair{
+					{Key: "index_type", Value: IndexFaissIVFFlat},
+					{Key: metricTypeKey, Value: distance.L2},
+				},
+			},
+		})
+		_, _, _, err = his.search(context.Background(), []*searchRequest{searchReq}, defaultCollectionID, []UniqueID{defaultPartitionID}, plan, Timestamp(0))
+		assert.Error(t, err)
+		var mismatchErr *metricTypeMismatchError
+		assert.True(t, errors.As(err, &mismatchErr))
+		assert.Contains(t, err.Error(), distance.IP)
+		assert.Contains(t, err.Error(), distance.L2)
+	})
+}
+
+func TestHistorical_skipFullyDeletedSegments(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	his, err := genSimpleHistorical(ctx, newTSafeReplica())
+	require.NoError(t, err)
+	seg, err := his.replica.getSegmentByID(defaultSegmentID)
+	require.NoError(t, err)
+	ids := make([]int64, defaultMsgLength)
+	tss := make([]Timestamp, defaultMsgLength)
+	for i := range ids {
+		ids[i] = int64(i)
+		tss[i] = 100
+	}
+	seg.setPKIndex(newInt64PkIndex(ids))
+	pks, timestamps := genDeleteRecords(ids, tss)
+	offset := seg.segmentPreDelete(len(pks))
+	require.NoError(t, seg.segmentDelete(offset, pks, timestamps))
+
+	nodeID := fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)
+
+	t.Run("search", func(t *testing.T) {
+		skipped := metrics.QueryNodeFullyDeletedSegmentsSkipped.WithLabelValues(nodeID, metrics.SearchLabel)
+		before := testutil.ToFloat64(skipped)
+		plan, searchReqs, err := genSimpleSearchPlanAndRequests(IndexFaissIDMap)
+		require.NoError(t, err)
+
+		results, segmentIDs, err := his.searchSegments(context.Background(), []UniqueID{defaultSegmentID}, searchReqs, plan, 100)
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+		assert.Empty(t, segmentIDs)
+		assert.Equal(t, before+1, testutil.ToFloat64(skipped))
+
+		// time travel before the deletes
+		results, segmentIDs, err = his.searchSegments(context.Background(), []UniqueID{defaultSegmentID}, searchReqs, plan, 50)
+		assert.NoError(t, err)
+		defer deleteSearchResults(results)
+		assert.Len(t, results, 1)
+		assert.Equal(t, []UniqueID{defaultSegmentID}, segmentIDs)
+		assert.Equal(t, before+1, testutil.ToFloat64(skipped))
+	})
+
+	t.Run("retrieve", func(t *testing.T) {
+		skipped := metrics.QueryNodeFullyDeletedSegmentsSkipped.WithLabelValues(nodeID, metrics.QueryLabel)
+		before := testutil.ToFloat64(skipped)
+		expr, err := genSimpleRetrievePlanExpr()
+		require.NoError(t, err)
+		retrieve := func(ts Timestamp) []*segcorepb.RetrieveResults {
+			plan, err := createRetrievePlanByExpr(newCollection(defaultCollectionID, genSimpleSegCoreSchema()), expr, ts)
+			require.NoError(t, err)
+			defer plan.delete()
+			results, err := his.retrieveBySegmentIDs(defaultCollectionID, []UniqueID{defaultSegmentID}, nil, plan)
+			require.NoError(t, err)
+			return results
+		}
+
+		assert.Empty(t, retrieve(100))
+		assert.Equal(t, before+1, testutil.ToFloat64(skipped))
+
+		// time travel before the deletes
+		results := retrieve(50)
+		require.Len(t, results, 1)
+		assert.NotEmpty(t, results[0].GetIds().GetIntId().GetData())
+		assert.Equal(t, before+1, testutil.ToFloat64(skipped))
+	})
 }
@@ -19,11 +19,13 @@ package querynode
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
@@ -57,6 +59,7 @@ func (node *QueryNode) GetComponentStates(ctx context.Context) (*internalpb.Comp
 		NodeID:    nodeID,
 		Role:      typeutil.QueryNodeRole,
 		StateCode: code,
+		ExtraInfo: node.readStats.extraInfo(),
 	}
 	stats.State = info
 	log.Debug("Get QueryNode component state done", zap.Any("stateCode", info.StateCode))
@@ -91,11 +94,8 @@ func (node *QueryNode) GetStatisticsChannel(ctx context.Context) (*milvuspb.Stri
 func (node *QueryNode) AddQueryChannel(ctx context.Context, in *queryPb.AddQueryChannelRequest) (*commonpb.Status, error) {
 	code := node.stateCode.Load().(internalpb.StateCode)
 	if code != internalpb.StateCode_Healthy {
-		err := fmt.Errorf("query node %d is not ready", Params.QueryNodeCfg.QueryNodeID)
-		status := &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		}
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		status := toStatus(err)
 		return status, nil
 	}
 	dct := &addQueryChannelTask{
@@ -109,10 +109,7 @@ func (node *QueryNode) AddQueryChannel(ctx context.Context, in *queryPb.AddQuery
 
 	err := node.scheduler.queue.Enqueue(dct)
 	if err != nil {
-		status := &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		}
+		status := toStatus(err)
 		log.Error(err.Error())
 		return status, nil
 	}
@@ -125,10 +122,7 @@ func (node *QueryNode) AddQueryChannel(ctx context.Context, in *queryPb.AddQuery
 	waitFunc := func() (*commonpb.Status, error) {
 		err = dct.WaitToFinish()
 		if err != nil {
-			status := &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			}
+			status := toStatus(err)
 			log.Error(err.Error())
 			return status, nil
 		}
@@ -199,14 +193,17 @@ func (node *QueryNode) RemoveQueryChannel(ctx context.Context, in *queryPb.Remov
 
 // WatchDmChannels create consumers on dmChannels to receive Incremental data，which is the important part of real-time query
 func (node *QueryNode) WatchDmChannels(ctx context.Context, in *queryPb.WatchDmChannelsRequest) (*commonpb.Status, error) {
+	resp, err := node.WatchDmChannelsWithStatus(ctx, in)
+	return resp.GetStatus(), err
+}
+
+// WatchDmChannelsWithStatus watches the dm channels as WatchDmChannels, and reports the status of each channel
+func (node *QueryNode) WatchDmChannelsWithStatus(ctx context.Context, in *queryPb.WatchDmChannelsRequest) (*queryPb.WatchDmChannelsResponse, error) {
 	code := node.stateCode.Load().(internalpb.StateCode)
 	if code != internalpb.StateCode_Healthy {
-		err := fmt.Errorf("query node %d is not ready", Params.QueryNodeCfg.QueryNodeID)
-		status := &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		}
-		return status, nil
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		status := toStatus(err)
+		return &queryPb.WatchDmChannelsResponse{Status: status}, nil
 	}
 	dct := &watchDmChannelsTask{
 		baseTask: baseTask{
@@ -219,28 +216,27 @@ func (node *QueryNode) WatchDmChannels(ctx context.Context, in *queryPb.WatchDmC
 
 	err := node.scheduler.queue.Enqueue(dct)
 	if err != nil {
-		status := &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		}
+		status := toStatus(err)
 		log.Error(err.Error())
-		return status, nil
+		return &queryPb.WatchDmChannelsResponse{Status: status}, nil
 	}
 	log.Debug("watchDmChannelsTask Enqueue done", zap.Int64("collectionID", in.CollectionID), zap.Int64("nodeID", Params.QueryNodeCfg.QueryNodeID), zap.Int64("replicaID", in.GetReplicaID()))
 
-	waitFunc := func() (*commonpb.Status, error) {
+	// the status of each channel is reported even if the request fails, the channels watched are kept unless
+	// the request is all or nothing
+	waitFunc := func() (*queryPb.WatchDmChannelsResponse, error) {
 		err = dct.WaitToFinish()
 		if err != nil {
-			status := &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			}
+			status := toStatus(err)
 			log.Error(err.Error())
-			return status, nil
+			return &queryPb.WatchDmChannelsResponse{Status: status, ChannelStatus: dct.channelStatus}, nil
 		}
 		log.Debug("watchDmChannelsTask WaitToFinish done", zap.Int64("collectionID", in.CollectionID), zap.Int64("nodeID", Params.QueryNodeCfg.QueryNodeID))
-		return &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_Success,
+		return &queryPb.WatchDmChannelsResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_Success,
+			},
+			ChannelStatus: dct.channelStatus,
 		}, nil
 	}
 
@@ -251,11 +247,8 @@ func (node *QueryNode) WatchDmChannels(ctx context.Context, in *queryPb.WatchDmC
 func (node *QueryNode) WatchDeltaChannels(ctx context.Context, in *queryPb.WatchDeltaChannelsRequest) (*commonpb.Status, error) {
 	code := node.stateCode.Load().(internalpb.StateCode)
 	if code != internalpb.StateCode_Healthy {
-		err := fmt.Errorf("query node %d is not ready", Params.QueryNodeCfg.QueryNodeID)
-		status := &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		}
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		status := toStatus(err)
 		return status, nil
 	}
 	dct := &watchDeltaChannelsTask{
@@ -269,10 +262,7 @@ func (node *QueryNode) WatchDeltaChannels(ctx context.Context, in *queryPb.Watch
 
 	err := node.scheduler.queue.Enqueue(dct)
 	if err != nil {
-		status := &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		}
+		status := toStatus(err)
 		log.Error(err.Error())
 		return status, nil
 	}
@@ -281,10 +271,7 @@ func (node *QueryNode) WatchDeltaChannels(ctx context.Context, in *queryPb.Watch
 	waitFunc := func() (*commonpb.Status, error) {
 		err = dct.WaitToFinish()
 		if err != nil {
-			status := &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			}
+			status := toStatus(err)
 			log.Error(err.Error())
 			return status, nil
 		}
@@ -301,11 +288,8 @@ func (node *QueryNode) WatchDeltaChannels(ctx context.Context, in *queryPb.Watch
 func (node *QueryNode) LoadSegments(ctx context.Context, in *queryPb.LoadSegmentsRequest) (*commonpb.Status, error) {
 	code := node.stateCode.Load().(internalpb.StateCode)
 	if code != internalpb.StateCode_Healthy {
-		err := fmt.Errorf("query node %d is not ready", Params.QueryNodeCfg.QueryNodeID)
-		status := &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		}
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		status := toStatus(err)
 		return status, nil
 	}
 	dct := &loadSegmentsTask{
@@ -319,10 +303,7 @@ func (node *QueryNode) LoadSegments(ctx context.Context, in *queryPb.LoadSegment
 
 	err := node.scheduler.queue.Enqueue(dct)
 	if err != nil {
-		status := &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		}
+		status := toStatus(err)
 		log.Error(err.Error())
 		return status, nil
 	}
@@ -335,10 +316,7 @@ func (node *QueryNode) LoadSegments(ctx context.Context, in *queryPb.LoadSegment
 	waitFunc := func() (*commonpb.Status, error) {
 		err = dct.WaitToFinish()
 		if err != nil {
-			status := &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			}
+			status := toStatus(err)
 			log.Error(err.Error())
 			return status, nil
 		}
@@ -355,11 +333,8 @@ func (node *QueryNode) LoadSegments(ctx context.Context, in *queryPb.LoadSegment
 func (node *QueryNode) ReleaseCollection(ctx context.Context, in *queryPb.ReleaseCollectionRequest) (*commonpb.Status, error) {
 	code := node.stateCode.Load().(internalpb.StateCode)
 	if code != internalpb.StateCode_Healthy {
-		err := fmt.Errorf("query node %d is not ready", Params.QueryNodeCfg.QueryNodeID)
-		status := &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		}
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		status := toStatus(err)
 		return status, nil
 	}
 	dct := &releaseCollectionTask{
@@ -373,10 +348,7 @@ func (node *QueryNode) ReleaseCollection(ctx context.Context, in *queryPb.Releas
 
 	err := node.scheduler.queue.Enqueue(dct)
 	if err != nil {
-		status := &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		}
+		status := toStatus(err)
 		log.Error(err.Error())
 		return status, nil
 	}
@@ -401,11 +373,8 @@ func (node *QueryNode) ReleaseCollection(ctx context.Context, in *queryPb.Releas
 func (node *QueryNode) ReleasePartitions(ctx context.Context, in *queryPb.ReleasePartitionsRequest) (*commonpb.Status, error) {
 	code := node.stateCode.Load().(internalpb.StateCode)
 	if code != internalpb.StateCode_Healthy {
-		err := fmt.Errorf("query node %d is not ready", Params.QueryNodeCfg.QueryNodeID)
-		status := &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		}
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		status := toStatus(err)
 		return status, nil
 	}
 	dct := &releasePartitionsTask{
@@ -419,10 +388,7 @@ func (node *QueryNode) ReleasePartitions(ctx context.Context, in *queryPb.Releas
 
 	err := node.scheduler.queue.Enqueue(dct)
 	if err != nil {
-		status := &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		}
+		status := toStatus(err)
 		log.Error(err.Error())
 		return status, nil
 	}
@@ -447,11 +413,8 @@ func (node *QueryNode) ReleasePartitions(ctx context.Context, in *queryPb.Releas
 func (node *QueryNode) ReleaseSegments(ctx context.Context, in *queryPb.ReleaseSegmentsRequest) (*commonpb.Status, error) {
 	code := node.stateCode.Load().(internalpb.StateCode)
 	if code != internalpb.StateCode_Healthy {
-		err := fmt.Errorf("query node %d is not ready", Params.QueryNodeCfg.QueryNodeID)
-		status := &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		}
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		status := toStatus(err)
 		return status, nil
 	}
 	status := &commonpb.Status{
@@ -461,14 +424,12 @@ func (node *QueryNode) ReleaseSegments(ctx context.Context, in *queryPb.ReleaseS
 		err := node.historical.replica.removeSegment(id)
 		if err != nil {
 			// not return, try to release all segments
-			status.ErrorCode = commonpb.ErrorCode_UnexpectedError
-			status.Reason = err.Error()
+			status = toStatus(err)
 		}
 		err = node.streaming.replica.removeSegment(id)
 		if err != nil {
 			// not return, try to release all segments
-			status.ErrorCode = commonpb.ErrorCode_UnexpectedError
-			status.Reason = err.Error()
+			status = toStatus(err)
 		}
 	}
 
@@ -476,53 +437,351 @@ func (node *QueryNode) ReleaseSegments(ctx context.Context, in *queryPb.ReleaseS
 	return status, nil
 }
 
+// SyncDistribution flips the serving state of the sealed segments on the queryNode at once
+func (node *QueryNode) SyncDistribution(ctx context.Context, in *queryPb.SyncDistributionRequest) (*commonpb.Status, error) {
+	code := node.stateCode.Load().(internalpb.StateCode)
+	if code != internalpb.StateCode_Healthy {
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		status := toStatus(err)
+		return status, nil
+	}
+
+	err := node.historical.replica.syncSegmentServing(in.GetActions(), in.GetVersion())
+	if err != nil {
+		log.Warn("sync distribution failed",
+			zap.Int64("collectionID", in.GetCollectionID()),
+			zap.Int64("version", in.GetVersion()),
+			zap.Error(err))
+		return toStatus(err), nil
+	}
+
+	log.Debug("sync distribution done",
+		zap.Int64("collectionID", in.GetCollectionID()),
+		zap.Int64("version", in.GetVersion()),
+		zap.Any("actions", in.GetActions()))
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}, nil
+}
+
+// PauseChannel stops the flow graphs of the channel from consuming, the loaded data is kept and the tSafe of the channel freezes
+func (node *QueryNode) PauseChannel(ctx context.Context, in *queryPb.PauseChannelRequest) (*commonpb.Status, error) {
+	code := node.stateCode.Load().(internalpb.StateCode)
+	if code != internalpb.StateCode_Healthy {
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		status := toStatus(err)
+		return status, nil
+	}
+
+	// refuse to pause if a waiting request would time out soon
+	if qs, err := node.queryShardService.getQueryShard(in.GetChannelName()); err == nil {
+		if err := qs.checkPauseDeadlineBudget(Params.QueryNodeCfg.PauseDeadlineBudget); err != nil {
+			log.Warn("refuse to pause channel",
+				zap.Int64("collectionID", in.GetCollectionID()),
+				zap.String("channel", in.GetChannelName()),
+				zap.Error(err))
+			return toStatus(err), nil
+		}
+	}
+
+	err := node.dataSyncService.pauseFlowGraphsByDMLChannel(in.GetCollectionID(), in.GetChannelName())
+	if err != nil {
+		log.Warn("pause channel failed",
+			zap.Int64("collectionID", in.GetCollectionID()),
+			zap.String("channel", in.GetChannelName()),
+			zap.Error(err))
+		return toStatus(err), nil
+	}
+
+	log.Info("pause channel done",
+		zap.Int64("collectionID", in.GetCollectionID()),
+		zap.String("channel", in.GetChannelName()))
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}, nil
+}
+
+// ResumeChannel lets the paused flow graphs of the channel continue consuming from where they were paused
+func (node *QueryNode) ResumeChannel(ctx context.Context, in *queryPb.ResumeChannelRequest) (*commonpb.Status, error) {
+	code := node.stateCode.Load().(internalpb.StateCode)
+	if code != internalpb.StateCode_Healthy {
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		status := toStatus(err)
+		return status, nil
+	}
+
+	err := node.dataSyncService.resumeFlowGraphsByDMLChannel(in.GetCollectionID(), in.GetChannelName())
+	if err != nil {
+		log.Warn("resume channel failed",
+			zap.Int64("collectionID", in.GetCollectionID()),
+			zap.String("channel", in.GetChannelName()),
+			zap.Error(err))
+		return toStatus(err), nil
+	}
+
+	log.Info("resume channel done",
+		zap.Int64("collectionID", in.GetCollectionID()),
+		zap.String("channel", in.GetChannelName()))
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}, nil
+}
+
+// ExportSegmentDeletes exports the applied deletes of a sealed segment, which could be imported by another replica
+// loading the same segment to skip replaying the delta logs covered by the snapshot
+func (node *QueryNode) ExportSegmentDeletes(ctx context.Context, in *queryPb.ExportSegmentDeletesRequest) (*queryPb.ExportSegmentDeletesResponse, error) {
+	code := node.stateCode.Load().(internalpb.StateCode)
+	if code != internalpb.StateCode_Healthy {
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		return &queryPb.ExportSegmentDeletesResponse{
+			Status: toStatus(err),
+		}, nil
+	}
+
+	failResponse := func(err error) *queryPb.ExportSegmentDeletesResponse {
+		log.Warn("export segment deletes failed",
+			zap.Int64("collectionID", in.GetCollectionID()),
+			zap.Int64("segmentID", in.GetSegmentID()),
+			zap.Error(err))
+		return &queryPb.ExportSegmentDeletesResponse{
+			Status:    toStatus(err),
+			SegmentID: in.GetSegmentID(),
+		}
+	}
+	segment, err := node.historical.replica.getSegmentByID(in.GetSegmentID())
+	if err != nil {
+		return failResponse(err), nil
+	}
+	if segment.collectionID != in.GetCollectionID() {
+		return failResponse(fmt.Errorf("segment %d doesn't belong to collection %d", in.GetSegmentID(), in.GetCollectionID())), nil
+	}
+	snapshot, err := segment.exportDeleteSnapshot()
+	if err != nil {
+		return failResponse(err), nil
+	}
+
+	log.Info("export segment deletes done",
+		zap.Int64("collectionID", in.GetCollectionID()),
+		zap.Int64("segmentID", in.GetSegmentID()),
+		zap.Int("snapshotSize", len(snapshot)))
+	return &queryPb.ExportSegmentDeletesResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		SegmentID: in.GetSegmentID(),
+		Snapshot:  snapshot,
+	}, nil
+}
+
+// UpdateLoadConfig updates the load configs of a loaded collection without reloading it, see updateLoadConfigs
+func (node *QueryNode) UpdateLoadConfig(ctx context.Context, in *queryPb.UpdateLoadConfigRequest) (*queryPb.UpdateLoadConfigResponse, error) {
+	code := node.stateCode.Load().(internalpb.StateCode)
+	if code != internalpb.StateCode_Healthy {
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		return &queryPb.UpdateLoadConfigResponse{
+			Status: toStatus(err),
+		}, nil
+	}
+
+	results, err := updateLoadConfigs(node.historical.replica, node.streaming.replica, &node.readLimiter, in.GetCollectionID(), in.GetConfigs())
+	if err != nil {
+		log.Warn("update load config failed",
+			zap.Int64("collectionID", in.GetCollectionID()),
+			zap.Any("configs", in.GetConfigs()),
+			zap.Error(err))
+		return &queryPb.UpdateLoadConfigResponse{
+			Status: toStatus(err),
+		}, nil
+	}
+
+	log.Info("update load config done",
+		zap.Int64("collectionID", in.GetCollectionID()),
+		zap.Any("configs", in.GetConfigs()),
+		zap.Any("results", results))
+	return &queryPb.UpdateLoadConfigResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		Results: results,
+	}, nil
+}
+
+// GetDataDistribution returns the dm channels watched by the queryNode, with the versions of the WatchDmChannels
+// requests they are watched with
+func (node *QueryNode) GetDataDistribution(ctx context.Context, in *queryPb.GetDataDistributionRequest) (*queryPb.GetDataDistributionResponse, error) {
+	code := node.stateCode.Load().(internalpb.StateCode)
+	if code != internalpb.StateCode_Healthy {
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		return &queryPb.GetDataDistributionResponse{
+			Status: toStatus(err),
+		}, nil
+	}
+
+	channels := node.dmChannelOwnership.getDistribution()
+	paused := make(map[Channel]struct{})
+	for _, channel := range node.dataSyncService.getPausedDMLChannels() {
+		paused[channel] = struct{}{}
+	}
+	ingestionRates := node.dataSyncService.getIngestionRates()
+	for _, channel := range channels {
+		_, channel.Paused = paused[channel.GetChannel()]
+		if rates, ok := ingestionRates[channel.GetChannel()]; ok {
+			channel.InsertRowsPerSec = rates.insert.rowsPerSec
+			channel.InsertBytesPerSec = rates.insert.bytesPerSec
+			channel.DeleteRowsPerSec = rates.delete.rowsPerSec
+			channel.DeleteBytesPerSec = rates.delete.bytesPerSec
+		}
+		// the query shard of a channel is added once the channel is watched
+		if qs, err := node.queryShardService.getQueryShard(channel.GetChannel()); err == nil {
+			channel.ServiceableTs = qs.getAppliedTs()
+		}
+	}
+
+	node.indexManifests.prune(node.historical.replica.hasSegment, indexManifestRestoreGrace)
+	return &queryPb.GetDataDistributionResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		NodeID:   Params.QueryNodeCfg.QueryNodeID,
+		Channels: channels,
+		Indexes:  node.indexManifests.getIndexes(),
+	}, nil
+}
+
+// PromoteSegments promotes the warm standby segments to serving without loading anything from storage,
+// the deletes since the checkpoints, which may be missed by the segments, are replayed before they are served
+func (node *QueryNode) PromoteSegments(ctx context.Context, in *queryPb.PromoteSegmentsRequest) (*commonpb.Status, error) {
+	code := node.stateCode.Load().(internalpb.StateCode)
+	if code != internalpb.StateCode_Healthy {
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		status := toStatus(err)
+		return status, nil
+	}
+
+	failStatus := func(err error) *commonpb.Status {
+		log.Warn("promote segments failed",
+			zap.Int64("collectionID", in.GetCollectionID()),
+			zap.Int64s("segmentIDs", in.GetSegmentIDs()),
+			zap.Int64("version", in.GetVersion()),
+			zap.Error(err))
+		return toStatus(err)
+	}
+	segmentIDs := make(map[UniqueID]struct{}, len(in.GetSegmentIDs()))
+	for _, segmentID := range in.GetSegmentIDs() {
+		segment, err := node.historical.replica.getSegmentByID(segmentID)
+		if err != nil {
+			return failStatus(err), nil
+		}
+		if segment.collectionID != in.GetCollectionID() {
+			return failStatus(fmt.Errorf("segment %d doesn't belong to collection %d", segmentID, in.GetCollectionID())), nil
+		}
+		if !segment.isStandby() {
+			return failStatus(fmt.Errorf("segment %d is not warm standby", segmentID)), nil
+		}
+		segmentIDs[segmentID] = struct{}{}
+	}
+
+	for _, checkpoint := range in.GetCheckpoints() {
+		if err := node.loader.replayDeletes(ctx, in.GetCollectionID(), checkpoint, segmentIDs); err != nil {
+			return failStatus(err), nil
+		}
+	}
+	if err := node.historical.replica.promoteSegments(in.GetSegmentIDs(), in.GetVersion()); err != nil {
+		return failStatus(err), nil
+	}
+
+	log.Info("promote segments done",
+		zap.Int64("collectionID", in.GetCollectionID()),
+		zap.Int64s("segmentIDs", in.GetSegmentIDs()),
+		zap.Int64("version", in.GetVersion()))
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}, nil
+}
+
+// RefreshIndex re-attaches the stale indexes of a sealed segment, whose index files were garbage collected upstream,
+// with the current index infos of the request, the indexes not stale are skipped
+func (node *QueryNode) RefreshIndex(ctx context.Context, in *queryPb.RefreshIndexRequest) (*commonpb.Status, error) {
+	code := node.stateCode.Load().(internalpb.StateCode)
+	if code != internalpb.StateCode_Healthy {
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
+		status := toStatus(err)
+		return status, nil
+	}
+
+	failStatus := func(err error) *commonpb.Status {
+		log.Warn("refresh index failed",
+			zap.Int64("collectionID", in.GetCollectionID()),
+			zap.Int64("segmentID", in.GetSegmentID()),
+			zap.Error(err))
+		return toStatus(err)
+	}
+	segment, err := node.historical.replica.getSegmentByID(in.GetSegmentID())
+	if err != nil {
+		return failStatus(err), nil
+	}
+	if segment.collectionID != in.GetCollectionID() {
+		return failStatus(fmt.Errorf("segment %d doesn't belong to collection %d", in.GetSegmentID(), in.GetCollectionID())), nil
+	}
+
+	refreshed := make([]int64, 0)
+	for _, indexInfo := range in.GetIndexInfos() {
+		ok, err := node.loader.refreshIndex(segment, indexInfo)
+		if err != nil {
+			return failStatus(err), nil
+		}
+		if ok {
+			refreshed = append(refreshed, indexInfo.GetFieldID())
+		}
+	}
+
+	log.Info("refresh index done",
+		zap.Int64("collectionID", in.GetCollectionID()),
+		zap.Int64("segmentID", in.GetSegmentID()),
+		zap.Int64s("refreshedFieldIDs", refreshed))
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}, nil
+}
+
 // GetSegmentInfo returns segment information of the collection on the queryNode, and the information includes memSize, numRow, indexName, indexID ...
 func (node *QueryNode) GetSegmentInfo(ctx context.Context, in *queryPb.GetSegmentInfoRequest) (*queryPb.GetSegmentInfoResponse, error) {
 	code := node.stateCode.Load().(internalpb.StateCode)
 	if code != internalpb.StateCode_Healthy {
-		err := fmt.Errorf("query node %d is not ready", Params.QueryNodeCfg.QueryNodeID)
+		err := errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)
 		res := &queryPb.GetSegmentInfoResponse{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			},
+			Status: toStatus(err),
 		}
 		return res, nil
 	}
 	var segmentInfos []*queryPb.SegmentInfo
 
-	segmentIDs := make(map[int64]struct{})
-	for _, segmentID := range in.GetSegmentIDs() {
-		segmentIDs[segmentID] = struct{}{}
+	collectionIDs := in.GetCollectionIDs()
+	if len(collectionIDs) == 0 {
+		collectionIDs = []UniqueID{in.GetCollectionID()}
 	}
 
 	// get info from historical
-	historicalSegmentInfos, err := node.historical.replica.getSegmentInfosByColID(in.CollectionID)
+	historicalSegmentInfos, err := node.historical.replica.getSegmentInfos(collectionIDs, in.GetSegmentIDs(), in.GetDetail())
 	if err != nil {
-		log.Debug("GetSegmentInfo: get historical segmentInfo failed", zap.Int64("collectionID", in.CollectionID), zap.Error(err))
+		log.Debug("GetSegmentInfo: get historical segmentInfo failed", zap.Int64s("collectionIDs", collectionIDs), zap.Error(err))
 		res := &queryPb.GetSegmentInfoResponse{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			},
+			Status: toStatus(err),
 		}
 		return res, nil
 	}
-	segmentInfos = append(segmentInfos, filterSegmentInfo(historicalSegmentInfos, segmentIDs)...)
+	segmentInfos = append(segmentInfos, historicalSegmentInfos...)
 
 	// get info from streaming
-	streamingSegmentInfos, err := node.streaming.replica.getSegmentInfosByColID(in.CollectionID)
+	streamingSegmentInfos, err := node.streaming.replica.getSegmentInfos(collectionIDs, in.GetSegmentIDs(), in.GetDetail())
 	if err != nil {
-		log.Debug("GetSegmentInfo: get streaming segmentInfo failed", zap.Int64("collectionID", in.CollectionID), zap.Error(err))
+		log.Debug("GetSegmentInfo: get streaming segmentInfo failed", zap.Int64s("collectionIDs", collectionIDs), zap.Error(err))
 		res := &queryPb.GetSegmentInfoResponse{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			},
+			Status: toStatus(err),
 		}
 		return res, nil
 	}
-	segmentInfos = append(segmentInfos, filterSegmentInfo(streamingSegmentInfos, segmentIDs)...)
+	segmentInfos = append(segmentInfos, streamingSegmentInfos...)
 
 	return &queryPb.GetSegmentInfoResponse{
 		Status: &commonpb.Status{
@@ -532,22 +791,6 @@ func (node *QueryNode) GetSegmentInfo(ctx context.Context, in *queryPb.GetSegmen
 	}, nil
 }
 
-// filterSegmentInfo returns segment info which segment id in segmentIDs map
-func filterSegmentInfo(segmentInfos []*queryPb.SegmentInfo, segmentIDs map[int64]struct{}) []*queryPb.SegmentInfo {
-	if len(segmentIDs) == 0 {
-		return segmentInfos
-	}
-	filtered := make([]*queryPb.SegmentInfo, 0, len(segmentIDs))
-	for _, info := range segmentInfos {
-		_, ok := segmentIDs[info.GetSegmentID()]
-		if !ok {
-			continue
-		}
-		filtered = append(filtered, info)
-	}
-	return filtered
-}
-
 // isHealthy checks if QueryNode is healthy
 func (node *QueryNode) isHealthy() bool {
 	code := node.stateCode.Load().(internalpb.StateCode)
@@ -556,16 +799,47 @@ func (node *QueryNode) isHealthy() bool {
 
 // Search performs replica search tasks.
 func (node *QueryNode) Search(ctx context.Context, req *queryPb.SearchRequest) (*internalpb.SearchResults, error) {
+	requestID := req.GetReq().GetRequestId()
+	tagRequestID(ctx, requestID)
+	start := time.Now()
+	results, err := node.search(ctx, req)
+	if results != nil {
+		results.RequestId = requestID
+		withRequestID(results.GetStatus(), requestID)
+	}
+	latency := time.Since(start)
+	observeReadLatency(metrics.SearchLabel, requestID, latency)
+	node.slowReads.observe("search", req.GetReq().GetCollectionID(), req.GetDmlChannel(), req.GetSegmentIDs(),
+		requestID, latency, results.GetStatus())
+	return results, err
+}
+
+func (node *QueryNode) search(ctx context.Context, req *queryPb.SearchRequest) (*internalpb.SearchResults, error) {
 	if !node.isHealthy() {
 		return &internalpb.SearchResults{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    msgQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID),
-			},
+			Status: toStatus(errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)),
 		}, nil
 	}
 
-	log.Debug("Received SearchRequest", zap.String("vchannel", req.GetDmlChannel()), zap.Int64s("segmentIDs", req.GetSegmentIDs()))
+	done := node.readStats.begin()
+	defer done()
+	node.readStats.countRequest(req.GetReq().GetCollectionID())
+
+	// the requests of the shard leader to the followers are part of an admitted request, and are never capped,
+	// otherwise the leader would wait for the permits its own request holds
+	if len(req.GetSegmentIDs()) == 0 {
+		release, err := node.readLimiter.acquire(ctx, req.GetReq().GetCollectionID())
+		if err != nil {
+			log.Warn("search rejected", zap.Int64("collectionID", req.GetReq().GetCollectionID()), zap.Error(err))
+			return &internalpb.SearchResults{
+				Status: toStatus(err),
+			}, nil
+		}
+		defer release()
+	}
+
+	log.Debug("Received SearchRequest", zap.String("vchannel", req.GetDmlChannel()), zap.Int64s("segmentIDs", req.GetSegmentIDs()),
+		zap.String("mandatoryFilter", req.GetReq().GetMandatoryFilter()))
 
 	if node.queryShardService == nil {
 		return &internalpb.SearchResults{
@@ -580,10 +854,7 @@ func (node *QueryNode) Search(ctx context.Context, req *queryPb.SearchRequest) (
 		err := node.queryShardService.addQueryShard(req.Req.CollectionID, req.GetDmlChannel(), 0) // TODO: add replicaID in request or remove it in query shard
 		if err != nil {
 			return &internalpb.SearchResults{
-				Status: &commonpb.Status{
-					ErrorCode: commonpb.ErrorCode_UnexpectedError,
-					Reason:    err.Error(),
-				},
+				Status: toStatus(err),
 			}, nil
 		}
 	}
@@ -591,10 +862,7 @@ func (node *QueryNode) Search(ctx context.Context, req *queryPb.SearchRequest) (
 	qs, err := node.queryShardService.getQueryShard(req.GetDmlChannel())
 	if err != nil {
 		return &internalpb.SearchResults{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			},
+			Status: toStatus(err),
 		}, nil
 	}
 
@@ -602,28 +870,56 @@ func (node *QueryNode) Search(ctx context.Context, req *queryPb.SearchRequest) (
 	if err != nil {
 		log.Warn("QueryService failed to search", zap.String("vchannel", req.GetDmlChannel()), zap.Int64s("segmentIDs", req.GetSegmentIDs()), zap.Error(err))
 		return &internalpb.SearchResults{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			},
+			Status: toStatus(err),
 		}, nil
 	}
 	log.Debug("Search Shard Done", zap.String("vchannel", req.GetDmlChannel()), zap.Int64s("segmentIDs", req.GetSegmentIDs()))
 
+	compressSearchResults(results)
 	return results, err
 }
 
 // Query performs replica query tasks.
 func (node *QueryNode) Query(ctx context.Context, req *queryPb.QueryRequest) (*internalpb.RetrieveResults, error) {
+	requestID := req.GetReq().GetRequestId()
+	tagRequestID(ctx, requestID)
+	start := time.Now()
+	results, err := node.query(ctx, req)
+	if results != nil {
+		results.RequestId = requestID
+		withRequestID(results.GetStatus(), requestID)
+	}
+	latency := time.Since(start)
+	observeReadLatency(metrics.QueryLabel, requestID, latency)
+	node.slowReads.observe("query", req.GetReq().GetCollectionID(), req.GetDmlChannel(), req.GetSegmentIDs(),
+		requestID, latency, results.GetStatus())
+	return results, err
+}
+
+func (node *QueryNode) query(ctx context.Context, req *queryPb.QueryRequest) (*internalpb.RetrieveResults, error) {
 	if !node.isHealthy() {
 		return &internalpb.RetrieveResults{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    msgQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID),
-			},
+			Status: toStatus(errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)),
 		}, nil
 	}
-	log.Debug("Received QueryRequest", zap.String("vchannel", req.GetDmlChannel()), zap.Int64s("segmentIDs", req.GetSegmentIDs()))
+	done := node.readStats.begin()
+	defer done()
+	node.readStats.countRequest(req.GetReq().GetCollectionID())
+
+	// see Search for the requests of the shard leader
+	if len(req.GetSegmentIDs()) == 0 {
+		release, err := node.readLimiter.acquire(ctx, req.GetReq().GetCollectionID())
+		if err != nil {
+			log.Warn("query rejected", zap.Int64("collectionID", req.GetReq().GetCollectionID()), zap.Error(err))
+			return &internalpb.RetrieveResults{
+				Status: toStatus(err),
+			}, nil
+		}
+		defer release()
+	}
+
+	log.Debug("Received QueryRequest", zap.String("vchannel", req.GetDmlChannel()), zap.Int64s("segmentIDs", req.GetSegmentIDs()),
+		zap.String("mandatoryFilter", req.GetReq().GetMandatoryFilter()))
 
 	if node.queryShardService == nil {
 		return &internalpb.RetrieveResults{
@@ -638,10 +934,7 @@ func (node *QueryNode) Query(ctx context.Context, req *queryPb.QueryRequest) (*i
 		err := node.queryShardService.addQueryShard(req.Req.CollectionID, req.GetDmlChannel(), 0) // TODO: add replicaID in request or remove it in query shard
 		if err != nil {
 			return &internalpb.RetrieveResults{
-				Status: &commonpb.Status{
-					ErrorCode: commonpb.ErrorCode_UnexpectedError,
-					Reason:    err.Error(),
-				},
+				Status: toStatus(err),
 			}, nil
 		}
 	}
@@ -649,10 +942,7 @@ func (node *QueryNode) Query(ctx context.Context, req *queryPb.QueryRequest) (*i
 	qs, err := node.queryShardService.getQueryShard(req.GetDmlChannel())
 	if err != nil {
 		return &internalpb.RetrieveResults{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			},
+			Status: toStatus(err),
 		}, nil
 	}
 
@@ -660,14 +950,12 @@ func (node *QueryNode) Query(ctx context.Context, req *queryPb.QueryRequest) (*i
 	if err != nil {
 		log.Warn("QueryService failed to query", zap.String("vchannel", req.GetDmlChannel()), zap.Int64s("segmentIDs", req.GetSegmentIDs()), zap.Error(err))
 		return &internalpb.RetrieveResults{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			},
+			Status: toStatus(err),
 		}, nil
 	}
 	log.Debug("Query Shard Done", zap.String("vchannel", req.GetDmlChannel()), zap.Int64s("segmentIDs", req.GetSegmentIDs()))
 
+	compressRetrieveResults(results)
 	return results, nil
 }
 
@@ -681,10 +969,7 @@ func (node *QueryNode) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsR
 			zap.Error(errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)))
 
 		return &milvuspb.GetMetricsResponse{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    msgQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID),
-			},
+			Status:   toStatus(errQueryNodeIsUnhealthy(Params.QueryNodeCfg.QueryNodeID)),
 			Response: "",
 		}, nil
 	}
@@ -697,10 +982,7 @@ func (node *QueryNode) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsR
 			zap.Error(err))
 
 		return &milvuspb.GetMetricsResponse{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			},
+			Status:   toStatus(err),
 			Response: "",
 		}, nil
 	}
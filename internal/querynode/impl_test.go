@@ -23,15 +23,23 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	queryPb "github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/util/etcd"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 )
@@ -48,6 +56,15 @@ func TestImpl_GetComponentStates(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, commonpb.ErrorCode_Success, rsp.Status.ErrorCode)
 
+	// read queue is reported in extra info
+	done := node.readStats.begin()
+	rsp, err = node.GetComponentStates(ctx)
+	assert.NoError(t, err)
+	queueLength, err := funcutil.GetAttrByKeyFromRepeatedKV(common.ReadQueueLengthKey, rsp.GetState().GetExtraInfo())
+	assert.NoError(t, err)
+	assert.Equal(t, "1", queueLength)
+	done()
+
 	node.UpdateStateCode(internalpb.StateCode_Abnormal)
 	rsp, err = node.GetComponentStates(ctx)
 	assert.NoError(t, err)
@@ -106,7 +123,7 @@ func TestImpl_AddQueryChannel(t *testing.T) {
 	node.UpdateStateCode(internalpb.StateCode_Abnormal)
 	status, err = node.AddQueryChannel(ctx, req)
 	assert.NoError(t, err)
-	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.ErrorCode)
+	assert.Equal(t, commonpb.ErrorCode_NotReadyServe, status.ErrorCode)
 }
 
 func TestImpl_RemoveQueryChannel(t *testing.T) {
@@ -141,12 +158,101 @@ func TestImpl_WatchDmChannels(t *testing.T) {
 
 	status, err := node.WatchDmChannels(ctx, req)
 	assert.NoError(t, err)
-	assert.Equal(t, commonpb.ErrorCode_Success, status.ErrorCode)
+	assert.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+
+	resp, err := node.WatchDmChannelsWithStatus(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, resp.GetStatus().GetErrorCode())
 
 	node.UpdateStateCode(internalpb.StateCode_Abnormal)
 	status, err = node.WatchDmChannels(ctx, req)
 	assert.NoError(t, err)
-	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.ErrorCode)
+	assert.Equal(t, commonpb.ErrorCode_NotReadyServe, status.GetErrorCode())
+
+	resp, err = node.WatchDmChannelsWithStatus(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_NotReadyServe, resp.GetStatus().GetErrorCode())
+}
+
+func TestImpl_GetDataDistribution(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+
+	req := &queryPb.GetDataDistributionRequest{
+		Base: genCommonMsgBase(commonpb.MsgType_SystemInfo),
+	}
+	rsp, err := node.GetDataDistribution(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, rsp.GetStatus().GetErrorCode())
+	assert.Empty(t, rsp.GetChannels())
+
+	watchResp, err := node.WatchDmChannels(ctx, &queryPb.WatchDmChannelsRequest{
+		Base:         genCommonMsgBase(commonpb.MsgType_WatchDmChannels),
+		CollectionID: defaultCollectionID,
+		PartitionIDs: []UniqueID{defaultPartitionID},
+		Schema:       genSimpleSegCoreSchema(),
+		Infos: []*datapb.VchannelInfo{
+			{
+				CollectionID: defaultCollectionID,
+				ChannelName:  defaultDMLChannel,
+			},
+		},
+		ReplicaID: 1,
+		Version:   10,
+	})
+	assert.NoError(t, err)
+	require.Equal(t, commonpb.ErrorCode_Success, watchResp.GetErrorCode())
+
+	rsp, err = node.GetDataDistribution(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, rsp.GetStatus().GetErrorCode())
+	require.Len(t, rsp.GetChannels(), 1)
+	assert.Equal(t, defaultDMLChannel, rsp.GetChannels()[0].GetChannel())
+	assert.Equal(t, defaultCollectionID, rsp.GetChannels()[0].GetCollectionID())
+	assert.Equal(t, int64(1), rsp.GetChannels()[0].GetReplicaID())
+	assert.Equal(t, int64(10), rsp.GetChannels()[0].GetVersion())
+
+	qs, err := node.queryShardService.getQueryShard(defaultDMLChannel)
+	require.NoError(t, err)
+	qs.setServiceableTime(2000, tsTypeDML)
+	qs.setServiceableTime(1000, tsTypeDelta)
+	rsp, err = node.GetDataDistribution(ctx, req)
+	assert.NoError(t, err)
+	require.Len(t, rsp.GetChannels(), 1)
+	assert.Equal(t, Timestamp(1000), rsp.GetChannels()[0].GetServiceableTs())
+	assert.False(t, rsp.GetChannels()[0].GetPaused())
+
+	// the paused channels are reported
+	status, err := node.PauseChannel(ctx, &queryPb.PauseChannelRequest{
+		Base:         genCommonMsgBase(commonpb.MsgType_WatchDmChannels),
+		CollectionID: defaultCollectionID,
+		ChannelName:  defaultDMLChannel,
+	})
+	assert.NoError(t, err)
+	require.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+	rsp, err = node.GetDataDistribution(ctx, req)
+	assert.NoError(t, err)
+	require.Len(t, rsp.GetChannels(), 1)
+	assert.True(t, rsp.GetChannels()[0].GetPaused())
+
+	status, err = node.ResumeChannel(ctx, &queryPb.ResumeChannelRequest{
+		Base:         genCommonMsgBase(commonpb.MsgType_WatchDmChannels),
+		CollectionID: defaultCollectionID,
+		ChannelName:  defaultDMLChannel,
+	})
+	assert.NoError(t, err)
+	require.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+	rsp, err = node.GetDataDistribution(ctx, req)
+	assert.NoError(t, err)
+	require.Len(t, rsp.GetChannels(), 1)
+	assert.False(t, rsp.GetChannels()[0].GetPaused())
+
+	node.UpdateStateCode(internalpb.StateCode_Abnormal)
+	rsp, err = node.GetDataDistribution(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_NotReadyServe, rsp.GetStatus().GetErrorCode())
 }
 
 func TestImpl_LoadSegments(t *testing.T) {
@@ -173,7 +279,7 @@ func TestImpl_LoadSegments(t *testing.T) {
 	node.UpdateStateCode(internalpb.StateCode_Abnormal)
 	status, err = node.LoadSegments(ctx, req)
 	assert.NoError(t, err)
-	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.ErrorCode)
+	assert.Equal(t, commonpb.ErrorCode_NotReadyServe, status.ErrorCode)
 }
 
 func TestImpl_ReleaseCollection(t *testing.T) {
@@ -198,7 +304,7 @@ func TestImpl_ReleaseCollection(t *testing.T) {
 	node.UpdateStateCode(internalpb.StateCode_Abnormal)
 	status, err = node.ReleaseCollection(ctx, req)
 	assert.NoError(t, err)
-	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.ErrorCode)
+	assert.Equal(t, commonpb.ErrorCode_NotReadyServe, status.ErrorCode)
 }
 
 func TestImpl_ReleasePartitions(t *testing.T) {
@@ -224,7 +330,7 @@ func TestImpl_ReleasePartitions(t *testing.T) {
 	node.UpdateStateCode(internalpb.StateCode_Abnormal)
 	status, err = node.ReleasePartitions(ctx, req)
 	assert.NoError(t, err)
-	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.ErrorCode)
+	assert.Equal(t, commonpb.ErrorCode_NotReadyServe, status.ErrorCode)
 }
 
 func TestImpl_GetSegmentInfo(t *testing.T) {
@@ -257,7 +363,7 @@ func TestImpl_GetSegmentInfo(t *testing.T) {
 		node.UpdateStateCode(internalpb.StateCode_Abnormal)
 		rsp, err = node.GetSegmentInfo(ctx, req)
 		assert.NoError(t, err)
-		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, rsp.Status.ErrorCode)
+		assert.Equal(t, commonpb.ErrorCode_NotReadyServe, rsp.Status.ErrorCode)
 	})
 
 	t.Run("test no collection in historical", func(t *testing.T) {
@@ -365,7 +471,7 @@ func TestImpl_GetSegmentInfo(t *testing.T) {
 		node.UpdateStateCode(internalpb.StateCode_Abnormal)
 		rsp, err = node.GetSegmentInfo(ctx, req)
 		assert.NoError(t, err)
-		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, rsp.Status.ErrorCode)
+		assert.Equal(t, commonpb.ErrorCode_NotReadyServe, rsp.Status.ErrorCode)
 	})
 
 	t.Run("test GetSegmentInfo without streaming partition", func(t *testing.T) {
@@ -445,6 +551,99 @@ func TestImpl_GetSegmentInfo(t *testing.T) {
 	})
 }
 
+func TestImpl_GetSegmentInfoDetail(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	const numSegments = 200
+	for i := 1; i <= numSegments; i++ {
+		segmentID := defaultSegmentID + UniqueID(i)
+		require.NoError(t, node.historical.replica.addSegment(segmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeSealed, true))
+		segment, err := node.historical.replica.getSegmentByID(segmentID)
+		require.NoError(t, err)
+		segment.setIndexedFieldInfo(simpleVecField.id, genIndexedFieldInfo(simpleVecField.id, UniqueID(i)))
+	}
+
+	getSegmentInfo := func(req *queryPb.GetSegmentInfoRequest) []*queryPb.SegmentInfo {
+		req.Base = &commonpb.MsgBase{MsgType: commonpb.MsgType_SegmentInfo, MsgID: rand.Int63()}
+		rsp, err := node.GetSegmentInfo(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, commonpb.ErrorCode_Success, rsp.GetStatus().GetErrorCode())
+		return rsp.GetInfos()
+	}
+
+	t.Run("detail level", func(t *testing.T) {
+		full := getSegmentInfo(&queryPb.GetSegmentInfoRequest{CollectionID: defaultCollectionID})
+		summary := getSegmentInfo(&queryPb.GetSegmentInfoRequest{
+			CollectionID: defaultCollectionID,
+			Detail:       queryPb.SegmentInfoDetail_SummaryDetail,
+		})
+		// the sealed segments and the growing segment of genSimpleQueryNode
+		require.Len(t, full, numSegments+2)
+		require.Len(t, summary, len(full))
+
+		fullSize, summarySize := 0, 0
+		for i, info := range full {
+			assert.Equal(t, info.GetSegmentID(), summary[i].GetSegmentID())
+			assert.Equal(t, info.GetCollectionID(), summary[i].GetCollectionID())
+			assert.Equal(t, info.GetPartitionID(), summary[i].GetPartitionID())
+			assert.Equal(t, info.GetNumRows(), summary[i].GetNumRows())
+			assert.Equal(t, info.GetMemSize(), summary[i].GetMemSize())
+			assert.Equal(t, info.GetDmChannel(), summary[i].GetDmChannel())
+			assert.Equal(t, info.GetSegmentState(), summary[i].GetSegmentState())
+			assert.Equal(t, info.GetVersion(), summary[i].GetVersion())
+			assert.Empty(t, summary[i].GetIndexInfos())
+			assert.Nil(t, summary[i].GetLoadStats())
+			assert.Nil(t, summary[i].GetBloomFilterStats())
+			fullSize += proto.Size(info)
+			summarySize += proto.Size(summary[i])
+		}
+		indexed := 0
+		for _, info := range full {
+			if len(info.GetIndexInfos()) > 0 {
+				indexed++
+			}
+		}
+		assert.Equal(t, numSegments, indexed)
+		t.Logf("segment infos of %d segments, full %d bytes, summary %d bytes", len(full), fullSize, summarySize)
+		assert.Less(t, summarySize*2, fullSize)
+	})
+
+	t.Run("segment filter", func(t *testing.T) {
+		for _, detail := range []queryPb.SegmentInfoDetail{queryPb.SegmentInfoDetail_FullDetail, queryPb.SegmentInfoDetail_SummaryDetail} {
+			infos := getSegmentInfo(&queryPb.GetSegmentInfoRequest{
+				CollectionID: defaultCollectionID,
+				SegmentIDs:   []UniqueID{defaultSegmentID + 2, -1, defaultSegmentID + 1},
+				Detail:       detail,
+			})
+			require.Len(t, infos, 2)
+			assert.Equal(t, defaultSegmentID+2, infos[0].GetSegmentID())
+			assert.Equal(t, defaultSegmentID+1, infos[1].GetSegmentID())
+			assert.Equal(t, detail == queryPb.SegmentInfoDetail_FullDetail, len(infos[0].GetIndexInfos()) > 0)
+		}
+	})
+
+	t.Run("collection filter", func(t *testing.T) {
+		infos := getSegmentInfo(&queryPb.GetSegmentInfoRequest{
+			CollectionIDs: []UniqueID{defaultCollectionID + 1},
+			Detail:        queryPb.SegmentInfoDetail_SummaryDetail,
+		})
+		assert.Empty(t, infos)
+		infos = getSegmentInfo(&queryPb.GetSegmentInfoRequest{
+			CollectionIDs: []UniqueID{defaultCollectionID + 1},
+			SegmentIDs:    []UniqueID{defaultSegmentID + 1},
+		})
+		assert.Empty(t, infos)
+		infos = getSegmentInfo(&queryPb.GetSegmentInfoRequest{
+			CollectionIDs: []UniqueID{defaultCollectionID + 1, defaultCollectionID},
+			Detail:        queryPb.SegmentInfoDetail_SummaryDetail,
+		})
+		assert.Len(t, infos, numSegments+2)
+	})
+}
+
 func TestImpl_isHealthy(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -614,3 +813,427 @@ func TestImpl_Query(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+func TestImpl_PauseResumeChannel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pauseReq := &queryPb.PauseChannelRequest{
+		Base:         genCommonMsgBase(commonpb.MsgType_WatchDmChannels),
+		CollectionID: defaultCollectionID,
+		ChannelName:  defaultDMLChannel,
+	}
+	resumeReq := &queryPb.ResumeChannelRequest{
+		Base:         genCommonMsgBase(commonpb.MsgType_WatchDmChannels),
+		CollectionID: defaultCollectionID,
+		ChannelName:  defaultDMLChannel,
+	}
+
+	t.Run("test pause and resume", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		_, err = node.dataSyncService.addFlowGraphsForDMLChannels(defaultCollectionID, []Channel{defaultDMLChannel})
+		require.NoError(t, err)
+
+		status, err := node.PauseChannel(ctx, pauseReq)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, status.ErrorCode)
+		assert.Equal(t, []Channel{defaultDMLChannel}, node.dataSyncService.getPausedDMLChannels())
+
+		status, err = node.ResumeChannel(ctx, resumeReq)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, status.ErrorCode)
+		assert.Empty(t, node.dataSyncService.getPausedDMLChannels())
+	})
+
+	t.Run("test channel not watched", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+
+		status, err := node.PauseChannel(ctx, pauseReq)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.ErrorCode)
+
+		status, err = node.ResumeChannel(ctx, resumeReq)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.ErrorCode)
+	})
+
+	t.Run("test waiting request within budget", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		_, err = node.dataSyncService.addFlowGraphsForDMLChannels(defaultCollectionID, []Channel{defaultDMLChannel})
+		require.NoError(t, err)
+		err = node.queryShardService.addQueryShard(defaultCollectionID, defaultDMLChannel, defaultReplicaID)
+		require.NoError(t, err)
+		qs, err := node.queryShardService.getQueryShard(defaultDMLChannel)
+		require.NoError(t, err)
+
+		qs.watcherCond.L.Lock()
+		qs.waitingDeadlines[1] = time.Now().Add(Params.QueryNodeCfg.PauseDeadlineBudget / 2)
+		qs.watcherCond.L.Unlock()
+		status, err := node.PauseChannel(ctx, pauseReq)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.ErrorCode)
+		assert.Empty(t, node.dataSyncService.getPausedDMLChannels())
+
+		qs.watcherCond.L.Lock()
+		qs.waitingDeadlines[1] = time.Now().Add(Params.QueryNodeCfg.PauseDeadlineBudget * 2)
+		qs.watcherCond.L.Unlock()
+		status, err = node.PauseChannel(ctx, pauseReq)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, status.ErrorCode)
+	})
+
+	t.Run("test invalid query node", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		node.UpdateStateCode(internalpb.StateCode_Abnormal)
+
+		status, err := node.PauseChannel(ctx, pauseReq)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_NotReadyServe, status.ErrorCode)
+
+		status, err = node.ResumeChannel(ctx, resumeReq)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_NotReadyServe, status.ErrorCode)
+	})
+}
+
+func TestImpl_ExportSegmentDeletes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req := &queryPb.ExportSegmentDeletesRequest{
+		Base:         genCommonMsgBase(commonpb.MsgType_LoadSegments),
+		CollectionID: defaultCollectionID,
+		SegmentID:    defaultSegmentID,
+	}
+
+	t.Run("test export and import", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		segment, err := node.historical.replica.getSegmentByID(defaultSegmentID)
+		require.NoError(t, err)
+		pkData := make([]int64, defaultMsgLength)
+		for i := range pkData {
+			pkData[i] = int64(i)
+		}
+		segment.setPKIndex(newInt64PkIndex(pkData))
+		pks, timestamps := genDeleteRecords([]int64{1, 3}, []Timestamp{50, 60})
+		require.NoError(t, segment.segmentLoadDeletedRecord(pks, timestamps, int64(len(pks))))
+
+		rsp, err := node.ExportSegmentDeletes(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, rsp.GetStatus().GetErrorCode())
+		assert.Equal(t, defaultSegmentID, rsp.GetSegmentID())
+
+		replica := genPKIndexedSealedSegment(t, defaultMsgLength)
+		defer deleteSegment(replica)
+		maxTs, err := replica.importDeleteSnapshot(rsp.GetSnapshot())
+		require.NoError(t, err)
+		assert.Equal(t, Timestamp(60), maxTs)
+		assert.ElementsMatch(t, retrieveSimpleIDs(t, segment, 100), retrieveSimpleIDs(t, replica, 100))
+	})
+
+	t.Run("test no pk index", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		rsp, err := node.ExportSegmentDeletes(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, rsp.GetStatus().GetErrorCode())
+	})
+
+	t.Run("test segment not found", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		rsp, err := node.ExportSegmentDeletes(ctx, &queryPb.ExportSegmentDeletesRequest{
+			CollectionID: defaultCollectionID,
+			SegmentID:    defaultSegmentID + 1,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_SegmentReleased, rsp.GetStatus().GetErrorCode())
+
+		rsp, err = node.ExportSegmentDeletes(ctx, &queryPb.ExportSegmentDeletesRequest{
+			CollectionID: defaultCollectionID + 1,
+			SegmentID:    defaultSegmentID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, rsp.GetStatus().GetErrorCode())
+	})
+
+	t.Run("test invalid query node", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		node.UpdateStateCode(internalpb.StateCode_Abnormal)
+		rsp, err := node.ExportSegmentDeletes(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_NotReadyServe, rsp.GetStatus().GetErrorCode())
+	})
+}
+
+func TestImpl_UpdateLoadConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req := &queryPb.UpdateLoadConfigRequest{
+		Base:         genCommonMsgBase(commonpb.MsgType_LoadCollection),
+		CollectionID: defaultCollectionID,
+		Configs: []*commonpb.KeyValuePair{
+			{Key: loadConfigTTLSeconds, Value: "3600"},
+			{Key: loadConfigPKIndexEnabled, Value: "true"},
+		},
+	}
+
+	t.Run("test update load config", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		rsp, err := node.UpdateLoadConfig(ctx, req)
+		assert.NoError(t, err)
+		require.Equal(t, commonpb.ErrorCode_Success, rsp.GetStatus().GetErrorCode())
+		assert.Equal(t, []*queryPb.LoadConfigUpdateResult{
+			{Key: loadConfigTTLSeconds, InPlace: true},
+			{Key: loadConfigPKIndexEnabled, ReloadSegmentIDs: []UniqueID{defaultSegmentID}},
+		}, rsp.GetResults())
+
+		collection, err := node.historical.replica.getCollectionByID(defaultCollectionID)
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, collection.getTTL())
+		assert.True(t, collection.isPKIndexEnabled())
+	})
+
+	t.Run("test invalid config", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		rsp, err := node.UpdateLoadConfig(ctx, &queryPb.UpdateLoadConfigRequest{
+			CollectionID: defaultCollectionID,
+			Configs: []*commonpb.KeyValuePair{
+				{Key: loadConfigTTLSeconds, Value: "3600"},
+				{Key: "mmap_enabled", Value: "true"},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, rsp.GetStatus().GetErrorCode())
+		assert.Empty(t, rsp.GetResults())
+
+		collection, err := node.historical.replica.getCollectionByID(defaultCollectionID)
+		require.NoError(t, err)
+		assert.Zero(t, collection.getTTL())
+	})
+
+	t.Run("test invalid query node", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		node.UpdateStateCode(internalpb.StateCode_Abnormal)
+		rsp, err := node.UpdateLoadConfig(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_NotReadyServe, rsp.GetStatus().GetErrorCode())
+	})
+}
+
+func TestImpl_PromoteSegments(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the delta channel holds the deletes of pks 0~9 at ts 50 since the checkpoint
+	deletedPKs := make([]int64, defaultDelLength)
+	deleteTss := make([]Timestamp, defaultDelLength)
+	for i := range deletedPKs {
+		deletedPKs[i] = int64(i)
+		deleteTss[i] = 50
+	}
+	genNode := func(t *testing.T) *QueryNode {
+		deleteMsg := genDeleteMsg(1, defaultCollectionID, schemapb.DataType_Int64).(*msgstream.DeleteMsg)
+		deleteMsg.Timestamps = deleteTss
+		msgChan := make(chan *msgstream.MsgPack, 1)
+		msgChan <- &msgstream.MsgPack{Msgs: []msgstream.TsMsg{deleteMsg}}
+
+		lastMsgID := &mockMsgID{}
+		lastMsgID.On("AtEarliestPosition").Return(false, nil)
+		lastMsgID.On("LessOrEqualThan", mock.AnythingOfType("string")).Return(true, nil)
+		msgStream := &LoadDeleteMsgStream{}
+		msgStream.On("Seek", mock.AnythingOfType("string")).Return(nil)
+		msgStream.On("GetLatestMsgID", mock.AnythingOfType("string")).Return(lastMsgID, nil)
+		msgStream.On("Chan").Return(msgChan)
+		node, err := genSimpleQueryNodeWithMQFactory(ctx, &mockMsgStreamFactory{mockMqStream: msgStream})
+		require.NoError(t, err)
+		return node
+	}
+	// the standby segment is loaded after the deletes, which are missed by it
+	genStandbySegment := func(t *testing.T, node *QueryNode) *Segment {
+		segment, err := genSimpleSealedSegment()
+		require.NoError(t, err)
+		segment.segmentID = defaultSegmentID + 1
+		segment.setOnService(false)
+		segment.setStandby(true)
+		pks := make([]primaryKey, defaultMsgLength)
+		for i := range pks {
+			pks[i] = newInt64PrimaryKey(int64(i))
+		}
+		segment.updateBloomFilter(pks)
+		require.NoError(t, node.historical.replica.setSegment(segment))
+		return segment
+	}
+	genRequest := func(segmentIDs ...UniqueID) *queryPb.PromoteSegmentsRequest {
+		return &queryPb.PromoteSegmentsRequest{
+			Base:         genCommonMsgBase(commonpb.MsgType_LoadSegments),
+			CollectionID: defaultCollectionID,
+			SegmentIDs:   segmentIDs,
+			Checkpoints:  []*internalpb.MsgPosition{{ChannelName: defaultDeltaChannel, MsgID: []byte{1}}},
+			Version:      2,
+		}
+	}
+
+	t.Run("test promote", func(t *testing.T) {
+		node := genNode(t)
+		standby := genStandbySegment(t, node)
+		primary, err := genSimpleSealedSegment()
+		require.NoError(t, err)
+		defer deleteSegment(primary)
+		pks, tss := genDeleteRecords(deletedPKs, deleteTss)
+		require.NoError(t, primary.segmentLoadDeletedRecord(pks, tss, int64(len(pks))))
+		assert.Len(t, retrieveSimpleIDs(t, standby, 100), len(retrieveSimpleIDs(t, primary, 100))+defaultDelLength)
+
+		status, err := node.PromoteSegments(ctx, genRequest(standby.segmentID))
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+		assert.True(t, standby.getOnService())
+		assert.False(t, standby.isStandby())
+		assert.ElementsMatch(t, retrieveSimpleIDs(t, primary, 100), retrieveSimpleIDs(t, standby, 100))
+
+		// promoted already
+		status, err = node.PromoteSegments(ctx, genRequest(standby.segmentID))
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
+	})
+
+	t.Run("test not standby", func(t *testing.T) {
+		node := genNode(t)
+		standby := genStandbySegment(t, node)
+		for _, c := range []struct {
+			req  *queryPb.PromoteSegmentsRequest
+			code commonpb.ErrorCode
+		}{
+			{genRequest(standby.segmentID, defaultSegmentID), commonpb.ErrorCode_UnexpectedError},
+			{genRequest(standby.segmentID, defaultSegmentID+2), commonpb.ErrorCode_SegmentReleased},
+		} {
+			status, err := node.PromoteSegments(ctx, c.req)
+			assert.NoError(t, err)
+			assert.Equal(t, c.code, status.GetErrorCode())
+			assert.False(t, standby.getOnService())
+			assert.True(t, standby.isStandby())
+		}
+
+		req := genRequest(standby.segmentID)
+		req.CollectionID = defaultCollectionID + 1
+		status, err := node.PromoteSegments(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
+	})
+
+	t.Run("test node not healthy", func(t *testing.T) {
+		node := genNode(t)
+		standby := genStandbySegment(t, node)
+		node.UpdateStateCode(internalpb.StateCode_Abnormal)
+		status, err := node.PromoteSegments(ctx, genRequest(standby.segmentID))
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_NotReadyServe, status.GetErrorCode())
+		assert.True(t, standby.isStandby())
+	})
+}
+
+func TestImpl_RefreshIndex(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	indexPaths, err := generateIndex(defaultSegmentID)
+	require.NoError(t, err)
+	indexInfo := &queryPb.FieldIndexInfo{
+		FieldID:        simpleVecField.id,
+		EnableIndex:    true,
+		IndexName:      indexName,
+		IndexID:        indexID,
+		BuildID:        buildID,
+		IndexParams:    funcutil.Map2KeyValuePair(genSimpleIndexParams()),
+		IndexFilePaths: indexPaths,
+	}
+	genRequest := func() *queryPb.RefreshIndexRequest {
+		return &queryPb.RefreshIndexRequest{
+			Base:         genCommonMsgBase(commonpb.MsgType_LoadIndex),
+			CollectionID: defaultCollectionID,
+			SegmentID:    defaultSegmentID,
+			IndexInfos:   []*queryPb.FieldIndexInfo{proto.Clone(indexInfo).(*queryPb.FieldIndexInfo)},
+		}
+	}
+	// genStaleNode returns the node whose sealed segment serves the vector field by brute force for the index is stale
+	genStaleNode := func(t *testing.T) (*QueryNode, *Segment) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		node.indexManifests = newIndexManifestStore(t.TempDir())
+		segment, err := node.historical.replica.getSegmentByID(defaultSegmentID)
+		require.NoError(t, err)
+		segment.indexManifests = node.indexManifests
+		segment.setIndexStale(simpleVecField.id, &IndexedFieldInfo{indexInfo: indexInfo, rawDataLoaded: true})
+		segment.saveIndexManifest()
+		return node, segment
+	}
+
+	t.Run("test refresh", func(t *testing.T) {
+		node, segment := genStaleNode(t)
+		distReq := &queryPb.GetDataDistributionRequest{Base: genCommonMsgBase(commonpb.MsgType_SystemInfo)}
+		rsp, err := node.GetDataDistribution(ctx, distReq)
+		assert.NoError(t, err)
+		require.Len(t, rsp.GetIndexes(), 1)
+		assert.Equal(t, defaultSegmentID, rsp.GetIndexes()[0].GetSegmentID())
+		assert.True(t, rsp.GetIndexes()[0].GetStale())
+
+		status, err := node.RefreshIndex(ctx, genRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+		assert.Nil(t, segment.getStaleIndex(simpleVecField.id))
+		assert.True(t, segment.hasLoadIndexForIndexedField(simpleVecField.id))
+		rsp, err = node.GetDataDistribution(ctx, distReq)
+		assert.NoError(t, err)
+		require.Len(t, rsp.GetIndexes(), 1)
+		assert.False(t, rsp.GetIndexes()[0].GetStale())
+
+		// the attached index is skipped
+		status, err = node.RefreshIndex(ctx, genRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+	})
+
+	t.Run("test index not found", func(t *testing.T) {
+		node, segment := genStaleNode(t)
+		req := genRequest()
+		req.IndexInfos[0].IndexFilePaths = []string{"not-exist-index-file"}
+		status, err := node.RefreshIndex(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
+		assert.NotNil(t, segment.getStaleIndex(simpleVecField.id))
+	})
+
+	t.Run("test invalid segment", func(t *testing.T) {
+		node, _ := genStaleNode(t)
+		req := genRequest()
+		req.SegmentID = defaultSegmentID + 1
+		status, err := node.RefreshIndex(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_SegmentReleased, status.GetErrorCode())
+
+		req = genRequest()
+		req.CollectionID = defaultCollectionID + 1
+		status, err = node.RefreshIndex(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
+	})
+
+	t.Run("test node not healthy", func(t *testing.T) {
+		node, segment := genStaleNode(t)
+		node.UpdateStateCode(internalpb.StateCode_Abnormal)
+		status, err := node.RefreshIndex(ctx, genRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_NotReadyServe, status.GetErrorCode())
+		assert.NotNil(t, segment.getStaleIndex(simpleVecField.id))
+	})
+}
@@ -0,0 +1,375 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	queryPb "github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+const (
+	// indexManifestSchemaVersion is the version of the layout of the manifests in the record files, the files
+	// of newer versions are skipped on load
+	indexManifestSchemaVersion = 1
+
+	indexManifestDirName = "index_manifest"
+	indexManifestExt     = ".manifest"
+	// indexManifestLegacyExt is the extension of the manifest files written as plain json before the record files
+	indexManifestLegacyExt = ".json"
+
+	// indexManifestRestoreGrace is how long the manifests loaded at startup are kept without their segments
+	// being loaded again
+	indexManifestRestoreGrace = 10 * time.Minute
+)
+
+// indexManifestField is an index served by a sealed segment, or a stale one whose index files were garbage
+// collected before it is attached
+type indexManifestField struct {
+	FieldID   int64  `json:"fieldID"`
+	IndexID   int64  `json:"indexID"`
+	BuildID   int64  `json:"buildID"`
+	Version   int64  `json:"version"`
+	FilesHash uint32 `json:"filesHash"` // crc32 of the sorted index file paths
+	Stale     bool   `json:"stale,omitempty"`
+}
+
+// indexManifest records the indexes served by a sealed segment
+type indexManifest struct {
+	CollectionID int64                `json:"collectionID"`
+	PartitionID  int64                `json:"partitionID"`
+	SegmentID    int64                `json:"segmentID"`
+	Fields       []indexManifestField `json:"fields"`
+}
+
+// legacyIndexManifestFile is the layout of the plain json manifest files, checksum is the crc32 of the encoded
+// manifest
+type legacyIndexManifestFile struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Checksum      uint32          `json:"checksum"`
+	Manifest      json.RawMessage `json:"manifest"`
+}
+
+// indexManifestStore persists the indexes served by the sealed segments to the local disk, one record file
+// of the manifest per segment, so that the indexes are reported right after QueryNode restarts from a crash, before the segments
+// are loaded again. The manifests are removed with their segments, including on graceful stop, and the ones
+// no loaded segment owns are pruned. A nil store persists nothing.
+type indexManifestStore struct {
+	dir string
+
+	mu        sync.RWMutex // guards manifests, restored and the manifest files
+	manifests map[UniqueID]*indexManifest
+	restored  map[UniqueID]struct{} // the manifests loaded at startup whose segments are not loaded again yet
+	loadedAt  time.Time
+}
+
+func newIndexManifestStore(dir string) *indexManifestStore {
+	return &indexManifestStore{
+		dir:       dir,
+		manifests: make(map[UniqueID]*indexManifest),
+		restored:  make(map[UniqueID]struct{}),
+	}
+}
+
+// load loads the persisted manifests, the corrupted ones are removed, and the ones of newer layouts are skipped.
+// The legacy json manifests are rewritten as record files
+func (s *indexManifestStore) load() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(s.dir, os.ModePerm); err != nil {
+		return err
+	}
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	legacyPaths := make([]string, 0)
+	for _, file := range files {
+		filePath := filepath.Join(s.dir, file.Name())
+		if file.IsDir() {
+			continue
+		}
+		if strings.HasSuffix(file.Name(), recordTmpExt) {
+			// left by a crash during saving
+			_ = os.Remove(filePath)
+			continue
+		}
+		if strings.HasSuffix(file.Name(), indexManifestLegacyExt) {
+			legacyPaths = append(legacyPaths, filePath)
+			continue
+		}
+		if !strings.HasSuffix(file.Name(), indexManifestExt) {
+			continue
+		}
+
+		manifest, schemaVersion, err := readIndexManifest(filePath)
+		if err != nil {
+			log.Warn("remove corrupted index manifest", zap.String("path", filePath), zap.Error(err))
+			_ = os.Remove(filePath)
+			continue
+		}
+		if schemaVersion > indexManifestSchemaVersion {
+			log.Warn("skip index manifest of newer schema version", zap.String("path", filePath),
+				zap.Int("schemaVersion", schemaVersion), zap.Int("supportedVersion", indexManifestSchemaVersion))
+			continue
+		}
+		s.manifests[manifest.SegmentID] = manifest
+		s.restored[manifest.SegmentID] = struct{}{}
+	}
+	// the legacy manifests are upgraded after the record files are loaded, which are newer if both exist
+	for _, filePath := range legacyPaths {
+		s.upgradeLegacyLocked(filePath)
+	}
+	s.loadedAt = time.Now()
+	log.Info("index manifests loaded", zap.String("dir", s.dir), zap.Int("num", len(s.manifests)))
+	return nil
+}
+
+// upgradeLegacyLocked loads the legacy manifest file and rewrites it as a record file. The legacy file is kept
+// if it fails to be rewritten, so that it's upgraded on the next load
+func (s *indexManifestStore) upgradeLegacyLocked(filePath string) {
+	manifest, schemaVersion, err := readLegacyIndexManifest(filePath)
+	if err != nil {
+		log.Warn("remove corrupted legacy index manifest", zap.String("path", filePath), zap.Error(err))
+		_ = os.Remove(filePath)
+		return
+	}
+	if schemaVersion > indexManifestSchemaVersion {
+		log.Warn("skip legacy index manifest of newer schema version", zap.String("path", filePath),
+			zap.Int("schemaVersion", schemaVersion), zap.Int("supportedVersion", indexManifestSchemaVersion))
+		return
+	}
+	if _, ok := s.manifests[manifest.SegmentID]; ok {
+		// already rewritten, the crash happened before the legacy file was removed
+		_ = os.Remove(filePath)
+		return
+	}
+	s.manifests[manifest.SegmentID] = manifest
+	s.restored[manifest.SegmentID] = struct{}{}
+	if err := writeIndexManifest(s.getPath(manifest.SegmentID), manifest); err != nil {
+		log.Warn("failed to upgrade legacy index manifest", zap.String("path", filePath), zap.Error(err))
+		return
+	}
+	_ = os.Remove(filePath)
+	log.Info("legacy index manifest upgraded", zap.String("path", filePath), zap.Int64("segmentID", manifest.SegmentID))
+}
+
+// save persists the indexes served by segment, the manifest is removed if the segment serves no index
+func (s *indexManifestStore) save(segment *Segment) error {
+	if s == nil {
+		return nil
+	}
+	manifest := &indexManifest{
+		CollectionID: segment.collectionID,
+		PartitionID:  segment.partitionID,
+		SegmentID:    segment.segmentID,
+	}
+	version := segment.getVersion()
+	infos, stale := segment.indexedFields.snapshot()
+	for fieldID, info := range infos {
+		if info.indexInfo == nil || !info.indexInfo.GetEnableIndex() {
+			continue
+		}
+		manifest.Fields = append(manifest.Fields, indexManifestField{
+			FieldID:   fieldID,
+			IndexID:   info.indexInfo.GetIndexID(),
+			BuildID:   info.indexInfo.GetBuildID(),
+			Version:   version,
+			FilesHash: hashIndexFilePaths(info.indexInfo.GetIndexFilePaths()),
+		})
+	}
+	for fieldID, info := range stale {
+		manifest.Fields = append(manifest.Fields, indexManifestField{
+			FieldID:   fieldID,
+			IndexID:   info.indexInfo.GetIndexID(),
+			BuildID:   info.indexInfo.GetBuildID(),
+			Version:   version,
+			FilesHash: hashIndexFilePaths(info.indexInfo.GetIndexFilePaths()),
+			Stale:     true,
+		})
+	}
+	if len(manifest.Fields) == 0 {
+		s.remove(segment.segmentID)
+		return nil
+	}
+	sort.Slice(manifest.Fields, func(i, j int) bool { return manifest.Fields[i].FieldID < manifest.Fields[j].FieldID })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.manifests[manifest.SegmentID] = manifest
+	delete(s.restored, manifest.SegmentID)
+	return writeIndexManifest(s.getPath(manifest.SegmentID), manifest)
+}
+
+// remove removes the manifest of segment
+func (s *indexManifestStore) remove(segmentID UniqueID) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeLocked(segmentID)
+}
+
+// prune removes the manifests of the segments not owned, the ones loaded at startup are kept within grace
+// for their segments to be loaded again
+func (s *indexManifestStore) prune(owned func(segmentID UniqueID) bool, grace time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for segmentID := range s.manifests {
+		if owned(segmentID) {
+			continue
+		}
+		if _, ok := s.restored[segmentID]; ok && time.Since(s.loadedAt) < grace {
+			continue
+		}
+		log.Info("prune index manifest of segment not loaded", zap.Int64("segmentID", segmentID))
+		s.removeLocked(segmentID)
+	}
+}
+
+func (s *indexManifestStore) removeLocked(segmentID UniqueID) {
+	delete(s.manifests, segmentID)
+	delete(s.restored, segmentID)
+	for _, filePath := range []string{s.getPath(segmentID), s.getLegacyPath(segmentID)} {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			log.Warn("failed to remove index manifest", zap.Int64("segmentID", segmentID), zap.String("path", filePath), zap.Error(err))
+		}
+	}
+}
+
+// getIndexes returns the indexes recorded by the manifests ordered by segment and field
+func (s *indexManifestStore) getIndexes() []*queryPb.SegmentFieldIndex {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	indexes := make([]*queryPb.SegmentFieldIndex, 0)
+	for _, manifest := range s.manifests {
+		for _, field := range manifest.Fields {
+			indexes = append(indexes, &queryPb.SegmentFieldIndex{
+				CollectionID: manifest.CollectionID,
+				SegmentID:    manifest.SegmentID,
+				FieldID:      field.FieldID,
+				IndexID:      field.IndexID,
+				BuildID:      field.BuildID,
+				Version:      field.Version,
+				Stale:        field.Stale,
+			})
+		}
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		if indexes[i].SegmentID != indexes[j].SegmentID {
+			return indexes[i].SegmentID < indexes[j].SegmentID
+		}
+		return indexes[i].FieldID < indexes[j].FieldID
+	})
+	return indexes
+}
+
+func (s *indexManifestStore) getPath(segmentID UniqueID) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%d%s", segmentID, indexManifestExt))
+}
+
+func (s *indexManifestStore) getLegacyPath(segmentID UniqueID) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%d%s", segmentID, indexManifestLegacyExt))
+}
+
+// writeIndexManifest writes the manifest as the only record of a record file
+func writeIndexManifest(filePath string, manifest *indexManifest) error {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	return writeRecordFile(filePath, indexManifestSchemaVersion, [][]byte{data})
+}
+
+// readIndexManifest reads the manifest file, the manifest is nil if the file is of a newer schema version
+func readIndexManifest(filePath string) (*indexManifest, int, error) {
+	version, records, err := readRecordFile(filePath)
+	schemaVersion := int(version)
+	if err != nil {
+		return nil, schemaVersion, err
+	}
+	if schemaVersion > indexManifestSchemaVersion {
+		return nil, schemaVersion, nil
+	}
+	if schemaVersion <= 0 {
+		return nil, schemaVersion, fmt.Errorf("invalid schema version %d", schemaVersion)
+	}
+	if len(records) != 1 {
+		return nil, schemaVersion, fmt.Errorf("%d records in index manifest, expected 1", len(records))
+	}
+	manifest := &indexManifest{}
+	if err := json.Unmarshal(records[0], manifest); err != nil {
+		return nil, schemaVersion, err
+	}
+	return manifest, schemaVersion, nil
+}
+
+// readLegacyIndexManifest reads the legacy json manifest file, the manifest is nil if the file is of a newer
+// schema version
+func readLegacyIndexManifest(filePath string) (*indexManifest, int, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, 0, err
+	}
+	file := &legacyIndexManifestFile{}
+	if err := json.Unmarshal(content, file); err != nil {
+		return nil, 0, err
+	}
+	if file.SchemaVersion > indexManifestSchemaVersion {
+		return nil, file.SchemaVersion, nil
+	}
+	if file.SchemaVersion <= 0 {
+		return nil, file.SchemaVersion, fmt.Errorf("invalid schema version %d", file.SchemaVersion)
+	}
+	if checksum := crc32.ChecksumIEEE(file.Manifest); checksum != file.Checksum {
+		return nil, file.SchemaVersion, fmt.Errorf("checksum mismatch, expected %d, actual %d", file.Checksum, checksum)
+	}
+	manifest := &indexManifest{}
+	if err := json.Unmarshal(file.Manifest, manifest); err != nil {
+		return nil, file.SchemaVersion, err
+	}
+	return manifest, file.SchemaVersion, nil
+}
+
+// hashIndexFilePaths returns the crc32 of the sorted index file paths
+func hashIndexFilePaths(paths []string) uint32 {
+	sorted := append([]string{}, paths...)
+	sort.Strings(sorted)
+	return crc32.ChecksumIEEE([]byte(strings.Join(sorted, "\n")))
+}
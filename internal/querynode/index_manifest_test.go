@@ -0,0 +1,323 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"encoding/json"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	queryPb "github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+func genIndexedSealedSegment(t *testing.T, store *indexManifestStore, version int64) *Segment {
+	segment, err := genSimpleSealedSegment()
+	require.NoError(t, err)
+	segment.setVersion(version)
+	segment.indexManifests = store
+	segment.setIndexedFieldInfo(simpleVecField.id, &IndexedFieldInfo{
+		indexInfo: &queryPb.FieldIndexInfo{
+			FieldID:        simpleVecField.id,
+			EnableIndex:    true,
+			IndexID:        10,
+			BuildID:        20,
+			IndexFilePaths: []string{"index/2", "index/1"},
+		},
+	})
+	// not reported without index
+	segment.setIndexedFieldInfo(simpleConstField.id, &IndexedFieldInfo{})
+	return segment
+}
+
+// writeLegacyIndexManifest writes the manifest as a plain json file as it was before the record files
+func writeLegacyIndexManifest(t *testing.T, filePath string, schemaVersion int, manifest *indexManifest) {
+	data, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	content, err := json.Marshal(&legacyIndexManifestFile{
+		SchemaVersion: schemaVersion,
+		Checksum:      crc32.ChecksumIEEE(data),
+		Manifest:      data,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filePath, content, 0644))
+}
+
+func TestIndexManifestStore(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "index_manifest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := newIndexManifestStore(dir)
+	require.NoError(t, store.load())
+	assert.Empty(t, store.getIndexes())
+
+	segment := genIndexedSealedSegment(t, store, 3)
+	defer deleteSegment(segment)
+	segment.saveIndexManifest()
+	expected := []*queryPb.SegmentFieldIndex{{
+		CollectionID: defaultCollectionID,
+		SegmentID:    defaultSegmentID,
+		FieldID:      simpleVecField.id,
+		IndexID:      10,
+		BuildID:      20,
+		Version:      3,
+	}}
+	assert.Equal(t, expected, store.getIndexes())
+
+	t.Run("restart", func(t *testing.T) {
+		restarted := newIndexManifestStore(dir)
+		require.NoError(t, restarted.load())
+		assert.Equal(t, expected, restarted.getIndexes())
+		assert.Equal(t, hashIndexFilePaths([]string{"index/1", "index/2"}), restarted.manifests[defaultSegmentID].Fields[0].FilesHash)
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		garbage := filepath.Join(dir, "100.manifest")
+		require.NoError(t, ioutil.WriteFile(garbage, []byte("{not json"), 0644))
+
+		content, err := ioutil.ReadFile(store.getPath(defaultSegmentID))
+		require.NoError(t, err)
+		content[len(content)-1]++
+		mismatched := filepath.Join(dir, "101.manifest")
+		require.NoError(t, ioutil.WriteFile(mismatched, content, 0644))
+
+		tmp := filepath.Join(dir, "102.manifest.tmp")
+		require.NoError(t, ioutil.WriteFile(tmp, content, 0644))
+
+		legacy := filepath.Join(dir, "103.json")
+		require.NoError(t, ioutil.WriteFile(legacy, []byte(`{"schemaVersion": 1}`), 0644))
+
+		invalid := filepath.Join(dir, "104.manifest")
+		require.NoError(t, writeRecordFile(invalid, indexManifestSchemaVersion, [][]byte{[]byte("{}"), []byte("{}")}))
+
+		restarted := newIndexManifestStore(dir)
+		require.NoError(t, restarted.load())
+		assert.Equal(t, expected, restarted.getIndexes())
+		for _, path := range []string{garbage, mismatched, tmp, legacy, invalid} {
+			_, err := os.Stat(path)
+			assert.True(t, os.IsNotExist(err), path)
+		}
+	})
+
+	t.Run("newer schema version", func(t *testing.T) {
+		newer := filepath.Join(dir, "105.manifest")
+		require.NoError(t, writeRecordFile(newer, indexManifestSchemaVersion+1, [][]byte{[]byte(`{"layout": "unknown"}`)}))
+		defer os.Remove(newer)
+
+		restarted := newIndexManifestStore(dir)
+		require.NoError(t, restarted.load())
+		assert.Equal(t, expected, restarted.getIndexes())
+		_, err := os.Stat(newer)
+		assert.NoError(t, err)
+	})
+
+	t.Run("legacy", func(t *testing.T) {
+		const legacySegmentID = UniqueID(106)
+		legacyManifest := &indexManifest{
+			CollectionID: defaultCollectionID,
+			PartitionID:  defaultPartitionID,
+			SegmentID:    legacySegmentID,
+			Fields:       []indexManifestField{{FieldID: simpleVecField.id, IndexID: 11, BuildID: 21, Version: 2}},
+		}
+		legacy := store.getLegacyPath(legacySegmentID)
+		writeLegacyIndexManifest(t, legacy, indexManifestSchemaVersion, legacyManifest)
+
+		// the record file is newer than the legacy one of the same segment
+		outdated := store.getLegacyPath(defaultSegmentID)
+		writeLegacyIndexManifest(t, outdated, indexManifestSchemaVersion, &indexManifest{
+			CollectionID: defaultCollectionID,
+			SegmentID:    defaultSegmentID,
+			Fields:       []indexManifestField{{FieldID: simpleVecField.id, IndexID: 1, BuildID: 1, Version: 1}},
+		})
+
+		newer := filepath.Join(dir, "107.json")
+		writeLegacyIndexManifest(t, newer, indexManifestSchemaVersion+1, legacyManifest)
+		defer os.Remove(newer)
+
+		upgraded := append([]*queryPb.SegmentFieldIndex{}, expected...)
+		upgraded = append(upgraded, &queryPb.SegmentFieldIndex{
+			CollectionID: defaultCollectionID,
+			SegmentID:    legacySegmentID,
+			FieldID:      simpleVecField.id,
+			IndexID:      11,
+			BuildID:      21,
+			Version:      2,
+		})
+		restarted := newIndexManifestStore(dir)
+		require.NoError(t, restarted.load())
+		assert.Equal(t, upgraded, restarted.getIndexes())
+		for _, path := range []string{legacy, outdated} {
+			_, err := os.Stat(path)
+			assert.True(t, os.IsNotExist(err), path)
+		}
+		_, err := os.Stat(newer)
+		assert.NoError(t, err)
+
+		// the legacy manifest is rewritten as a record file
+		manifest, schemaVersion, err := readIndexManifest(restarted.getPath(legacySegmentID))
+		require.NoError(t, err)
+		assert.Equal(t, indexManifestSchemaVersion, schemaVersion)
+		assert.Equal(t, legacyManifest, manifest)
+		restarted = newIndexManifestStore(dir)
+		require.NoError(t, restarted.load())
+		assert.Equal(t, upgraded, restarted.getIndexes())
+
+		restarted.remove(legacySegmentID)
+		_, err = os.Stat(restarted.getPath(legacySegmentID))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("no index", func(t *testing.T) {
+		other, err := genSimpleSealedSegment()
+		require.NoError(t, err)
+		defer deleteSegment(other)
+		other.segmentID = defaultSegmentID + 1
+		require.NoError(t, store.save(other))
+		_, err = os.Stat(store.getPath(other.segmentID))
+		assert.True(t, os.IsNotExist(err))
+		assert.Equal(t, expected, store.getIndexes())
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		store.remove(defaultSegmentID)
+		assert.Empty(t, store.getIndexes())
+		_, err := os.Stat(store.getPath(defaultSegmentID))
+		assert.True(t, os.IsNotExist(err))
+
+		restarted := newIndexManifestStore(dir)
+		require.NoError(t, restarted.load())
+		assert.Empty(t, restarted.getIndexes())
+	})
+
+	t.Run("deleted segment", func(t *testing.T) {
+		deleted := genIndexedSealedSegment(t, store, 4)
+		deleteSegment(deleted)
+		deleted.saveIndexManifest()
+		assert.Empty(t, store.getIndexes())
+	})
+
+	t.Run("nil store", func(t *testing.T) {
+		var nilStore *indexManifestStore
+		assert.NoError(t, nilStore.load())
+		assert.NoError(t, nilStore.save(segment))
+		nilStore.remove(defaultSegmentID)
+		nilStore.prune(func(UniqueID) bool { return false }, 0)
+		assert.Nil(t, nilStore.getIndexes())
+	})
+}
+
+func TestIndexManifestStore_removeSegment(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "index_manifest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	store := newIndexManifestStore(dir)
+
+	replica, err := genSimpleReplica()
+	require.NoError(t, err)
+	defer replica.freeAll()
+	segment := genIndexedSealedSegment(t, store, 1)
+	require.NoError(t, replica.setSegment(segment))
+	segment.saveIndexManifest()
+	assert.Len(t, store.getIndexes(), 1)
+
+	require.NoError(t, replica.removeSegment(defaultSegmentID))
+	assert.Empty(t, store.getIndexes())
+	_, err = os.Stat(store.getPath(defaultSegmentID))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestIndexManifestStore_prune(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "index_manifest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	notOwned := func(UniqueID) bool { return false }
+
+	// the segment is loaded before restart
+	segment := genIndexedSealedSegment(t, newIndexManifestStore(dir), 1)
+	defer deleteSegment(segment)
+	segment.saveIndexManifest()
+
+	store := newIndexManifestStore(dir)
+	require.NoError(t, store.load())
+
+	t.Run("restored within grace", func(t *testing.T) {
+		store.prune(notOwned, time.Hour)
+		assert.Len(t, store.getIndexes(), 1)
+	})
+
+	t.Run("owned", func(t *testing.T) {
+		store.prune(func(segmentID UniqueID) bool { return segmentID == defaultSegmentID }, 0)
+		assert.Len(t, store.getIndexes(), 1)
+	})
+
+	t.Run("saved again", func(t *testing.T) {
+		segment.indexManifests = store
+		segment.saveIndexManifest()
+		store.prune(notOwned, time.Hour)
+		assert.Empty(t, store.getIndexes())
+		_, err := os.Stat(store.getPath(defaultSegmentID))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("restored after grace", func(t *testing.T) {
+		segment.saveIndexManifest()
+		restarted := newIndexManifestStore(dir)
+		require.NoError(t, restarted.load())
+		restarted.prune(notOwned, 0)
+		assert.Empty(t, restarted.getIndexes())
+		_, err := os.Stat(restarted.getPath(defaultSegmentID))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestImpl_GetDataDistribution_restart(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "index_manifest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the segment is loaded before crash
+	segment := genIndexedSealedSegment(t, newIndexManifestStore(dir), 5)
+	defer deleteSegment(segment)
+	segment.saveIndexManifest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	node.indexManifests = newIndexManifestStore(dir)
+	require.NoError(t, node.indexManifests.load())
+
+	rsp, err := node.GetDataDistribution(ctx, &queryPb.GetDataDistributionRequest{
+		Base: genCommonMsgBase(commonpb.MsgType_SystemInfo),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, rsp.GetStatus().GetErrorCode())
+	require.Len(t, rsp.GetIndexes(), 1)
+	assert.Equal(t, defaultSegmentID, rsp.GetIndexes()[0].GetSegmentID())
+	assert.Equal(t, simpleVecField.id, rsp.GetIndexes()[0].GetFieldID())
+	assert.Equal(t, int64(20), rsp.GetIndexes()[0].GetBuildID())
+	assert.Equal(t, int64(5), rsp.GetIndexes()[0].GetVersion())
+}
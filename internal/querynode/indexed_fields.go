@@ -0,0 +1,127 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+// clone returns a deep copy of the info
+func (info *IndexedFieldInfo) clone() *IndexedFieldInfo {
+	if info == nil {
+		return nil
+	}
+	cloned := &IndexedFieldInfo{rawDataLoaded: info.rawDataLoaded}
+	if info.fieldBinlog != nil {
+		cloned.fieldBinlog = proto.Clone(info.fieldBinlog).(*datapb.FieldBinlog)
+	}
+	if info.indexInfo != nil {
+		cloned.indexInfo = proto.Clone(info.indexInfo).(*querypb.FieldIndexInfo)
+	}
+	return cloned
+}
+
+// indexedFieldInfos holds the infos of the indexed fields of a segment, and the stale indexes not attached for their
+// index files were garbage collected upstream. It's safe for concurrent use, the zero value is ready to use.
+// The infos are copied in and out, so that the loader and the readers never share them.
+type indexedFieldInfos struct {
+	mu    sync.RWMutex
+	infos map[FieldID]*IndexedFieldInfo
+	stale map[FieldID]*IndexedFieldInfo
+}
+
+func (f *indexedFieldInfos) set(fieldID FieldID, info *IndexedFieldInfo) {
+	cloned := info.clone()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.infos == nil {
+		f.infos = make(map[FieldID]*IndexedFieldInfo)
+	}
+	f.infos[fieldID] = cloned
+}
+
+func (f *indexedFieldInfos) get(fieldID FieldID) (*IndexedFieldInfo, bool) {
+	f.mu.RLock()
+	info, ok := f.infos[fieldID]
+	f.mu.RUnlock()
+	return info.clone(), ok
+}
+
+// inspect calls fn with the info of the field, nil if the field is not indexed.
+// fn is called under the read lock, it must neither keep nor mutate the info
+func (f *indexedFieldInfos) inspect(fieldID FieldID, fn func(info *IndexedFieldInfo)) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	fn(f.infos[fieldID])
+}
+
+// inspectAll calls fn with the info of every indexed field, under the same constraints as inspect
+func (f *indexedFieldInfos) inspectAll(fn func(fieldID FieldID, info *IndexedFieldInfo)) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for fieldID, info := range f.infos {
+		fn(fieldID, info)
+	}
+}
+
+// snapshot returns the copies of the infos of the indexed fields and the stale indexes, taken at the same time
+func (f *indexedFieldInfos) snapshot() (infos map[FieldID]*IndexedFieldInfo, stale map[FieldID]*IndexedFieldInfo) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	infos = make(map[FieldID]*IndexedFieldInfo, len(f.infos))
+	for fieldID, info := range f.infos {
+		infos[fieldID] = info.clone()
+	}
+	stale = make(map[FieldID]*IndexedFieldInfo, len(f.stale))
+	for fieldID, info := range f.stale {
+		stale[fieldID] = info.clone()
+	}
+	return infos, stale
+}
+
+func (f *indexedFieldInfos) setStale(fieldID FieldID, info *IndexedFieldInfo) {
+	cloned := info.clone()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.stale == nil {
+		f.stale = make(map[FieldID]*IndexedFieldInfo)
+	}
+	f.stale[fieldID] = cloned
+}
+
+func (f *indexedFieldInfos) getStale(fieldID FieldID) *IndexedFieldInfo {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.stale[fieldID].clone()
+}
+
+// attachStale replaces the stale index of the field by the attached one of info
+func (f *indexedFieldInfos) attachStale(fieldID FieldID, info *IndexedFieldInfo) {
+	cloned := info.clone()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.stale, fieldID)
+	if f.infos == nil {
+		f.infos = make(map[FieldID]*IndexedFieldInfo)
+	}
+	f.infos[fieldID] = cloned
+}
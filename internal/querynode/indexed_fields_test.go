@@ -0,0 +1,147 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+func genIndexedFieldInfo(fieldID FieldID, indexID UniqueID) *IndexedFieldInfo {
+	return &IndexedFieldInfo{
+		fieldBinlog: &datapb.FieldBinlog{
+			FieldID: fieldID,
+			Binlogs: []*datapb.Binlog{{LogPath: fmt.Sprintf("insert_log/1/2/3/%d/%d", fieldID, indexID)}},
+		},
+		indexInfo: &querypb.FieldIndexInfo{
+			FieldID:        fieldID,
+			IndexID:        indexID,
+			IndexName:      fmt.Sprintf("index-%d", indexID),
+			EnableIndex:    true,
+			IndexFilePaths: []string{fmt.Sprintf("index_files/%d", indexID)},
+		},
+	}
+}
+
+func TestIndexedFieldInfos(t *testing.T) {
+	var fields indexedFieldInfos
+	_, ok := fields.get(simpleVecField.id)
+	assert.False(t, ok)
+	assert.Nil(t, fields.getStale(simpleVecField.id))
+
+	info := genIndexedFieldInfo(simpleVecField.id, 1)
+	fields.set(simpleVecField.id, info)
+	// mutating the info set doesn't affect the stored one
+	info.indexInfo.IndexName = "mutated"
+	info.rawDataLoaded = true
+	got, ok := fields.get(simpleVecField.id)
+	require.True(t, ok)
+	assert.Equal(t, "index-1", got.indexInfo.GetIndexName())
+	assert.False(t, got.rawDataLoaded)
+
+	// neither does mutating the info got
+	got.indexInfo.IndexName = "mutated"
+	got.fieldBinlog.Binlogs[0].LogPath = "mutated"
+	again, _ := fields.get(simpleVecField.id)
+	assert.Equal(t, "index-1", again.indexInfo.GetIndexName())
+	assert.Equal(t, "insert_log/1/2/3/100/1", again.fieldBinlog.GetBinlogs()[0].GetLogPath())
+
+	fields.setStale(simpleConstField.id, genIndexedFieldInfo(simpleConstField.id, 2))
+	infos, stale := fields.snapshot()
+	assert.Len(t, infos, 1)
+	assert.Len(t, stale, 1)
+	infos[simpleVecField.id].indexInfo.IndexName = "mutated"
+	delete(infos, simpleVecField.id)
+	again, ok = fields.get(simpleVecField.id)
+	assert.True(t, ok)
+	assert.Equal(t, "index-1", again.indexInfo.GetIndexName())
+
+	fields.attachStale(simpleConstField.id, genIndexedFieldInfo(simpleConstField.id, 3))
+	assert.Nil(t, fields.getStale(simpleConstField.id))
+	attached, ok := fields.get(simpleConstField.id)
+	require.True(t, ok)
+	assert.Equal(t, int64(3), attached.indexInfo.GetIndexID())
+}
+
+// TestSegment_indexedFieldInfosRace attaches indexes while reading the indexed field infos the way the retrieves
+// and GetSegmentInfo do, the race detector reports any unsynchronized access
+func TestSegment_indexedFieldInfosRace(t *testing.T) {
+	replica, err := genSimpleReplica()
+	require.NoError(t, err)
+	require.NoError(t, replica.addSegment(defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeSealed, true))
+	segment, err := replica.getSegmentByID(defaultSegmentID)
+	require.NoError(t, err)
+	colReplica := replica.(*collectionReplica)
+
+	const rounds = 200
+	var wg sync.WaitGroup
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			info := genIndexedFieldInfo(simpleVecField.id, UniqueID(i))
+			segment.setIndexedFieldInfo(simpleVecField.id, info)
+			// the loader keeps using its own info after setting it
+			info.rawDataLoaded = i%2 == 0
+			info.indexInfo.IndexFilePaths = append(info.indexInfo.IndexFilePaths, "extra")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			segment.setIndexStale(simpleConstField.id, genIndexedFieldInfo(simpleConstField.id, UniqueID(i)))
+			segment.attachStaleIndex(simpleConstField.id, genIndexedFieldInfo(simpleConstField.id, UniqueID(i)))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			if info, err := segment.getIndexedFieldInfo(simpleVecField.id); err == nil {
+				info.indexInfo.IndexName = "mutated"
+			}
+			segment.isOffsetsOnlyField(simpleVecField.id)
+			segment.getOffsetsOnlyFieldIDs()
+			segment.hasLoadIndexForIndexedField(simpleVecField.id)
+			assert.NoError(t, segment.checkMetricType(simpleVecField.id, defaultMetricType))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			info := colReplica.getSegmentInfo(segment)
+			_, err := proto.Marshal(info)
+			assert.NoError(t, err)
+			for _, indexInfo := range info.GetIndexInfos() {
+				assert.NotEqual(t, "mutated", indexInfo.GetIndexName())
+			}
+		}
+	}()
+	wg.Wait()
+
+	info, err := segment.getIndexedFieldInfo(simpleVecField.id)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("index-%d", rounds-1), info.indexInfo.GetIndexName())
+	assert.Equal(t, []string{fmt.Sprintf("index_files/%d", rounds-1)}, info.indexInfo.GetIndexFilePaths())
+}
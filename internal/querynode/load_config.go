@@ -0,0 +1,208 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+// the load configs of a collection which could be updated without reloading it
+const (
+	// loadConfigTTLSeconds is the time to live of the rows in seconds, applied to the loaded segments in place
+	loadConfigTTLSeconds = "collection_ttl_seconds"
+	// loadConfigSegmentRowBudget is the expected row count of growing segments, applied to the growing segments
+	// created later, the loaded ones keep their pre-allocated memory
+	loadConfigSegmentRowBudget = "segment_row_budget"
+	// loadConfigPKIndexEnabled is whether to build the pk index of sealed segments, applied to the sealed segments
+	// loaded later, the loaded ones need a reload to build or drop their pk index
+	loadConfigPKIndexEnabled = "pk_index_enabled"
+	// loadConfigMaxConcurrentReads is the cap of the concurrent search and query requests of the collection on
+	// the query node, applied to the requests in place, 0 means no cap
+	loadConfigMaxConcurrentReads = "max_concurrent_reads"
+	// loadConfigMaskedFields is the comma separated names of the fields whose values never leave the query node in
+	// the results of search and query, applied to the requests in place, empty to mask no field
+	loadConfigMaskedFields = "masked_fields"
+	// loadConfigMaskPolicy is the policy of the requests outputting the masked fields, "mask" to return zero values
+	// of the masked fields, "reject" to reject the requests, applied to the requests in place
+	loadConfigMaskPolicy = "masked_fields_policy"
+	// loadConfigChunkStatsFields is the comma separated names of at most two integer fields whose ranges are tracked
+	// per chunk by the chunk search of growing segments, applied to the growing segments created later
+	loadConfigChunkStatsFields = "chunk_stats_fields"
+)
+
+// loadConfigUpdate applies a validated load config to the collections, and returns how it takes effect
+type loadConfigUpdate func() *querypb.LoadConfigUpdateResult
+
+// updateLoadConfigs applies the load configs to the collection loaded in the replicas,
+// nothing is applied if any config is unknown or invalid
+func updateLoadConfigs(historical, streaming ReplicaInterface, readLimiter *collectionReadLimiter, collectionID UniqueID,
+	configs []*commonpb.KeyValuePair) ([]*querypb.LoadConfigUpdateResult, error) {
+	var collections []*Collection
+	for _, replica := range []ReplicaInterface{historical, streaming} {
+		if collection, err := replica.getCollectionByID(collectionID); err == nil {
+			collections = append(collections, collection)
+		}
+	}
+	if len(collections) == 0 {
+		return nil, fmt.Errorf("collection %d is not loaded", collectionID)
+	}
+
+	updates := make([]loadConfigUpdate, 0, len(configs))
+	keys := make(map[string]struct{}, len(configs))
+	for _, config := range configs {
+		key, value := config.GetKey(), config.GetValue()
+		if _, ok := keys[key]; ok {
+			return nil, fmt.Errorf("duplicate load config %s", key)
+		}
+		keys[key] = struct{}{}
+
+		switch key {
+		case loadConfigTTLSeconds:
+			ttlSeconds, err := parseNonNegativeLoadConfig(key, value)
+			if err != nil {
+				return nil, err
+			}
+			updates = append(updates, func() *querypb.LoadConfigUpdateResult {
+				for _, collection := range collections {
+					collection.setTTL(ttlSeconds)
+				}
+				return &querypb.LoadConfigUpdateResult{Key: key, InPlace: true}
+			})
+		case loadConfigSegmentRowBudget:
+			budget, err := parseNonNegativeLoadConfig(key, value)
+			if err != nil {
+				return nil, err
+			}
+			updates = append(updates, func() *querypb.LoadConfigUpdateResult {
+				for _, collection := range collections {
+					collection.setSegmentRowBudget(budget)
+				}
+				return &querypb.LoadConfigUpdateResult{Key: key}
+			})
+		case loadConfigPKIndexEnabled:
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid load config %s = %s, %w", key, value, err)
+			}
+			updates = append(updates, func() *querypb.LoadConfigUpdateResult {
+				for _, collection := range collections {
+					collection.setPKIndexEnabled(enabled)
+				}
+				return &querypb.LoadConfigUpdateResult{
+					Key: key,
+					ReloadSegmentIDs: getSealedSegmentIDs(historical, collectionID, func(segment *Segment) bool {
+						return segment.hasPKIndex() != enabled
+					}),
+				}
+			})
+		case loadConfigMaxConcurrentReads:
+			maxReads, err := parseNonNegativeLoadConfig(key, value)
+			if err != nil {
+				return nil, err
+			}
+			updates = append(updates, func() *querypb.LoadConfigUpdateResult {
+				readLimiter.setCap(collectionID, maxReads)
+				return &querypb.LoadConfigUpdateResult{Key: key, InPlace: true}
+			})
+		case loadConfigMaskedFields:
+			fieldIDs, err := parseMaskedFields(collections[0], value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid load config %s = %s, %w", key, value, err)
+			}
+			updates = append(updates, func() *querypb.LoadConfigUpdateResult {
+				for _, collection := range collections {
+					collection.setMaskedFields(fieldIDs)
+				}
+				return &querypb.LoadConfigUpdateResult{Key: key, InPlace: true}
+			})
+		case loadConfigMaskPolicy:
+			reject, err := parseMaskPolicy(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid load config %s = %s, %w", key, value, err)
+			}
+			updates = append(updates, func() *querypb.LoadConfigUpdateResult {
+				for _, collection := range collections {
+					collection.setMaskReject(reject)
+				}
+				return &querypb.LoadConfigUpdateResult{Key: key, InPlace: true}
+			})
+		case loadConfigChunkStatsFields:
+			fieldIDs, err := parseChunkStatsFields(collections[0], value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid load config %s = %s, %w", key, value, err)
+			}
+			updates = append(updates, func() *querypb.LoadConfigUpdateResult {
+				for _, collection := range collections {
+					collection.setChunkStatsFields(fieldIDs)
+				}
+				return &querypb.LoadConfigUpdateResult{Key: key}
+			})
+		default:
+			return nil, fmt.Errorf("unknown load config %s", key)
+		}
+	}
+
+	results := make([]*querypb.LoadConfigUpdateResult, 0, len(updates))
+	for _, update := range updates {
+		results = append(results, update())
+	}
+	return results, nil
+}
+
+func parseNonNegativeLoadConfig(key, value string) (int64, error) {
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid load config %s = %s, %w", key, value, err)
+	}
+	if v < 0 {
+		return 0, fmt.Errorf("invalid load config %s = %s, should not be negative", key, value)
+	}
+	return v, nil
+}
+
+// getSealedSegmentIDs returns the sorted ids of the sealed segments of collection in replica matching filter
+func getSealedSegmentIDs(replica ReplicaInterface, collectionID UniqueID, filter func(segment *Segment) bool) []UniqueID {
+	partitionIDs, err := replica.getPartitionIDs(collectionID)
+	if err != nil {
+		return nil
+	}
+	var segmentIDs []UniqueID
+	for _, partitionID := range partitionIDs {
+		ids, err := replica.getSegmentIDs(partitionID)
+		if err != nil {
+			continue
+		}
+		for _, segmentID := range ids {
+			segment, err := replica.getSegmentByID(segmentID)
+			if err != nil || segment.getType() != segmentTypeSealed {
+				continue
+			}
+			if filter(segment) {
+				segmentIDs = append(segmentIDs, segmentID)
+			}
+		}
+	}
+	sort.Slice(segmentIDs, func(i, j int) bool {
+		return segmentIDs[i] < segmentIDs[j]
+	})
+	return segmentIDs
+}
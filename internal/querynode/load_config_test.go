@@ -0,0 +1,177 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+func TestUpdateLoadConfigs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	historical, streaming := node.historical.replica, node.streaming.replica
+	hCol, err := historical.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+	sCol, err := streaming.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+
+	update := func(kvs ...string) ([]*querypb.LoadConfigUpdateResult, error) {
+		configs := make([]*commonpb.KeyValuePair, 0, len(kvs)/2)
+		for i := 0; i+1 < len(kvs); i += 2 {
+			configs = append(configs, &commonpb.KeyValuePair{Key: kvs[i], Value: kvs[i+1]})
+		}
+		return updateLoadConfigs(historical, streaming, &node.readLimiter, defaultCollectionID, configs)
+	}
+
+	t.Run("in place", func(t *testing.T) {
+		results, err := update(loadConfigTTLSeconds, "3600")
+		require.NoError(t, err)
+		assert.Equal(t, []*querypb.LoadConfigUpdateResult{{Key: loadConfigTTLSeconds, InPlace: true}}, results)
+		assert.Equal(t, time.Hour, hCol.getTTL())
+		assert.Equal(t, time.Hour, sCol.getTTL())
+	})
+
+	t.Run("future segments only", func(t *testing.T) {
+		results, err := update(loadConfigSegmentRowBudget, "10000")
+		require.NoError(t, err)
+		assert.Equal(t, []*querypb.LoadConfigUpdateResult{{Key: loadConfigSegmentRowBudget}}, results)
+		assert.Equal(t, int64(10000), sCol.getSegmentRowBudget())
+	})
+
+	t.Run("reload required", func(t *testing.T) {
+		assert.False(t, hCol.isPKIndexEnabled())
+		results, err := update(loadConfigPKIndexEnabled, "true")
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.False(t, results[0].GetInPlace())
+		// the loaded sealed segment has no pk index
+		assert.Equal(t, []UniqueID{defaultSegmentID}, results[0].GetReloadSegmentIDs())
+		assert.True(t, hCol.isPKIndexEnabled())
+
+		// the sealed segments loaded later build their pk index
+		insertData, err := genInsertData(defaultMsgLength, genSimpleInsertDataSchema())
+		require.NoError(t, err)
+		segment, err := newSegment(hCol, defaultSegmentID+1, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeSealed, true)
+		require.NoError(t, err)
+		defer deleteSegment(segment)
+		err = node.loader.loadSealedSegments(segment, insertData)
+		require.NoError(t, err)
+		assert.True(t, segment.hasPKIndex())
+
+		// the loaded segment matches the config again
+		results, err = update(loadConfigPKIndexEnabled, "false")
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Empty(t, results[0].GetReloadSegmentIDs())
+		assert.False(t, hCol.isPKIndexEnabled())
+	})
+
+	t.Run("max concurrent reads", func(t *testing.T) {
+		results, err := update(loadConfigMaxConcurrentReads, "8")
+		require.NoError(t, err)
+		assert.Equal(t, []*querypb.LoadConfigUpdateResult{{Key: loadConfigMaxConcurrentReads, InPlace: true}}, results)
+		assert.Equal(t, int64(8), node.readLimiter.getCap(defaultCollectionID))
+
+		_, err = update(loadConfigMaxConcurrentReads, "-1")
+		assert.Error(t, err)
+		assert.Equal(t, int64(8), node.readLimiter.getCap(defaultCollectionID))
+	})
+
+	t.Run("masked fields", func(t *testing.T) {
+		results, err := update(loadConfigMaskedFields, defaultConstFieldName, loadConfigMaskPolicy, maskPolicyReject)
+		require.NoError(t, err)
+		assert.Equal(t, []*querypb.LoadConfigUpdateResult{
+			{Key: loadConfigMaskedFields, InPlace: true},
+			{Key: loadConfigMaskPolicy, InPlace: true},
+		}, results)
+		for _, collection := range []*Collection{hCol, sCol} {
+			mask := collection.getFieldMask()
+			assert.True(t, mask.isMasked(simpleConstField.id))
+			assert.True(t, mask.reject)
+		}
+
+		_, err = update(loadConfigMaskedFields, defaultPKFieldName)
+		assert.Error(t, err)
+		_, err = update(loadConfigMaskPolicy, "drop")
+		assert.Error(t, err)
+		assert.True(t, sCol.getFieldMask().isMasked(simpleConstField.id))
+
+		_, err = update(loadConfigMaskedFields, "", loadConfigMaskPolicy, maskPolicyMask)
+		require.NoError(t, err)
+		assert.False(t, sCol.getFieldMask().isMasked(simpleConstField.id))
+		assert.False(t, sCol.getFieldMask().reject)
+	})
+
+	t.Run("chunk stats fields", func(t *testing.T) {
+		results, err := update(loadConfigChunkStatsFields, defaultConstFieldName)
+		require.NoError(t, err)
+		assert.Equal(t, []*querypb.LoadConfigUpdateResult{{Key: loadConfigChunkStatsFields}}, results)
+		for _, collection := range []*Collection{hCol, sCol} {
+			assert.Equal(t, []FieldID{simpleConstField.id}, collection.getChunkStatsFields())
+		}
+
+		_, err = update(loadConfigChunkStatsFields, defaultVecFieldName)
+		assert.Error(t, err)
+		assert.Equal(t, []FieldID{simpleConstField.id}, sCol.getChunkStatsFields())
+
+		_, err = update(loadConfigChunkStatsFields, "")
+		require.NoError(t, err)
+		assert.Empty(t, sCol.getChunkStatsFields())
+	})
+
+	t.Run("multiple configs", func(t *testing.T) {
+		results, err := update(loadConfigPKIndexEnabled, "true", loadConfigTTLSeconds, "60")
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, loadConfigPKIndexEnabled, results[0].GetKey())
+		assert.Equal(t, []UniqueID{defaultSegmentID}, results[0].GetReloadSegmentIDs())
+		assert.Equal(t, &querypb.LoadConfigUpdateResult{Key: loadConfigTTLSeconds, InPlace: true}, results[1])
+		assert.Equal(t, time.Minute, hCol.getTTL())
+	})
+
+	t.Run("invalid configs", func(t *testing.T) {
+		invalids := [][]string{
+			{"mmap_enabled", "true"},
+			{loadConfigTTLSeconds, "abc"},
+			{loadConfigTTLSeconds, "-1"},
+			{loadConfigTTLSeconds, "1.5"},
+			{loadConfigPKIndexEnabled, "yes"},
+			{loadConfigTTLSeconds, "1", loadConfigTTLSeconds, "2"},
+		}
+		for _, kvs := range invalids {
+			// nothing is applied if any config is invalid
+			_, err := update(append([]string{loadConfigSegmentRowBudget, "1"}, kvs...)...)
+			assert.Error(t, err, kvs)
+			assert.Equal(t, int64(10000), sCol.getSegmentRowBudget(), kvs)
+			assert.Equal(t, time.Minute, hCol.getTTL(), kvs)
+		}
+
+		_, err := updateLoadConfigs(historical, streaming, &node.readLimiter, defaultCollectionID+1, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,101 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// applyMandatoryFilter ANDs the mandatory filter attached by proxy into the predicates of the serialized plan,
+// so that no row outside the filter is returned whatever the user expression is. The plan is returned as is
+// if there is no mandatory filter, and the filter is rejected if it references unknown or vector fields
+func applyMandatoryFilter(collection *Collection, serializedPlan []byte, serializedFilter []byte) ([]byte, error) {
+	if len(serializedFilter) == 0 {
+		return serializedPlan, nil
+	}
+	filter := &planpb.Expr{}
+	if err := proto.Unmarshal(serializedFilter, filter); err != nil {
+		return nil, fmt.Errorf("invalid mandatory filter, %w", err)
+	}
+	if filter.GetExpr() == nil {
+		return nil, errors.New("invalid mandatory filter, empty expression")
+	}
+	for _, fieldID := range getExprFieldIDs(filter) {
+		field, err := collection.getFieldByID(fieldID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mandatory filter, %w", err)
+		}
+		if typeutil.IsVectorType(field.schema.GetDataType()) {
+			return nil, fmt.Errorf("invalid mandatory filter, vector field %d of collection %d could not be filtered", fieldID, collection.ID())
+		}
+	}
+
+	plan := &planpb.PlanNode{}
+	if err := proto.Unmarshal(serializedPlan, plan); err != nil {
+		return nil, err
+	}
+	switch node := plan.GetNode().(type) {
+	case *planpb.PlanNode_VectorAnns:
+		node.VectorAnns.Predicates = andExpr(node.VectorAnns.GetPredicates(), filter)
+	case *planpb.PlanNode_Predicates:
+		node.Predicates = andExpr(node.Predicates, filter)
+	default:
+		return nil, fmt.Errorf("unsupported plan node %T to apply mandatory filter", node)
+	}
+	return proto.Marshal(plan)
+}
+
+// andExpr returns the logical and of expr and filter, filter only if expr is nil
+func andExpr(expr *planpb.Expr, filter *planpb.Expr) *planpb.Expr {
+	if expr == nil {
+		return filter
+	}
+	return &planpb.Expr{
+		Expr: &planpb.Expr_BinaryExpr{
+			BinaryExpr: &planpb.BinaryExpr{
+				Op:    planpb.BinaryExpr_LogicalAnd,
+				Left:  expr,
+				Right: filter,
+			},
+		},
+	}
+}
+
+// getExprFieldIDs returns the ids of the fields referenced by expr
+func getExprFieldIDs(expr *planpb.Expr) []FieldID {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_TermExpr:
+		return []FieldID{e.TermExpr.GetColumnInfo().GetFieldId()}
+	case *planpb.Expr_UnaryRangeExpr:
+		return []FieldID{e.UnaryRangeExpr.GetColumnInfo().GetFieldId()}
+	case *planpb.Expr_BinaryRangeExpr:
+		return []FieldID{e.BinaryRangeExpr.GetColumnInfo().GetFieldId()}
+	case *planpb.Expr_CompareExpr:
+		return []FieldID{e.CompareExpr.GetLeftColumnInfo().GetFieldId(), e.CompareExpr.GetRightColumnInfo().GetFieldId()}
+	case *planpb.Expr_UnaryExpr:
+		return getExprFieldIDs(e.UnaryExpr.GetChild())
+	case *planpb.Expr_BinaryExpr:
+		return append(getExprFieldIDs(e.BinaryExpr.GetLeft()), getExprFieldIDs(e.BinaryExpr.GetRight())...)
+	}
+	return nil
+}
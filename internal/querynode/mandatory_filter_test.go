@@ -0,0 +1,308 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// genTenantFilter generates the expression of `int32 const field == tenant`
+func genTenantFilter(tenant int64) *planpb.Expr {
+	return &planpb.Expr{
+		Expr: &planpb.Expr_UnaryRangeExpr{
+			UnaryRangeExpr: &planpb.UnaryRangeExpr{
+				ColumnInfo: &planpb.ColumnInfo{
+					FieldId:  simpleConstField.id,
+					DataType: simpleConstField.dataType,
+				},
+				Op:    planpb.OpType_Equal,
+				Value: &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: tenant}},
+			},
+		},
+	}
+}
+
+// genPKTermExpr generates the expression of `pk in [pks...]`
+func genPKTermExpr(pks ...int64) *planpb.Expr {
+	values := make([]*planpb.GenericValue, 0, len(pks))
+	for _, pk := range pks {
+		values = append(values, &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: pk}})
+	}
+	return &planpb.Expr{
+		Expr: &planpb.Expr_TermExpr{
+			TermExpr: &planpb.TermExpr{
+				ColumnInfo: &planpb.ColumnInfo{
+					FieldId:      simplePKField.id,
+					DataType:     simplePKField.dataType,
+					IsPrimaryKey: true,
+				},
+				Values: values,
+			},
+		},
+	}
+}
+
+func genExprSearchPlan(predicates *planpb.Expr) ([]byte, error) {
+	return proto.Marshal(&planpb.PlanNode{
+		Node: &planpb.PlanNode_VectorAnns{
+			VectorAnns: &planpb.VectorANNS{
+				FieldId:    simpleVecField.id,
+				Predicates: predicates,
+				QueryInfo: &planpb.QueryInfo{
+					Topk:         defaultTopK,
+					MetricType:   defaultMetricType,
+					SearchParams: fmt.Sprintf(`{"nprobe": %d}`, defaultNProb),
+					RoundDecimal: -1,
+				},
+				PlaceholderTag: "$0",
+			},
+		},
+	})
+}
+
+func TestMandatoryFilter_applyMandatoryFilter(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	defer deleteCollection(collection)
+
+	filter, err := proto.Marshal(genTenantFilter(2))
+	require.NoError(t, err)
+
+	t.Run("no filter", func(t *testing.T) {
+		plan, err := genExprSearchPlan(genPKTermExpr(1))
+		require.NoError(t, err)
+		filtered, err := applyMandatoryFilter(collection, plan, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, plan, filtered)
+	})
+
+	t.Run("search plan", func(t *testing.T) {
+		plan, err := genExprSearchPlan(genPKTermExpr(1))
+		require.NoError(t, err)
+		filtered, err := applyMandatoryFilter(collection, plan, filter)
+		require.NoError(t, err)
+		planNode := &planpb.PlanNode{}
+		require.NoError(t, proto.Unmarshal(filtered, planNode))
+		predicates := planNode.GetVectorAnns().GetPredicates().GetBinaryExpr()
+		require.NotNil(t, predicates)
+		assert.Equal(t, planpb.BinaryExpr_LogicalAnd, predicates.GetOp())
+		assert.True(t, proto.Equal(genPKTermExpr(1), predicates.GetLeft()))
+		assert.True(t, proto.Equal(genTenantFilter(2), predicates.GetRight()))
+
+		// search without expression
+		plan, err = genExprSearchPlan(nil)
+		require.NoError(t, err)
+		filtered, err = applyMandatoryFilter(collection, plan, filter)
+		require.NoError(t, err)
+		require.NoError(t, proto.Unmarshal(filtered, planNode))
+		assert.True(t, proto.Equal(genTenantFilter(2), planNode.GetVectorAnns().GetPredicates()))
+	})
+
+	t.Run("retrieve plan", func(t *testing.T) {
+		plan, err := genSimpleRetrievePlanExpr()
+		require.NoError(t, err)
+		filtered, err := applyMandatoryFilter(collection, plan, filter)
+		require.NoError(t, err)
+		planNode := &planpb.PlanNode{}
+		require.NoError(t, proto.Unmarshal(filtered, planNode))
+		predicates := planNode.GetPredicates().GetBinaryExpr()
+		require.NotNil(t, predicates)
+		assert.Equal(t, planpb.BinaryExpr_LogicalAnd, predicates.GetOp())
+		assert.True(t, proto.Equal(genTenantFilter(2), predicates.GetRight()))
+		assert.Equal(t, []int64{simplePKField.id}, planNode.GetOutputFieldIds())
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		plan, err := genSimpleRetrievePlanExpr()
+		require.NoError(t, err)
+
+		_, err = applyMandatoryFilter(collection, plan, []byte("corrupted"))
+		assert.Error(t, err)
+
+		empty, err := proto.Marshal(&planpb.Expr{})
+		require.NoError(t, err)
+		_, err = applyMandatoryFilter(collection, plan, empty)
+		assert.Error(t, err)
+
+		unknownField := genTenantFilter(2)
+		unknownField.GetUnaryRangeExpr().ColumnInfo.FieldId = 999
+		unknown, err := proto.Marshal(&planpb.Expr{
+			Expr: &planpb.Expr_UnaryExpr{UnaryExpr: &planpb.UnaryExpr{Op: planpb.UnaryExpr_Not, Child: unknownField}},
+		})
+		require.NoError(t, err)
+		_, err = applyMandatoryFilter(collection, plan, unknown)
+		assert.Error(t, err)
+
+		vectorField := genTenantFilter(2)
+		vectorField.GetUnaryRangeExpr().ColumnInfo.FieldId = simpleVecField.id
+		vector, err := proto.Marshal(vectorField)
+		require.NoError(t, err)
+		_, err = applyMandatoryFilter(collection, plan, vector)
+		assert.Error(t, err)
+
+		_, err = applyMandatoryFilter(collection, []byte("corrupted"), filter)
+		assert.Error(t, err)
+		_, err = applyMandatoryFilter(collection, nil, filter)
+		assert.Error(t, err)
+	})
+}
+
+func TestMandatoryFilter_getExprFieldIDs(t *testing.T) {
+	expr := &planpb.Expr{
+		Expr: &planpb.Expr_BinaryExpr{
+			BinaryExpr: &planpb.BinaryExpr{
+				Op:   planpb.BinaryExpr_LogicalOr,
+				Left: genPKTermExpr(1),
+				Right: &planpb.Expr{
+					Expr: &planpb.Expr_CompareExpr{
+						CompareExpr: &planpb.CompareExpr{
+							LeftColumnInfo:  &planpb.ColumnInfo{FieldId: 101},
+							RightColumnInfo: &planpb.ColumnInfo{FieldId: 103},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.ElementsMatch(t, []FieldID{simplePKField.id, 101, 103}, getExprFieldIDs(expr))
+
+	expr = &planpb.Expr{
+		Expr: &planpb.Expr_BinaryRangeExpr{BinaryRangeExpr: &planpb.BinaryRangeExpr{ColumnInfo: &planpb.ColumnInfo{FieldId: 104}}},
+	}
+	assert.Equal(t, []FieldID{104}, getExprFieldIDs(expr))
+	assert.Empty(t, getExprFieldIDs(&planpb.Expr{}))
+}
+
+func TestMandatoryFilter_queryShard(t *testing.T) {
+	qs, err := genSimpleQueryShard(context.Background())
+	require.NoError(t, err)
+
+	filter, err := proto.Marshal(genTenantFilter(2))
+	require.NoError(t, err)
+
+	// user expressions trying to read rows of other tenants
+	userExprs := map[string]*planpb.Expr{
+		"pk in [1, 2, 3]": genPKTermExpr(1, 2, 3),
+		"pk in [1, 3]":    genPKTermExpr(1, 3),
+		"pk in [1, 2, 3] or not pk in [1, 2, 3]": {
+			Expr: &planpb.Expr_BinaryExpr{
+				BinaryExpr: &planpb.BinaryExpr{
+					Op:   planpb.BinaryExpr_LogicalOr,
+					Left: genPKTermExpr(1, 2, 3),
+					Right: &planpb.Expr{
+						Expr: &planpb.Expr_UnaryExpr{UnaryExpr: &planpb.UnaryExpr{Op: planpb.UnaryExpr_Not, Child: genPKTermExpr(1, 2, 3)}},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("query", func(t *testing.T) {
+		for name, userExpr := range userExprs {
+			plan, err := proto.Marshal(&planpb.PlanNode{
+				Node:           &planpb.PlanNode_Predicates{Predicates: userExpr},
+				OutputFieldIds: []int64{simplePKField.id, simpleConstField.id},
+			})
+			require.NoError(t, err)
+			req, err := genSimpleRetrieveRequest()
+			require.NoError(t, err)
+			req.SerializedExprPlan = plan
+			req.MandatoryFilter = fmt.Sprintf("%s == 2", defaultConstFieldName)
+			req.MandatoryFilterPlan = filter
+
+			resp, err := qs.query(context.Background(), &querypb.QueryRequest{
+				Req:        req,
+				SegmentIDs: []int64{defaultSegmentID},
+			})
+			require.NoError(t, err, name)
+			for _, pk := range resp.GetIds().GetIntId().GetData() {
+				assert.Equal(t, int64(2), pk, name)
+			}
+			for _, fieldData := range resp.GetFieldsData() {
+				if fieldData.GetFieldId() == simpleConstField.id {
+					for _, tenant := range fieldData.GetScalars().GetIntData().GetData() {
+						assert.Equal(t, int32(2), tenant, name)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("search", func(t *testing.T) {
+		for name, userExpr := range userExprs {
+			plan, err := genExprSearchPlan(userExpr)
+			require.NoError(t, err)
+			req, err := genSimpleSearchRequest(IndexFaissIDMap)
+			require.NoError(t, err)
+			req.DslType = commonpb.DslType_BoolExprV1
+			req.SerializedExprPlan = plan
+			req.MandatoryFilterPlan = filter
+
+			results, err := qs.search(context.Background(), &querypb.SearchRequest{
+				Req:        req,
+				SegmentIDs: []int64{defaultSegmentID},
+			})
+			require.NoError(t, err, name)
+			data := &schemapb.SearchResultData{}
+			require.NoError(t, proto.Unmarshal(results.GetSlicedBlob(), data))
+			for _, id := range data.GetIds().GetIntId().GetData() {
+				// invalid hits are filled with -1
+				assert.Contains(t, []int64{2, -1}, id, name)
+			}
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		unknownField := genTenantFilter(2)
+		unknownField.GetUnaryRangeExpr().ColumnInfo.FieldId = 999
+		unknown, err := proto.Marshal(unknownField)
+		require.NoError(t, err)
+
+		retrieveReq, err := genSimpleRetrieveRequest()
+		require.NoError(t, err)
+		retrieveReq.MandatoryFilterPlan = unknown
+		_, err = qs.query(context.Background(), &querypb.QueryRequest{Req: retrieveReq, SegmentIDs: []int64{defaultSegmentID}})
+		assert.Error(t, err)
+
+		searchReq, err := genSimpleSearchRequest(IndexFaissIDMap)
+		require.NoError(t, err)
+		searchReq.DslType = commonpb.DslType_BoolExprV1
+		searchReq.SerializedExprPlan, err = genExprSearchPlan(nil)
+		require.NoError(t, err)
+		searchReq.MandatoryFilterPlan = unknown
+		_, err = qs.search(context.Background(), &querypb.SearchRequest{Req: searchReq, SegmentIDs: []int64{defaultSegmentID}})
+		assert.Error(t, err)
+	})
+
+	t.Run("dsl search", func(t *testing.T) {
+		req, err := genSimpleSearchRequest(IndexFaissIDMap)
+		require.NoError(t, err)
+		req.MandatoryFilterPlan = filter
+		_, err = qs.search(context.Background(), &querypb.SearchRequest{Req: req, SegmentIDs: []int64{defaultSegmentID}})
+		assert.Error(t, err)
+	})
+}
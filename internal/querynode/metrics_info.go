@@ -18,7 +18,11 @@ package querynode
 
 import (
 	"context"
+	"sort"
 
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
@@ -56,17 +60,40 @@ func getSystemInfoMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest,
 			RetrieveResultReceiveBufSize: Params.QueryNodeCfg.RetrieveResultReceiveBufSize,
 
 			SimdType: Params.CommonCfg.SimdType,
+
+			ExpectedSegcoreABIVersion: expectedSegcoreABIVersion,
 		},
 	}
+	if node.segcoreVersion != nil {
+		nodeInfos.SystemConfigurations.SegcoreVersion = node.segcoreVersion.version
+		nodeInfos.SystemConfigurations.SegcoreABIVersion = node.segcoreVersion.abiVersion
+		nodeInfos.SystemConfigurations.SimdCapabilities = node.segcoreVersion.simdCapabilities
+	}
+	if node.dataSyncService != nil {
+		nodeInfos.PausedChannels = node.dataSyncService.getPausedDMLChannels()
+		for channel, progress := range node.dataSyncService.getCatchUpProgress() {
+			nodeInfos.CatchUpProgress = append(nodeInfos.CatchUpProgress, metricsinfo.ChannelCatchUpProgress{
+				Channel:      channel,
+				CatchingUp:   progress.catchingUp,
+				StartLagMs:   progress.startLag.Milliseconds(),
+				LagMs:        progress.lag.Milliseconds(),
+				ReplayedMsgs: progress.replayedMsgs,
+				ReplayedRows: progress.replayedRows,
+				Batches:      progress.batches,
+			})
+		}
+	}
+	if node.historical != nil && node.streaming != nil {
+		nodeInfos.BloomFilterStats = getBloomFilterStatsMetrics(node.historical.replica, node.streaming.replica)
+		nodeInfos.LoadStats = getLoadStatsMetrics(node.historical.replica, node.streaming.replica)
+		nodeInfos.SegmentSearchStats = getSegmentSearchStatsMetrics(node.historical.replica, node.streaming.replica)
+	}
 	metricsinfo.FillDeployMetricsWithEnv(&nodeInfos.SystemInfo)
 
 	resp, err := metricsinfo.MarshalComponentInfos(nodeInfos)
 	if err != nil {
 		return &milvuspb.GetMetricsResponse{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			},
+			Status:        toStatus(err),
 			Response:      "",
 			ComponentName: metricsinfo.ConstructComponentName(typeutil.QueryNodeRole, Params.QueryNodeCfg.QueryNodeID),
 		}, nil
@@ -81,3 +108,122 @@ func getSystemInfoMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest,
 		ComponentName: metricsinfo.ConstructComponentName(typeutil.QueryNodeRole, Params.QueryNodeCfg.QueryNodeID),
 	}, nil
 }
+
+// getBloomFilterStatsMetrics collects the bloom filter stats of all the segments in replicas
+func getBloomFilterStatsMetrics(replicas ...ReplicaInterface) []metricsinfo.SegmentBloomFilterStats {
+	ret := make([]metricsinfo.SegmentBloomFilterStats, 0)
+	for _, replica := range replicas {
+		for _, collectionID := range replica.getCollectionIDs() {
+			segmentInfos, err := replica.getSegmentInfosByColID(collectionID)
+			if err != nil {
+				log.Warn("failed to get segment infos for bloom filter stats", zap.Int64("collectionID", collectionID), zap.Error(err))
+				continue
+			}
+			for _, info := range segmentInfos {
+				stats := info.GetBloomFilterStats()
+				if stats == nil {
+					continue
+				}
+				ret = append(ret, metricsinfo.SegmentBloomFilterStats{
+					SegmentID:          info.GetSegmentID(),
+					CollectionID:       info.GetCollectionID(),
+					BitSize:            stats.GetBitSize(),
+					HashFunctions:      stats.GetHashFunctions(),
+					FillRatio:          stats.GetFillRatio(),
+					ApproxElementCount: stats.GetApproxElementCount(),
+					EstimatedFPR:       stats.GetEstimatedFpr(),
+					Lookups:            stats.GetLookups(),
+					Pruned:             stats.GetPruned(),
+				})
+			}
+		}
+	}
+	return ret
+}
+
+// getLoadStatsMetrics aggregates the load stats of the segments in replicas by collection,
+// the segments not loaded from storage, such as the growing segments consuming DML channels, are skipped
+func getLoadStatsMetrics(replicas ...ReplicaInterface) []metricsinfo.CollectionLoadStats {
+	collections := make(map[UniqueID]*metricsinfo.CollectionLoadStats)
+	for _, replica := range replicas {
+		for _, collectionID := range replica.getCollectionIDs() {
+			segmentInfos, err := replica.getSegmentInfosByColID(collectionID)
+			if err != nil {
+				log.Warn("failed to get segment infos for load stats", zap.Int64("collectionID", collectionID), zap.Error(err))
+				continue
+			}
+			for _, info := range segmentInfos {
+				stats := info.GetLoadStats()
+				if stats.GetBinlogSize() == 0 && stats.GetIndexSize() == 0 {
+					continue
+				}
+				collection, ok := collections[collectionID]
+				if !ok {
+					collection = &metricsinfo.CollectionLoadStats{CollectionID: collectionID}
+					collections[collectionID] = collection
+				}
+				collection.Segments++
+				collection.BinlogSize += stats.GetBinlogSize()
+				collection.IndexSize += stats.GetIndexSize()
+				collection.DownloadMs += stats.GetDownloadMs()
+				collection.DeserializeMs += stats.GetDeserializeMs()
+				collection.SegcoreLoadMs += stats.GetSegcoreLoadMs()
+				collection.IndexLoadMs += stats.GetIndexLoadMs()
+				collection.LoadMs += stats.GetLoadMs()
+				if stats.GetLoadMs() > collection.MaxLoadMs {
+					collection.MaxLoadMs = stats.GetLoadMs()
+				}
+			}
+		}
+	}
+	ret := make([]metricsinfo.CollectionLoadStats, 0, len(collections))
+	for _, collection := range collections {
+		ret = append(ret, *collection)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].CollectionID < ret[j].CollectionID
+	})
+	return ret
+}
+
+// getSegmentSearchStatsMetrics collects the search stats of the segments in replicas searched at least once,
+// sorted by segment id
+func getSegmentSearchStatsMetrics(replicas ...ReplicaInterface) []metricsinfo.SegmentSearchStats {
+	ret := make([]metricsinfo.SegmentSearchStats, 0)
+	for _, replica := range replicas {
+		for _, collectionID := range replica.getCollectionIDs() {
+			partitionIDs, err := replica.getPartitionIDs(collectionID)
+			if err != nil {
+				continue
+			}
+			for _, partitionID := range partitionIDs {
+				segmentIDs, err := replica.getSegmentIDs(partitionID)
+				if err != nil {
+					continue
+				}
+				for _, segmentID := range segmentIDs {
+					segment, err := replica.getSegmentByID(segmentID)
+					if err != nil {
+						continue
+					}
+					stats := segment.getSearchStats()
+					if stats.searches == 0 {
+						continue
+					}
+					ret = append(ret, metricsinfo.SegmentSearchStats{
+						SegmentID:      segmentID,
+						CollectionID:   collectionID,
+						Searches:       stats.searches,
+						TotalLatencyUs: stats.totalLatency.Microseconds(),
+						MaxLatencyUs:   stats.maxLatency.Microseconds(),
+						RowsScanned:    stats.rowsScanned,
+					})
+				}
+			}
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].SegmentID < ret[j].SegmentID
+	})
+	return ret
+}
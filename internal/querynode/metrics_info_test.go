@@ -19,12 +19,14 @@ package querynode
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/util/etcd"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 )
 
@@ -46,4 +48,70 @@ func TestGetSystemInfoMetrics(t *testing.T) {
 	resp, err := getSystemInfoMetrics(ctx, req, node)
 	assert.NoError(t, err)
 	resp.Status.ErrorCode = commonpb.ErrorCode_Success
+
+	t.Run("segcore version", func(t *testing.T) {
+		node.segcoreVersion = &segcoreVersion{
+			abiVersion:       expectedSegcoreABIVersion,
+			version:          "2.0-abc",
+			simdCapabilities: []string{"sse4_2", "avx2"},
+		}
+		resp, err := getSystemInfoMetrics(ctx, req, node)
+		assert.NoError(t, err)
+		infos := metricsinfo.QueryNodeInfos{}
+		err = metricsinfo.UnmarshalComponentInfos(resp.GetResponse(), &infos)
+		assert.NoError(t, err)
+		assert.Equal(t, "2.0-abc", infos.SystemConfigurations.SegcoreVersion)
+		assert.Equal(t, expectedSegcoreABIVersion, infos.SystemConfigurations.SegcoreABIVersion)
+		assert.Equal(t, expectedSegcoreABIVersion, infos.SystemConfigurations.ExpectedSegcoreABIVersion)
+		assert.Equal(t, []string{"sse4_2", "avx2"}, infos.SystemConfigurations.SimdCapabilities)
+	})
+
+	t.Run("paused channels", func(t *testing.T) {
+		_, err := node.dataSyncService.addFlowGraphsForDMLChannels(defaultCollectionID, []Channel{defaultDMLChannel})
+		assert.NoError(t, err)
+		err = node.dataSyncService.pauseFlowGraphsByDMLChannel(defaultCollectionID, defaultDMLChannel)
+		assert.NoError(t, err)
+
+		resp, err := getSystemInfoMetrics(ctx, req, node)
+		assert.NoError(t, err)
+		infos := metricsinfo.QueryNodeInfos{}
+		err = metricsinfo.UnmarshalComponentInfos(resp.GetResponse(), &infos)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{defaultDMLChannel}, infos.PausedChannels)
+	})
+
+	t.Run("bloom filter stats", func(t *testing.T) {
+		resp, err := getSystemInfoMetrics(ctx, req, node)
+		assert.NoError(t, err)
+		infos := metricsinfo.QueryNodeInfos{}
+		err = metricsinfo.UnmarshalComponentInfos(resp.GetResponse(), &infos)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, infos.BloomFilterStats)
+		for _, stats := range infos.BloomFilterStats {
+			assert.Equal(t, defaultSegmentID, stats.SegmentID)
+			assert.Equal(t, defaultCollectionID, stats.CollectionID)
+			assert.NotZero(t, stats.BitSize)
+			assert.NotZero(t, stats.HashFunctions)
+		}
+	})
+	t.Run("segment search stats", func(t *testing.T) {
+		segment, err := node.historical.replica.getSegmentByID(defaultSegmentID)
+		assert.NoError(t, err)
+		segment.recordSearch(time.Millisecond)
+		segment.recordSearch(3 * time.Millisecond)
+
+		resp, err := getSystemInfoMetrics(ctx, req, node)
+		assert.NoError(t, err)
+		infos := metricsinfo.QueryNodeInfos{}
+		err = metricsinfo.UnmarshalComponentInfos(resp.GetResponse(), &infos)
+		assert.NoError(t, err)
+		assert.Equal(t, []metricsinfo.SegmentSearchStats{{
+			SegmentID:      defaultSegmentID,
+			CollectionID:   defaultCollectionID,
+			Searches:       2,
+			TotalLatencyUs: 4000,
+			MaxLatencyUs:   3000,
+			RowsScanned:    2 * segment.getRowCount(),
+		}}, infos.SegmentSearchStats)
+	})
 }
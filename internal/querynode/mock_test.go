@@ -38,7 +38,6 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
-	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
@@ -46,6 +45,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util"
 	"github.com/milvus-io/milvus/internal/util/etcd"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/testutil"
 )
 
 // ---------- unittest util functions ----------
@@ -581,6 +581,18 @@ func genSimpleSegCoreSchema() *schemapb.CollectionSchema {
 	return &schema
 }
 
+// genScalarOnlySchema returns the schema of genSimpleSegCoreSchema without the vector field
+func genScalarOnlySchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Name:   defaultCollectionName,
+		AutoID: false,
+		Fields: []*schemapb.FieldSchema{
+			genConstantField(simpleConstField),
+			genPKField(simplePKField),
+		},
+	}
+}
+
 func genSimpleInsertDataSchema() *schemapb.CollectionSchema {
 	fieldUID := genConstantField(uidField)
 	fieldTimestamp := genConstantField(timestampField)
@@ -689,104 +701,7 @@ func genVectorChunkManager(ctx context.Context) (*storage.VectorChunkManager, er
 // ---------- unittest util functions ----------
 // functions of inserting data init
 func genInsertData(msgLength int, schema *schemapb.CollectionSchema) (*storage.InsertData, error) {
-	insertData := &storage.InsertData{
-		Data: make(map[int64]storage.FieldData),
-	}
-
-	for _, f := range schema.Fields {
-		switch f.DataType {
-		case schemapb.DataType_Bool:
-			data := make([]bool, msgLength)
-			for i := 0; i < msgLength; i++ {
-				data[i] = true
-			}
-			insertData.Data[f.FieldID] = &storage.BoolFieldData{
-				NumRows: []int64{int64(msgLength)},
-				Data:    data,
-			}
-		case schemapb.DataType_Int8:
-			data := make([]int8, msgLength)
-			for i := 0; i < msgLength; i++ {
-				data[i] = int8(i)
-			}
-			insertData.Data[f.FieldID] = &storage.Int8FieldData{
-				NumRows: []int64{int64(msgLength)},
-				Data:    data,
-			}
-		case schemapb.DataType_Int16:
-			data := make([]int16, msgLength)
-			for i := 0; i < msgLength; i++ {
-				data[i] = int16(i)
-			}
-			insertData.Data[f.FieldID] = &storage.Int16FieldData{
-				NumRows: []int64{int64(msgLength)},
-				Data:    data,
-			}
-		case schemapb.DataType_Int32:
-			data := make([]int32, msgLength)
-			for i := 0; i < msgLength; i++ {
-				data[i] = int32(i)
-			}
-			insertData.Data[f.FieldID] = &storage.Int32FieldData{
-				NumRows: []int64{int64(msgLength)},
-				Data:    data,
-			}
-		case schemapb.DataType_Int64:
-			data := make([]int64, msgLength)
-			for i := 0; i < msgLength; i++ {
-				data[i] = int64(i)
-			}
-			insertData.Data[f.FieldID] = &storage.Int64FieldData{
-				NumRows: []int64{int64(msgLength)},
-				Data:    data,
-			}
-		case schemapb.DataType_Float:
-			data := make([]float32, msgLength)
-			for i := 0; i < msgLength; i++ {
-				data[i] = float32(i)
-			}
-			insertData.Data[f.FieldID] = &storage.FloatFieldData{
-				NumRows: []int64{int64(msgLength)},
-				Data:    data,
-			}
-		case schemapb.DataType_Double:
-			data := make([]float64, msgLength)
-			for i := 0; i < msgLength; i++ {
-				data[i] = float64(i)
-			}
-			insertData.Data[f.FieldID] = &storage.DoubleFieldData{
-				NumRows: []int64{int64(msgLength)},
-				Data:    data,
-			}
-		case schemapb.DataType_FloatVector:
-			dim := simpleVecField.dim // if no dim specified, use simpleVecField's dim
-			for _, p := range f.TypeParams {
-				if p.Key == dimKey {
-					var err error
-					dim, err = strconv.Atoi(p.Value)
-					if err != nil {
-						return nil, err
-					}
-				}
-			}
-			data := make([]float32, 0)
-			for i := 0; i < msgLength; i++ {
-				for j := 0; j < dim; j++ {
-					data = append(data, float32(i*j)*0.1)
-				}
-			}
-			insertData.Data[f.FieldID] = &storage.FloatVectorFieldData{
-				NumRows: []int64{int64(msgLength)},
-				Data:    data,
-				Dim:     dim,
-			}
-		default:
-			err := errors.New("data type not supported")
-			return nil, err
-		}
-	}
-
-	return insertData, nil
+	return testutil.GenInsertData(msgLength, schema)
 }
 
 func genSimpleInsertData() (*storage.InsertData, error) {
@@ -1096,51 +1011,8 @@ func genSealedSegment(schemaForCreate *schemapb.CollectionSchema,
 	if err != nil {
 		return nil, err
 	}
-	for k, v := range insertData.Data {
-		var numRows []int64
-		var data interface{}
-		switch fieldData := v.(type) {
-		case *storage.BoolFieldData:
-			numRows = fieldData.NumRows
-			data = fieldData.Data
-		case *storage.Int8FieldData:
-			numRows = fieldData.NumRows
-			data = fieldData.Data
-		case *storage.Int16FieldData:
-			numRows = fieldData.NumRows
-			data = fieldData.Data
-		case *storage.Int32FieldData:
-			numRows = fieldData.NumRows
-			data = fieldData.Data
-		case *storage.Int64FieldData:
-			numRows = fieldData.NumRows
-			data = fieldData.Data
-		case *storage.FloatFieldData:
-			numRows = fieldData.NumRows
-			data = fieldData.Data
-		case *storage.DoubleFieldData:
-			numRows = fieldData.NumRows
-			data = fieldData.Data
-		case *storage.StringFieldData:
-			numRows = fieldData.NumRows
-			data = fieldData.Data
-		case *storage.FloatVectorFieldData:
-			numRows = fieldData.NumRows
-			data = fieldData.Data
-		case *storage.BinaryVectorFieldData:
-			numRows = fieldData.NumRows
-			data = fieldData.Data
-		default:
-			return nil, errors.New("unexpected field data type")
-		}
-		totalNumRows := int64(0)
-		for _, numRow := range numRows {
-			totalNumRows += numRow
-		}
-		err := seg.segmentLoadFieldData(k, int(totalNumRows), data)
-		if err != nil {
-			return nil, err
-		}
+	if err := loadSegmentFromInsertData(seg, insertData); err != nil {
+		return nil, err
 	}
 	return seg, nil
 }
@@ -1174,7 +1046,7 @@ func genSimpleReplica() (ReplicaInterface, error) {
 	if err != nil {
 		return nil, err
 	}
-	r := newCollectionReplica(kv)
+	r := newCollectionReplica(kv, newQueryNodeConfig(), newCollectionRegistry())
 	schema := genSimpleSegCoreSchema()
 	r.addCollection(defaultCollectionID, schema)
 	err = r.addPartition(defaultCollectionID, defaultPartitionID)
@@ -1187,7 +1059,7 @@ func genSimpleSegmentLoaderWithMqFactory(historicalReplica ReplicaInterface, str
 		return nil, err
 	}
 	cm := storage.NewLocalChunkManager(storage.RootPath(defaultLocalStorage))
-	return newSegmentLoader(historicalReplica, streamingReplica, kv, cm, factory), nil
+	return newSegmentLoader(historicalReplica, streamingReplica, kv, cm, factory, newQueryNodeConfig()), nil
 }
 
 func genSimpleHistorical(ctx context.Context, tSafeReplica TSafeReplicaInterface) (*historical, error) {
@@ -1357,34 +1229,7 @@ func genDSLByIndexType(indexType string) (string, error) {
 }
 
 func genPlaceHolderGroup(nq int) ([]byte, error) {
-	placeholderValue := &milvuspb.PlaceholderValue{
-		Tag:    "$0",
-		Type:   milvuspb.PlaceholderType_FloatVector,
-		Values: make([][]byte, 0),
-	}
-	for i := 0; i < nq; i++ {
-		var vec = make([]float32, defaultDim)
-		for j := 0; j < defaultDim; j++ {
-			vec[j] = rand.Float32()
-		}
-		var rawData []byte
-		for k, ele := range vec {
-			buf := make([]byte, 4)
-			common.Endian.PutUint32(buf, math.Float32bits(ele+float32(k*2)))
-			rawData = append(rawData, buf...)
-		}
-		placeholderValue.Values = append(placeholderValue.Values, rawData)
-	}
-
-	// generate placeholder
-	placeholderGroup := milvuspb.PlaceholderGroup{
-		Placeholders: []*milvuspb.PlaceholderValue{placeholderValue},
-	}
-	placeGroupByte, err := proto.Marshal(&placeholderGroup)
-	if err != nil {
-		return nil, err
-	}
-	return placeGroupByte, nil
+	return testutil.GenPlaceholderGroup(nq, defaultDim)
 }
 
 func genSimplePlaceHolderGroup() ([]byte, error) {
@@ -1715,6 +1560,7 @@ func saveChangeInfo(key string, value string) error {
 
 func genSimpleQueryNodeWithMQFactory(ctx context.Context, fac dependency.Factory) (*QueryNode, error) {
 	node := NewQueryNode(ctx, fac)
+	node.config = newQueryNodeConfig()
 	etcdCli, err := etcd.GetEtcdClient(&Params.EtcdCfg)
 	if err != nil {
 		return nil, err
@@ -1769,7 +1615,7 @@ func genSimpleQueryNodeWithMQFactory(ctx context.Context, fac dependency.Factory
 	// init shard cluster service
 	node.ShardClusterService = newShardClusterService(node.etcdCli, node.session, node)
 
-	node.queryShardService = newQueryShardService(node.queryNodeLoopCtx, node.historical, node.streaming, node.ShardClusterService, node.factory)
+	node.queryShardService = newQueryShardService(node.queryNodeLoopCtx, node.historical, node.streaming, node.ShardClusterService, node.factory, node.config)
 
 	node.UpdateStateCode(internalpb.StateCode_Healthy)
 
@@ -1783,118 +1629,7 @@ func genSimpleQueryNode(ctx context.Context) (*QueryNode, error) {
 }
 
 func genFieldData(fieldName string, fieldID int64, fieldType schemapb.DataType, fieldValue interface{}, dim int64) *schemapb.FieldData {
-	var fieldData *schemapb.FieldData
-	switch fieldType {
-	case schemapb.DataType_Bool:
-		fieldData = &schemapb.FieldData{
-			Type:      schemapb.DataType_Bool,
-			FieldName: fieldName,
-			Field: &schemapb.FieldData_Scalars{
-				Scalars: &schemapb.ScalarField{
-					Data: &schemapb.ScalarField_BoolData{
-						BoolData: &schemapb.BoolArray{
-							Data: fieldValue.([]bool),
-						},
-					},
-				},
-			},
-			FieldId: fieldID,
-		}
-	case schemapb.DataType_Int32:
-		fieldData = &schemapb.FieldData{
-			Type:      schemapb.DataType_Int32,
-			FieldName: fieldName,
-			Field: &schemapb.FieldData_Scalars{
-				Scalars: &schemapb.ScalarField{
-					Data: &schemapb.ScalarField_IntData{
-						IntData: &schemapb.IntArray{
-							Data: fieldValue.([]int32),
-						},
-					},
-				},
-			},
-			FieldId: fieldID,
-		}
-	case schemapb.DataType_Int64:
-		fieldData = &schemapb.FieldData{
-			Type:      schemapb.DataType_Int64,
-			FieldName: fieldName,
-			Field: &schemapb.FieldData_Scalars{
-				Scalars: &schemapb.ScalarField{
-					Data: &schemapb.ScalarField_LongData{
-						LongData: &schemapb.LongArray{
-							Data: fieldValue.([]int64),
-						},
-					},
-				},
-			},
-			FieldId: fieldID,
-		}
-	case schemapb.DataType_Float:
-		fieldData = &schemapb.FieldData{
-			Type:      schemapb.DataType_Float,
-			FieldName: fieldName,
-			Field: &schemapb.FieldData_Scalars{
-				Scalars: &schemapb.ScalarField{
-					Data: &schemapb.ScalarField_FloatData{
-						FloatData: &schemapb.FloatArray{
-							Data: fieldValue.([]float32),
-						},
-					},
-				},
-			},
-			FieldId: fieldID,
-		}
-	case schemapb.DataType_Double:
-		fieldData = &schemapb.FieldData{
-			Type:      schemapb.DataType_Double,
-			FieldName: fieldName,
-			Field: &schemapb.FieldData_Scalars{
-				Scalars: &schemapb.ScalarField{
-					Data: &schemapb.ScalarField_DoubleData{
-						DoubleData: &schemapb.DoubleArray{
-							Data: fieldValue.([]float64),
-						},
-					},
-				},
-			},
-			FieldId: fieldID,
-		}
-	case schemapb.DataType_BinaryVector:
-		fieldData = &schemapb.FieldData{
-			Type:      schemapb.DataType_BinaryVector,
-			FieldName: fieldName,
-			Field: &schemapb.FieldData_Vectors{
-				Vectors: &schemapb.VectorField{
-					Dim: dim,
-					Data: &schemapb.VectorField_BinaryVector{
-						BinaryVector: fieldValue.([]byte),
-					},
-				},
-			},
-			FieldId: fieldID,
-		}
-	case schemapb.DataType_FloatVector:
-		fieldData = &schemapb.FieldData{
-			Type:      schemapb.DataType_FloatVector,
-			FieldName: fieldName,
-			Field: &schemapb.FieldData_Vectors{
-				Vectors: &schemapb.VectorField{
-					Dim: dim,
-					Data: &schemapb.VectorField_FloatVector{
-						FloatVector: &schemapb.FloatArray{
-							Data: fieldValue.([]float32),
-						},
-					},
-				},
-			},
-			FieldId: fieldID,
-		}
-	default:
-		log.Error("not supported field type", zap.String("field type", fieldType.String()))
-	}
-
-	return fieldData
+	return testutil.GenFieldData(fieldName, fieldID, fieldType, fieldValue, dim)
 }
 
 type mockMsgStreamFactory struct {
@@ -0,0 +1,139 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// stringBinlog is a binlog of a varchar field, which is a serialized schemapb.StringArray,
+// along with the bounds of its rows in content
+type stringBinlog struct {
+	content []byte
+	begins  []int
+	ends    []int
+}
+
+// scanStringBinlog finds the bounds of the rows of the serialized schemapb.StringArray in content, the rows are the
+// length-delimited values of field 1, scanning them allocates no string per row unlike proto.Unmarshal
+func scanStringBinlog(content []byte) (*stringBinlog, error) {
+	b := &stringBinlog{content: content}
+	for pos := 0; pos < len(content); {
+		tag, n := proto.DecodeVarint(content[pos:])
+		if n == 0 {
+			return nil, fmt.Errorf("invalid tag at %d of string binlog of %d bytes", pos, len(content))
+		}
+		pos += n
+		fieldNum, wireType := tag>>3, tag&7
+		var length uint64
+		switch wireType {
+		case proto.WireVarint:
+			_, n = proto.DecodeVarint(content[pos:])
+			if n == 0 {
+				return nil, fmt.Errorf("invalid varint at %d of string binlog of %d bytes", pos, len(content))
+			}
+			pos += n
+			continue
+		case proto.WireFixed64:
+			length = 8
+		case proto.WireFixed32:
+			length = 4
+		case proto.WireBytes:
+			length, n = proto.DecodeVarint(content[pos:])
+			if n == 0 {
+				return nil, fmt.Errorf("invalid length at %d of string binlog of %d bytes", pos, len(content))
+			}
+			pos += n
+		default:
+			return nil, fmt.Errorf("unexpected wire type %d at %d of string binlog", wireType, pos)
+		}
+		if length > uint64(len(content)-pos) {
+			return nil, fmt.Errorf("value of %d bytes at %d out of string binlog of %d bytes", length, pos, len(content))
+		}
+		if fieldNum == 1 && wireType == proto.WireBytes {
+			b.begins = append(b.begins, pos)
+			b.ends = append(b.ends, pos+int(length))
+		}
+		pos += int(length)
+	}
+	return b, nil
+}
+
+func (b *stringBinlog) numRows() int64 {
+	return int64(len(b.begins))
+}
+
+func (b *stringBinlog) row(offset int64) []byte {
+	return b.content[b.begins[offset]:b.ends[offset]]
+}
+
+// fillStringColumn returns the varchar column of the rows at offsetsInBinlog of the binlogs of dataPaths. Every binlog
+// is read and scanned once however many rows are in it, and the strings of the rows are packed into a single buffer
+// and sliced from it, so that filling the column makes no allocation per row. The buffer is Go memory kept alive by
+// the strings sliced from it, so the column stays valid as long as it's referenced and needs no copy to outlive
+// the request, while the binlogs read are released once the column is filled
+func fillStringColumn(vcm storage.ChunkManager, dataPaths []string, offsetsInBinlog []int64) (*schemapb.StringArray, error) {
+	binlogs := make(map[string]*stringBinlog)
+	size := 0
+	for i, dataPath := range dataPaths {
+		binlog, ok := binlogs[dataPath]
+		if !ok {
+			content, err := vcm.Read(dataPath)
+			if err != nil {
+				return nil, err
+			}
+			binlog, err = scanStringBinlog(content)
+			if err != nil {
+				return nil, fmt.Errorf("%w, path = %s", err, dataPath)
+			}
+			binlogs[dataPath] = binlog
+		}
+		if offsetsInBinlog[i] < 0 || offsetsInBinlog[i] >= binlog.numRows() {
+			return nil, fmt.Errorf("offset %d out of %d rows of string binlog %s", offsetsInBinlog[i], binlog.numRows(), dataPath)
+		}
+		size += len(binlog.row(offsetsInBinlog[i]))
+	}
+
+	packed := make([]byte, size)
+	data := make([]string, len(dataPaths))
+	pos := 0
+	for i, dataPath := range dataPaths {
+		n := copy(packed[pos:], binlogs[dataPath].row(offsetsInBinlog[i]))
+		data[i] = unsafeString(packed[pos : pos+n])
+		pos += n
+	}
+	return &schemapb.StringArray{Data: data}, nil
+}
+
+// unsafeString returns the string sharing the memory of b, b must not be modified afterwards
+func unsafeString(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	var str string
+	header := (*reflect.StringHeader)(unsafe.Pointer(&str))
+	header.Data = uintptr(unsafe.Pointer(&b[0]))
+	header.Len = len(b)
+	return str
+}
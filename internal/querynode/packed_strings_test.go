@@ -0,0 +1,272 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+)
+
+func genStringBinlog(t testing.TB, strs []string) []byte {
+	content, err := proto.Marshal(&schemapb.StringArray{Data: strs})
+	require.NoError(t, err)
+	return content
+}
+
+func TestPackedStrings_scanStringBinlog(t *testing.T) {
+	strs := []string{"a", "", "中文", funcutil.GenRandomStr(), string(make([]byte, 300))}
+	binlog, err := scanStringBinlog(genStringBinlog(t, strs))
+	require.NoError(t, err)
+	require.Equal(t, int64(len(strs)), binlog.numRows())
+	for i, str := range strs {
+		assert.Equal(t, str, string(binlog.row(int64(i))))
+	}
+
+	binlog, err = scanStringBinlog(nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), binlog.numRows())
+
+	// the unknown fields are skipped
+	unknown := proto.NewBuffer(nil)
+	require.NoError(t, unknown.EncodeVarint(2<<3|proto.WireVarint))
+	require.NoError(t, unknown.EncodeVarint(100))
+	require.NoError(t, unknown.EncodeVarint(3<<3|proto.WireFixed32))
+	require.NoError(t, unknown.EncodeFixed32(1))
+	require.NoError(t, unknown.EncodeVarint(4<<3|proto.WireFixed64))
+	require.NoError(t, unknown.EncodeFixed64(1))
+	require.NoError(t, unknown.EncodeVarint(5<<3|proto.WireBytes))
+	require.NoError(t, unknown.EncodeRawBytes([]byte("unknown")))
+	binlog, err = scanStringBinlog(append(unknown.Bytes(), genStringBinlog(t, strs[:1])...))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), binlog.numRows())
+	assert.Equal(t, strs[0], string(binlog.row(0)))
+
+	content := genStringBinlog(t, strs)
+	_, err = scanStringBinlog(content[:len(content)-1])
+	assert.Error(t, err)
+	_, err = scanStringBinlog([]byte("can convert to string array"))
+	assert.Error(t, err)
+	_, err = scanStringBinlog([]byte{0x0A})
+	assert.Error(t, err)
+	_, err = scanStringBinlog([]byte{0x80})
+	assert.Error(t, err)
+}
+
+func TestPackedStrings_fillStringColumn(t *testing.T) {
+	binlogs := map[string][]string{
+		"/binlog/0": {"a", "bb", "", "dddd"},
+		"/binlog/1": {"中文", funcutil.GenRandomStr()},
+	}
+	reads := make(map[string]int)
+	vcm := newMockChunkManager(withRead(func(path string) ([]byte, error) {
+		reads[path]++
+		strs, ok := binlogs[path]
+		if !ok {
+			return nil, errors.New("mock")
+		}
+		return genStringBinlog(t, strs), nil
+	}))
+
+	dataPaths := []string{"/binlog/0", "/binlog/0", "/binlog/1", "/binlog/0", "/binlog/1", "/binlog/0"}
+	offsets := []int64{3, 0, 1, 2, 0, 3}
+	column, err := fillStringColumn(vcm, dataPaths, offsets)
+	require.NoError(t, err)
+	require.Len(t, column.GetData(), len(dataPaths))
+	for i, dataPath := range dataPaths {
+		assert.Equal(t, binlogs[dataPath][offsets[i]], column.GetData()[i])
+	}
+	// every binlog is read once however many rows are in it
+	assert.Equal(t, map[string]int{"/binlog/0": 1, "/binlog/1": 1}, reads)
+
+	column, err = fillStringColumn(vcm, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, column.GetData())
+
+	_, err = fillStringColumn(vcm, []string{"/binlog/0"}, []int64{4})
+	assert.Error(t, err)
+	_, err = fillStringColumn(vcm, []string{"/binlog/0"}, []int64{-1})
+	assert.Error(t, err)
+	_, err = fillStringColumn(vcm, []string{"/binlog/2"}, []int64{0})
+	assert.Error(t, err)
+	_, err = fillStringColumn(newMockChunkManager(withReadIllegalString()), []string{"/binlog/0"}, []int64{0})
+	assert.Error(t, err)
+}
+
+func TestPackedStrings_lifetime(t *testing.T) {
+	const numRows = 1000
+	strs := make([]string, numRows)
+	for i := range strs {
+		strs[i] = fmt.Sprintf("row-%d-%s", i, funcutil.GenRandomStr())
+	}
+	vcm := newMockChunkManager(withRead(func(path string) ([]byte, error) {
+		return genStringBinlog(t, strs), nil
+	}))
+	dataPaths := make([]string, numRows)
+	offsets := make([]int64, numRows)
+	for i := range offsets {
+		dataPaths[i] = "/binlog/0"
+		offsets[i] = int64(numRows - 1 - i)
+	}
+	column, err := fillStringColumn(vcm, dataPaths, offsets)
+	require.NoError(t, err)
+
+	// the strings keep the packed buffer alive, so they stay valid through garbage collections,
+	// and the buffer is never written once packed, so they are safe to read concurrently
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for round := 0; round < 10; round++ {
+				runtime.GC()
+				for i, str := range column.GetData() {
+					if str != strs[numRows-1-i] {
+						t.Errorf("row %d changed to %s", i, str)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// the column marshaled and unmarshaled is the same
+	content, err := proto.Marshal(column)
+	require.NoError(t, err)
+	var unmarshaled schemapb.StringArray
+	require.NoError(t, proto.Unmarshal(content, &unmarshaled))
+	assert.Equal(t, column.GetData(), unmarshaled.GetData())
+}
+
+func TestSegment_fillIndexedFieldsDataVarChar(t *testing.T) {
+	const varCharFieldID = FieldID(200)
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	defer deleteCollection(collection)
+	collection.updateSchema(&schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: simplePKField.id, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: simpleVecField.id, Name: "vec", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: fmt.Sprint(defaultDim)}}},
+			{FieldID: varCharFieldID, Name: "varchar", DataType: schemapb.DataType_VarChar},
+		},
+	})
+	segment, err := newSegment(collection, defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeSealed, true)
+	require.NoError(t, err)
+	defer deleteSegment(segment)
+
+	rowSizes := []int64{3, 2}
+	segment.setIDBinlogRowSizes(rowSizes)
+	fieldBinlog := &datapb.FieldBinlog{FieldID: varCharFieldID}
+	// written[path] are the strings written to the binlog path, every row is the segment offset of the row
+	written := make(map[string][]string)
+	var rows int64
+	for i, size := range rowSizes {
+		path := fmt.Sprintf("/binlog/%d", i)
+		fieldBinlog.Binlogs = append(fieldBinlog.Binlogs, &datapb.Binlog{LogPath: path, EntriesNum: size})
+		for j := int64(0); j < size; j++ {
+			written[path] = append(written[path], fmt.Sprint(rows))
+			rows++
+		}
+	}
+	segment.setIndexedFieldInfo(varCharFieldID, &IndexedFieldInfo{
+		fieldBinlog: fieldBinlog,
+		indexInfo:   &querypb.FieldIndexInfo{FieldID: varCharFieldID, EnableIndex: true},
+	})
+	vcm := newMockChunkManager(withRead(func(path string) ([]byte, error) {
+		strs, ok := written[path]
+		if !ok {
+			return nil, fmt.Errorf("%s not found", path)
+		}
+		return genStringBinlog(t, strs), nil
+	}))
+
+	offsets := []int64{4, 0, 2, 3}
+	result := &segcorepb.RetrieveResults{
+		Ids:    &schemapb.IDs{},
+		Offset: offsets,
+		FieldsData: []*schemapb.FieldData{{
+			Type:    schemapb.DataType_VarChar,
+			FieldId: varCharFieldID,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{}},
+			}},
+		}},
+	}
+	require.NoError(t, segment.fillIndexedFieldsData(defaultCollectionID, vcm, result, newBinlogTracker(0)))
+	fieldData := result.GetFieldsData()[0]
+	assert.Equal(t, varCharFieldID, fieldData.GetFieldId())
+	assert.Equal(t, schemapb.DataType_VarChar, fieldData.GetType())
+	assert.Equal(t, []string{"4", "0", "2", "3"}, fieldData.GetScalars().GetStringData().GetData())
+}
+
+func BenchmarkPackedStrings_fillStringColumn(b *testing.B) {
+	const numRows = 1000
+	strs := make([]string, numRows)
+	for i := range strs {
+		strs[i] = funcutil.GenRandomStr()
+	}
+	content := genStringBinlog(b, strs)
+	vcm := newMockChunkManager(withRead(func(path string) ([]byte, error) {
+		return content, nil
+	}))
+	dataPaths := make([]string, numRows)
+	offsets := make([]int64, numRows)
+	for i := range offsets {
+		dataPaths[i] = "/binlog/0"
+		offsets[i] = int64(i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := fillStringColumn(vcm, dataPaths, offsets); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkPackedStrings_unmarshalStringArray is the cost of decoding a binlog of varchar field the usual way,
+// which allocates a string per row, for comparison with BenchmarkPackedStrings_fillStringColumn
+func BenchmarkPackedStrings_unmarshalStringArray(b *testing.B) {
+	const numRows = 1000
+	strs := make([]string, numRows)
+	for i := range strs {
+		strs[i] = funcutil.GenRandomStr()
+	}
+	content := genStringBinlog(b, strs)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var arr schemapb.StringArray
+		if err := proto.Unmarshal(content, &arr); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// parsePlanPartitionKeys returns the values of the partition key of col that the rows matched by the serialized
+// search or retrieve plan must take, nil if col has no partition key or the plan doesn't constrain it to
+// a finite set of values, e.g. a range on the partition key
+func parsePlanPartitionKeys(col *Collection, expr []byte) *schemapb.FieldData {
+	field := col.getPartitionKeyField()
+	if field == nil {
+		return nil
+	}
+	planNode := &planpb.PlanNode{}
+	if err := proto.Unmarshal(expr, planNode); err != nil {
+		return nil
+	}
+	predicates := planNode.GetPredicates()
+	if predicates == nil {
+		predicates = planNode.GetVectorAnns().GetPredicates()
+	}
+	values, ok := parsePartitionKeys(predicates, field.ID())
+	if !ok {
+		return nil
+	}
+
+	keys := &schemapb.FieldData{
+		Type:    field.schema.GetDataType(),
+		FieldId: field.ID(),
+	}
+	switch field.schema.GetDataType() {
+	case schemapb.DataType_Int64:
+		data := make([]int64, 0, len(values))
+		for _, value := range values {
+			data = append(data, value.GetInt64Val())
+		}
+		keys.Field = &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: data}},
+			},
+		}
+	case schemapb.DataType_VarChar:
+		data := make([]string, 0, len(values))
+		for _, value := range values {
+			data = append(data, value.GetStringVal())
+		}
+		keys.Field = &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: data}},
+			},
+		}
+	default:
+		return nil
+	}
+	return keys
+}
+
+// parsePartitionKeys returns the values of the field fieldID that the rows matched by expr must take, false if
+// expr doesn't constrain the field to a finite set of values. Only `==` and `in` on the field are taken.
+func parsePartitionKeys(expr *planpb.Expr, fieldID FieldID) ([]*planpb.GenericValue, bool) {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_BinaryExpr:
+		left, leftOK := parsePartitionKeys(e.BinaryExpr.GetLeft(), fieldID)
+		right, rightOK := parsePartitionKeys(e.BinaryExpr.GetRight(), fieldID)
+		switch e.BinaryExpr.GetOp() {
+		case planpb.BinaryExpr_LogicalAnd:
+			// the matched rows take the values of both sides, the side with fewer values is taken
+			if leftOK && (!rightOK || len(left) <= len(right)) {
+				return left, true
+			}
+			return right, rightOK
+		case planpb.BinaryExpr_LogicalOr:
+			if leftOK && rightOK {
+				return append(append([]*planpb.GenericValue{}, left...), right...), true
+			}
+		}
+	case *planpb.Expr_TermExpr:
+		if e.TermExpr.GetColumnInfo().GetFieldId() == fieldID {
+			return e.TermExpr.GetValues(), true
+		}
+	case *planpb.Expr_UnaryRangeExpr:
+		if e.UnaryRangeExpr.GetColumnInfo().GetFieldId() == fieldID && e.UnaryRangeExpr.GetOp() == planpb.OpType_Equal {
+			return []*planpb.GenericValue{e.UnaryRangeExpr.GetValue()}, true
+		}
+	}
+	return nil, false
+}
+
+// prunePartitionsByKey returns the partitions of partIDs that the partition keys are routed to, partIDs are the
+// loaded partitions of col. Proxy routes the rows by the hash of their partition keys to the partitions of the
+// collection in the order of partition id, and the partitions of a collection with partition key are created
+// along with it and never created or dropped afterwards. So the partitions are all known only if the whole
+// collection is loaded with the number of partitions in the schema, otherwise partIDs are returned as is.
+func prunePartitionsByKey(replica ReplicaInterface, col *Collection, partIDs []UniqueID, keys *schemapb.FieldData,
+	queryType string) []UniqueID {
+	if keys == nil || len(partIDs) == 0 || col.getLoadType() != loadTypeCollection {
+		return partIDs
+	}
+	field := col.getPartitionKeyField()
+	if field == nil {
+		return partIDs
+	}
+	numPartitions, err := typeutil.GetNumPartitions(field.schema)
+	if err != nil || numPartitions != len(partIDs) {
+		log.Warn("partitions mismatch the partition key, skip pruning", zap.Int64("collectionID", col.ID()),
+			zap.Int("numPartitions", numPartitions), zap.Int64s("partitionIDs", partIDs), zap.Error(err))
+		return partIDs
+	}
+	sorted := make([]UniqueID, len(partIDs))
+	copy(sorted, partIDs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	indexes, err := typeutil.HashKey2Partitions(keys, len(sorted))
+	if err != nil {
+		log.Warn("failed to hash partition keys", zap.Int64("collectionID", col.ID()), zap.Error(err))
+		return partIDs
+	}
+	routed := make(map[UniqueID]struct{}, len(indexes))
+	for _, index := range indexes {
+		routed[sorted[index]] = struct{}{}
+	}
+
+	targetPartIDs := make([]UniqueID, 0, len(routed))
+	pruned := 0
+	for _, partID := range partIDs {
+		if _, ok := routed[partID]; ok {
+			targetPartIDs = append(targetPartIDs, partID)
+			continue
+		}
+		segIDs, err := replica.getSegmentIDs(partID)
+		if err == nil {
+			pruned += len(segIDs)
+		}
+	}
+	metrics.QueryNodePartitionKeyPrunedSegments.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), queryType).Add(float64(pruned))
+	log.Debug("prune partitions by partition keys", zap.Int64("collectionID", col.ID()),
+		zap.Int64s("partitionIDs", targetPartIDs), zap.Int("prunedSegments", pruned))
+	return targetPartIDs
+}
@@ -0,0 +1,267 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// genPartitionKeySchema generates the simple schema taking the pk field as the partition key of 4 partitions
+func genPartitionKeySchema() *schemapb.CollectionSchema {
+	schema := genSimpleSegCoreSchema()
+	for _, field := range schema.GetFields() {
+		if field.GetFieldID() == simplePKField.id {
+			field.IsPartitionKey = true
+			field.TypeParams = append(field.TypeParams, &commonpb.KeyValuePair{Key: typeutil.NumPartitionsKey, Value: "4"})
+		}
+	}
+	return schema
+}
+
+// genPKEqualExpr generates the predicate `pk == value`
+func genPKEqualExpr(value int64) *planpb.Expr {
+	return &planpb.Expr{
+		Expr: &planpb.Expr_UnaryRangeExpr{
+			UnaryRangeExpr: &planpb.UnaryRangeExpr{
+				ColumnInfo: genPKColumnInfo(),
+				Op:         planpb.OpType_Equal,
+				Value:      &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: value}},
+			},
+		},
+	}
+}
+
+func genLogicalExpr(op planpb.BinaryExpr_BinaryOp, left, right *planpb.Expr) *planpb.Expr {
+	return &planpb.Expr{
+		Expr: &planpb.Expr_BinaryExpr{
+			BinaryExpr: &planpb.BinaryExpr{Op: op, Left: left, Right: right},
+		},
+	}
+}
+
+func genRetrievePlanExprWithPredicates(t *testing.T, predicates *planpb.Expr) []byte {
+	expr, err := proto.Marshal(&planpb.PlanNode{
+		Node:           &planpb.PlanNode_Predicates{Predicates: predicates},
+		OutputFieldIds: []int64{simplePKField.id},
+	})
+	require.NoError(t, err)
+	return expr
+}
+
+// genPartitionKeyReplica generates a replica of the partition key collection loaded as a whole, with a growing
+// segment in every partition, the segment id is the partition id plus 100
+func genPartitionKeyReplica(t *testing.T, partIDs []UniqueID) ReplicaInterface {
+	replica, err := genSimpleReplica()
+	require.NoError(t, err)
+	col, err := replica.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+	col.updateSchema(genPartitionKeySchema())
+	col.setLoadType(loadTypeCollection)
+	for _, partID := range partIDs {
+		if partID != defaultPartitionID {
+			require.NoError(t, replica.addPartition(defaultCollectionID, partID))
+		}
+		require.NoError(t, replica.addSegment(partID+100, partID, defaultCollectionID, defaultDMLChannel, segmentTypeGrowing, true))
+	}
+	return replica
+}
+
+// routedPartition returns the partition of partIDs the int64 partition key is routed to
+func routedPartition(key int64, partIDs []UniqueID) UniqueID {
+	hash, _ := typeutil.Hash32Int64(key)
+	return partIDs[hash%uint32(len(partIDs))]
+}
+
+func TestParsePartitionKeys(t *testing.T) {
+	int64Vals := func(values []*planpb.GenericValue) []int64 {
+		ret := make([]int64, 0, len(values))
+		for _, value := range values {
+			ret = append(ret, value.GetInt64Val())
+		}
+		return ret
+	}
+
+	cases := []struct {
+		name   string
+		expr   *planpb.Expr
+		values []int64
+		ok     bool
+	}{
+		{"term", genPKTermExpr(1, 2, 3), []int64{1, 2, 3}, true},
+		{"equal", genPKEqualExpr(5), []int64{5}, true},
+		{"and", genLogicalExpr(planpb.BinaryExpr_LogicalAnd, genPKTermExpr(1, 2, 3), genPKEqualExpr(2)), []int64{2}, true},
+		{"and range", genLogicalExpr(planpb.BinaryExpr_LogicalAnd, genPKRangeExpr(0, 10), genPKTermExpr(1, 2)), []int64{1, 2}, true},
+		{"or", genLogicalExpr(planpb.BinaryExpr_LogicalOr, genPKTermExpr(1, 2), genPKEqualExpr(5)), []int64{1, 2, 5}, true},
+		{"or range", genLogicalExpr(planpb.BinaryExpr_LogicalOr, genPKRangeExpr(0, 10), genPKTermExpr(1, 2)), nil, false},
+		{"range", genPKRangeExpr(0, 10), nil, false},
+		{"not", &planpb.Expr{Expr: &planpb.Expr_UnaryExpr{UnaryExpr: &planpb.UnaryExpr{
+			Op: planpb.UnaryExpr_Not, Child: genPKEqualExpr(5)}}}, nil, false},
+		{"no predicate", nil, nil, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			values, ok := parsePartitionKeys(c.expr, simplePKField.id)
+			assert.Equal(t, c.ok, ok)
+			if c.ok {
+				assert.ElementsMatch(t, c.values, int64Vals(values))
+			}
+		})
+	}
+
+	t.Run("other field", func(t *testing.T) {
+		_, ok := parsePartitionKeys(genPKTermExpr(1, 2), simpleConstField.id)
+		assert.False(t, ok)
+	})
+}
+
+func TestParsePlanPartitionKeys(t *testing.T) {
+	col := newCollection(defaultCollectionID, genPartitionKeySchema())
+	defer deleteCollection(col)
+
+	keys := parsePlanPartitionKeys(col, genRetrievePlanExprWithPredicates(t, genPKTermExpr(1, 2, 3)))
+	require.NotNil(t, keys)
+	assert.Equal(t, schemapb.DataType_Int64, keys.GetType())
+	assert.Equal(t, simplePKField.id, keys.GetFieldId())
+	assert.Equal(t, []int64{1, 2, 3}, keys.GetScalars().GetLongData().GetData())
+
+	// the predicates of search plans
+	searchExpr, err := proto.Marshal(&planpb.PlanNode{
+		Node: &planpb.PlanNode_VectorAnns{
+			VectorAnns: &planpb.VectorANNS{FieldId: simpleVecField.id, Predicates: genPKEqualExpr(7)},
+		},
+	})
+	require.NoError(t, err)
+	keys = parsePlanPartitionKeys(col, searchExpr)
+	assert.Equal(t, []int64{7}, keys.GetScalars().GetLongData().GetData())
+
+	// ranges are not routed
+	assert.Nil(t, parsePlanPartitionKeys(col, genRetrievePlanExprWithPredicates(t, genPKRangeExpr(0, 10))))
+
+	// no partition key
+	col2 := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	defer deleteCollection(col2)
+	assert.Nil(t, parsePlanPartitionKeys(col2, genRetrievePlanExprWithPredicates(t, genPKTermExpr(1, 2, 3))))
+}
+
+func TestPrunePartitionsByKey(t *testing.T) {
+	partIDs := []UniqueID{defaultPartitionID, 11, 12, 13}
+	replica := genPartitionKeyReplica(t, partIDs)
+	col, err := replica.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+	pruned := metrics.QueryNodePartitionKeyPrunedSegments.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), metrics.SearchLabel)
+
+	genKeys := func(values ...int64) *schemapb.FieldData {
+		col := newCollection(defaultCollectionID, genPartitionKeySchema())
+		defer deleteCollection(col)
+		return parsePlanPartitionKeys(col, genRetrievePlanExprWithPredicates(t, genPKTermExpr(values...)))
+	}
+
+	t.Run("pruned", func(t *testing.T) {
+		for key := int64(0); key < 20; key++ {
+			before := testutil.ToFloat64(pruned)
+			targets := prunePartitionsByKey(replica, col, partIDs, genKeys(key), metrics.SearchLabel)
+			assert.Equal(t, []UniqueID{routedPartition(key, partIDs)}, targets)
+			assert.Equal(t, before+3, testutil.ToFloat64(pruned))
+		}
+	})
+
+	t.Run("multiple keys", func(t *testing.T) {
+		expected := make(map[UniqueID]struct{})
+		for _, key := range []int64{1, 5, 9} {
+			expected[routedPartition(key, partIDs)] = struct{}{}
+		}
+		targets := prunePartitionsByKey(replica, col, partIDs, genKeys(1, 5, 9), metrics.SearchLabel)
+		assert.Len(t, targets, len(expected))
+		for _, partID := range targets {
+			assert.Contains(t, expected, partID)
+		}
+	})
+
+	t.Run("partitions in any order", func(t *testing.T) {
+		shuffled := []UniqueID{13, defaultPartitionID, 12, 11}
+		targets := prunePartitionsByKey(replica, col, shuffled, genKeys(3), metrics.SearchLabel)
+		assert.Equal(t, []UniqueID{routedPartition(3, partIDs)}, targets)
+	})
+
+	t.Run("not pruned", func(t *testing.T) {
+		before := testutil.ToFloat64(pruned)
+		assert.Equal(t, partIDs, prunePartitionsByKey(replica, col, partIDs, nil, metrics.SearchLabel))
+
+		// the partitions loaded are fewer or more than the partitions the rows are routed to
+		assert.Equal(t, partIDs[:3], prunePartitionsByKey(replica, col, partIDs[:3], genKeys(3), metrics.SearchLabel))
+		morePartIDs := append([]UniqueID{14}, partIDs...)
+		assert.Equal(t, morePartIDs, prunePartitionsByKey(replica, col, morePartIDs, genKeys(3), metrics.SearchLabel))
+
+		col.setLoadType(loadTypePartition)
+		defer col.setLoadType(loadTypeCollection)
+		assert.Equal(t, partIDs, prunePartitionsByKey(replica, col, partIDs, genKeys(3), metrics.SearchLabel))
+		assert.Equal(t, before, testutil.ToFloat64(pruned))
+	})
+}
+
+func TestStreaming_retrieveWithPartitionKey(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	partIDs := []UniqueID{defaultPartitionID, 11, 12, 13}
+	streaming, err := genSimpleStreaming(ctx, newTSafeReplica())
+	require.NoError(t, err)
+	streaming.replica = genPartitionKeyReplica(t, partIDs)
+	col, err := streaming.replica.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+
+	retrieve := func(predicates *planpb.Expr, partIDs []UniqueID) ([]UniqueID, []UniqueID) {
+		plan, err := createRetrievePlanByExpr(col, genRetrievePlanExprWithPredicates(t, predicates), 100)
+		require.NoError(t, err)
+		defer plan.delete()
+		_, segmentIDs, retrievedPartIDs, err := streaming.retrieve(defaultCollectionID, partIDs, plan)
+		require.NoError(t, err)
+		return segmentIDs, retrievedPartIDs
+	}
+
+	t.Run("pruned", func(t *testing.T) {
+		routed := routedPartition(5, partIDs)
+		segmentIDs, retrievedPartIDs := retrieve(genPKEqualExpr(5), nil)
+		assert.Equal(t, []UniqueID{routed + 100}, segmentIDs)
+		assert.Equal(t, []UniqueID{routed}, retrievedPartIDs)
+	})
+
+	t.Run("range not pruned", func(t *testing.T) {
+		segmentIDs, retrievedPartIDs := retrieve(genPKRangeExpr(0, 10), nil)
+		assert.Len(t, segmentIDs, len(partIDs))
+		assert.ElementsMatch(t, partIDs, retrievedPartIDs)
+	})
+
+	t.Run("partitions specified", func(t *testing.T) {
+		segmentIDs, retrievedPartIDs := retrieve(genPKEqualExpr(5), partIDs)
+		assert.Len(t, segmentIDs, len(partIDs))
+		assert.ElementsMatch(t, partIDs, retrievedPartIDs)
+	})
+}
@@ -0,0 +1,246 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+// pendingDelete is a delete of pk matching no growing segment when it arrived
+type pendingDelete struct {
+	collectionID UniqueID
+	partitionID  UniqueID // -1 if the delete is of all the partitions
+	timestamp    Timestamp
+}
+
+// pendingDeletes keeps the deletes of a channel whose pks match no growing segment, since the inserts of the pks
+// may arrive later than the deletes, e.g. the inserts retried out of order. A pending delete is applied to the row
+// of its pk inserted later if the row is not later than the delete, or expires after window. Most of the pending
+// deletes are of the sealed segments, which are handled by the delta channel, so at most maxSize pks are kept.
+type pendingDeletes struct {
+	window  time.Duration
+	maxSize int64
+
+	mu      sync.Mutex
+	deletes map[interface{}][]pendingDelete // pk value -> deletes
+	size    int64
+}
+
+// newPendingDeletes returns pendingDeletes keeping the deletes for window, disabled if window is not positive
+func newPendingDeletes(window time.Duration, maxSize int64) *pendingDeletes {
+	return &pendingDeletes{
+		window:  window,
+		maxSize: maxSize,
+		deletes: make(map[interface{}][]pendingDelete),
+	}
+}
+
+func (p *pendingDeletes) enabled() bool {
+	return p.window > 0
+}
+
+// add keeps the deletes of pks, the ones beyond maxSize are dropped
+func (p *pendingDeletes) add(collectionID, partitionID UniqueID, pks []primaryKey, timestamps []Timestamp) {
+	if !p.enabled() || len(pks) == 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	added, dropped := 0, 0
+	for i, pk := range pks {
+		if p.size >= p.maxSize {
+			dropped = len(pks) - i
+			break
+		}
+		key := primaryKeyValue(pk)
+		p.deletes[key] = append(p.deletes[key], pendingDelete{
+			collectionID: collectionID,
+			partitionID:  partitionID,
+			timestamp:    timestamps[i],
+		})
+		p.size++
+		added++
+	}
+	p.observe(added, metrics.DroppedLabel, dropped)
+}
+
+// match returns the pending deletes of the rows inserted into the partition with pks and timestamps, and removes
+// them. A row matches the earliest pending delete of its pk not earlier than the row, the earlier deletes are kept,
+// since they may be of the rows of the pk inserted even earlier but arriving later.
+func (p *pendingDeletes) match(collectionID, partitionID UniqueID, pks []primaryKey, timestamps []Timestamp) ([]primaryKey, []Timestamp) {
+	if !p.enabled() {
+		return nil, nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.size == 0 {
+		return nil, nil
+	}
+
+	var matchedPKs []primaryKey
+	var matchedTss []Timestamp
+	for i, pk := range pks {
+		key := primaryKeyValue(pk)
+		deletes := p.deletes[key]
+		matched := -1
+		for j, d := range deletes {
+			if d.collectionID != collectionID || (d.partitionID != -1 && d.partitionID != partitionID) || d.timestamp < timestamps[i] {
+				continue
+			}
+			if matched == -1 || d.timestamp < deletes[matched].timestamp {
+				matched = j
+			}
+		}
+		if matched == -1 {
+			continue
+		}
+		matchedPKs = append(matchedPKs, pk)
+		matchedTss = append(matchedTss, deletes[matched].timestamp)
+		if len(deletes) == 1 {
+			delete(p.deletes, key)
+		} else {
+			p.deletes[key] = append(deletes[:matched], deletes[matched+1:]...)
+		}
+		p.size--
+	}
+	p.observe(-len(matchedPKs), metrics.AppliedLabel, len(matchedPKs))
+	return matchedPKs, matchedTss
+}
+
+// expire removes the pending deletes earlier than ts by more than window
+func (p *pendingDeletes) expire(ts Timestamp) {
+	if !p.enabled() {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.size == 0 {
+		return
+	}
+
+	deadline := tsoutil.PhysicalTime(ts).Add(-p.window)
+	expired := 0
+	for key, deletes := range p.deletes {
+		kept := deletes[:0]
+		for _, d := range deletes {
+			if tsoutil.PhysicalTime(d.timestamp).Before(deadline) {
+				expired++
+				continue
+			}
+			kept = append(kept, d)
+		}
+		if len(kept) == 0 {
+			delete(p.deletes, key)
+		} else {
+			p.deletes[key] = kept
+		}
+	}
+	p.size -= int64(expired)
+	p.observe(-expired, metrics.ExpiredLabel, expired)
+}
+
+// transfer moves the pending deletes of the partitions of collection to dst, the ones of all the partitions are
+// copied instead, since they may still match the rows inserted into the other partitions of the channel. The deletes
+// beyond the maxSize of dst are dropped. It returns the number of the deletes transferred.
+func (p *pendingDeletes) transfer(dst *pendingDeletes, collectionID UniqueID, partitionIDs []UniqueID) int {
+	if !p.enabled() || p == dst {
+		return 0
+	}
+	partitions := make(map[UniqueID]struct{}, len(partitionIDs))
+	for _, partitionID := range partitionIDs {
+		partitions[partitionID] = struct{}{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	dst.mu.Lock()
+	defer dst.mu.Unlock()
+
+	moved, copied, dropped := 0, 0, 0
+	for key, deletes := range p.deletes {
+		kept := deletes[:0]
+		for _, d := range deletes {
+			_, ok := partitions[d.partitionID]
+			if d.collectionID != collectionID || (!ok && d.partitionID != -1) {
+				kept = append(kept, d)
+				continue
+			}
+			if d.partitionID == -1 {
+				kept = append(kept, d)
+			} else {
+				moved++
+			}
+			if !dst.enabled() || dst.size >= dst.maxSize {
+				dropped++
+				continue
+			}
+			dst.deletes[key] = append(dst.deletes[key], d)
+			dst.size++
+			copied++
+		}
+		if len(kept) == 0 {
+			delete(p.deletes, key)
+		} else {
+			p.deletes[key] = kept
+		}
+	}
+	p.size -= int64(moved)
+	p.observe(copied-moved, metrics.DroppedLabel, dropped)
+	return copied
+}
+
+// clear removes all the pending deletes, along with the flow graph of channel
+func (p *pendingDeletes) clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.observe(-int(p.size), metrics.ExpiredLabel, 0)
+	p.deletes = make(map[interface{}][]pendingDelete)
+	p.size = 0
+}
+
+func (p *pendingDeletes) len() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.size
+}
+
+func (p *pendingDeletes) observe(delta int, status string, resolved int) {
+	nodeID := fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)
+	if delta != 0 {
+		metrics.QueryNodePendingDeletes.WithLabelValues(nodeID).Add(float64(delta))
+	}
+	if resolved > 0 {
+		metrics.QueryNodePendingDeletesResolved.WithLabelValues(nodeID, status).Add(float64(resolved))
+	}
+}
+
+// primaryKeyValue returns the value of pk as a map key
+func primaryKeyValue(pk primaryKey) interface{} {
+	switch pk := pk.(type) {
+	case *int64PrimaryKey:
+		return pk.Value
+	case *varCharPrimaryKey:
+		return pk.Value
+	default:
+		return pk
+	}
+}
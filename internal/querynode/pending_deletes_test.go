@@ -0,0 +1,160 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+func TestPendingDeletes(t *testing.T) {
+	now := time.Now()
+	tsAt := func(seconds int) Timestamp {
+		return tsoutil.ComposeTSByTime(now.Add(time.Duration(seconds)*time.Second), 0)
+	}
+	pksOf := func(values ...int64) []primaryKey {
+		pks := make([]primaryKey, 0, len(values))
+		for _, value := range values {
+			pks = append(pks, newInt64PrimaryKey(value))
+		}
+		return pks
+	}
+	resolved := func(status string) float64 {
+		return testutil.ToFloat64(metrics.QueryNodePendingDeletesResolved.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), status))
+	}
+
+	t.Run("match", func(t *testing.T) {
+		p := newPendingDeletes(time.Minute, 100)
+		defer p.clear()
+		p.add(defaultCollectionID, -1, pksOf(1, 2), []Timestamp{tsAt(10), tsAt(10)})
+		p.add(defaultCollectionID, defaultPartitionID, pksOf(3), []Timestamp{tsAt(10)})
+		p.add(defaultCollectionID, defaultPartitionID+1, pksOf(4), []Timestamp{tsAt(10)})
+		assert.Equal(t, int64(4), p.len())
+
+		applied := resolved(metrics.AppliedLabel)
+		// pk 2 is inserted later than the delete, pk 4 is of another partition and pk 5 is never deleted
+		pks, tss := p.match(defaultCollectionID, defaultPartitionID, pksOf(1, 2, 3, 4, 5),
+			[]Timestamp{tsAt(5), tsAt(15), tsAt(10), tsAt(5), tsAt(5)})
+		assert.Equal(t, pksOf(1, 3), pks)
+		assert.Equal(t, []Timestamp{tsAt(10), tsAt(10)}, tss)
+		assert.Equal(t, int64(2), p.len())
+		assert.Equal(t, applied+2, resolved(metrics.AppliedLabel))
+
+		pks, _ = p.match(defaultCollectionID+1, defaultPartitionID+1, pksOf(4), []Timestamp{tsAt(5)})
+		assert.Empty(t, pks)
+		pks, _ = p.match(defaultCollectionID, defaultPartitionID+1, pksOf(4), []Timestamp{tsAt(5)})
+		assert.Equal(t, pksOf(4), pks)
+	})
+
+	t.Run("match the earliest delete later than the row", func(t *testing.T) {
+		p := newPendingDeletes(time.Minute, 100)
+		defer p.clear()
+		p.add(defaultCollectionID, -1, pksOf(1, 1, 1), []Timestamp{tsAt(30), tsAt(10), tsAt(20)})
+
+		_, tss := p.match(defaultCollectionID, defaultPartitionID, pksOf(1), []Timestamp{tsAt(15)})
+		assert.Equal(t, []Timestamp{tsAt(20)}, tss)
+		_, tss = p.match(defaultCollectionID, defaultPartitionID, pksOf(1), []Timestamp{tsAt(5)})
+		assert.Equal(t, []Timestamp{tsAt(10)}, tss)
+		assert.Equal(t, int64(1), p.len())
+	})
+
+	t.Run("varchar pks", func(t *testing.T) {
+		p := newPendingDeletes(time.Minute, 100)
+		defer p.clear()
+		p.add(defaultCollectionID, -1, []primaryKey{newVarCharPrimaryKey("a")}, []Timestamp{tsAt(10)})
+		pks, _ := p.match(defaultCollectionID, defaultPartitionID,
+			[]primaryKey{newVarCharPrimaryKey("b"), newVarCharPrimaryKey("a")}, []Timestamp{tsAt(5), tsAt(5)})
+		assert.Equal(t, []primaryKey{newVarCharPrimaryKey("a")}, pks)
+	})
+
+	t.Run("max size", func(t *testing.T) {
+		p := newPendingDeletes(time.Minute, 2)
+		defer p.clear()
+		dropped := resolved(metrics.DroppedLabel)
+		p.add(defaultCollectionID, -1, pksOf(1, 2, 3), []Timestamp{tsAt(10), tsAt(10), tsAt(10)})
+		assert.Equal(t, int64(2), p.len())
+		assert.Equal(t, dropped+1, resolved(metrics.DroppedLabel))
+	})
+
+	t.Run("expire", func(t *testing.T) {
+		p := newPendingDeletes(time.Minute, 100)
+		defer p.clear()
+		p.add(defaultCollectionID, -1, pksOf(1, 2, 2), []Timestamp{tsAt(0), tsAt(0), tsAt(30)})
+
+		expired := resolved(metrics.ExpiredLabel)
+		p.expire(tsAt(60))
+		assert.Equal(t, int64(3), p.len())
+		p.expire(tsAt(61))
+		assert.Equal(t, int64(1), p.len())
+		assert.Equal(t, expired+2, resolved(metrics.ExpiredLabel))
+
+		p.clear()
+		assert.Equal(t, int64(0), p.len())
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		p := newPendingDeletes(time.Minute, 100)
+		defer p.clear()
+		dst := newPendingDeletes(time.Minute, 100)
+		defer dst.clear()
+		p.add(defaultCollectionID, -1, pksOf(1), []Timestamp{tsAt(10)})
+		p.add(defaultCollectionID, defaultPartitionID, pksOf(2), []Timestamp{tsAt(10)})
+		p.add(defaultCollectionID, defaultPartitionID+1, pksOf(3), []Timestamp{tsAt(10)})
+		p.add(defaultCollectionID+1, defaultPartitionID, pksOf(4), []Timestamp{tsAt(10)})
+
+		// the deletes of all the partitions are copied, the ones of the partition are moved
+		assert.Equal(t, 2, p.transfer(dst, defaultCollectionID, []UniqueID{defaultPartitionID}))
+		assert.Equal(t, int64(3), p.len())
+		assert.Equal(t, int64(2), dst.len())
+		pks, _ := dst.match(defaultCollectionID, defaultPartitionID, pksOf(1, 2, 3, 4), []Timestamp{tsAt(5), tsAt(5), tsAt(5), tsAt(5)})
+		assert.Equal(t, pksOf(1, 2), pks)
+		pks, _ = p.match(defaultCollectionID, defaultPartitionID, pksOf(1, 2), []Timestamp{tsAt(5), tsAt(5)})
+		assert.Equal(t, pksOf(1), pks)
+
+		assert.Equal(t, 0, p.transfer(p, defaultCollectionID, []UniqueID{defaultPartitionID + 1}))
+		assert.Equal(t, int64(2), p.len())
+	})
+
+	t.Run("transfer beyond max size", func(t *testing.T) {
+		p := newPendingDeletes(time.Minute, 100)
+		defer p.clear()
+		dst := newPendingDeletes(time.Minute, 1)
+		defer dst.clear()
+		p.add(defaultCollectionID, defaultPartitionID, pksOf(1, 2), []Timestamp{tsAt(10), tsAt(10)})
+
+		dropped := resolved(metrics.DroppedLabel)
+		assert.Equal(t, 1, p.transfer(dst, defaultCollectionID, []UniqueID{defaultPartitionID}))
+		assert.Equal(t, int64(0), p.len())
+		assert.Equal(t, int64(1), dst.len())
+		assert.Equal(t, dropped+1, resolved(metrics.DroppedLabel))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		p := newPendingDeletes(0, 100)
+		p.add(defaultCollectionID, -1, pksOf(1), []Timestamp{tsAt(10)})
+		assert.Equal(t, int64(0), p.len())
+		pks, _ := p.match(defaultCollectionID, defaultPartitionID, pksOf(1), []Timestamp{tsAt(5)})
+		assert.Empty(t, pks)
+	})
+}
@@ -0,0 +1,174 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"sort"
+	"unsafe"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// pkIndex maps the primary keys of a sealed segment to their row offsets
+type pkIndex interface {
+	// search returns the offset of the row with primary key pk, false if pk doesn't exist
+	search(pk primaryKey) (int64, bool)
+	// pkType returns the data type of primary keys in the index
+	pkType() schemapb.DataType
+	// rowCount returns the number of rows indexed
+	rowCount() int
+	// memSize returns the memory held by the index in bytes
+	memSize() int64
+	// walk calls fn with every primary key and its row offset in pk order
+	walk(fn func(pk primaryKey, offset int64))
+}
+
+// newPkIndex builds a sorted pk index from the primary key column of a segment
+func newPkIndex(data storage.FieldData) (pkIndex, error) {
+	switch fieldData := data.(type) {
+	case *storage.Int64FieldData:
+		return newInt64PkIndex(fieldData.Data), nil
+	case *storage.StringFieldData:
+		return newVarCharPkIndex(fieldData.Data), nil
+	default:
+		return nil, fmt.Errorf("unsupported primary key field data type %T", data)
+	}
+}
+
+// int64PkIndex is a pk index of int64 primary keys, pks are sorted in ascending order,
+// offsets[i] is the row offset of pks[i]
+type int64PkIndex struct {
+	pks     []int64
+	offsets []int64
+}
+
+func newInt64PkIndex(data []int64) *int64PkIndex {
+	index := &int64PkIndex{
+		pks:     make([]int64, len(data)),
+		offsets: make([]int64, len(data)),
+	}
+	copy(index.pks, data)
+	for i := range index.offsets {
+		index.offsets[i] = int64(i)
+	}
+	// keep the first offset ahead if pk duplicates
+	sort.Stable(index)
+	return index
+}
+
+func (index *int64PkIndex) Len() int           { return len(index.pks) }
+func (index *int64PkIndex) Less(i, j int) bool { return index.pks[i] < index.pks[j] }
+func (index *int64PkIndex) Swap(i, j int) {
+	index.pks[i], index.pks[j] = index.pks[j], index.pks[i]
+	index.offsets[i], index.offsets[j] = index.offsets[j], index.offsets[i]
+}
+
+func (index *int64PkIndex) search(pk primaryKey) (int64, bool) {
+	int64Pk, ok := pk.(*int64PrimaryKey)
+	if !ok {
+		return -1, false
+	}
+	i := sort.Search(len(index.pks), func(i int) bool { return index.pks[i] >= int64Pk.Value })
+	if i < len(index.pks) && index.pks[i] == int64Pk.Value {
+		return index.offsets[i], true
+	}
+	return -1, false
+}
+
+func (index *int64PkIndex) pkType() schemapb.DataType {
+	return schemapb.DataType_Int64
+}
+
+func (index *int64PkIndex) rowCount() int {
+	return len(index.pks)
+}
+
+func (index *int64PkIndex) memSize() int64 {
+	return int64(unsafe.Sizeof(*index)) + int64(cap(index.pks))*8 + int64(cap(index.offsets))*8
+}
+
+func (index *int64PkIndex) walk(fn func(pk primaryKey, offset int64)) {
+	for i, pk := range index.pks {
+		fn(newInt64PrimaryKey(pk), index.offsets[i])
+	}
+}
+
+// varCharPkIndex is a pk index of varchar primary keys, pks are sorted in ascending order,
+// offsets[i] is the row offset of pks[i]
+type varCharPkIndex struct {
+	pks     []string
+	offsets []int64
+}
+
+func newVarCharPkIndex(data []string) *varCharPkIndex {
+	index := &varCharPkIndex{
+		pks:     make([]string, len(data)),
+		offsets: make([]int64, len(data)),
+	}
+	copy(index.pks, data)
+	for i := range index.offsets {
+		index.offsets[i] = int64(i)
+	}
+	// keep the first offset ahead if pk duplicates
+	sort.Stable(index)
+	return index
+}
+
+func (index *varCharPkIndex) Len() int           { return len(index.pks) }
+func (index *varCharPkIndex) Less(i, j int) bool { return index.pks[i] < index.pks[j] }
+func (index *varCharPkIndex) Swap(i, j int) {
+	index.pks[i], index.pks[j] = index.pks[j], index.pks[i]
+	index.offsets[i], index.offsets[j] = index.offsets[j], index.offsets[i]
+}
+
+func (index *varCharPkIndex) search(pk primaryKey) (int64, bool) {
+	varCharPk, ok := pk.(*varCharPrimaryKey)
+	if !ok {
+		return -1, false
+	}
+	i := sort.SearchStrings(index.pks, varCharPk.Value)
+	if i < len(index.pks) && index.pks[i] == varCharPk.Value {
+		return index.offsets[i], true
+	}
+	return -1, false
+}
+
+func (index *varCharPkIndex) pkType() schemapb.DataType {
+	return schemapb.DataType_VarChar
+}
+
+func (index *varCharPkIndex) rowCount() int {
+	return len(index.pks)
+}
+
+func (index *varCharPkIndex) memSize() int64 {
+	size := int64(unsafe.Sizeof(*index))
+	size += int64(cap(index.pks)) * int64(unsafe.Sizeof(""))
+	for _, pk := range index.pks {
+		size += int64(len(pk))
+	}
+	size += int64(cap(index.offsets)) * 8
+	return size
+}
+
+func (index *varCharPkIndex) walk(fn func(pk primaryKey, offset int64)) {
+	for i, pk := range index.pks {
+		fn(newVarCharPrimaryKey(pk), index.offsets[i])
+	}
+}
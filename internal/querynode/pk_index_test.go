@@ -0,0 +1,128 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+const pkIndexTestRowCount = 100000
+
+func TestPkIndex_int64(t *testing.T) {
+	perm := rand.Perm(pkIndexTestRowCount)
+	data := make([]int64, pkIndexTestRowCount)
+	for offset, pk := range perm {
+		data[offset] = int64(pk)
+	}
+
+	index, err := newPkIndex(&storage.Int64FieldData{NumRows: []int64{pkIndexTestRowCount}, Data: data})
+	assert.NoError(t, err)
+	assert.Equal(t, schemapb.DataType_Int64, index.pkType())
+	assert.Equal(t, pkIndexTestRowCount, index.rowCount())
+
+	for offset, pk := range data {
+		result, ok := index.search(newInt64PrimaryKey(pk))
+		assert.True(t, ok)
+		assert.Equal(t, int64(offset), result)
+	}
+
+	_, ok := index.search(newInt64PrimaryKey(-1))
+	assert.False(t, ok)
+	_, ok = index.search(newInt64PrimaryKey(pkIndexTestRowCount))
+	assert.False(t, ok)
+	_, ok = index.search(newVarCharPrimaryKey("1"))
+	assert.False(t, ok)
+
+	// sorted pks and offsets, 8 bytes each
+	assert.GreaterOrEqual(t, index.memSize(), int64(pkIndexTestRowCount*16))
+	assert.Less(t, index.memSize(), int64(pkIndexTestRowCount*16+1024))
+}
+
+func TestPkIndex_varChar(t *testing.T) {
+	perm := rand.Perm(pkIndexTestRowCount)
+	data := make([]string, pkIndexTestRowCount)
+	var strLen int64
+	for offset, pk := range perm {
+		data[offset] = "pk-" + strconv.Itoa(pk)
+		strLen += int64(len(data[offset]))
+	}
+
+	index, err := newPkIndex(&storage.StringFieldData{NumRows: []int64{pkIndexTestRowCount}, Data: data})
+	assert.NoError(t, err)
+	assert.Equal(t, schemapb.DataType_VarChar, index.pkType())
+	assert.Equal(t, pkIndexTestRowCount, index.rowCount())
+
+	for offset, pk := range data {
+		result, ok := index.search(newVarCharPrimaryKey(pk))
+		assert.True(t, ok)
+		assert.Equal(t, int64(offset), result)
+	}
+
+	_, ok := index.search(newVarCharPrimaryKey("pk-"))
+	assert.False(t, ok)
+	_, ok = index.search(newVarCharPrimaryKey("pk-" + strconv.Itoa(pkIndexTestRowCount)))
+	assert.False(t, ok)
+	_, ok = index.search(newInt64PrimaryKey(1))
+	assert.False(t, ok)
+
+	// string headers, string bytes and offsets
+	assert.GreaterOrEqual(t, index.memSize(), int64(pkIndexTestRowCount*24)+strLen)
+}
+
+func TestPkIndex_duplicatedPK(t *testing.T) {
+	index := newInt64PkIndex([]int64{3, 1, 3, 2, 3})
+	offset, ok := index.search(newInt64PrimaryKey(3))
+	assert.True(t, ok)
+	assert.Equal(t, int64(0), offset)
+
+	strIndex := newVarCharPkIndex([]string{"b", "a", "b"})
+	offset, ok = strIndex.search(newVarCharPrimaryKey("b"))
+	assert.True(t, ok)
+	assert.Equal(t, int64(0), offset)
+}
+
+func TestPkIndex_walk(t *testing.T) {
+	var pks []int64
+	var offsets []int64
+	newInt64PkIndex([]int64{3, 1, 3, 2}).walk(func(pk primaryKey, offset int64) {
+		pks = append(pks, pk.(*int64PrimaryKey).Value)
+		offsets = append(offsets, offset)
+	})
+	assert.Equal(t, []int64{1, 2, 3, 3}, pks)
+	assert.Equal(t, []int64{1, 3, 0, 2}, offsets)
+
+	var strPks []string
+	offsets = nil
+	newVarCharPkIndex([]string{"b", "a"}).walk(func(pk primaryKey, offset int64) {
+		strPks = append(strPks, pk.(*varCharPrimaryKey).Value)
+		offsets = append(offsets, offset)
+	})
+	assert.Equal(t, []string{"a", "b"}, strPks)
+	assert.Equal(t, []int64{1, 0}, offsets)
+}
+
+func TestPkIndex_unsupportedType(t *testing.T) {
+	_, err := newPkIndex(&storage.FloatFieldData{NumRows: []int64{1}, Data: []float32{1}})
+	assert.Error(t, err)
+}
@@ -30,12 +30,29 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"math"
 	"unsafe"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
 )
 
 // SearchPlan is a wrapper of the underlying C-structure C.CSearchPlan
 type SearchPlan struct {
-	cSearchPlan C.CSearchPlan
+	cSearchPlan   C.CSearchPlan
+	prefilter     *prefilterPlan      // evaluates the predicate alone, nil if no predicate or prefiltering disabled
+	partitionKeys *schemapb.FieldData // partition keys the matched rows take, nil if not constrained
+	// chunkSearchable is true if the plan has no predicate or a predicate the chunk search evaluates, so the growing
+	// segments could be searched by chunks
+	chunkSearchable bool
+	chunkPredicate  *chunkPredicate // the predicate evaluated by the chunk search, nil if no predicate
+	expireTs        Timestamp       // rows inserted before expireTs are invisible, 0 means rows never expire
 }
 
 // createSearchPlan returns a new SearchPlan and error
@@ -71,7 +88,25 @@ func createSearchPlanByExpr(col *Collection, expr []byte) (*SearchPlan, error) {
 		return nil, err1
 	}
 
-	var newPlan = &SearchPlan{cSearchPlan: cPlan}
+	var newPlan = &SearchPlan{cSearchPlan: cPlan, partitionKeys: parsePlanPartitionKeys(col, expr)}
+	if Params.QueryNodeCfg.PrefilterSelectivity > 0 {
+		prefilter, err := newPrefilterPlan(col, expr)
+		if err != nil {
+			// search without prefiltering
+			log.Warn("failed to create prefilter plan", zap.Int64("collectionID", col.id), zap.Error(err))
+		}
+		newPlan.prefilter = prefilter
+	}
+	if Params.QueryNodeCfg.EnableGrowingChunkSearch {
+		planNode := &planpb.PlanNode{}
+		if err := proto.Unmarshal(expr, planNode); err == nil {
+			if predicates := planNode.GetVectorAnns().GetPredicates(); predicates == nil {
+				newPlan.chunkSearchable = planNode.GetVectorAnns() != nil
+			} else {
+				newPlan.chunkPredicate, newPlan.chunkSearchable = newChunkPredicate(predicates)
+			}
+		}
+	}
 	return newPlan, nil
 }
 
@@ -87,12 +122,33 @@ func (plan *SearchPlan) getMetricType() string {
 	return metricType
 }
 
+// getFieldID returns the id of the vector field to search
+func (plan *SearchPlan) getFieldID() FieldID {
+	fieldID := C.GetFieldID(plan.cSearchPlan)
+	return FieldID(fieldID)
+}
+
+// setExpireTs makes the rows inserted before expireTs invisible to the plan, 0 means rows never expire
+func (plan *SearchPlan) setExpireTs(expireTs Timestamp) {
+	C.SetSearchPlanExpireTs(plan.cSearchPlan, C.uint64_t(expireTs))
+	plan.expireTs = expireTs
+	if plan.prefilter != nil {
+		plan.prefilter.retrievePlan.setExpireTs(expireTs)
+	}
+}
+
 func (plan *SearchPlan) delete() {
 	C.DeleteSearchPlan(plan.cSearchPlan)
+	if plan.prefilter != nil {
+		plan.prefilter.delete()
+	}
 }
 
 type searchRequest struct {
 	cPlaceholderGroup C.CPlaceholderGroup
+	// floatVectors are the float query vectors decoded for the chunk search of growing segments,
+	// nil if the plan is not searched by chunks
+	floatVectors [][]float32
 }
 
 func parseSearchRequest(plan *SearchPlan, searchRequestBlob []byte) (*searchRequest, error) {
@@ -109,9 +165,39 @@ func parseSearchRequest(plan *SearchPlan, searchRequestBlob []byte) (*searchRequ
 	}
 
 	var newSearchRequest = &searchRequest{cPlaceholderGroup: cPlaceholderGroup}
+	if plan.chunkSearchable {
+		newSearchRequest.floatVectors = parseFloatVectors(searchRequestBlob)
+	}
 	return newSearchRequest, nil
 }
 
+// parseFloatVectors returns the float vectors of the serialized placeholder group, nil if they are not float vectors
+func parseFloatVectors(searchRequestBlob []byte) [][]float32 {
+	placeholderGroup := &milvuspb.PlaceholderGroup{}
+	if err := proto.Unmarshal(searchRequestBlob, placeholderGroup); err != nil {
+		return nil
+	}
+	if len(placeholderGroup.GetPlaceholders()) != 1 {
+		return nil
+	}
+	placeholder := placeholderGroup.GetPlaceholders()[0]
+	if placeholder.GetType() != milvuspb.PlaceholderType_FloatVector {
+		return nil
+	}
+	vectors := make([][]float32, 0, len(placeholder.GetValues()))
+	for _, value := range placeholder.GetValues() {
+		if len(value)%4 != 0 {
+			return nil
+		}
+		vector := make([]float32, len(value)/4)
+		for i := range vector {
+			vector[i] = math.Float32frombits(common.Endian.Uint32(value[i*4:]))
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors
+}
+
 func (pg *searchRequest) getNumOfQuery() int64 {
 	numQueries := C.GetNumOfQueries(pg.cPlaceholderGroup)
 	return int64(numQueries)
@@ -125,6 +211,14 @@ func (pg *searchRequest) delete() {
 type RetrievePlan struct {
 	cRetrievePlan C.CRetrievePlan
 	Timestamp     Timestamp
+	pks           []primaryKey        // primary keys to look up if the plan is `pk in [...]`, nil otherwise
+	partitionKeys *schemapb.FieldData // partition keys the matched rows take, nil if not constrained
+	sampleSize    int64               // number of the matched rows to sample, 0 if not sampled
+	sampleSeed    int64               // seed of the sampling, random if 0
+	limit         int64               // number of the top rows sorted by orderByField to return, 0 if not limited
+	orderByField  FieldID             // field to sort the rows by if limited
+	orderDesc     bool                // whether the rows are sorted in descending order
+	outputFields  []*collectionField  // current schema of the output fields the results are coerced to, nil if unknown
 }
 
 // func createRetrievePlan(col *Collection, msg *segcorepb.RetrieveRequest, timestamp uint64) (*RetrievePlan, error) {
@@ -154,10 +248,48 @@ func createRetrievePlanByExpr(col *Collection, expr []byte, timestamp Timestamp)
 	var newPlan = &RetrievePlan{
 		cRetrievePlan: cPlan,
 		Timestamp:     timestamp,
+		pks:           parseTermPKs(expr),
+		partitionKeys: parsePlanPartitionKeys(col, expr),
+		outputFields:  parsePlanOutputFields(col, expr),
 	}
+	newPlan.sampleSize, newPlan.sampleSeed = parsePlanSample(expr)
+	newPlan.limit, newPlan.orderByField, newPlan.orderDesc = parsePlanOrder(expr)
+	newPlan.setExpireTs(col.getExpireTs(timestamp))
 	return newPlan, nil
 }
 
+// parseTermPKs returns the primary keys if the serialized plan is an exact lookup
+// on primary keys, i.e. `pk in [...]`, nil otherwise
+func parseTermPKs(expr []byte) []primaryKey {
+	planNode := &planpb.PlanNode{}
+	if err := proto.Unmarshal(expr, planNode); err != nil {
+		return nil
+	}
+	termExpr := planNode.GetPredicates().GetTermExpr()
+	if termExpr == nil || !termExpr.GetColumnInfo().GetIsPrimaryKey() {
+		return nil
+	}
+	pks := make([]primaryKey, 0, len(termExpr.GetValues()))
+	switch termExpr.GetColumnInfo().GetDataType() {
+	case schemapb.DataType_Int64:
+		for _, value := range termExpr.GetValues() {
+			pks = append(pks, newInt64PrimaryKey(value.GetInt64Val()))
+		}
+	case schemapb.DataType_VarChar:
+		for _, value := range termExpr.GetValues() {
+			pks = append(pks, newVarCharPrimaryKey(value.GetStringVal()))
+		}
+	default:
+		return nil
+	}
+	return pks
+}
+
+// setExpireTs makes the rows inserted before expireTs invisible to the plan, 0 means rows never expire
+func (plan *RetrievePlan) setExpireTs(expireTs Timestamp) {
+	C.SetRetrievePlanExpireTs(plan.cRetrievePlan, C.uint64_t(expireTs))
+}
+
 func (plan *RetrievePlan) delete() {
 	C.DeleteRetrievePlan(plan.cRetrievePlan)
 }
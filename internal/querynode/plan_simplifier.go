@@ -0,0 +1,265 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// predicateValue is the value of a predicate known before evaluating it on any row
+type predicateValue int
+
+const (
+	predicateUnknown     predicateValue = iota // depends on the row
+	predicateAlwaysTrue                        // matches every row
+	predicateAlwaysFalse                       // matches no row
+)
+
+func (v predicateValue) not() predicateValue {
+	switch v {
+	case predicateAlwaysTrue:
+		return predicateAlwaysFalse
+	case predicateAlwaysFalse:
+		return predicateAlwaysTrue
+	}
+	return predicateUnknown
+}
+
+// simplifyPlan simplifies the predicates of the serialized plan before creating the segcore plan, see simplifyExpr.
+// It returns whether the predicates never match, in which case no segment needs to be searched or retrieved,
+// the plan is returned as is if nothing could be simplified
+func simplifyPlan(serializedPlan []byte) ([]byte, bool, error) {
+	plan := &planpb.PlanNode{}
+	if err := proto.Unmarshal(serializedPlan, plan); err != nil {
+		return nil, false, err
+	}
+
+	switch node := plan.GetNode().(type) {
+	case *planpb.PlanNode_VectorAnns:
+		if node.VectorAnns.GetPredicates() == nil {
+			return serializedPlan, false, nil
+		}
+		expr, value := simplifyExpr(node.VectorAnns.GetPredicates())
+		switch value {
+		case predicateAlwaysFalse:
+			return serializedPlan, true, nil
+		case predicateAlwaysTrue:
+			node.VectorAnns.Predicates = nil
+		default:
+			node.VectorAnns.Predicates = expr
+		}
+	case *planpb.PlanNode_Predicates:
+		expr, value := simplifyExpr(node.Predicates)
+		switch value {
+		case predicateAlwaysFalse:
+			return serializedPlan, true, nil
+		case predicateAlwaysTrue:
+			// segcore requires the predicates of retrieve plans, keep the original ones
+			return serializedPlan, false, nil
+		default:
+			node.Predicates = expr
+		}
+	default:
+		return serializedPlan, false, nil
+	}
+
+	simplified, err := proto.Marshal(plan)
+	if err != nil {
+		return nil, false, err
+	}
+	return simplified, false, nil
+}
+
+// simplifyExpr returns an expression equivalent to expr with the constant clauses folded and the duplicate
+// clauses of logical and/or removed, along with the value of expr if it's the same for every row, in which
+// case the returned expression should not be used. expr is kept intact.
+//
+// The rules are:
+//   - term expressions without values match no row
+//   - binary range expressions with an empty range match no row
+//   - comparing a column with itself is folded, except the equality of floating point columns because of NaN
+//   - not of a constant is folded, and double negation is removed
+//   - logical and/or are flattened, the constant operands are folded and the duplicate operands removed
+func simplifyExpr(expr *planpb.Expr) (*planpb.Expr, predicateValue) {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_TermExpr:
+		if len(e.TermExpr.GetValues()) == 0 {
+			return expr, predicateAlwaysFalse
+		}
+	case *planpb.Expr_BinaryRangeExpr:
+		if isEmptyRange(e.BinaryRangeExpr) {
+			return expr, predicateAlwaysFalse
+		}
+	case *planpb.Expr_CompareExpr:
+		return expr, simplifySelfCompare(e.CompareExpr)
+	case *planpb.Expr_UnaryExpr:
+		if e.UnaryExpr.GetOp() != planpb.UnaryExpr_Not {
+			break
+		}
+		child, value := simplifyExpr(e.UnaryExpr.GetChild())
+		if value != predicateUnknown {
+			return expr, value.not()
+		}
+		if grandChild, ok := child.GetExpr().(*planpb.Expr_UnaryExpr); ok && grandChild.UnaryExpr.GetOp() == planpb.UnaryExpr_Not {
+			return grandChild.UnaryExpr.GetChild(), predicateUnknown
+		}
+		return &planpb.Expr{
+			Expr: &planpb.Expr_UnaryExpr{
+				UnaryExpr: &planpb.UnaryExpr{Op: planpb.UnaryExpr_Not, Child: child},
+			},
+		}, predicateUnknown
+	case *planpb.Expr_BinaryExpr:
+		return simplifyLogicalExpr(expr, e.BinaryExpr.GetOp())
+	}
+	return expr, predicateUnknown
+}
+
+// simplifyLogicalExpr simplifies the logical and/or expr
+func simplifyLogicalExpr(expr *planpb.Expr, op planpb.BinaryExpr_BinaryOp) (*planpb.Expr, predicateValue) {
+	// the operand value deciding the result, and the one having no effect
+	absorbing, neutral := predicateAlwaysFalse, predicateAlwaysTrue
+	switch op {
+	case planpb.BinaryExpr_LogicalAnd:
+	case planpb.BinaryExpr_LogicalOr:
+		absorbing, neutral = predicateAlwaysTrue, predicateAlwaysFalse
+	default:
+		return expr, predicateUnknown
+	}
+
+	var operands []*planpb.Expr
+	for _, operand := range flattenLogicalExpr(expr, op, nil) {
+		simplified, value := simplifyExpr(operand)
+		switch value {
+		case absorbing:
+			return expr, absorbing
+		case neutral:
+			continue
+		}
+		// the simplified operand may be a chain of the same op, e.g. not not (a and b)
+		for _, o := range flattenLogicalExpr(simplified, op, nil) {
+			operands = appendDistinctExpr(operands, o)
+		}
+	}
+	if len(operands) == 0 {
+		return expr, neutral
+	}
+
+	simplified := operands[0]
+	for _, operand := range operands[1:] {
+		simplified = &planpb.Expr{
+			Expr: &planpb.Expr_BinaryExpr{
+				BinaryExpr: &planpb.BinaryExpr{Op: op, Left: simplified, Right: operand},
+			},
+		}
+	}
+	return simplified, predicateUnknown
+}
+
+// flattenLogicalExpr appends the operands of the chain of op rooted at expr to operands, from left to right
+func flattenLogicalExpr(expr *planpb.Expr, op planpb.BinaryExpr_BinaryOp, operands []*planpb.Expr) []*planpb.Expr {
+	if e, ok := expr.GetExpr().(*planpb.Expr_BinaryExpr); ok && e.BinaryExpr.GetOp() == op {
+		operands = flattenLogicalExpr(e.BinaryExpr.GetLeft(), op, operands)
+		return flattenLogicalExpr(e.BinaryExpr.GetRight(), op, operands)
+	}
+	return append(operands, expr)
+}
+
+// appendDistinctExpr appends expr to exprs unless an equal one is in exprs already
+func appendDistinctExpr(exprs []*planpb.Expr, expr *planpb.Expr) []*planpb.Expr {
+	for _, e := range exprs {
+		if proto.Equal(e, expr) {
+			return exprs
+		}
+	}
+	return append(exprs, expr)
+}
+
+// simplifySelfCompare returns the value of comparing a column with itself, unknown for different columns
+func simplifySelfCompare(expr *planpb.CompareExpr) predicateValue {
+	if expr.GetLeftColumnInfo().GetFieldId() != expr.GetRightColumnInfo().GetFieldId() {
+		return predicateUnknown
+	}
+	switch expr.GetOp() {
+	case planpb.OpType_GreaterThan, planpb.OpType_LessThan:
+		// false even for NaN
+		return predicateAlwaysFalse
+	}
+	switch expr.GetLeftColumnInfo().GetDataType() {
+	case schemapb.DataType_Float, schemapb.DataType_Double:
+		// NaN is not equal to itself
+		return predicateUnknown
+	}
+	switch expr.GetOp() {
+	case planpb.OpType_Equal, planpb.OpType_GreaterEqual, planpb.OpType_LessEqual:
+		return predicateAlwaysTrue
+	case planpb.OpType_NotEqual:
+		return predicateAlwaysFalse
+	}
+	return predicateUnknown
+}
+
+// isEmptyRange returns whether no value is in the range of expr
+func isEmptyRange(expr *planpb.BinaryRangeExpr) bool {
+	cmp, ok := compareGenericValue(expr.GetLowerValue(), expr.GetUpperValue())
+	if !ok {
+		return false
+	}
+	return cmp > 0 || (cmp == 0 && !(expr.GetLowerInclusive() && expr.GetUpperInclusive()))
+}
+
+// compareGenericValue compares a with b, returns false if they are not of the same type
+func compareGenericValue(a, b *planpb.GenericValue) (int, bool) {
+	switch av := a.GetVal().(type) {
+	case *planpb.GenericValue_Int64Val:
+		bv, ok := b.GetVal().(*planpb.GenericValue_Int64Val)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case av.Int64Val < bv.Int64Val:
+			return -1, true
+		case av.Int64Val > bv.Int64Val:
+			return 1, true
+		}
+		return 0, true
+	case *planpb.GenericValue_FloatVal:
+		bv, ok := b.GetVal().(*planpb.GenericValue_FloatVal)
+		// NaN bounds are not comparable
+		if !ok || av.FloatVal != av.FloatVal || bv.FloatVal != bv.FloatVal {
+			return 0, false
+		}
+		switch {
+		case av.FloatVal < bv.FloatVal:
+			return -1, true
+		case av.FloatVal > bv.FloatVal:
+			return 1, true
+		}
+		return 0, true
+	case *planpb.GenericValue_StringVal:
+		bv, ok := b.GetVal().(*planpb.GenericValue_StringVal)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(av.StringVal, bv.StringVal), true
+	}
+	return 0, false
+}
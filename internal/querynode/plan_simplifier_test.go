@@ -0,0 +1,353 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/parser/planparser"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+func TestSimplifyExpr(t *testing.T) {
+	schema, err := typeutil.CreateSchemaHelper(genSimpleSegCoreSchema())
+	require.NoError(t, err)
+	parse := func(exprStr string) *planpb.Expr {
+		expr, err := planparser.ParseExpr(schema, exprStr)
+		require.NoError(t, err, exprStr)
+		return expr
+	}
+
+	cases := []struct {
+		expr       string
+		simplified string
+		value      predicateValue
+	}{
+		{"pk > 1", "pk > 1", predicateUnknown},
+		{"pk > 1 and pk > 1", "pk > 1", predicateUnknown},
+		{"pk > 1 or const < 2 or pk > 1", "pk > 1 or const < 2", predicateUnknown},
+		{"(pk > 1 and const > 1) and (const > 1 and pk > 1)", "pk > 1 and const > 1", predicateUnknown},
+		{"pk in [] and const > 1", "", predicateAlwaysFalse},
+		{"pk in [] or const > 1", "const > 1", predicateUnknown},
+		{"pk in [] or const in []", "", predicateAlwaysFalse},
+		{"not (pk in [])", "", predicateAlwaysTrue},
+		{"not (pk in []) and const > 1", "const > 1", predicateUnknown},
+		{"not (pk in []) or const > 1", "", predicateAlwaysTrue},
+		{"not (not (const > 1))", "const > 1", predicateUnknown},
+		{"not (not (pk > 1 and const > 1)) and pk > 1", "pk > 1 and const > 1", predicateUnknown},
+		{"not (const > 1 and const > 1)", "not (const > 1)", predicateUnknown},
+		{"5 < pk < 1", "", predicateAlwaysFalse},
+		{"1 < pk <= 1", "", predicateAlwaysFalse},
+		{"1 <= pk <= 1", "1 <= pk <= 1", predicateUnknown},
+		{"pk < pk", "", predicateAlwaysFalse},
+		{"pk != pk or const > 1", "const > 1", predicateUnknown},
+		{"pk == pk and const > 1", "const > 1", predicateUnknown},
+		{"pk >= pk", "", predicateAlwaysTrue},
+		{"pk == const", "pk == const", predicateUnknown},
+	}
+	for _, c := range cases {
+		expr := parse(c.expr)
+		original := proto.Clone(expr)
+		simplified, value := simplifyExpr(expr)
+		assert.Equal(t, c.value, value, c.expr)
+		if c.value == predicateUnknown {
+			assert.True(t, proto.Equal(parse(c.simplified), simplified), "%s: %s", c.expr, proto.MarshalTextString(simplified))
+		}
+		// the original expression is kept intact
+		assert.True(t, proto.Equal(original, expr), c.expr)
+	}
+
+	t.Run("floating point", func(t *testing.T) {
+		selfCompare := func(op planpb.OpType) *planpb.Expr {
+			column := &planpb.ColumnInfo{FieldId: 105, DataType: schemapb.DataType_Double}
+			return &planpb.Expr{
+				Expr: &planpb.Expr_CompareExpr{
+					CompareExpr: &planpb.CompareExpr{LeftColumnInfo: column, RightColumnInfo: column, Op: op},
+				},
+			}
+		}
+		// NaN is not equal to itself
+		for _, op := range []planpb.OpType{planpb.OpType_Equal, planpb.OpType_NotEqual, planpb.OpType_GreaterEqual, planpb.OpType_LessEqual} {
+			_, value := simplifyExpr(selfCompare(op))
+			assert.Equal(t, predicateUnknown, value, op.String())
+		}
+		for _, op := range []planpb.OpType{planpb.OpType_GreaterThan, planpb.OpType_LessThan} {
+			_, value := simplifyExpr(selfCompare(op))
+			assert.Equal(t, predicateAlwaysFalse, value, op.String())
+		}
+
+		nanRange := &planpb.Expr{
+			Expr: &planpb.Expr_BinaryRangeExpr{
+				BinaryRangeExpr: &planpb.BinaryRangeExpr{
+					ColumnInfo: &planpb.ColumnInfo{FieldId: 105, DataType: schemapb.DataType_Double},
+					LowerValue: &planpb.GenericValue{Val: &planpb.GenericValue_FloatVal{FloatVal: math.NaN()}},
+					UpperValue: &planpb.GenericValue{Val: &planpb.GenericValue_FloatVal{FloatVal: 1.0}},
+				},
+			},
+		}
+		_, value := simplifyExpr(nanRange)
+		assert.Equal(t, predicateUnknown, value)
+	})
+}
+
+func TestSimplifyPlan(t *testing.T) {
+	schema := genSimpleSegCoreSchema()
+	queryInfo := &planpb.QueryInfo{Topk: defaultTopK, MetricType: defaultMetricType, SearchParams: `{"nprobe": 10}`}
+	simplify := func(plan *planpb.PlanNode) (*planpb.PlanNode, bool) {
+		serialized, err := proto.Marshal(plan)
+		require.NoError(t, err)
+		simplified, neverMatch, err := simplifyPlan(serialized)
+		require.NoError(t, err)
+		simplifiedPlan := &planpb.PlanNode{}
+		require.NoError(t, proto.Unmarshal(simplified, simplifiedPlan))
+		return simplifiedPlan, neverMatch
+	}
+
+	t.Run("search", func(t *testing.T) {
+		plan, err := planparser.CreateQueryPlan(schema, "pk > 1 and pk > 1", defaultVecFieldName, queryInfo)
+		require.NoError(t, err)
+		simplified, neverMatch := simplify(plan)
+		assert.False(t, neverMatch)
+		assert.True(t, proto.Equal(plan.GetVectorAnns().GetPredicates().GetBinaryExpr().GetLeft(), simplified.GetVectorAnns().GetPredicates()))
+		assert.True(t, proto.Equal(plan.GetVectorAnns().GetQueryInfo(), simplified.GetVectorAnns().GetQueryInfo()))
+
+		// the predicates matching every row are removed
+		plan, err = planparser.CreateQueryPlan(schema, "not (pk in [])", defaultVecFieldName, queryInfo)
+		require.NoError(t, err)
+		simplified, neverMatch = simplify(plan)
+		assert.False(t, neverMatch)
+		assert.Nil(t, simplified.GetVectorAnns().GetPredicates())
+
+		plan, err = planparser.CreateQueryPlan(schema, "pk in [] and const > 1", defaultVecFieldName, queryInfo)
+		require.NoError(t, err)
+		_, neverMatch = simplify(plan)
+		assert.True(t, neverMatch)
+
+		plan, err = planparser.CreateQueryPlan(schema, "", defaultVecFieldName, queryInfo)
+		require.NoError(t, err)
+		simplified, neverMatch = simplify(plan)
+		assert.False(t, neverMatch)
+		assert.True(t, proto.Equal(plan, simplified))
+	})
+
+	t.Run("retrieve", func(t *testing.T) {
+		plan, err := planparser.CreateExprPlan(schema, "pk in [1, 2] or pk in [1, 2]")
+		require.NoError(t, err)
+		plan.OutputFieldIds = []int64{simplePKField.id}
+		simplified, neverMatch := simplify(plan)
+		assert.False(t, neverMatch)
+		assert.NotNil(t, simplified.GetPredicates().GetTermExpr())
+		assert.Equal(t, plan.GetOutputFieldIds(), simplified.GetOutputFieldIds())
+
+		// segcore requires the predicates of retrieve plans
+		plan, err = planparser.CreateExprPlan(schema, "not (pk in [])")
+		require.NoError(t, err)
+		simplified, neverMatch = simplify(plan)
+		assert.False(t, neverMatch)
+		assert.True(t, proto.Equal(plan, simplified))
+
+		plan, err = planparser.CreateExprPlan(schema, "pk in []")
+		require.NoError(t, err)
+		_, neverMatch = simplify(plan)
+		assert.True(t, neverMatch)
+	})
+
+	t.Run("invalid plan", func(t *testing.T) {
+		_, _, err := simplifyPlan([]byte("invalid plan"))
+		assert.Error(t, err)
+	})
+}
+
+// randomExprGenerator generates random predicates on the pk and const fields, with values
+// around the bounds of the rows in the simple segment, and duplicate clauses on purpose
+type randomExprGenerator struct {
+	r *rand.Rand
+}
+
+func (g *randomExprGenerator) column() *planpb.ColumnInfo {
+	if g.r.Intn(2) == 0 {
+		return &planpb.ColumnInfo{FieldId: simplePKField.id, DataType: simplePKField.dataType, IsPrimaryKey: true}
+	}
+	return &planpb.ColumnInfo{FieldId: simpleConstField.id, DataType: simpleConstField.dataType}
+}
+
+func (g *randomExprGenerator) value() *planpb.GenericValue {
+	values := []int64{-1, 0, 1, 2, 50, 98, 99, 100}
+	return &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: values[g.r.Intn(len(values))]}}
+}
+
+func (g *randomExprGenerator) op() planpb.OpType {
+	return planpb.OpType(1 + g.r.Intn(6))
+}
+
+func (g *randomExprGenerator) leaf() *planpb.Expr {
+	switch g.r.Intn(4) {
+	case 0:
+		term := &planpb.TermExpr{ColumnInfo: g.column()}
+		for i := g.r.Intn(4); i > 0; i-- {
+			term.Values = append(term.Values, g.value())
+		}
+		return &planpb.Expr{Expr: &planpb.Expr_TermExpr{TermExpr: term}}
+	case 1:
+		return &planpb.Expr{
+			Expr: &planpb.Expr_UnaryRangeExpr{
+				UnaryRangeExpr: &planpb.UnaryRangeExpr{ColumnInfo: g.column(), Op: g.op(), Value: g.value()},
+			},
+		}
+	case 2:
+		return &planpb.Expr{
+			Expr: &planpb.Expr_BinaryRangeExpr{
+				BinaryRangeExpr: &planpb.BinaryRangeExpr{
+					ColumnInfo:     g.column(),
+					LowerInclusive: g.r.Intn(2) == 0,
+					UpperInclusive: g.r.Intn(2) == 0,
+					LowerValue:     g.value(),
+					UpperValue:     g.value(),
+				},
+			},
+		}
+	default:
+		column := g.column()
+		return &planpb.Expr{
+			Expr: &planpb.Expr_CompareExpr{
+				CompareExpr: &planpb.CompareExpr{LeftColumnInfo: column, RightColumnInfo: column, Op: g.op()},
+			},
+		}
+	}
+}
+
+func (g *randomExprGenerator) expr(depth int) *planpb.Expr {
+	if depth == 0 || g.r.Intn(4) == 0 {
+		return g.leaf()
+	}
+	if g.r.Intn(4) == 0 {
+		return &planpb.Expr{
+			Expr: &planpb.Expr_UnaryExpr{
+				UnaryExpr: &planpb.UnaryExpr{Op: planpb.UnaryExpr_Not, Child: g.expr(depth - 1)},
+			},
+		}
+	}
+	op := planpb.BinaryExpr_LogicalAnd
+	if g.r.Intn(2) == 0 {
+		op = planpb.BinaryExpr_LogicalOr
+	}
+	left := g.expr(depth - 1)
+	right := g.expr(depth - 1)
+	if g.r.Intn(3) == 0 {
+		right = proto.Clone(left).(*planpb.Expr)
+	}
+	return &planpb.Expr{
+		Expr: &planpb.Expr_BinaryExpr{
+			BinaryExpr: &planpb.BinaryExpr{Op: op, Left: left, Right: right},
+		},
+	}
+}
+
+// TestSimplifyPlan_semantics checks that the simplified plans retrieve the same rows as the original ones
+func TestSimplifyPlan_semantics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	collection, err := node.historical.replica.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+	segment, err := node.historical.replica.getSegmentByID(defaultSegmentID)
+	require.NoError(t, err)
+
+	retrieve := func(serializedPlan []byte) []int64 {
+		plan, err := createRetrievePlanByExpr(collection, serializedPlan, typeutil.MaxTimestamp)
+		require.NoError(t, err)
+		defer plan.delete()
+		result, err := segment.retrieve(plan)
+		require.NoError(t, err)
+		ids := result.GetIds().GetIntId().GetData()
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		return ids
+	}
+
+	g := &randomExprGenerator{r: rand.New(rand.NewSource(20221016))}
+	var simplifiedCount, constantCount int
+	for i := 0; i < 300; i++ {
+		expr := g.expr(4)
+		serialized, err := proto.Marshal(&planpb.PlanNode{
+			Node:           &planpb.PlanNode_Predicates{Predicates: expr},
+			OutputFieldIds: []int64{simplePKField.id},
+		})
+		require.NoError(t, err)
+
+		expected := retrieve(serialized)
+		simplified, neverMatch, err := simplifyPlan(serialized)
+		require.NoError(t, err)
+		if neverMatch {
+			assert.Empty(t, expected, proto.MarshalTextString(expr))
+			constantCount++
+			continue
+		}
+		if _, value := simplifyExpr(expr); value == predicateAlwaysTrue {
+			assert.Len(t, expected, defaultMsgLength, proto.MarshalTextString(expr))
+			constantCount++
+		}
+		if len(simplified) < len(serialized) {
+			simplifiedCount++
+		}
+		assert.Equal(t, expected, retrieve(simplified), proto.MarshalTextString(expr))
+	}
+	// the random predicates exercise the rules
+	assert.Greater(t, simplifiedCount, 0)
+	assert.Greater(t, constantCount, 0)
+}
+
+func TestQueryShard_neverMatch(t *testing.T) {
+	qs, err := genSimpleQueryShard(context.Background())
+	require.NoError(t, err)
+
+	plan, err := planparser.CreateExprPlan(genSimpleSegCoreSchema(), "pk in [] and pk > 1")
+	require.NoError(t, err)
+	plan.OutputFieldIds = []int64{simplePKField.id}
+	expr, err := proto.Marshal(plan)
+	require.NoError(t, err)
+	req, err := genSimpleRetrieveRequest()
+	require.NoError(t, err)
+	req.SerializedExprPlan = expr
+
+	// no segment is retrieved, neither the shard cluster of the unknown channel
+	resp, err := qs.query(context.Background(), &querypb.QueryRequest{
+		Req:        req,
+		DmlChannel: "unknown-channel",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetIds().GetIntId().GetData())
+
+	updateDynamicConfig(qs.config, func(dynamic *DynamicQueryNodeConfig) { dynamic.SimplifyPredicates = false })
+	_, err = qs.query(context.Background(), &querypb.QueryRequest{
+		Req:        req,
+		DmlChannel: "unknown-channel",
+	})
+	assert.Error(t, err)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
 )
 
 func TestPlan_Plan(t *testing.T) {
@@ -69,6 +70,47 @@ func TestPlan_createSearchPlanByExpr(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestPlan_parseTermPKs(t *testing.T) {
+	genTermExpr := func(isPrimaryKey bool, dataType schemapb.DataType, values ...*planpb.GenericValue) []byte {
+		planNode := &planpb.PlanNode{
+			Node: &planpb.PlanNode_Predicates{
+				Predicates: &planpb.Expr{
+					Expr: &planpb.Expr_TermExpr{
+						TermExpr: &planpb.TermExpr{
+							ColumnInfo: &planpb.ColumnInfo{
+								FieldId:      simplePKField.id,
+								DataType:     dataType,
+								IsPrimaryKey: isPrimaryKey,
+							},
+							Values: values,
+						},
+					},
+				},
+			},
+		}
+		expr, err := proto.Marshal(planNode)
+		assert.NoError(t, err)
+		return expr
+	}
+	int64Val := func(v int64) *planpb.GenericValue {
+		return &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: v}}
+	}
+	stringVal := func(v string) *planpb.GenericValue {
+		return &planpb.GenericValue{Val: &planpb.GenericValue_StringVal{StringVal: v}}
+	}
+
+	pks := parseTermPKs(genTermExpr(true, schemapb.DataType_Int64, int64Val(1), int64Val(2)))
+	assert.Equal(t, []primaryKey{newInt64PrimaryKey(1), newInt64PrimaryKey(2)}, pks)
+
+	pks = parseTermPKs(genTermExpr(true, schemapb.DataType_VarChar, stringVal("a")))
+	assert.Equal(t, []primaryKey{newVarCharPrimaryKey("a")}, pks)
+
+	// not a pk lookup
+	assert.Nil(t, parseTermPKs(genTermExpr(false, schemapb.DataType_Int64, int64Val(1))))
+	assert.Nil(t, parseTermPKs(genTermExpr(true, schemapb.DataType_Float)))
+	assert.Nil(t, parseTermPKs([]byte("invalid plan")))
+}
+
 func TestPlan_NilCollection(t *testing.T) {
 	collection := &Collection{
 		id: defaultCollectionID,
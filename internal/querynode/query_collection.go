@@ -1004,15 +1004,27 @@ func (q *queryCollection) search(msg queryMsg) error {
 	if err != nil {
 		return err
 	}
+	if err := collection.checkSearchable(); err != nil {
+		return err
+	}
+	if err := collection.getFieldMask().checkOutputFields(collectionID, searchMsg.GetOutputFieldsId()); err != nil {
+		return err
+	}
 
 	var plan *SearchPlan
 	if searchMsg.GetDslType() == commonpb.DslType_BoolExprV1 {
-		expr := searchMsg.SerializedExprPlan
+		expr, err := applyMandatoryFilter(collection, searchMsg.SerializedExprPlan, searchMsg.GetMandatoryFilterPlan())
+		if err != nil {
+			return err
+		}
 		plan, err = createSearchPlanByExpr(collection, expr)
 		if err != nil {
 			return err
 		}
 	} else {
+		if len(searchMsg.GetMandatoryFilterPlan()) > 0 {
+			return errors.New("mandatory filter is not supported by dsl search")
+		}
 		dsl := searchMsg.Dsl
 		plan, err = createSearchPlan(collection, dsl)
 		if err != nil {
@@ -1021,14 +1033,16 @@ func (q *queryCollection) search(msg queryMsg) error {
 	}
 
 	defer plan.delete()
+	plan.setExpireTs(collection.getExpireTs(travelTimestamp))
 
-	topK := plan.getTopK()
-	if topK == 0 {
-		return fmt.Errorf("limit must be greater than 0, msgID = %d", searchMsg.ID())
+	annsFieldID, queryInfo, err := searchQueryInfo(&searchMsg.SearchRequest, plan)
+	if err != nil {
+		return err
 	}
-	if topK >= 16385 {
-		return fmt.Errorf("limit %d is too large, msgID = %d", topK, searchMsg.ID())
+	if _, err := validateSearchRequest(collection, annsFieldID, queryInfo, searchMsg.PlaceholderGroup, searchMsg.OutputFieldsId); err != nil {
+		return fmt.Errorf("%w, msgID = %d", err, searchMsg.ID())
 	}
+	topK := plan.getTopK()
 	searchRequestBlob := searchMsg.PlaceholderGroup
 	searchReq, err := parseSearchRequest(plan, searchRequestBlob)
 	if err != nil {
@@ -1066,7 +1080,7 @@ func (q *queryCollection) search(msg queryMsg) error {
 	}()
 	// historical search
 	log.Debug("historical search start", zap.Int64("msgID", searchMsg.ID()))
-	hisSearchResults, sealedSegmentSearched, sealedPartitionSearched, err := q.historical.search(searchRequests, collection.id, searchMsg.PartitionIDs, plan, travelTimestamp)
+	hisSearchResults, sealedSegmentSearched, sealedPartitionSearched, err := q.historical.search(ctx, searchRequests, collection.id, searchMsg.PartitionIDs, plan, travelTimestamp)
 	if err != nil {
 		return err
 	}
@@ -1076,7 +1090,7 @@ func (q *queryCollection) search(msg queryMsg) error {
 
 	log.Debug("streaming search start", zap.Int64("msgID", searchMsg.ID()))
 	for _, channel := range collection.getVChannels() {
-		strSearchResults, growingSegmentSearched, growingPartitionSearched, err := q.streaming.search(searchRequests, collection.id, searchMsg.PartitionIDs, channel, plan, travelTimestamp)
+		strSearchResults, growingSegmentSearched, growingPartitionSearched, err := q.streaming.search(ctx, searchRequests, collection.id, searchMsg.PartitionIDs, channel, plan, travelTimestamp)
 		if err != nil {
 			return err
 		}
@@ -1236,8 +1250,14 @@ func (q *queryCollection) retrieve(msg queryMsg) error {
 	if err != nil {
 		return err
 	}
+	if err := collection.getFieldMask().checkOutputFields(collectionID, retrieveMsg.GetOutputFieldsId()); err != nil {
+		return err
+	}
 
-	expr := retrieveMsg.SerializedExprPlan
+	expr, err := applyMandatoryFilter(collection, retrieveMsg.SerializedExprPlan, retrieveMsg.GetMandatoryFilterPlan())
+	if err != nil {
+		return err
+	}
 	plan, err := createRetrievePlanByExpr(collection, expr, timestamp)
 	if err != nil {
 		return err
@@ -1265,11 +1285,12 @@ func (q *queryCollection) retrieve(msg queryMsg) error {
 		q.vectorChunkManager, err = storage.NewVectorChunkManager(q.localChunkManager, q.remoteChunkManager,
 			&etcdpb.CollectionMeta{
 				ID:     collection.id,
-				Schema: collection.schema,
+				Schema: collection.Schema(),
 			}, Params.QueryNodeCfg.CacheMemoryLimit, Params.QueryNodeCfg.CacheEnabled)
 		if err != nil {
 			return err
 		}
+		q.vectorChunkManager.SetEvictionListener(onDiskFileEvicted)
 	}
 
 	// historical retrieve
@@ -1292,7 +1313,7 @@ func (q *queryCollection) retrieve(msg queryMsg) error {
 	log.Debug("streaming retrieve", zap.Int64("msgID", retrieveMsg.ID()), zap.Int64("collectionID", collectionID), zap.Int64s("retrieve partitionIDs", streamingPartitionRetrived), zap.Int64s("retrieve segmentIDs", streamingSegmentRetrived))
 	tr.Record(fmt.Sprintf("streaming retrieve done, msgID = %d", retrieveMsg.ID()))
 
-	result, err := mergeRetrieveResults(mergeList)
+	result, err := mergeSegmentRetrieveResults(plan, mergeList)
 	if err != nil {
 		return err
 	}
@@ -1312,6 +1333,7 @@ func (q *queryCollection) retrieve(msg queryMsg) error {
 			Status:                    &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
 			Ids:                       result.Ids,
 			FieldsData:                result.FieldsData,
+			MatchedCount:              result.MatchedCount,
 			ResultChannelID:           retrieveMsg.ResultChannelID,
 			SealedSegmentIDsRetrieved: sealedSegmentRetrieved,
 			ChannelIDsRetrieved:       collection.getVChannels(),
@@ -1394,7 +1416,19 @@ func (q *queryCollection) publishSearchResultWithCtx(ctx context.Context, result
 	return q.sessionManager.SendSearchResult(ctx, nodeID, result)
 }
 
+// getFieldMask returns the masked fields of the collection, which are masked in the results published
+func (q *queryCollection) getFieldMask() fieldMask {
+	collection, err := q.streaming.replica.getCollectionByID(q.collectionID)
+	if err != nil {
+		return fieldMask{}
+	}
+	return collection.getFieldMask()
+}
+
 func (q *queryCollection) publishSearchResult(result *internalpb.SearchResults, nodeID UniqueID) error {
+	if err := q.getFieldMask().maskSearchResults(result); err != nil {
+		return err
+	}
 	metrics.QueryNodeSQCount.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), metrics.SearchLabel, metrics.TotalLabel).Inc()
 	return q.publishSearchResultWithCtx(q.releaseCtx, result, nodeID)
 }
@@ -1404,6 +1438,9 @@ func (q *queryCollection) publishRetrieveResultWithCtx(ctx context.Context, resu
 }
 
 func (q *queryCollection) publishRetrieveResult(result *internalpb.RetrieveResults, nodeID UniqueID) error {
+	if err := q.getFieldMask().maskFieldsData(result.FieldsData); err != nil {
+		return err
+	}
 	metrics.QueryNodeSQCount.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), metrics.QueryLabel, metrics.TotalLabel).Inc()
 	return q.publishRetrieveResultWithCtx(q.releaseCtx, result, nodeID)
 }
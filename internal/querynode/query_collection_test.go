@@ -130,9 +130,10 @@ func TestQueryCollection_withoutVChannel(t *testing.T) {
 	etcdKV := etcdkv.NewEtcdKV(etcdCli, Params.EtcdCfg.MetaRootPath)
 
 	schema := genTestCollectionSchema(0, false, 2)
-	historicalReplica := newCollectionReplica(etcdKV)
+	collections := newCollectionRegistry()
+	historicalReplica := newCollectionReplica(etcdKV, newQueryNodeConfig(), collections)
 	tsReplica := newTSafeReplica()
-	streamingReplica := newCollectionReplica(etcdKV)
+	streamingReplica := newCollectionReplica(etcdKV, newQueryNodeConfig(), collections)
 	historical := newHistorical(context.Background(), historicalReplica, tsReplica)
 
 	//add a segment to historical data
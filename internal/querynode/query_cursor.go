@@ -0,0 +1,155 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// digestSegmentIDs returns the digest of the set of segmentIDs, whatever the order of them is
+func digestSegmentIDs(segmentIDs []UniqueID) uint64 {
+	sorted := make([]UniqueID, len(segmentIDs))
+	copy(sorted, segmentIDs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	h := fnv.New64a()
+	buf := make([]byte, 8)
+	for _, segmentID := range sorted {
+		binary.LittleEndian.PutUint64(buf, uint64(segmentID))
+		h.Write(buf)
+	}
+	return h.Sum64()
+}
+
+// applyQueryCursor ANDs the predicate seeking after the last primary key of cursor into the predicates of the
+// serialized plan. The plan must be ordered by the primary key with limit, so that the rows after the last primary key
+// are exactly the rows of the following pages, and the rows of the former pages are filtered out before sorting
+func applyQueryCursor(collection *Collection, serializedPlan []byte, cursor *internalpb.QueryCursor) ([]byte, error) {
+	pkField, err := collection.getPKField()
+	if err != nil {
+		return nil, err
+	}
+	plan := &planpb.PlanNode{}
+	if err := proto.Unmarshal(serializedPlan, plan); err != nil {
+		return nil, err
+	}
+	if plan.GetLimit() <= 0 || plan.GetOrderByFieldId() != pkField.schema.GetFieldID() {
+		return nil, errors.New("query cursor requires the plan ordered by primary key with limit")
+	}
+	node, ok := plan.GetNode().(*planpb.PlanNode_Predicates)
+	if !ok {
+		return nil, fmt.Errorf("unsupported plan node %T to apply query cursor", plan.GetNode())
+	}
+
+	pkType := pkField.schema.GetDataType()
+	var value *planpb.GenericValue
+	switch ids := cursor.GetLastPk().GetIdField().(type) {
+	case *schemapb.IDs_IntId:
+		if pkType != schemapb.DataType_Int64 || len(ids.IntId.GetData()) != 1 {
+			return nil, fmt.Errorf("invalid last primary key of query cursor, pk type %s, %d int keys", pkType, len(ids.IntId.GetData()))
+		}
+		value = &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: ids.IntId.GetData()[0]}}
+	case *schemapb.IDs_StrId:
+		if pkType != schemapb.DataType_VarChar || len(ids.StrId.GetData()) != 1 {
+			return nil, fmt.Errorf("invalid last primary key of query cursor, pk type %s, %d string keys", pkType, len(ids.StrId.GetData()))
+		}
+		value = &planpb.GenericValue{Val: &planpb.GenericValue_StringVal{StringVal: ids.StrId.GetData()[0]}}
+	default:
+		return nil, errors.New("invalid query cursor without last primary key")
+	}
+
+	op := planpb.OpType_GreaterThan
+	if plan.GetOrderDesc() {
+		op = planpb.OpType_LessThan
+	}
+	seek := &planpb.Expr{
+		Expr: &planpb.Expr_UnaryRangeExpr{
+			UnaryRangeExpr: &planpb.UnaryRangeExpr{
+				ColumnInfo: &planpb.ColumnInfo{
+					FieldId:      pkField.schema.GetFieldID(),
+					DataType:     pkType,
+					IsPrimaryKey: true,
+				},
+				Op:    op,
+				Value: value,
+			},
+		},
+	}
+	node.Predicates = andExpr(node.Predicates, seek)
+	return proto.Marshal(plan)
+}
+
+// resumeQueryCursor checks the cursor of req against the snapshot and the distribution of the shard led by q,
+// and returns a copy of req whose plan seeks after the cursor, which is forwarded to the followers without the cursor.
+// cursorExpiredError is returned if the snapshot is out of retention or the distribution has changed
+func (q *queryShard) resumeQueryCursor(req *querypb.QueryRequest) (*querypb.QueryRequest, error) {
+	cursor := req.GetReq().GetCursor()
+	snapshotTs := cursor.GetSnapshotTimestamp()
+	if snapshotTs == 0 || snapshotTs != req.GetReq().GetSnapshotTimestamp() {
+		return nil, fmt.Errorf("query cursor should be resumed at its snapshot ts %d, but got %d",
+			snapshotTs, req.GetReq().GetSnapshotTimestamp())
+	}
+	if err := q.checkSnapshotTs(snapshotTs, true); err != nil {
+		var expired *snapshotTsExpiredError
+		if errors.As(err, &expired) {
+			return nil, &cursorExpiredError{channel: q.channel, reason: err.Error()}
+		}
+		return nil, err
+	}
+
+	cluster, ok := q.clusterService.getShardCluster(q.channel)
+	if !ok {
+		return nil, fmt.Errorf("channel %s leader is not here", q.channel)
+	}
+	digest := cluster.distributionDigest()
+	found := false
+	for _, distribution := range cursor.GetDistributions() {
+		if distribution.GetDmlChannel() != q.channel {
+			continue
+		}
+		if distribution.GetDigest() != digest {
+			return nil, &cursorExpiredError{channel: q.channel, reason: "the distribution of the shard has changed"}
+		}
+		found = true
+	}
+	if !found {
+		return nil, &cursorExpiredError{channel: q.channel, reason: "the shard is not read by the former pages"}
+	}
+
+	collection, err := q.streaming.replica.getCollectionByID(q.collectionID)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := applyQueryCursor(collection, req.GetReq().GetSerializedExprPlan(), cursor)
+	if err != nil {
+		return nil, err
+	}
+	resumed := proto.Clone(req).(*querypb.QueryRequest)
+	resumed.Req.SerializedExprPlan = plan
+	resumed.Req.Cursor = nil
+	return resumed, nil
+}
@@ -0,0 +1,255 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+// genCursorRetrieveExpr generates the plan returning the first limit rows of all the rows sorted by primary key
+func genCursorRetrieveExpr(t *testing.T, limit int64, desc bool) []byte {
+	expr, err := proto.Marshal(&planpb.PlanNode{
+		Node:           &planpb.PlanNode_Predicates{Predicates: genPKRangeExpr(0, 1<<40)},
+		OutputFieldIds: []int64{simplePKField.id},
+		Limit:          limit,
+		OrderByFieldId: simplePKField.id,
+		OrderDesc:      desc,
+	})
+	require.NoError(t, err)
+	return expr
+}
+
+func genInt64PKs(pks ...int64) *schemapb.IDs {
+	return &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}}
+}
+
+func TestDigestSegmentIDs(t *testing.T) {
+	assert.Equal(t, digestSegmentIDs([]int64{1, 2, 3}), digestSegmentIDs([]int64{3, 1, 2}))
+	assert.NotEqual(t, digestSegmentIDs([]int64{1, 2, 3}), digestSegmentIDs([]int64{1, 2}))
+	assert.NotEqual(t, digestSegmentIDs([]int64{1, 2, 3}), digestSegmentIDs([]int64{1, 2, 4}))
+	assert.Equal(t, digestSegmentIDs(nil), digestSegmentIDs([]int64{}))
+
+	// the input is left unsorted
+	segmentIDs := []int64{3, 1, 2}
+	digestSegmentIDs(segmentIDs)
+	assert.Equal(t, []int64{3, 1, 2}, segmentIDs)
+}
+
+func TestApplyQueryCursor(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+
+	for _, desc := range []bool{false, true} {
+		expr, err := applyQueryCursor(collection, genCursorRetrieveExpr(t, 10, desc), &internalpb.QueryCursor{LastPk: genInt64PKs(5)})
+		require.NoError(t, err)
+		plan := &planpb.PlanNode{}
+		require.NoError(t, proto.Unmarshal(expr, plan))
+		and := plan.GetPredicates().GetBinaryExpr()
+		require.NotNil(t, and)
+		assert.Equal(t, planpb.BinaryExpr_LogicalAnd, and.GetOp())
+		assert.True(t, proto.Equal(genPKRangeExpr(0, 1<<40), and.GetLeft()))
+		seek := and.GetRight().GetUnaryRangeExpr()
+		require.NotNil(t, seek)
+		assert.Equal(t, simplePKField.id, seek.GetColumnInfo().GetFieldId())
+		assert.Equal(t, int64(5), seek.GetValue().GetInt64Val())
+		if desc {
+			assert.Equal(t, planpb.OpType_LessThan, seek.GetOp())
+		} else {
+			assert.Equal(t, planpb.OpType_GreaterThan, seek.GetOp())
+		}
+		assert.Equal(t, int64(10), plan.GetLimit())
+	}
+
+	t.Run("not ordered by primary key", func(t *testing.T) {
+		cursor := &internalpb.QueryCursor{LastPk: genInt64PKs(5)}
+		_, err := applyQueryCursor(collection, genCursorRetrieveExpr(t, 0, false), cursor)
+		assert.Error(t, err)
+
+		expr, err := proto.Marshal(&planpb.PlanNode{
+			Node:           &planpb.PlanNode_Predicates{Predicates: genPKRangeExpr(0, 10)},
+			Limit:          10,
+			OrderByFieldId: simpleConstField.id,
+		})
+		require.NoError(t, err)
+		_, err = applyQueryCursor(collection, expr, cursor)
+		assert.Error(t, err)
+
+		_, err = applyQueryCursor(collection, []byte{1, 2, 3}, cursor)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid last primary key", func(t *testing.T) {
+		expr := genCursorRetrieveExpr(t, 10, false)
+		for _, pk := range []*schemapb.IDs{
+			nil,
+			genInt64PKs(),
+			genInt64PKs(1, 2),
+			{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: []string{"1"}}}},
+		} {
+			_, err := applyQueryCursor(collection, expr, &internalpb.QueryCursor{LastPk: pk})
+			assert.Error(t, err, pk.String())
+		}
+	})
+}
+
+func TestQueryCursor_pagination(t *testing.T) {
+	const (
+		total    = 10000
+		pageSize = 100
+	)
+	// the rows of the segments are not aligned to the pages
+	rowsOfSegments := []int{3334, 3333, 3333}
+	segments := make([]*Segment, 0, len(rowsOfSegments))
+	pkBase := int64(0)
+	for i, rows := range rowsOfSegments {
+		seg := genOrderedSealedSegment(t, UniqueID(i+1), pkBase, make([]int32, rows))
+		defer deleteSegment(seg)
+		segments = append(segments, seg)
+		pkBase += int64(rows)
+	}
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+
+	for _, desc := range []bool{false, true} {
+		seen := make(map[int64]bool, total)
+		pks := make([]int64, 0, total)
+		var cursor *internalpb.QueryCursor
+		for page := 0; ; page++ {
+			require.LessOrEqual(t, page, total/pageSize, "desc %v", desc)
+			expr := genCursorRetrieveExpr(t, pageSize, desc)
+			if cursor != nil {
+				var err error
+				expr, err = applyQueryCursor(collection, expr, cursor)
+				require.NoError(t, err)
+			}
+			plan, err := createRetrievePlanByExpr(collection, expr, Timestamp(total))
+			require.NoError(t, err)
+			results := make([]*segcorepb.RetrieveResults, 0, len(segments))
+			for _, seg := range segments {
+				result, err := seg.retrieve(plan)
+				require.NoError(t, err)
+				results = append(results, result)
+			}
+			merged, err := mergeSegmentRetrieveResults(plan, results)
+			plan.delete()
+			require.NoError(t, err)
+
+			ids := merged.GetIds().GetIntId().GetData()
+			for _, pk := range ids {
+				assert.False(t, seen[pk], "duplicate pk %d, desc %v", pk, desc)
+				seen[pk] = true
+			}
+			pks = append(pks, ids...)
+			if len(ids) < pageSize {
+				break
+			}
+			cursor = &internalpb.QueryCursor{LastPk: genInt64PKs(ids[len(ids)-1])}
+		}
+
+		assert.Len(t, pks, total, "desc %v", desc)
+		assert.True(t, sort.SliceIsSorted(pks, func(i, j int) bool { return (pks[i] < pks[j]) != desc }), "desc %v", desc)
+		for pk := int64(0); pk < total; pk++ {
+			assert.True(t, seen[pk], "missing pk %d, desc %v", pk, desc)
+		}
+	}
+}
+
+func TestQueryShard_resumeQueryCursor(t *testing.T) {
+	qs, err := genSimpleQueryShard(context.Background())
+	require.NoError(t, err)
+	now := tsoutil.ComposeTSByTime(time.Now(), 0)
+	qs.setServiceableTime(now, tsTypeDML)
+	qs.setServiceableTime(now, tsTypeDelta)
+	cluster, ok := qs.clusterService.getShardCluster(defaultDMLChannel)
+	require.True(t, ok)
+
+	// the shard leader reports the digest of its distribution
+	req, err := genSimpleRetrieveRequest()
+	require.NoError(t, err)
+	result, err := qs.query(context.Background(), &querypb.QueryRequest{Req: req, DmlChannel: defaultDMLChannel})
+	require.NoError(t, err)
+	assert.Equal(t, cluster.distributionDigest(), result.GetDistributionDigest())
+
+	cluster.updateSegment(segmentEvent{segmentID: 100, nodeID: 1, state: segmentStateLoaded})
+	genRequest := func(snapshotTs Timestamp, distributions ...*internalpb.ShardDistribution) *querypb.QueryRequest {
+		return &querypb.QueryRequest{
+			Req: &internalpb.RetrieveRequest{
+				CollectionID:       defaultCollectionID,
+				SerializedExprPlan: genCursorRetrieveExpr(t, 10, false),
+				SnapshotTimestamp:  snapshotTs,
+				Cursor: &internalpb.QueryCursor{
+					SnapshotTimestamp: snapshotTs,
+					LastPk:            genInt64PKs(5),
+					Distributions:     distributions,
+				},
+			},
+			DmlChannel: defaultDMLChannel,
+		}
+	}
+	current := &internalpb.ShardDistribution{DmlChannel: defaultDMLChannel, Digest: cluster.distributionDigest()}
+
+	t.Run("resumed", func(t *testing.T) {
+		req := genRequest(now, current)
+		resumed, err := qs.resumeQueryCursor(req)
+		require.NoError(t, err)
+		assert.Nil(t, resumed.GetReq().GetCursor())
+		assert.NotEqual(t, req.GetReq().GetSerializedExprPlan(), resumed.GetReq().GetSerializedExprPlan())
+		// the request is left intact
+		assert.NotNil(t, req.GetReq().GetCursor())
+	})
+
+	t.Run("snapshot mismatch", func(t *testing.T) {
+		req := genRequest(now, current)
+		req.Req.SnapshotTimestamp = now - 1
+		_, err := qs.resumeQueryCursor(req)
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrCursorExpired))
+	})
+
+	t.Run("snapshot out of retention", func(t *testing.T) {
+		_, err := qs.resumeQueryCursor(genRequest(100, current))
+		assert.True(t, errors.Is(err, ErrCursorExpired))
+	})
+
+	t.Run("shard not read", func(t *testing.T) {
+		_, err := qs.resumeQueryCursor(genRequest(now, &internalpb.ShardDistribution{DmlChannel: "other", Digest: current.GetDigest()}))
+		assert.True(t, errors.Is(err, ErrCursorExpired))
+	})
+
+	t.Run("segment released", func(t *testing.T) {
+		cluster.removeSegment(segmentEvent{segmentID: 100, nodeID: 1})
+		_, err := qs.resumeQueryCursor(genRequest(now, current))
+		assert.True(t, errors.Is(err, ErrCursorExpired))
+
+		_, err = qs.query(context.Background(), genRequest(now, current))
+		assert.Equal(t, commonpb.ErrorCode_CursorExpired, errorCodeOf(err))
+	})
+}
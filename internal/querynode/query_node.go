@@ -56,7 +56,9 @@ import (
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util"
+	"github.com/milvus-io/milvus/internal/util/bufferpool"
 	"github.com/milvus-io/milvus/internal/util/dependency"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
@@ -71,6 +73,9 @@ var _ types.QueryNodeComponent = (*QueryNode)(nil)
 
 var Params paramtable.ComponentParam
 
+// bufferPool pools the temporary byte buffers of search and insert, buffers are released once the request is done
+var bufferPool = bufferpool.NewPool()
+
 // QueryNode communicates with outside services and union all
 // services in querynode package.
 //
@@ -98,13 +103,19 @@ type QueryNode struct {
 
 	// dataSyncService
 	dataSyncService *dataSyncService
+	// versions of the watched dm channels, fencing the WatchDmChannels requests
+	dmChannelOwnership *channelOwnership
 
 	// internal services
 	//queryService *queryService
 	statsService *statsService
+	// pushes the stats of the loaded collections, nil if disabled
+	collectionStatsPublisher *collectionStatsPublisher
 
 	// segment loader
 	loader *segmentLoader
+	// persists the indexes served by the sealed segments, reported before the segments reload after a crash
+	indexManifests *indexManifestStore
 
 	// etcd client
 	etcdCli *clientv3.Client
@@ -120,10 +131,29 @@ type QueryNode struct {
 	cacheStorage  storage.ChunkManager
 	etcdKV        *etcdkv.EtcdKV
 
+	// fails the object storage accesses fast during storage outages, nil if disabled
+	storageBreaker *storageBreaker
+
 	// shard cluster service, handle shard leader functions
 	ShardClusterService *ShardClusterService
 	//shard query service, handles shard-level query & search
 	queryShardService *queryShardService
+
+	// in-flight search and query requests, reported in component states
+	readStats readTaskStats
+	// caps the concurrent search and query requests of each collection
+	readLimiter collectionReadLimiter
+	// logs the search and query requests slower than the threshold
+	slowReads slowReadLog
+
+	// version of the linked segcore library, checked at Init
+	segcoreVersion *segcoreVersion
+
+	// read-only debug shell over unix socket, nil if disabled
+	debugServer *debugServer
+
+	// validated configuration, loaded at Init
+	config *QueryNodeConfig
 }
 
 // NewQueryNode will return a QueryNode with abnormal state.
@@ -133,6 +163,7 @@ func NewQueryNode(ctx context.Context, factory dependency.Factory) *QueryNode {
 		queryNodeLoopCtx:    ctx1,
 		queryNodeLoopCancel: cancel,
 		factory:             factory,
+		dmChannelOwnership:  newChannelOwnership(),
 	}
 
 	node.scheduler = newTaskScheduler(ctx1)
@@ -189,6 +220,7 @@ func (node *QueryNode) InitSegcore() {
 	Params.CommonCfg.SimdType = C.GoString(cRealSimdType)
 	C.free(unsafe.Pointer(cRealSimdType))
 	C.free(unsafe.Pointer(cSimdType))
+	log.Info("segcore SIMD type chosen", zap.String("simdType", Params.CommonCfg.SimdType))
 
 	// override segcore index slice size
 	cIndexSliceSize := C.int64_t(Params.CommonCfg.IndexSliceSize)
@@ -273,9 +305,36 @@ func (node *QueryNode) handleSessionEvent(ctx context.Context, event *sessionuti
 func (node *QueryNode) Init() error {
 	var initError error = nil
 	node.initOnce.Do(func() {
+		// refuse to start with an incompatible segcore library before any other cgo call
+		segcoreVersion := getSegcoreVersion()
+		log.Info("QueryNode segcore version",
+			zap.String("version", segcoreVersion.version),
+			zap.Int64("abiVersion", segcoreVersion.abiVersion),
+			zap.Int64("expectedABIVersion", expectedSegcoreABIVersion),
+			zap.Strings("simdCapabilities", segcoreVersion.simdCapabilities))
+		if err := checkSegcoreVersion(segcoreVersion, Params.CommonCfg.SimdType); err != nil {
+			log.Error("QueryNode segcore self-check failed", zap.Error(err))
+			initError = err
+			return
+		}
+		node.segcoreVersion = segcoreVersion
+		bufferPool.SetPoison(Params.QueryNodeCfg.PoisonReleasedBuffers)
+
+		config, err := loadQueryNodeConfig(metricsinfo.GetMemoryCount())
+		if err != nil {
+			log.Error("QueryNode config validation failed", zap.Error(err))
+			initError = err
+			return
+		}
+		node.config = config
+		cgoSearchLimiter.setBounds(config.SearchConcurrencyMin, config.SearchConcurrencyMax)
+		cgoReadPool.setSize(config.CgoReadPoolSize)
+		cgoWritePool.setSize(config.CgoWritePoolSize)
+		node.readLimiter.setQueueLimit(config.CollectionReadQueueLimit)
+
 		//ctx := context.Background()
 		log.Debug("QueryNode session info", zap.String("metaPath", Params.EtcdCfg.MetaRootPath))
-		err := node.initSession()
+		err = node.initSession()
 		if err != nil {
 			log.Error("QueryNode init session failed", zap.Error(err))
 			initError = err
@@ -290,6 +349,17 @@ func (node *QueryNode) Init() error {
 			initError = err
 			return
 		}
+		if config.StorageBreakerFailureThreshold > 0 {
+			vectorStorage := node.vectorStorage
+			node.storageBreaker = newStorageBreaker(node.queryNodeLoopCtx,
+				config.StorageBreakerFailureThreshold,
+				config.StorageBreakerCoolDown,
+				func() error {
+					_, err := vectorStorage.Size(storageProbePath)
+					return err
+				})
+			node.vectorStorage = newBreakerChunkManager(vectorStorage, node.storageBreaker)
+		}
 
 		node.cacheStorage, err = node.factory.NewCacheStorageChunkManager(node.queryNodeLoopCtx)
 		if err != nil {
@@ -302,8 +372,10 @@ func (node *QueryNode) Init() error {
 		log.Debug("queryNode try to connect etcd success", zap.Any("MetaRootPath", Params.EtcdCfg.MetaRootPath))
 		node.tSafeReplica = newTSafeReplica()
 
-		streamingReplica := newCollectionReplica(node.etcdKV)
-		historicalReplica := newCollectionReplica(node.etcdKV)
+		// the replicas share the collections, so that they never diverge
+		collections := newCollectionRegistry()
+		streamingReplica := newCollectionReplica(node.etcdKV, config, collections)
+		historicalReplica := newCollectionReplica(node.etcdKV, config, collections)
 
 		node.historical = newHistorical(node.queryNodeLoopCtx,
 			historicalReplica,
@@ -321,7 +393,17 @@ func (node *QueryNode) Init() error {
 			node.streaming.replica,
 			node.etcdKV,
 			node.vectorStorage,
-			node.factory)
+			node.factory,
+			config)
+
+		// the indexes served before a crash are reported until the segments are loaded again, the manifests
+		// are kept under the node id for the local storage may be shared by the nodes on the same host
+		node.indexManifests = newIndexManifestStore(filepath.Join(Params.LocalStorageCfg.Path, indexManifestDirName,
+			strconv.FormatInt(Params.QueryNodeCfg.QueryNodeID, 10)))
+		if err := node.indexManifests.load(); err != nil {
+			log.Warn("QueryNode failed to load index manifests", zap.Error(err))
+		}
+		node.loader.indexManifests = node.indexManifests
 
 		// node.statsService = newStatsService(node.queryNodeLoopCtx, node.historical.replica, node.factory)
 		node.dataSyncService = newDataSyncService(node.queryNodeLoopCtx, streamingReplica, historicalReplica, node.tSafeReplica, node.factory)
@@ -371,7 +453,35 @@ func (node *QueryNode) Start() error {
 	// create shardClusterService for shardLeader functions.
 	node.ShardClusterService = newShardClusterService(node.etcdCli, node.session, node)
 	// create shard-level query service
-	node.queryShardService = newQueryShardService(node.queryNodeLoopCtx, node.historical, node.streaming, node.ShardClusterService, node.factory)
+	node.queryShardService = newQueryShardService(node.queryNodeLoopCtx, node.historical, node.streaming, node.ShardClusterService, node.factory, node.config)
+	// the indexed output fields of retrieve results are filled from the object storage
+	if node.storageBreaker != nil && node.queryShardService.remoteChunkManager != nil {
+		node.queryShardService.remoteChunkManager = newBreakerChunkManager(node.queryShardService.remoteChunkManager, node.storageBreaker)
+	}
+	// reap idle empty growing segments, keep the ones still tracked by shard leaders
+	node.streaming.startGrowingSegmentGC(node.ShardClusterService.hasSegment)
+	if node.config.SearchConcurrencyMax > 0 {
+		go cgoSearchLimiter.adjustLoop(node.queryNodeLoopCtx, node.config.SearchConcurrencyAdjustInterval)
+	}
+
+	// the debug shell is optional, failing to start it shall not fail the query node
+	if Params.QueryNodeCfg.DebugSocketPath != "" {
+		node.debugServer = newDebugServer(node, Params.QueryNodeCfg.DebugSocketPath)
+		if err := node.debugServer.start(); err != nil {
+			log.Warn("failed to start debug server", zap.String("socket", Params.QueryNodeCfg.DebugSocketPath), zap.Error(err))
+			node.debugServer = nil
+		}
+	}
+
+	// pushing the collection stats is optional, failing to start it shall not fail the query node
+	if interval := Params.QueryNodeCfg.CollectionStatsPublishInterval; interval > 0 {
+		node.collectionStatsPublisher = newCollectionStatsPublisher(node.queryNodeLoopCtx, node.historical.replica, node.streaming.replica,
+			node.tSafeReplica, &node.readStats, node.factory)
+		if err := node.collectionStatsPublisher.start(interval); err != nil {
+			log.Warn("failed to start collection stats publisher", zap.Error(err))
+			node.collectionStatsPublisher = nil
+		}
+	}
 
 	Params.QueryNodeCfg.CreatedTime = time.Now()
 	Params.QueryNodeCfg.UpdatedTime = time.Now()
@@ -385,12 +495,28 @@ func (node *QueryNode) Start() error {
 	return nil
 }
 
+// reloadConfig updates the dynamic config of the node from Params.QueryNodeCfg, the config is kept if the new
+// one is invalid
+func (node *QueryNode) reloadConfig() error {
+	if err := node.config.reload(metricsinfo.GetMemoryCount()); err != nil {
+		log.Warn("QueryNode failed to reload config", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 // Stop mainly stop QueryNode's query service, historical loop and streaming loop.
 func (node *QueryNode) Stop() error {
 	node.UpdateStateCode(internalpb.StateCode_Abnormal)
 	node.queryNodeLoopCancel()
 
 	// close services
+	if node.debugServer != nil {
+		node.debugServer.stop()
+	}
+	if node.collectionStatsPublisher != nil {
+		node.collectionStatsPublisher.close()
+	}
 	if node.dataSyncService != nil {
 		node.dataSyncService.close()
 	}
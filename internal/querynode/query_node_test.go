@@ -56,6 +56,8 @@ func setup() {
 	os.Setenv("QUERY_NODE_ID", "1")
 	Params.Init()
 	Params.EtcdCfg.MetaRootPath = "/etcd/test/root/querynode"
+	// the binlogs of the test segments are not saved in the layout of data node
+	Params.QueryNodeCfg.StrictBinlogPathLayout = false
 }
 
 func genTestCollectionSchema(collectionID UniqueID, isBinary bool, dim int) *schemapb.CollectionSchema {
@@ -204,9 +206,11 @@ func newQueryNodeMock() *QueryNode {
 
 	factory := newMessageStreamFactory()
 	svr := NewQueryNode(ctx, factory)
+	svr.config = newQueryNodeConfig()
 	tsReplica := newTSafeReplica()
-	streamingReplica := newCollectionReplica(etcdKV)
-	historicalReplica := newCollectionReplica(etcdKV)
+	collections := newCollectionRegistry()
+	streamingReplica := newCollectionReplica(etcdKV, svr.config, collections)
+	historicalReplica := newCollectionReplica(etcdKV, svr.config, collections)
 	svr.historical = newHistorical(svr.queryNodeLoopCtx, historicalReplica, tsReplica)
 	svr.streaming = newStreaming(ctx, streamingReplica, factory, etcdKV, tsReplica)
 	svr.dataSyncService = newDataSyncService(ctx, svr.streaming.replica, svr.historical.replica, tsReplica, factory)
@@ -219,7 +223,7 @@ func newQueryNodeMock() *QueryNode {
 	if err != nil {
 		panic(err)
 	}
-	svr.loader = newSegmentLoader(svr.historical.replica, svr.streaming.replica, etcdKV, svr.vectorStorage, factory)
+	svr.loader = newSegmentLoader(svr.historical.replica, svr.streaming.replica, etcdKV, svr.vectorStorage, factory, svr.config)
 	svr.etcdKV = etcdKV
 
 	return svr
@@ -29,6 +29,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
@@ -60,13 +61,17 @@ type queryShard struct {
 	serviceDmTs       atomic.Uint64
 	serviceDeltaTs    atomic.Uint64
 	startTickerOnce   sync.Once
-	ticker            *time.Ticker // timed ticker for trigger timeout check
+	ticker            *time.Ticker        // timed ticker for trigger timeout check
+	waitingDeadlines  map[int64]time.Time // deadlines of requests waiting for serviceable ts, guarded by watcherCond.L
+	waiterID          int64
 
 	localChunkManager  storage.ChunkManager
 	remoteChunkManager storage.ChunkManager
 	vectorChunkManager *storage.VectorChunkManager
 	localCacheEnabled  bool
 	localCacheSize     int64
+
+	config *QueryNodeConfig
 }
 
 func newQueryShard(
@@ -80,6 +85,7 @@ func newQueryShard(
 	localChunkManager storage.ChunkManager,
 	remoteChunkManager storage.ChunkManager,
 	localCacheEnabled bool,
+	config *QueryNodeConfig,
 ) *queryShard {
 	ctx, cancel := context.WithCancel(ctx)
 	qs := &queryShard{
@@ -94,9 +100,11 @@ func newQueryShard(
 		localChunkManager:  localChunkManager,
 		remoteChunkManager: remoteChunkManager,
 		localCacheEnabled:  localCacheEnabled,
-		localCacheSize:     Params.QueryNodeCfg.CacheMemoryLimit,
+		localCacheSize:     config.CacheMemoryLimit,
+		config:             config,
 
-		watcherCond: sync.NewCond(&sync.Mutex{}),
+		watcherCond:      sync.NewCond(&sync.Mutex{}),
+		waitingDeadlines: make(map[int64]time.Time),
 	}
 	deltaChannel, err := funcutil.ConvertChannelName(channel, Params.CommonCfg.RootCoordDml, Params.CommonCfg.RootCoordDelta)
 	if err != nil {
@@ -226,6 +234,12 @@ func (q *queryShard) waitUntilServiceable(ctx context.Context, guaranteeTs Times
 	q.watcherCond.L.Lock()
 	defer q.watcherCond.L.Unlock()
 	st := q.getServiceableTime(tp)
+	if deadline, ok := ctx.Deadline(); ok && guaranteeTs > st {
+		q.waiterID++
+		id := q.waiterID
+		q.waitingDeadlines[id] = deadline
+		defer delete(q.waitingDeadlines, id)
+	}
 	for guaranteeTs > st {
 		log.Debug("serviceable ts before guarantee ts", zap.Uint64("serviceable ts", st), zap.Uint64("guarantee ts", guaranteeTs), zap.String("channel", q.channel))
 		q.watcherCond.Wait()
@@ -238,12 +252,44 @@ func (q *queryShard) waitUntilServiceable(ctx context.Context, guaranteeTs Times
 	log.Debug("wait serviceable ts done", zap.String("tsType", tp.String()), zap.Uint64("guarantee ts", guaranteeTs), zap.Uint64("serviceable ts", st), zap.String("channel", q.channel))
 }
 
+// earliestWaitingDeadline returns the earliest deadline of the requests waiting for serviceable ts,
+// returns false if no request with deadline is waiting
+func (q *queryShard) earliestWaitingDeadline() (time.Time, bool) {
+	q.watcherCond.L.Lock()
+	defer q.watcherCond.L.Unlock()
+	var earliest time.Time
+	for _, deadline := range q.waitingDeadlines {
+		if earliest.IsZero() || deadline.Before(earliest) {
+			earliest = deadline
+		}
+	}
+	return earliest, !earliest.IsZero()
+}
+
+// checkPauseDeadlineBudget checks that no request waiting for serviceable ts would time out within the budget
+// if the tSafe of the shard freezes, no check applies if the budget is not positive
+func (q *queryShard) checkPauseDeadlineBudget(budget time.Duration) error {
+	if budget <= 0 {
+		return nil
+	}
+	deadline, ok := q.earliestWaitingDeadline()
+	if ok && time.Until(deadline) < budget {
+		return &pauseDeadlineBudgetError{channel: q.channel, deadline: deadline, budget: budget}
+	}
+	return nil
+}
+
 func (q *queryShard) getServiceableTime(tp tsType) Timestamp {
-	gracefulTimeInMilliSecond := Params.QueryNodeCfg.GracefulTime
+	gracefulTimeInMilliSecond := q.config.GracefulTime
 	gracefulTime := typeutil.ZeroTimestamp
 	if gracefulTimeInMilliSecond > 0 {
 		gracefulTime = tsoutil.ComposeTS(gracefulTimeInMilliSecond, 0)
 	}
+	return q.getTSafe(tp) + gracefulTime
+}
+
+// getTSafe returns the latest tSafe of tp, without graceful time
+func (q *queryShard) getTSafe(tp tsType) Timestamp {
 	var serviceTs Timestamp
 	switch tp {
 	case tsTypeDML: // use min value of dml & delta
@@ -251,7 +297,100 @@ func (q *queryShard) getServiceableTime(tp tsType) Timestamp {
 	case tsTypeDelta: // check delta ts only
 		serviceTs = q.serviceDeltaTs.Load()
 	}
-	return serviceTs + gracefulTime
+	return serviceTs
+}
+
+// getAppliedTs returns the timestamp both the inserts and the deletes of the shard are applied up to
+func (q *queryShard) getAppliedTs() Timestamp {
+	dmlTs, deltaTs := q.getTSafe(tsTypeDML), q.getTSafe(tsTypeDelta)
+	if deltaTs < dmlTs {
+		return deltaTs
+	}
+	return dmlTs
+}
+
+// getReadTs returns the ts the results of the shard leader read at ts cover all the data up to, which is ts itself
+// unless the DML tSafe lags behind it, for the data newer than the tSafe may be consumed partially
+func (q *queryShard) getReadTs(ts Timestamp) Timestamp {
+	if tSafe := q.getTSafe(tsTypeDML); tSafe < ts {
+		return tSafe
+	}
+	return ts
+}
+
+// checkSnapshotTs checks that a pinned snapshot ts is not older than the retention boundary,
+// and for shard leader, that it is already covered by the DML tSafe.
+// Followers wait for their delta tSafe to catch up with the snapshot instead.
+func (q *queryShard) checkSnapshotTs(snapshotTs Timestamp, isLeader bool) error {
+	tp := tsTypeDelta
+	if isLeader {
+		tp = tsTypeDML
+	}
+	tSafe := q.getTSafe(tp)
+	retentionTs := getRetentionBoundary(tSafe)
+	if snapshotTs < retentionTs {
+		return &snapshotTsExpiredError{snapshotTs: snapshotTs, retentionTs: retentionTs}
+	}
+	if isLeader && snapshotTs > tSafe {
+		return &snapshotTsNotServiceableError{snapshotTs: snapshotTs, tSafe: tSafe}
+	}
+	return nil
+}
+
+// boundGuaranteeTs bounds a guarantee ts ahead of tSafe by more than the configured max lag, which is usually
+// generated from a skewed clock and would park the request waiting for tSafe until timeout. The guarantee ts
+// is clamped to tSafe plus the max lag, which weakens the consistency of the request, or rejected in strict mode.
+// No bound applies if the max lag is not positive, which is the default, or before the first tSafe.
+func (q *queryShard) boundGuaranteeTs(guaranteeTs Timestamp, isLeader bool) (Timestamp, error) {
+	config := q.config.getDynamic()
+	maxLag := config.MaxGuaranteeTsLag
+	tp := tsTypeDelta
+	if isLeader {
+		tp = tsTypeDML
+	}
+	tSafe := q.getTSafe(tp)
+	if maxLag <= 0 || tSafe == 0 {
+		return guaranteeTs, nil
+	}
+	bound := tsoutil.AddPhysicalDurationOnTs(maxLag, tSafe)
+	if guaranteeTs <= bound {
+		return guaranteeTs, nil
+	}
+
+	nodeID := fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)
+	lag := tsoutil.PhysicalTime(guaranteeTs).Sub(tsoutil.PhysicalTime(tSafe))
+	if config.StrictGuaranteeTs {
+		metrics.QueryNodeSkewedGuaranteeTs.WithLabelValues(nodeID, metrics.RejectLabel).Inc()
+		return 0, &guaranteeTsTooFarAheadError{guaranteeTs: guaranteeTs, tSafe: tSafe, maxLag: maxLag}
+	}
+	metrics.QueryNodeSkewedGuaranteeTs.WithLabelValues(nodeID, metrics.ClampLabel).Inc()
+	log.Warn("guarantee ts is too far ahead of tSafe, clamp it",
+		zap.String("channel", q.channel),
+		zap.Uint64("guaranteeTs", guaranteeTs),
+		zap.Uint64("tSafe", tSafe),
+		zap.Duration("lag", lag),
+		zap.Duration("maxLag", maxLag))
+	return bound, nil
+}
+
+// getRetentionBoundary returns the oldest ts which could still be travelled to at ts
+func getRetentionBoundary(ts Timestamp) Timestamp {
+	physical, _ := tsoutil.ParseHybridTs(ts)
+	retentionInMilliSecond := Params.CommonCfg.RetentionDuration * 1000
+	if physical <= retentionInMilliSecond {
+		return typeutil.ZeroTimestamp
+	}
+	return tsoutil.ComposeTS(physical-retentionInMilliSecond, 0)
+}
+
+// resetServiceableTime resets the serviceable time of tp to zero, the reads wait for the tSafe to advance again
+func (q *queryShard) resetServiceableTime(tp tsType) {
+	switch tp {
+	case tsTypeDML:
+		q.serviceDmTs.Store(0)
+	case tsTypeDelta:
+		q.serviceDeltaTs.Store(0)
+	}
 }
 
 func (q *queryShard) setServiceableTime(t Timestamp, tp tsType) {
@@ -285,32 +424,78 @@ func (q *queryShard) search(ctx context.Context, req *querypb.SearchRequest) (*i
 	collectionID := req.Req.CollectionID
 	segmentIDs := req.SegmentIDs
 	timestamp := req.Req.TravelTimestamp
+	guaranteeTs := req.GetReq().GetGuaranteeTimestamp()
 
 	// check ctx timeout
 	if !funcutil.CheckCtxValid(ctx) {
 		return nil, errors.New("search context timeout")
 	}
 
+	// search exactly at the pinned snapshot if any
+	if snapshotTs := req.GetReq().GetSnapshotTimestamp(); snapshotTs != 0 {
+		if err := q.checkSnapshotTs(snapshotTs, len(segmentIDs) == 0); err != nil {
+			log.Warn("invalid snapshot ts for search", zap.Int64("collectionID", collectionID), zap.Error(err))
+			return nil, err
+		}
+		req.Req.TravelTimestamp = snapshotTs
+		req.Req.GuaranteeTimestamp = snapshotTs
+		timestamp = snapshotTs
+		guaranteeTs = snapshotTs
+	} else {
+		// the bounded guarantee ts only applies to this node, the request forwarded to followers is left intact
+		bounded, err := q.boundGuaranteeTs(guaranteeTs, len(segmentIDs) == 0)
+		if err != nil {
+			log.Warn("invalid guarantee ts for search", zap.Int64("collectionID", collectionID), zap.Error(err))
+			return nil, err
+		}
+		guaranteeTs = bounded
+	}
+
 	// check if collection has been released
 	collection, err := q.historical.replica.getCollectionByID(collectionID)
 	if err != nil {
 		return nil, err
 	}
-	if req.GetReq().GetGuaranteeTimestamp() >= collection.getReleaseTime() {
+	if guaranteeTs >= collection.getReleaseTime() {
 		log.Warn("collection release before search", zap.Int64("collectionID", collectionID))
 		return nil, fmt.Errorf("retrieve failed, collection has been released, collectionID = %d", collectionID)
 	}
+	if err := collection.checkSearchable(); err != nil {
+		return nil, err
+	}
+	mask := collection.getFieldMask()
+	if err := mask.checkOutputFields(collectionID, req.Req.GetOutputFieldsId()); err != nil {
+		return nil, err
+	}
 
 	// deserialize query plan
 
 	var plan *SearchPlan
+	var neverMatch bool
 	if req.Req.GetDslType() == commonpb.DslType_BoolExprV1 {
-		expr := req.Req.SerializedExprPlan
+		expr, err := applyMandatoryFilter(collection, req.Req.SerializedExprPlan, req.Req.GetMandatoryFilterPlan())
+		if err != nil {
+			log.Warn("failed to apply mandatory filter to search plan", zap.Int64("collectionID", collectionID),
+				zap.String("mandatoryFilter", req.Req.GetMandatoryFilter()), zap.Error(err))
+			return nil, err
+		}
+		if q.config.getDynamic().SimplifyPredicates {
+			expr, neverMatch, err = simplifyPlan(expr)
+			if err != nil {
+				return nil, err
+			}
+		}
 		plan, err = createSearchPlanByExpr(collection, expr)
 		if err != nil {
 			return nil, err
 		}
+		if plan.prefilter != nil {
+			plan.prefilter.histograms = q.getFieldHistograms(collection, plan.prefilter.predicates)
+		}
 	} else {
+		if len(req.Req.GetMandatoryFilterPlan()) > 0 {
+			return nil, errors.New("mandatory filter is not supported by dsl search")
+		}
 		dsl := req.Req.Dsl
 		plan, err = createSearchPlan(collection, dsl)
 		if err != nil {
@@ -318,16 +503,65 @@ func (q *queryShard) search(ctx context.Context, req *querypb.SearchRequest) (*i
 		}
 	}
 	defer plan.delete()
+	plan.setExpireTs(collection.getExpireTs(timestamp))
 
-	schemaHelper, err := typeutil.CreateSchemaHelper(collection.schema)
+	schemaHelper, err := typeutil.CreateSchemaHelper(collection.Schema())
 	if err != nil {
 		return nil, err
 	}
 
-	// validate top-k
+	// validate the request with the checks shared with the proxy
+	annsFieldID, queryInfo, err := searchQueryInfo(req.Req, plan)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := validateSearchRequest(collection, annsFieldID, queryInfo, req.Req.GetPlaceholderGroup(), req.Req.GetOutputFieldsId()); err != nil {
+		return nil, err
+	}
 	topK := plan.getTopK()
-	if topK <= 0 || topK >= 16385 {
-		return nil, fmt.Errorf("limit should be in range [1, 16385], but got %d", topK)
+
+	// scores are converted to cosine similarity on each node before reduce
+	if req.GetReq().GetNormalizeScores() {
+		if err := checkScoreNormalizable(collection, plan.getFieldID(), plan.getMetricType()); err != nil {
+			return nil, err
+		}
+	}
+
+	// the predicates match no row, no segment needs to be searched
+	if neverMatch {
+		searchReq, err := parseSearchRequest(plan, req.Req.PlaceholderGroup)
+		if err != nil {
+			return nil, err
+		}
+		defer searchReq.delete()
+		log.Debug("predicates never match, skip search", zap.Int64("collectionID", collectionID))
+		return &internalpb.SearchResults{
+			Status:         &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			MetricType:     plan.getMetricType(),
+			NumQueries:     searchReq.getNumOfQuery(),
+			TopK:           topK,
+			SlicedBlob:     nil,
+			SlicedOffset:   1,
+			SlicedNumCount: 1,
+		}, nil
+	}
+
+	// search only the distinct query vectors, the request is copied so that the caller's one is kept intact
+	var dedup *placeholderDedup
+	if q.config.getDynamic().EnableSearchDedup {
+		placeholderGroup, d := dedupPlaceholderGroup(req.GetReq().GetPlaceholderGroup())
+		if d != nil {
+			d.observe()
+		}
+		if d != nil && d.deduplicated() {
+			dedupInternalReq := *req.Req
+			dedupInternalReq.PlaceholderGroup = placeholderGroup
+			dedupReq := *req
+			dedupReq.Req = &dedupInternalReq
+			req = &dedupReq
+			dedup = d
+			defer dedup.release()
+		}
 	}
 
 	// parse plan to search request
@@ -339,17 +573,39 @@ func (q *queryShard) search(ctx context.Context, req *querypb.SearchRequest) (*i
 	queryNum := searchReq.getNumOfQuery()
 	searchRequests := []*searchRequest{searchReq}
 
+	var results *internalpb.SearchResults
 	if len(segmentIDs) == 0 {
 		// segmentIDs not specified, searching as shard leader
-		return q.searchLeader(ctx, req, searchRequests, collectionID, schemaHelper, plan, topK, queryNum, timestamp)
+		results, err = q.searchLeader(ctx, req, searchRequests, collection, schemaHelper, plan, topK, queryNum, timestamp, guaranteeTs)
+	} else {
+		// segmentIDs specified search as shard follower
+		results, err = q.searchFollower(ctx, req, searchRequests, collection, schemaHelper, plan, topK, queryNum, timestamp, guaranteeTs)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	// segmentIDs specified search as shard follower
-	return q.searchFollower(ctx, req, searchRequests, collectionID, schemaHelper, plan, topK, queryNum, timestamp)
+	// expand the results of the distinct query vectors back to the original queries
+	if dedup != nil {
+		if err := dedup.expandSearchResults(results); err != nil {
+			log.Warn("failed to expand deduplicated search results", zap.Int64("collectionID", collectionID), zap.Error(err))
+			return nil, err
+		}
+	}
+	if err := mask.maskSearchResults(results); err != nil {
+		log.Warn("failed to mask search results", zap.Int64("collectionID", collectionID), zap.Error(err))
+		return nil, err
+	}
+	return results, nil
 }
 
-func (q *queryShard) searchLeader(ctx context.Context, req *querypb.SearchRequest, searchRequests []*searchRequest, collectionID UniqueID,
-	schemaHelper *typeutil.SchemaHelper, plan *SearchPlan, topK int64, queryNum int64, timestamp Timestamp) (*internalpb.SearchResults, error) {
+func (q *queryShard) searchLeader(ctx context.Context, req *querypb.SearchRequest, searchRequests []*searchRequest, collection *Collection,
+	schemaHelper *typeutil.SchemaHelper, plan *SearchPlan, topK int64, queryNum int64, timestamp Timestamp, guaranteeTs Timestamp) (*internalpb.SearchResults, error) {
+	collectionID := collection.ID()
+	weights, err := parsePartitionWeights(req.GetReq(), plan.getMetricType())
+	if err != nil {
+		return nil, err
+	}
 	q.streaming.replica.queryRLock()
 	defer q.streaming.replica.queryRUnlock()
 	cluster, ok := q.clusterService.getShardCluster(req.GetDmlChannel())
@@ -362,7 +618,8 @@ func (q *queryShard) searchLeader(ctx context.Context, req *querypb.SearchReques
 
 	var results []*internalpb.SearchResults
 	var streamingResults []*SearchResult
-	var err error
+	var streamingSegmentIDs []UniqueID
+	var readTs Timestamp
 	var mut sync.Mutex
 	var wg sync.WaitGroup
 
@@ -387,11 +644,10 @@ func (q *queryShard) searchLeader(ctx context.Context, req *querypb.SearchReques
 	go func() {
 		defer wg.Done()
 		// hold request until guarantee timestamp >= service timestamp
-		guaranteeTs := req.GetReq().GetGuaranteeTimestamp()
 		q.waitUntilServiceable(ctx, guaranteeTs, tsTypeDML)
+		readTs = q.getReadTs(timestamp)
 		// shard leader queries its own streaming data
-		// TODO add context
-		sResults, _, _, sErr := q.streaming.search(searchRequests, collectionID, req.Req.PartitionIDs, req.DmlChannel, plan, timestamp)
+		sResults, sSegmentIDs, _, sErr := q.streaming.search(searchCtx, searchRequests, collectionID, req.Req.PartitionIDs, req.DmlChannel, plan, timestamp)
 		mut.Lock()
 		defer mut.Unlock()
 		if sErr != nil {
@@ -401,6 +657,7 @@ func (q *queryShard) searchLeader(ctx context.Context, req *querypb.SearchReques
 			return
 		}
 		streamingResults = sResults
+		streamingSegmentIDs = sSegmentIDs
 	}()
 
 	wg.Wait()
@@ -410,6 +667,11 @@ func (q *queryShard) searchLeader(ctx context.Context, req *querypb.SearchReques
 
 	defer deleteSearchResults(streamingResults)
 
+	var skippedSegments int64
+	for _, result := range results {
+		skippedSegments += result.GetSkippedSegments()
+	}
+
 	results = append(results, &internalpb.SearchResults{
 		Status:         &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
 		MetricType:     plan.getMetricType(),
@@ -420,7 +682,18 @@ func (q *queryShard) searchLeader(ctx context.Context, req *querypb.SearchReques
 		SlicedNumCount: 1,
 	})
 
-	if len(streamingResults) > 0 {
+	if len(streamingResults) > 0 && weights != nil {
+		segmentWeights, err := weights.segmentWeights(q.streaming.replica, streamingSegmentIDs)
+		if err != nil {
+			return nil, err
+		}
+		blob, err := reduceWeightedSearchResults(plan, streamingResults, segmentWeights, collectionID, searchRequests[0].getNumOfQuery())
+		if err != nil {
+			log.Warn("failed to reduce weighted streaming results", zap.Int64("collectionID", collectionID), zap.Error(err))
+			return nil, err
+		}
+		results[len(results)-1].SlicedBlob = blob
+	} else if len(streamingResults) > 0 {
 		// reduce search results
 		numSegment := int64(len(streamingResults))
 		err = reduceSearchResultsAndFillData(plan, streamingResults, numSegment)
@@ -451,6 +724,18 @@ func (q *queryShard) searchLeader(ctx context.Context, req *querypb.SearchReques
 		}
 
 		results[len(results)-1].SlicedBlob = blob
+		if req.GetReq().GetNormalizeScores() {
+			err = normalizeSearchResults(collection, plan, req.GetReq().GetPlaceholderGroup(), timestamp, results[len(results)-1],
+				func(retrievePlan *RetrievePlan) ([]*segcorepb.RetrieveResults, error) {
+					retrieveResults, _, _, err := q.streaming.retrieve(collectionID, req.GetReq().GetPartitionIDs(), retrievePlan,
+						func(segment *Segment) bool { return segment.vChannelID == q.channel })
+					return retrieveResults, err
+				})
+			if err != nil {
+				log.Warn("failed to normalize scores of streaming results", zap.Int64("collectionID", collectionID), zap.Error(err))
+				return nil, err
+			}
+		}
 	}
 
 	// reduce shard search results: unmarshal -> reduce -> marshal
@@ -476,11 +761,20 @@ func (q *queryShard) searchLeader(ctx context.Context, req *querypb.SearchReques
 		log.Warn("shard leader reduce errors", zap.Error(err))
 		return nil, err
 	}
+	if Params.QueryNodeCfg.ValidateSearchResult {
+		if err := typeutil.ValidateSearchResultData(reducedResultData); err != nil {
+			metrics.QueryNodeSearchResultViolations.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Inc()
+			log.Error("shard leader reduced an invalid search result", zap.String("shard", q.channel), zap.Error(err))
+			return nil, err
+		}
+	}
 	searchResults, err := encodeSearchResultData(reducedResultData, queryNum, plan.getTopK(), plan.getMetricType())
 	if err != nil {
 		log.Warn("shard leader encode search result errors", zap.Error(err))
 		return nil, err
 	}
+	searchResults.SkippedSegments = skippedSegments
+	searchResults.ReadTimestamp = readTs
 	if searchResults.SlicedBlob == nil {
 		log.Debug("shard leader send nil results to proxy",
 			zap.String("shard", q.channel))
@@ -493,21 +787,59 @@ func (q *queryShard) searchLeader(ctx context.Context, req *querypb.SearchReques
 	return searchResults, nil
 }
 
-func (q *queryShard) searchFollower(ctx context.Context, req *querypb.SearchRequest, searchRequests []*searchRequest, collectionID UniqueID,
-	schemaHelper *typeutil.SchemaHelper, plan *SearchPlan, topK int64, queryNum int64, timestamp Timestamp) (*internalpb.SearchResults, error) {
+func (q *queryShard) searchFollower(ctx context.Context, req *querypb.SearchRequest, searchRequests []*searchRequest, collection *Collection,
+	schemaHelper *typeutil.SchemaHelper, plan *SearchPlan, topK int64, queryNum int64, timestamp Timestamp, guaranteeTs Timestamp) (*internalpb.SearchResults, error) {
+	collectionID := collection.ID()
+	weights, err := parsePartitionWeights(req.GetReq(), plan.getMetricType())
+	if err != nil {
+		return nil, err
+	}
 	q.historical.replica.queryRLock()
 	defer q.historical.replica.queryRUnlock()
 	segmentIDs := req.GetSegmentIDs()
 	// hold request until guarantee timestamp >= service timestamp
-	guaranteeTs := req.GetReq().GetGuaranteeTimestamp()
 	q.waitUntilServiceable(ctx, guaranteeTs, tsTypeDelta)
 	// search each segments by segment IDs in request
-	historicalResults, _, err := q.historical.searchSegments(segmentIDs, searchRequests, plan, timestamp)
+	historicalResults, searchedSegmentIDs, err := q.historical.searchSegments(ctx, segmentIDs, searchRequests, plan, timestamp)
 	if err != nil {
 		return nil, err
 	}
 	defer deleteSearchResults(historicalResults)
 
+	// all the segments are skipped
+	if len(historicalResults) == 0 {
+		return &internalpb.SearchResults{
+			Status:         &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			MetricType:     plan.getMetricType(),
+			NumQueries:     queryNum,
+			TopK:           topK,
+			SlicedBlob:     nil,
+			SlicedOffset:   1,
+			SlicedNumCount: 1,
+		}, nil
+	}
+
+	if weights != nil {
+		segmentWeights, err := weights.segmentWeights(q.historical.replica, searchedSegmentIDs)
+		if err != nil {
+			return nil, err
+		}
+		blob, err := reduceWeightedSearchResults(plan, historicalResults, segmentWeights, collectionID, searchRequests[0].getNumOfQuery())
+		if err != nil {
+			log.Warn("failed to reduce weighted historical results", zap.Int64("collectionID", collectionID), zap.Error(err))
+			return nil, err
+		}
+		return &internalpb.SearchResults{
+			Status:         &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			MetricType:     plan.getMetricType(),
+			NumQueries:     queryNum,
+			TopK:           topK,
+			SlicedBlob:     blob,
+			SlicedOffset:   1,
+			SlicedNumCount: 1,
+		}, nil
+	}
+
 	// reduce search results
 	numSegment := int64(len(historicalResults))
 	err = reduceSearchResultsAndFillData(plan, historicalResults, numSegment)
@@ -548,6 +880,20 @@ func (q *queryShard) searchFollower(ctx context.Context, req *querypb.SearchRequ
 		SlicedOffset:   1,
 		SlicedNumCount: 1,
 	}
+	if req.GetReq().GetNormalizeScores() {
+		vcm, err := q.getVectorChunkManager(collection)
+		if err != nil {
+			return nil, err
+		}
+		err = normalizeSearchResults(collection, plan, req.GetReq().GetPlaceholderGroup(), timestamp, resp,
+			func(retrievePlan *RetrievePlan) ([]*segcorepb.RetrieveResults, error) {
+				return q.historical.retrieveBySegmentIDs(collectionID, segmentIDs, vcm, retrievePlan)
+			})
+		if err != nil {
+			log.Warn("failed to normalize scores of historical results", zap.Int64("collectionID", collectionID), zap.Error(err))
+			return nil, err
+		}
+	}
 	log.Debug("shard follower send search result to leader")
 	return resp, nil
 }
@@ -566,7 +912,7 @@ func reduceSearchResultData(searchResultData []*schemapb.SearchResultData, nq in
 					},
 				},
 			},
-			Topks: make([]int64, 0),
+			Topks: make([]int64, nq),
 		}, nil
 	}
 	ret := &schemapb.SearchResultData{
@@ -586,6 +932,10 @@ func reduceSearchResultData(searchResultData []*schemapb.SearchResultData, nq in
 
 	var skipDupCnt int64
 	var dummyCnt int64
+	// insert timestamps of hits are carried only if requested by the search
+	withTimestamps := typeutil.HasSearchTimestamps(searchResultData)
+	// unweighted scores of hits are carried only if the search weights partitions
+	withRawScores := typeutil.HasSearchRawScores(searchResultData)
 	// var realTopK int64 = -1
 	for i := int64(0); i < nq; i++ {
 		offsets := make([]int64, len(searchResultData))
@@ -611,6 +961,12 @@ func reduceSearchResultData(searchResultData []*schemapb.SearchResultData, nq in
 				typeutil.AppendFieldData(ret.FieldsData, searchResultData[sel].FieldsData, idx)
 				ret.Ids.GetIntId().Data = append(ret.Ids.GetIntId().Data, id)
 				ret.Scores = append(ret.Scores, score)
+				if withTimestamps {
+					typeutil.AppendSearchTimestamp(ret, searchResultData[sel], idx)
+				}
+				if withRawScores {
+					typeutil.AppendSearchRawScore(ret, searchResultData[sel], idx)
+				}
 				idSet[id] = struct{}{}
 				j++
 			} else {
@@ -624,16 +980,18 @@ func reduceSearchResultData(searchResultData []*schemapb.SearchResultData, nq in
 			typeutil.AppendFieldData(ret.FieldsData, searchResultData[0].FieldsData, 0)
 			ret.Ids.GetIntId().Data = append(ret.Ids.GetIntId().Data, -1)
 			ret.Scores = append(ret.Scores, -1*float32(math.MaxFloat32))
+			if withTimestamps {
+				ret.Timestamps = append(ret.Timestamps, 0)
+			}
+			if withRawScores {
+				ret.RawScores = append(ret.RawScores, -1*float32(math.MaxFloat32))
+			}
 			j++
 			dummyCnt++
 		}
 
-		// if realTopK != -1 && realTopK != j {
-		// 	log.Warn("Proxy Reduce Search Result", zap.Error(errors.New("the length (topk) between all result of query is different")))
-		// 	// return nil, errors.New("the length (topk) between all result of query is different")
-		// }
-		// realTopK = j
-		// ret.Topks = append(ret.Topks, realTopK)
+		// every query is padded to topk, so the per-query topks are all topk
+		ret.Topks = append(ret.Topks, j)
 	}
 	log.Debug("skip duplicated search result", zap.Int64("count", skipDupCnt))
 	log.Debug("add dummy data in search result", zap.Int64("count", dummyCnt))
@@ -706,51 +1064,120 @@ func encodeSearchResultData(searchResultData *schemapb.SearchResultData, nq int6
 	return
 }
 
+// getVectorChunkManager returns the vector chunk manager of the shard, creates it if not yet
+func (q *queryShard) getVectorChunkManager(collection *Collection) (storage.ChunkManager, error) {
+	// TODO: init vector chunk manager at most once
+	if q.vectorChunkManager == nil {
+		if q.localChunkManager == nil {
+			return nil, fmt.Errorf("can not create vector chunk manager for local chunk manager is nil")
+		}
+		if q.remoteChunkManager == nil {
+			return nil, fmt.Errorf("can not create vector chunk manager for remote chunk manager is nil")
+		}
+		vcm, err := storage.NewVectorChunkManager(q.localChunkManager, q.remoteChunkManager,
+			&etcdpb.CollectionMeta{
+				ID:     collection.id,
+				Schema: collection.Schema(),
+			}, q.localCacheSize, q.localCacheEnabled)
+		if err != nil {
+			return nil, err
+		}
+		vcm.SetEvictionListener(onDiskFileEvicted)
+		q.vectorChunkManager = vcm
+	}
+	return q.vectorChunkManager, nil
+}
+
 func (q *queryShard) query(ctx context.Context, req *querypb.QueryRequest) (*internalpb.RetrieveResults, error) {
 	collectionID := req.Req.CollectionID
 	segmentIDs := req.SegmentIDs
 	partitionIDs := req.Req.PartitionIDs
 	expr := req.Req.SerializedExprPlan
 	timestamp := req.Req.TravelTimestamp
+	guaranteeTs := req.GetReq().GetGuaranteeTimestamp()
 
 	// check ctx timeout
 	if !funcutil.CheckCtxValid(ctx) {
 		return nil, errors.New("search context timeout")
 	}
 
+	// resume after the cursor of the former page, which is checked by the shard leader only
+	if req.GetReq().GetCursor() != nil && len(segmentIDs) == 0 {
+		resumed, err := q.resumeQueryCursor(req)
+		if err != nil {
+			log.Warn("failed to resume query cursor", zap.Int64("collectionID", collectionID), zap.Error(err))
+			return nil, err
+		}
+		req = resumed
+		expr = req.Req.SerializedExprPlan
+	}
+
+	// query exactly at the pinned snapshot if any
+	if snapshotTs := req.GetReq().GetSnapshotTimestamp(); snapshotTs != 0 {
+		if err := q.checkSnapshotTs(snapshotTs, len(segmentIDs) == 0); err != nil {
+			log.Warn("invalid snapshot ts for query", zap.Int64("collectionID", collectionID), zap.Error(err))
+			return nil, err
+		}
+		req.Req.TravelTimestamp = snapshotTs
+		req.Req.GuaranteeTimestamp = snapshotTs
+		timestamp = snapshotTs
+		guaranteeTs = snapshotTs
+	} else {
+		// the bounded guarantee ts only applies to this node, the request forwarded to followers is left intact
+		bounded, err := q.boundGuaranteeTs(guaranteeTs, len(segmentIDs) == 0)
+		if err != nil {
+			log.Warn("invalid guarantee ts for query", zap.Int64("collectionID", collectionID), zap.Error(err))
+			return nil, err
+		}
+		guaranteeTs = bounded
+	}
+
 	// check if collection has been released
 	collection, err := q.streaming.replica.getCollectionByID(collectionID)
 	if err != nil {
 		return nil, err
 	}
 
-	if req.GetReq().GetGuaranteeTimestamp() >= collection.getReleaseTime() {
+	if guaranteeTs >= collection.getReleaseTime() {
 		log.Warn("collection release before query", zap.Int64("collectionID", collectionID))
 		return nil, fmt.Errorf("retrieve failed, collection has been released, collectionID = %d", collectionID)
 	}
+	mask := collection.getFieldMask()
+	if err := mask.checkOutputFields(collectionID, req.Req.GetOutputFieldsId()); err != nil {
+		return nil, err
+	}
 	// deserialize query plan
-	plan, err := createRetrievePlanByExpr(collection, expr, timestamp)
+	expr, err = applyMandatoryFilter(collection, expr, req.Req.GetMandatoryFilterPlan())
 	if err != nil {
+		log.Warn("failed to apply mandatory filter to retrieve plan", zap.Int64("collectionID", collectionID),
+			zap.String("mandatoryFilter", req.Req.GetMandatoryFilter()), zap.Error(err))
 		return nil, err
 	}
-	defer plan.delete()
-
-	// TODO: init vector chunk manager at most once
-	if q.vectorChunkManager == nil {
-		if q.localChunkManager == nil {
-			return nil, fmt.Errorf("can not create vector chunk manager for local chunk manager is nil")
-		}
-		if q.remoteChunkManager == nil {
-			return nil, fmt.Errorf("can not create vector chunk manager for remote chunk manager is nil")
-		}
-		q.vectorChunkManager, err = storage.NewVectorChunkManager(q.localChunkManager, q.remoteChunkManager,
-			&etcdpb.CollectionMeta{
-				ID:     collection.id,
-				Schema: collection.schema,
-			}, q.localCacheSize, q.localCacheEnabled)
+	if q.config.getDynamic().SimplifyPredicates {
+		var neverMatch bool
+		expr, neverMatch, err = simplifyPlan(expr)
 		if err != nil {
 			return nil, err
 		}
+		// the predicates match no row, no segment needs to be retrieved
+		if neverMatch {
+			log.Debug("predicates never match, skip query", zap.Int64("collectionID", collectionID))
+			return &internalpb.RetrieveResults{
+				Status:     &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+				Ids:        &schemapb.IDs{},
+				FieldsData: []*schemapb.FieldData{},
+			}, nil
+		}
+	}
+	plan, err := createRetrievePlanByExpr(collection, expr, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	defer plan.delete()
+
+	vcm, err := q.getVectorChunkManager(collection)
+	if err != nil {
+		return nil, err
 	}
 
 	// check if shard leader b.c only leader receives request with no segment specified
@@ -761,6 +1188,8 @@ func (q *queryShard) query(ctx context.Context, req *querypb.QueryRequest) (*int
 		if !ok {
 			return nil, fmt.Errorf("channel %s leader is not here", req.GetDmlChannel())
 		}
+		// the digest is taken before dispatching, a cursor is expired if the distribution changes meanwhile
+		digest := cluster.distributionDigest()
 
 		// add cancel when error occurs
 		queryCtx, cancel := context.WithCancel(ctx)
@@ -768,6 +1197,7 @@ func (q *queryShard) query(ctx context.Context, req *querypb.QueryRequest) (*int
 
 		var results []*internalpb.RetrieveResults
 		var streamingResults []*segcorepb.RetrieveResults
+		var readTs Timestamp
 		var err error
 		var mut sync.Mutex
 		var wg sync.WaitGroup
@@ -792,8 +1222,8 @@ func (q *queryShard) query(ctx context.Context, req *querypb.QueryRequest) (*int
 		go func() {
 			defer wg.Done()
 			// hold request until guarantee timestamp >= service timestamp
-			guaranteeTs := req.GetReq().GetGuaranteeTimestamp()
 			q.waitUntilServiceable(ctx, guaranteeTs, tsTypeDML)
+			readTs = q.getReadTs(timestamp)
 			// shard leader queries its own streaming data
 			// TODO add context
 			sResults, _, _, sErr := q.streaming.retrieve(collectionID, partitionIDs, plan, func(segment *Segment) bool { return segment.vChannelID == q.channel })
@@ -813,45 +1243,59 @@ func (q *queryShard) query(ctx context.Context, req *querypb.QueryRequest) (*int
 			return nil, err
 		}
 
-		streamingResult, err := mergeRetrieveResults(streamingResults)
+		streamingResult, err := mergeSegmentRetrieveResults(plan, streamingResults)
 		if err != nil {
 			return nil, err
 		}
 
 		// complete results with merged streaming result
 		results = append(results, &internalpb.RetrieveResults{
-			Status:     &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
-			Ids:        streamingResult.Ids,
-			FieldsData: streamingResult.FieldsData,
+			Status:       &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			Ids:          streamingResult.Ids,
+			FieldsData:   streamingResult.FieldsData,
+			MatchedCount: streamingResult.MatchedCount,
 		})
+		var skippedSegments int64
+		for _, result := range results {
+			skippedSegments += result.GetSkippedSegments()
+		}
 		// merge shard query results
-		mergedResults, err := mergeInternalRetrieveResults(results)
+		mergedResults, err := mergeShardRetrieveResults(plan, results)
 		if err != nil {
 			return nil, err
 		}
+		if err := mask.maskFieldsData(mergedResults.FieldsData); err != nil {
+			return nil, err
+		}
+		mergedResults.SkippedSegments = skippedSegments
+		mergedResults.ReadTimestamp = readTs
+		mergedResults.DistributionDigest = digest
 		log.Debug("leader retrieve result", zap.String("channel", req.DmlChannel), zap.String("ids", mergedResults.Ids.String()))
 		return mergedResults, nil
 	}
 	q.historical.replica.queryRLock()
 	defer q.historical.replica.queryRUnlock()
 	// hold request until guarantee timestamp >= service timestamp
-	guaranteeTs := req.GetReq().GetGuaranteeTimestamp()
 	q.waitUntilServiceable(ctx, guaranteeTs, tsTypeDelta)
 	// shard follower considers solely historical segments
-	retrieveResults, err := q.historical.retrieveBySegmentIDs(collectionID, segmentIDs, q.vectorChunkManager, plan)
+	retrieveResults, err := q.historical.retrieveBySegmentIDs(collectionID, segmentIDs, vcm, plan)
 	if err != nil {
 		return nil, err
 	}
-	mergedResult, err := mergeRetrieveResults(retrieveResults)
+	mergedResult, err := mergeSegmentRetrieveResults(plan, retrieveResults)
 	if err != nil {
 		return nil, err
 	}
+	if err := mask.maskFieldsData(mergedResult.FieldsData); err != nil {
+		return nil, err
+	}
 
 	log.Debug("follower retrieve result", zap.String("ids", mergedResult.Ids.String()))
 	RetrieveResults := &internalpb.RetrieveResults{
-		Status:     &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
-		Ids:        mergedResult.Ids,
-		FieldsData: mergedResult.FieldsData,
+		Status:       &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Ids:          mergedResult.Ids,
+		FieldsData:   mergedResult.FieldsData,
+		MatchedCount: mergedResult.MatchedCount,
 	}
 	return RetrieveResults, nil
 }
@@ -46,11 +46,11 @@ type queryShardService struct {
 	localChunkManager   storage.ChunkManager
 	remoteChunkManager  storage.ChunkManager
 	localCacheEnabled   bool
-}
 
-func newQueryShardService(ctx context.Context, historical *historical, streaming *streaming, clusterService *ShardClusterService, factory dependency.Factory) *queryShardService {
-	queryShardServiceCtx, queryShardServiceCancel := context.WithCancel(ctx)
+	config *QueryNodeConfig
+}
 
+func newQueryShardService(ctx context.Context, historical *historical, streaming *streaming, clusterService *ShardClusterService, factory dependency.Factory, config *QueryNodeConfig) *queryShardService {
 	path := Params.LoadWithDefault("localStorage.Path", "/tmp/milvus/data")
 	enabled, _ := Params.Load("localStorage.enabled")
 	localCacheEnabled, _ := strconv.ParseBool(enabled)
@@ -64,6 +64,17 @@ func newQueryShardService(ctx context.Context, historical *historical, streaming
 		storage.BucketName(Params.MinioCfg.BucketName),
 		storage.CreateBucket(true))
 
+	return newQueryShardServiceWithChunkManagers(ctx, historical, streaming, clusterService, factory, config,
+		localChunkManager, remoteChunkManager, localCacheEnabled)
+}
+
+// newQueryShardServiceWithChunkManagers returns the query shard service reading the vector fields not in memory
+// from remoteChunkManager, cached in localChunkManager if localCacheEnabled
+func newQueryShardServiceWithChunkManagers(ctx context.Context, historical *historical, streaming *streaming, clusterService *ShardClusterService,
+	factory dependency.Factory, config *QueryNodeConfig, localChunkManager storage.ChunkManager, remoteChunkManager storage.ChunkManager,
+	localCacheEnabled bool) *queryShardService {
+	queryShardServiceCtx, queryShardServiceCancel := context.WithCancel(ctx)
+
 	qss := &queryShardService{
 		ctx:                 queryShardServiceCtx,
 		cancel:              queryShardServiceCancel,
@@ -76,6 +87,7 @@ func newQueryShardService(ctx context.Context, historical *historical, streaming
 		remoteChunkManager:  remoteChunkManager,
 		localCacheEnabled:   localCacheEnabled,
 		factory:             factory,
+		config:              config,
 	}
 	return qss
 }
@@ -97,6 +109,7 @@ func (q *queryShardService) addQueryShard(collectionID UniqueID, channel Channel
 		q.localChunkManager,
 		q.remoteChunkManager,
 		q.localCacheEnabled,
+		q.config,
 	)
 	q.queryShards[channel] = qs
 	return nil
@@ -18,15 +18,25 @@ package querynode
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
 func genSimpleQueryShard(ctx context.Context) (*queryShard, error) {
@@ -59,7 +69,7 @@ func genSimpleQueryShard(ctx context.Context) (*queryShard, error) {
 	shardClusterService.clusters.Store(defaultDMLChannel, shardCluster)
 
 	qs := newQueryShard(ctx, defaultCollectionID, defaultDMLChannel, defaultReplicaID, shardClusterService,
-		historical, streaming, localCM, remoteCM, false)
+		historical, streaming, localCM, remoteCM, false, newQueryNodeConfig())
 	qs.deltaChannel = defaultDeltaChannel
 
 	err = qs.watchDMLTSafe()
@@ -119,6 +129,49 @@ func TestQueryShard_Search(t *testing.T) {
 	})
 }
 
+func TestQueryShard_SearchDedup(t *testing.T) {
+	qs, err := genSimpleQueryShard(context.Background())
+	require.NoError(t, err)
+
+	req, err := genSimpleSearchRequest(IndexFaissIDMap)
+	require.NoError(t, err)
+	req.PlaceholderGroup, err = genDuplicatePlaceHolderGroup(100, 5)
+	require.NoError(t, err)
+	placeholderGroup := req.PlaceholderGroup
+
+	search := func(enableDedup bool, segmentIDs []int64, dmlChannel string) *schemapb.SearchResultData {
+		updateDynamicConfig(qs.config, func(dynamic *DynamicQueryNodeConfig) { dynamic.EnableSearchDedup = enableDedup })
+		results, err := qs.search(context.Background(), &querypb.SearchRequest{
+			Req:        req,
+			DmlChannel: dmlChannel,
+			SegmentIDs: segmentIDs,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), results.GetNumQueries())
+		// the request of caller is kept intact
+		assert.Equal(t, placeholderGroup, req.GetPlaceholderGroup())
+		data := &schemapb.SearchResultData{}
+		require.NoError(t, proto.Unmarshal(results.GetSlicedBlob(), data))
+		return data
+	}
+
+	t.Run("search follower", func(t *testing.T) {
+		metrics.QueryNodeSearchDedupRatio.Reset()
+		expected := search(false, []int64{defaultSegmentID}, "")
+		assert.Equal(t, 0, testutil.CollectAndCount(metrics.QueryNodeSearchDedupRatio))
+		result := search(true, []int64{defaultSegmentID}, "")
+		assert.Equal(t, 1, testutil.CollectAndCount(metrics.QueryNodeSearchDedupRatio))
+		assert.Equal(t, int64(100), result.GetNumQueries())
+		assert.True(t, proto.Equal(expected, result))
+	})
+
+	t.Run("search leader", func(t *testing.T) {
+		expected := search(false, []int64{}, defaultDMLChannel)
+		result := search(true, []int64{}, defaultDMLChannel)
+		assert.True(t, proto.Equal(expected, result))
+	})
+}
+
 func TestQueryShard_Query(t *testing.T) {
 	qs, err := genSimpleQueryShard(context.Background())
 	assert.NoError(t, err)
@@ -176,6 +229,278 @@ func TestQueryShard_WaitUntilServiceable(t *testing.T) {
 	qs.waitUntilServiceable(context.Background(), 1000, tsTypeDML)
 }
 
+func TestQueryShard_getAppliedTs(t *testing.T) {
+	qs, err := genSimpleQueryShard(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Timestamp(0), qs.getAppliedTs())
+
+	qs.setServiceableTime(1000, tsTypeDML)
+	assert.Equal(t, Timestamp(0), qs.getAppliedTs())
+	qs.setServiceableTime(2000, tsTypeDelta)
+	assert.Equal(t, Timestamp(1000), qs.getAppliedTs())
+	qs.setServiceableTime(3000, tsTypeDML)
+	assert.Equal(t, Timestamp(2000), qs.getAppliedTs())
+}
+
+func TestQueryShard_WaitingDeadline(t *testing.T) {
+	qs, err := genSimpleQueryShard(context.Background())
+	assert.NoError(t, err)
+	err = updateQueryShardTSafe(qs, 1000)
+	assert.NoError(t, err)
+
+	_, ok := qs.earliestWaitingDeadline()
+	assert.False(t, ok)
+
+	// requests without deadline or already serviceable are not tracked
+	qs.waitUntilServiceable(context.Background(), 1000, tsTypeDML)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	qs.waitUntilServiceable(ctx, 1000, tsTypeDML)
+	_, ok = qs.earliestWaitingDeadline()
+	assert.False(t, ok)
+
+	ctx1, cancel1 := context.WithTimeout(context.Background(), time.Minute)
+	ctx2, cancel2 := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel2()
+	done := make(chan struct{})
+	go func() {
+		qs.waitUntilServiceable(ctx1, 2000, tsTypeDML)
+		qs.waitUntilServiceable(ctx2, 2000, tsTypeDML)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool {
+		_, ok := qs.earliestWaitingDeadline()
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	deadline, ok := qs.earliestWaitingDeadline()
+	assert.True(t, ok)
+	expected, _ := ctx1.Deadline()
+	assert.Equal(t, expected, deadline)
+
+	// the request is untracked once it times out
+	cancel1()
+	assert.Eventually(t, func() bool {
+		deadline, ok := qs.earliestWaitingDeadline()
+		expected, _ := ctx2.Deadline()
+		return ok && deadline.Equal(expected)
+	}, time.Second, 10*time.Millisecond)
+
+	err = updateQueryShardTSafe(qs, 2000)
+	assert.NoError(t, err)
+	<-done
+	_, ok = qs.earliestWaitingDeadline()
+	assert.False(t, ok)
+}
+
+func TestQueryShard_SnapshotTs(t *testing.T) {
+	qs, err := genSimpleQueryShard(context.Background())
+	assert.NoError(t, err)
+
+	segment, err := qs.streaming.replica.getSegmentByID(defaultSegmentID)
+	assert.NoError(t, err)
+	records, err := genSimpleCommonBlob()
+	assert.NoError(t, err)
+	insert := func(rows []int, ts Timestamp) {
+		ids := make([]int64, 0, len(rows))
+		timestamps := make([]Timestamp, 0, len(rows))
+		blobs := make([]*commonpb.Blob, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, int64(row))
+			timestamps = append(timestamps, ts)
+			blobs = append(blobs, records[row])
+		}
+		offset, err := segment.segmentPreInsert(len(rows))
+		assert.NoError(t, err)
+		err = segment.segmentInsert(offset, &ids, &timestamps, &blobs)
+		assert.NoError(t, err)
+		qs.setServiceableTime(ts, tsTypeDML)
+		qs.setServiceableTime(ts, tsTypeDelta)
+	}
+
+	queryAt := func(snapshotTs Timestamp) (*internalpb.RetrieveResults, error) {
+		req, err := genSimpleRetrieveRequest()
+		assert.NoError(t, err)
+		req.SnapshotTimestamp = snapshotTs
+		return qs.query(context.Background(), &querypb.QueryRequest{
+			Req:        req,
+			DmlChannel: defaultDMLChannel,
+		})
+	}
+
+	insert([]int{1}, 100)
+	pinned, err := queryAt(100)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{1}, pinned.GetIds().GetIntId().GetData())
+
+	// interleaved inserts after the snapshot are invisible at the pinned ts
+	insert([]int{2, 3}, 200)
+	again, err := queryAt(100)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, pinned.GetIds().GetIntId().GetData(), again.GetIds().GetIntId().GetData())
+
+	latest, err := queryAt(200)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{1, 2, 3}, latest.GetIds().GetIntId().GetData())
+	assert.Equal(t, Timestamp(100), pinned.GetReadTimestamp())
+	assert.Equal(t, Timestamp(200), latest.GetReadTimestamp())
+
+	t.Run("read ts bounded by tSafe", func(t *testing.T) {
+		req, err := genSimpleRetrieveRequest()
+		assert.NoError(t, err)
+		req.TravelTimestamp = 300
+		req.GuaranteeTimestamp = 0
+		results, err := qs.query(context.Background(), &querypb.QueryRequest{
+			Req:        req,
+			DmlChannel: defaultDMLChannel,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, Timestamp(200), results.GetReadTimestamp())
+
+		searchReq, err := genSimpleSearchRequest(IndexFaissIDMap)
+		assert.NoError(t, err)
+		searchReq.TravelTimestamp = 150
+		searchReq.GuaranteeTimestamp = 0
+		searchResults, err := qs.search(context.Background(), &querypb.SearchRequest{
+			Req:        searchReq,
+			DmlChannel: defaultDMLChannel,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, Timestamp(150), searchResults.GetReadTimestamp())
+	})
+
+	t.Run("search at snapshot", func(t *testing.T) {
+		req, err := genSimpleSearchRequest(IndexFaissIDMap)
+		assert.NoError(t, err)
+		req.SnapshotTimestamp = 100
+		request := &querypb.SearchRequest{
+			Req:        req,
+			DmlChannel: defaultDMLChannel,
+		}
+		_, err = qs.search(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, Timestamp(100), request.GetReq().GetTravelTimestamp())
+		assert.Equal(t, Timestamp(100), request.GetReq().GetGuaranteeTimestamp())
+	})
+
+	t.Run("not serviceable", func(t *testing.T) {
+		_, err := queryAt(300)
+		assert.Error(t, err)
+		var notServiceable *snapshotTsNotServiceableError
+		assert.True(t, errors.As(err, &notServiceable))
+
+		req, err := genSimpleSearchRequest(IndexFaissIDMap)
+		assert.NoError(t, err)
+		req.SnapshotTimestamp = 300
+		_, err = qs.search(context.Background(), &querypb.SearchRequest{
+			Req:        req,
+			DmlChannel: defaultDMLChannel,
+		})
+		assert.True(t, errors.As(err, &notServiceable))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		now := tsoutil.ComposeTSByTime(time.Now(), 0)
+		qs.setServiceableTime(now, tsTypeDML)
+		qs.setServiceableTime(now, tsTypeDelta)
+
+		_, err := queryAt(100)
+		assert.Error(t, err)
+		var expired *snapshotTsExpiredError
+		assert.True(t, errors.As(err, &expired))
+
+		// followers check the retention boundary as well
+		req, err := genSimpleRetrieveRequest()
+		assert.NoError(t, err)
+		req.SnapshotTimestamp = 100
+		_, err = qs.query(context.Background(), &querypb.QueryRequest{
+			Req:        req,
+			SegmentIDs: []int64{defaultSegmentID},
+		})
+		assert.True(t, errors.As(err, &expired))
+	})
+}
+
+func TestQueryShard_boundGuaranteeTs(t *testing.T) {
+	qs, err := genSimpleQueryShard(context.Background())
+	assert.NoError(t, err)
+
+	updateDynamicConfig(qs.config, func(dynamic *DynamicQueryNodeConfig) {
+		dynamic.MaxGuaranteeTsLag = time.Minute
+		dynamic.StrictGuaranteeTs = false
+	})
+
+	now := time.Now()
+	skewed := tsoutil.ComposeTSByTime(now.Add(time.Hour), 0)
+
+	// no bound before the first tSafe
+	ts, err := qs.boundGuaranteeTs(skewed, true)
+	assert.NoError(t, err)
+	assert.Equal(t, skewed, ts)
+
+	tSafe := tsoutil.ComposeTSByTime(now, 0)
+	qs.setServiceableTime(tSafe, tsTypeDML)
+	qs.setServiceableTime(tSafe, tsTypeDelta)
+
+	t.Run("normal", func(t *testing.T) {
+		guaranteeTs := tsoutil.ComposeTSByTime(now.Add(time.Second), 0)
+		ts, err := qs.boundGuaranteeTs(guaranteeTs, true)
+		assert.NoError(t, err)
+		assert.Equal(t, guaranteeTs, ts)
+
+		ts, err = qs.boundGuaranteeTs(tSafe, false)
+		assert.NoError(t, err)
+		assert.Equal(t, tSafe, ts)
+	})
+
+	t.Run("clamp", func(t *testing.T) {
+		clamped := metrics.QueryNodeSkewedGuaranteeTs.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), metrics.ClampLabel)
+		before := testutil.ToFloat64(clamped)
+		for _, isLeader := range []bool{true, false} {
+			ts, err := qs.boundGuaranteeTs(skewed, isLeader)
+			assert.NoError(t, err)
+			assert.Equal(t, tsoutil.AddPhysicalDurationOnTs(time.Minute, tSafe), ts)
+		}
+		assert.Equal(t, before+2, testutil.ToFloat64(clamped))
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		updateDynamicConfig(qs.config, func(dynamic *DynamicQueryNodeConfig) { dynamic.StrictGuaranteeTs = true })
+		defer updateDynamicConfig(qs.config, func(dynamic *DynamicQueryNodeConfig) { dynamic.StrictGuaranteeTs = false })
+
+		_, err := qs.boundGuaranteeTs(skewed, true)
+		var tooFarAhead *guaranteeTsTooFarAheadError
+		assert.True(t, errors.As(err, &tooFarAhead))
+		assert.Equal(t, tSafe, tooFarAhead.tSafe)
+
+		req, err := genSimpleRetrieveRequest()
+		assert.NoError(t, err)
+		req.GuaranteeTimestamp = skewed
+		_, err = qs.query(context.Background(), &querypb.QueryRequest{
+			Req:        req,
+			DmlChannel: defaultDMLChannel,
+		})
+		assert.True(t, errors.As(err, &tooFarAhead))
+
+		searchReq, err := genSimpleSearchRequest(IndexFaissIDMap)
+		assert.NoError(t, err)
+		searchReq.GuaranteeTimestamp = skewed
+		_, err = qs.search(context.Background(), &querypb.SearchRequest{
+			Req:        searchReq,
+			SegmentIDs: []int64{defaultSegmentID},
+		})
+		assert.True(t, errors.As(err, &tooFarAhead))
+	})
+
+	t.Run("no bound", func(t *testing.T) {
+		updateDynamicConfig(qs.config, func(dynamic *DynamicQueryNodeConfig) { dynamic.MaxGuaranteeTsLag = 0 })
+		defer updateDynamicConfig(qs.config, func(dynamic *DynamicQueryNodeConfig) { dynamic.MaxGuaranteeTsLag = time.Minute })
+
+		ts, err := qs.boundGuaranteeTs(skewed, true)
+		assert.NoError(t, err)
+		assert.Equal(t, skewed, ts)
+	})
+}
+
 func genSearchResultData(nq int64, topk int64, ids []int64, scores []float32) *schemapb.SearchResultData {
 	return &schemapb.SearchResultData{
 		NumQueries: nq,
@@ -226,6 +551,44 @@ func TestReduceSearchResultData(t *testing.T) {
 		assert.Nil(t, err)
 		assert.ElementsMatch(t, []int64{1, 5, 2, 3}, res.Ids.GetIntId().Data)
 	})
+	t.Run("padded result passes validation", func(t *testing.T) {
+		ids := []int64{1, 2, -1, -1}
+		scores := []float32{-1.0, -2.0, -3.0, -4.0}
+		data := genSearchResultData(nq, topk, ids, scores)
+		res, err := reduceSearchResultData([]*schemapb.SearchResultData{data}, nq, topk, metricType)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{topk}, res.Topks)
+		assert.Equal(t, []int64{1, 2, -1, -1}, res.Ids.GetIntId().Data)
+		assert.NoError(t, typeutil.ValidateSearchResultData(res))
+	})
+	t.Run("timestamps of chosen duplicates", func(t *testing.T) {
+		data1 := genSearchResultData(nq, topk, []int64{1, 2, 3, 4}, []float32{-1.0, -2.0, -3.0, -4.0})
+		data1.Timestamps = []uint64{11, 12, 13, 14}
+		data2 := genSearchResultData(nq, topk, []int64{5, 1, 3, 4}, []float32{-1.0, -1.5, -3.0, -4.0})
+		data2.Timestamps = []uint64{25, 21, 23, 24}
+		res, err := reduceSearchResultData([]*schemapb.SearchResultData{data1, data2}, nq, topk, metricType)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 5, 2, 3}, res.Ids.GetIntId().Data)
+		assert.Equal(t, []uint64{11, 25, 12, 13}, res.Timestamps)
+	})
+	t.Run("padded timestamps", func(t *testing.T) {
+		data := genSearchResultData(nq, topk, []int64{1, 2, -1, -1}, []float32{-1.0, -2.0, -3.0, -4.0})
+		data.Timestamps = []uint64{7, 8, 0, 0}
+		res, err := reduceSearchResultData([]*schemapb.SearchResultData{data}, nq, topk, metricType)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{7, 8, 0, 0}, res.Timestamps)
+		assert.NoError(t, typeutil.ValidateSearchResultData(res))
+
+		data.Timestamps = nil
+		res, err = reduceSearchResultData([]*schemapb.SearchResultData{data}, nq, topk, metricType)
+		assert.NoError(t, err)
+		assert.Nil(t, res.Timestamps)
+	})
+	t.Run("empty result passes validation", func(t *testing.T) {
+		res, err := reduceSearchResultData(nil, nq, topk, metricType)
+		assert.NoError(t, err)
+		assert.NoError(t, typeutil.ValidateSearchResultData(res))
+	})
 }
 
 func TestMergeInternalRetrieveResults(t *testing.T) {
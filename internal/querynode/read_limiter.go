@@ -0,0 +1,191 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+// collectionReadLimiter caps the concurrent search and query requests of each collection on the query node, so that
+// a hot collection never starves its co-tenants. The requests beyond the cap of their collection wait in FIFO order,
+// at most queueLimit of them per collection, and the others are rejected by readRateLimitedError. The collections
+// without cap are not limited. The zero value rejects all the requests beyond the caps until the queue limit is set,
+// all methods are safe for concurrent use.
+type collectionReadLimiter struct {
+	mu          sync.Mutex
+	queueLimit  int
+	collections map[UniqueID]*collectionReadLimit
+}
+
+// collectionReadLimit is the cap and the reads of a collection
+type collectionReadLimit struct {
+	collectionID UniqueID
+	maxReads     int64 // 0 means no cap
+	inflight     int64
+	rejected     int64
+	waiters      []chan struct{}
+}
+
+// setQueueLimit sets the max number of waiting requests of each collection, the waiting requests are kept
+func (l *collectionReadLimiter) setQueueLimit(queueLimit int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.queueLimit = queueLimit
+}
+
+// setCap updates the cap of the concurrent reads of collection, 0 means no cap. The waiting requests are let in
+// at once if the cap is raised, and the in-flight ones are never interrupted if it's lowered
+func (l *collectionReadLimiter) setCap(collectionID UniqueID, maxReads int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limit := l.getLimit(collectionID)
+	limit.maxReads = maxReads
+	limit.grant()
+	l.observe(limit)
+}
+
+// getCap returns the cap of the concurrent reads of collection, 0 if not capped
+func (l *collectionReadLimiter) getCap(collectionID UniqueID) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limit, ok := l.collections[collectionID]; ok {
+		return limit.maxReads
+	}
+	return 0
+}
+
+// removeCollection drops the cap and the counters of the released collection, the waiting requests are let in
+func (l *collectionReadLimiter) removeCollection(collectionID UniqueID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limit, ok := l.collections[collectionID]
+	if !ok {
+		return
+	}
+	limit.maxReads = 0
+	limit.grant()
+	delete(l.collections, collectionID)
+	nodeID := fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)
+	metrics.QueryNodeCollectionReadInflight.DeleteLabelValues(nodeID, fmt.Sprint(collectionID))
+	metrics.QueryNodeCollectionReadRejected.DeleteLabelValues(nodeID, fmt.Sprint(collectionID))
+}
+
+// acquire waits for a permit of a read request of collection, the returned release must be called once the request
+// is done. The request is rejected by readRateLimitedError if the queue of the collection is full, or fails with
+// the error of ctx if ctx is done while waiting
+func (l *collectionReadLimiter) acquire(ctx context.Context, collectionID UniqueID) (release func(), err error) {
+	l.mu.Lock()
+	limit := l.getLimit(collectionID)
+	if len(limit.waiters) == 0 && (limit.maxReads <= 0 || limit.inflight < limit.maxReads) {
+		limit.inflight++
+		l.observe(limit)
+		l.mu.Unlock()
+		return l.releaseFunc(limit), nil
+	}
+	if len(limit.waiters) >= l.queueLimit {
+		limit.rejected++
+		err := &readRateLimitedError{collectionID: collectionID, maxReads: limit.maxReads, queued: len(limit.waiters)}
+		l.mu.Unlock()
+		metrics.QueryNodeCollectionReadRejected.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), fmt.Sprint(collectionID)).Inc()
+		return nil, err
+	}
+	waiter := make(chan struct{})
+	limit.waiters = append(limit.waiters, waiter)
+	l.mu.Unlock()
+
+	select {
+	case <-waiter:
+		return l.releaseFunc(limit), nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		granted := !limit.removeWaiter(waiter)
+		l.mu.Unlock()
+		if granted {
+			l.releaseFunc(limit)()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// getStats returns the number of in-flight, waiting and rejected requests of collection
+func (l *collectionReadLimiter) getStats(collectionID UniqueID) (inflight int64, queued int, rejected int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limit, ok := l.collections[collectionID]; ok {
+		return limit.inflight, len(limit.waiters), limit.rejected
+	}
+	return 0, 0, 0
+}
+
+func (l *collectionReadLimiter) releaseFunc(limit *collectionReadLimit) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			limit.inflight--
+			limit.grant()
+			l.observe(limit)
+		})
+	}
+}
+
+// getLimit returns the limit of collection, it's called with mu held
+func (l *collectionReadLimiter) getLimit(collectionID UniqueID) *collectionReadLimit {
+	if l.collections == nil {
+		l.collections = make(map[UniqueID]*collectionReadLimit)
+	}
+	limit, ok := l.collections[collectionID]
+	if !ok {
+		limit = &collectionReadLimit{collectionID: collectionID}
+		l.collections[collectionID] = limit
+	}
+	return limit
+}
+
+// observe reports the in-flight requests of limit unless its collection is removed, it's called with mu held
+func (l *collectionReadLimiter) observe(limit *collectionReadLimit) {
+	if l.collections[limit.collectionID] != limit {
+		return
+	}
+	metrics.QueryNodeCollectionReadInflight.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID),
+		fmt.Sprint(limit.collectionID)).Set(float64(limit.inflight))
+}
+
+// grant lets in the waiting requests within the cap in FIFO order
+func (limit *collectionReadLimit) grant() {
+	for len(limit.waiters) > 0 && (limit.maxReads <= 0 || limit.inflight < limit.maxReads) {
+		close(limit.waiters[0])
+		limit.waiters = limit.waiters[1:]
+		limit.inflight++
+	}
+}
+
+// removeWaiter removes waiter from the queue, returns false if it has been granted
+func (limit *collectionReadLimit) removeWaiter(waiter chan struct{}) bool {
+	for i, w := range limit.waiters {
+		if w == waiter {
+			limit.waiters = append(limit.waiters[:i], limit.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	queryPb "github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+// acquireAsync acquires a permit in background, the result is sent to the returned channel
+func acquireAsync(l *collectionReadLimiter, ctx context.Context, collectionID UniqueID) <-chan error {
+	ch := make(chan error, 1)
+	go func() {
+		release, err := l.acquire(ctx, collectionID)
+		if err == nil {
+			defer release()
+		}
+		ch <- err
+	}()
+	return ch
+}
+
+func waitQueued(t *testing.T, l *collectionReadLimiter, collectionID UniqueID, queued int) {
+	assert.Eventually(t, func() bool {
+		_, n, _ := l.getStats(collectionID)
+		return n == queued
+	}, time.Second, time.Millisecond)
+}
+
+func TestCollectionReadLimiter_saturate(t *testing.T) {
+	ctx := context.Background()
+	l := &collectionReadLimiter{}
+	l.setQueueLimit(1)
+	l.setCap(defaultCollectionID, 2)
+
+	// saturate the cap of the collection
+	releases := make([]func(), 0, 2)
+	for i := 0; i < 2; i++ {
+		release, err := l.acquire(ctx, defaultCollectionID)
+		require.NoError(t, err)
+		releases = append(releases, release)
+	}
+	waiting := acquireAsync(l, ctx, defaultCollectionID)
+	waitQueued(t, l, defaultCollectionID, 1)
+
+	// the queue is full
+	_, err := l.acquire(ctx, defaultCollectionID)
+	var rateLimitedErr *readRateLimitedError
+	require.True(t, errors.As(err, &rateLimitedErr))
+	assert.Equal(t, commonpb.ErrorCode_RateLimit, errorCodeOf(err))
+	inflight, queued, rejected := l.getStats(defaultCollectionID)
+	assert.Equal(t, int64(2), inflight)
+	assert.Equal(t, 1, queued)
+	assert.Equal(t, int64(1), rejected)
+	nodeID, collectionID := fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), fmt.Sprint(defaultCollectionID)
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.QueryNodeCollectionReadInflight.WithLabelValues(nodeID, collectionID)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.QueryNodeCollectionReadRejected.WithLabelValues(nodeID, collectionID)))
+
+	// another collection is unaffected, capped or not
+	otherID := defaultCollectionID + 1
+	for i := 0; i < 10; i++ {
+		release, err := l.acquire(ctx, otherID)
+		require.NoError(t, err)
+		defer release()
+	}
+	l.setCap(otherID+1, 1)
+	release, err := l.acquire(ctx, otherID+1)
+	require.NoError(t, err)
+	release()
+
+	// the waiting request is let in once a permit is released, and releasing twice is a no-op
+	releases[0]()
+	releases[0]()
+	assert.NoError(t, <-waiting)
+	releases[1]()
+	inflight, queued, _ = l.getStats(defaultCollectionID)
+	assert.Equal(t, int64(0), inflight)
+	assert.Equal(t, 0, queued)
+}
+
+func TestCollectionReadLimiter_updateCap(t *testing.T) {
+	ctx := context.Background()
+	l := &collectionReadLimiter{}
+	l.setQueueLimit(10)
+	l.setCap(defaultCollectionID, 1)
+
+	release, err := l.acquire(ctx, defaultCollectionID)
+	require.NoError(t, err)
+	first := acquireAsync(l, ctx, defaultCollectionID)
+	second := acquireAsync(l, ctx, defaultCollectionID)
+	waitQueued(t, l, defaultCollectionID, 2)
+
+	// raising the cap lets the waiting requests in at once
+	l.setCap(defaultCollectionID, 3)
+	assert.NoError(t, <-first)
+	assert.NoError(t, <-second)
+
+	// lowering the cap never interrupts the in-flight requests
+	l.setCap(defaultCollectionID, 1)
+	assert.Equal(t, int64(1), l.getCap(defaultCollectionID))
+	waiting := acquireAsync(l, ctx, defaultCollectionID)
+	waitQueued(t, l, defaultCollectionID, 1)
+	release()
+	assert.NoError(t, <-waiting)
+
+	// no cap
+	l.setCap(defaultCollectionID, 0)
+	for i := 0; i < 10; i++ {
+		release, err := l.acquire(ctx, defaultCollectionID)
+		require.NoError(t, err)
+		defer release()
+	}
+}
+
+func TestCollectionReadLimiter_cancel(t *testing.T) {
+	l := &collectionReadLimiter{}
+	l.setQueueLimit(1)
+	l.setCap(defaultCollectionID, 1)
+	release, err := l.acquire(context.Background(), defaultCollectionID)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	waiting := acquireAsync(l, ctx, defaultCollectionID)
+	waitQueued(t, l, defaultCollectionID, 1)
+	cancel()
+	assert.ErrorIs(t, <-waiting, context.Canceled)
+	inflight, queued, _ := l.getStats(defaultCollectionID)
+	assert.Equal(t, int64(1), inflight)
+	assert.Equal(t, 0, queued)
+	release()
+}
+
+func TestCollectionReadLimiter_removeCollection(t *testing.T) {
+	ctx := context.Background()
+	l := &collectionReadLimiter{}
+	l.setQueueLimit(1)
+	l.setCap(defaultCollectionID, 1)
+	release, err := l.acquire(ctx, defaultCollectionID)
+	require.NoError(t, err)
+	waiting := acquireAsync(l, ctx, defaultCollectionID)
+	waitQueued(t, l, defaultCollectionID, 1)
+
+	// the waiting requests are let in, and the cap is dropped
+	l.removeCollection(defaultCollectionID)
+	assert.NoError(t, <-waiting)
+	release()
+	assert.Equal(t, int64(0), l.getCap(defaultCollectionID))
+	inflight, queued, rejected := l.getStats(defaultCollectionID)
+	assert.Equal(t, int64(0), inflight)
+	assert.Equal(t, 0, queued)
+	assert.Equal(t, int64(0), rejected)
+
+	l.removeCollection(defaultCollectionID)
+}
+
+func TestImpl_readLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	node.queryShardService.addQueryShard(defaultCollectionID, defaultDMLChannel, defaultReplicaID)
+	node.readLimiter.setQueueLimit(0)
+	node.readLimiter.setCap(defaultCollectionID, 1)
+	release, err := node.readLimiter.acquire(ctx, defaultCollectionID)
+	require.NoError(t, err)
+	defer release()
+
+	searchReq, err := genSimpleSearchRequest(IndexFaissIDMap)
+	require.NoError(t, err)
+	searchResults, err := node.Search(ctx, &queryPb.SearchRequest{Req: searchReq, DmlChannel: defaultDMLChannel})
+	require.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_RateLimit, searchResults.GetStatus().GetErrorCode())
+
+	retrieveReq, err := genSimpleRetrieveRequest()
+	require.NoError(t, err)
+	retrieveResults, err := node.Query(ctx, &queryPb.QueryRequest{Req: retrieveReq, DmlChannel: defaultDMLChannel})
+	require.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_RateLimit, retrieveResults.GetStatus().GetErrorCode())
+
+	// the requests of the shard leader are never capped
+	retrieveResults, err = node.Query(ctx, &queryPb.QueryRequest{Req: retrieveReq, DmlChannel: defaultDMLChannel,
+		SegmentIDs: []UniqueID{defaultSegmentID}})
+	require.NoError(t, err)
+	assert.NotEqual(t, commonpb.ErrorCode_RateLimit, retrieveResults.GetStatus().GetErrorCode())
+
+	// raising the cap takes effect without releasing the collection
+	_, err = node.UpdateLoadConfig(ctx, &queryPb.UpdateLoadConfigRequest{
+		CollectionID: defaultCollectionID,
+		Configs:      []*commonpb.KeyValuePair{{Key: loadConfigMaxConcurrentReads, Value: "2"}},
+	})
+	require.NoError(t, err)
+	retrieveResults, err = node.Query(ctx, &queryPb.QueryRequest{Req: retrieveReq, DmlChannel: defaultDMLChannel})
+	require.NoError(t, err)
+	assert.NotEqual(t, commonpb.ErrorCode_RateLimit, retrieveResults.GetStatus().GetErrorCode())
+}
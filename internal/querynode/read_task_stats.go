@@ -0,0 +1,101 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+)
+
+// readLatencyWeight is the weight of the latest latency in the moving average of read latency
+const readLatencyWeight = 0.2
+
+// readTaskStats keeps the number of in-flight search and query requests, and the moving average
+// of their latency. The zero value is ready to use, all methods are safe for concurrent use.
+type readTaskStats struct {
+	inflight   atomic.Int64
+	avgLatency atomic.Int64 // nanoseconds
+
+	mu       sync.Mutex
+	requests map[UniqueID]int64 // read requests per collection since the last takeRequests
+}
+
+// begin records a read request entering, the returned function must be called when it finishes
+func (s *readTaskStats) begin() func() {
+	s.inflight.Inc()
+	start := time.Now()
+	return func() {
+		s.inflight.Dec()
+		s.observeLatency(time.Since(start))
+	}
+}
+
+func (s *readTaskStats) observeLatency(latency time.Duration) {
+	for {
+		old := s.avgLatency.Load()
+		avg := int64(latency)
+		if old > 0 {
+			avg = int64(float64(old)*(1-readLatencyWeight) + float64(latency)*readLatencyWeight)
+		}
+		if s.avgLatency.CAS(old, avg) {
+			return
+		}
+	}
+}
+
+// countRequest records a read request of collectionID
+func (s *readTaskStats) countRequest(collectionID UniqueID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.requests == nil {
+		s.requests = make(map[UniqueID]int64)
+	}
+	s.requests[collectionID]++
+}
+
+// takeRequests returns the read requests per collection counted since the last call, and resets the counters
+func (s *readTaskStats) takeRequests() map[UniqueID]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := s.requests
+	s.requests = nil
+	return requests
+}
+
+// queueLength returns the number of read requests being served
+func (s *readTaskStats) queueLength() int64 {
+	return s.inflight.Load()
+}
+
+// estimatedWait returns the time a new read request is expected to wait for the ones ahead of it
+func (s *readTaskStats) estimatedWait() time.Duration {
+	return time.Duration(s.queueLength() * s.avgLatency.Load())
+}
+
+// extraInfo returns the stats as the extra info of component states
+func (s *readTaskStats) extraInfo() []*commonpb.KeyValuePair {
+	return []*commonpb.KeyValuePair{
+		{Key: common.ReadQueueLengthKey, Value: strconv.FormatInt(s.queueLength(), 10)},
+		{Key: common.ReadQueueWaitMsKey, Value: strconv.FormatInt(s.estimatedWait().Milliseconds(), 10)},
+	}
+}
@@ -0,0 +1,68 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+)
+
+func TestReadTaskStats(t *testing.T) {
+	var stats readTaskStats
+	assert.Equal(t, int64(0), stats.queueLength())
+	assert.Equal(t, time.Duration(0), stats.estimatedWait())
+
+	stats.observeLatency(100 * time.Millisecond)
+	done1 := stats.begin()
+	done2 := stats.begin()
+	assert.Equal(t, int64(2), stats.queueLength())
+	assert.Equal(t, 200*time.Millisecond, stats.estimatedWait())
+
+	queueLength, err := funcutil.GetAttrByKeyFromRepeatedKV(common.ReadQueueLengthKey, stats.extraInfo())
+	assert.NoError(t, err)
+	assert.Equal(t, "2", queueLength)
+	wait, err := funcutil.GetAttrByKeyFromRepeatedKV(common.ReadQueueWaitMsKey, stats.extraInfo())
+	assert.NoError(t, err)
+	assert.Equal(t, "200", wait)
+
+	done1()
+	done2()
+	assert.Equal(t, int64(0), stats.queueLength())
+	assert.Equal(t, time.Duration(0), stats.estimatedWait())
+
+	// moving average
+	stats.avgLatency.Store(int64(100 * time.Millisecond))
+	stats.observeLatency(200 * time.Millisecond)
+	assert.Equal(t, int64(120*time.Millisecond), stats.avgLatency.Load())
+}
+
+func TestReadTaskStats_requests(t *testing.T) {
+	var stats readTaskStats
+	assert.Empty(t, stats.takeRequests())
+
+	stats.countRequest(1)
+	stats.countRequest(2)
+	stats.countRequest(1)
+	assert.Equal(t, map[UniqueID]int64{1: 2, 2: 1}, stats.takeRequests())
+	// reset after taken
+	assert.Empty(t, stats.takeRequests())
+}
@@ -0,0 +1,262 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+// A record file is the on-disk format shared by the local persistence of QueryNode. It starts with a header of
+// the magic and the version of the layout of the records, which is owned by the user of the file, followed by
+// the records appended one by one:
+//
+//	| magic (4 bytes) | version (uint32) | length (uint32) | crc32 (uint32) | payload | length | crc32 | payload | ...
+//
+// The integers are little endian, and crc32 is the IEEE checksum of payload. A crash during appending leaves
+// a torn record at the end of file, which is truncated on recovery with everything after it.
+const (
+	recordHeaderSize = 8
+	recordFrameSize  = 8
+
+	// recordMaxSize bounds the length of a record, so that a corrupted length never allocates a huge payload
+	recordMaxSize = 64 << 20
+
+	recordTmpExt = ".tmp"
+)
+
+var recordMagic = []byte("MVRF")
+
+// recordReader reads the records of a record file one by one, the reading stops at the first torn or corrupted record
+type recordReader struct {
+	reader  *bufio.Reader
+	version uint32
+	offset  int64 // the end of the last intact record
+}
+
+// newRecordReader reads the header of the record file from r
+func newRecordReader(r io.Reader) (*recordReader, error) {
+	reader := bufio.NewReader(r)
+	header := make([]byte, recordHeaderSize)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, &recordCorruptedError{offset: 0, reason: "torn header"}
+	}
+	if !bytes.Equal(header[:len(recordMagic)], recordMagic) {
+		return nil, &recordCorruptedError{offset: 0, reason: "bad magic"}
+	}
+	return &recordReader{
+		reader:  reader,
+		version: binary.LittleEndian.Uint32(header[len(recordMagic):]),
+		offset:  recordHeaderSize,
+	}, nil
+}
+
+// next returns the next record, io.EOF if all the records are read, or recordCorruptedError at a torn
+// or corrupted record
+func (r *recordReader) next() ([]byte, error) {
+	frame := make([]byte, recordFrameSize)
+	n, err := io.ReadFull(r.reader, frame)
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, &recordCorruptedError{offset: r.offset, reason: fmt.Sprintf("torn frame of %d bytes", n)}
+	}
+	length := binary.LittleEndian.Uint32(frame)
+	checksum := binary.LittleEndian.Uint32(frame[4:])
+	if length > recordMaxSize {
+		return nil, &recordCorruptedError{offset: r.offset, reason: fmt.Sprintf("record length %d exceeds the limit", length)}
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r.reader, payload); err != nil {
+		return nil, &recordCorruptedError{offset: r.offset, reason: "torn payload"}
+	}
+	if crc32.ChecksumIEEE(payload) != checksum {
+		return nil, &recordCorruptedError{offset: r.offset, reason: "checksum mismatch"}
+	}
+	r.offset += recordFrameSize + int64(length)
+	return payload, nil
+}
+
+// readRecordFile reads the version and the records of the record file, the intact records before the first
+// torn or corrupted one are returned along with recordCorruptedError
+func readRecordFile(filePath string) (uint32, [][]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer file.Close()
+	reader, err := newRecordReader(file)
+	if err != nil {
+		return 0, nil, err
+	}
+	var records [][]byte
+	for {
+		record, err := reader.next()
+		if err == io.EOF {
+			return reader.version, records, nil
+		}
+		if err != nil {
+			return reader.version, records, err
+		}
+		records = append(records, record)
+	}
+}
+
+// writeRecordFile replaces the record file with the records by writing a temporary file and renaming it,
+// so that a crash never leaves a partially written file, it suits the snapshot-like files
+func writeRecordFile(filePath string, version uint32, records [][]byte) error {
+	var buf bytes.Buffer
+	buf.Write(encodeRecordHeader(version))
+	for _, record := range records {
+		if len(record) > recordMaxSize {
+			return fmt.Errorf("record length %d exceeds the limit", len(record))
+		}
+		buf.Write(encodeRecord(record))
+	}
+
+	tmpPath := filePath + recordTmpExt
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(buf.Bytes()); err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+// recordWriter appends records to a record file, it suits the log-like files
+type recordWriter struct {
+	file *os.File
+	size int64 // the end of the last appended record
+}
+
+// openRecordWriter opens the record file for appending, the file is created if not exists. The intact records
+// of the existing file are returned for replaying, and the torn or corrupted records are truncated,
+// the file is reset if even its header is corrupted. The version of the existing file must be version.
+func openRecordWriter(filePath string, version uint32) (*recordWriter, [][]byte, error) {
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+	w := &recordWriter{file: file}
+	records, err := w.recover(filePath, version)
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+	return w, records, nil
+}
+
+func (w *recordWriter) recover(filePath string, version uint32) ([][]byte, error) {
+	info, err := w.file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	var records [][]byte
+	if info.Size() > 0 {
+		reader, err := newRecordReader(io.NewSectionReader(w.file, 0, info.Size()))
+		if err != nil {
+			log.Warn("reset record file of corrupted header", zap.String("path", filePath), zap.Error(err))
+		} else {
+			if reader.version != version {
+				return nil, &recordVersionError{path: filePath, expected: version, actual: reader.version}
+			}
+			for {
+				record, err := reader.next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					log.Warn("truncate torn record file", zap.String("path", filePath), zap.Int64("size", info.Size()), zap.Error(err))
+					break
+				}
+				records = append(records, record)
+			}
+			w.size = reader.offset
+		}
+	}
+	if w.size == 0 {
+		if err := w.file.Truncate(0); err != nil {
+			return nil, err
+		}
+		if _, err := w.file.WriteAt(encodeRecordHeader(version), 0); err != nil {
+			return nil, err
+		}
+		w.size = recordHeaderSize
+	} else if w.size < info.Size() {
+		if err := w.file.Truncate(w.size); err != nil {
+			return nil, err
+		}
+	}
+	return records, w.file.Sync()
+}
+
+// append appends the record to the file, the file is truncated back if the record is written partially
+func (w *recordWriter) append(record []byte) error {
+	if len(record) > recordMaxSize {
+		return fmt.Errorf("record length %d exceeds the limit", len(record))
+	}
+	data := encodeRecord(record)
+	if _, err := w.file.WriteAt(data, w.size); err != nil {
+		_ = w.file.Truncate(w.size)
+		return err
+	}
+	w.size += int64(len(data))
+	return nil
+}
+
+// sync flushes the appended records to disk
+func (w *recordWriter) sync() error {
+	return w.file.Sync()
+}
+
+func (w *recordWriter) close() error {
+	return w.file.Close()
+}
+
+func encodeRecordHeader(version uint32) []byte {
+	header := make([]byte, recordHeaderSize)
+	copy(header, recordMagic)
+	binary.LittleEndian.PutUint32(header[len(recordMagic):], version)
+	return header
+}
+
+func encodeRecord(record []byte) []byte {
+	data := make([]byte, recordFrameSize+len(record))
+	binary.LittleEndian.PutUint32(data, uint32(len(record)))
+	binary.LittleEndian.PutUint32(data[4:], crc32.ChecksumIEEE(record))
+	copy(data[recordFrameSize:], record)
+	return data
+}
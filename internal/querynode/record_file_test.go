@@ -0,0 +1,227 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func genRecords(num int) [][]byte {
+	records := make([][]byte, 0, num)
+	for i := 0; i < num; i++ {
+		record := make([]byte, i*7)
+		rand.Read(record)
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestRecordFile_writeAndRead(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "snapshot")
+	records := genRecords(10)
+
+	require.NoError(t, writeRecordFile(filePath, 3, records))
+	version, read, err := readRecordFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), version)
+	assert.Equal(t, records, read)
+	_, err = os.Stat(filePath + recordTmpExt)
+	assert.True(t, os.IsNotExist(err))
+
+	t.Run("empty", func(t *testing.T) {
+		require.NoError(t, writeRecordFile(filePath, 1, nil))
+		version, read, err := readRecordFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), version)
+		assert.Empty(t, read)
+	})
+
+	t.Run("not exist", func(t *testing.T) {
+		_, _, err := readRecordFile(filepath.Join(dir, "not-exist"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("bad magic", func(t *testing.T) {
+		garbage := filepath.Join(dir, "garbage")
+		require.NoError(t, ioutil.WriteFile(garbage, []byte("not a record file"), 0644))
+		_, _, err := readRecordFile(garbage)
+		var corruptedErr *recordCorruptedError
+		require.True(t, errors.As(err, &corruptedErr))
+		assert.Equal(t, int64(0), corruptedErr.offset)
+	})
+
+	t.Run("too large length", func(t *testing.T) {
+		large := filepath.Join(dir, "large")
+		content := encodeRecordHeader(1)
+		frame := make([]byte, recordFrameSize)
+		binary.LittleEndian.PutUint32(frame, recordMaxSize+1)
+		require.NoError(t, ioutil.WriteFile(large, append(content, frame...), 0644))
+		_, read, err := readRecordFile(large)
+		var corruptedErr *recordCorruptedError
+		require.True(t, errors.As(err, &corruptedErr))
+		assert.Equal(t, int64(recordHeaderSize), corruptedErr.offset)
+		assert.Empty(t, read)
+	})
+}
+
+func TestRecordFile_writer(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "wal")
+	records := genRecords(5)
+
+	w, replayed, err := openRecordWriter(filePath, 1)
+	require.NoError(t, err)
+	assert.Empty(t, replayed)
+	for _, record := range records {
+		require.NoError(t, w.append(record))
+	}
+	require.NoError(t, w.sync())
+	require.NoError(t, w.close())
+
+	t.Run("reopen", func(t *testing.T) {
+		w, replayed, err := openRecordWriter(filePath, 1)
+		require.NoError(t, err)
+		assert.Equal(t, records, replayed)
+		require.NoError(t, w.append([]byte("more")))
+		require.NoError(t, w.close())
+
+		_, read, err := readRecordFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, append(append([][]byte{}, records...), []byte("more")), read)
+	})
+
+	t.Run("torn write", func(t *testing.T) {
+		info, err := os.Stat(filePath)
+		require.NoError(t, err)
+		// the last record "more" is torn
+		require.NoError(t, os.Truncate(filePath, info.Size()-2))
+
+		_, read, err := readRecordFile(filePath)
+		var corruptedErr *recordCorruptedError
+		require.True(t, errors.As(err, &corruptedErr))
+		assert.Equal(t, records, read)
+
+		w, replayed, err := openRecordWriter(filePath, 1)
+		require.NoError(t, err)
+		assert.Equal(t, records, replayed)
+		require.NoError(t, w.append([]byte("again")))
+		require.NoError(t, w.close())
+		_, read, err = readRecordFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, append(append([][]byte{}, records...), []byte("again")), read)
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		_, _, err := openRecordWriter(filePath, 2)
+		var versionErr *recordVersionError
+		require.True(t, errors.As(err, &versionErr))
+		assert.Equal(t, uint32(1), versionErr.actual)
+	})
+
+	t.Run("corrupted header", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(filePath, []byte("MV"), 0644))
+		w, replayed, err := openRecordWriter(filePath, 2)
+		require.NoError(t, err)
+		assert.Empty(t, replayed)
+		require.NoError(t, w.append([]byte("first")))
+		require.NoError(t, w.close())
+		version, read, err := readRecordFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(2), version)
+		assert.Equal(t, [][]byte{[]byte("first")}, read)
+	})
+
+	t.Run("too large record", func(t *testing.T) {
+		w, _, err := openRecordWriter(filePath, 2)
+		require.NoError(t, err)
+		defer w.close()
+		assert.Error(t, w.append(make([]byte, recordMaxSize+1)))
+		assert.Error(t, writeRecordFile(filePath, 2, [][]byte{make([]byte, recordMaxSize+1)}))
+	})
+}
+
+// TestRecordFile_corrupted reads the record files corrupted randomly, the intact prefix of the records
+// must be read and recovered, and nothing after the first corrupted record is read
+func TestRecordFile_corrupted(t *testing.T) {
+	dir := t.TempDir()
+	records := genRecords(20)
+	origin := filepath.Join(dir, "origin")
+	require.NoError(t, writeRecordFile(origin, 1, records))
+	content, err := ioutil.ReadFile(origin)
+	require.NoError(t, err)
+
+	isPrefix := func(read [][]byte) bool {
+		if len(read) > len(records) {
+			return false
+		}
+		for i := range read {
+			if !bytes.Equal(read[i], records[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 500; i++ {
+		corrupted := append([]byte{}, content...)
+		switch i % 3 {
+		case 0:
+			// flip some bytes
+			for j := 0; j < 1+r.Intn(4); j++ {
+				corrupted[r.Intn(len(corrupted))] ^= byte(1 + r.Intn(255))
+			}
+		case 1:
+			// torn at a random position
+			corrupted = corrupted[:r.Intn(len(corrupted))]
+		case 2:
+			// garbage appended
+			garbage := make([]byte, r.Intn(64))
+			r.Read(garbage)
+			corrupted = append(corrupted, garbage...)
+		}
+		filePath := filepath.Join(dir, "corrupted")
+		require.NoError(t, ioutil.WriteFile(filePath, corrupted, 0644))
+
+		_, read, _ := readRecordFile(filePath)
+		require.True(t, isPrefix(read), "case %d", i)
+
+		w, replayed, err := openRecordWriter(filePath, 1)
+		if err != nil {
+			// the version in header is corrupted
+			var versionErr *recordVersionError
+			require.True(t, errors.As(err, &versionErr), "case %d", i)
+			continue
+		}
+		require.True(t, isPrefix(replayed), "case %d", i)
+		require.NoError(t, w.append([]byte("recovered")))
+		require.NoError(t, w.close())
+		_, recovered, err := readRecordFile(filePath)
+		require.NoError(t, err, "case %d", i)
+		assert.Equal(t, append(replayed, []byte("recovered")), recovered, "case %d", i)
+	}
+}
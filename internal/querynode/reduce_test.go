@@ -24,9 +24,13 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
 func TestReduce_AllFunc(t *testing.T) {
@@ -81,7 +85,7 @@ func TestReduce_AllFunc(t *testing.T) {
 	placeholderGroups := make([]*searchRequest, 0)
 	placeholderGroups = append(placeholderGroups, holder)
 
-	searchResult, err := segment.search(plan, placeholderGroups, []Timestamp{0})
+	searchResult, err := segment.search(context.Background(), plan, placeholderGroups, []Timestamp{0})
 	assert.NoError(t, err)
 
 	err = checkSearchResult(nq, plan, searchResult)
@@ -98,3 +102,87 @@ func TestReduce_nilPlan(t *testing.T) {
 	err := reduceSearchResultsAndFillData(plan, nil, 1)
 	assert.Error(t, err)
 }
+
+func TestReduce_outputTimestamps(t *testing.T) {
+	const nq = 2
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+
+	collection, err := node.streaming.replica.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+	segment, err := node.streaming.replica.getSegmentByID(defaultSegmentID)
+	require.NoError(t, err)
+
+	// distinct insert timestamps of rows
+	records, err := genSimpleCommonBlob()
+	require.NoError(t, err)
+	ids := make([]int64, 0, defaultMsgLength)
+	timestamps := make([]Timestamp, 0, defaultMsgLength)
+	for i := 0; i < defaultMsgLength; i++ {
+		ids = append(ids, int64(i))
+		timestamps = append(timestamps, Timestamp(1000+i*10))
+	}
+	offset, err := segment.segmentPreInsert(defaultMsgLength)
+	require.NoError(t, err)
+	require.NoError(t, segment.segmentInsert(offset, &ids, &timestamps, &records))
+
+	search := func(outputTimestamps bool) *schemapb.SearchResultData {
+		planNode := &planpb.PlanNode{
+			Node: &planpb.PlanNode_VectorAnns{
+				VectorAnns: &planpb.VectorANNS{
+					FieldId: simpleVecField.id,
+					QueryInfo: &planpb.QueryInfo{
+						Topk:             defaultTopK,
+						MetricType:       L2,
+						SearchParams:     `{"nprobe": 10}`,
+						RoundDecimal:     -1,
+						OutputTimestamps: outputTimestamps,
+					},
+					PlaceholderTag: "$0",
+				},
+			},
+		}
+		expr, err := proto.Marshal(planNode)
+		require.NoError(t, err)
+		plan, err := createSearchPlanByExpr(collection, expr)
+		require.NoError(t, err)
+		defer plan.delete()
+		placeholderGroup, err := genPlaceHolderGroup(nq)
+		require.NoError(t, err)
+		req, err := parseSearchRequest(plan, placeholderGroup)
+		require.NoError(t, err)
+		defer req.delete()
+
+		searchResult, err := segment.search(context.Background(), plan, []*searchRequest{req}, []Timestamp{typeutil.MaxTimestamp})
+		require.NoError(t, err)
+		searchResults := []*SearchResult{searchResult}
+		defer deleteSearchResults(searchResults)
+		require.NoError(t, reduceSearchResultsAndFillData(plan, searchResults, 1))
+		reqSlices, err := getReqSlices([]int64{nq}, nq)
+		require.NoError(t, err)
+		blobs, err := marshal(defaultCollectionID, 0, searchResults, 1, reqSlices)
+		require.NoError(t, err)
+		defer deleteSearchResultDataBlobs(blobs)
+		blob, err := getSearchResultDataBlob(blobs, 0)
+		require.NoError(t, err)
+
+		data := &schemapb.SearchResultData{}
+		require.NoError(t, proto.Unmarshal(blob, data))
+		return data
+	}
+
+	data := search(true)
+	hits := data.GetIds().GetIntId().GetData()
+	require.Len(t, hits, nq*int(defaultTopK))
+	require.Len(t, data.GetTimestamps(), len(hits))
+	for i, id := range hits {
+		// every hit carries the exact timestamp it was inserted at
+		require.NotEqual(t, int64(-1), id)
+		assert.Equal(t, uint64(1000+id*10), data.GetTimestamps()[i])
+	}
+
+	assert.Empty(t, search(false).GetTimestamps())
+}
@@ -0,0 +1,76 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/compressor"
+	"github.com/milvus-io/milvus/internal/util/timerecord"
+)
+
+// compressSearchResults compresses the sliced blob of search results to send if compression is enabled,
+// the results are sent uncompressed if compression fails
+func compressSearchResults(results *internalpb.SearchResults) {
+	compressType := compressor.CompressType(Params.QueryNodeCfg.ResultCompressType)
+	if compressType == compressor.CompressTypeNone {
+		return
+	}
+	tr := timerecord.NewTimeRecorder("compressSearchResults")
+	size := len(results.GetSlicedBlob())
+	compressed, err := compressor.CompressSearchResults(results, compressType, Params.QueryNodeCfg.ResultCompressThreshold)
+	if err != nil {
+		log.Warn("failed to compress search results", zap.String("compressType", string(compressType)), zap.Error(err))
+		return
+	}
+	if compressed {
+		observeResultCompression(metrics.SearchLabel, size, len(results.GetSlicedBlob()), tr)
+	}
+}
+
+// compressRetrieveResults compresses the ids and fields data of retrieve results to send if compression is enabled,
+// the results are sent uncompressed if compression fails
+func compressRetrieveResults(results *internalpb.RetrieveResults) {
+	compressType := compressor.CompressType(Params.QueryNodeCfg.ResultCompressType)
+	if compressType == compressor.CompressTypeNone {
+		return
+	}
+	tr := timerecord.NewTimeRecorder("compressRetrieveResults")
+	size := proto.Size(results)
+	compressed, err := compressor.CompressRetrieveResults(results, compressType, Params.QueryNodeCfg.ResultCompressThreshold)
+	if err != nil {
+		log.Warn("failed to compress retrieve results", zap.String("compressType", string(compressType)), zap.Error(err))
+		return
+	}
+	if compressed {
+		observeResultCompression(metrics.QueryLabel, size, proto.Size(results), tr)
+	}
+}
+
+func observeResultCompression(queryType string, size int, compressedSize int, tr *timerecord.TimeRecorder) {
+	nodeID := fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)
+	metrics.QueryNodeResultCompressLatency.WithLabelValues(nodeID, queryType).Observe(float64(tr.ElapseSpan().Milliseconds()))
+	if size > 0 {
+		metrics.QueryNodeResultCompressRatio.WithLabelValues(nodeID, queryType).Observe(float64(compressedSize) / float64(size))
+	}
+}
@@ -0,0 +1,117 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/compressor"
+)
+
+func setResultCompression(compressType string, threshold int) func() {
+	oldType, oldThreshold := Params.QueryNodeCfg.ResultCompressType, Params.QueryNodeCfg.ResultCompressThreshold
+	Params.QueryNodeCfg.ResultCompressType = compressType
+	Params.QueryNodeCfg.ResultCompressThreshold = threshold
+	return func() {
+		Params.QueryNodeCfg.ResultCompressType = oldType
+		Params.QueryNodeCfg.ResultCompressThreshold = oldThreshold
+	}
+}
+
+func TestCompressSearchResults(t *testing.T) {
+	blob := []byte(strings.Repeat("search result", 1000))
+
+	t.Run("disabled", func(t *testing.T) {
+		defer setResultCompression("none", 0)()
+		results := &internalpb.SearchResults{SlicedBlob: blob}
+		compressSearchResults(results)
+		assert.Equal(t, blob, results.GetSlicedBlob())
+		assert.Empty(t, results.GetSlicedBlobCompressType())
+	})
+
+	t.Run("under threshold", func(t *testing.T) {
+		defer setResultCompression("zstd", len(blob)+1)()
+		results := &internalpb.SearchResults{SlicedBlob: blob}
+		compressSearchResults(results)
+		assert.Equal(t, blob, results.GetSlicedBlob())
+		assert.Empty(t, results.GetSlicedBlobCompressType())
+	})
+
+	for _, compressType := range []string{"zstd", "snappy"} {
+		t.Run(compressType, func(t *testing.T) {
+			defer setResultCompression(compressType, len(blob))()
+			results := &internalpb.SearchResults{SlicedBlob: blob}
+			compressSearchResults(results)
+			assert.Equal(t, compressType, results.GetSlicedBlobCompressType())
+			assert.Less(t, len(results.GetSlicedBlob()), len(blob))
+			assert.Greater(t, testutil.CollectAndCount(metrics.QueryNodeResultCompressRatio), 0)
+
+			require.NoError(t, compressor.DecompressSearchResults(results))
+			assert.Equal(t, blob, results.GetSlicedBlob())
+		})
+	}
+}
+
+func TestCompressRetrieveResults(t *testing.T) {
+	genResults := func() *internalpb.RetrieveResults {
+		return &internalpb.RetrieveResults{
+			Ids: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: make([]int64, 1000)}}},
+			FieldsData: []*schemapb.FieldData{
+				genFieldData("int64Field", 101, schemapb.DataType_Int64, make([]int64, 1000), 1),
+			},
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		defer setResultCompression("none", 0)()
+		results := genResults()
+		compressRetrieveResults(results)
+		assert.Empty(t, results.GetCompressType())
+		assert.NotNil(t, results.GetIds())
+	})
+
+	t.Run("under threshold", func(t *testing.T) {
+		defer setResultCompression("snappy", 1<<20)()
+		results := genResults()
+		compressRetrieveResults(results)
+		assert.Empty(t, results.GetCompressType())
+		assert.NotNil(t, results.GetIds())
+	})
+
+	for _, compressType := range []string{"zstd", "snappy"} {
+		t.Run(compressType, func(t *testing.T) {
+			defer setResultCompression(compressType, 1024)()
+			results := genResults()
+			compressRetrieveResults(results)
+			assert.Equal(t, compressType, results.GetCompressType())
+			assert.Nil(t, results.GetIds())
+			assert.Nil(t, results.GetFieldsData())
+
+			require.NoError(t, compressor.DecompressRetrieveResults(results))
+			assert.Equal(t, 1000, len(results.GetIds().GetIntId().GetData()))
+			assert.Equal(t, 1, len(results.GetFieldsData()))
+		})
+	}
+}
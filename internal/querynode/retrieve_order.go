@@ -0,0 +1,46 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// parsePlanOrder returns the limit, the field to sort by and the direction of the serialized plan,
+// 0 limit if the plan does not limit
+func parsePlanOrder(expr []byte) (int64, FieldID, bool) {
+	planNode := &planpb.PlanNode{}
+	if err := proto.Unmarshal(expr, planNode); err != nil {
+		return 0, 0, false
+	}
+	return planNode.GetLimit(), planNode.GetOrderByFieldId(), planNode.GetOrderDesc()
+}
+
+// orderRetrieveResult returns the top plan.limit rows of the rows retrieved from a segment, sorted by
+// plan.orderByField
+func orderRetrieveResult(result *segcorepb.RetrieveResults, plan *RetrievePlan) (*segcorepb.RetrieveResults, error) {
+	rows := typeutil.OrderedRows{IDs: result.GetIds(), FieldsData: result.GetFieldsData()}
+	indexes, err := typeutil.TopOrderedRows(rows, plan.orderByField, plan.orderDesc, int(plan.limit))
+	if err != nil {
+		return nil, err
+	}
+	return selectRetrieveRows(result, indexes)
+}
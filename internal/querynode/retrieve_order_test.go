@@ -0,0 +1,149 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// genOrderRetrievePlan generates the plan returning the top limit rows of all the rows sorted by simpleConstField
+func genOrderRetrievePlan(t *testing.T, limit int64, desc bool) *RetrievePlan {
+	expr, err := proto.Marshal(&planpb.PlanNode{
+		Node:           &planpb.PlanNode_Predicates{Predicates: genPKRangeExpr(0, 10000)},
+		OutputFieldIds: []int64{simplePKField.id, simpleConstField.id},
+		Limit:          limit,
+		OrderByFieldId: simpleConstField.id,
+		OrderDesc:      desc,
+	})
+	require.NoError(t, err)
+	plan, err := createRetrievePlanByExpr(newCollection(defaultCollectionID, genSimpleSegCoreSchema()), expr, defaultMsgLength)
+	require.NoError(t, err)
+	return plan
+}
+
+// genOrderedSealedSegment generates the sealed segment of the pks from pkBase, whose simpleConstField takes values
+func genOrderedSealedSegment(t *testing.T, segmentID UniqueID, pkBase int64, values []int32) *Segment {
+	col := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	seg, err := newSegment(col, segmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeSealed, true)
+	require.NoError(t, err)
+	insertData, err := genInsertData(len(values), genSimpleInsertDataSchema())
+	require.NoError(t, err)
+	pks := insertData.Data[simplePKField.id].(*storage.Int64FieldData).Data
+	for i := range pks {
+		pks[i] = pkBase + int64(i)
+	}
+	copy(insertData.Data[simpleConstField.id].(*storage.Int32FieldData).Data, values)
+	require.NoError(t, loadSegmentFromInsertData(seg, insertData))
+	return seg
+}
+
+func TestParsePlanOrder(t *testing.T) {
+	limit, _, _ := parsePlanOrder(genRetrievePlanExprWithPredicates(t, genPKRangeExpr(0, 10)))
+	assert.Equal(t, int64(0), limit)
+
+	plan := genOrderRetrievePlan(t, 5, true)
+	defer plan.delete()
+	assert.Equal(t, int64(5), plan.limit)
+	assert.Equal(t, simpleConstField.id, plan.orderByField)
+	assert.True(t, plan.orderDesc)
+
+	limit, _, _ = parsePlanOrder([]byte{1, 2, 3})
+	assert.Equal(t, int64(0), limit)
+}
+
+func TestMergeRetrieveResults_order(t *testing.T) {
+	type row struct {
+		pk    int64
+		value int32
+	}
+	const limit = 15
+	// three segments of random values with ties
+	all := make([]row, 0)
+	segments := make([]*Segment, 0, 3)
+	for i := 0; i < 3; i++ {
+		pkBase := int64(i * 1000)
+		values := make([]int32, defaultMsgLength)
+		for j := range values {
+			values[j] = rand.Int31n(20)
+			all = append(all, row{pk: pkBase + int64(j), value: values[j]})
+		}
+		seg := genOrderedSealedSegment(t, UniqueID(i+1), pkBase, values)
+		defer deleteSegment(seg)
+		segments = append(segments, seg)
+	}
+
+	for _, desc := range []bool{false, true} {
+		plan := genOrderRetrievePlan(t, limit, desc)
+		results := make([]*segcorepb.RetrieveResults, 0, len(segments))
+		for _, seg := range segments {
+			result, err := seg.retrieve(plan)
+			require.NoError(t, err)
+			// each segment returns its top rows only
+			assert.Len(t, result.GetIds().GetIntId().GetData(), limit)
+			assert.Len(t, result.GetOffset(), limit)
+			results = append(results, result)
+		}
+
+		sort.Slice(all, func(i, j int) bool {
+			if all[i].value != all[j].value {
+				return (all[i].value < all[j].value) != desc
+			}
+			return all[i].pk < all[j].pk
+		})
+		expectedPKs := make([]int64, 0, limit)
+		expectedValues := make([]int32, 0, limit)
+		for _, r := range all[:limit] {
+			expectedPKs = append(expectedPKs, r.pk)
+			expectedValues = append(expectedValues, r.value)
+		}
+
+		merged, err := mergeSegmentRetrieveResults(plan, results)
+		require.NoError(t, err)
+		assert.Equal(t, expectedPKs, merged.GetIds().GetIntId().GetData(), "desc %v", desc)
+		for _, fieldData := range merged.GetFieldsData() {
+			if fieldData.GetFieldId() == simpleConstField.id {
+				assert.Equal(t, expectedValues, fieldData.GetScalars().GetIntData().GetData(), "desc %v", desc)
+			}
+		}
+
+		// the shards of the first segment and the others
+		shardResults := make([]*internalpb.RetrieveResults, 0, 2)
+		for _, shard := range [][]*segcorepb.RetrieveResults{results[:1], results[1:]} {
+			shardResult, err := mergeSegmentRetrieveResults(plan, shard)
+			require.NoError(t, err)
+			shardResults = append(shardResults, &internalpb.RetrieveResults{
+				Ids:        shardResult.GetIds(),
+				FieldsData: shardResult.GetFieldsData(),
+			})
+		}
+		shardMerged, err := mergeShardRetrieveResults(plan, shardResults)
+		require.NoError(t, err)
+		assert.Equal(t, expectedPKs, shardMerged.GetIds().GetIntId().GetData(), "desc %v", desc)
+		plan.delete()
+	}
+}
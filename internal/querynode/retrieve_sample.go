@@ -0,0 +1,124 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"math/rand"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// parsePlanSample returns the sample size and seed of the serialized plan, 0 size if the plan does not sample
+func parsePlanSample(expr []byte) (int64, int64) {
+	planNode := &planpb.PlanNode{}
+	if err := proto.Unmarshal(expr, planNode); err != nil {
+		return 0, 0
+	}
+	return planNode.GetSampleSize(), planNode.GetSampleSeed()
+}
+
+// sampleRetrieveResult uniformly samples at most size rows of the rows matched in a segment, in random order.
+// MatchedCount of the sampled result is the number of the matched rows.
+func sampleRetrieveResult(result *segcorepb.RetrieveResults, size int64, r *rand.Rand) (*segcorepb.RetrieveResults, error) {
+	rows := typeutil.GetSizeOfIDs(result.GetIds())
+	sampled, err := selectRetrieveRows(result, typeutil.ReservoirSample(rows, int(size), r))
+	if err != nil {
+		return nil, err
+	}
+	sampled.MatchedCount = int64(rows)
+	return sampled, nil
+}
+
+// mergeSegmentRetrieveResults merges the results of the segments retrieved by plan, the samples of the segments
+// are resampled proportionally to their matched rows if plan samples, and the top rows of the segments are merged
+// into the top plan.limit rows if plan limits. The fields data of the results are coerced
+// to the current schema of the output fields before merged.
+func mergeSegmentRetrieveResults(plan *RetrievePlan, results []*segcorepb.RetrieveResults) (*segcorepb.RetrieveResults, error) {
+	for _, rr := range results {
+		if rr == nil {
+			continue
+		}
+		fieldsData, err := coerceFieldsData(plan.outputFields, typeutil.GetSizeOfIDs(rr.GetIds()), rr.GetFieldsData())
+		if err != nil {
+			return nil, err
+		}
+		rr.FieldsData = fieldsData
+	}
+	if plan.limit > 0 {
+		rows := make([]typeutil.OrderedRows, 0, len(results))
+		for _, rr := range results {
+			rows = append(rows, typeutil.OrderedRows{IDs: rr.GetIds(), FieldsData: rr.GetFieldsData()})
+		}
+		ids, fieldsData, err := typeutil.MergeOrderedRows(rows, plan.orderByField, plan.orderDesc, int(plan.limit))
+		if err != nil {
+			return nil, err
+		}
+		return &segcorepb.RetrieveResults{Ids: ids, FieldsData: fieldsData}, nil
+	}
+	if plan.sampleSize <= 0 {
+		return mergeRetrieveResults(results)
+	}
+	samples := make([]typeutil.SampledRows, 0, len(results))
+	for _, rr := range results {
+		samples = append(samples, typeutil.SampledRows{IDs: rr.GetIds(), FieldsData: rr.GetFieldsData(), Matched: rr.GetMatchedCount()})
+	}
+	ids, fieldsData, matched, err := typeutil.MergeSampledRows(samples, int(plan.sampleSize), typeutil.NewSampleRand(plan.sampleSeed, 0))
+	if err != nil {
+		return nil, err
+	}
+	return &segcorepb.RetrieveResults{Ids: ids, FieldsData: fieldsData, MatchedCount: matched}, nil
+}
+
+// mergeShardRetrieveResults merges the results of the shard cluster retrieved by plan, like
+// mergeSegmentRetrieveResults
+func mergeShardRetrieveResults(plan *RetrievePlan, results []*internalpb.RetrieveResults) (*internalpb.RetrieveResults, error) {
+	for _, rr := range results {
+		fieldsData, err := coerceFieldsData(plan.outputFields, typeutil.GetSizeOfIDs(rr.GetIds()), rr.GetFieldsData())
+		if err != nil {
+			return nil, err
+		}
+		rr.FieldsData = fieldsData
+	}
+	if plan.limit > 0 {
+		rows := make([]typeutil.OrderedRows, 0, len(results))
+		for _, rr := range results {
+			rows = append(rows, typeutil.OrderedRows{IDs: rr.GetIds(), FieldsData: rr.GetFieldsData()})
+		}
+		ids, fieldsData, err := typeutil.MergeOrderedRows(rows, plan.orderByField, plan.orderDesc, int(plan.limit))
+		if err != nil {
+			return nil, err
+		}
+		return &internalpb.RetrieveResults{Ids: ids, FieldsData: fieldsData}, nil
+	}
+	if plan.sampleSize <= 0 {
+		return mergeInternalRetrieveResults(results)
+	}
+	samples := make([]typeutil.SampledRows, 0, len(results))
+	for _, rr := range results {
+		samples = append(samples, typeutil.SampledRows{IDs: rr.GetIds(), FieldsData: rr.GetFieldsData(), Matched: rr.GetMatchedCount()})
+	}
+	ids, fieldsData, matched, err := typeutil.MergeSampledRows(samples, int(plan.sampleSize), typeutil.NewSampleRand(plan.sampleSeed, 0))
+	if err != nil {
+		return nil, err
+	}
+	return &internalpb.RetrieveResults{Ids: ids, FieldsData: fieldsData, MatchedCount: matched}, nil
+}
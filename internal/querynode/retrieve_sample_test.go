@@ -0,0 +1,170 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+)
+
+// genSampleRetrievePlan generates the plan sampling size rows of `lower <= pk <= upper`
+func genSampleRetrievePlan(t *testing.T, lower, upper int64, size, seed int64) *RetrievePlan {
+	expr, err := proto.Marshal(&planpb.PlanNode{
+		Node:           &planpb.PlanNode_Predicates{Predicates: genPKRangeExpr(lower, upper)},
+		OutputFieldIds: []int64{simplePKField.id},
+		SampleSize:     size,
+		SampleSeed:     seed,
+	})
+	require.NoError(t, err)
+	plan, err := createRetrievePlanByExpr(newCollection(defaultCollectionID, genSimpleSegCoreSchema()), expr, defaultMsgLength)
+	require.NoError(t, err)
+	return plan
+}
+
+func TestParsePlanSample(t *testing.T) {
+	size, seed := parsePlanSample(genRetrievePlanExprWithPredicates(t, genPKRangeExpr(0, 10)))
+	assert.Equal(t, int64(0), size)
+	assert.Equal(t, int64(0), seed)
+
+	plan := genSampleRetrievePlan(t, 0, 10, 5, 42)
+	defer plan.delete()
+	assert.Equal(t, int64(5), plan.sampleSize)
+	assert.Equal(t, int64(42), plan.sampleSeed)
+
+	size, _ = parsePlanSample([]byte{1, 2, 3})
+	assert.Equal(t, int64(0), size)
+}
+
+func TestSegment_retrieveSample(t *testing.T) {
+	seg, err := genSimpleSealedSegment()
+	require.NoError(t, err)
+	defer deleteSegment(seg)
+
+	retrieve := func(lower, upper int64, size, seed int64) *segcorepb.RetrieveResults {
+		plan := genSampleRetrievePlan(t, lower, upper, size, seed)
+		defer plan.delete()
+		result, err := seg.retrieve(plan)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("sample size", func(t *testing.T) {
+		result := retrieve(0, defaultMsgLength-1, 10, 0)
+		assert.Equal(t, int64(defaultMsgLength), result.GetMatchedCount())
+		pks := result.GetIds().GetIntId().GetData()
+		assert.Len(t, pks, 10)
+		assert.Len(t, result.GetOffset(), 10)
+		assert.Equal(t, pks, result.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+		seen := make(map[int64]bool)
+		for _, pk := range pks {
+			assert.False(t, seen[pk])
+			seen[pk] = true
+		}
+
+		// only the matched rows are sampled
+		result = retrieve(0, 19, 10, 0)
+		assert.Equal(t, int64(20), result.GetMatchedCount())
+		assert.Len(t, result.GetIds().GetIntId().GetData(), 10)
+		for _, pk := range result.GetIds().GetIntId().GetData() {
+			assert.Less(t, pk, int64(20))
+		}
+
+		// all the matched rows are returned if fewer than the sample size
+		result = retrieve(0, 4, 10, 0)
+		assert.Equal(t, int64(5), result.GetMatchedCount())
+		assert.ElementsMatch(t, []int64{0, 1, 2, 3, 4}, result.GetIds().GetIntId().GetData())
+	})
+
+	t.Run("seeded", func(t *testing.T) {
+		expected := retrieve(0, defaultMsgLength-1, 10, 42).GetIds().GetIntId().GetData()
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, expected, retrieve(0, defaultMsgLength-1, 10, 42).GetIds().GetIntId().GetData())
+		}
+	})
+}
+
+func TestMergeRetrieveResults_sample(t *testing.T) {
+	seg, err := genSimpleSealedSegment()
+	require.NoError(t, err)
+	defer deleteSegment(seg)
+
+	// the segments matching 10 and 90 rows are sampled into 10 rows, about 1 of which comes from the smaller one
+	small := genSampleRetrievePlan(t, 0, 9, 10, 0)
+	defer small.delete()
+	large := genSampleRetrievePlan(t, 10, defaultMsgLength-1, 10, 0)
+	defer large.delete()
+	retrieve := func() []*segcorepb.RetrieveResults {
+		smallResult, err := seg.retrieve(small)
+		require.NoError(t, err)
+		largeResult, err := seg.retrieve(large)
+		require.NoError(t, err)
+		return []*segcorepb.RetrieveResults{smallResult, largeResult}
+	}
+
+	t.Run("proportional", func(t *testing.T) {
+		const rounds = 300
+		var fromSmall, total int
+		for round := 0; round < rounds; round++ {
+			merged, err := mergeSegmentRetrieveResults(small, retrieve())
+			require.NoError(t, err)
+			assert.Equal(t, int64(defaultMsgLength), merged.GetMatchedCount())
+			pks := merged.GetIds().GetIntId().GetData()
+			assert.Len(t, pks, 10)
+			for _, pk := range pks {
+				if pk < 10 {
+					fromSmall++
+				}
+				total++
+			}
+		}
+		assert.InDelta(t, 0.1, float64(fromSmall)/float64(total), 0.03)
+	})
+
+	t.Run("shards", func(t *testing.T) {
+		results := retrieve()
+		shardResults := make([]*internalpb.RetrieveResults, 0, len(results))
+		for _, result := range results {
+			shardResults = append(shardResults, &internalpb.RetrieveResults{
+				Ids:          result.GetIds(),
+				FieldsData:   result.GetFieldsData(),
+				MatchedCount: result.GetMatchedCount(),
+			})
+		}
+		merged, err := mergeShardRetrieveResults(small, shardResults)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(defaultMsgLength), merged.GetMatchedCount())
+		assert.Len(t, merged.GetIds().GetIntId().GetData(), 10)
+	})
+
+	t.Run("not sampled", func(t *testing.T) {
+		plan := genSampleRetrievePlan(t, 0, defaultMsgLength-1, 0, 0)
+		defer plan.delete()
+		result, err := seg.retrieve(plan)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), result.GetMatchedCount())
+		merged, err := mergeSegmentRetrieveResults(plan, []*segcorepb.RetrieveResults{result})
+		assert.NoError(t, err)
+		assert.Len(t, merged.GetIds().GetIntId().GetData(), defaultMsgLength)
+	})
+}
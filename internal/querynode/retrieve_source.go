@@ -0,0 +1,136 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// maxBinlogExistEntries bounds the number of binlog files whose existence is cached
+const maxBinlogExistEntries = 65536
+
+// binlogExistCache caches whether the binlog files exist for ttl, so that the retrieve requests
+// don't check the same binlog files on the remote storage again and again
+type binlogExistCache struct {
+	ttl time.Duration // nothing is cached if not positive
+
+	mu      sync.Mutex // guards entries
+	entries map[string]binlogExistEntry
+}
+
+type binlogExistEntry struct {
+	exist    bool
+	expireAt time.Time
+}
+
+func newBinlogExistCache(ttl time.Duration) *binlogExistCache {
+	return &binlogExistCache{
+		ttl:     ttl,
+		entries: make(map[string]binlogExistEntry),
+	}
+}
+
+// exist returns whether the binlog file of path exists in vcm, the cached result is returned if not expired
+func (c *binlogExistCache) exist(vcm storage.ChunkManager, path string) bool {
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[path]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expireAt) {
+		return entry.exist
+	}
+
+	exist := vcm.Exist(path)
+	if c.ttl <= 0 {
+		return exist
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxBinlogExistEntries {
+		for p, e := range c.entries {
+			if !now.Before(e.expireAt) {
+				delete(c.entries, p)
+			}
+		}
+		if len(c.entries) >= maxBinlogExistEntries {
+			c.entries = make(map[string]binlogExistEntry)
+		}
+	}
+	c.entries[path] = binlogExistEntry{exist: exist, expireAt: now.Add(c.ttl)}
+	return exist
+}
+
+// retrieveSourceError is the error of a retrieve request outputting a vector field of a segment,
+// whose raw data could be retrieved from none of the sources
+type retrieveSourceError struct {
+	segmentID UniqueID
+	fieldID   FieldID
+	fieldName string
+	missing   []string // the missing sources along with the reasons
+}
+
+func (e *retrieveSourceError) Error() string {
+	return fmt.Sprintf("no source to retrieve the vector field %s(%d) of segment %d: %s",
+		e.fieldName, e.fieldID, e.segmentID, strings.Join(e.missing, ", "))
+}
+
+// checkRetrieveSources checks that the raw data of every vector field of outputFields could be retrieved from
+// the segment, by segcore if the raw data is kept in memory, either as the column or besides the index,
+// or by fillIndexedFieldsData if all the binlog files of the field exist, which are checked through binlogs
+func (s *Segment) checkRetrieveSources(outputFields []*collectionField, vcm storage.ChunkManager, binlogs *binlogExistCache) error {
+	for _, field := range outputFields {
+		if !typeutil.IsVectorType(field.schema.GetDataType()) || !s.isOffsetsOnlyField(field.ID()) {
+			continue
+		}
+		fieldInfo, err := s.getIndexedFieldInfo(field.ID())
+		if err != nil {
+			continue
+		}
+
+		missing := []string{
+			"raw data dropped from memory",
+			fmt.Sprintf("index %s keeps no raw data", fieldInfo.indexInfo.GetIndexName()),
+		}
+		var missingBinlogs []string
+		for _, binlog := range fieldInfo.fieldBinlog.GetBinlogs() {
+			if !binlogs.exist(vcm, binlog.GetLogPath()) {
+				missingBinlogs = append(missingBinlogs, binlog.GetLogPath())
+			}
+		}
+		switch {
+		case len(fieldInfo.fieldBinlog.GetBinlogs()) == 0:
+			missing = append(missing, "no binlog")
+		case len(missingBinlogs) > 0:
+			missing = append(missing, fmt.Sprintf("binlogs %s not found", strings.Join(truncateBinlogPaths(missingBinlogs), ", ")))
+		default:
+			continue
+		}
+		return &retrieveSourceError{
+			segmentID: s.segmentID,
+			fieldID:   field.ID(),
+			fieldName: field.schema.GetName(),
+			missing:   missing,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+func TestBinlogExistCache(t *testing.T) {
+	cm := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+	const path = "insert_log/1/2/3/100/1"
+	require.NoError(t, cm.Write(path, []byte("binlog")))
+
+	t.Run("cached", func(t *testing.T) {
+		cache := newBinlogExistCache(time.Hour)
+		assert.True(t, cache.exist(cm, path))
+		assert.False(t, cache.exist(cm, "insert_log/1/2/3/100/2"))
+
+		require.NoError(t, cm.Remove(path))
+		defer func() { require.NoError(t, cm.Write(path, []byte("binlog"))) }()
+		assert.True(t, cache.exist(cm, path))
+
+		// expired
+		cache.entries[path] = binlogExistEntry{exist: true, expireAt: time.Now().Add(-time.Second)}
+		assert.False(t, cache.exist(cm, path))
+	})
+
+	t.Run("no cache", func(t *testing.T) {
+		cache := newBinlogExistCache(0)
+		assert.True(t, cache.exist(cm, path))
+		assert.Empty(t, cache.entries)
+	})
+}
+
+func TestSegment_checkRetrieveSources(t *testing.T) {
+	replica, err := genSimpleReplica()
+	require.NoError(t, err)
+	collection, err := replica.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+	segment, err := newSegment(collection, defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeSealed, true)
+	require.NoError(t, err)
+	defer deleteSegment(segment)
+
+	vecField, err := collection.getFieldByID(simpleVecField.id)
+	require.NoError(t, err)
+	pkField, err := collection.getFieldByID(simplePKField.id)
+	require.NoError(t, err)
+	outputFields := []*collectionField{pkField, vecField}
+
+	cm := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+	const existing, missing = "insert_log/1/2/3/100/1", "insert_log/1/2/3/100/2"
+	require.NoError(t, cm.Write(existing, []byte("binlog")))
+	binlogs := newBinlogExistCache(time.Minute)
+	indexedFieldInfo := func(rawDataLoaded bool, paths ...string) *IndexedFieldInfo {
+		fieldBinlog := &datapb.FieldBinlog{FieldID: simpleVecField.id}
+		for _, path := range paths {
+			fieldBinlog.Binlogs = append(fieldBinlog.Binlogs, &datapb.Binlog{LogPath: path})
+		}
+		return &IndexedFieldInfo{
+			fieldBinlog:   fieldBinlog,
+			indexInfo:     &querypb.FieldIndexInfo{FieldID: simpleVecField.id, IndexName: "ivf", EnableIndex: true},
+			rawDataLoaded: rawDataLoaded,
+		}
+	}
+
+	t.Run("in-memory column", func(t *testing.T) {
+		assert.NoError(t, segment.checkRetrieveSources(outputFields, cm, binlogs))
+	})
+
+	t.Run("index with raw data", func(t *testing.T) {
+		segment.setIndexedFieldInfo(simpleVecField.id, indexedFieldInfo(true, missing))
+		assert.NoError(t, segment.checkRetrieveSources(outputFields, cm, binlogs))
+	})
+
+	t.Run("binlog", func(t *testing.T) {
+		segment.setIndexedFieldInfo(simpleVecField.id, indexedFieldInfo(false, existing))
+		assert.NoError(t, segment.checkRetrieveSources(outputFields, cm, binlogs))
+		// the vector field is not output
+		segment.setIndexedFieldInfo(simpleVecField.id, indexedFieldInfo(false, missing))
+		assert.NoError(t, segment.checkRetrieveSources([]*collectionField{pkField}, cm, binlogs))
+	})
+
+	t.Run("all missing", func(t *testing.T) {
+		segment.setIndexedFieldInfo(simpleVecField.id, indexedFieldInfo(false, existing, missing))
+		err := segment.checkRetrieveSources(outputFields, cm, binlogs)
+		var sourceErr *retrieveSourceError
+		require.True(t, errors.As(err, &sourceErr))
+		assert.Equal(t, simpleVecField.id, sourceErr.fieldID)
+		assert.Contains(t, err.Error(), vecField.schema.GetName())
+		assert.Contains(t, err.Error(), "raw data dropped from memory")
+		assert.Contains(t, err.Error(), "index ivf keeps no raw data")
+		assert.Contains(t, err.Error(), "binlogs "+missing+" not found")
+		assert.NotContains(t, err.Error(), existing)
+
+		segment.setIndexedFieldInfo(simpleVecField.id, indexedFieldInfo(false))
+		err = segment.checkRetrieveSources(outputFields, cm, binlogs)
+		assert.True(t, errors.As(err, &sourceErr))
+		assert.Contains(t, err.Error(), "no binlog")
+	})
+}
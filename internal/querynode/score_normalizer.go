@@ -0,0 +1,284 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/distance"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// scoreNormalizer converts the inner product scores of search results to cosine similarity,
+// i.e. ip(q, x) / (|q| * |x|), given the norms of query vectors and hit vectors
+type scoreNormalizer struct {
+	queryNorms  []float64
+	vectorNorms map[int64]float64
+}
+
+// newScoreNormalizer computes the norms of the float query vectors in the serialized placeholder group
+func newScoreNormalizer(placeholderGroup []byte) (*scoreNormalizer, error) {
+	group := &milvuspb.PlaceholderGroup{}
+	if err := proto.Unmarshal(placeholderGroup, group); err != nil {
+		return nil, err
+	}
+	if len(group.GetPlaceholders()) == 0 {
+		return nil, &scoreNormalizationError{reason: "no query vectors in request"}
+	}
+	placeholder := group.GetPlaceholders()[0]
+	if placeholder.GetType() != milvuspb.PlaceholderType_FloatVector {
+		return nil, &scoreNormalizationError{reason: fmt.Sprintf("query vectors of type %s are not supported", placeholder.GetType().String())}
+	}
+	queryNorms := make([]float64, 0, len(placeholder.GetValues()))
+	for _, value := range placeholder.GetValues() {
+		if len(value)%4 != 0 {
+			return nil, &scoreNormalizationError{reason: fmt.Sprintf("invalid float vector of %d bytes", len(value))}
+		}
+		var sum float64
+		for i := 0; i < len(value); i += 4 {
+			v := float64(math.Float32frombits(common.Endian.Uint32(value[i:])))
+			sum += v * v
+		}
+		queryNorms = append(queryNorms, math.Sqrt(sum))
+	}
+	return &scoreNormalizer{
+		queryNorms:  queryNorms,
+		vectorNorms: make(map[int64]float64),
+	}, nil
+}
+
+// addVectors records the norms of the vectors of ids, vectors are flattened with dim
+func (n *scoreNormalizer) addVectors(ids []int64, vectors []float32, dim int) error {
+	if dim <= 0 || len(vectors) != len(ids)*dim {
+		return &scoreNormalizationError{reason: fmt.Sprintf("%d vectors with dim %d mis-match with %d ids", len(vectors), dim, len(ids))}
+	}
+	for i, id := range ids {
+		var sum float64
+		for _, v := range vectors[i*dim : (i+1)*dim] {
+			sum += float64(v) * float64(v)
+		}
+		n.vectorNorms[id] = math.Sqrt(sum)
+	}
+	return nil
+}
+
+// addRetrieveResults records the norms of the vectors of vector field retrieved from segments
+func (n *scoreNormalizer) addRetrieveResults(results []*segcorepb.RetrieveResults, fieldID FieldID) error {
+	for _, result := range results {
+		ids := result.GetIds().GetIntId().GetData()
+		if len(ids) == 0 {
+			continue
+		}
+		var vectorField *schemapb.VectorField
+		for _, fieldData := range result.GetFieldsData() {
+			if fieldData.GetFieldId() == fieldID {
+				vectorField = fieldData.GetVectors()
+				break
+			}
+		}
+		if vectorField.GetFloatVector() == nil {
+			return &scoreNormalizationError{reason: fmt.Sprintf("float vectors of field %d are not retrieved", fieldID)}
+		}
+		if err := n.addVectors(ids, vectorField.GetFloatVector().GetData(), int(vectorField.GetDim())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalize converts the scores of data to cosine similarity and re-sorts the hits of each query
+// in descending order of score, data is laid out topk hits per query, invalid hits have id -1
+func (n *scoreNormalizer) normalize(data *schemapb.SearchResultData) error {
+	nq, topk := data.GetNumQueries(), data.GetTopK()
+	if nq != int64(len(n.queryNorms)) {
+		return &scoreNormalizationError{reason: fmt.Sprintf("nq %d of result mis-match with %d query vectors", nq, len(n.queryNorms))}
+	}
+	if data.GetIds().GetStrId() != nil {
+		return &scoreNormalizationError{reason: "only int64 primary keys are supported"}
+	}
+	ids := data.GetIds().GetIntId().GetData()
+	if int64(len(ids)) != nq*topk || len(data.GetScores()) != len(ids) {
+		return &scoreNormalizationError{reason: fmt.Sprintf("result of %d ids and %d scores mis-match with nq %d and topk %d", len(ids), len(data.GetScores()), nq, topk)}
+	}
+	if len(data.GetTimestamps()) > 0 && len(data.GetTimestamps()) != len(ids) {
+		return &scoreNormalizationError{reason: fmt.Sprintf("result of %d ids and %d timestamps mis-match", len(ids), len(data.GetTimestamps()))}
+	}
+
+	scores := make([]float32, len(ids))
+	order := make([]int64, 0, len(ids))
+	for qi := int64(0); qi < nq; qi++ {
+		offset := qi * topk
+		for i := offset; i < offset+topk; i++ {
+			scores[i] = data.Scores[i]
+			if ids[i] == -1 {
+				continue
+			}
+			vectorNorm, ok := n.vectorNorms[ids[i]]
+			if !ok {
+				return &scoreNormalizationError{reason: fmt.Sprintf("norm of vector %d is unavailable", ids[i])}
+			}
+			// cosine similarity of zero vectors is undefined, regard them as irrelevant
+			if vectorNorm == 0 || n.queryNorms[qi] == 0 {
+				scores[i] = 0
+				continue
+			}
+			scores[i] = float32(float64(data.Scores[i]) / (n.queryNorms[qi] * vectorNorm))
+		}
+
+		block := make([]int64, topk)
+		for i := range block {
+			block[i] = offset + int64(i)
+		}
+		sort.SliceStable(block, func(i, j int) bool {
+			validI, validJ := ids[block[i]] != -1, ids[block[j]] != -1
+			if validI != validJ {
+				return validI
+			}
+			return scores[block[i]] > scores[block[j]]
+		})
+		order = append(order, block...)
+	}
+
+	sortedIDs := make([]int64, 0, len(ids))
+	sortedScores := make([]float32, 0, len(ids))
+	sortedFieldsData := make([]*schemapb.FieldData, len(data.GetFieldsData()))
+	var sortedTimestamps []uint64
+	for _, idx := range order {
+		sortedIDs = append(sortedIDs, ids[idx])
+		sortedScores = append(sortedScores, scores[idx])
+		typeutil.AppendFieldData(sortedFieldsData, data.GetFieldsData(), idx)
+		if len(data.GetTimestamps()) > 0 {
+			sortedTimestamps = append(sortedTimestamps, data.GetTimestamps()[idx])
+		}
+	}
+	data.Ids.GetIntId().Data = sortedIDs
+	data.Scores = sortedScores
+	data.FieldsData = sortedFieldsData
+	data.Timestamps = sortedTimestamps
+	return nil
+}
+
+// checkScoreNormalizable checks if the scores of searching the vector field with metric type can be normalized
+func checkScoreNormalizable(collection *Collection, fieldID FieldID, metricType string) error {
+	if strings.ToUpper(metricType) != distance.IP {
+		return &scoreNormalizationError{reason: fmt.Sprintf("metric type must be %s, but got %s", distance.IP, metricType)}
+	}
+	field, err := collection.getFieldByID(fieldID)
+	if err != nil {
+		return err
+	}
+	if field.schema.GetDataType() != schemapb.DataType_FloatVector {
+		return &scoreNormalizationError{reason: fmt.Sprintf("field %d is not a float vector field", fieldID)}
+	}
+	return nil
+}
+
+// genVectorRetrieveExpr generates a serialized retrieve plan of the vectors of vector field by int64 primary keys
+func genVectorRetrieveExpr(collection *Collection, fieldID FieldID, ids []int64) ([]byte, error) {
+	pkField, err := collection.getPKField()
+	if err != nil {
+		return nil, err
+	}
+	if pkField.schema.GetDataType() != schemapb.DataType_Int64 {
+		return nil, &scoreNormalizationError{reason: "only int64 primary keys are supported"}
+	}
+	values := make([]*planpb.GenericValue, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: id}})
+	}
+	planNode := &planpb.PlanNode{
+		Node: &planpb.PlanNode_Predicates{
+			Predicates: &planpb.Expr{
+				Expr: &planpb.Expr_TermExpr{
+					TermExpr: &planpb.TermExpr{
+						ColumnInfo: &planpb.ColumnInfo{
+							FieldId:      pkField.schema.GetFieldID(),
+							DataType:     schemapb.DataType_Int64,
+							IsPrimaryKey: true,
+						},
+						Values: values,
+					},
+				},
+			},
+		},
+		OutputFieldIds: []int64{fieldID},
+	}
+	return proto.Marshal(planNode)
+}
+
+// normalizeSearchResults converts the inner product scores in the blob of result to cosine similarity,
+// the hit vectors are looked up from local segments by retrieve to compute their norms
+func normalizeSearchResults(collection *Collection, plan *SearchPlan, placeholderGroup []byte, timestamp Timestamp,
+	result *internalpb.SearchResults, retrieve func(plan *RetrievePlan) ([]*segcorepb.RetrieveResults, error)) error {
+	if result.GetSlicedBlob() == nil {
+		return nil
+	}
+	data := &schemapb.SearchResultData{}
+	if err := proto.Unmarshal(result.GetSlicedBlob(), data); err != nil {
+		return err
+	}
+
+	normalizer, err := newScoreNormalizer(placeholderGroup)
+	if err != nil {
+		return err
+	}
+	ids := make([]int64, 0, len(data.GetIds().GetIntId().GetData()))
+	for _, id := range data.GetIds().GetIntId().GetData() {
+		if id != -1 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > 0 {
+		expr, err := genVectorRetrieveExpr(collection, plan.getFieldID(), ids)
+		if err != nil {
+			return err
+		}
+		retrievePlan, err := createRetrievePlanByExpr(collection, expr, timestamp)
+		if err != nil {
+			return err
+		}
+		defer retrievePlan.delete()
+		retrieveResults, err := retrieve(retrievePlan)
+		if err != nil {
+			return err
+		}
+		if err := normalizer.addRetrieveResults(retrieveResults, plan.getFieldID()); err != nil {
+			return err
+		}
+	}
+	if err := normalizer.normalize(data); err != nil {
+		return err
+	}
+
+	blob, err := proto.Marshal(data)
+	if err != nil {
+		return err
+	}
+	result.SlicedBlob = blob
+	return nil
+}
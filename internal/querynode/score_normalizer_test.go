@@ -0,0 +1,356 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/distance"
+)
+
+func genFloatPlaceholderGroup(t *testing.T, queries [][]float32) []byte {
+	placeholder := &milvuspb.PlaceholderValue{
+		Tag:  "$0",
+		Type: milvuspb.PlaceholderType_FloatVector,
+	}
+	for _, query := range queries {
+		buf := make([]byte, 4*len(query))
+		for i, v := range query {
+			common.Endian.PutUint32(buf[4*i:], math.Float32bits(v))
+		}
+		placeholder.Values = append(placeholder.Values, buf)
+	}
+	blob, err := proto.Marshal(&milvuspb.PlaceholderGroup{Placeholders: []*milvuspb.PlaceholderValue{placeholder}})
+	require.NoError(t, err)
+	return blob
+}
+
+func genRandomVectors(n, dim int) [][]float32 {
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		vectors[i] = make([]float32, dim)
+		for j := range vectors[i] {
+			vectors[i][j] = rand.Float32()*2 - 1
+		}
+	}
+	return vectors
+}
+
+func bruteForceCosine(left, right []float32) float64 {
+	var dot, normLeft, normRight float64
+	for i := range left {
+		dot += float64(left[i]) * float64(right[i])
+		normLeft += float64(left[i]) * float64(left[i])
+		normRight += float64(right[i]) * float64(right[i])
+	}
+	return dot / (math.Sqrt(normLeft) * math.Sqrt(normRight))
+}
+
+// genIPSearchResultData emulates an IP search of queries on vectors, output field holds id * 10,
+// the last query only hits one vector so that the rest hits are padded with invalid ones
+func genIPSearchResultData(queries, vectors [][]float32, topk int) *schemapb.SearchResultData {
+	nq := len(queries)
+	data := &schemapb.SearchResultData{
+		NumQueries: int64(nq),
+		TopK:       int64(topk),
+		Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{}}},
+		FieldsData: []*schemapb.FieldData{
+			genFieldData("int64", 101, schemapb.DataType_Int64, []int64{}, 1),
+		},
+	}
+	outputs := data.FieldsData[0].GetScalars().GetLongData()
+	for qi, query := range queries {
+		ids := make([]int64, len(vectors))
+		scores := make([]float32, len(vectors))
+		for id, vector := range vectors {
+			ids[id] = int64(id)
+			scores[id] = distance.CalcIP(int64(len(query)), query, 0, vector, 0)
+		}
+		sort.Slice(ids, func(i, j int) bool { return scores[ids[i]] > scores[ids[j]] })
+		hits := topk
+		if qi == nq-1 {
+			hits = 1
+		}
+		for i := 0; i < topk; i++ {
+			if i < hits {
+				data.Ids.GetIntId().Data = append(data.Ids.GetIntId().Data, ids[i])
+				data.Scores = append(data.Scores, scores[ids[i]])
+				outputs.Data = append(outputs.Data, ids[i]*10)
+			} else {
+				data.Ids.GetIntId().Data = append(data.Ids.GetIntId().Data, -1)
+				data.Scores = append(data.Scores, -1*float32(math.MaxFloat32))
+				outputs.Data = append(outputs.Data, 0)
+			}
+		}
+	}
+	return data
+}
+
+func TestScoreNormalizer_bruteForce(t *testing.T) {
+	const (
+		dim  = 16
+		n    = 200
+		nq   = 4
+		topk = 10
+	)
+	vectors := genRandomVectors(n, dim)
+	queries := genRandomVectors(nq, dim)
+	// scale the queries and vectors so that the IP order differs from the cosine order
+	for i := range vectors {
+		for j := range vectors[i] {
+			vectors[i][j] *= float32(i%7 + 1)
+		}
+	}
+	for j := range queries[0] {
+		queries[0][j] *= 3
+	}
+
+	normalizer, err := newScoreNormalizer(genFloatPlaceholderGroup(t, queries))
+	require.NoError(t, err)
+	ids := make([]int64, n)
+	flattened := make([]float32, 0, n*dim)
+	for id, vector := range vectors {
+		ids[id] = int64(id)
+		flattened = append(flattened, vector...)
+	}
+	require.NoError(t, normalizer.addVectors(ids, flattened, dim))
+
+	data := genIPSearchResultData(queries, vectors, topk)
+	originalIDs := make([]int64, len(data.Ids.GetIntId().Data))
+	copy(originalIDs, data.Ids.GetIntId().Data)
+	// insert timestamps of hits are reordered along with ids
+	for _, id := range originalIDs {
+		data.Timestamps = append(data.Timestamps, uint64(id*100+1))
+	}
+	require.NoError(t, normalizer.normalize(data))
+
+	resultIDs := data.GetIds().GetIntId().GetData()
+	outputs := data.GetFieldsData()[0].GetScalars().GetLongData().GetData()
+	assert.Equal(t, nq*topk, len(resultIDs))
+	assert.Equal(t, nq*topk, len(data.GetScores()))
+	assert.Equal(t, nq*topk, len(outputs))
+	for qi := 0; qi < nq; qi++ {
+		// the same hits of each query, sorted by brute force cosine similarity
+		expected := make([]int64, 0, topk)
+		for _, id := range originalIDs[qi*topk : (qi+1)*topk] {
+			if id != -1 {
+				expected = append(expected, id)
+			}
+		}
+		sort.SliceStable(expected, func(i, j int) bool {
+			return bruteForceCosine(queries[qi], vectors[expected[i]]) > bruteForceCosine(queries[qi], vectors[expected[j]])
+		})
+		for i := 0; i < topk; i++ {
+			idx := qi*topk + i
+			if i >= len(expected) {
+				assert.Equal(t, int64(-1), resultIDs[idx])
+				continue
+			}
+			assert.Equal(t, expected[i], resultIDs[idx])
+			assert.InDelta(t, bruteForceCosine(queries[qi], vectors[expected[i]]), float64(data.GetScores()[idx]), 1e-5)
+			assert.LessOrEqual(t, math.Abs(float64(data.GetScores()[idx])), 1+1e-5)
+			assert.Equal(t, resultIDs[idx]*10, outputs[idx])
+			assert.Equal(t, uint64(resultIDs[idx]*100+1), data.GetTimestamps()[idx])
+		}
+	}
+}
+
+func TestScoreNormalizer_errors(t *testing.T) {
+	queries := genRandomVectors(2, 4)
+
+	t.Run("missing vector norm", func(t *testing.T) {
+		normalizer, err := newScoreNormalizer(genFloatPlaceholderGroup(t, queries))
+		require.NoError(t, err)
+		data := genIPSearchResultData(queries, genRandomVectors(10, 4), 3)
+		err = normalizer.normalize(data)
+		assert.Error(t, err)
+		_, ok := err.(*scoreNormalizationError)
+		assert.True(t, ok)
+	})
+
+	t.Run("nq mis-match", func(t *testing.T) {
+		normalizer, err := newScoreNormalizer(genFloatPlaceholderGroup(t, queries[:1]))
+		require.NoError(t, err)
+		assert.Error(t, normalizer.normalize(genIPSearchResultData(queries, genRandomVectors(10, 4), 3)))
+	})
+
+	t.Run("timestamps mis-match", func(t *testing.T) {
+		normalizer, err := newScoreNormalizer(genFloatPlaceholderGroup(t, queries))
+		require.NoError(t, err)
+		data := genIPSearchResultData(queries, genRandomVectors(10, 4), 3)
+		data.Timestamps = []uint64{1}
+		assert.Error(t, normalizer.normalize(data))
+	})
+
+	t.Run("string ids", func(t *testing.T) {
+		normalizer, err := newScoreNormalizer(genFloatPlaceholderGroup(t, queries))
+		require.NoError(t, err)
+		data := &schemapb.SearchResultData{
+			NumQueries: 2,
+			TopK:       1,
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: []string{"a", "b"}}}},
+			Scores:     []float32{1, 1},
+		}
+		assert.Error(t, normalizer.normalize(data))
+	})
+
+	t.Run("binary query vectors", func(t *testing.T) {
+		group := &milvuspb.PlaceholderGroup{
+			Placeholders: []*milvuspb.PlaceholderValue{{
+				Type:   milvuspb.PlaceholderType_BinaryVector,
+				Values: [][]byte{{0xff}},
+			}},
+		}
+		blob, err := proto.Marshal(group)
+		require.NoError(t, err)
+		_, err = newScoreNormalizer(blob)
+		assert.Error(t, err)
+	})
+
+	t.Run("vectors mis-match with ids", func(t *testing.T) {
+		normalizer, err := newScoreNormalizer(genFloatPlaceholderGroup(t, queries))
+		require.NoError(t, err)
+		assert.Error(t, normalizer.addVectors([]int64{1, 2}, make([]float32, 4), 4))
+		assert.Error(t, normalizer.addVectors([]int64{1}, make([]float32, 4), 0))
+	})
+}
+
+func TestScoreNormalizer_zeroVector(t *testing.T) {
+	queries := [][]float32{{1, 0}}
+	normalizer, err := newScoreNormalizer(genFloatPlaceholderGroup(t, queries))
+	require.NoError(t, err)
+	require.NoError(t, normalizer.addVectors([]int64{1, 2}, []float32{0, 0, -2, 0}, 2))
+
+	data := genIPSearchResultData(queries, [][]float32{{5, 5}, {0, 0}, {-2, 0}}, 2)
+	// only vector 1 and 2 are in the result
+	data.Ids.GetIntId().Data = []int64{1, 2}
+	data.Scores = []float32{0, -2}
+	data.FieldsData[0].GetScalars().GetLongData().Data = []int64{10, 20}
+	require.NoError(t, normalizer.normalize(data))
+	assert.Equal(t, []int64{1, 2}, data.GetIds().GetIntId().GetData())
+	assert.Equal(t, []float32{0, -1}, data.GetScores())
+}
+
+func TestScoreNormalizer_checkScoreNormalizable(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	vecField := collection.getVectorFields()[0]
+
+	assert.NoError(t, checkScoreNormalizable(collection, vecField.schema.GetFieldID(), distance.IP))
+	assert.NoError(t, checkScoreNormalizable(collection, vecField.schema.GetFieldID(), "ip"))
+
+	err := checkScoreNormalizable(collection, vecField.schema.GetFieldID(), distance.L2)
+	assert.Error(t, err)
+	_, ok := err.(*scoreNormalizationError)
+	assert.True(t, ok)
+
+	pkField, err := collection.getPKField()
+	require.NoError(t, err)
+	assert.Error(t, checkScoreNormalizable(collection, pkField.schema.GetFieldID(), distance.IP))
+	assert.Error(t, checkScoreNormalizable(collection, 999, distance.IP))
+}
+
+func TestScoreNormalizer_genVectorRetrieveExpr(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	vecField := collection.getVectorFields()[0]
+
+	expr, err := genVectorRetrieveExpr(collection, vecField.schema.GetFieldID(), []int64{3, 1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []primaryKey{newInt64PrimaryKey(3), newInt64PrimaryKey(1), newInt64PrimaryKey(2)}, parseTermPKs(expr))
+
+	plan, err := createRetrievePlanByExpr(collection, expr, Timestamp(1000))
+	require.NoError(t, err)
+	plan.delete()
+}
+
+func TestScoreNormalizer_normalizeSearchResults(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	plan, searchRequests, err := genSimpleSearchPlanAndRequests(IndexFaissIDMap)
+	require.NoError(t, err)
+	defer plan.delete()
+	defer searchRequests[0].delete()
+
+	const n, topk = 20, 5
+	vectors := genRandomVectors(n, defaultDim)
+	queries := genRandomVectors(2, defaultDim)
+	placeholderGroup := genFloatPlaceholderGroup(t, queries)
+	data := genIPSearchResultData(queries, vectors, topk)
+	blob, err := proto.Marshal(data)
+	require.NoError(t, err)
+
+	retrieve := func(retrievePlan *RetrievePlan) ([]*segcorepb.RetrieveResults, error) {
+		// every hit is looked up by its pk
+		ids := make([]int64, 0, len(retrievePlan.pks))
+		vectorData := make([]float32, 0, len(retrievePlan.pks)*defaultDim)
+		for _, pk := range retrievePlan.pks {
+			id := pk.(*int64PrimaryKey).Value
+			ids = append(ids, id)
+			vectorData = append(vectorData, vectors[id]...)
+		}
+		return []*segcorepb.RetrieveResults{{
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids}}},
+			FieldsData: []*schemapb.FieldData{genFieldData(defaultVecFieldName, plan.getFieldID(), schemapb.DataType_FloatVector, vectorData, defaultDim)},
+		}}, nil
+	}
+
+	t.Run("normalized", func(t *testing.T) {
+		result := &internalpb.SearchResults{
+			Status:     &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			NumQueries: 2,
+			TopK:       topk,
+			SlicedBlob: blob,
+		}
+		err := normalizeSearchResults(collection, plan, placeholderGroup, Timestamp(1000), result, retrieve)
+		require.NoError(t, err)
+
+		normalized := &schemapb.SearchResultData{}
+		require.NoError(t, proto.Unmarshal(result.SlicedBlob, normalized))
+		for i, id := range normalized.GetIds().GetIntId().GetData() {
+			if id == -1 {
+				continue
+			}
+			assert.InDelta(t, bruteForceCosine(queries[i/topk], vectors[id]), float64(normalized.GetScores()[i]), 1e-5)
+		}
+	})
+
+	t.Run("nil blob", func(t *testing.T) {
+		result := &internalpb.SearchResults{}
+		assert.NoError(t, normalizeSearchResults(collection, plan, placeholderGroup, Timestamp(1000), result, retrieve))
+		assert.Nil(t, result.SlicedBlob)
+	})
+
+	t.Run("vectors not retrieved", func(t *testing.T) {
+		result := &internalpb.SearchResults{SlicedBlob: blob}
+		err := normalizeSearchResults(collection, plan, placeholderGroup, Timestamp(1000), result,
+			func(*RetrievePlan) ([]*segcorepb.RetrieveResults, error) {
+				return []*segcorepb.RetrieveResults{}, nil
+			})
+		assert.Error(t, err)
+	})
+}
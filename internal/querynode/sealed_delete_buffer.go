@@ -0,0 +1,221 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+// bufferedDelete is a delete from the delta channel whose pk matched no sealed segment when it arrived
+type bufferedDelete struct {
+	pk        primaryKey
+	timestamp Timestamp
+	replayed  bool // replayed against any sealed segment loaded later
+}
+
+// sealedDeleteBuffer keeps the deletes of a collection matching no sealed segment, since the delta channels may be
+// watched before the sealed segments of the deletes are loaded, then the deletes would be lost and the deleted rows
+// visible once the segments are loaded. The buffered deletes are replayed against the sealed segments registered
+// later, and expire after window. At most maxSize pks are kept, the deletes are kept after replayed since a pk may
+// be in several segments, and the bloom filters may give false positives.
+type sealedDeleteBuffer struct {
+	collectionID UniqueID
+	window       time.Duration
+	maxSize      int64
+
+	// mu also serializes matching the deletes against the registered segments and registering segments,
+	// so that a delete is either matched by a segment registered or buffered before the segment is registered
+	mu      sync.Mutex
+	deletes map[UniqueID][]*bufferedDelete // partition id, -1 for the deletes of all the partitions -> deletes
+	size    int64
+}
+
+// newSealedDeleteBuffer returns sealedDeleteBuffer keeping the deletes for window, disabled if window is not positive
+func newSealedDeleteBuffer(collectionID UniqueID, window time.Duration, maxSize int64) *sealedDeleteBuffer {
+	return &sealedDeleteBuffer{
+		collectionID: collectionID,
+		window:       window,
+		maxSize:      maxSize,
+		deletes:      make(map[UniqueID][]*bufferedDelete),
+	}
+}
+
+func (b *sealedDeleteBuffer) enabled() bool {
+	return b.window > 0
+}
+
+// bufferUnmatched runs match, which matches the deletes of partitionID against the registered sealed segments,
+// and buffers the deletes returned matching no segment. The ones beyond maxSize are dropped
+func (b *sealedDeleteBuffer) bufferUnmatched(partitionID UniqueID, match func() ([]primaryKey, []Timestamp)) {
+	if !b.enabled() {
+		match()
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	pks, timestamps := match()
+	added, dropped := 0, 0
+	for i, pk := range pks {
+		if b.size >= b.maxSize {
+			dropped = len(pks) - i
+			break
+		}
+		b.deletes[partitionID] = append(b.deletes[partitionID], &bufferedDelete{
+			pk:        pk,
+			timestamp: timestamps[i],
+		})
+		b.size++
+		added++
+	}
+	if dropped > 0 {
+		log.Warn("too many deletes matching no sealed segment, drop the ones beyond the limit",
+			zap.Int64("collectionID", b.collectionID),
+			zap.Int64("partitionID", partitionID),
+			zap.Int64("maxSize", b.maxSize),
+			zap.Int("dropped", dropped))
+	}
+	b.observe(added, metrics.DroppedLabel, dropped)
+}
+
+// register runs setSegment, which registers segment into the historical replica, and returns the buffered deletes
+// of the segment to replay. A buffered delete is of the segment if it is of the partition of the segment, its pk may
+// be in the segment by the bloom filter, and it's not earlier than the delta checkpoint of the segment, the earlier
+// ones are in the delta logs loaded already
+func (b *sealedDeleteBuffer) register(segment *Segment, setSegment func() error) ([]primaryKey, []Timestamp, error) {
+	if !b.enabled() {
+		return nil, nil, setSegment()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.size == 0 {
+		return nil, nil, setSegment()
+	}
+
+	var candidates []*bufferedDelete
+	for _, partitionID := range []UniqueID{-1, segment.partitionID} {
+		for _, d := range b.deletes[partitionID] {
+			if d.timestamp >= segment.deltaCheckpoint {
+				candidates = append(candidates, d)
+			}
+		}
+	}
+	pks := make([]primaryKey, 0, len(candidates))
+	for _, d := range candidates {
+		pks = append(pks, d.pk)
+	}
+	// matched before registered, since the duplicate of a registered version is released by setSegment
+	indexes, err := matchSegmentPKs(pks, segment)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := setSegment(); err != nil {
+		return nil, nil, err
+	}
+
+	matchedPKs := make([]primaryKey, 0, len(indexes))
+	matchedTss := make([]Timestamp, 0, len(indexes))
+	for _, index := range indexes {
+		candidates[index].replayed = true
+		matchedPKs = append(matchedPKs, candidates[index].pk)
+		matchedTss = append(matchedTss, candidates[index].timestamp)
+	}
+	b.observe(0, metrics.AppliedLabel, len(matchedPKs))
+	return matchedPKs, matchedTss, nil
+}
+
+// expire removes the buffered deletes earlier than ts by more than window, the ones never replayed are warned,
+// since their segments are not loaded in time and the rows deleted may be visible once loaded
+func (b *sealedDeleteBuffer) expire(ts Timestamp) {
+	if !b.enabled() {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.size == 0 {
+		return
+	}
+
+	deadline := tsoutil.PhysicalTime(ts).Add(-b.window)
+	removed, unreplayed := 0, 0
+	for partitionID, deletes := range b.deletes {
+		kept := deletes[:0]
+		for _, d := range deletes {
+			if tsoutil.PhysicalTime(d.timestamp).Before(deadline) {
+				removed++
+				if !d.replayed {
+					unreplayed++
+				}
+				continue
+			}
+			kept = append(kept, d)
+		}
+		if len(kept) == 0 {
+			delete(b.deletes, partitionID)
+		} else {
+			b.deletes[partitionID] = kept
+		}
+	}
+	b.size -= int64(removed)
+	if unreplayed > 0 {
+		log.Warn("buffered deletes expired before their sealed segments are loaded",
+			zap.Int64("collectionID", b.collectionID),
+			zap.Duration("window", b.window),
+			zap.Int("expired", unreplayed))
+	}
+	b.observe(-removed, metrics.ExpiredLabel, unreplayed)
+}
+
+// clear removes all the buffered deletes, along with the collection
+func (b *sealedDeleteBuffer) clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.observe(-int(b.size), metrics.ExpiredLabel, 0)
+	b.deletes = make(map[UniqueID][]*bufferedDelete)
+	b.size = 0
+}
+
+func (b *sealedDeleteBuffer) len() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.size
+}
+
+func (b *sealedDeleteBuffer) observe(delta int, status string, resolved int) {
+	nodeID := fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)
+	if delta != 0 {
+		metrics.QueryNodeBufferedSealedDeletes.WithLabelValues(nodeID).Add(float64(delta))
+	}
+	if resolved > 0 {
+		metrics.QueryNodeBufferedSealedDeletesResolved.WithLabelValues(nodeID, status).Add(float64(resolved))
+	}
+}
+
+// applyBufferedDeletes applies the buffered deletes replayed against the registered sealed segment. The deletes of
+// all the partitions and of the partition of segment are interleaved, and may be older than the live deletes
+// applied once the segment is registered, so they are forced
+func applyBufferedDeletes(segment *Segment, pks []primaryKey, timestamps []Timestamp) error {
+	return segment.applyDeletes(pks, timestamps, true)
+}
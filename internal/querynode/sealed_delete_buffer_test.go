@@ -0,0 +1,228 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/flowgraph"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+func TestSealedDeleteBuffer(t *testing.T) {
+	now := time.Now()
+	tsAt := func(seconds int) Timestamp {
+		return tsoutil.ComposeTSByTime(now.Add(time.Duration(seconds)*time.Second), 0)
+	}
+	pksOf := func(values ...int64) []primaryKey {
+		pks := make([]primaryKey, 0, len(values))
+		for _, value := range values {
+			pks = append(pks, newInt64PrimaryKey(value))
+		}
+		return pks
+	}
+	unmatched := func(pks []primaryKey, timestamps []Timestamp) func() ([]primaryKey, []Timestamp) {
+		return func() ([]primaryKey, []Timestamp) { return pks, timestamps }
+	}
+	resolved := func(status string) float64 {
+		return testutil.ToFloat64(metrics.QueryNodeBufferedSealedDeletesResolved.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), status))
+	}
+	genSegment := func(t *testing.T, checkpoint Timestamp, values ...int64) *Segment {
+		collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+		t.Cleanup(func() { deleteCollection(collection) })
+		segment, err := newSegment(collection, defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeSealed, true)
+		require.NoError(t, err)
+		t.Cleanup(func() { deleteSegment(segment) })
+		buf := make([]byte, 8)
+		for _, value := range values {
+			common.Endian.PutUint64(buf, uint64(value))
+			segment.pkFilter.Add(buf)
+		}
+		segment.deltaCheckpoint = checkpoint
+		return segment
+	}
+
+	t.Run("register", func(t *testing.T) {
+		b := newSealedDeleteBuffer(defaultCollectionID, time.Minute, 100)
+		defer b.clear()
+		b.bufferUnmatched(-1, unmatched(pksOf(1, 5), []Timestamp{tsAt(10), tsAt(10)}))
+		b.bufferUnmatched(defaultPartitionID, unmatched(pksOf(2, 4), []Timestamp{tsAt(10), tsAt(0)}))
+		b.bufferUnmatched(defaultPartitionID+1, unmatched(pksOf(3), []Timestamp{tsAt(10)}))
+		assert.Equal(t, int64(5), b.len())
+
+		// pk 3 is of another partition, pk 4 is in the delta logs loaded and pk 5 is not in the segment
+		segment := genSegment(t, tsAt(5), 1, 2, 3, 4)
+		applied := resolved(metrics.AppliedLabel)
+		registered := false
+		pks, tss, err := b.register(segment, func() error {
+			registered = true
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.True(t, registered)
+		assert.Equal(t, pksOf(1, 2), pks)
+		assert.Equal(t, []Timestamp{tsAt(10), tsAt(10)}, tss)
+		assert.Equal(t, applied+2, resolved(metrics.AppliedLabel))
+		// the deletes are kept for the other segments of the pks
+		assert.Equal(t, int64(5), b.len())
+
+		_, _, err = b.register(segment, func() error { return errors.New("mock error") })
+		assert.Error(t, err)
+		assert.Equal(t, applied+2, resolved(metrics.AppliedLabel))
+	})
+
+	t.Run("max size", func(t *testing.T) {
+		b := newSealedDeleteBuffer(defaultCollectionID, time.Minute, 2)
+		defer b.clear()
+		dropped := resolved(metrics.DroppedLabel)
+		b.bufferUnmatched(-1, unmatched(pksOf(1, 2, 3), []Timestamp{tsAt(10), tsAt(10), tsAt(10)}))
+		assert.Equal(t, int64(2), b.len())
+		assert.Equal(t, dropped+1, resolved(metrics.DroppedLabel))
+	})
+
+	t.Run("expire", func(t *testing.T) {
+		b := newSealedDeleteBuffer(defaultCollectionID, time.Minute, 100)
+		defer b.clear()
+		b.bufferUnmatched(-1, unmatched(pksOf(1, 2, 3), []Timestamp{tsAt(0), tsAt(0), tsAt(30)}))
+		_, _, err := b.register(genSegment(t, 0, 1), func() error { return nil })
+		require.NoError(t, err)
+
+		expired := resolved(metrics.ExpiredLabel)
+		b.expire(tsAt(60))
+		assert.Equal(t, int64(3), b.len())
+		b.expire(tsAt(61))
+		assert.Equal(t, int64(1), b.len())
+		// pk 1 has been replayed, only pk 2 expires before its segment is loaded
+		assert.Equal(t, expired+1, resolved(metrics.ExpiredLabel))
+
+		b.clear()
+		assert.Equal(t, int64(0), b.len())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		b := newSealedDeleteBuffer(defaultCollectionID, 0, 100)
+		matched := false
+		b.bufferUnmatched(-1, func() ([]primaryKey, []Timestamp) {
+			matched = true
+			return pksOf(1), []Timestamp{tsAt(10)}
+		})
+		assert.True(t, matched)
+		assert.Equal(t, int64(0), b.len())
+		pks, _, err := b.register(genSegment(t, 0, 1), func() error { return nil })
+		assert.NoError(t, err)
+		assert.Empty(t, pks)
+	})
+}
+
+// saveSimpleStatsLog saves the pk stats log of the segment saved by saveSimpleBinLog
+func saveSimpleStatsLog(t *testing.T, cm storage.ChunkManager) []*datapb.FieldBinlog {
+	schema := genSimpleInsertDataSchema()
+	insertData, err := genInsertData(defaultMsgLength, schema)
+	require.NoError(t, err)
+	_, statsBlobs, err := storage.NewInsertCodec(genCollectionMeta(defaultCollectionID, schema)).Serialize(defaultPartitionID, defaultSegmentID, insertData)
+	require.NoError(t, err)
+
+	statslogs := make([]*datapb.FieldBinlog, 0, len(statsBlobs))
+	for _, blob := range statsBlobs {
+		fieldID, err := strconv.ParseInt(blob.GetKey(), 10, 64)
+		require.NoError(t, err)
+		path := fmt.Sprintf("sealed-delete-buffer-test/stats_log/%d/%d/%d/%d/1", defaultCollectionID, defaultPartitionID, defaultSegmentID, fieldID)
+		require.NoError(t, cm.Write(path, blob.GetValue()))
+		t.Cleanup(func() { cm.Remove(path) })
+		statslogs = append(statslogs, &datapb.FieldBinlog{
+			FieldID: fieldID,
+			Binlogs: []*datapb.Binlog{{LogPath: path}},
+		})
+	}
+	return statslogs
+}
+
+func TestDeleteNode_deleteBeforeSegmentLoaded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	require.NoError(t, node.historical.replica.removeSegment(defaultSegmentID))
+	collection, err := node.historical.replica.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+	fieldBinlog, err := saveSimpleBinLog(ctx)
+	require.NoError(t, err)
+	statslogs := saveSimpleStatsLog(t, node.loader.cm)
+
+	// the deletes arrive from the delta channel before the segment of the pks is loaded
+	deleteTs := tsoutil.ComposeTSByTime(time.Now(), 0)
+	dNode := newDeleteNode(node.historical.replica)
+	dNode.Operate([]flowgraph.Msg{&deleteMsg{
+		deleteMessages: []*msgstream.DeleteMsg{{
+			BaseMsg: genMsgStreamBaseMsg(),
+			DeleteRequest: internalpb.DeleteRequest{
+				Base:           genCommonMsgBase(commonpb.MsgType_Delete),
+				CollectionName: defaultCollectionName,
+				CollectionID:   defaultCollectionID,
+				PartitionID:    -1,
+				PrimaryKeys:    &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2}}}},
+				Timestamps:     []Timestamp{deleteTs, deleteTs},
+				NumRows:        2,
+			},
+		}},
+		timeRange: TimeRange{timestampMin: deleteTs, timestampMax: deleteTs},
+	}})
+	assert.Equal(t, int64(2), collection.sealedDeletes.len())
+
+	err = node.loader.loadSegment(&querypb.LoadSegmentsRequest{
+		Base:         &commonpb.MsgBase{MsgType: commonpb.MsgType_LoadSegments, MsgID: rand.Int63()},
+		Schema:       genSimpleInsertDataSchema(),
+		CollectionID: defaultCollectionID,
+		Infos: []*querypb.SegmentLoadInfo{{
+			SegmentID:    defaultSegmentID,
+			PartitionID:  defaultPartitionID,
+			CollectionID: defaultCollectionID,
+			BinlogPaths:  fieldBinlog,
+			Statslogs:    statslogs,
+		}},
+	}, segmentTypeSealed)
+	require.NoError(t, err)
+
+	segment, err := node.historical.replica.getSegmentByID(defaultSegmentID)
+	require.NoError(t, err)
+	visible := retrieveSimpleIDs(t, segment, deleteTs+1)
+	assert.Len(t, visible, defaultMsgLength-2)
+	assert.NotContains(t, visible, int64(1))
+	assert.NotContains(t, visible, int64(2))
+	// the rows are visible before the deletes
+	assert.Contains(t, retrieveSimpleIDs(t, segment, deleteTs-1), int64(1))
+}
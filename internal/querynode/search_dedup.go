@@ -0,0 +1,186 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/bufferpool"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// placeholderDedup maps the query vectors of a search request to the distinct ones
+type placeholderDedup struct {
+	nq        int64
+	numUnique int64
+	offsets   []int64 // offsets[i] is the index of the distinct query vector of the i-th query
+
+	buffer *bufferpool.Buffer // pooled buffer of the deduplicated placeholder group, nil if not deduplicated
+}
+
+// dedupPlaceholderGroup hashes the query vectors of the serialized placeholder group, and returns the placeholder
+// group of the distinct query vectors in order of first appearance, along with the mapping from the original queries.
+// The placeholder group is returned as is with a nil mapping if it could not be parsed, segcore reports the error then.
+// The deduplicated placeholder group is marshaled into a pooled buffer, which must be released once the search is done
+func dedupPlaceholderGroup(placeholderGroup []byte) ([]byte, *placeholderDedup) {
+	group := &milvuspb.PlaceholderGroup{}
+	if err := proto.Unmarshal(placeholderGroup, group); err != nil || len(group.GetPlaceholders()) != 1 {
+		return placeholderGroup, nil
+	}
+	placeholder := group.GetPlaceholders()[0]
+	values := placeholder.GetValues()
+	dedup := &placeholderDedup{
+		nq:      int64(len(values)),
+		offsets: make([]int64, 0, len(values)),
+	}
+	uniqueValues := make([][]byte, 0, len(values))
+	indexes := make(map[string]int64, len(values))
+	for _, value := range values {
+		index, ok := indexes[string(value)]
+		if !ok {
+			index = int64(len(uniqueValues))
+			indexes[string(value)] = index
+			uniqueValues = append(uniqueValues, value)
+		}
+		dedup.offsets = append(dedup.offsets, index)
+	}
+	dedup.numUnique = int64(len(uniqueValues))
+	if !dedup.deduplicated() {
+		return placeholderGroup, dedup
+	}
+
+	dedupGroup := &milvuspb.PlaceholderGroup{
+		Placeholders: []*milvuspb.PlaceholderValue{{
+			Tag:    placeholder.GetTag(),
+			Type:   placeholder.GetType(),
+			Values: uniqueValues,
+		}},
+	}
+	buffer := bufferPool.Get(proto.Size(dedupGroup))
+	marshaler := proto.NewBuffer(buffer.Bytes()[:0])
+	if err := marshaler.Marshal(dedupGroup); err != nil {
+		buffer.Release()
+		return placeholderGroup, nil
+	}
+	dedup.buffer = buffer
+	return marshaler.Bytes(), dedup
+}
+
+// release returns the buffer of the deduplicated placeholder group to pool, it's safe to release more than once
+func (d *placeholderDedup) release() {
+	d.buffer.Release()
+	d.buffer = nil
+}
+
+// deduplicated returns whether there are duplicate query vectors to skip
+func (d *placeholderDedup) deduplicated() bool {
+	return d.numUnique < d.nq
+}
+
+// observe records the ratio of distinct query vectors
+func (d *placeholderDedup) observe() {
+	if d.nq > 0 {
+		metrics.QueryNodeSearchDedupRatio.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).
+			Observe(float64(d.numUnique) / float64(d.nq))
+	}
+}
+
+// expandSearchResults expands the search results of the distinct query vectors back to the original queries
+func (d *placeholderDedup) expandSearchResults(results *internalpb.SearchResults) error {
+	if results.GetSlicedBlob() == nil {
+		results.NumQueries = d.nq
+		return nil
+	}
+	data := &schemapb.SearchResultData{}
+	if err := proto.Unmarshal(results.GetSlicedBlob(), data); err != nil {
+		return err
+	}
+	expanded, err := d.expandSearchResultData(data)
+	if err != nil {
+		return err
+	}
+	blob, err := proto.Marshal(expanded)
+	if err != nil {
+		return err
+	}
+	results.SlicedBlob = blob
+	results.NumQueries = d.nq
+	return nil
+}
+
+// expandSearchResultData copies the hits of each distinct query vector to its original queries, hits are laid out
+// by topks of each query, or topk hits per query if topks are not set
+func (d *placeholderDedup) expandSearchResultData(data *schemapb.SearchResultData) (*schemapb.SearchResultData, error) {
+	if data.GetNumQueries() != d.numUnique {
+		return nil, fmt.Errorf("nq %d of search result mis-match with %d distinct query vectors", data.GetNumQueries(), d.numUnique)
+	}
+	topks := data.GetTopks()
+	if len(topks) != 0 && int64(len(topks)) != d.numUnique {
+		return nil, fmt.Errorf("%d topks of search result mis-match with %d distinct query vectors", len(topks), d.numUnique)
+	}
+	starts := make([]int64, d.numUnique+1)
+	for i := int64(0); i < d.numUnique; i++ {
+		if len(topks) == 0 {
+			starts[i+1] = starts[i] + data.GetTopK()
+		} else {
+			starts[i+1] = starts[i] + topks[i]
+		}
+	}
+	numHits := starts[d.numUnique]
+	if int64(len(data.GetScores())) != numHits || int64(typeutil.GetSizeOfIDs(data.GetIds())) != numHits {
+		return nil, fmt.Errorf("search result of %d scores and %d ids mis-match with %d hits",
+			len(data.GetScores()), typeutil.GetSizeOfIDs(data.GetIds()), numHits)
+	}
+
+	ret := &schemapb.SearchResultData{
+		NumQueries: d.nq,
+		TopK:       data.GetTopK(),
+		FieldsData: make([]*schemapb.FieldData, len(data.GetFieldsData())),
+		Scores:     make([]float32, 0, numHits*d.nq/d.numUnique),
+		Ids:        &schemapb.IDs{},
+	}
+	if numHits == 0 {
+		ret.FieldsData = data.GetFieldsData()
+		ret.Ids = data.GetIds()
+	}
+	if len(topks) != 0 {
+		ret.Topks = make([]int64, 0, d.nq)
+	}
+	for _, offset := range d.offsets {
+		for j := starts[offset]; j < starts[offset+1]; j++ {
+			typeutil.AppendIDs(ret.Ids, data.GetIds(), int(j))
+			typeutil.AppendFieldData(ret.FieldsData, data.GetFieldsData(), j)
+			ret.Scores = append(ret.Scores, data.GetScores()[j])
+			if len(data.GetTimestamps()) > 0 {
+				typeutil.AppendSearchTimestamp(ret, data, j)
+			}
+			if len(data.GetRawScores()) > 0 {
+				typeutil.AppendSearchRawScore(ret, data, j)
+			}
+		}
+		if len(topks) != 0 {
+			ret.Topks = append(ret.Topks, topks[offset])
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,208 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// genDuplicatePlaceHolderGroup generates a placeholder group of nq query vectors cycling through numUnique distinct ones
+func genDuplicatePlaceHolderGroup(nq int, numUnique int) ([]byte, error) {
+	uniqueGroup, err := genPlaceHolderGroup(numUnique)
+	if err != nil {
+		return nil, err
+	}
+	group := &milvuspb.PlaceholderGroup{}
+	if err := proto.Unmarshal(uniqueGroup, group); err != nil {
+		return nil, err
+	}
+	placeholder := group.GetPlaceholders()[0]
+	values := make([][]byte, 0, nq)
+	for i := 0; i < nq; i++ {
+		values = append(values, placeholder.GetValues()[i%numUnique])
+	}
+	placeholder.Values = values
+	return proto.Marshal(group)
+}
+
+func TestSearchDedup_dedupPlaceholderGroup(t *testing.T) {
+	placeholderGroup, err := genDuplicatePlaceHolderGroup(100, 5)
+	require.NoError(t, err)
+
+	deduped, dedup := dedupPlaceholderGroup(placeholderGroup)
+	require.NotNil(t, dedup)
+	assert.True(t, dedup.deduplicated())
+	assert.Equal(t, int64(100), dedup.nq)
+	assert.Equal(t, int64(5), dedup.numUnique)
+	for i, offset := range dedup.offsets {
+		assert.Equal(t, int64(i%5), offset)
+	}
+
+	original := &milvuspb.PlaceholderGroup{}
+	require.NoError(t, proto.Unmarshal(placeholderGroup, original))
+	group := &milvuspb.PlaceholderGroup{}
+	require.NoError(t, proto.Unmarshal(deduped, group))
+	require.Equal(t, 1, len(group.GetPlaceholders()))
+	assert.Equal(t, original.GetPlaceholders()[0].GetTag(), group.GetPlaceholders()[0].GetTag())
+	assert.Equal(t, original.GetPlaceholders()[0].GetType(), group.GetPlaceholders()[0].GetType())
+	assert.Equal(t, original.GetPlaceholders()[0].GetValues()[:5], group.GetPlaceholders()[0].GetValues())
+	require.NotNil(t, dedup.buffer)
+	dedup.release()
+	assert.Nil(t, dedup.buffer)
+	dedup.release()
+
+	t.Run("poison released buffers", func(t *testing.T) {
+		bufferPool.SetPoison(true)
+		defer bufferPool.SetPoison(false)
+		deduped, dedup := dedupPlaceholderGroup(placeholderGroup)
+		require.NotNil(t, dedup)
+		buffer := dedup.buffer
+		dedup.release()
+		assert.Panics(t, func() { buffer.Bytes() })
+		// the released placeholder group must not be parsed any more
+		assert.Error(t, proto.Unmarshal(deduped, &milvuspb.PlaceholderGroup{}))
+	})
+
+	t.Run("distinct query vectors", func(t *testing.T) {
+		placeholderGroup, err := genPlaceHolderGroup(10)
+		require.NoError(t, err)
+		deduped, dedup := dedupPlaceholderGroup(placeholderGroup)
+		require.NotNil(t, dedup)
+		assert.False(t, dedup.deduplicated())
+		assert.Equal(t, placeholderGroup, deduped)
+		assert.Nil(t, dedup.buffer)
+		dedup.release()
+	})
+
+	t.Run("invalid placeholder group", func(t *testing.T) {
+		invalid := []byte("invalid placeholder group")
+		deduped, dedup := dedupPlaceholderGroup(invalid)
+		assert.Nil(t, dedup)
+		assert.Equal(t, invalid, deduped)
+
+		empty, err := proto.Marshal(&milvuspb.PlaceholderGroup{})
+		require.NoError(t, err)
+		_, dedup = dedupPlaceholderGroup(empty)
+		assert.Nil(t, dedup)
+	})
+}
+
+func TestSearchDedup_expandSearchResultData(t *testing.T) {
+	dedup := &placeholderDedup{nq: 4, numUnique: 2, offsets: []int64{0, 1, 1, 0}}
+
+	t.Run("topk per query", func(t *testing.T) {
+		data := &schemapb.SearchResultData{
+			NumQueries: 2,
+			TopK:       2,
+			Scores:     []float32{1, 2, 3, -1},
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{10, 11, 20, -1}}}},
+			FieldsData: []*schemapb.FieldData{genFieldData("int64Field", 101, schemapb.DataType_Int64, []int64{100, 110, 200, 0}, 1)},
+		}
+		expanded, err := dedup.expandSearchResultData(data)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), expanded.GetNumQueries())
+		assert.Equal(t, int64(2), expanded.GetTopK())
+		assert.Nil(t, expanded.GetTopks())
+		assert.Equal(t, []float32{1, 2, 3, -1, 3, -1, 1, 2}, expanded.GetScores())
+		assert.Equal(t, []int64{10, 11, 20, -1, 20, -1, 10, 11}, expanded.GetIds().GetIntId().GetData())
+		assert.Equal(t, []int64{100, 110, 200, 0, 200, 0, 100, 110}, expanded.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+	})
+
+	t.Run("topks of each query", func(t *testing.T) {
+		data := &schemapb.SearchResultData{
+			NumQueries: 2,
+			TopK:       2,
+			Topks:      []int64{2, 1},
+			Scores:     []float32{1, 2, 3},
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{10, 11, 20}}}},
+			Timestamps: []uint64{100, 110, 200},
+		}
+		expanded, err := dedup.expandSearchResultData(data)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2, 1, 1, 2}, expanded.GetTopks())
+		assert.Equal(t, []float32{1, 2, 3, 3, 1, 2}, expanded.GetScores())
+		assert.Equal(t, []int64{10, 11, 20, 20, 10, 11}, expanded.GetIds().GetIntId().GetData())
+		assert.Equal(t, []uint64{100, 110, 200, 200, 100, 110}, expanded.GetTimestamps())
+	})
+
+	t.Run("no hits", func(t *testing.T) {
+		data := &schemapb.SearchResultData{
+			NumQueries: 2,
+			TopK:       2,
+			Topks:      []int64{0, 0},
+		}
+		expanded, err := dedup.expandSearchResultData(data)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{0, 0, 0, 0}, expanded.GetTopks())
+		assert.Empty(t, expanded.GetScores())
+	})
+
+	t.Run("mis-matched results", func(t *testing.T) {
+		_, err := dedup.expandSearchResultData(&schemapb.SearchResultData{NumQueries: 3, TopK: 1})
+		assert.Error(t, err)
+		_, err = dedup.expandSearchResultData(&schemapb.SearchResultData{NumQueries: 2, TopK: 1, Topks: []int64{1}})
+		assert.Error(t, err)
+		_, err = dedup.expandSearchResultData(&schemapb.SearchResultData{NumQueries: 2, TopK: 1, Scores: []float32{1}})
+		assert.Error(t, err)
+	})
+}
+
+func TestSearchDedup_expandSearchResults(t *testing.T) {
+	dedup := &placeholderDedup{nq: 3, numUnique: 1, offsets: []int64{0, 0, 0}}
+
+	results := &internalpb.SearchResults{NumQueries: 1, TopK: 1}
+	require.NoError(t, dedup.expandSearchResults(results))
+	assert.Equal(t, int64(3), results.GetNumQueries())
+	assert.Nil(t, results.GetSlicedBlob())
+
+	blob, err := proto.Marshal(&schemapb.SearchResultData{
+		NumQueries: 1,
+		TopK:       1,
+		Scores:     []float32{0.5},
+		Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{7}}}},
+	})
+	require.NoError(t, err)
+	results = &internalpb.SearchResults{NumQueries: 1, TopK: 1, SlicedBlob: blob}
+	require.NoError(t, dedup.expandSearchResults(results))
+	assert.Equal(t, int64(3), results.GetNumQueries())
+	data := &schemapb.SearchResultData{}
+	require.NoError(t, proto.Unmarshal(results.GetSlicedBlob(), data))
+	assert.Equal(t, int64(3), data.GetNumQueries())
+	assert.Equal(t, []int64{7, 7, 7}, data.GetIds().GetIntId().GetData())
+
+	results = &internalpb.SearchResults{SlicedBlob: []byte("corrupted")}
+	assert.Error(t, dedup.expandSearchResults(results))
+}
+
+func BenchmarkSearchDedup_dedupPlaceholderGroup(b *testing.B) {
+	placeholderGroup, err := genDuplicatePlaceHolderGroup(100, 10)
+	require.NoError(b, err)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, dedup := dedupPlaceholderGroup(placeholderGroup)
+		dedup.release()
+	}
+}
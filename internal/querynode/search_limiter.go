@@ -0,0 +1,230 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+const (
+	// searchLatencyTolerance is the ratio the average latency of the segment searches could exceed the baseline by
+	// before the limit is decreased
+	searchLatencyTolerance = 0.1
+	// searchBaselineDrift is the inverse of the rate the baseline latency follows the higher average latencies,
+	// so that the baseline catches up with heavier workloads
+	searchBaselineDrift = 20
+)
+
+// cgoSearchLimiter limits the concurrent segment searches in segcore of the query node, configured at Init
+var cgoSearchLimiter = newSearchLimiter()
+
+// searchWindow is the stats of the segment searches since the last adjustment of the limit
+type searchWindow struct {
+	searches int64         // searches done
+	waited   int64         // searches waited for a permit
+	waitTime time.Duration // total time waited for permits
+	execTime time.Duration // total time of the searches in segcore
+}
+
+// searchLimiter limits the concurrent segment searches in segcore, so that the searches don't oversubscribe the CPU.
+// A search holds a single permit only while it runs in segcore, a request fanning out to more segments than the
+// limit never holds a permit while waiting for another, and thus never deadlocks.
+// The limit starts at the number of CPUs and is adjusted within [min, max] by AIMD: it is decreased by a quarter
+// once the average latency of the searches exceeds the baseline, the lowest average latency observed, by
+// searchLatencyTolerance, which means the CPU is oversubscribed, otherwise it is increased by one if any search
+// waited for a permit. A limiter of non-positive max limits nothing.
+type searchLimiter struct {
+	mu sync.Mutex
+	// notify is closed and replaced once a permit is released or the limit is changed, to wake up the waiters
+	notify  chan struct{}
+	min     int
+	max     int
+	limit   int
+	running int
+
+	window   searchWindow
+	baseline time.Duration
+}
+
+func newSearchLimiter() *searchLimiter {
+	return &searchLimiter{notify: make(chan struct{})}
+}
+
+// wakeUp wakes up the waiters for a permit, it's called with mu held
+func (l *searchLimiter) wakeUp() {
+	close(l.notify)
+	l.notify = make(chan struct{})
+}
+
+// setBounds resets the limit to the number of CPUs within [min, max], the limiter is disabled if max is not positive
+func (l *searchLimiter) setBounds(min, max int) {
+	l.mu.Lock()
+	l.min, l.max = min, max
+	l.limit = clampInt(runtime.NumCPU(), min, max)
+	l.window = searchWindow{}
+	l.baseline = 0
+	limit := l.limit
+	l.wakeUp()
+	l.mu.Unlock()
+	metrics.QueryNodeSearchConcurrencyLimit.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Set(float64(limit))
+}
+
+// acquire waits for a permit of a segment search until ctx is done, the returned release must be called once the
+// search is done with the time the search ran in segcore, which excludes the time waiting for a worker of
+// cgoReadPool, so that a queue of the pool is not taken as the oversubscription of the CPU
+func (l *searchLimiter) acquire(ctx context.Context) (release func(exec time.Duration), err error) {
+	start := time.Now()
+	waited := false
+	for {
+		l.mu.Lock()
+		if l.max <= 0 {
+			l.mu.Unlock()
+			return func(time.Duration) {}, nil
+		}
+		if l.running < l.limit {
+			break
+		}
+		notify := l.notify
+		l.mu.Unlock()
+
+		waited = true
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-notify:
+		}
+	}
+	l.running++
+	wait := time.Since(start)
+	if waited {
+		l.window.waited++
+		l.window.waitTime += wait
+	}
+	l.mu.Unlock()
+	if waited {
+		metrics.QueryNodeSearchConcurrencyWaitLatency.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Observe(float64(wait.Milliseconds()))
+	}
+
+	return func(exec time.Duration) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.running--
+		l.window.searches++
+		l.window.execTime += exec
+		l.wakeUp()
+	}, nil
+}
+
+// getLimit returns the current limit, 0 if the limiter is disabled
+func (l *searchLimiter) getLimit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.max <= 0 {
+		return 0
+	}
+	return l.limit
+}
+
+// adjustLoop adjusts the limit every interval until ctx is done
+func (l *searchLimiter) adjustLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.adjust(interval)
+		}
+	}
+}
+
+// adjust adjusts the limit by the stats of the searches done in the last interval
+func (l *searchLimiter) adjust(interval time.Duration) {
+	l.mu.Lock()
+	window := l.window
+	l.window = searchWindow{}
+	prev := l.limit
+	l.limit = l.nextLimit(window)
+	limit, baseline := l.limit, l.baseline
+	l.wakeUp()
+	l.mu.Unlock()
+
+	metrics.QueryNodeSearchConcurrencyLimit.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Set(float64(limit))
+	if limit != prev {
+		var avgWait, avgExec time.Duration
+		if window.waited > 0 {
+			avgWait = window.waitTime / time.Duration(window.waited)
+		}
+		if window.searches > 0 {
+			avgExec = window.execTime / time.Duration(window.searches)
+		}
+		log.Debug("search concurrency limit adjusted",
+			zap.Int("prevLimit", prev),
+			zap.Int("limit", limit),
+			zap.Float64("throughput", float64(window.searches)/interval.Seconds()),
+			zap.Int64("waited", window.waited),
+			zap.Duration("avgWait", avgWait),
+			zap.Duration("avgExec", avgExec),
+			zap.Duration("baseline", baseline))
+	}
+}
+
+// nextLimit returns the limit after the searches of window and updates the baseline, it's called with mu held
+func (l *searchLimiter) nextLimit(window searchWindow) int {
+	if l.max <= 0 || window.searches == 0 {
+		return l.limit
+	}
+	avgExec := window.execTime / time.Duration(window.searches)
+	if l.baseline == 0 || avgExec < l.baseline {
+		l.baseline = avgExec
+	} else {
+		l.baseline += (avgExec - l.baseline) / searchBaselineDrift
+	}
+
+	limit := l.limit
+	switch {
+	case float64(avgExec) > float64(l.baseline)*(1+searchLatencyTolerance):
+		decrease := limit / 4
+		if decrease < 1 {
+			decrease = 1
+		}
+		limit -= decrease
+	case window.waited > 0:
+		limit++
+	}
+	return clampInt(limit, l.min, l.max)
+}
+
+func clampInt(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
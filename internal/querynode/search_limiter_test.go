@@ -0,0 +1,331 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+func TestSearchLimiter_disabled(t *testing.T) {
+	l := newSearchLimiter()
+	assert.Equal(t, 0, l.getLimit())
+	releases := make([]func(time.Duration), 0, 100)
+	for i := 0; i < 100; i++ {
+		release, err := l.acquire(context.Background())
+		require.NoError(t, err)
+		releases = append(releases, release)
+	}
+	for _, release := range releases {
+		release(time.Millisecond)
+	}
+
+	l.setBounds(1, 0)
+	assert.Equal(t, 0, l.getLimit())
+}
+
+func TestSearchLimiter_acquire(t *testing.T) {
+	l := newSearchLimiter()
+	l.setBounds(1, 1)
+	assert.Equal(t, 1, l.getLimit())
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.QueryNodeSearchConcurrencyLimit.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID))))
+
+	l.setBounds(2, 2)
+	var running, maxRunning atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := l.acquire(context.Background())
+			assert.NoError(t, err)
+			defer release(time.Millisecond)
+			n := running.Inc()
+			for {
+				max := maxRunning.Load()
+				if n <= max || maxRunning.CAS(max, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Dec()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(2), maxRunning.Load())
+	assert.Equal(t, int64(16), l.window.searches)
+	assert.True(t, l.window.waited > 0)
+	// the searches are timed by the callers
+	assert.Equal(t, 16*time.Millisecond, l.window.execTime)
+
+	// the limit starts at the number of CPUs within the bounds
+	l.setBounds(1, 1024)
+	assert.Equal(t, runtime.NumCPU(), l.getLimit())
+	assert.Equal(t, int64(0), l.window.searches)
+}
+
+func TestSearchLimiter_adjust(t *testing.T) {
+	l := newSearchLimiter()
+	l.setBounds(1, 1)
+	l.max = 4
+
+	// a waiter is woken up once the limit is increased
+	release, err := l.acquire(context.Background())
+	require.NoError(t, err)
+	acquired := make(chan func(time.Duration))
+	go func() {
+		release, err := l.acquire(context.Background())
+		assert.NoError(t, err)
+		acquired <- release
+	}()
+	assert.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.running == 1 && l.window.searches == 0
+	}, time.Second, time.Millisecond)
+	select {
+	case <-acquired:
+		t.Fatal("acquired beyond the limit")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	l.mu.Lock()
+	l.window = searchWindow{searches: 10, waited: 1, execTime: 10 * time.Millisecond}
+	l.mu.Unlock()
+	l.adjust(time.Second)
+	assert.Equal(t, 2, l.getLimit())
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.QueryNodeSearchConcurrencyLimit.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID))))
+	select {
+	case release2 := <-acquired:
+		release2(0)
+	case <-time.After(time.Second):
+		t.Fatal("waiter not woken up")
+	}
+	release(0)
+
+	// the limiter disabled wakes up the waiters too
+	l.setBounds(1, 1)
+	release, err = l.acquire(context.Background())
+	require.NoError(t, err)
+	go func() {
+		release, err := l.acquire(context.Background())
+		assert.NoError(t, err)
+		acquired <- release
+	}()
+	l.setBounds(0, 0)
+	select {
+	case release2 := <-acquired:
+		release2(0)
+	case <-time.After(time.Second):
+		t.Fatal("waiter not woken up")
+	}
+	release(0)
+}
+
+func TestSearchLimiter_cancel(t *testing.T) {
+	l := newSearchLimiter()
+	l.setBounds(1, 1)
+	release, err := l.acquire(context.Background())
+	require.NoError(t, err)
+
+	// a waiter returns once its context is done, without taking a permit
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() {
+		_, err := l.acquire(ctx)
+		errCh <- err
+	}()
+	select {
+	case <-errCh:
+		t.Fatal("acquired beyond the limit")
+	case <-time.After(10 * time.Millisecond):
+	}
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("waiter not canceled")
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	l.mu.Lock()
+	assert.Equal(t, 1, l.running)
+	l.mu.Unlock()
+	release(0)
+	release, err = l.acquire(context.Background())
+	require.NoError(t, err)
+	release(0)
+}
+
+func TestSearchLimiter_nextLimit(t *testing.T) {
+	l := newSearchLimiter()
+	l.setBounds(2, 10)
+	l.limit = 8
+
+	// no searches
+	assert.Equal(t, 8, l.nextLimit(searchWindow{}))
+	assert.Equal(t, time.Duration(0), l.baseline)
+
+	// not saturated
+	assert.Equal(t, 8, l.nextLimit(searchWindow{searches: 10, execTime: 100 * time.Millisecond}))
+	assert.Equal(t, 10*time.Millisecond, l.baseline)
+
+	// saturated, additive increase up to max
+	for _, expected := range []int{9, 10, 10} {
+		l.limit = l.nextLimit(searchWindow{searches: 10, waited: 5, execTime: 105 * time.Millisecond})
+		assert.Equal(t, expected, l.limit)
+	}
+
+	// oversubscribed, multiplicative decrease down to min
+	for _, expected := range []int{8, 6, 5, 4, 3, 2, 2} {
+		l.limit = l.nextLimit(searchWindow{searches: 10, waited: 5, execTime: time.Second})
+		assert.Equal(t, expected, l.limit)
+	}
+	// the baseline follows the higher latencies slowly
+	assert.True(t, l.baseline > 10*time.Millisecond)
+	assert.True(t, l.baseline < 50*time.Millisecond)
+
+	// disabled
+	l.setBounds(0, 0)
+	assert.Equal(t, 0, l.nextLimit(searchWindow{searches: 10, waited: 5, execTime: time.Second}))
+}
+
+// TestSearchLimiter_oversubscription simulates a node of 8 CPUs under unbounded demand, where the searches
+// slow down proportionally beyond 8 concurrent ones plus 5% of contention overhead per excess search. Starting
+// from twice the CPUs, e.g. the CPUs seen by a node sharing its host, the limit converges around the number of CPUs,
+// and the p99 latency stays close to the uncontended latency.
+func TestSearchLimiter_oversubscription(t *testing.T) {
+	const (
+		cpus     = 8
+		baseline = 10 * time.Millisecond
+		windows  = 200
+	)
+	latency := func(concurrency int) time.Duration {
+		if concurrency <= cpus {
+			return baseline
+		}
+		excess := float64(concurrency - cpus)
+		return time.Duration(float64(baseline) * float64(concurrency) / cpus * (1 + 0.05*excess))
+	}
+	// window returns the searches of a second with concurrency searches running all the time
+	window := func(concurrency int) (searchWindow, []time.Duration) {
+		exec := latency(concurrency)
+		searches := int64(time.Second/exec) * int64(concurrency)
+		latencies := make([]time.Duration, searches)
+		for i := range latencies {
+			latencies[i] = exec
+		}
+		return searchWindow{searches: searches, waited: searches, execTime: exec * time.Duration(searches)}, latencies
+	}
+	p99 := func(latencies []time.Duration) time.Duration {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		return latencies[len(latencies)*99/100]
+	}
+
+	l := newSearchLimiter()
+	l.setBounds(1, 64)
+	l.limit = 2 * cpus
+	var limited []time.Duration
+	var searches int64
+	for i := 0; i < windows; i++ {
+		w, latencies := window(l.limit)
+		l.limit = l.nextLimit(w)
+		// the first windows converge from the initial limit
+		if i >= windows/2 {
+			limited = append(limited, latencies...)
+			searches += w.searches
+			assert.True(t, l.limit >= cpus-2 && l.limit <= cpus+3, "limit %d", l.limit)
+		}
+	}
+	_, unlimited := window(64)
+
+	require.NotEmpty(t, limited)
+	assert.True(t, p99(limited) <= baseline*3/2, "p99 %s", p99(limited))
+	assert.True(t, p99(unlimited) > baseline*10, "p99 %s", p99(unlimited))
+	// the throughput is kept close to the capacity of the CPUs
+	throughput := float64(searches) / float64(windows/2)
+	assert.True(t, throughput >= 0.8*cpus*float64(time.Second/baseline), "throughput %f", throughput)
+}
+
+func TestSearchLimiter_segmentSearch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+
+	cgoSearchLimiter.setBounds(1, 1)
+	defer cgoSearchLimiter.setBounds(0, 0)
+
+	// a request fanning out to more segments than the limit never deadlocks
+	segIDs := make([]UniqueID, 8)
+	for i := range segIDs {
+		segIDs[i] = defaultSegmentID
+	}
+	plan, searchReqs, err := genSimpleSearchPlanAndRequests(IndexFaissIDMap)
+	require.NoError(t, err)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				searchResults, _, err := node.historical.searchSegments(context.Background(), segIDs, searchReqs, plan, Timestamp(1000))
+				assert.NoError(t, err)
+				assert.Len(t, searchResults, len(segIDs))
+				deleteSearchResults(searchResults)
+			}()
+		}
+		wg.Wait()
+	}()
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("search deadlocked")
+	}
+	cgoSearchLimiter.mu.Lock()
+	assert.Equal(t, 0, cgoSearchLimiter.running)
+	assert.GreaterOrEqual(t, cgoSearchLimiter.window.searches, int64(4*len(segIDs)))
+	cgoSearchLimiter.mu.Unlock()
+
+	// a search canceled while waiting for a permit returns the error of its context
+	release, err := cgoSearchLimiter.acquire(context.Background())
+	require.NoError(t, err)
+	searchCtx, searchCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer searchCancel()
+	_, _, err = node.historical.searchSegments(searchCtx, segIDs[:1], searchReqs, plan, Timestamp(1000))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	release(0)
+}
@@ -0,0 +1,253 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"math"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// prefilterPlan evaluates the predicate of a search plan alone, the rows it matches are the candidates of the
+// vector search. On the segments where the predicate matches a few rows, the vector search considers the
+// candidates only instead of post-filtering the whole segment, and a few candidates are searched by brute force.
+type prefilterPlan struct {
+	predicates   *planpb.Expr
+	retrievePlan *RetrievePlan
+	// histograms of the fields of the predicates, set before searching if any is built
+	histograms map[FieldID]*equiDepthHistogram
+}
+
+// newPrefilterPlan returns the prefilter plan of the serialized search plan, nil if the search has no predicate
+func newPrefilterPlan(col *Collection, expr []byte) (*prefilterPlan, error) {
+	planNode := &planpb.PlanNode{}
+	if err := proto.Unmarshal(expr, planNode); err != nil {
+		return nil, err
+	}
+	predicates := planNode.GetVectorAnns().GetPredicates()
+	if predicates == nil {
+		return nil, nil
+	}
+	// no output field, only the offsets of the matched rows are returned
+	retrieveExpr, err := proto.Marshal(&planpb.PlanNode{
+		Node: &planpb.PlanNode_Predicates{Predicates: predicates},
+	})
+	if err != nil {
+		return nil, err
+	}
+	retrievePlan, err := createRetrievePlanByExpr(col, retrieveExpr, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &prefilterPlan{
+		predicates:   predicates,
+		retrievePlan: retrievePlan,
+	}, nil
+}
+
+func (p *prefilterPlan) delete() {
+	p.retrievePlan.delete()
+}
+
+// shouldPrefilter returns whether the predicate of prefilter is estimated to be selective enough on the segment
+func (s *Segment) shouldPrefilter(prefilter *prefilterPlan) bool {
+	threshold := Params.QueryNodeCfg.PrefilterSelectivity
+	if threshold <= 0 {
+		return false
+	}
+	rowCount := s.getRowCount()
+	if rowCount <= 0 {
+		return false
+	}
+	return estimateSelectivity(prefilter.predicates, s, rowCount, prefilter.histograms) <= threshold
+}
+
+// searchWithPrefilter evaluates the predicate of plan on the segment first, then searches the matched rows only
+func (s *Segment) searchWithPrefilter(plan *SearchPlan, searchReq *searchRequest, timestamp Timestamp) (*SearchResult, error) {
+	// the plan is shared by the segments searched concurrently
+	retrievePlan := *plan.prefilter.retrievePlan
+	retrievePlan.Timestamp = timestamp
+	result, err := s.retrieveWithOffsetsOnlyFields(&retrievePlan, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := result.GetOffset()
+	var numRows int64
+	for _, offset := range offsets {
+		if offset >= numRows {
+			numRows = offset + 1
+		}
+	}
+	candidates := make([]byte, (numRows+7)/8)
+	for _, offset := range offsets {
+		candidates[offset>>3] |= 1 << (offset & 0x7)
+	}
+	bruteForce := int64(len(offsets)) <= Params.QueryNodeCfg.PrefilterBruteForceRows
+	log.Debug("search with prefilter", zap.Int64("segmentID", s.segmentID),
+		zap.Int("candidates", len(offsets)), zap.Bool("bruteForce", bruteForce))
+	return s.searchWithCandidates(plan, searchReq, timestamp, candidates, numRows, bruteForce)
+}
+
+// estimateSelectivity estimates the fraction of the rows of segment matching expr, taking the pk range recorded in
+// statslog as the zone map of the pk field, and the histograms of the collection as the distributions of the other
+// fields. The fraction of the predicates that can't be estimated is 1.
+func estimateSelectivity(expr *planpb.Expr, segment *Segment, rowCount int64, histograms map[FieldID]*equiDepthHistogram) float64 {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_BinaryExpr:
+		left := estimateSelectivity(e.BinaryExpr.GetLeft(), segment, rowCount, histograms)
+		right := estimateSelectivity(e.BinaryExpr.GetRight(), segment, rowCount, histograms)
+		switch e.BinaryExpr.GetOp() {
+		case planpb.BinaryExpr_LogicalAnd:
+			return math.Min(left, right)
+		case planpb.BinaryExpr_LogicalOr:
+			return math.Min(1, left+right)
+		}
+	case *planpb.Expr_TermExpr:
+		if !e.TermExpr.GetColumnInfo().GetIsPrimaryKey() {
+			if h := histograms[e.TermExpr.GetColumnInfo().GetFieldId()]; h != nil {
+				var selectivity float64
+				for _, value := range e.TermExpr.GetValues() {
+					v, ok := genericValueToFloat(value)
+					if !ok {
+						return 1
+					}
+					selectivity += h.estimateEqual(v)
+				}
+				return math.Min(1, selectivity)
+			}
+			return 1
+		}
+		// every pk matches one row at most
+		matched := 0
+		for _, value := range e.TermExpr.GetValues() {
+			pk := genericValueToPK(value, e.TermExpr.GetColumnInfo().GetDataType())
+			if pk == nil || segment.mayContainPKs([]primaryKey{pk}) {
+				matched++
+			}
+		}
+		return math.Min(1, float64(matched)/float64(rowCount))
+	case *planpb.Expr_UnaryRangeExpr:
+		column := e.UnaryRangeExpr.GetColumnInfo()
+		if h := histograms[column.GetFieldId()]; h != nil && !column.GetIsPrimaryKey() {
+			return estimateUnaryRangeByHistogram(h, e.UnaryRangeExpr)
+		}
+		if !column.GetIsPrimaryKey() || column.GetDataType() != schemapb.DataType_Int64 {
+			return 1
+		}
+		value := e.UnaryRangeExpr.GetValue().GetInt64Val()
+		switch e.UnaryRangeExpr.GetOp() {
+		case planpb.OpType_GreaterThan, planpb.OpType_GreaterEqual:
+			return estimatePKRangeSelectivity(segment, value, math.MaxInt64)
+		case planpb.OpType_LessThan, planpb.OpType_LessEqual:
+			return estimatePKRangeSelectivity(segment, math.MinInt64, value)
+		case planpb.OpType_Equal:
+			return math.Min(1, 1/float64(rowCount))
+		}
+	case *planpb.Expr_BinaryRangeExpr:
+		column := e.BinaryRangeExpr.GetColumnInfo()
+		if h := histograms[column.GetFieldId()]; h != nil && !column.GetIsPrimaryKey() {
+			lower, ok1 := genericValueToFloat(e.BinaryRangeExpr.GetLowerValue())
+			upper, ok2 := genericValueToFloat(e.BinaryRangeExpr.GetUpperValue())
+			if !ok1 || !ok2 {
+				return 1
+			}
+			return h.estimateRange(lower, e.BinaryRangeExpr.GetLowerInclusive(), upper, e.BinaryRangeExpr.GetUpperInclusive())
+		}
+		if !column.GetIsPrimaryKey() || column.GetDataType() != schemapb.DataType_Int64 {
+			return 1
+		}
+		return estimatePKRangeSelectivity(segment, e.BinaryRangeExpr.GetLowerValue().GetInt64Val(),
+			e.BinaryRangeExpr.GetUpperValue().GetInt64Val())
+	}
+	return 1
+}
+
+// estimatePKRangeSelectivity estimates the fraction of the rows of segment whose int64 pk is in [lower, upper],
+// assuming the pks are evenly distributed in the pk range of segment
+func estimatePKRangeSelectivity(segment *Segment, lower, upper int64) float64 {
+	minPK, ok1 := segment.minPK.(*storage.Int64PrimaryKey)
+	maxPK, ok2 := segment.maxPK.(*storage.Int64PrimaryKey)
+	if !ok1 || !ok2 {
+		return 1
+	}
+	if lower < minPK.Value {
+		lower = minPK.Value
+	}
+	if upper > maxPK.Value {
+		upper = maxPK.Value
+	}
+	if lower > upper {
+		return 0
+	}
+	return (float64(upper) - float64(lower) + 1) / (float64(maxPK.Value) - float64(minPK.Value) + 1)
+}
+
+// estimateUnaryRangeByHistogram estimates the fraction of the rows matching expr by the histogram of its field
+func estimateUnaryRangeByHistogram(h *equiDepthHistogram, expr *planpb.UnaryRangeExpr) float64 {
+	value, ok := genericValueToFloat(expr.GetValue())
+	if !ok {
+		return 1
+	}
+	switch expr.GetOp() {
+	case planpb.OpType_GreaterThan:
+		return h.estimateRange(value, false, math.Inf(1), true)
+	case planpb.OpType_GreaterEqual:
+		return h.estimateRange(value, true, math.Inf(1), true)
+	case planpb.OpType_LessThan:
+		return h.estimateRange(math.Inf(-1), true, value, false)
+	case planpb.OpType_LessEqual:
+		return h.estimateRange(math.Inf(-1), true, value, true)
+	case planpb.OpType_Equal:
+		return h.estimateEqual(value)
+	case planpb.OpType_NotEqual:
+		return 1 - h.estimateEqual(value)
+	}
+	return 1
+}
+
+// genericValueToFloat returns the numeric value as float64, false if value is not numeric
+func genericValueToFloat(value *planpb.GenericValue) (float64, bool) {
+	switch v := value.GetVal().(type) {
+	case *planpb.GenericValue_Int64Val:
+		return float64(v.Int64Val), true
+	case *planpb.GenericValue_FloatVal:
+		return v.FloatVal, true
+	}
+	return 0, false
+}
+
+// genericValueToPK returns the primary key of value, nil if value is not a valid pk of dataType
+func genericValueToPK(value *planpb.GenericValue, dataType schemapb.DataType) primaryKey {
+	switch dataType {
+	case schemapb.DataType_Int64:
+		if v, ok := value.GetVal().(*planpb.GenericValue_Int64Val); ok {
+			return newInt64PrimaryKey(v.Int64Val)
+		}
+	case schemapb.DataType_VarChar:
+		if v, ok := value.GetVal().(*planpb.GenericValue_StringVal); ok {
+			return newVarCharPrimaryKey(v.StringVal)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+func TestEstimateSelectivity(t *testing.T) {
+	segment := genPKIndexedSealedSegment(t, defaultMsgLength)
+	defer deleteSegment(segment)
+	rowCount := int64(1000)
+
+	term := func(pks ...int64) *planpb.Expr {
+		values := make([]*planpb.GenericValue, 0, len(pks))
+		for _, pk := range pks {
+			values = append(values, &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: pk}})
+		}
+		return &planpb.Expr{Expr: &planpb.Expr_TermExpr{TermExpr: &planpb.TermExpr{ColumnInfo: genPKColumnInfo(), Values: values}}}
+	}
+	unary := func(op planpb.OpType, value int64) *planpb.Expr {
+		return &planpb.Expr{Expr: &planpb.Expr_UnaryRangeExpr{UnaryRangeExpr: &planpb.UnaryRangeExpr{
+			ColumnInfo: genPKColumnInfo(),
+			Op:         op,
+			Value:      &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: value}},
+		}}}
+	}
+	binary := func(op planpb.BinaryExpr_BinaryOp, left, right *planpb.Expr) *planpb.Expr {
+		return &planpb.Expr{Expr: &planpb.Expr_BinaryExpr{BinaryExpr: &planpb.BinaryExpr{Op: op, Left: left, Right: right}}}
+	}
+	nonPK := &planpb.Expr{Expr: &planpb.Expr_UnaryRangeExpr{UnaryRangeExpr: &planpb.UnaryRangeExpr{
+		ColumnInfo: &planpb.ColumnInfo{FieldId: simpleConstField.id, DataType: schemapb.DataType_Int32},
+		Op:         planpb.OpType_LessThan,
+		Value:      &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: 10}},
+	}}}
+
+	// the pks not in the pk index match no row
+	assert.Equal(t, 0.002, estimateSelectivity(term(1, 2, int64(defaultMsgLength)), segment, rowCount, nil))
+	assert.Equal(t, 1/float64(rowCount), estimateSelectivity(unary(planpb.OpType_Equal, 1), segment, rowCount, nil))
+	assert.Equal(t, float64(1), estimateSelectivity(nonPK, segment, rowCount, nil))
+	assert.Equal(t, float64(1), estimateSelectivity(&planpb.Expr{Expr: &planpb.Expr_UnaryExpr{UnaryExpr: &planpb.UnaryExpr{
+		Op: planpb.UnaryExpr_Not, Child: term(1)}}}, segment, rowCount, nil))
+
+	// unknown pk range
+	assert.Equal(t, float64(1), estimateSelectivity(genPKRangeExpr(0, 9), segment, rowCount, nil))
+
+	segment.updatePKRange(newInt64PrimaryKey(0), newInt64PrimaryKey(999))
+	assert.Equal(t, 0.01, estimateSelectivity(genPKRangeExpr(0, 9), segment, rowCount, nil))
+	assert.Equal(t, 0.01, estimateSelectivity(genPKRangeExpr(-100, 9), segment, rowCount, nil))
+	assert.Equal(t, float64(0), estimateSelectivity(genPKRangeExpr(1000, 2000), segment, rowCount, nil))
+	assert.Equal(t, 0.1, estimateSelectivity(unary(planpb.OpType_LessThan, 99), segment, rowCount, nil))
+	assert.Equal(t, 0.1, estimateSelectivity(unary(planpb.OpType_GreaterEqual, 900), segment, rowCount, nil))
+
+	assert.Equal(t, 0.01, estimateSelectivity(binary(planpb.BinaryExpr_LogicalAnd, genPKRangeExpr(0, 9), nonPK), segment, rowCount, nil))
+	assert.Equal(t, 0.02, estimateSelectivity(binary(planpb.BinaryExpr_LogicalOr, genPKRangeExpr(0, 9), genPKRangeExpr(500, 509)), segment, rowCount, nil))
+	assert.Equal(t, float64(1), estimateSelectivity(binary(planpb.BinaryExpr_LogicalOr, genPKRangeExpr(0, 9), nonPK), segment, rowCount, nil))
+
+	// the fields with histograms are estimated by the histograms
+	values := make([]float64, 0, 100)
+	for i := 0; i < 100; i++ {
+		values = append(values, float64(i))
+	}
+	histograms := map[FieldID]*equiDepthHistogram{
+		simpleConstField.id: newEquiDepthHistogram(simpleConstField.id, genWeightedValues(values, 1), 10),
+	}
+	assert.InDelta(t, 0.1, estimateSelectivity(nonPK, segment, rowCount, histograms), 1e-9)
+	assert.InDelta(t, 0.5, estimateSelectivity(genConstFieldRangeExpr(50, 99), segment, rowCount, histograms), 0.01)
+	assert.InDelta(t, 0.01, estimateSelectivity(binary(planpb.BinaryExpr_LogicalAnd, genPKRangeExpr(0, 9), nonPK), segment, rowCount, histograms), 1e-9)
+	assert.InDelta(t, 0.11, estimateSelectivity(binary(planpb.BinaryExpr_LogicalOr, genPKRangeExpr(0, 9), nonPK), segment, rowCount, histograms), 1e-9)
+}
+
+func TestSegment_searchWithPrefilter(t *testing.T) {
+	selectivity, bruteForceRows := Params.QueryNodeCfg.PrefilterSelectivity, Params.QueryNodeCfg.PrefilterBruteForceRows
+	defer func() {
+		Params.QueryNodeCfg.PrefilterSelectivity, Params.QueryNodeCfg.PrefilterBruteForceRows = selectivity, bruteForceRows
+	}()
+
+	msgLength := 1000
+	segment := genPKIndexedSealedSegment(t, msgLength)
+	defer deleteSegment(segment)
+	segment.updatePKRange(newInt64PrimaryKey(0), newInt64PrimaryKey(int64(msgLength-1)))
+	// 10 rows of 1000 match, and pk 3 is deleted
+	pks, timestamps := genDeleteRecords([]int64{3}, []Timestamp{Timestamp(msgLength)})
+	offset := segment.segmentPreDelete(len(pks))
+	require.NoError(t, segment.segmentDelete(offset, pks, timestamps))
+	placeholderGroup, err := genPlaceHolderGroup(defaultNQ)
+	require.NoError(t, err)
+
+	search := func(selectivity float64, bruteForceRows int64) *schemapb.SearchResultData {
+		Params.QueryNodeCfg.PrefilterSelectivity = selectivity
+		Params.QueryNodeCfg.PrefilterBruteForceRows = bruteForceRows
+		plan := genSearchPlanWithPredicates(t, genPKRangeExpr(0, 9))
+		defer plan.delete()
+		assert.Equal(t, selectivity > 0, plan.prefilter != nil)
+		return searchSegmentResultData(t, segment, plan, placeholderGroup)
+	}
+
+	// post-filtering
+	expected := search(0, 0)
+	hits := expected.GetIds().GetIntId().GetData()
+	require.Len(t, hits, defaultNQ*int(defaultTopK))
+	for _, id := range hits {
+		if id != -1 {
+			assert.True(t, id >= 0 && id < 10 && id != 3, id)
+		}
+	}
+
+	// the results of prefiltering are identical to post-filtering
+	assert.True(t, proto.Equal(expected, search(0.01, 0)))
+	assert.True(t, proto.Equal(expected, search(0.01, 2048)))
+
+	t.Run("not selective enough", func(t *testing.T) {
+		Params.QueryNodeCfg.PrefilterSelectivity = 0.001
+		plan := genSearchPlanWithPredicates(t, genPKRangeExpr(0, 9))
+		defer plan.delete()
+		assert.False(t, segment.shouldPrefilter(plan.prefilter))
+
+		Params.QueryNodeCfg.PrefilterSelectivity = 0.01
+		assert.True(t, segment.shouldPrefilter(plan.prefilter))
+	})
+
+	t.Run("no predicate", func(t *testing.T) {
+		plan := genSearchPlanWithPredicates(t, nil)
+		defer plan.delete()
+		assert.Nil(t, plan.prefilter)
+	})
+
+	t.Run("no candidate", func(t *testing.T) {
+		Params.QueryNodeCfg.PrefilterSelectivity = 0.01
+		plan := genSearchPlanWithPredicates(t, genPKRangeExpr(3, 3))
+		defer plan.delete()
+		data := searchSegmentResultData(t, segment, plan, placeholderGroup)
+		for _, id := range data.GetIds().GetIntId().GetData() {
+			assert.Equal(t, int64(-1), id)
+		}
+	})
+}
+
+func BenchmarkSegment_searchSelectivePredicate(b *testing.B) {
+	selectivity := Params.QueryNodeCfg.PrefilterSelectivity
+	defer func() { Params.QueryNodeCfg.PrefilterSelectivity = selectivity }()
+
+	msgLength := 100000
+	segment, err := genSealedSegmentWithMsgLength(msgLength)
+	require.NoError(b, err)
+	defer deleteSegment(segment)
+	segment.updatePKRange(newInt64PrimaryKey(0), newInt64PrimaryKey(int64(msgLength-1)))
+	placeholderGroup, err := genPlaceHolderGroup(1)
+	require.NoError(b, err)
+
+	for _, bc := range []struct {
+		name        string
+		selectivity float64
+	}{
+		{"postfilter", 0},
+		{"prefilter", 0.01},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			Params.QueryNodeCfg.PrefilterSelectivity = bc.selectivity
+			// matches 0.1% of the rows
+			plan := genSearchPlanWithPredicates(b, genPKRangeExpr(0, int64(msgLength/1000-1)))
+			defer plan.delete()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				searchSegmentResultData(b, segment, plan, placeholderGroup)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/util/searchvalidation"
+)
+
+// validateSearchRequest checks the search request against the collection with the validation shared with the
+// proxy, and returns the number of the query vectors. The index metric types are checked per segment, since the
+// segments of a collection may be indexed differently while the index is being rebuilt.
+func validateSearchRequest(collection *Collection, annsFieldID FieldID, queryInfo *planpb.QueryInfo,
+	placeholderGroup []byte, outputFieldIDs []FieldID) (int64, error) {
+	spec, err := searchvalidation.NewCollectionSearchSpec(collection.ID(), collection.Schema())
+	if err != nil {
+		return 0, err
+	}
+	return searchvalidation.ValidateSearchRequest(spec, annsFieldID, queryInfo, placeholderGroup, outputFieldIDs)
+}
+
+// searchQueryInfo returns the anns field and the query info of the search request. They are taken from the
+// serialized plan of the searches with boolean expressions, or from the plan created by the dsl of the others, whose
+// search params and round decimal are unknown.
+func searchQueryInfo(req *internalpb.SearchRequest, plan *SearchPlan) (FieldID, *planpb.QueryInfo, error) {
+	if req.GetDslType() == commonpb.DslType_BoolExprV1 {
+		planNode := &planpb.PlanNode{}
+		if err := proto.Unmarshal(req.GetSerializedExprPlan(), planNode); err != nil {
+			return 0, nil, err
+		}
+		if vectorAnns := planNode.GetVectorAnns(); vectorAnns != nil {
+			return vectorAnns.GetFieldId(), vectorAnns.GetQueryInfo(), nil
+		}
+	}
+	return plan.getFieldID(), &planpb.QueryInfo{
+		Topk:         plan.getTopK(),
+		MetricType:   plan.getMetricType(),
+		RoundDecimal: -1,
+	}, nil
+}
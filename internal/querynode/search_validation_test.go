@@ -0,0 +1,116 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/util/searchvalidation"
+)
+
+func TestValidateSearchRequest_Conformance(t *testing.T) {
+	schema := searchvalidation.ConformanceSchema()
+	collection := newCollection(searchvalidation.ConformanceCollectionID, schema)
+	defer deleteCollection(collection)
+
+	// the query nodes receive the field IDs resolved by the proxy, the fields not exist are of ID -1
+	fieldIDOf := func(name string) FieldID {
+		for _, field := range schema.GetFields() {
+			if field.GetName() == name {
+				return field.GetFieldID()
+			}
+		}
+		return -1
+	}
+	for _, c := range searchvalidation.ConformanceCases() {
+		t.Run(c.Name, func(t *testing.T) {
+			outputFieldIDs := make([]FieldID, 0, len(c.OutputFields))
+			for _, name := range c.OutputFields {
+				outputFieldIDs = append(outputFieldIDs, fieldIDOf(name))
+			}
+			_, err := validateSearchRequest(collection, fieldIDOf(c.AnnsField), c.QueryInfo, c.PlaceholderGroup, outputFieldIDs)
+			if c.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSearchQueryInfo(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	defer deleteCollection(collection)
+
+	t.Run("expr", func(t *testing.T) {
+		queryInfo := &planpb.QueryInfo{
+			Topk:         defaultTopK,
+			MetricType:   L2,
+			SearchParams: `{"nprobe": 10}`,
+			RoundDecimal: 3,
+		}
+		expr, err := proto.Marshal(&planpb.PlanNode{
+			Node: &planpb.PlanNode_VectorAnns{
+				VectorAnns: &planpb.VectorANNS{
+					FieldId:        simpleVecField.id,
+					QueryInfo:      queryInfo,
+					PlaceholderTag: "$0",
+				},
+			},
+		})
+		require.NoError(t, err)
+		plan, err := createSearchPlanByExpr(collection, expr)
+		require.NoError(t, err)
+		defer plan.delete()
+
+		fieldID, info, err := searchQueryInfo(&internalpb.SearchRequest{
+			DslType:            commonpb.DslType_BoolExprV1,
+			SerializedExprPlan: expr,
+		}, plan)
+		require.NoError(t, err)
+		assert.Equal(t, simpleVecField.id, fieldID)
+		assert.True(t, proto.Equal(queryInfo, info))
+
+		_, _, err = searchQueryInfo(&internalpb.SearchRequest{
+			DslType:            commonpb.DslType_BoolExprV1,
+			SerializedExprPlan: []byte{0xff},
+		}, plan)
+		assert.Error(t, err)
+	})
+
+	t.Run("dsl", func(t *testing.T) {
+		dsl, err := genDSLByIndexType(IndexFaissIVFFlat)
+		require.NoError(t, err)
+		plan, err := createSearchPlan(collection, dsl)
+		require.NoError(t, err)
+		defer plan.delete()
+
+		fieldID, info, err := searchQueryInfo(&internalpb.SearchRequest{DslType: commonpb.DslType_Dsl, Dsl: dsl}, plan)
+		require.NoError(t, err)
+		assert.Equal(t, simpleVecField.id, fieldID)
+		assert.Equal(t, int64(defaultTopK), info.GetTopk())
+		assert.Equal(t, defaultMetricType, info.GetMetricType())
+		assert.Equal(t, int64(-1), info.GetRoundDecimal())
+	})
+}
@@ -0,0 +1,155 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/distance"
+)
+
+// partitionWeights are the weights of the hits of partitions in search, the partitions not listed are weighted 1.
+// The scores in query node are larger-is-better, i.e. the distances are negated for L2, and a hit of weight w is
+// ranked by
+//
+//	IP: the score subtracted by 1 - w, i.e. ip + w - 1
+//	L2: the distance multiplied by 1 / w, i.e. -l2 / w
+//
+// so that the hits of the partitions with larger weights rank higher, and the weight 1 keeps the score unchanged.
+// The weighted scores are only used to rank the hits, the unweighted ones are kept in RawScores of the results.
+type partitionWeights map[UniqueID]float32
+
+// parsePartitionWeights returns the partition weights of req, nil if the search is not weighted
+func parsePartitionWeights(req *internalpb.SearchRequest, metricType string) (partitionWeights, error) {
+	partitionIDs, weights := req.GetWeightedPartitionIDs(), req.GetPartitionWeights()
+	if len(partitionIDs) != len(weights) {
+		return nil, &partitionWeightError{reason: fmt.Sprintf("%d partitions mis-match with %d weights", len(partitionIDs), len(weights))}
+	}
+	if len(partitionIDs) == 0 {
+		return nil, nil
+	}
+	if req.GetNormalizeScores() {
+		return nil, &partitionWeightError{reason: "weighted scores could not be normalized"}
+	}
+	switch strings.ToUpper(metricType) {
+	case distance.IP, distance.L2:
+	default:
+		return nil, &partitionWeightError{reason: fmt.Sprintf("metric type %s is not supported", metricType)}
+	}
+	pw := make(partitionWeights, len(partitionIDs))
+	for i, partitionID := range partitionIDs {
+		weight := weights[i]
+		if !(weight > 0) || math.IsInf(float64(weight), 0) {
+			return nil, &partitionWeightError{reason: fmt.Sprintf("weight %v of partition %d should be positive", weight, partitionID)}
+		}
+		pw[partitionID] = weight
+	}
+	return pw, nil
+}
+
+// get returns the weight of partition
+func (pw partitionWeights) get(partitionID UniqueID) float32 {
+	if weight, ok := pw[partitionID]; ok {
+		return weight
+	}
+	return 1
+}
+
+// segmentWeights returns the weights of the segments in replica by their partitions
+func (pw partitionWeights) segmentWeights(replica ReplicaInterface, segmentIDs []UniqueID) ([]float32, error) {
+	weights := make([]float32, 0, len(segmentIDs))
+	for _, segmentID := range segmentIDs {
+		segment, err := replica.getSegmentByID(segmentID)
+		if err != nil {
+			return nil, err
+		}
+		weights = append(weights, pw.get(segment.partitionID))
+	}
+	return weights, nil
+}
+
+// weightSearchResultData scales the scores of data by weight, keeping the unweighted scores in RawScores
+func weightSearchResultData(data *schemapb.SearchResultData, weight float32, metricType string) {
+	data.RawScores = make([]float32, len(data.GetScores()))
+	copy(data.RawScores, data.GetScores())
+	positive := distance.PositivelyRelated(metricType)
+	for i, id := range data.GetIds().GetIntId().GetData() {
+		// invalid hits keep the lowest score
+		if id == -1 {
+			continue
+		}
+		if positive {
+			data.Scores[i] -= 1 - weight
+		} else {
+			data.Scores[i] /= weight
+		}
+	}
+}
+
+// reduceWeightedSearchResults reduces the search results of segments into a serialized SearchResultData, the
+// scores of each segment are weighted before the reduce. Unlike the unweighted reduce, the results are reduced
+// one by one by segcore, and merged by the weighted scores.
+func reduceWeightedSearchResults(plan *SearchPlan, searchResults []*SearchResult, weights []float32,
+	collectionID UniqueID, nq int64) ([]byte, error) {
+	reqSlices, err := getReqSlices([]int64{nq}, nq)
+	if err != nil {
+		return nil, err
+	}
+	metricType := plan.getMetricType()
+	data := make([]*schemapb.SearchResultData, 0, len(searchResults))
+	for i, searchResult := range searchResults {
+		segmentData, err := reduceSegmentSearchResult(plan, searchResult, collectionID, reqSlices)
+		if err != nil {
+			return nil, err
+		}
+		weightSearchResultData(segmentData, weights[i], metricType)
+		data = append(data, segmentData)
+	}
+	reduced, err := reduceSearchResultData(data, nq, plan.getTopK(), metricType)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(reduced)
+}
+
+// reduceSegmentSearchResult reduces the search result of a single segment and decodes it
+func reduceSegmentSearchResult(plan *SearchPlan, searchResult *SearchResult, collectionID UniqueID, reqSlices []int32) (*schemapb.SearchResultData, error) {
+	searchResults := []*SearchResult{searchResult}
+	if err := reduceSearchResultsAndFillData(plan, searchResults, 1); err != nil {
+		return nil, err
+	}
+	blobs, err := marshal(collectionID, 0, searchResults, 1, reqSlices)
+	defer deleteSearchResultDataBlobs(blobs)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := getSearchResultDataBlob(blobs, 0)
+	if err != nil {
+		return nil, err
+	}
+	data := &schemapb.SearchResultData{}
+	if err := proto.Unmarshal(blob, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,182 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/distance"
+)
+
+func TestParsePartitionWeights(t *testing.T) {
+	t.Run("not weighted", func(t *testing.T) {
+		weights, err := parsePartitionWeights(&internalpb.SearchRequest{}, distance.L2)
+		assert.NoError(t, err)
+		assert.Nil(t, weights)
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		weights, err := parsePartitionWeights(&internalpb.SearchRequest{
+			WeightedPartitionIDs: []UniqueID{1, 2},
+			PartitionWeights:     []float32{2, 0.5},
+		}, distance.IP)
+		assert.NoError(t, err)
+		assert.Equal(t, float32(2), weights.get(1))
+		assert.Equal(t, float32(0.5), weights.get(2))
+		assert.Equal(t, float32(1), weights.get(3))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := []struct {
+			req        *internalpb.SearchRequest
+			metricType string
+		}{
+			{&internalpb.SearchRequest{WeightedPartitionIDs: []UniqueID{1, 2}, PartitionWeights: []float32{2}}, distance.L2},
+			{&internalpb.SearchRequest{WeightedPartitionIDs: []UniqueID{1}, PartitionWeights: []float32{2}}, distance.HAMMING},
+			{&internalpb.SearchRequest{WeightedPartitionIDs: []UniqueID{1}, PartitionWeights: []float32{0}}, distance.L2},
+			{&internalpb.SearchRequest{WeightedPartitionIDs: []UniqueID{1}, PartitionWeights: []float32{float32(math.Inf(1))}}, distance.L2},
+			{&internalpb.SearchRequest{WeightedPartitionIDs: []UniqueID{1}, PartitionWeights: []float32{2}, NormalizeScores: true}, distance.IP},
+		}
+		for _, c := range cases {
+			_, err := parsePartitionWeights(c.req, c.metricType)
+			var weightErr *partitionWeightError
+			assert.True(t, errors.As(err, &weightErr))
+		}
+	})
+}
+
+func TestWeightSearchResultData(t *testing.T) {
+	genData := func() *schemapb.SearchResultData {
+		return &schemapb.SearchResultData{
+			NumQueries: 1,
+			TopK:       3,
+			Scores:     []float32{-1, -2, -1 * float32(math.MaxFloat32)},
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2, -1}}}},
+			Topks:      []int64{3},
+		}
+	}
+
+	t.Run("IP", func(t *testing.T) {
+		data := genData()
+		weightSearchResultData(data, 1.5, distance.IP)
+		assert.Equal(t, []float32{-0.5, -1.5, -1 * float32(math.MaxFloat32)}, data.GetScores())
+		assert.Equal(t, []float32{-1, -2, -1 * float32(math.MaxFloat32)}, data.GetRawScores())
+	})
+
+	t.Run("L2", func(t *testing.T) {
+		// the distances are negated, -2 is the distance 2
+		data := genData()
+		weightSearchResultData(data, 2, distance.L2)
+		assert.Equal(t, []float32{-0.5, -1, -1 * float32(math.MaxFloat32)}, data.GetScores())
+		assert.Equal(t, []float32{-1, -2, -1 * float32(math.MaxFloat32)}, data.GetRawScores())
+	})
+
+	t.Run("weight 1", func(t *testing.T) {
+		for _, metricType := range []string{distance.IP, distance.L2} {
+			data := genData()
+			weightSearchResultData(data, 1, metricType)
+			assert.Equal(t, data.GetRawScores(), data.GetScores())
+		}
+	})
+
+	t.Run("reduce", func(t *testing.T) {
+		// the hit of distance 3 in the partition weighted 2 outranks the hit of distance 2 weighted 1
+		weighted := &schemapb.SearchResultData{
+			NumQueries: 1,
+			TopK:       2,
+			Scores:     []float32{-3, -1 * float32(math.MaxFloat32)},
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{3, -1}}}},
+		}
+		weightSearchResultData(weighted, 2, distance.L2)
+		unweighted := &schemapb.SearchResultData{
+			NumQueries: 1,
+			TopK:       2,
+			Scores:     []float32{-2, -1 * float32(math.MaxFloat32)},
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{2, -1}}}},
+		}
+		weightSearchResultData(unweighted, 1, distance.L2)
+		reduced, err := reduceSearchResultData([]*schemapb.SearchResultData{unweighted, weighted}, 1, 2, distance.L2)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 2}, reduced.GetIds().GetIntId().GetData())
+		assert.Equal(t, []float32{-3, -2}, reduced.GetRawScores())
+	})
+}
+
+func TestQueryShard_searchWeighted(t *testing.T) {
+	qs, err := genSimpleQueryShard(context.Background())
+	require.NoError(t, err)
+
+	search := func(weights []float32) *schemapb.SearchResultData {
+		req, err := genSimpleSearchRequest(IndexFaissIDMap)
+		require.NoError(t, err)
+		if weights != nil {
+			req.WeightedPartitionIDs = []UniqueID{defaultPartitionID}
+			req.PartitionWeights = weights
+		}
+		resp, err := qs.search(context.Background(), &querypb.SearchRequest{
+			Req:        req,
+			SegmentIDs: []int64{defaultSegmentID},
+		})
+		require.NoError(t, err)
+		data, err := decodeSearchResults([]*internalpb.SearchResults{resp})
+		require.NoError(t, err)
+		require.Len(t, data, 1)
+		return data[0]
+	}
+	unweighted := search(nil)
+	assert.Nil(t, unweighted.GetRawScores())
+
+	t.Run("weight 1", func(t *testing.T) {
+		// weight 1 reproduces the unweighted result exactly
+		weighted := search([]float32{1})
+		assert.Equal(t, unweighted.GetIds().GetIntId().GetData(), weighted.GetIds().GetIntId().GetData())
+		assert.Equal(t, unweighted.GetScores(), weighted.GetScores())
+		assert.Equal(t, unweighted.GetScores(), weighted.GetRawScores())
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		weighted := search([]float32{2})
+		assert.Equal(t, unweighted.GetIds().GetIntId().GetData(), weighted.GetIds().GetIntId().GetData())
+		assert.Equal(t, unweighted.GetScores(), weighted.GetRawScores())
+		for i, id := range weighted.GetIds().GetIntId().GetData() {
+			if id != -1 {
+				assert.Equal(t, unweighted.GetScores()[i]/2, weighted.GetScores()[i])
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		req, err := genSimpleSearchRequest(IndexFaissIDMap)
+		require.NoError(t, err)
+		req.WeightedPartitionIDs = []UniqueID{defaultPartitionID}
+		_, err = qs.search(context.Background(), &querypb.SearchRequest{
+			Req:        req,
+			SegmentIDs: []int64{defaultSegmentID},
+		})
+		var weightErr *partitionWeightError
+		assert.True(t, errors.As(err, &weightErr))
+	})
+}
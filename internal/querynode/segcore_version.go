@@ -0,0 +1,96 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../core/output/include
+
+#cgo darwin LDFLAGS: -L${SRCDIR}/../core/output/lib -lmilvus_segcore -Wl,-rpath,"${SRCDIR}/../core/output/lib"
+#cgo linux LDFLAGS: -L${SRCDIR}/../core/output/lib -lmilvus_segcore -Wl,-rpath=${SRCDIR}/../core/output/lib
+#cgo windows LDFLAGS: -L${SRCDIR}/../core/output/lib -lmilvus_segcore -Wl,-rpath=${SRCDIR}/../core/output/lib
+
+#include <stdlib.h>
+#include "segcore/segcore_init_c.h"
+
+*/
+import "C"
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// expectedSegcoreABIVersion is the version of the segcore C API which query node is built against,
+// it must be bumped together with SEGCORE_ABI_VERSION in segcore_init_c.h
+const expectedSegcoreABIVersion int64 = 1
+
+// segcoreVersion is the version information reported by the linked segcore library
+type segcoreVersion struct {
+	abiVersion       int64
+	version          string
+	simdCapabilities []string
+}
+
+// getSegcoreVersion queries the linked segcore library, it's a variable so that tests could stub the cgo calls
+var getSegcoreVersion = func() *segcoreVersion {
+	cVersion := C.SegcoreVersion()
+	defer C.free(unsafe.Pointer(cVersion))
+	cCapabilities := C.SegcoreSimdCapabilities()
+	defer C.free(unsafe.Pointer(cCapabilities))
+	return &segcoreVersion{
+		abiVersion:       int64(C.SegcoreABIVersion()),
+		version:          C.GoString(cVersion),
+		simdCapabilities: parseSimdCapabilities(C.GoString(cCapabilities)),
+	}
+}
+
+func parseSimdCapabilities(capabilities string) []string {
+	result := make([]string, 0)
+	for _, capability := range strings.Split(capabilities, ",") {
+		capability = strings.TrimSpace(capability)
+		if capability != "" {
+			result = append(result, capability)
+		}
+	}
+	return result
+}
+
+// checkSegcoreVersion checks that the linked segcore library is compatible with query node, and that the configured
+// SIMD type is supported by the CPU, a mismatch would otherwise crash deep in cgo calls
+func checkSegcoreVersion(version *segcoreVersion, simdType string) error {
+	if version.abiVersion != expectedSegcoreABIVersion {
+		return fmt.Errorf("segcore library %s has ABI version %d, but query node is built against ABI version %d, "+
+			"please deploy query node and segcore library of the same build", version.version, version.abiVersion, expectedSegcoreABIVersion)
+	}
+
+	simdType = strings.ToLower(simdType)
+	if simdType == "" || simdType == "auto" {
+		return nil
+	}
+	// segcore regards avx as sse4_2
+	if simdType == "avx" {
+		simdType = "sse4_2"
+	}
+	for _, capability := range version.simdCapabilities {
+		if capability == simdType {
+			return nil
+		}
+	}
+	return fmt.Errorf("SIMD type %s is not supported by the CPU, supported SIMD types are [%s], please set common.simdType to auto",
+		simdType, strings.Join(version.simdCapabilities, ", "))
+}
@@ -0,0 +1,82 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/util/etcd"
+)
+
+func TestSegcoreVersion_linked(t *testing.T) {
+	version := getSegcoreVersion()
+	assert.Equal(t, expectedSegcoreABIVersion, version.abiVersion)
+	assert.NotEmpty(t, version.version)
+	assert.NoError(t, checkSegcoreVersion(version, "auto"))
+}
+
+func TestCheckSegcoreVersion(t *testing.T) {
+	version := &segcoreVersion{
+		abiVersion:       expectedSegcoreABIVersion,
+		version:          "2.0-abc",
+		simdCapabilities: []string{"sse4_2", "avx", "avx2"},
+	}
+	assert.NoError(t, checkSegcoreVersion(version, "auto"))
+	assert.NoError(t, checkSegcoreVersion(version, ""))
+	assert.NoError(t, checkSegcoreVersion(version, "AVX2"))
+	assert.NoError(t, checkSegcoreVersion(version, "avx"))
+	assert.NoError(t, checkSegcoreVersion(version, "sse4_2"))
+
+	err := checkSegcoreVersion(version, "avx512")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "avx512")
+
+	version.abiVersion = expectedSegcoreABIVersion + 1
+	err = checkSegcoreVersion(version, "auto")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "2.0-abc")
+}
+
+func TestParseSimdCapabilities(t *testing.T) {
+	assert.Equal(t, []string{}, parseSimdCapabilities(""))
+	assert.Equal(t, []string{"sse4_2", "avx2"}, parseSimdCapabilities("sse4_2, avx2,"))
+}
+
+func TestQueryNode_initSegcoreVersionMismatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	getSegcoreVersionBak := getSegcoreVersion
+	defer func() { getSegcoreVersion = getSegcoreVersionBak }()
+	getSegcoreVersion = func() *segcoreVersion {
+		return &segcoreVersion{abiVersion: expectedSegcoreABIVersion + 1, version: "mismatched"}
+	}
+
+	node, err := genSimpleQueryNode(ctx)
+	assert.NoError(t, err)
+	etcdcli, err := etcd.GetEtcdClient(&Params.EtcdCfg)
+	assert.NoError(t, err)
+	defer etcdcli.Close()
+	node.SetEtcdClient(etcdcli)
+	err = node.Init()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "mismatched")
+	assert.Nil(t, node.segcoreVersion)
+}
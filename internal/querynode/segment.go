@@ -29,12 +29,16 @@ package querynode
 */
 import "C"
 import (
-	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/milvus-io/milvus/internal/util/funcutil"
@@ -45,6 +49,7 @@ import (
 	"github.com/bits-and-blooms/bloom/v3"
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/common"
@@ -56,6 +61,8 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/segcorepb"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/cgoconverter"
+	"github.com/milvus-io/milvus/internal/util/searchvalidation"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
 type segmentType = commonpb.SegmentState
@@ -74,6 +81,9 @@ const (
 type IndexedFieldInfo struct {
 	fieldBinlog *datapb.FieldBinlog
 	indexInfo   *querypb.FieldIndexInfo
+	// rawDataLoaded is true if the raw data is kept in memory besides the index,
+	// e.g. the index is attached asynchronously after serving by brute force
+	rawDataLoaded bool
 }
 
 // Segment is a wrapper of the underlying C-structure segment.
@@ -84,12 +94,31 @@ type Segment struct {
 	segmentID    UniqueID
 	partitionID  UniqueID
 	collectionID UniqueID
+	version      int64 // load version, set before the segment is registered into replica
 
-	onService bool
+	servingMu      sync.RWMutex // guards onService, servingVersion and standby
+	onService      bool         // only the segments on service are searched and queried
+	servingVersion int64        // version of the latest applied serving action
+	standby        bool         // warm standby segment, kept out of service until promoted
 
 	vChannelID   Channel
 	lastMemSize  int64
 	lastRowCount int64
+	rowBudget    int64 // pre-allocation hint of growing segment, 0 if not pre-allocated
+	chunkRows    int64 // rows of a chunk of growing segment, 0 for sealed segment
+	rowSize      int64 // size of the rows of row based inserts expected by the schema, 0 if unknown
+
+	lastActiveTime atomic.Int64 // unix nano of the latest insert or delete, used to reap idle growing segments
+	deletedRows    atomic.Int64 // pks deleted from growing segment since created or compacted, estimates its dead rows
+
+	// deleteMu serializes the delete batches applied by applyDeletes, guards lastDeleteTs
+	deleteMu     sync.Mutex
+	lastDeleteTs Timestamp // the latest timestamp of the delete batches applied
+
+	// reserveMu serializes the row range reservations of growing segment, guards reservedRows and pendingInserts
+	reserveMu      sync.Mutex
+	reservedRows   int64           // end offset of the latest reservation
+	pendingInserts map[int64]int64 // offset to row count of the reserved ranges not inserted yet
 
 	rmMutex          sync.RWMutex // guards recentlyModified
 	recentlyModified bool
@@ -97,12 +126,56 @@ type Segment struct {
 	typeMu      sync.Mutex // guards builtIndex
 	segmentType segmentType
 
+	// idBinlogRowSizes are the row counts of the binlogs of sealed segment, and idBinlogRowEnds are the end offsets
+	// of their rows. The counts are taken from the timestamp binlogs, so the rows of a binlog are located the same
+	// way whatever the type of the primary key is
 	idBinlogRowSizes []int64
+	idBinlogRowEnds  []int64
+	pkType           schemapb.DataType // data type of the primary key in the collection schema
 
-	indexedFieldMutex sync.RWMutex // guards indexedFieldInfos
-	indexedFieldInfos map[UniqueID]*IndexedFieldInfo
+	// indexedFields holds the indexed field infos, and the stale indexes whose fields are served by brute force
+	// on raw data until the indexes are refreshed
+	indexedFields   indexedFieldInfos
+	indexPending    atomic.Bool         // index files are being loaded asynchronously, serve by brute force meanwhile
+	indexLoadCancel context.CancelFunc  // cancels the asynchronous index loading once deleted, guarded by segPtrMu
+	indexManifests  *indexManifestStore // persists the indexes served, set by loader for sealed segments
 
 	pkFilter *bloom.BloomFilter //  bloom filter of pk inside a segment
+	// bloomFilterLookups and bloomFilterPruned count the delete pks tested against pkFilter and the ones rejected
+	bloomFilterLookups atomic.Int64
+	bloomFilterPruned  atomic.Int64
+
+	searchStats segmentSearchStats // searches done in segcore, reset once the segment is released
+
+	// pkIndex is the optional sorted pk index of sealed segment, set before the segment is registered into replica
+	pkIndex pkIndex
+	minPK   primaryKey // min pk recorded in statslog, nil if unknown
+	maxPK   primaryKey // max pk recorded in statslog, nil if unknown
+	// insertTimestamps are the insert timestamps of the rows by offset, set along with pkIndex,
+	// nil if unknown, then all the rows are taken as inserted before any delete
+	insertTimestamps []Timestamp
+
+	// appliedDeletes tracks the deletes of sealed segment with pk index, exported as delete snapshot
+	appliedDeletes appliedDeletes
+	// deltaCheckpoint is the latest delete timestamp covered by the delta logs and the delete snapshot of sealed
+	// segment, set by loader before the segment is registered
+	deltaCheckpoint Timestamp
+
+	// chunkSearch mirrors the float vectors of growing segment to select the candidates of search, nil if disabled
+	chunkSearch *growingChunkSearch
+
+	// loadStats records the bytes downloaded and the durations of the phases of loading the segment
+	loadStats segmentLoadStats
+
+	// quarantine excludes the segment from search and query after operations on it failed repeatedly
+	quarantine segmentQuarantine
+
+	// fieldTransforms are the transforms applied to the field data of sealed segment on load,
+	// set before the segment is registered into replica
+	fieldTransforms []*querypb.FieldTransform
+
+	// diskUsage tracks the local files of the segment
+	diskUsage segmentDiskUsage
 }
 
 // ID returns the identity number.
@@ -110,8 +183,116 @@ func (s *Segment) ID() UniqueID {
 	return s.segmentID
 }
 
+func (s *Segment) setVersion(version int64) {
+	s.version = version
+}
+
+func (s *Segment) getFieldTransforms() []*querypb.FieldTransform {
+	return s.fieldTransforms
+}
+
+func (s *Segment) getVersion() int64 {
+	return s.version
+}
+
+// touch records now as the latest activity time of the segment
+func (s *Segment) touch() {
+	s.lastActiveTime.Store(time.Now().UnixNano())
+}
+
+func (s *Segment) getLastActiveTime() time.Time {
+	return time.Unix(0, s.lastActiveTime.Load())
+}
+
+// isIdle returns true if the segment holds neither rows nor deletes,
+// and has not been inserted into or deleted from for at least tolerance
+func (s *Segment) isIdle(tolerance time.Duration) bool {
+	if s.getRowCount() > 0 || s.getDeletedCount() > 0 {
+		return false
+	}
+	return time.Since(s.getLastActiveTime()) >= tolerance
+}
+
+// shouldCompact returns true if the growing segment holds at least minRows rows,
+// and the ratio of the rows deleted since created or compacted reaches deadRatio
+func (s *Segment) shouldCompact(deadRatio float64, minRows int64) bool {
+	rowCount := s.getRowCount()
+	if rowCount <= 0 || rowCount < minRows {
+		return false
+	}
+	return float64(s.deletedRows.Load())/float64(rowCount) >= deadRatio
+}
+
+func (s *Segment) setPKIndex(index pkIndex) {
+	s.pkIndex = index
+}
+
+func (s *Segment) hasPKIndex() bool {
+	return s.pkIndex != nil
+}
+
+func (s *Segment) setInsertTimestamps(timestamps []Timestamp) {
+	s.insertTimestamps = timestamps
+}
+
+// rowInsertTs returns the insert timestamp of the row at offset, 0 if unknown
+func (s *Segment) rowInsertTs(offset int64) Timestamp {
+	if offset < 0 || offset >= int64(len(s.insertTimestamps)) {
+		return 0
+	}
+	return s.insertTimestamps[offset]
+}
+
+// updatePKRange extends the pk range of segment with the min and max pk recorded in statslog
+func (s *Segment) updatePKRange(minPK, maxPK primaryKey) {
+	if minPK == nil || maxPK == nil {
+		return
+	}
+	if s.minPK == nil || (s.minPK.Type() == minPK.Type() && minPK.LT(s.minPK)) {
+		s.minPK = minPK
+	}
+	if s.maxPK == nil || (s.maxPK.Type() == maxPK.Type() && maxPK.GT(s.maxPK)) {
+		s.maxPK = maxPK
+	}
+}
+
+// searchPK returns the row offset of pk in the segment by the pk index,
+// found is false if pk doesn't exist or the segment has no pk index
+func (s *Segment) searchPK(pk primaryKey) (offset int64, found bool) {
+	if s.pkIndex == nil || pk.Type() != s.pkIndex.pkType() {
+		return -1, false
+	}
+	if s.minPK != nil && s.minPK.Type() == pk.Type() && pk.LT(s.minPK) {
+		return -1, false
+	}
+	if s.maxPK != nil && s.maxPK.Type() == pk.Type() && pk.GT(s.maxPK) {
+		return -1, false
+	}
+	return s.pkIndex.search(pk)
+}
+
+// mayContainPKs returns false only if the pk index proves that none of pks exists in the segment
+func (s *Segment) mayContainPKs(pks []primaryKey) bool {
+	if len(pks) == 0 || !s.hasPKIndex() {
+		return true
+	}
+	for _, pk := range pks {
+		if _, ok := s.searchPK(pk); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Segment) setIDBinlogRowSizes(sizes []int64) {
-	s.idBinlogRowSizes = sizes
+	ends := make([]int64, len(sizes))
+	var end int64
+	for i, size := range sizes {
+		end += size
+		ends[i] = end
+	}
+	s.idBinlogRowSizes = append([]int64(nil), sizes...)
+	s.idBinlogRowEnds = ends
 }
 
 func (s *Segment) getIDBinlogRowSizes() []int64 {
@@ -143,53 +324,201 @@ func (s *Segment) getType() segmentType {
 }
 
 func (s *Segment) getOnService() bool {
+	s.servingMu.RLock()
+	defer s.servingMu.RUnlock()
 	return s.onService
 }
 
 func (s *Segment) setOnService(onService bool) {
+	s.servingMu.Lock()
+	defer s.servingMu.Unlock()
 	s.onService = onService
 }
 
+// setServing applies a serving action of version, it returns false and ignores the action if a newer
+// action has been applied, so that delayed actions of an earlier distribution could not take effect
+func (s *Segment) setServing(serving bool, version int64) bool {
+	s.servingMu.Lock()
+	defer s.servingMu.Unlock()
+	if version < s.servingVersion {
+		return false
+	}
+	s.onService = serving
+	s.servingVersion = version
+	return true
+}
+
+func (s *Segment) isStandby() bool {
+	s.servingMu.RLock()
+	defer s.servingMu.RUnlock()
+	return s.standby
+}
+
+func (s *Segment) setStandby(standby bool) {
+	s.servingMu.Lock()
+	defer s.servingMu.Unlock()
+	s.standby = standby
+}
+
+// promote flips the warm standby segment to serving as a serving action of version,
+// it returns false and changes nothing if the segment is not standby
+func (s *Segment) promote(version int64) bool {
+	s.servingMu.Lock()
+	defer s.servingMu.Unlock()
+	if !s.standby {
+		return false
+	}
+	s.standby = false
+	s.onService = true
+	if version > s.servingVersion {
+		s.servingVersion = version
+	}
+	return true
+}
+
+// setIndexedFieldInfo records a copy of info as the indexed field info of the field,
+// mutating info afterwards doesn't affect the segment
 func (s *Segment) setIndexedFieldInfo(fieldID UniqueID, info *IndexedFieldInfo) {
-	s.indexedFieldMutex.Lock()
-	defer s.indexedFieldMutex.Unlock()
-	s.indexedFieldInfos[fieldID] = info
+	s.indexedFields.set(fieldID, info)
 }
 
+// getIndexedFieldInfo returns a copy of the indexed field info of the field
 func (s *Segment) getIndexedFieldInfo(fieldID UniqueID) (*IndexedFieldInfo, error) {
-	s.indexedFieldMutex.RLock()
-	defer s.indexedFieldMutex.RUnlock()
-	if info, ok := s.indexedFieldInfos[fieldID]; ok {
-		return &IndexedFieldInfo{
-			fieldBinlog: info.fieldBinlog,
-			indexInfo:   info.indexInfo,
-		}, nil
+	if info, ok := s.indexedFields.get(fieldID); ok {
+		return info, nil
 	}
 	return nil, errors.New("Invalid fieldID " + strconv.Itoa(int(fieldID)))
 }
 
-func (s *Segment) hasLoadIndexForIndexedField(fieldID int64) bool {
-	s.indexedFieldMutex.RLock()
-	defer s.indexedFieldMutex.RUnlock()
+// getAllIndexedFieldInfos returns the copies of the indexed field infos of all the fields
+func (s *Segment) getAllIndexedFieldInfos() map[FieldID]*IndexedFieldInfo {
+	infos, _ := s.indexedFields.snapshot()
+	return infos
+}
 
-	if fieldInfo, ok := s.indexedFieldInfos[fieldID]; ok {
-		return fieldInfo.indexInfo != nil && fieldInfo.indexInfo.EnableIndex
+// setIndexStale marks the index of the field stale, the raw data of the field is expected to be loaded
+func (s *Segment) setIndexStale(fieldID UniqueID, info *IndexedFieldInfo) {
+	s.indexedFields.setStale(fieldID, info)
+}
+
+// getStaleIndex returns a copy of the stale index of the field, nil if the index is not stale
+func (s *Segment) getStaleIndex(fieldID UniqueID) *IndexedFieldInfo {
+	return s.indexedFields.getStale(fieldID)
+}
+
+// attachStaleIndex records the refreshed index of the stale field as attached
+func (s *Segment) attachStaleIndex(fieldID UniqueID, info *IndexedFieldInfo) {
+	s.indexedFields.attachStale(fieldID, info)
+}
+
+// saveIndexManifest persists the indexes served by the segment, nothing is persisted once the segment is deleted
+func (s *Segment) saveIndexManifest() {
+	s.segPtrMu.RLock()
+	defer s.segPtrMu.RUnlock()
+	if s.segmentPtr == nil {
+		return
+	}
+	if err := s.indexManifests.save(s); err != nil {
+		log.Warn("failed to save index manifest", zap.Int64("collectionID", s.collectionID),
+			zap.Int64("segmentID", s.segmentID), zap.Error(err))
 	}
+}
 
-	return false
+func (s *Segment) setIndexPending(pending bool) {
+	s.indexPending.Store(pending)
+}
+
+func (s *Segment) isIndexPending() bool {
+	return s.indexPending.Load()
+}
+
+// startIndexLoading marks the index pending and returns the context of the asynchronous index loading,
+// which is canceled once the segment is deleted, false if the segment is deleted already
+func (s *Segment) startIndexLoading() (context.Context, bool) {
+	s.segPtrMu.Lock()
+	defer s.segPtrMu.Unlock()
+	if s.segmentPtr == nil {
+		return nil, false
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.indexLoadCancel = cancel
+	s.setIndexPending(true)
+	return ctx, true
+}
+
+func (s *Segment) hasLoadIndexForIndexedField(fieldID int64) bool {
+	var loaded bool
+	s.indexedFields.inspect(fieldID, func(fieldInfo *IndexedFieldInfo) {
+		loaded = fieldInfo != nil && fieldInfo.indexInfo != nil && fieldInfo.indexInfo.EnableIndex
+	})
+	return loaded
+}
+
+// getOffsetsOnlyFieldIDs returns the indexed fields whose raw data is not in memory, segcore returns only
+// the row offsets for these fields on retrieve, and fillIndexedFieldsData fills them from binlogs
+func (s *Segment) getOffsetsOnlyFieldIDs() []FieldID {
+	fieldIDs := make([]FieldID, 0)
+	s.indexedFields.inspectAll(func(fieldID FieldID, fieldInfo *IndexedFieldInfo) {
+		if isOffsetsOnlyField(fieldInfo) {
+			fieldIDs = append(fieldIDs, fieldID)
+		}
+	})
+	return fieldIDs
+}
+
+func (s *Segment) isOffsetsOnlyField(fieldID FieldID) bool {
+	var offsetsOnly bool
+	s.indexedFields.inspect(fieldID, func(fieldInfo *IndexedFieldInfo) {
+		offsetsOnly = fieldInfo != nil && isOffsetsOnlyField(fieldInfo)
+	})
+	return offsetsOnly
+}
+
+func isOffsetsOnlyField(fieldInfo *IndexedFieldInfo) bool {
+	return fieldInfo.indexInfo != nil && fieldInfo.indexInfo.EnableIndex && !fieldInfo.rawDataLoaded
+}
+
+// checkMetricType checks whether the vector field could be searched with metricType.
+// Fields searched by brute force or with a flat index accept any metric type,
+// other indexes only accept the metric type they are built with.
+func (s *Segment) checkMetricType(fieldID FieldID, metricType string) error {
+	var indexType, indexMetricType string
+	matched := true
+	s.indexedFields.inspect(fieldID, func(fieldInfo *IndexedFieldInfo) {
+		if fieldInfo == nil || fieldInfo.indexInfo == nil || !fieldInfo.indexInfo.EnableIndex {
+			return
+		}
+		indexType, indexMetricType, matched = searchvalidation.MatchIndexMetricType(fieldInfo.indexInfo.GetIndexParams(), metricType)
+	})
+	if matched {
+		return nil
+	}
+	return &metricTypeMismatchError{
+		segmentID:       s.segmentID,
+		fieldID:         fieldID,
+		indexType:       indexType,
+		indexMetricType: indexMetricType,
+		metricType:      metricType,
+	}
 }
 
 func newSegment(collection *Collection, segmentID UniqueID, partitionID UniqueID, collectionID UniqueID, vChannelID Channel, segType segmentType, onService bool) (*Segment, error) {
 	/*
-		CSegmentInterface
-		NewSegment(CCollection collection, uint64_t segment_id, SegmentType seg_type);
+		CStatus
+		NewSegmentWithStatus(CCollection collection, SegmentType seg_type, int64_t segment_id, CSegmentInterface* newSegment);
+
+		CStatus
+		NewGrowingSegmentWithChunkRows(CCollection collection, int64_t segment_id, int64_t chunk_rows, CSegmentInterface* newSegment);
 	*/
 	var segmentPtr C.CSegmentInterface
+	var status C.CStatus
+	var chunkRows int64
 	switch segType {
 	case segmentTypeSealed:
-		segmentPtr = C.NewSegment(collection.collectionPtr, C.Sealed, C.int64_t(segmentID))
+		status = C.NewSegmentWithStatus(collection.collectionPtr, C.Sealed, C.int64_t(segmentID), &segmentPtr)
 	case segmentTypeGrowing:
-		segmentPtr = C.NewSegment(collection.collectionPtr, C.Growing, C.int64_t(segmentID))
+		chunkRows = collection.getGrowingChunkRows()
+		status = C.NewGrowingSegmentWithChunkRows(collection.collectionPtr, C.int64_t(segmentID), C.int64_t(chunkRows), &segmentPtr)
 	default:
 		err := fmt.Errorf("illegal segment type %d when create segment  %d", segType, segmentID)
 		log.Error("create new segment error",
@@ -200,25 +529,70 @@ func newSegment(collection *Collection, segmentID UniqueID, partitionID UniqueID
 			zap.Error(err))
 		return nil, err
 	}
+	if err := HandleCStatus(&status, "NewSegment failed"); err != nil {
+		if segcoreErr, ok := err.(*SegcoreError); ok {
+			segcoreErr.collectionID = collectionID
+			segcoreErr.segmentID = segmentID
+		}
+		log.Error("create new segment error",
+			zap.Int64("collectionID", collectionID),
+			zap.Int64("partitionID", partitionID),
+			zap.Int64("segmentID", segmentID),
+			zap.Int32("segment type", int32(segType)),
+			zap.Error(err))
+		return nil, err
+	}
 
 	log.Debug("create segment",
 		zap.Int64("collectionID", collectionID),
 		zap.Int64("partitionID", partitionID),
 		zap.Int64("segmentID", segmentID),
-		zap.Int32("segmentType", int32(segType)))
+		zap.Int32("segmentType", int32(segType)),
+		zap.Int64("chunkRows", chunkRows))
 
 	var segment = &Segment{
-		segmentPtr:        segmentPtr,
-		segmentType:       segType,
-		segmentID:         segmentID,
-		partitionID:       partitionID,
-		collectionID:      collectionID,
-		vChannelID:        vChannelID,
-		onService:         onService,
-		indexedFieldInfos: make(map[UniqueID]*IndexedFieldInfo),
+		segmentPtr:   segmentPtr,
+		segmentType:  segType,
+		segmentID:    segmentID,
+		partitionID:  partitionID,
+		collectionID: collectionID,
+		vChannelID:   vChannelID,
+		onService:    onService,
+		chunkRows:    chunkRows,
 
 		pkFilter: bloom.NewWithEstimates(bloomFilterSize, maxBloomFalsePositive),
 	}
+	segment.touch()
+	if pkField, err := collection.getPKField(); err == nil {
+		segment.pkType = pkField.schema.GetDataType()
+	}
+
+	if segType == segmentTypeGrowing {
+		if rowBudget := collection.getSegmentRowBudget(); rowBudget > 0 {
+			status := C.ReserveGrowingSegment(segmentPtr, C.int64_t(rowBudget))
+			if err := HandleCStatus(&status, "ReserveGrowingSegment failed"); err != nil {
+				// fall back to allocating on insert
+				log.Warn("failed to pre-allocate growing segment",
+					zap.Int64("segmentID", segmentID),
+					zap.Int64("rowBudget", rowBudget),
+					zap.Error(err))
+			} else {
+				segment.rowBudget = rowBudget
+			}
+		}
+		if Params.QueryNodeCfg.EnableGrowingChunkSearch {
+			chunkSearch, err := newGrowingChunkSearch(collection, int(chunkRows), Params.QueryNodeCfg.ChunkSearchSimilarityTolerance)
+			if err != nil {
+				// the segment is still served by full scan
+				log.Warn("failed to create chunk search of growing segment, searched by full scan",
+					zap.Int64("collectionID", collectionID),
+					zap.Int64("segmentID", segmentID),
+					zap.Error(err))
+			}
+			segment.chunkSearch = chunkSearch
+		}
+		segment.rowSize = collection.rowSize()
+	}
 
 	return segment, nil
 }
@@ -234,9 +608,14 @@ func deleteSegment(segment *Segment) {
 
 	segment.segPtrMu.Lock()
 	defer segment.segPtrMu.Unlock()
+	if segment.indexLoadCancel != nil {
+		segment.indexLoadCancel()
+	}
 	cPtr := segment.segmentPtr
 	C.DeleteSegment(cPtr)
 	segment.segmentPtr = nil
+	segment.releaseDiskFiles()
+	segment.searchStats.reset()
 
 	log.Debug("delete segment from memory", zap.Int64("collectionID", segment.collectionID), zap.Int64("partitionID", segment.partitionID), zap.Int64("segmentID", segment.ID()))
 
@@ -271,6 +650,28 @@ func (s *Segment) getDeletedCount() int64 {
 	return int64(deletedCount)
 }
 
+func (s *Segment) getRowBudget() int64 {
+	return s.rowBudget
+}
+
+func (s *Segment) getChunkRows() int64 {
+	return s.chunkRows
+}
+
+func (s *Segment) getAllocatedChunkNum() int64 {
+	/*
+		long int
+		GetAllocatedChunkNum(CSegmentInterface c_segment);
+	*/
+	s.segPtrMu.RLock()
+	defer s.segPtrMu.RUnlock()
+	if s.segmentPtr == nil || s.getType() != segmentTypeGrowing {
+		return 0
+	}
+	var chunkNum = C.GetAllocatedChunkNum(s.segmentPtr)
+	return int64(chunkNum)
+}
+
 func (s *Segment) getMemSize() int64 {
 	/*
 		long int
@@ -283,10 +684,63 @@ func (s *Segment) getMemSize() int64 {
 	}
 	var memoryUsageInBytes = C.GetMemoryUsageInBytes(s.segmentPtr)
 
-	return int64(memoryUsageInBytes)
+	memSize := int64(memoryUsageInBytes)
+	if s.pkIndex != nil {
+		memSize += s.pkIndex.memSize()
+	}
+	memSize += int64(cap(s.insertTimestamps)) * 8
+	if s.chunkSearch != nil {
+		memSize += s.chunkSearch.memSize()
+	}
+	return memSize
+}
+
+func (s *Segment) search(ctx context.Context, plan *SearchPlan,
+	searchRequests []*searchRequest,
+	timestamp []Timestamp) (*SearchResult, error) {
+	// the permit is held only during the search in segcore, see searchLimiter, it's acquired out of guard so that
+	// a search canceled while waiting for it is not taken as a failure of the segment
+	release, err := cgoSearchLimiter.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the search is timed on the worker of the pool, excluding the time queued for it
+	var exec time.Duration
+	defer func() { release(exec) }()
+
+	var searchResult *SearchResult
+	err = s.guard(segmentOpSearch, func() error {
+		return cgoReadPool.run(func() error {
+			var err error
+			start := time.Now()
+			searchResult, err = s.searchInSegcore(plan, searchRequests, timestamp)
+			exec = time.Since(start)
+			if err == nil {
+				s.recordSearch(exec)
+			}
+			return err
+		})
+	})
+	return searchResult, err
+}
+
+// recordSearch records a search on the segment for latency, scanning the rows of the segment. The search is not
+// recorded once the segment is released, so that the stats reset on release stay empty.
+func (s *Segment) recordSearch(latency time.Duration) {
+	s.segPtrMu.RLock()
+	defer s.segPtrMu.RUnlock()
+	if s.segmentPtr == nil {
+		return
+	}
+	s.searchStats.record(latency, int64(C.GetRowCount(s.segmentPtr)))
+}
+
+// getSearchStats returns the snapshot of the searches done on the segment
+func (s *Segment) getSearchStats() searchStats {
+	return s.searchStats.snapshot()
 }
 
-func (s *Segment) search(plan *SearchPlan,
+func (s *Segment) searchInSegcore(plan *SearchPlan,
 	searchRequests []*searchRequest,
 	timestamp []Timestamp) (*SearchResult, error) {
 	/*
@@ -298,10 +752,20 @@ func (s *Segment) search(plan *SearchPlan,
 			long int* result_ids,
 			float* result_distances);
 	*/
+	if plan.chunkSearchable && s.chunkSearch != nil && len(searchRequests) == 1 {
+		result, ok, err := s.searchByChunks(plan, searchRequests[0], timestamp[0])
+		if ok || err != nil {
+			return result, err
+		}
+	}
+	if plan.prefilter != nil && s.shouldPrefilter(plan.prefilter) {
+		return s.searchWithPrefilter(plan, searchRequests[0], timestamp[0])
+	}
+
 	s.segPtrMu.RLock()
 	defer s.segPtrMu.RUnlock()
 	if s.segmentPtr == nil {
-		return nil, errors.New("null seg core pointer")
+		return nil, fmt.Errorf("%w, null seg core pointer, segmentID = %d", ErrSegmentReleased, s.segmentID)
 	}
 	cPlaceholderGroups := make([]C.CPlaceholderGroup, 0)
 	for _, pg := range searchRequests {
@@ -323,6 +787,38 @@ func (s *Segment) search(plan *SearchPlan,
 	return &searchResult, nil
 }
 
+// searchWithCandidates searches only the rows set in candidates, bit i of candidates[i/8] is row i,
+// the predicate of plan is not evaluated. bruteForce searches the candidates by brute force instead of
+// the vector index if the raw vectors are in memory
+func (s *Segment) searchWithCandidates(plan *SearchPlan, searchReq *searchRequest, timestamp Timestamp,
+	candidates []byte, numRows int64, bruteForce bool) (*SearchResult, error) {
+	s.segPtrMu.RLock()
+	defer s.segPtrMu.RUnlock()
+	return s.searchWithCandidatesLocked(plan, searchReq, timestamp, candidates, numRows, bruteForce)
+}
+
+// searchWithCandidatesLocked is searchWithCandidates with segPtrMu held by the caller
+func (s *Segment) searchWithCandidatesLocked(plan *SearchPlan, searchReq *searchRequest, timestamp Timestamp,
+	candidates []byte, numRows int64, bruteForce bool) (*SearchResult, error) {
+	if s.segmentPtr == nil {
+		return nil, fmt.Errorf("%w, null seg core pointer, segmentID = %d", ErrSegmentReleased, s.segmentID)
+	}
+
+	var cCandidates *C.uint8_t
+	if len(candidates) > 0 {
+		cCandidates = (*C.uint8_t)(unsafe.Pointer(&candidates[0]))
+	}
+	var searchResult SearchResult
+	tr := timerecord.NewTimeRecorder("cgoSearchWithCandidates")
+	status := C.SearchWithCandidates(s.segmentPtr, plan.cSearchPlan, searchReq.cPlaceholderGroup, C.uint64_t(timestamp),
+		cCandidates, C.int64_t(numRows), C.bool(bruteForce), &searchResult.cSearchResult, C.int64_t(s.segmentID))
+	metrics.QueryNodeSQSegmentLatencyInCore.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), metrics.SearchLabel).Observe(float64(tr.ElapseSpan().Milliseconds()))
+	if err := HandleCStatus(&status, "SearchWithCandidates failed"); err != nil {
+		return nil, err
+	}
+	return &searchResult, nil
+}
+
 // HandleCProto deal with the result proto returned from CGO
 func HandleCProto(cRes *C.CProto, msg proto.Message) error {
 	// Standalone CProto is protobuf created by C side,
@@ -334,74 +830,257 @@ func HandleCProto(cRes *C.CProto, msg proto.Message) error {
 	return proto.Unmarshal(blob, msg)
 }
 
+// retrieve retrieves the output fields of plan, the indexed fields whose raw data is not in memory are
+// returned without data, which are filled from binlogs by fillIndexedFieldsData
 func (s *Segment) retrieve(plan *RetrievePlan) (*segcorepb.RetrieveResults, error) {
+	results, err := s.retrieveBatch([]*RetrievePlan{plan})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// retrieveBatch retrieves plans against the segment in one go, the segment pointer is pinned once for all the plans,
+// so that they are executed against the same segment, e.g. not released or replaced in between. The results are in
+// the order of plans. It fails without retrieving anything if any plan is nil or the segment has been released, and
+// returns no result if any plan fails, the C results of the plans retrieved before are freed then
+func (s *Segment) retrieveBatch(plans []*RetrievePlan) ([]*segcorepb.RetrieveResults, error) {
+	var invalid []string
+	for i, plan := range plans {
+		if plan == nil || plan.cRetrievePlan == nil {
+			invalid = append(invalid, fmt.Sprintf("plan %d is nil", i))
+		}
+	}
+
+	cResults := make([]RetrieveResult, len(plans))
+	err := s.guard(segmentOpRetrieve, func() error {
+		return cgoReadPool.run(func() error {
+			s.segPtrMu.RLock()
+			defer s.segPtrMu.RUnlock()
+			if s.segmentPtr == nil {
+				return fmt.Errorf("%w, null seg core pointer, segmentID = %d, %s", ErrSegmentReleased, s.segmentID,
+					strings.Join(append(invalid, fmt.Sprintf("%d plans not retrieved", len(plans))), ", "))
+			}
+			if len(invalid) > 0 {
+				return fmt.Errorf("invalid retrieve plans of segment %d: %s", s.segmentID, strings.Join(invalid, ", "))
+			}
+			offsetsOnlyFieldIDs := s.getOffsetsOnlyFieldIDs()
+			for i, plan := range plans {
+				if err := s.retrieveInSegcore(plan, offsetsOnlyFieldIDs, &cResults[i]); err != nil {
+					deleteRetrieveResults(cResults[:i])
+					return fmt.Errorf("retrieve plan %d of %d failed: %w", i, len(plans), err)
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the results are unmarshalled out of the lock, each C result is freed by HandleCProto
+	results := make([]*segcorepb.RetrieveResults, len(plans))
+	for i := range plans {
+		results[i] = new(segcorepb.RetrieveResults)
+		if err := HandleCProto(&cResults[i].cRetrieveResult, results[i]); err != nil {
+			deleteRetrieveResults(cResults[i+1:])
+			return nil, err
+		}
+	}
+	for i, plan := range plans {
+		if results[i], err = s.postProcessRetrieveResult(results[i], plan); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// postProcessRetrieveResult samples and orders the rows of result retrieved by plan
+func (s *Segment) postProcessRetrieveResult(result *segcorepb.RetrieveResults, plan *RetrievePlan) (*segcorepb.RetrieveResults, error) {
+	var err error
+	if plan.sampleSize > 0 {
+		result, err = sampleRetrieveResult(result, plan.sampleSize, typeutil.NewSampleRand(plan.sampleSeed, s.ID()))
+	}
+	// the top rows are left to the merge if the field to sort by is filled from binlogs later
+	if err == nil && plan.limit > 0 && !s.isOffsetsOnlyField(plan.orderByField) {
+		result, err = orderRetrieveResult(result, plan)
+	}
+	return result, err
+}
+
+// deleteRetrieveResults frees the C results not unmarshalled
+func deleteRetrieveResults(results []RetrieveResult) {
+	for i := range results {
+		if results[i].cRetrieveResult.proto_blob != nil {
+			C.DeleteRetrieveResult(&results[i].cRetrieveResult)
+			results[i].cRetrieveResult.proto_blob = nil
+		}
+	}
+}
+
+// selectRetrieveRows returns the rows of result in the order of rows. The fields returned without data by segcore,
+// which are filled from binlogs later, are kept without data.
+func selectRetrieveRows(result *segcorepb.RetrieveResults, rows []int) (*segcorepb.RetrieveResults, error) {
+	builders := make([]*typeutil.ColumnBuilder, len(result.GetFieldsData()))
+	for i, column := range result.GetFieldsData() {
+		if column.GetScalars().GetData() == nil && column.GetVectors().GetData() == nil {
+			continue
+		}
+		builder, err := typeutil.NewColumnBuilderOf(column)
+		if err != nil {
+			return nil, err
+		}
+		builders[i] = builder
+	}
+
+	selected := &segcorepb.RetrieveResults{
+		Ids:        &schemapb.IDs{},
+		FieldsData: make([]*schemapb.FieldData, 0, len(result.GetFieldsData())),
+	}
+	for _, row := range rows {
+		typeutil.AppendIDs(selected.Ids, result.Ids, row)
+		if row < len(result.GetOffset()) {
+			selected.Offset = append(selected.Offset, result.Offset[row])
+		}
+		for i, builder := range builders {
+			if builder == nil {
+				continue
+			}
+			if err := builder.AppendRow(result.FieldsData[i], row); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for i, column := range result.GetFieldsData() {
+		if builders[i] == nil {
+			selected.FieldsData = append(selected.FieldsData, proto.Clone(column).(*schemapb.FieldData))
+			continue
+		}
+		selected.FieldsData = append(selected.FieldsData, builders[i].Build())
+	}
+	return selected, nil
+}
+
+func (s *Segment) retrieveWithOffsetsOnlyFields(plan *RetrievePlan, offsetsOnlyFieldIDs []FieldID) (*segcorepb.RetrieveResults, error) {
 	s.segPtrMu.RLock()
 	defer s.segPtrMu.RUnlock()
 	if s.segmentPtr == nil {
-		return nil, errors.New("null seg core pointer")
+		return nil, fmt.Errorf("%w, null seg core pointer, segmentID = %d", ErrSegmentReleased, s.segmentID)
 	}
 
 	var retrieveResult RetrieveResult
+	if err := s.retrieveInSegcore(plan, offsetsOnlyFieldIDs, &retrieveResult); err != nil {
+		return nil, err
+	}
+	result := new(segcorepb.RetrieveResults)
+	if err := HandleCProto(&retrieveResult.cRetrieveResult, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// retrieveInSegcore retrieves plan into the C result, it's called with segPtrMu held and the segment pointer checked
+func (s *Segment) retrieveInSegcore(plan *RetrievePlan, offsetsOnlyFieldIDs []FieldID, retrieveResult *RetrieveResult) error {
 	ts := C.uint64_t(plan.Timestamp)
+	var cFieldIDs *C.int64_t
+	if len(offsetsOnlyFieldIDs) > 0 {
+		cFieldIDs = (*C.int64_t)(unsafe.Pointer(&offsetsOnlyFieldIDs[0]))
+	}
 	tr := timerecord.NewTimeRecorder("cgoRetrieve")
-	status := C.Retrieve(s.segmentPtr, plan.cRetrievePlan, ts, &retrieveResult.cRetrieveResult)
+	status := C.RetrieveWithOffsetsOnlyFields(s.segmentPtr, plan.cRetrievePlan, ts,
+		cFieldIDs, C.int64_t(len(offsetsOnlyFieldIDs)), &retrieveResult.cRetrieveResult)
 	metrics.QueryNodeSQSegmentLatencyInCore.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID),
 		metrics.QueryLabel).Observe(float64(tr.ElapseSpan().Milliseconds()))
 	if err := HandleCStatus(&status, "Retrieve failed"); err != nil {
+		return err
+	}
+	metrics.QueryNodeRetrieveMaterializedBytes.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).
+		Add(float64(retrieveResult.cRetrieveResult.proto_size))
+	return nil
+}
+
+// retrieveByOffsets returns the column of fieldID of the rows at offsets, the offsets must be less than the row count
+func (s *Segment) retrieveByOffsets(fieldID FieldID, offsets []int64) (*schemapb.FieldData, error) {
+	s.segPtrMu.RLock()
+	defer s.segPtrMu.RUnlock()
+	if s.segmentPtr == nil {
+		return nil, fmt.Errorf("%w, null seg core pointer, segmentID = %d", ErrSegmentReleased, s.segmentID)
+	}
+	if len(offsets) == 0 {
+		return nil, fmt.Errorf("no offset to retrieve, segmentID = %d", s.segmentID)
+	}
+
+	var retrieveResult RetrieveResult
+	status := C.RetrieveByOffsets(s.segmentPtr, C.int64_t(fieldID), (*C.int64_t)(unsafe.Pointer(&offsets[0])),
+		C.int64_t(len(offsets)), &retrieveResult.cRetrieveResult)
+	if err := HandleCStatus(&status, "RetrieveByOffsets failed"); err != nil {
 		return nil, err
 	}
 	result := new(segcorepb.RetrieveResults)
 	if err := HandleCProto(&retrieveResult.cRetrieveResult, result); err != nil {
 		return nil, err
 	}
-	return result, nil
+	if len(result.GetFieldsData()) != 1 {
+		return nil, fmt.Errorf("unexpected columns %d retrieved by offsets, segmentID = %d", len(result.GetFieldsData()), s.segmentID)
+	}
+	return result.GetFieldsData()[0], nil
 }
 
-func (s *Segment) getFieldDataPath(indexedFieldInfo *IndexedFieldInfo, offset int64) (dataPath string, offsetInBinlog int64) {
+// getFieldDataPath returns the binlog of the indexed field holding the row at offset, and the offset of the row in
+// the binlog. The binlogs of the field are expected to be split the same way as the timestamp binlogs.
+func (s *Segment) getFieldDataPath(indexedFieldInfo *IndexedFieldInfo, offset int64) (dataPath string, offsetInBinlog int64, err error) {
+	binlogs := indexedFieldInfo.fieldBinlog.GetBinlogs()
+	if len(binlogs) != len(s.idBinlogRowEnds) {
+		return "", 0, fmt.Errorf("%d binlogs of field %d mismatch %d binlogs of rows, segmentID = %d, pkType = %s",
+			len(binlogs), indexedFieldInfo.fieldBinlog.GetFieldID(), len(s.idBinlogRowEnds), s.segmentID, s.pkType.String())
+	}
+	index := sort.Search(len(s.idBinlogRowEnds), func(i int) bool { return s.idBinlogRowEnds[i] > offset })
+	if offset < 0 || index == len(s.idBinlogRowEnds) {
+		return "", 0, fmt.Errorf("offset %d out of %d rows of binlogs, segmentID = %d, pkType = %s",
+			offset, s.rowsOfBinlogs(), s.segmentID, s.pkType.String())
+	}
 	offsetInBinlog = offset
-	for index, idBinlogRowSize := range s.idBinlogRowSizes {
-		if offsetInBinlog < idBinlogRowSize {
-			dataPath = indexedFieldInfo.fieldBinlog.Binlogs[index].GetLogPath()
-			break
-		} else {
-			offsetInBinlog -= idBinlogRowSize
-		}
+	if index > 0 {
+		offsetInBinlog -= s.idBinlogRowEnds[index-1]
 	}
-	return dataPath, offsetInBinlog
+	return binlogs[index].GetLogPath(), offsetInBinlog, nil
 }
 
-func fillBinVecFieldData(vcm storage.ChunkManager, dataPath string, fieldData *schemapb.FieldData, i int, offset int64, endian binary.ByteOrder) error {
-	dim := fieldData.GetVectors().GetDim()
-	rowBytes := dim / 8
+// rowsOfBinlogs returns the total row count of the binlogs of sealed segment
+func (s *Segment) rowsOfBinlogs() int64 {
+	if len(s.idBinlogRowEnds) == 0 {
+		return 0
+	}
+	return s.idBinlogRowEnds[len(s.idBinlogRowEnds)-1]
+}
+
+func fillBinVecFieldData(vcm storage.ChunkManager, dataPath string, builder *typeutil.ColumnBuilder, offset int64, endian binary.ByteOrder) error {
+	rowBytes := builder.Dim() / 8
 	content, err := vcm.ReadAt(dataPath, offset*rowBytes, rowBytes)
 	if err != nil {
 		return err
 	}
-	x := fieldData.GetVectors().GetData().(*schemapb.VectorField_BinaryVector)
-	resultLen := dim / 8
-	copy(x.BinaryVector[i*int(resultLen):(i+1)*int(resultLen)], content)
-	return nil
+	return builder.AppendBinaryVector(content)
 }
 
-func fillFloatVecFieldData(vcm storage.ChunkManager, dataPath string, fieldData *schemapb.FieldData, i int, offset int64, endian binary.ByteOrder) error {
-	dim := fieldData.GetVectors().GetDim()
+func fillFloatVecFieldData(vcm storage.ChunkManager, dataPath string, builder *typeutil.ColumnBuilder, offset int64, endian binary.ByteOrder) error {
+	dim := builder.Dim()
 	rowBytes := dim * 4
 	content, err := vcm.ReadAt(dataPath, offset*rowBytes, rowBytes)
 	if err != nil {
 		return err
 	}
-	x := fieldData.GetVectors().GetData().(*schemapb.VectorField_FloatVector)
-	floatResult := make([]float32, dim)
-	buf := bytes.NewReader(content)
-	if err = binary.Read(buf, endian, &floatResult); err != nil {
-		return err
+	if int64(len(content)) != rowBytes {
+		return fmt.Errorf("read %d bytes of float vector with dim %d from %s", len(content), dim, dataPath)
 	}
-	resultLen := dim
-	copy(x.FloatVector.Data[i*int(resultLen):(i+1)*int(resultLen)], floatResult)
-	return nil
+	vector := make([]float32, dim)
+	for j := range vector {
+		vector[j] = math.Float32frombits(endian.Uint32(content[j*4:]))
+	}
+	return builder.AppendFloatVector(vector)
 }
 
-func fillBoolFieldData(vcm storage.ChunkManager, dataPath string, fieldData *schemapb.FieldData, i int, offset int64, endian binary.ByteOrder) error {
+func fillBoolFieldData(vcm storage.ChunkManager, dataPath string, builder *typeutil.ColumnBuilder, offset int64, endian binary.ByteOrder) error {
 	// read whole file.
 	// TODO: optimize here.
 	content, err := vcm.Read(dataPath)
@@ -413,11 +1092,10 @@ func fillBoolFieldData(vcm storage.ChunkManager, dataPath string, fieldData *sch
 	if err != nil {
 		return err
 	}
-	fieldData.GetScalars().GetBoolData().GetData()[i] = arr.Data[offset]
-	return nil
+	return builder.AppendBool(arr.Data[offset])
 }
 
-func fillStringFieldData(vcm storage.ChunkManager, dataPath string, fieldData *schemapb.FieldData, i int, offset int64, endian binary.ByteOrder) error {
+func fillStringFieldData(vcm storage.ChunkManager, dataPath string, builder *typeutil.ColumnBuilder, offset int64, endian binary.ByteOrder) error {
 	// read whole file.
 	// TODO: optimize here.
 	content, err := vcm.Read(dataPath)
@@ -429,11 +1107,10 @@ func fillStringFieldData(vcm storage.ChunkManager, dataPath string, fieldData *s
 	if err != nil {
 		return err
 	}
-	fieldData.GetScalars().GetStringData().GetData()[i] = arr.Data[offset]
-	return nil
+	return builder.AppendString(arr.Data[offset])
 }
 
-func fillInt8FieldData(vcm storage.ChunkManager, dataPath string, fieldData *schemapb.FieldData, i int, offset int64, endian binary.ByteOrder) error {
+func fillInt8FieldData(vcm storage.ChunkManager, dataPath string, builder *typeutil.ColumnBuilder, offset int64, endian binary.ByteOrder) error {
 	// read by offset.
 	rowBytes := int64(1)
 	content, err := vcm.ReadAt(dataPath, offset*rowBytes, rowBytes)
@@ -444,11 +1121,10 @@ func fillInt8FieldData(vcm storage.ChunkManager, dataPath string, fieldData *sch
 	if err := funcutil.ReadBinary(endian, content, &i8); err != nil {
 		return err
 	}
-	fieldData.GetScalars().GetIntData().GetData()[i] = int32(i8)
-	return nil
+	return builder.AppendInt(int32(i8))
 }
 
-func fillInt16FieldData(vcm storage.ChunkManager, dataPath string, fieldData *schemapb.FieldData, i int, offset int64, endian binary.ByteOrder) error {
+func fillInt16FieldData(vcm storage.ChunkManager, dataPath string, builder *typeutil.ColumnBuilder, offset int64, endian binary.ByteOrder) error {
 	// read by offset.
 	rowBytes := int64(2)
 	content, err := vcm.ReadAt(dataPath, offset*rowBytes, rowBytes)
@@ -459,84 +1135,102 @@ func fillInt16FieldData(vcm storage.ChunkManager, dataPath string, fieldData *sc
 	if err := funcutil.ReadBinary(endian, content, &i16); err != nil {
 		return err
 	}
-	fieldData.GetScalars().GetIntData().GetData()[i] = int32(i16)
-	return nil
+	return builder.AppendInt(int32(i16))
 }
 
-func fillInt32FieldData(vcm storage.ChunkManager, dataPath string, fieldData *schemapb.FieldData, i int, offset int64, endian binary.ByteOrder) error {
+func fillInt32FieldData(vcm storage.ChunkManager, dataPath string, builder *typeutil.ColumnBuilder, offset int64, endian binary.ByteOrder) error {
 	// read by offset.
 	rowBytes := int64(4)
 	content, err := vcm.ReadAt(dataPath, offset*rowBytes, rowBytes)
 	if err != nil {
 		return err
 	}
-	return funcutil.ReadBinary(endian, content, &(fieldData.GetScalars().GetIntData().GetData()[i]))
+	var i32 int32
+	if err := funcutil.ReadBinary(endian, content, &i32); err != nil {
+		return err
+	}
+	return builder.AppendInt(i32)
 }
 
-func fillInt64FieldData(vcm storage.ChunkManager, dataPath string, fieldData *schemapb.FieldData, i int, offset int64, endian binary.ByteOrder) error {
+func fillInt64FieldData(vcm storage.ChunkManager, dataPath string, builder *typeutil.ColumnBuilder, offset int64, endian binary.ByteOrder) error {
 	// read by offset.
 	rowBytes := int64(8)
 	content, err := vcm.ReadAt(dataPath, offset*rowBytes, rowBytes)
 	if err != nil {
 		return err
 	}
-	return funcutil.ReadBinary(endian, content, &(fieldData.GetScalars().GetLongData().GetData()[i]))
+	var i64 int64
+	if err := funcutil.ReadBinary(endian, content, &i64); err != nil {
+		return err
+	}
+	return builder.AppendLong(i64)
 }
 
-func fillFloatFieldData(vcm storage.ChunkManager, dataPath string, fieldData *schemapb.FieldData, i int, offset int64, endian binary.ByteOrder) error {
+func fillFloatFieldData(vcm storage.ChunkManager, dataPath string, builder *typeutil.ColumnBuilder, offset int64, endian binary.ByteOrder) error {
 	// read by offset.
 	rowBytes := int64(4)
 	content, err := vcm.ReadAt(dataPath, offset*rowBytes, rowBytes)
 	if err != nil {
 		return err
 	}
-	return funcutil.ReadBinary(endian, content, &(fieldData.GetScalars().GetFloatData().GetData()[i]))
+	var f32 float32
+	if err := funcutil.ReadBinary(endian, content, &f32); err != nil {
+		return err
+	}
+	return builder.AppendFloat(f32)
 }
 
-func fillDoubleFieldData(vcm storage.ChunkManager, dataPath string, fieldData *schemapb.FieldData, i int, offset int64, endian binary.ByteOrder) error {
+func fillDoubleFieldData(vcm storage.ChunkManager, dataPath string, builder *typeutil.ColumnBuilder, offset int64, endian binary.ByteOrder) error {
 	// read by offset.
 	rowBytes := int64(8)
 	content, err := vcm.ReadAt(dataPath, offset*rowBytes, rowBytes)
 	if err != nil {
 		return err
 	}
-	return funcutil.ReadBinary(endian, content, &(fieldData.GetScalars().GetDoubleData().GetData()[i]))
+	var f64 float64
+	if err := funcutil.ReadBinary(endian, content, &f64); err != nil {
+		return err
+	}
+	return builder.AppendDouble(f64)
 }
 
-func fillFieldData(vcm storage.ChunkManager, dataPath string, fieldData *schemapb.FieldData, i int, offset int64, endian binary.ByteOrder) error {
-	switch fieldData.Type {
+// fillFieldData appends the row at offset of the binlog of dataPath to builder
+func fillFieldData(vcm storage.ChunkManager, dataPath string, builder *typeutil.ColumnBuilder, offset int64, endian binary.ByteOrder) error {
+	switch builder.DataType() {
 	case schemapb.DataType_BinaryVector:
-		return fillBinVecFieldData(vcm, dataPath, fieldData, i, offset, endian)
+		return fillBinVecFieldData(vcm, dataPath, builder, offset, endian)
 	case schemapb.DataType_FloatVector:
-		return fillFloatVecFieldData(vcm, dataPath, fieldData, i, offset, endian)
+		return fillFloatVecFieldData(vcm, dataPath, builder, offset, endian)
 	case schemapb.DataType_Bool:
-		return fillBoolFieldData(vcm, dataPath, fieldData, i, offset, endian)
+		return fillBoolFieldData(vcm, dataPath, builder, offset, endian)
 	case schemapb.DataType_String, schemapb.DataType_VarChar:
-		return fillStringFieldData(vcm, dataPath, fieldData, i, offset, endian)
+		return fillStringFieldData(vcm, dataPath, builder, offset, endian)
 	case schemapb.DataType_Int8:
-		return fillInt8FieldData(vcm, dataPath, fieldData, i, offset, endian)
+		return fillInt8FieldData(vcm, dataPath, builder, offset, endian)
 	case schemapb.DataType_Int16:
-		return fillInt16FieldData(vcm, dataPath, fieldData, i, offset, endian)
+		return fillInt16FieldData(vcm, dataPath, builder, offset, endian)
 	case schemapb.DataType_Int32:
-		return fillInt32FieldData(vcm, dataPath, fieldData, i, offset, endian)
+		return fillInt32FieldData(vcm, dataPath, builder, offset, endian)
 	case schemapb.DataType_Int64:
-		return fillInt64FieldData(vcm, dataPath, fieldData, i, offset, endian)
+		return fillInt64FieldData(vcm, dataPath, builder, offset, endian)
 	case schemapb.DataType_Float:
-		return fillFloatFieldData(vcm, dataPath, fieldData, i, offset, endian)
+		return fillFloatFieldData(vcm, dataPath, builder, offset, endian)
 	case schemapb.DataType_Double:
-		return fillDoubleFieldData(vcm, dataPath, fieldData, i, offset, endian)
+		return fillDoubleFieldData(vcm, dataPath, builder, offset, endian)
 	default:
-		return fmt.Errorf("invalid data type: %s", fieldData.Type.String())
+		return fmt.Errorf("invalid data type: %s", builder.DataType().String())
 	}
 }
 
+// fillIndexedFieldsData fills the raw data of indexed fields from binlogs,
+// every binlog file read is recorded in tracker.
 func (s *Segment) fillIndexedFieldsData(collectionID UniqueID,
-	vcm storage.ChunkManager, result *segcorepb.RetrieveResults) error {
+	vcm storage.ChunkManager, result *segcorepb.RetrieveResults, tracker *binlogTracker) error {
 
 	for _, fieldData := range result.FieldsData {
-		// If the vector field doesn't have indexed. Vector data is in memory for
-		// brute force search. No need to download data from remote.
-		if !s.hasLoadIndexForIndexedField(fieldData.FieldId) {
+		// If the field isn't indexed, or its raw data is kept in memory besides the index,
+		// the data is returned by segcore directly. No need to download data from remote.
+		if !s.isOffsetsOnlyField(fieldData.FieldId) {
 			continue
 		}
 
@@ -545,16 +1239,54 @@ func (s *Segment) fillIndexedFieldsData(collectionID UniqueID,
 			continue
 		}
 
-		// TODO: optimize here. Now we'll read a whole file from storage every time we retrieve raw data by offset.
+		// check the binlog files to read before reading any of them
+		dataPaths := make([]string, len(result.Offset))
+		offsetsInBinlog := make([]int64, len(result.Offset))
 		for i, offset := range result.Offset {
-			dataPath, offsetInBinlog := s.getFieldDataPath(indexedFieldInfo, offset)
-			endian := common.Endian
+			dataPaths[i], offsetsInBinlog[i], err = s.getFieldDataPath(indexedFieldInfo, offset)
+			if err != nil {
+				return err
+			}
+			if err := tracker.touch(dataPaths[i]); err != nil {
+				return err
+			}
+		}
 
-			// fill field data that fieldData[i] = dataPath[offsetInBinlog*rowBytes, (offsetInBinlog+1)*rowBytes]
-			if err := fillFieldData(vcm, dataPath, fieldData, i, offsetInBinlog, endian); err != nil {
+		var field *schemapb.FieldData
+		switch fieldData.GetType() {
+		case schemapb.DataType_String, schemapb.DataType_VarChar:
+			column, err := fillStringColumn(vcm, dataPaths, offsetsInBinlog)
+			if err != nil {
 				return err
 			}
+			field = &schemapb.FieldData{Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_StringData{StringData: column},
+			}}}
+		default:
+			builder, err := typeutil.NewColumnBuilderOf(fieldData)
+			if err != nil {
+				return err
+			}
+			// TODO: optimize here. Now we'll read a whole file from storage every time we retrieve raw data by offset.
+			for i := range result.Offset {
+				endian := common.Endian
+
+				// fill the i-th row by dataPath[offsetInBinlog*rowBytes, (offsetInBinlog+1)*rowBytes]
+				if err := fillFieldData(vcm, dataPaths[i], builder, offsetsInBinlog[i], endian); err != nil {
+					return err
+				}
+			}
+			field = builder.Build()
 		}
+		// the binlog files read may be cached on disk
+		attributed := make(map[string]struct{})
+		for _, dataPath := range dataPaths {
+			if _, ok := attributed[dataPath]; !ok {
+				attributed[dataPath] = struct{}{}
+				s.attributeCachedFile(vcm, dataPath)
+			}
+		}
+		fieldData.Field = field.Field
 	}
 
 	return nil
@@ -588,15 +1320,70 @@ func (s *Segment) segmentPreInsert(numOfRecords int) (int64, error) {
 	if s.segmentType != segmentTypeGrowing {
 		return 0, nil
 	}
+	s.touch()
+
+	s.reserveMu.Lock()
+	defer s.reserveMu.Unlock()
+	rows := int64(numOfRecords)
+	if rows < 0 {
+		return 0, &insertOffsetError{segmentID: s.segmentID, offset: s.reservedRows, rows: rows, reason: "negative row count"}
+	}
+	if s.reservedRows > math.MaxInt64-rows {
+		return 0, &insertOffsetError{segmentID: s.segmentID, offset: s.reservedRows, rows: rows, reason: "offset overflows"}
+	}
 	var offset int64
 	cOffset := (*C.int64_t)(&offset)
-	status := C.PreInsert(s.segmentPtr, C.int64_t(int64(numOfRecords)), cOffset)
+	status := C.PreInsert(s.segmentPtr, C.int64_t(rows), cOffset)
 	if err := HandleCStatus(&status, "PreInsert failed"); err != nil {
 		return 0, err
 	}
+	// the reservations are serialized, so segcore must reserve right after the previous one
+	if offset != s.reservedRows {
+		return 0, &insertOffsetError{segmentID: s.segmentID, offset: offset, rows: rows,
+			reason: fmt.Sprintf("segcore reserved offset mismatches the reserved rows %d", s.reservedRows)}
+	}
+	s.reservedRows = offset + rows
+	if rows > 0 {
+		if s.pendingInserts == nil {
+			s.pendingInserts = make(map[int64]int64)
+		}
+		s.pendingInserts[offset] = rows
+	}
 	return offset, nil
 }
 
+// consumeReservation checks that the row range to insert is exactly a range reserved by segmentPreInsert
+// and not inserted yet, so that the inserts never overlap, and marks it inserted
+func (s *Segment) consumeReservation(offset int64, rows int64) error {
+	s.reserveMu.Lock()
+	defer s.reserveMu.Unlock()
+	reserved, ok := s.pendingInserts[offset]
+	if !ok {
+		return &insertOffsetError{segmentID: s.segmentID, offset: offset, rows: rows, reason: "range is not reserved or inserted already"}
+	}
+	if reserved != rows {
+		return &insertOffsetError{segmentID: s.segmentID, offset: offset, rows: rows,
+			reason: fmt.Sprintf("%d rows are reserved at the offset", reserved)}
+	}
+	delete(s.pendingInserts, offset)
+	return nil
+}
+
+// abandonReservation gives up the row range reserved at offset by segmentPreInsert, whose rows are not going to be
+// inserted since the insert failed before reaching segcore. Segcore can't take the range back, so the rows reserved
+// after it never become visible, but the range is not pending anymore, which would block compactGrowing forever
+// from rebuilding the segment with the visible rows. It's a no-op if the range is not pending.
+func (s *Segment) abandonReservation(offset int64, rows int64) {
+	s.reserveMu.Lock()
+	defer s.reserveMu.Unlock()
+	if reserved, ok := s.pendingInserts[offset]; !ok || reserved != rows {
+		return
+	}
+	delete(s.pendingInserts, offset)
+	log.Warn("abandon the reserved rows not inserted", zap.Int64("segmentID", s.segmentID),
+		zap.Int64("offset", offset), zap.Int64("rows", rows))
+}
+
 func (s *Segment) segmentPreDelete(numOfRecords int) int64 {
 	/*
 		long int
@@ -604,6 +1391,7 @@ func (s *Segment) segmentPreDelete(numOfRecords int) int64 {
 	*/
 	s.segPtrMu.RLock()
 	defer s.segPtrMu.RUnlock() // thread safe guaranteed by segCore, use RLock
+	s.touch()
 	var offset = C.PreDelete(s.segmentPtr, C.int64_t(int64(numOfRecords)))
 
 	return int64(offset)
@@ -629,7 +1417,7 @@ func (s *Segment) segmentInsert(offset int64, entityIDs *[]UniqueID, timestamps
 	}
 
 	if s.segmentPtr == nil {
-		return errors.New("null seg core pointer")
+		return fmt.Errorf("%w, null seg core pointer, segmentID = %d", ErrSegmentReleased, s.segmentID)
 	}
 
 	// Blobs to one big blob
@@ -638,10 +1426,21 @@ func (s *Segment) segmentInsert(offset int64, entityIDs *[]UniqueID, timestamps
 
 	assert.Equal(nil, numOfRow, len(*records))
 	if numOfRow != len(*records) {
+		s.abandonReservation(offset, int64(numOfRow))
 		return errors.New("entityIDs row num not equal to length of records")
 	}
+	if err := s.checkInsertRecords(*records); err != nil {
+		s.abandonReservation(offset, int64(numOfRow))
+		return err
+	}
+	if err := s.consumeReservation(offset, int64(numOfRow)); err != nil {
+		return err
+	}
 
-	var rawData = make([]byte, numOfRow*sizeofPerRow)
+	// segcore copies the rows on insert, so the buffer is released once inserted
+	rawDataBuffer := bufferPool.Get(numOfRow * sizeofPerRow)
+	defer rawDataBuffer.Release()
+	var rawData = rawDataBuffer.Bytes()
 	var copyOffset = 0
 	for i := 0; i < len(*records); i++ {
 		copy(rawData[copyOffset:], (*records)[i].Value)
@@ -665,11 +1464,38 @@ func (s *Segment) segmentInsert(offset int64, entityIDs *[]UniqueID, timestamps
 	if err := HandleCStatus(&status, "Insert failed"); err != nil {
 		return err
 	}
+	if s.chunkSearch != nil {
+		if err := s.chunkSearch.append(offset, *timestamps, *records); err != nil {
+			// the rows not mirrored would be missed by the chunk search
+			log.Warn("failed to mirror inserts for chunk search, disable it", zap.Int64("segmentID", s.segmentID), zap.Error(err))
+			s.chunkSearch.disable()
+		}
+	}
 
 	s.setRecentlyModified(true)
 	return nil
 }
 
+// checkInsertRecords checks the rows of row based inserts are of the size expected by the schema, or of the size
+// of the first row if unknown, for segcore takes the size of the first row as the size of every row.
+// The rows must be checked before the range is reserved, a reserved range never inserted stalls the visibility
+// of the later rows
+func (s *Segment) checkInsertRecords(records []*commonpb.Blob) error {
+	if len(records) == 0 {
+		return nil
+	}
+	expected := s.rowSize
+	if expected <= 0 {
+		expected = int64(len(records[0].GetValue()))
+	}
+	for _, record := range records {
+		if actual := int64(len(record.GetValue())); actual != expected {
+			return &insertPayloadSizeError{collectionID: s.collectionID, field: "row", expected: expected, actual: actual}
+		}
+	}
+	return nil
+}
+
 func (s *Segment) segmentDelete(offset int64, entityIDs []primaryKey, timestamps []Timestamp) error {
 	/*
 		CStatus
@@ -686,7 +1512,7 @@ func (s *Segment) segmentDelete(offset int64, entityIDs []primaryKey, timestamps
 	s.segPtrMu.RLock()
 	defer s.segPtrMu.RUnlock() // thread safe guaranteed by segCore, use RLock
 	if s.segmentPtr == nil {
-		return errors.New("null seg core pointer")
+		return fmt.Errorf("%w, null seg core pointer, segmentID = %d", ErrSegmentReleased, s.segmentID)
 	}
 
 	if len(entityIDs) != len(timestamps) {
@@ -709,15 +1535,83 @@ func (s *Segment) segmentDelete(offset int64, entityIDs []primaryKey, timestamps
 		if err := HandleCStatus(&status, "Delete failed"); err != nil {
 			return err
 		}
+		if s.chunkSearch != nil {
+			s.chunkSearch.delete(int64Pks)
+		}
 	case schemapb.DataType_VarChar:
-		//TODO::
+		// segcore only keeps the delete records of int64 pks, nothing is applied
+		return &deletePKTypeError{segmentID: s.segmentID, pkType: pkType}
 	default:
 		return fmt.Errorf("invalid data type of primary keys")
 	}
 
+	if s.segmentType == segmentTypeGrowing {
+		s.deletedRows.Add(int64(len(entityIDs)))
+	}
+	s.recordAppliedDeletes(entityIDs, timestamps)
 	return nil
 }
 
+// compactGrowing rebuilds the growing segment with the rows not deleted before horizon to reclaim the memory
+// of the dead rows, the deletes at or after horizon are migrated to the new segcore segment. The caller must
+// block the inserts and deletes of the segment meanwhile. The segcore segment is swapped under segPtrMu,
+// so a search or query sees either the old or the new one, but the reads before horizon see the compacted rows.
+// It returns the number of the rows dropped.
+func (s *Segment) compactGrowing(horizon Timestamp) (int64, error) {
+	/*
+		CStatus
+		CompactGrowingSegment(CSegmentInterface c_segment,
+		                      uint64_t horizon,
+		                      CSegmentInterface* new_segment,
+		                      int64_t* dead_rows);
+	*/
+	if s.getType() != segmentTypeGrowing {
+		return 0, fmt.Errorf("segment %d is not growing", s.segmentID)
+	}
+
+	s.segPtrMu.RLock()
+	oldPtr := s.segmentPtr
+	if oldPtr == nil {
+		s.segPtrMu.RUnlock()
+		return 0, fmt.Errorf("%w, null seg core pointer, segmentID = %d", ErrSegmentReleased, s.segmentID)
+	}
+	s.reserveMu.Lock()
+	pending := len(s.pendingInserts)
+	s.reserveMu.Unlock()
+	if pending > 0 {
+		s.segPtrMu.RUnlock()
+		return 0, fmt.Errorf("%d reserved row ranges of segment %d are not inserted yet", pending, s.segmentID)
+	}
+	// the searches and queries go on with the old segment while building the new one
+	var newPtr C.CSegmentInterface
+	var deadRows int64
+	status := C.CompactGrowingSegment(oldPtr, C.uint64_t(horizon), &newPtr, (*C.int64_t)(&deadRows))
+	s.segPtrMu.RUnlock()
+	if err := HandleCStatus(&status, "CompactGrowingSegment failed"); err != nil {
+		return 0, err
+	}
+
+	s.segPtrMu.Lock()
+	if s.segmentPtr != oldPtr {
+		s.segPtrMu.Unlock()
+		C.DeleteSegment(newPtr)
+		return 0, fmt.Errorf("%w, segment %d is released while compacting", ErrSegmentReleased, s.segmentID)
+	}
+	s.segmentPtr = newPtr
+	s.reserveMu.Lock()
+	s.reservedRows = int64(C.GetRowCount(newPtr))
+	s.reserveMu.Unlock()
+	s.deletedRows.Store(0)
+	if s.chunkSearch != nil {
+		// the offsets of the rows are changed
+		s.chunkSearch.disable()
+	}
+	s.segPtrMu.Unlock()
+	// no reader holds the old segment after the swap
+	C.DeleteSegment(oldPtr)
+	return deadRows, nil
+}
+
 //-------------------------------------------------------------------------------------- interfaces for sealed segment
 func (s *Segment) segmentLoadFieldData(fieldID int64, rowCount int, data interface{}) error {
 	/*
@@ -727,7 +1621,7 @@ func (s *Segment) segmentLoadFieldData(fieldID int64, rowCount int, data interfa
 	s.segPtrMu.RLock()
 	defer s.segPtrMu.RUnlock() // thread safe guaranteed by segCore, use RLock
 	if s.segmentPtr == nil {
-		return errors.New("null seg core pointer")
+		return fmt.Errorf("%w, null seg core pointer, segmentID = %d", ErrSegmentReleased, s.segmentID)
 	}
 	if s.segmentType != segmentTypeSealed {
 		errMsg := fmt.Sprintln("segmentLoadFieldData failed, illegal segment type ", s.segmentType, "segmentID = ", s.ID())
@@ -815,7 +1709,7 @@ func (s *Segment) segmentLoadDeletedRecord(primaryKeys []primaryKey, timestamps
 	s.segPtrMu.RLock()
 	defer s.segPtrMu.RUnlock() // thread safe guaranteed by segCore, use RLock
 	if s.segmentPtr == nil {
-		return errors.New("null seg core pointer")
+		return fmt.Errorf("%w, null seg core pointer, segmentID = %d", ErrSegmentReleased, s.segmentID)
 	}
 	if s.segmentType != segmentTypeSealed {
 		errMsg := fmt.Sprintln("segmentLoadFieldData failed, illegal segment type ", s.segmentType, "segmentID = ", s.ID())
@@ -825,6 +1719,13 @@ func (s *Segment) segmentLoadDeletedRecord(primaryKeys []primaryKey, timestamps
 	if len(primaryKeys) <= 0 {
 		return fmt.Errorf("empty pks to delete")
 	}
+	if len(primaryKeys) != len(timestamps) {
+		return errors.New("length of primaryKeys not equal to length of timestamps")
+	}
+	// the records may be concatenated from several delta logs, which are not ordered across the logs,
+	// the order within a log is checked by the loader
+	primaryKeys, timestamps, _ = sortDeleteRecords(primaryKeys, timestamps)
+	rowCount = int64(len(primaryKeys))
 	pkType := primaryKeys[0].Type()
 	switch pkType {
 	case schemapb.DataType_Int64:
@@ -846,17 +1747,43 @@ func (s *Segment) segmentLoadDeletedRecord(primaryKeys []primaryKey, timestamps
 			return err
 		}
 	case schemapb.DataType_VarChar:
-		//TODO::
+		return &deletePKTypeError{segmentID: s.segmentID, pkType: pkType}
 	default:
 		return fmt.Errorf("invalid data type of primary keys")
 	}
 
+	s.recordAppliedDeletes(primaryKeys, timestamps)
+
 	log.Debug("load deleted record done",
 		zap.Int64("row count", rowCount),
 		zap.Int64("segmentID", s.ID()))
 	return nil
 }
 
+// segmentDropFieldData releases the raw data of the field from segcore, e.g. once the index of the field is attached
+func (s *Segment) segmentDropFieldData(fieldID int64) error {
+	/*
+		CStatus
+		DropFieldData(CSegmentInterface c_segment, int64_t field_id);
+	*/
+	s.segPtrMu.RLock()
+	defer s.segPtrMu.RUnlock() // thread safe guaranteed by segCore, use RLock
+	if s.segmentPtr == nil {
+		return fmt.Errorf("%w, null seg core pointer, segmentID = %d", ErrSegmentReleased, s.segmentID)
+	}
+	if s.segmentType != segmentTypeSealed {
+		return fmt.Errorf("segmentDropFieldData failed, illegal segment type %d, segmentID = %d", s.segmentType, s.ID())
+	}
+
+	status := C.DropFieldData(s.segmentPtr, C.int64_t(fieldID))
+	if err := HandleCStatus(&status, "DropFieldData failed"); err != nil {
+		return err
+	}
+
+	log.Debug("drop field data done", zap.Int64("segmentID", s.ID()), zap.Int64("fieldID", fieldID))
+	return nil
+}
+
 func (s *Segment) segmentLoadIndexData(bytesIndex [][]byte, indexInfo *querypb.FieldIndexInfo) error {
 	loadIndexInfo, err := newLoadIndexInfo()
 	defer deleteLoadIndexInfo(loadIndexInfo)
@@ -872,7 +1799,7 @@ func (s *Segment) segmentLoadIndexData(bytesIndex [][]byte, indexInfo *querypb.F
 	s.segPtrMu.RLock()
 	defer s.segPtrMu.RUnlock() // thread safe guaranteed by segCore, use RLock
 	if s.segmentPtr == nil {
-		return errors.New("null seg core pointer")
+		return fmt.Errorf("%w, null seg core pointer, segmentID = %d", ErrSegmentReleased, s.segmentID)
 	}
 
 	if s.segmentType != segmentTypeSealed {
@@ -0,0 +1,124 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+// maxReportedBinlogPaths is the max number of offending binlog paths listed in the error of a load request
+const maxReportedBinlogPaths = 10
+
+// binlogPathLayouts is the number of path elements following the directory of each kind of binlogs, the binlogs are
+// written by data node in the layouts of
+// ${root}/insert_log/${collection_id}/${partition_id}/${segment_id}/${field_id}/${log_id}
+// ${root}/stats_log/${collection_id}/${partition_id}/${segment_id}/${field_id}/${log_id}
+// ${root}/delta_log/${collection_id}/${partition_id}/${segment_id}/${log_id}
+var binlogPathLayouts = map[string]int{
+	"insert_log": 5,
+	"stats_log":  5,
+	"delta_log":  4,
+}
+
+// binlogPathIDs is the ids embedded in a binlog path
+type binlogPathIDs struct {
+	collectionID UniqueID
+	partitionID  UniqueID
+	segmentID    UniqueID
+}
+
+// parseBinlogPath parses the ids embedded in binlogPath, returns false if the path is not in the standard layouts
+func parseBinlogPath(binlogPath string) (binlogPathIDs, bool) {
+	elems := strings.Split(strings.Trim(binlogPath, "/"), "/")
+	for i := len(elems) - 1; i >= 0; i-- {
+		n, ok := binlogPathLayouts[elems[i]]
+		if !ok {
+			continue
+		}
+		if len(elems)-i-1 != n {
+			return binlogPathIDs{}, false
+		}
+		var ids [3]UniqueID
+		for j := range ids {
+			id, err := strconv.ParseInt(elems[i+1+j], 10, 64)
+			if err != nil {
+				return binlogPathIDs{}, false
+			}
+			ids[j] = id
+		}
+		return binlogPathIDs{collectionID: ids[0], partitionID: ids[1], segmentID: ids[2]}, true
+	}
+	return binlogPathIDs{}, false
+}
+
+// checkBinlogPaths checks the ids embedded in the binlog paths of the segments to load against the request, so
+// that the files of other collections or segments are never loaded. The paths not in the standard layouts are
+// rejected as well if strict, or only warned otherwise
+func checkBinlogPaths(req *querypb.LoadSegmentsRequest, strict bool) error {
+	var offending, nonStandard []string
+	for _, info := range req.GetInfos() {
+		for _, fieldBinlogs := range [][]*datapb.FieldBinlog{info.GetBinlogPaths(), info.GetStatslogs(), info.GetDeltalogs()} {
+			for _, fieldBinlog := range fieldBinlogs {
+				for _, binlog := range fieldBinlog.GetBinlogs() {
+					ids, ok := parseBinlogPath(binlog.GetLogPath())
+					if !ok {
+						nonStandard = append(nonStandard, binlog.GetLogPath())
+						continue
+					}
+					if ids.collectionID != req.GetCollectionID() || ids.partitionID != info.GetPartitionID() || ids.segmentID != info.GetSegmentID() {
+						offending = append(offending, fmt.Sprintf("%s (expected partition %d, segment %d)",
+							binlog.GetLogPath(), info.GetPartitionID(), info.GetSegmentID()))
+					}
+				}
+			}
+		}
+	}
+
+	if len(nonStandard) > 0 {
+		if strict {
+			for _, binlogPath := range nonStandard {
+				offending = append(offending, fmt.Sprintf("%s (not in the standard layout)", binlogPath))
+			}
+		} else {
+			log.Warn("binlog paths not in the standard layout are loaded unchecked",
+				zap.Int64("collectionID", req.GetCollectionID()),
+				zap.Int("numPaths", len(nonStandard)),
+				zap.Strings("paths", truncateBinlogPaths(nonStandard)))
+		}
+	}
+	if len(offending) > 0 {
+		return &binlogPathError{collectionID: req.GetCollectionID(), paths: truncateBinlogPaths(offending)}
+	}
+	return nil
+}
+
+// truncateBinlogPaths returns the first maxReportedBinlogPaths of paths, and the number of the rest if any
+func truncateBinlogPaths(paths []string) []string {
+	if len(paths) <= maxReportedBinlogPaths {
+		return paths
+	}
+	truncated := append([]string{}, paths[:maxReportedBinlogPaths]...)
+	return append(truncated, fmt.Sprintf("and %d more", len(paths)-maxReportedBinlogPaths))
+}
@@ -0,0 +1,122 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+func TestParseBinlogPath(t *testing.T) {
+	cases := []struct {
+		path string
+		ids  binlogPathIDs
+		ok   bool
+	}{
+		{"files/insert_log/1/2/3/100/4", binlogPathIDs{1, 2, 3}, true},
+		{"/files/stats_log/1/2/3/100/4", binlogPathIDs{1, 2, 3}, true},
+		{"files/delta_log/1/2/3/4", binlogPathIDs{1, 2, 3}, true},
+		// the last directory of binlogs takes effect
+		{"insert_log/delta_log/1/2/3/4", binlogPathIDs{1, 2, 3}, true},
+		{"files/insert_log/1/2/3/4", binlogPathIDs{}, false},
+		{"files/delta_log/1/2/3/100/4", binlogPathIDs{}, false},
+		{"files/insert_log/a/2/3/100/4", binlogPathIDs{}, false},
+		{"1/2/3/100", binlogPathIDs{}, false},
+		{"", binlogPathIDs{}, false},
+	}
+	for _, c := range cases {
+		ids, ok := parseBinlogPath(c.path)
+		assert.Equal(t, c.ok, ok, c.path)
+		assert.Equal(t, c.ids, ids, c.path)
+	}
+}
+
+func TestCheckBinlogPaths(t *testing.T) {
+	genReq := func(binlogPaths, statslogs, deltalogs []string) *querypb.LoadSegmentsRequest {
+		toFieldBinlogs := func(paths []string) []*datapb.FieldBinlog {
+			fieldBinlog := &datapb.FieldBinlog{FieldID: 100}
+			for _, path := range paths {
+				fieldBinlog.Binlogs = append(fieldBinlog.Binlogs, &datapb.Binlog{LogPath: path})
+			}
+			return []*datapb.FieldBinlog{fieldBinlog}
+		}
+		return &querypb.LoadSegmentsRequest{
+			CollectionID: defaultCollectionID,
+			Infos: []*querypb.SegmentLoadInfo{{
+				CollectionID: defaultCollectionID,
+				PartitionID:  defaultPartitionID,
+				SegmentID:    defaultSegmentID,
+				BinlogPaths:  toFieldBinlogs(binlogPaths),
+				Statslogs:    toFieldBinlogs(statslogs),
+				Deltalogs:    toFieldBinlogs(deltalogs),
+			}},
+		}
+	}
+	insertLog := func(collectionID, partitionID, segmentID UniqueID) string {
+		return fmt.Sprintf("files/insert_log/%d/%d/%d/100/1", collectionID, partitionID, segmentID)
+	}
+	statsLog := fmt.Sprintf("files/stats_log/%d/%d/%d/100/1", defaultCollectionID, defaultPartitionID, defaultSegmentID)
+	deltaLog := fmt.Sprintf("files/delta_log/%d/%d/%d/1", defaultCollectionID, defaultPartitionID, defaultSegmentID)
+
+	t.Run("test correct paths", func(t *testing.T) {
+		req := genReq([]string{insertLog(defaultCollectionID, defaultPartitionID, defaultSegmentID)}, []string{statsLog}, []string{deltaLog})
+		assert.NoError(t, checkBinlogPaths(req, true))
+	})
+
+	t.Run("test mismatched paths", func(t *testing.T) {
+		foreignCollection := insertLog(defaultCollectionID+1, defaultPartitionID, defaultSegmentID)
+		foreignSegment := insertLog(defaultCollectionID, defaultPartitionID, defaultSegmentID+1)
+		req := genReq([]string{foreignCollection, foreignSegment}, []string{statsLog}, []string{deltaLog})
+		for _, strict := range []bool{true, false} {
+			err := checkBinlogPaths(req, strict)
+			var pathErr *binlogPathError
+			assert.True(t, errors.As(err, &pathErr))
+			assert.Len(t, pathErr.paths, 2)
+			assert.Contains(t, err.Error(), foreignCollection)
+			assert.Contains(t, err.Error(), foreignSegment)
+			assert.Equal(t, commonpb.ErrorCode_IllegalArgument, errorCodeOf(err))
+		}
+	})
+
+	t.Run("test non-standard paths", func(t *testing.T) {
+		nonStandard := JoinIDPath(defaultCollectionID, defaultPartitionID, defaultSegmentID, 100)
+		req := genReq([]string{nonStandard}, []string{statsLog}, []string{deltaLog})
+		err := checkBinlogPaths(req, true)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), nonStandard)
+		assert.NoError(t, checkBinlogPaths(req, false))
+	})
+
+	t.Run("test truncated paths", func(t *testing.T) {
+		var paths []string
+		for i := 0; i < maxReportedBinlogPaths+5; i++ {
+			paths = append(paths, insertLog(defaultCollectionID+1, defaultPartitionID, defaultSegmentID))
+		}
+		err := checkBinlogPaths(genReq(paths, nil, nil), true)
+		var pathErr *binlogPathError
+		assert.True(t, errors.As(err, &pathErr))
+		assert.Len(t, pathErr.paths, maxReportedBinlogPaths+1)
+		assert.Contains(t, err.Error(), "and 5 more")
+	})
+}
@@ -0,0 +1,143 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// segmentDiskFile is a local file of a segment
+type segmentDiskFile struct {
+	size  int64
+	evict func() // removes the file from the local disk
+}
+
+// segmentDiskUsage tracks the local files of a segment, e.g. the raw vectors of the offsets only fields cached on
+// disk by the vector chunk managers on retrieve. The memory size reported by segcore misses them, so the disk usage
+// is reported besides the memory size. The files are removed along with the segment.
+type segmentDiskUsage struct {
+	mu    sync.Mutex
+	files map[string]segmentDiskFile
+	size  int64
+}
+
+// diskFileOwners attributes the local files of the node to the segments they are of, so that the files evicted by
+// the caches are taken off the disk usage of their segments
+var diskFileOwners = struct {
+	sync.Mutex
+	owners map[string]*Segment
+}{owners: make(map[string]*Segment)}
+
+// getDiskUsage returns the bytes of the local files of the segment
+func (s *Segment) getDiskUsage() int64 {
+	s.diskUsage.mu.Lock()
+	defer s.diskUsage.mu.Unlock()
+	return s.diskUsage.size
+}
+
+// addDiskFile attributes the local file of filePath to the segment, evict removes the file once the segment is deleted
+func (s *Segment) addDiskFile(filePath string, size int64, evict func()) {
+	diskFileOwners.Lock()
+	diskFileOwners.owners[filePath] = s
+	diskFileOwners.Unlock()
+
+	s.diskUsage.mu.Lock()
+	defer s.diskUsage.mu.Unlock()
+	if _, ok := s.diskUsage.files[filePath]; ok {
+		return
+	}
+	if s.diskUsage.files == nil {
+		s.diskUsage.files = make(map[string]segmentDiskFile)
+	}
+	s.diskUsage.files[filePath] = segmentDiskFile{size: size, evict: evict}
+	s.diskUsage.size += size
+	metrics.QueryNodeSegmentDiskUsage.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Add(float64(size))
+}
+
+// removeDiskFile takes the local file of filePath off the disk usage of the segment, the file is removed already
+func (s *Segment) removeDiskFile(filePath string) {
+	diskFileOwners.Lock()
+	if diskFileOwners.owners[filePath] == s {
+		delete(diskFileOwners.owners, filePath)
+	}
+	diskFileOwners.Unlock()
+
+	s.diskUsage.mu.Lock()
+	defer s.diskUsage.mu.Unlock()
+	file, ok := s.diskUsage.files[filePath]
+	if !ok {
+		return
+	}
+	delete(s.diskUsage.files, filePath)
+	s.diskUsage.size -= file.size
+	metrics.QueryNodeSegmentDiskUsage.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Sub(float64(file.size))
+}
+
+// releaseDiskFiles removes all the local files of the segment, the disk usage drops to zero
+func (s *Segment) releaseDiskFiles() {
+	s.diskUsage.mu.Lock()
+	files, size := s.diskUsage.files, s.diskUsage.size
+	s.diskUsage.files, s.diskUsage.size = nil, 0
+	s.diskUsage.mu.Unlock()
+	if len(files) == 0 {
+		return
+	}
+	metrics.QueryNodeSegmentDiskUsage.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Sub(float64(size))
+
+	diskFileOwners.Lock()
+	for filePath := range files {
+		if diskFileOwners.owners[filePath] == s {
+			delete(diskFileOwners.owners, filePath)
+		}
+	}
+	diskFileOwners.Unlock()
+	// evicted out of the locks, the eviction listeners find no owner then
+	for _, file := range files {
+		if file.evict != nil {
+			file.evict()
+		}
+	}
+}
+
+// attributeCachedFile attributes the file of filePath to the segment if it's cached on disk by vcm
+func (s *Segment) attributeCachedFile(vcm storage.ChunkManager, filePath string) {
+	vectorChunkManager, ok := vcm.(*storage.VectorChunkManager)
+	if !ok {
+		return
+	}
+	size, ok := vectorChunkManager.CachedSize(filePath)
+	if !ok {
+		return
+	}
+	s.addDiskFile(filePath, size, func() {
+		vectorChunkManager.Evict(filePath)
+	})
+}
+
+// onDiskFileEvicted takes the local file evicted by a cache off the disk usage of its segment
+func onDiskFileEvicted(filePath string) {
+	diskFileOwners.Lock()
+	owner, ok := diskFileOwners.owners[filePath]
+	diskFileOwners.Unlock()
+	if ok {
+		owner.removeDiskFile(filePath)
+	}
+}
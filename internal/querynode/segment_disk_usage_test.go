@@ -0,0 +1,70 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+func TestSegment_diskUsage(t *testing.T) {
+	segment, err := genSimpleSealedSegment()
+	require.NoError(t, err)
+
+	gauge := metrics.QueryNodeSegmentDiskUsage.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID))
+	base := testutil.ToFloat64(gauge)
+
+	evicted := make(map[string]int)
+	evict := func(filePath string) func() {
+		return func() { evicted[filePath]++ }
+	}
+	segment.addDiskFile("disk_usage/a", 100, evict("disk_usage/a"))
+	segment.addDiskFile("disk_usage/b", 50, evict("disk_usage/b"))
+	// attributed once only
+	segment.addDiskFile("disk_usage/a", 100, evict("disk_usage/a"))
+	assert.Equal(t, int64(150), segment.getDiskUsage())
+	assert.Equal(t, base+150, testutil.ToFloat64(gauge))
+
+	// evicted by the cache, the file is removed already
+	onDiskFileEvicted("disk_usage/b")
+	assert.Equal(t, int64(100), segment.getDiskUsage())
+	assert.Equal(t, base+100, testutil.ToFloat64(gauge))
+	assert.Zero(t, evicted["disk_usage/b"])
+
+	// files of no segment are ignored
+	onDiskFileEvicted("disk_usage/c")
+	assert.Equal(t, int64(100), segment.getDiskUsage())
+
+	// the chunk managers other than the vector chunk managers cache nothing
+	segment.attributeCachedFile(newMockChunkManager(), "disk_usage/d")
+	assert.Equal(t, int64(100), segment.getDiskUsage())
+
+	deleteSegment(segment)
+	assert.Zero(t, segment.getDiskUsage())
+	assert.Equal(t, base, testutil.ToFloat64(gauge))
+	assert.Equal(t, 1, evicted["disk_usage/a"])
+
+	diskFileOwners.Lock()
+	assert.NotContains(t, diskFileOwners.owners, "disk_usage/a")
+	diskFileOwners.Unlock()
+}
@@ -0,0 +1,109 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+// loadPhaseStats is the bytes downloaded and the wall-clock durations of the phases of loading a segment or a field
+type loadPhaseStats struct {
+	binlogSize  int64
+	indexSize   int64
+	download    time.Duration // download of binlogs and index files
+	deserialize time.Duration // deserialization of binlogs and index files
+	segcoreLoad time.Duration // load of raw data into segcore
+	indexLoad   time.Duration // load of index into segcore
+}
+
+func (stats *loadPhaseStats) add(delta loadPhaseStats) {
+	stats.binlogSize += delta.binlogSize
+	stats.indexSize += delta.indexSize
+	stats.download += delta.download
+	stats.deserialize += delta.deserialize
+	stats.segcoreLoad += delta.segcoreLoad
+	stats.indexLoad += delta.indexLoad
+}
+
+// segmentLoadStats records the load phases of a segment, in total and per field.
+// The asynchronous index loading is recorded as well, so it may change after the segment is registered.
+type segmentLoadStats struct {
+	mu           sync.RWMutex
+	total        loadPhaseStats
+	fields       map[FieldID]*loadPhaseStats
+	loadDuration time.Duration // wall-clock duration of loading the segment, without the asynchronous index loading
+}
+
+// record adds delta to the total stats, and to the stats of fieldID unless it's common.InvalidFieldID
+func (stats *segmentLoadStats) record(fieldID FieldID, delta loadPhaseStats) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	stats.total.add(delta)
+	if fieldID == common.InvalidFieldID {
+		return
+	}
+	if stats.fields == nil {
+		stats.fields = make(map[FieldID]*loadPhaseStats)
+	}
+	field, ok := stats.fields[fieldID]
+	if !ok {
+		field = &loadPhaseStats{}
+		stats.fields[fieldID] = field
+	}
+	field.add(delta)
+}
+
+func (stats *segmentLoadStats) setLoadDuration(d time.Duration) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	stats.loadDuration = d
+}
+
+func (stats *segmentLoadStats) toProto() *querypb.SegmentLoadStats {
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+	ret := &querypb.SegmentLoadStats{
+		BinlogSize:    stats.total.binlogSize,
+		IndexSize:     stats.total.indexSize,
+		DownloadMs:    stats.total.download.Milliseconds(),
+		DeserializeMs: stats.total.deserialize.Milliseconds(),
+		SegcoreLoadMs: stats.total.segcoreLoad.Milliseconds(),
+		IndexLoadMs:   stats.total.indexLoad.Milliseconds(),
+		LoadMs:        stats.loadDuration.Milliseconds(),
+		FieldStats:    make([]*querypb.FieldLoadStats, 0, len(stats.fields)),
+	}
+	for fieldID, field := range stats.fields {
+		ret.FieldStats = append(ret.FieldStats, &querypb.FieldLoadStats{
+			FieldID:       fieldID,
+			BinlogSize:    field.binlogSize,
+			IndexSize:     field.indexSize,
+			DownloadMs:    field.download.Milliseconds(),
+			DeserializeMs: field.deserialize.Milliseconds(),
+			SegcoreLoadMs: field.segcoreLoad.Milliseconds(),
+			IndexLoadMs:   field.indexLoad.Milliseconds(),
+		})
+	}
+	sort.Slice(ret.FieldStats, func(i, j int) bool {
+		return ret.FieldStats[i].FieldID < ret.FieldStats[j].FieldID
+	})
+	return ret
+}
@@ -0,0 +1,166 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+)
+
+func TestSegmentLoadStats(t *testing.T) {
+	stats := &segmentLoadStats{}
+	stats.record(101, loadPhaseStats{binlogSize: 10, download: 3 * time.Millisecond, deserialize: time.Millisecond})
+	stats.record(100, loadPhaseStats{indexSize: 20, download: 5 * time.Millisecond, indexLoad: 2 * time.Millisecond})
+	stats.record(101, loadPhaseStats{segcoreLoad: 4 * time.Millisecond})
+	stats.record(common.InvalidFieldID, loadPhaseStats{segcoreLoad: 6 * time.Millisecond})
+	stats.setLoadDuration(30 * time.Millisecond)
+
+	pb := stats.toProto()
+	assert.Equal(t, int64(10), pb.GetBinlogSize())
+	assert.Equal(t, int64(20), pb.GetIndexSize())
+	assert.Equal(t, int64(8), pb.GetDownloadMs())
+	assert.Equal(t, int64(1), pb.GetDeserializeMs())
+	assert.Equal(t, int64(10), pb.GetSegcoreLoadMs())
+	assert.Equal(t, int64(2), pb.GetIndexLoadMs())
+	assert.Equal(t, int64(30), pb.GetLoadMs())
+
+	// sorted by field, the phases not attributed to any field are only in the totals
+	require.Len(t, pb.GetFieldStats(), 2)
+	assert.Equal(t, &querypb.FieldLoadStats{FieldID: 100, IndexSize: 20, DownloadMs: 5, IndexLoadMs: 2}, pb.GetFieldStats()[0])
+	assert.Equal(t, &querypb.FieldLoadStats{FieldID: 101, BinlogSize: 10, DownloadMs: 3, DeserializeMs: 1, SegcoreLoadMs: 4}, pb.GetFieldStats()[1])
+
+	empty := (&segmentLoadStats{}).toProto()
+	assert.Zero(t, empty.GetLoadMs())
+	assert.Empty(t, empty.GetFieldStats())
+}
+
+// throttledChunkManager delays every read to simulate a slow object storage
+type throttledChunkManager struct {
+	storage.ChunkManager
+	delay time.Duration
+}
+
+func (cm *throttledChunkManager) Read(filePath string) ([]byte, error) {
+	time.Sleep(cm.delay)
+	return cm.ChunkManager.Read(filePath)
+}
+
+func TestSegmentLoader_loadStats(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	schema := genSimpleInsertDataSchema()
+	fieldBinlog, err := saveBinLog(ctx, defaultCollectionID, defaultPartitionID, defaultSegmentID, defaultMsgLength, schema)
+	require.NoError(t, err)
+
+	segmentID := UniqueID(100)
+	indexPaths, err := generateIndex(segmentID)
+	require.NoError(t, err)
+	indexInfo := &querypb.FieldIndexInfo{
+		FieldID:        simpleVecField.id,
+		EnableIndex:    true,
+		IndexName:      indexName,
+		IndexID:        indexID,
+		BuildID:        buildID,
+		IndexParams:    funcutil.Map2KeyValuePair(genSimpleIndexParams()),
+		IndexFilePaths: indexPaths,
+	}
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	const delay = 20 * time.Millisecond
+	node.loader.cm = &throttledChunkManager{ChunkManager: node.loader.cm, delay: delay}
+
+	req := &querypb.LoadSegmentsRequest{
+		Base: &commonpb.MsgBase{
+			MsgType: commonpb.MsgType_LoadSegments,
+			MsgID:   rand.Int63(),
+		},
+		Schema: schema,
+		Infos: []*querypb.SegmentLoadInfo{
+			{
+				SegmentID:    segmentID,
+				PartitionID:  defaultPartitionID,
+				CollectionID: defaultCollectionID,
+				BinlogPaths:  fieldBinlog,
+				IndexInfos:   []*querypb.FieldIndexInfo{indexInfo},
+			},
+		},
+	}
+	tr := time.Now()
+	err = node.loader.loadSegment(req, segmentTypeSealed)
+	require.NoError(t, err)
+	elapsed := time.Since(tr)
+
+	t.Run("segment info", func(t *testing.T) {
+		rsp, err := node.GetSegmentInfo(ctx, &querypb.GetSegmentInfoRequest{
+			SegmentIDs:   []UniqueID{segmentID},
+			CollectionID: defaultCollectionID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, commonpb.ErrorCode_Success, rsp.GetStatus().GetErrorCode())
+		require.Len(t, rsp.GetInfos(), 1)
+		stats := rsp.GetInfos()[0].GetLoadStats()
+
+		// every binlog and index file is delayed, the vector field is loaded from index files
+		var binlogs, binlogSize int64
+		for _, field := range stats.GetFieldStats() {
+			binlogSize += field.GetBinlogSize()
+			if field.GetFieldID() == simpleVecField.id {
+				assert.Zero(t, field.GetBinlogSize())
+				assert.NotZero(t, field.GetIndexSize())
+				assert.GreaterOrEqual(t, field.GetDownloadMs(), int64(len(indexPaths))*delay.Milliseconds())
+				continue
+			}
+			binlogs++
+			assert.NotZero(t, field.GetBinlogSize())
+			assert.GreaterOrEqual(t, field.GetDownloadMs(), delay.Milliseconds())
+		}
+		assert.Equal(t, int64(len(fieldBinlog)-1), binlogs)
+		assert.Equal(t, binlogSize, stats.GetBinlogSize())
+		assert.GreaterOrEqual(t, stats.GetDownloadMs(), (binlogs+int64(len(indexPaths)))*delay.Milliseconds())
+
+		// the phases are within the load of the segment, which is within the whole request
+		phases := stats.GetDownloadMs() + stats.GetDeserializeMs() + stats.GetSegcoreLoadMs() + stats.GetIndexLoadMs()
+		assert.GreaterOrEqual(t, stats.GetLoadMs(), phases)
+		assert.LessOrEqual(t, stats.GetLoadMs(), elapsed.Milliseconds())
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		collections := getLoadStatsMetrics(node.historical.replica, node.streaming.replica)
+		require.Len(t, collections, 1)
+		collection := collections[0]
+		assert.Equal(t, defaultCollectionID, collection.CollectionID)
+		// the segment of genSimpleQueryNode is not loaded by the loader
+		assert.Equal(t, int64(1), collection.Segments)
+		assert.NotZero(t, collection.BinlogSize)
+		assert.NotZero(t, collection.IndexSize)
+		assert.Equal(t, collection.LoadMs, collection.MaxLoadMs)
+		assert.GreaterOrEqual(t, collection.DownloadMs, int64(len(fieldBinlog))*delay.Milliseconds())
+	})
+}
@@ -57,6 +57,64 @@ type segmentLoader struct {
 	etcdKV *etcdkv.EtcdKV
 
 	factory msgstream.Factory
+	config  *QueryNodeConfig
+
+	// persists the indexes served by the loaded sealed segments, nil if not persisted
+	indexManifests *indexManifestStore
+
+	loadingMu       sync.Mutex // guards loadingSegments
+	loadingSegments map[segmentLoadKey]*segmentLoadCall
+
+	refreshMu sync.Mutex // serializes refreshIndex, so that a stale index is attached once
+}
+
+// segmentLoadKey identifies the load of a segment version into the replica of segment type
+type segmentLoadKey struct {
+	segmentID   UniqueID
+	version     int64
+	segmentType segmentType
+}
+
+// segmentLoadCall is an in-flight load of segment, err is the outcome once done is closed
+type segmentLoadCall struct {
+	done chan struct{}
+	err  error
+}
+
+// startSegmentLoads registers the loads of infos, the ones being loaded by other requests are returned as waiting,
+// and the rest are returned to be loaded with their calls, which must be finished by finishSegmentLoads
+func (loader *segmentLoader) startSegmentLoads(infos []*querypb.SegmentLoadInfo, segmentType segmentType) ([]*querypb.SegmentLoadInfo, []*segmentLoadCall, map[UniqueID]*segmentLoadCall) {
+	loader.loadingMu.Lock()
+	defer loader.loadingMu.Unlock()
+
+	toLoad := make([]*querypb.SegmentLoadInfo, 0, len(infos))
+	calls := make([]*segmentLoadCall, 0, len(infos))
+	waiting := make(map[UniqueID]*segmentLoadCall)
+	for _, info := range infos {
+		key := segmentLoadKey{segmentID: info.GetSegmentID(), version: info.GetVersion(), segmentType: segmentType}
+		if call, ok := loader.loadingSegments[key]; ok {
+			waiting[info.GetSegmentID()] = call
+			continue
+		}
+		call := &segmentLoadCall{done: make(chan struct{})}
+		loader.loadingSegments[key] = call
+		toLoad = append(toLoad, info)
+		calls = append(calls, call)
+	}
+	return toLoad, calls, waiting
+}
+
+// finishSegmentLoads publishes the outcome of the loads started by startSegmentLoads to the waiting requests
+func (loader *segmentLoader) finishSegmentLoads(infos []*querypb.SegmentLoadInfo, calls []*segmentLoadCall, segmentType segmentType, err error) {
+	loader.loadingMu.Lock()
+	defer loader.loadingMu.Unlock()
+
+	for i, info := range infos {
+		key := segmentLoadKey{segmentID: info.GetSegmentID(), version: info.GetVersion(), segmentType: segmentType}
+		delete(loader.loadingSegments, key)
+		calls[i].err = err
+		close(calls[i].done)
+	}
 }
 
 func (loader *segmentLoader) loadSegment(req *querypb.LoadSegmentsRequest, segmentType segmentType) error {
@@ -81,21 +139,73 @@ func (loader *segmentLoader) loadSegment(req *querypb.LoadSegmentsRequest, segme
 		return err
 	}
 
+	if err := checkBinlogPaths(req, Params.QueryNodeCfg.StrictBinlogPathLayout); err != nil {
+		log.Error("load segment failed, invalid binlog paths", zap.Int64("loadSegmentRequest msgID", req.Base.MsgID), zap.Error(err))
+		return err
+	}
+
+	// the segments being loaded by other requests, e.g. the retries of a timeout request, are not loaded again,
+	// but share the outcome of the in-flight loads
+	infos, calls, waiting := loader.startSegmentLoads(req.Infos, segmentType)
+	err := loader.loadSegmentInfos(req, infos, metaReplica, segmentType)
+	loader.finishSegmentLoads(infos, calls, segmentType, err)
+	if err != nil {
+		return err
+	}
+
+	for segmentID, call := range waiting {
+		log.Debug("wait for the in-flight load of segment",
+			zap.Int64("collectionID", req.CollectionID),
+			zap.Int64("segmentID", segmentID),
+			zap.Int64("loadSegmentRequest msgID", req.Base.MsgID))
+		<-call.done
+		if call.err != nil {
+			return call.err
+		}
+	}
+	return nil
+}
+
+// loadSegmentInfos loads the segments of infos and registers them into meta replica
+func (loader *segmentLoader) loadSegmentInfos(req *querypb.LoadSegmentsRequest, infos []*querypb.SegmentLoadInfo,
+	metaReplica ReplicaInterface, segmentType segmentType) error {
+	if len(infos) == 0 {
+		return nil
+	}
+
 	log.Debug("segmentLoader start loading...",
 		zap.Any("collectionID", req.CollectionID),
-		zap.Any("numOfSegments", len(req.Infos)),
+		zap.Any("numOfSegments", len(infos)),
 		zap.Any("loadType", segmentType),
 	)
+	// index files of sealed segments are loaded after the segments are registered if enabled and not required
+	// synchronously, standby segments are promoted without loading anything, so their indexes are always attached on load
+	asyncIndex := segmentType == segmentTypeSealed && Params.QueryNodeCfg.AsyncIndexLoading &&
+		!req.GetSyncIndexLoading() && !req.GetStandby()
+
+	var fieldTransforms []*querypb.FieldTransform
+	if segmentType == segmentTypeSealed && len(req.GetFieldTransforms()) > 0 {
+		collection, err := loader.historicalReplica.getCollectionByID(req.GetCollectionID())
+		if err != nil {
+			return err
+		}
+		if err := checkFieldTransforms(collection, req.GetFieldTransforms(), infos); err != nil {
+			log.Warn("invalid field transforms", zap.Int64("collectionID", req.GetCollectionID()), zap.Error(err))
+			return err
+		}
+		fieldTransforms = req.GetFieldTransforms()
+	}
+
 	// check memory limit
 	concurrencyLevel := runtime.GOMAXPROCS(0)
 	for ; concurrencyLevel > 1; concurrencyLevel /= 2 {
-		err := loader.checkSegmentSize(req.CollectionID, req.Infos, concurrencyLevel)
+		err := loader.checkSegmentSize(req.CollectionID, infos, concurrencyLevel, segmentType, asyncIndex)
 		if err == nil {
 			break
 		}
 	}
 
-	err := loader.checkSegmentSize(req.CollectionID, req.Infos, concurrencyLevel)
+	err := loader.checkSegmentSize(req.CollectionID, infos, concurrencyLevel, segmentType, asyncIndex)
 	if err != nil {
 		log.Error("load failed, OOM if loaded", zap.Int64("loadSegmentRequest msgID", req.Base.MsgID), zap.Error(err))
 		return err
@@ -108,7 +218,7 @@ func (loader *segmentLoader) loadSegment(req *querypb.LoadSegmentsRequest, segme
 		}
 	}
 
-	for _, info := range req.Infos {
+	for _, info := range infos {
 		segmentID := info.SegmentID
 		partitionID := info.PartitionID
 		collectionID := info.CollectionID
@@ -118,7 +228,10 @@ func (loader *segmentLoader) loadSegment(req *querypb.LoadSegmentsRequest, segme
 			segmentGC()
 			return err
 		}
-		segment, err := newSegment(collection, segmentID, partitionID, collectionID, "", segmentType, true)
+		// balanced segments are kept out of service until SyncDistribution hands them over,
+		// and standby segments until they are promoted
+		standby := req.GetStandby() && segmentType == segmentTypeSealed
+		segment, err := newSegment(collection, segmentID, partitionID, collectionID, "", segmentType, !req.GetDeferServing() && !standby)
 		if err != nil {
 			log.Error("load segment failed when create new segment",
 				zap.Int64("collectionID", collectionID),
@@ -129,18 +242,32 @@ func (loader *segmentLoader) loadSegment(req *querypb.LoadSegmentsRequest, segme
 			segmentGC()
 			return err
 		}
+		segment.setVersion(info.GetVersion())
+		segment.setStandby(standby)
+		if segmentType == segmentTypeSealed {
+			segment.indexManifests = loader.indexManifests
+			segment.fieldTransforms = fieldTransforms
+		}
 
 		newSegments[segmentID] = segment
 	}
 
+	var pendingMu sync.Mutex
+	pendingIndexes := make(map[UniqueID]map[int64]*IndexedFieldInfo)
+
 	loadSegmentFunc := func(idx int) error {
-		loadInfo := req.Infos[idx]
+		loadInfo := infos[idx]
 		collectionID := loadInfo.CollectionID
 		partitionID := loadInfo.PartitionID
 		segmentID := loadInfo.SegmentID
 		segment := newSegments[segmentID]
 		tr := timerecord.NewTimeRecorder("loadDurationPerSegment")
-		err = loader.loadSegmentInternal(segment, loadInfo)
+		pending, err := loader.loadSegmentInternal(segment, loadInfo, asyncIndex)
+		if len(pending) > 0 {
+			pendingMu.Lock()
+			pendingIndexes[segmentID] = pending
+			pendingMu.Unlock()
+		}
 		if err != nil {
 			log.Error("load segment failed when load data into memory",
 				zap.Int64("collectionID", collectionID),
@@ -151,37 +278,122 @@ func (loader *segmentLoader) loadSegment(req *querypb.LoadSegmentsRequest, segme
 			return err
 		}
 
-		metrics.QueryNodeLoadSegmentLatency.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Observe(float64(tr.ElapseSpan().Milliseconds()))
+		loadDuration := tr.ElapseSpan()
+		segment.loadStats.setLoadDuration(loadDuration)
+		metrics.QueryNodeLoadSegmentLatency.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Observe(float64(loadDuration.Milliseconds()))
 
 		return nil
 	}
 	// start to load
-	err = funcutil.ProcessFuncParallel(len(req.Infos), concurrencyLevel, loadSegmentFunc, "loadSegmentFunc")
+	err = funcutil.ProcessFuncParallel(len(infos), concurrencyLevel, loadSegmentFunc, "loadSegmentFunc")
 	if err != nil {
 		segmentGC()
 		return err
 	}
 
 	// set segment to meta replica
-	for _, s := range newSegments {
-		err = metaReplica.setSegment(s)
+	for segmentID, s := range newSegments {
+		var bufferedPKs []primaryKey
+		var bufferedTss []Timestamp
+		if segmentType == segmentTypeSealed {
+			// the deletes from the delta channels arriving before the segment is registered are replayed
+			var collection *Collection
+			collection, err = metaReplica.getCollectionByID(s.collectionID)
+			if err == nil {
+				bufferedPKs, bufferedTss, err = collection.sealedDeletes.register(s, func() error {
+					return metaReplica.setSegment(s)
+				})
+			}
+		} else {
+			err = metaReplica.setSegment(s)
+		}
 		if err != nil {
 			log.Error("load segment failed, set segment to meta failed",
 				zap.Int64("collectionID", s.collectionID),
 				zap.Int64("partitionID", s.partitionID),
 				zap.Int64("segmentID", s.segmentID),
+				zap.Int64("version", s.getVersion()),
 				zap.Int64("loadSegmentRequest msgID", req.Base.MsgID),
 				zap.Error(err))
 			segmentGC()
 			return err
 		}
+		// registered segments are owned by meta replica now,
+		// and the duplicate of a registered version has been released by it
+		delete(newSegments, segmentID)
+		if registered, err := metaReplica.getSegmentByID(segmentID); err != nil || registered != s {
+			continue
+		}
+
+		if err := applyBufferedDeletes(s, bufferedPKs, bufferedTss); err != nil {
+			log.Warn("failed to replay the buffered deletes of segment",
+				zap.Int64("collectionID", s.collectionID),
+				zap.Int64("segmentID", s.segmentID),
+				zap.Int("numDeletes", len(bufferedPKs)),
+				zap.Error(err))
+		}
+		s.saveIndexManifest()
+		if pending, ok := pendingIndexes[segmentID]; ok {
+			if ctx, ok := s.startIndexLoading(); ok {
+				go loader.loadIndexAsync(ctx, s, pending)
+			}
+		}
 	}
 
 	return nil
 }
 
+// loadIndexAsync loads the index files of a registered segment, the segment is served by brute force
+// on raw data until the index is attached, and the raw data is released then. The loading stops once ctx is
+// canceled, i.e. the segment is released
+func (loader *segmentLoader) loadIndexAsync(ctx context.Context, segment *Segment, indexedFieldInfos map[int64]*IndexedFieldInfo) {
+	defer segment.setIndexPending(false)
+	for fieldID, fieldInfo := range indexedFieldInfos {
+		if ctx.Err() != nil {
+			log.Debug("segment released, stop loading index asynchronously",
+				zap.Int64("collectionID", segment.collectionID),
+				zap.Int64("segmentID", segment.ID()))
+			return
+		}
+		tr := timerecord.NewTimeRecorder("loadIndexAsync")
+		err := loader.loadFieldIndexData(segment, fieldInfo.indexInfo)
+		if storage.IsErrNoSuchKey(err) {
+			loader.markIndexStale(segment, fieldID, fieldInfo, err)
+			segment.saveIndexManifest()
+			continue
+		}
+		if err != nil {
+			log.Warn("load index asynchronously failed, segment keeps serving by brute force",
+				zap.Int64("collectionID", segment.collectionID),
+				zap.Int64("segmentID", segment.ID()),
+				zap.Int64("fieldID", fieldID),
+				zap.Error(err))
+			continue
+		}
+		// retrieves fill the field from binlogs before the raw data is dropped
+		segment.setIndexedFieldInfo(fieldID, &IndexedFieldInfo{
+			fieldBinlog: fieldInfo.fieldBinlog,
+			indexInfo:   fieldInfo.indexInfo,
+		})
+		if err := segment.segmentDropFieldData(fieldID); err != nil {
+			log.Warn("failed to release raw data of the indexed field",
+				zap.Int64("collectionID", segment.collectionID),
+				zap.Int64("segmentID", segment.ID()),
+				zap.Int64("fieldID", fieldID),
+				zap.Error(err))
+		}
+		segment.saveIndexManifest()
+		log.Debug("load vector field's index data asynchronously done",
+			zap.Int64("segmentID", segment.ID()),
+			zap.Int64("fieldID", fieldID),
+			zap.Duration("duration", tr.ElapseSpan()))
+	}
+}
+
+// loadSegmentInternal loads the data of a segment. If asyncIndex is set, the raw data of the indexed fields
+// is loaded instead of the index files, and the indexed field infos to attach later are returned
 func (loader *segmentLoader) loadSegmentInternal(segment *Segment,
-	loadInfo *querypb.SegmentLoadInfo) error {
+	loadInfo *querypb.SegmentLoadInfo, asyncIndex bool) (map[int64]*IndexedFieldInfo, error) {
 	collectionID := loadInfo.CollectionID
 	partitionID := loadInfo.PartitionID
 	segmentID := loadInfo.SegmentID
@@ -192,10 +404,11 @@ func (loader *segmentLoader) loadSegmentInternal(segment *Segment,
 
 	pkFieldID, err := loader.historicalReplica.getPKFieldIDByCollectionID(collectionID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var nonIndexedFieldBinlogs []*datapb.FieldBinlog
+	var pendingIndexedFieldInfos map[int64]*IndexedFieldInfo
 	if segment.getType() == segmentTypeSealed {
 		fieldID2IndexInfo := make(map[int64]*querypb.FieldIndexInfo)
 		for _, indexInfo := range loadInfo.IndexInfos {
@@ -218,16 +431,29 @@ func (loader *segmentLoader) loadSegmentInternal(segment *Segment,
 			}
 		}
 
+		if asyncIndex {
+			pendingIndexedFieldInfos = make(map[int64]*IndexedFieldInfo)
+			for fieldID, fieldInfo := range indexedFieldInfos {
+				if fieldInfo.indexInfo != nil && fieldInfo.indexInfo.EnableIndex {
+					// serve by brute force on raw data until the index is attached
+					fieldInfo.rawDataLoaded = true
+					pendingIndexedFieldInfos[fieldID] = fieldInfo
+					nonIndexedFieldBinlogs = append(nonIndexedFieldBinlogs, fieldInfo.fieldBinlog)
+					delete(indexedFieldInfos, fieldID)
+				}
+			}
+		}
+
 		err = loader.loadIndexedFieldData(segment, indexedFieldInfos)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		nonIndexedFieldBinlogs = loadInfo.BinlogPaths
 	}
 	err = loader.loadFiledBinlogData(segment, nonIndexedFieldBinlogs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if pkFieldID == common.InvalidFieldID {
@@ -235,15 +461,36 @@ func (loader *segmentLoader) loadSegmentInternal(segment *Segment,
 	} else {
 		log.Debug("loading bloom filter...")
 		pkStatsBinlogs := loader.filterPKStatsBinlogs(loadInfo.Statslogs, pkFieldID)
-		err = loader.loadSegmentBloomFilter(segment, pkStatsBinlogs)
+		err = loader.loadSegmentBloomFilter(segment, pkStatsBinlogs, loadInfo.GetBloomFilterPath())
 		if err != nil {
-			return err
+			return nil, err
+		}
+	}
+
+	var coveredTs Timestamp
+	if len(loadInfo.GetDeleteSnapshot()) > 0 && segment.getType() == segmentTypeSealed {
+		coveredTs, err = segment.importDeleteSnapshot(loadInfo.GetDeleteSnapshot())
+		if err != nil {
+			// nothing is applied if the snapshot mismatches, replay all the delta logs instead
+			log.Warn("failed to import delete snapshot, replay all delta logs",
+				zap.Int64("collectionID", collectionID),
+				zap.Int64("segmentID", segmentID),
+				zap.Error(err))
+			coveredTs = 0
+		} else {
+			log.Info("delete snapshot imported",
+				zap.Int64("collectionID", collectionID),
+				zap.Int64("segmentID", segmentID),
+				zap.Uint64("coveredTs", coveredTs))
 		}
 	}
 
 	log.Debug("loading delta...")
-	err = loader.loadDeltaLogs(segment, loadInfo.Deltalogs)
-	return err
+	err = loader.loadDeltaLogs(segment, loadInfo.Deltalogs, coveredTs)
+	if err != nil {
+		return nil, err
+	}
+	return pendingIndexedFieldInfos, nil
 }
 
 func (loader *segmentLoader) filterPKStatsBinlogs(fieldBinlogs []*datapb.FieldBinlog, pkFieldID int64) []string {
@@ -261,8 +508,15 @@ func (loader *segmentLoader) filterPKStatsBinlogs(fieldBinlogs []*datapb.FieldBi
 func (loader *segmentLoader) loadFiledBinlogData(segment *Segment, fieldBinlogs []*datapb.FieldBinlog) error {
 	segmentType := segment.getType()
 	iCodec := storage.InsertCodec{}
-	blobs := make([]*storage.Blob, 0)
+	insertData := &storage.InsertData{Data: make(map[FieldID]storage.FieldData)}
+	// binlogs are downloaded and deserialized field by field to record the load stats of each field
 	for _, fieldBinlog := range fieldBinlogs {
+		if len(fieldBinlog.Binlogs) == 0 {
+			continue
+		}
+		tr := timerecord.NewTimeRecorder("loadFieldBinlog")
+		var size int64
+		blobs := make([]*storage.Blob, 0, len(fieldBinlog.Binlogs))
 		for _, path := range fieldBinlog.Binlogs {
 			binLog, err := loader.cm.Read(path.GetLogPath())
 			if err != nil {
@@ -273,11 +527,26 @@ func (loader *segmentLoader) loadFiledBinlogData(segment *Segment, fieldBinlogs
 				Value: binLog,
 			}
 			blobs = append(blobs, blob)
+			size += int64(len(binLog))
 		}
-	}
+		download := tr.RecordSpan()
 
-	_, _, insertData, err := iCodec.Deserialize(blobs)
-	if err != nil {
+		_, _, fieldData, err := iCodec.Deserialize(blobs)
+		if err != nil {
+			log.Warn(err.Error())
+			return err
+		}
+		for fieldID, data := range fieldData.Data {
+			insertData.Data[fieldID] = data
+		}
+		segment.loadStats.record(fieldBinlog.FieldID, loadPhaseStats{
+			binlogSize:  size,
+			download:    download,
+			deserialize: tr.RecordSpan(),
+		})
+	}
+	if len(insertData.Data) == 0 {
+		err := fmt.Errorf("no binlog to load, segmentID = %d", segment.ID())
 		log.Warn(err.Error())
 		return err
 	}
@@ -290,6 +559,10 @@ func (loader *segmentLoader) loadFiledBinlogData(segment *Segment, fieldBinlogs
 		}
 		return loader.loadGrowingSegments(segment, ids, timestamps, rowData)
 	case segmentTypeSealed:
+		// only the loaded copy is transformed, the binlogs are kept as they are
+		if err := applyFieldTransforms(insertData, segment.getFieldTransforms()); err != nil {
+			return err
+		}
 		return loader.loadSealedSegments(segment, insertData)
 	default:
 		err := errors.New(fmt.Sprintln("illegal segment type when load segment, collectionID = ", segment.collectionID))
@@ -309,6 +582,15 @@ func (loader *segmentLoader) loadIndexedFieldData(segment *Segment, vecFieldInfo
 		} else {
 			indexInfo := fieldInfo.indexInfo
 			err := loader.loadFieldIndexData(segment, indexInfo)
+			if storage.IsErrNoSuchKey(err) {
+				// serve by brute force on raw data until the index is refreshed
+				if rawErr := loader.loadFiledBinlogData(segment, []*datapb.FieldBinlog{fieldInfo.fieldBinlog}); rawErr != nil {
+					return &staleIndexError{segmentID: segment.ID(), fieldID: fieldID, err: rawErr}
+				}
+				fieldInfo.rawDataLoaded = true
+				loader.markIndexStale(segment, fieldID, fieldInfo, err)
+				continue
+			}
 			if err != nil {
 				return err
 			}
@@ -320,16 +602,55 @@ func (loader *segmentLoader) loadIndexedFieldData(segment *Segment, vecFieldInfo
 	return nil
 }
 
+// markIndexStale marks the index of the field stale for its index files were garbage collected upstream,
+// the raw data of the field is loaded and the segment is served by brute force until the index is refreshed
+func (loader *segmentLoader) markIndexStale(segment *Segment, fieldID int64, fieldInfo *IndexedFieldInfo, err error) {
+	segment.setIndexStale(fieldID, fieldInfo)
+	metrics.QueryNodeStaleIndexes.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Inc()
+	log.Warn("index files are garbage collected, segment keeps serving by brute force until the index is refreshed",
+		zap.Int64("collectionID", segment.collectionID),
+		zap.Int64("segmentID", segment.ID()),
+		zap.Int64("fieldID", fieldID),
+		zap.Int64("buildID", fieldInfo.indexInfo.GetBuildID()),
+		zap.Error(err))
+}
+
+// refreshIndex attaches the index of indexInfo to the stale field of segment, the index is skipped if the field
+// is not stale
+func (loader *segmentLoader) refreshIndex(segment *Segment, indexInfo *querypb.FieldIndexInfo) (bool, error) {
+	loader.refreshMu.Lock()
+	defer loader.refreshMu.Unlock()
+	fieldID := indexInfo.GetFieldID()
+	stale := segment.getStaleIndex(fieldID)
+	if stale == nil {
+		return false, nil
+	}
+	if err := loader.loadFieldIndexData(segment, indexInfo); err != nil {
+		return false, err
+	}
+	segment.attachStaleIndex(fieldID, &IndexedFieldInfo{
+		fieldBinlog:   stale.fieldBinlog,
+		indexInfo:     indexInfo,
+		rawDataLoaded: true,
+	})
+	segment.saveIndexManifest()
+	return true, nil
+}
+
 func (loader *segmentLoader) loadFieldIndexData(segment *Segment, indexInfo *querypb.FieldIndexInfo) error {
 	indexBuffer := make([][]byte, 0)
 	indexCodec := storage.NewIndexFileBinlogCodec()
 	filteredPaths := make([]string, 0, len(indexInfo.IndexFilePaths))
+	var stats loadPhaseStats
+	tr := timerecord.NewTimeRecorder("loadFieldIndexData")
 	for _, p := range indexInfo.IndexFilePaths {
 		log.Debug("load index file", zap.String("path", p))
 		indexPiece, err := loader.cm.Read(p)
 		if err != nil {
 			return err
 		}
+		stats.indexSize += int64(len(indexPiece))
+		stats.download += tr.RecordSpan()
 
 		if path.Base(p) != storage.IndexParamsKey {
 			data, _, _, _, err := indexCodec.Deserialize([]*storage.Blob{{Key: path.Base(p), Value: indexPiece}})
@@ -339,11 +660,19 @@ func (loader *segmentLoader) loadFieldIndexData(segment *Segment, indexInfo *que
 			indexBuffer = append(indexBuffer, data[0].Value)
 			filteredPaths = append(filteredPaths, p)
 		}
+		stats.deserialize += tr.RecordSpan()
 	}
 	// 2. use index bytes and index path to update segment
 	indexInfo.IndexFilePaths = filteredPaths
-	err := segment.segmentLoadIndexData(indexBuffer, indexInfo)
-	return err
+	err := cgoWritePool.run(func() error {
+		return segment.segmentLoadIndexData(indexBuffer, indexInfo)
+	})
+	if err != nil {
+		return err
+	}
+	stats.indexLoad = tr.RecordSpan()
+	segment.loadStats.record(indexInfo.FieldID, stats)
+	return nil
 }
 
 func (loader *segmentLoader) loadGrowingSegments(segment *Segment,
@@ -379,15 +708,21 @@ func (loader *segmentLoader) loadGrowingSegments(segment *Segment,
 	}
 	pks, err := getPrimaryKeys(tmpInsertMsg, loader.streamingReplica)
 	if err != nil {
+		segment.abandonReservation(offset, int64(numOfRecords))
 		return err
 	}
 	segment.updateBloomFilter(pks)
 
-	// 3. do insert
-	err = segment.segmentInsert(offset, &ids, &timestamps, &records)
+	// 3. do insert, rows are inserted into segcore at once, so it's not attributed to any field
+	tr := timerecord.NewTimeRecorder("segmentInsert")
+	err = cgoWritePool.run(func() error {
+		return segment.segmentInsert(offset, &ids, &timestamps, &records)
+	})
 	if err != nil {
+		segment.abandonReservation(offset, int64(numOfRecords))
 		return err
 	}
+	segment.loadStats.record(common.InvalidFieldID, loadPhaseStats{segcoreLoad: tr.ElapseSpan()})
 	log.Debug("Do insert done in segment loader", zap.Int("len", numOfRecords), zap.Int64("segmentID", segment.ID()), zap.Int64("collectionID", segment.collectionID))
 
 	return nil
@@ -438,16 +773,106 @@ func (loader *segmentLoader) loadSealedSegments(segment *Segment, insertData *st
 		for _, numRow := range numRows {
 			totalNumRows += numRow
 		}
-		err := segment.segmentLoadFieldData(fieldID, int(totalNumRows), data)
+		tr := timerecord.NewTimeRecorder("segmentLoadFieldData")
+		err := cgoWritePool.run(func() error {
+			return segment.segmentLoadFieldData(fieldID, int(totalNumRows), data)
+		})
 		if err != nil {
 			// TODO: return or continue?
 			return err
 		}
+		segment.loadStats.record(fieldID, loadPhaseStats{segcoreLoad: tr.ElapseSpan()})
+	}
+
+	collection, err := loader.historicalReplica.getCollectionByID(segment.collectionID)
+	if err != nil {
+		return err
+	}
+	if collection.isPKIndexEnabled() {
+		return loader.loadPKIndex(segment, insertData)
 	}
 	return nil
 }
 
-func (loader *segmentLoader) loadSegmentBloomFilter(segment *Segment, binlogPaths []string) error {
+// loadPKIndex builds the in-memory pk index of sealed segment from the loaded primary key column
+func (loader *segmentLoader) loadPKIndex(segment *Segment, insertData *storage.InsertData) error {
+	pkFieldID, err := loader.historicalReplica.getPKFieldIDByCollectionID(segment.collectionID)
+	if err != nil {
+		return err
+	}
+	pkData, ok := insertData.Data[pkFieldID]
+	if !ok {
+		// primary key field is loaded from scalar index
+		return nil
+	}
+	tsData, ok := insertData.Data[common.TimeStampField].(*storage.Int64FieldData)
+	if !ok || len(tsData.Data) != pkData.RowNum() {
+		// deletes could not be told from those before the insert of rows without insert timestamps
+		log.Warn("skip building pk index of sealed segment without insert timestamps",
+			zap.Int64("collectionID", segment.collectionID),
+			zap.Int64("segmentID", segment.segmentID))
+		return nil
+	}
+	tr := timerecord.NewTimeRecorder("loadPKIndex")
+	index, err := newPkIndex(pkData)
+	if err != nil {
+		return err
+	}
+	timestamps := make([]Timestamp, len(tsData.Data))
+	for i, ts := range tsData.Data {
+		timestamps[i] = Timestamp(ts)
+	}
+	segment.setInsertTimestamps(timestamps)
+	segment.setPKIndex(index)
+	log.Debug("build pk index of sealed segment",
+		zap.Int64("collectionID", segment.collectionID),
+		zap.Int64("segmentID", segment.segmentID),
+		zap.Int("rowCount", index.rowCount()),
+		zap.Int64("memSize", index.memSize()),
+		zap.Duration("timeCost", tr.ElapseSpan()))
+	return nil
+}
+
+// loadSegmentBloomFilter restores the pk bloom filter of segment. The filter serialized at filterPath is preferred,
+// which saves reading all the stats logs. The filter is rebuilt from the stats logs if it's absent or mismatches,
+// then written to filterPath for the later loads
+func (loader *segmentLoader) loadSegmentBloomFilter(segment *Segment, binlogPaths []string, filterPath string) error {
+	if filterPath != "" {
+		data, err := loader.cm.Read(filterPath)
+		if err == nil {
+			err = segment.loadBloomFilter(data)
+		}
+		if err == nil {
+			log.Debug("load serialized bloom filter", zap.Int64("segmentID", segment.segmentID), zap.String("path", filterPath))
+			return nil
+		}
+		log.Warn("failed to load serialized bloom filter, rebuild it from stats logs",
+			zap.Int64("segmentID", segment.segmentID),
+			zap.String("path", filterPath),
+			zap.Error(err))
+	}
+
+	if err := loader.loadStatsBloomFilter(segment, binlogPaths); err != nil {
+		return err
+	}
+	if filterPath != "" && len(binlogPaths) > 0 {
+		data, err := segment.serializeBloomFilter()
+		if err == nil {
+			err = loader.cm.Write(filterPath, data)
+		}
+		if err != nil {
+			// the filter is rebuilt again on the next load
+			log.Warn("failed to save serialized bloom filter",
+				zap.Int64("segmentID", segment.segmentID),
+				zap.String("path", filterPath),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// loadStatsBloomFilter builds the pk bloom filter and the pk range of segment by the stats logs
+func (loader *segmentLoader) loadStatsBloomFilter(segment *Segment, binlogPaths []string) error {
 	if len(binlogPaths) == 0 {
 		log.Info("there are no stats logs saved with segment", zap.Any("segmentID", segment.segmentID))
 		return nil
@@ -475,15 +900,27 @@ func (loader *segmentLoader) loadSegmentBloomFilter(segment *Segment, binlogPath
 		if err != nil {
 			return err
 		}
+		segment.updatePKRange(stat.MinPk, stat.MaxPk)
 	}
 	return nil
 }
 
-func (loader *segmentLoader) loadDeltaLogs(segment *Segment, deltaLogs []*datapb.FieldBinlog) error {
+// loadDeltaLogs replays the delta logs of segment, the deletes no later than coveredTs are skipped
+// since they are applied by the imported delete snapshot already
+func (loader *segmentLoader) loadDeltaLogs(segment *Segment, deltaLogs []*datapb.FieldBinlog, coveredTs Timestamp) error {
 	dCodec := storage.DeleteCodec{}
 	var blobs []*storage.Blob
+	var skipped int
+	segment.deltaCheckpoint = coveredTs
 	for _, deltaLog := range deltaLogs {
 		for _, log := range deltaLog.GetBinlogs() {
+			if log.GetTimestampTo() > segment.deltaCheckpoint {
+				segment.deltaCheckpoint = log.GetTimestampTo()
+			}
+			if coveredTs > 0 && log.GetTimestampTo() > 0 && log.GetTimestampTo() <= coveredTs {
+				skipped++
+				continue
+			}
 			value, err := loader.cm.Read(log.GetLogPath())
 			if err != nil {
 				return err
@@ -495,23 +932,66 @@ func (loader *segmentLoader) loadDeltaLogs(segment *Segment, deltaLogs []*datapb
 			blobs = append(blobs, blob)
 		}
 	}
+	if skipped > 0 {
+		log.Info("skip delta logs covered by delete snapshot",
+			zap.Int64("segmentID", segment.segmentID),
+			zap.Int("numSkipped", skipped),
+			zap.Uint64("coveredTs", coveredTs))
+	}
 	if len(blobs) == 0 {
 		log.Info("there are no delta logs saved with segment", zap.Any("segmentID", segment.segmentID))
 		return nil
 	}
-	_, _, deltaData, err := dCodec.Deserialize(blobs)
-	if err != nil {
-		return err
+	// the logs are deserialized one by one, since the records are only expected in timestamp order within a log
+	var pks []primaryKey
+	var tss []Timestamp
+	for _, blob := range blobs {
+		_, _, deltaData, err := dCodec.Deserialize([]*storage.Blob{blob})
+		if err != nil {
+			return err
+		}
+		if !isTimestampOrdered(deltaData.Tss) {
+			metrics.QueryNodeUnorderedDeleteBatches.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Inc()
+			log.Warn("deleted records of delta log are out of timestamp order, reordered before loading",
+				zap.Int64("segmentID", segment.segmentID),
+				zap.String("logPath", blob.Key),
+				zap.Int64("rowCount", deltaData.RowCount))
+		}
+		pks = append(pks, deltaData.Pks...)
+		tss = append(tss, deltaData.Tss...)
 	}
 
-	err = segment.segmentLoadDeletedRecord(deltaData.Pks, deltaData.Tss, deltaData.RowCount)
-	if err != nil {
-		return err
+	if coveredTs > 0 {
+		pks, tss = filterDeletesAfter(pks, tss, coveredTs)
+		if len(pks) == 0 {
+			return nil
+		}
 	}
-	return nil
+	return cgoWritePool.run(func() error {
+		return segment.segmentLoadDeletedRecord(pks, tss, int64(len(pks)))
+	})
+}
+
+// filterDeletesAfter returns the delete records later than ts
+func filterDeletesAfter(pks []primaryKey, timestamps []Timestamp, ts Timestamp) ([]primaryKey, []Timestamp) {
+	filteredPks := make([]primaryKey, 0, len(pks))
+	filteredTss := make([]Timestamp, 0, len(timestamps))
+	for i, pk := range pks {
+		if timestamps[i] > ts {
+			filteredPks = append(filteredPks, pk)
+			filteredTss = append(filteredTss, timestamps[i])
+		}
+	}
+	return filteredPks, filteredTss
 }
 
 func (loader *segmentLoader) FromDmlCPLoadDelete(ctx context.Context, collectionID int64, position *internalpb.MsgPosition) error {
+	return loader.replayDeletes(ctx, collectionID, position, nil)
+}
+
+// replayDeletes applies the deletes of collection from position to the latest position of the channel to the sealed
+// segments, only to the ones of segmentIDs if it's not nil
+func (loader *segmentLoader) replayDeletes(ctx context.Context, collectionID int64, position *internalpb.MsgPosition, segmentIDs map[UniqueID]struct{}) error {
 	log.Debug("from dml check point load delete", zap.Any("position", position), zap.Any("msg id", position.MsgID))
 	stream, err := loader.factory.NewMsgStream(ctx)
 	if err != nil {
@@ -547,7 +1027,6 @@ func (loader *segmentLoader) FromDmlCPLoadDelete(ctx context.Context, collection
 	delData := &deleteData{
 		deleteIDs:        make(map[UniqueID][]primaryKey),
 		deleteTimestamps: make(map[UniqueID][]Timestamp),
-		deleteOffset:     make(map[UniqueID]int64),
 	}
 
 	log.Debug("start read delta msg from seek position to last position",
@@ -598,18 +1077,12 @@ func (loader *segmentLoader) FromDmlCPLoadDelete(ctx context.Context, collection
 
 	log.Debug("All data has been read, there is no more data", zap.Int64("Collection ID", collectionID),
 		zap.String("channel", pChannelName), zap.Any("msg id", position.GetMsgID()))
-	for segmentID, pks := range delData.deleteIDs {
-		segment, err := loader.historicalReplica.getSegmentByID(segmentID)
-		if err != nil {
-			log.Debug(err.Error())
+	delData.sortByTimestamp()
+	wg := sync.WaitGroup{}
+	for segmentID := range delData.deleteIDs {
+		if _, ok := segmentIDs[segmentID]; segmentIDs != nil && !ok {
 			continue
 		}
-		offset := segment.segmentPreDelete(len(pks))
-		delData.deleteOffset[segmentID] = offset
-	}
-
-	wg := sync.WaitGroup{}
-	for segmentID := range delData.deleteOffset {
 		wg.Add(1)
 		go deletePk(loader.historicalReplica, delData, segmentID, &wg)
 	}
@@ -623,7 +1096,7 @@ func deletePk(replica ReplicaInterface, deleteData *deleteData, segmentID Unique
 	log.Debug("QueryNode::iNode::delete", zap.Any("SegmentID", segmentID))
 	targetSegment, err := replica.getSegmentByID(segmentID)
 	if err != nil {
-		log.Error(err.Error())
+		log.Debug(err.Error())
 		return
 	}
 
@@ -633,9 +1106,9 @@ func deletePk(replica ReplicaInterface, deleteData *deleteData, segmentID Unique
 
 	ids := deleteData.deleteIDs[segmentID]
 	timestamps := deleteData.deleteTimestamps[segmentID]
-	offset := deleteData.deleteOffset[segmentID]
 
-	err = targetSegment.segmentDelete(offset, ids, timestamps)
+	// the deletes replayed from the checkpoint may overlap the live deletes applied to the segment
+	err = targetSegment.applyDeletes(ids, timestamps, true)
 	if err != nil {
 		log.Warn("QueryNode: targetSegmentDelete failed", zap.Error(err))
 		return
@@ -652,7 +1125,11 @@ func JoinIDPath(ids ...UniqueID) string {
 	return path.Join(idStr...)
 }
 
-func (loader *segmentLoader) checkSegmentSize(collectionID UniqueID, segmentLoadInfos []*querypb.SegmentLoadInfo, concurrency int) error {
+// checkSegmentSize checks whether the memory is enough to load the segments, the index files of the segments are
+// counted besides the raw data if asyncIndex is set, for they coexist in memory until the indexes are attached,
+// so is the mirror of the chunk search of growing segments
+func (loader *segmentLoader) checkSegmentSize(collectionID UniqueID, segmentLoadInfos []*querypb.SegmentLoadInfo, concurrency int,
+	segmentType segmentType, asyncIndex bool) error {
 	usedMem := metricsinfo.GetUsedMemoryCount()
 	totalMem := metricsinfo.GetMemoryCount()
 	if len(segmentLoadInfos) < concurrency {
@@ -663,10 +1140,21 @@ func (loader *segmentLoader) checkSegmentSize(collectionID UniqueID, segmentLoad
 		return fmt.Errorf("get memory failed when checkSegmentSize, collectionID = %d", collectionID)
 	}
 
+	var chunkSearchCollection *Collection
+	if segmentType == segmentTypeGrowing && Params.QueryNodeCfg.EnableGrowingChunkSearch {
+		chunkSearchCollection, _ = loader.streamingReplica.getCollectionByID(collectionID)
+	}
+
 	usedMemAfterLoad := usedMem
 	maxSegmentSize := uint64(0)
 	for _, loadInfo := range segmentLoadInfos {
-		segmentSize := uint64(loadInfo.SegmentSize)
+		segmentSize := loader.getLoadSegmentSize(collectionID, loadInfo)
+		if asyncIndex {
+			segmentSize += getLoadIndexSize(loadInfo)
+		}
+		if chunkSearchCollection != nil {
+			segmentSize += uint64(estimateChunkSearchSize(chunkSearchCollection, loadInfo.GetNumOfRows()))
+		}
 		usedMemAfterLoad += segmentSize
 		if segmentSize > maxSegmentSize {
 			maxSegmentSize = segmentSize
@@ -678,20 +1166,50 @@ func (loader *segmentLoader) checkSegmentSize(collectionID UniqueID, segmentLoad
 	}
 
 	// when load segment, data will be copied from go memory to c++ memory
-	if usedMemAfterLoad+maxSegmentSize*uint64(concurrency) > uint64(float64(totalMem)*Params.QueryNodeCfg.OverloadedMemoryThresholdPercentage) {
-		return fmt.Errorf("load segment failed, OOM if load, collectionID = %d, maxSegmentSize = %.2f MB, concurrency = %d, usedMemAfterLoad = %.2f MB, totalMem = %.2f MB, thresholdFactor = %f",
-			collectionID, toMB(maxSegmentSize), concurrency, toMB(usedMemAfterLoad), toMB(totalMem), Params.QueryNodeCfg.OverloadedMemoryThresholdPercentage)
+	thresholdFactor := loader.config.OverloadedMemoryThresholdPercentage
+	if usedMemAfterLoad+maxSegmentSize*uint64(concurrency) > uint64(float64(totalMem)*thresholdFactor) {
+		return fmt.Errorf("%w, load segment failed, OOM if load, collectionID = %d, maxSegmentSize = %.2f MB, concurrency = %d, usedMemAfterLoad = %.2f MB, totalMem = %.2f MB, thresholdFactor = %f",
+			ErrInsufficientMemory, collectionID, toMB(maxSegmentSize), concurrency, toMB(usedMemAfterLoad), toMB(totalMem), thresholdFactor)
 	}
 
 	return nil
 }
 
+// getLoadSegmentSize returns the size of segment to be loaded, estimated by collection schema if not reported
+func (loader *segmentLoader) getLoadSegmentSize(collectionID UniqueID, loadInfo *querypb.SegmentLoadInfo) uint64 {
+	if loadInfo.GetSegmentSize() > 0 || loadInfo.GetNumOfRows() == 0 {
+		return uint64(loadInfo.GetSegmentSize())
+	}
+	collection, err := loader.historicalReplica.getCollectionByID(collectionID)
+	if err != nil {
+		collection, err = loader.streamingReplica.getCollectionByID(collectionID)
+	}
+	if err != nil {
+		log.Warn("failed to estimate segment size, collection not found",
+			zap.Int64("collectionID", collectionID), zap.Int64("segmentID", loadInfo.GetSegmentID()))
+		return 0
+	}
+	return uint64(collection.EstimateSegmentSize(loadInfo.GetNumOfRows()))
+}
+
+// getLoadIndexSize returns the size of the enabled index files of segment to be loaded
+func getLoadIndexSize(loadInfo *querypb.SegmentLoadInfo) uint64 {
+	var size uint64
+	for _, indexInfo := range loadInfo.GetIndexInfos() {
+		if indexInfo.GetEnableIndex() {
+			size += uint64(indexInfo.GetIndexSize())
+		}
+	}
+	return size
+}
+
 func newSegmentLoader(
 	historicalReplica ReplicaInterface,
 	streamingReplica ReplicaInterface,
 	etcdKV *etcdkv.EtcdKV,
 	cm storage.ChunkManager,
-	factory msgstream.Factory) *segmentLoader {
+	factory msgstream.Factory,
+	config *QueryNodeConfig) *segmentLoader {
 
 	return &segmentLoader{
 		historicalReplica: historicalReplica,
@@ -701,5 +1219,8 @@ func newSegmentLoader(
 		etcdKV: etcdKV,
 
 		factory: factory,
+		config:  config,
+
+		loadingSegments: make(map[segmentLoadKey]*segmentLoadCall),
 	}
 }
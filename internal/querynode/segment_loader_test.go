@@ -19,18 +19,27 @@ package querynode
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/rand"
 	"runtime"
+	"sync"
 	"testing"
+	"time"
+	"unsafe"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/mq/msgstream"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
 )
 
@@ -119,6 +128,122 @@ func TestSegmentLoader_loadSegment(t *testing.T) {
 	})
 }
 
+// slowChunkManager delays and counts the reads of the wrapped chunk manager
+type slowChunkManager struct {
+	storage.ChunkManager
+	delay   time.Duration
+	readErr error
+
+	mu    sync.Mutex
+	reads map[string]int
+}
+
+func (cm *slowChunkManager) Read(filePath string) ([]byte, error) {
+	cm.mu.Lock()
+	cm.reads[filePath]++
+	cm.mu.Unlock()
+	time.Sleep(cm.delay)
+	if cm.readErr != nil {
+		return nil, cm.readErr
+	}
+	return cm.ChunkManager.Read(filePath)
+}
+
+func TestSegmentLoader_loadSegmentSingleFlight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	schema := genSimpleInsertDataSchema()
+	fieldBinlog, err := saveSimpleBinLog(ctx)
+	require.NoError(t, err)
+
+	genLoadRequest := func() *querypb.LoadSegmentsRequest {
+		return &querypb.LoadSegmentsRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_LoadSegments,
+				MsgID:   rand.Int63(),
+			},
+			Schema: schema,
+			Infos: []*querypb.SegmentLoadInfo{
+				{
+					SegmentID:    defaultSegmentID,
+					PartitionID:  defaultPartitionID,
+					CollectionID: defaultCollectionID,
+					BinlogPaths:  fieldBinlog,
+					Version:      1,
+				},
+			},
+		}
+	}
+	// fire the identical requests at once, as the retries of a timeout request
+	loadConcurrently := func(loader *segmentLoader) []error {
+		errs := make([]error, 2)
+		var wg sync.WaitGroup
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = loader.loadSegment(genLoadRequest(), segmentTypeSealed)
+			}(i)
+		}
+		wg.Wait()
+		return errs
+	}
+
+	t.Run("load once", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		err = node.historical.replica.removeSegment(defaultSegmentID)
+		require.NoError(t, err)
+
+		loader := node.loader
+		cm := &slowChunkManager{ChunkManager: loader.cm, delay: 100 * time.Millisecond, reads: make(map[string]int)}
+		loader.cm = cm
+
+		for _, err := range loadConcurrently(loader) {
+			assert.NoError(t, err)
+		}
+		assert.NotEmpty(t, cm.reads)
+		for path, count := range cm.reads {
+			assert.Equal(t, 1, count, path)
+		}
+		assert.Empty(t, loader.loadingSegments)
+
+		segment, err := node.historical.replica.getSegmentByID(defaultSegmentID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), segment.getVersion())
+		assert.Equal(t, int64(defaultMsgLength), segment.getRowCount())
+		assert.Equal(t, 1, node.historical.replica.getSegmentNum())
+
+		// the loaded version is registered idempotently
+		err = loader.loadSegment(genLoadRequest(), segmentTypeSealed)
+		assert.NoError(t, err)
+		registered, err := node.historical.replica.getSegmentByID(defaultSegmentID)
+		require.NoError(t, err)
+		assert.Same(t, segment, registered)
+	})
+
+	t.Run("share failure", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		err = node.historical.replica.removeSegment(defaultSegmentID)
+		require.NoError(t, err)
+
+		loader := node.loader
+		cm := &slowChunkManager{ChunkManager: loader.cm, delay: 100 * time.Millisecond, readErr: errors.New("mock"), reads: make(map[string]int)}
+		loader.cm = cm
+
+		errs := loadConcurrently(loader)
+		assert.Error(t, errs[0])
+		assert.Equal(t, errs[0], errs[1])
+		for path, count := range cm.reads {
+			assert.Equal(t, 1, count, path)
+		}
+		assert.Empty(t, loader.loadingSegments)
+		assert.False(t, node.historical.replica.hasSegment(defaultSegmentID))
+	})
+}
+
 func TestSegmentLoader_loadSegmentFieldsData(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -320,8 +445,161 @@ func TestSegmentLoader_checkSegmentSize(t *testing.T) {
 	loader := node.loader
 	assert.NotNil(t, loader)
 
-	err = loader.checkSegmentSize(defaultCollectionID, []*querypb.SegmentLoadInfo{{SegmentID: defaultSegmentID, SegmentSize: 1024}}, runtime.GOMAXPROCS(0))
+	err = loader.checkSegmentSize(defaultCollectionID, []*querypb.SegmentLoadInfo{{SegmentID: defaultSegmentID, SegmentSize: 1024}}, runtime.GOMAXPROCS(0), segmentTypeSealed, false)
+	assert.NoError(t, err)
+
+	t.Run("estimate segment size by schema", func(t *testing.T) {
+		col, err := loader.historicalReplica.getCollectionByID(defaultCollectionID)
+		assert.NoError(t, err)
+
+		loadInfo := &querypb.SegmentLoadInfo{SegmentID: defaultSegmentID, NumOfRows: defaultMsgLength}
+		assert.Equal(t, uint64(col.EstimateSegmentSize(defaultMsgLength)), loader.getLoadSegmentSize(defaultCollectionID, loadInfo))
+
+		// reported segment size takes precedence
+		loadInfo.SegmentSize = 1024
+		assert.Equal(t, uint64(1024), loader.getLoadSegmentSize(defaultCollectionID, loadInfo))
+
+		// unknown collection
+		loadInfo.SegmentSize = 0
+		assert.Equal(t, uint64(0), loader.getLoadSegmentSize(defaultCollectionID+1, loadInfo))
+	})
+}
+
+func TestSegmentLoader_loadPKIndex(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	assert.NoError(t, err)
+	loader := node.loader
+	assert.NotNil(t, loader)
+
+	col, err := loader.historicalReplica.getCollectionByID(defaultCollectionID)
 	assert.NoError(t, err)
+
+	const rowCount = 100000
+	insertData, err := genInsertData(rowCount, genSimpleInsertDataSchema())
+	assert.NoError(t, err)
+
+	loadSealed := func(enablePKIndex bool) *Segment {
+		Params.QueryNodeCfg.EnableSealedPKIndex = enablePKIndex
+		defer func() { Params.QueryNodeCfg.EnableSealedPKIndex = false }()
+
+		segment, err := newSegment(col, defaultSegmentID+1, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeSealed, true)
+		assert.NoError(t, err)
+		err = loader.loadSealedSegments(segment, insertData)
+		assert.NoError(t, err)
+		return segment
+	}
+
+	t.Run("pk index disabled", func(t *testing.T) {
+		segment := loadSealed(false)
+		defer deleteSegment(segment)
+		assert.False(t, segment.hasPKIndex())
+		_, ok := segment.searchPK(newInt64PrimaryKey(1))
+		assert.False(t, ok)
+	})
+
+	t.Run("pk index enabled", func(t *testing.T) {
+		segment := loadSealed(true)
+		defer deleteSegment(segment)
+		assert.True(t, segment.hasPKIndex())
+		assert.Equal(t, rowCount, segment.pkIndex.rowCount())
+		assert.Len(t, segment.insertTimestamps, rowCount)
+
+		for _, pk := range []int64{0, 1, rowCount / 2, rowCount - 1} {
+			offset, ok := segment.searchPK(newInt64PrimaryKey(pk))
+			assert.True(t, ok)
+			assert.Equal(t, pk, offset)
+		}
+		_, ok := segment.searchPK(newInt64PrimaryKey(rowCount))
+		assert.False(t, ok)
+
+		// memory of pk index is reported in segment memory size
+		memSize := segment.getMemSize()
+		segment.setPKIndex(nil)
+		assert.Equal(t, memSize-segment.getMemSize(), int64(rowCount*16+int(unsafe.Sizeof(int64PkIndex{}))))
+	})
+}
+
+func TestSegmentLoader_loadDeleteSnapshot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	loader := node.loader
+	col, err := loader.historicalReplica.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+	fieldBinlog, err := saveSimpleBinLog(ctx)
+	require.NoError(t, err)
+
+	Params.QueryNodeCfg.EnableSealedPKIndex = true
+	defer func() { Params.QueryNodeCfg.EnableSealedPKIndex = false }()
+
+	saveDeltaLog := func(path string, ids []int64, timestamps []Timestamp) *datapb.Binlog {
+		deleteData := &storage.DeleteData{}
+		for i, id := range ids {
+			deleteData.Append(newInt64PrimaryKey(id), timestamps[i])
+		}
+		blob, err := (&storage.DeleteCodec{}).Serialize(defaultCollectionID, defaultPartitionID, defaultSegmentID, deleteData)
+		require.NoError(t, err)
+		require.NoError(t, loader.cm.Write(path, blob.GetValue()))
+		return &datapb.Binlog{
+			TimestampFrom: timestamps[0],
+			TimestampTo:   timestamps[len(timestamps)-1],
+			LogPath:       path,
+		}
+	}
+	oldLog := saveDeltaLog("delete-snapshot-test/old", []int64{1, 3}, []Timestamp{50, 60})
+	newLog := saveDeltaLog("delete-snapshot-test/new", []int64{2, 3}, []Timestamp{150, 170})
+	defer loader.cm.Remove(newLog.GetLogPath())
+
+	loadSealed := func(deltaLogs []*datapb.Binlog, snapshot []byte) *Segment {
+		segment, err := newSegment(col, defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeSealed, true)
+		require.NoError(t, err)
+		_, err = loader.loadSegmentInternal(segment, &querypb.SegmentLoadInfo{
+			SegmentID:      defaultSegmentID,
+			PartitionID:    defaultPartitionID,
+			CollectionID:   defaultCollectionID,
+			BinlogPaths:    fieldBinlog,
+			Deltalogs:      []*datapb.FieldBinlog{{Binlogs: deltaLogs}},
+			DeleteSnapshot: snapshot,
+		}, false)
+		require.NoError(t, err)
+		return segment
+	}
+
+	// the serving replica loaded the old delta log, then pk 2 is deleted from the delta channel
+	serving := loadSealed([]*datapb.Binlog{oldLog}, nil)
+	defer deleteSegment(serving)
+	pks, timestamps := genDeleteRecords([]int64{2}, []Timestamp{150})
+	offset := serving.segmentPreDelete(len(pks))
+	require.NoError(t, serving.segmentDelete(offset, pks, timestamps))
+	snapshot, err := serving.exportDeleteSnapshot()
+	require.NoError(t, err)
+
+	replayed := loadSealed([]*datapb.Binlog{oldLog, newLog}, nil)
+	defer deleteSegment(replayed)
+
+	// the old delta log is covered by the snapshot, it must not be read
+	require.NoError(t, loader.cm.Remove(oldLog.GetLogPath()))
+	imported := loadSealed([]*datapb.Binlog{oldLog, newLog}, snapshot)
+	defer deleteSegment(imported)
+
+	for _, ts := range []Timestamp{40, 55, 100, 160, 200} {
+		assert.ElementsMatch(t, retrieveSimpleIDs(t, replayed, ts), retrieveSimpleIDs(t, imported, ts), "ts %d", ts)
+	}
+	assert.Equal(t, replayed.appliedDeletes.offsets, imported.appliedDeletes.offsets)
+
+	t.Run("fall back to full replay", func(t *testing.T) {
+		invalid := append([]byte{}, snapshot...)
+		invalid[4]++
+		segment := loadSealed([]*datapb.Binlog{newLog}, invalid)
+		defer deleteSegment(segment)
+		assert.ElementsMatch(t, []int64{1, 3}, retrieveSimpleIDs(t, segment, 160))
+		assert.ElementsMatch(t, []int64{1}, retrieveSimpleIDs(t, segment, 200))
+	})
 }
 
 func TestSegmentLoader_testLoadGrowing(t *testing.T) {
@@ -509,6 +787,341 @@ func TestSegmentLoader_testLoadSealedSegmentWithIndex(t *testing.T) {
 	assert.Equal(t, true, vecFieldInfo.indexInfo.EnableIndex)
 }
 
+// blockIndexChunkManager blocks reading index files until unblock is closed
+type blockIndexChunkManager struct {
+	storage.ChunkManager
+	indexPaths map[string]struct{}
+	unblock    chan struct{}
+}
+
+func (cm *blockIndexChunkManager) Read(filePath string) ([]byte, error) {
+	if _, ok := cm.indexPaths[filePath]; ok {
+		<-cm.unblock
+	}
+	return cm.ChunkManager.Read(filePath)
+}
+
+func TestSegmentLoader_testLoadSealedSegmentWithAsyncIndex(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	schema := genSimpleInsertDataSchema()
+	fieldBinlog, err := saveBinLog(ctx, defaultCollectionID, defaultPartitionID, defaultSegmentID, defaultMsgLength, schema)
+	assert.NoError(t, err)
+
+	segmentID := UniqueID(100)
+	indexPaths, err := generateIndex(segmentID)
+	assert.NoError(t, err)
+	indexInfo := &querypb.FieldIndexInfo{
+		FieldID:        simpleVecField.id,
+		EnableIndex:    true,
+		IndexName:      indexName,
+		IndexID:        indexID,
+		BuildID:        buildID,
+		IndexParams:    funcutil.Map2KeyValuePair(genSimpleIndexParams()),
+		IndexFilePaths: indexPaths,
+	}
+
+	node, err := genSimpleQueryNode(ctx)
+	assert.NoError(t, err)
+	loader := node.loader
+	assert.NotNil(t, loader)
+
+	Params.QueryNodeCfg.AsyncIndexLoading = true
+	defer func() { Params.QueryNodeCfg.AsyncIndexLoading = false }()
+
+	cm := &blockIndexChunkManager{
+		ChunkManager: loader.cm,
+		indexPaths:   make(map[string]struct{}),
+		unblock:      make(chan struct{}),
+	}
+	for _, p := range indexPaths {
+		cm.indexPaths[p] = struct{}{}
+	}
+	loader.cm = cm
+
+	req := &querypb.LoadSegmentsRequest{
+		Base: &commonpb.MsgBase{
+			MsgType: commonpb.MsgType_LoadSegments,
+			MsgID:   rand.Int63(),
+		},
+		DstNodeID: 0,
+		Schema:    schema,
+		Infos: []*querypb.SegmentLoadInfo{
+			{
+				SegmentID:    segmentID,
+				PartitionID:  defaultPartitionID,
+				CollectionID: defaultCollectionID,
+				BinlogPaths:  fieldBinlog,
+				IndexInfos:   []*querypb.FieldIndexInfo{indexInfo},
+			},
+		},
+	}
+
+	err = loader.loadSegment(req, segmentTypeSealed)
+	assert.NoError(t, err)
+
+	// segment is served by brute force before the index is attached
+	segment, err := node.historical.replica.getSegmentByID(segmentID)
+	assert.NoError(t, err)
+	assert.True(t, segment.isIndexPending())
+	assert.False(t, segment.hasLoadIndexForIndexedField(simpleVecField.id))
+	infos, err := node.historical.replica.getSegmentInfosByColID(defaultCollectionID)
+	assert.NoError(t, err)
+	for _, info := range infos {
+		if info.GetSegmentID() == segmentID {
+			assert.True(t, info.GetIndexPending())
+		}
+	}
+
+	plan, searchReqs, err := genSimpleSearchPlanAndRequests(IndexFaissIDMap)
+	assert.NoError(t, err)
+	searchResults, _, err := node.historical.searchSegments(context.Background(), []UniqueID{segmentID}, searchReqs, plan, Timestamp(1000))
+	assert.NoError(t, err)
+	deleteSearchResults(searchResults)
+
+	close(cm.unblock)
+	assert.Eventually(t, func() bool {
+		return !segment.isIndexPending()
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.True(t, segment.hasLoadIndexForIndexedField(simpleVecField.id))
+	// the raw data is released once the index is attached
+	assert.True(t, segment.isOffsetsOnlyField(simpleVecField.id))
+	searchResults, _, err = node.historical.searchSegments(context.Background(), []UniqueID{segmentID}, searchReqs, plan, Timestamp(1000))
+	assert.NoError(t, err)
+	deleteSearchResults(searchResults)
+
+	// the index loading stops once the segment is released
+	cm.unblock = make(chan struct{})
+	req.Base.MsgID = rand.Int63()
+	req.Infos[0].SegmentID = segmentID + 1
+	err = loader.loadSegment(req, segmentTypeSealed)
+	assert.NoError(t, err)
+	released, err := node.historical.replica.getSegmentByID(segmentID + 1)
+	assert.NoError(t, err)
+	assert.True(t, released.isIndexPending())
+	err = node.historical.replica.removeSegment(segmentID + 1)
+	assert.NoError(t, err)
+	close(cm.unblock)
+	assert.Eventually(t, func() bool {
+		return !released.isIndexPending()
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.False(t, released.hasLoadIndexForIndexedField(simpleVecField.id))
+
+	// index files are counted on memory admission
+	loadInfo := &querypb.SegmentLoadInfo{SegmentID: segmentID, SegmentSize: 1024, IndexInfos: []*querypb.FieldIndexInfo{
+		{FieldID: simpleVecField.id, EnableIndex: true, IndexSize: 2048},
+		{FieldID: simpleVecField.id + 1, EnableIndex: false, IndexSize: 4096},
+	}}
+	assert.Equal(t, uint64(2048), getLoadIndexSize(loadInfo))
+}
+
+func TestSegmentLoader_testLoadStandbySegment(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	schema := genSimpleInsertDataSchema()
+	fieldBinlog, err := saveBinLog(ctx, defaultCollectionID, defaultPartitionID, defaultSegmentID, defaultMsgLength, schema)
+	assert.NoError(t, err)
+
+	segmentID := UniqueID(100)
+	indexPaths, err := generateIndex(segmentID)
+	assert.NoError(t, err)
+	indexInfo := &querypb.FieldIndexInfo{
+		FieldID:        simpleVecField.id,
+		EnableIndex:    true,
+		IndexName:      indexName,
+		IndexID:        indexID,
+		BuildID:        buildID,
+		IndexParams:    funcutil.Map2KeyValuePair(genSimpleIndexParams()),
+		IndexFilePaths: indexPaths,
+	}
+
+	node, err := genSimpleQueryNode(ctx)
+	assert.NoError(t, err)
+	loader := node.loader
+	assert.NotNil(t, loader)
+
+	req := &querypb.LoadSegmentsRequest{
+		Base: &commonpb.MsgBase{
+			MsgType: commonpb.MsgType_LoadSegments,
+			MsgID:   rand.Int63(),
+		},
+		DstNodeID: 0,
+		Schema:    schema,
+		Infos: []*querypb.SegmentLoadInfo{
+			{
+				SegmentID:    segmentID,
+				PartitionID:  defaultPartitionID,
+				CollectionID: defaultCollectionID,
+				BinlogPaths:  fieldBinlog,
+				IndexInfos:   []*querypb.FieldIndexInfo{indexInfo},
+			},
+		},
+		Standby: true,
+	}
+
+	err = loader.loadSegment(req, segmentTypeSealed)
+	assert.NoError(t, err)
+
+	// the index is attached on load, but the segment is kept out of service
+	segment, err := node.historical.replica.getSegmentByID(segmentID)
+	assert.NoError(t, err)
+	assert.True(t, segment.isStandby())
+	assert.False(t, segment.getOnService())
+	assert.False(t, segment.isIndexPending())
+	assert.True(t, segment.hasLoadIndexForIndexedField(simpleVecField.id))
+}
+
+func TestSegmentLoader_testLoadStaleIndex(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	schema := genSimpleInsertDataSchema()
+	fieldBinlog, err := saveBinLog(ctx, defaultCollectionID, defaultPartitionID, defaultSegmentID, defaultMsgLength, schema)
+	require.NoError(t, err)
+
+	segmentID := UniqueID(100)
+	indexPaths, err := generateIndex(segmentID)
+	require.NoError(t, err)
+	genIndexInfo := func() *querypb.FieldIndexInfo {
+		return &querypb.FieldIndexInfo{
+			FieldID:        simpleVecField.id,
+			EnableIndex:    true,
+			IndexName:      indexName,
+			IndexID:        indexID,
+			BuildID:        buildID,
+			IndexParams:    funcutil.Map2KeyValuePair(genSimpleIndexParams()),
+			IndexFilePaths: append([]string{}, indexPaths...),
+		}
+	}
+
+	// genLoader returns the loader whose chunk manager reads the removed paths as garbage collected
+	genLoader := func(t *testing.T, removed ...string) (*QueryNode, *segmentLoader) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		loader := node.loader
+		loader.indexManifests = newIndexManifestStore(t.TempDir())
+		removedPaths := make(map[string]struct{})
+		for _, p := range removed {
+			removedPaths[p] = struct{}{}
+		}
+		cm := &mockChunkManager{ChunkManager: loader.cm}
+		cm.read = func(path string) ([]byte, error) {
+			if _, ok := removedPaths[path]; ok {
+				return nil, storage.WrapErrNoSuchKey(path)
+			}
+			return cm.ChunkManager.Read(path)
+		}
+		loader.cm = cm
+		return node, loader
+	}
+	genRequest := func(sync bool) *querypb.LoadSegmentsRequest {
+		return &querypb.LoadSegmentsRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_LoadSegments,
+				MsgID:   rand.Int63(),
+			},
+			Schema: schema,
+			Infos: []*querypb.SegmentLoadInfo{
+				{
+					SegmentID:    segmentID,
+					PartitionID:  defaultPartitionID,
+					CollectionID: defaultCollectionID,
+					BinlogPaths:  fieldBinlog,
+					IndexInfos:   []*querypb.FieldIndexInfo{genIndexInfo()},
+				},
+			},
+			SyncIndexLoading: sync,
+		}
+	}
+	staleIndexes := func() float64 {
+		return testutil.ToFloat64(metrics.QueryNodeStaleIndexes.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)))
+	}
+	assertStale := func(t *testing.T, loader *segmentLoader, segment *Segment) {
+		assert.NotNil(t, segment.getStaleIndex(simpleVecField.id))
+		assert.False(t, segment.hasLoadIndexForIndexedField(simpleVecField.id))
+		indexes := loader.indexManifests.getIndexes()
+		require.Len(t, indexes, 1)
+		assert.Equal(t, simpleVecField.id, indexes[0].GetFieldID())
+		assert.Equal(t, int64(buildID), indexes[0].GetBuildID())
+		assert.True(t, indexes[0].GetStale())
+	}
+
+	t.Run("sync", func(t *testing.T) {
+		node, loader := genLoader(t, indexPaths...)
+		before := staleIndexes()
+		err := loader.loadSegment(genRequest(true), segmentTypeSealed)
+		require.NoError(t, err)
+		assert.Equal(t, before+1, staleIndexes())
+
+		// the segment is served by brute force on raw data
+		segment, err := node.historical.replica.getSegmentByID(segmentID)
+		require.NoError(t, err)
+		assertStale(t, loader, segment)
+		plan, searchReqs, err := genSimpleSearchPlanAndRequests(IndexFaissIDMap)
+		require.NoError(t, err)
+		searchResults, _, err := node.historical.searchSegments(context.Background(), []UniqueID{segmentID}, searchReqs, plan, Timestamp(1000))
+		assert.NoError(t, err)
+		deleteSearchResults(searchResults)
+
+		// the index is still missing
+		_, err = loader.refreshIndex(segment, genIndexInfo())
+		assert.True(t, storage.IsErrNoSuchKey(err))
+		assertStale(t, loader, segment)
+
+		// the current index is attached, and refreshed once
+		loader.cm = loader.cm.(*mockChunkManager).ChunkManager
+		refreshed, err := loader.refreshIndex(segment, genIndexInfo())
+		assert.NoError(t, err)
+		assert.True(t, refreshed)
+		assert.Nil(t, segment.getStaleIndex(simpleVecField.id))
+		assert.True(t, segment.hasLoadIndexForIndexedField(simpleVecField.id))
+		assert.False(t, segment.isOffsetsOnlyField(simpleVecField.id))
+		indexes := loader.indexManifests.getIndexes()
+		require.Len(t, indexes, 1)
+		assert.False(t, indexes[0].GetStale())
+
+		refreshed, err = loader.refreshIndex(segment, genIndexInfo())
+		assert.NoError(t, err)
+		assert.False(t, refreshed)
+	})
+
+	t.Run("async", func(t *testing.T) {
+		Params.QueryNodeCfg.AsyncIndexLoading = true
+		defer func() { Params.QueryNodeCfg.AsyncIndexLoading = false }()
+		node, loader := genLoader(t, indexPaths...)
+		before := staleIndexes()
+		err := loader.loadSegment(genRequest(false), segmentTypeSealed)
+		require.NoError(t, err)
+
+		segment, err := node.historical.replica.getSegmentByID(segmentID)
+		require.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			return !segment.isIndexPending()
+		}, 10*time.Second, 10*time.Millisecond)
+		assert.Equal(t, before+1, staleIndexes())
+		assertStale(t, loader, segment)
+	})
+
+	t.Run("raw data unavailable", func(t *testing.T) {
+		removed := append([]string{}, indexPaths...)
+		for _, binlog := range fieldBinlog {
+			if binlog.GetFieldID() == simpleVecField.id {
+				for _, path := range binlog.GetBinlogs() {
+					removed = append(removed, path.GetLogPath())
+				}
+			}
+		}
+		node, loader := genLoader(t, removed...)
+		err := loader.loadSegment(genRequest(true), segmentTypeSealed)
+		var staleErr *staleIndexError
+		assert.True(t, errors.As(err, &staleErr))
+		_, err = node.historical.replica.getSegmentByID(segmentID)
+		assert.Error(t, err)
+	})
+}
+
 func TestSegmentLoader_testFromDmlCPLoadDelete(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
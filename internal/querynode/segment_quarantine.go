@@ -0,0 +1,121 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+// the operations on segments guarded against failures and panics
+const (
+	segmentOpSearch   = "search"
+	segmentOpRetrieve = "retrieve"
+	segmentOpInsert   = "insert"
+	segmentOpDelete   = "delete"
+)
+
+// segmentOpHook is called before every guarded operation on segments if set, tests simulate failing segments
+// by returning an error or panicking in it
+var segmentOpHook func(segment *Segment, op string) error
+
+// segmentQuarantine tracks the consecutive failures of the operations on a sealed segment. Only the recovered
+// panics and the internal segcore errors are failures, the errors of bad requests are not. A sealed segment is
+// quarantined once SegmentQuarantineFailures operations failed in a row, search and query on the quarantined
+// segment fail, and it's reported in GetSegmentInfo for QueryCoord to load it on other nodes.
+// The growing segments are never quarantined, for QueryCoord can't move them. The quarantined segments are
+// released from quarantine once the quarantine is disabled.
+type segmentQuarantine struct {
+	failures    atomic.Int64
+	quarantined atomic.Bool
+	// config of the replica the segment is registered in, the segment out of replicas is never quarantined
+	config *QueryNodeConfig
+}
+
+// isQuarantined returns whether the segment is quarantined
+func (s *Segment) isQuarantined() bool {
+	return s.quarantine.quarantined.Load()
+}
+
+// releaseQuarantine releases the segment from quarantine and resets its failures
+func (s *Segment) releaseQuarantine() {
+	s.quarantine.failures.Store(0)
+	if s.quarantine.quarantined.CAS(true, false) {
+		log.Info("release segment from quarantine", zap.Int64("collectionID", s.collectionID), zap.Int64("segmentID", s.segmentID))
+	}
+}
+
+// guard runs op on the segment, a panic of op is recovered as an error so that a corrupted segment could not
+// take down the whole QueryNode. The panics raised by segcore in C++ can't be recovered, only the ones raised
+// in Go, e.g. by cgo pointer checks or by the conversion of the results, are.
+func (s *Segment) guard(op string, fn func() error) (err error) {
+	if s.isQuarantined() {
+		return &segmentQuarantinedError{segmentID: s.segmentID}
+	}
+	panicked := false
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+			err = fmt.Errorf("%s on segment %d panicked: %v", op, s.segmentID, r)
+			log.Error("operation on segment panicked", zap.Int64("segmentID", s.segmentID), zap.String("op", op),
+				zap.Any("panic", r), zap.ByteString("stack", debug.Stack()))
+		}
+		s.recordOpResult(op, err, panicked)
+	}()
+	if segmentOpHook != nil {
+		if err := segmentOpHook(s, op); err != nil {
+			return err
+		}
+	}
+	return fn()
+}
+
+// recordOpResult records the result of op, and quarantines the sealed segment after too many consecutive failures
+func (s *Segment) recordOpResult(op string, err error, panicked bool) {
+	if err == nil {
+		s.quarantine.failures.Store(0)
+		return
+	}
+	if s.getType() != segmentTypeSealed {
+		return
+	}
+	// the errors other than the internal ones of segcore, e.g. of bad requests, say nothing about the segment
+	var segcoreErr *SegcoreError
+	if !panicked && !errors.As(err, &segcoreErr) {
+		return
+	}
+	failures := s.quarantine.failures.Inc()
+	if s.quarantine.config == nil {
+		return
+	}
+	threshold := int64(s.quarantine.config.getDynamic().SegmentQuarantineFailures)
+	if threshold <= 0 || failures < threshold {
+		return
+	}
+	if s.quarantine.quarantined.CAS(false, true) {
+		metrics.QueryNodeQuarantinedSegments.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Inc()
+		log.Error("quarantine segment for operations on it failed repeatedly", zap.Int64("collectionID", s.collectionID),
+			zap.Int64("segmentID", s.segmentID), zap.String("op", op), zap.Int64("failures", failures), zap.Error(err))
+	}
+}
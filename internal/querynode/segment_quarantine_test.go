@@ -0,0 +1,188 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+)
+
+// failSegment makes the guarded operations on the segment segmentID fail by panicking, until the returned
+// function is called
+func failSegment(segmentID UniqueID) func() {
+	segmentOpHook = func(segment *Segment, op string) error {
+		if segment.ID() == segmentID {
+			panic(fmt.Sprintf("simulated corruption of segment %d", segmentID))
+		}
+		return nil
+	}
+	return func() { segmentOpHook = nil }
+}
+
+func TestSegment_guard(t *testing.T) {
+	quarantined := metrics.QueryNodeQuarantinedSegments.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID))
+
+	seg, err := genSimpleSealedSegment()
+	require.NoError(t, err)
+	defer deleteSegment(seg)
+	seg.quarantine.config = newQueryNodeConfig()
+	updateDynamicConfig(seg.quarantine.config, func(dynamic *DynamicQueryNodeConfig) { dynamic.SegmentQuarantineFailures = 3 })
+
+	t.Run("panic recovered", func(t *testing.T) {
+		err := seg.guard(segmentOpSearch, func() error { panic("boom") })
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "boom")
+		assert.False(t, seg.isQuarantined())
+	})
+
+	segcoreErr := &SegcoreError{code: commonpb.ErrorCode_UnexpectedError, message: "failed"}
+
+	t.Run("success resets failures", func(t *testing.T) {
+		assert.Error(t, seg.guard(segmentOpSearch, func() error { return segcoreErr }))
+		assert.Equal(t, int64(1), seg.quarantine.failures.Load())
+		assert.NoError(t, seg.guard(segmentOpSearch, func() error { return nil }))
+		assert.Equal(t, int64(0), seg.quarantine.failures.Load())
+	})
+
+	t.Run("request errors not counted", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			assert.Error(t, seg.guard(segmentOpSearch, func() error { return errors.New("metric type mismatch") }))
+		}
+		assert.Equal(t, int64(0), seg.quarantine.failures.Load())
+		assert.False(t, seg.isQuarantined())
+	})
+
+	t.Run("growing never quarantined", func(t *testing.T) {
+		seg.setType(segmentTypeGrowing)
+		defer seg.setType(segmentTypeSealed)
+		for i := 0; i < 5; i++ {
+			assert.Error(t, seg.guard(segmentOpInsert, func() error { panic("boom") }))
+		}
+		assert.False(t, seg.isQuarantined())
+	})
+
+	t.Run("quarantined", func(t *testing.T) {
+		before := testutil.ToFloat64(quarantined)
+		for i := 0; i < 3; i++ {
+			assert.False(t, seg.isQuarantined())
+			assert.Error(t, seg.guard(segmentOpRetrieve, func() error { return segcoreErr }))
+		}
+		assert.True(t, seg.isQuarantined())
+		assert.Equal(t, before+1, testutil.ToFloat64(quarantined))
+
+		called := false
+		err := seg.guard(segmentOpSearch, func() error {
+			called = true
+			return nil
+		})
+		assert.False(t, called)
+		var quarantinedErr *segmentQuarantinedError
+		assert.True(t, errors.As(err, &quarantinedErr))
+		assert.Equal(t, before+1, testutil.ToFloat64(quarantined))
+
+		seg.releaseQuarantine()
+		assert.False(t, seg.isQuarantined())
+		assert.Equal(t, int64(0), seg.quarantine.failures.Load())
+		assert.NoError(t, seg.guard(segmentOpSearch, func() error { return nil }))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		seg, err := genSimpleSealedSegment()
+		require.NoError(t, err)
+		defer deleteSegment(seg)
+		seg.quarantine.config = newQueryNodeConfig()
+		updateDynamicConfig(seg.quarantine.config, func(dynamic *DynamicQueryNodeConfig) { dynamic.SegmentQuarantineFailures = 0 })
+		for i := 0; i < 10; i++ {
+			assert.Error(t, seg.guard(segmentOpSearch, func() error { return segcoreErr }))
+		}
+		assert.False(t, seg.isQuarantined())
+	})
+}
+
+func TestHistorical_quarantineSegment(t *testing.T) {
+	defer func(failures int) { Params.QueryNodeCfg.SegmentQuarantineFailures = failures }(Params.QueryNodeCfg.SegmentQuarantineFailures)
+	Params.QueryNodeCfg.SegmentQuarantineFailures = 2
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	his, err := genSimpleHistorical(ctx, newTSafeReplica())
+	require.NoError(t, err)
+	healthySegmentID := defaultSegmentID + 1
+	healthy, err := genSealedSegment(genSimpleSegCoreSchema(), genSimpleInsertDataSchema(), defaultCollectionID,
+		defaultPartitionID, healthySegmentID, defaultDMLChannel, defaultMsgLength)
+	require.NoError(t, err)
+	require.NoError(t, his.replica.setSegment(healthy))
+	segmentIDs := []UniqueID{defaultSegmentID, healthySegmentID}
+
+	plan, searchReqs, err := genSimpleSearchPlanAndRequests(IndexFaissIDMap)
+	require.NoError(t, err)
+	defer plan.delete()
+
+	restore := failSegment(defaultSegmentID)
+	defer restore()
+
+	// the failures of the corrupted segment fail the requests without taking down the node
+	for i := 0; i < 2; i++ {
+		_, _, err := his.searchSegments(context.Background(), segmentIDs, searchReqs, plan, defaultMsgLength)
+		assert.Error(t, err)
+	}
+
+	seg, err := his.replica.getSegmentByID(defaultSegmentID)
+	require.NoError(t, err)
+	assert.True(t, seg.isQuarantined())
+	assert.False(t, healthy.isQuarantined())
+
+	// the requests on the quarantined segment fail rather than return partial results
+	var quarantinedErr *segmentQuarantinedError
+	_, _, err = his.searchSegments(context.Background(), segmentIDs, searchReqs, plan, defaultMsgLength)
+	assert.True(t, errors.As(err, &quarantinedErr))
+
+	expr, err := genSimpleRetrievePlanExpr()
+	require.NoError(t, err)
+	retrievePlan, err := createRetrievePlanByExpr(newCollection(defaultCollectionID, genSimpleSegCoreSchema()), expr, defaultMsgLength)
+	require.NoError(t, err)
+	defer retrievePlan.delete()
+	_, err = his.retrieveBySegmentIDs(defaultCollectionID, segmentIDs, nil, retrievePlan)
+	assert.True(t, errors.As(err, &quarantinedErr))
+
+	// the healthy segments keep serving
+	results, searched, err := his.searchSegments(context.Background(), []UniqueID{healthySegmentID}, searchReqs, plan, defaultMsgLength)
+	assert.NoError(t, err)
+	defer deleteSearchResults(results)
+	assert.Equal(t, []UniqueID{healthySegmentID}, searched)
+
+	// reported in GetSegmentInfo for QueryCoord to load it elsewhere
+	infos, err := his.replica.getSegmentInfosByColID(defaultCollectionID)
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	for _, info := range infos {
+		assert.Equal(t, info.GetSegmentID() == defaultSegmentID, info.GetQuarantined())
+	}
+	// disabling the quarantine releases the quarantined segments
+	Params.QueryNodeCfg.SegmentQuarantineFailures = 0
+	require.NoError(t, his.replica.(*collectionReplica).config.reload(0))
+	assert.False(t, seg.isQuarantined())
+}
@@ -0,0 +1,110 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// sampleBatchRows is the number of sampled rows read from segcore at a time, the deadline of sampling is
+// checked between the batches
+const sampleBatchRows = 1024
+
+// fieldSample is a uniform sample of the values of a field of a segment
+type fieldSample struct {
+	values   *schemapb.FieldData // values of the sampled rows, nil if no row is sampled
+	rows     int                 // number of the sampled rows
+	rowCount int64               // rows of the segment the values are sampled from
+	partial  bool                // the deadline exceeded before all the rows to sample were read
+}
+
+// sampleFieldValues reads the values of fieldID of sampleSize rows chosen uniformly from the segment. The rows are
+// read in random order, once deadline exceeded the rows read so far are returned as a partial sample, which is
+// still a uniform sample of the segment. The deleted rows are sampled as well.
+func (s *Segment) sampleFieldValues(fieldID FieldID, sampleSize int, deadline time.Time) (*fieldSample, error) {
+	return s.sampleFieldValuesWithRand(fieldID, sampleSize, deadline, typeutil.NewSampleRand(0, s.segmentID))
+}
+
+func (s *Segment) sampleFieldValuesWithRand(fieldID FieldID, sampleSize int, deadline time.Time, r *rand.Rand) (*fieldSample, error) {
+	if s.isOffsetsOnlyField(fieldID) {
+		return nil, fmt.Errorf("raw data of field %d is not in memory, segmentID = %d", fieldID, s.segmentID)
+	}
+	rowCount := s.getRowCount()
+	if rowCount < 0 {
+		return nil, fmt.Errorf("%w, null seg core pointer, segmentID = %d", ErrSegmentReleased, s.segmentID)
+	}
+	sample := &fieldSample{rowCount: rowCount}
+	offsets := sampleOffsets(rowCount, sampleSize, r)
+
+	var builder *typeutil.ColumnBuilder
+	for start := 0; start < len(offsets); start += sampleBatchRows {
+		if !time.Now().Before(deadline) {
+			sample.partial = true
+			break
+		}
+		end := start + sampleBatchRows
+		if end > len(offsets) {
+			end = len(offsets)
+		}
+		column, err := s.retrieveByOffsets(fieldID, offsets[start:end])
+		if err != nil {
+			return nil, err
+		}
+		if builder == nil {
+			if builder, err = typeutil.NewColumnBuilderOf(column); err != nil {
+				return nil, err
+			}
+		}
+		if err := builder.AppendColumn(column); err != nil {
+			return nil, err
+		}
+	}
+	if builder != nil {
+		sample.rows = builder.Len()
+		sample.values = builder.Build()
+	}
+	return sample, nil
+}
+
+// sampleOffsets uniformly samples min(n, k) of the offsets [0, n) by Floyd's algorithm, which takes O(k) time
+// and space regardless of n. The sampled offsets are returned in random order, so that any prefix of them
+// is a uniform sample as well.
+func sampleOffsets(n int64, k int, r *rand.Rand) []int64 {
+	if n <= 0 || k <= 0 {
+		return []int64{}
+	}
+	if int64(k) > n {
+		k = int(n)
+	}
+	selected := make(map[int64]struct{}, k)
+	offsets := make([]int64, 0, k)
+	for j := n - int64(k); j < n; j++ {
+		t := r.Int63n(j + 1)
+		if _, ok := selected[t]; ok {
+			t = j
+		}
+		selected[t] = struct{}{}
+		offsets = append(offsets, t)
+	}
+	r.Shuffle(len(offsets), func(i, j int) { offsets[i], offsets[j] = offsets[j], offsets[i] })
+	return offsets
+}
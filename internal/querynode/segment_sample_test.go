@@ -0,0 +1,114 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleOffsets(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	assert.Empty(t, sampleOffsets(0, 10, r))
+	assert.Empty(t, sampleOffsets(10, 0, r))
+	assert.ElementsMatch(t, []int64{0, 1, 2, 3, 4}, sampleOffsets(5, 10, r))
+
+	offsets := sampleOffsets(1<<40, 1000, r)
+	assert.Len(t, offsets, 1000)
+	distinct := make(map[int64]struct{})
+	for _, offset := range offsets {
+		assert.True(t, offset >= 0 && offset < 1<<40)
+		distinct[offset] = struct{}{}
+	}
+	assert.Len(t, distinct, 1000)
+
+	// every offset is sampled with the same probability, so is the first sampled one. The chi-square statistics
+	// of 99 degrees of freedom exceed 148.2 with probability 0.001
+	const n, k, trials = 100, 10, 20000
+	counts := make([]float64, n)
+	firsts := make([]float64, n)
+	for i := 0; i < trials; i++ {
+		offsets := sampleOffsets(n, k, r)
+		for _, offset := range offsets {
+			counts[offset]++
+		}
+		firsts[offsets[0]]++
+	}
+	chiSquare := func(observed []float64, expected float64) float64 {
+		var statistics float64
+		for _, o := range observed {
+			statistics += (o - expected) * (o - expected) / expected
+		}
+		return statistics
+	}
+	assert.Less(t, chiSquare(counts, float64(trials*k/n)), 148.2)
+	assert.Less(t, chiSquare(firsts, float64(trials/n)), 148.2)
+}
+
+func TestSegment_sampleFieldValues(t *testing.T) {
+	msgLength := 5000
+	segment, err := genSealedSegmentWithMsgLength(msgLength)
+	require.NoError(t, err)
+
+	// the values of the int32 field are the offsets of the rows
+	sample, err := segment.sampleFieldValuesWithRand(simpleConstField.id, 3000, time.Now().Add(time.Minute), rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+	assert.False(t, sample.partial)
+	assert.Equal(t, int64(msgLength), sample.rowCount)
+	assert.Equal(t, 3000, sample.rows)
+	values := sample.values.GetScalars().GetIntData().GetData()
+	require.Len(t, values, 3000)
+	distinct := make(map[int32]struct{})
+	var sum float64
+	for _, v := range values {
+		assert.True(t, v >= 0 && v < int32(msgLength))
+		distinct[v] = struct{}{}
+		sum += float64(v)
+	}
+	assert.Len(t, distinct, 3000)
+	// the standard deviation of the mean of the sample is below 30
+	assert.InDelta(t, float64(msgLength-1)/2, sum/3000, 150)
+
+	// all the rows are sampled at most
+	sample, err = segment.sampleFieldValues(simpleConstField.id, 2*msgLength, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, sample.partial)
+	assert.Equal(t, msgLength, sample.rows)
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		sample, err := segment.sampleFieldValues(simpleConstField.id, 100, time.Now().Add(-time.Second))
+		require.NoError(t, err)
+		assert.True(t, sample.partial)
+		assert.Equal(t, 0, sample.rows)
+		assert.Nil(t, sample.values)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := segment.sampleFieldValues(10000, 100, time.Now().Add(time.Minute))
+		assert.Error(t, err)
+	})
+
+	t.Run("released", func(t *testing.T) {
+		deleteSegment(segment)
+		_, err := segment.sampleFieldValues(simpleConstField.id, 100, time.Now().Add(time.Minute))
+		assert.ErrorIs(t, err, ErrSegmentReleased)
+	})
+}
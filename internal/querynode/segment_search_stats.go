@@ -0,0 +1,60 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"sync"
+	"time"
+)
+
+// searchStats is the snapshot of the searches done on a segment
+type searchStats struct {
+	searches     int64
+	totalLatency time.Duration
+	maxLatency   time.Duration
+	rowsScanned  int64 // rows of the segment summed over the searches
+}
+
+// segmentSearchStats collects the latency and the rows scanned of the searches on a segment in segcore
+type segmentSearchStats struct {
+	mu    sync.Mutex
+	stats searchStats
+}
+
+// record records a search scanning rows for latency
+func (s *segmentSearchStats) record(latency time.Duration, rows int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.searches++
+	s.stats.totalLatency += latency
+	if latency > s.stats.maxLatency {
+		s.stats.maxLatency = latency
+	}
+	s.stats.rowsScanned += rows
+}
+
+func (s *segmentSearchStats) snapshot() searchStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+func (s *segmentSearchStats) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats = searchStats{}
+}
@@ -0,0 +1,57 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSegmentSearchStats(t *testing.T) {
+	var stats segmentSearchStats
+	assert.Equal(t, searchStats{}, stats.snapshot())
+
+	stats.record(2*time.Millisecond, 100)
+	stats.record(5*time.Millisecond, 100)
+	stats.record(time.Millisecond, 120)
+	assert.Equal(t, searchStats{
+		searches:     3,
+		totalLatency: 8 * time.Millisecond,
+		maxLatency:   5 * time.Millisecond,
+		rowsScanned:  320,
+	}, stats.snapshot())
+
+	stats.reset()
+	assert.Equal(t, searchStats{}, stats.snapshot())
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				stats.record(time.Microsecond, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(1000), stats.snapshot().searches)
+	assert.Equal(t, int64(1000), stats.snapshot().rowsScanned)
+}
@@ -18,18 +18,25 @@ package querynode
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"math"
 	"math/rand"
+	"sort"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/storage"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
@@ -38,6 +45,8 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/segcorepb"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
 //-------------------------------------------------------------------------------------- constructor and destructor
@@ -62,6 +71,28 @@ func TestSegment_newSegment(t *testing.T) {
 			collectionID, "", 100, true)
 		assert.Error(t, err)
 	})
+
+	t.Run("test invalid schema", func(t *testing.T) {
+		schema := genSimpleSegCoreSchema()
+		schema.Fields[0] = genFloatVectorField(vecFieldParam{
+			id:         simpleVecField.id,
+			dim:        0,
+			metricType: defaultMetricType,
+			vecType:    schemapb.DataType_FloatVector,
+		})
+		invalidCollection := newCollection(collectionID, schema)
+		defer deleteCollection(invalidCollection)
+
+		segment, err := newSegment(invalidCollection, defaultSegmentID, defaultPartitionID, collectionID, "", segmentTypeSealed, true)
+		assert.Nil(t, segment)
+		var segcoreErr *SegcoreError
+		require.True(t, errors.As(err, &segcoreErr))
+		assert.Equal(t, commonpb.ErrorCode_IllegalArgument, segcoreErr.Code())
+		assert.Equal(t, collectionID, segcoreErr.collectionID)
+		assert.Equal(t, defaultSegmentID, segcoreErr.segmentID)
+		assert.Contains(t, segcoreErr.message, "invalid dim 0")
+		assert.Equal(t, commonpb.ErrorCode_IllegalArgument, errorCodeOf(err))
+	})
 }
 
 func TestSegment_deleteSegment(t *testing.T) {
@@ -240,6 +271,202 @@ func TestSegment_retrieve(t *testing.T) {
 	assert.Equal(t, res.GetFieldsData()[0].GetScalars().Data.(*schemapb.ScalarField_IntData).IntData.Data, []int32{1, 2, 3})
 }
 
+func TestSegment_retrieveBatch(t *testing.T) {
+	segment, err := genSealedSegmentWithMsgLength(defaultMsgLength)
+	require.NoError(t, err)
+	defer deleteSegment(segment)
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	defer deleteCollection(collection)
+
+	var plans []*RetrievePlan
+	for _, r := range [][2]int64{{0, 9}, {50, 99}, {20, 20}, {200, 300}} {
+		plan, err := createRetrievePlanByExpr(collection, genRetrievePlanExprWithPredicates(t, genPKRangeExpr(r[0], r[1])), 1000)
+		require.NoError(t, err)
+		defer plan.delete()
+		plans = append(plans, plan)
+	}
+
+	t.Run("in order", func(t *testing.T) {
+		results, err := segment.retrieveBatch(plans)
+		require.NoError(t, err)
+		require.Len(t, results, len(plans))
+		for i, plan := range plans {
+			expected, err := segment.retrieve(plan)
+			require.NoError(t, err)
+			assert.Equal(t, expected.GetIds().GetIntId().GetData(), results[i].GetIds().GetIntId().GetData())
+		}
+		assert.Len(t, results[0].GetIds().GetIntId().GetData(), 10)
+		assert.Len(t, results[1].GetIds().GetIntId().GetData(), 50)
+		assert.Equal(t, []int64{20}, results[2].GetIds().GetIntId().GetData())
+		assert.Empty(t, results[3].GetIds().GetIntId().GetData())
+
+		results, err = segment.retrieveBatch(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("nil plans", func(t *testing.T) {
+		results, err := segment.retrieveBatch([]*RetrievePlan{plans[0], nil, {}})
+		assert.Error(t, err)
+		assert.Nil(t, results)
+		assert.Contains(t, err.Error(), "plan 1 is nil")
+		assert.Contains(t, err.Error(), "plan 2 is nil")
+	})
+
+	t.Run("released segment", func(t *testing.T) {
+		released, err := genSealedSegmentWithMsgLength(defaultMsgLength)
+		require.NoError(t, err)
+		deleteSegment(released)
+		results, err := released.retrieveBatch([]*RetrievePlan{plans[0], nil})
+		assert.ErrorIs(t, err, ErrSegmentReleased)
+		assert.Nil(t, results)
+		assert.Contains(t, err.Error(), "plan 1 is nil")
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		failed, err := genSealedSegmentWithMsgLength(defaultMsgLength)
+		require.NoError(t, err)
+		defer deleteSegment(failed)
+		defer failSegment(failed.ID())()
+		results, err := failed.retrieveBatch(plans)
+		assert.Error(t, err)
+		assert.Nil(t, results)
+	})
+}
+
+func TestSegment_scalarOnlyLifecycle(t *testing.T) {
+	schema := genScalarOnlySchema()
+	collection := newCollection(defaultCollectionID, schema)
+	defer deleteCollection(collection)
+	segment, err := newSegment(collection, defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeGrowing, true)
+	require.NoError(t, err)
+	defer deleteSegment(segment)
+
+	// the pks of the rows are 0, 1 and 2
+	const N = 3
+	records, err := genCommonBlob(N, schema)
+	require.NoError(t, err)
+	ids := []int64{0, 1, 2}
+	timestamps := []Timestamp{100, 100, 100}
+	offset, err := segment.segmentPreInsert(N)
+	require.NoError(t, err)
+	require.NoError(t, segment.segmentInsert(offset, &ids, &timestamps, &records))
+	assert.Equal(t, int64(N), segment.getRowCount())
+	assert.Greater(t, segment.getMemSize(), int64(0))
+
+	planExpr, err := genSimpleRetrievePlanExpr()
+	require.NoError(t, err)
+	retrieve := func(ts Timestamp) []int64 {
+		plan, err := createRetrievePlanByExpr(collection, planExpr, ts)
+		require.NoError(t, err)
+		defer plan.delete()
+		res, err := segment.retrieve(plan)
+		require.NoError(t, err)
+		return res.GetIds().GetIntId().GetData()
+	}
+	assert.ElementsMatch(t, []int64{1, 2}, retrieve(200))
+
+	deleteOffset := segment.segmentPreDelete(1)
+	require.NoError(t, segment.segmentDelete(deleteOffset, []primaryKey{newInt64PrimaryKey(1)}, []Timestamp{150}))
+	assert.ElementsMatch(t, []int64{2}, retrieve(200))
+	assert.ElementsMatch(t, []int64{1, 2}, retrieve(120))
+	assert.Equal(t, int64(1), segment.getDeletedCount())
+	assert.Equal(t, int64(N), segment.getRowCount())
+}
+
+func TestSegment_retrieveExpiredByTTL(t *testing.T) {
+	collectionID := UniqueID(0)
+	collectionMeta := genTestCollectionMeta(collectionID, false)
+	collection := newCollection(collectionMeta.ID, collectionMeta.Schema)
+	segment, err := newSegment(collection, UniqueID(0), defaultPartitionID, collectionID, "", segmentTypeGrowing, true)
+	assert.NoError(t, err)
+
+	// the first half of rows are inserted two hours ago, the rest are inserted now
+	const DIM = 16
+	const N = 100
+	now := time.Now()
+	ids := make([]int64, 0, N)
+	timestamps := make([]Timestamp, 0, N)
+	records := make([]*commonpb.Blob, 0, N)
+	for i := 0; i < N; i++ {
+		ids = append(ids, int64(i))
+		if i < N/2 {
+			timestamps = append(timestamps, tsoutil.ComposeTSByTime(now.Add(-2*time.Hour), 0))
+		} else {
+			timestamps = append(timestamps, tsoutil.ComposeTSByTime(now, 0))
+		}
+		var rawData []byte
+		for j := 0; j < DIM; j++ {
+			buf := make([]byte, 4)
+			common.Endian.PutUint32(buf, math.Float32bits(float32(i*DIM+j)))
+			rawData = append(rawData, buf...)
+		}
+		bs := make([]byte, 4)
+		common.Endian.PutUint32(bs, uint32(i+1))
+		rawData = append(rawData, bs...)
+		records = append(records, &commonpb.Blob{Value: rawData})
+	}
+	offset, err := segment.segmentPreInsert(N)
+	assert.NoError(t, err)
+	err = segment.segmentInsert(offset, &ids, &timestamps, &records)
+	assert.NoError(t, err)
+
+	planNode := &planpb.PlanNode{
+		Node: &planpb.PlanNode_Predicates{
+			Predicates: &planpb.Expr{
+				Expr: &planpb.Expr_TermExpr{
+					TermExpr: &planpb.TermExpr{
+						ColumnInfo: &planpb.ColumnInfo{
+							FieldId:  101,
+							DataType: schemapb.DataType_Int32,
+						},
+						Values: []*planpb.GenericValue{
+							{Val: &planpb.GenericValue_Int64Val{Int64Val: 1}},
+							{Val: &planpb.GenericValue_Int64Val{Int64Val: N/2 + 1}},
+							{Val: &planpb.GenericValue_Int64Val{Int64Val: N}},
+						},
+					},
+				},
+			},
+		},
+		OutputFieldIds: []FieldID{101},
+	}
+	planExpr, err := proto.Marshal(planNode)
+	assert.NoError(t, err)
+	retrieveAt := func(readTime time.Time) []int32 {
+		plan, err := createRetrievePlanByExpr(collection, planExpr, tsoutil.ComposeTSByTime(readTime, 0))
+		assert.NoError(t, err)
+		defer plan.delete()
+		res, err := segment.retrieve(plan)
+		assert.NoError(t, err)
+		return res.GetFieldsData()[0].GetScalars().GetIntData().GetData()
+	}
+	retrieve := func() []int32 {
+		return retrieveAt(now.Add(time.Minute))
+	}
+
+	t.Run("no ttl", func(t *testing.T) {
+		collection.setTTL(0)
+		assert.Equal(t, []int32{1, N/2 + 1, N}, retrieve())
+	})
+
+	t.Run("ttl expires old rows", func(t *testing.T) {
+		collection.setTTL(int64(time.Hour / time.Second))
+		assert.Equal(t, []int32{N/2 + 1, N}, retrieve())
+	})
+
+	t.Run("ttl longer than rows' age", func(t *testing.T) {
+		collection.setTTL(int64(3 * time.Hour / time.Second))
+		assert.Equal(t, []int32{1, N/2 + 1, N}, retrieve())
+	})
+
+	t.Run("ttl counts from the read ts", func(t *testing.T) {
+		collection.setTTL(int64(time.Hour / time.Second))
+		// the old rows are half an hour old at the read ts, the new rows are not inserted yet
+		assert.Equal(t, []int32{1}, retrieveAt(now.Add(-90*time.Minute)))
+	})
+}
+
 func TestSegment_getDeletedCount(t *testing.T) {
 	collectionID := UniqueID(0)
 	collectionMeta := genTestCollectionMeta(collectionID, false)
@@ -398,6 +625,46 @@ func TestSegment_segmentInsert(t *testing.T) {
 
 	err = segment.segmentInsert(offset, &ids, &timestamps, &records)
 	assert.NoError(t, err)
+
+	t.Run("test poison released buffers", func(t *testing.T) {
+		bufferPool.SetPoison(true)
+		defer bufferPool.SetPoison(false)
+		rowCount := segment.getRowCount()
+		for i := 0; i < 2; i++ {
+			offset, err := segment.segmentPreInsert(N)
+			require.NoError(t, err)
+			assert.NoError(t, segment.segmentInsert(offset, &ids, &timestamps, &records))
+		}
+		assert.Equal(t, rowCount+2*N, segment.getRowCount())
+	})
+
+	t.Run("test mismatched row size", func(t *testing.T) {
+		assert.Equal(t, int64(DIM*4+4), segment.rowSize)
+		assert.NoError(t, segment.checkInsertRecords(records))
+		rowCount := segment.getRowCount()
+
+		truncated := []*commonpb.Blob{{Value: rawData[4:]}, {Value: rawData[4:]}, {Value: rawData[4:]}}
+		var sizeErr *insertPayloadSizeError
+		require.True(t, errors.As(segment.checkInsertRecords(truncated), &sizeErr))
+		assert.Equal(t, int64(DIM*4+4), sizeErr.expected)
+		assert.Equal(t, int64(DIM*4), sizeErr.actual)
+
+		// the rows are checked before the reservation is consumed
+		err = segment.segmentInsert(offset+N, &ids, &timestamps, &truncated)
+		require.True(t, errors.As(err, &sizeErr))
+		assert.Equal(t, rowCount, segment.getRowCount())
+
+		mixed := []*commonpb.Blob{{Value: rawData}, {Value: rawData[4:]}, {Value: rawData}}
+		require.True(t, errors.As(segment.checkInsertRecords(mixed), &sizeErr))
+		assert.Equal(t, int64(DIM*4), sizeErr.actual)
+
+		// the size of the first row is expected if the schema size is unknown
+		segment.rowSize = 0
+		defer func() { segment.rowSize = DIM*4 + 4 }()
+		assert.NoError(t, segment.checkInsertRecords(truncated))
+		require.True(t, errors.As(segment.checkInsertRecords(mixed), &sizeErr))
+		assert.Equal(t, int64(DIM*4+4), sizeErr.expected)
+	})
 	deleteSegment(segment)
 	deleteCollection(collection)
 
@@ -418,6 +685,280 @@ func TestSegment_segmentInsert(t *testing.T) {
 	})
 }
 
+func TestSegment_rowBudget(t *testing.T) {
+	collectionID := UniqueID(0)
+	collectionMeta := genTestCollectionMeta(collectionID, false)
+	collection := newCollection(collectionMeta.ID, collectionMeta.Schema)
+	defer deleteCollection(collection)
+
+	const DIM = 16
+	const N = 100
+	var vec = [DIM]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	var rawData []byte
+	for _, ele := range vec {
+		buf := make([]byte, 4)
+		common.Endian.PutUint32(buf, math.Float32bits(ele))
+		rawData = append(rawData, buf...)
+	}
+	bs := make([]byte, 4)
+	common.Endian.PutUint32(bs, 1)
+	rawData = append(rawData, bs...)
+	ids := make([]int64, 0, N)
+	timestamps := make([]uint64, 0, N)
+	records := make([]*commonpb.Blob, 0, N)
+	for i := 0; i < N; i++ {
+		ids = append(ids, int64(i))
+		timestamps = append(timestamps, 0)
+		records = append(records, &commonpb.Blob{Value: rawData})
+	}
+
+	t.Run("no hint", func(t *testing.T) {
+		segment, err := newSegment(collection, UniqueID(1), defaultPartitionID, collectionID, "", segmentTypeGrowing, true)
+		assert.NoError(t, err)
+		defer deleteSegment(segment)
+		assert.Equal(t, int64(0), segment.getRowBudget())
+		assert.Equal(t, int64(0), segment.getAllocatedChunkNum())
+
+		offset, err := segment.segmentPreInsert(N)
+		assert.NoError(t, err)
+		err = segment.segmentInsert(offset, &ids, &timestamps, &records)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(N), segment.getRowCount())
+		assert.Equal(t, int64(1), segment.getAllocatedChunkNum())
+	})
+
+	t.Run("pre-allocate with hint", func(t *testing.T) {
+		rowBudget := int64(100000)
+		collection.setSegmentRowBudget(rowBudget)
+		defer collection.setSegmentRowBudget(0)
+
+		segment, err := newSegment(collection, UniqueID(2), defaultPartitionID, collectionID, "", segmentTypeGrowing, true)
+		assert.NoError(t, err)
+		defer deleteSegment(segment)
+		assert.Equal(t, rowBudget, segment.getRowBudget())
+		chunkNum := segment.getAllocatedChunkNum()
+		assert.Greater(t, chunkNum, int64(1))
+
+		offset, err := segment.segmentPreInsert(N)
+		assert.NoError(t, err)
+		err = segment.segmentInsert(offset, &ids, &timestamps, &records)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(N), segment.getRowCount())
+		assert.Equal(t, chunkNum, segment.getAllocatedChunkNum())
+	})
+
+	t.Run("sealed segment ignores hint", func(t *testing.T) {
+		collection.setSegmentRowBudget(100000)
+		defer collection.setSegmentRowBudget(0)
+
+		segment, err := newSegment(collection, UniqueID(3), defaultPartitionID, collectionID, "", segmentTypeSealed, true)
+		assert.NoError(t, err)
+		defer deleteSegment(segment)
+		assert.Equal(t, int64(0), segment.getRowBudget())
+		assert.Equal(t, int64(0), segment.getAllocatedChunkNum())
+	})
+}
+
+func TestSegment_growingChunkRows(t *testing.T) {
+	t.Run("validate", func(t *testing.T) {
+		assert.NoError(t, validateGrowingChunkRows(0))
+		assert.NoError(t, validateGrowingChunkRows(minGrowingChunkRows))
+		assert.NoError(t, validateGrowingChunkRows(maxGrowingChunkRows))
+		assert.Error(t, validateGrowingChunkRows(minGrowingChunkRows-1))
+		assert.Error(t, validateGrowingChunkRows(maxGrowingChunkRows+1))
+		assert.Error(t, validateGrowingChunkRows(-1))
+	})
+
+	replica, err := genSimpleReplica()
+	require.NoError(t, err)
+	collection, err := replica.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+	assert.Equal(t, Params.QueryNodeCfg.ChunkRows, collection.getGrowingChunkRows())
+
+	plan, searchReqs, err := genSimpleSearchPlanAndRequests(IndexFaissIDMap)
+	require.NoError(t, err)
+	defer plan.delete()
+
+	const N = 1000
+	records, err := genCommonBlob(N, genSimpleSegCoreSchema())
+	require.NoError(t, err)
+	ids := make([]int64, 0, N)
+	timestamps := make([]Timestamp, 0, N)
+	for i := 0; i < N; i++ {
+		ids = append(ids, int64(i))
+		timestamps = append(timestamps, 0)
+	}
+
+	for i, chunkRows := range []int64{0, minGrowingChunkRows, 4096} {
+		collection.setGrowingChunkRows(chunkRows)
+		segmentID := UniqueID(100 + i)
+		require.NoError(t, replica.addSegment(segmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeGrowing, true))
+		segment, err := replica.getSegmentByID(segmentID)
+		require.NoError(t, err)
+
+		expected := chunkRows
+		if chunkRows == 0 {
+			expected = Params.QueryNodeCfg.ChunkRows
+		}
+		assert.Equal(t, expected, segment.getChunkRows())
+
+		offset, err := segment.segmentPreInsert(N)
+		require.NoError(t, err)
+		require.NoError(t, segment.segmentInsert(offset, &ids, &timestamps, &records))
+		assert.Equal(t, int64(N), segment.getRowCount())
+
+		searchResult, err := segment.search(context.Background(), plan, searchReqs, []Timestamp{typeutil.MaxTimestamp})
+		assert.NoError(t, err)
+		deleteSearchResults([]*SearchResult{searchResult})
+	}
+	collection.setGrowingChunkRows(0)
+	require.NoError(t, replica.addSegment(defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeSealed, true))
+
+	chunkRows := make(map[UniqueID]int64)
+	for _, stat := range replica.getSegmentStatistics() {
+		chunkRows[stat.GetSegmentID()] = stat.GetChunkRows()
+	}
+	assert.Equal(t, Params.QueryNodeCfg.ChunkRows, chunkRows[100])
+	assert.Equal(t, int64(minGrowingChunkRows), chunkRows[101])
+	assert.Equal(t, int64(4096), chunkRows[102])
+	// sealed segment
+	assert.Equal(t, int64(0), chunkRows[defaultSegmentID])
+}
+
+func TestSegment_compactGrowing(t *testing.T) {
+	replica, err := genSimpleReplica()
+	require.NoError(t, err)
+	collection, err := replica.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+	collection.setGrowingChunkRows(minGrowingChunkRows)
+	defer collection.setGrowingChunkRows(0)
+	require.NoError(t, replica.addSegment(defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeGrowing, true))
+	segment, err := replica.getSegmentByID(defaultSegmentID)
+	require.NoError(t, err)
+
+	insertRows := func(n int, ts Timestamp) {
+		records, err := genCommonBlob(n, genSimpleSegCoreSchema())
+		require.NoError(t, err)
+		ids := make([]int64, 0, n)
+		timestamps := make([]Timestamp, 0, n)
+		for i := 0; i < n; i++ {
+			ids = append(ids, int64(i))
+			timestamps = append(timestamps, ts)
+		}
+		offset, err := segment.segmentPreInsert(n)
+		require.NoError(t, err)
+		require.NoError(t, segment.segmentInsert(offset, &ids, &timestamps, &records))
+	}
+	deleteRows := func(from, to int64, ts Timestamp) {
+		pks := make([]primaryKey, 0, to-from)
+		timestamps := make([]Timestamp, 0, to-from)
+		for pk := from; pk < to; pk++ {
+			pks = append(pks, newInt64PrimaryKey(pk))
+			timestamps = append(timestamps, ts)
+		}
+		offset := segment.segmentPreDelete(len(pks))
+		require.NoError(t, segment.segmentDelete(offset, pks, timestamps))
+	}
+
+	const N = 1000
+	values := make([]*planpb.GenericValue, 0, N)
+	for i := 0; i < N; i++ {
+		values = append(values, &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: int64(i)}})
+	}
+	planExpr, err := proto.Marshal(&planpb.PlanNode{
+		Node: &planpb.PlanNode_Predicates{
+			Predicates: &planpb.Expr{
+				Expr: &planpb.Expr_TermExpr{
+					TermExpr: &planpb.TermExpr{
+						ColumnInfo: &planpb.ColumnInfo{
+							FieldId:  simplePKField.id,
+							DataType: simplePKField.dataType,
+						},
+						Values: values,
+					},
+				},
+			},
+		},
+		OutputFieldIds: []FieldID{simplePKField.id, simpleConstField.id},
+	})
+	require.NoError(t, err)
+	retrieveAt := func(ts Timestamp) *segcorepb.RetrieveResults {
+		plan, err := createRetrievePlanByExpr(collection, planExpr, ts)
+		require.NoError(t, err)
+		defer plan.delete()
+		res, err := segment.retrieve(plan)
+		require.NoError(t, err)
+		// offsets change with the compaction, only compare the rows
+		return &segcorepb.RetrieveResults{Ids: res.GetIds(), FieldsData: res.GetFieldsData()}
+	}
+
+	// the first 900 rows are deleted before the horizon, the last one after it
+	insertRows(N, 1)
+	deleteRows(0, 900, 10)
+	deleteRows(N-1, N, 30)
+	const horizon = Timestamp(20)
+	assert.Equal(t, int64(901), segment.deletedRows.Load())
+	assert.True(t, segment.shouldCompact(0.9, N))
+	assert.False(t, segment.shouldCompact(0.95, N))
+	assert.False(t, segment.shouldCompact(0.9, N+1))
+
+	memSize := segment.getMemSize()
+	latest := retrieveAt(typeutil.MaxTimestamp)
+	beforeTailDelete := retrieveAt(25)
+	assert.Len(t, latest.GetIds().GetIntId().GetData(), 99)
+	assert.Len(t, beforeTailDelete.GetIds().GetIntId().GetData(), 100)
+
+	deadRows, err := segment.compactGrowing(horizon)
+	require.NoError(t, err)
+	assert.Equal(t, int64(900), deadRows)
+	assert.Equal(t, int64(100), segment.getRowCount())
+	assert.Less(t, segment.getMemSize(), memSize)
+	assert.Equal(t, int64(0), segment.deletedRows.Load())
+	assert.True(t, proto.Equal(latest, retrieveAt(typeutil.MaxTimestamp)))
+	// the delete after the horizon is migrated
+	assert.True(t, proto.Equal(beforeTailDelete, retrieveAt(25)))
+
+	// inserts and deletes go on with the compacted segment
+	insertRows(1, 40)
+	assert.Equal(t, int64(101), segment.getRowCount())
+	assert.Len(t, retrieveAt(typeutil.MaxTimestamp).GetIds().GetIntId().GetData(), 100)
+	deleteRows(900, 950, 50)
+	assert.Len(t, retrieveAt(typeutil.MaxTimestamp).GetIds().GetIntId().GetData(), 50)
+
+	t.Run("failed inserts", func(t *testing.T) {
+		rowCount := segment.getRowCount()
+		records, err := genCommonBlob(2, genSimpleSegCoreSchema())
+		require.NoError(t, err)
+		ids, timestamps := []int64{2000, 2001}, []Timestamp{60, 60}
+		// the rows of records mismatch the ids, or the payload mismatches the schema
+		for _, invalid := range [][]*commonpb.Blob{records[:1], {records[0], {Value: records[1].Value[1:]}}} {
+			offset, err := segment.segmentPreInsert(len(ids))
+			require.NoError(t, err)
+			assert.Error(t, segment.segmentInsert(offset, &ids, &timestamps, &invalid))
+			assert.Empty(t, segment.pendingInserts)
+		}
+
+		// the ranges abandoned don't block the compaction, after which the inserts are visible again
+		_, err = segment.compactGrowing(typeutil.MaxTimestamp)
+		require.NoError(t, err)
+		insertRows(1, 70)
+		assert.Equal(t, rowCount+1, segment.getRowCount())
+	})
+
+	t.Run("pending inserts", func(t *testing.T) {
+		_, err := segment.segmentPreInsert(1)
+		require.NoError(t, err)
+		_, err = segment.compactGrowing(typeutil.MaxTimestamp)
+		assert.Error(t, err)
+	})
+
+	t.Run("released segment", func(t *testing.T) {
+		deleteSegment(segment)
+		_, err := segment.compactGrowing(typeutil.MaxTimestamp)
+		assert.ErrorIs(t, err, ErrSegmentReleased)
+	})
+}
+
 func TestSegment_segmentDelete(t *testing.T) {
 	collectionID := UniqueID(0)
 	collectionMeta := genTestCollectionMeta(collectionID, false)
@@ -524,16 +1065,26 @@ func TestSegment_segmentSearch(t *testing.T) {
 	placeholderGroups := make([]*searchRequest, 0)
 	placeholderGroups = append(placeholderGroups, holder)
 
-	searchResult, err := segment.search(plan, placeholderGroups, []Timestamp{0})
+	searchResult, err := segment.search(context.Background(), plan, placeholderGroups, []Timestamp{0})
 	assert.NoError(t, err)
 
 	err = checkSearchResult(nq, plan, searchResult)
 	assert.NoError(t, err)
 
+	stats := segment.getSearchStats()
+	assert.Equal(t, int64(1), stats.searches)
+	assert.Equal(t, segment.getRowCount(), stats.rowsScanned)
+	assert.Equal(t, stats.totalLatency, stats.maxLatency)
+
 	plan.delete()
 	holder.delete()
 	deleteSegment(segment)
 	deleteCollection(collection)
+
+	// the stats are reset on release, and the searches on the released segment are not recorded
+	assert.Equal(t, searchStats{}, segment.getSearchStats())
+	segment.recordSearch(time.Millisecond)
+	assert.Equal(t, searchStats{}, segment.getSearchStats())
 }
 
 //-------------------------------------------------------------------------------------- preDm functions
@@ -577,6 +1128,119 @@ func TestSegment_segmentPreInsert(t *testing.T) {
 	deleteCollection(collection)
 }
 
+func TestSegment_concurrentInsert(t *testing.T) {
+	collectionID := UniqueID(0)
+	collectionMeta := genTestCollectionMeta(collectionID, false)
+	collection := newCollection(collectionMeta.ID, collectionMeta.Schema)
+	defer deleteCollection(collection)
+	segment, err := newSegment(collection, UniqueID(0), defaultPartitionID, collectionID, "", segmentTypeGrowing, true)
+	require.NoError(t, err)
+	defer deleteSegment(segment)
+
+	const DIM = 16
+	const N = 100
+	const goroutines = 16
+	var vec = [DIM]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	var rawData []byte
+	for _, ele := range vec {
+		buf := make([]byte, 4)
+		common.Endian.PutUint32(buf, math.Float32bits(ele))
+		rawData = append(rawData, buf...)
+	}
+	bs := make([]byte, 4)
+	common.Endian.PutUint32(bs, 1)
+	rawData = append(rawData, bs...)
+
+	offsets := make([]int64, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids := make([]int64, N)
+			timestamps := make([]uint64, N)
+			records := make([]*commonpb.Blob, N)
+			for j := 0; j < N; j++ {
+				ids[j] = int64(i*N + j)
+				timestamps[j] = uint64(i*N + j + 1)
+				records[j] = &commonpb.Blob{Value: rawData}
+			}
+			offset, err := segment.segmentPreInsert(N)
+			assert.NoError(t, err)
+			offsets[i] = offset
+			assert.NoError(t, segment.segmentInsert(offset, &ids, &timestamps, &records))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(goroutines*N), segment.getRowCount())
+	// the reserved ranges cover the rows without overlap
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	for i, offset := range offsets {
+		assert.Equal(t, int64(i*N), offset)
+	}
+	assert.Empty(t, segment.pendingInserts)
+}
+
+func TestSegment_insertOffsetInvariant(t *testing.T) {
+	collectionID := UniqueID(0)
+	collectionMeta := genTestCollectionMeta(collectionID, false)
+	collection := newCollection(collectionMeta.ID, collectionMeta.Schema)
+	defer deleteCollection(collection)
+	segment, err := newSegment(collection, UniqueID(0), defaultPartitionID, collectionID, "", segmentTypeGrowing, true)
+	require.NoError(t, err)
+	defer deleteSegment(segment)
+
+	const DIM = 16
+	const N = 3
+	var vec = [DIM]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	var rawData []byte
+	for _, ele := range vec {
+		buf := make([]byte, 4)
+		common.Endian.PutUint32(buf, math.Float32bits(ele))
+		rawData = append(rawData, buf...)
+	}
+	bs := make([]byte, 4)
+	common.Endian.PutUint32(bs, 1)
+	rawData = append(rawData, bs...)
+	ids := []int64{1, 2, 3}
+	timestamps := []uint64{1, 1, 1}
+	records := []*commonpb.Blob{{Value: rawData}, {Value: rawData}, {Value: rawData}}
+
+	var offsetErr *insertOffsetError
+	offset, err := segment.segmentPreInsert(N)
+	require.NoError(t, err)
+
+	t.Run("unreserved range", func(t *testing.T) {
+		err := segment.segmentInsert(offset+N, &ids, &timestamps, &records)
+		assert.True(t, errors.As(err, &offsetErr))
+		partialIDs, partialTimestamps, partialRecords := ids[:N-1], timestamps[:N-1], records[:N-1]
+		err = segment.segmentInsert(offset, &partialIDs, &partialTimestamps, &partialRecords)
+		assert.True(t, errors.As(err, &offsetErr))
+		assert.Zero(t, segment.getRowCount())
+	})
+
+	t.Run("overlap", func(t *testing.T) {
+		require.NoError(t, segment.segmentInsert(offset, &ids, &timestamps, &records))
+		err := segment.segmentInsert(offset, &ids, &timestamps, &records)
+		assert.True(t, errors.As(err, &offsetErr))
+		assert.Equal(t, int64(N), segment.getRowCount())
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		_, err := segment.segmentPreInsert(-1)
+		assert.True(t, errors.As(err, &offsetErr))
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		segment.reserveMu.Lock()
+		segment.reservedRows = math.MaxInt64 - 1
+		segment.reserveMu.Unlock()
+		_, err := segment.segmentPreInsert(N)
+		assert.True(t, errors.As(err, &offsetErr))
+	})
+}
+
 func TestSegment_segmentPreDelete(t *testing.T) {
 	collectionID := UniqueID(0)
 	collectionMeta := genTestCollectionMeta(collectionID, false)
@@ -947,6 +1611,31 @@ func TestSegment_BasicMetrics(t *testing.T) {
 		assert.Equal(t, false, resOnService)
 	})
 
+	t.Run("test serving version", func(t *testing.T) {
+		assert.True(t, segment.setServing(true, 10))
+		assert.True(t, segment.getOnService())
+		// a delayed action of an earlier version is ignored
+		assert.False(t, segment.setServing(false, 9))
+		assert.True(t, segment.getOnService())
+		assert.True(t, segment.setServing(false, 10))
+		assert.False(t, segment.getOnService())
+		assert.True(t, segment.setServing(true, 11))
+		assert.True(t, segment.getOnService())
+	})
+
+	t.Run("test standby", func(t *testing.T) {
+		assert.False(t, segment.promote(12))
+		segment.setOnService(false)
+		segment.setStandby(true)
+		assert.True(t, segment.isStandby())
+		assert.True(t, segment.promote(12))
+		assert.True(t, segment.getOnService())
+		assert.False(t, segment.isStandby())
+		// serving actions older than the promotion are ignored
+		assert.False(t, segment.setServing(false, 11))
+		assert.True(t, segment.getOnService())
+	})
+
 	t.Run("test IndexedFieldInfo", func(t *testing.T) {
 		fieldID := rowIDFieldID
 		info := &IndexedFieldInfo{
@@ -958,7 +1647,9 @@ func TestSegment_BasicMetrics(t *testing.T) {
 		segment.setIndexedFieldInfo(fieldID, info)
 		resInfo, err := segment.getIndexedFieldInfo(fieldID)
 		assert.NoError(t, err)
-		assert.Equal(t, info, resInfo)
+		assert.NotSame(t, info, resInfo)
+		assert.True(t, proto.Equal(info.fieldBinlog, resInfo.fieldBinlog))
+		assert.Equal(t, info.rawDataLoaded, resInfo.rawDataLoaded)
 
 		_, err = segment.getIndexedFieldInfo(FieldID(1000))
 		assert.Error(t, err)
@@ -1016,9 +1707,136 @@ func TestSegment_fillIndexedFieldsData(t *testing.T) {
 			Offset:     []int64{0},
 			FieldsData: fieldData,
 		}
-		err = segment.fillIndexedFieldsData(defaultCollectionID, vecCM, result)
+		err = segment.fillIndexedFieldsData(defaultCollectionID, vecCM, result, newBinlogTracker(0))
 		assert.Error(t, err)
 	})
+
+	t.Run("test fillIndexedFieldsData exceeds binlog limit", func(t *testing.T) {
+		const binlogNum = 200
+		fieldID := FieldID(100)
+		binlogs := make([]*datapb.Binlog, 0, binlogNum)
+		rowSizes := make([]int64, 0, binlogNum)
+		offsets := make([]int64, 0, binlogNum)
+		for i := 0; i < binlogNum; i++ {
+			binlogs = append(binlogs, &datapb.Binlog{LogPath: fmt.Sprintf("/binlog/%d", i)})
+			rowSizes = append(rowSizes, 1)
+			offsets = append(offsets, int64(i))
+		}
+		segment.setIDBinlogRowSizes(rowSizes)
+		segment.setIndexedFieldInfo(fieldID, &IndexedFieldInfo{
+			fieldBinlog: &datapb.FieldBinlog{
+				FieldID: fieldID,
+				Binlogs: binlogs,
+			},
+			indexInfo: &querypb.FieldIndexInfo{EnableIndex: true},
+		})
+		result := &segcorepb.RetrieveResults{
+			Ids:    &schemapb.IDs{},
+			Offset: offsets,
+			FieldsData: []*schemapb.FieldData{
+				{
+					Type:    schemapb.DataType_FloatVector,
+					FieldId: fieldID,
+					Field: &schemapb.FieldData_Vectors{
+						Vectors: &schemapb.VectorField{
+							Dim: defaultDim,
+							Data: &schemapb.VectorField_FloatVector{
+								FloatVector: &schemapb.FloatArray{Data: make([]float32, binlogNum*defaultDim)},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		tracker := newBinlogTracker(binlogNum / 2)
+		err = segment.fillIndexedFieldsData(defaultCollectionID, vecCM, result, tracker)
+		var limitErr *binlogLimitExceededError
+		assert.True(t, errors.As(err, &limitErr))
+		assert.Equal(t, binlogNum/2, limitErr.limit)
+		assert.Equal(t, binlogNum/2, tracker.count())
+	})
+}
+
+func TestSegment_retrieveOffsetsOnlyFields(t *testing.T) {
+	segment, err := genSimpleSealedSegment()
+	assert.NoError(t, err)
+	defer deleteSegment(segment)
+
+	expr, err := genSimpleRetrievePlanExpr()
+	assert.NoError(t, err)
+	planNode := &planpb.PlanNode{}
+	assert.NoError(t, proto.Unmarshal(expr, planNode))
+	planNode.OutputFieldIds = []FieldID{simplePKField.id, simpleVecField.id}
+	expr, err = proto.Marshal(planNode)
+	assert.NoError(t, err)
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	plan, err := createRetrievePlanByExpr(collection, expr, Timestamp(1000))
+	assert.NoError(t, err)
+	defer plan.delete()
+
+	// the index is loaded and the raw data of the vector field is in binlogs only
+	segment.setIDBinlogRowSizes([]int64{defaultMsgLength})
+	segment.setIndexedFieldInfo(simpleVecField.id, &IndexedFieldInfo{
+		fieldBinlog: &datapb.FieldBinlog{
+			FieldID: simpleVecField.id,
+			Binlogs: []*datapb.Binlog{{LogPath: "/binlog/0"}},
+		},
+		indexInfo: &querypb.FieldIndexInfo{FieldID: simpleVecField.id, EnableIndex: true},
+	})
+	assert.Equal(t, []FieldID{simpleVecField.id}, segment.getOffsetsOnlyFieldIDs())
+
+	// every element of a row read from binlog is the row offset
+	vcm := newMockChunkManager(withReadAt(func(path string, offset int64, length int64) ([]byte, error) {
+		content := make([]byte, length)
+		for i := int64(0); i < length; i += 4 {
+			common.Endian.PutUint32(content[i:], math.Float32bits(float32(offset/length)))
+		}
+		return content, nil
+	}))
+
+	materialized := metrics.QueryNodeRetrieveMaterializedBytes.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID))
+	before := testutil.ToFloat64(materialized)
+	expected, err := segment.retrieveWithOffsetsOnlyFields(plan, nil)
+	assert.NoError(t, err)
+	fullBytes := testutil.ToFloat64(materialized) - before
+	assert.NoError(t, segment.fillIndexedFieldsData(defaultCollectionID, vcm, expected, newBinlogTracker(0)))
+
+	before = testutil.ToFloat64(materialized)
+	result, err := segment.retrieve(plan)
+	assert.NoError(t, err)
+	offsetsOnlyBytes := testutil.ToFloat64(materialized) - before
+	assert.Empty(t, result.GetFieldsData()[1].GetVectors().GetFloatVector().GetData())
+	assert.NoError(t, segment.fillIndexedFieldsData(defaultCollectionID, vcm, result, newBinlogTracker(0)))
+
+	assert.Equal(t, 3, len(result.GetOffset()))
+	assert.True(t, proto.Equal(expected, result))
+	vectors := result.GetFieldsData()[1].GetVectors().GetFloatVector().GetData()
+	assert.Equal(t, 3*defaultDim, len(vectors))
+	for i, offset := range result.GetOffset() {
+		assert.Equal(t, float32(offset), vectors[i*defaultDim])
+	}
+	// the vector field isn't materialized by segcore
+	assert.Less(t, offsetsOnlyBytes, fullBytes)
+	assert.GreaterOrEqual(t, fullBytes-offsetsOnlyBytes, float64(3*defaultDim*4))
+
+	// the raw data is kept in memory besides the index, retrieve it from segcore directly
+	segment.setIndexedFieldInfo(simpleVecField.id, &IndexedFieldInfo{
+		indexInfo:     &querypb.FieldIndexInfo{FieldID: simpleVecField.id, EnableIndex: true},
+		rawDataLoaded: true,
+	})
+	assert.Empty(t, segment.getOffsetsOnlyFieldIDs())
+	result, err = segment.retrieve(plan)
+	assert.NoError(t, err)
+	assert.Equal(t, 3*defaultDim, len(result.GetFieldsData()[1].GetVectors().GetFloatVector().GetData()))
+	assert.NoError(t, segment.fillIndexedFieldsData(defaultCollectionID, newMockChunkManager(withReadAtErr()), result, newBinlogTracker(0)))
+}
+
+// newTestColumnBuilder returns the builder of the field of column
+func newTestColumnBuilder(t *testing.T, column *schemapb.FieldData) *typeutil.ColumnBuilder {
+	builder, err := typeutil.NewColumnBuilderOf(column)
+	require.NoError(t, err)
+	return builder
 }
 
 func Test_getFieldDataPath(t *testing.T) {
@@ -1035,17 +1853,111 @@ func Test_getFieldDataPath(t *testing.T) {
 			},
 		},
 	}
-	s := &Segment{
-		idBinlogRowSizes: []int64{10, 15},
-	}
+	s := &Segment{}
+	s.setIDBinlogRowSizes([]int64{10, 15})
 
-	path, offsetInBinlog := s.getFieldDataPath(indexedFieldInfo, 4)
+	path, offsetInBinlog, err := s.getFieldDataPath(indexedFieldInfo, 4)
+	assert.NoError(t, err)
 	assert.Equal(t, indexedFieldInfo.fieldBinlog.Binlogs[0].LogPath, path)
 	assert.Equal(t, int64(4), offsetInBinlog)
 
-	path, offsetInBinlog = s.getFieldDataPath(indexedFieldInfo, 11)
+	path, offsetInBinlog, err = s.getFieldDataPath(indexedFieldInfo, 11)
+	assert.NoError(t, err)
 	assert.Equal(t, indexedFieldInfo.fieldBinlog.Binlogs[1].LogPath, path)
 	assert.Equal(t, int64(1), offsetInBinlog)
+
+	path, offsetInBinlog, err = s.getFieldDataPath(indexedFieldInfo, 24)
+	assert.NoError(t, err)
+	assert.Equal(t, indexedFieldInfo.fieldBinlog.Binlogs[1].LogPath, path)
+	assert.Equal(t, int64(14), offsetInBinlog)
+
+	for _, offset := range []int64{-1, 25} {
+		_, _, err = s.getFieldDataPath(indexedFieldInfo, offset)
+		assert.Error(t, err)
+	}
+
+	// the binlogs of the field are split differently from the rows
+	s.setIDBinlogRowSizes([]int64{25})
+	_, _, err = s.getFieldDataPath(indexedFieldInfo, 4)
+	assert.Error(t, err)
+}
+
+func TestSegment_fillIndexedFieldsDataVarCharPK(t *testing.T) {
+	collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+	defer deleteCollection(collection)
+	collection.updateSchema(&schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: simplePKField.id, Name: "pk", DataType: schemapb.DataType_VarChar, IsPrimaryKey: true},
+			{FieldID: simpleVecField.id, Name: "vec", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: fmt.Sprint(defaultDim)}}},
+		},
+	})
+	segment, err := newSegment(collection, defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultDMLChannel, segmentTypeSealed, true)
+	require.NoError(t, err)
+	defer deleteSegment(segment)
+	assert.Equal(t, schemapb.DataType_VarChar, segment.pkType)
+
+	// the binlogs of varchar pks hold different numbers of rows
+	rowSizes := []int64{3, 5, 2}
+	segment.setIDBinlogRowSizes(rowSizes)
+	assert.Equal(t, rowSizes, segment.getIDBinlogRowSizes())
+	fieldBinlog := &datapb.FieldBinlog{FieldID: simpleVecField.id}
+	// written[path] are the vectors written to the binlog path, every element of a row is the segment offset of the row
+	written := make(map[string][]float32)
+	var rows int64
+	for i, size := range rowSizes {
+		path := fmt.Sprintf("/binlog/%d", i)
+		fieldBinlog.Binlogs = append(fieldBinlog.Binlogs, &datapb.Binlog{LogPath: path, EntriesNum: size})
+		for j := int64(0); j < size; j++ {
+			for k := 0; k < defaultDim; k++ {
+				written[path] = append(written[path], float32(rows))
+			}
+			rows++
+		}
+	}
+	segment.setIndexedFieldInfo(simpleVecField.id, &IndexedFieldInfo{
+		fieldBinlog: fieldBinlog,
+		indexInfo:   &querypb.FieldIndexInfo{FieldID: simpleVecField.id, EnableIndex: true},
+	})
+	vcm := newMockChunkManager(withReadAt(func(path string, offset int64, length int64) ([]byte, error) {
+		vectors, ok := written[path]
+		if !ok || (offset+length)/4 > int64(len(vectors)) {
+			return nil, fmt.Errorf("read %d bytes at %d out of %s", length, offset, path)
+		}
+		content := make([]byte, length)
+		for i := int64(0); i < length; i += 4 {
+			common.Endian.PutUint32(content[i:], math.Float32bits(vectors[(offset+i)/4]))
+		}
+		return content, nil
+	}))
+	genResult := func(offsets ...int64) *segcorepb.RetrieveResults {
+		return &segcorepb.RetrieveResults{
+			Ids:    &schemapb.IDs{},
+			Offset: offsets,
+			FieldsData: []*schemapb.FieldData{{
+				Type:    schemapb.DataType_FloatVector,
+				FieldId: simpleVecField.id,
+				Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+					Dim:  defaultDim,
+					Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{}},
+				}},
+			}},
+		}
+	}
+
+	offsets := []int64{0, 2, 3, 7, 8, 9}
+	result := genResult(offsets...)
+	require.NoError(t, segment.fillIndexedFieldsData(defaultCollectionID, vcm, result, newBinlogTracker(0)))
+	vectors := result.GetFieldsData()[0].GetVectors().GetFloatVector().GetData()
+	require.Len(t, vectors, len(offsets)*defaultDim)
+	for i, offset := range offsets {
+		for k := 0; k < defaultDim; k++ {
+			assert.Equal(t, float32(offset), vectors[i*defaultDim+k])
+		}
+	}
+
+	// the offset is beyond the rows of the binlogs
+	assert.Error(t, segment.fillIndexedFieldsData(defaultCollectionID, vcm, genResult(rows), newBinlogTracker(0)))
 }
 
 func generateBoolArray(numRows int) []bool {
@@ -1264,14 +2176,13 @@ func Test_fillBinVecFieldData(t *testing.T) {
 	f := newBinaryVectorFieldData("bv", 1, 8)
 
 	path := funcutil.GenRandomStr()
-	index := 0
 	offset := int64(100)
 	endian := common.Endian
 
-	assert.NoError(t, fillBinVecFieldData(m, path, f, index, offset, endian))
+	assert.NoError(t, fillBinVecFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtErr())
-	assert.Error(t, fillBinVecFieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillBinVecFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 }
 
 func Test_fillFloatVecFieldData(t *testing.T) {
@@ -1282,17 +2193,16 @@ func Test_fillFloatVecFieldData(t *testing.T) {
 	f := newFloatVectorFieldData("fv", 1, 8)
 
 	path := funcutil.GenRandomStr()
-	index := 0
 	offset := int64(100)
 	endian := common.Endian
 
-	assert.NoError(t, fillFloatVecFieldData(m, path, f, index, offset, endian))
+	assert.NoError(t, fillFloatVecFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtErr())
-	assert.Error(t, fillFloatVecFieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillFloatVecFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtEmptyContent())
-	assert.Error(t, fillFloatVecFieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillFloatVecFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 }
 
 func Test_fillBoolFieldData(t *testing.T) {
@@ -1304,16 +2214,15 @@ func Test_fillBoolFieldData(t *testing.T) {
 	f := newScalarFieldData(schemapb.DataType_Bool, "f", 1)
 
 	path := funcutil.GenRandomStr()
-	index := 0
 	endian := common.Endian
 
-	assert.NoError(t, fillBoolFieldData(m, path, f, index, offset, endian))
+	assert.NoError(t, fillBoolFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadErr())
-	assert.Error(t, fillBoolFieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillBoolFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadIllegalBool())
-	assert.Error(t, fillBoolFieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillBoolFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 }
 
 func Test_fillStringFieldData(t *testing.T) {
@@ -1325,16 +2234,15 @@ func Test_fillStringFieldData(t *testing.T) {
 	f := newScalarFieldData(schemapb.DataType_VarChar, "f", 1)
 
 	path := funcutil.GenRandomStr()
-	index := 0
 	endian := common.Endian
 
-	assert.NoError(t, fillStringFieldData(m, path, f, index, offset, endian))
+	assert.NoError(t, fillStringFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadErr())
-	assert.Error(t, fillStringFieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillStringFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadIllegalString())
-	assert.Error(t, fillStringFieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillStringFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 }
 
 func Test_fillInt8FieldData(t *testing.T) {
@@ -1346,16 +2254,15 @@ func Test_fillInt8FieldData(t *testing.T) {
 	f := newScalarFieldData(schemapb.DataType_Int8, "f", 1)
 
 	path := funcutil.GenRandomStr()
-	index := 0
 	endian := common.Endian
 
-	assert.NoError(t, fillInt8FieldData(m, path, f, index, offset, endian))
+	assert.NoError(t, fillInt8FieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtErr())
-	assert.Error(t, fillInt8FieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillInt8FieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtEmptyContent())
-	assert.Error(t, fillInt8FieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillInt8FieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 }
 
 func Test_fillInt16FieldData(t *testing.T) {
@@ -1367,16 +2274,15 @@ func Test_fillInt16FieldData(t *testing.T) {
 	f := newScalarFieldData(schemapb.DataType_Int16, "f", 1)
 
 	path := funcutil.GenRandomStr()
-	index := 0
 	endian := common.Endian
 
-	assert.NoError(t, fillInt16FieldData(m, path, f, index, offset, endian))
+	assert.NoError(t, fillInt16FieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtErr())
-	assert.Error(t, fillInt16FieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillInt16FieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtEmptyContent())
-	assert.Error(t, fillInt16FieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillInt16FieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 }
 
 func Test_fillInt32FieldData(t *testing.T) {
@@ -1388,16 +2294,15 @@ func Test_fillInt32FieldData(t *testing.T) {
 	f := newScalarFieldData(schemapb.DataType_Int32, "f", 1)
 
 	path := funcutil.GenRandomStr()
-	index := 0
 	endian := common.Endian
 
-	assert.NoError(t, fillInt32FieldData(m, path, f, index, offset, endian))
+	assert.NoError(t, fillInt32FieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtErr())
-	assert.Error(t, fillInt32FieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillInt32FieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtEmptyContent())
-	assert.Error(t, fillInt32FieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillInt32FieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 }
 
 func Test_fillInt64FieldData(t *testing.T) {
@@ -1409,16 +2314,15 @@ func Test_fillInt64FieldData(t *testing.T) {
 	f := newScalarFieldData(schemapb.DataType_Int64, "f", 1)
 
 	path := funcutil.GenRandomStr()
-	index := 0
 	endian := common.Endian
 
-	assert.NoError(t, fillInt64FieldData(m, path, f, index, offset, endian))
+	assert.NoError(t, fillInt64FieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtErr())
-	assert.Error(t, fillInt64FieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillInt64FieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtEmptyContent())
-	assert.Error(t, fillInt64FieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillInt64FieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 }
 
 func Test_fillFloatFieldData(t *testing.T) {
@@ -1430,16 +2334,15 @@ func Test_fillFloatFieldData(t *testing.T) {
 	f := newScalarFieldData(schemapb.DataType_Float, "f", 1)
 
 	path := funcutil.GenRandomStr()
-	index := 0
 	endian := common.Endian
 
-	assert.NoError(t, fillFloatFieldData(m, path, f, index, offset, endian))
+	assert.NoError(t, fillFloatFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtErr())
-	assert.Error(t, fillFloatFieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillFloatFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtEmptyContent())
-	assert.Error(t, fillFloatFieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillFloatFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 }
 
 func Test_fillDoubleFieldData(t *testing.T) {
@@ -1451,16 +2354,15 @@ func Test_fillDoubleFieldData(t *testing.T) {
 	f := newScalarFieldData(schemapb.DataType_Double, "f", 1)
 
 	path := funcutil.GenRandomStr()
-	index := 0
 	endian := common.Endian
 
-	assert.NoError(t, fillDoubleFieldData(m, path, f, index, offset, endian))
+	assert.NoError(t, fillDoubleFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtErr())
-	assert.Error(t, fillDoubleFieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillDoubleFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 
 	m = newMockChunkManager(withReadAtEmptyContent())
-	assert.Error(t, fillDoubleFieldData(m, path, f, index, offset, endian))
+	assert.Error(t, fillDoubleFieldData(m, path, newTestColumnBuilder(t, f), offset, endian))
 }
 
 func Test_fillFieldData(t *testing.T) {
@@ -1481,7 +2383,6 @@ func Test_fillFieldData(t *testing.T) {
 
 	offset := int64(100)
 	path := funcutil.GenRandomStr()
-	index := 0
 	endian := common.Endian
 
 	for _, f := range fs {
@@ -1496,10 +2397,16 @@ func Test_fillFieldData(t *testing.T) {
 			m = newMockChunkManager(withDefaultReadAt())
 		}
 
-		assert.NoError(t, fillFieldData(m, path, f, index, offset, endian))
+		// the rows are appended to the column of the field
+		builder := newTestColumnBuilder(t, f)
+		assert.NoError(t, fillFieldData(m, path, builder, offset, endian))
+		assert.NoError(t, fillFieldData(m, path, builder, offset, endian))
+		column := builder.Build()
+		rows, err := typeutil.GetRowCountOfFieldData(column)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, rows, f.Type.String())
+		assert.Equal(t, f.GetVectors().GetDim(), column.GetVectors().GetDim())
 	}
-
-	assert.Error(t, fillFieldData(m, path, &schemapb.FieldData{Type: schemapb.DataType_None}, index, offset, endian))
 }
 
 func TestUpdateBloomFilter(t *testing.T) {
@@ -1551,3 +2458,70 @@ func TestUpdateBloomFilter(t *testing.T) {
 	})
 
 }
+
+func TestSegment_checkMetricType(t *testing.T) {
+	segment, err := genSimpleSealedSegment()
+	assert.NoError(t, err)
+	defer deleteSegment(segment)
+
+	setIndex := func(indexType string, metricType string) {
+		segment.setIndexedFieldInfo(simpleVecField.id, &IndexedFieldInfo{
+			indexInfo: &querypb.FieldIndexInfo{
+				FieldID:     simpleVecField.id,
+				EnableIndex: true,
+				IndexParams: []*commonpb.KeyValuePair{
+					{Key: "index_type", Value: indexType},
+					{Key: metricTypeKey, Value: metricType},
+				},
+			},
+		})
+	}
+
+	// brute force
+	assert.NoError(t, segment.checkMetricType(simpleVecField.id, "IP"))
+
+	setIndex(IndexFaissIDMap, "L2")
+	assert.NoError(t, segment.checkMetricType(simpleVecField.id, "IP"))
+
+	setIndex(IndexFaissIVFFlat, "L2")
+	assert.NoError(t, segment.checkMetricType(simpleVecField.id, "L2"))
+	err = segment.checkMetricType(simpleVecField.id, "IP")
+	var mismatchErr *metricTypeMismatchError
+	assert.True(t, errors.As(err, &mismatchErr))
+	assert.Equal(t, "L2", mismatchErr.indexMetricType)
+	assert.Equal(t, "IP", mismatchErr.metricType)
+
+	setIndex(IndexHNSW, "IP")
+	assert.Error(t, segment.checkMetricType(simpleVecField.id, "L2"))
+}
+
+func TestSegment_searchPK(t *testing.T) {
+	segment := &Segment{segmentID: defaultSegmentID}
+	pks := []primaryKey{newInt64PrimaryKey(10), newInt64PrimaryKey(20)}
+
+	// without pk index nothing is found, but segment can't be pruned
+	_, ok := segment.searchPK(newInt64PrimaryKey(10))
+	assert.False(t, ok)
+	assert.True(t, segment.mayContainPKs(pks))
+
+	segment.setPKIndex(newInt64PkIndex([]int64{30, 10, 40}))
+	offset, ok := segment.searchPK(newInt64PrimaryKey(10))
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), offset)
+	_, ok = segment.searchPK(newVarCharPrimaryKey("10"))
+	assert.False(t, ok)
+	assert.True(t, segment.mayContainPKs(pks))
+	assert.False(t, segment.mayContainPKs([]primaryKey{newInt64PrimaryKey(20)}))
+	assert.True(t, segment.mayContainPKs(nil))
+
+	// pk out of the statslog range is not looked up
+	segment.updatePKRange(newInt64PrimaryKey(20), newInt64PrimaryKey(40))
+	segment.updatePKRange(newInt64PrimaryKey(30), newInt64PrimaryKey(35))
+	assert.Equal(t, newInt64PrimaryKey(20), segment.minPK)
+	assert.Equal(t, newInt64PrimaryKey(40), segment.maxPK)
+	_, ok = segment.searchPK(newInt64PrimaryKey(10))
+	assert.False(t, ok)
+	offset, ok = segment.searchPK(newInt64PrimaryKey(40))
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), offset)
+}
@@ -19,6 +19,7 @@ package querynode
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/golang/protobuf/proto"
@@ -27,6 +28,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/util/compressor"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
@@ -380,22 +382,48 @@ func (sc *ShardCluster) getSegment(segmentID int64) (*shardSegmentInfo, bool) {
 	}, true
 }
 
+// distributionDigest returns the digest of the sealed segments of the shard, which changes once a segment is added to
+// or removed from the shard, e.g. by handoff or release
+func (sc *ShardCluster) distributionDigest() uint64 {
+	sc.mut.RLock()
+	segmentIDs := make([]int64, 0, len(sc.segments))
+	for segmentID := range sc.segments {
+		segmentIDs = append(segmentIDs, segmentID)
+	}
+	sc.mut.RUnlock()
+	return digestSegmentIDs(segmentIDs)
+}
+
 // segmentAllocations returns node to segments mappings.
-func (sc *ShardCluster) segmentAllocations(partitionIDs []int64) map[int64][]int64 {
+// If maxSegments is positive, only the first maxSegments segments in the order of segment ID are allocated,
+// and the number of the segments skipped is returned.
+func (sc *ShardCluster) segmentAllocations(partitionIDs []int64, maxSegments int64) (map[int64][]int64, int64) {
 	result := make(map[int64][]int64) // nodeID => segmentIDs
 	sc.mut.RLock()
 	defer sc.mut.RUnlock()
 
+	segments := make([]*shardSegmentInfo, 0, len(sc.segments))
 	for _, segment := range sc.segments {
 		if len(partitionIDs) > 0 && !inList(partitionIDs, segment.partitionID) {
 			continue
 		}
+		segments = append(segments, segment)
+	}
+
+	var skipped int64
+	if maxSegments > 0 && int64(len(segments)) > maxSegments {
+		sort.Slice(segments, func(i, j int) bool { return segments[i].segmentID < segments[j].segmentID })
+		skipped = int64(len(segments)) - maxSegments
+		segments = segments[:maxSegments]
+	}
+	for _, segment := range segments {
 		result[segment.nodeID] = append(result[segment.nodeID], segment.segmentID)
 	}
-	return result
+	return result, skipped
 }
 
 // Search preforms search operation on shard cluster.
+// The segments skipped by max_scanned_segments of the request are counted in SkippedSegments of the results.
 func (sc *ShardCluster) Search(ctx context.Context, req *querypb.SearchRequest) ([]*internalpb.SearchResults, error) {
 	if sc.state.Load() != int32(available) {
 		return nil, fmt.Errorf("ShardCluster for %s replicaID %d is no available", sc.vchannelName, sc.replicaID)
@@ -408,9 +436,9 @@ func (sc *ShardCluster) Search(ctx context.Context, req *querypb.SearchRequest)
 	//req.GetReq().GetPartitionIDs()
 
 	// get node allocation
-	segAllocs := sc.segmentAllocations(req.GetReq().GetPartitionIDs())
+	segAllocs, skipped := sc.segmentAllocations(req.GetReq().GetPartitionIDs(), req.GetReq().GetMaxScannedSegments())
 
-	log.Debug("cluster segment distribution", zap.Int("len", len(segAllocs)))
+	log.Debug("cluster segment distribution", zap.Int("len", len(segAllocs)), zap.Int64("skipped", skipped))
 	for nodeID, segmentIDs := range segAllocs {
 		log.Debug("segments distribution", zap.Int64("nodeID", nodeID), zap.Int64s("segments", segmentIDs))
 	}
@@ -438,11 +466,23 @@ func (sc *ShardCluster) Search(ctx context.Context, req *querypb.SearchRequest)
 			partialResult, nodeErr := node.client.Search(reqCtx, nodeReq)
 			resultMut.Lock()
 			defer resultMut.Unlock()
-			if nodeErr != nil || partialResult.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+			if nodeErr != nil {
 				cancel()
 				err = fmt.Errorf("Search %d failed, reason %s err %w", node.nodeID, partialResult.GetStatus().GetReason(), nodeErr)
 				return
 			}
+			// the error code reported by the follower is kept for the callers to decide whether to retry
+			if partialResult.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+				cancel()
+				err = fmt.Errorf("Search %d failed, reason %w", node.nodeID, errorOfStatus(partialResult.GetStatus()))
+				return
+			}
+			// the results of other nodes may be compressed for transmission
+			if decErr := compressor.DecompressSearchResults(partialResult); decErr != nil {
+				cancel()
+				err = fmt.Errorf("Search %d failed, err %w", node.nodeID, decErr)
+				return
+			}
 			results = append(results, partialResult)
 		}()
 	}
@@ -452,10 +492,15 @@ func (sc *ShardCluster) Search(ctx context.Context, req *querypb.SearchRequest)
 		return nil, err
 	}
 
+	// at least one segment is dispatched if any is skipped
+	if skipped > 0 && len(results) > 0 {
+		results[0].SkippedSegments += skipped
+	}
 	return results, nil
 }
 
 // Query performs query operation on shard cluster.
+// The segments skipped by max_scanned_segments of the request are counted in SkippedSegments of the results.
 func (sc *ShardCluster) Query(ctx context.Context, req *querypb.QueryRequest) ([]*internalpb.RetrieveResults, error) {
 	if sc.state.Load() != int32(available) {
 		return nil, fmt.Errorf("ShardCluster for %s replicaID %d is no available", sc.vchannelName, sc.replicaID)
@@ -467,7 +512,7 @@ func (sc *ShardCluster) Query(ctx context.Context, req *querypb.QueryRequest) ([
 	}
 
 	// get node allocation
-	segAllocs := sc.segmentAllocations(req.GetReq().GetPartitionIDs())
+	segAllocs, skipped := sc.segmentAllocations(req.GetReq().GetPartitionIDs(), req.GetReq().GetMaxScannedSegments())
 
 	// TODO dispatch to local queryShardService query dml channel growing segments
 
@@ -493,11 +538,23 @@ func (sc *ShardCluster) Query(ctx context.Context, req *querypb.QueryRequest) ([
 			partialResult, nodeErr := node.client.Query(reqCtx, nodeReq)
 			resultMut.Lock()
 			defer resultMut.Unlock()
-			if nodeErr != nil || partialResult.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+			if nodeErr != nil {
 				cancel()
 				err = fmt.Errorf("Query %d failed, reason %s err %w", node.nodeID, partialResult.GetStatus().GetReason(), nodeErr)
 				return
 			}
+			// the error code reported by the follower is kept for the callers to decide whether to retry
+			if partialResult.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+				cancel()
+				err = fmt.Errorf("Query %d failed, reason %w", node.nodeID, errorOfStatus(partialResult.GetStatus()))
+				return
+			}
+			// the results of other nodes may be compressed for transmission
+			if decErr := compressor.DecompressRetrieveResults(partialResult); decErr != nil {
+				cancel()
+				err = fmt.Errorf("Query %d failed, err %w", node.nodeID, decErr)
+				return
+			}
 			results = append(results, partialResult)
 		}()
 	}
@@ -507,5 +564,9 @@ func (sc *ShardCluster) Query(ctx context.Context, req *querypb.QueryRequest) ([
 		return nil, err
 	}
 
+	// at least one segment is dispatched if any is skipped
+	if skipped > 0 && len(results) > 0 {
+		results[0].SkippedSegments += skipped
+	}
 	return results, nil
 }
@@ -85,6 +85,16 @@ func (s *ShardClusterService) getShardCluster(vchannelName string) (*ShardCluste
 	return raw.(*ShardCluster), true
 }
 
+// hasSegment returns true if the shardCluster of the segment's vchannel tracks the segment.
+func (s *ShardClusterService) hasSegment(segment *Segment) bool {
+	cs, ok := s.getShardCluster(segment.vChannelID)
+	if !ok {
+		return false
+	}
+	_, ok = cs.getSegment(segment.segmentID)
+	return ok
+}
+
 // releaseShardCluster removes shardCluster from service and stops it.
 func (s *ShardClusterService) releaseShardCluster(vchannelName string) error {
 	raw, ok := s.clusters.LoadAndDelete(vchannelName)
@@ -26,6 +26,11 @@ func TestShardClusterService(t *testing.T) {
 	_, ok = clusterService.getShardCluster("non-exist-channel")
 	assert.False(t, ok)
 
+	shardCluster.segments[defaultSegmentID] = &shardSegmentInfo{segmentID: defaultSegmentID}
+	assert.True(t, clusterService.hasSegment(&Segment{segmentID: defaultSegmentID, vChannelID: defaultDMLChannel}))
+	assert.False(t, clusterService.hasSegment(&Segment{segmentID: defaultSegmentID + 1, vChannelID: defaultDMLChannel}))
+	assert.False(t, clusterService.hasSegment(&Segment{segmentID: defaultSegmentID, vChannelID: "non-exist-channel"}))
+
 	err := clusterService.releaseShardCluster(defaultDMLChannel)
 	assert.NoError(t, err)
 
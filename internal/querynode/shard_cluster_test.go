@@ -19,11 +19,17 @@ package querynode
 import (
 	"context"
 	"errors"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/compressor"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -826,6 +832,94 @@ func TestShardCluster_Search(t *testing.T) {
 	})
 }
 
+func TestShardCluster_decompressResults(t *testing.T) {
+	collectionID := int64(1)
+	vchannelName := "dml_1_1_v0"
+	replicaID := int64(0)
+	ctx := context.Background()
+
+	nodeEvents := []nodeEvent{
+		{
+			nodeID:   1,
+			nodeAddr: "addr_1",
+		},
+	}
+	segmentEvents := []segmentEvent{
+		{
+			segmentID: 1,
+			nodeID:    1,
+			state:     segmentStateLoaded,
+		},
+	}
+	blob := []byte(strings.Repeat("search result", 100))
+
+	t.Run("compressed results", func(t *testing.T) {
+		searchResult := &internalpb.SearchResults{SlicedBlob: blob}
+		compressed, err := compressor.CompressSearchResults(searchResult, compressor.CompressTypeZstd, 0)
+		require.NoError(t, err)
+		require.True(t, compressed)
+		queryResult := &internalpb.RetrieveResults{
+			Ids: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: make([]int64, 1000)}}},
+		}
+		compressed, err = compressor.CompressRetrieveResults(queryResult, compressor.CompressTypeSnappy, 0)
+		require.NoError(t, err)
+		require.True(t, compressed)
+
+		sc := NewShardCluster(collectionID, replicaID, vchannelName,
+			&mockNodeDetector{
+				initNodes: nodeEvents,
+			}, &mockSegmentDetector{
+				initSegments: segmentEvents,
+			}, func(nodeID int64, addr string) shardQueryNode {
+				return &mockShardQueryNode{
+					searchResult: searchResult,
+					queryResult:  queryResult,
+				}
+			})
+		defer sc.Close()
+
+		searchResults, err := sc.Search(ctx, &querypb.SearchRequest{
+			DmlChannel: vchannelName,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(searchResults))
+		assert.Equal(t, blob, searchResults[0].GetSlicedBlob())
+		assert.Empty(t, searchResults[0].GetSlicedBlobCompressType())
+
+		queryResults, err := sc.Query(ctx, &querypb.QueryRequest{
+			DmlChannel: vchannelName,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(queryResults))
+		assert.Equal(t, 1000, len(queryResults[0].GetIds().GetIntId().GetData()))
+		assert.Empty(t, queryResults[0].GetCompressType())
+	})
+
+	t.Run("corrupted results", func(t *testing.T) {
+		sc := NewShardCluster(collectionID, replicaID, vchannelName,
+			&mockNodeDetector{
+				initNodes: nodeEvents,
+			}, &mockSegmentDetector{
+				initSegments: segmentEvents,
+			}, func(nodeID int64, addr string) shardQueryNode {
+				return &mockShardQueryNode{
+					searchResult: &internalpb.SearchResults{SlicedBlob: blob, SlicedBlobCompressType: string(compressor.CompressTypeZstd)},
+					queryResult:  &internalpb.RetrieveResults{CompressedBlob: blob, CompressType: string(compressor.CompressTypeZstd)},
+				}
+			})
+		defer sc.Close()
+
+		_, err := sc.Search(ctx, &querypb.SearchRequest{
+			DmlChannel: vchannelName,
+		})
+		assert.Error(t, err)
+		_, err = sc.Query(ctx, &querypb.QueryRequest{
+			DmlChannel: vchannelName,
+		})
+		assert.Error(t, err)
+	})
+}
+
 func TestShardCluster_Query(t *testing.T) {
 	collectionID := int64(1)
 	vchannelName := "dml_1_1_v0"
@@ -1023,3 +1117,155 @@ func TestShardCluster_Query(t *testing.T) {
 	})
 
 }
+
+// scannedShardQueryNode records the segments it is requested to search or query
+type scannedShardQueryNode struct {
+	mut      sync.Mutex
+	segments []int64
+}
+
+func (m *scannedShardQueryNode) Search(_ context.Context, req *querypb.SearchRequest) (*internalpb.SearchResults, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.segments = append(m.segments, req.GetSegmentIDs()...)
+	return &internalpb.SearchResults{}, nil
+}
+
+func (m *scannedShardQueryNode) Query(_ context.Context, req *querypb.QueryRequest) (*internalpb.RetrieveResults, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.segments = append(m.segments, req.GetSegmentIDs()...)
+	return &internalpb.RetrieveResults{}, nil
+}
+
+func (m *scannedShardQueryNode) Stop() error {
+	return nil
+}
+
+func TestShardCluster_MaxScannedSegments(t *testing.T) {
+	ctx := context.Background()
+	collectionID := int64(1)
+	vchannelName := "dml_1_1_v0"
+	replicaID := int64(0)
+
+	nodeEvents := []nodeEvent{
+		{nodeID: 1, nodeAddr: "addr_1"},
+		{nodeID: 2, nodeAddr: "addr_2"},
+		{nodeID: 3, nodeAddr: "addr_3"},
+	}
+	// 100 segments of 2 partitions spread over the nodes in random order
+	var segmentEvents []segmentEvent
+	for _, i := range rand.Perm(100) {
+		segmentEvents = append(segmentEvents, segmentEvent{
+			segmentID:   int64(1000 + i),
+			partitionID: int64(i % 2),
+			nodeID:      int64(i%3 + 1),
+			state:       segmentStateLoaded,
+		})
+	}
+
+	nodes := make(map[string]*scannedShardQueryNode)
+	sc := NewShardCluster(collectionID, replicaID, vchannelName,
+		&mockNodeDetector{initNodes: nodeEvents}, &mockSegmentDetector{initSegments: segmentEvents},
+		func(nodeID int64, addr string) shardQueryNode {
+			node := &scannedShardQueryNode{}
+			nodes[addr] = node
+			return node
+		})
+	defer sc.Close()
+	require.EqualValues(t, available, sc.state.Load())
+
+	scanned := func() []int64 {
+		var segments []int64
+		for _, node := range nodes {
+			node.mut.Lock()
+			segments = append(segments, node.segments...)
+			node.segments = nil
+			node.mut.Unlock()
+		}
+		sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+		return segments
+	}
+	skipped := func(results []*internalpb.SearchResults) int64 {
+		var skipped int64
+		for _, result := range results {
+			skipped += result.GetSkippedSegments()
+		}
+		return skipped
+	}
+	expected := []int64{1000, 1001, 1002, 1003, 1004, 1005, 1006, 1007, 1008, 1009}
+
+	t.Run("search", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			results, err := sc.Search(ctx, &querypb.SearchRequest{
+				Req:        &internalpb.SearchRequest{MaxScannedSegments: 10},
+				DmlChannel: vchannelName,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, expected, scanned())
+			assert.Equal(t, int64(90), skipped(results))
+		}
+	})
+
+	t.Run("query", func(t *testing.T) {
+		results, err := sc.Query(ctx, &querypb.QueryRequest{
+			Req:        &internalpb.RetrieveRequest{MaxScannedSegments: 10},
+			DmlChannel: vchannelName,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, scanned())
+		var skipped int64
+		for _, result := range results {
+			skipped += result.GetSkippedSegments()
+		}
+		assert.Equal(t, int64(90), skipped)
+	})
+
+	t.Run("partitions", func(t *testing.T) {
+		results, err := sc.Search(ctx, &querypb.SearchRequest{
+			Req:        &internalpb.SearchRequest{PartitionIDs: []int64{1}, MaxScannedSegments: 5},
+			DmlChannel: vchannelName,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1001, 1003, 1005, 1007, 1009}, scanned())
+		assert.Equal(t, int64(45), skipped(results))
+	})
+
+	t.Run("not limited", func(t *testing.T) {
+		for _, maxSegments := range []int64{0, 100, 200} {
+			results, err := sc.Search(ctx, &querypb.SearchRequest{
+				Req:        &internalpb.SearchRequest{MaxScannedSegments: maxSegments},
+				DmlChannel: vchannelName,
+			})
+			require.NoError(t, err)
+			assert.Len(t, scanned(), 100)
+			assert.Equal(t, int64(0), skipped(results))
+		}
+	})
+}
+
+func TestShardCluster_distributionDigest(t *testing.T) {
+	vchannelName := "dml_1_1_v0"
+	segmentEvents := []segmentEvent{
+		{segmentID: 1, nodeID: 1, state: segmentStateLoaded},
+		{segmentID: 2, nodeID: 2, state: segmentStateLoaded},
+	}
+	sc := NewShardCluster(1, 0, vchannelName,
+		&mockNodeDetector{}, &mockSegmentDetector{initSegments: segmentEvents}, buildMockQueryNode)
+	defer sc.Close()
+
+	digest := sc.distributionDigest()
+	assert.Equal(t, digestSegmentIDs([]int64{2, 1}), digest)
+
+	// moving a segment to another node keeps the digest
+	sc.updateSegment(segmentEvent{segmentID: 2, nodeID: 3, state: segmentStateLoaded})
+	assert.Equal(t, digest, sc.distributionDigest())
+
+	sc.updateSegment(segmentEvent{segmentID: 3, nodeID: 1, state: segmentStateLoading})
+	assert.NotEqual(t, digest, sc.distributionDigest())
+
+	sc.removeSegment(segmentEvent{segmentID: 3, nodeID: 1})
+	assert.Equal(t, digest, sc.distributionDigest())
+	sc.removeSegment(segmentEvent{segmentID: 1, nodeID: 1})
+	assert.NotEqual(t, digest, sc.distributionDigest())
+}
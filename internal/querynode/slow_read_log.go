@@ -0,0 +1,98 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+)
+
+// requestIDExemplarLabel is the exemplar label of the request ids in the read latency metrics
+const requestIDExemplarLabel = "request_id"
+
+// slowReadLog logs the search and query requests slower than Params.QueryNodeCfg.SlowReadThreshold, along with the
+// ids of the client requests to correlate them with the logs of proxy
+type slowReadLog struct {
+	logger *zap.Logger // log.L() if nil
+}
+
+// observe logs the read request of op if it took latency beyond the threshold
+func (l *slowReadLog) observe(op string, collectionID UniqueID, channel Channel, segmentIDs []UniqueID,
+	requestID string, latency time.Duration, status *commonpb.Status) {
+	threshold := Params.QueryNodeCfg.SlowReadThreshold
+	if threshold <= 0 || latency < threshold {
+		return
+	}
+	logger := l.logger
+	if logger == nil {
+		logger = log.L()
+	}
+	logger.Warn("slow "+op+" request",
+		zap.String("requestID", requestID),
+		zap.Int64("collectionID", collectionID),
+		zap.String("channel", channel),
+		zap.Int("numSegments", len(segmentIDs)),
+		zap.Duration("latency", latency),
+		zap.String("errorCode", status.GetErrorCode().String()))
+}
+
+// observeReadLatency observes the latency of the read request of queryType, with requestID as the exemplar to find
+// the logs of the requests in the slow buckets. The request ids too long for an exemplar are left out
+func observeReadLatency(queryType string, requestID string, latency time.Duration) {
+	observer := metrics.QueryNodeSQReqLatency.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID), queryType)
+	ms := float64(latency.Milliseconds())
+	exemplarObserver, ok := observer.(prometheus.ExemplarObserver)
+	if !ok || requestID == "" || !utf8.ValidString(requestID) ||
+		utf8.RuneCountInString(requestIDExemplarLabel)+utf8.RuneCountInString(requestID) > prometheus.ExemplarMaxRunes {
+		observer.Observe(ms)
+		return
+	}
+	exemplarObserver.ObserveWithExemplar(ms, prometheus.Labels{requestIDExemplarLabel: requestID})
+}
+
+// tagRequestID tags the trace span of ctx with requestID
+func tagRequestID(ctx context.Context, requestID string) {
+	if requestID == "" {
+		return
+	}
+	if sp := opentracing.SpanFromContext(ctx); sp != nil {
+		sp.SetTag("request_id", requestID)
+	}
+}
+
+// withRequestID appends requestID to the reason of the failed status, if not yet, e.g. by the followers of the
+// shard leader
+func withRequestID(status *commonpb.Status, requestID string) {
+	if requestID == "" || status == nil || status.GetErrorCode() == commonpb.ErrorCode_Success {
+		return
+	}
+	tag := fmt.Sprintf("requestID=%s", requestID)
+	if !strings.Contains(status.GetReason(), tag) {
+		status.Reason = fmt.Sprintf("%s, %s", status.GetReason(), tag)
+	}
+}
@@ -0,0 +1,140 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	queryPb "github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+func observeSlowReads(l *slowReadLog, threshold time.Duration) (*observer.ObservedLogs, func()) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l.logger = zap.New(core)
+	old := Params.QueryNodeCfg.SlowReadThreshold
+	Params.QueryNodeCfg.SlowReadThreshold = threshold
+	return logs, func() { Params.QueryNodeCfg.SlowReadThreshold = old }
+}
+
+func TestSlowReadLog_observe(t *testing.T) {
+	l := &slowReadLog{}
+	logs, reset := observeSlowReads(l, time.Second)
+	defer reset()
+
+	l.observe("search", defaultCollectionID, defaultDMLChannel, nil, "fast-request", time.Millisecond, nil)
+	assert.Equal(t, 0, logs.Len())
+
+	l.observe("search", defaultCollectionID, defaultDMLChannel, nil, "slow-request", 2*time.Second, nil)
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "slow search request", entry.Message)
+	assert.Equal(t, "slow-request", entry.ContextMap()["requestID"])
+
+	// disabled
+	Params.QueryNodeCfg.SlowReadThreshold = 0
+	l.observe("search", defaultCollectionID, defaultDMLChannel, nil, "slow-request", time.Hour, nil)
+	assert.Equal(t, 1, logs.Len())
+}
+
+func TestObserveReadLatency(t *testing.T) {
+	assert.NotPanics(t, func() {
+		observeReadLatency(metrics.SearchLabel, "", time.Millisecond)
+		observeReadLatency(metrics.SearchLabel, "request", time.Millisecond)
+		// too long or invalid for an exemplar
+		observeReadLatency(metrics.QueryLabel, strings.Repeat("a", prometheus.ExemplarMaxRunes), time.Millisecond)
+		observeReadLatency(metrics.QueryLabel, string([]byte{0xff, 0xfe}), time.Millisecond)
+	})
+}
+
+func TestWithRequestID(t *testing.T) {
+	status := &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "failed"}
+	withRequestID(status, "abc")
+	assert.Equal(t, "failed, requestID=abc", status.GetReason())
+	// tagged once
+	withRequestID(status, "abc")
+	assert.Equal(t, "failed, requestID=abc", status.GetReason())
+
+	success := &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}
+	withRequestID(success, "abc")
+	assert.Empty(t, success.GetReason())
+	withRequestID(nil, "abc")
+}
+
+func TestImpl_SearchRequestID(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	node.queryShardService.addQueryShard(defaultCollectionID, defaultDMLChannel, defaultReplicaID)
+	logs, reset := observeSlowReads(&node.slowReads, time.Nanosecond)
+	defer reset()
+
+	t.Run("test search", func(t *testing.T) {
+		req, err := genSimpleSearchRequest(IndexFaissIDMap)
+		require.NoError(t, err)
+		req.RequestId = "search-request"
+
+		results, err := node.Search(ctx, &queryPb.SearchRequest{Req: req, DmlChannel: defaultDMLChannel})
+		assert.NoError(t, err)
+		assert.Equal(t, "search-request", results.GetRequestId())
+
+		entries := logs.FilterMessage("slow search request").All()
+		require.NotEmpty(t, entries)
+		assert.Equal(t, "search-request", entries[len(entries)-1].ContextMap()["requestID"])
+	})
+
+	t.Run("test query", func(t *testing.T) {
+		req, err := genSimpleRetrieveRequest()
+		require.NoError(t, err)
+		req.RequestId = "query-request"
+
+		results, err := node.Query(ctx, &queryPb.QueryRequest{Req: req, DmlChannel: defaultDMLChannel})
+		assert.NoError(t, err)
+		assert.Equal(t, "query-request", results.GetRequestId())
+
+		entries := logs.FilterMessage("slow query request").All()
+		require.NotEmpty(t, entries)
+		assert.Equal(t, "query-request", entries[len(entries)-1].ContextMap()["requestID"])
+	})
+
+	t.Run("test error status", func(t *testing.T) {
+		node.UpdateStateCode(internalpb.StateCode_Abnormal)
+		defer node.UpdateStateCode(internalpb.StateCode_Healthy)
+
+		req, err := genSimpleSearchRequest(IndexFaissIDMap)
+		require.NoError(t, err)
+		req.RequestId = "failed-request"
+		results, err := node.Search(ctx, &queryPb.SearchRequest{Req: req, DmlChannel: defaultDMLChannel})
+		assert.NoError(t, err)
+		assert.Equal(t, "failed-request", results.GetRequestId())
+		assert.Contains(t, results.GetStatus().GetReason(), "requestID=failed-request")
+	})
+}
@@ -0,0 +1,282 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"go.uber.org/zap"
+	"golang.org/x/exp/mmap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/errorutil"
+)
+
+// storageProbePath is the object stat by the probe of the storage breaker, it doesn't need to exist,
+// since a "not found" response proves the object storage is reachable
+const storageProbePath = "querynode-storage-probe"
+
+// isStorageFailure returns whether err implies the object storage is unreachable or unhealthy,
+// the error responses of a healthy storage such as NoSuchKey are not failures
+func isStorageFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || os.IsNotExist(err) || storage.IsErrNoSuchKey(err) {
+		return false
+	}
+	if el, ok := err.(errorutil.ErrorList); ok {
+		for _, e := range el {
+			if isStorageFailure(e) {
+				return true
+			}
+		}
+		return false
+	}
+	switch minio.ToErrorResponse(err).Code {
+	case "", "InternalError", "ServiceUnavailable", "SlowDown", "XMinioServerNotInitialized":
+		return true
+	}
+	return false
+}
+
+// storageBreaker fails the object storage accesses fast during storage outages, instead of letting every
+// access wait for its timeout. It opens after threshold consecutive failures, then a background probe
+// checks the storage every coolDown and closes the breaker once the storage recovers.
+type storageBreaker struct {
+	ctx       context.Context
+	threshold int
+	coolDown  time.Duration
+	probe     func() error
+
+	mu       sync.Mutex
+	failures int // consecutive failures
+	open     bool
+}
+
+func newStorageBreaker(ctx context.Context, threshold int, coolDown time.Duration, probe func() error) *storageBreaker {
+	metrics.QueryNodeStorageBreakerOpen.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Set(0)
+	return &storageBreaker{
+		ctx:       ctx,
+		threshold: threshold,
+		coolDown:  coolDown,
+		probe:     probe,
+	}
+}
+
+// isOpen returns whether the object storage accesses fail fast
+func (b *storageBreaker) isOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.open
+}
+
+// allow returns ErrStorageUnavailable if the breaker is open
+func (b *storageBreaker) allow() error {
+	if b.isOpen() {
+		return ErrStorageUnavailable
+	}
+	return nil
+}
+
+// record counts the result of an object storage access, and opens the breaker after threshold consecutive failures
+func (b *storageBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !isStorageFailure(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.open || b.failures < b.threshold {
+		return
+	}
+
+	b.open = true
+	log.Warn("object storage is unavailable, storage breaker opened",
+		zap.Int("failures", b.failures),
+		zap.Duration("coolDown", b.coolDown),
+		zap.Error(err))
+	metrics.QueryNodeStorageBreakerOpen.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Set(1)
+	go b.probeLoop()
+}
+
+// probeLoop probes the object storage every coolDown until it recovers
+func (b *storageBreaker) probeLoop() {
+	ticker := time.NewTicker(b.coolDown)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := b.probe(); isStorageFailure(err) {
+				log.Debug("object storage is still unavailable", zap.Error(err))
+				continue
+			}
+			b.close()
+			return
+		}
+	}
+}
+
+// close closes the breaker after the object storage recovers
+func (b *storageBreaker) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.open = false
+	b.failures = 0
+	log.Info("object storage recovered, storage breaker closed")
+	metrics.QueryNodeStorageBreakerOpen.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Set(0)
+}
+
+// breakerChunkManager guards the accesses of an object storage chunk manager with a storage breaker
+type breakerChunkManager struct {
+	cm      storage.ChunkManager
+	breaker *storageBreaker
+}
+
+var _ storage.ChunkManager = (*breakerChunkManager)(nil)
+
+func newBreakerChunkManager(cm storage.ChunkManager, breaker *storageBreaker) *breakerChunkManager {
+	return &breakerChunkManager{
+		cm:      cm,
+		breaker: breaker,
+	}
+}
+
+// do runs f unless the breaker is open, and records its result
+func (bcm *breakerChunkManager) do(f func() error) error {
+	if err := bcm.breaker.allow(); err != nil {
+		return err
+	}
+	err := f()
+	bcm.breaker.record(err)
+	return err
+}
+
+func (bcm *breakerChunkManager) Path(filePath string) (path string, err error) {
+	err = bcm.do(func() error {
+		path, err = bcm.cm.Path(filePath)
+		return err
+	})
+	return path, err
+}
+
+func (bcm *breakerChunkManager) Size(filePath string) (size int64, err error) {
+	err = bcm.do(func() error {
+		size, err = bcm.cm.Size(filePath)
+		return err
+	})
+	return size, err
+}
+
+func (bcm *breakerChunkManager) Write(filePath string, content []byte) error {
+	return bcm.do(func() error {
+		return bcm.cm.Write(filePath, content)
+	})
+}
+
+func (bcm *breakerChunkManager) MultiWrite(contents map[string][]byte) error {
+	return bcm.do(func() error {
+		return bcm.cm.MultiWrite(contents)
+	})
+}
+
+// Exist returns false if the breaker is open, the result is not recorded since Exist swallows the errors
+func (bcm *breakerChunkManager) Exist(filePath string) bool {
+	if bcm.breaker.allow() != nil {
+		return false
+	}
+	return bcm.cm.Exist(filePath)
+}
+
+func (bcm *breakerChunkManager) Read(filePath string) (content []byte, err error) {
+	err = bcm.do(func() error {
+		content, err = bcm.cm.Read(filePath)
+		return err
+	})
+	return content, err
+}
+
+func (bcm *breakerChunkManager) Reader(filePath string) (reader storage.FileReader, err error) {
+	err = bcm.do(func() error {
+		reader, err = bcm.cm.Reader(filePath)
+		return err
+	})
+	return reader, err
+}
+
+func (bcm *breakerChunkManager) MultiRead(filePaths []string) (contents [][]byte, err error) {
+	err = bcm.do(func() error {
+		contents, err = bcm.cm.MultiRead(filePaths)
+		return err
+	})
+	return contents, err
+}
+
+func (bcm *breakerChunkManager) ListWithPrefix(prefix string) (filePaths []string, err error) {
+	err = bcm.do(func() error {
+		filePaths, err = bcm.cm.ListWithPrefix(prefix)
+		return err
+	})
+	return filePaths, err
+}
+
+func (bcm *breakerChunkManager) ReadWithPrefix(prefix string) (filePaths []string, contents [][]byte, err error) {
+	err = bcm.do(func() error {
+		filePaths, contents, err = bcm.cm.ReadWithPrefix(prefix)
+		return err
+	})
+	return filePaths, contents, err
+}
+
+// Mmap is not guarded, since the object storage doesn't support mmap and always fails it
+func (bcm *breakerChunkManager) Mmap(filePath string) (*mmap.ReaderAt, error) {
+	return bcm.cm.Mmap(filePath)
+}
+
+func (bcm *breakerChunkManager) ReadAt(filePath string, off int64, length int64) (p []byte, err error) {
+	err = bcm.do(func() error {
+		p, err = bcm.cm.ReadAt(filePath, off, length)
+		return err
+	})
+	return p, err
+}
+
+func (bcm *breakerChunkManager) Remove(filePath string) error {
+	return bcm.do(func() error {
+		return bcm.cm.Remove(filePath)
+	})
+}
+
+func (bcm *breakerChunkManager) MultiRemove(filePaths []string) error {
+	return bcm.do(func() error {
+		return bcm.cm.MultiRemove(filePaths)
+	})
+}
+
+func (bcm *breakerChunkManager) RemoveWithPrefix(prefix string) error {
+	return bcm.do(func() error {
+		return bcm.cm.RemoveWithPrefix(prefix)
+	})
+}
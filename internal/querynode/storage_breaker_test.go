@@ -0,0 +1,173 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/errorutil"
+)
+
+func TestIsStorageFailure(t *testing.T) {
+	assert.False(t, isStorageFailure(nil))
+	assert.False(t, isStorageFailure(context.Canceled))
+	assert.False(t, isStorageFailure(&os.PathError{Op: "stat", Path: "a", Err: os.ErrNotExist}))
+	assert.False(t, isStorageFailure(minio.ErrorResponse{Code: "NoSuchKey"}))
+	assert.False(t, isStorageFailure(storage.WrapErrNoSuchKey("a")))
+	assert.False(t, isStorageFailure(errorutil.ErrorList{minio.ErrorResponse{Code: "NoSuchKey"}}))
+
+	assert.True(t, isStorageFailure(errors.New("dial tcp: i/o timeout")))
+	assert.True(t, isStorageFailure(context.DeadlineExceeded))
+	assert.True(t, isStorageFailure(minio.ErrorResponse{Code: "SlowDown"}))
+	assert.True(t, isStorageFailure(errorutil.ErrorList{minio.ErrorResponse{Code: "NoSuchKey"}, errors.New("connection refused")}))
+}
+
+func TestStorageBreaker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		failing = atomic.NewBool(false)
+		reads   = atomic.NewInt32(0)
+		probes  = atomic.NewInt32(0)
+		readErr = errors.New("dial tcp: i/o timeout")
+	)
+	mock := newMockChunkManager(
+		withRead(func(path string) ([]byte, error) {
+			reads.Inc()
+			if failing.Load() {
+				return nil, readErr
+			}
+			return []byte(path), nil
+		}),
+		withReadAt(func(path string, offset int64, length int64) ([]byte, error) {
+			reads.Inc()
+			if failing.Load() {
+				return nil, readErr
+			}
+			return defaultReadAt(path, offset, length)
+		}))
+	breaker := newStorageBreaker(ctx, 3, 50*time.Millisecond, func() error {
+		probes.Inc()
+		if failing.Load() {
+			return readErr
+		}
+		return minio.ErrorResponse{Code: "NoSuchKey"}
+	})
+	cm := newBreakerChunkManager(mock, breaker)
+	open := metrics.QueryNodeStorageBreakerOpen.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID))
+
+	t.Run("consecutive failures", func(t *testing.T) {
+		failing.Store(true)
+		for i := 0; i < 2; i++ {
+			_, err := cm.Read("a")
+			assert.Equal(t, readErr, err)
+		}
+		// a success resets the failures
+		failing.Store(false)
+		_, err := cm.Read("a")
+		assert.NoError(t, err)
+		failing.Store(true)
+		for i := 0; i < 2; i++ {
+			_, err := cm.Read("a")
+			assert.Equal(t, readErr, err)
+		}
+		assert.False(t, breaker.isOpen())
+		assert.Equal(t, float64(0), testutil.ToFloat64(open))
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		_, err := cm.ReadAt("a", 0, 8)
+		assert.Equal(t, readErr, err)
+		require.True(t, breaker.isOpen())
+		assert.Equal(t, float64(1), testutil.ToFloat64(open))
+
+		reads.Store(0)
+		_, err = cm.Read("a")
+		assert.True(t, errors.Is(err, ErrStorageUnavailable))
+		_, err = cm.ReadAt("a", 0, 8)
+		assert.True(t, errors.Is(err, ErrStorageUnavailable))
+		assert.False(t, cm.Exist("a"))
+		assert.Equal(t, int32(0), reads.Load())
+	})
+
+	t.Run("recovery", func(t *testing.T) {
+		// the breaker keeps open while the probe fails
+		assert.Eventually(t, func() bool { return probes.Load() >= 2 }, time.Second, 10*time.Millisecond)
+		assert.True(t, breaker.isOpen())
+
+		failing.Store(false)
+		assert.Eventually(t, func() bool { return !breaker.isOpen() }, time.Second, 10*time.Millisecond)
+		assert.Equal(t, float64(0), testutil.ToFloat64(open))
+		content, err := cm.Read("a")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("a"), content)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		notFound := newBreakerChunkManager(newMockChunkManager(withRead(func(path string) ([]byte, error) {
+			return nil, minio.ErrorResponse{Code: "NoSuchKey"}
+		})), breaker)
+		for i := 0; i < 5; i++ {
+			_, err := notFound.Read("a")
+			assert.Error(t, err)
+			assert.False(t, errors.Is(err, ErrStorageUnavailable))
+		}
+		assert.False(t, breaker.isOpen())
+	})
+}
+
+func TestStorageBreaker_segmentLoader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	require.NoError(t, err)
+	segment, err := node.historical.replica.getSegmentByID(defaultSegmentID)
+	require.NoError(t, err)
+
+	breaker := newStorageBreaker(ctx, 2, time.Hour, func() error { return nil })
+	reads := 0
+	node.loader.cm = newBreakerChunkManager(newMockChunkManager(withRead(func(path string) ([]byte, error) {
+		reads++
+		return nil, errors.New("connection refused")
+	})), breaker)
+
+	deltaLogs := []*datapb.FieldBinlog{{Binlogs: []*datapb.Binlog{{LogPath: "delta"}}}}
+	for i := 0; i < 2; i++ {
+		err = node.loader.loadDeltaLogs(segment, deltaLogs, 0)
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrStorageUnavailable))
+	}
+	err = node.loader.loadDeltaLogs(segment, deltaLogs, 0)
+	assert.True(t, errors.Is(err, ErrStorageUnavailable))
+	assert.Equal(t, 2, reads)
+}
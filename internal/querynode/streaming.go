@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -45,6 +46,7 @@ type streaming struct {
 func newStreaming(ctx context.Context, replica ReplicaInterface, factory msgstream.Factory, etcdKV *etcdkv.EtcdKV, tSafeReplica TSafeReplicaInterface) *streaming {
 
 	return &streaming{
+		ctx:          ctx,
 		replica:      replica,
 		tSafeReplica: tSafeReplica,
 	}
@@ -54,6 +56,65 @@ func (s *streaming) start() {
 	// TODO: start stats
 }
 
+// startGrowingSegmentGC periodically removes growing segments which stay empty and idle,
+// segments for which exempt returns true are kept, and compacts the growing segments with many deleted rows.
+// It is disabled if the interval is not positive.
+func (s *streaming) startGrowingSegmentGC(exempt func(segment *Segment) bool) {
+	interval := Params.QueryNodeCfg.GrowingSegmentGCInterval
+	if interval <= 0 {
+		log.Info("growing segment gc is disabled", zap.Duration("interval", interval))
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.ctx.Done():
+				log.Debug("stop growing segment gc")
+				return
+			case <-ticker.C:
+				s.removeIdleGrowingSegments(exempt)
+				s.compactGrowingSegments()
+			}
+		}
+	}()
+}
+
+// removeIdleGrowingSegments removes the idle empty growing segments once and returns their ids
+func (s *streaming) removeIdleGrowingSegments(exempt func(segment *Segment) bool) []UniqueID {
+	removed := s.replica.removeIdleGrowingSegments(Params.QueryNodeCfg.GrowingSegmentIdleTolerance, exempt)
+	if len(removed) > 0 {
+		log.Info("remove idle growing segments",
+			zap.Duration("idleTolerance", Params.QueryNodeCfg.GrowingSegmentIdleTolerance),
+			zap.Int64s("segmentIDs", removed))
+		metrics.QueryNodeNumReapedGrowingSegments.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Add(float64(len(removed)))
+	}
+	return removed
+}
+
+// compactGrowingSegments compacts the growing segments with many deleted rows once, dropping the rows deleted
+// before the tSafe of their channels, and returns the number of the rows dropped of each compacted segment
+func (s *streaming) compactGrowingSegments() map[UniqueID]int64 {
+	deadRatio := Params.QueryNodeCfg.GrowingCompactionDeadRatio
+	if deadRatio <= 0 {
+		return nil
+	}
+	horizon := func(segment *Segment) (Timestamp, bool) {
+		ts, err := s.tSafeReplica.getTSafe(segment.vChannelID)
+		return ts, err == nil && ts > 0
+	}
+	compacted := s.replica.compactGrowingSegments(deadRatio, Params.QueryNodeCfg.GrowingCompactionMinRows, horizon)
+	var deadRows int64
+	for _, rows := range compacted {
+		deadRows += rows
+	}
+	if deadRows > 0 {
+		metrics.QueryNodeCompactedGrowingRows.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID)).Add(float64(deadRows))
+	}
+	return compacted
+}
+
 func (s *streaming) close() {
 	// TODO: stop stats
 
@@ -71,7 +132,11 @@ func (s *streaming) retrieve(collID UniqueID, partIDs []UniqueID, plan *Retrieve
 		if err != nil {
 			return retrieveResults, retrieveSegmentIDs, retrievePartIDs, err
 		}
-		retrievePartIDs = strPartIDs
+		col, err := s.replica.getCollectionByID(collID)
+		if err != nil {
+			return retrieveResults, retrieveSegmentIDs, retrievePartIDs, err
+		}
+		retrievePartIDs = prunePartitionsByKey(s.replica, col, strPartIDs, plan.partitionKeys, metrics.QueryLabel)
 	} else {
 		for _, id := range partIDs {
 			_, err := s.replica.getPartitionByID(id)
@@ -114,7 +179,7 @@ func (s *streaming) retrieve(collID UniqueID, partIDs []UniqueID, plan *Retrieve
 }
 
 // search will search all the target segments in streaming
-func (s *streaming) search(searchReqs []*searchRequest, collID UniqueID, partIDs []UniqueID, vChannel Channel,
+func (s *streaming) search(ctx context.Context, searchReqs []*searchRequest, collID UniqueID, partIDs []UniqueID, vChannel Channel,
 	plan *SearchPlan, searchTs Timestamp) ([]*SearchResult, []UniqueID, []UniqueID, error) {
 
 	searchResults := make([]*SearchResult, 0)
@@ -166,6 +231,10 @@ func (s *streaming) search(searchReqs []*searchRequest, collID UniqueID, partIDs
 		return searchResults, searchSegmentIDs, searchPartIDs, nil
 	}
 
+	if len(partIDs) == 0 {
+		searchPartIDs = prunePartitionsByKey(s.replica, col, searchPartIDs, plan.partitionKeys, metrics.SearchLabel)
+	}
+
 	var segmentLock sync.RWMutex
 	for _, partID := range searchPartIDs {
 		segIDs, err := s.replica.getSegmentIDsByVChannel(partID, vChannel)
@@ -215,7 +284,7 @@ func (s *streaming) search(searchReqs []*searchRequest, collID UniqueID, partIDs
 				//}
 
 				tr := timerecord.NewTimeRecorder("searchOnGrowing")
-				searchResult, err := seg.search(plan, searchReqs, []Timestamp{searchTs})
+				searchResult, err := seg.search(ctx, plan, searchReqs, []Timestamp{searchTs})
 				if err != nil {
 					err2 = err
 					return
@@ -18,9 +18,15 @@ package querynode
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
 )
 
 func TestStreaming_streaming(t *testing.T) {
@@ -35,6 +41,93 @@ func TestStreaming_streaming(t *testing.T) {
 	streaming.start()
 }
 
+func TestStreaming_removeIdleGrowingSegments(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tSafe := newTSafeReplica()
+	streaming, err := genSimpleStreaming(ctx, tSafe)
+	assert.NoError(t, err)
+	defer streaming.close()
+
+	segment, err := streaming.replica.getSegmentByID(defaultSegmentID)
+	assert.NoError(t, err)
+
+	// recently created segment is kept
+	removed := streaming.removeIdleGrowingSegments(nil)
+	assert.Empty(t, removed)
+
+	segment.lastActiveTime.Store(time.Now().Add(-2 * Params.QueryNodeCfg.GrowingSegmentIdleTolerance).UnixNano())
+	removed = streaming.removeIdleGrowingSegments(func(*Segment) bool { return true })
+	assert.Empty(t, removed)
+
+	removed = streaming.removeIdleGrowingSegments(nil)
+	assert.Equal(t, []UniqueID{defaultSegmentID}, removed)
+	assert.False(t, streaming.replica.hasSegment(defaultSegmentID))
+}
+
+func TestStreaming_compactGrowingSegments(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tSafe := newTSafeReplica()
+	streaming, err := genSimpleStreaming(ctx, tSafe)
+	require.NoError(t, err)
+	defer streaming.close()
+
+	segment, err := streaming.replica.getSegmentByID(defaultSegmentID)
+	require.NoError(t, err)
+	const N = 100
+	records, err := genCommonBlob(N, genSimpleSegCoreSchema())
+	require.NoError(t, err)
+	ids := make([]int64, 0, N)
+	timestamps := make([]Timestamp, 0, N)
+	for i := 0; i < N; i++ {
+		ids = append(ids, int64(i))
+		timestamps = append(timestamps, 1)
+	}
+	offset, err := segment.segmentPreInsert(N)
+	require.NoError(t, err)
+	require.NoError(t, segment.segmentInsert(offset, &ids, &timestamps, &records))
+	pks := make([]primaryKey, 0, 60)
+	deleteTimestamps := make([]Timestamp, 0, 60)
+	for i := 0; i < 60; i++ {
+		pks = append(pks, newInt64PrimaryKey(int64(i)))
+		deleteTimestamps = append(deleteTimestamps, 5)
+	}
+	require.NoError(t, segment.segmentDelete(segment.segmentPreDelete(len(pks)), pks, deleteTimestamps))
+
+	defer func(ratio float64, minRows int64) {
+		Params.QueryNodeCfg.GrowingCompactionDeadRatio = ratio
+		Params.QueryNodeCfg.GrowingCompactionMinRows = minRows
+	}(Params.QueryNodeCfg.GrowingCompactionDeadRatio, Params.QueryNodeCfg.GrowingCompactionMinRows)
+	Params.QueryNodeCfg.GrowingCompactionMinRows = 0
+	compactedRows := metrics.QueryNodeCompactedGrowingRows.WithLabelValues(fmt.Sprint(Params.QueryNodeCfg.QueryNodeID))
+	before := testutil.ToFloat64(compactedRows)
+
+	// disabled
+	Params.QueryNodeCfg.GrowingCompactionDeadRatio = 0
+	assert.Nil(t, streaming.compactGrowingSegments())
+
+	// no tSafe of the channel yet
+	Params.QueryNodeCfg.GrowingCompactionDeadRatio = 0.5
+	assert.Empty(t, streaming.compactGrowingSegments())
+
+	tSafe.addTSafe(defaultDMLChannel)
+	require.NoError(t, tSafe.setTSafe(defaultDMLChannel, 10))
+	Params.QueryNodeCfg.GrowingCompactionDeadRatio = 0.7
+	assert.Empty(t, streaming.compactGrowingSegments())
+	assert.Equal(t, int64(N), segment.getRowCount())
+
+	Params.QueryNodeCfg.GrowingCompactionDeadRatio = 0.5
+	assert.Equal(t, map[UniqueID]int64{defaultSegmentID: 60}, streaming.compactGrowingSegments())
+	assert.Equal(t, int64(N-60), segment.getRowCount())
+	assert.Equal(t, before+60, testutil.ToFloat64(compactedRows))
+
+	// nothing is deleted since the compaction
+	assert.Empty(t, streaming.compactGrowingSegments())
+}
+
 func TestStreaming_search(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -48,7 +141,7 @@ func TestStreaming_search(t *testing.T) {
 		plan, searchReqs, err := genSimpleSearchPlanAndRequests(IndexFaissIDMap)
 		assert.NoError(t, err)
 
-		res, _, _, err := streaming.search(searchReqs,
+		res, _, _, err := streaming.search(context.Background(), searchReqs,
 			defaultCollectionID,
 			[]UniqueID{defaultPartitionID},
 			defaultDMLChannel,
@@ -67,7 +160,7 @@ func TestStreaming_search(t *testing.T) {
 		plan, searchReqs, err := genSimpleSearchPlanAndRequests(IndexFaissIDMap)
 		assert.NoError(t, err)
 
-		res, _, _, err := streaming.search(searchReqs,
+		res, _, _, err := streaming.search(context.Background(), searchReqs,
 			defaultCollectionID,
 			[]UniqueID{},
 			defaultDMLChannel,
@@ -93,7 +186,7 @@ func TestStreaming_search(t *testing.T) {
 		err = streaming.replica.removePartition(defaultPartitionID)
 		assert.NoError(t, err)
 
-		res, _, _, err := streaming.search(searchReqs,
+		res, _, _, err := streaming.search(context.Background(), searchReqs,
 			defaultCollectionID,
 			[]UniqueID{defaultPartitionID},
 			defaultDMLChannel,
@@ -119,7 +212,7 @@ func TestStreaming_search(t *testing.T) {
 		err = streaming.replica.removePartition(defaultPartitionID)
 		assert.NoError(t, err)
 
-		_, _, _, err = streaming.search(searchReqs,
+		_, _, _, err = streaming.search(context.Background(), searchReqs,
 			defaultCollectionID,
 			[]UniqueID{defaultPartitionID},
 			defaultDMLChannel,
@@ -140,7 +233,7 @@ func TestStreaming_search(t *testing.T) {
 		err = streaming.replica.removePartition(defaultPartitionID)
 		assert.NoError(t, err)
 
-		res, _, _, err := streaming.search(searchReqs,
+		res, _, _, err := streaming.search(context.Background(), searchReqs,
 			defaultCollectionID,
 			[]UniqueID{},
 			defaultDMLChannel,
@@ -164,7 +257,7 @@ func TestStreaming_search(t *testing.T) {
 
 		seg.segmentPtr = nil
 
-		_, _, _, err = streaming.search(searchReqs,
+		_, _, _, err = streaming.search(context.Background(), searchReqs,
 			defaultCollectionID,
 			[]UniqueID{},
 			defaultDMLChannel,
@@ -22,8 +22,10 @@ import (
 	"fmt"
 	"math/rand"
 	"runtime/debug"
+	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/log"
@@ -32,6 +34,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	queryPb "github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
 type task interface {
@@ -62,6 +65,8 @@ type watchDmChannelsTask struct {
 	baseTask
 	req  *queryPb.WatchDmChannelsRequest
 	node *QueryNode
+
+	channelStatus []*queryPb.DmChannelWatchStatus // the status of each channel of request, set by Execute
 }
 
 type watchDeltaChannelsTask struct {
@@ -188,10 +193,21 @@ func (w *watchDmChannelsTask) PreExecute(ctx context.Context) error {
 	return nil
 }
 
-func (w *watchDmChannelsTask) Execute(ctx context.Context) error {
+func (w *watchDmChannelsTask) Execute(ctx context.Context) (err error) {
 	collectionID := w.req.CollectionID
 	partitionIDs := w.req.GetPartitionIDs()
 
+	// every channel of the request is reported, the ones without status of their own share the outcome of the request
+	requestInfos := w.req.GetInfos()
+	channelStatus := make(map[Channel]*queryPb.DmChannelWatchStatus)
+	defer func() {
+		w.setChannelStatus(requestInfos, channelStatus, err)
+	}()
+
+	if err := validateGrowingChunkRows(w.req.GetGrowingChunkRows()); err != nil {
+		return fmt.Errorf("failed to watch dm channels of collection %d, %w", collectionID, err)
+	}
+
 	lType := w.req.GetLoadMeta().GetLoadType()
 	if lType == queryPb.LoadType_UnKnownType {
 		// if no partitionID is specified, load type is load collection
@@ -202,6 +218,22 @@ func (w *watchDmChannelsTask) Execute(ctx context.Context) error {
 		}
 	}
 
+	// fence the channels by the version of the request, only the channels not watched with the version are watched
+	infos, rebuildOwners, err := w.fenceChannels()
+	if err != nil {
+		return err
+	}
+	if len(infos) == 0 && len(w.req.GetInfos()) > 0 {
+		log.Info("all dm channels are watched with the version of request, ignore the retry",
+			zap.Int64("collectionID", collectionID),
+			zap.Int64("version", w.req.GetVersion()))
+		return nil
+	}
+	for channel, owner := range rebuildOwners {
+		w.releaseChannel(channel, owner)
+	}
+	w.req.Infos = infos
+
 	// get all vChannels
 	vChannels := make([]Channel, 0)
 	pChannels := make([]Channel, 0)
@@ -222,40 +254,157 @@ func (w *watchDmChannelsTask) Execute(ctx context.Context) error {
 		zap.String("collectionName", w.req.Schema.Name),
 		zap.Int64("collectionID", collectionID),
 		zap.Int64("replicaID", w.req.GetReplicaID()),
+		zap.Int64("version", w.req.GetVersion()),
 		zap.Any("load type", lType),
 		zap.Strings("vChannels", vChannels),
 		zap.Strings("pChannels", pChannels),
+		zap.Bool("allOrNothing", w.req.GetAllOrNothing()),
 	)
 
 	// init collection meta
 	sCol := w.node.streaming.replica.addCollection(collectionID, w.req.Schema)
 	hCol := w.node.historical.replica.addCollection(collectionID, w.req.Schema)
+	sCol.setSegmentRowBudget(w.req.GetSegmentRowBudget())
+	sCol.setGrowingChunkRows(w.req.GetGrowingChunkRows())
+	sCol.setTTL(w.req.GetCollectionTtlSeconds())
+	hCol.setTTL(w.req.GetCollectionTtlSeconds())
+	w.node.readLimiter.setCap(collectionID, w.req.GetMaxConcurrentReads())
 
-	//add shard cluster
-	for _, vchannel := range vChannels {
-		w.node.ShardClusterService.addShardCluster(w.req.GetCollectionID(), w.req.GetReplicaID(), vchannel)
-	}
-
-	// load growing segments
-	unFlushedSegments := make([]*queryPb.SegmentLoadInfo, 0)
-	unFlushedSegmentIDs := make([]UniqueID, 0)
+	// update partition info from unFlushedSegments and loadMeta
 	for _, info := range w.req.Infos {
 		for _, ufInfo := range info.UnflushedSegments {
-			// unFlushed segment may not have binLogs, skip loading
 			if len(ufInfo.Binlogs) > 0 {
-				unFlushedSegments = append(unFlushedSegments, &queryPb.SegmentLoadInfo{
-					SegmentID:    ufInfo.ID,
-					PartitionID:  ufInfo.PartitionID,
-					CollectionID: ufInfo.CollectionID,
-					BinlogPaths:  ufInfo.Binlogs,
-					NumOfRows:    ufInfo.NumOfRows,
-					Statslogs:    ufInfo.Statslogs,
-					Deltalogs:    ufInfo.Deltalogs,
-				})
-				unFlushedSegmentIDs = append(unFlushedSegmentIDs, ufInfo.ID)
+				w.node.streaming.replica.addPartition(collectionID, ufInfo.PartitionID)
+				w.node.historical.replica.addPartition(collectionID, ufInfo.PartitionID)
+			}
+		}
+	}
+	for _, partitionID := range w.req.GetLoadMeta().GetPartitionIDs() {
+		w.node.historical.replica.addPartition(collectionID, partitionID)
+		w.node.streaming.replica.addPartition(collectionID, partitionID)
+	}
+
+	// watch the channels concurrently, so that a slow channel, e.g. seeking a big backlog, never delays the others
+	consumeSubName := funcutil.GenChannelSubName(Params.CommonCfg.QueryNodeSubName, collectionID, Params.QueryNodeCfg.QueryNodeID)
+	parallelism := Params.QueryNodeCfg.WatchDmChannelsParallelism
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+	watched := make([]*watchedDmChannel, len(w.req.Infos))
+	errs := make([]error, len(w.req.Infos))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, info := range w.req.Infos {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, info *datapb.VchannelInfo) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			watched[i], errs[i] = w.watchChannel(info, VPChannels[info.ChannelName], consumeSubName)
+		}(i, info)
+	}
+	wg.Wait()
+
+	var firstErr error
+	failed := make([]Channel, 0)
+	for i, info := range w.req.Infos {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			failed = append(failed, info.ChannelName)
+			channelStatus[info.ChannelName] = newDmChannelWatchStatus(info, errs[i])
+		}
+	}
+	if firstErr != nil && w.req.GetAllOrNothing() {
+		for i, info := range w.req.Infos {
+			if watched[i] == nil {
+				continue
+			}
+			w.rollbackChannel(info.ChannelName, watched[i])
+			watched[i] = nil
+			channelStatus[info.ChannelName] = newDmChannelWatchStatus(info,
+				fmt.Errorf("rolled back for the failure of dm channels %v", failed))
+		}
+	}
+
+	watchedChannels := make([]Channel, 0, len(w.req.Infos))
+	watchedPChannels := make([]Channel, 0, len(w.req.Infos))
+	for i, info := range w.req.Infos {
+		if watched[i] != nil {
+			watchedChannels = append(watchedChannels, info.ChannelName)
+			watchedPChannels = append(watchedPChannels, VPChannels[info.ChannelName])
+		}
+	}
+	if len(watchedChannels) > 0 {
+		w.serveChannels(sCol, hCol, lType, watchedChannels, watchedPChannels, rebuildOwners, watched)
+	}
+	for i, info := range w.req.Infos {
+		if watched[i] != nil {
+			channelStatus[info.ChannelName] = &queryPb.DmChannelWatchStatus{
+				Channel:      info.ChannelName,
+				Status:       &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+				SeekPosition: watched[i].position,
+			}
+		} else if _, ok := channelStatus[info.ChannelName]; !ok {
+			// neither failed nor rolled back, skipped for its flow graph exists, which is served already
+			channelStatus[info.ChannelName] = &queryPb.DmChannelWatchStatus{
+				Channel: info.ChannelName,
+				Status:  &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
 			}
 		}
 	}
+
+	if firstErr != nil {
+		log.Warn("failed to watch dm channels", zap.Int64("collectionID", collectionID),
+			zap.Strings("failedChannels", failed), zap.Strings("watchedChannels", watchedChannels), zap.Error(firstErr))
+		return fmt.Errorf("failed to watch dm channels %v of collection %d, %w", failed, collectionID, firstErr)
+	}
+	log.Debug("WatchDmChannels done", zap.Int64("collectionID", collectionID), zap.Strings("vChannels", vChannels))
+	return nil
+}
+
+// watchedDmChannel is a dm channel whose flow graph is created and positioned, but not started yet
+type watchedDmChannel struct {
+	flowGraph         *queryNodeFlowGraph
+	position          *internalpb.MsgPosition // the seek position of request, nil if consumed from the latest position
+	growingSegmentIDs []UniqueID
+}
+
+// watchChannel loads the growing segments of the dm channel, and creates its flow graph positioned at the
+// seek position of info, everything is cleaned up on failure. The channel whose flow graph exists already is
+// skipped, nil is returned without error
+func (w *watchDmChannelsTask) watchChannel(info *datapb.VchannelInfo, pChannel Channel, consumeSubName string) (_ *watchedDmChannel, err error) {
+	collectionID := w.req.GetCollectionID()
+	channel := info.ChannelName
+	if _, err := w.node.dataSyncService.getFlowGraphByDMLChannel(collectionID, channel); err == nil {
+		log.Warn("dml flow graph exists, skip watching dm channel", zap.Int64("collectionID", collectionID), zap.String("vChannel", channel))
+		return nil, nil
+	}
+	result := &watchedDmChannel{}
+	if info.SeekPosition != nil {
+		result.position = proto.Clone(info.SeekPosition).(*internalpb.MsgPosition)
+	}
+
+	// load growing segments
+	unFlushedSegments := make([]*queryPb.SegmentLoadInfo, 0)
+	for _, ufInfo := range info.UnflushedSegments {
+		// unFlushed segment may not have binLogs, skip loading
+		if len(ufInfo.Binlogs) > 0 {
+			unFlushedSegments = append(unFlushedSegments, &queryPb.SegmentLoadInfo{
+				SegmentID:    ufInfo.ID,
+				PartitionID:  ufInfo.PartitionID,
+				CollectionID: ufInfo.CollectionID,
+				BinlogPaths:  ufInfo.Binlogs,
+				NumOfRows:    ufInfo.NumOfRows,
+				Statslogs:    ufInfo.Statslogs,
+				Deltalogs:    ufInfo.Deltalogs,
+			})
+			result.growingSegmentIDs = append(result.growingSegmentIDs, ufInfo.ID)
+		}
+	}
 	req := &queryPb.LoadSegmentsRequest{
 		Base: &commonpb.MsgBase{
 			MsgType: commonpb.MsgType_LoadSegments,
@@ -266,152 +415,101 @@ func (w *watchDmChannelsTask) Execute(ctx context.Context) error {
 		Schema:       w.req.GetSchema(),
 		LoadMeta:     w.req.GetLoadMeta(),
 	}
-
-	// update partition info from unFlushedSegments and loadMeta
-	for _, info := range req.Infos {
-		w.node.streaming.replica.addPartition(collectionID, info.PartitionID)
-		w.node.historical.replica.addPartition(collectionID, info.PartitionID)
-	}
-	for _, partitionID := range req.GetLoadMeta().GetPartitionIDs() {
-		w.node.historical.replica.addPartition(collectionID, partitionID)
-		w.node.streaming.replica.addPartition(collectionID, partitionID)
-	}
-
 	log.Debug("loading growing segments in WatchDmChannels...",
 		zap.Int64("collectionID", collectionID),
-		zap.Int64s("unFlushedSegmentIDs", unFlushedSegmentIDs),
+		zap.String("vChannel", channel),
+		zap.Int64s("unFlushedSegmentIDs", result.growingSegmentIDs),
 	)
-	err := w.node.loader.loadSegment(req, segmentTypeGrowing)
-	if err != nil {
-		return err
+	if err = w.node.loader.loadSegment(req, segmentTypeGrowing); err != nil {
+		return nil, err
 	}
-	log.Debug("successfully load growing segments done in WatchDmChannels",
-		zap.Int64("collectionID", collectionID),
-		zap.Int64s("unFlushedSegmentIDs", unFlushedSegmentIDs),
-	)
-
 	// remove growing segment if watch dmChannels failed
 	defer func() {
 		if err != nil {
-			for _, segmentID := range unFlushedSegmentIDs {
+			for _, segmentID := range result.growingSegmentIDs {
 				w.node.streaming.replica.removeSegment(segmentID)
 			}
 		}
 	}()
 
-	consumeSubName := funcutil.GenChannelSubName(Params.CommonCfg.QueryNodeSubName, collectionID, Params.QueryNodeCfg.QueryNodeID)
-
-	// group channels by to seeking or consuming
-	channel2SeekPosition := make(map[string]*internalpb.MsgPosition)
-	channel2AsConsumerPosition := make(map[string]*internalpb.MsgPosition)
-	for _, info := range w.req.Infos {
-		if info.SeekPosition == nil || len(info.SeekPosition.MsgID) == 0 {
-			channel2AsConsumerPosition[info.ChannelName] = info.SeekPosition
-			continue
-		}
-		info.SeekPosition.MsgGroup = consumeSubName
-		channel2SeekPosition[info.ChannelName] = info.SeekPosition
+	// the channel is consumed from the latest position without seek position
+	seekPosition := info.SeekPosition
+	if seekPosition != nil && len(seekPosition.MsgID) == 0 {
+		seekPosition = nil
 	}
-	log.Debug("watchDMChannel, group channels done", zap.Int64("collectionID", collectionID))
 
 	// add excluded segments for unFlushed segments,
 	// unFlushed segments before check point should be filtered out.
-	unFlushedCheckPointInfos := make([]*datapb.SegmentInfo, 0)
-	for _, info := range w.req.Infos {
-		unFlushedCheckPointInfos = append(unFlushedCheckPointInfos, info.UnflushedSegments...)
-	}
-	w.node.streaming.replica.addExcludedSegments(collectionID, unFlushedCheckPointInfos)
-	log.Debug("watchDMChannel, add check points info for unFlushed segments done",
-		zap.Int64("collectionID", collectionID),
-		zap.Any("unFlushedCheckPointInfos", unFlushedCheckPointInfos),
-	)
-
-	// add excluded segments for flushed segments,
-	// flushed segments with later check point than seekPosition should be filtered out.
-	flushedCheckPointInfos := make([]*datapb.SegmentInfo, 0)
-	for _, info := range w.req.Infos {
-		for _, flushedSegment := range info.FlushedSegments {
-			for _, position := range channel2SeekPosition {
-				if flushedSegment.DmlPosition != nil &&
-					flushedSegment.DmlPosition.ChannelName == position.ChannelName &&
-					flushedSegment.DmlPosition.Timestamp > position.Timestamp {
-					flushedCheckPointInfos = append(flushedCheckPointInfos, flushedSegment)
+	w.node.streaming.replica.addExcludedSegments(collectionID, info.UnflushedSegments)
+
+	// add excluded segments for flushed and dropped segments,
+	// flushed and dropped segments with later check point than seekPosition should be filtered out.
+	if seekPosition != nil {
+		checkPointInfos := make([]*datapb.SegmentInfo, 0)
+		for _, segments := range [][]*datapb.SegmentInfo{info.FlushedSegments, info.DroppedSegments} {
+			for _, segment := range segments {
+				if segment.GetDmlPosition().GetChannelName() == seekPosition.ChannelName &&
+					segment.GetDmlPosition().GetTimestamp() > seekPosition.Timestamp {
+					checkPointInfos = append(checkPointInfos, segment)
 				}
 			}
 		}
+		w.node.streaming.replica.addExcludedSegments(collectionID, checkPointInfos)
 	}
-	w.node.streaming.replica.addExcludedSegments(collectionID, flushedCheckPointInfos)
-	log.Debug("watchDMChannel, add check points info for flushed segments done",
-		zap.Int64("collectionID", collectionID),
-		zap.Any("flushedCheckPointInfos", flushedCheckPointInfos),
-	)
-
-	// add excluded segments for dropped segments,
-	// dropped segments with later check point than seekPosition should be filtered out.
-	droppedCheckPointInfos := make([]*datapb.SegmentInfo, 0)
-	for _, info := range w.req.Infos {
-		for _, droppedSegment := range info.DroppedSegments {
-			for _, position := range channel2SeekPosition {
-				if droppedSegment != nil &&
-					droppedSegment.DmlPosition.ChannelName == position.ChannelName &&
-					droppedSegment.DmlPosition.Timestamp > position.Timestamp {
-					droppedCheckPointInfos = append(droppedCheckPointInfos, droppedSegment)
-				}
-			}
-		}
-	}
-	w.node.streaming.replica.addExcludedSegments(collectionID, droppedCheckPointInfos)
-	log.Debug("watchDMChannel, add check points info for dropped segments done",
-		zap.Int64("collectionID", collectionID),
-		zap.Any("droppedCheckPointInfos", droppedCheckPointInfos),
-	)
 
 	// add flow graph
-	channel2FlowGraph, err := w.node.dataSyncService.addFlowGraphsForDMLChannels(collectionID, vChannels)
+	channel2FlowGraph, err := w.node.dataSyncService.addFlowGraphsForDMLChannels(collectionID, []Channel{channel})
 	if err != nil {
-		log.Warn("watchDMChannel, add flowGraph for dmChannels failed", zap.Int64("collectionID", collectionID), zap.Strings("vChannels", vChannels), zap.Error(err))
-		return err
+		log.Warn("watchDMChannel, add flowGraph for dmChannel failed", zap.Int64("collectionID", collectionID), zap.String("vChannel", channel), zap.Error(err))
+		return nil, err
 	}
-	log.Debug("Query node add DML flow graphs", zap.Int64("collectionID", collectionID), zap.Any("channels", vChannels))
+	fg, ok := channel2FlowGraph[channel]
+	if !ok {
+		return nil, fmt.Errorf("dml flow graph of dm channel %s is added concurrently by another watch", channel)
+	}
+	result.flowGraph = fg
 
-	// channels as consumer
-	for channel, fg := range channel2FlowGraph {
-		if _, ok := channel2AsConsumerPosition[channel]; ok {
-			// use pChannel to consume
-			err = fg.consumeFlowGraph(VPChannels[channel], consumeSubName)
-			if err != nil {
-				log.Error("msgStream as consumer failed for dmChannels", zap.Int64("collectionID", collectionID), zap.String("vChannel", channel))
-				break
-			}
-		}
+	if seekPosition == nil {
+		// use pChannel to consume
+		err = result.flowGraph.consumeFlowGraph(pChannel, consumeSubName)
+	} else {
+		seekPosition.MsgGroup = consumeSubName
+		// use pChannel to seek
+		seekPosition.ChannelName = pChannel
+		err = result.flowGraph.seekQueryNodeFlowGraph(seekPosition)
+	}
+	if err != nil {
+		log.Warn("msgStream consume or seek failed for dmChannel", zap.Int64("collectionID", collectionID), zap.String("vChannel", channel), zap.Error(err))
+		result.flowGraph.flowGraph.Close()
+		w.node.dataSyncService.removeFlowGraphsByDMLChannels([]Channel{channel})
+		return nil, err
+	}
+	return result, nil
+}
 
-		if pos, ok := channel2SeekPosition[channel]; ok {
-			pos.MsgGroup = consumeSubName
-			// use pChannel to seek
-			pos.ChannelName = VPChannels[channel]
-			err = fg.seekQueryNodeFlowGraph(pos)
-			if err != nil {
-				log.Error("msgStream seek failed for dmChannels", zap.Int64("collectionID", collectionID), zap.String("vChannel", channel))
-				break
-			}
-		}
+// rollbackChannel tears down the dm channel watched by watchChannel
+func (w *watchDmChannelsTask) rollbackChannel(channel Channel, watched *watchedDmChannel) {
+	log.Info("roll back watched dm channel", zap.Int64("collectionID", w.req.GetCollectionID()), zap.String("vChannel", channel))
+	watched.flowGraph.flowGraph.Close()
+	w.node.dataSyncService.removeFlowGraphsByDMLChannels([]Channel{channel})
+	for _, segmentID := range watched.growingSegmentIDs {
+		w.node.streaming.replica.removeSegment(segmentID)
 	}
+}
 
-	if err != nil {
-		log.Warn("watchDMChannel, add flowGraph for dmChannels failed", zap.Int64("collectionID", collectionID), zap.Strings("vChannels", vChannels), zap.Error(err))
-		for _, fg := range channel2FlowGraph {
-			fg.flowGraph.Close()
-		}
-		gcChannels := make([]Channel, 0)
-		for channel := range channel2FlowGraph {
-			gcChannels = append(gcChannels, channel)
+// serveChannels registers the watched dm channels to the replicas, the query shards and the ownership,
+// and starts their flow graphs
+func (w *watchDmChannelsTask) serveChannels(sCol, hCol *Collection, lType queryPb.LoadType, vChannels, pChannels []Channel,
+	rebuildOwners map[Channel]*channelOwner, watched []*watchedDmChannel) {
+	collectionID := w.req.GetCollectionID()
+
+	// add shard cluster, the rebuilt channels keep theirs
+	for _, vchannel := range vChannels {
+		if _, ok := rebuildOwners[vchannel]; !ok {
+			w.node.ShardClusterService.addShardCluster(collectionID, w.req.GetReplicaID(), vchannel)
 		}
-		w.node.dataSyncService.removeFlowGraphsByDMLChannels(gcChannels)
-		return err
 	}
 
-	log.Debug("watchDMChannel, add flowGraph for dmChannels success", zap.Int64("collectionID", collectionID), zap.Strings("vChannels", vChannels))
-
 	sCol.addVChannels(vChannels)
 	sCol.addPChannels(pChannels)
 	sCol.setLoadType(lType)
@@ -426,8 +524,11 @@ func (w *watchDmChannelsTask) Execute(ctx context.Context) error {
 		w.node.tSafeReplica.addTSafe(channel)
 	}
 
-	// add tsafe watch in query shard if exists
+	// add tsafe watch in query shard if exists, the query shards of the rebuilt channels are watching already
 	for _, dmlChannel := range vChannels {
+		if _, ok := rebuildOwners[dmlChannel]; ok {
+			continue
+		}
 		if !w.node.queryShardService.hasQueryShard(dmlChannel) {
 			//TODO add replica id in req
 			w.node.queryShardService.addQueryShard(collectionID, dmlChannel, 0)
@@ -445,12 +546,112 @@ func (w *watchDmChannelsTask) Execute(ctx context.Context) error {
 	}
 
 	// start flow graphs
-	for _, fg := range channel2FlowGraph {
-		fg.flowGraph.Start()
+	for _, channel := range watched {
+		if channel != nil {
+			channel.flowGraph.flowGraph.Start()
+		}
 	}
 
-	log.Debug("WatchDmChannels done", zap.Int64("collectionID", collectionID), zap.Strings("vChannels", vChannels))
-	return nil
+	for i, info := range w.req.Infos {
+		if watched[i] == nil {
+			continue
+		}
+		w.node.dmChannelOwnership.own(info.ChannelName, &channelOwner{
+			collectionID:      collectionID,
+			replicaID:         w.req.GetReplicaID(),
+			version:           w.req.GetVersion(),
+			position:          watched[i].position,
+			growingSegmentIDs: watched[i].growingSegmentIDs,
+		})
+	}
+}
+
+// setChannelStatus sets the status of every channel of infos, the channels without status of their own, e.g.
+// the ones ignored as retries, or the ones of a request failed as a whole, share the outcome of the request
+func (w *watchDmChannelsTask) setChannelStatus(infos []*datapb.VchannelInfo, channelStatus map[Channel]*queryPb.DmChannelWatchStatus, err error) {
+	w.channelStatus = make([]*queryPb.DmChannelWatchStatus, 0, len(infos))
+	for _, info := range infos {
+		status, ok := channelStatus[info.GetChannelName()]
+		if !ok {
+			status = newDmChannelWatchStatus(info, err)
+		}
+		w.channelStatus = append(w.channelStatus, status)
+	}
+}
+
+func newDmChannelWatchStatus(info *datapb.VchannelInfo, err error) *queryPb.DmChannelWatchStatus {
+	status := &queryPb.DmChannelWatchStatus{
+		Channel: info.GetChannelName(),
+		Status:  &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+	}
+	if err != nil {
+		status.Status = toStatus(err)
+	} else if info.GetSeekPosition() != nil {
+		status.SeekPosition = proto.Clone(info.GetSeekPosition()).(*internalpb.MsgPosition)
+	}
+	return status
+}
+
+// fenceChannels returns the infos of the channels to watch and the current owners of the channels to rebuild,
+// the request is rejected as a whole if it is stale on any channel
+func (w *watchDmChannelsTask) fenceChannels() ([]*datapb.VchannelInfo, map[Channel]*channelOwner, error) {
+	infos := make([]*datapb.VchannelInfo, 0, len(w.req.GetInfos()))
+	rebuildOwners := make(map[Channel]*channelOwner)
+	for _, info := range w.req.GetInfos() {
+		action, owner := w.node.dmChannelOwnership.check(info.GetChannelName(), w.req.GetVersion())
+		switch action {
+		case watchActionStale:
+			log.Warn("reject stale watch request",
+				zap.Int64("collectionID", w.req.GetCollectionID()),
+				zap.String("channel", info.GetChannelName()),
+				zap.Int64("version", w.req.GetVersion()),
+				zap.Int64("currentVersion", owner.version))
+			return nil, nil, &staleWatchError{channel: info.GetChannelName(), version: w.req.GetVersion(), current: owner.version}
+		case watchActionDuplicate:
+			continue
+		case watchActionRebuild:
+			rebuildOwners[info.GetChannelName()] = owner
+		}
+		infos = append(infos, info)
+	}
+	return infos, rebuildOwners, nil
+}
+
+// releaseChannel tears down the flow graph and the growing segments of the channel watched with owner, so that
+// the channel could be watched again from the positions of a newer request
+func (w *watchDmChannelsTask) releaseChannel(channel Channel, owner *channelOwner) {
+	log.Info("rebuild dm channel for newer watch request",
+		zap.Int64("collectionID", owner.collectionID),
+		zap.String("channel", channel),
+		zap.Int64("version", w.req.GetVersion()),
+		zap.Int64("currentVersion", owner.version))
+
+	w.node.dataSyncService.removeFlowGraphsByDMLChannels([]Channel{channel})
+
+	// the new flow graph replays from an older position, the reads wait for it to catch up rather than being
+	// served at the tSafe of the torn down one without the rows of the removed growing segments
+	if err := w.node.tSafeReplica.setTSafe(channel, typeutil.ZeroTimestamp); err != nil {
+		log.Warn("failed to reset tSafe of rebuilt dm channel", zap.String("channel", channel), zap.Error(err))
+	}
+	if qs, err := w.node.queryShardService.getQueryShard(channel); err == nil {
+		qs.resetServiceableTime(tsTypeDML)
+	}
+
+	segmentIDs := append([]UniqueID{}, owner.growingSegmentIDs...)
+	partitionIDs, err := w.node.streaming.replica.getPartitionIDs(owner.collectionID)
+	if err == nil {
+		for _, partitionID := range partitionIDs {
+			ids, err := w.node.streaming.replica.getSegmentIDsByVChannel(partitionID, channel)
+			if err == nil {
+				segmentIDs = append(segmentIDs, ids...)
+			}
+		}
+	}
+	for _, segmentID := range segmentIDs {
+		w.node.streaming.replica.removeSegment(segmentID)
+	}
+
+	w.node.dmChannelOwnership.release([]Channel{channel})
 }
 
 func (w *watchDmChannelsTask) PostExecute(ctx context.Context) error {
@@ -522,6 +723,16 @@ func (w *watchDeltaChannelsTask) Execute(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	// the delta channels may be watched before any sealed segment is loaded, preload the partitions so that the
+	// deletes of the partitions are buffered until their segments are loaded
+	for _, partitionID := range w.req.GetLoadMeta().GetPartitionIDs() {
+		if err := w.node.historical.replica.addPartition(collectionID, partitionID); err != nil {
+			return err
+		}
+		if err := w.node.streaming.replica.addPartition(collectionID, partitionID); err != nil {
+			return err
+		}
+	}
 
 	channel2FlowGraph, err := w.node.dataSyncService.addFlowGraphsForDeltaChannels(collectionID, vDeltaChannels)
 	if err != nil {
@@ -635,8 +846,11 @@ func (l *loadSegmentsTask) Execute(ctx context.Context) error {
 
 	// init meta
 	collectionID := l.req.GetCollectionID()
-	l.node.historical.replica.addCollection(collectionID, l.req.GetSchema())
-	l.node.streaming.replica.addCollection(collectionID, l.req.GetSchema())
+	hCol := l.node.historical.replica.addCollection(collectionID, l.req.GetSchema())
+	sCol := l.node.streaming.replica.addCollection(collectionID, l.req.GetSchema())
+	// the followers only learn the TTL from the segments they load
+	hCol.setTTL(l.req.GetCollectionTtlSeconds())
+	sCol.setTTL(l.req.GetCollectionTtlSeconds())
 	for _, partitionID := range l.req.GetLoadMeta().GetPartitionIDs() {
 		err = l.node.historical.replica.addPartition(collectionID, partitionID)
 		if err != nil {
@@ -703,6 +917,7 @@ func (r *releaseCollectionTask) Execute(ctx context.Context) error {
 	)
 
 	r.node.queryShardService.releaseCollection(r.req.CollectionID)
+	r.node.readLimiter.removeCollection(r.req.CollectionID)
 
 	err := r.releaseReplica(r.node.streaming.replica, replicaStreaming)
 	if err != nil {
@@ -736,6 +951,7 @@ func (r *releaseCollectionTask) releaseReplica(replica ReplicaInterface, replica
 	if replicaType == replicaStreaming {
 		channels = collection.getVChannels()
 		r.node.dataSyncService.removeFlowGraphsByDMLChannels(channels)
+		r.node.dmChannelOwnership.release(channels)
 	} else {
 		// remove all tSafes and flow graphs of the target collection
 		channels = collection.getVDeltaChannels()
@@ -18,11 +18,14 @@ package querynode
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/apache/pulsar-client-go/pulsar"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/milvus-io/milvus/internal/mq/msgstream"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -250,6 +253,32 @@ func TestTask_watchDmChannelsTask(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("test execute with growing chunk rows", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		assert.NoError(t, err)
+
+		task := watchDmChannelsTask{
+			req:  genWatchDMChannelsRequest(),
+			node: node,
+		}
+		task.req.Infos = []*datapb.VchannelInfo{
+			{
+				CollectionID: defaultCollectionID,
+				ChannelName:  defaultDMLChannel,
+			},
+		}
+		task.req.GrowingChunkRows = 1
+		err = task.Execute(ctx)
+		assert.Error(t, err)
+
+		task.req.GrowingChunkRows = 1024
+		err = task.Execute(ctx)
+		assert.NoError(t, err)
+		col, err := node.streaming.replica.getCollectionByID(defaultCollectionID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1024), col.getGrowingChunkRows())
+	})
+
 	t.Run("test execute loadPartition", func(t *testing.T) {
 		node, err := genSimpleQueryNode(ctx)
 		assert.NoError(t, err)
@@ -273,6 +302,32 @@ func TestTask_watchDmChannelsTask(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("test execute with collection ttl", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		assert.NoError(t, err)
+
+		task := watchDmChannelsTask{
+			req:  genWatchDMChannelsRequest(),
+			node: node,
+		}
+		task.req.Infos = []*datapb.VchannelInfo{
+			{
+				CollectionID: defaultCollectionID,
+				ChannelName:  defaultDMLChannel,
+			},
+		}
+		task.req.CollectionTtlSeconds = 3600
+		err = task.Execute(ctx)
+		assert.NoError(t, err)
+
+		sCol, err := node.streaming.replica.getCollectionByID(defaultCollectionID)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Hour, sCol.getTTL())
+		hCol, err := node.historical.replica.getCollectionByID(defaultCollectionID)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Hour, hCol.getTTL())
+	})
+
 	t.Run("test execute loadPartition without init collection and partition", func(t *testing.T) {
 		node, err := genSimpleQueryNode(ctx)
 		assert.NoError(t, err)
@@ -415,6 +470,212 @@ func TestTask_watchDmChannelsTask(t *testing.T) {
 		err = task.Execute(ctx)
 		assert.NoError(t, err)
 	})
+
+	t.Run("test execute partial failure", func(t *testing.T) {
+		genInfos := func() []*datapb.VchannelInfo {
+			return []*datapb.VchannelInfo{
+				{
+					CollectionID: defaultCollectionID,
+					ChannelName:  defaultDMLChannel,
+				},
+				{
+					CollectionID: defaultCollectionID,
+					ChannelName:  defaultDMLChannel + "_1",
+					SeekPosition: &internalpb.MsgPosition{
+						ChannelName: defaultDMLChannel + "_1",
+						MsgID:       []byte{1, 2, 3, 4, 5, 6, 7, 8},
+					},
+				},
+			}
+		}
+
+		t.Run("channels watched independently", func(t *testing.T) {
+			node, err := genSimpleQueryNode(ctx)
+			require.NoError(t, err)
+
+			task := watchDmChannelsTask{
+				req:  genWatchDMChannelsRequest(),
+				node: node,
+			}
+			task.req.Infos = genInfos()
+			err = task.Execute(ctx)
+			assert.Error(t, err)
+
+			require.Len(t, task.channelStatus, 2)
+			assert.Equal(t, defaultDMLChannel, task.channelStatus[0].GetChannel())
+			assert.Equal(t, commonpb.ErrorCode_Success, task.channelStatus[0].GetStatus().GetErrorCode())
+			assert.Equal(t, defaultDMLChannel+"_1", task.channelStatus[1].GetChannel())
+			assert.NotEqual(t, commonpb.ErrorCode_Success, task.channelStatus[1].GetStatus().GetErrorCode())
+
+			// the succeeded channel is served, the failed one is cleaned up
+			_, err = node.dataSyncService.getFlowGraphByDMLChannel(defaultCollectionID, defaultDMLChannel)
+			assert.NoError(t, err)
+			_, ok := node.dmChannelOwnership.get(defaultDMLChannel)
+			assert.True(t, ok)
+			_, err = node.dataSyncService.getFlowGraphByDMLChannel(defaultCollectionID, defaultDMLChannel+"_1")
+			assert.Error(t, err)
+			_, ok = node.dmChannelOwnership.get(defaultDMLChannel + "_1")
+			assert.False(t, ok)
+		})
+
+		t.Run("all or nothing", func(t *testing.T) {
+			node, err := genSimpleQueryNode(ctx)
+			require.NoError(t, err)
+
+			task := watchDmChannelsTask{
+				req:  genWatchDMChannelsRequest(),
+				node: node,
+			}
+			task.req.Infos = genInfos()
+			task.req.AllOrNothing = true
+			err = task.Execute(ctx)
+			assert.Error(t, err)
+
+			require.Len(t, task.channelStatus, 2)
+			for _, status := range task.channelStatus {
+				assert.NotEqual(t, commonpb.ErrorCode_Success, status.GetStatus().GetErrorCode())
+			}
+
+			// the succeeded channel is rolled back
+			assert.Empty(t, node.dataSyncService.dmlChannel2FlowGraph)
+			_, ok := node.dmChannelOwnership.get(defaultDMLChannel)
+			assert.False(t, ok)
+		})
+
+		t.Run("request failure", func(t *testing.T) {
+			node, err := genSimpleQueryNode(ctx)
+			require.NoError(t, err)
+
+			task := watchDmChannelsTask{
+				req:  genWatchDMChannelsRequest(),
+				node: node,
+			}
+			task.req.Infos = genInfos()
+			task.req.GrowingChunkRows = 1
+			err = task.Execute(ctx)
+			assert.Error(t, err)
+
+			// every channel shares the failure of request
+			require.Len(t, task.channelStatus, 2)
+			for _, status := range task.channelStatus {
+				assert.NotEqual(t, commonpb.ErrorCode_Success, status.GetStatus().GetErrorCode())
+			}
+		})
+	})
+
+	t.Run("test execute flow graph exists", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		fgs, err := node.dataSyncService.addFlowGraphsForDMLChannels(defaultCollectionID, []Channel{defaultDMLChannel})
+		require.NoError(t, err)
+		existing := fgs[defaultDMLChannel]
+
+		task := watchDmChannelsTask{
+			req:  genWatchDMChannelsRequest(),
+			node: node,
+		}
+		task.req.Infos = []*datapb.VchannelInfo{
+			{
+				CollectionID: defaultCollectionID,
+				ChannelName:  defaultDMLChannel,
+			},
+		}
+		task.req.AllOrNothing = true
+		require.NoError(t, task.Execute(ctx))
+
+		// the channel is skipped, the existing flow graph is kept
+		require.Len(t, task.channelStatus, 1)
+		assert.Equal(t, commonpb.ErrorCode_Success, task.channelStatus[0].GetStatus().GetErrorCode())
+		fg, err := node.dataSyncService.getFlowGraphByDMLChannel(defaultCollectionID, defaultDMLChannel)
+		require.NoError(t, err)
+		assert.Same(t, existing, fg)
+	})
+
+	t.Run("test execute fencing by version", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		assert.NoError(t, err)
+
+		execute := func(version int64, ts Timestamp) error {
+			task := watchDmChannelsTask{
+				req:  genWatchDMChannelsRequest(),
+				node: node,
+			}
+			task.req.Version = version
+			task.req.Infos = []*datapb.VchannelInfo{
+				{
+					CollectionID: defaultCollectionID,
+					ChannelName:  defaultDMLChannel,
+					SeekPosition: &internalpb.MsgPosition{
+						ChannelName: defaultDMLChannel,
+						Timestamp:   ts,
+					},
+				},
+			}
+			return task.Execute(ctx)
+		}
+		checkOwner := func(version int64, ts Timestamp) *queryNodeFlowGraph {
+			assert.Len(t, node.dataSyncService.dmlChannel2FlowGraph, 1)
+			fg, err := node.dataSyncService.getFlowGraphByDMLChannel(defaultCollectionID, defaultDMLChannel)
+			assert.NoError(t, err)
+			owner, ok := node.dmChannelOwnership.get(defaultDMLChannel)
+			assert.True(t, ok)
+			assert.Equal(t, version, owner.version)
+			assert.Equal(t, ts, owner.position.GetTimestamp())
+			return fg
+		}
+
+		err = execute(2, 100)
+		assert.NoError(t, err)
+		fg := checkOwner(2, 100)
+
+		// the retry is ignored
+		err = execute(2, 200)
+		assert.NoError(t, err)
+		assert.Same(t, fg, checkOwner(2, 100))
+		assert.True(t, node.streaming.replica.hasSegment(defaultSegmentID))
+
+		// the stale request is rejected
+		err = execute(1, 300)
+		var staleErr *staleWatchError
+		assert.True(t, errors.As(err, &staleErr))
+		assert.Same(t, fg, checkOwner(2, 100))
+
+		// the newer request rebuilds the flow graph and drops the growing segments of the channel,
+		// the reads wait for the new flow graph rather than being served at the tSafe of the old one
+		assert.NoError(t, node.tSafeReplica.setTSafe(defaultDMLChannel, 1000))
+		qs, err := node.queryShardService.getQueryShard(defaultDMLChannel)
+		assert.NoError(t, err)
+		qs.setServiceableTime(1000, tsTypeDML)
+		err = execute(3, 400)
+		assert.NoError(t, err)
+		fg = checkOwner(3, 400)
+		assert.False(t, node.streaming.replica.hasSegment(defaultSegmentID))
+		tSafe, err := node.tSafeReplica.getTSafe(defaultDMLChannel)
+		assert.NoError(t, err)
+		assert.Less(t, tSafe, Timestamp(1000))
+		assert.Less(t, qs.getTSafe(tsTypeDML), Timestamp(1000))
+
+		// the unversioned request is never taken as a retry
+		err = execute(0, 450)
+		assert.NoError(t, err)
+		assert.NotSame(t, fg, checkOwner(0, 450))
+
+		// the channel released is watched by the requests of any version
+		releaseTask := releaseCollectionTask{
+			req: &querypb.ReleaseCollectionRequest{
+				Base:         genCommonMsgBase(commonpb.MsgType_ReleaseCollection),
+				CollectionID: defaultCollectionID,
+			},
+			node: node,
+		}
+		err = releaseTask.releaseReplica(node.streaming.replica, replicaStreaming)
+		assert.NoError(t, err)
+		_, ok := node.dmChannelOwnership.get(defaultDMLChannel)
+		assert.False(t, ok)
+		err = execute(1, 500)
+		assert.NoError(t, err)
+		checkOwner(1, 500)
+	})
 }
 
 func TestTask_watchDeltaChannelsTask(t *testing.T) {
@@ -573,6 +834,43 @@ func TestTask_loadSegmentsTask(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("test execute set ttl", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		assert.NoError(t, err)
+
+		fieldBinlog, err := saveSimpleBinLog(ctx)
+		assert.NoError(t, err)
+
+		req := &querypb.LoadSegmentsRequest{
+			Base:                 genCommonMsgBase(commonpb.MsgType_LoadSegments),
+			CollectionID:         defaultCollectionID,
+			Schema:               genSimpleInsertDataSchema(),
+			CollectionTtlSeconds: 3600,
+			Infos: []*querypb.SegmentLoadInfo{
+				{
+					SegmentID:    defaultSegmentID,
+					PartitionID:  defaultPartitionID,
+					CollectionID: defaultCollectionID,
+					BinlogPaths:  fieldBinlog,
+				},
+			},
+		}
+
+		task := loadSegmentsTask{
+			req:  req,
+			node: node,
+		}
+		err = task.Execute(ctx)
+		assert.NoError(t, err)
+
+		hCol, err := node.historical.replica.getCollectionByID(defaultCollectionID)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Hour, hCol.getTTL())
+		sCol, err := node.streaming.replica.getCollectionByID(defaultCollectionID)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Hour, sCol.getTTL())
+	})
+
 	t.Run("test execute grpc error", func(t *testing.T) {
 		node, err := genSimpleQueryNode(ctx)
 		assert.NoError(t, err)
@@ -620,8 +918,7 @@ func TestTask_loadSegmentsTask(t *testing.T) {
 		col, err := node.historical.replica.getCollectionByID(defaultCollectionID)
 		assert.NoError(t, err)
 
-		sizePerRecord, err := typeutil.EstimateSizePerRecord(col.schema)
-		assert.NoError(t, err)
+		sizePerRecord := col.EstimateRowSize(nil)
 
 		task := loadSegmentsTask{
 			req:  genLoadEmptySegmentsRequest(),
@@ -632,12 +929,12 @@ func TestTask_loadSegmentsTask(t *testing.T) {
 				SegmentID:    defaultSegmentID,
 				PartitionID:  defaultPartitionID,
 				CollectionID: defaultCollectionID,
-				NumOfRows:    totalRAM / int64(sizePerRecord),
+				NumOfRows:    totalRAM / sizePerRecord,
 				SegmentSize:  totalRAM,
 			},
 		}
 		// Reach the segment size that would cause OOM
-		for node.loader.checkSegmentSize(defaultCollectionID, task.req.Infos, 1) == nil {
+		for node.loader.checkSegmentSize(defaultCollectionID, task.req.Infos, 1, segmentTypeSealed, false) == nil {
 			task.req.Infos[0].SegmentSize *= 2
 		}
 		err = task.Execute(ctx)
@@ -18,6 +18,7 @@ package querynode
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"go.uber.org/zap"
@@ -32,6 +33,7 @@ type TSafeReplicaInterface interface {
 	addTSafe(vChannel Channel)
 	removeTSafe(vChannel Channel)
 	registerTSafeWatcher(vChannel Channel, watcher *tSafeWatcher) error
+	getTSafeChannels() []Channel
 }
 
 // tSafeReplica implements `TSafeReplicaInterface` interface.
@@ -107,6 +109,18 @@ func (t *tSafeReplica) registerTSafeWatcher(vChannel Channel, watcher *tSafeWatc
 	return ts.registerTSafeWatcher(watcher)
 }
 
+// getTSafeChannels returns the sorted channels with tSafe
+func (t *tSafeReplica) getTSafeChannels() []Channel {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	channels := make([]Channel, 0, len(t.tSafes))
+	for channel := range t.tSafes {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
 func newTSafeReplica() TSafeReplicaInterface {
 	var replica TSafeReplicaInterface = &tSafeReplica{
 		tSafes: make(map[string]*tSafe),
@@ -40,9 +40,13 @@ func TestTSafeReplica(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, timestamp, resT)
 
+		replica.addTSafe(defaultDeltaChannel)
+		assert.Equal(t, []Channel{defaultDMLChannel, defaultDeltaChannel}, replica.getTSafeChannels())
+
 		replica.removeTSafe(defaultDMLChannel)
 		_, err = replica.getTSafe(defaultDMLChannel)
 		assert.Error(t, err)
+		assert.Equal(t, []Channel{defaultDeltaChannel}, replica.getTSafeChannels())
 	})
 
 	t.Run("test invalid", func(t *testing.T) {
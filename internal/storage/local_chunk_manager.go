@@ -110,7 +110,7 @@ func (lcm *LocalChunkManager) Exist(filePath string) bool {
 // Read reads the local storage data if exists.
 func (lcm *LocalChunkManager) Read(filePath string) ([]byte, error) {
 	if !lcm.Exist(filePath) {
-		return nil, fmt.Errorf("file not exist: %s", filePath)
+		return nil, WrapErrNoSuchKey(filePath)
 	}
 	absPath := path.Join(lcm.localPath, filePath)
 	file, err := os.Open(path.Clean(absPath))
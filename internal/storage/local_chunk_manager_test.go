@@ -77,7 +77,7 @@ func TestLocalCM(t *testing.T) {
 						return
 					}
 					got, err := testCM.Read(path.Join(testLoadRoot, test.loadKey))
-					assert.Error(t, err)
+					assert.True(t, IsErrNoSuchKey(err))
 					assert.Empty(t, got)
 				}
 			})
@@ -235,7 +235,7 @@ func TestLocalCM(t *testing.T) {
 				assert.NoError(t, err)
 
 				v, err = testCM.Read(k)
-				require.Error(t, err)
+				require.True(t, IsErrNoSuchKey(err))
 				require.Empty(t, v)
 			})
 		}
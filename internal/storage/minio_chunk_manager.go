@@ -160,7 +160,11 @@ func (mcm *MinioChunkManager) Read(filePath string) ([]byte, error) {
 	}
 	defer object.Close()
 
-	return ioutil.ReadAll(object)
+	data, err := ioutil.ReadAll(object)
+	if err != nil && minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return nil, WrapErrNoSuchKey(filePath)
+	}
+	return data, err
 }
 
 func (mcm *MinioChunkManager) MultiRead(keys []string) ([][]byte, error) {
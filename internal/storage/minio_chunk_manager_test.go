@@ -260,7 +260,7 @@ func TestMinIOCM(t *testing.T) {
 				assert.NoError(t, err)
 
 				v, err = testCM.Read(k)
-				require.Error(t, err)
+				require.True(t, IsErrNoSuchKey(err))
 				require.Empty(t, v)
 			})
 		}
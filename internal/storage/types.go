@@ -12,11 +12,26 @@
 package storage
 
 import (
+	"errors"
+	"fmt"
 	"io"
 
 	"golang.org/x/exp/mmap"
 )
 
+// ErrNoSuchKey is the error of reading a file which does not exist, e.g. removed by garbage collection
+var ErrNoSuchKey = errors.New("NoSuchKey")
+
+// WrapErrNoSuchKey returns an ErrNoSuchKey of filePath
+func WrapErrNoSuchKey(filePath string) error {
+	return fmt.Errorf("%w(key=%s)", ErrNoSuchKey, filePath)
+}
+
+// IsErrNoSuchKey returns whether err is caused by reading a file which does not exist
+func IsErrNoSuchKey(err error) bool {
+	return errors.Is(err, ErrNoSuchKey)
+}
+
 type FileReader interface {
 	io.Reader
 	io.Closer
@@ -35,7 +50,7 @@ type ChunkManager interface {
 	MultiWrite(contents map[string][]byte) error
 	// Exist returns true if @filePath exists.
 	Exist(filePath string) bool
-	// Read reads @filePath and returns content.
+	// Read reads @filePath and returns content, the error is ErrNoSuchKey if @filePath does not exist.
 	Read(filePath string) ([]byte, error)
 	// Reader return a reader for @filePath
 	Reader(filePath string) (FileReader, error)
@@ -47,6 +47,9 @@ type VectorChunkManager struct {
 	cacheSize      int64
 	cacheSizeMutex sync.Mutex
 	fixSize        bool // Prevent cache capactiy from changing too frequently
+
+	// evictionListener is called with the path of every file evicted from the local cache
+	evictionListener func(filePath string)
 }
 
 var _ ChunkManager = (*VectorChunkManager)(nil)
@@ -80,6 +83,9 @@ func NewVectorChunkManager(cacheStorage ChunkManager, vectorStorage ChunkManager
 			vcm.cacheSizeMutex.Lock()
 			vcm.cacheSize -= int64(size)
 			vcm.cacheSizeMutex.Unlock()
+			if vcm.evictionListener != nil {
+				vcm.evictionListener(k.(string))
+			}
 		})
 		if err != nil {
 			return nil, err
@@ -323,6 +329,31 @@ func (vcm *VectorChunkManager) RemoveWithPrefix(prefix string) error {
 	return nil
 }
 
+// SetEvictionListener sets the listener called with the path of every file evicted from the local cache, it should
+// be set before any read
+func (vcm *VectorChunkManager) SetEvictionListener(listener func(filePath string)) {
+	vcm.evictionListener = listener
+}
+
+// CachedSize returns the size of the local cache file of filePath, and whether the file is cached
+func (vcm *VectorChunkManager) CachedSize(filePath string) (int64, bool) {
+	if !vcm.cacheEnable || vcm.cache == nil {
+		return 0, false
+	}
+	r, ok := vcm.cache.Get(filePath)
+	if !ok {
+		return 0, false
+	}
+	return int64(r.(*mmap.ReaderAt).Len()), true
+}
+
+// Evict removes the file of filePath from the local cache, the file is kept in the vector storage
+func (vcm *VectorChunkManager) Evict(filePath string) {
+	if vcm.cacheEnable && vcm.cache != nil {
+		vcm.cache.Remove(filePath)
+	}
+}
+
 func (vcm *VectorChunkManager) Close() {
 	if vcm.cache != nil && vcm.cacheEnable {
 		vcm.cache.Close()
@@ -437,3 +437,37 @@ func TestVectorChunkManager_Read(t *testing.T) {
 		vcm.Close()
 	}
 }
+
+func TestVectorChunkManager_Evict(t *testing.T) {
+	vcm, cancel, err := buildVectorChunkManager(localPath, true)
+	assert.NoError(t, err)
+	defer cancel()
+	defer vcm.Close()
+
+	evicted := make(chan string, 1)
+	vcm.SetEvictionListener(func(filePath string) {
+		evicted <- filePath
+	})
+
+	binlogs := initBinlogFile(initMeta())
+	for _, binlog := range binlogs {
+		assert.NoError(t, vcm.vectorStorage.Write(binlog.Key, binlog.Value))
+	}
+	_, ok := vcm.CachedSize("109")
+	assert.False(t, ok)
+
+	_, err = vcm.Read("109")
+	assert.NoError(t, err)
+	size, ok := vcm.CachedSize("109")
+	assert.True(t, ok)
+	assert.Equal(t, int64(16*4), size)
+
+	vcm.Evict("109")
+	assert.Equal(t, "109", <-evicted)
+	_, ok = vcm.CachedSize("109")
+	assert.False(t, ok)
+	// the file is kept in the vector storage
+	assert.True(t, vcm.Exist("109"))
+
+	assert.NoError(t, vcm.RemoveWithPrefix(localPath))
+}
@@ -1159,7 +1159,12 @@ type QueryNode interface {
 	//     Subscribe a query channel and be a producer of a query result channel.
 	AddQueryChannel(ctx context.Context, req *querypb.AddQueryChannelRequest) (*commonpb.Status, error)
 	RemoveQueryChannel(ctx context.Context, req *querypb.RemoveQueryChannelRequest) (*commonpb.Status, error)
+	// WatchDmChannels watches the channels of the request concurrently, the status is Success only if all the
+	// channels are watched.
 	WatchDmChannels(ctx context.Context, req *querypb.WatchDmChannelsRequest) (*commonpb.Status, error)
+	// WatchDmChannelsWithStatus watches the channels of the request as WatchDmChannels, and returns the status of
+	// each channel along with the overall status.
+	WatchDmChannelsWithStatus(ctx context.Context, req *querypb.WatchDmChannelsRequest) (*querypb.WatchDmChannelsResponse, error)
 	WatchDeltaChannels(ctx context.Context, req *querypb.WatchDeltaChannelsRequest) (*commonpb.Status, error)
 	// LoadSegments notifies QueryNode to load the sealed segments from storage. The load tasks are sync to this
 	// rpc, QueryNode will return after all the sealed segments are loaded.
@@ -1174,6 +1179,82 @@ type QueryNode interface {
 	ReleasePartitions(ctx context.Context, req *querypb.ReleasePartitionsRequest) (*commonpb.Status, error)
 	ReleaseSegments(ctx context.Context, req *querypb.ReleaseSegmentsRequest) (*commonpb.Status, error)
 	GetSegmentInfo(ctx context.Context, req *querypb.GetSegmentInfoRequest) (*querypb.GetSegmentInfoResponse, error)
+	// SyncDistribution flips the serving state of the loaded sealed segments in QueryNode, all the actions of
+	// the request are applied at once, so that no search or query sees a part of them.
+	//
+	// Return UnexpectedError code in status:
+	//     If QueryNode isn't in HEALTHY: states not HEALTHY or dynamic checks not HEALTHY.
+	//     If any segment in the actions is not loaded in QueryNode.
+	// Return Success code in status:
+	//     The serving states are flipped, actions older than the applied version are ignored.
+	SyncDistribution(ctx context.Context, req *querypb.SyncDistributionRequest) (*commonpb.Status, error)
+	// PauseChannel stops QueryNode from applying the inserts and deletes of a DML channel without releasing
+	// the loaded data, the tSafe of the channel freezes until it is resumed.
+	//
+	// Return UnexpectedError code in status:
+	//     If QueryNode isn't in HEALTHY: states not HEALTHY or dynamic checks not HEALTHY.
+	//     If the channel is not watched by QueryNode.
+	//     If a request waiting for the tSafe of the channel would time out within the configured budget.
+	// Return Success code in status:
+	//     The channel is paused, pausing a paused channel succeeds as well.
+	PauseChannel(ctx context.Context, req *querypb.PauseChannelRequest) (*commonpb.Status, error)
+	// ResumeChannel lets QueryNode continue consuming a paused channel from the exact position it was paused at.
+	//
+	// Return UnexpectedError code in status:
+	//     If QueryNode isn't in HEALTHY: states not HEALTHY or dynamic checks not HEALTHY.
+	//     If the channel is not watched by QueryNode.
+	// Return Success code in status:
+	//     The channel is resumed, resuming a channel not paused succeeds as well.
+	ResumeChannel(ctx context.Context, req *querypb.ResumeChannelRequest) (*commonpb.Status, error)
+	// ExportSegmentDeletes exports the deletes applied to a sealed segment in a versioned compact form, which could be
+	// attached to the SegmentLoadInfo of another replica to skip replaying the delta logs covered by the snapshot.
+	//
+	// Return UnexpectedError code in status:
+	//     If QueryNode isn't in HEALTHY: states not HEALTHY or dynamic checks not HEALTHY.
+	//     If the segment is not a sealed segment loaded by QueryNode, or it has no pk index.
+	// Return Success code in status:
+	//     The snapshot of the segment is returned.
+	ExportSegmentDeletes(ctx context.Context, req *querypb.ExportSegmentDeletesRequest) (*querypb.ExportSegmentDeletesResponse, error)
+	// UpdateLoadConfig updates the load configs of a loaded collection without reloading it. The configs are applied
+	// to the segments loaded later, and to the loaded segments as well if they could be applied in place.
+	//
+	// Return UnexpectedError code in status:
+	//     If QueryNode isn't in HEALTHY: states not HEALTHY or dynamic checks not HEALTHY.
+	//     If the collection is not loaded, or any config is unknown or invalid, in which case nothing is updated.
+	// Return Success code in status:
+	//     The configs are updated, the results tell how each config takes effect and the loaded segments
+	//     that need a reload for it.
+	UpdateLoadConfig(ctx context.Context, req *querypb.UpdateLoadConfigRequest) (*querypb.UpdateLoadConfigResponse, error)
+	// GetDataDistribution returns the dm channels watched by QueryNode with the versions of their ownership, the
+	// timestamps the inserts and deletes of the channels are applied up to, and the indexes served by the sealed
+	// segments, which are persisted so that they are reported before the segments are loaded again after a crash.
+	//
+	// Return UnexpectedError code in status:
+	//     If QueryNode isn't in HEALTHY: states not HEALTHY or dynamic checks not HEALTHY.
+	// Return Success code in status:
+	//     The watched channels are returned.
+	GetDataDistribution(ctx context.Context, req *querypb.GetDataDistributionRequest) (*querypb.GetDataDistributionResponse, error)
+	// PromoteSegments promotes the warm standby segments, which are loaded with LoadSegmentsRequest.standby set, to
+	// serving without loading anything from storage. The deletes since the checkpoints are replayed to the segments
+	// before they are served.
+	//
+	// Return UnexpectedError code in status:
+	//     If QueryNode isn't in HEALTHY: states not HEALTHY or dynamic checks not HEALTHY.
+	//     If any segment is not a warm standby segment loaded by QueryNode, in which case nothing is promoted.
+	//     If the deletes could not be replayed.
+	// Return Success code in status:
+	//     The segments are serving.
+	PromoteSegments(ctx context.Context, req *querypb.PromoteSegmentsRequest) (*commonpb.Status, error)
+	// RefreshIndex re-attaches the stale indexes of a sealed segment, whose index files were garbage collected
+	// upstream, with the current index infos of the request. The indexes not stale are left untouched.
+	//
+	// Return UnexpectedError code in status:
+	//     If QueryNode isn't in HEALTHY: states not HEALTHY or dynamic checks not HEALTHY.
+	//     If the segment is not a sealed segment of the collection loaded by QueryNode.
+	//     If any index could not be loaded.
+	// Return Success code in status:
+	//     The stale indexes are attached.
+	RefreshIndex(ctx context.Context, req *querypb.RefreshIndexRequest) (*commonpb.Status, error)
 
 	Search(ctx context.Context, req *querypb.SearchRequest) (*internalpb.SearchResults, error)
 	Query(ctx context.Context, req *querypb.QueryRequest) (*internalpb.RetrieveResults, error)
@@ -0,0 +1,168 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferpool
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// SmallSize is the capacity of small buffers, e.g. placeholder groups of a few query vectors
+	SmallSize = 4 << 10
+	// MediumSize is the capacity of medium buffers
+	MediumSize = 64 << 10
+	// LargeSize is the capacity of large buffers, buffers larger than it are allocated and never pooled
+	LargeSize = 1 << 20
+)
+
+// poisonByte fills the released buffers in poison mode, so that reading a released buffer returns garbage
+const poisonByte = 0xDE
+
+// block is the pooled storage of buffers, generation is bumped on every release,
+// so a Buffer handle got before the release is detected as stale
+type block struct {
+	data       []byte
+	class      int // -1 if the block is not pooled
+	generation uint64
+}
+
+// Pool is a pool of byte buffers backed by a sync.Pool per size class, the buffer of a size is got from
+// the smallest class which fits it, buffers larger than all the classes are allocated on demand
+type Pool struct {
+	classes []int
+	pools   []sync.Pool
+	poison  int32
+
+	gets   int64 // number of buffers got
+	allocs int64 // number of blocks allocated
+}
+
+// NewPool returns a pool of buffers in the size classes, small, medium and large classes are used if none is given
+func NewPool(classes ...int) *Pool {
+	if len(classes) == 0 {
+		classes = []int{SmallSize, MediumSize, LargeSize}
+	}
+	sorted := make([]int, len(classes))
+	copy(sorted, classes)
+	sort.Ints(sorted)
+	return &Pool{
+		classes: sorted,
+		pools:   make([]sync.Pool, len(sorted)),
+	}
+}
+
+// SetPoison enables or disables the poison mode, in which released buffers are filled with garbage and
+// never reused, and using or releasing a buffer after release panics. It's for debugging only
+func (p *Pool) SetPoison(poison bool) {
+	if poison {
+		atomic.StoreInt32(&p.poison, 1)
+	} else {
+		atomic.StoreInt32(&p.poison, 0)
+	}
+}
+
+func (p *Pool) isPoison() bool {
+	return atomic.LoadInt32(&p.poison) == 1
+}
+
+// Get returns a zeroed buffer of size bytes, the buffer must be released once it's no longer used
+func (p *Pool) Get(size int) *Buffer {
+	atomic.AddInt64(&p.gets, 1)
+	class := sort.SearchInts(p.classes, size)
+	if class == len(p.classes) {
+		atomic.AddInt64(&p.allocs, 1)
+		return &Buffer{pool: p, block: &block{data: make([]byte, size), class: -1}, size: size}
+	}
+	b, ok := p.pools[class].Get().(*block)
+	if ok {
+		data := b.data[:size]
+		for i := range data {
+			data[i] = 0
+		}
+	} else {
+		atomic.AddInt64(&p.allocs, 1)
+		b = &block{data: make([]byte, p.classes[class]), class: class}
+	}
+	return &Buffer{pool: p, block: b, generation: atomic.LoadUint64(&b.generation), size: size}
+}
+
+// Stats returns the number of buffers got and the number of blocks allocated for them
+func (p *Pool) Stats() (gets int64, allocs int64) {
+	return atomic.LoadInt64(&p.gets), atomic.LoadInt64(&p.allocs)
+}
+
+// put returns the released block to pool, in poison mode the block is filled with garbage and dropped instead,
+// so the slices of the released buffer keep reading garbage rather than the content of the next user
+func (p *Pool) put(b *block) {
+	if p.isPoison() {
+		for i := range b.data {
+			b.data[i] = poisonByte
+		}
+		return
+	}
+	if b.class >= 0 {
+		p.pools[b.class].Put(b)
+	}
+}
+
+// Buffer is a handle of a byte buffer got from Pool, each Get returns a new handle,
+// so the handle of a released buffer stays stale after its storage is reused
+type Buffer struct {
+	pool       *Pool
+	block      *block
+	generation uint64
+	size       int
+}
+
+func (b *Buffer) released() bool {
+	return atomic.LoadUint64(&b.block.generation) != b.generation
+}
+
+// Bytes returns the content of buffer, which must not be used after the buffer is released.
+// Bytes returns nil once released, and panics in poison mode
+func (b *Buffer) Bytes() []byte {
+	if b.released() {
+		if b.pool.isPoison() {
+			panic(fmt.Sprintf("bufferpool: use of released buffer of %d bytes", b.size))
+		}
+		return nil
+	}
+	return b.block.data[:b.size]
+}
+
+// Len returns the size of buffer
+func (b *Buffer) Len() int {
+	return b.size
+}
+
+// Release returns the buffer to pool, releasing a nil buffer is a no-op.
+// Releasing a buffer twice is a no-op, and panics in poison mode
+func (b *Buffer) Release() {
+	if b == nil {
+		return
+	}
+	if !atomic.CompareAndSwapUint64(&b.block.generation, b.generation, b.generation+1) {
+		if b.pool.isPoison() {
+			panic(fmt.Sprintf("bufferpool: buffer of %d bytes released twice", b.size))
+		}
+		return
+	}
+	b.pool.put(b.block)
+}
@@ -0,0 +1,179 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferpool
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool_Get(t *testing.T) {
+	p := NewPool()
+	assert.Equal(t, []int{SmallSize, MediumSize, LargeSize}, p.classes)
+
+	cases := []struct {
+		size  int
+		class int
+	}{
+		{0, 0},
+		{100, 0},
+		{SmallSize, 0},
+		{SmallSize + 1, 1},
+		{MediumSize, 1},
+		{LargeSize, 2},
+		{LargeSize + 1, -1},
+	}
+	for _, c := range cases {
+		buf := p.Get(c.size)
+		assert.Equal(t, c.size, buf.Len())
+		assert.Equal(t, c.size, len(buf.Bytes()))
+		assert.Equal(t, c.class, buf.block.class)
+		if c.class >= 0 {
+			assert.Equal(t, p.classes[c.class], cap(buf.Bytes()))
+		}
+		buf.Release()
+	}
+	gets, _ := p.Stats()
+	assert.Equal(t, int64(len(cases)), gets)
+
+	p = NewPool(128, 16)
+	assert.Equal(t, []int{16, 128}, p.classes)
+	assert.Equal(t, 1, p.Get(17).block.class)
+}
+
+func TestPool_Reuse(t *testing.T) {
+	p := NewPool(64)
+	buf := p.Get(10)
+	copy(buf.Bytes(), "0123456789")
+	buf.Release()
+	assert.Nil(t, buf.Bytes())
+	// release is a no-op once released
+	buf.Release()
+
+	// the reused storage is zeroed, and the stale handle can't release it
+	reused := p.Get(20)
+	assert.Equal(t, make([]byte, 20), reused.Bytes())
+	buf.Release()
+	assert.NotNil(t, reused.Bytes())
+	assert.Nil(t, buf.Bytes())
+	reused.Release()
+
+	// oversize buffers are never pooled
+	oversize := p.Get(65)
+	assert.Equal(t, -1, oversize.block.class)
+	oversize.Release()
+	assert.Nil(t, oversize.Bytes())
+
+	var nilBuf *Buffer
+	nilBuf.Release()
+}
+
+func TestPool_Poison(t *testing.T) {
+	p := NewPool(64)
+	p.SetPoison(true)
+	assert.True(t, p.isPoison())
+
+	buf := p.Get(10)
+	data := buf.Bytes()
+	copy(data, "0123456789")
+	buf.Release()
+	for _, b := range data {
+		assert.Equal(t, byte(poisonByte), b)
+	}
+	assert.Panics(t, func() { buf.Bytes() })
+	assert.Panics(t, func() { buf.Release() })
+
+	// the poisoned storage is never reused, so the slices of the released buffer keep reading garbage
+	reused := p.Get(10)
+	copy(reused.Bytes(), "9876543210")
+	for _, b := range data {
+		assert.Equal(t, byte(poisonByte), b)
+	}
+	reused.Release()
+	_, allocs := p.Stats()
+	assert.Equal(t, int64(2), allocs)
+
+	p.SetPoison(false)
+	assert.False(t, p.isPoison())
+	assert.NotPanics(t, func() { buf.Bytes() })
+	assert.NotPanics(t, func() { buf.Release() })
+}
+
+func TestPool_Concurrent(t *testing.T) {
+	p := NewPool()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				size := (i*1000 + j) % (2 * MediumSize)
+				buf := p.Get(size)
+				data := buf.Bytes()
+				for k := range data {
+					if data[k] != 0 {
+						t.Errorf("buffer not zeroed, got %d at %d", data[k], k)
+						return
+					}
+					data[k] = byte(i)
+				}
+				for k := range data {
+					if data[k] != byte(i) {
+						t.Errorf("buffer shared by goroutines, got %d, want %d", data[k], i)
+						return
+					}
+				}
+				buf.Release()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	gets, allocs := p.Stats()
+	assert.Equal(t, int64(16*1000), gets)
+	assert.Less(t, allocs, gets)
+}
+
+func benchmarkPoolGet(b *testing.B, size int) {
+	p := NewPool()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			buf := p.Get(size)
+			buf.Bytes()[0] = 1
+			buf.Release()
+		}
+	})
+}
+
+func benchmarkMake(b *testing.B, size int) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			data := make([]byte, size)
+			data[0] = 1
+		}
+	})
+}
+
+func BenchmarkPool_GetMedium(b *testing.B) { benchmarkPoolGet(b, MediumSize) }
+func BenchmarkPool_GetLarge(b *testing.B)  { benchmarkPoolGet(b, LargeSize) }
+func BenchmarkMakeMedium(b *testing.B)     { benchmarkMake(b, MediumSize) }
+func BenchmarkMakeLarge(b *testing.B)      { benchmarkMake(b, LargeSize) }
@@ -1,15 +1,19 @@
 package compressor
 
 import (
+	"fmt"
 	"io"
 
+	"github.com/klauspost/compress/s2"
 	"github.com/klauspost/compress/zstd"
 )
 
 type CompressType string
 
 const (
-	CompressTypeZstd CompressType = "zstd"
+	CompressTypeNone   CompressType = "none"
+	CompressTypeZstd   CompressType = "zstd"
+	CompressTypeSnappy CompressType = "snappy"
 
 	DefaultCompressAlgorithm CompressType = CompressTypeZstd
 )
@@ -193,3 +197,41 @@ func ZstdCompressBytes(src, dst []byte) []byte {
 func ZstdDecompressBytes(src, dst []byte) ([]byte, error) {
 	return globalZstdDecompressor.DecodeAll(src, dst)
 }
+
+// Use case: compress small blocks with snappy format
+// This can be called concurrently
+func SnappyCompressBytes(src, dst []byte) []byte {
+	return s2.EncodeSnappy(dst, src)
+}
+
+// Use case: decompress small blocks with snappy format
+// This can be called concurrently
+func SnappyDecompressBytes(src, dst []byte) ([]byte, error) {
+	return s2.Decode(dst, src)
+}
+
+// CompressBytes compresses small blocks with the given compress type
+// This can be called concurrently
+func CompressBytes(compressType CompressType, src []byte) ([]byte, error) {
+	switch compressType {
+	case CompressTypeZstd:
+		return ZstdCompressBytes(src, nil), nil
+	case CompressTypeSnappy:
+		return SnappyCompressBytes(src, nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported compress type %s", compressType)
+	}
+}
+
+// DecompressBytes decompresses small blocks compressed with the given compress type
+// This can be called concurrently
+func DecompressBytes(compressType CompressType, src []byte) ([]byte, error) {
+	switch compressType {
+	case CompressTypeZstd:
+		return ZstdDecompressBytes(src, nil)
+	case CompressTypeSnappy:
+		return SnappyDecompressBytes(src, nil)
+	default:
+		return nil, fmt.Errorf("unsupported compress type %s", compressType)
+	}
+}
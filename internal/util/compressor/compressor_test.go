@@ -162,3 +162,29 @@ func TestCurrencyGlobalMethods(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestCompressBytes(t *testing.T) {
+	data := []byte(strings.Repeat("hello compress algorithms!", 100))
+	for _, compressType := range []CompressType{CompressTypeZstd, CompressTypeSnappy} {
+		compressed, err := CompressBytes(compressType, data)
+		assert.NoError(t, err)
+		assert.Less(t, len(compressed), len(data))
+
+		origin, err := DecompressBytes(compressType, compressed)
+		assert.NoError(t, err)
+		assert.Equal(t, data, origin)
+
+		_, err = DecompressBytes(compressType, []byte("not compressed"))
+		assert.Error(t, err, compressType)
+	}
+
+	snappyBytes := SnappyCompressBytes(data, nil)
+	origin, err := SnappyDecompressBytes(snappyBytes, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, data, origin)
+
+	_, err = CompressBytes(CompressTypeNone, data)
+	assert.Error(t, err)
+	_, err = DecompressBytes("lz4", data)
+	assert.Error(t, err)
+}
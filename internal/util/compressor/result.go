@@ -0,0 +1,113 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compressor
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+)
+
+// CompressSearchResults compresses the sliced blob of results with compressType if the blob is no smaller than
+// threshold, it returns false and leaves results untouched if compression doesn't reduce the size
+func CompressSearchResults(results *internalpb.SearchResults, compressType CompressType, threshold int) (bool, error) {
+	if results.GetSlicedBlobCompressType() != "" {
+		return false, fmt.Errorf("search results are already compressed with %s", results.GetSlicedBlobCompressType())
+	}
+	blob := results.GetSlicedBlob()
+	if len(blob) == 0 || len(blob) < threshold {
+		return false, nil
+	}
+	compressed, err := CompressBytes(compressType, blob)
+	if err != nil {
+		return false, err
+	}
+	if len(compressed) >= len(blob) {
+		return false, nil
+	}
+	results.SlicedBlob = compressed
+	results.SlicedBlobCompressType = string(compressType)
+	return true, nil
+}
+
+// DecompressSearchResults decompresses the sliced blob of results in place if it's compressed
+func DecompressSearchResults(results *internalpb.SearchResults) error {
+	if results.GetSlicedBlobCompressType() == "" {
+		return nil
+	}
+	blob, err := DecompressBytes(CompressType(results.GetSlicedBlobCompressType()), results.GetSlicedBlob())
+	if err != nil {
+		return fmt.Errorf("failed to decompress search results: %w", err)
+	}
+	results.SlicedBlob = blob
+	results.SlicedBlobCompressType = ""
+	return nil
+}
+
+// CompressRetrieveResults marshals the ids and fields data of results and compresses them into the compressed blob
+// with compressType if the marshaled size is no smaller than threshold, it returns false and leaves results
+// untouched if compression doesn't reduce the size
+func CompressRetrieveResults(results *internalpb.RetrieveResults, compressType CompressType, threshold int) (bool, error) {
+	if results.GetCompressType() != "" {
+		return false, fmt.Errorf("retrieve results are already compressed with %s", results.GetCompressType())
+	}
+	payload := &internalpb.RetrieveResults{
+		Ids:        results.GetIds(),
+		FieldsData: results.GetFieldsData(),
+	}
+	if size := proto.Size(payload); size == 0 || size < threshold {
+		return false, nil
+	}
+	blob, err := proto.Marshal(payload)
+	if err != nil {
+		return false, err
+	}
+	compressed, err := CompressBytes(compressType, blob)
+	if err != nil {
+		return false, err
+	}
+	if len(compressed) >= len(blob) {
+		return false, nil
+	}
+	results.Ids = nil
+	results.FieldsData = nil
+	results.CompressedBlob = compressed
+	results.CompressType = string(compressType)
+	return true, nil
+}
+
+// DecompressRetrieveResults restores the ids and fields data of results in place if they're compressed
+func DecompressRetrieveResults(results *internalpb.RetrieveResults) error {
+	if results.GetCompressType() == "" {
+		return nil
+	}
+	blob, err := DecompressBytes(CompressType(results.GetCompressType()), results.GetCompressedBlob())
+	if err != nil {
+		return fmt.Errorf("failed to decompress retrieve results: %w", err)
+	}
+	payload := &internalpb.RetrieveResults{}
+	if err := proto.Unmarshal(blob, payload); err != nil {
+		return fmt.Errorf("failed to unmarshal decompressed retrieve results: %w", err)
+	}
+	results.Ids = payload.GetIds()
+	results.FieldsData = payload.GetFieldsData()
+	results.CompressedBlob = nil
+	results.CompressType = ""
+	return nil
+}
@@ -0,0 +1,184 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compressor
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+func genRetrieveResults(n int) *internalpb.RetrieveResults {
+	ids := make([]int64, 0, n)
+	strs := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		ids = append(ids, int64(i))
+		strs = append(strs, strings.Repeat("milvus", 10))
+	}
+	return &internalpb.RetrieveResults{
+		Ids: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids}}},
+		FieldsData: []*schemapb.FieldData{
+			{
+				Type:    schemapb.DataType_VarChar,
+				FieldId: 101,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: strs}},
+				}},
+			},
+		},
+		SealedSegmentIDsRetrieved: []int64{1, 2},
+	}
+}
+
+func TestSearchResultsCompression(t *testing.T) {
+	blob := []byte(strings.Repeat("search result data", 100))
+	for _, compressType := range []CompressType{CompressTypeZstd, CompressTypeSnappy} {
+		results := &internalpb.SearchResults{SlicedBlob: blob, NumQueries: 10}
+		compressed, err := CompressSearchResults(results, compressType, 1024)
+		require.NoError(t, err)
+		assert.True(t, compressed)
+		assert.Equal(t, string(compressType), results.GetSlicedBlobCompressType())
+		assert.Less(t, len(results.GetSlicedBlob()), len(blob))
+
+		// compressed only once
+		_, err = CompressSearchResults(results, compressType, 0)
+		assert.Error(t, err)
+
+		// round trip through the wire
+		bs, err := proto.Marshal(results)
+		require.NoError(t, err)
+		received := &internalpb.SearchResults{}
+		require.NoError(t, proto.Unmarshal(bs, received))
+		require.NoError(t, DecompressSearchResults(received))
+		assert.Equal(t, blob, received.GetSlicedBlob())
+		assert.Empty(t, received.GetSlicedBlobCompressType())
+		assert.Equal(t, int64(10), received.GetNumQueries())
+
+		// decompressing uncompressed results is a no-op
+		require.NoError(t, DecompressSearchResults(received))
+		assert.Equal(t, blob, received.GetSlicedBlob())
+	}
+}
+
+func TestSearchResultsCompression_threshold(t *testing.T) {
+	blob := []byte(strings.Repeat("search result data", 100))
+	results := &internalpb.SearchResults{SlicedBlob: blob}
+	compressed, err := CompressSearchResults(results, CompressTypeZstd, len(blob)+1)
+	require.NoError(t, err)
+	assert.False(t, compressed)
+	assert.Equal(t, blob, results.GetSlicedBlob())
+	assert.Empty(t, results.GetSlicedBlobCompressType())
+
+	compressed, err = CompressSearchResults(results, CompressTypeZstd, len(blob))
+	require.NoError(t, err)
+	assert.True(t, compressed)
+
+	// incompressible blob is sent as is
+	random := make([]byte, 4096)
+	_, err = rand.Read(random)
+	require.NoError(t, err)
+	results = &internalpb.SearchResults{SlicedBlob: random}
+	compressed, err = CompressSearchResults(results, CompressTypeSnappy, 0)
+	require.NoError(t, err)
+	assert.False(t, compressed)
+	assert.Equal(t, random, results.GetSlicedBlob())
+
+	// empty blob
+	results = &internalpb.SearchResults{}
+	compressed, err = CompressSearchResults(results, CompressTypeZstd, 0)
+	require.NoError(t, err)
+	assert.False(t, compressed)
+}
+
+func TestSearchResultsCompression_errors(t *testing.T) {
+	blob := []byte(strings.Repeat("search result data", 100))
+	_, err := CompressSearchResults(&internalpb.SearchResults{SlicedBlob: blob}, CompressTypeNone, 0)
+	assert.Error(t, err)
+
+	err = DecompressSearchResults(&internalpb.SearchResults{SlicedBlob: blob, SlicedBlobCompressType: string(CompressTypeZstd)})
+	assert.Error(t, err)
+	err = DecompressSearchResults(&internalpb.SearchResults{SlicedBlob: blob, SlicedBlobCompressType: "lz4"})
+	assert.Error(t, err)
+}
+
+func TestRetrieveResultsCompression(t *testing.T) {
+	for _, compressType := range []CompressType{CompressTypeZstd, CompressTypeSnappy} {
+		expected := genRetrieveResults(100)
+		results := genRetrieveResults(100)
+		size := proto.Size(results)
+		compressed, err := CompressRetrieveResults(results, compressType, 1024)
+		require.NoError(t, err)
+		assert.True(t, compressed)
+		assert.Nil(t, results.GetIds())
+		assert.Nil(t, results.GetFieldsData())
+		assert.Equal(t, string(compressType), results.GetCompressType())
+		assert.Less(t, proto.Size(results), size)
+		assert.Equal(t, []int64{1, 2}, results.GetSealedSegmentIDsRetrieved())
+
+		_, err = CompressRetrieveResults(results, compressType, 0)
+		assert.Error(t, err)
+
+		bs, err := proto.Marshal(results)
+		require.NoError(t, err)
+		received := &internalpb.RetrieveResults{}
+		require.NoError(t, proto.Unmarshal(bs, received))
+		require.NoError(t, DecompressRetrieveResults(received))
+		assert.True(t, proto.Equal(expected, received))
+	}
+}
+
+func TestRetrieveResultsCompression_threshold(t *testing.T) {
+	results := genRetrieveResults(100)
+	expected := genRetrieveResults(100)
+	size := proto.Size(&internalpb.RetrieveResults{Ids: results.Ids, FieldsData: results.FieldsData})
+	compressed, err := CompressRetrieveResults(results, CompressTypeSnappy, size+1)
+	require.NoError(t, err)
+	assert.False(t, compressed)
+	assert.True(t, proto.Equal(expected, results))
+
+	compressed, err = CompressRetrieveResults(results, CompressTypeSnappy, size)
+	require.NoError(t, err)
+	assert.True(t, compressed)
+
+	// empty results are too small to compress
+	results = &internalpb.RetrieveResults{}
+	compressed, err = CompressRetrieveResults(results, CompressTypeZstd, 0)
+	require.NoError(t, err)
+	assert.False(t, compressed)
+}
+
+func TestRetrieveResultsCompression_errors(t *testing.T) {
+	_, err := CompressRetrieveResults(genRetrieveResults(100), CompressTypeNone, 0)
+	assert.Error(t, err)
+
+	err = DecompressRetrieveResults(&internalpb.RetrieveResults{CompressedBlob: []byte("corrupted"), CompressType: string(CompressTypeSnappy)})
+	assert.Error(t, err)
+
+	// decompressed but not a retrieve result
+	err = DecompressRetrieveResults(&internalpb.RetrieveResults{
+		CompressedBlob: SnappyCompressBytes([]byte{0xff, 0xff, 0xff}, nil),
+		CompressType:   string(CompressTypeSnappy),
+	})
+	assert.Error(t, err)
+}
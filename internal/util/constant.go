@@ -23,6 +23,8 @@ const (
 	HeaderAuthorize      = "authorization"
 	// HeaderSourceID identify requests from Milvus members and client requests
 	HeaderSourceID = "sourceId"
+	// HeaderRequestID identify a client request in the logs of proxy and query nodes, generated if not supplied
+	HeaderRequestID = "request-id"
 	// MemberCredID id for Milvus members (data/index/query node/coord component)
 	MemberCredID        = "@@milvus-member@@"
 	CredentialSeperator = ":"
@@ -17,6 +17,8 @@
 package flowgraph
 
 import (
+	"sync"
+
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/mq/msgstream"
 	"github.com/milvus-io/milvus/internal/util/trace"
@@ -30,6 +32,12 @@ type InputNode struct {
 	BaseNode
 	inStream msgstream.MsgStream
 	name     string
+
+	pauseMu   sync.Mutex
+	pauseCh   chan struct{} // closed when the node is paused
+	resumeCh  chan struct{} // closed when the node is resumed, nil if the node is not paused
+	closeCh   chan struct{}
+	closeOnce sync.Once
 }
 
 // IsInputNode returns whether Node is InputNode
@@ -44,6 +52,11 @@ func (inNode *InputNode) Start() {
 
 // Close implements node
 func (inNode *InputNode) Close() {
+	inNode.closeOnce.Do(func() {
+		if inNode.closeCh != nil {
+			close(inNode.closeCh)
+		}
+	})
 	inNode.inStream.Close()
 	log.Debug("message stream closed",
 		zap.String("node name", inNode.name),
@@ -60,9 +73,73 @@ func (inNode *InputNode) InStream() msgstream.MsgStream {
 	return inNode.inStream
 }
 
+// Pause stops the node from consuming new message packs, the unconsumed message packs are kept in the
+// msgstream so that the node continues from the exact position after resumed. Returns false if already paused
+func (inNode *InputNode) Pause() bool {
+	inNode.pauseMu.Lock()
+	defer inNode.pauseMu.Unlock()
+	if inNode.resumeCh != nil {
+		return false
+	}
+	if inNode.pauseCh != nil {
+		close(inNode.pauseCh)
+	}
+	inNode.resumeCh = make(chan struct{})
+	return true
+}
+
+// Resume lets a paused node continue consuming, returns false if the node is not paused
+func (inNode *InputNode) Resume() bool {
+	inNode.pauseMu.Lock()
+	defer inNode.pauseMu.Unlock()
+	if inNode.resumeCh == nil {
+		return false
+	}
+	close(inNode.resumeCh)
+	inNode.resumeCh = nil
+	inNode.pauseCh = make(chan struct{})
+	return true
+}
+
+// IsPaused returns whether the node is paused
+func (inNode *InputNode) IsPaused() bool {
+	inNode.pauseMu.Lock()
+	defer inNode.pauseMu.Unlock()
+	return inNode.resumeCh != nil
+}
+
+func (inNode *InputNode) pauseChannels() (pauseCh <-chan struct{}, resumeCh <-chan struct{}) {
+	inNode.pauseMu.Lock()
+	defer inNode.pauseMu.Unlock()
+	if inNode.resumeCh != nil {
+		return nil, inNode.resumeCh
+	}
+	return inNode.pauseCh, nil
+}
+
+// consume receives a message pack from msgstream, it blocks while the node is paused
+func (inNode *InputNode) consume() (*msgstream.MsgPack, bool) {
+	for {
+		pauseCh, resumeCh := inNode.pauseChannels()
+		if resumeCh != nil {
+			select {
+			case <-resumeCh:
+				continue
+			case <-inNode.closeCh:
+				return nil, false
+			}
+		}
+		select {
+		case msgPack, ok := <-inNode.inStream.Chan():
+			return msgPack, ok
+		case <-pauseCh:
+		}
+	}
+}
+
 // Operate consume a message pack from msgstream and return
 func (inNode *InputNode) Operate(in []Msg) []Msg {
-	msgPack, ok := <-inNode.inStream.Chan()
+	msgPack, ok := inNode.consume()
 	if !ok {
 		log.Warn("Receive Msg failed from upstream node", zap.Any("input node", inNode.Name()))
 		return []Msg{}
@@ -105,5 +182,7 @@ func NewInputNode(inStream msgstream.MsgStream, nodeName string, maxQueueLength
 		BaseNode: baseNode,
 		inStream: inStream,
 		name:     nodeName,
+		pauseCh:  make(chan struct{}),
+		closeCh:  make(chan struct{}),
 	}
 }
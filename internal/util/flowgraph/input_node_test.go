@@ -20,9 +20,11 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/milvus-io/milvus/internal/mq/msgstream"
 	"github.com/milvus-io/milvus/internal/util/dependency"
 )
 
@@ -70,3 +72,76 @@ func Test_NewInputNode(t *testing.T) {
 	assert.Equal(t, node.maxQueueLength, maxQueueLength)
 	assert.Equal(t, node.maxParallelism, maxParallelism)
 }
+
+type chanMsgStream struct {
+	msgstream.MsgStream
+	ch chan *msgstream.MsgPack
+}
+
+func (ms *chanMsgStream) Chan() <-chan *msgstream.MsgPack {
+	return ms.ch
+}
+
+func (ms *chanMsgStream) Close() {}
+
+func TestInputNode_PauseResume(t *testing.T) {
+	stream := &chanMsgStream{ch: make(chan *msgstream.MsgPack, 10)}
+	inputNode := NewInputNode(stream, "input_node", 1024, 1)
+	defer inputNode.Close()
+
+	assert.False(t, inputNode.IsPaused())
+	assert.False(t, inputNode.Resume())
+	assert.True(t, inputNode.Pause())
+	assert.False(t, inputNode.Pause())
+	assert.True(t, inputNode.IsPaused())
+
+	for i := 1; i <= 3; i++ {
+		stream.ch <- &msgstream.MsgPack{BeginTs: uint64(i), EndTs: uint64(i)}
+	}
+
+	outputCh := make(chan []Msg, 1)
+	go func() {
+		outputCh <- inputNode.Operate([]Msg{})
+	}()
+	select {
+	case <-outputCh:
+		t.Fatal("paused input node should not consume")
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.Equal(t, 3, len(stream.ch))
+
+	assert.True(t, inputNode.Resume())
+	assert.False(t, inputNode.IsPaused())
+	output := <-outputCh
+	assert.Equal(t, uint64(1), output[0].TimeTick())
+	for i := 2; i <= 3; i++ {
+		output = inputNode.Operate([]Msg{})
+		assert.Equal(t, uint64(i), output[0].TimeTick())
+	}
+
+	// pause takes effect on a node waiting for messages
+	go func() {
+		outputCh <- inputNode.Operate([]Msg{})
+	}()
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, inputNode.Pause())
+	time.Sleep(50 * time.Millisecond)
+	stream.ch <- &msgstream.MsgPack{BeginTs: 4, EndTs: 4}
+	select {
+	case <-outputCh:
+		t.Fatal("paused input node should not consume")
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.True(t, inputNode.Resume())
+	output = <-outputCh
+	assert.Equal(t, uint64(4), output[0].TimeTick())
+
+	// close unblocks a paused node
+	assert.True(t, inputNode.Pause())
+	go func() {
+		outputCh <- inputNode.Operate([]Msg{})
+	}()
+	inputNode.Close()
+	output = <-outputCh
+	assert.Empty(t, output)
+}
@@ -101,12 +101,72 @@ type QueryNodeConfiguration struct {
 	RetrieveResultReceiveBufSize int64 `json:"retrieve_result_receive_buf_size"`
 
 	SimdType string `json:"simd_type"`
+
+	SegcoreVersion            string   `json:"segcore_version"`
+	SegcoreABIVersion         int64    `json:"segcore_abi_version"`
+	ExpectedSegcoreABIVersion int64    `json:"expected_segcore_abi_version"`
+	SimdCapabilities          []string `json:"simd_capabilities"`
+}
+
+// SegmentBloomFilterStats records the pk bloom filter statistics of a segment loaded in QueryNode.
+type SegmentBloomFilterStats struct {
+	SegmentID          int64   `json:"segment_id"`
+	CollectionID       int64   `json:"collection_id"`
+	BitSize            uint64  `json:"bit_size"`
+	HashFunctions      uint32  `json:"hash_functions"`
+	FillRatio          float64 `json:"fill_ratio"`
+	ApproxElementCount uint64  `json:"approx_element_count"`
+	EstimatedFPR       float64 `json:"estimated_fpr"`
+	Lookups            int64   `json:"lookups"`
+	Pruned             int64   `json:"pruned"`
+}
+
+// SegmentSearchStats records the searches done on a segment loaded in QueryNode since loaded, the latencies are of
+// the searches in segcore, and RowsScanned sums the rows of the segment over the searches.
+type SegmentSearchStats struct {
+	SegmentID      int64 `json:"segment_id"`
+	CollectionID   int64 `json:"collection_id"`
+	Searches       int64 `json:"searches"`
+	TotalLatencyUs int64 `json:"total_latency_us"`
+	MaxLatencyUs   int64 `json:"max_latency_us"`
+	RowsScanned    int64 `json:"rows_scanned"`
+}
+
+// CollectionLoadStats aggregates the bytes downloaded and the load phase durations of the segments of a collection
+// loaded from storage in QueryNode, the durations are summed over the segments.
+type CollectionLoadStats struct {
+	CollectionID  int64 `json:"collection_id"`
+	Segments      int64 `json:"segments"`
+	BinlogSize    int64 `json:"binlog_size"`
+	IndexSize     int64 `json:"index_size"`
+	DownloadMs    int64 `json:"download_ms"`
+	DeserializeMs int64 `json:"deserialize_ms"`
+	SegcoreLoadMs int64 `json:"segcore_load_ms"`
+	IndexLoadMs   int64 `json:"index_load_ms"`
+	LoadMs        int64 `json:"load_ms"`
+	MaxLoadMs     int64 `json:"max_load_ms"`
+}
+
+// ChannelCatchUpProgress records the progress of a DML channel replaying its backlog in QueryNode.
+type ChannelCatchUpProgress struct {
+	Channel      string `json:"channel"`
+	CatchingUp   bool   `json:"catching_up"`
+	StartLagMs   int64  `json:"start_lag_ms"`
+	LagMs        int64  `json:"lag_ms"`
+	ReplayedMsgs int64  `json:"replayed_msgs"`
+	ReplayedRows int64  `json:"replayed_rows"`
+	Batches      int64  `json:"batches"`
 }
 
 // QueryNodeInfos implements ComponentInfos
 type QueryNodeInfos struct {
 	BaseComponentInfos
-	SystemConfigurations QueryNodeConfiguration `json:"system_configurations"`
+	SystemConfigurations QueryNodeConfiguration    `json:"system_configurations"`
+	PausedChannels       []string                  `json:"paused_channels"`
+	BloomFilterStats     []SegmentBloomFilterStats `json:"bloom_filter_stats"`
+	CatchUpProgress      []ChannelCatchUpProgress  `json:"catch_up_progress"`
+	LoadStats            []CollectionLoadStats     `json:"load_stats"`
+	SegmentSearchStats   []SegmentSearchStats      `json:"segment_search_stats"`
 }
 
 // QueryCoordConfiguration records the configuration of QueryCoord.
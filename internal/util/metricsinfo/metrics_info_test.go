@@ -96,6 +96,54 @@ func TestQueryNodeInfos_Codec(t *testing.T) {
 
 			SimdType: "avx2",
 		},
+		BloomFilterStats: []SegmentBloomFilterStats{
+			{
+				SegmentID:          1,
+				CollectionID:       2,
+				BitSize:            1 << 20,
+				HashFunctions:      8,
+				FillRatio:          0.5,
+				ApproxElementCount: 100000,
+				EstimatedFPR:       0.004,
+				Lookups:            100,
+				Pruned:             99,
+			},
+		},
+		CatchUpProgress: []ChannelCatchUpProgress{
+			{
+				Channel:      "by-dev-rootcoord-dml_0_1v0",
+				CatchingUp:   true,
+				StartLagMs:   60000,
+				LagMs:        30000,
+				ReplayedMsgs: 1000,
+				ReplayedRows: 100000,
+				Batches:      2,
+			},
+		},
+		LoadStats: []CollectionLoadStats{
+			{
+				CollectionID:  2,
+				Segments:      10,
+				BinlogSize:    1 << 30,
+				IndexSize:     1 << 28,
+				DownloadMs:    20000,
+				DeserializeMs: 5000,
+				SegcoreLoadMs: 3000,
+				IndexLoadMs:   2000,
+				LoadMs:        31000,
+				MaxLoadMs:     4000,
+			},
+		},
+		SegmentSearchStats: []SegmentSearchStats{
+			{
+				SegmentID:      1,
+				CollectionID:   2,
+				Searches:       100,
+				TotalLatencyUs: 500000,
+				MaxLatencyUs:   20000,
+				RowsScanned:    100000000,
+			},
+		},
 	}
 	s, err := MarshalComponentInfos(infos1)
 	assert.Equal(t, nil, err)
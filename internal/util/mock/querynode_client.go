@@ -54,6 +54,10 @@ func (m *QueryNodeClient) WatchDmChannels(ctx context.Context, in *querypb.Watch
 	return &commonpb.Status{}, m.Err
 }
 
+func (m *QueryNodeClient) WatchDmChannelsWithStatus(ctx context.Context, in *querypb.WatchDmChannelsRequest, opts ...grpc.CallOption) (*querypb.WatchDmChannelsResponse, error) {
+	return &querypb.WatchDmChannelsResponse{}, m.Err
+}
+
 func (m *QueryNodeClient) WatchDeltaChannels(ctx context.Context, in *querypb.WatchDeltaChannelsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	return &commonpb.Status{}, m.Err
 }
@@ -78,6 +82,38 @@ func (m *QueryNodeClient) GetSegmentInfo(ctx context.Context, in *querypb.GetSeg
 	return &querypb.GetSegmentInfoResponse{}, m.Err
 }
 
+func (m *QueryNodeClient) SyncDistribution(ctx context.Context, in *querypb.SyncDistributionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.Err
+}
+
+func (m *QueryNodeClient) PauseChannel(ctx context.Context, in *querypb.PauseChannelRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.Err
+}
+
+func (m *QueryNodeClient) ResumeChannel(ctx context.Context, in *querypb.ResumeChannelRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.Err
+}
+
+func (m *QueryNodeClient) ExportSegmentDeletes(ctx context.Context, in *querypb.ExportSegmentDeletesRequest, opts ...grpc.CallOption) (*querypb.ExportSegmentDeletesResponse, error) {
+	return &querypb.ExportSegmentDeletesResponse{}, m.Err
+}
+
+func (m *QueryNodeClient) UpdateLoadConfig(ctx context.Context, in *querypb.UpdateLoadConfigRequest, opts ...grpc.CallOption) (*querypb.UpdateLoadConfigResponse, error) {
+	return &querypb.UpdateLoadConfigResponse{}, m.Err
+}
+
+func (m *QueryNodeClient) GetDataDistribution(ctx context.Context, in *querypb.GetDataDistributionRequest, opts ...grpc.CallOption) (*querypb.GetDataDistributionResponse, error) {
+	return &querypb.GetDataDistributionResponse{}, m.Err
+}
+
+func (m *QueryNodeClient) PromoteSegments(ctx context.Context, in *querypb.PromoteSegmentsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.Err
+}
+
+func (m *QueryNodeClient) RefreshIndex(ctx context.Context, in *querypb.RefreshIndexRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.Err
+}
+
 func (m *QueryNodeClient) Search(ctx context.Context, in *querypb.SearchRequest, opts ...grpc.CallOption) (*internalpb.SearchResults, error) {
 	return &internalpb.SearchResults{}, m.Err
 }
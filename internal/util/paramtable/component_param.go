@@ -12,6 +12,7 @@
 package paramtable
 
 import (
+	"fmt"
 	"math"
 	"os"
 	"path"
@@ -417,6 +418,31 @@ type proxyConfig struct {
 	BufFlagCleanupInterval   time.Duration
 	GinLogging               bool
 
+	// replica selection
+	ReplicaLoadPollInterval time.Duration
+	ReplicaSelectionPolicy  string // name of the registered policy to select the replica of a shard
+
+	// consume the collection stats pushed by query nodes, the stats of a query node expire after CollectionStatsExpire
+	CollectionStatsEnabled bool
+	CollectionStatsExpire  time.Duration
+
+	// debug
+	ValidateSearchResult bool
+
+	// query results are spilled to local files once their size exceeds the budget, 0 disables spilling
+	QueryResultSpillBudget int64
+	QueryResultSpillDir    string
+
+	// the query results requested in Arrow format are refused if their Arrow IPC stream exceeds MaxArrowResultSize bytes
+	MaxArrowResultSize int64
+
+	// capacity of the LRU cache of the filter expressions parsed into plans, 0 disables the cache
+	ExprCacheSize int
+
+	// synced deletes wait for the shard leaders to apply them for at most DeleteSyncTimeout
+	DeleteSyncTimeout      time.Duration
+	DeleteSyncPollInterval time.Duration
+
 	// required from QueryCoord
 	SearchResultChannelNames   []string
 	RetrieveResultChannelNames []string
@@ -445,6 +471,17 @@ func (p *proxyConfig) init(base *BaseTable) {
 	p.initBufFlagExpireTime()
 	p.initBufFlagCleanupInterval()
 	p.initGinLogging()
+	p.initReplicaLoadPollInterval()
+	p.initReplicaSelectionPolicy()
+	p.initCollectionStatsEnabled()
+	p.initCollectionStatsExpire()
+	p.initValidateSearchResult()
+	p.initQueryResultSpillBudget()
+	p.initQueryResultSpillDir()
+	p.initMaxArrowResultSize()
+	p.initExprCacheSize()
+	p.initDeleteSyncTimeout()
+	p.initDeleteSyncPollInterval()
 }
 
 // InitAlias initialize Alias member.
@@ -543,6 +580,54 @@ func (p *proxyConfig) initGinLogging() {
 	p.GinLogging = p.Base.ParseBool("proxy.ginLogging", true)
 }
 
+func (p *proxyConfig) initReplicaLoadPollInterval() {
+	interval := p.Base.ParseIntWithDefault("proxy.replicaSelection.pollInterval", 500)
+	p.ReplicaLoadPollInterval = time.Duration(interval) * time.Millisecond
+}
+
+func (p *proxyConfig) initReplicaSelectionPolicy() {
+	p.ReplicaSelectionPolicy = p.Base.LoadWithDefault("proxy.replicaSelection.policy", "least_queue")
+}
+
+func (p *proxyConfig) initCollectionStatsEnabled() {
+	p.CollectionStatsEnabled = p.Base.ParseBool("proxy.collectionStats.enabled", false)
+}
+
+func (p *proxyConfig) initCollectionStatsExpire() {
+	expire := p.Base.ParseIntWithDefault("proxy.collectionStats.expire", 60)
+	p.CollectionStatsExpire = time.Duration(expire) * time.Second
+}
+
+func (p *proxyConfig) initValidateSearchResult() {
+	p.ValidateSearchResult = p.Base.ParseBool("proxy.debug.validateSearchResult", false)
+}
+
+func (p *proxyConfig) initQueryResultSpillBudget() {
+	p.QueryResultSpillBudget = p.Base.ParseInt64WithDefault("proxy.queryResultSpill.memoryBudget", 1073741824)
+}
+
+func (p *proxyConfig) initQueryResultSpillDir() {
+	p.QueryResultSpillDir = p.Base.LoadWithDefault("proxy.queryResultSpill.dir", os.TempDir())
+}
+
+func (p *proxyConfig) initMaxArrowResultSize() {
+	p.MaxArrowResultSize = p.Base.ParseInt64WithDefault("proxy.maxArrowResultSize", 268435456)
+}
+
+func (p *proxyConfig) initExprCacheSize() {
+	p.ExprCacheSize = p.Base.ParseIntWithDefault("proxy.exprCacheSize", 1024)
+}
+
+func (p *proxyConfig) initDeleteSyncTimeout() {
+	timeout := p.Base.ParseIntWithDefault("proxy.deleteSync.timeout", 5000)
+	p.DeleteSyncTimeout = time.Duration(timeout) * time.Millisecond
+}
+
+func (p *proxyConfig) initDeleteSyncPollInterval() {
+	interval := p.Base.ParseIntWithDefault("proxy.deleteSync.pollInterval", 100)
+	p.DeleteSyncPollInterval = time.Duration(interval) * time.Millisecond
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // --- querycoord ---
 type queryCoordConfig struct {
@@ -565,6 +650,14 @@ type queryCoordConfig struct {
 	OverloadedMemoryThresholdPercentage float64
 	BalanceIntervalSeconds              int64
 	MemoryUsageMaxDifferencePercentage  float64
+
+	//---- Growing segments ---
+	// send the expected row count of growing segments to query nodes, which pre-allocate the segments by it
+	EnableSegmentRowBudget bool
+
+	//---- TTL ---
+	// time to live of the rows of the loaded collections in seconds, sent to query nodes, 0 means no TTL
+	CollectionTTLSeconds int64
 }
 
 func (p *queryCoordConfig) init(base *BaseTable) {
@@ -578,6 +671,12 @@ func (p *queryCoordConfig) init(base *BaseTable) {
 	p.initOverloadedMemoryThresholdPercentage()
 	p.initBalanceIntervalSeconds()
 	p.initMemoryUsageMaxDifferencePercentage()
+
+	//---- Growing segments ---
+	p.initEnableSegmentRowBudget()
+
+	//---- TTL ---
+	p.initCollectionTTLSeconds()
 }
 
 func (p *queryCoordConfig) initAutoHandoff() {
@@ -627,6 +726,18 @@ func (p *queryCoordConfig) initMemoryUsageMaxDifferencePercentage() {
 	p.MemoryUsageMaxDifferencePercentage = float64(diffPercentage) / 100
 }
 
+func (p *queryCoordConfig) initEnableSegmentRowBudget() {
+	p.EnableSegmentRowBudget = p.Base.ParseBool("queryCoord.segmentRowBudget.enabled", false)
+}
+
+func (p *queryCoordConfig) initCollectionTTLSeconds() {
+	ttl := p.Base.ParseInt64WithDefault("queryCoord.collectionTTLSeconds", 0)
+	if ttl < 0 {
+		ttl = 0
+	}
+	p.CollectionTTLSeconds = ttl
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // --- querynode ---
 type queryNodeConfig struct {
@@ -658,12 +769,21 @@ type queryNodeConfig struct {
 
 	// stats
 	StatsPublishInterval int
+	// interval to push the collection stats to the query node stats channel, disabled if not positive
+	CollectionStatsPublishInterval time.Duration
 
 	GracefulTime int64
 	SliceIndex   int
 
 	// segcore
-	ChunkRows int64
+	ChunkRows           int64
+	EnableSealedPKIndex bool
+	// mirror growing float vectors in Go chunks with norm bounds, searched chunk by chunk with early termination
+	EnableGrowingChunkSearch bool
+	// relative slack of the similarities computed by the chunk search in float64 against the ones of segcore in float32
+	ChunkSearchSimilarityTolerance float64
+	// serve sealed segments by brute force on raw data once loaded, and attach their indexes asynchronously
+	AsyncIndexLoading bool
 
 	CreatedTime time.Time
 	UpdatedTime time.Time
@@ -674,6 +794,117 @@ type queryNodeConfig struct {
 	// cache limit
 	CacheEnabled     bool
 	CacheMemoryLimit int64
+
+	// retrieve
+	MaxRetrieveBinlogFiles int
+	RetrieveBinlogExistTTL time.Duration // how long the existence of the binlogs to retrieve the vector fields from is cached
+
+	// debug
+	ValidateSearchResult  bool
+	PoisonReleasedBuffers bool // fill the released pooled buffers with garbage and panic on use after release
+
+	// growing segment gc
+	GrowingSegmentGCInterval    time.Duration
+	GrowingSegmentIdleTolerance time.Duration
+	// growing segments holding at least GrowingCompactionMinRows rows are compacted once the ratio of
+	// the deleted rows reaches GrowingCompactionDeadRatio, disabled if the ratio is not positive
+	GrowingCompactionDeadRatio float64
+	GrowingCompactionMinRows   int64
+
+	// guarantee ts
+	MaxGuaranteeTsLag time.Duration // max physical time a guarantee ts could be ahead of tSafe, no bound if not positive
+	StrictGuaranteeTs bool          // reject the guarantee ts beyond the bound instead of clamping it
+
+	// result compression
+	ResultCompressType      string // none, zstd or snappy
+	ResultCompressThreshold int    // min size in bytes of the results to compress
+
+	// refuse to pause a channel if a query waiting for its tSafe would time out within the budget
+	PauseDeadlineBudget time.Duration
+
+	// search only the distinct query vectors of a request
+	EnableSearchDedup bool
+
+	// evaluate the predicate of a search first if the estimated selectivity on a segment is no more than
+	// PrefilterSelectivity, 0 disables prefiltering. The candidates no more than PrefilterBruteForceRows
+	// are searched by brute force instead of the vector index
+	PrefilterSelectivity    float64
+	PrefilterBruteForceRows int64
+
+	// the selectivity of the range predicates on scalar fields is estimated by the equi-depth histograms of
+	// HistogramBuckets buckets built from HistogramSampleSize rows sampled from the segments of a collection within
+	// HistogramBuildTimeout, cached for HistogramTTL. 0 HistogramSampleSize disables the histograms
+	HistogramSampleSize   int64
+	HistogramBuckets      int64
+	HistogramTTL          time.Duration
+	HistogramBuildTimeout time.Duration
+
+	// simplify the predicates of search and query plans before creating the segcore plans
+	SimplifyPredicates bool
+
+	// drop the inserts into AutoID collections whose pks are not allocated by the coordinator
+	ValidateAutoID bool
+
+	// catch-up mode of the DML flow graph seeking from a checkpoint lagging real time more than CatchUpLag,
+	// the inserts are applied in batches of CatchUpBatchRows rows, disabled if CatchUpLag is not positive
+	CatchUpLag       time.Duration
+	CatchUpBatchRows int64
+
+	// the consecutive time ticks without data messages in between are coalesced within TimeTickCoalesceWindow
+	// before updating tSafe, disabled if not positive
+	TimeTickCoalesceWindow time.Duration
+
+	// the deletes of a channel matching no growing segment are kept for PendingDeleteWindow, in case the inserts of
+	// their pks arrive later, at most PendingDeleteMaxSize pks. Disabled if PendingDeleteWindow is not positive
+	PendingDeleteWindow  time.Duration
+	PendingDeleteMaxSize int64
+
+	// the deletes of the delta channels matching no sealed segment are buffered for SealedDeleteBufferWindow, and
+	// replayed against the sealed segments loaded later, at most SealedDeleteBufferMaxSize pks. Disabled if
+	// SealedDeleteBufferWindow is not positive
+	SealedDeleteBufferWindow  time.Duration
+	SealedDeleteBufferMaxSize int64
+
+	// fail the flow graph on the messages of types the filter nodes don't support, instead of dropping them
+	StrictMsgType bool
+
+	// max number of the dm channels of a WatchDmChannels request watched concurrently, 1 if not positive
+	WatchDmChannelsParallelism int
+
+	// unix socket of the read-only debug shell, disabled if empty
+	DebugSocketPath string
+
+	// the object storage accesses fail fast for StorageBreakerCoolDown after StorageBreakerFailureThreshold
+	// consecutive failures, disabled if StorageBreakerFailureThreshold is not positive
+	StorageBreakerFailureThreshold int
+	StorageBreakerCoolDown         time.Duration
+
+	// a sealed segment is quarantined after SegmentQuarantineFailures consecutive panicked operations or
+	// internal segcore errors, disabled if not positive
+	SegmentQuarantineFailures int
+
+	// reject the segments to load whose binlog paths are not in the standard layouts, whose ids can't be checked
+	// against the load request, instead of only warning
+	StrictBinlogPathLayout bool
+
+	// the search and query requests taking longer than SlowReadThreshold are logged, disabled if not positive
+	SlowReadThreshold time.Duration
+
+	// the concurrent segcore searches are limited within [SearchConcurrencyMin, SearchConcurrencyMax], the limit is
+	// adjusted every SearchConcurrencyAdjustInterval, disabled if SearchConcurrencyMax is not positive
+	SearchConcurrencyMin            int
+	SearchConcurrencyMax            int
+	SearchConcurrencyAdjustInterval time.Duration
+
+	// the search and retrieve calls into segcore run on CgoReadPoolSize threads, and the insert, delete and load calls
+	// on CgoWritePoolSize threads, so that heavy searches never delay the inserts, the calls run on the threads of the
+	// callers if the size is not positive
+	CgoReadPoolSize  int
+	CgoWritePoolSize int
+
+	// the search and query requests of a collection beyond its cap of concurrent reads wait in a queue of at most
+	// CollectionReadQueueLimit requests, the others are rejected
+	CollectionReadQueueLimit int
 }
 
 func (p *queryNodeConfig) init(base *BaseTable) {
@@ -690,13 +921,74 @@ func (p *queryNodeConfig) init(base *BaseTable) {
 	p.initSearchResultReceiveBufSize()
 
 	p.initStatsPublishInterval()
+	p.initCollectionStatsPublishInterval()
 
 	p.initSegcoreChunkRows()
+	p.initEnableSealedPKIndex()
+	p.initEnableGrowingChunkSearch()
+	p.initChunkSearchSimilarityTolerance()
+	p.initAsyncIndexLoading()
 
 	p.initOverloadedMemoryThresholdPercentage()
 
 	p.initCacheMemoryLimit()
 	p.initCacheEnabled()
+
+	p.initMaxRetrieveBinlogFiles()
+	p.initRetrieveBinlogExistTTL()
+
+	p.initValidateSearchResult()
+	p.initPoisonReleasedBuffers()
+
+	p.initGrowingSegmentGCInterval()
+	p.initGrowingSegmentIdleTolerance()
+	p.initGrowingCompactionDeadRatio()
+	p.initGrowingCompactionMinRows()
+
+	p.initMaxGuaranteeTsLag()
+	p.initStrictGuaranteeTs()
+
+	p.initResultCompressType()
+	p.initResultCompressThreshold()
+
+	p.initPauseDeadlineBudget()
+
+	p.initEnableSearchDedup()
+	p.initPrefilterSelectivity()
+	p.initPrefilterBruteForceRows()
+	p.initHistogramSampleSize()
+	p.initHistogramBuckets()
+	p.initHistogramTTL()
+	p.initHistogramBuildTimeout()
+	p.initSimplifyPredicates()
+
+	p.initValidateAutoID()
+
+	p.initCatchUpLag()
+	p.initCatchUpBatchRows()
+	p.initTimeTickCoalesceWindow()
+	p.initPendingDeleteWindow()
+	p.initPendingDeleteMaxSize()
+	p.initSealedDeleteBufferWindow()
+	p.initSealedDeleteBufferMaxSize()
+	p.initStrictMsgType()
+	p.initWatchDmChannelsParallelism()
+	p.initDebugSocketPath()
+
+	p.initStorageBreakerFailureThreshold()
+	p.initStorageBreakerCoolDown()
+
+	p.initSegmentQuarantineFailures()
+	p.initStrictBinlogPathLayout()
+
+	p.initSlowReadThreshold()
+
+	p.initSearchConcurrencyMin()
+	p.initSearchConcurrencyMax()
+	p.initSearchConcurrencyAdjustInterval()
+	p.initCgoReadPoolSize()
+	p.initCgoWritePoolSize()
+	p.initCollectionReadQueueLimit()
 }
 
 // InitAlias initializes an alias for the QueryNode role.
@@ -731,6 +1023,11 @@ func (p *queryNodeConfig) initStatsPublishInterval() {
 	p.StatsPublishInterval = p.Base.ParseIntWithDefault("queryNode.stats.publishInterval", 1000)
 }
 
+func (p *queryNodeConfig) initCollectionStatsPublishInterval() {
+	interval := p.Base.ParseIntWithDefault("queryNode.stats.collectionStatsPublishInterval", 0)
+	p.CollectionStatsPublishInterval = time.Duration(interval) * time.Millisecond
+}
+
 // dataSync:
 func (p *queryNodeConfig) initFlowGraphMaxQueueLength() {
 	p.FlowGraphMaxQueueLength = p.Base.ParseInt32WithDefault("queryNode.dataSync.flowGraph.maxQueueLength", 1024)
@@ -762,6 +1059,22 @@ func (p *queryNodeConfig) initSegcoreChunkRows() {
 	p.ChunkRows = p.Base.ParseInt64WithDefault("queryNode.segcore.chunkRows", 32768)
 }
 
+func (p *queryNodeConfig) initEnableSealedPKIndex() {
+	p.EnableSealedPKIndex = p.Base.ParseBool("queryNode.segcore.pkIndex.enabled", false)
+}
+
+func (p *queryNodeConfig) initEnableGrowingChunkSearch() {
+	p.EnableGrowingChunkSearch = p.Base.ParseBool("queryNode.segcore.chunkSearch.enabled", false)
+}
+
+func (p *queryNodeConfig) initChunkSearchSimilarityTolerance() {
+	p.ChunkSearchSimilarityTolerance = p.Base.ParseFloatWithDefault("queryNode.segcore.chunkSearch.similarityTolerance", 1e-5)
+}
+
+func (p *queryNodeConfig) initAsyncIndexLoading() {
+	p.AsyncIndexLoading = p.Base.ParseBool("queryNode.segcore.asyncIndexLoading.enabled", false)
+}
+
 func (p *queryNodeConfig) initOverloadedMemoryThresholdPercentage() {
 	overloadedMemoryThresholdPercentage := p.Base.LoadWithDefault("queryCoord.overloadedMemoryThresholdPercentage", "90")
 	thresholdPercentage, err := strconv.ParseInt(overloadedMemoryThresholdPercentage, 10, 64)
@@ -788,6 +1101,184 @@ func (p *queryNodeConfig) initCacheEnabled() {
 	}
 }
 
+func (p *queryNodeConfig) initValidateSearchResult() {
+	p.ValidateSearchResult = p.Base.ParseBool("queryNode.debug.validateSearchResult", false)
+}
+
+func (p *queryNodeConfig) initValidateAutoID() {
+	p.ValidateAutoID = p.Base.ParseBool("queryNode.dataSync.validateAutoID", true)
+}
+
+func (p *queryNodeConfig) initCatchUpLag() {
+	p.CatchUpLag = time.Duration(p.Base.ParseInt64WithDefault("queryNode.dataSync.catchUp.lag", 10)) * time.Second
+}
+
+func (p *queryNodeConfig) initCatchUpBatchRows() {
+	p.CatchUpBatchRows = p.Base.ParseInt64WithDefault("queryNode.dataSync.catchUp.batchRows", 65536)
+}
+
+func (p *queryNodeConfig) initTimeTickCoalesceWindow() {
+	p.TimeTickCoalesceWindow = time.Duration(p.Base.ParseInt64WithDefault("queryNode.dataSync.timeTickCoalesceWindow", 10)) * time.Millisecond
+}
+
+func (p *queryNodeConfig) initPendingDeleteWindow() {
+	p.PendingDeleteWindow = time.Duration(p.Base.ParseInt64WithDefault("queryNode.dataSync.pendingDelete.window", 10)) * time.Second
+}
+
+func (p *queryNodeConfig) initPendingDeleteMaxSize() {
+	p.PendingDeleteMaxSize = p.Base.ParseInt64WithDefault("queryNode.dataSync.pendingDelete.maxSize", 65536)
+}
+
+func (p *queryNodeConfig) initSealedDeleteBufferWindow() {
+	p.SealedDeleteBufferWindow = time.Duration(p.Base.ParseInt64WithDefault("queryNode.dataSync.sealedDeleteBuffer.window", 60)) * time.Second
+}
+
+func (p *queryNodeConfig) initSealedDeleteBufferMaxSize() {
+	p.SealedDeleteBufferMaxSize = p.Base.ParseInt64WithDefault("queryNode.dataSync.sealedDeleteBuffer.maxSize", 65536)
+}
+
+func (p *queryNodeConfig) initStrictMsgType() {
+	p.StrictMsgType = p.Base.ParseBool("queryNode.dataSync.strictMsgType", false)
+}
+
+func (p *queryNodeConfig) initWatchDmChannelsParallelism() {
+	p.WatchDmChannelsParallelism = p.Base.ParseIntWithDefault("queryNode.dataSync.watchDmChannels.parallelism", 4)
+}
+
+func (p *queryNodeConfig) initDebugSocketPath() {
+	p.DebugSocketPath = p.Base.LoadWithDefault("queryNode.debug.socketPath", "")
+}
+
+func (p *queryNodeConfig) initStorageBreakerFailureThreshold() {
+	p.StorageBreakerFailureThreshold = p.Base.ParseIntWithDefault("queryNode.storageBreaker.failureThreshold", 5)
+}
+
+func (p *queryNodeConfig) initStorageBreakerCoolDown() {
+	p.StorageBreakerCoolDown = time.Duration(p.Base.ParseInt64WithDefault("queryNode.storageBreaker.coolDown", 10)) * time.Second
+}
+
+func (p *queryNodeConfig) initSegmentQuarantineFailures() {
+	p.SegmentQuarantineFailures = p.Base.ParseIntWithDefault("queryNode.segment.quarantineFailures", 0)
+}
+
+func (p *queryNodeConfig) initStrictBinlogPathLayout() {
+	p.StrictBinlogPathLayout = p.Base.ParseBool("queryNode.segment.strictBinlogPathLayout", true)
+}
+
+func (p *queryNodeConfig) initSlowReadThreshold() {
+	p.SlowReadThreshold = time.Duration(p.Base.ParseInt64WithDefault("queryNode.slowRead.threshold", 1000)) * time.Millisecond
+}
+
+func (p *queryNodeConfig) initSearchConcurrencyMin() {
+	p.SearchConcurrencyMin = p.Base.ParseIntWithDefault("queryNode.searchConcurrency.min", 1)
+}
+
+func (p *queryNodeConfig) initSearchConcurrencyMax() {
+	p.SearchConcurrencyMax = p.Base.ParseIntWithDefault("queryNode.searchConcurrency.max", 0)
+}
+
+func (p *queryNodeConfig) initSearchConcurrencyAdjustInterval() {
+	p.SearchConcurrencyAdjustInterval = time.Duration(p.Base.ParseInt64WithDefault("queryNode.searchConcurrency.adjustInterval", 5)) * time.Second
+}
+
+func (p *queryNodeConfig) initCgoReadPoolSize() {
+	p.CgoReadPoolSize = p.Base.ParseIntWithDefault("queryNode.cgoPool.readSize", 0)
+}
+
+func (p *queryNodeConfig) initCgoWritePoolSize() {
+	p.CgoWritePoolSize = p.Base.ParseIntWithDefault("queryNode.cgoPool.writeSize", 0)
+}
+
+func (p *queryNodeConfig) initCollectionReadQueueLimit() {
+	p.CollectionReadQueueLimit = p.Base.ParseIntWithDefault("queryNode.collectionRead.queueLimit", 64)
+}
+
+func (p *queryNodeConfig) initPoisonReleasedBuffers() {
+	p.PoisonReleasedBuffers = p.Base.ParseBool("queryNode.debug.poisonReleasedBuffers", false)
+}
+
+func (p *queryNodeConfig) initMaxRetrieveBinlogFiles() {
+	p.MaxRetrieveBinlogFiles = p.Base.ParseIntWithDefault("queryNode.retrieve.maxBinlogFiles", 1024)
+}
+
+func (p *queryNodeConfig) initRetrieveBinlogExistTTL() {
+	p.RetrieveBinlogExistTTL = time.Duration(p.Base.ParseInt64WithDefault("queryNode.retrieve.binlogExistTTL", 60)) * time.Second
+}
+
+func (p *queryNodeConfig) initGrowingSegmentGCInterval() {
+	p.GrowingSegmentGCInterval = time.Duration(p.Base.ParseInt64WithDefault("queryNode.gc.interval", 60)) * time.Second
+}
+
+func (p *queryNodeConfig) initGrowingSegmentIdleTolerance() {
+	p.GrowingSegmentIdleTolerance = time.Duration(p.Base.ParseInt64WithDefault("queryNode.gc.growingIdleTolerance", 10*60)) * time.Second
+}
+
+func (p *queryNodeConfig) initGrowingCompactionDeadRatio() {
+	p.GrowingCompactionDeadRatio = p.Base.ParseFloatWithDefault("queryNode.gc.growingCompactionDeadRatio", 0.5)
+}
+
+func (p *queryNodeConfig) initGrowingCompactionMinRows() {
+	p.GrowingCompactionMinRows = p.Base.ParseInt64WithDefault("queryNode.gc.growingCompactionMinRows", 10000)
+}
+
+func (p *queryNodeConfig) initMaxGuaranteeTsLag() {
+	p.MaxGuaranteeTsLag = time.Duration(p.Base.ParseInt64WithDefault("queryNode.guaranteeTs.maxLag", 0)) * time.Second
+}
+
+func (p *queryNodeConfig) initStrictGuaranteeTs() {
+	p.StrictGuaranteeTs = p.Base.ParseBool("queryNode.guaranteeTs.strict", false)
+}
+
+func (p *queryNodeConfig) initResultCompressType() {
+	compressType := strings.ToLower(p.Base.LoadWithDefault("queryNode.resultCompression.type", "none"))
+	switch compressType {
+	case "none", "zstd", "snappy":
+		p.ResultCompressType = compressType
+	default:
+		panic(fmt.Errorf("invalid queryNode.resultCompression.type %s, should be none, zstd or snappy", compressType))
+	}
+}
+
+func (p *queryNodeConfig) initResultCompressThreshold() {
+	p.ResultCompressThreshold = p.Base.ParseIntWithDefault("queryNode.resultCompression.threshold", 65536)
+}
+
+func (p *queryNodeConfig) initPauseDeadlineBudget() {
+	p.PauseDeadlineBudget = time.Duration(p.Base.ParseInt64WithDefault("queryNode.dataSync.pauseDeadlineBudget", 10)) * time.Second
+}
+
+func (p *queryNodeConfig) initEnableSearchDedup() {
+	p.EnableSearchDedup = p.Base.ParseBool("queryNode.search.dedup", true)
+}
+
+func (p *queryNodeConfig) initPrefilterSelectivity() {
+	p.PrefilterSelectivity = p.Base.ParseFloatWithDefault("queryNode.search.prefilter.selectivity", 0.01)
+}
+
+func (p *queryNodeConfig) initPrefilterBruteForceRows() {
+	p.PrefilterBruteForceRows = p.Base.ParseInt64WithDefault("queryNode.search.prefilter.bruteForceRows", 2048)
+}
+
+func (p *queryNodeConfig) initHistogramSampleSize() {
+	p.HistogramSampleSize = p.Base.ParseInt64WithDefault("queryNode.search.prefilter.histogram.sampleSize", 10000)
+}
+
+func (p *queryNodeConfig) initHistogramBuckets() {
+	p.HistogramBuckets = p.Base.ParseInt64WithDefault("queryNode.search.prefilter.histogram.buckets", 64)
+}
+
+func (p *queryNodeConfig) initHistogramTTL() {
+	p.HistogramTTL = time.Duration(p.Base.ParseInt64WithDefault("queryNode.search.prefilter.histogram.ttl", 300)) * time.Second
+}
+
+func (p *queryNodeConfig) initHistogramBuildTimeout() {
+	p.HistogramBuildTimeout = time.Duration(p.Base.ParseInt64WithDefault("queryNode.search.prefilter.histogram.buildTimeout", 1000)) * time.Millisecond
+}
+
+func (p *queryNodeConfig) initSimplifyPredicates() {
+	p.SimplifyPredicates = p.Base.ParseBool("queryNode.plan.simplifyPredicates", true)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // --- datacoord ---
 type dataCoordConfig struct {
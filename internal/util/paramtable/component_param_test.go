@@ -137,6 +137,18 @@ func TestComponentParam(t *testing.T) {
 		t.Logf("MaxDimension: %d", Params.MaxDimension)
 
 		t.Logf("MaxTaskNum: %d", Params.MaxTaskNum)
+
+		assert.Equal(t, 500*time.Millisecond, Params.ReplicaLoadPollInterval)
+		assert.Equal(t, "least_queue", Params.ReplicaSelectionPolicy)
+		assert.False(t, Params.CollectionStatsEnabled)
+		assert.Equal(t, time.Minute, Params.CollectionStatsExpire)
+		assert.False(t, Params.ValidateSearchResult)
+		assert.Equal(t, int64(1073741824), Params.QueryResultSpillBudget)
+		assert.Equal(t, os.TempDir(), Params.QueryResultSpillDir)
+		assert.Equal(t, int64(268435456), Params.MaxArrowResultSize)
+		assert.Equal(t, 1024, Params.ExprCacheSize)
+		assert.Equal(t, 5*time.Second, Params.DeleteSyncTimeout)
+		assert.Equal(t, 100*time.Millisecond, Params.DeleteSyncPollInterval)
 	})
 
 	t.Run("test proxyConfig panic", func(t *testing.T) {
@@ -194,7 +206,9 @@ func TestComponentParam(t *testing.T) {
 	})
 
 	t.Run("test queryCoordConfig", func(t *testing.T) {
-		//Params := CParams.QueryCoordCfg
+		Params := CParams.QueryCoordCfg
+		assert.False(t, Params.EnableSegmentRowBudget)
+		assert.Equal(t, int64(0), Params.CollectionTTLSeconds)
 	})
 
 	t.Run("test queryNodeConfig", func(t *testing.T) {
@@ -213,6 +227,7 @@ func TestComponentParam(t *testing.T) {
 
 		interval := Params.StatsPublishInterval
 		assert.Equal(t, 1000, interval)
+		assert.Equal(t, time.Duration(0), Params.CollectionStatsPublishInterval)
 
 		bufSize := Params.SearchReceiveBufSize
 		assert.Equal(t, int64(512), bufSize)
@@ -228,6 +243,67 @@ func TestComponentParam(t *testing.T) {
 
 		maxParallelism := Params.FlowGraphMaxParallelism
 		assert.Equal(t, int32(1024), maxParallelism)
+
+		assert.False(t, Params.EnableSealedPKIndex)
+		assert.False(t, Params.EnableGrowingChunkSearch)
+		assert.Equal(t, 1e-5, Params.ChunkSearchSimilarityTolerance)
+		assert.False(t, Params.AsyncIndexLoading)
+		assert.Equal(t, 1024, Params.MaxRetrieveBinlogFiles)
+		assert.Equal(t, time.Minute, Params.RetrieveBinlogExistTTL)
+
+		assert.False(t, Params.ValidateSearchResult)
+
+		assert.Equal(t, time.Minute, Params.GrowingSegmentGCInterval)
+		assert.Equal(t, 10*time.Minute, Params.GrowingSegmentIdleTolerance)
+		assert.Equal(t, 0.5, Params.GrowingCompactionDeadRatio)
+		assert.Equal(t, int64(10000), Params.GrowingCompactionMinRows)
+
+		assert.Equal(t, time.Duration(0), Params.MaxGuaranteeTsLag)
+		assert.False(t, Params.StrictGuaranteeTs)
+
+		assert.Equal(t, "none", Params.ResultCompressType)
+		assert.Equal(t, 65536, Params.ResultCompressThreshold)
+		Params.Base.Save("queryNode.resultCompression.type", "Snappy")
+		Params.initResultCompressType()
+		assert.Equal(t, "snappy", Params.ResultCompressType)
+		Params.Base.Save("queryNode.resultCompression.type", "lz4")
+		assert.Panics(t, func() { Params.initResultCompressType() })
+		Params.Base.Save("queryNode.resultCompression.type", "none")
+		Params.initResultCompressType()
+
+		assert.Equal(t, 10*time.Second, Params.PauseDeadlineBudget)
+
+		assert.True(t, Params.EnableSearchDedup)
+		assert.Equal(t, 0.01, Params.PrefilterSelectivity)
+		assert.Equal(t, int64(2048), Params.PrefilterBruteForceRows)
+		assert.Equal(t, int64(10000), Params.HistogramSampleSize)
+		assert.Equal(t, int64(64), Params.HistogramBuckets)
+		assert.Equal(t, 5*time.Minute, Params.HistogramTTL)
+		assert.Equal(t, time.Second, Params.HistogramBuildTimeout)
+		assert.True(t, Params.SimplifyPredicates)
+		assert.False(t, Params.PoisonReleasedBuffers)
+		assert.True(t, Params.ValidateAutoID)
+		assert.Equal(t, 10*time.Second, Params.CatchUpLag)
+		assert.Equal(t, int64(65536), Params.CatchUpBatchRows)
+		assert.Equal(t, 10*time.Millisecond, Params.TimeTickCoalesceWindow)
+		assert.Equal(t, 10*time.Second, Params.PendingDeleteWindow)
+		assert.Equal(t, int64(65536), Params.PendingDeleteMaxSize)
+		assert.Equal(t, time.Minute, Params.SealedDeleteBufferWindow)
+		assert.Equal(t, int64(65536), Params.SealedDeleteBufferMaxSize)
+		assert.False(t, Params.StrictMsgType)
+		assert.Equal(t, 4, Params.WatchDmChannelsParallelism)
+		assert.Equal(t, "", Params.DebugSocketPath)
+		assert.Equal(t, 5, Params.StorageBreakerFailureThreshold)
+		assert.Equal(t, 10*time.Second, Params.StorageBreakerCoolDown)
+		assert.Equal(t, 0, Params.SegmentQuarantineFailures)
+		assert.True(t, Params.StrictBinlogPathLayout)
+		assert.Equal(t, time.Second, Params.SlowReadThreshold)
+		assert.Equal(t, 1, Params.SearchConcurrencyMin)
+		assert.Equal(t, 0, Params.SearchConcurrencyMax)
+		assert.Equal(t, 5*time.Second, Params.SearchConcurrencyAdjustInterval)
+		assert.Equal(t, 0, Params.CgoReadPoolSize)
+		assert.Equal(t, 0, Params.CgoWritePoolSize)
+		assert.Equal(t, 64, Params.CollectionReadQueueLimit)
 	})
 
 	t.Run("test dataCoordConfig", func(t *testing.T) {
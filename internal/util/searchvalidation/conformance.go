@@ -0,0 +1,140 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package searchvalidation
+
+import (
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// The collection of the conformance cases
+const (
+	ConformanceCollectionID = int64(1)
+	ConformanceFloatVecDim  = 8
+	ConformanceBinaryVecDim = 16
+)
+
+// ConformanceCase is a search request of the collection of ConformanceSchema, with whether it should be accepted.
+// The unit tests of the proxy and the query nodes run the cases through their own integrations of the validation,
+// so that both sides are kept accepting and rejecting the same requests.
+type ConformanceCase struct {
+	Name             string
+	AnnsField        string
+	QueryInfo        *planpb.QueryInfo
+	PlaceholderGroup []byte
+	OutputFields     []string
+	Valid            bool
+}
+
+// ConformanceSchema returns the schema of the collection of the conformance cases
+func ConformanceSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Name: "conformance",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "age", DataType: schemapb.DataType_Int32},
+			{
+				FieldID:    102,
+				Name:       "float_vec",
+				DataType:   schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: strconv.Itoa(ConformanceFloatVecDim)}},
+			},
+			{
+				FieldID:    103,
+				Name:       "binary_vec",
+				DataType:   schemapb.DataType_BinaryVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: strconv.Itoa(ConformanceBinaryVecDim)}},
+			},
+		},
+	}
+}
+
+// ConformanceCases returns the conformance cases
+func ConformanceCases() []ConformanceCase {
+	queryInfo := func(topK int64, metricType string, roundDecimal int64, searchParams string) *planpb.QueryInfo {
+		return &planpb.QueryInfo{
+			Topk:         topK,
+			MetricType:   metricType,
+			SearchParams: searchParams,
+			RoundDecimal: roundDecimal,
+		}
+	}
+	floatInfo := queryInfo(10, "L2", -1, `{"nprobe": 10}`)
+	binaryInfo := queryInfo(10, "JACCARD", -1, `{"nprobe": 10}`)
+	floatVectors := conformancePlaceholderGroup(milvuspb.PlaceholderType_FloatVector, 2, ConformanceFloatVecDim*4)
+	binaryVectors := conformancePlaceholderGroup(milvuspb.PlaceholderType_BinaryVector, 2, ConformanceBinaryVecDim/8)
+
+	return []ConformanceCase{
+		{Name: "float vector", AnnsField: "float_vec", QueryInfo: floatInfo, PlaceholderGroup: floatVectors, Valid: true},
+		{Name: "binary vector", AnnsField: "binary_vec", QueryInfo: binaryInfo, PlaceholderGroup: binaryVectors, Valid: true},
+		{Name: "output fields", AnnsField: "float_vec", QueryInfo: floatInfo, PlaceholderGroup: floatVectors,
+			OutputFields: []string{"pk", "age", "float_vec"}, Valid: true},
+		{Name: "inner product", AnnsField: "float_vec", QueryInfo: queryInfo(10, "IP", -1, ""),
+			PlaceholderGroup: floatVectors, Valid: true},
+		{Name: "max limit", AnnsField: "float_vec", QueryInfo: queryInfo(MaxTopK, "L2", -1, ""),
+			PlaceholderGroup: floatVectors, Valid: true},
+		{Name: "max round decimal", AnnsField: "float_vec", QueryInfo: queryInfo(10, "L2", MaxRoundDecimal, ""),
+			PlaceholderGroup: floatVectors, Valid: true},
+
+		{Name: "anns field not exist", AnnsField: "not_exist", QueryInfo: floatInfo, PlaceholderGroup: floatVectors},
+		{Name: "scalar anns field", AnnsField: "age", QueryInfo: floatInfo, PlaceholderGroup: floatVectors},
+		{Name: "zero limit", AnnsField: "float_vec", QueryInfo: queryInfo(0, "L2", -1, ""), PlaceholderGroup: floatVectors},
+		{Name: "limit too large", AnnsField: "float_vec", QueryInfo: queryInfo(MaxTopK+1, "L2", -1, ""),
+			PlaceholderGroup: floatVectors},
+		{Name: "round decimal too large", AnnsField: "float_vec", QueryInfo: queryInfo(10, "L2", MaxRoundDecimal+1, ""),
+			PlaceholderGroup: floatVectors},
+		{Name: "negative round decimal", AnnsField: "float_vec", QueryInfo: queryInfo(10, "L2", -2, ""),
+			PlaceholderGroup: floatVectors},
+		{Name: "invalid search params", AnnsField: "float_vec", QueryInfo: queryInfo(10, "L2", -1, `{"nprobe": `),
+			PlaceholderGroup: floatVectors},
+		{Name: "unknown metric type", AnnsField: "float_vec", QueryInfo: queryInfo(10, "COSINE_XX", -1, ""),
+			PlaceholderGroup: floatVectors},
+		{Name: "binary metric type of float vector", AnnsField: "float_vec", QueryInfo: queryInfo(10, "HAMMING", -1, ""),
+			PlaceholderGroup: floatVectors},
+		{Name: "float metric type of binary vector", AnnsField: "binary_vec", QueryInfo: queryInfo(10, "L2", -1, ""),
+			PlaceholderGroup: binaryVectors},
+		{Name: "placeholder dim mismatch", AnnsField: "float_vec", QueryInfo: floatInfo,
+			PlaceholderGroup: conformancePlaceholderGroup(milvuspb.PlaceholderType_FloatVector, 2, ConformanceFloatVecDim*4+4)},
+		{Name: "placeholder type mismatch", AnnsField: "float_vec", QueryInfo: floatInfo, PlaceholderGroup: binaryVectors},
+		{Name: "no query vector", AnnsField: "float_vec", QueryInfo: floatInfo,
+			PlaceholderGroup: conformancePlaceholderGroup(milvuspb.PlaceholderType_FloatVector, 0, ConformanceFloatVecDim*4)},
+		{Name: "invalid placeholder group", AnnsField: "float_vec", QueryInfo: floatInfo, PlaceholderGroup: []byte{0xff}},
+		{Name: "output field not exist", AnnsField: "float_vec", QueryInfo: floatInfo, PlaceholderGroup: floatVectors,
+			OutputFields: []string{"pk", "not_exist"}},
+	}
+}
+
+// conformancePlaceholderGroup returns the serialized placeholder group of nq query vectors of size bytes
+func conformancePlaceholderGroup(placeholderType milvuspb.PlaceholderType, nq int, size int) []byte {
+	values := make([][]byte, 0, nq)
+	for i := 0; i < nq; i++ {
+		values = append(values, make([]byte, size))
+	}
+	group, err := proto.Marshal(&milvuspb.PlaceholderGroup{
+		Placeholders: []*milvuspb.PlaceholderValue{{Tag: "$0", Type: placeholderType, Values: values}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return group
+}
@@ -0,0 +1,136 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package searchvalidation provides the checks of search requests shared by the proxy and the query nodes, so that
+// both sides accept and reject the same requests. The proxy builds the CollectionSearchSpec from its meta cache, the
+// query nodes from their collections.
+package searchvalidation
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// FieldSpec is what the search validation knows about a field
+type FieldSpec struct {
+	FieldID  int64
+	Name     string
+	DataType schemapb.DataType
+	// Dim is the dim of the vector fields, 0 if the schema does not specify it
+	Dim int64
+	// IndexParams are the params of the index of the field, nil if the field is not indexed or the index is unknown
+	IndexParams []*commonpb.KeyValuePair
+}
+
+// CollectionSearchSpec is what the search validation knows about a collection
+type CollectionSearchSpec struct {
+	collectionID int64
+	fields       []*FieldSpec
+	byID         map[int64]*FieldSpec
+	byName       map[string]*FieldSpec
+}
+
+// NewCollectionSearchSpec returns the CollectionSearchSpec of the collection of schema, without index info
+func NewCollectionSearchSpec(collectionID int64, schema *schemapb.CollectionSchema) (*CollectionSearchSpec, error) {
+	spec := &CollectionSearchSpec{
+		collectionID: collectionID,
+		fields:       make([]*FieldSpec, 0, len(schema.GetFields())),
+		byID:         make(map[int64]*FieldSpec, len(schema.GetFields())),
+		byName:       make(map[string]*FieldSpec, len(schema.GetFields())),
+	}
+	for _, field := range schema.GetFields() {
+		fieldSpec := &FieldSpec{
+			FieldID:  field.GetFieldID(),
+			Name:     field.GetName(),
+			DataType: field.GetDataType(),
+		}
+		if typeutil.IsVectorType(field.GetDataType()) {
+			dim, err := dimOfField(field)
+			if err != nil {
+				return nil, err
+			}
+			fieldSpec.Dim = dim
+		}
+		spec.fields = append(spec.fields, fieldSpec)
+		spec.byID[fieldSpec.FieldID] = fieldSpec
+		spec.byName[fieldSpec.Name] = fieldSpec
+	}
+	return spec, nil
+}
+
+// dimOfField returns the dim type param of the vector field, or 0 if it is not specified
+func dimOfField(field *schemapb.FieldSchema) (int64, error) {
+	for _, kv := range field.GetTypeParams() {
+		if kv.GetKey() != "dim" {
+			continue
+		}
+		dim, err := strconv.ParseInt(kv.GetValue(), 10, 64)
+		if err != nil || dim <= 0 {
+			return 0, fmt.Errorf("invalid dim %s of field %s", kv.GetValue(), field.GetName())
+		}
+		if field.GetDataType() == schemapb.DataType_BinaryVector && dim%8 != 0 {
+			return 0, fmt.Errorf("dim %d of binary vector field %s should be multiple of 8", dim, field.GetName())
+		}
+		return dim, nil
+	}
+	return 0, nil
+}
+
+// CollectionID returns the ID of the collection
+func (s *CollectionSearchSpec) CollectionID() int64 {
+	return s.collectionID
+}
+
+// Fields returns the specs of the fields in order of the schema
+func (s *CollectionSearchSpec) Fields() []*FieldSpec {
+	return s.fields
+}
+
+// FieldByID returns the spec of the field of fieldID
+func (s *CollectionSearchSpec) FieldByID(fieldID int64) (*FieldSpec, error) {
+	field, ok := s.byID[fieldID]
+	if !ok {
+		return nil, fmt.Errorf("field %d not exist in collection %d", fieldID, s.collectionID)
+	}
+	return field, nil
+}
+
+// FieldByName returns the spec of the field of name
+func (s *CollectionSearchSpec) FieldByName(name string) (*FieldSpec, error) {
+	field, ok := s.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("Field %s not exist", name)
+	}
+	return field, nil
+}
+
+// SetIndexParams records the params of the index of the vector field, so that the metric type of search requests
+// is checked against the index
+func (s *CollectionSearchSpec) SetIndexParams(fieldID int64, indexParams []*commonpb.KeyValuePair) error {
+	field, err := s.FieldByID(fieldID)
+	if err != nil {
+		return err
+	}
+	if !typeutil.IsVectorType(field.DataType) {
+		return fmt.Errorf("field %s is not a vector field", field.Name)
+	}
+	field.IndexParams = indexParams
+	return nil
+}
@@ -0,0 +1,87 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package searchvalidation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+func TestNewCollectionSearchSpec(t *testing.T) {
+	t.Run("fields", func(t *testing.T) {
+		spec, err := NewCollectionSearchSpec(ConformanceCollectionID, ConformanceSchema())
+		require.NoError(t, err)
+		assert.Equal(t, ConformanceCollectionID, spec.CollectionID())
+		assert.Len(t, spec.Fields(), 4)
+
+		field, err := spec.FieldByName("float_vec")
+		require.NoError(t, err)
+		assert.Equal(t, int64(ConformanceFloatVecDim), field.Dim)
+		byID, err := spec.FieldByID(field.FieldID)
+		require.NoError(t, err)
+		assert.Same(t, field, byID)
+
+		field, err = spec.FieldByName("age")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), field.Dim)
+
+		_, err = spec.FieldByName("not_exist")
+		assert.Error(t, err)
+		_, err = spec.FieldByID(999)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid dim", func(t *testing.T) {
+		for _, field := range []*schemapb.FieldSchema{
+			{Name: "vec", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "x"}}},
+			{Name: "vec", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "0"}}},
+			{Name: "vec", DataType: schemapb.DataType_BinaryVector, TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "12"}}},
+		} {
+			_, err := NewCollectionSearchSpec(1, &schemapb.CollectionSchema{Fields: []*schemapb.FieldSchema{field}})
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("unspecified dim", func(t *testing.T) {
+		schema := &schemapb.CollectionSchema{Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "vec", DataType: schemapb.DataType_FloatVector},
+		}}
+		spec, err := NewCollectionSearchSpec(1, schema)
+		require.NoError(t, err)
+		field, err := spec.FieldByID(100)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), field.Dim)
+	})
+
+	t.Run("index params", func(t *testing.T) {
+		spec, err := NewCollectionSearchSpec(ConformanceCollectionID, ConformanceSchema())
+		require.NoError(t, err)
+		indexParams := []*commonpb.KeyValuePair{{Key: "index_type", Value: "IVF_FLAT"}, {Key: "metric_type", Value: "L2"}}
+		require.NoError(t, spec.SetIndexParams(102, indexParams))
+		field, err := spec.FieldByID(102)
+		require.NoError(t, err)
+		assert.Equal(t, indexParams, field.IndexParams)
+
+		assert.Error(t, spec.SetIndexParams(101, indexParams))
+		assert.Error(t, spec.SetIndexParams(999, indexParams))
+	})
+}
@@ -0,0 +1,194 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package searchvalidation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/indexparamcheck"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+const (
+	// MaxTopK is the max limit of search requests
+	MaxTopK = 16384
+	// MaxRoundDecimal is the max number of decimal places the scores are rounded to, -1 for no rounding
+	MaxRoundDecimal = 6
+)
+
+// ValidateSearchRequest runs all the checks of a search request, and returns the number of the query vectors
+func ValidateSearchRequest(spec *CollectionSearchSpec, annsFieldID int64, queryInfo *planpb.QueryInfo,
+	placeholderGroup []byte, outputFieldIDs []int64) (int64, error) {
+	if err := ValidateSearchParams(spec, annsFieldID, queryInfo); err != nil {
+		return 0, err
+	}
+	nq, err := ValidatePlaceholders(spec, annsFieldID, placeholderGroup)
+	if err != nil {
+		return 0, err
+	}
+	if err := ValidateOutputFields(spec, outputFieldIDs); err != nil {
+		return 0, err
+	}
+	return nq, nil
+}
+
+// ValidateSearchParams checks the anns field and the query info of a search request
+func ValidateSearchParams(spec *CollectionSearchSpec, annsFieldID int64, queryInfo *planpb.QueryInfo) error {
+	field, err := spec.FieldByID(annsFieldID)
+	if err != nil {
+		return err
+	}
+	if !typeutil.IsVectorType(field.DataType) {
+		return fmt.Errorf("anns field %s is not a vector field", field.Name)
+	}
+
+	topK := queryInfo.GetTopk()
+	if topK <= 0 || topK > MaxTopK {
+		return fmt.Errorf("limit should be in range [1, %d], but got %d", MaxTopK, topK)
+	}
+	roundDecimal := queryInfo.GetRoundDecimal()
+	if roundDecimal != -1 && (roundDecimal < 0 || roundDecimal > MaxRoundDecimal) {
+		return fmt.Errorf("round_decimal %d is invalid, should be -1 or in range [0, %d]", roundDecimal, MaxRoundDecimal)
+	}
+	if searchParams := queryInfo.GetSearchParams(); searchParams != "" && !json.Valid([]byte(searchParams)) {
+		return fmt.Errorf("search params %s is not valid json", searchParams)
+	}
+
+	metricType := queryInfo.GetMetricType()
+	if !containsFold(metricTypesOf(field.DataType), metricType) {
+		return fmt.Errorf("metric type %s is not supported by field %s of %s, supported metric types: %v",
+			metricType, field.Name, field.DataType.String(), metricTypesOf(field.DataType))
+	}
+	if field.IndexParams != nil {
+		if indexType, indexMetricType, ok := MatchIndexMetricType(field.IndexParams, metricType); !ok {
+			return fmt.Errorf("metric type %s of search request is incompatible with index %s built with metric type %s, field = %s",
+				metricType, indexType, indexMetricType, field.Name)
+		}
+	}
+	return nil
+}
+
+// MatchIndexMetricType returns whether the index of indexParams could be searched with metricType, along with the
+// type and the metric type of the index. The flat indexes accept any metric type, the other indexes only accept the
+// metric type they are built with.
+func MatchIndexMetricType(indexParams []*commonpb.KeyValuePair, metricType string) (string, string, bool) {
+	indexType, _ := funcutil.GetAttrByKeyFromRepeatedKV("index_type", indexParams)
+	indexMetricType, err := funcutil.GetAttrByKeyFromRepeatedKV(indexparamcheck.Metric, indexParams)
+	switch indexparamcheck.IndexType(indexType) {
+	case indexparamcheck.IndexFaissIDMap, indexparamcheck.IndexFaissBinIDMap:
+		return indexType, indexMetricType, true
+	}
+	return indexType, indexMetricType, err != nil || strings.EqualFold(indexMetricType, metricType)
+}
+
+// ValidatePlaceholders checks the query vectors of the serialized placeholder group against the anns field, and
+// returns the number of the query vectors
+func ValidatePlaceholders(spec *CollectionSearchSpec, annsFieldID int64, placeholderGroup []byte) (int64, error) {
+	field, err := spec.FieldByID(annsFieldID)
+	if err != nil {
+		return 0, err
+	}
+	group := &milvuspb.PlaceholderGroup{}
+	if err := proto.Unmarshal(placeholderGroup, group); err != nil {
+		return 0, fmt.Errorf("invalid placeholder group: %w", err)
+	}
+	if len(group.GetPlaceholders()) != 1 {
+		return 0, fmt.Errorf("placeholder group should contain exactly one placeholder, but got %d", len(group.GetPlaceholders()))
+	}
+
+	placeholder := group.GetPlaceholders()[0]
+	var expectedType milvuspb.PlaceholderType
+	var vectorSize int64
+	switch field.DataType {
+	case schemapb.DataType_FloatVector:
+		expectedType, vectorSize = milvuspb.PlaceholderType_FloatVector, field.Dim*4
+	case schemapb.DataType_BinaryVector:
+		expectedType, vectorSize = milvuspb.PlaceholderType_BinaryVector, field.Dim/8
+	default:
+		return 0, fmt.Errorf("anns field %s is not a vector field", field.Name)
+	}
+	if placeholder.GetType() != expectedType {
+		return 0, fmt.Errorf("query vectors of %s mismatch field %s of %s",
+			placeholder.GetType().String(), field.Name, field.DataType.String())
+	}
+	if len(placeholder.GetValues()) == 0 {
+		return 0, fmt.Errorf("no query vector in placeholder group")
+	}
+	// the dim is unknown if the schema does not specify it
+	if vectorSize > 0 {
+		for i, value := range placeholder.GetValues() {
+			if int64(len(value)) != vectorSize {
+				return 0, fmt.Errorf("the %d-th query vector is of %d bytes, mismatch dim %d of field %s",
+					i, len(value), field.Dim, field.Name)
+			}
+		}
+	}
+	return int64(len(placeholder.GetValues())), nil
+}
+
+// ValidateOutputFields checks that the output fields exist
+func ValidateOutputFields(spec *CollectionSearchSpec, outputFieldIDs []int64) error {
+	for _, fieldID := range outputFieldIDs {
+		if _, err := spec.FieldByID(fieldID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResolveOutputFields returns the IDs of the output fields of names
+func ResolveOutputFields(spec *CollectionSearchSpec, names []string) ([]int64, error) {
+	fieldIDs := make([]int64, 0, len(names))
+	for _, name := range names {
+		field, err := spec.FieldByName(name)
+		if err != nil {
+			return nil, err
+		}
+		fieldIDs = append(fieldIDs, field.FieldID)
+	}
+	return fieldIDs, nil
+}
+
+// metricTypesOf returns the metric types supported by the vector fields of dataType
+func metricTypesOf(dataType schemapb.DataType) []string {
+	switch dataType {
+	case schemapb.DataType_FloatVector:
+		return indexparamcheck.METRICS
+	case schemapb.DataType_BinaryVector:
+		return indexparamcheck.BinIDMapMetrics
+	default:
+		return nil
+	}
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package searchvalidation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+)
+
+func TestValidateSearchRequest_Conformance(t *testing.T) {
+	spec, err := NewCollectionSearchSpec(ConformanceCollectionID, ConformanceSchema())
+	require.NoError(t, err)
+	for _, c := range ConformanceCases() {
+		t.Run(c.Name, func(t *testing.T) {
+			annsFieldID := int64(-1)
+			if field, err := spec.FieldByName(c.AnnsField); err == nil {
+				annsFieldID = field.FieldID
+			}
+			outputFieldIDs, err := ResolveOutputFields(spec, c.OutputFields)
+			if err != nil {
+				assert.False(t, c.Valid)
+				return
+			}
+			nq, err := ValidateSearchRequest(spec, annsFieldID, c.QueryInfo, c.PlaceholderGroup, outputFieldIDs)
+			if c.Valid {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(2), nq)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateSearchParams_Index(t *testing.T) {
+	spec, err := NewCollectionSearchSpec(ConformanceCollectionID, ConformanceSchema())
+	require.NoError(t, err)
+	field, err := spec.FieldByName("float_vec")
+	require.NoError(t, err)
+	queryInfo := func(metricType string) *planpb.QueryInfo {
+		return &planpb.QueryInfo{Topk: 10, MetricType: metricType, RoundDecimal: -1}
+	}
+
+	require.NoError(t, spec.SetIndexParams(field.FieldID, []*commonpb.KeyValuePair{
+		{Key: "index_type", Value: "IVF_FLAT"},
+		{Key: "metric_type", Value: "L2"},
+	}))
+	assert.NoError(t, ValidateSearchParams(spec, field.FieldID, queryInfo("L2")))
+	assert.Error(t, ValidateSearchParams(spec, field.FieldID, queryInfo("IP")))
+
+	// flat indexes accept any metric type
+	require.NoError(t, spec.SetIndexParams(field.FieldID, []*commonpb.KeyValuePair{
+		{Key: "index_type", Value: "FLAT"},
+		{Key: "metric_type", Value: "L2"},
+	}))
+	assert.NoError(t, ValidateSearchParams(spec, field.FieldID, queryInfo("IP")))
+}
+
+func TestMatchIndexMetricType(t *testing.T) {
+	indexParams := []*commonpb.KeyValuePair{{Key: "index_type", Value: "HNSW"}, {Key: "metric_type", Value: "IP"}}
+	indexType, indexMetricType, ok := MatchIndexMetricType(indexParams, "ip")
+	assert.True(t, ok)
+	assert.Equal(t, "HNSW", indexType)
+	assert.Equal(t, "IP", indexMetricType)
+	_, _, ok = MatchIndexMetricType(indexParams, "L2")
+	assert.False(t, ok)
+
+	_, _, ok = MatchIndexMetricType([]*commonpb.KeyValuePair{{Key: "index_type", Value: "BIN_FLAT"}, {Key: "metric_type", Value: "JACCARD"}}, "HAMMING")
+	assert.True(t, ok)
+	// the metric type of the index is unknown
+	_, _, ok = MatchIndexMetricType([]*commonpb.KeyValuePair{{Key: "index_type", Value: "HNSW"}}, "L2")
+	assert.True(t, ok)
+}
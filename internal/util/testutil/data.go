@@ -0,0 +1,289 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testutil provides the generators of the test data shared by the unit tests and the embedded components
+// for integration tests.
+package testutil
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// DefaultDim is the dim of the float vector fields without dim type param
+const DefaultDim = 128
+
+// GenInsertData generates msgLength rows of the fields of schema, the i-th row of the numeric scalar fields is i
+func GenInsertData(msgLength int, schema *schemapb.CollectionSchema) (*storage.InsertData, error) {
+	insertData := &storage.InsertData{
+		Data: make(map[int64]storage.FieldData),
+	}
+
+	for _, f := range schema.Fields {
+		switch f.DataType {
+		case schemapb.DataType_Bool:
+			data := make([]bool, msgLength)
+			for i := 0; i < msgLength; i++ {
+				data[i] = true
+			}
+			insertData.Data[f.FieldID] = &storage.BoolFieldData{
+				NumRows: []int64{int64(msgLength)},
+				Data:    data,
+			}
+		case schemapb.DataType_Int8:
+			data := make([]int8, msgLength)
+			for i := 0; i < msgLength; i++ {
+				data[i] = int8(i)
+			}
+			insertData.Data[f.FieldID] = &storage.Int8FieldData{
+				NumRows: []int64{int64(msgLength)},
+				Data:    data,
+			}
+		case schemapb.DataType_Int16:
+			data := make([]int16, msgLength)
+			for i := 0; i < msgLength; i++ {
+				data[i] = int16(i)
+			}
+			insertData.Data[f.FieldID] = &storage.Int16FieldData{
+				NumRows: []int64{int64(msgLength)},
+				Data:    data,
+			}
+		case schemapb.DataType_Int32:
+			data := make([]int32, msgLength)
+			for i := 0; i < msgLength; i++ {
+				data[i] = int32(i)
+			}
+			insertData.Data[f.FieldID] = &storage.Int32FieldData{
+				NumRows: []int64{int64(msgLength)},
+				Data:    data,
+			}
+		case schemapb.DataType_Int64:
+			data := make([]int64, msgLength)
+			for i := 0; i < msgLength; i++ {
+				data[i] = int64(i)
+			}
+			insertData.Data[f.FieldID] = &storage.Int64FieldData{
+				NumRows: []int64{int64(msgLength)},
+				Data:    data,
+			}
+		case schemapb.DataType_Float:
+			data := make([]float32, msgLength)
+			for i := 0; i < msgLength; i++ {
+				data[i] = float32(i)
+			}
+			insertData.Data[f.FieldID] = &storage.FloatFieldData{
+				NumRows: []int64{int64(msgLength)},
+				Data:    data,
+			}
+		case schemapb.DataType_Double:
+			data := make([]float64, msgLength)
+			for i := 0; i < msgLength; i++ {
+				data[i] = float64(i)
+			}
+			insertData.Data[f.FieldID] = &storage.DoubleFieldData{
+				NumRows: []int64{int64(msgLength)},
+				Data:    data,
+			}
+		case schemapb.DataType_FloatVector:
+			dim := DefaultDim // if no dim specified, use DefaultDim
+			for _, p := range f.TypeParams {
+				if p.Key == "dim" {
+					var err error
+					dim, err = strconv.Atoi(p.Value)
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+			data := make([]float32, 0)
+			for i := 0; i < msgLength; i++ {
+				for j := 0; j < dim; j++ {
+					data = append(data, float32(i*j)*0.1)
+				}
+			}
+			insertData.Data[f.FieldID] = &storage.FloatVectorFieldData{
+				NumRows: []int64{int64(msgLength)},
+				Data:    data,
+				Dim:     dim,
+			}
+		default:
+			err := errors.New("data type not supported")
+			return nil, err
+		}
+	}
+
+	return insertData, nil
+}
+
+// GenFieldData wraps fieldValue of fieldType, e.g. []int64 for DataType_Int64, into the field data of a
+// column-based insert or a retrieve result, dim is only used by the vector fields
+func GenFieldData(fieldName string, fieldID int64, fieldType schemapb.DataType, fieldValue interface{}, dim int64) *schemapb.FieldData {
+	var fieldData *schemapb.FieldData
+	switch fieldType {
+	case schemapb.DataType_Bool:
+		fieldData = &schemapb.FieldData{
+			Type:      schemapb.DataType_Bool,
+			FieldName: fieldName,
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_BoolData{
+						BoolData: &schemapb.BoolArray{
+							Data: fieldValue.([]bool),
+						},
+					},
+				},
+			},
+			FieldId: fieldID,
+		}
+	case schemapb.DataType_Int32:
+		fieldData = &schemapb.FieldData{
+			Type:      schemapb.DataType_Int32,
+			FieldName: fieldName,
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_IntData{
+						IntData: &schemapb.IntArray{
+							Data: fieldValue.([]int32),
+						},
+					},
+				},
+			},
+			FieldId: fieldID,
+		}
+	case schemapb.DataType_Int64:
+		fieldData = &schemapb.FieldData{
+			Type:      schemapb.DataType_Int64,
+			FieldName: fieldName,
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{
+						LongData: &schemapb.LongArray{
+							Data: fieldValue.([]int64),
+						},
+					},
+				},
+			},
+			FieldId: fieldID,
+		}
+	case schemapb.DataType_Float:
+		fieldData = &schemapb.FieldData{
+			Type:      schemapb.DataType_Float,
+			FieldName: fieldName,
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_FloatData{
+						FloatData: &schemapb.FloatArray{
+							Data: fieldValue.([]float32),
+						},
+					},
+				},
+			},
+			FieldId: fieldID,
+		}
+	case schemapb.DataType_Double:
+		fieldData = &schemapb.FieldData{
+			Type:      schemapb.DataType_Double,
+			FieldName: fieldName,
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_DoubleData{
+						DoubleData: &schemapb.DoubleArray{
+							Data: fieldValue.([]float64),
+						},
+					},
+				},
+			},
+			FieldId: fieldID,
+		}
+	case schemapb.DataType_BinaryVector:
+		fieldData = &schemapb.FieldData{
+			Type:      schemapb.DataType_BinaryVector,
+			FieldName: fieldName,
+			Field: &schemapb.FieldData_Vectors{
+				Vectors: &schemapb.VectorField{
+					Dim: dim,
+					Data: &schemapb.VectorField_BinaryVector{
+						BinaryVector: fieldValue.([]byte),
+					},
+				},
+			},
+			FieldId: fieldID,
+		}
+	case schemapb.DataType_FloatVector:
+		fieldData = &schemapb.FieldData{
+			Type:      schemapb.DataType_FloatVector,
+			FieldName: fieldName,
+			Field: &schemapb.FieldData_Vectors{
+				Vectors: &schemapb.VectorField{
+					Dim: dim,
+					Data: &schemapb.VectorField_FloatVector{
+						FloatVector: &schemapb.FloatArray{
+							Data: fieldValue.([]float32),
+						},
+					},
+				},
+			},
+			FieldId: fieldID,
+		}
+	default:
+		log.Error("not supported field type", zap.String("field type", fieldType.String()))
+	}
+
+	return fieldData
+}
+
+// GenPlaceholderGroup generates the serialized placeholder group of nq random float vectors of dim
+func GenPlaceholderGroup(nq int, dim int) ([]byte, error) {
+	placeholderValue := &milvuspb.PlaceholderValue{
+		Tag:    "$0",
+		Type:   milvuspb.PlaceholderType_FloatVector,
+		Values: make([][]byte, 0),
+	}
+	for i := 0; i < nq; i++ {
+		var vec = make([]float32, dim)
+		for j := 0; j < dim; j++ {
+			vec[j] = rand.Float32()
+		}
+		var rawData []byte
+		for k, ele := range vec {
+			buf := make([]byte, 4)
+			common.Endian.PutUint32(buf, math.Float32bits(ele+float32(k*2)))
+			rawData = append(rawData, buf...)
+		}
+		placeholderValue.Values = append(placeholderValue.Values, rawData)
+	}
+
+	// generate placeholder
+	placeholderGroup := milvuspb.PlaceholderGroup{
+		Placeholders: []*milvuspb.PlaceholderValue{placeholderValue},
+	}
+	placeGroupByte, err := proto.Marshal(&placeholderGroup)
+	if err != nil {
+		return nil, err
+	}
+	return placeGroupByte, nil
+}
@@ -0,0 +1,77 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testutil
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+func TestGenInsertData(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, DataType: schemapb.DataType_FloatVector},
+		},
+	}
+	insertData, err := GenInsertData(10, schema)
+	require.NoError(t, err)
+	assert.Len(t, insertData.Data, 2)
+
+	pks := insertData.Data[100].(*storage.Int64FieldData)
+	assert.Equal(t, 10, pks.RowNum())
+	for i, pk := range pks.Data {
+		assert.Equal(t, int64(i), pk)
+	}
+	vectors := insertData.Data[101].(*storage.FloatVectorFieldData)
+	assert.Equal(t, 10, vectors.RowNum())
+	assert.Equal(t, DefaultDim, vectors.Dim)
+}
+
+func TestGenFieldData(t *testing.T) {
+	fieldData := GenFieldData("pk", 100, schemapb.DataType_Int64, []int64{1, 2, 3}, 0)
+	assert.Equal(t, int64(100), fieldData.GetFieldId())
+	assert.Equal(t, "pk", fieldData.GetFieldName())
+	assert.Equal(t, []int64{1, 2, 3}, fieldData.GetScalars().GetLongData().GetData())
+
+	fieldData = GenFieldData("vec", 101, schemapb.DataType_FloatVector, []float32{1, 2, 3, 4}, 2)
+	assert.Equal(t, int64(2), fieldData.GetVectors().GetDim())
+	assert.Equal(t, []float32{1, 2, 3, 4}, fieldData.GetVectors().GetFloatVector().GetData())
+
+	assert.Nil(t, GenFieldData("str", 102, schemapb.DataType_VarChar, []string{"a"}, 0))
+}
+
+func TestGenPlaceholderGroup(t *testing.T) {
+	blob, err := GenPlaceholderGroup(3, 4)
+	require.NoError(t, err)
+
+	var group milvuspb.PlaceholderGroup
+	require.NoError(t, proto.Unmarshal(blob, &group))
+	require.Len(t, group.GetPlaceholders(), 1)
+	assert.Equal(t, milvuspb.PlaceholderType_FloatVector, group.GetPlaceholders()[0].GetType())
+	assert.Len(t, group.GetPlaceholders()[0].GetValues(), 3)
+	for _, value := range group.GetPlaceholders()[0].GetValues() {
+		assert.Len(t, value, 4*4)
+	}
+}
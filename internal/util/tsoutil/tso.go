@@ -60,6 +60,17 @@ func ParseHybridTs(ts uint64) (int64, int64) {
 	return int64(physical), int64(logical)
 }
 
+// PhysicalTime returns the physical part of ts as time.Time
+func PhysicalTime(ts uint64) time.Time {
+	physicalTime, _ := ParseTS(ts)
+	return physicalTime
+}
+
+// AddPhysicalDurationOnTs adds physical duration on ts and return ts, the duration is truncated to milliseconds
+func AddPhysicalDurationOnTs(duration time.Duration, ts uint64) uint64 {
+	return AddPhysicalTimeOnTs(duration.Milliseconds(), ts)
+}
+
 // CalculateDuration returns the number of milliseconds obtained by subtracting ts2 from ts1.
 func CalculateDuration(ts1, ts2 typeutil.Timestamp) int64 {
 	p1, _ := ParseHybridTs(ts1)
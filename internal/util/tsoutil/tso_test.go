@@ -63,3 +63,14 @@ func TestCalculateDuration(t *testing.T) {
 	diff := CalculateDuration(ts2, ts1)
 	assert.Equal(t, durationInMilliSecs, diff)
 }
+
+func TestPhysicalTime(t *testing.T) {
+	now := time.Unix(0, time.Now().UnixNano()/int64(time.Millisecond)*int64(time.Millisecond))
+	ts := ComposeTSByTime(now, 10)
+	assert.True(t, now.Equal(PhysicalTime(ts)))
+
+	later := AddPhysicalDurationOnTs(time.Minute, ts)
+	assert.Equal(t, time.Minute, PhysicalTime(later).Sub(PhysicalTime(ts)))
+	_, logical := ParseHybridTs(later)
+	assert.Equal(t, int64(10), logical)
+}
@@ -0,0 +1,372 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeutil
+
+import (
+	"fmt"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// ColumnBuilder builds a column of schemapb.FieldData row by row, either from values or from the rows of
+// other columns of the same field. The built column always carries the data container of its type,
+// even if no row is appended, and the dim of vectors.
+type ColumnBuilder struct {
+	fieldID   int64
+	fieldName string
+	dataType  schemapb.DataType
+	dim       int64
+	rows      int
+
+	bools        []bool
+	ints         []int32
+	longs        []int64
+	floats       []float32
+	doubles      []float64
+	strings      []string
+	floatVector  []float32
+	binaryVector []byte
+}
+
+// NewColumnBuilder returns the builder of the column of field, dim is required by the vector types only
+func NewColumnBuilder(fieldID int64, fieldName string, dataType schemapb.DataType, dim int64) (*ColumnBuilder, error) {
+	switch dataType {
+	case schemapb.DataType_Bool, schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32,
+		schemapb.DataType_Int64, schemapb.DataType_Float, schemapb.DataType_Double,
+		schemapb.DataType_String, schemapb.DataType_VarChar:
+		dim = 0
+	case schemapb.DataType_FloatVector:
+		if dim <= 0 {
+			return nil, fmt.Errorf("invalid dim %d of float vector field %d", dim, fieldID)
+		}
+	case schemapb.DataType_BinaryVector:
+		if dim <= 0 || dim%8 != 0 {
+			return nil, fmt.Errorf("invalid dim %d of binary vector field %d", dim, fieldID)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported data type %s of field %d", dataType.String(), fieldID)
+	}
+	return &ColumnBuilder{
+		fieldID:   fieldID,
+		fieldName: fieldName,
+		dataType:  dataType,
+		dim:       dim,
+	}, nil
+}
+
+// NewColumnBuilderOf returns the builder of the field of column, the data of column is not appended
+func NewColumnBuilderOf(column *schemapb.FieldData) (*ColumnBuilder, error) {
+	return NewColumnBuilder(column.GetFieldId(), column.GetFieldName(), column.GetType(), column.GetVectors().GetDim())
+}
+
+// NewColumnBuilders returns the builders of the fields of columns
+func NewColumnBuilders(columns []*schemapb.FieldData) ([]*ColumnBuilder, error) {
+	builders := make([]*ColumnBuilder, 0, len(columns))
+	for _, column := range columns {
+		builder, err := NewColumnBuilderOf(column)
+		if err != nil {
+			return nil, err
+		}
+		builders = append(builders, builder)
+	}
+	return builders, nil
+}
+
+// DataType returns the data type of the column built
+func (b *ColumnBuilder) DataType() schemapb.DataType {
+	return b.dataType
+}
+
+// Dim returns the dim of the column built, 0 if not of vectors
+func (b *ColumnBuilder) Dim() int64 {
+	return b.dim
+}
+
+// Len returns the number of rows appended
+func (b *ColumnBuilder) Len() int {
+	return b.rows
+}
+
+func (b *ColumnBuilder) checkType(value string, dataTypes ...schemapb.DataType) error {
+	for _, dataType := range dataTypes {
+		if b.dataType == dataType {
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot append %s to field %d of %s", value, b.fieldID, b.dataType.String())
+}
+
+// AppendBool appends a row of Bool
+func (b *ColumnBuilder) AppendBool(v bool) error {
+	if err := b.checkType("bool", schemapb.DataType_Bool); err != nil {
+		return err
+	}
+	b.bools = append(b.bools, v)
+	b.rows++
+	return nil
+}
+
+// AppendInt appends a row of Int8, Int16 or Int32, the value is not checked against the range of the type
+func (b *ColumnBuilder) AppendInt(v int32) error {
+	if err := b.checkType("int", schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32); err != nil {
+		return err
+	}
+	b.ints = append(b.ints, v)
+	b.rows++
+	return nil
+}
+
+// AppendLong appends a row of Int64
+func (b *ColumnBuilder) AppendLong(v int64) error {
+	if err := b.checkType("long", schemapb.DataType_Int64); err != nil {
+		return err
+	}
+	b.longs = append(b.longs, v)
+	b.rows++
+	return nil
+}
+
+// AppendFloat appends a row of Float
+func (b *ColumnBuilder) AppendFloat(v float32) error {
+	if err := b.checkType("float", schemapb.DataType_Float); err != nil {
+		return err
+	}
+	b.floats = append(b.floats, v)
+	b.rows++
+	return nil
+}
+
+// AppendDouble appends a row of Double
+func (b *ColumnBuilder) AppendDouble(v float64) error {
+	if err := b.checkType("double", schemapb.DataType_Double); err != nil {
+		return err
+	}
+	b.doubles = append(b.doubles, v)
+	b.rows++
+	return nil
+}
+
+// AppendString appends a row of String or VarChar
+func (b *ColumnBuilder) AppendString(v string) error {
+	if err := b.checkType("string", schemapb.DataType_String, schemapb.DataType_VarChar); err != nil {
+		return err
+	}
+	b.strings = append(b.strings, v)
+	b.rows++
+	return nil
+}
+
+// AppendFloatVector appends a row of FloatVector, v must be of dim elements
+func (b *ColumnBuilder) AppendFloatVector(v []float32) error {
+	if err := b.checkType("float vector", schemapb.DataType_FloatVector); err != nil {
+		return err
+	}
+	if int64(len(v)) != b.dim {
+		return fmt.Errorf("cannot append float vector of %d elements to field %d of dim %d", len(v), b.fieldID, b.dim)
+	}
+	b.floatVector = append(b.floatVector, v...)
+	b.rows++
+	return nil
+}
+
+// AppendBinaryVector appends a row of BinaryVector, v must be of dim/8 bytes
+func (b *ColumnBuilder) AppendBinaryVector(v []byte) error {
+	if err := b.checkType("binary vector", schemapb.DataType_BinaryVector); err != nil {
+		return err
+	}
+	if int64(len(v)) != b.dim/8 {
+		return fmt.Errorf("cannot append binary vector of %d bytes to field %d of dim %d", len(v), b.fieldID, b.dim)
+	}
+	b.binaryVector = append(b.binaryVector, v...)
+	b.rows++
+	return nil
+}
+
+// checkColumn checks column is of the data type and the dim of the builder
+func (b *ColumnBuilder) checkColumn(column *schemapb.FieldData) error {
+	if column.GetType() != b.dataType {
+		return fmt.Errorf("cannot append column of %s to field %d of %s", column.GetType().String(), b.fieldID, b.dataType.String())
+	}
+	if dim := column.GetVectors().GetDim(); dim != b.dim {
+		return fmt.Errorf("cannot append column of dim %d to field %d of dim %d", dim, b.fieldID, b.dim)
+	}
+	switch b.dataType {
+	case schemapb.DataType_FloatVector:
+		if n := int64(len(column.GetVectors().GetFloatVector().GetData())); n%b.dim != 0 {
+			return fmt.Errorf("float vector column of field %d has %d elements, not a multiple of dim %d", b.fieldID, n, b.dim)
+		}
+	case schemapb.DataType_BinaryVector:
+		if n := int64(len(column.GetVectors().GetBinaryVector())); n%(b.dim/8) != 0 {
+			return fmt.Errorf("binary vector column of field %d has %d bytes, not a multiple of dim %d", b.fieldID, n, b.dim)
+		}
+	}
+	return nil
+}
+
+// AppendRow appends the idx-th row of column, column must be of the data type and the dim of the builder
+func (b *ColumnBuilder) AppendRow(column *schemapb.FieldData, idx int) error {
+	if err := b.checkColumn(column); err != nil {
+		return err
+	}
+	outOfRange := func(rows int) error {
+		return fmt.Errorf("row %d out of range of column of field %d with %d rows of %s",
+			idx, column.GetFieldId(), rows, b.dataType.String())
+	}
+	switch b.dataType {
+	case schemapb.DataType_Bool:
+		data := column.GetScalars().GetBoolData().GetData()
+		if idx < 0 || idx >= len(data) {
+			return outOfRange(len(data))
+		}
+		return b.AppendBool(data[idx])
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		data := column.GetScalars().GetIntData().GetData()
+		if idx < 0 || idx >= len(data) {
+			return outOfRange(len(data))
+		}
+		return b.AppendInt(data[idx])
+	case schemapb.DataType_Int64:
+		data := column.GetScalars().GetLongData().GetData()
+		if idx < 0 || idx >= len(data) {
+			return outOfRange(len(data))
+		}
+		return b.AppendLong(data[idx])
+	case schemapb.DataType_Float:
+		data := column.GetScalars().GetFloatData().GetData()
+		if idx < 0 || idx >= len(data) {
+			return outOfRange(len(data))
+		}
+		return b.AppendFloat(data[idx])
+	case schemapb.DataType_Double:
+		data := column.GetScalars().GetDoubleData().GetData()
+		if idx < 0 || idx >= len(data) {
+			return outOfRange(len(data))
+		}
+		return b.AppendDouble(data[idx])
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		data := column.GetScalars().GetStringData().GetData()
+		if idx < 0 || idx >= len(data) {
+			return outOfRange(len(data))
+		}
+		return b.AppendString(data[idx])
+	case schemapb.DataType_FloatVector:
+		data := column.GetVectors().GetFloatVector().GetData()
+		rows := len(data) / int(b.dim)
+		if idx < 0 || idx >= rows {
+			return outOfRange(rows)
+		}
+		return b.AppendFloatVector(data[int64(idx)*b.dim : int64(idx+1)*b.dim])
+	case schemapb.DataType_BinaryVector:
+		data := column.GetVectors().GetBinaryVector()
+		rowBytes := b.dim / 8
+		rows := len(data) / int(rowBytes)
+		if idx < 0 || idx >= rows {
+			return outOfRange(rows)
+		}
+		return b.AppendBinaryVector(data[int64(idx)*rowBytes : int64(idx+1)*rowBytes])
+	}
+	return fmt.Errorf("unsupported data type %s of field %d", b.dataType.String(), b.fieldID)
+}
+
+// AppendColumn appends all the rows of column, column must be of the data type and the dim of the builder
+func (b *ColumnBuilder) AppendColumn(column *schemapb.FieldData) error {
+	if err := b.checkColumn(column); err != nil {
+		return err
+	}
+	rows, err := GetRowCountOfFieldData(column)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < rows; i++ {
+		if err := b.AppendRow(column, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Build returns the column of the rows appended, and resets the builder for building another column
+// of the field
+func (b *ColumnBuilder) Build() *schemapb.FieldData {
+	column := &schemapb.FieldData{
+		Type:      b.dataType,
+		FieldName: b.fieldName,
+		FieldId:   b.fieldID,
+	}
+	switch b.dataType {
+	case schemapb.DataType_FloatVector:
+		column.Field = &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+			Dim:  b.dim,
+			Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: b.floatVector}},
+		}}
+	case schemapb.DataType_BinaryVector:
+		column.Field = &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+			Dim:  b.dim,
+			Data: &schemapb.VectorField_BinaryVector{BinaryVector: b.binaryVector},
+		}}
+	default:
+		scalars := &schemapb.ScalarField{}
+		switch b.dataType {
+		case schemapb.DataType_Bool:
+			scalars.Data = &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: b.bools}}
+		case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+			scalars.Data = &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: b.ints}}
+		case schemapb.DataType_Int64:
+			scalars.Data = &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: b.longs}}
+		case schemapb.DataType_Float:
+			scalars.Data = &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: b.floats}}
+		case schemapb.DataType_Double:
+			scalars.Data = &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: b.doubles}}
+		case schemapb.DataType_String, schemapb.DataType_VarChar:
+			scalars.Data = &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: b.strings}}
+		}
+		column.Field = &schemapb.FieldData_Scalars{Scalars: scalars}
+	}
+
+	b.rows = 0
+	b.bools, b.ints, b.longs, b.floats, b.doubles, b.strings = nil, nil, nil, nil, nil, nil
+	b.floatVector, b.binaryVector = nil, nil
+	return column
+}
+
+// BuildColumns builds the columns of builders
+func BuildColumns(builders []*ColumnBuilder) []*schemapb.FieldData {
+	columns := make([]*schemapb.FieldData, 0, len(builders))
+	for _, builder := range builders {
+		columns = append(columns, builder.Build())
+	}
+	return columns
+}
+
+// MergeColumns returns the column of the rows of dst followed by the rows of src, dst and src must be
+// columns of the same field, of the same data type and dim
+func MergeColumns(dst, src *schemapb.FieldData) (*schemapb.FieldData, error) {
+	if dst.GetFieldId() != src.GetFieldId() {
+		return nil, fmt.Errorf("cannot merge column of field %d into field %d", src.GetFieldId(), dst.GetFieldId())
+	}
+	builder, err := NewColumnBuilderOf(dst)
+	if err != nil {
+		return nil, err
+	}
+	if err := builder.AppendColumn(dst); err != nil {
+		return nil, err
+	}
+	if err := builder.AppendColumn(src); err != nil {
+		return nil, err
+	}
+	return builder.Build(), nil
+}
@@ -0,0 +1,363 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeutil
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// columnBuilderCase is a column of 3 rows of a data type, and the way to append its rows by value
+type columnBuilderCase struct {
+	dataType schemapb.DataType
+	dim      int64
+	column   *schemapb.FieldData
+	appendFn func(b *ColumnBuilder, row int) error
+}
+
+func genColumnBuilderCases() []columnBuilderCase {
+	scalar := func(dataType schemapb.DataType, scalars *schemapb.ScalarField) *schemapb.FieldData {
+		return &schemapb.FieldData{
+			Type:      dataType,
+			FieldName: dataType.String(),
+			FieldId:   100 + int64(dataType),
+			Field:     &schemapb.FieldData_Scalars{Scalars: scalars},
+		}
+	}
+	vector := func(dataType schemapb.DataType, vectors *schemapb.VectorField) *schemapb.FieldData {
+		return &schemapb.FieldData{
+			Type:      dataType,
+			FieldName: dataType.String(),
+			FieldId:   100 + int64(dataType),
+			Field:     &schemapb.FieldData_Vectors{Vectors: vectors},
+		}
+	}
+
+	bools := []bool{true, false, true}
+	ints := []int32{1, -2, 3}
+	longs := []int64{1 << 40, -2, 3}
+	floats := []float32{1.5, -2.5, 3.5}
+	doubles := []float64{1.25, -2.25, 3.25}
+	strs := []string{"a", "", "ccc"}
+	floatVector := []float32{1, 2, 3, 4, 5, 6}
+	binaryVector := []byte{1, 2, 3, 4, 5, 6}
+
+	cases := []columnBuilderCase{
+		{
+			dataType: schemapb.DataType_Bool,
+			column:   scalar(schemapb.DataType_Bool, &schemapb.ScalarField{Data: &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: bools}}}),
+			appendFn: func(b *ColumnBuilder, row int) error { return b.AppendBool(bools[row]) },
+		},
+		{
+			dataType: schemapb.DataType_Int64,
+			column:   scalar(schemapb.DataType_Int64, &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: longs}}}),
+			appendFn: func(b *ColumnBuilder, row int) error { return b.AppendLong(longs[row]) },
+		},
+		{
+			dataType: schemapb.DataType_Float,
+			column:   scalar(schemapb.DataType_Float, &schemapb.ScalarField{Data: &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: floats}}}),
+			appendFn: func(b *ColumnBuilder, row int) error { return b.AppendFloat(floats[row]) },
+		},
+		{
+			dataType: schemapb.DataType_Double,
+			column:   scalar(schemapb.DataType_Double, &schemapb.ScalarField{Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: doubles}}}),
+			appendFn: func(b *ColumnBuilder, row int) error { return b.AppendDouble(doubles[row]) },
+		},
+		{
+			dataType: schemapb.DataType_FloatVector,
+			dim:      2,
+			column:   vector(schemapb.DataType_FloatVector, &schemapb.VectorField{Dim: 2, Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: floatVector}}}),
+			appendFn: func(b *ColumnBuilder, row int) error { return b.AppendFloatVector(floatVector[row*2 : (row+1)*2]) },
+		},
+		{
+			dataType: schemapb.DataType_BinaryVector,
+			dim:      16,
+			column:   vector(schemapb.DataType_BinaryVector, &schemapb.VectorField{Dim: 16, Data: &schemapb.VectorField_BinaryVector{BinaryVector: binaryVector}}),
+			appendFn: func(b *ColumnBuilder, row int) error { return b.AppendBinaryVector(binaryVector[row*2 : (row+1)*2]) },
+		},
+	}
+	for _, dataType := range []schemapb.DataType{schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32} {
+		cases = append(cases, columnBuilderCase{
+			dataType: dataType,
+			column:   scalar(dataType, &schemapb.ScalarField{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: ints}}}),
+			appendFn: func(b *ColumnBuilder, row int) error { return b.AppendInt(ints[row]) },
+		})
+	}
+	for _, dataType := range []schemapb.DataType{schemapb.DataType_String, schemapb.DataType_VarChar} {
+		cases = append(cases, columnBuilderCase{
+			dataType: dataType,
+			column:   scalar(dataType, &schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: strs}}}),
+			appendFn: func(b *ColumnBuilder, row int) error { return b.AppendString(strs[row]) },
+		})
+	}
+	return cases
+}
+
+func TestColumnBuilder_append(t *testing.T) {
+	for _, c := range genColumnBuilderCases() {
+		t.Run(c.dataType.String(), func(t *testing.T) {
+			builder, err := NewColumnBuilder(c.column.GetFieldId(), c.column.GetFieldName(), c.dataType, c.dim)
+			require.NoError(t, err)
+			assert.Equal(t, c.dataType, builder.DataType())
+			assert.Equal(t, c.dim, builder.Dim())
+
+			// by value
+			for row := 0; row < 3; row++ {
+				require.NoError(t, c.appendFn(builder, row))
+			}
+			assert.Equal(t, 3, builder.Len())
+			assert.True(t, proto.Equal(c.column, builder.Build()))
+
+			// the builder is reset by Build
+			assert.Equal(t, 0, builder.Len())
+
+			// by row, in another order
+			for _, row := range []int{2, 0, 1} {
+				require.NoError(t, builder.AppendRow(c.column, row))
+			}
+			column := builder.Build()
+			rows, err := GetRowCountOfFieldData(column)
+			require.NoError(t, err)
+			assert.Equal(t, 3, rows)
+			expected, err := NewColumnBuilderOf(c.column)
+			require.NoError(t, err)
+			for _, row := range []int{2, 0, 1} {
+				require.NoError(t, c.appendFn(expected, row))
+			}
+			assert.True(t, proto.Equal(expected.Build(), column))
+
+			// by column
+			require.NoError(t, builder.AppendColumn(c.column))
+			assert.True(t, proto.Equal(c.column, builder.Build()))
+
+			// out of range rows
+			assert.Error(t, builder.AppendRow(c.column, -1))
+			assert.Error(t, builder.AppendRow(c.column, 3))
+			assert.Equal(t, 0, builder.Len())
+		})
+	}
+}
+
+func TestColumnBuilder_empty(t *testing.T) {
+	for _, c := range genColumnBuilderCases() {
+		t.Run(c.dataType.String(), func(t *testing.T) {
+			builder, err := NewColumnBuilderOf(c.column)
+			require.NoError(t, err)
+
+			// the empty column carries the data container of its type and the dim
+			column := builder.Build()
+			assert.Equal(t, c.dataType, column.GetType())
+			assert.Equal(t, c.column.GetFieldId(), column.GetFieldId())
+			assert.Equal(t, c.column.GetFieldName(), column.GetFieldName())
+			assert.Equal(t, c.dim, column.GetVectors().GetDim())
+			if c.dim > 0 {
+				assert.NotNil(t, column.GetVectors().GetData())
+			} else {
+				assert.NotNil(t, column.GetScalars().GetData())
+			}
+			rows, err := GetRowCountOfFieldData(column)
+			require.NoError(t, err)
+			assert.Equal(t, 0, rows)
+
+			// appending an empty column appends nothing
+			require.NoError(t, builder.AppendColumn(column))
+			assert.Equal(t, 0, builder.Len())
+
+			// as does the column without data
+			dataless := proto.Clone(c.column).(*schemapb.FieldData)
+			if c.dim > 0 {
+				dataless.GetVectors().Data = nil
+			} else {
+				dataless.GetScalars().Data = nil
+			}
+			require.NoError(t, builder.AppendColumn(dataless))
+			assert.Equal(t, 0, builder.Len())
+			assert.Error(t, builder.AppendRow(dataless, 0))
+		})
+	}
+}
+
+func TestColumnBuilder_mismatch(t *testing.T) {
+	cases := genColumnBuilderCases()
+	for i, c := range cases {
+		builder, err := NewColumnBuilderOf(c.column)
+		require.NoError(t, err)
+		for j, other := range cases {
+			if i == j {
+				continue
+			}
+			// Int8, Int16 and Int32 share the appending of int, as String and VarChar of string
+			if err := other.appendFn(builder, 0); err == nil {
+				assert.Contains(t, sameAppendTypes(c.dataType), other.dataType, "%s appended to %s", other.dataType, c.dataType)
+			}
+			// the columns of the other types are never appended
+			assert.Error(t, builder.AppendRow(other.column, 0), "%s appended to %s", other.dataType, c.dataType)
+			assert.Error(t, builder.AppendColumn(other.column), "%s appended to %s", other.dataType, c.dataType)
+		}
+	}
+
+	// the column of the type but of another container
+	builder, err := NewColumnBuilder(100, "long", schemapb.DataType_Int64, 0)
+	require.NoError(t, err)
+	column := &schemapb.FieldData{
+		Type:    schemapb.DataType_Int64,
+		FieldId: 100,
+		Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: []int32{1}}},
+		}},
+	}
+	assert.Error(t, builder.AppendRow(column, 0))
+	assert.Error(t, builder.AppendColumn(column))
+	assert.Equal(t, 0, builder.Len())
+
+	// the vectors of another dim
+	builder, err = NewColumnBuilder(101, "vector", schemapb.DataType_FloatVector, 4)
+	require.NoError(t, err)
+	assert.Error(t, builder.AppendFloatVector([]float32{1, 2}))
+	assert.Error(t, builder.AppendColumn(genColumnBuilderCases()[4].column))
+	column = &schemapb.FieldData{
+		Type:    schemapb.DataType_FloatVector,
+		FieldId: 101,
+		Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+			Dim:  4,
+			Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: []float32{1, 2, 3, 4, 5, 6}}},
+		}},
+	}
+	assert.Error(t, builder.AppendColumn(column))
+	builder, err = NewColumnBuilder(102, "binary", schemapb.DataType_BinaryVector, 16)
+	require.NoError(t, err)
+	assert.Error(t, builder.AppendBinaryVector([]byte{1}))
+	assert.Equal(t, 0, builder.Len())
+}
+
+// sameAppendTypes returns the data types sharing the appending by value with dataType
+func sameAppendTypes(dataType schemapb.DataType) []schemapb.DataType {
+	switch dataType {
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		return []schemapb.DataType{schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32}
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		return []schemapb.DataType{schemapb.DataType_String, schemapb.DataType_VarChar}
+	default:
+		return nil
+	}
+}
+
+func TestNewColumnBuilder(t *testing.T) {
+	_, err := NewColumnBuilder(100, "none", schemapb.DataType_None, 0)
+	assert.Error(t, err)
+	_, err = NewColumnBuilder(100, "vector", schemapb.DataType_FloatVector, 0)
+	assert.Error(t, err)
+	_, err = NewColumnBuilder(100, "binary", schemapb.DataType_BinaryVector, 12)
+	assert.Error(t, err)
+
+	// the dim of the scalar types is ignored
+	builder, err := NewColumnBuilder(100, "long", schemapb.DataType_Int64, 8)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), builder.Dim())
+
+	cases := genColumnBuilderCases()
+	columns := make([]*schemapb.FieldData, 0, len(cases))
+	for _, c := range cases {
+		columns = append(columns, c.column)
+	}
+	builders, err := NewColumnBuilders(columns)
+	require.NoError(t, err)
+	for i, builder := range builders {
+		require.NoError(t, builder.AppendColumn(columns[i]))
+	}
+	built := BuildColumns(builders)
+	require.Len(t, built, len(columns))
+	for i := range columns {
+		assert.True(t, proto.Equal(columns[i], built[i]))
+	}
+	assert.Empty(t, BuildColumns(nil))
+
+	_, err = NewColumnBuilders(append(columns, &schemapb.FieldData{Type: schemapb.DataType_None}))
+	assert.Error(t, err)
+}
+
+func TestMergeColumns(t *testing.T) {
+	cases := genColumnBuilderCases()
+	for _, c := range cases {
+		t.Run(c.dataType.String(), func(t *testing.T) {
+			merged, err := MergeColumns(c.column, c.column)
+			require.NoError(t, err)
+			rows, err := GetRowCountOfFieldData(merged)
+			require.NoError(t, err)
+			assert.Equal(t, 6, rows)
+
+			builder, err := NewColumnBuilderOf(c.column)
+			require.NoError(t, err)
+			for _, row := range []int{0, 1, 2, 0, 1, 2} {
+				require.NoError(t, c.appendFn(builder, row))
+			}
+			assert.True(t, proto.Equal(builder.Build(), merged))
+
+			// the inputs are kept
+			rows, err = GetRowCountOfFieldData(c.column)
+			require.NoError(t, err)
+			assert.Equal(t, 3, rows)
+
+			// with empty columns
+			empty := builder.Build()
+			merged, err = MergeColumns(empty, c.column)
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(c.column, merged))
+			merged, err = MergeColumns(c.column, empty)
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(c.column, merged))
+			merged, err = MergeColumns(empty, empty)
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(empty, merged))
+		})
+	}
+
+	t.Run("mismatch", func(t *testing.T) {
+		for i, c := range cases {
+			for j, other := range cases {
+				if i == j {
+					continue
+				}
+				_, err := MergeColumns(c.column, other.column)
+				assert.Error(t, err, "%s merged into %s", other.dataType, c.dataType)
+			}
+		}
+
+		// the column of the same field but of another type
+		other := proto.Clone(cases[3].column).(*schemapb.FieldData)
+		other.FieldId = cases[1].column.GetFieldId()
+		_, err := MergeColumns(cases[1].column, other)
+		assert.Error(t, err)
+
+		// the columns of the same type but of another field or dim
+		other = proto.Clone(cases[1].column).(*schemapb.FieldData)
+		other.FieldId++
+		_, err = MergeColumns(cases[1].column, other)
+		assert.Error(t, err)
+
+		other = proto.Clone(cases[4].column).(*schemapb.FieldData)
+		other.GetVectors().Dim = 3
+		other.GetVectors().GetFloatVector().Data = []float32{1, 2, 3}
+		_, err = MergeColumns(cases[4].column, other)
+		assert.Error(t, err)
+	})
+}
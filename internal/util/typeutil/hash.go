@@ -17,6 +17,7 @@
 package typeutil
 
 import (
+	"fmt"
 	"hash/crc32"
 	"unsafe"
 
@@ -94,3 +95,27 @@ func HashPK2Channels(primaryKeys *schemapb.IDs, shardNames []string) []uint32 {
 
 	return hashValues
 }
+
+// HashKey2Partitions hash partition keys to the indexes of numPartitions partitions,
+// the partitions of a collection are indexed in the order of partition id
+func HashKey2Partitions(keys *schemapb.FieldData, numPartitions int) ([]uint32, error) {
+	if numPartitions <= 0 {
+		return nil, fmt.Errorf("invalid number of partitions %d", numPartitions)
+	}
+	n := uint32(numPartitions)
+	var hashValues []uint32
+	switch keys.GetType() {
+	case schemapb.DataType_Int64:
+		for _, key := range keys.GetScalars().GetLongData().GetData() {
+			value, _ := Hash32Int64(key)
+			hashValues = append(hashValues, value%n)
+		}
+	case schemapb.DataType_VarChar:
+		for _, key := range keys.GetScalars().GetStringData().GetData() {
+			hashValues = append(hashValues, HashString2Uint32(key)%n)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported partition key type %s", keys.GetType().String())
+	}
+	return hashValues, nil
+}
@@ -94,3 +94,44 @@ func TestHashPK2Channels(t *testing.T) {
 	assert.Equal(t, 5, len(ret))
 	assert.Equal(t, ret[1], ret[2])
 }
+
+func TestHashKey2Partitions(t *testing.T) {
+	int64Keys := &schemapb.FieldData{
+		Type: schemapb.DataType_Int64,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{100, 102, 102, 103, 104}}},
+			},
+		},
+	}
+	ret, err := HashKey2Partitions(int64Keys, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(ret))
+	// same key hash to same partition
+	assert.Equal(t, ret[1], ret[2])
+	for i, key := range int64Keys.GetScalars().GetLongData().GetData() {
+		hash, _ := Hash32Int64(key)
+		assert.Equal(t, hash%3, ret[i])
+	}
+
+	stringKeys := &schemapb.FieldData{
+		Type: schemapb.DataType_VarChar,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"ab", "bc", "bc", "abd"}}},
+			},
+		},
+	}
+	ret, err = HashKey2Partitions(stringKeys, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(ret))
+	assert.Equal(t, ret[1], ret[2])
+	for _, index := range ret {
+		assert.Less(t, index, uint32(3))
+	}
+
+	_, err = HashKey2Partitions(stringKeys, 0)
+	assert.Error(t, err)
+	_, err = HashKey2Partitions(&schemapb.FieldData{Type: schemapb.DataType_Float}, 3)
+	assert.Error(t, err)
+}
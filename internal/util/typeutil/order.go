@@ -0,0 +1,266 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeutil
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// IsOrderableType returns whether the fields of dataType could be sorted by, i.e. the numeric and string types
+func IsOrderableType(dataType schemapb.DataType) bool {
+	switch dataType {
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32, schemapb.DataType_Int64,
+		schemapb.DataType_Float, schemapb.DataType_Double, schemapb.DataType_String, schemapb.DataType_VarChar:
+		return true
+	default:
+		return false
+	}
+}
+
+// OrderedRows are the rows sorted by a field, e.g. the top rows of a segment
+type OrderedRows struct {
+	IDs        *schemapb.IDs
+	FieldsData []*schemapb.FieldData
+}
+
+func (r OrderedRows) rowCount() int {
+	if r.IDs == nil {
+		return 0
+	}
+	return GetSizeOfIDs(r.IDs)
+}
+
+// rowRef refers to row of source
+type rowRef struct {
+	source int
+	row    int
+}
+
+// rowOrder compares the rows of the sources by the field to sort by, and then by primary key
+type rowOrder struct {
+	sources []OrderedRows
+	fields  []*schemapb.FieldData // the field to sort by of each source
+	desc    bool
+}
+
+func newRowOrder(sources []OrderedRows, orderFieldID int64, desc bool) (*rowOrder, error) {
+	o := &rowOrder{sources: sources, fields: make([]*schemapb.FieldData, len(sources)), desc: desc}
+	var orderType schemapb.DataType
+	for i, source := range sources {
+		rows := source.rowCount()
+		if rows == 0 {
+			continue
+		}
+		for _, fieldData := range source.FieldsData {
+			if fieldData.GetFieldId() == orderFieldID {
+				o.fields[i] = fieldData
+				break
+			}
+		}
+		if o.fields[i] == nil {
+			return nil, fmt.Errorf("field %d to sort by is not retrieved", orderFieldID)
+		}
+		dataType, n, err := getOrderableData(o.fields[i])
+		if err != nil {
+			return nil, err
+		}
+		if n != rows {
+			return nil, fmt.Errorf("field %d to sort by has %d rows, expect %d", orderFieldID, n, rows)
+		}
+		if orderType == schemapb.DataType_None {
+			orderType = dataType
+		} else if dataType != orderType {
+			return nil, fmt.Errorf("field %d to sort by is of mismatched types %s and %s", orderFieldID, orderType.String(), dataType.String())
+		}
+	}
+	return o, nil
+}
+
+// getOrderableData returns the type the values of the field data to sort by are stored in and their count,
+// or error if the field is not orderable
+func getOrderableData(fieldData *schemapb.FieldData) (schemapb.DataType, int, error) {
+	switch data := fieldData.GetScalars().GetData().(type) {
+	case *schemapb.ScalarField_IntData:
+		return schemapb.DataType_Int32, len(data.IntData.GetData()), nil
+	case *schemapb.ScalarField_LongData:
+		return schemapb.DataType_Int64, len(data.LongData.GetData()), nil
+	case *schemapb.ScalarField_FloatData:
+		return schemapb.DataType_Float, len(data.FloatData.GetData()), nil
+	case *schemapb.ScalarField_DoubleData:
+		return schemapb.DataType_Double, len(data.DoubleData.GetData()), nil
+	case *schemapb.ScalarField_StringData:
+		return schemapb.DataType_VarChar, len(data.StringData.GetData()), nil
+	default:
+		return schemapb.DataType_None, 0, fmt.Errorf("unsupported data type %s to sort by", fieldData.GetType().String())
+	}
+}
+
+// compareValues compares the values of a and b of the same orderable type
+func compareValues(a *schemapb.FieldData, i int, b *schemapb.FieldData, j int) int {
+	switch data := a.GetScalars().GetData().(type) {
+	case *schemapb.ScalarField_IntData:
+		return compareInt64(int64(data.IntData.Data[i]), int64(b.GetScalars().GetIntData().Data[j]))
+	case *schemapb.ScalarField_LongData:
+		return compareInt64(data.LongData.Data[i], b.GetScalars().GetLongData().Data[j])
+	case *schemapb.ScalarField_FloatData:
+		return compareFloat64(float64(data.FloatData.Data[i]), float64(b.GetScalars().GetFloatData().Data[j]))
+	case *schemapb.ScalarField_DoubleData:
+		return compareFloat64(data.DoubleData.Data[i], b.GetScalars().GetDoubleData().Data[j])
+	case *schemapb.ScalarField_StringData:
+		x, y := data.StringData.Data[i], b.GetScalars().GetStringData().Data[j]
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+		return 0
+	default:
+		return 0
+	}
+}
+
+func compareInt64(x, y int64) int {
+	if x < y {
+		return -1
+	} else if x > y {
+		return 1
+	}
+	return 0
+}
+
+// compareFloat64 compares x and y, NaN is ordered before any other value so that the order is total
+func compareFloat64(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	case math.IsNaN(x) && !math.IsNaN(y):
+		return -1
+	case !math.IsNaN(x) && math.IsNaN(y):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// less returns whether row a is ordered before row b, the ties of the field are broken by the ascending primary keys
+func (o *rowOrder) less(a, b rowRef) bool {
+	if c := compareValues(o.fields[a.source], a.row, o.fields[b.source], b.row); c != 0 {
+		return (c < 0) != o.desc
+	}
+	return lessPK(GetPK(o.sources[a.source].IDs, int64(a.row)), GetPK(o.sources[b.source].IDs, int64(b.row)))
+}
+
+// rowHeap is a max heap of the rows by order, the top is the last row of the kept ones
+type rowHeap struct {
+	order *rowOrder
+	rows  []rowRef
+}
+
+func (h *rowHeap) Len() int           { return len(h.rows) }
+func (h *rowHeap) Less(i, j int) bool { return h.order.less(h.rows[j], h.rows[i]) }
+func (h *rowHeap) Swap(i, j int)      { h.rows[i], h.rows[j] = h.rows[j], h.rows[i] }
+func (h *rowHeap) Push(x interface{}) { h.rows = append(h.rows, x.(rowRef)) }
+
+func (h *rowHeap) Pop() interface{} {
+	n := len(h.rows)
+	x := h.rows[n-1]
+	h.rows = h.rows[:n-1]
+	return x
+}
+
+// TopOrderedRows returns the indexes of the top k rows sorted by the field of orderFieldID, descending if desc,
+// the ties are broken by the ascending primary keys. The rows are partially sorted by a heap of k rows.
+func TopOrderedRows(rows OrderedRows, orderFieldID int64, desc bool, k int) ([]int, error) {
+	order, err := newRowOrder([]OrderedRows{rows}, orderFieldID, desc)
+	if err != nil {
+		return nil, err
+	}
+	n := rows.rowCount()
+	if k > n {
+		k = n
+	}
+	if k <= 0 {
+		return []int{}, nil
+	}
+	h := &rowHeap{order: order, rows: make([]rowRef, 0, k)}
+	for i := 0; i < n; i++ {
+		r := rowRef{row: i}
+		if h.Len() < k {
+			heap.Push(h, r)
+		} else if order.less(r, h.rows[0]) {
+			// replace the last of the kept rows
+			h.rows[0] = r
+			heap.Fix(h, 0)
+		}
+	}
+	sort.Slice(h.rows, func(i, j int) bool { return order.less(h.rows[i], h.rows[j]) })
+	indexes := make([]int, 0, k)
+	for _, r := range h.rows {
+		indexes = append(indexes, r.row)
+	}
+	return indexes, nil
+}
+
+// MergeOrderedRows merges the rows of the sources into the top k rows sorted like TopOrderedRows. The sources are
+// expected to be bounded by k rows each, e.g. by TopOrderedRows, and the rows of the duplicated primary keys are
+// kept once.
+func MergeOrderedRows(sources []OrderedRows, orderFieldID int64, desc bool, k int) (*schemapb.IDs, []*schemapb.FieldData, error) {
+	order, err := newRowOrder(sources, orderFieldID, desc)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := &schemapb.IDs{}
+	fieldsData := []*schemapb.FieldData{}
+	candidates := make([]rowRef, 0)
+	for i, source := range sources {
+		n := source.rowCount()
+		if n == 0 {
+			continue
+		}
+		if len(candidates) == 0 {
+			fieldsData = make([]*schemapb.FieldData, len(source.FieldsData))
+		} else if len(source.FieldsData) != len(fieldsData) {
+			return nil, nil, fmt.Errorf("mismatch FieldData in ordered results, expect %d get %d", len(fieldsData), len(source.FieldsData))
+		}
+		for row := 0; row < n; row++ {
+			candidates = append(candidates, rowRef{source: i, row: row})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return order.less(candidates[i], candidates[j]) })
+
+	merged := make(map[interface{}]struct{})
+	for _, r := range candidates {
+		if len(merged) >= k {
+			break
+		}
+		pk := GetPK(sources[r.source].IDs, int64(r.row))
+		if _, ok := merged[pk]; ok {
+			continue
+		}
+		merged[pk] = struct{}{}
+		AppendIDs(ids, sources[r.source].IDs, r.row)
+		AppendFieldData(fieldsData, sources[r.source].FieldsData, int64(r.row))
+	}
+	return ids, fieldsData, nil
+}
@@ -0,0 +1,200 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeutil
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+const orderFieldID = 101
+
+// genOrderedRows generates the rows of the int64 pks and the values of the field to sort by
+func genOrderedRows(pks []int64, values *schemapb.ScalarField) OrderedRows {
+	return OrderedRows{
+		IDs: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}},
+		FieldsData: []*schemapb.FieldData{
+			{
+				Type:    schemapb.DataType_Int64,
+				FieldId: 100,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: pks}},
+				}},
+			},
+			{
+				FieldId: orderFieldID,
+				Field:   &schemapb.FieldData_Scalars{Scalars: values},
+			},
+		},
+	}
+}
+
+func int32Values(values ...int32) *schemapb.ScalarField {
+	return &schemapb.ScalarField{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: values}}}
+}
+
+func TestIsOrderableType(t *testing.T) {
+	assert.True(t, IsOrderableType(schemapb.DataType_Int8))
+	assert.True(t, IsOrderableType(schemapb.DataType_Int64))
+	assert.True(t, IsOrderableType(schemapb.DataType_Double))
+	assert.True(t, IsOrderableType(schemapb.DataType_VarChar))
+	assert.False(t, IsOrderableType(schemapb.DataType_Bool))
+	assert.False(t, IsOrderableType(schemapb.DataType_FloatVector))
+	assert.False(t, IsOrderableType(schemapb.DataType_BinaryVector))
+}
+
+func TestTopOrderedRows(t *testing.T) {
+	rows := genOrderedRows([]int64{5, 4, 3, 2, 1, 0}, int32Values(3, 1, 2, 1, 3, 1))
+
+	t.Run("ascending", func(t *testing.T) {
+		indexes, err := TopOrderedRows(rows, orderFieldID, false, 4)
+		assert.NoError(t, err)
+		// the ties are broken by the ascending pks
+		assert.Equal(t, []int{5, 3, 1, 2}, indexes)
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		indexes, err := TopOrderedRows(rows, orderFieldID, true, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{4, 0, 2}, indexes)
+	})
+
+	t.Run("fewer rows than k", func(t *testing.T) {
+		indexes, err := TopOrderedRows(rows, orderFieldID, false, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{5, 3, 1, 2, 4, 0}, indexes)
+
+		indexes, err = TopOrderedRows(OrderedRows{}, orderFieldID, false, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, indexes)
+	})
+
+	t.Run("floats", func(t *testing.T) {
+		floats := genOrderedRows([]int64{0, 1, 2, 3}, &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: []float64{0.5, math.NaN(), -1, 0.5}}},
+		})
+		indexes, err := TopOrderedRows(floats, orderFieldID, false, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 0, 3}, indexes)
+	})
+
+	t.Run("strings", func(t *testing.T) {
+		strs := genOrderedRows([]int64{0, 1, 2}, &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"b", "c", "a"}}},
+		})
+		indexes, err := TopOrderedRows(strs, orderFieldID, true, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 0}, indexes)
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		_, err := TopOrderedRows(rows, 102, false, 4)
+		assert.Error(t, err)
+
+		bools := genOrderedRows([]int64{0, 1}, &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: []bool{true, false}}},
+		})
+		_, err = TopOrderedRows(bools, orderFieldID, false, 1)
+		assert.Error(t, err)
+
+		_, err = TopOrderedRows(genOrderedRows([]int64{0, 1}, int32Values(1)), orderFieldID, false, 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestMergeOrderedRows(t *testing.T) {
+	type row struct {
+		pk    int64
+		value int32
+	}
+	const k = 20
+	for _, desc := range []bool{false, true} {
+		// three sources of random values with ties, each bounded by the top k rows
+		all := make([]row, 0)
+		sources := make([]OrderedRows, 0, 3)
+		for s := 0; s < 3; s++ {
+			pks := make([]int64, 0, 100)
+			values := make([]int32, 0, 100)
+			for i := 0; i < 100; i++ {
+				pk := int64(s*1000 + i)
+				value := rand.Int31n(30)
+				pks = append(pks, pk)
+				values = append(values, value)
+				all = append(all, row{pk: pk, value: value})
+			}
+			source := genOrderedRows(pks, int32Values(values...))
+			indexes, err := TopOrderedRows(source, orderFieldID, desc, k)
+			require.NoError(t, err)
+			top := genOrderedRows([]int64{}, int32Values())
+			for _, i := range indexes {
+				AppendIDs(top.IDs, source.IDs, i)
+				AppendFieldData(top.FieldsData, source.FieldsData, int64(i))
+			}
+			sources = append(sources, top)
+		}
+
+		ids, fieldsData, err := MergeOrderedRows(sources, orderFieldID, desc, k)
+		require.NoError(t, err)
+
+		sort.Slice(all, func(i, j int) bool {
+			if all[i].value != all[j].value {
+				return (all[i].value < all[j].value) != desc
+			}
+			return all[i].pk < all[j].pk
+		})
+		expectedPKs := make([]int64, 0, k)
+		expectedValues := make([]int32, 0, k)
+		for _, r := range all[:k] {
+			expectedPKs = append(expectedPKs, r.pk)
+			expectedValues = append(expectedValues, r.value)
+		}
+		assert.Equal(t, expectedPKs, ids.GetIntId().GetData(), "desc %v", desc)
+		assert.Equal(t, expectedPKs, fieldsData[0].GetScalars().GetLongData().GetData(), "desc %v", desc)
+		assert.Equal(t, expectedValues, fieldsData[1].GetScalars().GetIntData().GetData(), "desc %v", desc)
+	}
+
+	t.Run("duplicated pks", func(t *testing.T) {
+		sources := []OrderedRows{
+			genOrderedRows([]int64{1, 2}, int32Values(1, 2)),
+			{},
+			genOrderedRows([]int64{1, 3}, int32Values(1, 3)),
+		}
+		ids, _, err := MergeOrderedRows(sources, orderFieldID, false, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 2, 3}, ids.GetIntId().GetData())
+	})
+
+	t.Run("mismatched sources", func(t *testing.T) {
+		longs := genOrderedRows([]int64{3}, &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1}}},
+		})
+		_, _, err := MergeOrderedRows([]OrderedRows{genOrderedRows([]int64{1}, int32Values(1)), longs}, orderFieldID, false, 3)
+		assert.Error(t, err)
+
+		missing := genOrderedRows([]int64{3}, int32Values(1))
+		missing.FieldsData = missing.FieldsData[:1]
+		_, _, err = MergeOrderedRows([]OrderedRows{genOrderedRows([]int64{1}, int32Values(1)), missing}, orderFieldID, false, 3)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,165 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeutil
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// NewSampleRand returns the random source of a sampling. The sources of the same non-zero seed and salt generate
+// the same sequence, so that the samples are reproducible, while the different salts, e.g. segment IDs, keep
+// the samples of the segments independent. A zero seed means a randomly seeded source.
+func NewSampleRand(seed int64, salt int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	// mix the salt by the multiplier of splitmix64 to spread the close salts
+	return rand.New(rand.NewSource(seed ^ (salt * -7046029254386353131)))
+}
+
+// ReservoirSample uniformly samples min(n, k) of the indexes [0, n) by reservoir sampling. The sampled indexes
+// are returned in random order, so that any prefix of them is a uniform sample as well.
+func ReservoirSample(n, k int, r *rand.Rand) []int {
+	if n <= 0 || k <= 0 {
+		return []int{}
+	}
+	if k > n {
+		k = n
+	}
+	reservoir := make([]int, k)
+	for i := 0; i < k; i++ {
+		reservoir[i] = i
+	}
+	for i := k; i < n; i++ {
+		if j := r.Intn(i + 1); j < k {
+			reservoir[j] = i
+		}
+	}
+	r.Shuffle(k, func(i, j int) { reservoir[i], reservoir[j] = reservoir[j], reservoir[i] })
+	return reservoir
+}
+
+// SampleSources draws at most k rows without replacement from the union of the sources, source i of which has
+// matched[i] rows in total and available[i] of them uniformly sampled in random order. Every draw picks a source
+// with the probability of its undrawn rows over all the undrawn rows, so the number of rows drawn from each source
+// follows the sizes of the sources rather than the sizes of their samples. Returns the sources of the draws in order,
+// the draws of source i take the first rows of its sample. A source runs out of draws once its sample is exhausted.
+func SampleSources(matched []int64, available []int, k int, r *rand.Rand) []int {
+	remaining := make([]int64, len(matched))
+	left := make([]int, len(matched))
+	var total int64
+	for i := range matched {
+		left[i] = available[i]
+		if left[i] > 0 && matched[i] > 0 {
+			remaining[i] = matched[i]
+			total += matched[i]
+		}
+	}
+
+	draws := make([]int, 0, k)
+	for len(draws) < k && total > 0 {
+		x := r.Int63n(total)
+		i := 0
+		for ; x >= remaining[i]; i++ {
+			x -= remaining[i]
+		}
+		draws = append(draws, i)
+		remaining[i]--
+		total--
+		if left[i]--; left[i] == 0 {
+			total -= remaining[i]
+			remaining[i] = 0
+		}
+	}
+	return draws
+}
+
+// SampledRows are the rows uniformly sampled in random order from Matched rows, e.g. of a segment
+type SampledRows struct {
+	IDs        *schemapb.IDs
+	FieldsData []*schemapb.FieldData
+	Matched    int64 // less than the sampled rows means the rows are all the matched ones
+}
+
+// MergeSampledRows merges the samples into a uniform sample of at most k rows of all their matched rows, drawn by
+// SampleSources. The rows of the duplicated primary keys are drawn once. For the seeded samples to be reproducible,
+// the samples are merged in the order of their first primary keys rather than the order they are passed in.
+// Returns the merged rows and the total matched rows.
+func MergeSampledRows(samples []SampledRows, k int, r *rand.Rand) (*schemapb.IDs, []*schemapb.FieldData, int64, error) {
+	nonEmpty := make([]SampledRows, 0, len(samples))
+	for _, sample := range samples {
+		if GetSizeOfIDs(sample.IDs) > 0 {
+			nonEmpty = append(nonEmpty, sample)
+		}
+	}
+	sort.Slice(nonEmpty, func(i, j int) bool {
+		return lessPK(GetPK(nonEmpty[i].IDs, 0), GetPK(nonEmpty[j].IDs, 0))
+	})
+
+	ids := &schemapb.IDs{}
+	fieldsData := []*schemapb.FieldData{}
+	if len(nonEmpty) > 0 {
+		fieldsData = make([]*schemapb.FieldData, len(nonEmpty[0].FieldsData))
+	}
+	matched := make([]int64, len(nonEmpty))
+	available := make([]int, len(nonEmpty))
+	var total int64
+	for i, sample := range nonEmpty {
+		if len(sample.FieldsData) != len(fieldsData) {
+			return nil, nil, 0, fmt.Errorf("mismatch FieldData in sampled results, expect %d get %d", len(fieldsData), len(sample.FieldsData))
+		}
+		available[i] = GetSizeOfIDs(sample.IDs)
+		matched[i] = sample.Matched
+		if matched[i] < int64(available[i]) {
+			matched[i] = int64(available[i])
+		}
+		total += matched[i]
+	}
+
+	next := make([]int, len(nonEmpty))
+	drawn := make(map[interface{}]struct{})
+	for _, i := range SampleSources(matched, available, k, r) {
+		idx := next[i]
+		next[i]++
+		pk := GetPK(nonEmpty[i].IDs, int64(idx))
+		if _, ok := drawn[pk]; ok {
+			continue
+		}
+		drawn[pk] = struct{}{}
+		AppendIDs(ids, nonEmpty[i].IDs, idx)
+		AppendFieldData(fieldsData, nonEmpty[i].FieldsData, int64(idx))
+	}
+	return ids, fieldsData, total, nil
+}
+
+func lessPK(a, b interface{}) bool {
+	switch a := a.(type) {
+	case int64:
+		b, ok := b.(int64)
+		return ok && a < b
+	case string:
+		b, ok := b.(string)
+		return ok && a < b
+	default:
+		return false
+	}
+}
@@ -0,0 +1,175 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeutil
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+func TestReservoirSample(t *testing.T) {
+	r := NewSampleRand(1, 0)
+	assert.Empty(t, ReservoirSample(0, 10, r))
+	assert.Empty(t, ReservoirSample(10, 0, r))
+	assert.ElementsMatch(t, []int{0, 1, 2}, ReservoirSample(3, 10, r))
+
+	sample := ReservoirSample(100, 10, r)
+	assert.Len(t, sample, 10)
+	seen := make(map[int]bool)
+	for _, i := range sample {
+		assert.True(t, i >= 0 && i < 100)
+		assert.False(t, seen[i])
+		seen[i] = true
+	}
+
+	// every index is sampled with the probability k/n
+	const n, k, rounds = 20, 5, 20000
+	counts := make([]int, n)
+	for round := 0; round < rounds; round++ {
+		for _, i := range ReservoirSample(n, k, r) {
+			counts[i]++
+		}
+	}
+	expected := float64(rounds * k / n)
+	for i, count := range counts {
+		assert.InDelta(t, expected, float64(count), expected*0.1, "index %d", i)
+	}
+}
+
+func TestSampleSources(t *testing.T) {
+	r := NewSampleRand(1, 0)
+	assert.Empty(t, SampleSources(nil, nil, 10, r))
+	assert.Empty(t, SampleSources([]int64{0, 0}, []int{0, 0}, 10, r))
+
+	t.Run("proportional", func(t *testing.T) {
+		// the sources of 1000 and 9000 rows, both sampled 100 rows
+		const rounds = 2000
+		drawn := make([]int, 2)
+		for round := 0; round < rounds; round++ {
+			draws := SampleSources([]int64{1000, 9000}, []int{100, 100}, 100, r)
+			assert.Len(t, draws, 100)
+			for _, i := range draws {
+				drawn[i]++
+			}
+		}
+		ratio := float64(drawn[0]) / float64(drawn[0]+drawn[1])
+		assert.InDelta(t, 0.1, ratio, 0.01)
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		draws := SampleSources([]int64{3, 2}, []int{3, 2}, 10, r)
+		assert.ElementsMatch(t, []int{0, 0, 0, 1, 1}, draws)
+
+		// the short sample of a source limits its draws
+		draws = SampleSources([]int64{100, 100}, []int{1, 10}, 10, r)
+		assert.Len(t, draws, 10)
+		count := 0
+		for _, i := range draws {
+			if i == 0 {
+				count++
+			}
+		}
+		assert.LessOrEqual(t, count, 1)
+	})
+
+	t.Run("reproducible", func(t *testing.T) {
+		matched, available := []int64{50, 70, 30}, []int{20, 20, 20}
+		expected := SampleSources(matched, available, 20, NewSampleRand(42, 7))
+		assert.Equal(t, expected, SampleSources(matched, available, 20, NewSampleRand(42, 7)))
+		assert.Equal(t, ReservoirSample(100, 10, NewSampleRand(42, 7)), ReservoirSample(100, 10, NewSampleRand(42, 7)))
+		assert.NotEqual(t, ReservoirSample(100, 10, NewSampleRand(42, 7)), ReservoirSample(100, 10, NewSampleRand(42, 8)))
+	})
+}
+
+// genSampledRows generates the shuffled rows of pks in [begin, begin+n) with a field of the same values
+func genSampledRows(begin, n int64, matched int64, r *rand.Rand) SampledRows {
+	pks := make([]int64, 0, n)
+	for _, i := range ReservoirSample(int(n), int(n), r) {
+		pks = append(pks, begin+int64(i))
+	}
+	return SampledRows{
+		IDs: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}},
+		FieldsData: []*schemapb.FieldData{{
+			Type:    schemapb.DataType_Int64,
+			FieldId: 100,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: pks}},
+			}},
+		}},
+		Matched: matched,
+	}
+}
+
+func TestMergeSampledRows(t *testing.T) {
+	r := NewSampleRand(1, 0)
+	ids, fieldsData, matched, err := MergeSampledRows(nil, 10, r)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, GetSizeOfIDs(ids))
+	assert.Empty(t, fieldsData)
+	assert.Equal(t, int64(0), matched)
+
+	t.Run("proportional", func(t *testing.T) {
+		// the small sample of 1000 rows and the large one of 9000 rows
+		const rounds = 500
+		var fromSmall, total int
+		for round := 0; round < rounds; round++ {
+			samples := []SampledRows{genSampledRows(0, 100, 1000, r), genSampledRows(1000, 100, 9000, r)}
+			ids, fieldsData, matched, err := MergeSampledRows(samples, 100, r)
+			require.NoError(t, err)
+			assert.Equal(t, int64(10000), matched)
+			assert.Equal(t, 100, GetSizeOfIDs(ids))
+			assert.Equal(t, ids.GetIntId().GetData(), fieldsData[0].GetScalars().GetLongData().GetData())
+			for _, pk := range ids.GetIntId().GetData() {
+				if pk < 1000 {
+					fromSmall++
+				}
+				total++
+			}
+		}
+		assert.InDelta(t, 0.1, float64(fromSmall)/float64(total), 0.01)
+	})
+
+	t.Run("duplicated", func(t *testing.T) {
+		samples := []SampledRows{genSampledRows(0, 10, 10, r), genSampledRows(0, 10, 10, r)}
+		ids, _, matched, err := MergeSampledRows(samples, 20, r)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20), matched)
+		assert.ElementsMatch(t, []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, ids.GetIntId().GetData())
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		sample := genSampledRows(10, 10, 10, r)
+		sample.FieldsData = append(sample.FieldsData, sample.FieldsData[0])
+		_, _, _, err := MergeSampledRows([]SampledRows{genSampledRows(0, 10, 10, r), sample}, 10, r)
+		assert.Error(t, err)
+	})
+
+	t.Run("order independent", func(t *testing.T) {
+		samples := []SampledRows{genSampledRows(0, 10, 100, r), genSampledRows(100, 10, 300, r), genSampledRows(500, 10, 200, r)}
+		expected, _, _, err := MergeSampledRows(samples, 10, NewSampleRand(42, 0))
+		require.NoError(t, err)
+		reversed := []SampledRows{samples[2], samples[1], samples[0]}
+		ids, _, _, err := MergeSampledRows(reversed, 10, NewSampleRand(42, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, expected.GetIntId().GetData(), ids.GetIntId().GetData())
+	})
+}
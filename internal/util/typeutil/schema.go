@@ -54,49 +54,53 @@ func GetMaxLengthOfVarLengthField(fieldSchema *schemapb.FieldSchema) (int, error
 	return maxLength, nil
 }
 
-// EstimateSizePerRecord returns the estimate size of a record in a collection
-func EstimateSizePerRecord(schema *schemapb.CollectionSchema) (int, error) {
-	res := 0
-	for _, fs := range schema.Fields {
-		switch fs.DataType {
-		case schemapb.DataType_Bool, schemapb.DataType_Int8:
-			res++
-		case schemapb.DataType_Int16:
-			res += 2
-		case schemapb.DataType_Int32, schemapb.DataType_Float:
-			res += 4
-		case schemapb.DataType_Int64, schemapb.DataType_Double:
-			res += 8
-		case schemapb.DataType_VarChar:
-			maxLengthPerRow, err := GetMaxLengthOfVarLengthField(fs)
-			if err != nil {
-				return 0, err
-			}
-			res += maxLengthPerRow
-		case schemapb.DataType_BinaryVector:
-			for _, kv := range fs.TypeParams {
-				if kv.Key == "dim" {
-					v, err := strconv.Atoi(kv.Value)
-					if err != nil {
-						return -1, err
-					}
-					res += v / 8
-					break
+// EstimateFieldSize returns the estimated size in bytes of one value of the field, variable-length fields are
+// counted by avgLength if it's positive, otherwise by their max length
+func EstimateFieldSize(fieldSchema *schemapb.FieldSchema, avgLength int) (int, error) {
+	switch fieldSchema.GetDataType() {
+	case schemapb.DataType_Bool, schemapb.DataType_Int8:
+		return 1, nil
+	case schemapb.DataType_Int16:
+		return 2, nil
+	case schemapb.DataType_Int32, schemapb.DataType_Float:
+		return 4, nil
+	case schemapb.DataType_Int64, schemapb.DataType_Double:
+		return 8, nil
+	case schemapb.DataType_String:
+		return avgLength, nil
+	case schemapb.DataType_VarChar:
+		if avgLength > 0 {
+			return avgLength, nil
+		}
+		return GetMaxLengthOfVarLengthField(fieldSchema)
+	case schemapb.DataType_BinaryVector, schemapb.DataType_FloatVector:
+		for _, kv := range fieldSchema.GetTypeParams() {
+			if kv.GetKey() == "dim" {
+				dim, err := strconv.Atoi(kv.GetValue())
+				if err != nil {
+					return -1, err
 				}
-			}
-		case schemapb.DataType_FloatVector:
-			for _, kv := range fs.TypeParams {
-				if kv.Key == "dim" {
-					v, err := strconv.Atoi(kv.Value)
-					if err != nil {
-						return -1, err
-					}
-					res += v * 4
-					break
+				if fieldSchema.GetDataType() == schemapb.DataType_BinaryVector {
+					return dim / 8, nil
 				}
+				return dim * 4, nil
 			}
 		}
 	}
+	return 0, nil
+}
+
+// EstimateRowSize returns the estimated size in bytes of a record in a collection, avgVarCharLen gives the average
+// length of variable-length fields by field id, the fields absent from it are counted by their max length
+func EstimateRowSize(schema *schemapb.CollectionSchema, avgVarCharLen map[int64]int) (int, error) {
+	res := 0
+	for _, fs := range schema.GetFields() {
+		size, err := EstimateFieldSize(fs, avgVarCharLen[fs.GetFieldID()])
+		if err != nil {
+			return 0, err
+		}
+		res += size
+	}
 	return res, nil
 }
 
@@ -395,6 +399,40 @@ func GetPrimaryFieldSchema(schema *schemapb.CollectionSchema) (*schemapb.FieldSc
 	return nil, errors.New("primary field is not found")
 }
 
+// NumPartitionsKey is the type param of the partition key field giving the number of partitions
+// the rows are routed to, DefaultNumPartitions if absent
+const NumPartitionsKey = "num_partitions"
+
+// DefaultNumPartitions is the number of partitions of a collection with partition key by default
+const DefaultNumPartitions = 16
+
+// GetPartitionKeyFieldSchema returns the partition key field of schema, nil if the collection has no partition key
+func GetPartitionKeyFieldSchema(schema *schemapb.CollectionSchema) *schemapb.FieldSchema {
+	for _, fieldSchema := range schema.GetFields() {
+		if fieldSchema.GetIsPartitionKey() {
+			return fieldSchema
+		}
+	}
+	return nil
+}
+
+// GetNumPartitions returns the number of partitions the partition key field routes the rows to
+func GetNumPartitions(fieldSchema *schemapb.FieldSchema) (int, error) {
+	for _, kv := range fieldSchema.GetTypeParams() {
+		if kv.GetKey() == NumPartitionsKey {
+			numPartitions, err := strconv.Atoi(kv.GetValue())
+			if err != nil {
+				return 0, fmt.Errorf("invalid %s %s of partition key field %s", NumPartitionsKey, kv.GetValue(), fieldSchema.GetName())
+			}
+			if numPartitions <= 0 {
+				return 0, fmt.Errorf("%s of partition key field %s should be positive, got %d", NumPartitionsKey, fieldSchema.GetName(), numPartitions)
+			}
+			return numPartitions, nil
+		}
+	}
+	return DefaultNumPartitions, nil
+}
+
 // GetPrimaryFieldData get primary field data from all field data inserted from sdk
 func GetPrimaryFieldData(datas []*schemapb.FieldData, primaryFieldSchema *schemapb.FieldSchema) (*schemapb.FieldData, error) {
 	primaryFieldName := primaryFieldSchema.Name
@@ -461,6 +499,18 @@ func GetSizeOfIDs(data *schemapb.IDs) int {
 	return result
 }
 
+// GetPK returns the primary key at idx of ids, nil if the id type is unknown
+func GetPK(data *schemapb.IDs, idx int64) interface{} {
+	switch data.GetIdField().(type) {
+	case *schemapb.IDs_IntId:
+		return data.GetIntId().GetData()[idx]
+	case *schemapb.IDs_StrId:
+		return data.GetStrId().GetData()[idx]
+	default:
+		return nil
+	}
+}
+
 func IsPrimaryFieldType(dataType schemapb.DataType) bool {
 	if dataType == schemapb.DataType_Int64 || dataType == schemapb.DataType_VarChar {
 		return true
@@ -26,6 +26,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSchema(t *testing.T) {
@@ -124,12 +125,38 @@ func TestSchema(t *testing.T) {
 		},
 	}
 
-	t.Run("EstimateSizePerRecord", func(t *testing.T) {
-		size, err := EstimateSizePerRecord(schema)
+	t.Run("EstimateRowSize", func(t *testing.T) {
+		size, err := EstimateRowSize(schema, nil)
 		assert.Equal(t, 680, size)
 		assert.Nil(t, err)
 	})
 
+	t.Run("EstimateFieldSize", func(t *testing.T) {
+		varChar := &schemapb.FieldSchema{
+			DataType:   schemapb.DataType_VarChar,
+			TypeParams: []*commonpb.KeyValuePair{{Key: "max_length_per_row", Value: "64"}},
+		}
+		size, err := EstimateFieldSize(varChar, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 64, size)
+		// counted by the average length
+		size, err = EstimateFieldSize(varChar, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, size)
+
+		_, err = EstimateFieldSize(&schemapb.FieldSchema{DataType: schemapb.DataType_VarChar}, 0)
+		assert.Error(t, err)
+		_, err = EstimateFieldSize(&schemapb.FieldSchema{
+			DataType:   schemapb.DataType_FloatVector,
+			TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "invalid"}},
+		}, 0)
+		assert.Error(t, err)
+
+		size, err = EstimateFieldSize(&schemapb.FieldSchema{DataType: schemapb.DataType_String}, 32)
+		assert.NoError(t, err)
+		assert.Equal(t, 32, size)
+	})
+
 	t.Run("SchemaHelper", func(t *testing.T) {
 		_, err := CreateSchemaHelper(nil)
 		assert.NotNil(t, err)
@@ -538,3 +565,51 @@ func TestGetPrimaryFieldSchema(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, schemapb.DataType_Int64, primaryField.DataType)
 }
+
+func TestGetPartitionKeyFieldSchema(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 1, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 2, Name: "key", DataType: schemapb.DataType_VarChar},
+		},
+	}
+	assert.Nil(t, GetPartitionKeyFieldSchema(schema))
+
+	schema.Fields[1].IsPartitionKey = true
+	field := GetPartitionKeyFieldSchema(schema)
+	require.NotNil(t, field)
+	assert.Equal(t, int64(2), field.GetFieldID())
+
+	numPartitions, err := GetNumPartitions(field)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultNumPartitions, numPartitions)
+
+	field.TypeParams = []*commonpb.KeyValuePair{{Key: NumPartitionsKey, Value: "4"}}
+	numPartitions, err = GetNumPartitions(field)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, numPartitions)
+
+	for _, invalid := range []string{"0", "-1", "four"} {
+		field.TypeParams = []*commonpb.KeyValuePair{{Key: NumPartitionsKey, Value: invalid}}
+		_, err = GetNumPartitions(field)
+		assert.Error(t, err)
+	}
+}
+
+func TestGetPK(t *testing.T) {
+	intIDs := &schemapb.IDs{
+		IdField: &schemapb.IDs_IntId{
+			IntId: &schemapb.LongArray{Data: []int64{1, 2, 3}},
+		},
+	}
+	assert.Equal(t, int64(2), GetPK(intIDs, 1))
+
+	strIDs := &schemapb.IDs{
+		IdField: &schemapb.IDs_StrId{
+			StrId: &schemapb.StringArray{Data: []string{"a", "b", "c"}},
+		},
+	}
+	assert.Equal(t, "c", GetPK(strIDs, 2))
+
+	assert.Nil(t, GetPK(&schemapb.IDs{}, 0))
+}
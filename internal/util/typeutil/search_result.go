@@ -0,0 +1,180 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeutil
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// ValidateSearchResultData checks the topk-per-nq layout of a reduced search result:
+// len(topks) must equal nq, sum(topks) must equal the length of ids and scores,
+// and every output field must carry exactly one row per hit.
+func ValidateSearchResultData(data *schemapb.SearchResultData) error {
+	if data == nil {
+		return errors.New("search result data is nil")
+	}
+	if int64(len(data.GetTopks())) != data.GetNumQueries() {
+		return fmt.Errorf("search result's topks length(%d) mis-match with nq(%d)", len(data.GetTopks()), data.GetNumQueries())
+	}
+
+	var total int64
+	for i, topk := range data.GetTopks() {
+		if topk < 0 {
+			return fmt.Errorf("search result's topks[%d] is negative: %d", i, topk)
+		}
+		total += topk
+	}
+
+	idLen, err := getSizeOfIDsStrict(data.GetIds(), total)
+	if err != nil {
+		return err
+	}
+	if int64(idLen) != total {
+		return fmt.Errorf("search result's ID length(%d) mis-match with sum of topks(%d)", idLen, total)
+	}
+	if int64(len(data.GetScores())) != total {
+		return fmt.Errorf("search result's score length(%d) mis-match with sum of topks(%d)", len(data.GetScores()), total)
+	}
+	if len(data.GetTimestamps()) != 0 && int64(len(data.GetTimestamps())) != total {
+		return fmt.Errorf("search result's timestamp length(%d) mis-match with sum of topks(%d)", len(data.GetTimestamps()), total)
+	}
+	if len(data.GetRawScores()) != 0 && int64(len(data.GetRawScores())) != total {
+		return fmt.Errorf("search result's raw score length(%d) mis-match with sum of topks(%d)", len(data.GetRawScores()), total)
+	}
+
+	for _, fieldData := range data.GetFieldsData() {
+		if fieldData == nil {
+			continue
+		}
+		rowCount, err := GetRowCountOfFieldData(fieldData)
+		if err != nil {
+			return err
+		}
+		if int64(rowCount) != total {
+			return fmt.Errorf("search result's field %d row count(%d) mis-match with sum of topks(%d)",
+				fieldData.GetFieldId(), rowCount, total)
+		}
+	}
+	return nil
+}
+
+// HasSearchTimestamps returns whether any of the search results carries the insert timestamps of hits.
+func HasSearchTimestamps(data []*schemapb.SearchResultData) bool {
+	for _, d := range data {
+		if len(d.GetTimestamps()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// AppendSearchTimestamp appends the insert timestamp of the idx-th hit of src to dst,
+// or 0 if src carries no timestamp for the hit.
+func AppendSearchTimestamp(dst *schemapb.SearchResultData, src *schemapb.SearchResultData, idx int64) {
+	var ts uint64
+	if idx >= 0 && idx < int64(len(src.GetTimestamps())) {
+		ts = src.GetTimestamps()[idx]
+	}
+	dst.Timestamps = append(dst.Timestamps, ts)
+}
+
+// HasSearchRawScores returns whether any of the search results carries the unweighted scores of hits.
+func HasSearchRawScores(data []*schemapb.SearchResultData) bool {
+	for _, d := range data {
+		if len(d.GetRawScores()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// AppendSearchRawScore appends the unweighted score of the idx-th hit of src to dst,
+// or the score of the hit if src is not weighted.
+func AppendSearchRawScore(dst *schemapb.SearchResultData, src *schemapb.SearchResultData, idx int64) {
+	score := src.GetScores()[idx]
+	if idx < int64(len(src.GetRawScores())) {
+		score = src.GetRawScores()[idx]
+	}
+	dst.RawScores = append(dst.RawScores, score)
+}
+
+// getSizeOfIDsStrict returns the number of ids, reporting an error if the id field is
+// missing while hits are expected or holds an unknown id type.
+func getSizeOfIDsStrict(ids *schemapb.IDs, expected int64) (int, error) {
+	switch ids.GetIdField().(type) {
+	case *schemapb.IDs_IntId:
+		return len(ids.GetIntId().GetData()), nil
+	case *schemapb.IDs_StrId:
+		return len(ids.GetStrId().GetData()), nil
+	case nil:
+		if expected == 0 {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("search result's IDs is empty, expected %d ids", expected)
+	default:
+		return 0, fmt.Errorf("search result's IDs has unsupported type %T", ids.GetIdField())
+	}
+}
+
+// GetRowCountOfFieldData returns the number of rows stored in a field data.
+func GetRowCountOfFieldData(fieldData *schemapb.FieldData) (int, error) {
+	switch field := fieldData.GetField().(type) {
+	case *schemapb.FieldData_Scalars:
+		switch scalar := field.Scalars.GetData().(type) {
+		case *schemapb.ScalarField_BoolData:
+			return len(scalar.BoolData.GetData()), nil
+		case *schemapb.ScalarField_IntData:
+			return len(scalar.IntData.GetData()), nil
+		case *schemapb.ScalarField_LongData:
+			return len(scalar.LongData.GetData()), nil
+		case *schemapb.ScalarField_FloatData:
+			return len(scalar.FloatData.GetData()), nil
+		case *schemapb.ScalarField_DoubleData:
+			return len(scalar.DoubleData.GetData()), nil
+		case *schemapb.ScalarField_StringData:
+			return len(scalar.StringData.GetData()), nil
+		case *schemapb.ScalarField_BytesData:
+			return len(scalar.BytesData.GetData()), nil
+		case nil:
+			return 0, nil
+		default:
+			return 0, fmt.Errorf("unsupported scalar type %T of field %d", scalar, fieldData.GetFieldId())
+		}
+	case *schemapb.FieldData_Vectors:
+		dim := field.Vectors.GetDim()
+		if dim <= 0 {
+			return 0, fmt.Errorf("invalid dim %d of vector field %d", dim, fieldData.GetFieldId())
+		}
+		switch vector := field.Vectors.GetData().(type) {
+		case *schemapb.VectorField_FloatVector:
+			return len(vector.FloatVector.GetData()) / int(dim), nil
+		case *schemapb.VectorField_BinaryVector:
+			return len(vector.BinaryVector) * 8 / int(dim), nil
+		case nil:
+			return 0, nil
+		default:
+			return 0, fmt.Errorf("unsupported vector type %T of field %d", vector, fieldData.GetFieldId())
+		}
+	case nil:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("unsupported field data type %T of field %d", field, fieldData.GetFieldId())
+	}
+}
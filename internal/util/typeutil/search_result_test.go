@@ -0,0 +1,230 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeutil
+
+import (
+	"testing"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/stretchr/testify/assert"
+)
+
+func genValidSearchResultData() *schemapb.SearchResultData {
+	return &schemapb.SearchResultData{
+		NumQueries: 2,
+		TopK:       2,
+		Topks:      []int64{2, 1},
+		Scores:     []float32{0.1, 0.2, 0.3},
+		Ids: &schemapb.IDs{
+			IdField: &schemapb.IDs_IntId{
+				IntId: &schemapb.LongArray{Data: []int64{1, 2, 3}},
+			},
+		},
+		FieldsData: []*schemapb.FieldData{
+			{
+				Type:    schemapb.DataType_Int64,
+				FieldId: 100,
+				Field: &schemapb.FieldData_Scalars{
+					Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_LongData{
+							LongData: &schemapb.LongArray{Data: []int64{10, 20, 30}},
+						},
+					},
+				},
+			},
+			{
+				Type:    schemapb.DataType_FloatVector,
+				FieldId: 101,
+				Field: &schemapb.FieldData_Vectors{
+					Vectors: &schemapb.VectorField{
+						Dim: 2,
+						Data: &schemapb.VectorField_FloatVector{
+							FloatVector: &schemapb.FloatArray{Data: []float32{1, 1, 2, 2, 3, 3}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateSearchResultData(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, ValidateSearchResultData(genValidSearchResultData()))
+	})
+
+	t.Run("valid empty", func(t *testing.T) {
+		data := &schemapb.SearchResultData{
+			NumQueries: 2,
+			TopK:       10,
+			Topks:      []int64{0, 0},
+		}
+		assert.NoError(t, ValidateSearchResultData(data))
+	})
+
+	t.Run("valid string ids", func(t *testing.T) {
+		data := genValidSearchResultData()
+		data.Ids = &schemapb.IDs{
+			IdField: &schemapb.IDs_StrId{
+				StrId: &schemapb.StringArray{Data: []string{"a", "b", "c"}},
+			},
+		}
+		assert.NoError(t, ValidateSearchResultData(data))
+	})
+
+	t.Run("nil data", func(t *testing.T) {
+		err := ValidateSearchResultData(nil)
+		assert.EqualError(t, err, "search result data is nil")
+	})
+
+	t.Run("topks length mis-match", func(t *testing.T) {
+		data := genValidSearchResultData()
+		data.Topks = []int64{3}
+		err := ValidateSearchResultData(data)
+		assert.EqualError(t, err, "search result's topks length(1) mis-match with nq(2)")
+	})
+
+	t.Run("negative topk", func(t *testing.T) {
+		data := genValidSearchResultData()
+		data.Topks = []int64{-1, 4}
+		err := ValidateSearchResultData(data)
+		assert.EqualError(t, err, "search result's topks[0] is negative: -1")
+	})
+
+	t.Run("ids length mis-match", func(t *testing.T) {
+		data := genValidSearchResultData()
+		data.Topks = []int64{2, 2}
+		err := ValidateSearchResultData(data)
+		assert.EqualError(t, err, "search result's ID length(3) mis-match with sum of topks(4)")
+	})
+
+	t.Run("scores length mis-match", func(t *testing.T) {
+		data := genValidSearchResultData()
+		data.Scores = data.Scores[:2]
+		err := ValidateSearchResultData(data)
+		assert.EqualError(t, err, "search result's score length(2) mis-match with sum of topks(3)")
+	})
+
+	t.Run("valid timestamps", func(t *testing.T) {
+		data := genValidSearchResultData()
+		data.Timestamps = []uint64{100, 200, 300}
+		assert.NoError(t, ValidateSearchResultData(data))
+	})
+
+	t.Run("timestamps length mis-match", func(t *testing.T) {
+		data := genValidSearchResultData()
+		data.Timestamps = []uint64{100, 200}
+		err := ValidateSearchResultData(data)
+		assert.EqualError(t, err, "search result's timestamp length(2) mis-match with sum of topks(3)")
+	})
+
+	t.Run("raw scores length mis-match", func(t *testing.T) {
+		data := genValidSearchResultData()
+		data.RawScores = []float32{0.1}
+		err := ValidateSearchResultData(data)
+		assert.EqualError(t, err, "search result's raw score length(1) mis-match with sum of topks(3)")
+	})
+
+	t.Run("missing ids", func(t *testing.T) {
+		data := genValidSearchResultData()
+		data.Ids = nil
+		err := ValidateSearchResultData(data)
+		assert.EqualError(t, err, "search result's IDs is empty, expected 3 ids")
+	})
+
+	t.Run("scalar field row count mis-match", func(t *testing.T) {
+		data := genValidSearchResultData()
+		data.FieldsData[0].GetScalars().GetLongData().Data = []int64{10}
+		err := ValidateSearchResultData(data)
+		assert.EqualError(t, err, "search result's field 100 row count(1) mis-match with sum of topks(3)")
+	})
+
+	t.Run("vector field row count mis-match", func(t *testing.T) {
+		data := genValidSearchResultData()
+		data.FieldsData[1].GetVectors().GetFloatVector().Data = []float32{1, 1}
+		err := ValidateSearchResultData(data)
+		assert.EqualError(t, err, "search result's field 101 row count(1) mis-match with sum of topks(3)")
+	})
+
+	t.Run("invalid vector dim", func(t *testing.T) {
+		data := genValidSearchResultData()
+		data.FieldsData[1].GetVectors().Dim = 0
+		err := ValidateSearchResultData(data)
+		assert.EqualError(t, err, "invalid dim 0 of vector field 101")
+	})
+}
+
+func TestAppendSearchTimestamp(t *testing.T) {
+	withTimestamps := &schemapb.SearchResultData{Timestamps: []uint64{100, 200, 300}}
+	withoutTimestamps := &schemapb.SearchResultData{}
+	assert.True(t, HasSearchTimestamps([]*schemapb.SearchResultData{withoutTimestamps, withTimestamps}))
+	assert.False(t, HasSearchTimestamps([]*schemapb.SearchResultData{withoutTimestamps}))
+	assert.False(t, HasSearchTimestamps(nil))
+
+	dst := &schemapb.SearchResultData{}
+	AppendSearchTimestamp(dst, withTimestamps, 2)
+	AppendSearchTimestamp(dst, withTimestamps, 0)
+	AppendSearchTimestamp(dst, withoutTimestamps, 0)
+	AppendSearchTimestamp(dst, withTimestamps, 3)
+	assert.Equal(t, []uint64{300, 100, 0, 0}, dst.Timestamps)
+}
+
+func TestAppendSearchRawScore(t *testing.T) {
+	weighted := &schemapb.SearchResultData{Scores: []float32{1.5, 2.5}, RawScores: []float32{1, 2}}
+	unweighted := &schemapb.SearchResultData{Scores: []float32{3, 4}}
+	assert.True(t, HasSearchRawScores([]*schemapb.SearchResultData{unweighted, weighted}))
+	assert.False(t, HasSearchRawScores([]*schemapb.SearchResultData{unweighted}))
+
+	dst := &schemapb.SearchResultData{}
+	AppendSearchRawScore(dst, weighted, 1)
+	AppendSearchRawScore(dst, unweighted, 0)
+	AppendSearchRawScore(dst, weighted, 0)
+	assert.Equal(t, []float32{2, 3, 1}, dst.RawScores)
+}
+
+func TestGetRowCountOfFieldData(t *testing.T) {
+	binary := &schemapb.FieldData{
+		Type: schemapb.DataType_BinaryVector,
+		Field: &schemapb.FieldData_Vectors{
+			Vectors: &schemapb.VectorField{
+				Dim:  16,
+				Data: &schemapb.VectorField_BinaryVector{BinaryVector: make([]byte, 6)},
+			},
+		},
+	}
+	rowCount, err := GetRowCountOfFieldData(binary)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rowCount)
+
+	str := &schemapb.FieldData{
+		Type: schemapb.DataType_VarChar,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_StringData{
+					StringData: &schemapb.StringArray{Data: []string{"a", "b"}},
+				},
+			},
+		},
+	}
+	rowCount, err = GetRowCountOfFieldData(str)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rowCount)
+
+	rowCount, err = GetRowCountOfFieldData(&schemapb.FieldData{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, rowCount)
+}